@@ -12,6 +12,14 @@
 //	    Output format: text, json, badges (default "text")
 //	-update-readme
 //	    Update README.md with compliance badges
+//	-concurrency int
+//	    Number of endpoint checks run at once (default 4)
+//	-check-timeout duration
+//	    Timeout of a single endpoint check (default 10s)
+//	-deadline duration
+//	    Fail the run if the checks take longer (default 0, no deadline)
+//	-stream
+//	    Print each endpoint result to stderr as it completes
 //
 // Examples:
 //
@@ -26,6 +34,9 @@
 //
 //	# Update README.md with compliance badges
 //	compliance -update-readme
+//
+//	# CI run against a large deployment: bounded, time-boxed, with progress
+//	compliance -concurrency 8 -check-timeout 30s -deadline 5m -stream
 package main
 
 import (
@@ -36,6 +47,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -49,6 +61,10 @@ var (
 	updateReadme = flag.Bool("update-readme", false, "Update README.md with compliance badges")
 	readmePath   = flag.String("readme", "README.md", "Path to README.md file")
 	verbose      = flag.Bool("v", false, "Verbose output")
+	concurrency  = flag.Int("concurrency", compliance.DefaultConcurrency, "Number of endpoint checks run at once")
+	checkTimeout = flag.Duration("check-timeout", compliance.DefaultCheckTimeout, "Timeout of a single endpoint check")
+	deadline     = flag.Duration("deadline", 0, "Fail the run if the checks take longer (0 disables)")
+	stream       = flag.Bool("stream", false, "Print each endpoint result to stderr as it completes")
 )
 
 func main() {
@@ -63,7 +79,18 @@ func main() {
 
 	// Create compliance checker and run checks
 	checker := compliance.NewChecker(*baseURL, logger.Logger)
+	checker.SetConcurrency(*concurrency)
+	checker.SetCheckTimeout(*checkTimeout)
+	if *stream {
+		checker.SetReporter(streamResult)
+	}
+
 	ctx := context.Background()
+	if *deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *deadline)
+		defer cancel()
+	}
 	results, err := checker.CheckAll(ctx)
 	if err != nil {
 		logger.Error("compliance check failed", zap.Error(err))
@@ -127,6 +154,29 @@ func generateOutput(results []compliance.Result) error {
 	return nil
 }
 
+// streamResult prints an endpoint result to stderr as soon as it completes,
+// as a JSON line with -output json and as a text line otherwise.
+func streamResult(result compliance.EndpointResult) {
+	if *outputFormat == "json" {
+		line, err := json.Marshal(result)
+		if err == nil {
+			fmt.Fprintln(os.Stderr, string(line))
+		}
+		return
+	}
+
+	status := "PASS"
+	if !result.Passed {
+		status = "FAIL"
+	}
+	detail := fmt.Sprintf("%d", result.StatusCode)
+	if result.Error != "" {
+		detail = result.Error
+	}
+	fmt.Fprintf(os.Stderr, "%s %-6s %-6s %s (%s, %s)\n", status, result.SpecName, result.Method, result.Path,
+		detail, time.Duration(result.DurationMs)*time.Millisecond)
+}
+
 // determineExitCode returns 1 if any spec is not compliant, 0 otherwise.
 func determineExitCode(results []compliance.Result) int {
 	for _, result := range results {
@@ -21,6 +21,9 @@
 //	# Start with custom config file
 //	./gateway --config=/etc/netweave/config.yaml
 //
+//	# Print the effective configuration with secrets redacted
+//	./gateway --config=/etc/netweave/config.yaml --print-config
+//
 //	# Start with environment variable overrides
 //	export NETWEAVE_SERVER_PORT=9090
 //	export NETWEAVE_REDIS_ADDRESSES=redis.example.com:6379
@@ -32,30 +35,57 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
 
 	"github.com/piwi3910/netweave/internal/adapter"
+	adapterbreaker "github.com/piwi3910/netweave/internal/adapter/breaker"
+	adaptercache "github.com/piwi3910/netweave/internal/adapter/cache"
 	"github.com/piwi3910/netweave/internal/adapters/kubernetes"
 	"github.com/piwi3910/netweave/internal/adapters/mock"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
 	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/cost"
+	"github.com/piwi3910/netweave/internal/dms"
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
 	"github.com/piwi3910/netweave/internal/dms/adapters/helm"
+	"github.com/piwi3910/netweave/internal/dms/adapters/manifests"
 	dmsmock "github.com/piwi3910/netweave/internal/dms/adapters/mock"
+	dmsbreaker "github.com/piwi3910/netweave/internal/dms/breaker"
 	dmsregistry "github.com/piwi3910/netweave/internal/dms/registry"
+	dmsstorage "github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/export"
+	"github.com/piwi3910/netweave/internal/lifecycle"
 	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/provisioning"
+	"github.com/piwi3910/netweave/internal/readfallback"
+	"github.com/piwi3910/netweave/internal/resolver"
 	"github.com/piwi3910/netweave/internal/server"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/webhookca"
+	"github.com/piwi3910/netweave/internal/workers"
 )
 
 const (
 	redisModeSentinel = "sentinel"
 	adapterTypeMock   = "mock"
+	defaultOCloudID   = "default-ocloud"
+
+	// informerSyncTimeout bounds the initial sync of the Kubernetes informer caches.
+	informerSyncTimeout = time.Minute
 )
 
 const (
@@ -70,6 +100,11 @@ var (
 	// Command-line flags.
 	configPath  = flag.String("config", config.DefaultConfigPath, "Path to configuration file")
 	showVersion = flag.Bool("version", false, "Show version information and exit")
+	showGraph   = flag.Bool("startup-graph", false, "Print the startup phase dependency graph as JSON and exit")
+	printConfig = flag.Bool("print-config", false, "Print the effective configuration, secrets redacted, as YAML and exit")
+
+	startupTimeout = flag.Duration("startup-timeout", 0,
+		"Abort startup if initialization takes longer than this, naming the stuck phase (0 disables)")
 )
 
 func main() {
@@ -85,6 +120,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Print the startup phase graph and exit if requested
+	if *showGraph {
+		if err := WriteStartupGraph(os.Stdout); err != nil {
+			panic(err)
+		}
+		os.Exit(0)
+	}
+
+	// Print the redacted configuration and exit if requested
+	if *printConfig {
+		if err := PrintConfig(os.Stdout, *configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Fatal error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Run the application
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Fatal error: %v\n", err)
@@ -92,32 +144,69 @@ func main() {
 	}
 }
 
+// PrintConfig writes the configuration loaded from path, with the values of
+// fields tagged redact:"true" replaced, to w as YAML.
+func PrintConfig(w io.Writer, path string) error {
+	cfg, err := loadConfiguration(path)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	data, err := yaml.Marshal(config.Redacted(cfg))
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
 // run executes the main application logic.
 // It returns an error if any critical initialization or runtime error occurs.
 func run() error {
+	// Time each initialization phase; the watchdog aborts a hanging startup
+	phases := NewStartupTracker(*startupTimeout)
+	phases.Start()
+
 	// Step 1: Load configuration
-	cfg, err := loadConfiguration(*configPath)
-	if err != nil {
+	var cfg *config.Config
+	if err := phases.Run(PhaseConfig, func() (err error) {
+		cfg, err = loadConfiguration(*configPath)
+		return err
+	}); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
 	// Step 2: Initialize structured logger
-	logger, err := setupLogger(cfg)
-	if err != nil {
+	var logger *zap.Logger
+	if err := phases.Run(PhaseLogger, func() (err error) {
+		logger, err = setupLogger(cfg)
+		return err
+	}); err != nil {
 		return err
 	}
+	phases.SetLogger(logger)
 
 	logger.Info("O2-IMS Gateway starting",
 		zap.String("version", Version),
 		zap.String("service", ServiceName),
 		zap.String("environment", cfg.Environment),
+		zap.String("mode", cfg.Server.GatewayMode()),
 	)
 
+	// All outbound HTTP clients resolve hosts through the shared resolver
+	resolver.SetDefault(resolver.New(resolver.Config{
+		Servers:     cfg.DNS.Servers,
+		CacheTTL:    cfg.DNS.CacheTTL,
+		NegativeTTL: cfg.DNS.NegativeTTL,
+		MaxEntries:  cfg.DNS.MaxEntries,
+		Timeout:     cfg.DNS.Timeout,
+	}))
+
 	// Step 3-6: Initialize components
-	components, err := initializeComponents(cfg, logger)
+	components, err := initializeComponents(cfg, logger, phases)
 	if err != nil {
 		return err
 	}
+	phases.Done()
 	// Close errors are logged but not returned since we're shutting down anyway.
 	// The Close method still returns aggregated errors for debugging.
 	defer func() {
@@ -137,6 +226,9 @@ type ApplicationComponents struct {
 	healthChecker *observability.HealthChecker
 	server        *server.Server
 	authStore     server.AuthStore
+	dmsStore      dmsstorage.Store
+	notifications *Notifications
+	poolMonitors  []*storage.PoolMonitor
 }
 
 // NewApplicationComponentsForTest creates an ApplicationComponents instance for testing.
@@ -161,6 +253,12 @@ func (c *ApplicationComponents) Close(logger *zap.Logger) error {
 			closeErrors = append(closeErrors, fmt.Errorf("ims adapter: %w", err))
 		}
 	}
+	if c.dmsStore != nil {
+		if err := c.dmsStore.Close(); err != nil {
+			logger.Warn("failed to close DMS subscription store", zap.Error(err))
+			closeErrors = append(closeErrors, fmt.Errorf("dms store: %w", err))
+		}
+	}
 	if c.authStore != nil {
 		if err := c.authStore.Close(); err != nil {
 			logger.Warn("failed to close auth Redis connection", zap.Error(err))
@@ -195,12 +293,19 @@ func setupLogger(cfg *config.Config) (*zap.Logger, error) {
 }
 
 // initializeComponents initializes all application components.
-func initializeComponents(cfg *config.Config, logger *zap.Logger) (*ApplicationComponents, error) {
+func initializeComponents(
+	cfg *config.Config,
+	logger *zap.Logger,
+	phases *StartupTracker,
+) (*ApplicationComponents, error) {
 	ctx := context.Background()
 
 	// Initialize Redis storage
-	store, err := initializeRedisStorage(cfg, logger)
-	if err != nil {
+	var store *storage.RedisStore
+	if err := phases.Run(PhaseRedis, func() (err error) {
+		store, err = initializeRedisStorage(cfg, logger)
+		return err
+	}); err != nil {
 		logger.Error("failed to initialize Redis storage", zap.Error(err))
 		return nil, fmt.Errorf("failed to initialize Redis storage: %w", err)
 	}
@@ -210,73 +315,84 @@ func initializeComponents(cfg *config.Config, logger *zap.Logger) (*ApplicationC
 		zap.Strings("addresses", cfg.Redis.Addresses),
 	)
 
-	// Initialize adapter (Kubernetes or Mock based on environment variable)
+	// Initialize the IMS adapter required by the gateway mode
 	var imsAdapter adapter.Adapter
-	adapterType := os.Getenv("ADAPTER_TYPE")
-	if adapterType == "" {
-		adapterType = "kubernetes"
-	}
-
-	if adapterType == adapterTypeMock {
-		logger.Info("initializing mock adapter")
-		mockAdapter := mock.NewAdapter(true) // Pre-populate with sample data
-		if err := mockAdapter.Initialize(ctx); err != nil {
-			logger.Error("failed to initialize mock adapter", zap.Error(err))
-			if closeErr := store.Close(); closeErr != nil {
-				logger.Warn("failed to close Redis connection during cleanup", zap.Error(closeErr))
-			}
-			return nil, fmt.Errorf("failed to initialize mock adapter: %w", err)
-		}
-		imsAdapter = mockAdapter
-		logger.Info("mock adapter initialized successfully",
-			zap.String("adapter", mockAdapter.Name()),
-			zap.String("version", mockAdapter.Version()),
-		)
-	} else {
-		k8sAdapter, err := initializeKubernetesAdapter(cfg, logger)
-		if err != nil {
-			logger.Error("failed to initialize Kubernetes adapter", zap.Error(err))
-			if closeErr := store.Close(); closeErr != nil {
-				logger.Warn("failed to close Redis connection during cleanup", zap.Error(closeErr))
-			}
-			return nil, fmt.Errorf("failed to initialize Kubernetes adapter: %w", err)
+	if err := phases.Run(PhaseIMSAdapter, func() (err error) {
+		imsAdapter, err = InitializeIMSAdapter(ctx, cfg, logger)
+		return err
+	}); err != nil {
+		if closeErr := store.Close(); closeErr != nil {
+			logger.Warn("failed to close Redis connection during cleanup", zap.Error(closeErr))
 		}
-		imsAdapter = k8sAdapter
-		logger.Info("Kubernetes adapter initialized successfully",
-			zap.String("adapter", k8sAdapter.Name()),
-			zap.String("version", k8sAdapter.Version()),
-		)
+		return nil, err
 	}
 
+	poolMonitors := []*storage.PoolMonitor{newRedisPoolMonitor("storage", store.Client, cfg, logger)}
+
 	// Initialize health checker
-	healthChecker := initializeHealthChecker(store, imsAdapter, logger)
+	var healthChecker *observability.HealthChecker
+	_ = phases.Run(PhaseHealthChecker, func() error {
+		healthChecker = initializeHealthChecker(store, imsAdapter, logger)
+		return nil
+	})
 	logger.Info("health checker initialized")
 
+	// Guard the adapter backends with circuit breakers, reported by /ready
+	breakers := initializeCircuitBreakers(cfg, healthChecker, logger)
+
 	// Initialize auth store if multi-tenancy is enabled (done before server creation)
 	var authStore server.AuthStore
 	if cfg.MultiTenancy.Enabled {
 		var redisAuthStore *auth.RedisStore
-		var err error
-		redisAuthStore, _, err = InitializeAuth(cfg, logger)
+		err := phases.Run(PhaseAuth, func() (err error) {
+			redisAuthStore, _, err = InitializeAuth(cfg, logger)
+			return err
+		})
 		authStore = redisAuthStore
 		if err != nil {
 			logger.Error("failed to initialize authentication subsystem")
 			if closeErr := store.Close(); closeErr != nil {
 				logger.Warn("failed to close Redis connection during cleanup", zap.Error(closeErr))
 			}
-			if closeErr := imsAdapter.Close(); closeErr != nil {
-				logger.Warn("failed to close IMS adapter during cleanup", zap.Error(closeErr))
+			if imsAdapter != nil {
+				if closeErr := imsAdapter.Close(); closeErr != nil {
+					logger.Warn("failed to close IMS adapter during cleanup", zap.Error(closeErr))
+				}
 			}
 			return nil, fmt.Errorf("failed to initialize auth: %w", err)
 		}
 		logger.Info("authentication store initialized",
 			zap.Bool("require_mtls", cfg.MultiTenancy.RequireMTLS),
 		)
+		poolMonitors = append(poolMonitors, newRedisPoolMonitor("auth", redisAuthStore.Client(), cfg, logger))
 	}
 
 	// Create and configure HTTP server with auth store
-	srv := server.New(cfg, logger, imsAdapter, store, authStore)
-	srv.SetHealthChecker(healthChecker)
+	var serverAdapter adapter.Adapter
+	if err := phases.Run(PhaseAdapterCache, func() (err error) {
+		guardedAdapter := imsAdapter
+		if breakers != nil && imsAdapter != nil {
+			guardedAdapter = adapterbreaker.New(imsAdapter, breakers)
+		}
+		serverAdapter, err = initializeAdapterCache(cfg, guardedAdapter, store, logger)
+		return err
+	}); err != nil {
+		if closeErr := store.Close(); closeErr != nil {
+			logger.Warn("failed to close Redis connection during cleanup", zap.Error(closeErr))
+		}
+		if closeErr := imsAdapter.Close(); closeErr != nil {
+			logger.Warn("failed to close IMS adapter during cleanup", zap.Error(closeErr))
+		}
+		return nil, err
+	}
+	var srv *server.Server
+	if err := phases.Run(PhaseServer, func() (err error) {
+		srv, err = newServer(ctx, cfg, logger, serverAdapter, store, authStore, healthChecker)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
 	logger.Info("HTTP server created",
 		zap.String("host", cfg.Server.Host),
 		zap.Int("port", cfg.Server.Port),
@@ -286,8 +402,11 @@ func initializeComponents(cfg *config.Config, logger *zap.Logger) (*ApplicationC
 
 	// Load OpenAPI specification for documentation endpoints
 	// This is fail-fast - server won't start without a valid OpenAPI spec
-	spec, err := loadOpenAPISpec(logger)
-	if err != nil {
+	var spec []byte
+	if err := phases.Run(PhaseOpenAPI, func() (err error) {
+		spec, err = loadOpenAPISpec(logger)
+		return err
+	}); err != nil {
 		logger.Error("failed to load OpenAPI specification", zap.Error(err))
 		return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
 	}
@@ -302,6 +421,7 @@ func initializeComponents(cfg *config.Config, logger *zap.Logger) (*ApplicationC
 		healthChecker: healthChecker,
 		server:        srv,
 		authStore:     authStore,
+		poolMonitors:  poolMonitors,
 	}
 
 	if authStore != nil {
@@ -310,15 +430,180 @@ func initializeComponents(cfg *config.Config, logger *zap.Logger) (*ApplicationC
 		logger.Info("multi-tenancy is disabled")
 	}
 
+	// Initialize the DMS subscription store before the DMS routes use it
+	var dmsStore dmsstorage.Store
+	if err := phases.Run(PhaseDMSStore, func() (err error) {
+		dmsStore, err = initializeDMSStore(cfg, store, logger)
+		return err
+	}); err != nil {
+		logger.Error("failed to initialize DMS subscription store", zap.Error(err))
+		return nil, fmt.Errorf("failed to initialize DMS store: %w", err)
+	}
+	components.dmsStore = dmsStore
+	srv.SetDMSStore(dmsStore)
+
+	// Persist DMS jobs in Redis so their status survives gateway restarts
+	srv.SetDMSJobStore(dmsstorage.NewRedisJobStore(store.Client, cfg.DMS.Jobs.Retention))
+
+	// Share DMS quota usage between replicas and across restarts
+	srv.SetDMSQuotaUsageStore(dmsstorage.NewRedisQuotaUsageStore(store.Client))
+
+	// Keep the DMS adapters registered through the API across restarts and
+	// share them with the other replicas, with their passwords encrypted
+	if cfg.DMS.Storage.Backend == config.DMSStorageRedis {
+		encryptionKey, err := cfg.DMS.Registrations.GetEncryptionKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get DMS registration encryption key: %w", err)
+		}
+		if encryptionKey == "" {
+			logger.Warn("no DMS registration encryption key is configured; " +
+				"adapters with a password cannot be registered through the API")
+		}
+		srv.SetDMSRegistrationStore(dmsstorage.NewRedisRegistrationStore(store.Client, encryptionKey))
+	}
+
 	// Initialize DMS subsystem
-	if err := initializeDMS(cfg, srv, imsAdapter, logger); err != nil {
+	var dmsReg *dmsregistry.Registry
+	if err := phases.Run(PhaseDMS, func() (err error) {
+		dmsReg, err = initializeDMS(cfg, srv, imsAdapter, breakers, logger)
+		return err
+	}); err != nil {
 		logger.Error("failed to initialize DMS subsystem", zap.Error(err))
 		return nil, fmt.Errorf("failed to initialize DMS: %w", err)
 	}
 
+	// Initialize the infrastructure provisioning API
+	if cfg.Provisioning.Enabled {
+		if err := phases.Run(PhaseProvisioning, func() error {
+			return initializeProvisioning(cfg, srv, logger)
+		}); err != nil {
+			logger.Error("failed to initialize infrastructure provisioning", zap.Error(err))
+			return nil, fmt.Errorf("failed to initialize provisioning: %w", err)
+		}
+	}
+
+	// Probe the adapter backends so bad endpoints and credentials surface
+	// now rather than on the first request
+	if err := phases.Run(PhaseStartupChecks, func() error {
+		return RunStartupChecks(cfg.StartupChecks, StartupProbes(imsAdapter, dmsReg), logger)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Initialize subscription notification delivery and the webhook CA
+	// issuing mTLS certificates to subscribers
+	var notifications *Notifications
+	if err := phases.Run(PhaseNotifications, func() error {
+		webhookCA, err := InitializeWebhookCA(ctx, cfg, store, logger)
+		if err != nil {
+			return err
+		}
+		srv.SetWebhookCA(webhookCA)
+		notifications, err = InitializeNotifications(
+			cfg, imsAdapter, store, webhookCA, srv.CallbackDialGuard("webhook"), logger)
+		return err
+	}); err != nil {
+		logger.Error("failed to initialize notifications", zap.Error(err))
+		return nil, fmt.Errorf("failed to initialize notifications: %w", err)
+	}
+	components.notifications = notifications
+
 	return components, nil
 }
 
+// newServer creates the HTTP server and attaches the Redis-backed stores and
+// optional features it serves.
+func newServer(
+	ctx context.Context,
+	cfg *config.Config,
+	logger *zap.Logger,
+	serverAdapter adapter.Adapter,
+	store *storage.RedisStore,
+	authStore server.AuthStore,
+	healthChecker *observability.HealthChecker,
+) (*server.Server, error) {
+	srv := server.New(
+		cfg, observability.ModuleLogger(logger, observability.ModuleServer), serverAdapter, store, authStore)
+	srv.SetHealthChecker(healthChecker)
+
+	// Persist a redacted configuration snapshot so config changes show up in
+	// GET /admin/config/history. Failure is not fatal.
+	srv.SetConfigHistoryStore(storage.NewRedisConfigHistoryStore(store.Client, storage.DefaultConfigHistoryMaxEntries))
+	if err := srv.RecordConfigSnapshot(ctx, cfg, server.ConfigSnapshotReasonStartup); err != nil {
+		logger.Warn("failed to record configuration snapshot", zap.Error(err))
+	}
+
+	// Signing secrets rotated through /admin/webhooks/signing-keys are shared
+	// with the webhook workers of every replica through Redis.
+	srv.SetSigningKeyStore(storage.NewRedisSigningKeyStore(store.Client))
+
+	// Filter match statistics recorded by the notification controller back
+	// the GET /subscriptions?stats=true admin view.
+	srv.SetSubscriptionStatsStore(storage.NewRedisSubscriptionStatsStore(store.Client))
+
+	// Delivery attempts recorded by the webhook workers back the
+	// GET /admin/subscriptions/deliverability admin view.
+	srv.SetDeliverabilityStore(
+		storage.NewRedisDeliverabilityStore(store.Client, cfg.Notifications.DeliverabilityWindow))
+
+	// Index resources by globalAssetId and serial number for O(1) identifier lookups.
+	srv.SetResourceIndex(storage.NewRedisResourceIndex(store.Client))
+
+	// Cache resource pool and resource lookups locally; mutations on any replica
+	// invalidate cached entries through Redis pub/sub.
+	if cfg.Server.ReadCacheTTL > 0 {
+		readCache := storage.NewLocalCache(cfg.Server.ReadCacheTTL)
+		srv.SetReadCache(readCache, storage.NewCacheInvalidationBus(store.Client, readCache))
+	}
+
+	// Serve consistency=snapshot list pages from Redis so any replica can continue a pagination session.
+	if cfg.Server.ListSnapshotTTL > 0 {
+		srv.SetListSnapshotStore(storage.NewRedisListSnapshotStore(store.Client, cfg.Server.ListSnapshotTTL))
+	}
+
+	// Keep inventory reconciliation tasks in Redis so any replica can serve them.
+	srv.SetReconciliationStore(storage.NewRedisReconciliationStore(store.Client, storage.DefaultReconciliationTTL))
+
+	// Estimate resource pool and NF deployment costs from the configured pricing model
+	if cfg.Pricing.Enabled {
+		srv.SetPricing(&cost.Pricing{
+			Currency:     cfg.Pricing.Currency,
+			VCPUHour:     cfg.Pricing.VCPUHour,
+			MemoryGBHour: cfg.Pricing.MemoryGBHour,
+			GPUHour:      cfg.Pricing.GPUHour,
+		})
+	}
+
+	// Write scheduled inventory exports to the configured object store
+	if cfg.Export.Enabled {
+		exportStore, err := export.NewS3Store(ctx, cfg.Export.ObjectStore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure inventory export: %w", err)
+		}
+		srv.SetExportStore(exportStore, storage.NewRedisRunClaims(store.Client))
+	}
+
+	// Invoke configured lifecycle hooks around create and delete operations
+	if len(cfg.Hooks) > 0 {
+		hookRunner, err := server.HooksFromConfig(cfg.Hooks, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure lifecycle hooks: %w", err)
+		}
+		srv.SetHooks(hookRunner)
+	}
+
+	// Check configured validation rules on resource pool, resource and NF deployment writes
+	if len(cfg.Validation.Rules) > 0 {
+		rules, err := server.ValidationRulesFromConfig(cfg.Validation.Rules, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure validation rules: %w", err)
+		}
+		srv.SetValidationRules(rules)
+	}
+
+	return srv, nil
+}
+
 // runServerWithShutdown starts the server and handles graceful shutdown.
 func runServerWithShutdown(cfg *config.Config, logger *zap.Logger, components *ApplicationComponents) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -328,6 +613,10 @@ func runServerWithShutdown(cfg *config.Config, logger *zap.Logger, components *A
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
+	// Components holding work that must not be dropped register here and are
+	// drained on shutdown
+	drainer := lifecycle.New(logger.Named("lifecycle"))
+
 	// Start server
 	serverErrors := make(chan error, 1)
 	go func() {
@@ -340,8 +629,65 @@ func runServerWithShutdown(cfg *config.Config, logger *zap.Logger, components *A
 		}
 	}()
 
+	// Apply reloadable settings on SIGHUP and when the configuration file
+	// changes
+	components.server.StartConfigReload(ctx, *configPath)
+
+	// Re-check stored subscription callbacks against the current callback policy
+	components.server.StartCallbackRevalidation(ctx)
+
+	// Delete expired O2-IMS and O2-DMS subscriptions
+	components.server.StartSubscriptionExpiry(ctx)
+
+	// Write scheduled inventory exports for analytics pipelines
+	components.server.StartScheduledExport(ctx)
+
+	// Run the O-RAN compliance checks against this gateway and report
+	// score regressions
+	components.server.StartComplianceSelfCheck(ctx)
+
+	// Notify DMS subscribers of deployment state transitions
+	components.server.StartDMSNotifications(ctx)
+
+	// Apply DMS adapter registrations made on other replicas
+	components.server.StartDMSAdapterSync(ctx)
+
+	// Execute asynchronous DMS jobs, including jobs left behind by a restart.
+	// Jobs run until drained rather than until the shutdown signal.
+	components.server.StartDMSJobs(context.WithoutCancel(ctx))
+	drainer.Register("dms_jobs", lifecycle.StageWork, components.server.DrainDMSJobs)
+
+	// Reconcile infrastructure provisioning requests
+	components.server.StartProvisioning(ctx)
+
+	// Run the health and readiness checks in the background; /health and
+	// /ready serve the last results
+	if interval := cfg.Observability.Health.ProbeInterval; interval > 0 && components.healthChecker != nil {
+		components.healthChecker.StartProber(ctx, interval)
+	}
+
+	// Export the Redis connection pool stats and resize the pools
+	for _, monitor := range components.poolMonitors {
+		monitor.Start(ctx)
+	}
+
+	// Start notification delivery; deliveries in progress are drained on
+	// shutdown
+	if components.notifications != nil {
+		components.notifications.Start(ctx, logger)
+		drainer.Register("webhook_deliveries", lifecycle.StageWork, components.notifications.Drain)
+	}
+
+	// Stop the informer caches once the workers reading them have drained
+	if k8sAdapter, ok := components.imsAdapter.(*kubernetes.Adapter); ok {
+		drainer.Register("informer_caches", lifecycle.StageCaches, func(context.Context) error {
+			k8sAdapter.StopInformers()
+			return nil
+		})
+	}
+
 	// Wait for shutdown signal or error
-	return handleShutdown(ctx, cancel, components.server, cfg, logger, shutdown, serverErrors)
+	return handleShutdown(ctx, cancel, components.server, drainer, cfg, logger, shutdown, serverErrors)
 }
 
 // handleShutdown waits for shutdown signals or errors and performs graceful shutdown.
@@ -349,6 +695,7 @@ func handleShutdown(
 	ctx context.Context,
 	cancel context.CancelFunc,
 	srv *server.Server,
+	drainer *lifecycle.Manager,
 	cfg *config.Config,
 	logger *zap.Logger,
 	shutdown chan os.Signal,
@@ -362,7 +709,7 @@ func handleShutdown(
 	case sig := <-shutdown:
 		logger.Info("shutdown signal received", zap.String("signal", sig.String()))
 		cancel()
-		return gracefulShutdown(ctx, srv, cfg, logger)
+		return gracefulShutdown(ctx, srv, drainer, cfg, logger)
 	}
 }
 
@@ -387,18 +734,39 @@ func initializeLogger(cfg *config.Config) (*zap.Logger, error) {
 	var logger *zap.Logger
 	var err error
 
+	// The configured level is the initial runtime level; it can be changed
+	// without a restart via PUT /admin/loglevel.
+	if err := observability.SetLogLevel("", parseLogLevel(cfg.Observability.Logging.Level).String()); err != nil {
+		return nil, fmt.Errorf("failed to set log level: %w", err)
+	}
+
+	// Redact secrets before entries reach any output; applied before the
+	// runtime level filter so per-module levels keep working.
+	opts := []zap.Option{}
+	if cfg.Observability.Logging.Redaction.Enabled {
+		redactor, err := observability.NewRedactor(
+			cfg.Observability.Logging.Redaction.Fields,
+			cfg.Observability.Logging.Redaction.Patterns,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure log redaction: %w", err)
+		}
+		opts = append(opts, observability.RedactionOption(redactor))
+	}
+	opts = append(opts, observability.DynamicLevel())
+
 	// Determine log configuration based on settings
 	if cfg.Observability.Logging.Development {
 		// Development mode - console output with colors
 		loggerCfg := zap.NewDevelopmentConfig()
-		loggerCfg.Level = parseLogLevel(cfg.Observability.Logging.Level)
+		loggerCfg.Level = observability.BaseLevel()
 		loggerCfg.OutputPaths = cfg.Observability.Logging.OutputPaths
 		loggerCfg.ErrorOutputPaths = cfg.Observability.Logging.ErrorOutputPaths
-		logger, err = loggerCfg.Build()
+		logger, err = loggerCfg.Build(opts...)
 	} else {
 		// Production mode - JSON output
 		loggerCfg := zap.NewProductionConfig()
-		loggerCfg.Level = parseLogLevel(cfg.Observability.Logging.Level)
+		loggerCfg.Level = observability.BaseLevel()
 		loggerCfg.OutputPaths = cfg.Observability.Logging.OutputPaths
 		loggerCfg.ErrorOutputPaths = cfg.Observability.Logging.ErrorOutputPaths
 		loggerCfg.DisableCaller = !cfg.Observability.Logging.EnableCaller
@@ -411,7 +779,7 @@ func initializeLogger(cfg *config.Config) (*zap.Logger, error) {
 			loggerCfg.Encoding = "json"
 		}
 
-		logger, err = loggerCfg.Build()
+		logger, err = loggerCfg.Build(opts...)
 	}
 
 	if err != nil {
@@ -457,6 +825,9 @@ func initializeRedisStorage(cfg *config.Config, logger *zap.Logger) (*storage.Re
 	if err := verifyRedisConnectivity(store); err != nil {
 		return nil, err
 	}
+	if fallback := cfg.Redis.ReadFallback; fallback.Enabled {
+		store.EnableReadFallback(readfallback.New("subscriptions", fallback.MaxStaleness, fallback.MaxEntries))
+	}
 
 	logger.Info("Redis connectivity verified")
 	return store, nil
@@ -512,11 +883,45 @@ func buildRedisConfig(cfg *config.Config, password, redisModeSentinelPassword st
 		DialTimeout:            cfg.Redis.DialTimeout,
 		ReadTimeout:            cfg.Redis.ReadTimeout,
 		WriteTimeout:           cfg.Redis.WriteTimeout,
-		PoolSize:               cfg.Redis.PoolSize,
+		PoolSize:               redisPoolSize(cfg),
 		AllowInsecureCallbacks: cfg.Security.AllowInsecureCallbacks,
 	}
 }
 
+// redisPoolSize returns the pool size of the Redis clients: the upper bound
+// of automatic resizing when enabled, as go-redis pools cannot grow, or the
+// configured size.
+func redisPoolSize(cfg *config.Config) int {
+	if cfg.Redis.PoolAutoResize.Enabled {
+		return cfg.Redis.PoolAutoResize.MaxSize
+	}
+	return cfg.Redis.PoolSize
+}
+
+// newRedisPoolMonitor returns the monitor exporting the pool stats of a Redis
+// client, resizing the pool when configured.
+func newRedisPoolMonitor(
+	name string,
+	client redis.UniversalClient,
+	cfg *config.Config,
+	logger *zap.Logger,
+) *storage.PoolMonitor {
+	monitorCfg := storage.PoolMonitorConfig{
+		Interval:    cfg.Redis.PoolStatsInterval,
+		InitialSize: cfg.Redis.PoolSize,
+		PoolTimeout: cfg.Redis.PoolTimeout,
+	}
+	if resize := cfg.Redis.PoolAutoResize; resize.Enabled {
+		monitorCfg.AutoResize = &storage.PoolAutoResizeConfig{
+			MinSize:            resize.MinSize,
+			MaxSize:            resize.MaxSize,
+			WaitThreshold:      resize.WaitThreshold,
+			SustainedIntervals: resize.SustainedIntervals,
+		}
+	}
+	return storage.NewPoolMonitor(name, client, monitorCfg, logger)
+}
+
 // configureRedisMode sets up Redis mode (standalone/sentinel/cluster).
 func configureRedisMode(redisCfg *storage.RedisConfig, cfg *config.Config, logger *zap.Logger) error {
 	switch cfg.Redis.Mode {
@@ -576,15 +981,53 @@ func verifyRedisConnectivity(store *storage.RedisStore) error {
 	return nil
 }
 
+// InitializeIMSAdapter creates the IMS adapter for the gateway mode:
+//   - ims+dms: the Kubernetes adapter, or the mock adapter when ADAPTER_TYPE=mock.
+//     Kubernetes must be reachable.
+//   - simulator: the mock adapter with sample data.
+//   - dms-only: none; the returned adapter is nil and O2-IMS routes are not served.
+func InitializeIMSAdapter(ctx context.Context, cfg *config.Config, logger *zap.Logger) (adapter.Adapter, error) {
+	mode := cfg.Server.GatewayMode()
+	if mode == config.GatewayModeDMSOnly {
+		logger.Info("IMS adapter disabled", zap.String("mode", mode))
+		return nil, nil
+	}
+
+	if mode == config.GatewayModeSimulator || os.Getenv("ADAPTER_TYPE") == adapterTypeMock {
+		logger.Info("initializing mock adapter", zap.String("mode", mode))
+		mockAdapter := mock.NewAdapter(true) // Pre-populate with sample data
+		if err := mockAdapter.Initialize(ctx); err != nil {
+			logger.Error("failed to initialize mock adapter", zap.Error(err))
+			return nil, fmt.Errorf("failed to initialize mock adapter: %w", err)
+		}
+		logger.Info("mock adapter initialized successfully",
+			zap.String("adapter", mockAdapter.Name()),
+			zap.String("version", mockAdapter.Version()),
+		)
+		return mockAdapter, nil
+	}
+
+	k8sAdapter, err := initializeKubernetesAdapter(cfg, logger)
+	if err != nil {
+		logger.Error("failed to initialize Kubernetes adapter", zap.Error(err))
+		return nil, fmt.Errorf("failed to initialize Kubernetes adapter: %w", err)
+	}
+	logger.Info("Kubernetes adapter initialized successfully",
+		zap.String("adapter", k8sAdapter.Name()),
+		zap.String("version", k8sAdapter.Version()),
+	)
+	return k8sAdapter, nil
+}
+
 // initializeKubernetesAdapter creates and initializes the Kubernetes adapter.
 func initializeKubernetesAdapter(cfg *config.Config, logger *zap.Logger) (*kubernetes.Adapter, error) {
 	// Build Kubernetes adapter configuration
 	k8sCfg := &kubernetes.Config{
 		Kubeconfig:          cfg.Kubernetes.ConfigPath,
-		OCloudID:            "default-ocloud",
+		OCloudID:            defaultOCloudID,
 		DeploymentManagerID: "netweave-k8s-dm",
 		Namespace:           cfg.Kubernetes.Namespace,
-		Logger:              logger,
+		Logger:              observability.ModuleLogger(logger, observability.ModuleAdapters),
 	}
 
 	// Set default namespace if not specified
@@ -610,14 +1053,333 @@ func initializeKubernetesAdapter(cfg *config.Config, logger *zap.Logger) (*kuber
 	}
 
 	logger.Info("Kubernetes connectivity verified")
+
+	if hedging := cfg.Kubernetes.Hedging; hedging.Enabled {
+		adapter.EnableHedging(kubernetes.HedgingConfig{
+			Delay:         hedging.Delay,
+			MaxInFlight:   hedging.MaxInFlight,
+			BudgetPercent: hedging.BudgetPercent,
+		})
+	}
+
+	adapter.SetObjectLimits(kubernetes.ObjectLimits{
+		MaxResourcePools: cfg.Kubernetes.ObjectLimits.MaxResourcePools,
+		MaxResources:     cfg.Kubernetes.ObjectLimits.MaxResources,
+		WarnRatio:        cfg.Kubernetes.ObjectLimits.WarnRatio,
+	})
+
+	// Serve node and namespace reads from watch-backed caches. If the caches
+	// cannot sync, reads keep going to the API server.
+	if cfg.Kubernetes.EnableWatch {
+		syncCtx, syncCancel := context.WithTimeout(context.Background(), informerSyncTimeout)
+		defer syncCancel()
+		if err := adapter.StartInformers(syncCtx, cfg.Kubernetes.WatchResync); err != nil {
+			logger.Warn("failed to start Kubernetes informers; reading from the API server", zap.Error(err))
+		}
+	}
+
 	return adapter, nil
 }
 
+// Notifications delivers O2-IMS subscription notifications. The controller
+// watches Kubernetes nodes (resources) and namespaces (resource pools), queues
+// an event per matching subscription on a Redis stream, and the webhook
+// workers POST them to the callbacks. Deliveries that still fail after all
+// retries are moved to the Redis dead-letter stream workers.DLQStreamKey.
+type Notifications struct {
+	Controller *controllers.SubscriptionController
+	Worker     *workers.WebhookWorker
+
+	// QueueSnapshotPath is where the undelivered notifications are exported
+	// on Drain and replayed from on Start. Disabled when empty.
+	QueueSnapshotPath string
+
+	redisClient redis.UniversalClient
+	logger      *zap.Logger
+}
+
+// InitializeWebhookCA creates the webhook CA when notifications.ca is
+// enabled, loading its key pair from the configured files or generating one
+// shared by all replicas through Redis. It returns nil when the CA is
+// disabled.
+func InitializeWebhookCA(
+	ctx context.Context,
+	cfg *config.Config,
+	store *storage.RedisStore,
+	logger *zap.Logger,
+) (*webhookca.Authority, error) {
+	caCfg := cfg.Notifications.CA
+	if !caCfg.Enabled {
+		return nil, nil
+	}
+
+	ca, err := webhookca.New(ctx, webhookca.Config{
+		CertFile:     caCfg.CertFile,
+		KeyFile:      caCfg.KeyFile,
+		CertValidity: caCfg.CertValidity,
+	}, storage.NewRedisWebhookCertificateStore(store.Client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook CA: %w", err)
+	}
+
+	source := "generated"
+	if caCfg.CertFile != "" {
+		source = caCfg.CertFile
+	}
+	logger.Info("webhook CA enabled", zap.String("ca", source), zap.Duration("cert_validity", caCfg.CertValidity))
+	return ca, nil
+}
+
+// InitializeNotifications creates the notification controller and webhook
+// workers. It returns nil when notifications are disabled or the IMS adapter
+// is not the Kubernetes adapter, which is the only adapter that can be watched.
+// With a webhook CA, deliveries use mTLS with the gateway's client
+// certificate and check the CA's revocations. With a dial guard, deliveries
+// to callbacks resolving to blocked addresses are refused at connection time.
+func InitializeNotifications(
+	cfg *config.Config,
+	imsAdapter adapter.Adapter,
+	store *storage.RedisStore,
+	webhookCA *webhookca.Authority,
+	dialGuard *resolver.Guard,
+	logger *zap.Logger,
+) (*Notifications, error) {
+	if !cfg.Notifications.Enabled {
+		logger.Info("subscription notifications disabled")
+		return nil, nil
+	}
+	k8sAdapter, ok := imsAdapter.(*kubernetes.Adapter)
+	if !ok {
+		logger.Info("subscription notifications require the Kubernetes adapter; not starting them",
+			zap.String("mode", cfg.Server.GatewayMode()))
+		return nil, nil
+	}
+
+	controller, err := controllers.NewSubscriptionController(&controllers.Config{
+		K8sClient:           k8sAdapter.GetClient(),
+		Store:               store,
+		RedisClient:         store.Client,
+		Logger:              logger.Named("notifications"),
+		OCloudID:            defaultOCloudID,
+		InformerFactory:     k8sAdapter.InformerFactory(),
+		Stats:               storage.NewRedisSubscriptionStatsStore(store.Client),
+		BusyEventsPerMinute: cfg.Notifications.BusyEventsPerMinute,
+		DigestCheckInterval: cfg.Notifications.DigestCheckInterval,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription controller: %w", err)
+	}
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient:     store.Client,
+		Logger:          logger.Named("webhooks"),
+		WorkerCount:     cfg.Notifications.Workers,
+		Timeout:         cfg.Notifications.Timeout,
+		MaxRetries:      cfg.Notifications.MaxRetries,
+		RetryBackoff:    cfg.Notifications.RetryBackoff,
+		MaxBackoff:      cfg.Notifications.MaxBackoff,
+		OrderingTimeout: cfg.Notifications.OrderingTimeout,
+		HMACSecret:      cfg.Notifications.HMACSecret,
+		SigningKeys:     storage.NewRedisSigningKeyStore(store.Client),
+		Deliverability:  storage.NewRedisDeliverabilityStore(store.Client, cfg.Notifications.DeliverabilityWindow),
+		CA:              webhookCA,
+		DialGuard:       dialGuard,
+		Subscriptions:   store,
+		Autoscale:       webhookAutoscaleConfig(&cfg.Notifications.Autoscale),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook worker: %w", err)
+	}
+
+	if cfg.Notifications.HMACSecret == "" {
+		logger.Warn("notifications.hmac_secret is not set; webhook notifications will not be signed")
+	}
+	return &Notifications{
+		Controller:        controller,
+		Worker:            worker,
+		QueueSnapshotPath: cfg.Notifications.QueueSnapshotPath,
+		redisClient:       store.Client,
+		logger:            logger,
+	}, nil
+}
+
+// webhookAutoscaleConfig returns the webhook worker autoscaling settings of
+// cfg, or nil when autoscaling is disabled.
+func webhookAutoscaleConfig(cfg *config.WebhookAutoscaleConfig) *workers.AutoscaleConfig {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &workers.AutoscaleConfig{
+		Enabled:                true,
+		MinWorkers:             cfg.MinWorkers,
+		MaxWorkers:             cfg.MaxWorkers,
+		TargetBacklogPerWorker: cfg.TargetBacklogPerWorker,
+		TargetLatency:          cfg.TargetLatency,
+		Interval:               cfg.Interval,
+		ScaleDownCooldown:      cfg.ScaleDownCooldown,
+		ClaimIdle:              cfg.ClaimIdle,
+	}
+}
+
+// Start runs the controller in the background until ctx is canceled, and the
+// webhook workers until Drain is called, so that deliveries in progress are
+// not dropped when the gateway shuts down. Notifications exported by the
+// previous shutdown are put back on the queue first.
+func (n *Notifications) Start(ctx context.Context, logger *zap.Logger) {
+	n.importQueue(ctx, logger)
+	go func() {
+		if err := n.Controller.Start(ctx); err != nil {
+			logger.Error("subscription controller stopped with error", zap.Error(err))
+		}
+	}()
+	go func() {
+		if err := n.Worker.Start(context.WithoutCancel(ctx)); err != nil {
+			logger.Error("webhook worker stopped with error", zap.Error(err))
+		}
+	}()
+	logger.Info("subscription notifications started",
+		zap.Int("workers", n.Worker.WorkerCount),
+		zap.Int("max_retries", n.Worker.MaxRetries),
+	)
+}
+
+// Drain stops the webhook workers reading new events and waits for the
+// deliveries in progress until ctx expires. The notifications left on the
+// queue, including interrupted ones, are then exported to the queue
+// snapshot, if one is configured.
+func (n *Notifications) Drain(ctx context.Context) error {
+	drainErr := n.Worker.Drain(ctx)
+	if n.QueueSnapshotPath != "" {
+		count, err := workers.ExportQueue(context.WithoutCancel(ctx), n.redisClient, n.QueueSnapshotPath)
+		if err != nil {
+			return fmt.Errorf("failed to export webhook queue: %w", err)
+		}
+		n.logger.Info("exported undelivered notifications",
+			zap.String("path", n.QueueSnapshotPath),
+			zap.Int("events", count))
+	}
+	if drainErr != nil {
+		return fmt.Errorf("failed to drain webhook deliveries: %w", drainErr)
+	}
+	return nil
+}
+
+// importQueue replays the queue snapshot, if one is configured and exists.
+// A snapshot that cannot be imported is left in place for inspection and
+// does not stop the gateway from starting.
+func (n *Notifications) importQueue(ctx context.Context, logger *zap.Logger) {
+	if n.QueueSnapshotPath == "" {
+		return
+	}
+	if _, err := os.Stat(n.QueueSnapshotPath); errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	result, err := workers.ImportQueue(ctx, n.redisClient, n.QueueSnapshotPath)
+	if err != nil {
+		logger.Error("failed to import webhook queue snapshot; notifications in it are not delivered",
+			zap.String("path", n.QueueSnapshotPath),
+			zap.Error(err))
+		return
+	}
+	logger.Info("imported webhook queue snapshot",
+		zap.String("path", n.QueueSnapshotPath),
+		zap.Int("imported", result.Imported),
+		zap.Int("skipped", result.Skipped))
+}
+
+// initializeDMSStore creates the DMS subscription store selected by
+// dms.storage.backend. The kubernetes backend persists subscriptions as
+// DMSSubscription custom resources and waits for its informer to sync.
+func initializeDMSStore(cfg *config.Config, store *storage.RedisStore, logger *zap.Logger) (dmsstorage.Store, error) {
+	switch cfg.DMS.Storage.Backend {
+	case config.DMSStorageRedis:
+		logger.Info("DMS subscriptions stored in Redis")
+		return dmsstorage.NewRedisStore(store.Client), nil
+
+	case config.DMSStorageKubernetes:
+		var (
+			restConfig *rest.Config
+			err        error
+		)
+		if cfg.Kubernetes.ConfigPath != "" {
+			restConfig, err = clientcmd.BuildConfigFromFlags("", cfg.Kubernetes.ConfigPath)
+		} else {
+			restConfig, err = rest.InClusterConfig()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to build Kubernetes client config: %w", err)
+		}
+		client, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+
+		namespace := cfg.DMS.Storage.Namespace
+		if namespace == "" {
+			namespace = cfg.Kubernetes.Namespace
+		}
+		if namespace == "" {
+			namespace = "default"
+		}
+
+		crdStore := dmsstorage.NewCRDStore(client, namespace)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := crdStore.Start(ctx); err != nil {
+			_ = crdStore.Close()
+			return nil, fmt.Errorf("failed to start DMSSubscription informer in %s: %w", namespace, err)
+		}
+		logger.Info("DMS subscriptions stored as DMSSubscription resources", zap.String("namespace", namespace))
+		return crdStore, nil
+
+	default:
+		logger.Info("DMS subscriptions stored in memory")
+		return dmsstorage.NewMemoryStore(), nil
+	}
+}
+
+// registerConfiguredDMSAdapter registers an adapter of dms.adapters. Each
+// adapter gets its own timeout for its initial health check.
+func registerConfiguredDMSAdapter(
+	reg *dmsregistry.Registry,
+	a *config.DMSAdapterConfig,
+	logger *zap.Logger,
+) error {
+	password, err := a.GetPassword()
+	if err != nil {
+		return fmt.Errorf("failed to get password of DMS adapter %s: %w", a.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = dms.RegisterAdapter(ctx, reg, a.Name, a.Type, &dms.AdapterConfig{
+		Enabled:       true,
+		IsDefault:     a.Default,
+		Kubeconfig:    a.Kubeconfig,
+		Namespace:     a.Namespace,
+		RepositoryURL: a.RepositoryURL,
+		ONAPURL:       a.APIURL,
+		OSMURL:        a.APIURL,
+		Username:      a.Username,
+		Password:      password,
+	}, logger)
+	if err != nil {
+		return fmt.Errorf("failed to register DMS adapter %s: %w", a.Name, err)
+	}
+	return nil
+}
+
 // initializeDMS initializes the DMS (Deployment Management Service) subsystem.
 // It creates a DMS registry and registers available deployment management adapters.
 //
 // Currently registered adapters:
 //   - Helm: Package manager for Kubernetes applications
+//   - Manifests: Plain manifests and kustomizations applied with server-side apply
+//     (DMS_ADAPTER_TYPE=manifests)
+//
+// Further adapters of any type are registered from dms.adapters in the
+// configuration, and at runtime through POST /o2dms/v1/adapters.
 //
 // Future adapters to be added:
 //   - ArgoCD: GitOps continuous delivery tool
@@ -631,27 +1393,39 @@ func initializeKubernetesAdapter(cfg *config.Config, logger *zap.Logger) (*kuber
 //   - cfg: Application configuration
 //   - srv: Server instance to configure with DMS routes
 //   - k8sAdapter: Kubernetes adapter for cluster access
+//   - breakers: Circuit breakers wrapped around every adapter, or nil
 //   - logger: Structured logger
 //
-// Returns an error if DMS initialization fails.
+// Returns the DMS registry, or an error if DMS initialization fails.
 func initializeDMS(
 	cfg *config.Config,
 	srv *server.Server,
 	_ adapter.Adapter,
+	breakers *circuitbreaker.Set,
 	logger *zap.Logger,
-) error {
-	// Create DMS registry with default configuration
-	dmsReg := dmsregistry.NewRegistry(logger, nil)
+) (*dmsregistry.Registry, error) {
+	logger = observability.ModuleLogger(logger, observability.ModuleDMS)
+
+	// Create DMS registry, guarding every adapter with a circuit breaker
+	registryConfig := &dmsregistry.Config{}
+	if breakers != nil {
+		registryConfig.Decorate = func(name string, plugin dmsadapter.DMSAdapter) dmsadapter.DMSAdapter {
+			return dmsbreaker.New(name, plugin, breakers)
+		}
+	}
+	dmsReg := dmsregistry.NewRegistry(logger, registryConfig)
 
-	// Determine DMS adapter type from environment variable
+	// Determine DMS adapter type from the gateway mode and environment variables
 	dmsAdapterType := os.Getenv("DMS_ADAPTER_TYPE")
-	if dmsAdapterType == "" {
+	switch {
+	case cfg.Server.GatewayMode() == config.GatewayModeSimulator:
+		dmsAdapterType = adapterTypeMock
+	case dmsAdapterType != "":
+	case os.Getenv("ADAPTER_TYPE") == adapterTypeMock:
 		// If ADAPTER_TYPE is set to mock, also default DMS to mock
-		if os.Getenv("ADAPTER_TYPE") == adapterTypeMock {
-			dmsAdapterType = adapterTypeMock
-		} else {
-			dmsAdapterType = "helm"
-		}
+		dmsAdapterType = adapterTypeMock
+	default:
+		dmsAdapterType = "helm"
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -662,7 +1436,7 @@ func initializeDMS(
 		logger.Info("initializing mock DMS adapter")
 		mockDMSAdapter := dmsmock.NewAdapter(true) // Pre-populate with sample data
 		if err := mockDMSAdapter.Initialize(ctx); err != nil {
-			return fmt.Errorf("failed to initialize mock DMS adapter: %w", err)
+			return nil, fmt.Errorf("failed to initialize mock DMS adapter: %w", err)
 		}
 
 		mockConfig := map[string]interface{}{
@@ -671,24 +1445,54 @@ func initializeDMS(
 		}
 
 		if err := dmsReg.Register(ctx, adapterTypeMock, adapterTypeMock, mockDMSAdapter, mockConfig, true); err != nil {
-			return fmt.Errorf("failed to register mock DMS adapter: %w", err)
+			return nil, fmt.Errorf("failed to register mock DMS adapter: %w", err)
 		}
 
 		logger.Info("mock DMS adapter registered successfully",
 			zap.String("adapter", adapterTypeMock),
 			zap.Int("packages", 5),
 		)
+	} else if dmsAdapterType == manifests.AdapterName {
+		// Initialize the manifests adapter for packages without a Helm chart
+		manifestsAdapter, err := manifests.NewAdapter(&manifests.Config{
+			Kubeconfig: cfg.Kubernetes.ConfigPath,
+			Namespace:  cfg.Kubernetes.Namespace,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create manifests adapter: %w", err)
+		}
+
+		manifestsAdapterConfig := map[string]interface{}{
+			"namespace": manifestsAdapter.Config.Namespace,
+			"timeout":   manifestsAdapter.Config.Timeout,
+		}
+
+		if err := dmsReg.Register(ctx, manifests.AdapterName, manifests.AdapterName, manifestsAdapter,
+			manifestsAdapterConfig, true); err != nil {
+			return nil, fmt.Errorf("failed to register manifests adapter: %w", err)
+		}
+
+		logger.Info("manifests DMS adapter registered successfully",
+			zap.String("adapter", manifests.AdapterName),
+		)
 	} else {
 		// Initialize Helm adapter
+		repoPassword, err := cfg.DMS.Helm.GetRepositoryPassword()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Helm repository password: %w", err)
+		}
 		helmConfig := &helm.Config{
-			Kubeconfig: cfg.Kubernetes.ConfigPath,
-			Namespace:  cfg.Kubernetes.Namespace,
-			Timeout:    30 * time.Second,
+			Kubeconfig:         cfg.Kubernetes.ConfigPath,
+			Namespace:          cfg.Kubernetes.Namespace,
+			RepositoryURL:      cfg.DMS.Helm.RepositoryURL,
+			RepositoryUsername: cfg.DMS.Helm.RepositoryUsername,
+			RepositoryPassword: repoPassword,
+			Timeout:            30 * time.Second,
 		}
 
 		helmAdapter, err := helm.NewAdapter(helmConfig)
 		if err != nil {
-			return fmt.Errorf("failed to create Helm adapter: %w", err)
+			return nil, fmt.Errorf("failed to create Helm adapter: %w", err)
 		}
 
 		helmAdapterConfig := map[string]interface{}{
@@ -697,7 +1501,7 @@ func initializeDMS(
 		}
 
 		if err := dmsReg.Register(ctx, "helm", "helm", helmAdapter, helmAdapterConfig, true); err != nil {
-			return fmt.Errorf("failed to register Helm adapter: %w", err)
+			return nil, fmt.Errorf("failed to register Helm adapter: %w", err)
 		}
 
 		logger.Info("Helm DMS adapter registered successfully",
@@ -705,6 +1509,28 @@ func initializeDMS(
 		)
 	}
 
+	// Register the additional adapters of the configuration
+	if cfg.Server.GatewayMode() != config.GatewayModeSimulator {
+		for i := range cfg.DMS.Adapters {
+			if err := registerConfiguredDMSAdapter(dmsReg, &cfg.DMS.Adapters[i], logger); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Restrict the namespaces each adapter may act on through the API
+	for name, policy := range cfg.DMS.NamespacePolicies {
+		nsPolicy := &dmsadapter.NamespacePolicy{Allow: policy.Allow, Deny: policy.Deny}
+		if err := dmsReg.SetNamespacePolicy(name, nsPolicy); err != nil {
+			return nil, err
+		}
+		logger.Info("DMS namespace policy configured",
+			zap.String("adapter", name),
+			zap.Strings("allow", policy.Allow),
+			zap.Strings("deny", policy.Deny),
+		)
+	}
+
 	// Setup DMS routes and handlers
 	srv.SetupDMS(dmsReg)
 
@@ -713,10 +1539,106 @@ func initializeDMS(
 		zap.Int("endpoints", 4), // deploymentLifecycle, nfDeployments, nfDeploymentDescriptors, subscriptions
 	)
 
+	return dmsReg, nil
+}
+
+// initializeProvisioning registers the configured provisioners and enables
+// the O2-IMS infrastructureProvisioning API. Provisioning requests are kept in
+// memory, so they are local to the replica and lost on restart.
+func initializeProvisioning(cfg *config.Config, srv *server.Server, logger *zap.Logger) error {
+	p := cfg.Provisioning
+	mgr := provisioning.NewManager(provisioning.NewMemoryStore(), provisioning.Config{
+		DefaultProvisioner: p.DefaultProvisioner,
+		ReconcileInterval:  p.ReconcileInterval,
+	}, logger.Named("provisioning"))
+
+	mgr.Register(provisioning.NewSimulator(p.Simulator.Delay))
+
+	if p.CAPI.Enabled {
+		capi, err := provisioning.NewCAPIProvisionerFromKubeconfig(p.CAPI.Kubeconfig, p.CAPI.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to create Cluster API provisioner: %w", err)
+		}
+		mgr.Register(capi)
+	}
+
+	srv.SetupProvisioning(mgr)
 	return nil
 }
 
-// initializeHealthChecker creates and configures the health checker.
+// initializeCircuitBreakers creates the circuit breakers of the adapter
+// backends when they are enabled, and reports their state in the details of
+// the readiness response. An open breaker doesn't make the gateway unready:
+// every replica shares the backend, so taking replicas out of service would
+// not help. Returns nil when the breakers are disabled, and in simulator
+// mode, whose mock adapters have no backend.
+func initializeCircuitBreakers(
+	cfg *config.Config,
+	healthChecker *observability.HealthChecker,
+	logger *zap.Logger,
+) *circuitbreaker.Set {
+	if !cfg.CircuitBreaker.Enabled || cfg.Server.GatewayMode() == config.GatewayModeSimulator {
+		return nil
+	}
+
+	breakers := circuitbreaker.NewSet(circuitbreaker.Settings{
+		FailureThreshold: uint32(cfg.CircuitBreaker.FailureThreshold),
+		OpenTimeout:      cfg.CircuitBreaker.OpenTimeout,
+		HalfOpenRequests: uint32(cfg.CircuitBreaker.HalfOpenRequests),
+	}, logger.Named("circuit-breaker"))
+	healthChecker.RegisterReadinessDetail("circuitBreakers", func() interface{} {
+		return breakers.Statuses()
+	})
+
+	logger.Info("adapter circuit breakers enabled",
+		zap.Int("failure_threshold", cfg.CircuitBreaker.FailureThreshold),
+		zap.Duration("open_timeout", cfg.CircuitBreaker.OpenTimeout),
+		zap.Int("half_open_requests", cfg.CircuitBreaker.HalfOpenRequests),
+	)
+	return breakers
+}
+
+// initializeAdapterCache wraps the IMS adapter with a cache of its list
+// results when the cache is enabled, and returns the adapter the API server
+// should use.
+func initializeAdapterCache(
+	cfg *config.Config,
+	imsAdapter adapter.Adapter,
+	store *storage.RedisStore,
+	logger *zap.Logger,
+) (adapter.Adapter, error) {
+	if !cfg.Cache.Enabled || imsAdapter == nil {
+		return imsAdapter, nil
+	}
+
+	var cacheStore adaptercache.Store
+	switch cfg.Cache.Backend {
+	case config.CacheBackendRedis:
+		cacheStore = adaptercache.NewRedisStore(store.Client)
+	default:
+		memoryStore, err := adaptercache.NewMemoryStore(cfg.Cache.MaxEntries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create adapter cache: %w", err)
+		}
+		cacheStore = memoryStore
+	}
+
+	logger.Info("adapter list cache enabled",
+		zap.String("backend", cfg.Cache.Backend),
+		zap.Duration("resource_pools_ttl", cfg.Cache.TTL.ResourcePools),
+		zap.Duration("resources_ttl", cfg.Cache.TTL.Resources),
+		zap.Duration("resource_types_ttl", cfg.Cache.TTL.ResourceTypes),
+	)
+	return adaptercache.New(imsAdapter, cacheStore, adaptercache.TTLs{
+		ResourcePools: cfg.Cache.TTL.ResourcePools,
+		Resources:     cfg.Cache.TTL.Resources,
+		ResourceTypes: cfg.Cache.TTL.ResourceTypes,
+	}, logger.Named("adapter-cache")), nil
+}
+
+// initializeHealthChecker creates and configures the health checker. The IMS
+// adapter check is only registered when the gateway mode uses an IMS adapter;
+// the DMS check is registered when the DMS subsystem is set up.
 func initializeHealthChecker(
 	store *storage.RedisStore,
 	imsAdapter adapter.Adapter,
@@ -727,48 +1649,61 @@ func initializeHealthChecker(
 	// Set health check timeout
 	healthChecker.SetTimeout(5 * time.Second)
 
-	// Register Redis health check
+	// Register Redis health and readiness checks
 	healthChecker.RegisterHealthCheck("redis", observability.RedisHealthCheck(func(ctx context.Context) error {
 		return store.Ping(ctx)
 	}))
-
-	// Register IMS adapter health check
-	healthChecker.RegisterHealthCheck(
-		"ims-adapter",
-		observability.KubernetesHealthCheck(func(ctx context.Context) error {
-			return imsAdapter.Health(ctx)
-		}),
-	)
-
-	// Register the same checks for readiness
-	healthChecker.RegisterReadinessCheck("redis",
+	// Without Redis the inventory is still served from the adapter, so a
+	// Redis outage degrades readiness instead of failing it.
+	healthChecker.RegisterReadinessCheckWithCriticality("redis", observability.CriticalityInformational,
 		observability.RedisHealthCheck(func(ctx context.Context) error {
 			return store.Ping(ctx)
 		}))
-
-	healthChecker.RegisterReadinessCheck("ims-adapter",
-		observability.KubernetesHealthCheck(func(ctx context.Context) error {
-			return imsAdapter.Health(ctx)
-		}))
+	checks := 1
+
+	// Register IMS adapter health and readiness checks
+	if imsAdapter != nil {
+		healthChecker.RegisterHealthCheck(
+			"ims-adapter",
+			observability.KubernetesHealthCheck(func(ctx context.Context) error {
+				return imsAdapter.Health(ctx)
+			}),
+		)
+		healthChecker.RegisterReadinessCheck("ims-adapter",
+			observability.KubernetesHealthCheck(func(ctx context.Context) error {
+				return imsAdapter.Health(ctx)
+			}))
+		checks++
+	}
 
 	logger.Info("health checks registered",
-		zap.Int("health_checks", 2),
-		zap.Int("readiness_checks", 2),
+		zap.Int("health_checks", checks),
+		zap.Int("readiness_checks", checks),
 	)
 
 	return healthChecker
 }
 
-// gracefulShutdown performs graceful shutdown of the application.
-func gracefulShutdown(ctx context.Context, srv *server.Server, cfg *config.Config, logger *zap.Logger) error {
+// gracefulShutdown performs graceful shutdown of the application: it stops
+// the HTTP server, then drains the background components registered with
+// drainer, all within the shutdown timeout.
+func gracefulShutdown(
+	ctx context.Context,
+	srv *server.Server,
+	drainer *lifecycle.Manager,
+	cfg *config.Config,
+	logger *zap.Logger,
+) error {
 	logger.Info("initiating graceful shutdown",
 		zap.Duration("timeout", cfg.Server.ShutdownTimeout),
 	)
 
-	// Create shutdown context with timeout derived from parent context
+	// Create shutdown context with timeout. It is detached from ctx, which is
+	// canceled when the shutdown begins. Streams get their own drain window
+	// on top of the regular shutdown timeout.
 	shutdownCtx, cancel := context.WithTimeout(
-		ctx,
-		cfg.Server.ShutdownTimeout,
+		context.WithoutCancel(ctx),
+		cfg.Server.ShutdownTimeout+cfg.Server.StreamDrainTimeout,
 	)
 	defer cancel()
 
@@ -777,12 +1712,19 @@ func gracefulShutdown(ctx context.Context, srv *server.Server, cfg *config.Confi
 
 	// Perform shutdown in a goroutine with the shutdown context
 	go func() {
-		// Shutdown HTTP server using the shutdown context
+		// Shutdown HTTP server using the shutdown context, so that no new
+		// work is accepted while the background components drain
 		if err := srv.ShutdownWithContext(shutdownCtx); err != nil {
 			shutdownComplete <- fmt.Errorf("server shutdown failed: %w", err)
 			return
 		}
 
+		// Finish or hand over in-flight deliveries and jobs
+		if err := drainer.Drain(shutdownCtx); err != nil {
+			shutdownComplete <- fmt.Errorf("draining components failed: %w", err)
+			return
+		}
+
 		shutdownComplete <- nil
 	}()
 
@@ -851,6 +1793,8 @@ func loadOpenAPISpec(logger *zap.Logger) ([]byte, error) {
 // Errors are returned without exposing sensitive credential details in messages.
 // This function is only called when cfg.MultiTenancy.Enabled is true.
 func InitializeAuth(cfg *config.Config, logger *zap.Logger) (*auth.RedisStore, *auth.Middleware, error) {
+	logger = observability.ModuleLogger(logger, observability.ModuleAuth)
+
 	// Get Redis password (reuse the same logic as main storage).
 	password, redisModeSentinelPassword, err := getRedisPasswords(cfg, logger)
 	if err != nil {
@@ -866,7 +1810,7 @@ func InitializeAuth(cfg *config.Config, logger *zap.Logger) (*auth.RedisStore, *
 		DialTimeout:      cfg.Redis.DialTimeout,
 		ReadTimeout:      cfg.Redis.ReadTimeout,
 		WriteTimeout:     cfg.Redis.WriteTimeout,
-		PoolSize:         cfg.Redis.PoolSize,
+		PoolSize:         redisPoolSize(cfg),
 	}
 
 	// Configure Redis mode.
@@ -897,6 +1841,12 @@ func InitializeAuth(cfg *config.Config, logger *zap.Logger) (*auth.RedisStore, *
 
 	logger.Info("auth Redis store initialized")
 
+	// Serve tenant reads and authentication lookups from memory during short
+	// Redis outages.
+	if fallback := cfg.Redis.ReadFallback; fallback.Enabled {
+		authStore.EnableReadFallback(readfallback.New("auth", fallback.MaxStaleness, fallback.MaxEntries))
+	}
+
 	// Initialize default roles if configured.
 	if cfg.MultiTenancy.InitializeDefaultRoles {
 		if err := authStore.InitializeDefaultRoles(ctx); err != nil {
@@ -907,17 +1857,19 @@ func InitializeAuth(cfg *config.Config, logger *zap.Logger) (*auth.RedisStore, *
 
 	// Create middleware config.
 	mwConfig := &auth.MiddlewareConfig{
-		Enabled:     true,
-		SkipPaths:   cfg.MultiTenancy.SkipAuthPaths,
-		RequireMTLS: cfg.MultiTenancy.RequireMTLS,
+		Enabled:          true,
+		Policies:         server.AuthPoliciesFromConfig(&cfg.MultiTenancy),
+		RequireMTLS:      cfg.MultiTenancy.RequireMTLS,
+		IdentityMappings: server.IdentityMappingsFromConfig(&cfg.MultiTenancy),
 	}
 
 	// Create auth middleware.
 	authMw := auth.NewMiddleware(authStore, mwConfig, logger)
 
 	logger.Info("auth middleware created",
-		zap.Int("skip_paths", len(mwConfig.SkipPaths)),
+		zap.Int("auth_policies", len(mwConfig.Policies)),
 		zap.Bool("require_mtls", mwConfig.RequireMTLS),
+		zap.Int("identity_mappings", len(mwConfig.IdentityMappings)),
 	)
 
 	return authStore, authMw, nil
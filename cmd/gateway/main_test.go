@@ -1,6 +1,10 @@
 package main_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -8,9 +12,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"k8s.io/client-go/kubernetes/fake"
 
 	main "github.com/piwi3910/netweave/cmd/gateway"
+	"github.com/piwi3910/netweave/internal/adapters/kubernetes"
+	"github.com/piwi3910/netweave/internal/adapters/mock"
 	"github.com/piwi3910/netweave/internal/config"
+	dmsmock "github.com/piwi3910/netweave/internal/dms/adapters/mock"
+	dmsregistry "github.com/piwi3910/netweave/internal/dms/registry"
+	"github.com/piwi3910/netweave/internal/storage"
 )
 
 func TestInitializeAuth_Standalone(t *testing.T) {
@@ -238,3 +249,245 @@ func TestApplicationComponents_Close(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestInitializeIMSAdapter_Modes(t *testing.T) {
+	t.Run("dms-only has no IMS adapter", func(t *testing.T) {
+		cfg := &config.Config{Server: config.ServerConfig{Mode: config.GatewayModeDMSOnly}}
+
+		imsAdapter, err := main.InitializeIMSAdapter(context.Background(), cfg, zap.NewNop())
+		require.NoError(t, err)
+		assert.Nil(t, imsAdapter)
+	})
+
+	t.Run("simulator uses the mock adapter without Kubernetes", func(t *testing.T) {
+		cfg := &config.Config{
+			Server:     config.ServerConfig{Mode: config.GatewayModeSimulator},
+			Kubernetes: config.KubernetesConfig{ConfigPath: "/nonexistent/kubeconfig"},
+		}
+
+		imsAdapter, err := main.InitializeIMSAdapter(context.Background(), cfg, zap.NewNop())
+		require.NoError(t, err)
+		require.NotNil(t, imsAdapter)
+		defer func() { _ = imsAdapter.Close() }()
+		assert.Equal(t, "mock", imsAdapter.Name())
+	})
+
+	t.Run("ims+dms requires Kubernetes", func(t *testing.T) {
+		t.Setenv("ADAPTER_TYPE", "")
+		cfg := &config.Config{Kubernetes: config.KubernetesConfig{ConfigPath: "/nonexistent/kubeconfig"}}
+
+		_, err := main.InitializeIMSAdapter(context.Background(), cfg, zap.NewNop())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Kubernetes adapter")
+	})
+}
+
+func TestInitializeNotifications(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = store.Close() })
+
+	k8sAdapter := kubernetes.NewForTesting(fake.NewClientset(), zap.NewNop())
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := &config.Config{}
+
+		notifications, err := main.InitializeNotifications(cfg, k8sAdapter, store, nil, nil, zap.NewNop())
+		require.NoError(t, err)
+		assert.Nil(t, notifications)
+	})
+
+	t.Run("requires the Kubernetes adapter", func(t *testing.T) {
+		cfg := &config.Config{Notifications: config.NotificationsConfig{Enabled: true}}
+
+		notifications, err := main.InitializeNotifications(cfg, mock.NewAdapter(false), store, nil, nil, zap.NewNop())
+		require.NoError(t, err)
+		assert.Nil(t, notifications)
+
+		notifications, err = main.InitializeNotifications(cfg, nil, store, nil, nil, zap.NewNop())
+		require.NoError(t, err)
+		assert.Nil(t, notifications)
+	})
+
+	t.Run("enabled with the Kubernetes adapter", func(t *testing.T) {
+		cfg := &config.Config{Notifications: config.NotificationsConfig{
+			Enabled:    true,
+			Workers:    2,
+			MaxRetries: 5,
+			HMACSecret: "secret",
+		}}
+
+		notifications, err := main.InitializeNotifications(cfg, k8sAdapter, store, nil, nil, zap.NewNop())
+		require.NoError(t, err)
+		require.NotNil(t, notifications)
+		assert.NotNil(t, notifications.Controller)
+		assert.Equal(t, 2, notifications.Worker.WorkerCount)
+		assert.Equal(t, 5, notifications.Worker.MaxRetries)
+		assert.Equal(t, "secret", notifications.Worker.HMACSecret)
+	})
+}
+
+func TestInitializeWebhookCA(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = store.Close() })
+
+	t.Run("disabled", func(t *testing.T) {
+		ca, err := main.InitializeWebhookCA(context.Background(), &config.Config{}, store, zap.NewNop())
+		require.NoError(t, err)
+		assert.Nil(t, ca)
+	})
+
+	t.Run("generated CA is shared through Redis", func(t *testing.T) {
+		cfg := &config.Config{Notifications: config.NotificationsConfig{
+			CA: config.WebhookCAConfig{Enabled: true},
+		}}
+
+		first, err := main.InitializeWebhookCA(context.Background(), cfg, store, zap.NewNop())
+		require.NoError(t, err)
+		require.NotNil(t, first)
+		second, err := main.InitializeWebhookCA(context.Background(), cfg, store, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, first.CACertificatePEM(), second.CACertificatePEM())
+	})
+
+	t.Run("missing CA files", func(t *testing.T) {
+		cfg := &config.Config{Notifications: config.NotificationsConfig{
+			CA: config.WebhookCAConfig{Enabled: true, CertFile: "/nonexistent/ca.crt", KeyFile: "/nonexistent/ca.key"},
+		}}
+
+		_, err := main.InitializeWebhookCA(context.Background(), cfg, store, zap.NewNop())
+		require.Error(t, err)
+	})
+}
+
+func TestRunStartupChecks(t *testing.T) {
+	probes := []main.StartupProbe{
+		{Name: "ims-adapter/kubernetes", Probe: func(context.Context) error { return nil }},
+		{Name: "dms-adapter/helm", Probe: func(context.Context) error {
+			return errors.New(`helm repository https://charts.example: failed to fetch index : 401 Unauthorized`)
+		}},
+		{Name: "dms-adapter/argocd", Probe: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	}
+	checks := config.StartupChecksConfig{Timeout: 50 * time.Millisecond}
+
+	t.Run("strict refuses to start", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		checks.Mode = config.StartupChecksStrict
+
+		err := main.RunStartupChecks(checks, probes, zap.New(core))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dms-adapter/helm, dms-adapter/argocd")
+
+		failures := logs.FilterMessage("startup check failed").All()
+		require.Len(t, failures, 2)
+		assert.Equal(t, "dms-adapter/helm", failures[0].ContextMap()["backend"])
+		assert.Contains(t, failures[0].ContextMap()["hint"], "rejected the credentials")
+		assert.Contains(t, failures[1].ContextMap()["hint"], "did not answer in time")
+		assert.Equal(t, 1, logs.FilterMessage("startup check passed").Len())
+	})
+
+	t.Run("lenient starts anyway", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		checks.Mode = config.StartupChecksLenient
+
+		require.NoError(t, main.RunStartupChecks(checks, probes, zap.New(core)))
+		assert.Equal(t, 2, logs.FilterMessage("startup check failed, starting anyway").Len())
+	})
+
+	t.Run("disabled skips probes", func(t *testing.T) {
+		checks.Mode = config.StartupChecksDisabled
+		failing := []main.StartupProbe{{Name: "dms-adapter/helm", Probe: func(context.Context) error {
+			t.Fatal("probe ran")
+			return nil
+		}}}
+
+		require.NoError(t, main.RunStartupChecks(checks, failing, zap.NewNop()))
+	})
+}
+
+func TestStartupProbes(t *testing.T) {
+	ctx := context.Background()
+	reg := dmsregistry.NewRegistry(zap.NewNop(), nil)
+	require.NoError(t, reg.Register(ctx, "mock", "mock", dmsmock.NewAdapter(false), nil, true))
+
+	probes := main.StartupProbes(mock.NewAdapter(false), reg)
+	require.Len(t, probes, 2)
+	assert.Equal(t, "ims-adapter/mock", probes[0].Name)
+	assert.Equal(t, "dms-adapter/mock", probes[1].Name)
+	for _, probe := range probes {
+		require.NoError(t, probe.Probe(ctx))
+	}
+
+	assert.Empty(t, main.StartupProbes(nil, nil))
+}
+
+func TestStartupGraph(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, phase := range main.StartupGraph() {
+		assert.False(t, seen[phase.Name], "duplicate phase %s", phase.Name)
+		for _, dep := range phase.DependsOn {
+			assert.True(t, seen[dep], "phase %s depends on %s, which runs later", phase.Name, dep)
+		}
+		seen[phase.Name] = true
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, main.WriteStartupGraph(&buf))
+	var graph struct {
+		Phases []main.StartupPhase `json:"phases"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &graph))
+	assert.Equal(t, main.StartupGraph(), graph.Phases)
+}
+
+func TestStartupTracker(t *testing.T) {
+	t.Run("records phase timings", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		phases := main.NewStartupTracker(0)
+		phases.SetLogger(zap.New(core))
+		phases.Start()
+
+		require.NoError(t, phases.Run(main.PhaseRedis, func() error {
+			phase, _ := phases.Current()
+			assert.Equal(t, main.PhaseRedis, phase)
+			return nil
+		}))
+		failure := errors.New("no adapter")
+		assert.ErrorIs(t, phases.Run(main.PhaseIMSAdapter, func() error { return failure }), failure)
+		phases.Done()
+
+		phase, _ := phases.Current()
+		assert.Empty(t, phase)
+		timings := phases.Timings()
+		require.Len(t, timings, 2)
+		assert.Equal(t, main.PhaseRedis, timings[0].Name)
+		assert.Equal(t, main.PhaseIMSAdapter, timings[1].Name)
+
+		completed := logs.FilterMessage("startup phase completed").All()
+		require.Len(t, completed, 2)
+		assert.Equal(t, true, completed[1].ContextMap()["failed"])
+		assert.Equal(t, 1, logs.FilterMessage("startup completed").Len())
+	})
+
+	t.Run("timeout names the stuck phase", func(t *testing.T) {
+		phases := main.NewStartupTracker(20 * time.Millisecond)
+		stuck := make(chan string, 1)
+		release := make(chan struct{})
+		phases.OnTimeout = func(phase string, _ time.Duration) {
+			stuck <- phase
+			close(release)
+		}
+		phases.Start()
+		defer phases.Done()
+
+		require.NoError(t, phases.Run(main.PhaseDMS, func() error {
+			<-release
+			return nil
+		}))
+		assert.Equal(t, main.PhaseDMS, <-stuck)
+	})
+}
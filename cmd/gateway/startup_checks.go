@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	dmsregistry "github.com/piwi3910/netweave/internal/dms/registry"
+)
+
+// StartupProbe checks that one backend is reachable with its configuration.
+type StartupProbe struct {
+	// Name identifies the backend in logs, e.g. "dms-adapter/helm".
+	Name string
+
+	// Probe returns an error if the backend cannot be used.
+	Probe func(ctx context.Context) error
+}
+
+// StartupProbes returns the probes of the IMS adapter, if any, and of every
+// registered DMS adapter. DMS adapters implementing ConfigProber are probed
+// with it, the others with their health check.
+func StartupProbes(imsAdapter adapter.Adapter, dmsReg *dmsregistry.Registry) []StartupProbe {
+	var probes []StartupProbe
+	if imsAdapter != nil {
+		probes = append(probes, StartupProbe{Name: "ims-adapter/" + imsAdapter.Name(), Probe: imsAdapter.Health})
+	}
+	if dmsReg == nil {
+		return probes
+	}
+	for _, meta := range dmsReg.ListMetadata() {
+		plugin := dmsReg.Get(meta.Name)
+		if plugin == nil {
+			continue
+		}
+		probe := plugin.Health
+		if prober, ok := plugin.(dmsadapter.ConfigProber); ok {
+			probe = prober.ProbeConfig
+		}
+		probes = append(probes, StartupProbe{Name: "dms-adapter/" + meta.Name, Probe: probe})
+	}
+	return probes
+}
+
+// RunStartupChecks runs the probes concurrently, each bounded by the
+// configured timeout, and logs a diagnostic for every failure. In strict mode
+// a failed probe is returned as an error so the gateway refuses to start.
+func RunStartupChecks(cfg config.StartupChecksConfig, probes []StartupProbe, logger *zap.Logger) error {
+	if cfg.Mode == "" || cfg.Mode == config.StartupChecksDisabled || len(probes) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = runStartupProbe(p, cfg.Timeout)
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, p := range probes {
+		if errs[i] == nil {
+			logger.Info("startup check passed", zap.String("backend", p.Name))
+			continue
+		}
+		failed = append(failed, p.Name)
+		fields := []zap.Field{
+			zap.String("backend", p.Name),
+			zap.String("mode", cfg.Mode),
+			zap.Error(errs[i]),
+		}
+		if hint := startupCheckHint(errs[i]); hint != "" {
+			fields = append(fields, zap.String("hint", hint))
+		}
+		if cfg.Mode == config.StartupChecksStrict {
+			logger.Error("startup check failed", fields...)
+		} else {
+			logger.Warn("startup check failed, starting anyway", fields...)
+		}
+	}
+
+	if len(failed) > 0 && cfg.Mode == config.StartupChecksStrict {
+		return fmt.Errorf("startup checks failed for %s (set startup_checks.mode to lenient to start anyway)",
+			strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// runStartupProbe runs a probe bounded by timeout. Probes that ignore their
+// context are abandoned when the timeout expires.
+func runStartupProbe(p StartupProbe, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Probe(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("no response within %s: %w", timeout, ctx.Err())
+	}
+}
+
+// startupCheckHint suggests the setting most likely at fault for a failed
+// probe, or returns "" if the error is not recognized.
+func startupCheckHint(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || strings.Contains(msg, "timeout"):
+		return "the backend did not answer in time; check the endpoint address, network policies, " +
+			"and startup_checks.timeout"
+	case strings.Contains(msg, "401") || strings.Contains(msg, "unauthorized"):
+		return "the backend rejected the credentials; check the configured username, password, or token"
+	case strings.Contains(msg, "403") || strings.Contains(msg, "forbidden"):
+		return "the credentials lack permissions; check the RBAC rules or repository permissions " +
+			"of the gateway identity"
+	case strings.Contains(msg, "x509") || strings.Contains(msg, "certificate"):
+		return "TLS verification failed; check the CA bundle and the backend server certificate"
+	case strings.Contains(msg, "no such host"):
+		return "the endpoint host name does not resolve; check the configured URL"
+	case strings.Contains(msg, "connection refused"):
+		return "nothing is listening on the endpoint; check the configured URL and port"
+	case strings.Contains(msg, "404") || strings.Contains(msg, "not found"):
+		return "the endpoint path does not exist; check the configured URL"
+	}
+	return ""
+}
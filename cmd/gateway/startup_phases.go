@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// Startup phases, in the order the gateway runs them.
+const (
+	PhaseConfig        = "config"
+	PhaseLogger        = "logger"
+	PhaseRedis         = "redis"
+	PhaseIMSAdapter    = "ims_adapter"
+	PhaseHealthChecker = "health_checker"
+	PhaseAuth          = "auth"
+	PhaseAdapterCache  = "adapter_cache"
+	PhaseServer        = "server"
+	PhaseOpenAPI       = "openapi"
+	PhaseDMSStore      = "dms_store"
+	PhaseDMS           = "dms"
+	PhaseProvisioning  = "provisioning"
+	PhaseStartupChecks = "startup_checks"
+	PhaseNotifications = "notifications"
+)
+
+// StartupPhase is a node of the startup dependency graph.
+type StartupPhase struct {
+	// Name identifies the phase in logs and metrics.
+	Name string `json:"name"`
+
+	// DependsOn lists the phases whose results this phase uses.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Optional phases are skipped when their feature is disabled.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// StartupGraph returns the startup phases in execution order with their
+// dependencies. Every phase only depends on phases listed before it.
+func StartupGraph() []StartupPhase {
+	return []StartupPhase{
+		{Name: PhaseConfig},
+		{Name: PhaseLogger, DependsOn: []string{PhaseConfig}},
+		{Name: PhaseRedis, DependsOn: []string{PhaseLogger}},
+		{Name: PhaseIMSAdapter, DependsOn: []string{PhaseLogger}},
+		{Name: PhaseHealthChecker, DependsOn: []string{PhaseRedis, PhaseIMSAdapter}},
+		{Name: PhaseAuth, DependsOn: []string{PhaseLogger}, Optional: true},
+		{Name: PhaseAdapterCache, DependsOn: []string{PhaseRedis, PhaseIMSAdapter}},
+		{Name: PhaseServer, DependsOn: []string{PhaseHealthChecker, PhaseAuth, PhaseAdapterCache}},
+		{Name: PhaseOpenAPI, DependsOn: []string{PhaseServer}},
+		{Name: PhaseDMSStore, DependsOn: []string{PhaseRedis, PhaseServer}},
+		{Name: PhaseDMS, DependsOn: []string{PhaseDMSStore, PhaseIMSAdapter}},
+		{Name: PhaseProvisioning, DependsOn: []string{PhaseServer}, Optional: true},
+		{Name: PhaseStartupChecks, DependsOn: []string{PhaseIMSAdapter, PhaseDMS}},
+		{Name: PhaseNotifications, DependsOn: []string{PhaseRedis, PhaseIMSAdapter}},
+	}
+}
+
+// WriteStartupGraph writes the startup dependency graph as JSON.
+func WriteStartupGraph(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]interface{}{"phases": StartupGraph()}); err != nil {
+		return fmt.Errorf("failed to encode startup graph: %w", err)
+	}
+	return nil
+}
+
+var startupPhaseDuration = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "o2ims",
+		Name:      "startup_phase_duration_seconds",
+		Help:      "Duration of each gateway startup phase in seconds",
+	},
+	[]string{"phase"},
+)
+
+// PhaseTiming is the recorded duration of a completed startup phase.
+type PhaseTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// StartupTracker times the startup phases and aborts startup when they take
+// longer than the startup timeout. The watchdog names the phase that was
+// running when the timeout expired, so a hanging dependency is identified.
+type StartupTracker struct {
+	timeout time.Duration
+
+	// OnTimeout is called by the watchdog with the stuck phase and how long
+	// it has been running. It defaults to logging and exiting the process.
+	OnTimeout func(phase string, running time.Duration)
+
+	mu           sync.Mutex
+	logger       *zap.Logger
+	started      time.Time
+	current      string
+	currentStart time.Time
+	completed    []PhaseTiming
+	watchdog     *time.Timer
+}
+
+// NewStartupTracker creates a tracker. A zero timeout disables the watchdog.
+func NewStartupTracker(timeout time.Duration) *StartupTracker {
+	t := &StartupTracker{timeout: timeout, started: time.Now()}
+	t.OnTimeout = t.abort
+	return t
+}
+
+// SetLogger sets the logger used to report phase timings. Phases run before
+// the logger exists are only exported as metrics.
+func (t *StartupTracker) SetLogger(logger *zap.Logger) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.logger = logger
+}
+
+// Start arms the watchdog.
+func (t *StartupTracker) Start() {
+	if t.timeout <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.watchdog = time.AfterFunc(t.timeout, func() {
+		phase, running := t.Current()
+		t.OnTimeout(phase, running)
+	})
+}
+
+// Run runs fn as the named phase and records its duration.
+func (t *StartupTracker) Run(phase string, fn func() error) error {
+	t.mu.Lock()
+	t.current = phase
+	t.currentStart = time.Now()
+	t.mu.Unlock()
+
+	err := fn()
+
+	t.mu.Lock()
+	duration := time.Since(t.currentStart)
+	t.current = ""
+	t.completed = append(t.completed, PhaseTiming{Name: phase, Duration: duration})
+	logger := t.logger
+	t.mu.Unlock()
+
+	startupPhaseDuration.WithLabelValues(phase).Set(duration.Seconds())
+	if logger != nil {
+		logger.Info("startup phase completed",
+			zap.String("phase", phase),
+			zap.Duration("duration", duration),
+			zap.Bool("failed", err != nil),
+		)
+	}
+	return err
+}
+
+// Current returns the running phase and how long it has been running, or
+// an empty name between phases.
+func (t *StartupTracker) Current() (string, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == "" {
+		return "", 0
+	}
+	return t.current, time.Since(t.currentStart)
+}
+
+// Timings returns the durations of the completed phases in execution order.
+func (t *StartupTracker) Timings() []PhaseTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]PhaseTiming(nil), t.completed...)
+}
+
+// Done disarms the watchdog and logs the total startup time.
+func (t *StartupTracker) Done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.watchdog != nil {
+		t.watchdog.Stop()
+	}
+	if t.logger != nil {
+		t.logger.Info("startup completed", zap.Duration("duration", time.Since(t.started)))
+	}
+}
+
+// abort reports the stuck phase and exits the process.
+func (t *StartupTracker) abort(phase string, running time.Duration) {
+	if phase == "" {
+		phase = "between phases"
+	}
+	t.mu.Lock()
+	logger := t.logger
+	t.mu.Unlock()
+	if logger != nil {
+		logger.Error("startup timed out",
+			zap.String("phase", phase),
+			zap.Duration("phase_running", running),
+			zap.Duration("timeout", t.timeout),
+		)
+		_ = logger.Sync()
+	}
+	fmt.Fprintf(os.Stderr, "Fatal error: startup did not complete within %s; stuck in phase %q for %s\n",
+		t.timeout, phase, running.Round(time.Millisecond))
+	os.Exit(1)
+}
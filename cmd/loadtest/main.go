@@ -0,0 +1,232 @@
+// Command loadtest drives a synthetic request mix against a netweave gateway
+// and reports per-route latency percentiles, error rates and SLO results.
+//
+// Usage:
+//
+//	loadtest [flags]
+//
+// Flags:
+//
+//	-url string
+//	    Gateway base URL (default "http://localhost:8080")
+//	-scenario string
+//	    Scenario JSON file; the built-in read/write/batch mix when empty
+//	-duration duration
+//	    Run duration, overriding the scenario
+//	-concurrency int
+//	    Parallel workers, overriding the scenario
+//	-rate float
+//	    Requests per second cap, overriding the scenario (0 = unlimited)
+//	-H value
+//	    Extra request header "Name: value" (repeatable)
+//	-output string
+//	    Output format: text, json, html (default "text")
+//	-out string
+//	    Output file (default stdout)
+//	-baseline string
+//	    Earlier JSON report to detect latency regressions against
+//	-regression-threshold float
+//	    Tolerated relative p95/p99 increase over the baseline (default 0.2)
+//
+// The command exits with status 1 when a route misses its SLO or regressed.
+//
+// Examples:
+//
+//	# Run the built-in mix for 30 seconds
+//	loadtest -url http://localhost:8080
+//
+//	# Record a release baseline as JSON
+//	loadtest -scenario scenario.json -output json -out baseline.json
+//
+//	# Compare a new build against the baseline and write an HTML report
+//	loadtest -scenario scenario.json -baseline baseline.json -output html -out report.html
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/tools/loadtest"
+)
+
+// headerFlags collects repeated -H flags.
+type headerFlags map[string]string
+
+// String implements flag.Value.
+func (h headerFlags) String() string {
+	pairs := make([]string, 0, len(h))
+	for name, value := range h {
+		pairs = append(pairs, name+": "+value)
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// Set implements flag.Value.
+func (h headerFlags) Set(header string) error {
+	name, value, ok := strings.Cut(header, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("header must be \"Name: value\", got %q", header)
+	}
+	h[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	return nil
+}
+
+var (
+	baseURL      = flag.String("url", "http://localhost:8080", "Gateway base URL")
+	scenarioPath = flag.String("scenario", "", "Scenario JSON file (default: built-in mix)")
+	duration     = flag.Duration("duration", 0, "Run duration, overriding the scenario")
+	concurrency  = flag.Int("concurrency", 0, "Parallel workers, overriding the scenario")
+	rate         = flag.Float64("rate", 0, "Requests per second cap, overriding the scenario (0 = unlimited)")
+	outputFormat = flag.String("output", "text", "Output format: text, json, html")
+	outputPath   = flag.String("out", "", "Output file (default stdout)")
+	baselinePath = flag.String("baseline", "", "Earlier JSON report to detect latency regressions against")
+	threshold    = flag.Float64("regression-threshold", 0.2, "Tolerated relative p95/p99 increase over the baseline")
+	verbose      = flag.Bool("v", false, "Verbose output")
+	headers      = headerFlags{}
+)
+
+func main() {
+	flag.Var(headers, "H", "Extra request header \"Name: value\" (repeatable)")
+	flag.Parse()
+
+	logger := initializeLogger()
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	passed, err := run(ctx, logger.Logger)
+	if err != nil {
+		logger.Error("load test failed", zap.Error(err))
+		// Exit after defer runs
+		defer os.Exit(2)
+		return
+	}
+	if !passed {
+		// Exit after defer runs
+		defer os.Exit(1)
+	}
+}
+
+// run executes the load test and writes the report. It reports whether every
+// SLO was met without regressions.
+func run(ctx context.Context, logger *zap.Logger) (bool, error) {
+	scenario, err := buildScenario()
+	if err != nil {
+		return false, err
+	}
+
+	runner, err := loadtest.NewRunner(scenario, logger)
+	if err != nil {
+		return false, err
+	}
+	report, err := runner.Run(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if *baselinePath != "" {
+		baseline, err := loadtest.LoadReport(*baselinePath)
+		if err != nil {
+			return false, err
+		}
+		report.Compare(baseline, *threshold)
+	}
+
+	if err := writeReport(report); err != nil {
+		return false, err
+	}
+	return report.Passed, nil
+}
+
+// buildScenario loads the scenario and applies the flag overrides.
+func buildScenario() (*loadtest.Scenario, error) {
+	scenario := loadtest.DefaultScenario(*baseURL)
+	if *scenarioPath != "" {
+		loaded, err := loadtest.LoadScenario(*scenarioPath)
+		if err != nil {
+			return nil, err
+		}
+		scenario = loaded
+	}
+
+	// Explicit flags win over the scenario file.
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "url":
+			scenario.BaseURL = *baseURL
+		case "duration":
+			scenario.Duration = loadtest.Duration(*duration)
+		case "concurrency":
+			scenario.Concurrency = *concurrency
+		case "rate":
+			scenario.Rate = *rate
+		}
+	})
+	if scenario.BaseURL == "" {
+		scenario.BaseURL = *baseURL
+	}
+	if len(headers) > 0 && scenario.Headers == nil {
+		scenario.Headers = make(map[string]string, len(headers))
+	}
+	for name, value := range headers {
+		scenario.Headers[name] = value
+	}
+	return scenario, nil
+}
+
+// writeReport writes the report in the requested format.
+func writeReport(report *loadtest.Report) (err error) {
+	var w io.Writer = os.Stdout
+	if *outputPath != "" {
+		// G304: the output path comes from a command line flag of this CLI tool
+		file, createErr := os.Create(filepath.Clean(*outputPath))
+		if createErr != nil {
+			return fmt.Errorf("failed to create report file: %w", createErr)
+		}
+		defer func() {
+			err = errors.Join(err, file.Close())
+		}()
+		w = file
+	}
+
+	switch *outputFormat {
+	case "json":
+		return report.WriteJSON(w)
+	case "html":
+		return report.WriteHTML(w)
+	case "text":
+		return report.WriteText(w)
+	default:
+		return fmt.Errorf("invalid output format: %s", *outputFormat)
+	}
+}
+
+// initializeLogger initializes and configures the logger based on verbosity setting.
+func initializeLogger() *observability.Logger {
+	obsLogger, err := observability.InitLogger("development")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Adjust log level based on verbosity
+	if !*verbose {
+		obsLogger.Logger = obsLogger.WithOptions(zap.IncreaseLevel(zap.InfoLevel))
+	}
+
+	return obsLogger
+}
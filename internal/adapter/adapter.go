@@ -6,8 +6,13 @@ package adapter
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/piwi3910/netweave/internal/cost"
+	"github.com/piwi3910/netweave/internal/digest"
 	"github.com/piwi3910/netweave/internal/models"
+	"github.com/piwi3910/netweave/internal/transform"
 )
 
 // Capability represents a feature that an adapter supports.
@@ -76,8 +81,44 @@ var (
 
 	// ErrNotImplemented indicates the operation is not yet implemented by the adapter.
 	ErrNotImplemented = errors.New("operation not implemented")
+
+	// ErrCapacityExceeded indicates a create would exceed a configured object
+	// count ceiling. Adapters return it wrapped in a *CapacityError.
+	ErrCapacityExceeded = errors.New("capacity exceeded")
 )
 
+// CapacityError reports that creating an object was refused because the
+// number of existing objects of its kind has reached the configured ceiling.
+// It matches ErrCapacityExceeded with errors.Is.
+type CapacityError struct {
+	// Kind is the kind of object refused, such as "resource pool".
+	Kind string
+
+	// TenantID is the tenant whose objects were counted. Empty means the
+	// ceiling applies to all objects created through the gateway.
+	TenantID string
+
+	// Count is the number of existing objects.
+	Count int
+
+	// Limit is the configured ceiling.
+	Limit int
+}
+
+// Error implements error.
+func (e *CapacityError) Error() string {
+	scope := ""
+	if e.TenantID != "" {
+		scope = fmt.Sprintf(" for tenant %q", e.TenantID)
+	}
+	return fmt.Sprintf("%s capacity exceeded%s: %d of %d allowed already exist", e.Kind, scope, e.Count, e.Limit)
+}
+
+// Is reports whether target is ErrCapacityExceeded.
+func (e *CapacityError) Is(target error) bool {
+	return target == ErrCapacityExceeded
+}
+
 // Filter provides criteria for filtering O2-IMS resources.
 // Filters are used in List operations to narrow down results based on
 // resource attributes, labels, location, and custom extensions.
@@ -142,10 +183,40 @@ type DeploymentManager struct {
 	// Capabilities lists the features supported by this deployment manager.
 	Capabilities []string `json:"capabilities,omitempty"`
 
+	// ProfileSupport describes the O2 API conformance of the gateway serving this
+	// deployment manager. It is populated by the gateway, not by adapters.
+	ProfileSupport *ProfileSupport `json:"profileSupport,omitempty"`
+
 	// Extensions provides vendor-specific additional metadata.
 	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
+// ProfileSupport describes which O2 profiles, API versions, and optional
+// extensions a deployment manager supports, so SMOs can discover optional
+// features without probing endpoints.
+type ProfileSupport struct {
+	// Profiles lists the supported O2 interface profiles (e.g. "o2ims-inventory", "o2dms").
+	Profiles []string `json:"profiles"`
+
+	// APIVersions lists the served API versions and their lifecycle status.
+	APIVersions []APIVersionSupport `json:"apiVersions"`
+
+	// Capabilities lists the O2-IMS capabilities of the backend adapter.
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Extensions lists the optional gateway extensions that are enabled.
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// APIVersionSupport describes a served API version.
+type APIVersionSupport struct {
+	// Version is the version string (e.g. "v1").
+	Version string `json:"version"`
+
+	// Status is the version lifecycle status (stable, deprecated).
+	Status string `json:"status"`
+}
+
 // ResourcePool represents an O2-IMS Resource Pool.
 // A Resource Pool is a logical grouping of infrastructure resources
 // (typically nodes/machines) with similar characteristics.
@@ -172,6 +243,10 @@ type ResourcePool struct {
 	// GlobalLocationID provides geographic coordinates (e.g., "geo:37.7749,-122.4194").
 	GlobalLocationID string `json:"globalLocationId,omitempty"`
 
+	// EstimatedCost is the estimated monthly cost of the capacity of the pool's
+	// resources. Set by the gateway when cost estimation is enabled.
+	EstimatedCost *cost.Estimate `json:"estimatedCost,omitempty"`
+
 	// Extensions provides vendor-specific additional metadata.
 	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
@@ -250,6 +325,26 @@ type Subscription struct {
 
 	// Filter specifies criteria for which events trigger notifications.
 	Filter *SubscriptionFilter `json:"filter,omitempty"`
+
+	// Transform optionally reshapes notifications before they are delivered.
+	Transform *transform.Template `json:"transform,omitempty"`
+
+	// Digest optionally delivers periodic summaries instead of individual
+	// notifications.
+	Digest *digest.Settings `json:"digest,omitempty"`
+
+	// ExpiresAt optionally sets when the subscription expires and is
+	// deleted. A PUT with a later time refreshes it.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// SigningSecret optionally sets the secret signing the notifications of
+	// the subscription with an X-Notification-Signature header. It is
+	// write-only: responses only carry a secret the gateway generated.
+	SigningSecret string `json:"signingSecret,omitempty"`
+
+	// GenerateSigningSecret asks the gateway to generate the signing secret,
+	// which is returned once, in the response of the request.
+	GenerateSigningSecret bool `json:"generateSigningSecret,omitempty"`
 }
 
 // SubscriptionFilter defines criteria for event filtering.
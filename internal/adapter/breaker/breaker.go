@@ -0,0 +1,174 @@
+// Package breaker provides an adapter decorator passing the backend calls of
+// an O2-IMS adapter through a circuit breaker, so that requests fail fast
+// with circuitbreaker.ErrOpen while the backend is down instead of each
+// waiting for its timeout.
+package breaker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+	"github.com/piwi3910/netweave/internal/httperror"
+)
+
+// Adapter wraps an adapter.Adapter and calls its backend operations through
+// a circuit breaker. Name, Version, Capabilities, Health and Close are passed
+// through, so health checks always reach the backend and report its actual
+// state.
+//
+// Only errors the handlers would report as a server error count as
+// failures; missing objects, invalid requests and unsupported operations
+// don't open the breaker.
+type Adapter struct {
+	adapter.Adapter
+
+	breaker *circuitbreaker.Breaker
+}
+
+// New wraps inner with the breaker named "ims-adapter/<name>" of set.
+func New(inner adapter.Adapter, set *circuitbreaker.Set) *Adapter {
+	translator := httperror.Default.For(inner)
+	return &Adapter{
+		Adapter: inner,
+		breaker: set.Breaker("ims-adapter/"+inner.Name(), func(err error) bool {
+			status := translator.Translate(err).Status
+			return status >= http.StatusInternalServerError && status != http.StatusNotImplemented
+		}),
+	}
+}
+
+// GetDeploymentManager retrieves the deployment manager through the breaker.
+func (a *Adapter) GetDeploymentManager(ctx context.Context, id string) (*adapter.DeploymentManager, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentManager, error) {
+		return a.Adapter.GetDeploymentManager(ctx, id)
+	})
+}
+
+// ListResourcePools lists resource pools through the breaker.
+func (a *Adapter) ListResourcePools(ctx context.Context, filter *adapter.Filter) ([]*adapter.ResourcePool, error) {
+	return circuitbreaker.Call(a.breaker, func() ([]*adapter.ResourcePool, error) {
+		return a.Adapter.ListResourcePools(ctx, filter)
+	})
+}
+
+// GetResourcePool retrieves a resource pool through the breaker.
+func (a *Adapter) GetResourcePool(ctx context.Context, id string) (*adapter.ResourcePool, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.ResourcePool, error) {
+		return a.Adapter.GetResourcePool(ctx, id)
+	})
+}
+
+// CreateResourcePool creates a resource pool through the breaker.
+func (a *Adapter) CreateResourcePool(
+	ctx context.Context,
+	pool *adapter.ResourcePool,
+) (*adapter.ResourcePool, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.ResourcePool, error) {
+		return a.Adapter.CreateResourcePool(ctx, pool)
+	})
+}
+
+// UpdateResourcePool updates a resource pool through the breaker.
+func (a *Adapter) UpdateResourcePool(
+	ctx context.Context,
+	id string,
+	pool *adapter.ResourcePool,
+) (*adapter.ResourcePool, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.ResourcePool, error) {
+		return a.Adapter.UpdateResourcePool(ctx, id, pool)
+	})
+}
+
+// DeleteResourcePool deletes a resource pool through the breaker.
+func (a *Adapter) DeleteResourcePool(ctx context.Context, id string) error {
+	return a.breaker.Do(func() error {
+		return a.Adapter.DeleteResourcePool(ctx, id)
+	})
+}
+
+// ListResources lists resources through the breaker.
+func (a *Adapter) ListResources(ctx context.Context, filter *adapter.Filter) ([]*adapter.Resource, error) {
+	return circuitbreaker.Call(a.breaker, func() ([]*adapter.Resource, error) {
+		return a.Adapter.ListResources(ctx, filter)
+	})
+}
+
+// GetResource retrieves a resource through the breaker.
+func (a *Adapter) GetResource(ctx context.Context, id string) (*adapter.Resource, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Resource, error) {
+		return a.Adapter.GetResource(ctx, id)
+	})
+}
+
+// CreateResource creates a resource through the breaker.
+func (a *Adapter) CreateResource(ctx context.Context, resource *adapter.Resource) (*adapter.Resource, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Resource, error) {
+		return a.Adapter.CreateResource(ctx, resource)
+	})
+}
+
+// UpdateResource updates a resource through the breaker.
+func (a *Adapter) UpdateResource(
+	ctx context.Context,
+	id string,
+	resource *adapter.Resource,
+) (*adapter.Resource, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Resource, error) {
+		return a.Adapter.UpdateResource(ctx, id, resource)
+	})
+}
+
+// DeleteResource deletes a resource through the breaker.
+func (a *Adapter) DeleteResource(ctx context.Context, id string) error {
+	return a.breaker.Do(func() error {
+		return a.Adapter.DeleteResource(ctx, id)
+	})
+}
+
+// ListResourceTypes lists resource types through the breaker.
+func (a *Adapter) ListResourceTypes(ctx context.Context, filter *adapter.Filter) ([]*adapter.ResourceType, error) {
+	return circuitbreaker.Call(a.breaker, func() ([]*adapter.ResourceType, error) {
+		return a.Adapter.ListResourceTypes(ctx, filter)
+	})
+}
+
+// GetResourceType retrieves a resource type through the breaker.
+func (a *Adapter) GetResourceType(ctx context.Context, id string) (*adapter.ResourceType, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.ResourceType, error) {
+		return a.Adapter.GetResourceType(ctx, id)
+	})
+}
+
+// CreateSubscription creates a subscription through the breaker.
+func (a *Adapter) CreateSubscription(ctx context.Context, sub *adapter.Subscription) (*adapter.Subscription, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Subscription, error) {
+		return a.Adapter.CreateSubscription(ctx, sub)
+	})
+}
+
+// GetSubscription retrieves a subscription through the breaker.
+func (a *Adapter) GetSubscription(ctx context.Context, id string) (*adapter.Subscription, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Subscription, error) {
+		return a.Adapter.GetSubscription(ctx, id)
+	})
+}
+
+// UpdateSubscription updates a subscription through the breaker.
+func (a *Adapter) UpdateSubscription(
+	ctx context.Context,
+	id string,
+	sub *adapter.Subscription,
+) (*adapter.Subscription, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Subscription, error) {
+		return a.Adapter.UpdateSubscription(ctx, id, sub)
+	})
+}
+
+// DeleteSubscription deletes a subscription through the breaker.
+func (a *Adapter) DeleteSubscription(ctx context.Context, id string) error {
+	return a.breaker.Do(func() error {
+		return a.Adapter.DeleteSubscription(ctx, id)
+	})
+}
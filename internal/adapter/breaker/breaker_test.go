@@ -0,0 +1,77 @@
+package breaker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/adapter/breaker"
+	"github.com/piwi3910/netweave/internal/adapters/mock"
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+)
+
+// flakyAdapter fails its resource pool lists while down and has no
+// resource pools.
+type flakyAdapter struct {
+	*mock.Adapter
+	down  bool
+	lists int
+}
+
+func (a *flakyAdapter) GetResourcePool(_ context.Context, id string) (*adapter.ResourcePool, error) {
+	return nil, fmt.Errorf("%w: %s", adapter.ErrResourcePoolNotFound, id)
+}
+
+func (a *flakyAdapter) ListResourcePools(
+	ctx context.Context,
+	filter *adapter.Filter,
+) ([]*adapter.ResourcePool, error) {
+	a.lists++
+	if a.down {
+		return nil, context.DeadlineExceeded
+	}
+	return a.Adapter.ListResourcePools(ctx, filter)
+}
+
+func TestAdapter(t *testing.T) {
+	ctx := context.Background()
+	set := circuitbreaker.NewSet(circuitbreaker.Settings{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+		HalfOpenRequests: 1,
+	}, zap.NewNop())
+	inner := &flakyAdapter{Adapter: mock.NewAdapter(false), down: true}
+	adp := breaker.New(inner, set)
+
+	// Missing objects don't count as failures.
+	for range 3 {
+		_, err := adp.GetResourcePool(ctx, "missing")
+		require.ErrorIs(t, err, adapter.ErrResourcePoolNotFound)
+	}
+
+	for range 2 {
+		_, err := adp.ListResourcePools(ctx, nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	_, err := adp.ListResourcePools(ctx, nil)
+	require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Equal(t, 2, inner.lists)
+
+	// Every backend call shares the adapter's breaker; health checks bypass it.
+	_, err = adp.GetResourcePool(ctx, "missing")
+	require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	require.NoError(t, adp.Health(ctx))
+
+	// The backend recovered: the half-open probe closes the breaker.
+	inner.down = false
+	time.Sleep(30 * time.Millisecond)
+	_, err = adp.ListResourcePools(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, circuitbreaker.StateClosed, set.Statuses()["ims-adapter/"+inner.Name()].State)
+}
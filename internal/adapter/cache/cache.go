@@ -0,0 +1,199 @@
+// Package cache provides an adapter decorator caching the results of the
+// adapter list operations.
+//
+// Clients such as SMOs poll the inventory lists every few seconds; without a
+// cache each poll reaches the backend (e.g. the Kubernetes API). Cached lists
+// are keyed by their filter, so every tenant and query is cached separately,
+// and are dropped whenever an object of the same kind is created, updated or
+// deleted through the adapter.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+// Object kinds whose lists are cached.
+const (
+	KindResourcePools = "resourcePools"
+	KindResources     = "resources"
+	KindResourceTypes = "resourceTypes"
+)
+
+// TTLs holds how long lists of each object kind are cached. A zero TTL
+// disables caching of that kind.
+type TTLs struct {
+	ResourcePools time.Duration
+	Resources     time.Duration
+	ResourceTypes time.Duration
+}
+
+// Adapter wraps an adapter.Adapter and serves ListResourcePools,
+// ListResources and ListResourceTypes from a Store. All other operations are
+// passed through; creating, updating or deleting resource pools or resources
+// invalidates the cached lists of that kind.
+//
+// Cached lists are decoded from their JSON form, so extension values have
+// their JSON types (e.g. numbers are float64). Store failures never fail a
+// request: the list is read from the wrapped adapter instead.
+type Adapter struct {
+	adapter.Adapter
+
+	store  Store
+	ttls   TTLs
+	logger *zap.Logger
+}
+
+// New wraps inner with a cache of its list results held in store.
+func New(inner adapter.Adapter, store Store, ttls TTLs, logger *zap.Logger) *Adapter {
+	return &Adapter{Adapter: inner, store: store, ttls: ttls, logger: logger}
+}
+
+// ListResourcePools returns the resource pools matching filter, from the cache if possible.
+func (a *Adapter) ListResourcePools(ctx context.Context, filter *adapter.Filter) ([]*adapter.ResourcePool, error) {
+	return cachedList(ctx, a, KindResourcePools, "ListResourcePools", a.ttls.ResourcePools, filter,
+		a.Adapter.ListResourcePools)
+}
+
+// ListResources returns the resources matching filter, from the cache if possible.
+func (a *Adapter) ListResources(ctx context.Context, filter *adapter.Filter) ([]*adapter.Resource, error) {
+	return cachedList(ctx, a, KindResources, "ListResources", a.ttls.Resources, filter,
+		a.Adapter.ListResources)
+}
+
+// ListResourceTypes returns the resource types matching filter, from the cache if possible.
+func (a *Adapter) ListResourceTypes(ctx context.Context, filter *adapter.Filter) ([]*adapter.ResourceType, error) {
+	return cachedList(ctx, a, KindResourceTypes, "ListResourceTypes", a.ttls.ResourceTypes, filter,
+		a.Adapter.ListResourceTypes)
+}
+
+// CreateResourcePool creates a resource pool and invalidates the cached resource pool lists.
+func (a *Adapter) CreateResourcePool(
+	ctx context.Context,
+	pool *adapter.ResourcePool,
+) (*adapter.ResourcePool, error) {
+	defer a.Invalidate(ctx, KindResourcePools)
+	return a.Adapter.CreateResourcePool(ctx, pool)
+}
+
+// UpdateResourcePool updates a resource pool and invalidates the cached resource pool lists.
+func (a *Adapter) UpdateResourcePool(
+	ctx context.Context,
+	id string,
+	pool *adapter.ResourcePool,
+) (*adapter.ResourcePool, error) {
+	defer a.Invalidate(ctx, KindResourcePools)
+	return a.Adapter.UpdateResourcePool(ctx, id, pool)
+}
+
+// DeleteResourcePool deletes a resource pool and invalidates the cached resource pool lists.
+func (a *Adapter) DeleteResourcePool(ctx context.Context, id string) error {
+	defer a.Invalidate(ctx, KindResourcePools)
+	return a.Adapter.DeleteResourcePool(ctx, id)
+}
+
+// CreateResource creates a resource and invalidates the cached resource lists.
+func (a *Adapter) CreateResource(ctx context.Context, resource *adapter.Resource) (*adapter.Resource, error) {
+	defer a.Invalidate(ctx, KindResources)
+	return a.Adapter.CreateResource(ctx, resource)
+}
+
+// UpdateResource updates a resource and invalidates the cached resource lists.
+func (a *Adapter) UpdateResource(
+	ctx context.Context,
+	id string,
+	resource *adapter.Resource,
+) (*adapter.Resource, error) {
+	defer a.Invalidate(ctx, KindResources)
+	return a.Adapter.UpdateResource(ctx, id, resource)
+}
+
+// DeleteResource deletes a resource and invalidates the cached resource lists.
+func (a *Adapter) DeleteResource(ctx context.Context, id string) error {
+	defer a.Invalidate(ctx, KindResources)
+	return a.Adapter.DeleteResource(ctx, id)
+}
+
+// Invalidate drops the cached lists of the given kinds. It runs after write
+// operations whether or not they succeeded, since a failed write may have
+// been partially applied. Failures are logged; the lists then expire by TTL.
+func (a *Adapter) Invalidate(ctx context.Context, kinds ...string) {
+	// Invalidate even if the request was cancelled during the write.
+	ctx = context.WithoutCancel(ctx)
+	for _, kind := range kinds {
+		if err := a.store.Invalidate(ctx, kind); err != nil {
+			a.logger.Warn("failed to invalidate cached lists; they expire by TTL",
+				zap.String("kind", kind),
+				zap.Error(err))
+		}
+	}
+}
+
+// cachedList returns the cached list of kind for filter, or lists it with
+// list and caches the result for ttl.
+func cachedList[T any](
+	ctx context.Context,
+	a *Adapter,
+	kind, operation string,
+	ttl time.Duration,
+	filter *adapter.Filter,
+	list func(context.Context, *adapter.Filter) ([]T, error),
+) ([]T, error) {
+	if ttl <= 0 {
+		return list(ctx, filter)
+	}
+	key, err := filterKey(filter)
+	if err != nil {
+		a.logger.Debug("filter cannot be cached", zap.String("kind", kind), zap.Error(err))
+		return list(ctx, filter)
+	}
+	gen, err := a.store.Generation(ctx, kind)
+	if err != nil {
+		a.logger.Warn("cache unavailable, listing from adapter", zap.String("kind", kind), zap.Error(err))
+		return list(ctx, filter)
+	}
+
+	data, ok, err := a.store.Get(ctx, kind, gen, key)
+	if err != nil {
+		a.logger.Warn("failed to read cached list", zap.String("kind", kind), zap.Error(err))
+	}
+	if ok {
+		var items []T
+		err := json.Unmarshal(data, &items)
+		if err == nil {
+			adapter.RecordCacheHit(a.Name(), operation)
+			return items, nil
+		}
+		a.logger.Warn("discarding malformed cached list", zap.String("kind", kind), zap.Error(err))
+	}
+	adapter.RecordCacheMiss(a.Name(), operation)
+
+	items, err := list(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(items); err == nil {
+		err = a.store.Set(ctx, kind, gen, key, data, ttl)
+	}
+	if err != nil {
+		a.logger.Warn("failed to cache list", zap.String("kind", kind), zap.Error(err))
+	}
+	return items, nil
+}
+
+// filterKey returns the cache key of a list filter.
+func filterKey(filter *adapter.Filter) (string, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,148 @@
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/adapter/cache"
+	"github.com/piwi3910/netweave/internal/adapters/mock"
+)
+
+// countingAdapter counts the list calls reaching the backend.
+type countingAdapter struct {
+	*mock.Adapter
+	poolLists atomic.Int32
+	typeLists atomic.Int32
+}
+
+func (a *countingAdapter) ListResourcePools(
+	ctx context.Context,
+	filter *adapter.Filter,
+) ([]*adapter.ResourcePool, error) {
+	a.poolLists.Add(1)
+	return a.Adapter.ListResourcePools(ctx, filter)
+}
+
+func (a *countingAdapter) ListResourceTypes(
+	ctx context.Context,
+	filter *adapter.Filter,
+) ([]*adapter.ResourceType, error) {
+	a.typeLists.Add(1)
+	return a.Adapter.ListResourceTypes(ctx, filter)
+}
+
+func newStores(t *testing.T) map[string]cache.Store {
+	t.Helper()
+
+	memory, err := cache.NewMemoryStore(100)
+	require.NoError(t, err)
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return map[string]cache.Store{"memory": memory, "redis": cache.NewRedisStore(client)}
+}
+
+func TestAdapter_ListResourcePools(t *testing.T) {
+	for name, store := range newStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			backend := &countingAdapter{Adapter: mock.NewAdapter(true)}
+			cached := cache.New(backend, store, cache.TTLs{ResourcePools: time.Minute}, zap.NewNop())
+			hits := testutil.ToFloat64(adapter.Metrics.CacheHits.WithLabelValues("mock", "ListResourcePools"))
+
+			pools, err := cached.ListResourcePools(ctx, nil)
+			require.NoError(t, err)
+			require.NotEmpty(t, pools)
+
+			again, err := cached.ListResourcePools(ctx, nil)
+			require.NoError(t, err)
+			want, err := json.Marshal(pools)
+			require.NoError(t, err)
+			got, err := json.Marshal(again)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+			assert.Equal(t, int32(1), backend.poolLists.Load(), "second list is served from the cache")
+			assert.InDelta(t, hits+1,
+				testutil.ToFloat64(adapter.Metrics.CacheHits.WithLabelValues("mock", "ListResourcePools")), 0)
+
+			_, err = cached.ListResourcePools(ctx, &adapter.Filter{TenantID: "tenant-a"})
+			require.NoError(t, err)
+			assert.Equal(t, int32(2), backend.poolLists.Load(), "filters are cached separately")
+
+			_, err = cached.CreateResourcePool(ctx, &adapter.ResourcePool{ResourcePoolID: "pool-new", Name: "new"})
+			require.NoError(t, err)
+			pools, err = cached.ListResourcePools(ctx, nil)
+			require.NoError(t, err)
+			assert.Equal(t, int32(3), backend.poolLists.Load(), "writes invalidate the cached lists")
+			assert.Len(t, pools, len(again)+1)
+		})
+	}
+}
+
+func TestAdapter_TTL(t *testing.T) {
+	ctx := context.Background()
+	store, err := cache.NewMemoryStore(100)
+	require.NoError(t, err)
+	backend := &countingAdapter{Adapter: mock.NewAdapter(true)}
+	cached := cache.New(backend, store, cache.TTLs{ResourcePools: 20 * time.Millisecond}, zap.NewNop())
+
+	_, err = cached.ListResourcePools(ctx, nil)
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+	_, err = cached.ListResourcePools(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), backend.poolLists.Load(), "expired lists are fetched again")
+
+	// A zero TTL disables caching of the kind.
+	_, err = cached.ListResourceTypes(ctx, nil)
+	require.NoError(t, err)
+	_, err = cached.ListResourceTypes(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), backend.typeLists.Load())
+}
+
+// failingStore is a Store whose backend is unreachable.
+type failingStore struct{}
+
+func (failingStore) Generation(context.Context, string) (uint64, error) {
+	return 0, errors.New("connection refused")
+}
+
+func (failingStore) Get(context.Context, string, uint64, string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingStore) Set(context.Context, string, uint64, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Invalidate(context.Context, string) error {
+	return errors.New("connection refused")
+}
+
+func TestAdapter_StoreUnavailable(t *testing.T) {
+	ctx := context.Background()
+	backend := &countingAdapter{Adapter: mock.NewAdapter(true)}
+	cached := cache.New(backend, failingStore{}, cache.TTLs{ResourcePools: time.Minute}, zap.NewNop())
+
+	pools, err := cached.ListResourcePools(ctx, nil)
+	require.NoError(t, err, "store failures fall back to the adapter")
+	assert.NotEmpty(t, pools)
+
+	_, err = cached.CreateResourcePool(ctx, &adapter.ResourcePool{ResourcePoolID: "pool-new", Name: "new"})
+	require.NoError(t, err, "invalidation failures do not fail writes")
+}
@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix prefixes the Redis keys of cached lists and generations.
+const redisKeyPrefix = "adapter-cache:"
+
+// Store holds cached list results. Entries are grouped by object kind, and
+// each kind has a generation that Invalidate advances: entries written for an
+// older generation are never returned again. Callers read the generation
+// before calling the backend and write the result under that generation, so a
+// list fetched concurrently with an invalidation is not served afterwards.
+type Store interface {
+	// Generation returns the current generation of kind.
+	Generation(ctx context.Context, kind string) (uint64, error)
+
+	// Get returns the entry stored for key under generation gen of kind.
+	Get(ctx context.Context, kind string, gen uint64, key string) ([]byte, bool, error)
+
+	// Set stores an entry for key under generation gen of kind for ttl.
+	Set(ctx context.Context, kind string, gen uint64, key string, value []byte, ttl time.Duration) error
+
+	// Invalidate drops every entry of kind by advancing its generation.
+	Invalidate(ctx context.Context, kind string) error
+}
+
+// memoryEntry is a cached list held by MemoryStore.
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryStore is an in-process Store evicting the least recently used
+// entries beyond its capacity. Each gateway replica has its own entries, so
+// writes made through another replica show up once the entries expire.
+type MemoryStore struct {
+	mu      sync.Mutex
+	gens    map[string]uint64
+	entries *lru.Cache[string, memoryEntry]
+}
+
+// NewMemoryStore creates an in-process store holding at most maxEntries lists.
+func NewMemoryStore(maxEntries int) (*MemoryStore, error) {
+	entries, err := lru.New[string, memoryEntry](maxEntries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+	return &MemoryStore{gens: make(map[string]uint64), entries: entries}, nil
+}
+
+// Generation returns the current generation of kind.
+func (s *MemoryStore) Generation(_ context.Context, kind string) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gens[kind], nil
+}
+
+// Get returns the entry stored for key under generation gen of kind.
+func (s *MemoryStore) Get(_ context.Context, kind string, gen uint64, key string) ([]byte, bool, error) {
+	entryKey := entryKey(kind, gen, key)
+	entry, ok := s.entries.Get(entryKey)
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		s.entries.Remove(entryKey)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set stores an entry for key under generation gen of kind for ttl.
+func (s *MemoryStore) Set(
+	_ context.Context,
+	kind string,
+	gen uint64,
+	key string,
+	value []byte,
+	ttl time.Duration,
+) error {
+	s.entries.Add(entryKey(kind, gen, key), memoryEntry{value: value, expiresAt: time.Now().Add(ttl)})
+	return nil
+}
+
+// Invalidate drops every entry of kind. Entries of older generations are
+// left for the LRU to evict.
+func (s *MemoryStore) Invalidate(_ context.Context, kind string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gens[kind]++
+	return nil
+}
+
+// RedisStore is a Store shared by every gateway replica using the same Redis,
+// so a write through any replica invalidates the lists cached by all of them.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a store on client. The client is owned by the caller.
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Generation returns the current generation of kind.
+func (s *RedisStore) Generation(ctx context.Context, kind string) (uint64, error) {
+	gen, err := s.client.Get(ctx, generationKey(kind)).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cache generation: %w", err)
+	}
+	return gen, nil
+}
+
+// Get returns the entry stored for key under generation gen of kind.
+func (s *RedisStore) Get(ctx context.Context, kind string, gen uint64, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, redisKeyPrefix+entryKey(kind, gen, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get cached list: %w", err)
+	}
+	return value, true, nil
+}
+
+// Set stores an entry for key under generation gen of kind for ttl.
+func (s *RedisStore) Set(
+	ctx context.Context,
+	kind string,
+	gen uint64,
+	key string,
+	value []byte,
+	ttl time.Duration,
+) error {
+	if err := s.client.Set(ctx, redisKeyPrefix+entryKey(kind, gen, key), value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache list: %w", err)
+	}
+	return nil
+}
+
+// Invalidate drops every entry of kind. Entries of older generations expire
+// by their TTL.
+func (s *RedisStore) Invalidate(ctx context.Context, kind string) error {
+	if err := s.client.Incr(ctx, generationKey(kind)).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate cached lists: %w", err)
+	}
+	return nil
+}
+
+// entryKey returns the key of the entry for key under generation gen of kind.
+func entryKey(kind string, gen uint64, key string) string {
+	return kind + ":" + strconv.FormatUint(gen, 10) + ":" + key
+}
+
+// generationKey returns the Redis key holding the generation of kind.
+func generationKey(kind string) string {
+	return redisKeyPrefix + kind + ":generation"
+}
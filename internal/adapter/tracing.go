@@ -8,6 +8,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/piwi3910/netweave/internal/observability"
 )
 
 const (
@@ -37,13 +39,22 @@ type Span struct {
 //	}
 //	adapter.RecordSuccess(span, len(resources))
 func StartSpan(ctx context.Context, adapterName, operation string) (context.Context, Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("adapter.name", adapterName),
+		attribute.String("adapter.operation", operation),
+	}
+	// Tie the span to the API call that triggered it.
+	if requestID := observability.RequestIDFromContext(ctx); requestID != "" {
+		attrs = append(attrs, attribute.String("request.id", requestID))
+	}
+	if correlationID := observability.CorrelationIDFromContext(ctx); correlationID != "" {
+		attrs = append(attrs, attribute.String("correlation.id", correlationID))
+	}
+
 	tracer := otel.Tracer(TracerName)
 	ctx, span := tracer.Start(ctx, operation,
 		trace.WithSpanKind(trace.SpanKindInternal),
-		trace.WithAttributes(
-			attribute.String("adapter.name", adapterName),
-			attribute.String("adapter.operation", operation),
-		),
+		trace.WithAttributes(attrs...),
 	)
 	return ctx, Span{Span: span}
 }
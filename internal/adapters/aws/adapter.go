@@ -12,16 +12,19 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/resolver"
 	"go.uber.org/zap"
 )
 
@@ -212,7 +215,12 @@ func initializeLogger(logger *zap.Logger) (*zap.Logger, error) {
 
 // buildAWSConfigOptions builds AWS SDK configuration options.
 func buildAWSConfigOptions(cfg *Config, logger *zap.Logger) []func(*config.LoadOptions) error {
-	opts := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(cfg.Region),
+		config.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions(func(t *http.Transport) {
+			t.DialContext = resolver.DialContext
+		})),
+	}
 
 	switch {
 	case cfg.Profile != "":
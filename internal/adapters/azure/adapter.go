@@ -12,6 +12,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/resolver"
 	"go.uber.org/zap"
 )
 
@@ -281,6 +283,7 @@ type azureClients struct {
 // createAzureClients creates all required Azure SDK clients.
 func createAzureClients(subscriptionID string, cred azcore.TokenCredential) (*azureClients, error) {
 	clientOpts := &arm.ClientOptions{}
+	clientOpts.Transport = &http.Client{Transport: resolver.NewTransport()}
 
 	vmClient, err := armcompute.NewVirtualMachinesClient(subscriptionID, cred, clientOpts)
 	if err != nil {
@@ -292,7 +295,7 @@ func createAzureClients(subscriptionID string, cred azcore.TokenCredential) (*az
 		return nil, fmt.Errorf("failed to create VM Sizes client: %w", err)
 	}
 
-	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, cred, nil)
+	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, cred, clientOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Resource Group client: %w", err)
 	}
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // Client provides access to the Dell DTIAS REST API.
@@ -112,6 +114,7 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 	httpClient := &http.Client{
 		Timeout: cfg.Timeout,
 		Transport: &http.Transport{
+			DialContext:         resolver.DialContext,
 			TLSClientConfig:     tlsConfig,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/observability"
 	"github.com/piwi3910/netweave/internal/storage"
 )
 
@@ -29,6 +31,10 @@ type Adapter struct {
 	// client is the Kubernetes client for API operations.
 	client kubernetes.Interface
 
+	// dynamicClient reads and writes NetweaveResource objects.
+	// If nil, resources cannot be created through the API.
+	dynamicClient dynamic.Interface
+
 	// store is the subscription storage backend (Redis).
 	store storage.Store
 
@@ -43,6 +49,17 @@ type Adapter struct {
 
 	// namespace is the default namespace for O2-IMS resources.
 	namespace string
+
+	// informers serves node and namespace reads from memory once
+	// StartInformers has synced. Nil means every read goes to the API server.
+	informers *informerCache
+
+	// hedger sends second attempts for slow API server reads.
+	// Nil means reads are not hedged.
+	hedger *hedger
+
+	// objectLimits caps the objects created per tenant.
+	objectLimits ObjectLimits
 }
 
 // Config holds configuration for creating a KubernetesAdapter.
@@ -128,8 +145,15 @@ func New(cfg *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
+	// Create dynamic client for NetweaveResource objects
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	adapter := &Adapter{
 		client:              client,
+		dynamicClient:       dynamicClient,
 		store:               cfg.Store,
 		logger:              logger,
 		oCloudID:            cfg.OCloudID,
@@ -184,12 +208,12 @@ func (a *Adapter) CreateSubscription(
 	ctx context.Context,
 	sub *adapter.Subscription,
 ) (*adapter.Subscription, error) {
-	a.logger.Debug("CreateSubscription called",
+	a.log(ctx).Debug("CreateSubscription called",
 		zap.String("callback", sub.Callback),
 		zap.String("subscriptionId", sub.SubscriptionID))
 
 	if a.store == nil {
-		a.logger.Warn("subscription storage not configured")
+		a.log(ctx).Warn("subscription storage not configured")
 		return nil, fmt.Errorf("subscription storage not configured")
 	}
 
@@ -212,13 +236,13 @@ func (a *Adapter) CreateSubscription(
 
 	// Store subscription in Redis
 	if err := a.store.Create(ctx, storageSub); err != nil {
-		a.logger.Error("failed to store subscription",
+		a.log(ctx).Error("failed to store subscription",
 			zap.String("subscriptionId", sub.SubscriptionID),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to store subscription: %w", err)
 	}
 
-	a.logger.Info("subscription created",
+	a.log(ctx).Info("subscription created",
 		zap.String("subscriptionId", sub.SubscriptionID),
 		zap.String("callback", sub.Callback))
 
@@ -227,11 +251,11 @@ func (a *Adapter) CreateSubscription(
 
 // GetSubscription retrieves a specific subscription by ID from Redis.
 func (a *Adapter) GetSubscription(ctx context.Context, id string) (*adapter.Subscription, error) {
-	a.logger.Debug("GetSubscription called",
+	a.log(ctx).Debug("GetSubscription called",
 		zap.String("id", id))
 
 	if a.store == nil {
-		a.logger.Warn("subscription storage not configured")
+		a.log(ctx).Warn("subscription storage not configured")
 		return nil, fmt.Errorf("subscription storage not configured")
 	}
 
@@ -239,11 +263,11 @@ func (a *Adapter) GetSubscription(ctx context.Context, id string) (*adapter.Subs
 	storageSub, err := a.store.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			a.logger.Debug("subscription not found",
+			a.log(ctx).Debug("subscription not found",
 				zap.String("subscriptionId", id))
 			return nil, fmt.Errorf("%w: %s", adapter.ErrSubscriptionNotFound, id)
 		}
-		a.logger.Error("failed to retrieve subscription",
+		a.log(ctx).Error("failed to retrieve subscription",
 			zap.String("subscriptionId", id),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to retrieve subscription: %w", err)
@@ -283,12 +307,12 @@ func (a *Adapter) UpdateSubscription(
 	var err error
 	defer func() { adapter.ObserveOperation("kubernetes", "UpdateSubscription", start, err) }()
 
-	a.logger.Debug("UpdateSubscription called",
+	a.log(ctx).Debug("UpdateSubscription called",
 		zap.String("id", id),
 		zap.String("callback", sub.Callback))
 
 	if a.store == nil {
-		a.logger.Warn("subscription storage not configured")
+		a.log(ctx).Warn("subscription storage not configured")
 		return nil, fmt.Errorf("subscription storage not configured")
 	}
 
@@ -309,7 +333,7 @@ func (a *Adapter) UpdateSubscription(
 		return nil, err
 	}
 
-	a.logger.Info("subscription updated",
+	a.log(ctx).Info("subscription updated",
 		zap.String("subscriptionId", id),
 		zap.String("oldCallback", existingSub.Callback),
 		zap.String("newCallback", sub.Callback))
@@ -325,11 +349,11 @@ func (a *Adapter) getExistingSubscription(ctx context.Context, id string) (*stor
 	existingSub, err := a.store.Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			a.logger.Debug("subscription not found",
+			a.log(ctx).Debug("subscription not found",
 				zap.String("subscriptionId", id))
 			return nil, fmt.Errorf("%w: %s", adapter.ErrSubscriptionNotFound, id)
 		}
-		a.logger.Error("failed to retrieve existing subscription",
+		a.log(ctx).Error("failed to retrieve existing subscription",
 			zap.String("subscriptionId", id),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to retrieve subscription: %w", err)
@@ -367,11 +391,11 @@ func (a *Adapter) updateSubscriptionInStore(
 ) error {
 	if err := a.store.Update(ctx, storageSub); err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			a.logger.Debug("subscription not found",
+			a.log(ctx).Debug("subscription not found",
 				zap.String("subscriptionId", id))
 			return fmt.Errorf("%w: %s", adapter.ErrSubscriptionNotFound, id)
 		}
-		a.logger.Error("failed to update subscription",
+		a.log(ctx).Error("failed to update subscription",
 			zap.String("subscriptionId", id),
 			zap.Error(err))
 		return fmt.Errorf("failed to update subscription: %w", err)
@@ -407,28 +431,28 @@ func (a *Adapter) convertToAdapterSubscription(
 // DeleteSubscription deletes a subscription by ID from Redis.
 // The controller package monitors Redis and stops the corresponding Kubernetes watchers.
 func (a *Adapter) DeleteSubscription(ctx context.Context, id string) error {
-	a.logger.Debug("DeleteSubscription called",
+	a.log(ctx).Debug("DeleteSubscription called",
 		zap.String("id", id))
 
 	if a.store == nil {
-		a.logger.Warn("subscription storage not configured")
+		a.log(ctx).Warn("subscription storage not configured")
 		return fmt.Errorf("subscription storage not configured")
 	}
 
 	// Delete subscription from Redis
 	if err := a.store.Delete(ctx, id); err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			a.logger.Debug("subscription not found for deletion",
+			a.log(ctx).Debug("subscription not found for deletion",
 				zap.String("subscriptionId", id))
 			return fmt.Errorf("%w: %s", adapter.ErrSubscriptionNotFound, id)
 		}
-		a.logger.Error("failed to delete subscription",
+		a.log(ctx).Error("failed to delete subscription",
 			zap.String("subscriptionId", id),
 			zap.Error(err))
 		return fmt.Errorf("failed to delete subscription: %w", err)
 	}
 
-	a.logger.Info("subscription deleted",
+	a.log(ctx).Info("subscription deleted",
 		zap.String("subscriptionId", id))
 
 	return nil
@@ -455,6 +479,10 @@ func (a *Adapter) Health(_ context.Context) error {
 func (a *Adapter) Close() error {
 	a.logger.Info("closing Kubernetes adapter")
 
+	if a.informers != nil {
+		a.informers.stop()
+	}
+
 	// Sync logger before shutdown
 	// Ignore sync errors on stderr/stdout which are common
 	_ = a.logger.Sync()
@@ -462,6 +490,14 @@ func (a *Adapter) Close() error {
 	return nil
 }
 
+// StopInformers stops the informer caches started by StartInformers, after
+// the components sharing their watches have stopped. Close stops them too.
+func (a *Adapter) StopInformers() {
+	if a.informers != nil {
+		a.informers.stop()
+	}
+}
+
 // GetClient returns the underlying Kubernetes client.
 // This method is primarily intended for testing purposes.
 func (a *Adapter) GetClient() kubernetes.Interface {
@@ -480,6 +516,18 @@ func (a *Adapter) SetNamespace(namespace string) {
 	a.namespace = namespace
 }
 
+// SetDynamicClient sets the dynamic client used for NetweaveResource objects.
+// This method is intended for testing purposes only.
+func (a *Adapter) SetDynamicClient(client dynamic.Interface) {
+	a.dynamicClient = client
+}
+
+// log returns the adapter logger with the request and correlation IDs of the
+// API call that ctx belongs to.
+func (a *Adapter) log(ctx context.Context) *zap.Logger {
+	return a.logger.With(observability.ExtractContextFields(ctx)...)
+}
+
 // NewForTesting creates a new Adapter with a provided Kubernetes client.
 // This function is intended for testing purposes only.
 func NewForTesting(client kubernetes.Interface, logger *zap.Logger) *Adapter {
@@ -110,12 +110,12 @@ func (a *Adapter) getDeploymentManager() *adapter.DeploymentManager {
 
 // GetOCloudInfrastructure retrieves O-Cloud infrastructure metadata.
 func (a *Adapter) GetOCloudInfrastructure(ctx context.Context) (map[string]interface{}, error) {
-	a.logger.Debug("GetOCloudInfrastructure called")
+	a.log(ctx).Debug("GetOCloudInfrastructure called")
 
 	// Get server version
 	version, err := a.client.Discovery().ServerVersion()
 	if err != nil {
-		a.logger.Error("failed to get server version",
+		a.log(ctx).Error("failed to get server version",
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to get Kubernetes server version: %w", err)
 	}
@@ -123,7 +123,7 @@ func (a *Adapter) GetOCloudInfrastructure(ctx context.Context) (map[string]inter
 	// Get API server endpoints
 	endpoints, err := a.client.CoreV1().Endpoints("default").Get(ctx, "kubernetes", metav1.GetOptions{})
 	if err != nil {
-		a.logger.Warn("failed to get API server endpoints",
+		a.log(ctx).Warn("failed to get API server endpoints",
 			zap.Error(err))
 	}
 
@@ -165,7 +165,7 @@ func (a *Adapter) GetOCloudInfrastructure(ctx context.Context) (map[string]inter
 	// Add deployment manager reference
 	infrastructure["deploymentManagers"] = []string{a.deploymentManagerID}
 
-	a.logger.Info("retrieved O-Cloud infrastructure",
+	a.log(ctx).Info("retrieved O-Cloud infrastructure",
 		zap.String("oCloudId", a.oCloudID),
 		zap.String("version", version.GitVersion))
 
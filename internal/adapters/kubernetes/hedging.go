@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// Hedge outcomes recorded in o2ims_kubernetes_hedge_wins_total.
+const (
+	hedgeWinnerPrimary = "primary"
+	hedgeWinnerHedge   = "hedge"
+)
+
+// Reasons a hedge was not sent, recorded in o2ims_kubernetes_hedges_skipped_total.
+const (
+	hedgeSkipBudget   = "budget"
+	hedgeSkipInFlight = "in_flight"
+)
+
+// hedgeBudgetBurst caps the hedge tokens that accumulate while reads are
+// fast, so a quiet period does not allow a burst of hedges later.
+const hedgeBudgetBurst = 10
+
+var (
+	hedgesSent = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "kubernetes",
+			Name:      "hedges_total",
+			Help:      "Second attempts sent for slow Kubernetes API reads",
+		},
+		[]string{"operation"},
+	)
+
+	hedgesSkipped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "kubernetes",
+			Name:      "hedges_skipped_total",
+			Help:      "Slow Kubernetes API reads not hedged because of a safeguard",
+		},
+		[]string{"operation", "reason"},
+	)
+
+	hedgeWins = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "kubernetes",
+			Name:      "hedge_wins_total",
+			Help:      "Hedged Kubernetes API reads by the attempt that answered first",
+		},
+		[]string{"operation", "winner"},
+	)
+)
+
+// HedgingConfig configures hedged reads against the Kubernetes API server.
+type HedgingConfig struct {
+	// Delay is how long a read waits for its first attempt before sending
+	// a second one. The first response of either attempt is returned.
+	Delay time.Duration
+
+	// MaxInFlight caps the hedge attempts outstanding at once.
+	// Zero means no cap.
+	MaxInFlight int
+
+	// BudgetPercent caps hedges as a percentage of reads, so a slow API
+	// server does not receive double the load. Zero means no budget.
+	BudgetPercent float64
+}
+
+// hedger enforces the hedging safeguards shared by all reads of an adapter.
+type hedger struct {
+	cfg HedgingConfig
+
+	mu       sync.Mutex
+	inFlight int
+	tokens   float64
+}
+
+// EnableHedging makes idempotent API server reads (node, namespace and
+// NetweaveResource lists and gets) send a second attempt when the first has
+// not answered within cfg.Delay. Reads served by the informer caches are not
+// hedged. A non-positive delay disables hedging.
+func (a *Adapter) EnableHedging(cfg HedgingConfig) {
+	if cfg.Delay <= 0 {
+		a.hedger = nil
+		return
+	}
+	a.hedger = &hedger{cfg: cfg, tokens: hedgeBudgetBurst}
+	a.logger.Info("Kubernetes read hedging enabled",
+		zap.Duration("delay", cfg.Delay),
+		zap.Int("maxInFlight", cfg.MaxInFlight),
+		zap.Float64("budgetPercent", cfg.BudgetPercent))
+}
+
+// earn credits the hedge budget for one read.
+func (h *hedger) earn() {
+	if h.cfg.BudgetPercent <= 0 {
+		return
+	}
+	h.mu.Lock()
+	h.tokens = min(h.tokens+h.cfg.BudgetPercent/100, hedgeBudgetBurst)
+	h.mu.Unlock()
+}
+
+// acquire reserves a hedge attempt. It returns the reason when a safeguard
+// forbids the hedge, and otherwise a function releasing the reservation.
+func (h *hedger) acquire() (func(), string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cfg.MaxInFlight > 0 && h.inFlight >= h.cfg.MaxInFlight {
+		return nil, hedgeSkipInFlight
+	}
+	if h.cfg.BudgetPercent > 0 {
+		if h.tokens < 1 {
+			return nil, hedgeSkipBudget
+		}
+		h.tokens--
+	}
+	h.inFlight++
+	return func() {
+		h.mu.Lock()
+		h.inFlight--
+		h.mu.Unlock()
+	}, ""
+}
+
+// hedgedRead runs read and, if it has not returned after the hedging delay
+// and the safeguards allow it, runs it a second time concurrently. The first
+// response wins and the other attempt's context is cancelled. read must be
+// idempotent. With a nil hedger read is called directly.
+func hedgedRead[T any](
+	ctx context.Context,
+	h *hedger,
+	operation string,
+	read func(context.Context) (T, error),
+) (T, error) {
+	if h == nil {
+		return read(ctx)
+	}
+	h.earn()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		value T
+		err   error
+		hedge bool
+	}
+	// Buffered so the losing attempt never blocks after we return.
+	results := make(chan result, 2)
+	attempt := func(hedge bool) {
+		value, err := read(ctx)
+		results <- result{value: value, err: err, hedge: hedge}
+	}
+	go attempt(false)
+
+	timer := time.NewTimer(h.cfg.Delay)
+	defer timer.Stop()
+	select {
+	case r := <-results:
+		return r.value, r.err
+	case <-timer.C:
+	}
+
+	release, reason := h.acquire()
+	if reason != "" {
+		hedgesSkipped.WithLabelValues(operation, reason).Inc()
+		r := <-results
+		return r.value, r.err
+	}
+	hedgesSent.WithLabelValues(operation).Inc()
+	go func() {
+		defer release()
+		attempt(true)
+	}()
+
+	r := <-results
+	winner := hedgeWinnerPrimary
+	if r.hedge {
+		winner = hedgeWinnerHedge
+	}
+	hedgeWins.WithLabelValues(operation, winner).Inc()
+	return r.value, r.err
+}
@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// slowFirstRead returns a read whose first call blocks until its context is
+// cancelled and whose later calls answer immediately.
+func slowFirstRead(calls *atomic.Int32) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return "fast", nil
+	}
+}
+
+func TestHedgedRead(t *testing.T) {
+	const delay = 10 * time.Millisecond
+
+	t.Run("disabled", func(t *testing.T) {
+		var calls atomic.Int32
+		got, err := hedgedRead(context.Background(), nil, "TestDisabled", func(context.Context) (string, error) {
+			calls.Add(1)
+			return "value", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "value", got)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("fast primary is not hedged", func(t *testing.T) {
+		var calls atomic.Int32
+		h := &hedger{cfg: HedgingConfig{Delay: time.Second}}
+		got, err := hedgedRead(context.Background(), h, "TestFast", func(context.Context) (string, error) {
+			calls.Add(1)
+			return "primary", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "primary", got)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Zero(t, testutil.ToFloat64(hedgesSent.WithLabelValues("TestFast")))
+	})
+
+	t.Run("hedge wins over slow primary", func(t *testing.T) {
+		var calls atomic.Int32
+		h := &hedger{cfg: HedgingConfig{Delay: delay}}
+		got, err := hedgedRead(context.Background(), h, "TestHedgeWins", slowFirstRead(&calls))
+		require.NoError(t, err)
+		assert.Equal(t, "fast", got)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.InDelta(t, 1, testutil.ToFloat64(hedgesSent.WithLabelValues("TestHedgeWins")), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(hedgeWins.WithLabelValues("TestHedgeWins", hedgeWinnerHedge)), 0)
+		assert.Eventually(t, func() bool {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			return h.inFlight == 0
+		}, time.Second, time.Millisecond, "hedge reservation is released")
+	})
+
+	t.Run("in-flight cap", func(t *testing.T) {
+		h := &hedger{cfg: HedgingConfig{Delay: delay, MaxInFlight: 1}, inFlight: 1}
+		got, err := hedgedRead(context.Background(), h, "TestInFlight", func(context.Context) (string, error) {
+			time.Sleep(3 * delay)
+			return "primary", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "primary", got)
+		assert.InDelta(t, 1,
+			testutil.ToFloat64(hedgesSkipped.WithLabelValues("TestInFlight", hedgeSkipInFlight)), 0)
+		assert.Zero(t, testutil.ToFloat64(hedgesSent.WithLabelValues("TestInFlight")))
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		h := &hedger{cfg: HedgingConfig{Delay: delay, BudgetPercent: 50}, tokens: 0.5}
+		read := func(context.Context) (string, error) {
+			time.Sleep(3 * delay)
+			return "primary", nil
+		}
+		// The first read earns the missing half token and may hedge; the
+		// second has nothing left.
+		for range 2 {
+			_, err := hedgedRead(context.Background(), h, "TestBudget", read)
+			require.NoError(t, err)
+		}
+		assert.InDelta(t, 1, testutil.ToFloat64(hedgesSent.WithLabelValues("TestBudget")), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(hedgesSkipped.WithLabelValues("TestBudget", hedgeSkipBudget)), 0)
+	})
+}
+
+// slowNodes makes the first node list hang like a request stuck on a slow
+// API server replica. The fake clientset serializes calls, so the hang must
+// happen before reaching it.
+type slowNodes struct {
+	typedcorev1.NodeInterface
+	lists atomic.Int32
+}
+
+func (n *slowNodes) List(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error) {
+	if n.lists.Add(1) == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return n.NodeInterface.List(ctx, opts)
+}
+
+type slowCoreV1 struct {
+	typedcorev1.CoreV1Interface
+	nodes *slowNodes
+}
+
+func (c slowCoreV1) Nodes() typedcorev1.NodeInterface { return c.nodes }
+
+type slowClient struct {
+	k8sclient.Interface
+	core slowCoreV1
+}
+
+func (c slowClient) CoreV1() typedcorev1.CoreV1Interface { return c.core }
+
+func TestAdapter_HedgedNodeList(t *testing.T) {
+	fakeClient := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})
+	nodes := &slowNodes{NodeInterface: fakeClient.CoreV1().Nodes()}
+	client := slowClient{
+		Interface: fakeClient,
+		core:      slowCoreV1{CoreV1Interface: fakeClient.CoreV1(), nodes: nodes},
+	}
+
+	adp := NewForTesting(client, zap.NewNop())
+	adp.EnableHedging(HedgingConfig{Delay: 10 * time.Millisecond, MaxInFlight: 1})
+
+	listed, err := adp.listNodes(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "node-a", listed[0].Name)
+	assert.Equal(t, int32(2), nodes.lists.Load())
+}
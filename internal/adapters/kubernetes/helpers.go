@@ -6,7 +6,6 @@ import (
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // getNamespaceByID retrieves a Kubernetes namespace by ID or name.
@@ -22,9 +21,9 @@ func (a *Adapter) getNamespaceByID(ctx context.Context, id string) (*corev1.Name
 	}
 
 	// Get namespace from Kubernetes
-	namespace, err := a.client.CoreV1().Namespaces().Get(ctx, namespaceName, metav1.GetOptions{})
+	namespace, err := a.getNamespace(ctx, namespaceName)
 	if err != nil {
-		a.logger.Error("failed to get namespace",
+		a.log(ctx).Error("failed to get namespace",
 			zap.String("namespace", namespaceName),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to get Kubernetes namespace %s: %w", namespaceName, err)
@@ -46,9 +45,9 @@ func (a *Adapter) getNodeByID(ctx context.Context, id string) (*corev1.Node, err
 	}
 
 	// Get node from Kubernetes
-	node, err := a.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	node, err := a.getNode(ctx, nodeName)
 	if err != nil {
-		a.logger.Error("failed to get node",
+		a.log(ctx).Error("failed to get node",
 			zap.String("node", nodeName),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to get Kubernetes node %s: %w", nodeName, err)
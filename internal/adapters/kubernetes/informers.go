@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// DefaultInformerResync is the informer resync period used when none is configured.
+const DefaultInformerResync = 10 * time.Minute
+
+// informerCache serves node and namespace reads from watch-backed informers
+// instead of the API server.
+type informerCache struct {
+	factory    informers.SharedInformerFactory
+	nodes      corelisters.NodeLister
+	namespaces corelisters.NamespaceLister
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+}
+
+func (c *informerCache) stop() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+}
+
+// StartInformers starts watching nodes and namespaces and waits until the
+// informer caches are synced. From then on ListResources, ListResourcePools,
+// ListResourceTypes and the matching Get calls are served from memory, and
+// InformerFactory returns the factory so other components (such as the
+// subscription controller) can share the watches. ctx bounds only the initial
+// sync; the informers run until Close is called.
+func (a *Adapter) StartInformers(ctx context.Context, resync time.Duration) error {
+	if a.informers != nil {
+		return nil
+	}
+	if resync <= 0 {
+		resync = DefaultInformerResync
+	}
+
+	factory := informers.NewSharedInformerFactory(a.client, resync)
+	ic := &informerCache{
+		factory:    factory,
+		nodes:      factory.Core().V1().Nodes().Lister(),
+		namespaces: factory.Core().V1().Namespaces().Lister(),
+		stopCh:     make(chan struct{}),
+	}
+
+	start := time.Now()
+	factory.Start(ic.stopCh)
+
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			ic.stop()
+			return fmt.Errorf("failed to sync %v informer cache: %w", informerType, ctx.Err())
+		}
+	}
+
+	a.informers = ic
+	a.logger.Info("Kubernetes informer caches synced",
+		zap.Duration("duration", time.Since(start)),
+		zap.Duration("resync", resync))
+	return nil
+}
+
+// InformerFactory returns the shared informer factory started by
+// StartInformers, or nil if the informers are not running.
+func (a *Adapter) InformerFactory() informers.SharedInformerFactory {
+	if a.informers == nil {
+		return nil
+	}
+	return a.informers.factory
+}
+
+// listNodes returns the nodes matching selector, sorted by name as the API
+// server returns them so pagination is stable.
+func (a *Adapter) listNodes(ctx context.Context, selector string) ([]*corev1.Node, error) {
+	if a.informers != nil {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+		}
+		nodes, err := a.informers.nodes.List(sel)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+		return nodes, nil
+	}
+
+	list, err := hedgedRead(ctx, a.hedger, "ListNodes", func(ctx context.Context) (*corev1.NodeList, error) {
+		return a.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*corev1.Node, len(list.Items))
+	for i := range list.Items {
+		nodes[i] = &list.Items[i]
+	}
+	return nodes, nil
+}
+
+// listNamespaces returns the namespaces matching selector, sorted by name.
+func (a *Adapter) listNamespaces(ctx context.Context, selector string) ([]*corev1.Namespace, error) {
+	if a.informers != nil {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+		}
+		namespaces, err := a.informers.namespaces.List(sel)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+		return namespaces, nil
+	}
+
+	list, err := hedgedRead(ctx, a.hedger, "ListNamespaces", func(ctx context.Context) (*corev1.NamespaceList, error) {
+		return a.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	})
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]*corev1.Namespace, len(list.Items))
+	for i := range list.Items {
+		namespaces[i] = &list.Items[i]
+	}
+	return namespaces, nil
+}
+
+// getNode returns the named node. Objects from the informer cache are shared
+// and must not be modified.
+func (a *Adapter) getNode(ctx context.Context, name string) (*corev1.Node, error) {
+	if a.informers != nil {
+		return a.informers.nodes.Get(name)
+	}
+	return hedgedRead(ctx, a.hedger, "GetNode", func(ctx context.Context) (*corev1.Node, error) {
+		return a.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	})
+}
+
+// getNamespace returns the named namespace. Objects from the informer cache
+// are shared and must not be modified.
+func (a *Adapter) getNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	if a.informers != nil {
+		return a.informers.namespaces.Get(name)
+	}
+	return hedgedRead(ctx, a.hedger, "GetNamespace", func(ctx context.Context) (*corev1.Namespace, error) {
+		return a.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	})
+}
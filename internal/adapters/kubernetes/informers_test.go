@@ -0,0 +1,100 @@
+package kubernetes_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	adapterapi "github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/adapters/kubernetes"
+)
+
+func TestAdapter_InformerCache(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-b",
+			Labels: map[string]string{"o2ims.io/resource-pool": "edge"},
+		}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "edge"}},
+	)
+	adp := kubernetes.NewForTesting(client, zap.NewNop())
+	t.Cleanup(func() { _ = adp.Close() })
+
+	assert.Nil(t, adp.InformerFactory())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, adp.StartInformers(ctx, 0))
+	require.NotNil(t, adp.InformerFactory())
+
+	// Count API server reads from here on; cached reads must not hit it.
+	var apiReads atomic.Int32
+	client.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetVerb() == "list" || action.GetVerb() == "get" {
+			apiReads.Add(1)
+		}
+		return false, nil, nil
+	})
+
+	resources, err := adp.ListResources(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, "k8s-node-node-a", resources[0].ResourceID, "cached nodes are sorted by name")
+	assert.Equal(t, "k8s-node-node-b", resources[1].ResourceID)
+
+	filtered, err := adp.ListResources(ctx, &adapterapi.Filter{ResourcePoolID: "k8s-namespace-edge"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "k8s-node-node-b", filtered[0].ResourceID)
+
+	resource, err := adp.GetResource(ctx, "k8s-node-node-a")
+	require.NoError(t, err)
+	assert.Equal(t, "k8s-node-node-a", resource.ResourceID)
+
+	_, err = adp.GetResource(ctx, "k8s-node-missing")
+	require.Error(t, err)
+
+	pools, err := adp.ListResourcePools(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, "k8s-namespace-edge", pools[0].ResourcePoolID)
+
+	assert.Zero(t, apiReads.Load(), "reads are served from the informer cache")
+
+	// Changes reach the cache through the watch.
+	_, err = client.CoreV1().Nodes().Create(ctx,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		resources, err := adp.ListResources(ctx, nil)
+		return err == nil && len(resources) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAdapter_StartInformers_SyncTimeout(t *testing.T) {
+	client := fake.NewClientset()
+	client.PrependReactor("list", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("api server unavailable")
+	})
+	adp := kubernetes.NewForTesting(client, zap.NewNop())
+	t.Cleanup(func() { _ = adp.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := adp.StartInformers(ctx, time.Minute)
+	require.Error(t, err)
+	assert.Nil(t, adp.InformerFactory(), "reads fall back to the API server")
+}
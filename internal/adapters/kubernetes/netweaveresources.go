@@ -0,0 +1,322 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+// NetweaveResource CRD identifiers.
+//
+// Resources created through the O2-IMS API are materialized as NetweaveResource
+// objects in the adapter namespace. The gateway only writes the spec; other
+// controllers fulfill the request and report progress through the status
+// subresource, which the gateway surfaces in the resource's
+// "o2ims.io/fulfillment" extension.
+const (
+	// NetweaveResourceGroup is the NetweaveResource API group.
+	NetweaveResourceGroup = "o2ims.io"
+
+	// NetweaveResourceVersion is the NetweaveResource API version.
+	NetweaveResourceVersion = "v1alpha1"
+
+	// NetweaveResourceKind is the NetweaveResource kind.
+	NetweaveResourceKind = "NetweaveResource"
+
+	// NetweaveResourceResource is the NetweaveResource resource name.
+	NetweaveResourceResource = "netweaveresources"
+)
+
+// Fulfillment phases reported by controllers in status.phase.
+const (
+	FulfillmentPhasePending      = "Pending"
+	FulfillmentPhaseProvisioning = "Provisioning"
+	FulfillmentPhaseReady        = "Ready"
+	FulfillmentPhaseFailed       = "Failed"
+)
+
+// NetweaveResourceGVR is the GroupVersionResource of the NetweaveResource CRD.
+var NetweaveResourceGVR = schema.GroupVersionResource{
+	Group:    NetweaveResourceGroup,
+	Version:  NetweaveResourceVersion,
+	Resource: NetweaveResourceResource,
+}
+
+// netweaveResourceName returns the object name for a resource ID.
+// Resource IDs are UUIDs, which are valid DNS-1123 subdomains once lowercased.
+func netweaveResourceName(id string) string {
+	return strings.ToLower(id)
+}
+
+// isNodeResourceID reports whether id refers to a node rather than a NetweaveResource.
+func isNodeResourceID(id string) bool {
+	return strings.HasPrefix(id, "k8s-node-")
+}
+
+// createNetweaveResource materializes a resource as a NetweaveResource object.
+func (a *Adapter) createNetweaveResource(
+	ctx context.Context,
+	resource *adapter.Resource,
+) (*adapter.Resource, error) {
+	if resource.ResourceID == "" {
+		return nil, fmt.Errorf("resource ID is required to create a %s", NetweaveResourceKind)
+	}
+	if err := a.checkResourceLimit(ctx, resource.TenantID); err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{
+		"resourceId":     resource.ResourceID,
+		"resourceTypeId": resource.ResourceTypeID,
+		"resourcePoolId": resource.ResourcePoolID,
+	}
+	if resource.GlobalAssetID != "" {
+		spec["globalAssetId"] = resource.GlobalAssetID
+	}
+	if resource.Description != "" {
+		spec["description"] = resource.Description
+	}
+	if len(resource.Extensions) > 0 {
+		extensions, err := specExtensions(resource.Extensions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource extensions: %w", err)
+		}
+		spec["extensions"] = extensions
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": NetweaveResourceGroup + "/" + NetweaveResourceVersion,
+			"kind":       NetweaveResourceKind,
+			"metadata": map[string]interface{}{
+				"name":      netweaveResourceName(resource.ResourceID),
+				"namespace": a.namespace,
+			},
+			"spec": spec,
+		},
+	}
+	obj.SetLabels(netweaveResourceLabels(resource))
+
+	created, err := a.dynamicClient.Resource(NetweaveResourceGVR).Namespace(a.namespace).
+		Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("resource %s: %w", resource.ResourceID, adapter.ErrResourceExists)
+		}
+		a.log(ctx).Error("failed to create NetweaveResource",
+			zap.String("resourceID", resource.ResourceID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to create %s %s: %w", NetweaveResourceKind, resource.ResourceID, err)
+	}
+
+	result := a.transformNetweaveResource(created)
+
+	a.log(ctx).Info("created resource",
+		zap.String("resourceID", result.ResourceID),
+		zap.String("resourceTypeID", result.ResourceTypeID))
+
+	return result, nil
+}
+
+// netweaveResourceLabels returns the labels used to select NetweaveResources.
+// IDs that are not valid label values are only recorded in the spec.
+func netweaveResourceLabels(resource *adapter.Resource) map[string]string {
+	labels := map[string]string{
+		"o2ims.io/managed": "true",
+	}
+	for key, value := range map[string]string{
+		"o2ims.io/resource-type":    resource.ResourceTypeID,
+		"o2ims.io/resource-pool-id": resource.ResourcePoolID,
+		labelTenantID:               resource.TenantID,
+	} {
+		if value != "" && len(validation.IsValidLabelValue(value)) == 0 {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// getNetweaveResource retrieves a NetweaveResource by resource ID.
+// It returns an error wrapping adapter.ErrResourceNotFound if the object or
+// the CRD does not exist.
+func (a *Adapter) getNetweaveResource(ctx context.Context, id string) (*unstructured.Unstructured, error) {
+	obj, err := hedgedRead(ctx, a.hedger, "GetNetweaveResource",
+		func(ctx context.Context) (*unstructured.Unstructured, error) {
+			return a.dynamicClient.Resource(NetweaveResourceGVR).Namespace(a.namespace).
+				Get(ctx, netweaveResourceName(id), metav1.GetOptions{})
+		})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("resource %s: %w", id, adapter.ErrResourceNotFound)
+		}
+		return nil, fmt.Errorf("failed to get %s %s: %w", NetweaveResourceKind, id, err)
+	}
+	return obj, nil
+}
+
+// listNetweaveResources lists NetweaveResources matching the filter.
+// A missing CRD is treated as an empty list so node listing keeps working in
+// clusters where the CRD is not installed.
+func (a *Adapter) listNetweaveResources(ctx context.Context, filter *adapter.Filter) ([]*adapter.Resource, error) {
+	if a.dynamicClient == nil {
+		return nil, nil
+	}
+
+	opts := metav1.ListOptions{}
+	if filter != nil && filter.TenantID != "" {
+		opts.LabelSelector = labelTenantID + "=" + filter.TenantID
+	}
+
+	list, err := hedgedRead(ctx, a.hedger, "ListNetweaveResources",
+		func(ctx context.Context) (*unstructured.UnstructuredList, error) {
+			return a.dynamicClient.Resource(NetweaveResourceGVR).Namespace(a.namespace).List(ctx, opts)
+		})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s objects: %w", NetweaveResourceKind, err)
+	}
+
+	resources := make([]*adapter.Resource, 0, len(list.Items))
+	for i := range list.Items {
+		resource := a.transformNetweaveResource(&list.Items[i])
+		if !adapter.MatchesFilter(filter, resource.ResourcePoolID, resource.ResourceTypeID, "", list.Items[i].GetLabels()) {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// updateNetweaveResource updates the mutable spec fields of a NetweaveResource.
+func (a *Adapter) updateNetweaveResource(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	resource *adapter.Resource,
+) (*adapter.Resource, error) {
+	if err := unstructured.SetNestedField(obj.Object, resource.Description, "spec", "description"); err != nil {
+		return nil, fmt.Errorf("failed to set description: %w", err)
+	}
+	if resource.Extensions != nil {
+		extensions, err := specExtensions(resource.Extensions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource extensions: %w", err)
+		}
+		if err := unstructured.SetNestedMap(obj.Object, extensions, "spec", "extensions"); err != nil {
+			return nil, fmt.Errorf("failed to set extensions: %w", err)
+		}
+	}
+
+	updated, err := a.dynamicClient.Resource(NetweaveResourceGVR).Namespace(a.namespace).
+		Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s %s: %w", NetweaveResourceKind, obj.GetName(), err)
+	}
+	return a.transformNetweaveResource(updated), nil
+}
+
+// deleteNetweaveResource deletes a NetweaveResource by resource ID. It returns
+// an error wrapping adapter.ErrResourceNotFound if the object does not exist.
+func (a *Adapter) deleteNetweaveResource(ctx context.Context, id string) error {
+	err := a.dynamicClient.Resource(NetweaveResourceGVR).Namespace(a.namespace).
+		Delete(ctx, netweaveResourceName(id), metav1.DeleteOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("resource %s: %w", id, adapter.ErrResourceNotFound)
+		}
+		return fmt.Errorf("failed to delete %s %s: %w", NetweaveResourceKind, id, err)
+	}
+
+	a.log(ctx).Info("deleted resource",
+		zap.String("resourceID", id))
+	return nil
+}
+
+// transformNetweaveResource converts a NetweaveResource to an O2-IMS Resource.
+// The fulfillment status written by controllers is exposed in the
+// "o2ims.io/fulfillment" extension; resources without a status are Pending.
+func (a *Adapter) transformNetweaveResource(obj *unstructured.Unstructured) *adapter.Resource {
+	resourceID, _, _ := unstructured.NestedString(obj.Object, "spec", "resourceId")
+	if resourceID == "" {
+		resourceID = obj.GetName()
+	}
+	resourceTypeID, _, _ := unstructured.NestedString(obj.Object, "spec", "resourceTypeId")
+	resourcePoolID, _, _ := unstructured.NestedString(obj.Object, "spec", "resourcePoolId")
+	globalAssetID, _, _ := unstructured.NestedString(obj.Object, "spec", "globalAssetId")
+	description, _, _ := unstructured.NestedString(obj.Object, "spec", "description")
+	extensions, _, _ := unstructured.NestedMap(obj.Object, "spec", "extensions")
+
+	if globalAssetID == "" {
+		globalAssetID = fmt.Sprintf("urn:k8s:netweaveresource:%s:%s", a.oCloudID, obj.GetUID())
+	}
+	if extensions == nil {
+		extensions = make(map[string]interface{})
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	if phase == "" {
+		phase = FulfillmentPhasePending
+	}
+	fulfillment := map[string]interface{}{
+		"phase": phase,
+	}
+	if message, _, _ := unstructured.NestedString(obj.Object, "status", "message"); message != "" {
+		fulfillment["message"] = message
+	}
+	if conditions, found, _ := unstructured.NestedSlice(obj.Object, "status", "conditions"); found {
+		fulfillment["conditions"] = conditions
+	}
+	if observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration"); found {
+		fulfillment["observedGeneration"] = observed
+		fulfillment["upToDate"] = observed >= obj.GetGeneration()
+	}
+
+	extensions["o2ims.io/fulfillment"] = fulfillment
+	extensions["kubernetes.io/kind"] = NetweaveResourceKind
+	extensions["kubernetes.io/name"] = obj.GetName()
+	extensions["kubernetes.io/creation-timestamp"] = obj.GetCreationTimestamp().Time
+
+	return &adapter.Resource{
+		ResourceID:     resourceID,
+		TenantID:       obj.GetLabels()[labelTenantID],
+		ResourceTypeID: resourceTypeID,
+		ResourcePoolID: resourcePoolID,
+		GlobalAssetID:  globalAssetID,
+		Description:    description,
+		Extensions:     extensions,
+	}
+}
+
+// specExtensions converts resource extensions to JSON-compatible types accepted
+// by unstructured objects (e.g. ints become float64), dropping the read-only
+// extensions the adapter derives from the object itself.
+func specExtensions(extensions map[string]interface{}) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(extensions))
+	for key, value := range extensions {
+		if key == "o2ims.io/fulfillment" || strings.HasPrefix(key, "kubernetes.io/") {
+			continue
+		}
+		m[key] = value
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	adapterapi "github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/adapters/kubernetes"
+)
+
+// newTestAdapterWithCRD creates a test adapter whose dynamic client serves NetweaveResources.
+func newTestAdapterWithCRD(t *testing.T) (*kubernetes.Adapter, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{kubernetes.NetweaveResourceGVR: "NetweaveResourceList"},
+	)
+	adp := newTestAdapter(t)
+	adp.SetDynamicClient(dynamicClient)
+	return adp, dynamicClient
+}
+
+func TestKubernetesAdapter_NetweaveResourceLifecycle(t *testing.T) {
+	const resourceID = "550E8400-e29b-41d4-a716-446655440000"
+
+	adp, dynamicClient := newTestAdapterWithCRD(t)
+	ctx := context.Background()
+
+	created, err := adp.CreateResource(ctx, &adapterapi.Resource{
+		ResourceID:     resourceID,
+		TenantID:       "tenant-a",
+		ResourceTypeID: "bare-metal-server",
+		ResourcePoolID: "pool-1",
+		Description:    "Edge server",
+		Extensions:     map[string]interface{}{"cpuCores": 32},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resourceID, created.ResourceID)
+	assert.Equal(t, "tenant-a", created.TenantID)
+	assert.Equal(t, float64(32), created.Extensions["cpuCores"])
+	assert.Equal(t, kubernetes.FulfillmentPhasePending,
+		created.Extensions["o2ims.io/fulfillment"].(map[string]interface{})["phase"])
+
+	// The object is written for controllers to fulfill.
+	crClient := dynamicClient.Resource(kubernetes.NetweaveResourceGVR).Namespace(adp.GetNamespace())
+	obj, err := crClient.Get(ctx, "550e8400-e29b-41d4-a716-446655440000", metav1.GetOptions{})
+	require.NoError(t, err)
+	typeID, _, _ := unstructured.NestedString(obj.Object, "spec", "resourceTypeId")
+	assert.Equal(t, "bare-metal-server", typeID)
+	assert.Equal(t, "pool-1", obj.GetLabels()["o2ims.io/resource-pool-id"])
+
+	// Creating the same resource twice reports a conflict.
+	_, err = adp.CreateResource(ctx, &adapterapi.Resource{ResourceID: resourceID, ResourceTypeID: "bare-metal-server"})
+	require.ErrorIs(t, err, adapterapi.ErrResourceExists)
+
+	// A controller reports fulfillment through the status subresource.
+	require.NoError(t, unstructured.SetNestedMap(obj.Object, map[string]interface{}{
+		"phase":              kubernetes.FulfillmentPhaseReady,
+		"message":            "Host provisioned",
+		"observedGeneration": int64(1),
+	}, "status"))
+	_, err = crClient.UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	got, err := adp.GetResource(ctx, resourceID)
+	require.NoError(t, err)
+	fulfillment := got.Extensions["o2ims.io/fulfillment"].(map[string]interface{})
+	assert.Equal(t, kubernetes.FulfillmentPhaseReady, fulfillment["phase"])
+	assert.Equal(t, "Host provisioned", fulfillment["message"])
+	assert.Equal(t, "Edge server", got.Description)
+
+	// Updates change the spec without persisting derived extensions.
+	got.Description = "Edge server (rack 4)"
+	updated, err := adp.UpdateResource(ctx, resourceID, got)
+	require.NoError(t, err)
+	assert.Equal(t, "Edge server (rack 4)", updated.Description)
+	obj, err = crClient.Get(ctx, "550e8400-e29b-41d4-a716-446655440000", metav1.GetOptions{})
+	require.NoError(t, err)
+	specExtensions, _, _ := unstructured.NestedMap(obj.Object, "spec", "extensions")
+	assert.Equal(t, map[string]interface{}{"cpuCores": float64(32)}, specExtensions)
+
+	// Listing returns nodes and NetweaveResources together, honoring filters.
+	_, err = adp.GetClient().CoreV1().Nodes().Create(ctx, &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	all, err := adp.ListResources(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	filtered, err := adp.ListResources(ctx, &adapterapi.Filter{ResourceTypeID: "bare-metal-server"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, resourceID, filtered[0].ResourceID)
+	otherTenant, err := adp.ListResources(ctx, &adapterapi.Filter{TenantID: "tenant-b"})
+	require.NoError(t, err)
+	assert.Empty(t, otherTenant)
+
+	// Deleting removes the object so the controller can release the resource.
+	require.NoError(t, adp.DeleteResource(ctx, resourceID))
+	_, err = crClient.Get(ctx, "550e8400-e29b-41d4-a716-446655440000", metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = adp.GetResource(ctx, resourceID)
+	require.Error(t, err)
+}
+
+func TestKubernetesAdapter_CreateResourceWithoutDynamicClient(t *testing.T) {
+	adp := newTestAdapter(t)
+
+	_, err := adp.CreateResource(context.Background(), &adapterapi.Resource{
+		ResourceID:     "550e8400-e29b-41d4-a716-446655440000",
+		ResourceTypeID: "bare-metal-server",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+}
@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+// Object kinds reported in capacity errors and the object limit metrics.
+const (
+	objectKindResourcePool = "resource pool"
+	objectKindResource     = "resource"
+)
+
+// DefaultObjectLimitWarnRatio is the share of a ceiling at which creations
+// are reported as near the limit when ObjectLimits.WarnRatio is zero.
+const DefaultObjectLimitWarnRatio = 0.8
+
+var (
+	objectLimitUtilization = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "kubernetes",
+			Name:      "object_limit_utilization_ratio",
+			Help:      "Objects created through the gateway as a share of the configured ceiling, per tenant",
+		},
+		[]string{"kind", "tenant"},
+	)
+
+	objectLimitNear = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "kubernetes",
+			Name:      "object_limit_near_total",
+			Help:      "Creations that brought a tenant to within the warning ratio of an object ceiling",
+		},
+		[]string{"kind"},
+	)
+
+	objectLimitRejections = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "kubernetes",
+			Name:      "object_limit_rejections_total",
+			Help:      "Creations refused because a tenant reached an object ceiling",
+		},
+		[]string{"kind"},
+	)
+)
+
+// ObjectLimits caps the objects created through the gateway per tenant, so
+// a misbehaving client cannot flood the cluster.
+type ObjectLimits struct {
+	// MaxResourcePools caps the namespaces a tenant may own.
+	// Zero means no limit.
+	MaxResourcePools int
+
+	// MaxResources caps the NetweaveResource objects a tenant may own in
+	// the adapter namespace. Zero means no limit.
+	MaxResources int
+
+	// WarnRatio is the share of a ceiling from which creations are counted
+	// as near the limit. Zero means DefaultObjectLimitWarnRatio.
+	WarnRatio float64
+}
+
+// SetObjectLimits makes CreateResourcePool and CreateResource count the
+// tenant's existing objects first and refuse the creation with an
+// *adapter.CapacityError once the ceiling is reached. Requests without a
+// tenant are counted against all objects created through the gateway.
+func (a *Adapter) SetObjectLimits(limits ObjectLimits) {
+	if limits.WarnRatio <= 0 {
+		limits.WarnRatio = DefaultObjectLimitWarnRatio
+	}
+	a.objectLimits = limits
+}
+
+// checkObjectLimit returns an *adapter.CapacityError if count objects of
+// kind already reach limit, and records the tenant's utilization otherwise.
+func (a *Adapter) checkObjectLimit(ctx context.Context, kind, tenantID string, count, limit int) error {
+	if count >= limit {
+		objectLimitUtilization.WithLabelValues(kind, tenantID).Set(float64(count) / float64(limit))
+		objectLimitRejections.WithLabelValues(kind).Inc()
+		a.log(ctx).Warn("object ceiling reached; refusing creation",
+			zap.String("kind", kind),
+			zap.String("tenantID", tenantID),
+			zap.Int("count", count),
+			zap.Int("limit", limit))
+		return &adapter.CapacityError{Kind: kind, TenantID: tenantID, Count: count, Limit: limit}
+	}
+
+	// Utilization once this creation succeeds.
+	utilization := float64(count+1) / float64(limit)
+	objectLimitUtilization.WithLabelValues(kind, tenantID).Set(utilization)
+	if utilization >= a.objectLimits.WarnRatio {
+		objectLimitNear.WithLabelValues(kind).Inc()
+		a.log(ctx).Warn("tenant is near an object ceiling",
+			zap.String("kind", kind),
+			zap.String("tenantID", tenantID),
+			zap.Int("count", count+1),
+			zap.Int("limit", limit))
+	}
+	return nil
+}
+
+// tenantSelector selects the objects created through the gateway for the
+// tenant. Tenant IDs that are not valid label values are never recorded as
+// labels, so their objects are counted with everyone else's.
+func tenantSelector(tenantID string) string {
+	selector := "o2ims.io/managed=true"
+	if tenantID != "" && len(validation.IsValidLabelValue(tenantID)) == 0 {
+		selector += "," + labelTenantID + "=" + tenantID
+	}
+	return selector
+}
+
+// checkResourcePoolLimit enforces ObjectLimits.MaxResourcePools.
+func (a *Adapter) checkResourcePoolLimit(ctx context.Context, tenantID string) error {
+	limit := a.objectLimits.MaxResourcePools
+	if limit <= 0 {
+		return nil
+	}
+	namespaces, err := a.listNamespaces(ctx, tenantSelector(tenantID))
+	if err != nil {
+		return fmt.Errorf("failed to count resource pools: %w", err)
+	}
+	return a.checkObjectLimit(ctx, objectKindResourcePool, tenantID, len(namespaces), limit)
+}
+
+// checkResourceLimit enforces ObjectLimits.MaxResources.
+func (a *Adapter) checkResourceLimit(ctx context.Context, tenantID string) error {
+	limit := a.objectLimits.MaxResources
+	if limit <= 0 {
+		return nil
+	}
+	count, err := hedgedRead(ctx, a.hedger, "CountNetweaveResources", func(ctx context.Context) (int, error) {
+		list, err := a.dynamicClient.Resource(NetweaveResourceGVR).Namespace(a.namespace).
+			List(ctx, metav1.ListOptions{LabelSelector: tenantSelector(tenantID)})
+		if apierrors.IsNotFound(err) {
+			// No CRD, no objects.
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		return len(list.Items), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count resources: %w", err)
+	}
+	return a.checkObjectLimit(ctx, objectKindResource, tenantID, count, limit)
+}
@@ -0,0 +1,71 @@
+package kubernetes_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adapterapi "github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/adapters/kubernetes"
+)
+
+func TestKubernetesAdapter_ObjectLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resource pools", func(t *testing.T) {
+		adp := newTestAdapter(t)
+		adp.SetObjectLimits(kubernetes.ObjectLimits{MaxResourcePools: 2})
+
+		for i := range 2 {
+			_, err := adp.CreateResourcePool(ctx, &adapterapi.ResourcePool{
+				Name: fmt.Sprintf("tenant-a-pool-%d", i), TenantID: "tenant-a",
+			})
+			require.NoError(t, err)
+		}
+
+		_, err := adp.CreateResourcePool(ctx, &adapterapi.ResourcePool{Name: "tenant-a-pool-2", TenantID: "tenant-a"})
+		require.ErrorIs(t, err, adapterapi.ErrCapacityExceeded)
+		var capacityErr *adapterapi.CapacityError
+		require.True(t, errors.As(err, &capacityErr))
+		assert.Equal(t, adapterapi.CapacityError{
+			Kind: "resource pool", TenantID: "tenant-a", Count: 2, Limit: 2,
+		}, *capacityErr)
+		assert.EqualError(t, err,
+			`resource pool capacity exceeded for tenant "tenant-a": 2 of 2 allowed already exist`)
+
+		// Other tenants have their own ceiling.
+		_, err = adp.CreateResourcePool(ctx, &adapterapi.ResourcePool{Name: "tenant-b-pool-0", TenantID: "tenant-b"})
+		require.NoError(t, err)
+	})
+
+	t.Run("resources", func(t *testing.T) {
+		adp, _ := newTestAdapterWithCRD(t)
+		adp.SetObjectLimits(kubernetes.ObjectLimits{MaxResources: 1})
+
+		newResource := func(id, tenantID string) *adapterapi.Resource {
+			return &adapterapi.Resource{
+				ResourceID: id, TenantID: tenantID, ResourceTypeID: "server", ResourcePoolID: "pool-1",
+			}
+		}
+		_, err := adp.CreateResource(ctx, newResource("550e8400-e29b-41d4-a716-446655440001", "tenant-a"))
+		require.NoError(t, err)
+
+		_, err = adp.CreateResource(ctx, newResource("550e8400-e29b-41d4-a716-446655440002", "tenant-a"))
+		require.ErrorIs(t, err, adapterapi.ErrCapacityExceeded)
+
+		_, err = adp.CreateResource(ctx, newResource("550e8400-e29b-41d4-a716-446655440003", "tenant-b"))
+		require.NoError(t, err)
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		adp := newTestAdapter(t)
+		for i := range 3 {
+			_, err := adp.CreateResourcePool(ctx, &adapterapi.ResourcePool{Name: fmt.Sprintf("pool-%d", i)})
+			require.NoError(t, err)
+		}
+	})
+}
@@ -17,7 +17,7 @@ func (a *Adapter) ListResourcePools(
 	ctx context.Context,
 	filter *adapter.Filter,
 ) ([]*adapter.ResourcePool, error) {
-	a.logger.Debug("ListResourcePools called",
+	a.log(ctx).Debug("ListResourcePools called",
 		zap.Any("filter", filter))
 
 	// Build label selector for tenant filtering (multi-tenancy)
@@ -27,30 +27,28 @@ func (a *Adapter) ListResourcePools(
 	}
 
 	// List namespaces with optional tenant label selector
-	namespaces, err := a.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+	namespaces, err := a.listNamespaces(ctx, labelSelector)
 	if err != nil {
-		a.logger.Error("failed to list namespaces",
+		a.log(ctx).Error("failed to list namespaces",
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to list Kubernetes namespaces: %w", err)
 	}
 
-	a.logger.Debug("retrieved namespaces from Kubernetes",
-		zap.Int("count", len(namespaces.Items)))
+	a.log(ctx).Debug("retrieved namespaces from Kubernetes",
+		zap.Int("count", len(namespaces)))
 
 	// Transform Kubernetes namespaces to O2-IMS Resource Pools
-	pools := make([]*adapter.ResourcePool, 0, len(namespaces.Items))
-	for i := range namespaces.Items {
-		pool := a.transformNamespaceToResourcePool(&namespaces.Items[i])
+	pools := make([]*adapter.ResourcePool, 0, len(namespaces))
+	for _, ns := range namespaces {
+		pool := a.transformNamespaceToResourcePool(ns)
 
 		// Apply filter
 		location := ""
-		if val, ok := namespaces.Items[i].Labels["topology.kubernetes.io/zone"]; ok {
+		if val, ok := ns.Labels["topology.kubernetes.io/zone"]; ok {
 			location = val
 		}
 
-		if adapter.MatchesFilter(filter, pool.ResourcePoolID, "", location, namespaces.Items[i].Labels) {
+		if adapter.MatchesFilter(filter, pool.ResourcePoolID, "", location, ns.Labels) {
 			pools = append(pools, pool)
 		}
 	}
@@ -60,7 +58,7 @@ func (a *Adapter) ListResourcePools(
 		pools = adapter.ApplyPagination(pools, filter.Limit, filter.Offset)
 	}
 
-	a.logger.Info("listed resource pools",
+	a.log(ctx).Info("listed resource pools",
 		zap.Int("count", len(pools)))
 
 	return pools, nil
@@ -68,7 +66,7 @@ func (a *Adapter) ListResourcePools(
 
 // GetResourcePool retrieves a specific Kubernetes namespace by name and transforms it to O2-IMS Resource Pool.
 func (a *Adapter) GetResourcePool(ctx context.Context, id string) (*adapter.ResourcePool, error) {
-	a.logger.Debug("GetResourcePool called",
+	a.log(ctx).Debug("GetResourcePool called",
 		zap.String("id", id))
 
 	// Get namespace from Kubernetes using helper
@@ -80,7 +78,7 @@ func (a *Adapter) GetResourcePool(ctx context.Context, id string) (*adapter.Reso
 	// Transform to O2-IMS Resource Pool
 	pool := a.transformNamespaceToResourcePool(namespace)
 
-	a.logger.Info("retrieved resource pool",
+	a.log(ctx).Info("retrieved resource pool",
 		zap.String("resourcePoolID", pool.ResourcePoolID),
 		zap.String("name", pool.Name))
 
@@ -92,9 +90,13 @@ func (a *Adapter) CreateResourcePool(
 	ctx context.Context,
 	pool *adapter.ResourcePool,
 ) (*adapter.ResourcePool, error) {
-	a.logger.Debug("CreateResourcePool called",
+	a.log(ctx).Debug("CreateResourcePool called",
 		zap.String("name", pool.Name))
 
+	if err := a.checkResourcePoolLimit(ctx, pool.TenantID); err != nil {
+		return nil, err
+	}
+
 	// Create namespace specification
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -126,7 +128,7 @@ func (a *Adapter) CreateResourcePool(
 	// Create the namespace
 	created, err := a.client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
 	if err != nil {
-		a.logger.Error("failed to create namespace",
+		a.log(ctx).Error("failed to create namespace",
 			zap.String("name", pool.Name),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to create Kubernetes namespace: %w", err)
@@ -135,7 +137,7 @@ func (a *Adapter) CreateResourcePool(
 	// Transform created namespace back to O2-IMS Resource Pool
 	result := a.transformNamespaceToResourcePool(created)
 
-	a.logger.Info("created resource pool",
+	a.log(ctx).Info("created resource pool",
 		zap.String("resourcePoolID", result.ResourcePoolID),
 		zap.String("name", result.Name))
 
@@ -149,7 +151,7 @@ func (a *Adapter) UpdateResourcePool(
 	id string,
 	pool *adapter.ResourcePool,
 ) (*adapter.ResourcePool, error) {
-	a.logger.Debug("UpdateResourcePool called",
+	a.log(ctx).Debug("UpdateResourcePool called",
 		zap.String("id", id),
 		zap.String("name", pool.Name))
 
@@ -163,7 +165,7 @@ func (a *Adapter) UpdateResourcePool(
 	// Get existing namespace
 	namespace, err := a.client.CoreV1().Namespaces().Get(ctx, namespaceName, metav1.GetOptions{})
 	if err != nil {
-		a.logger.Error("failed to get namespace for update",
+		a.log(ctx).Error("failed to get namespace for update",
 			zap.String("namespace", namespaceName),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to get Kubernetes namespace %s: %w", namespaceName, err)
@@ -190,7 +192,7 @@ func (a *Adapter) UpdateResourcePool(
 	// Update the namespace
 	updated, err := a.client.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
 	if err != nil {
-		a.logger.Error("failed to update namespace",
+		a.log(ctx).Error("failed to update namespace",
 			zap.String("namespace", namespaceName),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to update Kubernetes namespace: %w", err)
@@ -199,7 +201,7 @@ func (a *Adapter) UpdateResourcePool(
 	// Transform updated namespace back to O2-IMS Resource Pool
 	result := a.transformNamespaceToResourcePool(updated)
 
-	a.logger.Info("updated resource pool",
+	a.log(ctx).Info("updated resource pool",
 		zap.String("resourcePoolID", result.ResourcePoolID),
 		zap.String("name", result.Name))
 
@@ -208,7 +210,7 @@ func (a *Adapter) UpdateResourcePool(
 
 // DeleteResourcePool deletes a Kubernetes namespace by ID.
 func (a *Adapter) DeleteResourcePool(ctx context.Context, id string) error {
-	a.logger.Debug("DeleteResourcePool called",
+	a.log(ctx).Debug("DeleteResourcePool called",
 		zap.String("id", id))
 
 	// Parse resource pool ID to extract namespace name
@@ -221,13 +223,13 @@ func (a *Adapter) DeleteResourcePool(ctx context.Context, id string) error {
 	// Delete the namespace
 	err = a.client.CoreV1().Namespaces().Delete(ctx, namespaceName, metav1.DeleteOptions{})
 	if err != nil {
-		a.logger.Error("failed to delete namespace",
+		a.log(ctx).Error("failed to delete namespace",
 			zap.String("namespace", namespaceName),
 			zap.Error(err))
 		return fmt.Errorf("failed to delete Kubernetes namespace %s: %w", namespaceName, err)
 	}
 
-	a.logger.Info("deleted resource pool",
+	a.log(ctx).Info("deleted resource pool",
 		zap.String("namespace", namespaceName))
 
 	return nil
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,9 @@ import (
 	"github.com/piwi3910/netweave/internal/adapter"
 )
 
+// nvidiaGPUResource is the extended resource advertised by the NVIDIA device plugin.
+const nvidiaGPUResource corev1.ResourceName = "nvidia.com/gpu"
+
 // ListResources retrieves all Kubernetes nodes and transforms them to O2-IMS Resources.
 // Nodes in Kubernetes are compute resources, which map to O2-IMS Resources.
 func (a *Adapter) ListResources(
@@ -26,49 +30,60 @@ func (a *Adapter) ListResources(
 		adapter.ObserveOperationWithTracing(a.Name(), "ListResources", span, start, err)
 	}()
 
-	a.logger.Debug("ListResources called",
+	a.log(ctx).Debug("ListResources called",
 		zap.Any("filter", filter))
 
-	// Build label selector for tenant filtering (multi-tenancy)
-	labelSelector := ""
-	if filter != nil && filter.TenantID != "" {
-		labelSelector = fmt.Sprintf("o2ims.io/tenant-id=%s", filter.TenantID)
+	// Push supported filter predicates down to the API server as a label selector
+	// (including tenant isolation); only residual predicates are evaluated in memory
+	plan, planErr := planNodeList(filter)
+	if planErr != nil {
+		err = planErr
+		return nil, err
 	}
 
+	a.log(ctx).Debug("planned node list",
+		zap.String("labelSelector", plan.LabelSelector),
+		zap.Bool("residualFilter", plan.Residual != nil))
+
 	// Record backend API call timing
 	backendStart := time.Now()
-	// List nodes with optional tenant label selector
-	nodes, listErr := a.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+	// List nodes with the pushed-down label selector
+	nodes, listErr := a.listNodes(ctx, plan.LabelSelector)
 	adapter.ObserveBackendRequest(a.Name(), "/api/v1/nodes", "LIST", backendStart, 200, listErr)
 	adapter.RecordBackendCall(span, "/api/v1/nodes", "LIST", 200)
 
 	if listErr != nil {
 		err = fmt.Errorf("failed to list Kubernetes nodes: %w", listErr)
-		a.logger.Error("failed to list nodes",
+		a.log(ctx).Error("failed to list nodes",
 			zap.Error(err))
 		return nil, err
 	}
 
-	a.logger.Debug("retrieved nodes from Kubernetes",
-		zap.Int("count", len(nodes.Items)))
+	a.log(ctx).Debug("retrieved nodes from Kubernetes",
+		zap.Int("count", len(nodes)))
 
 	// Transform Kubernetes nodes to O2-IMS Resources
-	resources := make([]*adapter.Resource, 0, len(nodes.Items))
-	for i := range nodes.Items {
-		resource := a.transformNodeToResource(&nodes.Items[i])
-
-		// Apply filter
-		resourcePoolID := ""
-		if namespace, ok := nodes.Items[i].Labels["o2ims.io/resource-pool"]; ok {
-			resourcePoolID = fmt.Sprintf("k8s-namespace-%s", namespace)
+	resources := make([]*adapter.Resource, 0, len(nodes))
+	for _, node := range nodes {
+		resource := a.transformNodeToResource(node)
+
+		// Apply residual filter
+		if plan.Residual != nil &&
+			!adapter.MatchesFilter(plan.Residual, resource.ResourcePoolID, resource.ResourceTypeID, "", node.Labels) {
+			continue
 		}
+		resources = append(resources, resource)
+	}
 
-		if adapter.MatchesFilter(filter, resourcePoolID, resource.ResourceTypeID, "", nodes.Items[i].Labels) {
-			resources = append(resources, resource)
-		}
+	// Include resources materialized as NetweaveResource objects
+	crResources, crErr := a.listNetweaveResources(ctx, filter)
+	if crErr != nil {
+		err = crErr
+		a.log(ctx).Error("failed to list NetweaveResources",
+			zap.Error(err))
+		return nil, err
 	}
+	resources = append(resources, crResources...)
 
 	// Apply pagination
 	if filter != nil {
@@ -84,7 +99,7 @@ func (a *Adapter) ListResources(
 		"filtered":       filter != nil,
 	})
 
-	a.logger.Info("listed resources",
+	a.log(ctx).Info("listed resources",
 		zap.Int("count", len(resources)))
 
 	return resources, nil
@@ -102,17 +117,32 @@ func (a *Adapter) GetResource(ctx context.Context, id string) (*adapter.Resource
 
 	adapter.RecordResourceOperation(span, "node", "get", id)
 
-	a.logger.Debug("GetResource called",
+	a.log(ctx).Debug("GetResource called",
 		zap.String("id", id))
 
-	// Get node from Kubernetes using helper
-	node, err := a.getNodeByID(ctx, id)
-	if err != nil {
-		return nil, err
+	var resource *adapter.Resource
+	if a.dynamicClient != nil && !isNodeResourceID(id) {
+		// Resources created through the API are NetweaveResource objects
+		obj, crErr := a.getNetweaveResource(ctx, id)
+		if crErr == nil {
+			resource = a.transformNetweaveResource(obj)
+		} else if !errors.Is(crErr, adapter.ErrResourceNotFound) {
+			err = crErr
+			return nil, err
+		}
 	}
 
-	// Transform to O2-IMS Resource
-	resource := a.transformNodeToResource(node)
+	if resource == nil {
+		// Get node from Kubernetes using helper
+		node, nodeErr := a.getNodeByID(ctx, id)
+		if nodeErr != nil {
+			err = nodeErr
+			return nil, err
+		}
+
+		// Transform to O2-IMS Resource
+		resource = a.transformNodeToResource(node)
+	}
 
 	adapter.RecordSuccess(span, 1)
 	adapter.AddAttributes(span, map[string]interface{}{
@@ -120,42 +150,55 @@ func (a *Adapter) GetResource(ctx context.Context, id string) (*adapter.Resource
 		"resource.type": resource.ResourceTypeID,
 	})
 
-	a.logger.Info("retrieved resource",
+	a.log(ctx).Info("retrieved resource",
 		zap.String("resourceID", resource.ResourceID),
 		zap.String("resourceTypeID", resource.ResourceTypeID))
 
 	return resource, nil
 }
 
-// CreateResource creates a new Kubernetes node.
-// Note: In Kubernetes, nodes are typically managed by the cluster infrastructure (kubelet).
-// This method is provided for completeness but may have limited practical use.
+// CreateResource materializes a resource request as a NetweaveResource object.
+// Nodes cannot be created directly since kubelet registers them when joining the
+// cluster; instead, controllers watching NetweaveResources fulfill the request
+// and report progress through the object's status.
 func (a *Adapter) CreateResource(
-	_ context.Context,
+	ctx context.Context,
 	resource *adapter.Resource,
 ) (*adapter.Resource, error) {
-	a.logger.Debug("CreateResource called",
+	a.log(ctx).Debug("CreateResource called",
 		zap.String("resourceTypeID", resource.ResourceTypeID))
 
-	// Creating nodes directly is not a standard Kubernetes operation
-	// Nodes are typically registered by kubelet when they join the cluster
-	// This implementation returns an error indicating the operation is not supported
-	return nil, fmt.Errorf(
-		"creating nodes directly is not supported in Kubernetes; " +
-			"nodes are registered by kubelet when joining the cluster",
-	)
+	if a.dynamicClient == nil {
+		return nil, fmt.Errorf(
+			"creating nodes directly is not supported in Kubernetes; " +
+				"nodes are registered by kubelet when joining the cluster",
+		)
+	}
+
+	return a.createNetweaveResource(ctx, resource)
 }
 
-// UpdateResource updates a Kubernetes node's mutable fields (labels, annotations).
-// Note: Core node properties are managed by kubelet and cannot be modified directly.
+// UpdateResource updates the description and extensions of a resource backed by
+// a NetweaveResource object.
+// Note: Nodes are managed by kubelet and cannot be modified through this method.
 func (a *Adapter) UpdateResource(
-	_ context.Context,
-	_ string,
+	ctx context.Context,
+	id string,
 	resource *adapter.Resource,
 ) (*adapter.Resource, error) {
-	a.logger.Debug("UpdateResource called",
+	a.log(ctx).Debug("UpdateResource called",
 		zap.String("resourceID", resource.ResourceID))
 
+	if a.dynamicClient != nil && !isNodeResourceID(id) {
+		obj, err := a.getNetweaveResource(ctx, id)
+		if err == nil {
+			return a.updateNetweaveResource(ctx, obj, resource)
+		}
+		if !errors.Is(err, adapter.ErrResourceNotFound) {
+			return nil, err
+		}
+	}
+
 	// Updating nodes directly is not a standard Kubernetes operation
 	// Nodes are managed by kubelet and controllers
 	// This implementation returns an error indicating the operation is not supported
@@ -165,12 +208,20 @@ func (a *Adapter) UpdateResource(
 	)
 }
 
-// DeleteResource deletes a Kubernetes node by ID.
-// This drains the node and then removes it from the cluster.
+// DeleteResource deletes a resource by ID. Resources backed by NetweaveResource
+// objects are deleted by removing the object; otherwise the node is removed from
+// the cluster.
 func (a *Adapter) DeleteResource(ctx context.Context, id string) error {
-	a.logger.Debug("DeleteResource called",
+	a.log(ctx).Debug("DeleteResource called",
 		zap.String("id", id))
 
+	if a.dynamicClient != nil && !isNodeResourceID(id) {
+		err := a.deleteNetweaveResource(ctx, id)
+		if err == nil || !errors.Is(err, adapter.ErrResourceNotFound) {
+			return err
+		}
+	}
+
 	// Parse resource ID to extract node name
 	var nodeName string
 	_, err := fmt.Sscanf(id, "k8s-node-%s", &nodeName)
@@ -183,13 +234,13 @@ func (a *Adapter) DeleteResource(ctx context.Context, id string) error {
 	// Note: This does NOT delete the actual machine, only its registration in Kubernetes
 	err = a.client.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
 	if err != nil {
-		a.logger.Error("failed to delete node",
+		a.log(ctx).Error("failed to delete node",
 			zap.String("node", nodeName),
 			zap.Error(err))
 		return fmt.Errorf("failed to delete Kubernetes node %s: %w", nodeName, err)
 	}
 
-	a.logger.Info("deleted resource",
+	a.log(ctx).Info("deleted resource",
 		zap.String("node", nodeName))
 
 	return nil
@@ -242,12 +293,16 @@ func (a *Adapter) transformNodeToResource(node *corev1.Node) *adapter.Resource {
 	}
 
 	// Add capacity information
-	resource.Extensions["kubernetes.io/capacity"] = map[string]interface{}{
+	capacity := map[string]interface{}{
 		"cpu":              node.Status.Capacity.Cpu().String(),
 		"memory":           node.Status.Capacity.Memory().String(),
 		"ephemeralStorage": node.Status.Capacity.StorageEphemeral().String(),
 		"pods":             node.Status.Capacity.Pods().String(),
 	}
+	if gpus, ok := node.Status.Capacity[nvidiaGPUResource]; ok {
+		capacity[string(nvidiaGPUResource)] = gpus.String()
+	}
+	resource.Extensions["kubernetes.io/capacity"] = capacity
 
 	// Add allocatable resources
 	resource.Extensions["kubernetes.io/allocatable"] = map[string]interface{}{
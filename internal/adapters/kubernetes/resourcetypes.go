@@ -6,7 +6,6 @@ import (
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/piwi3910/netweave/internal/adapter"
 )
@@ -17,13 +16,13 @@ func (a *Adapter) ListResourceTypes(
 	ctx context.Context,
 	filter *adapter.Filter,
 ) ([]*adapter.ResourceType, error) {
-	a.logger.Debug("ListResourceTypes called",
+	a.log(ctx).Debug("ListResourceTypes called",
 		zap.Any("filter", filter))
 
 	// List all nodes to discover resource types
-	nodes, err := a.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := a.listNodes(ctx, "")
 	if err != nil {
-		a.logger.Error("failed to list nodes",
+		a.log(ctx).Error("failed to list nodes",
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to list Kubernetes nodes: %w", err)
 	}
@@ -31,8 +30,7 @@ func (a *Adapter) ListResourceTypes(
 	// Collect unique resource types
 	typeMap := make(map[string]*adapter.ResourceType)
 
-	for i := range nodes.Items {
-		node := &nodes.Items[i]
+	for _, node := range nodes {
 		resourceTypeID := a.getNodeResourceTypeID(node)
 
 		// Skip if we've already seen this type
@@ -56,7 +54,7 @@ func (a *Adapter) ListResourceTypes(
 		types = adapter.ApplyPagination(types, filter.Limit, filter.Offset)
 	}
 
-	a.logger.Info("listed resource types",
+	a.log(ctx).Info("listed resource types",
 		zap.Int("count", len(types)))
 
 	return types, nil
@@ -65,26 +63,25 @@ func (a *Adapter) ListResourceTypes(
 // GetResourceType retrieves a specific resource type by ID.
 // It finds a node with the matching type and derives the type information.
 func (a *Adapter) GetResourceType(ctx context.Context, id string) (*adapter.ResourceType, error) {
-	a.logger.Debug("GetResourceType called",
+	a.log(ctx).Debug("GetResourceType called",
 		zap.String("id", id))
 
 	// List all nodes to find one with this resource type
-	nodes, err := a.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := a.listNodes(ctx, "")
 	if err != nil {
-		a.logger.Error("failed to list nodes",
+		a.log(ctx).Error("failed to list nodes",
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to list Kubernetes nodes: %w", err)
 	}
 
 	// Find a node with the matching resource type
-	for i := range nodes.Items {
-		node := &nodes.Items[i]
+	for _, node := range nodes {
 		resourceTypeID := a.getNodeResourceTypeID(node)
 
 		if resourceTypeID == id {
 			resourceType := a.createResourceTypeFromNode(node, resourceTypeID)
 
-			a.logger.Info("retrieved resource type",
+			a.log(ctx).Info("retrieved resource type",
 				zap.String("resourceTypeID", resourceType.ResourceTypeID),
 				zap.String("name", resourceType.Name))
 
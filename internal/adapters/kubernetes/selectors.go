@@ -0,0 +1,180 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/models"
+)
+
+// Node labels used to map O2-IMS filter fields onto Kubernetes label selectors.
+const (
+	labelTenantID     = "o2ims.io/tenant-id"
+	labelResourcePool = "o2ims.io/resource-pool"
+
+	resourcePoolIDPrefix = "k8s-namespace-"
+	labelFieldPrefix     = "labels."
+)
+
+// nodeListPlan describes how a ListResources filter is split between server-side
+// label selection and in-memory evaluation.
+type nodeListPlan struct {
+	// LabelSelector is pushed down to the Kubernetes API server.
+	LabelSelector string
+
+	// Residual holds the predicates that could not be expressed as a label selector
+	// and must still be evaluated in memory. Nil when everything was pushed down.
+	Residual *adapter.Filter
+}
+
+// planNodeList translates the supported parts of filter into a Kubernetes label
+// selector. Predicates that cannot be expressed server-side (or whose semantics
+// would differ) are returned in the residual filter for in-memory evaluation.
+//
+// Pushed down:
+//   - TenantID -> o2ims.io/tenant-id=<id>
+//   - ResourcePoolID "k8s-namespace-<ns>" -> o2ims.io/resource-pool=<ns>
+//   - Labels -> <key>=<value>
+//   - Advanced conditions on labels.<key> with eq, ne, in, nin
+//   - Advanced conditions on resourcePoolId with eq
+//
+// When an AdvancedFilter is present, the basic fields (other than TenantID) are
+// ignored, matching adapter.MatchesFilter semantics. An invalid TenantID is an error
+// rather than a residual predicate, so tenant isolation can never be silently dropped.
+func planNodeList(filter *adapter.Filter) (nodeListPlan, error) {
+	if filter == nil {
+		return nodeListPlan{}, nil
+	}
+
+	var reqs []labels.Requirement
+	if filter.TenantID != "" {
+		req, err := labels.NewRequirement(labelTenantID, selection.Equals, []string{filter.TenantID})
+		if err != nil {
+			return nodeListPlan{}, fmt.Errorf("invalid tenant ID for label selector: %w", err)
+		}
+		reqs = append(reqs, *req)
+	}
+
+	residual := *filter
+	residual.TenantID = ""
+
+	if filter.AdvancedFilter != nil {
+		advResidual := *filter.AdvancedFilter
+		advResidual.Conditions = nil
+		for _, cond := range filter.AdvancedFilter.Conditions {
+			pushed, ok := conditionRequirements(cond)
+			if !ok {
+				advResidual.Conditions = append(advResidual.Conditions, cond)
+				continue
+			}
+			reqs = append(reqs, pushed...)
+		}
+		residual.AdvancedFilter = &advResidual
+	} else {
+		reqs = pushBasicFilter(&residual, reqs)
+	}
+
+	plan := nodeListPlan{LabelSelector: labels.NewSelector().Add(reqs...).String()}
+	if !isEmptyResidual(&residual) {
+		plan.Residual = &residual
+	}
+	return plan, nil
+}
+
+// pushBasicFilter moves the basic filter fields that map onto labels into reqs,
+// clearing them from residual.
+func pushBasicFilter(residual *adapter.Filter, reqs []labels.Requirement) []labels.Requirement {
+	if ns, ok := strings.CutPrefix(residual.ResourcePoolID, resourcePoolIDPrefix); ok && ns != "" {
+		if req, err := labels.NewRequirement(labelResourcePool, selection.Equals, []string{ns}); err == nil {
+			reqs = append(reqs, *req)
+			residual.ResourcePoolID = ""
+		}
+	}
+
+	if len(residual.Labels) > 0 {
+		remaining := make(map[string]string)
+		for key, value := range residual.Labels {
+			req, err := labels.NewRequirement(key, selection.Equals, []string{value})
+			if err != nil {
+				remaining[key] = value
+				continue
+			}
+			reqs = append(reqs, *req)
+		}
+		residual.Labels = nil
+		if len(remaining) > 0 {
+			residual.Labels = remaining
+		}
+	}
+
+	return reqs
+}
+
+// conditionRequirements converts an advanced filter condition into label requirements.
+// It returns false when the condition cannot be expressed with identical semantics.
+//
+// In-memory evaluation treats a missing label as a non-match for every operator,
+// whereas Kubernetes "!=" and "notin" match objects without the key; an explicit
+// existence requirement is added for those operators to keep results identical.
+func conditionRequirements(cond models.FilterCondition) ([]labels.Requirement, bool) {
+	if cond.Field == "resourcePoolId" && cond.Operator == models.OpEquals {
+		ns, ok := strings.CutPrefix(cond.Value, resourcePoolIDPrefix)
+		if !ok || ns == "" {
+			return nil, false
+		}
+		req, err := labels.NewRequirement(labelResourcePool, selection.Equals, []string{ns})
+		if err != nil {
+			return nil, false
+		}
+		return []labels.Requirement{*req}, true
+	}
+
+	key, ok := strings.CutPrefix(cond.Field, labelFieldPrefix)
+	if !ok || key == "" {
+		return nil, false
+	}
+
+	var op selection.Operator
+	values := []string{cond.Value}
+	needsExists := false
+
+	switch cond.Operator {
+	case models.OpEquals:
+		op = selection.Equals
+	case models.OpNotEquals:
+		op, needsExists = selection.NotEquals, true
+	case models.OpIn:
+		op, values = selection.In, cond.Values
+	case models.OpNotIn:
+		op, values, needsExists = selection.NotIn, cond.Values, true
+	default:
+		return nil, false
+	}
+
+	req, err := labels.NewRequirement(key, op, values)
+	if err != nil {
+		return nil, false
+	}
+
+	reqs := []labels.Requirement{*req}
+	if needsExists {
+		exists, err := labels.NewRequirement(key, selection.Exists, nil)
+		if err != nil {
+			return nil, false
+		}
+		reqs = append(reqs, *exists)
+	}
+	return reqs, true
+}
+
+// isEmptyResidual reports whether a residual filter has no predicates left to evaluate.
+func isEmptyResidual(f *adapter.Filter) bool {
+	if f.AdvancedFilter != nil {
+		return len(f.AdvancedFilter.Conditions) == 0
+	}
+	return f.ResourcePoolID == "" && f.ResourceTypeID == "" && f.Location == "" && len(f.Labels) == 0
+}
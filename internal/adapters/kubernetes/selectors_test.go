@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/models"
+)
+
+func TestPlanNodeList(t *testing.T) {
+	testCases := map[string]struct {
+		filter       *adapter.Filter
+		wantSelector string
+		wantResidual bool
+		wantErr      bool
+	}{
+		"nil filter": {
+			filter: nil,
+		},
+		"tenant only": {
+			filter:       &adapter.Filter{TenantID: "tenant-a"},
+			wantSelector: "o2ims.io/tenant-id=tenant-a",
+		},
+		"invalid tenant is an error": {
+			filter:  &adapter.Filter{TenantID: "bad tenant!"},
+			wantErr: true,
+		},
+		"pool and labels pushed down": {
+			filter: &adapter.Filter{
+				ResourcePoolID: "k8s-namespace-edge",
+				Labels:         map[string]string{"tier": "backend"},
+			},
+			wantSelector: "o2ims.io/resource-pool=edge,tier=backend",
+		},
+		"resource type stays residual": {
+			filter:       &adapter.Filter{ResourceTypeID: "k8s-node-type-generic"},
+			wantResidual: true,
+		},
+		"foreign pool id stays residual": {
+			filter:       &adapter.Filter{ResourcePoolID: "pool-1"},
+			wantResidual: true,
+		},
+		"advanced label conditions pushed down": {
+			filter: &adapter.Filter{AdvancedFilter: &models.AdvancedFilter{
+				Conditions: []models.FilterCondition{
+					{Field: "labels.zone", Operator: models.OpIn, Values: []string{"a", "b"}},
+					{Field: "labels.tier", Operator: models.OpNotEquals, Value: "frontend"},
+				},
+			}},
+			wantSelector: "tier!=frontend,tier,zone in (a,b)",
+		},
+		"advanced regex stays residual": {
+			filter: &adapter.Filter{AdvancedFilter: &models.AdvancedFilter{
+				Conditions: []models.FilterCondition{
+					{Field: "labels.zone", Operator: models.OpEquals, Value: "a"},
+					{Field: "labels.tier", Operator: models.OpRegex, Value: "^back"},
+				},
+			}},
+			wantSelector: "zone=a",
+			wantResidual: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plan, err := planNodeList(tc.filter)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSelector, plan.LabelSelector)
+			assert.Equal(t, tc.wantResidual, plan.Residual != nil)
+			if plan.Residual != nil {
+				assert.Empty(t, plan.Residual.TenantID)
+			}
+		})
+	}
+}
+
+func TestListResources_SelectorPushdown(t *testing.T) {
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	adp := &Adapter{
+		client: fake.NewClientset(
+			node("n1", map[string]string{"tier": "backend", "o2ims.io/resource-pool": "edge"}),
+			node("n2", map[string]string{"tier": "frontend", "o2ims.io/resource-pool": "edge"}),
+			node("n3", map[string]string{"o2ims.io/resource-pool": "core"}),
+		),
+		logger: zap.NewNop(),
+	}
+	ctx := context.Background()
+
+	resources, err := adp.ListResources(ctx, &adapter.Filter{
+		ResourcePoolID: "k8s-namespace-edge",
+		Labels:         map[string]string{"tier": "backend"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s-node-n1", resources[0].ResourceID)
+
+	// Nodes without the label must not match "ne", same as in-memory evaluation.
+	resources, err = adp.ListResources(ctx, &adapter.Filter{AdvancedFilter: &models.AdvancedFilter{
+		Conditions: []models.FilterCondition{
+			{Field: "labels.tier", Operator: models.OpNotEquals, Value: "frontend"},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "k8s-node-n1", resources[0].ResourceID)
+
+	// Residual predicates are still evaluated in memory.
+	resources, err = adp.ListResources(ctx, &adapter.Filter{
+		ResourcePoolID: "k8s-namespace-edge",
+		ResourceTypeID: "k8s-node-type-generic",
+	})
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/piwi3910/netweave/internal/adapter"
 	"github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 const (
@@ -60,6 +61,7 @@ func initWebhookClient() *http.Client {
 		sharedWebhookClient = &http.Client{
 			Timeout: defaultWebhookTimeout,
 			Transport: &http.Transport{
+				DialContext:         resolver.DialContext,
 				MaxIdleConns:        100,
 				MaxIdleConnsPerHost: 10,
 				IdleConnTimeout:     90 * time.Second,
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // AuthClient handles Keystone authentication for StarlingX API access.
@@ -40,7 +42,8 @@ func NewAuthClient(
 		projectName:      projectName,
 		domainName:       domainName,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: resolver.NewTransport(),
 		},
 		logger: logger,
 	}
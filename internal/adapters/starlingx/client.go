@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // Client is a StarlingX System Inventory (sysinv) API client.
@@ -27,7 +29,8 @@ func NewClient(endpoint string, authClient *AuthClient, logger *zap.Logger) *Cli
 		endpoint:   endpoint,
 		authClient: authClient,
 		httpClient: &http.Client{
-			Timeout: 60 * time.Second,
+			Timeout:   60 * time.Second,
+			Transport: resolver.NewTransport(),
 		},
 		logger: logger,
 	}
@@ -141,6 +141,32 @@ func (a *AuditLogger) LogWebhookFailure(
 	a.logEvent(ctx, event)
 }
 
+// LogWebhookBlocked logs a webhook delivery refused at connection time
+// because the callback host resolved to a blocked address.
+func (a *AuditLogger) LogWebhookBlocked(
+	ctx context.Context,
+	client string,
+	host string,
+	address string,
+	reason string,
+) {
+	event := &AuditEvent{
+		ID:           uuid.New().String(),
+		Type:         AuditEventWebhookDeliveryBlocked,
+		ResourceType: "webhook",
+		ResourceID:   host,
+		Action:       "delivery",
+		Details: map[string]string{
+			"client":  client,
+			"address": address,
+			"reason":  reason,
+		},
+		Timestamp: time.Now().UTC(),
+	}
+
+	a.logEvent(ctx, event)
+}
+
 // LogSignatureVerificationFailure logs a signature verification failure.
 func (a *AuditLogger) LogSignatureVerificationFailure(
 	ctx context.Context,
@@ -2,65 +2,62 @@ package auth
 
 import (
 	"context"
+
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // Context keys for storing authentication data.
-type contextKey string
-
-const (
+var (
 	// userContextKey is the key for storing the authenticated user in context.
-	userContextKey contextKey = "authenticated_user"
+	userContextKey = requestcontext.NewKey[*AuthenticatedUser]("authenticated_user")
 
 	// tenantContextKey is the key for storing the tenant in context.
-	tenantContextKey contextKey = "tenant"
-
-	// requestIDContextKey is the key for storing the request ID in context.
-	requestIDContextKey contextKey = "request_id"
+	tenantContextKey = requestcontext.NewKey[*Tenant]("auth_tenant")
 )
 
-// ContextWithUser adds an authenticated user to the context.
+// ContextWithUser adds an authenticated user to the context. The user's
+// identity and tenant are also recorded for requestcontext.Identity and
+// requestcontext.Tenant.
 func ContextWithUser(ctx context.Context, user *AuthenticatedUser) context.Context {
-	return context.WithValue(ctx, userContextKey, user)
+	if user != nil {
+		ctx = requestcontext.WithIdentity(ctx, requestcontext.Caller{
+			UserID:        user.UserID,
+			TenantID:      user.TenantID,
+			Subject:       user.Subject,
+			PlatformAdmin: user.IsPlatformAdmin,
+		})
+		ctx = requestcontext.WithTenant(ctx, user.TenantID)
+	}
+	return userContextKey.With(ctx, user)
 }
 
 // UserFromContext retrieves the authenticated user from the context.
 // Returns nil if no user is found in the context.
 func UserFromContext(ctx context.Context) *AuthenticatedUser {
-	user, ok := ctx.Value(userContextKey).(*AuthenticatedUser)
-	if !ok {
-		return nil
-	}
-	return user
+	return userContextKey.Value(ctx)
 }
 
 // ContextWithTenant adds a tenant to the context.
 func ContextWithTenant(ctx context.Context, tenant *Tenant) context.Context {
-	return context.WithValue(ctx, tenantContextKey, tenant)
+	return tenantContextKey.With(ctx, tenant)
 }
 
 // TenantFromContext retrieves the tenant from the context.
 // Returns nil if no tenant is found in the context.
 func TenantFromContext(ctx context.Context) *Tenant {
-	tenant, ok := ctx.Value(tenantContextKey).(*Tenant)
-	if !ok {
-		return nil
-	}
-	return tenant
+	return tenantContextKey.Value(ctx)
 }
 
 // ContextWithRequestID adds a request ID to the context.
+// It is equivalent to requestcontext.WithRequestID.
 func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
-	return context.WithValue(ctx, requestIDContextKey, requestID)
+	return requestcontext.WithRequestID(ctx, requestID)
 }
 
 // RequestIDFromContext retrieves the request ID from the context.
 // Returns an empty string if no request ID is found.
 func RequestIDFromContext(ctx context.Context) string {
-	requestID, ok := ctx.Value(requestIDContextKey).(string)
-	if !ok {
-		return ""
-	}
-	return requestID
+	return requestcontext.RequestID(ctx)
 }
 
 // TenantIDFromContext returns the tenant ID from the authenticated user in context.
@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// CertificateAttribute names a client certificate attribute that identity
+// mapping rules match against.
+type CertificateAttribute string
+
+const (
+	// CertAttributeCN matches the subject common name.
+	CertAttributeCN CertificateAttribute = "cn"
+
+	// CertAttributeOU matches each subject organizational unit.
+	CertAttributeOU CertificateAttribute = "ou"
+
+	// CertAttributeSANDNS matches each DNS subject alternative name.
+	CertAttributeSANDNS CertificateAttribute = "san_dns"
+
+	// CertAttributeSANURI matches each URI subject alternative name (e.g. SPIFFE IDs).
+	CertAttributeSANURI CertificateAttribute = "san_uri"
+
+	// CertAttributeSANEmail matches each email subject alternative name.
+	CertAttributeSANEmail CertificateAttribute = "san_email"
+)
+
+// MappedUserIDPrefix prefixes the user ID of identities authenticated through
+// an identity mapping rule rather than a stored user.
+const MappedUserIDPrefix = "mapped:"
+
+// IdentityMappingRule maps client certificates whose attribute matches a
+// regular expression to a tenant and role.
+type IdentityMappingRule struct {
+	// Name identifies the rule in audit records and dry-run results.
+	Name string
+
+	// Attribute is the certificate attribute the pattern is matched against.
+	Attribute CertificateAttribute
+
+	// Pattern is the regular expression the attribute value must match.
+	Pattern string
+
+	// Tenant is the tenant ID template. It may reference capture groups of
+	// Pattern as $1 or ${name}.
+	Tenant string
+
+	// Role is the name of the role granted to matching certificates.
+	Role RoleName
+}
+
+// Validate checks that the rule is well formed.
+func (r *IdentityMappingRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("identity mapping name is required")
+	}
+	switch r.Attribute {
+	case CertAttributeCN, CertAttributeOU, CertAttributeSANDNS, CertAttributeSANURI, CertAttributeSANEmail:
+	default:
+		return fmt.Errorf("identity mapping %q has invalid attribute %q "+
+			"(must be cn, ou, san_dns, san_uri, or san_email)", r.Name, r.Attribute)
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil || r.Pattern == "" {
+		return fmt.Errorf("identity mapping %q has invalid pattern %q", r.Name, r.Pattern)
+	}
+	if r.Tenant == "" {
+		return fmt.Errorf("identity mapping %q tenant is required", r.Name)
+	}
+	if r.Role == "" {
+		return fmt.Errorf("identity mapping %q role is required", r.Name)
+	}
+	return nil
+}
+
+// compiledIdentityMappingRule is an IdentityMappingRule with its pattern compiled.
+type compiledIdentityMappingRule struct {
+	rule    IdentityMappingRule
+	pattern *regexp.Regexp
+}
+
+// IdentityMapping is the result of mapping a client certificate with the
+// identity mapping rules.
+type IdentityMapping struct {
+	// Rule is the name of the rule that matched.
+	Rule string `json:"rule"`
+
+	// Attribute is the certificate attribute that matched.
+	Attribute CertificateAttribute `json:"attribute"`
+
+	// Value is the attribute value that matched.
+	Value string `json:"value"`
+
+	// TenantID is the tenant the certificate is mapped to.
+	TenantID string `json:"tenantId"`
+
+	// Role is the role the certificate is granted.
+	Role RoleName `json:"role"`
+}
+
+// certificateAttributeValues returns the values of attr in cert.
+func certificateAttributeValues(cert *CertificateInfo, attr CertificateAttribute) []string {
+	switch attr {
+	case CertAttributeCN:
+		if cert.Subject.CommonName == "" {
+			return nil
+		}
+		return []string{cert.Subject.CommonName}
+	case CertAttributeOU:
+		return cert.Subject.OrganizationalUnit
+	case CertAttributeSANDNS:
+		return cert.DNSNames
+	case CertAttributeSANURI:
+		return cert.URIs
+	case CertAttributeSANEmail:
+		if len(cert.EmailAddresses) == 0 && cert.Email != "" {
+			return []string{cert.Email}
+		}
+		return cert.EmailAddresses
+	}
+	return nil
+}
+
+// MapIdentity evaluates the identity mapping rules in order against cert and
+// returns the mapping of the first rule matching one of its attribute values,
+// or nil if no rule matches.
+func (m *Middleware) MapIdentity(cert *CertificateInfo) *IdentityMapping {
+	for _, compiled := range m.identityMappings {
+		for _, value := range certificateAttributeValues(cert, compiled.rule.Attribute) {
+			match := compiled.pattern.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+			tenantID := string(compiled.pattern.ExpandString(nil, compiled.rule.Tenant, value, match))
+			if tenantID == "" {
+				continue
+			}
+			return &IdentityMapping{
+				Rule:      compiled.rule.Name,
+				Attribute: compiled.rule.Attribute,
+				Value:     value,
+				TenantID:  tenantID,
+				Role:      compiled.rule.Role,
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveIdentityMapping loads the role and tenant of a mapping. It returns
+// ErrRoleNotFound or ErrTenantNotFound if either does not exist and
+// ErrTenantSuspended if the tenant is not active.
+func (m *Middleware) ResolveIdentityMapping(ctx context.Context, mapping *IdentityMapping) (*Role, *Tenant, error) {
+	role, err := m.store.GetRoleByName(ctx, mapping.Role)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mapped role: %w", err)
+	}
+	tenant, err := m.store.GetTenant(ctx, mapping.TenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mapped tenant: %w", err)
+	}
+	if !tenant.IsActive() {
+		return nil, nil, ErrTenantSuspended
+	}
+	return role, tenant, nil
+}
+
+// authenticateMapped authenticates a certificate matched by an identity
+// mapping rule as the mapped tenant and role, without a stored user.
+func (m *Middleware) authenticateMapped(
+	ctx context.Context,
+	c *gin.Context,
+	cert *CertificateInfo,
+	mapping *IdentityMapping,
+	policy PolicyRule,
+	subject, requestID string,
+	authStart time.Time,
+) {
+	role, tenant, err := m.ResolveIdentityMapping(ctx, mapping)
+	m.logIdentityMapping(c, subject, mapping, err)
+	if err != nil {
+		m.Logger.Warn("identity mapping rejected",
+			zap.String("rule", mapping.Rule),
+			zap.String("tenant_id", SanitizeForLogging(mapping.TenantID, 100)),
+			zap.String("role", SanitizeForLogging(string(mapping.Role), 50)),
+			zap.Error(err),
+			zap.String("request_id", requestID),
+		)
+		if !errors.Is(err, ErrRoleNotFound) && !errors.Is(err, ErrTenantNotFound) &&
+			!errors.Is(err, ErrTenantSuspended) {
+			RecordAuthenticationDuration("error", time.Since(authStart).Seconds())
+			problem.Abort(c, http.StatusInternalServerError, "InternalError",
+				"Authentication service temporarily unavailable")
+			return
+		}
+		RecordAuthenticationAttempt("failed", "mtls")
+		RecordAuthenticationDuration("failed", time.Since(authStart).Seconds())
+		problem.Abort(c, http.StatusForbidden, "Forbidden", "Authentication failed")
+		return
+	}
+
+	user := &TenantUser{
+		ID:         MappedUserIDPrefix + subject,
+		TenantID:   tenant.ID,
+		Subject:    subject,
+		CommonName: cert.Subject.CommonName,
+		Email:      cert.Email,
+		RoleID:     role.ID,
+		IsActive:   true,
+	}
+	if !policy.allowsRole(role) {
+		m.handleRoleDenied(c, user, role, policy, requestID, authStart)
+		return
+	}
+
+	m.finalizeAuthentication(ctx, c, user, role, tenant, subject, cert.Subject.CommonName, requestID, authStart)
+}
+
+// logIdentityMapping records the identity mapping decision for a request.
+func (m *Middleware) logIdentityMapping(c *gin.Context, subject string, mapping *IdentityMapping, err error) {
+	event := &AuditEvent{
+		ID:       uuid.New().String(),
+		Type:     AuditEventIdentityMapped,
+		TenantID: mapping.TenantID,
+		Subject:  subject,
+		Action:   "identity_mapped",
+		Details: map[string]string{
+			"rule":      mapping.Rule,
+			"attribute": string(mapping.Attribute),
+			"value":     mapping.Value,
+			"role":      string(mapping.Role),
+			"decision":  "accepted",
+		},
+		ClientIP:  middleware.GetClientIP(c),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if err != nil {
+		event.Type = AuditEventIdentityMappingRejected
+		event.Action = "identity_mapping_rejected"
+		event.Details["decision"] = "rejected"
+		event.Details["reason"] = err.Error()
+	}
+
+	if err := m.store.LogEvent(c.Request.Context(), event); err != nil {
+		m.Logger.Warn("failed to log identity mapping event", zap.Error(err))
+	}
+}
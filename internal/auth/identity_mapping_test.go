@@ -0,0 +1,153 @@
+package auth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/auth"
+)
+
+func identityMappingTestMiddleware(t *testing.T) (*auth.Middleware, *mockStore) {
+	t.Helper()
+
+	store := newMockStore()
+	store.tenants["ran-east"] = &auth.Tenant{ID: "ran-east", Status: auth.TenantStatusActive}
+	store.tenants["ran-west"] = &auth.Tenant{ID: "ran-west", Status: auth.TenantStatusSuspended}
+	store.roles["role-operator"] = &auth.Role{
+		ID: "role-operator", Name: auth.RoleOperator, Type: auth.RoleTypeTenant,
+		Permissions: []auth.Permission{auth.PermissionSubscriptionRead},
+	}
+
+	mw := setupTestMiddleware(t, store, &auth.MiddlewareConfig{
+		Enabled:     true,
+		RequireMTLS: true,
+		IdentityMappings: []auth.IdentityMappingRule{
+			{Name: "invalid", Attribute: "serial", Pattern: ".*", Tenant: "ran-east", Role: auth.RoleOperator},
+			{
+				Name: "spiffe", Attribute: auth.CertAttributeSANURI,
+				Pattern: `^spiffe://ran\.example/ns/(?P<tenant>[a-z0-9-]+)/`,
+				Tenant:  "${tenant}", Role: auth.RoleOperator,
+			},
+			{
+				Name: "ou", Attribute: auth.CertAttributeOU,
+				Pattern: `^tenant-([a-z0-9-]+)$`, Tenant: "$1", Role: auth.RoleOperator,
+			},
+			{
+				Name: "unknown-role", Attribute: auth.CertAttributeCN,
+				Pattern: `^legacy-`, Tenant: "ran-east", Role: "legacy",
+			},
+		},
+	})
+	return mw, store
+}
+
+func TestMiddleware_MapIdentity(t *testing.T) {
+	mw, _ := identityMappingTestMiddleware(t)
+
+	mapping := mw.MapIdentity(&auth.CertificateInfo{
+		Subject: auth.CertificateSubject{CommonName: "du-1", OrganizationalUnit: []string{"tenant-ran-west"}},
+		URIs:    []string{"spiffe://other.example/ns/x/sa/y", "spiffe://ran.example/ns/ran-east/sa/du"},
+	})
+	require.NotNil(t, mapping)
+	assert.Equal(t, auth.IdentityMapping{
+		Rule: "spiffe", Attribute: auth.CertAttributeSANURI, Value: "spiffe://ran.example/ns/ran-east/sa/du",
+		TenantID: "ran-east", Role: auth.RoleOperator,
+	}, *mapping, "rules are evaluated in order")
+
+	mapping = mw.MapIdentity(&auth.CertificateInfo{
+		Subject: auth.CertificateSubject{CommonName: "du-1", OrganizationalUnit: []string{"ops", "tenant-ran-west"}},
+	})
+	require.NotNil(t, mapping)
+	assert.Equal(t, "ou", mapping.Rule)
+	assert.Equal(t, "ran-west", mapping.TenantID)
+
+	assert.Nil(t, mw.MapIdentity(&auth.CertificateInfo{Subject: auth.CertificateSubject{CommonName: "du-1"}}))
+}
+
+func TestMiddleware_AuthenticationMiddleware_IdentityMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		cert       *x509.Certificate
+		xfcc       string
+		wantStatus int
+		wantEvent  auth.AuditEventType
+	}{
+		{
+			name: "mapped by URI SAN",
+			cert: &x509.Certificate{
+				Subject: pkix.Name{CommonName: "du-1", Organization: []string{"RAN"}},
+				URIs:    []*url.URL{{Scheme: "spiffe", Host: "ran.example", Path: "/ns/ran-east/sa/du"}},
+			},
+			wantStatus: http.StatusOK,
+			wantEvent:  auth.AuditEventIdentityMapped,
+		},
+		{
+			name:       "mapped by URI SAN from XFCC",
+			xfcc:       `Hash=abc;Subject="CN=du-1,O=RAN";URI=spiffe://ran.example/ns/ran-east/sa/du`,
+			wantStatus: http.StatusOK,
+			wantEvent:  auth.AuditEventIdentityMapped,
+		},
+		{
+			name: "suspended tenant",
+			cert: &x509.Certificate{
+				Subject: pkix.Name{CommonName: "du-2", OrganizationalUnit: []string{"tenant-ran-west"}},
+			},
+			wantStatus: http.StatusForbidden,
+			wantEvent:  auth.AuditEventIdentityMappingRejected,
+		},
+		{
+			name:       "unknown role",
+			cert:       &x509.Certificate{Subject: pkix.Name{CommonName: "legacy-du"}},
+			wantStatus: http.StatusForbidden,
+			wantEvent:  auth.AuditEventIdentityMappingRejected,
+		},
+		{
+			name:       "no rule matches falls back to stored users",
+			cert:       &x509.Certificate{Subject: pkix.Name{CommonName: "du-3"}},
+			wantStatus: http.StatusForbidden,
+			wantEvent:  auth.AuditEventAuthFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, store := identityMappingTestMiddleware(t)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(mw.AuthenticationMiddleware())
+			router.GET("/test", func(c *gin.Context) {
+				user := auth.UserFromContext(c.Request.Context())
+				c.JSON(http.StatusOK, gin.H{"user_id": user.UserID, "tenant_id": user.TenantID})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.cert != nil {
+				req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{tt.cert}}
+			}
+			if tt.xfcc != "" {
+				req.Header.Set("X-Forwarded-Client-Cert", tt.xfcc)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			require.Len(t, store.events, 1)
+			assert.Equal(t, tt.wantEvent, store.events[0].Type)
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"user_id":"mapped:CN=du-1,O=RAN","tenant_id":"ran-east"}`, w.Body.String())
+				assert.Equal(t, "spiffe", store.events[0].Details["rule"])
+				assert.Equal(t, "ran-east", store.events[0].TenantID)
+			}
+		})
+	}
+}
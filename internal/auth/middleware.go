@@ -2,16 +2,23 @@ package auth
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // MaxDNLength is the maximum allowed length for a DN string.
@@ -26,10 +33,25 @@ type MiddlewareConfig struct {
 	Enabled bool
 
 	// SkipPaths is a list of paths that should skip authentication.
+	//
+	// Deprecated: use Policies with AuthLevelAnonymous. Skip paths are evaluated
+	// as anonymous rules ahead of Policies.
 	SkipPaths []string
 
-	// RequireMTLS requires client certificates for authentication.
+	// Policies maps route paths and methods to required authentication levels.
+	// The first matching rule applies; requests matching no rule require
+	// authentication.
+	Policies []PolicyRule
+
+	// RequireMTLS declares that every client presents a client certificate,
+	// so missing ones are logged as warnings. Requests without a certificate
+	// are rejected by non-anonymous policies either way.
 	RequireMTLS bool
+
+	// IdentityMappings map client certificate attributes to a tenant and
+	// role. The first matching rule applies; certificates matching no rule
+	// are authenticated as the stored user with their subject.
+	IdentityMappings []IdentityMappingRule
 }
 
 // DefaultMiddlewareConfig returns a MiddlewareConfig with sensible defaults.
@@ -52,61 +74,107 @@ func DefaultMiddlewareConfig() *MiddlewareConfig {
 // Middleware provides authentication and authorization middleware for Gin.
 type Middleware struct {
 	store            Store
-	Config           *MiddlewareConfig // Exported for testing
-	Logger           *zap.Logger       // Exported for testing
-	compiledPatterns []*regexp.Regexp  // Pre-compiled regex patterns for skip paths
+	Config           *MiddlewareConfig              // Exported for testing
+	Logger           *zap.Logger                    // Exported for testing
+	policiesMu       sync.RWMutex                   // Guards policies, which SetPolicies replaces
+	policies         []*compiledPolicyRule          // Pre-compiled auth policies, skip paths first
+	identityMappings []*compiledIdentityMappingRule // Pre-compiled identity mapping rules
 }
 
 // NewMiddleware creates a new authentication middleware.
-// Pre-compiles regex patterns for skip paths during initialization for performance.
+// Pre-compiles the auth policy matrix during initialization for performance.
+// Invalid rules are logged and ignored; use PolicyRule.Validate and
+// IdentityMappingRule.Validate to reject them earlier.
 func NewMiddleware(store Store, config *MiddlewareConfig, logger *zap.Logger) *Middleware {
 	if config == nil {
 		config = DefaultMiddlewareConfig()
 	}
 
-	// Pre-compile regex patterns for paths with wildcards
-	compiledPatterns := make([]*regexp.Regexp, 0, len(config.SkipPaths))
-	for _, pattern := range config.SkipPaths {
-		if strings.Contains(pattern, "*") {
-			// Convert glob pattern to regex using shared helper
-			regexPattern := patternToRegex(pattern)
-			compiled, err := regexp.Compile(regexPattern)
-			if err != nil {
-				// Log warning for invalid patterns
-				logger.Warn("Failed to compile skip path pattern",
-					zap.String("pattern", pattern),
-					zap.Error(err))
-				continue
-			}
-			compiledPatterns = append(compiledPatterns, compiled)
+	rules := make([]PolicyRule, 0, len(config.SkipPaths)+len(config.Policies))
+	for _, path := range config.SkipPaths {
+		rules = append(rules, PolicyRule{Paths: []string{path}, Level: AuthLevelAnonymous})
+	}
+	rules = append(rules, config.Policies...)
+	policies := compilePolicyRules(rules, logger)
+
+	identityMappings := make([]*compiledIdentityMappingRule, 0, len(config.IdentityMappings))
+	for _, rule := range config.IdentityMappings {
+		if err := rule.Validate(); err != nil {
+			logger.Warn("Failed to compile identity mapping", zap.String("name", rule.Name), zap.Error(err))
+			continue
 		}
+		identityMappings = append(identityMappings, &compiledIdentityMappingRule{
+			rule:    rule,
+			pattern: regexp.MustCompile(rule.Pattern),
+		})
 	}
 
 	return &Middleware{
 		store:            store,
 		Config:           config,
 		Logger:           logger,
-		compiledPatterns: compiledPatterns,
+		policies:         policies,
+		identityMappings: identityMappings,
+	}
+}
+
+// compilePolicyRules compiles rules in order, logging and skipping invalid ones.
+func compilePolicyRules(rules []PolicyRule, logger *zap.Logger) []*compiledPolicyRule {
+	policies := make([]*compiledPolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		compiled, err := compilePolicyRule(rule)
+		if err != nil {
+			logger.Warn("Failed to compile auth policy",
+				zap.Strings("paths", rule.Paths),
+				zap.Error(err))
+			continue
+		}
+		policies = append(policies, compiled)
 	}
+	return policies
+}
+
+// SetPolicies replaces the auth policy matrix, e.g. after a configuration
+// reload. Requests already past policy evaluation are not affected. Invalid
+// rules are logged and ignored, as in NewMiddleware.
+func (m *Middleware) SetPolicies(rules []PolicyRule) {
+	policies := compilePolicyRules(rules, m.Logger)
+	m.policiesMu.Lock()
+	m.policies = policies
+	m.policiesMu.Unlock()
 }
 
 // AuthenticationMiddleware extracts user identity from the request.
-// It parses mTLS client certificates and looks up the user in the database.
+// It parses mTLS client certificates and maps them to a tenant and role with
+// the identity mapping rules, or else looks up the user in the database.
+// The auth policy matching the request decides whether credentials are required
+// (see PolicyFor): anonymous requests pass through unauthenticated, and role
+// policies reject authenticated users without one of the listed roles.
 //
 // SECURITY NOTE: Path Matching and Normalization
-// The shouldSkipAuth() function matches paths as-is without normalization.
+// Policy paths are matched as-is without normalization.
 // Path traversal sequences (../, ./, etc.) are NOT sanitized by this middleware.
 // It is the caller's responsibility to ensure paths are normalized BEFORE reaching
 // this middleware. Gin framework normalizes paths by default, but custom routers
 // or proxies should ensure proper path sanitization.
 func (m *Middleware) AuthenticationMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := uuid.New().String()
-		c.Set("request_id", requestID)
-		ctx := ContextWithRequestID(c.Request.Context(), requestID)
-		c.Request = c.Request.WithContext(ctx)
+		// Reuse the ID assigned by the request ID middleware so audit events
+		// and logs of one request share it.
+		requestID := requestcontext.RequestID(c.Request.Context())
+		if requestID == "" {
+			requestID = uuid.New().String()
+			c.Request = c.Request.WithContext(requestcontext.WithRequestID(c.Request.Context(), requestID))
+		}
+
+		if !m.Config.Enabled {
+			c.Next()
+			return
+		}
 
-		if m.ShouldSkipAuth(c.Request.URL.Path) || !m.Config.Enabled {
+		policy := m.PolicyFor(c.Request.Method, c.Request.URL.Path)
+		if policy.Level == AuthLevelAnonymous {
+			c.Set(anonymousAccessKey, true)
 			c.Next()
 			return
 		}
@@ -115,7 +183,7 @@ func (m *Middleware) AuthenticationMiddleware() gin.HandlerFunc {
 		cert := m.extractCertificate(c)
 
 		if cert == nil {
-			m.handleMissingCertificate(c, requestID, authStart)
+			m.handleMissingCertificate(c, policy, requestID, authStart)
 			return
 		}
 
@@ -126,35 +194,76 @@ func (m *Middleware) AuthenticationMiddleware() gin.HandlerFunc {
 			zap.String("request_id", requestID),
 		)
 
-		user, role, tenant, err := m.authenticateAndLoadContext(c.Request.Context(), subject, requestID)
+		ctx := c.Request.Context()
+		if mapping := m.MapIdentity(cert); mapping != nil {
+			m.authenticateMapped(ctx, c, cert, mapping, policy, subject, requestID, authStart)
+			return
+		}
+
+		user, role, tenant, err := m.authenticateAndLoadContext(ctx, subject, requestID)
 		if err != nil {
 			m.handleAuthenticationError(c, err, subject, requestID, authStart)
 			return
 		}
 
+		if !policy.allowsRole(role) {
+			m.handleRoleDenied(c, user, role, policy, requestID, authStart)
+			return
+		}
+
 		m.finalizeAuthentication(ctx, c, user, role, tenant, subject, cert.Subject.CommonName, requestID, authStart)
 	}
 }
 
-func (m *Middleware) handleMissingCertificate(c *gin.Context, requestID string, authStart time.Time) {
-	if !m.Config.RequireMTLS {
-		c.Next()
-		return
+func (m *Middleware) handleRoleDenied(
+	c *gin.Context,
+	user *TenantUser,
+	role *Role,
+	policy PolicyRule,
+	requestID string,
+	authStart time.Time,
+) {
+	m.Logger.Warn("role not permitted by auth policy",
+		zap.String("user_id", user.ID),
+		zap.String("role", SanitizeForLogging(string(role.Name), 50)),
+		zap.String("path", c.Request.URL.Path),
+		zap.String("request_id", requestID),
+	)
+	RecordAuthenticationAttempt("denied", "mtls")
+	RecordAuthenticationDuration("denied", time.Since(authStart).Seconds())
+	problem.Abort(c, http.StatusForbidden, "Forbidden",
+		"Role not permitted for this operation; requires one of: "+joinRoleNames(policy.Roles))
+}
+
+func joinRoleNames(roles []RoleName) string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
 	}
+	return strings.Join(names, ", ")
+}
 
-	m.Logger.Warn("no client certificate provided",
+// handleMissingCertificate rejects a request without a client certificate.
+// Client certificates are the only identity source, so no authenticated or
+// role policy can be satisfied without one; requests to anonymous paths never
+// get here. Without require_mtls the missing certificate is expected for some
+// clients and only logged at debug level.
+func (m *Middleware) handleMissingCertificate(c *gin.Context, policy PolicyRule, requestID string, authStart time.Time) {
+	fields := []zap.Field{
 		zap.String("path", c.Request.URL.Path),
-		zap.String("client_ip", c.ClientIP()),
+		zap.String("policy_level", string(policy.Level)),
+		zap.String("client_ip", middleware.GetClientIP(c)),
 		zap.String("request_id", requestID),
-	)
+	}
+	if m.Config.RequireMTLS {
+		m.Logger.Warn("no client certificate provided", fields...)
+	} else {
+		m.Logger.Debug("no client certificate provided", fields...)
+	}
 	m.logAuthFailure(c, "", "no client certificate")
 	RecordAuthenticationAttempt("failed", "mtls")
 	RecordAuthenticationDuration("failed", time.Since(authStart).Seconds())
-	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-		"error":   "Unauthorized",
-		"message": "Client certificate required",
-		"code":    http.StatusUnauthorized,
-	})
+	problem.Abort(c, http.StatusUnauthorized, "Unauthorized", "Client certificate required")
 }
 
 func (m *Middleware) authenticateAndLoadContext(
@@ -209,9 +318,7 @@ func (m *Middleware) handleAuthenticationError(
 	var aErr *authError
 	if !errors.As(err, &aErr) {
 		RecordAuthenticationDuration("error", time.Since(authStart).Seconds())
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": "InternalError", "message": "Authentication failed", "code": http.StatusInternalServerError,
-		})
+		problem.Abort(c, http.StatusInternalServerError, "InternalError", "Authentication failed")
 		return
 	}
 
@@ -220,16 +327,13 @@ func (m *Middleware) handleAuthenticationError(
 		if errors.Is(aErr.err, ErrUserNotFound) {
 			m.Logger.Warn("unknown user certificate",
 				zap.String("subject", SanitizeForLogging(subject, 200)),
-				zap.String("client_ip", c.ClientIP()),
+				zap.String("client_ip", middleware.GetClientIP(c)),
 				zap.String("request_id", requestID),
 			)
 			m.logAuthFailure(c, subject, "user not found")
 			RecordAuthenticationAttempt("failed", "mtls")
 			RecordAuthenticationDuration("failed", time.Since(authStart).Seconds())
-			c.AbortWithStatusJSON(
-				http.StatusForbidden,
-				gin.H{"error": "Forbidden", "message": "Authentication failed", "code": http.StatusForbidden},
-			)
+			problem.Abort(c, http.StatusForbidden, "Forbidden", "Authentication failed")
 		} else {
 			m.Logger.Error("failed to lookup user",
 				zap.String("subject", SanitizeForLogging(subject, 200)),
@@ -237,10 +341,7 @@ func (m *Middleware) handleAuthenticationError(
 				zap.String("request_id", requestID),
 			)
 			RecordAuthenticationDuration("error", time.Since(authStart).Seconds())
-			c.AbortWithStatusJSON(
-				http.StatusInternalServerError,
-				gin.H{"error": "InternalError", "message": "Authentication failed", "code": http.StatusInternalServerError},
-			)
+			problem.Abort(c, http.StatusInternalServerError, "InternalError", "Authentication failed")
 		}
 	case "user_inactive":
 		m.Logger.Warn("inactive user attempted access",
@@ -251,10 +352,7 @@ func (m *Middleware) handleAuthenticationError(
 		m.logAuthFailure(c, subject, "user inactive")
 		RecordAuthenticationAttempt("failed", "mtls")
 		RecordAuthenticationDuration("failed", time.Since(authStart).Seconds())
-		c.AbortWithStatusJSON(
-			http.StatusForbidden,
-			gin.H{"error": "Forbidden", "message": "Authentication failed", "code": http.StatusForbidden},
-		)
+		problem.Abort(c, http.StatusForbidden, "Forbidden", "Authentication failed")
 	case "role_lookup":
 		m.Logger.Error("failed to get user role",
 			zap.String("user_id", aErr.userID),
@@ -263,14 +361,8 @@ func (m *Middleware) handleAuthenticationError(
 			zap.String("request_id", requestID),
 		)
 		RecordAuthenticationDuration("error", time.Since(authStart).Seconds())
-		c.AbortWithStatusJSON(
-			http.StatusInternalServerError,
-			gin.H{
-				"error":   "InternalError",
-				"message": "Authentication service temporarily unavailable",
-				"code":    http.StatusInternalServerError,
-			},
-		)
+		problem.Abort(c, http.StatusInternalServerError, "InternalError",
+			"Authentication service temporarily unavailable")
 	case "tenant_lookup":
 		if errors.Is(aErr.err, ErrTenantNotFound) {
 			m.Logger.Warn("user's tenant not found",
@@ -280,10 +372,7 @@ func (m *Middleware) handleAuthenticationError(
 			)
 			RecordAuthenticationAttempt("failed", "mtls")
 			RecordAuthenticationDuration("failed", time.Since(authStart).Seconds())
-			c.AbortWithStatusJSON(
-				http.StatusForbidden,
-				gin.H{"error": "Forbidden", "message": "Authentication failed", "code": http.StatusForbidden},
-			)
+			problem.Abort(c, http.StatusForbidden, "Forbidden", "Authentication failed")
 		} else {
 			m.Logger.Error("failed to get tenant",
 				zap.String("tenant_id", aErr.tenantID),
@@ -291,14 +380,8 @@ func (m *Middleware) handleAuthenticationError(
 				zap.String("request_id", requestID),
 			)
 			RecordAuthenticationDuration("error", time.Since(authStart).Seconds())
-			c.AbortWithStatusJSON(
-				http.StatusInternalServerError,
-				gin.H{
-					"error":   "InternalError",
-					"message": "Authentication service temporarily unavailable",
-					"code":    http.StatusInternalServerError,
-				},
-			)
+			problem.Abort(c, http.StatusInternalServerError, "InternalError",
+				"Authentication service temporarily unavailable")
 		}
 	case "tenant_inactive":
 		m.Logger.Warn("access to suspended tenant",
@@ -309,10 +392,7 @@ func (m *Middleware) handleAuthenticationError(
 		m.logAuthFailure(c, subject, "tenant suspended")
 		RecordAuthenticationAttempt("failed", "mtls")
 		RecordAuthenticationDuration("failed", time.Since(authStart).Seconds())
-		c.AbortWithStatusJSON(
-			http.StatusForbidden,
-			gin.H{"error": "Forbidden", "message": "Tenant is suspended", "code": http.StatusForbidden},
-		)
+		problem.Abort(c, http.StatusForbidden, "Forbidden", "Tenant is suspended")
 	}
 }
 
@@ -330,26 +410,22 @@ func (m *Middleware) finalizeAuthentication(
 		Role: role, IsPlatformAdmin: role.Type == RoleTypePlatform && role.Name == RolePlatformAdmin,
 	}
 
-	c.Set("user", authUser)
-	c.Set("tenant", tenant)
-	c.Set("tenant_id", user.TenantID)
-	c.Set("user_id", user.ID)
-
 	ctx = ContextWithUser(ctx, authUser)
 	ctx = ContextWithTenant(ctx, tenant)
 	c.Request = c.Request.WithContext(ctx)
 
-	// Update last login asynchronously
+	// Update last login asynchronously; mapped identities have no stored user.
 	// We create a new context derived from the parent to satisfy contextcheck linter,
 	// but with a separate timeout to prevent cancellation if the request context is cancelled
-	userID := user.ID
-	go func(parentCtx context.Context, id string) {
-		asyncCtx, cancel := context.WithTimeout(context.WithoutCancel(parentCtx), 5*time.Second)
-		defer cancel()
-		if err := m.store.UpdateLastLogin(asyncCtx, id); err != nil {
-			m.Logger.Warn("failed to update last login", zap.String("user_id", id), zap.Error(err))
-		}
-	}(ctx, userID)
+	if !strings.HasPrefix(user.ID, MappedUserIDPrefix) {
+		go func(parentCtx context.Context, id string) {
+			asyncCtx, cancel := context.WithTimeout(context.WithoutCancel(parentCtx), 5*time.Second)
+			defer cancel()
+			if err := m.store.UpdateLastLogin(asyncCtx, id); err != nil {
+				m.Logger.Warn("failed to update last login", zap.String("user_id", id), zap.Error(err))
+			}
+		}(ctx, user.ID)
+	}
 
 	m.Logger.Info("user authenticated",
 		zap.String("user_id", user.ID),
@@ -362,23 +438,70 @@ func (m *Middleware) finalizeAuthentication(
 	c.Next()
 }
 
+// Viewer identifies the client of a request to a route that does not require
+// authentication, such as the API documentation. It returns nil if
+// authentication is disabled, the client presented no certificate, or the
+// certificate does not belong to an active user; it never rejects the request.
+func (m *Middleware) Viewer(c *gin.Context) (*AuthenticatedUser, *Tenant) {
+	if !m.Config.Enabled {
+		return nil, nil
+	}
+	cert := m.extractCertificate(c)
+	if cert == nil {
+		return nil, nil
+	}
+	ctx := c.Request.Context()
+	subject := m.BuildSubject(cert)
+
+	var (
+		userID, tenantID string
+		role             *Role
+		tenant           *Tenant
+		err              error
+	)
+	if mapping := m.MapIdentity(cert); mapping != nil {
+		role, tenant, err = m.ResolveIdentityMapping(ctx, mapping)
+		userID, tenantID = MappedUserIDPrefix+subject, mapping.TenantID
+	} else {
+		var user *TenantUser
+		user, role, tenant, err = m.authenticateAndLoadContext(ctx, subject, "")
+		if user != nil {
+			userID, tenantID = user.ID, user.TenantID
+		}
+	}
+	if err != nil {
+		m.Logger.Debug("viewer not identified",
+			zap.String("subject", SanitizeForLogging(subject, 200)),
+			zap.Error(err),
+		)
+		return nil, nil
+	}
+
+	return &AuthenticatedUser{
+		UserID: userID, TenantID: tenantID, Subject: subject, CommonName: cert.Subject.CommonName,
+		Role: role, IsPlatformAdmin: role.Type == RoleTypePlatform && role.Name == RolePlatformAdmin,
+	}, tenant
+}
+
 // RequirePermission returns a middleware that checks if the user has the required permission.
+// Requests admitted anonymously by the auth policy are not checked.
 func (m *Middleware) RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetString("request_id")
+		requestID := requestcontext.RequestID(c.Request.Context())
 
 		// Get authenticated user from context.
 		user := UserFromContext(c.Request.Context())
+		if user == nil && IsAnonymousAccess(c) {
+			// The auth policy admits this request without credentials.
+			c.Next()
+			return
+		}
 		if user == nil {
 			m.Logger.Warn("no authenticated user in context",
 				zap.String("path", c.Request.URL.Path),
 				zap.String("request_id", requestID),
 			)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Authentication required",
-				"code":    http.StatusUnauthorized,
-			})
+			problem.Abort(c, http.StatusUnauthorized, "Unauthorized", "Authentication required")
 			return
 		}
 
@@ -394,11 +517,7 @@ func (m *Middleware) RequirePermission(permission string) gin.HandlerFunc {
 
 			m.logAccessDenied(c, user, Permission(permission))
 			RecordAuthorizationCheck("denied", Permission(permission))
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "Insufficient permissions for this operation",
-				"code":    http.StatusForbidden,
-			})
+			problem.Abort(c, http.StatusForbidden, "Forbidden", "Insufficient permissions for this operation")
 			return
 		}
 
@@ -409,17 +528,18 @@ func (m *Middleware) RequirePermission(permission string) gin.HandlerFunc {
 }
 
 // RequireAnyPermission returns a middleware that checks if the user has any of the required permissions.
+// Requests admitted anonymously by the auth policy are not checked.
 func (m *Middleware) RequireAnyPermission(permissions ...Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetString("request_id")
+		requestID := requestcontext.RequestID(c.Request.Context())
 
 		user := UserFromContext(c.Request.Context())
+		if user == nil && IsAnonymousAccess(c) {
+			c.Next()
+			return
+		}
 		if user == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Authentication required",
-				"code":    http.StatusUnauthorized,
-			})
+			problem.Abort(c, http.StatusUnauthorized, "Unauthorized", "Authentication required")
 			return
 		}
 
@@ -437,26 +557,18 @@ func (m *Middleware) RequireAnyPermission(permissions ...Permission) gin.Handler
 			zap.String("request_id", requestID),
 		)
 
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-			"error":   "Forbidden",
-			"message": "Insufficient permissions",
-			"code":    http.StatusForbidden,
-		})
+		problem.Abort(c, http.StatusForbidden, "Forbidden", "Insufficient permissions")
 	}
 }
 
 // RequirePlatformAdmin returns a middleware that ensures the user is a platform admin.
 func (m *Middleware) RequirePlatformAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetString("request_id")
+		requestID := requestcontext.RequestID(c.Request.Context())
 
 		user := UserFromContext(c.Request.Context())
 		if user == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Authentication required",
-				"code":    http.StatusUnauthorized,
-			})
+			problem.Abort(c, http.StatusUnauthorized, "Unauthorized", "Authentication required")
 			return
 		}
 
@@ -468,11 +580,7 @@ func (m *Middleware) RequirePlatformAdmin() gin.HandlerFunc {
 				zap.String("request_id", requestID),
 			)
 
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "Platform administrator access required",
-				"code":    http.StatusForbidden,
-			})
+			problem.Abort(c, http.StatusForbidden, "Forbidden", "Platform administrator access required")
 			return
 		}
 
@@ -484,16 +592,12 @@ func (m *Middleware) RequirePlatformAdmin() gin.HandlerFunc {
 // This is useful for multi-tenant endpoints where a tenant ID is in the path.
 func (m *Middleware) RequireTenantAccess(tenantIDParam string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetString("request_id")
+		requestID := requestcontext.RequestID(c.Request.Context())
 		targetTenantID := c.Param(tenantIDParam)
 
 		user := UserFromContext(c.Request.Context())
 		if user == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Authentication required",
-				"code":    http.StatusUnauthorized,
-			})
+			problem.Abort(c, http.StatusUnauthorized, "Unauthorized", "Authentication required")
 			return
 		}
 
@@ -513,11 +617,7 @@ func (m *Middleware) RequireTenantAccess(tenantIDParam string) gin.HandlerFunc {
 				zap.String("request_id", requestID),
 			)
 
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error":   "Forbidden",
-				"message": "Access to other tenants is not allowed",
-				"code":    http.StatusForbidden,
-			})
+			problem.Abort(c, http.StatusForbidden, "Forbidden", "Access to other tenants is not allowed")
 			return
 		}
 
@@ -527,9 +627,12 @@ func (m *Middleware) RequireTenantAccess(tenantIDParam string) gin.HandlerFunc {
 
 // CertificateInfo represents parsed certificate information.
 type CertificateInfo struct {
-	Subject    CertificateSubject
-	CommonName string
-	Email      string
+	Subject        CertificateSubject
+	CommonName     string
+	Email          string
+	DNSNames       []string
+	URIs           []string
+	EmailAddresses []string
 }
 
 // CertificateSubject contains parsed subject fields.
@@ -546,19 +649,7 @@ type CertificateSubject struct {
 func (m *Middleware) extractCertificate(c *gin.Context) *CertificateInfo {
 	// Try to get from TLS connection (native Go TLS).
 	if c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0 {
-		cert := c.Request.TLS.PeerCertificates[0]
-		return &CertificateInfo{
-			Subject: CertificateSubject{
-				CommonName:         cert.Subject.CommonName,
-				Organization:       cert.Subject.Organization,
-				OrganizationalUnit: cert.Subject.OrganizationalUnit,
-				Country:            cert.Subject.Country,
-				Province:           cert.Subject.Province,
-				Locality:           cert.Subject.Locality,
-			},
-			CommonName: cert.Subject.CommonName,
-			Email:      m.ExtractEmail(cert.EmailAddresses),
-		}
+		return m.CertificateInfoFromX509(c.Request.TLS.PeerCertificates[0])
 	}
 
 	// Try to get from X-Forwarded-Client-Cert header (Envoy/Istio).
@@ -576,6 +667,26 @@ func (m *Middleware) extractCertificate(c *gin.Context) *CertificateInfo {
 	return nil
 }
 
+// CertificateInfoFromX509 returns the certificate information of a parsed
+// X.509 certificate.
+func (m *Middleware) CertificateInfoFromX509(cert *x509.Certificate) *CertificateInfo {
+	return &CertificateInfo{
+		Subject: CertificateSubject{
+			CommonName:         cert.Subject.CommonName,
+			Organization:       cert.Subject.Organization,
+			OrganizationalUnit: cert.Subject.OrganizationalUnit,
+			Country:            cert.Subject.Country,
+			Province:           cert.Subject.Province,
+			Locality:           cert.Subject.Locality,
+		},
+		CommonName:     cert.Subject.CommonName,
+		Email:          m.ExtractEmail(cert.EmailAddresses),
+		DNSNames:       cert.DNSNames,
+		URIs:           uriStrings(cert.URIs),
+		EmailAddresses: cert.EmailAddresses,
+	}
+}
+
 // BuildSubject constructs a normalized subject string from certificate info.
 func (m *Middleware) BuildSubject(cert *CertificateInfo) string {
 	parts := make([]string, 0)
@@ -610,7 +721,59 @@ func (m *Middleware) ParseXFCCHeader(xfcc string) *CertificateInfo {
 	subject := xfcc[subjectStart : subjectStart+subjectEnd]
 
 	// Parse the subject DN.
-	return m.ParseDNHeader(subject)
+	cert := m.ParseDNHeader(subject)
+	if cert == nil {
+		return nil
+	}
+
+	// Subject alternative names of the first (client) certificate.
+	for _, field := range xfccClientFields(xfcc) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, "\"")
+		switch strings.ToUpper(key) {
+		case "URI":
+			cert.URIs = append(cert.URIs, value)
+		case "DNS":
+			cert.DNSNames = append(cert.DNSNames, value)
+		}
+	}
+	return cert
+}
+
+// xfccClientFields returns the key=value fields of the first XFCC element,
+// which describes the client certificate, ignoring separators inside quoted
+// values.
+func xfccClientFields(xfcc string) []string {
+	var fields []string
+	quoted := false
+	start := 0
+	for i, r := range xfcc {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			fields = append(fields, xfcc[start:i])
+			start = i + 1
+		case r == ',' && !quoted:
+			return append(fields, xfcc[start:i])
+		}
+	}
+	return append(fields, xfcc[start:])
+}
+
+// uriStrings formats certificate URI subject alternative names.
+func uriStrings(uris []*url.URL) []string {
+	if len(uris) == 0 {
+		return nil
+	}
+	values := make([]string, len(uris))
+	for i, u := range uris {
+		values[i] = u.String()
+	}
+	return values
 }
 
 // ParseDNHeader parses a DN (Distinguished Name) string.
@@ -763,24 +926,11 @@ func (m *Middleware) ExtractEmail(emails []string) string {
 	return ""
 }
 
-// ShouldSkipAuth checks if the path should skip authentication.
-// Uses pre-compiled regex patterns for performance.
+// ShouldSkipAuth checks if the path skips authentication for every method,
+// i.e. the first policy applying to all methods of the path is anonymous.
+// Uses pre-compiled policies for performance.
 func (m *Middleware) ShouldSkipAuth(path string) bool {
-	// First check exact matches (no wildcard patterns)
-	for _, skipPath := range m.Config.SkipPaths {
-		if !strings.Contains(skipPath, "*") && path == skipPath {
-			return true
-		}
-	}
-
-	// Then check cached compiled patterns (wildcard patterns)
-	for _, pattern := range m.compiledPatterns {
-		if pattern.MatchString(path) {
-			return true
-		}
-	}
-
-	return false
+	return m.PolicyFor("", path).Level == AuthLevelAnonymous
 }
 
 // patternToRegex converts a glob-style pattern to a regex string.
@@ -843,7 +993,7 @@ func (m *Middleware) logAuthFailure(c *gin.Context, subject, reason string) {
 		Subject:   subject,
 		Action:    "authentication_failed",
 		Details:   map[string]string{"reason": reason},
-		ClientIP:  c.ClientIP(),
+		ClientIP:  middleware.GetClientIP(c),
 		UserAgent: c.Request.UserAgent(),
 	}
 
@@ -866,7 +1016,7 @@ func (m *Middleware) logAccessDenied(c *gin.Context, user *AuthenticatedUser, pe
 			"path":       c.Request.URL.Path,
 			"method":     c.Request.Method,
 		},
-		ClientIP:  c.ClientIP(),
+		ClientIP:  middleware.GetClientIP(c),
 		UserAgent: c.Request.UserAgent(),
 	}
 
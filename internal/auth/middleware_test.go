@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/history"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -183,6 +184,10 @@ func (m *mockStore) ListEventsByUser(_ context.Context, _ string, _ int) ([]*aut
 	return m.events, nil
 }
 
+func (m *mockStore) QueryEvents(_ context.Context, _ history.Query) (*auth.AuditEventPage, error) {
+	return &auth.AuditEventPage{Events: m.events}, nil
+}
+
 func (m *mockStore) Ping(_ context.Context) error {
 	return nil
 }
@@ -385,9 +390,9 @@ func TestMiddleware_AuthenticationMiddleware_NoCertificate(t *testing.T) {
 			wantStatus:  http.StatusUnauthorized,
 		},
 		{
-			name:        "mTLS not required - allows through",
+			name:        "mTLS not required - still returns 401",
 			requireMTLS: false,
-			wantStatus:  http.StatusOK,
+			wantStatus:  http.StatusUnauthorized,
 		},
 	}
 
@@ -1519,3 +1524,41 @@ func TestSanitizeForLogging(t *testing.T) {
 		})
 	}
 }
+
+func TestMiddleware_Viewer(t *testing.T) {
+	store := newMockStore()
+	store.tenants["tenant-1"] = &auth.Tenant{ID: "tenant-1", Name: "Acme", Status: auth.TenantStatusActive}
+	store.roles["role-1"] = &auth.Role{ID: "role-1", Name: auth.RoleViewer, Type: auth.RoleTypeTenant}
+	store.users["user-1"] = &auth.TenantUser{
+		ID: "user-1", TenantID: "tenant-1", Subject: "CN=testuser,O=TestOrg", RoleID: "role-1", IsActive: true,
+	}
+	mw := setupTestMiddleware(t, store, nil)
+
+	viewer := func(commonName string) (*auth.AuthenticatedUser, *auth.Tenant) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil)
+		if commonName != "" {
+			c.Request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{
+				Subject: pkix.Name{CommonName: commonName, Organization: []string{"TestOrg"}},
+			}}}
+		}
+		user, tenant := mw.Viewer(c)
+		assert.False(t, c.IsAborted(), "identifying the viewer never rejects the request")
+		return user, tenant
+	}
+
+	user, tenant := viewer("testuser")
+	require.NotNil(t, user)
+	assert.Equal(t, "user-1", user.UserID)
+	assert.Equal(t, auth.RoleViewer, user.Role.Name)
+	require.NotNil(t, tenant)
+	assert.Equal(t, "Acme", tenant.Name)
+
+	user, tenant = viewer("unknown")
+	assert.Nil(t, user)
+	assert.Nil(t, tenant)
+
+	user, _ = viewer("")
+	assert.Nil(t, user, "no certificate")
+}
@@ -5,6 +5,7 @@ package auth
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -57,6 +58,9 @@ const (
 
 	// Audit log permissions.
 	PermissionAuditRead Permission = "audit:read"
+
+	// DMS adapter registration permissions (platform-level).
+	PermissionDMSAdapterManage Permission = "dmsAdapters:manage"
 )
 
 // RoleType defines the scope of a role.
@@ -172,9 +176,20 @@ type TenantQuota struct {
 	// MaxResourcePools is the maximum number of resource pools allowed.
 	MaxResourcePools int `json:"maxResourcePools"`
 
+	// MaxResources is the maximum number of resources allowed (0 = unlimited).
+	MaxResources int `json:"maxResources,omitempty"`
+
 	// MaxDeployments is the maximum number of deployments allowed.
 	MaxDeployments int `json:"maxDeployments"`
 
+	// MaxDeploymentCPUMillicores is the maximum aggregate CPU requested by DMS
+	// deployments, in millicores (0 = unlimited).
+	MaxDeploymentCPUMillicores int64 `json:"maxDeploymentCpuMillicores,omitempty"`
+
+	// MaxDeploymentMemoryBytes is the maximum aggregate memory requested by DMS
+	// deployments, in bytes (0 = unlimited).
+	MaxDeploymentMemoryBytes int64 `json:"maxDeploymentMemoryBytes,omitempty"`
+
 	// MaxUsers is the maximum number of users allowed.
 	MaxUsers int `json:"maxUsers"`
 
@@ -201,6 +216,9 @@ type TenantUsage struct {
 	// ResourcePools is the current number of resource pools.
 	ResourcePools int `json:"resourcePools"`
 
+	// Resources is the current number of resources.
+	Resources int `json:"resources,omitempty"`
+
 	// Deployments is the current number of deployments.
 	Deployments int `json:"deployments"`
 
@@ -241,6 +259,11 @@ type Tenant struct {
 	// ContactEmail is the primary contact email for the tenant.
 	ContactEmail string `json:"contactEmail,omitempty"`
 
+	// NotificationCallback is the URL the gateway notifies of events that
+	// concern the tenant itself, such as the suspension of one of its
+	// subscriptions. Calls are subject to the callback policy.
+	NotificationCallback string `json:"notificationCallback,omitempty"`
+
 	// Metadata contains additional tenant-specific key-value pairs.
 	Metadata map[string]string `json:"metadata,omitempty"`
 
@@ -396,6 +419,12 @@ const (
 	AuditEventAuthFailure AuditEventType = "auth.failure"
 	// AuditEventAccessDenied indicates access was denied.
 	AuditEventAccessDenied AuditEventType = "access.denied"
+	// AuditEventIdentityMapped indicates a client certificate was
+	// authenticated through an identity mapping rule.
+	AuditEventIdentityMapped AuditEventType = "auth.identity.mapped"
+	// AuditEventIdentityMappingRejected indicates a client certificate matched
+	// an identity mapping rule whose tenant or role could not be used.
+	AuditEventIdentityMappingRejected AuditEventType = "auth.identity.rejected"
 
 	// AuditEventTenantCreated indicates a tenant was created.
 	AuditEventTenantCreated AuditEventType = "tenant.created"
@@ -451,8 +480,17 @@ const (
 	AuditEventSubscriptionDeleted AuditEventType = "subscription.deleted"
 	// AuditEventSubscriptionFilterModified indicates a subscription filter was modified.
 	AuditEventSubscriptionFilterModified AuditEventType = "subscription.filter.modified"
+	// AuditEventSubscriptionSuspended indicates a subscription was suspended
+	// because its callback violates the callback policy.
+	AuditEventSubscriptionSuspended AuditEventType = "subscription.suspended"
+	// AuditEventSubscriptionResumed indicates a suspended subscription was
+	// resumed after its callback was updated.
+	AuditEventSubscriptionResumed AuditEventType = "subscription.resumed"
 	// AuditEventWebhookDeliveryFailed indicates a webhook delivery failed.
 	AuditEventWebhookDeliveryFailed AuditEventType = "webhook.delivery.failed"
+	// AuditEventWebhookDeliveryBlocked indicates a webhook delivery was refused
+	// because its callback resolved to a private or metadata address.
+	AuditEventWebhookDeliveryBlocked AuditEventType = "webhook.delivery.blocked"
 	// AuditEventSignatureVerificationFailed indicates signature verification failed.
 	AuditEventSignatureVerificationFailed AuditEventType = "signature.verification.failed"
 
@@ -473,6 +511,8 @@ const (
 	AuditEventConfigExport AuditEventType = "admin.config.export"
 	// AuditEventAuditExport indicates audit logs were exported.
 	AuditEventAuditExport AuditEventType = "admin.audit.export"
+	// AuditEventConfigReloaded indicates the configuration file was reloaded.
+	AuditEventConfigReloaded AuditEventType = "admin.config.reloaded"
 )
 
 // AuditEvent represents a logged security or administrative event.
@@ -510,10 +550,64 @@ type AuditEvent struct {
 	// UserAgent is the client's user agent string.
 	UserAgent string `json:"userAgent,omitempty"`
 
+	// Outcome is whether the audited action succeeded. It is derived from
+	// Type with OutcomeOf when the event is logged without one.
+	Outcome AuditOutcome `json:"outcome,omitempty"`
+
 	// Timestamp is when the event occurred.
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Actor returns who caused the event: the user, or the certificate subject
+// for events without a user.
+func (e *AuditEvent) Actor() string {
+	if e.UserID != "" {
+		return e.UserID
+	}
+	return e.Subject
+}
+
+// AuditOutcome is the result of an audited action.
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess indicates the audited action succeeded.
+	AuditOutcomeSuccess AuditOutcome = "success"
+	// AuditOutcomeFailure indicates the audited action failed or was refused.
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// OutcomeOf returns the outcome implied by an audit event type: failure for
+// failed, blocked and denied events, success otherwise.
+func OutcomeOf(eventType AuditEventType) AuditOutcome {
+	for _, suffix := range []string{".failed", ".blocked", ".denied"} {
+		if strings.HasSuffix(string(eventType), suffix) {
+			return AuditOutcomeFailure
+		}
+	}
+	return AuditOutcomeSuccess
+}
+
+// Fields audit events can be filtered on with QueryEvents.
+const (
+	AuditFilterTenant       = "tenant"
+	AuditFilterActor        = "actor"
+	AuditFilterType         = "type"
+	AuditFilterAction       = "action"
+	AuditFilterOutcome      = "outcome"
+	AuditFilterResourceType = "resourceType"
+)
+
+// AuditEventPage is a page of audit events returned by QueryEvents.
+type AuditEventPage struct {
+	// Events are the audit events of the page, in query order.
+	Events []*AuditEvent
+
+	// NextCursor continues the query after the page. It is empty on the
+	// last page.
+	NextCursor string
+}
+
 // MarshalBinary implements encoding.BinaryMarshaler for Redis storage.
 func (e *AuditEvent) MarshalBinary() ([]byte, error) {
 	data, err := json.Marshal(e)
@@ -550,6 +644,7 @@ func GetDefaultRoles() []*Role {
 				PermissionResourceTypeRead,
 				PermissionDeploymentManagerRead,
 				PermissionAuditRead,
+				PermissionDMSAdapterManage,
 			},
 		},
 		{
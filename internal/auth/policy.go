@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthLevel is the authentication level required by a route policy.
+type AuthLevel string
+
+const (
+	// AuthLevelAnonymous allows requests without credentials.
+	AuthLevelAnonymous AuthLevel = "anonymous"
+
+	// AuthLevelAuthenticated requires an authenticated user.
+	AuthLevelAuthenticated AuthLevel = "authenticated"
+
+	// AuthLevelRole requires an authenticated user holding one of the policy roles.
+	AuthLevelRole AuthLevel = "role"
+)
+
+// anonymousAccessKey is the gin context key set when a request was admitted anonymously.
+const anonymousAccessKey = "auth_anonymous"
+
+// PolicyRule maps route paths and methods to the authentication level they require.
+type PolicyRule struct {
+	// Paths are exact paths or glob patterns (e.g. "/o2ims-infrastructureInventory/v1/*").
+	Paths []string
+
+	// Methods restricts the rule to these HTTP methods. Empty matches all methods.
+	Methods []string
+
+	// Level is the required authentication level.
+	Level AuthLevel
+
+	// Roles lists the accepted role names when Level is AuthLevelRole.
+	Roles []RoleName
+}
+
+// Validate checks that the rule is well formed.
+func (r *PolicyRule) Validate() error {
+	if len(r.Paths) == 0 {
+		return fmt.Errorf("auth policy must match at least one path")
+	}
+	switch r.Level {
+	case AuthLevelAnonymous, AuthLevelAuthenticated:
+	case AuthLevelRole:
+		if len(r.Roles) == 0 {
+			return fmt.Errorf("auth policy with level %q must list at least one role", AuthLevelRole)
+		}
+	default:
+		return fmt.Errorf("invalid auth policy level %q (must be anonymous, authenticated, or role)", r.Level)
+	}
+	return nil
+}
+
+// allowsRole reports whether an authenticated user with role satisfies the rule.
+func (r *PolicyRule) allowsRole(role *Role) bool {
+	if r.Level != AuthLevelRole {
+		return true
+	}
+	return role != nil && slices.Contains(r.Roles, role.Name)
+}
+
+// compiledPolicyRule is a PolicyRule with its path patterns pre-compiled.
+type compiledPolicyRule struct {
+	rule     PolicyRule
+	exact    []string
+	patterns []*regexp.Regexp
+	methods  []string
+}
+
+func compilePolicyRule(rule PolicyRule) (*compiledPolicyRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	compiled := &compiledPolicyRule{rule: rule}
+	for _, path := range rule.Paths {
+		if !strings.Contains(path, "*") {
+			compiled.exact = append(compiled.exact, path)
+			continue
+		}
+		pattern, err := regexp.Compile(patternToRegex(path))
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth policy path %q: %w", path, err)
+		}
+		compiled.patterns = append(compiled.patterns, pattern)
+	}
+	for _, method := range rule.Methods {
+		compiled.methods = append(compiled.methods, strings.ToUpper(method))
+	}
+	return compiled, nil
+}
+
+// matches reports whether the rule applies to the request. An empty method only
+// matches rules that apply to every method.
+func (r *compiledPolicyRule) matches(method, path string) bool {
+	if len(r.methods) > 0 && !slices.Contains(r.methods, strings.ToUpper(method)) {
+		return false
+	}
+	if slices.Contains(r.exact, path) {
+		return true
+	}
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultPolicy applies to requests that match no rule.
+var defaultPolicy = PolicyRule{Level: AuthLevelAuthenticated}
+
+// PolicyFor returns the policy applying to a request: the first matching rule,
+// or one requiring authentication if no rule matches. Skip paths are evaluated
+// first as anonymous rules.
+func (m *Middleware) PolicyFor(method, path string) PolicyRule {
+	m.policiesMu.RLock()
+	defer m.policiesMu.RUnlock()
+	for _, rule := range m.policies {
+		if rule.matches(method, path) {
+			return rule.rule
+		}
+	}
+	return defaultPolicy
+}
+
+// IsAnonymousAccess reports whether the request was admitted without
+// authentication under an anonymous policy.
+func IsAnonymousAccess(c *gin.Context) bool {
+	return c.GetBool(anonymousAccessKey)
+}
@@ -0,0 +1,194 @@
+package auth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/auth"
+)
+
+func TestPolicyRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    auth.PolicyRule
+		wantErr bool
+	}{
+		{name: "anonymous", rule: auth.PolicyRule{Paths: []string{"/health"}, Level: auth.AuthLevelAnonymous}},
+		{name: "authenticated", rule: auth.PolicyRule{Paths: []string{"/api/*"}, Level: auth.AuthLevelAuthenticated}},
+		{
+			name: "role",
+			rule: auth.PolicyRule{Paths: []string{"/api/*"}, Level: auth.AuthLevelRole, Roles: []auth.RoleName{auth.RoleOperator}},
+		},
+		{name: "no paths", rule: auth.PolicyRule{Level: auth.AuthLevelAnonymous}, wantErr: true},
+		{name: "role without roles", rule: auth.PolicyRule{Paths: []string{"/api"}, Level: auth.AuthLevelRole}, wantErr: true},
+		{name: "unknown level", rule: auth.PolicyRule{Paths: []string{"/api"}, Level: "public"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMiddleware_PolicyFor(t *testing.T) {
+	mw := setupTestMiddleware(t, newMockStore(), &auth.MiddlewareConfig{
+		Enabled:   true,
+		SkipPaths: []string{"/health"},
+		Policies: []auth.PolicyRule{
+			{Paths: []string{"/api/v1/*"}, Methods: []string{"get", "HEAD"}, Level: auth.AuthLevelAnonymous},
+			{Paths: []string{"/api/v1/pools/*"}, Level: auth.AuthLevelRole, Roles: []auth.RoleName{auth.RoleOperator}},
+			// Invalid rules are ignored.
+			{Paths: []string{"/api/v1/*"}, Level: "public"},
+		},
+	})
+
+	assert.Equal(t, auth.AuthLevelAnonymous, mw.PolicyFor(http.MethodPost, "/health").Level)
+	assert.Equal(t, auth.AuthLevelAnonymous, mw.PolicyFor(http.MethodGet, "/api/v1/pools/p1").Level)
+	assert.Equal(t, auth.AuthLevelRole, mw.PolicyFor(http.MethodPut, "/api/v1/pools/p1").Level)
+	assert.Equal(t, auth.AuthLevelAuthenticated, mw.PolicyFor(http.MethodPost, "/api/v1/resources").Level)
+	assert.Equal(t, auth.AuthLevelAuthenticated, mw.PolicyFor(http.MethodGet, "/other").Level)
+
+	// Method-restricted rules do not make a path skip authentication entirely.
+	assert.True(t, mw.ShouldSkipAuth("/health"))
+	assert.False(t, mw.ShouldSkipAuth("/api/v1/pools/p1"))
+}
+
+func TestMiddleware_SetPolicies(t *testing.T) {
+	mw := setupTestMiddleware(t, newMockStore(), &auth.MiddlewareConfig{
+		Enabled:  true,
+		Policies: []auth.PolicyRule{{Paths: []string{"/health"}, Level: auth.AuthLevelAnonymous}},
+	})
+	assert.Equal(t, auth.AuthLevelAuthenticated, mw.PolicyFor(http.MethodGet, "/version").Level)
+
+	mw.SetPolicies([]auth.PolicyRule{{Paths: []string{"/version"}, Level: auth.AuthLevelAnonymous}})
+	assert.Equal(t, auth.AuthLevelAnonymous, mw.PolicyFor(http.MethodGet, "/version").Level)
+	assert.Equal(t, auth.AuthLevelAuthenticated, mw.PolicyFor(http.MethodGet, "/health").Level)
+}
+
+func TestMiddleware_AuthPolicyMatrix(t *testing.T) {
+	store := newMockStore()
+	store.tenants["tenant-1"] = &auth.Tenant{ID: "tenant-1", Name: "Tenant", Status: auth.TenantStatusActive}
+	store.roles["role-viewer"] = &auth.Role{
+		ID:          "role-viewer",
+		Name:        auth.RoleViewer,
+		Type:        auth.RoleTypeTenant,
+		Permissions: []auth.Permission{auth.PermissionResourcePoolRead, auth.PermissionResourcePoolCreate},
+	}
+	store.users["user-1"] = &auth.TenantUser{
+		ID:       "user-1",
+		TenantID: "tenant-1",
+		Subject:  "CN=viewer,O=TestOrg",
+		RoleID:   "role-viewer",
+		IsActive: true,
+	}
+
+	mw := setupTestMiddleware(t, store, &auth.MiddlewareConfig{
+		Enabled:     true,
+		RequireMTLS: true,
+		Policies: []auth.PolicyRule{
+			{Paths: []string{"/pools", "/pools/*"}, Methods: []string{http.MethodGet}, Level: auth.AuthLevelAnonymous},
+			{
+				Paths:   []string{"/pools/*"},
+				Methods: []string{http.MethodDelete},
+				Level:   auth.AuthLevelRole,
+				Roles:   []auth.RoleName{auth.RoleTenantAdmin},
+			},
+		},
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(mw.AuthenticationMiddleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/pools", mw.RequirePermission(string(auth.PermissionResourcePoolRead)), ok)
+	router.POST("/pools", mw.RequirePermission(string(auth.PermissionResourcePoolCreate)), ok)
+	router.DELETE("/pools/:id", ok)
+
+	withCert := func(req *http.Request) *http.Request {
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{
+			Subject: pkix.Name{CommonName: "viewer", Organization: []string{"TestOrg"}},
+		}}}
+		return req
+	}
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+	}{
+		{
+			name:       "anonymous read",
+			req:        httptest.NewRequest(http.MethodGet, "/pools", nil),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "anonymous write requires authentication",
+			req:        httptest.NewRequest(http.MethodPost, "/pools", nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "authenticated write",
+			req:        withCert(httptest.NewRequest(http.MethodPost, "/pools", nil)),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "role policy rejects other roles",
+			req:        withCert(httptest.NewRequest(http.MethodDelete, "/pools/p1", nil)),
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tt.req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMiddleware_PoliciesWithoutCertificate(t *testing.T) {
+	// Without require_mtls, requests lacking a certificate still carry no
+	// identity, so only anonymous policies admit them.
+	mw := setupTestMiddleware(t, newMockStore(), &auth.MiddlewareConfig{
+		Enabled:     true,
+		RequireMTLS: false,
+		Policies: []auth.PolicyRule{
+			{Paths: []string{"/public"}, Level: auth.AuthLevelAnonymous},
+			{Paths: []string{"/admin/*"}, Level: auth.AuthLevelRole, Roles: []auth.RoleName{auth.RolePlatformAdmin}},
+		},
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(mw.AuthenticationMiddleware())
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/public", ok)
+	router.GET("/admin/tenants", ok)
+	router.GET("/pools", ok)
+
+	for path, wantStatus := range map[string]int{
+		"/public":        http.StatusOK,
+		"/admin/tenants": http.StatusUnauthorized,
+		"/pools":         http.StatusUnauthorized,
+	} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, wantStatus, w.Code)
+		})
+	}
+}
@@ -11,6 +11,9 @@ import (
 
 	redis "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/history"
+	"github.com/piwi3910/netweave/internal/readfallback"
 )
 
 // sanitizeSubjectKey creates a safe Redis key from a certificate subject.
@@ -37,7 +40,12 @@ const (
 	auditTenantIndex = "audit:tenant:"
 	auditUserIndex   = "audit:user:"
 	auditTypeIndex   = "audit:type:"
+	auditActionIndex = "audit:action:"
+	auditResultIndex = "audit:outcome:"
+	auditRTypeIndex  = "audit:resourcetype:"
+	auditQueryPrefix = "audit:query:"
 	usageKeyPrefix   = "usage:"
+	ownerKeyPrefix   = "owner:"
 
 	// Default TTL for audit events (30 days).
 	auditEventTTL = 30 * 24 * time.Hour
@@ -45,8 +53,9 @@ const (
 
 // Lua script for atomic quota check and increment.
 // KEYS[1] = tenant key
-// ARGV[1] = usage type (subscriptions, resourcePools, deployments, users)
+// ARGV[1] = usage type (subscriptions, resourcePools, resources, deployments, users)
 // Returns: 1 if incremented successfully, 0 if quota exceeded, -1 if tenant not found.
+// A resources quota of 0 is unlimited.
 var incrementUsageScript = redis.NewScript(`
 local tenantData = redis.call('GET', KEYS[1])
 if not tenantData then
@@ -67,6 +76,12 @@ if usageType == "subscriptions" then
 elseif usageType == "resourcePools" then
     currentUsage = usage.resourcePools or 0
     maxQuota = quota.maxResourcePools or 0
+elseif usageType == "resources" then
+    currentUsage = usage.resources or 0
+    maxQuota = quota.maxResources or 0
+    if maxQuota == 0 then
+        maxQuota = currentUsage + 1
+    end
 elseif usageType == "deployments" then
     currentUsage = usage.deployments or 0
     maxQuota = quota.maxDeployments or 0
@@ -86,6 +101,8 @@ if usageType == "subscriptions" then
     tenant.usage.subscriptions = currentUsage + 1
 elseif usageType == "resourcePools" then
     tenant.usage.resourcePools = currentUsage + 1
+elseif usageType == "resources" then
+    tenant.usage.resources = currentUsage + 1
 elseif usageType == "deployments" then
     tenant.usage.deployments = currentUsage + 1
 elseif usageType == "users" then
@@ -151,6 +168,10 @@ elseif usageType == "resourcePools" then
     if (usage.resourcePools or 0) > 0 then
         tenant.usage.resourcePools = (usage.resourcePools or 0) - 1
     end
+elseif usageType == "resources" then
+    if (usage.resources or 0) > 0 then
+        tenant.usage.resources = (usage.resources or 0) - 1
+    end
 elseif usageType == "deployments" then
     if (usage.deployments or 0) > 0 then
         tenant.usage.deployments = (usage.deployments or 0) - 1
@@ -170,6 +191,25 @@ redis.call('SET', KEYS[1], cjson.encode(tenant))
 return 1
 `)
 
+// Lua script for atomic quota replacement.
+// KEYS[1] = tenant key
+// ARGV[1] = quota JSON, ARGV[2] = timestamp
+// Returns: the updated tenant JSON, or false if the tenant was not found.
+var updateQuotaScript = redis.NewScript(`
+local tenantData = redis.call('GET', KEYS[1])
+if not tenantData then
+    return false
+end
+
+local tenant = cjson.decode(tenantData)
+tenant.quota = cjson.decode(ARGV[1])
+tenant.updatedAt = ARGV[2]
+
+local updated = cjson.encode(tenant)
+redis.call('SET', KEYS[1], updated)
+return updated
+`)
+
 // RedisConfig holds configuration for Redis connection.
 type RedisConfig struct {
 	// Addr is the Redis server address (host:port) for standalone mode.
@@ -231,6 +271,10 @@ type RedisStore struct {
 	client redis.UniversalClient
 	Config *RedisConfig // Exported for testing
 	logger *zap.Logger
+
+	// fallback serves tenant, user and role reads while Redis is
+	// unavailable (nil: disabled).
+	fallback *readfallback.Cache
 }
 
 // NewRedisStore creates a new RedisStore instance.
@@ -284,6 +328,58 @@ func NewRedisStoreWithClient(client redis.UniversalClient) *RedisStore {
 	}
 }
 
+// EnableReadFallback keeps the tenants, users and roles read from Redis in
+// cache, which answers tenant reads and authentication lookups while Redis is
+// unavailable. Tenant writes fail with ErrStorageUnavailable during an outage.
+func (r *RedisStore) EnableReadFallback(cache *readfallback.Cache) {
+	r.fallback = cache
+}
+
+// Client returns the underlying Redis client, e.g. to monitor its connection
+// pool.
+func (r *RedisStore) Client() redis.UniversalClient {
+	return r.client
+}
+
+// storageError wraps a failed Redis call, with ErrStorageUnavailable if Redis
+// could not be reached.
+func storageError(msg string, err error) error {
+	if readfallback.IsUnavailable(err) {
+		return fmt.Errorf("%w: %s: %w", ErrStorageUnavailable, msg, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// writeError wraps a failed Redis write like storageError and counts writes
+// rejected by an outage.
+func (r *RedisStore) writeError(msg string, err error) error {
+	if readfallback.IsUnavailable(err) {
+		r.fallback.RecordRejectedWrite()
+	}
+	return storageError(msg, err)
+}
+
+// getJSON reads the JSON object at key into dst, from the read fallback cache
+// while Redis is unavailable. It returns notFound if the key does not exist.
+func (r *RedisStore) getJSON(ctx context.Context, key, entityType string, notFound error, dst any) error {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return notFound
+		}
+		if readfallback.IsUnavailable(err) && r.fallback.Serve(ctx, key, dst) {
+			return nil
+		}
+		return storageError("failed to get "+entityType, err)
+	}
+
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", entityType, err)
+	}
+	r.fallback.Put(key, json.RawMessage(data))
+	return nil
+}
+
 // Close closes the Redis connection.
 func (r *RedisStore) Close() error {
 	if err := r.client.Close(); err != nil {
@@ -374,7 +470,7 @@ func (r *RedisStore) CreateTenant(ctx context.Context, tenant *Tenant) error {
 	// Use SetNX for atomic creation - only sets if key doesn't exist.
 	wasSet, err := r.client.SetNX(ctx, key, data, 0).Result()
 	if err != nil {
-		return fmt.Errorf("failed to create tenant: %w", err)
+		return r.writeError("failed to create tenant", err)
 	}
 	if !wasSet {
 		return ErrTenantExists
@@ -384,8 +480,10 @@ func (r *RedisStore) CreateTenant(ctx context.Context, tenant *Tenant) error {
 	if err := r.client.SAdd(ctx, tenantSetKey, tenant.ID).Err(); err != nil {
 		// Rollback: delete the tenant key if we can't add to set.
 		r.client.Del(ctx, key)
-		return fmt.Errorf("failed to create tenant: %w", err)
+		return r.writeError("failed to create tenant", err)
 	}
+	r.fallback.Put(key, json.RawMessage(data))
+	r.fallback.Delete(tenantSetKey)
 
 	return nil
 }
@@ -396,18 +494,9 @@ func (r *RedisStore) GetTenant(ctx context.Context, id string) (*Tenant, error)
 		return nil, ErrInvalidTenantID
 	}
 
-	key := tenantKeyPrefix + id
-	data, err := r.client.Get(ctx, key).Bytes()
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return nil, ErrTenantNotFound
-		}
-		return nil, fmt.Errorf("failed to get tenant: %w", err)
-	}
-
 	var tenant Tenant
-	if err := json.Unmarshal(data, &tenant); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tenant: %w", err)
+	if err := r.getJSON(ctx, tenantKeyPrefix+id, "tenant", ErrTenantNotFound, &tenant); err != nil {
+		return nil, err
 	}
 
 	return &tenant, nil
@@ -423,7 +512,7 @@ func (r *RedisStore) UpdateTenant(ctx context.Context, tenant *Tenant) error {
 
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to check tenant existence: %w", err)
+		return r.writeError("failed to check tenant existence", err)
 	}
 	if exists == 0 {
 		return ErrTenantNotFound
@@ -443,8 +532,10 @@ func (r *RedisStore) UpdateTenant(ctx context.Context, tenant *Tenant) error {
 	}
 
 	if err := r.client.Set(ctx, key, data, 0).Err(); err != nil {
-		return fmt.Errorf("failed to update tenant: %w", err)
+		return r.writeError("failed to update tenant", err)
 	}
+	r.fallback.Put(key, json.RawMessage(data))
+	r.fallback.Delete(tenantSetKey)
 
 	return nil
 }
@@ -459,7 +550,7 @@ func (r *RedisStore) DeleteTenant(ctx context.Context, id string) error {
 
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to check tenant existence: %w", err)
+		return r.writeError("failed to check tenant existence", err)
 	}
 	if exists == 0 {
 		return ErrTenantNotFound
@@ -471,8 +562,9 @@ func (r *RedisStore) DeleteTenant(ctx context.Context, id string) error {
 	pipe.Del(ctx, usageKeyPrefix+id)
 
 	if _, err := pipe.Exec(ctx); err != nil {
-		return fmt.Errorf("failed to delete tenant: %w", err)
+		return r.writeError("failed to delete tenant", err)
 	}
+	r.fallback.Delete(key, tenantSetKey)
 
 	return nil
 }
@@ -484,8 +576,13 @@ func (r *RedisStore) ListTenants(ctx context.Context) ([]*Tenant, error) {
 		ctx, r.client, r.logger, tenantSetKey, tenantKeyPrefix, "tenant", "tenant_id",
 	)
 	if err != nil {
-		return nil, err
+		var cached []*Tenant
+		if readfallback.IsUnavailable(err) && r.fallback.Serve(ctx, tenantSetKey, &cached) {
+			return cached, nil
+		}
+		return nil, storageError("failed to list tenants", err)
 	}
+	r.fallback.Put(tenantSetKey, tenants)
 	return tenants, nil
 }
 
@@ -498,7 +595,7 @@ func (r *RedisStore) IncrementUsage(ctx context.Context, tenantID, usageType str
 
 	// Validate usage type.
 	switch usageType {
-	case "subscriptions", "resourcePools", "deployments", "users":
+	case "subscriptions", "resourcePools", "resources", "deployments", "users":
 		// Valid usage type.
 	default:
 		return fmt.Errorf("unknown usage type: %s", usageType)
@@ -535,7 +632,7 @@ func (r *RedisStore) DecrementUsage(ctx context.Context, tenantID, usageType str
 
 	// Validate usage type.
 	switch usageType {
-	case "subscriptions", "resourcePools", "deployments", "users":
+	case "subscriptions", "resourcePools", "resources", "deployments", "users":
 		// Valid usage type.
 	default:
 		return fmt.Errorf("unknown usage type: %s", usageType)
@@ -561,6 +658,91 @@ func (r *RedisStore) DecrementUsage(ctx context.Context, tenantID, usageType str
 	}
 }
 
+// UpdateTenantQuota replaces the quota of a tenant and returns the updated
+// tenant. Usage counters are left untouched, unlike UpdateTenant which writes
+// the whole tenant and could undo concurrent usage changes.
+func (r *RedisStore) UpdateTenantQuota(ctx context.Context, tenantID string, quota TenantQuota) (*Tenant, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+
+	quotaData, err := json.Marshal(quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quota: %w", err)
+	}
+	timestamp := time.Now().UTC().Format(time.RFC3339Nano)
+
+	data, err := updateQuotaScript.Run(ctx, r.client, []string{tenantKeyPrefix + tenantID}, quotaData, timestamp).Text()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrTenantNotFound
+		}
+		return nil, r.writeError("failed to update tenant quota", err)
+	}
+
+	var tenant Tenant
+	if err := json.Unmarshal([]byte(data), &tenant); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tenant: %w", err)
+	}
+	r.fallback.Put(tenantKeyPrefix+tenantID, json.RawMessage(data))
+	r.fallback.Delete(tenantSetKey)
+	return &tenant, nil
+}
+
+// SetOwner records the tenant owning an inventory object.
+func (r *RedisStore) SetOwner(ctx context.Context, kind, id, tenantID string) error {
+	if tenantID == "" {
+		return ErrInvalidTenantID
+	}
+	if err := r.client.Set(ctx, ownerKeyPrefix+kind+":"+id, tenantID, 0).Err(); err != nil {
+		return fmt.Errorf("failed to set owner: %w", err)
+	}
+	return nil
+}
+
+// GetOwner returns the tenant owning an inventory object, or "" if none is recorded.
+func (r *RedisStore) GetOwner(ctx context.Context, kind, id string) (string, error) {
+	tenantID, err := r.client.Get(ctx, ownerKeyPrefix+kind+":"+id).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get owner: %w", err)
+	}
+	return tenantID, nil
+}
+
+// GetOwners returns the tenants owning several inventory objects of kind, keyed by ID.
+func (r *RedisStore) GetOwners(ctx context.Context, kind string, ids []string) (map[string]string, error) {
+	owners := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return owners, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = ownerKeyPrefix + kind + ":" + id
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owners: %w", err)
+	}
+	for i, value := range values {
+		if tenantID, ok := value.(string); ok {
+			owners[ids[i]] = tenantID
+		}
+	}
+	return owners, nil
+}
+
+// DeleteOwner removes the ownership record of an inventory object.
+func (r *RedisStore) DeleteOwner(ctx context.Context, kind, id string) error {
+	if err := r.client.Del(ctx, ownerKeyPrefix+kind+":"+id).Err(); err != nil {
+		return fmt.Errorf("failed to delete owner: %w", err)
+	}
+	return nil
+}
+
 // CreateUser creates a new user.
 // Uses a Lua script for atomic creation to prevent race conditions.
 func (r *RedisStore) CreateUser(ctx context.Context, user *TenantUser) error {
@@ -607,18 +789,9 @@ func (r *RedisStore) GetUser(ctx context.Context, id string) (*TenantUser, error
 		return nil, ErrInvalidUserID
 	}
 
-	key := userKeyPrefix + id
-	data, err := r.client.Get(ctx, key).Bytes()
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return nil, ErrUserNotFound
-		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
-	}
-
 	var user TenantUser
-	if err := json.Unmarshal(data, &user); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	if err := r.getJSON(ctx, userKeyPrefix+id, "user", ErrUserNotFound, &user); err != nil {
+		return nil, err
 	}
 
 	return &user, nil
@@ -636,7 +809,11 @@ func (r *RedisStore) GetUserBySubject(ctx context.Context, subject string) (*Ten
 		if errors.Is(err, redis.Nil) {
 			return nil, ErrUserNotFound
 		}
-		return nil, fmt.Errorf("failed to get user by subject: %w", err)
+		if !readfallback.IsUnavailable(err) || !r.fallback.Serve(ctx, subjectKey, &userID) {
+			return nil, storageError("failed to get user by subject", err)
+		}
+	} else {
+		r.fallback.Put(subjectKey, userID)
 	}
 
 	return r.GetUser(ctx, userID)
@@ -689,6 +866,7 @@ func (r *RedisStore) UpdateUser(ctx context.Context, user *TenantUser) error {
 	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
+	r.fallback.Delete(key, userSubjectIndex+sanitizeSubjectKey(existing.Subject))
 
 	return nil
 }
@@ -712,6 +890,7 @@ func (r *RedisStore) DeleteUser(ctx context.Context, id string) error {
 	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
+	r.fallback.Delete(userKeyPrefix+id, userSubjectIndex+sanitizeSubjectKey(user.Subject))
 
 	return nil
 }
@@ -841,18 +1020,9 @@ func (r *RedisStore) GetRole(ctx context.Context, id string) (*Role, error) {
 		return nil, ErrInvalidRoleID
 	}
 
-	key := roleKeyPrefix + id
-	data, err := r.client.Get(ctx, key).Bytes()
-	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return nil, ErrRoleNotFound
-		}
-		return nil, fmt.Errorf("failed to get role: %w", err)
-	}
-
 	var role Role
-	if err := json.Unmarshal(data, &role); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal role: %w", err)
+	if err := r.getJSON(ctx, roleKeyPrefix+id, "role", ErrRoleNotFound, &role); err != nil {
+		return nil, err
 	}
 
 	return &role, nil
@@ -913,6 +1083,7 @@ func (r *RedisStore) UpdateRole(ctx context.Context, role *Role) error {
 	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to update role: %w", err)
 	}
+	r.fallback.Delete(key)
 
 	return nil
 }
@@ -940,6 +1111,7 @@ func (r *RedisStore) DeleteRole(ctx context.Context, id string) error {
 	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to delete role: %w", err)
 	}
+	r.fallback.Delete(roleKeyPrefix + id)
 
 	return nil
 }
@@ -995,6 +1167,9 @@ func (r *RedisStore) LogEvent(ctx context.Context, event *AuditEvent) error {
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now().UTC()
 	}
+	if event.Outcome == "" {
+		event.Outcome = OutcomeOf(event.Type)
+	}
 
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -1002,33 +1177,22 @@ func (r *RedisStore) LogEvent(ctx context.Context, event *AuditEvent) error {
 	}
 
 	key := auditKeyPrefix + event.ID
-	score := float64(event.Timestamp.UnixNano())
-	// Calculate expiration cutoff for cleanup (events older than TTL).
-	expirationCutoff := float64(time.Now().Add(-auditEventTTL).UnixNano())
 
 	pipe := r.client.TxPipeline()
 
 	// Store the event with TTL.
 	pipe.Set(ctx, key, data, auditEventTTL)
 
-	// Use sorted sets with timestamp scores for better time-based queries.
-	pipe.ZAdd(ctx, auditListKey, redis.Z{Score: score, Member: event.ID})
-
-	// Cleanup old entries from sorted sets (older than TTL).
-	pipe.ZRemRangeByScore(ctx, auditListKey, "-inf", fmt.Sprintf("%f", expirationCutoff))
-
-	if event.TenantID != "" {
-		pipe.ZAdd(ctx, auditTenantIndex+event.TenantID, redis.Z{Score: score, Member: event.ID})
-		pipe.ZRemRangeByScore(ctx, auditTenantIndex+event.TenantID, "-inf", fmt.Sprintf("%f", expirationCutoff))
-	}
-
-	if event.UserID != "" {
-		pipe.ZAdd(ctx, auditUserIndex+event.UserID, redis.Z{Score: score, Member: event.ID})
-		pipe.ZRemRangeByScore(ctx, auditUserIndex+event.UserID, "-inf", fmt.Sprintf("%f", expirationCutoff))
-	}
-
-	pipe.ZAdd(ctx, auditTypeIndex+string(event.Type), redis.Z{Score: score, Member: event.ID})
-	pipe.ZRemRangeByScore(ctx, auditTypeIndex+string(event.Type), "-inf", fmt.Sprintf("%f", expirationCutoff))
+	// Index the event in sorted sets scored by its timestamp, dropping
+	// entries older than the TTL.
+	r.auditIndex().Add(ctx, pipe, event.ID, event.Timestamp, map[string]string{
+		AuditFilterTenant:       event.TenantID,
+		AuditFilterActor:        event.UserID,
+		AuditFilterType:         string(event.Type),
+		AuditFilterAction:       event.Action,
+		AuditFilterOutcome:      string(event.Outcome),
+		AuditFilterResourceType: event.ResourceType,
+	}, auditEventTTL)
 
 	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to log audit event: %w", err)
@@ -1157,6 +1321,96 @@ func (r *RedisStore) ListEventsByUser(ctx context.Context, userID string, limit
 	return events, nil
 }
 
+// auditIndex returns the history index of the audit events.
+func (r *RedisStore) auditIndex() *history.Index {
+	return &history.Index{
+		Client: r.client,
+		AllKey: auditListKey,
+		FieldPrefixes: map[string]string{
+			AuditFilterTenant:       auditTenantIndex,
+			AuditFilterActor:        auditUserIndex,
+			AuditFilterType:         auditTypeIndex,
+			AuditFilterAction:       auditActionIndex,
+			AuditFilterOutcome:      auditResultIndex,
+			AuditFilterResourceType: auditRTypeIndex,
+		},
+		TempPrefix: auditQueryPrefix,
+	}
+}
+
+// QueryEvents returns a page of the audit events selected by q. Filters and
+// time order are answered from the audit indexes; sorting by actor or
+// resource orders the most recent history.MaxSortScan matching events.
+func (r *RedisStore) QueryEvents(ctx context.Context, q history.Query) (*AuditEventPage, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	q.Normalize()
+	index := r.auditIndex()
+
+	if q.Sort == history.SortTime {
+		page, err := index.Query(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		events, err := r.getAuditEvents(ctx, page.IDs)
+		if err != nil {
+			return nil, err
+		}
+		return &AuditEventPage{Events: events, NextCursor: page.NextCursor}, nil
+	}
+
+	ids, err := index.Matching(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.getAuditEvents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	key := func(e *AuditEvent) string { return e.Actor() }
+	if q.Sort == history.SortResource {
+		key = func(e *AuditEvent) string { return e.ResourceType + "/" + e.ResourceID }
+	}
+	events, cursor, err := history.SortPage(q, events, key)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditEventPage{Events: events, NextCursor: cursor}, nil
+}
+
+// getAuditEvents retrieves the audit events with the given IDs in order,
+// skipping expired ones.
+func (r *RedisStore) getAuditEvents(ctx context.Context, ids []string) ([]*AuditEvent, error) {
+	events := make([]*AuditEvent, 0, len(ids))
+	if len(ids) == 0 {
+		return events, nil
+	}
+	// A pipeline rather than MGET, whose keys would span cluster slots.
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.Get(ctx, auditKeyPrefix+id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get audit events from Redis: %w", err)
+	}
+	for i, cmd := range cmds {
+		data, err := cmd.Result()
+		if err != nil {
+			// Log at debug level since event expiration is expected behavior.
+			r.logger.Debug("skipping audit event (likely expired)", zap.String("event_id", ids[i]))
+			continue
+		}
+		var event AuditEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit event: %w", err)
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
 // getAuditEvent retrieves an audit event by ID.
 func (r *RedisStore) getAuditEvent(ctx context.Context, id string) (*AuditEvent, error) {
 	key := auditKeyPrefix + id
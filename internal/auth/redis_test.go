@@ -7,6 +7,8 @@ import (
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/history"
+	"github.com/piwi3910/netweave/internal/readfallback"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -504,6 +506,94 @@ func TestRedisStore_AuditOperations(t *testing.T) {
 	})
 }
 
+func TestRedisStore_QueryEvents(t *testing.T) {
+	store := setupTestRedis(t)
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	events := []*auth.AuditEvent{
+		{ID: "e1", TenantID: "t1", UserID: "carol", Type: auth.AuditEventResourceCreated,
+			Action: "create", ResourceType: "resource", ResourceID: "r1", Timestamp: base},
+		{ID: "e2", TenantID: "t1", UserID: "alice", Type: auth.AuditEventWebhookDeliveryFailed,
+			Action: "delivery", ResourceType: "webhook", ResourceID: "w1", Timestamp: base.Add(time.Minute)},
+		{ID: "e3", TenantID: "t2", UserID: "bob", Type: auth.AuditEventResourceDeleted,
+			Action: "delete", ResourceType: "resource", ResourceID: "r2", Timestamp: base.Add(2 * time.Minute)},
+		{ID: "e4", TenantID: "t1", UserID: "bob", Type: auth.AuditEventResourceCreated,
+			Action: "create", ResourceType: "resource", ResourceID: "r3", Timestamp: base.Add(3 * time.Minute)},
+	}
+	for _, event := range events {
+		require.NoError(t, store.LogEvent(ctx, event))
+	}
+
+	ids := func(page *auth.AuditEventPage) []string {
+		result := make([]string, len(page.Events))
+		for i, event := range page.Events {
+			result[i] = event.ID
+		}
+		return result
+	}
+
+	t.Run("pages by time with a cursor", func(t *testing.T) {
+		page, err := store.QueryEvents(ctx, history.Query{Limit: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e4", "e3", "e2"}, ids(page))
+		require.NotEmpty(t, page.NextCursor)
+
+		page, err = store.QueryEvents(ctx, history.Query{Limit: 3, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e1"}, ids(page))
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("intersects filters", func(t *testing.T) {
+		page, err := store.QueryEvents(ctx, history.Query{Filters: map[string]string{
+			auth.AuditFilterTenant:       "t1",
+			auth.AuditFilterResourceType: "resource",
+		}, Ascending: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e1", "e4"}, ids(page))
+	})
+
+	t.Run("derives the outcome", func(t *testing.T) {
+		page, err := store.QueryEvents(ctx, history.Query{Filters: map[string]string{
+			auth.AuditFilterOutcome: string(auth.AuditOutcomeFailure),
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e2"}, ids(page))
+	})
+
+	t.Run("restricts the time window", func(t *testing.T) {
+		page, err := store.QueryEvents(ctx, history.Query{
+			Since: base.Add(time.Minute), Until: base.Add(2 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e3", "e2"}, ids(page))
+	})
+
+	t.Run("sorts by actor", func(t *testing.T) {
+		page, err := store.QueryEvents(ctx, history.Query{Sort: history.SortActor, Ascending: true, Limit: 2})
+		require.NoError(t, err)
+		// Events of the same actor stay ordered newest first.
+		assert.Equal(t, []string{"e2", "e4"}, ids(page))
+
+		page, err = store.QueryEvents(ctx, history.Query{
+			Sort: history.SortActor, Ascending: true, Limit: 2, Cursor: page.NextCursor,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e3", "e1"}, ids(page))
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("rejects a cursor of another query", func(t *testing.T) {
+		page, err := store.QueryEvents(ctx, history.Query{Limit: 1})
+		require.NoError(t, err)
+
+		_, err = store.QueryEvents(ctx, history.Query{Limit: 1, Ascending: true, Cursor: page.NextCursor})
+		require.ErrorIs(t, err, history.ErrInvalidCursor)
+	})
+}
+
 func TestRedisStore_Ping(t *testing.T) {
 	store := setupTestRedis(t)
 
@@ -787,3 +877,136 @@ func TestDeleteTenant(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, auth.ErrTenantNotFound)
 }
+
+// TestIncrementUsage_Resources tests the resources quota, where 0 is unlimited.
+func TestIncrementUsage_Resources(t *testing.T) {
+	store := setupTestRedis(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.CreateTenant(ctx, &auth.Tenant{
+		ID:     "tenant-res",
+		Name:   "Resource tenant",
+		Status: auth.TenantStatusActive,
+	}))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.IncrementUsage(ctx, "tenant-res", "resources"))
+	}
+
+	_, err := store.UpdateTenantQuota(ctx, "tenant-res", auth.TenantQuota{MaxResources: 3})
+	require.NoError(t, err)
+	err = store.IncrementUsage(ctx, "tenant-res", "resources")
+	assert.ErrorIs(t, err, auth.ErrQuotaExceeded)
+
+	require.NoError(t, store.DecrementUsage(ctx, "tenant-res", "resources"))
+	require.NoError(t, store.IncrementUsage(ctx, "tenant-res", "resources"))
+
+	retrieved, err := store.GetTenant(ctx, "tenant-res")
+	require.NoError(t, err)
+	assert.Equal(t, 3, retrieved.Usage.Resources)
+}
+
+// TestUpdateTenantQuota tests that quota updates keep usage counters.
+func TestUpdateTenantQuota(t *testing.T) {
+	store := setupTestRedis(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.CreateTenant(ctx, &auth.Tenant{
+		ID:     "tenant-quota",
+		Name:   "Quota tenant",
+		Status: auth.TenantStatusActive,
+		Quota:  auth.DefaultQuota(),
+		Usage:  auth.TenantUsage{Subscriptions: 4, ResourcePools: 2},
+	}))
+
+	quota := auth.DefaultQuota()
+	quota.MaxSubscriptions = 7
+	quota.MaxResources = 50
+	updated, err := store.UpdateTenantQuota(ctx, "tenant-quota", quota)
+	require.NoError(t, err)
+	assert.Equal(t, quota, updated.Quota)
+	assert.Equal(t, 4, updated.Usage.Subscriptions)
+	assert.False(t, updated.UpdatedAt.IsZero())
+
+	retrieved, err := store.GetTenant(ctx, "tenant-quota")
+	require.NoError(t, err)
+	assert.Equal(t, quota, retrieved.Quota)
+	assert.Equal(t, 2, retrieved.Usage.ResourcePools)
+
+	_, err = store.UpdateTenantQuota(ctx, "missing", quota)
+	assert.ErrorIs(t, err, auth.ErrTenantNotFound)
+}
+
+// TestOwnership tests recording the owners of inventory objects.
+func TestOwnership(t *testing.T) {
+	store := setupTestRedis(t)
+	ctx := context.Background()
+
+	owner, err := store.GetOwner(ctx, "resources", "r1")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+
+	require.NoError(t, store.SetOwner(ctx, "resources", "r1", "tenant-a"))
+	require.NoError(t, store.SetOwner(ctx, "resources", "r2", "tenant-b"))
+	require.NoError(t, store.SetOwner(ctx, "resourcePools", "r1", "tenant-b"))
+
+	owner, err = store.GetOwner(ctx, "resources", "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", owner)
+
+	owners, err := store.GetOwners(ctx, "resources", []string{"r1", "r2", "r3"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"r1": "tenant-a", "r2": "tenant-b"}, owners)
+
+	require.NoError(t, store.DeleteOwner(ctx, "resources", "r1"))
+	owner, err = store.GetOwner(ctx, "resources", "r1")
+	require.NoError(t, err)
+	assert.Empty(t, owner)
+
+	owner, err = store.GetOwner(ctx, "resourcePools", "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-b", owner)
+
+	assert.ErrorIs(t, store.SetOwner(ctx, "resources", "r4", ""), auth.ErrInvalidTenantID)
+}
+
+func TestRedisStore_ReadFallback(t *testing.T) {
+	mr := miniredis.RunT(t)
+	store := auth.NewRedisStoreWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1}))
+	defer func() { _ = store.Close() }()
+	store.EnableReadFallback(readfallback.New("auth", time.Minute, 100))
+
+	ctx := context.Background()
+	require.NoError(t, store.CreateTenant(ctx, &auth.Tenant{ID: "tenant-a", Name: "A", Status: auth.TenantStatusActive}))
+	require.NoError(t, store.CreateUser(ctx, &auth.TenantUser{
+		ID: "user-1", TenantID: "tenant-a", Subject: "CN=alice,O=ACME", RoleID: "role-admin", IsActive: true,
+	}))
+
+	// The authentication lookups while Redis is up fill the cache.
+	_, err := store.GetUserBySubject(ctx, "CN=alice,O=ACME")
+	require.NoError(t, err)
+	_, err = store.GetTenant(ctx, "tenant-a")
+	require.NoError(t, err)
+	_, err = store.ListTenants(ctx)
+	require.NoError(t, err)
+
+	mr.Close()
+
+	readCtx := readfallback.WithTracker(ctx)
+	user, err := store.GetUserBySubject(readCtx, "CN=alice,O=ACME")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", user.ID)
+	tenant, err := store.GetTenant(readCtx, "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, "A", tenant.Name)
+	tenants, err := store.ListTenants(readCtx)
+	require.NoError(t, err)
+	assert.Len(t, tenants, 1)
+	_, stale := readfallback.StaleAge(readCtx)
+	assert.True(t, stale)
+
+	_, err = store.GetTenant(ctx, "tenant-b")
+	require.ErrorIs(t, err, auth.ErrStorageUnavailable)
+
+	tenant.Name = "Renamed"
+	require.ErrorIs(t, store.UpdateTenant(ctx, tenant), auth.ErrStorageUnavailable)
+}
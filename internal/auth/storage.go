@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"errors"
+
+	"github.com/piwi3910/netweave/internal/history"
 )
 
 // Common sentinel errors for auth storage operations.
@@ -68,7 +70,7 @@ type TenantStore interface {
 	ListTenants(ctx context.Context) ([]*Tenant, error)
 
 	// IncrementUsage atomically increments a usage counter for a tenant.
-	// usageType can be "subscriptions", "resourcePools", "deployments", or "users".
+	// usageType can be "subscriptions", "resourcePools", "resources", "deployments", or "users".
 	IncrementUsage(ctx context.Context, tenantID, usageType string) error
 
 	// DecrementUsage atomically decrements a usage counter for a tenant.
@@ -158,6 +160,28 @@ type AuditStore interface {
 
 	// ListEventsByUser retrieves audit events for a specific user.
 	ListEventsByUser(ctx context.Context, userID string, limit int) ([]*AuditEvent, error)
+
+	// QueryEvents returns a page of the audit events selected by q. Filters
+	// use the AuditFilter field names.
+	QueryEvents(ctx context.Context, q history.Query) (*AuditEventPage, error)
+}
+
+// OwnershipStore records the tenant owning each inventory object, for backends
+// that cannot keep the tenant on the object itself.
+// Implementations must be safe for concurrent use.
+type OwnershipStore interface {
+	// SetOwner records tenantID as the owner of the object of kind with id.
+	SetOwner(ctx context.Context, kind, id, tenantID string) error
+
+	// GetOwner returns the owning tenant of an object, or "" if none is recorded.
+	GetOwner(ctx context.Context, kind, id string) (string, error)
+
+	// GetOwners returns the owning tenants of several objects of kind, keyed by
+	// ID. Objects without a recorded owner are left out.
+	GetOwners(ctx context.Context, kind string, ids []string) (map[string]string, error)
+
+	// DeleteOwner removes the ownership record of an object.
+	DeleteOwner(ctx context.Context, kind, id string) error
 }
 
 // Store combines all auth storage interfaces.
@@ -0,0 +1,230 @@
+// Package certreload serves the gateway's TLS certificate from files that
+// are replaced while the gateway runs, such as a Secret mounted from
+// cert-manager or SVIDs written by the SPIFFE helper. The files are checked
+// periodically; a changed key pair (and client CA bundle) is loaded and used
+// for new handshakes, while established connections keep theirs. A pair that
+// fails to load is logged and the previous one stays in use.
+package certreload
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// DefaultInterval is how often the files are checked when no interval is set.
+const DefaultInterval = 30 * time.Second
+
+var (
+	// Reloads counts certificate reloads by result (success or error).
+	Reloads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Name:      "tls_certificate_reloads_total",
+			Help:      "Server TLS certificate reloads by result",
+		},
+		[]string{"result"},
+	)
+
+	// Expiry is the expiry of the served certificate as a Unix timestamp.
+	Expiry = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Name:      "tls_certificate_expiry_timestamp_seconds",
+			Help:      "Expiry of the served TLS certificate (Unix seconds)",
+		},
+	)
+)
+
+// Config configures a Reloader.
+type Config struct {
+	// CertFile and KeyFile hold the PEM key pair.
+	CertFile string
+	KeyFile  string
+
+	// CAFile optionally holds the PEM bundle client certificates are
+	// verified against.
+	CAFile string
+
+	// Interval is how often the files are checked (DefaultInterval when 0).
+	Interval time.Duration
+
+	// Logger logs reloads and reload failures.
+	Logger *zap.Logger
+}
+
+// material is one loaded generation of the files.
+type material struct {
+	certPEM, keyPEM, caPEM []byte
+	cert                   *tls.Certificate
+	clientCAs              *x509.CertPool
+}
+
+// Reloader holds the current key pair and client CA bundle.
+type Reloader struct {
+	cfg Config
+
+	mu      sync.RWMutex
+	current *material
+}
+
+// New loads the files of cfg and returns a Reloader serving them. It fails
+// when they cannot be loaded, so a misconfiguration stops the startup.
+func New(cfg Config) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("cert and key files are required")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+
+	r := &Reloader{cfg: cfg}
+	m, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.set(m)
+	return r, nil
+}
+
+// GetCertificate returns the current key pair. It is the
+// tls.Config.GetCertificate of the server.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.cert, nil
+}
+
+// ClientCAs returns the current client CA pool, nil without a CA file.
+func (r *Reloader) ClientCAs() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.clientCAs
+}
+
+// TLSConfig returns a copy of base that serves the current key pair and
+// verifies client certificates against the current CA bundle.
+func (r *Reloader) TLSConfig(base *tls.Config) *tls.Config {
+	cfg := base.Clone()
+	cfg.GetCertificate = r.GetCertificate
+	cfg.ClientCAs = r.ClientCAs()
+	if r.cfg.CAFile != "" {
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			perConn := cfg.Clone()
+			perConn.GetConfigForClient = nil
+			perConn.ClientCAs = r.ClientCAs()
+			return perConn, nil
+		}
+	}
+	return cfg
+}
+
+// Run checks the files every interval until ctx is canceled.
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Reload(); err != nil {
+				r.cfg.Logger.Error("failed to reload TLS certificate; keeping the current one",
+					zap.String("cert_file", r.cfg.CertFile),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// Reload loads the files if their contents changed and reports whether it
+// did. On error the current key pair stays in use.
+func (r *Reloader) Reload() (bool, error) {
+	certPEM, keyPEM, caPEM, err := r.read()
+	if err != nil {
+		Reloads.WithLabelValues("error").Inc()
+		return false, err
+	}
+	r.mu.RLock()
+	cur := r.current
+	r.mu.RUnlock()
+	if bytes.Equal(certPEM, cur.certPEM) && bytes.Equal(keyPEM, cur.keyPEM) && bytes.Equal(caPEM, cur.caPEM) {
+		return false, nil
+	}
+
+	m, err := parse(certPEM, keyPEM, caPEM)
+	if err != nil {
+		// A key pair is often written as two files; a half-written pair
+		// fails here and loads on the next check.
+		Reloads.WithLabelValues("error").Inc()
+		return false, err
+	}
+	r.set(m)
+	Reloads.WithLabelValues("success").Inc()
+	r.cfg.Logger.Info("TLS certificate reloaded",
+		zap.String("subject", m.cert.Leaf.Subject.String()),
+		zap.Time("not_after", m.cert.Leaf.NotAfter))
+	return true, nil
+}
+
+// load reads and parses the files.
+func (r *Reloader) load() (*material, error) {
+	certPEM, keyPEM, caPEM, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	return parse(certPEM, keyPEM, caPEM)
+}
+
+// read returns the contents of the files.
+func (r *Reloader) read() (certPEM, keyPEM, caPEM []byte, err error) {
+	if certPEM, err = os.ReadFile(r.cfg.CertFile); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+	if keyPEM, err = os.ReadFile(r.cfg.KeyFile); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	if r.cfg.CAFile != "" {
+		if caPEM, err = os.ReadFile(r.cfg.CAFile); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+	}
+	return certPEM, keyPEM, caPEM, nil
+}
+
+// parse builds the key pair and client CA pool of the file contents.
+func parse(certPEM, keyPEM, caPEM []byte) (*material, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key pair: %w", err)
+	}
+	m := &material{certPEM: certPEM, keyPEM: keyPEM, caPEM: caPEM, cert: &cert}
+	if caPEM != nil {
+		m.clientCAs = x509.NewCertPool()
+		if !m.clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("client CA bundle holds no certificate")
+		}
+	}
+	return m, nil
+}
+
+// set makes m current.
+func (r *Reloader) set(m *material) {
+	r.mu.Lock()
+	r.current = m
+	r.mu.Unlock()
+	Expiry.Set(float64(m.cert.Leaf.NotAfter.Unix()))
+}
@@ -0,0 +1,138 @@
+package certreload_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/certreload"
+)
+
+// writePair writes a self-signed key pair for commonName to dir and returns
+// the certificate.
+func writePair(t *testing.T, dir, commonName string, uris ...*url.URL) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         uris,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), certPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), certPEM, 0o600))
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func servedCommonName(t *testing.T, r *certreload.Reloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	return cert.Leaf.Subject.CommonName
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	writePair(t, dir, "first")
+
+	r, err := certreload.New(certreload.Config{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "first", servedCommonName(t, r))
+	require.NotNil(t, r.ClientCAs())
+
+	reloaded, err := r.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged files are not reloaded")
+
+	writePair(t, dir, "second")
+	reloaded, err = r.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "second", servedCommonName(t, r))
+
+	// A half-written rotation keeps the current pair in use.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"), []byte("garbage"), 0o600))
+	reloaded, err = r.Reload()
+	require.Error(t, err)
+	assert.False(t, reloaded)
+	assert.Equal(t, "second", servedCommonName(t, r))
+}
+
+func TestNew_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	_, err := certreload.New(certreload.Config{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+	})
+	require.Error(t, err)
+
+	_, err = certreload.New(certreload.Config{})
+	require.Error(t, err)
+}
+
+func TestSPIFFEVerifier(t *testing.T) {
+	dir := t.TempDir()
+	smo := writePair(t, dir, "smo", &url.URL{Scheme: "spiffe", Host: "example.org", Path: "/ns/smo/sa/smo"})
+	other := writePair(t, dir, "other", &url.URL{Scheme: "spiffe", Host: "other.org", Path: "/ns/smo/sa/smo"})
+	plain := writePair(t, dir, "plain")
+
+	tests := []struct {
+		name       string
+		allowedIDs []string
+		cert       *x509.Certificate
+		wantErr    bool
+	}{
+		{name: "trust domain member", cert: smo},
+		{name: "allowed ID", allowedIDs: []string{"spiffe://example.org/ns/smo/sa/smo"}, cert: smo},
+		{name: "ID not allowed", allowedIDs: []string{"spiffe://example.org/ns/a/sa/a"}, cert: smo, wantErr: true},
+		{name: "foreign trust domain", cert: other, wantErr: true},
+		{name: "not an SVID", cert: plain, wantErr: true},
+		{name: "no client certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verify := certreload.SPIFFEVerifier("example.org", tt.allowedIDs)
+			var chains [][]*x509.Certificate
+			if tt.cert != nil {
+				chains = [][]*x509.Certificate{{tt.cert}}
+			}
+			err := verify(nil, chains)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package certreload
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SPIFFEIDOf returns the SPIFFE ID of an X.509 SVID: its single URI SAN
+// with the spiffe scheme.
+func SPIFFEIDOf(cert *x509.Certificate) (string, error) {
+	if len(cert.URIs) != 1 || cert.URIs[0].Scheme != "spiffe" {
+		return "", errors.New("certificate is not an X.509 SVID: it must have exactly one spiffe:// URI SAN")
+	}
+	return cert.URIs[0].String(), nil
+}
+
+// SPIFFEVerifier returns a tls.Config.VerifyPeerCertificate function that
+// accepts only client X.509 SVIDs of trustDomain and, when allowedIDs is not
+// empty, only the listed SPIFFE IDs. The chain itself is verified by the TLS
+// stack against the trust bundle of the client CA file.
+func SPIFFEVerifier(
+	trustDomain string, allowedIDs []string,
+) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	prefix := "spiffe://" + strings.TrimSuffix(trustDomain, "/") + "/"
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+			// No client certificate was presented; the client auth mode
+			// decides whether that is allowed.
+			return nil
+		}
+		id, err := SPIFFEIDOf(verifiedChains[0][0])
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(id, prefix) {
+			return fmt.Errorf("SPIFFE ID %s is not in trust domain %s", id, trustDomain)
+		}
+		if len(allowedIDs) > 0 && !slices.Contains(allowedIDs, id) {
+			return fmt.Errorf("SPIFFE ID %s is not allowed", id)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,230 @@
+// Package circuitbreaker stops the gateway from calling an adapter backend
+// that keeps failing. While a backend such as the Kubernetes API server or a
+// Helm repository is down, every request would otherwise wait for its own
+// timeout; with the breaker open they fail immediately with ErrOpen. After
+// OpenTimeout a few probe requests are let through, and the breaker closes
+// again once they succeed.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sony/gobreaker"
+	"go.uber.org/zap"
+)
+
+// ErrOpen is returned instead of calling the backend while its breaker is
+// open, or half-open with all probe requests in flight.
+var ErrOpen = errors.New("backend circuit breaker is open")
+
+// Breaker states.
+const (
+	StateClosed   = "closed"
+	StateHalfOpen = "half-open"
+	StateOpen     = "open"
+)
+
+var (
+	// State tracks the state of each adapter's breaker.
+	State = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "adapter",
+			Name:      "circuit_breaker_state",
+			Help:      "Adapter circuit breaker state (0=closed, 1=half-open, 2=open)",
+		},
+		[]string{"adapter"},
+	)
+
+	// Rejected counts the calls failed with ErrOpen without reaching the backend.
+	Rejected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "adapter",
+			Name:      "circuit_breaker_rejected_total",
+			Help:      "Total number of adapter calls rejected by an open circuit breaker",
+		},
+		[]string{"adapter"},
+	)
+)
+
+// Settings configures the breakers of a Set.
+type Settings struct {
+	// FailureThreshold is the number of consecutive failed calls that opens
+	// the breaker.
+	FailureThreshold uint32
+
+	// OpenTimeout is how long the breaker stays open before letting probe
+	// requests through.
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests is the number of probe requests let through while
+	// half-open. The breaker closes when all of them succeed and opens again
+	// on the first failure.
+	HalfOpenRequests uint32
+}
+
+// Status reports the state of a breaker.
+type Status struct {
+	State string `json:"state"`
+
+	// ConsecutiveFailures counts the failed calls since the last successful
+	// one or the last state change.
+	ConsecutiveFailures uint32 `json:"consecutiveFailures"`
+}
+
+// Breaker guards the calls to one adapter backend.
+type Breaker struct {
+	name      string
+	cb        *gobreaker.CircuitBreaker
+	isFailure func(error) bool
+}
+
+// Name returns the name of the adapter the breaker guards.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Status returns the current state of the breaker.
+func (b *Breaker) Status() Status {
+	return Status{
+		State:               stateName(b.cb.State()),
+		ConsecutiveFailures: b.cb.Counts().ConsecutiveFailures,
+	}
+}
+
+// Do calls fn unless the breaker is open, and records its outcome.
+func (b *Breaker) Do(fn func() error) error {
+	_, err := Call(b, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// Call calls fn unless the breaker is open, and records its outcome. The
+// error of fn is returned as is; calls rejected by the breaker return ErrOpen.
+func Call[T any](b *Breaker, fn func() (T, error)) (T, error) {
+	var result T
+	var callErr error
+	_, err := b.cb.Execute(func() (interface{}, error) {
+		result, callErr = fn()
+		return nil, callErr
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		Rejected.WithLabelValues(b.name).Inc()
+		var zero T
+		return zero, fmt.Errorf("%w: %s backend unavailable, retry later", ErrOpen, b.name)
+	}
+	return result, callErr
+}
+
+// Set holds the breakers of the gateway's adapters, keyed by adapter name.
+type Set struct {
+	settings Settings
+	logger   *zap.Logger
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewSet creates an empty set whose breakers use settings.
+func NewSet(settings Settings, logger *zap.Logger) *Set {
+	return &Set{
+		settings: settings,
+		logger:   logger,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Breaker returns the breaker of the named adapter, creating it if needed.
+// isFailure reports whether an error returned by the backend counts as a
+// failure; errors that are the caller's fault, such as a missing object or
+// a cancelled request, should not open the breaker. A nil isFailure counts
+// every error other than a cancelled request.
+func (s *Set) Breaker(name string, isFailure func(error) bool) *Breaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.breakers[name]; ok {
+		return b
+	}
+
+	b := &Breaker{name: name, isFailure: isFailure}
+	b.cb = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        name,
+		MaxRequests: s.settings.HalfOpenRequests,
+		Timeout:     s.settings.OpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= s.settings.FailureThreshold
+		},
+		IsSuccessful: func(err error) bool {
+			if err == nil || errors.Is(err, context.Canceled) {
+				return true
+			}
+			return b.isFailure != nil && !b.isFailure(err)
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			s.logger.Warn("adapter circuit breaker state changed",
+				zap.String("adapter", name),
+				zap.String("from", stateName(from)),
+				zap.String("to", stateName(to)),
+			)
+			State.WithLabelValues(name).Set(stateValue(to))
+		},
+	})
+	State.WithLabelValues(name).Set(stateValue(gobreaker.StateClosed))
+	s.breakers[name] = b
+	return b
+}
+
+// Remove drops the breaker of an adapter that is no longer used.
+func (s *Set) Remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.breakers, name)
+	State.DeleteLabelValues(name)
+	Rejected.DeleteLabelValues(name)
+}
+
+// Statuses returns the state of every breaker, keyed by adapter name.
+func (s *Set) Statuses() map[string]Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make(map[string]Status, len(s.breakers))
+	for name, b := range s.breakers {
+		statuses[name] = b.Status()
+	}
+	return statuses
+}
+
+// stateName returns the name of a gobreaker state.
+func stateName(state gobreaker.State) string {
+	switch state {
+	case gobreaker.StateHalfOpen:
+		return StateHalfOpen
+	case gobreaker.StateOpen:
+		return StateOpen
+	default:
+		return StateClosed
+	}
+}
+
+// stateValue returns the gauge value of a gobreaker state.
+func stateValue(state gobreaker.State) float64 {
+	switch state {
+	case gobreaker.StateHalfOpen:
+		return 1
+	case gobreaker.StateOpen:
+		return 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,118 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+)
+
+var (
+	errBackendDown = errors.New("connection refused")
+	errNotFound    = errors.New("not found")
+)
+
+func newSet() *circuitbreaker.Set {
+	return circuitbreaker.NewSet(circuitbreaker.Settings{
+		FailureThreshold: 3,
+		OpenTimeout:      50 * time.Millisecond,
+		HalfOpenRequests: 1,
+	}, zap.NewNop())
+}
+
+func isFailure(err error) bool {
+	return !errors.Is(err, errNotFound)
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	set := newSet()
+	b := set.Breaker("test-open", isFailure)
+
+	calls := 0
+	fail := func() error {
+		calls++
+		return errBackendDown
+	}
+	for range 3 {
+		assert.ErrorIs(t, b.Do(fail), errBackendDown)
+	}
+	assert.Equal(t, circuitbreaker.StateOpen, b.Status().State)
+	assert.InDelta(t, 2, testutil.ToFloat64(circuitbreaker.State.WithLabelValues("test-open")), 0)
+
+	// Open: the backend is not called.
+	err := b.Do(fail)
+	require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Contains(t, err.Error(), "test-open")
+	assert.Equal(t, 3, calls)
+	assert.InDelta(t, 1, testutil.ToFloat64(circuitbreaker.Rejected.WithLabelValues("test-open")), 0)
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	set := newSet()
+	b := set.Breaker("test-half-open", isFailure)
+
+	for range 3 {
+		_ = b.Do(func() error { return errBackendDown })
+	}
+	require.Equal(t, circuitbreaker.StateOpen, b.Status().State)
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, circuitbreaker.StateHalfOpen, b.Status().State)
+
+	// A failed probe opens the breaker again.
+	require.ErrorIs(t, b.Do(func() error { return errBackendDown }), errBackendDown)
+	assert.Equal(t, circuitbreaker.StateOpen, b.Status().State)
+
+	// A successful probe closes it.
+	time.Sleep(60 * time.Millisecond)
+	value, err := circuitbreaker.Call(b, func() (string, error) { return "ok", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "ok", value)
+	assert.Equal(t, circuitbreaker.Status{State: circuitbreaker.StateClosed}, b.Status())
+	assert.InDelta(t, 0, testutil.ToFloat64(circuitbreaker.State.WithLabelValues("test-half-open")), 0)
+}
+
+func TestBreaker_IgnoresCallerErrors(t *testing.T) {
+	set := newSet()
+	b := set.Breaker("test-caller-errors", isFailure)
+
+	for range 5 {
+		require.ErrorIs(t, b.Do(func() error { return errNotFound }), errNotFound)
+		require.ErrorIs(t, b.Do(func() error { return context.Canceled }), context.Canceled)
+	}
+	assert.Equal(t, circuitbreaker.StateClosed, b.Status().State)
+
+	// Successes and ignored errors reset the consecutive failures.
+	_ = b.Do(func() error { return errBackendDown })
+	_ = b.Do(func() error { return errBackendDown })
+	_ = b.Do(func() error { return errNotFound })
+	_ = b.Do(func() error { return errBackendDown })
+	assert.Equal(t, circuitbreaker.Status{State: circuitbreaker.StateClosed, ConsecutiveFailures: 1}, b.Status())
+}
+
+func TestSet(t *testing.T) {
+	set := newSet()
+
+	first := set.Breaker("ims-adapter/mock", nil)
+	assert.Same(t, first, set.Breaker("ims-adapter/mock", nil))
+	set.Breaker("dms-adapter/helm", nil)
+
+	for range 3 {
+		_ = first.Do(func() error { return errNotFound })
+	}
+	assert.Equal(t, map[string]circuitbreaker.Status{
+		"ims-adapter/mock": {State: circuitbreaker.StateOpen},
+		"dms-adapter/helm": {State: circuitbreaker.StateClosed},
+	}, set.Statuses(), "a nil isFailure counts every error")
+
+	set.Remove("ims-adapter/mock")
+	assert.NotContains(t, set.Statuses(), "ims-adapter/mock")
+	assert.NotSame(t, first, set.Breaker("ims-adapter/mock", nil))
+}
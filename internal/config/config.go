@@ -4,10 +4,17 @@
 package config
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,6 +30,10 @@ const (
 	tlsClientAuthRequireAndVerify = "require-and-verify"
 )
 
+// maxWebhookCertValidity bounds notifications.ca.cert_validity; it matches
+// the limit of the webhook CA.
+const maxWebhookCertValidity = 365 * 24 * time.Hour
+
 // Environment names for configuration.
 const (
 	EnvDevelopment = "dev"
@@ -61,6 +72,51 @@ type Config struct {
 	Validation    ValidationConfig    `mapstructure:"validation"`
 	MultiTenancy  MultiTenancyConfig  `mapstructure:"multi_tenancy"`
 
+	// Hooks are lifecycle hooks invoked around create and delete operations.
+	Hooks []HookConfig `mapstructure:"hooks"`
+
+	// Rollout is the default policy for staged rollouts of runtime settings.
+	Rollout RolloutConfig `mapstructure:"rollout"`
+
+	// DMS configures the O2-DMS subsystem.
+	DMS DMSConfig `mapstructure:"dms"`
+
+	// Pricing configures cost estimation for NF deployments and resource pools.
+	Pricing PricingConfig `mapstructure:"pricing"`
+
+	// Subscriptions configures how subscription creation is handled.
+	Subscriptions SubscriptionsConfig `mapstructure:"subscriptions"`
+
+	// Notifications configures webhook delivery of subscription notifications.
+	Notifications NotificationsConfig `mapstructure:"notifications"`
+
+	// StartupChecks configures the backend probes run before the gateway
+	// starts serving.
+	StartupChecks StartupChecksConfig `mapstructure:"startup_checks"`
+
+	// Cache configures caching of adapter list results.
+	Cache CacheConfig `mapstructure:"cache"`
+
+	// CircuitBreaker configures the circuit breakers in front of the adapter
+	// backends.
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+
+	// Export configures the scheduled inventory export to object storage.
+	Export ExportConfig `mapstructure:"export"`
+
+	// Compliance configures the scheduled compliance self-check.
+	Compliance ComplianceConfig `mapstructure:"compliance"`
+
+	// DNS configures the resolver used by outbound HTTP clients.
+	DNS DNSConfig `mapstructure:"dns"`
+
+	// Provisioning configures the O2-IMS infrastructure provisioning API.
+	Provisioning ProvisioningConfig `mapstructure:"provisioning"`
+
+	// Reload configures reloading the configuration file while the gateway
+	// runs.
+	Reload ReloadConfig `mapstructure:"reload"`
+
 	// Environment stores the detected environment (dev/staging/prod)
 	// This field is set automatically during Load() and used for validation
 	Environment string `mapstructure:"-"`
@@ -71,7 +127,9 @@ type MultiTenancyConfig struct {
 	// Enabled enables multi-tenancy and RBAC enforcement.
 	Enabled bool `mapstructure:"enabled"`
 
-	// RequireMTLS requires mTLS client certificates for authentication.
+	// RequireMTLS declares that every client presents an mTLS client
+	// certificate. Requests without one are rejected by non-anonymous auth
+	// policies either way; with RequireMTLS they are also logged as warnings.
 	RequireMTLS bool `mapstructure:"require_mtls"`
 
 	// InitializeDefaultRoles creates default system roles on startup.
@@ -84,1094 +142,3360 @@ type MultiTenancyConfig struct {
 	AuditLogRetentionDays int `mapstructure:"audit_log_retention_days"`
 
 	// SkipAuthPaths is a list of paths that skip authentication.
+	//
+	// Deprecated: use AuthPolicies with level "anonymous". Skip paths are still
+	// honored and evaluated before AuthPolicies.
 	SkipAuthPaths []string `mapstructure:"skip_auth_paths"`
-}
 
-// DefaultQuotaConfig contains default quota values for new tenants.
-type DefaultQuotaConfig struct {
-	MaxSubscriptions     int `mapstructure:"max_subscriptions"`
-	MaxResourcePools     int `mapstructure:"max_resource_pools"`
-	MaxDeployments       int `mapstructure:"max_deployments"`
-	MaxUsers             int `mapstructure:"max_users"`
-	MaxRequestsPerMinute int `mapstructure:"max_requests_per_minute"`
+	// AuthPolicies maps route paths and methods to required authentication
+	// levels. Rules are evaluated in order and the first match applies;
+	// requests matching no rule require authentication.
+	AuthPolicies []AuthPolicyConfig `mapstructure:"auth_policies"`
+
+	// IdentityMappings map mTLS client certificate attributes to a tenant
+	// and role. Rules are evaluated in order and the first match applies;
+	// certificates matching no rule are authenticated as the stored user
+	// with their subject.
+	IdentityMappings []IdentityMappingConfig `mapstructure:"identity_mappings"`
 }
 
-// ServerConfig contains HTTP server configuration.
-type ServerConfig struct {
-	// Host is the network interface to bind to (e.g., "0.0.0.0", "localhost")
-	Host string `mapstructure:"host"`
+// Authentication levels for AuthPolicyConfig.Level.
+const (
+	AuthLevelAnonymous     = "anonymous"
+	AuthLevelAuthenticated = "authenticated"
+	AuthLevelRole          = "role"
+)
 
-	// Port is the HTTP server port (default: 8080)
-	Port int `mapstructure:"port"`
+// AuthPolicyConfig is one rule of the authentication policy matrix.
+type AuthPolicyConfig struct {
+	// Paths are exact paths or glob patterns (e.g. "/o2ims-infrastructureInventory/v1/*").
+	Paths []string `mapstructure:"paths"`
 
-	// ReadTimeout is the maximum duration for reading the entire request
-	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+	// Methods restricts the rule to these HTTP methods. Empty matches all methods.
+	Methods []string `mapstructure:"methods"`
 
-	// WriteTimeout is the maximum duration before timing out writes of the response
-	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	// Level is the required authentication level: anonymous, authenticated, or role.
+	Level string `mapstructure:"level"`
 
-	// IdleTimeout is the maximum duration to wait for the next request when keep-alives are enabled
-	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+	// Roles lists the accepted role names when Level is "role".
+	Roles []string `mapstructure:"roles"`
+}
 
-	// ShutdownTimeout is the maximum duration to wait for graceful shutdown
-	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+// Certificate attributes for IdentityMappingConfig.Attribute.
+const (
+	CertAttributeCN       = "cn"
+	CertAttributeOU       = "ou"
+	CertAttributeSANDNS   = "san_dns"
+	CertAttributeSANURI   = "san_uri"
+	CertAttributeSANEmail = "san_email"
+)
 
-	// MaxHeaderBytes is the maximum size of request headers
-	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
+// IdentityMappingConfig maps client certificates whose attribute matches a
+// regular expression to a tenant and role.
+type IdentityMappingConfig struct {
+	// Name identifies the rule in audit records. Names must be unique.
+	Name string `mapstructure:"name"`
 
-	// GinMode sets the Gin framework mode ("debug", "release", "test")
-	GinMode string `mapstructure:"gin_mode"`
+	// Attribute is the certificate attribute to match: cn, ou, san_dns,
+	// san_uri, or san_email.
+	Attribute string `mapstructure:"attribute"`
+
+	// Pattern is the regular expression the attribute value must match.
+	Pattern string `mapstructure:"pattern"`
+
+	// Tenant is the tenant ID, which may reference capture groups of Pattern
+	// as $1 or ${name}.
+	Tenant string `mapstructure:"tenant"`
+
+	// Role is the name of the role granted to matching certificates.
+	Role string `mapstructure:"role"`
 }
 
-// RedisConfig contains Redis client and cluster configuration.
-type RedisConfig struct {
-	// Mode specifies Redis deployment mode: "standalone", "sentinel", "cluster"
-	Mode string `mapstructure:"mode"`
+// Lifecycle hook types for HookConfig.Type.
+const (
+	HookTypeWebhook = "webhook"
+	HookTypePlugin  = "plugin"
+)
 
-	// Addresses contains Redis server addresses
-	// For standalone: ["localhost:6379"]
-	// For sentinel: ["sentinel1:26379", "sentinel2:26379"]
-	// For cluster: ["node1:6379", "node2:6379", ...]
-	Addresses []string `mapstructure:"addresses"`
+// HookConfig configures a lifecycle hook.
+type HookConfig struct {
+	// Name identifies the hook in logs and metrics. Names must be unique.
+	Name string `mapstructure:"name"`
 
-	// MasterName is required for Sentinel mode (e.g., "mymaster")
-	MasterName string `mapstructure:"master_name"`
+	// Type is "webhook" (HTTP POST to URL) or "plugin" (in-process Go hook
+	// registered under Plugin).
+	Type string `mapstructure:"type"`
 
-	// Password for Redis authentication (optional, DEPRECATED: use PasswordEnvVar or PasswordFile)
-	// WARNING: Storing passwords in config files is insecure. Use environment variables or secret files instead.
-	Password string `mapstructure:"password"`
+	// URL is the webhook endpoint for webhook hooks.
+	URL string `mapstructure:"url"`
 
-	// PasswordEnvVar specifies the environment variable name containing the Redis password
-	// Example: "REDIS_PASSWORD"
-	// This takes priority over PasswordFile if both are set
-	PasswordEnvVar string `mapstructure:"password_env_var"`
+	// Plugin is the registered plugin name for plugin hooks.
+	Plugin string `mapstructure:"plugin"`
 
-	// PasswordFile specifies the path to a file containing the Redis password
-	// Example: "/run/secrets/redis-password" (Kubernetes Secret mount)
-	// The file should contain only the password, whitespace will be trimmed
-	PasswordFile string `mapstructure:"password_file"`
+	// Phases restricts the hook to "pre" and/or "post". Empty matches both.
+	Phases []string `mapstructure:"phases"`
 
-	// SentinelPassword for Sentinel authentication (optional, DEPRECATED: use SentinelPasswordEnvVar
-	// or SentinelPasswordFile)
-	// WARNING: Storing passwords in config files is insecure. Use environment variables or secret files instead.
-	// Best practice: Use different passwords for Sentinel and Redis.
-	SentinelPassword string `mapstructure:"sentinel_password"`
+	// Operations restricts the hook to "create" and/or "delete". Empty matches both.
+	Operations []string `mapstructure:"operations"`
 
-	// SentinelPasswordEnvVar specifies the environment variable name containing the Sentinel password
-	// Example: "SENTINEL_PASSWORD"
-	// This takes priority over SentinelPasswordFile if both are set
-	SentinelPasswordEnvVar string `mapstructure:"sentinel_password_env_var"`
+	// ObjectTypes restricts the hook to object types (resourcePool, resource,
+	// subscription). Empty matches all types.
+	ObjectTypes []string `mapstructure:"object_types"`
 
-	// SentinelPasswordFile specifies the path to a file containing the Sentinel password
-	// Example: "/run/secrets/sentinel-password" (Kubernetes Secret mount)
-	// The file should contain only the password, whitespace will be trimmed
-	SentinelPasswordFile string `mapstructure:"sentinel_password_file"`
+	// Timeout bounds each invocation. Zero uses the default of 5s.
+	Timeout time.Duration `mapstructure:"timeout"`
 
-	// DB is the Redis database number (0-15, only for standalone/sentinel)
-	DB int `mapstructure:"db"`
+	// FailurePolicy is "ignore" (default) or "abort". Aborting pre hooks reject
+	// the operation when they fail.
+	FailurePolicy string `mapstructure:"failure_policy"`
+}
 
-	// PoolSize is the maximum number of socket connections
-	PoolSize int `mapstructure:"pool_size"`
+// RolloutConfig is the default policy for staged rollouts of runtime-tunable
+// settings (request timeout, rate limits) started through the admin API.
+// Zero values use the built-in defaults.
+type RolloutConfig struct {
+	// Percent of requests served with the new settings during a rollout (1-99).
+	Percent int `mapstructure:"percent"`
 
-	// MinIdleConns is the minimum number of idle connections
-	MinIdleConns int `mapstructure:"min_idle_conns"`
+	// BakeTime is how long new settings must run before they are promoted.
+	BakeTime time.Duration `mapstructure:"bake_time"`
 
-	// MaxRetries is the maximum number of retries before giving up
-	MaxRetries int `mapstructure:"max_retries"`
+	// MinRequests is the number of requests served with the new settings
+	// before they are evaluated for rollback or promotion.
+	MinRequests int64 `mapstructure:"min_requests"`
 
-	// DialTimeout is the timeout for establishing new connections
-	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+	// MaxErrorRateIncrease is the largest allowed increase of the 5xx error
+	// rate (0-1) compared to the current settings before automatic rollback.
+	MaxErrorRateIncrease float64 `mapstructure:"max_error_rate_increase"`
+}
 
-	// ReadTimeout is the timeout for socket reads
-	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+// DMS subscription storage backends for DMSStorageConfig.Backend.
+const (
+	DMSStorageMemory     = "memory"
+	DMSStorageRedis      = "redis"
+	DMSStorageKubernetes = "kubernetes"
+)
 
-	// WriteTimeout is the timeout for socket writes
-	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+// DMSConfig configures the O2-DMS subsystem.
+type DMSConfig struct {
+	// Storage selects where DMS subscriptions are persisted.
+	Storage DMSStorageConfig `mapstructure:"storage"`
 
-	// PoolTimeout is the timeout when all connections are busy
-	PoolTimeout time.Duration `mapstructure:"pool_timeout"`
+	// NamespacePolicies restrict the namespaces each DMS adapter may act on
+	// through the API, keyed by adapter name (e.g. "helm"). The key "*"
+	// applies to adapters without a policy of their own.
+	NamespacePolicies map[string]DMSNamespacePolicy `mapstructure:"namespace_policies"`
 
-	// IdleTimeout is the amount of time after which client closes idle connections
-	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+	// Notifications configures delivery of DMS subscription notifications.
+	Notifications DMSNotificationsConfig `mapstructure:"notifications"`
 
-	// EnableTLS enables TLS for Redis connections
-	EnableTLS bool `mapstructure:"enable_tls"`
+	// Jobs configures asynchronous execution of NF deployment operations.
+	Jobs DMSJobsConfig `mapstructure:"jobs"`
 
-	// TLSInsecureSkipVerify skips TLS certificate verification (use only for testing)
-	TLSInsecureSkipVerify bool `mapstructure:"tls_insecure_skip_verify"`
+	// Helm configures the chart repository of the Helm DMS adapter.
+	Helm DMSHelmConfig `mapstructure:"helm"`
+
+	// Adapters registers further DMS adapters at startup, next to the one
+	// selected by DMS_ADAPTER_TYPE, e.g. Helm adapters for other chart
+	// repositories or Flux adapters for other clusters. Adapters can also be
+	// registered at runtime with POST /o2dms/v1/adapters.
+	Adapters []DMSAdapterConfig `mapstructure:"adapters"`
+
+	// Registrations configures how adapters registered at runtime are
+	// persisted and shared by replicas with the redis storage backend.
+	Registrations DMSRegistrationsConfig `mapstructure:"registrations"`
 }
 
-// GetPassword retrieves the Redis password from the configured source.
-// Priority order:
-//  1. Environment variable (if PasswordEnvVar is set)
-//  2. File (if PasswordFile is set)
-//  3. Direct password (DEPRECATED, for backward compatibility)
-//
-// Returns empty string if no password is configured (Redis without authentication).
-func (c *RedisConfig) GetPassword() (string, error) {
-	// Priority 1: Environment variable
-	if c.PasswordEnvVar != "" {
-		if pwd := os.Getenv(c.PasswordEnvVar); pwd != "" {
-			return pwd, nil
-		}
-		// Env var specified but not set - this is an error
-		return "", fmt.Errorf("environment variable %s is not set", c.PasswordEnvVar)
-	}
+// DMSRegistrationsConfig configures the persistence of the DMS adapters
+// registered through the API, used with dms.storage.backend redis.
+type DMSRegistrationsConfig struct {
+	// SyncInterval is how often each replica applies the registrations made
+	// and removed on other replicas. 0 only restores them at startup.
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+
+	// EncryptionKeyEnvVar names the environment variable holding the key that
+	// encrypts adapter passwords in Redis. It takes priority over
+	// EncryptionKeyFile. Without a key, adapters with a password can't be
+	// registered through the API.
+	EncryptionKeyEnvVar string `mapstructure:"encryption_key_env_var"`
+
+	// EncryptionKeyFile is the path of a file holding the encryption key,
+	// e.g. a Kubernetes Secret mount.
+	EncryptionKeyFile string `mapstructure:"encryption_key_file"`
+}
 
-	// Priority 2: Password file
-	if c.PasswordFile != "" {
-		data, err := os.ReadFile(c.PasswordFile)
-		if err != nil {
-			return "", fmt.Errorf("failed to read password file %s: %w", c.PasswordFile, err)
-		}
-		return strings.TrimSpace(string(data)), nil
-	}
+// GetEncryptionKey retrieves the key encrypting adapter passwords from the
+// configured environment variable or file. Returns an empty string if no key
+// is configured.
+func (c *DMSRegistrationsConfig) GetEncryptionKey() (string, error) {
+	return readSecret(c.EncryptionKeyEnvVar, c.EncryptionKeyFile)
+}
 
-	// Priority 3: Direct password (DEPRECATED)
-	return c.Password, nil
+// DMSAdapterConfig configures an additional DMS adapter.
+type DMSAdapterConfig struct {
+	// Name registers the adapter; requests select it with ?adapter=<name>.
+	Name string `mapstructure:"name"`
+
+	// Type is the adapter type: helm, argocd, flux, kustomize, manifests,
+	// crossplane, onaplcm or osmlcm.
+	Type string `mapstructure:"type"`
+
+	// Default makes the adapter the default DMS adapter.
+	Default bool `mapstructure:"default"`
+
+	// Kubeconfig is the path of the kubeconfig of the adapter's cluster.
+	// Empty uses the gateway's in-cluster configuration.
+	Kubeconfig string `mapstructure:"kubeconfig"`
+
+	// Namespace is the adapter's default namespace.
+	Namespace string `mapstructure:"namespace"`
+
+	// RepositoryURL is the chart repository of a Helm adapter.
+	RepositoryURL string `mapstructure:"repository_url"`
+
+	// APIURL is the endpoint of an ONAP SO (onaplcm) or OSM NBI (osmlcm).
+	APIURL string `mapstructure:"api_url"`
+
+	// Username authenticates to the chart repository or the ONAP/OSM API.
+	Username string `mapstructure:"username"`
+
+	// PasswordEnvVar names the environment variable holding the password.
+	// It takes priority over PasswordFile.
+	PasswordEnvVar string `mapstructure:"password_env_var"`
+
+	// PasswordFile is the path of a file holding the password.
+	PasswordFile string `mapstructure:"password_file"`
 }
 
-// IsUsingDeprecatedPassword returns true if the deprecated direct Password field
-// is being used instead of the recommended PasswordEnvVar or PasswordFile.
-// This method avoids direct access to sensitive password data for deprecation checks.
-func (c *RedisConfig) IsUsingDeprecatedPassword() bool {
-	return c.Password != "" && c.PasswordEnvVar == "" && c.PasswordFile == ""
+// GetPassword retrieves the adapter's password from the configured
+// environment variable or file. Returns an empty string if no password is
+// configured.
+func (c *DMSAdapterConfig) GetPassword() (string, error) {
+	return readSecret(c.PasswordEnvVar, c.PasswordFile)
 }
 
-// IsUsingDeprecatedSentinelPassword returns true if the deprecated direct SentinelPassword
-// field is being used instead of the recommended SentinelPasswordEnvVar or SentinelPasswordFile.
-// This method avoids direct access to sensitive password data for deprecation checks.
-func (c *RedisConfig) IsUsingDeprecatedSentinelPassword() bool {
-	return c.SentinelPassword != "" && c.SentinelPasswordEnvVar == "" && c.SentinelPasswordFile == ""
+// DMSHelmConfig configures the chart repository the Helm DMS adapter lists
+// packages from.
+type DMSHelmConfig struct {
+	// RepositoryURL is the chart repository URL (e.g. ChartMuseum, Harbor).
+	// Empty disables package listing from a repository.
+	RepositoryURL string `mapstructure:"repository_url"`
+
+	// RepositoryUsername is the username for repository authentication.
+	RepositoryUsername string `mapstructure:"repository_username"`
+
+	// RepositoryPasswordEnvVar names the environment variable holding the
+	// repository password. It takes priority over RepositoryPasswordFile.
+	RepositoryPasswordEnvVar string `mapstructure:"repository_password_env_var"`
+
+	// RepositoryPasswordFile is the path of a file holding the repository
+	// password, e.g. a Kubernetes Secret mount.
+	RepositoryPasswordFile string `mapstructure:"repository_password_file"`
 }
 
-// GetSentinelPassword retrieves the Sentinel password from the configured source.
-// Priority order:
-//  1. Environment variable (if SentinelPasswordEnvVar is set)
-//  2. File (if SentinelPasswordFile is set)
-//  3. Direct password (DEPRECATED, for backward compatibility)
-//
-// Returns empty string if no password is configured (Sentinel without authentication).
-func (c *RedisConfig) GetSentinelPassword() (string, error) {
-	// Priority 1: Environment variable
-	if c.SentinelPasswordEnvVar != "" {
-		if pwd := os.Getenv(c.SentinelPasswordEnvVar); pwd != "" {
-			return pwd, nil
+// GetRepositoryPassword retrieves the chart repository password from the
+// configured environment variable or file. Returns an empty string if no
+// password is configured.
+func (c *DMSHelmConfig) GetRepositoryPassword() (string, error) {
+	return readSecret(c.RepositoryPasswordEnvVar, c.RepositoryPasswordFile)
+}
+
+// readSecret reads a secret from the environment variable envVar, or else
+// from file. Returns an empty string if neither is set.
+func readSecret(envVar, file string) (string, error) {
+	if envVar != "" {
+		if secret := os.Getenv(envVar); secret != "" {
+			return secret, nil
 		}
-		// Env var specified but not set - this is an error
-		return "", fmt.Errorf("environment variable %s is not set", c.SentinelPasswordEnvVar)
+		return "", fmt.Errorf("environment variable %s is not set", envVar)
 	}
-
-	// Priority 2: Password file
-	if c.SentinelPasswordFile != "" {
-		data, err := os.ReadFile(c.SentinelPasswordFile)
+	if file != "" {
+		data, err := os.ReadFile(file)
 		if err != nil {
-			return "", fmt.Errorf("failed to read sentinel password file %s: %w", c.SentinelPasswordFile, err)
+			return "", fmt.Errorf("failed to read password file %s: %w", file, err)
 		}
 		return strings.TrimSpace(string(data)), nil
 	}
-
-	// Priority 3: Direct password (DEPRECATED)
-	return c.SentinelPassword, nil
+	return "", nil
 }
 
-// KubernetesConfig contains Kubernetes client configuration.
-type KubernetesConfig struct {
-	// ConfigPath is the path to kubeconfig file
-	// Leave empty to use in-cluster config when running in a pod
-	ConfigPath string `mapstructure:"config_path"`
-
-	// Context is the kubeconfig context to use (optional)
-	Context string `mapstructure:"context"`
+// List extensions modes for ServerConfig.ListExtensionsMode.
+const (
+	ListExtensionsTruncate = "truncate"
+	ListExtensionsOmit     = "omit"
+)
 
-	// Namespace is the default namespace for operations
-	// Leave empty to use all namespaces
-	Namespace string `mapstructure:"namespace"`
+// Cache backends for CacheConfig.Backend.
+const (
+	CacheBackendMemory = "memory"
+	CacheBackendRedis  = "redis"
+)
 
-	// QPS is the maximum queries per second to the Kubernetes API
-	QPS float32 `mapstructure:"qps"`
+// CacheConfig configures the cache in front of the adapter ListResourcePools,
+// ListResources and ListResourceTypes calls. Cached lists are dropped when
+// the gateway creates, updates or deletes an object of the same kind, and
+// expire after their TTL so changes made outside the gateway show up.
+type CacheConfig struct {
+	// Enabled turns on caching of adapter list results.
+	Enabled bool `mapstructure:"enabled"`
 
-	// Burst is the maximum burst for throttle
-	Burst int `mapstructure:"burst"`
+	// Backend is "memory" for an in-process LRU cache per replica or "redis"
+	// for a cache shared by every replica.
+	Backend string `mapstructure:"backend"`
 
-	// Timeout is the timeout for Kubernetes API requests
-	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxEntries bounds the number of cached lists of the memory backend.
+	MaxEntries int `mapstructure:"max_entries"`
 
-	// EnableWatch enables Kubernetes watch for real-time updates
-	EnableWatch bool `mapstructure:"enable_watch"`
+	// TTL is how long cached lists are served, per object kind.
+	TTL CacheTTLConfig `mapstructure:"ttl"`
+}
 
-	// WatchResync is the resync period for watch cache
-	WatchResync time.Duration `mapstructure:"watch_resync"`
+// CacheTTLConfig holds the cache TTL of each object kind. A zero TTL disables
+// caching of that kind.
+type CacheTTLConfig struct {
+	ResourcePools time.Duration `mapstructure:"resource_pools"`
+	Resources     time.Duration `mapstructure:"resources"`
+	ResourceTypes time.Duration `mapstructure:"resource_types"`
 }
 
-// TLSConfig contains TLS/mTLS configuration.
-type TLSConfig struct {
-	// Enabled enables TLS for the HTTP server
+// Inventory export formats.
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatParquet = "parquet"
+)
+
+// Exported inventory kinds.
+const (
+	ExportKindResources     = "resources"
+	ExportKindResourcePools = "resourcePools"
+)
+
+// ExportConfig configures the scheduled export of the resource and resource
+// pool inventory to an S3-compatible bucket for analytics pipelines.
+type ExportConfig struct {
+	// Enabled turns on the scheduled export.
 	Enabled bool `mapstructure:"enabled"`
 
-	// CertFile is the path to the TLS certificate file
-	CertFile string `mapstructure:"cert_file"`
+	// Interval is the time between exports.
+	Interval time.Duration `mapstructure:"interval"`
 
-	// KeyFile is the path to the TLS private key file
-	KeyFile string `mapstructure:"key_file"`
+	// Format is the file format of the exports: csv or parquet.
+	Format string `mapstructure:"format"`
 
-	// CAFile is the path to the CA certificate file for client verification
-	CAFile string `mapstructure:"ca_file"`
+	// Kinds lists the exported inventory kinds: resources, resourcePools.
+	Kinds []string `mapstructure:"kinds"`
 
-	// ClientAuth specifies the client authentication mode
-	// Options: "none", "request", "require", "verify", "require-and-verify"
-	ClientAuth string `mapstructure:"client_auth"`
+	// ResourceColumns selects the exported resource columns. Empty exports
+	// the default columns.
+	ResourceColumns []string `mapstructure:"resource_columns"`
 
-	// MinVersion is the minimum TLS version ("1.2", "1.3")
-	MinVersion string `mapstructure:"min_version"`
+	// ResourcePoolColumns selects the exported resource pool columns. Empty
+	// exports the default columns.
+	ResourcePoolColumns []string `mapstructure:"resource_pool_columns"`
 
-	// CipherSuites is a list of enabled cipher suites (optional)
-	CipherSuites []string `mapstructure:"cipher_suites"`
+	// ObjectStore is the bucket the exports are written to.
+	ObjectStore ExportObjectStoreConfig `mapstructure:"object_store"`
 }
 
-// ObservabilityConfig contains logging, metrics, and tracing configuration.
-type ObservabilityConfig struct {
-	Logging LoggingConfig `mapstructure:"logging"`
-	Metrics MetricsConfig `mapstructure:"metrics"`
-	Tracing TracingConfig `mapstructure:"tracing"`
-}
+// ExportObjectStoreConfig identifies an S3-compatible bucket. Credentials
+// come from the default AWS credential chain (environment, shared config or
+// instance role).
+type ExportObjectStoreConfig struct {
+	// Bucket is the name of the bucket.
+	Bucket string `mapstructure:"bucket"`
 
-// LoggingConfig contains structured logging configuration.
-type LoggingConfig struct {
-	// Level sets the log level ("debug", "info", "warn", "error", "fatal")
-	Level string `mapstructure:"level"`
+	// Prefix is prepended to the object keys of the exports.
+	Prefix string `mapstructure:"prefix"`
 
-	// Format sets the log format ("json", "console")
-	Format string `mapstructure:"format"`
+	// Region is the region of the bucket.
+	Region string `mapstructure:"region"`
 
-	// OutputPaths is a list of output destinations (e.g., ["stdout", "/var/log/app.log"])
-	OutputPaths []string `mapstructure:"output_paths"`
+	// Endpoint overrides the S3 endpoint, e.g. for MinIO or Ceph.
+	Endpoint string `mapstructure:"endpoint"`
 
-	// ErrorOutputPaths is a list of error output destinations
-	ErrorOutputPaths []string `mapstructure:"error_output_paths"`
+	// UsePathStyle addresses the bucket in the URL path instead of the host
+	// name, as most S3-compatible stores require.
+	UsePathStyle bool `mapstructure:"use_path_style"`
+}
 
-	// EnableCaller adds caller information to log entries
-	EnableCaller bool `mapstructure:"enable_caller"`
+// ComplianceConfig configures the scheduled compliance self-check, which runs
+// the O-RAN compliance checks of the compliance tool against the gateway's own
+// listener, exports the score of each specification and reports regressions.
+type ComplianceConfig struct {
+	// Enabled turns on the scheduled self-check.
+	Enabled bool `mapstructure:"enabled"`
 
-	// EnableStacktrace adds stacktrace on errors
-	EnableStacktrace bool `mapstructure:"enable_stacktrace"`
+	// Interval is the time between self-checks.
+	Interval time.Duration `mapstructure:"interval"`
 
-	// Development enables development mode (more verbose, console format)
-	Development bool `mapstructure:"development"`
-}
+	// BaseURL is the gateway URL the checks are sent to. Empty uses
+	// localhost and the server port, over HTTPS when TLS is enabled.
+	BaseURL string `mapstructure:"base_url"`
 
-// MetricsConfig contains Prometheus metrics configuration.
-type MetricsConfig struct {
-	// Enabled enables Prometheus metrics collection
-	Enabled bool `mapstructure:"enabled"`
+	// Headers are added to every check request, e.g. an Authorization
+	// header when authentication is enabled.
+	Headers map[string]string `mapstructure:"headers" redact:"true"`
 
-	// Path is the HTTP path for metrics endpoint (default: "/metrics")
-	Path string `mapstructure:"path"`
+	// CheckTimeout bounds each endpoint check.
+	CheckTimeout time.Duration `mapstructure:"check_timeout"`
 
-	// Port is the port for metrics server (0 = use main server port)
-	Port int `mapstructure:"port"`
+	// RegressionThreshold is the drop of a specification's score, in
+	// percentage points, from the previous run that is reported as a
+	// regression. Zero reports any drop.
+	RegressionThreshold float64 `mapstructure:"regression_threshold"`
+}
 
-	// Namespace is the Prometheus metrics namespace
-	Namespace string `mapstructure:"namespace"`
+// DNSConfig configures the resolver shared by outbound HTTP clients
+// (webhooks, SMO and O2-DMS backends, adapter endpoints).
+type DNSConfig struct {
+	// Servers lists upstream DNS servers as host:port. Empty uses the
+	// system resolver.
+	Servers []string `mapstructure:"servers"`
 
-	// Subsystem is the Prometheus metrics subsystem
-	Subsystem string `mapstructure:"subsystem"`
+	// CacheTTL is how long resolved addresses are cached. Zero disables
+	// caching.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
 
-	// EnableGoMetrics enables Go runtime metrics
-	EnableGoMetrics bool `mapstructure:"enable_go_metrics"`
+	// NegativeTTL is how long unknown hosts are cached. Zero disables
+	// negative caching.
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
 
-	// EnableProcessMetrics enables process metrics
-	EnableProcessMetrics bool `mapstructure:"enable_process_metrics"`
+	// MaxEntries bounds the number of cached hosts.
+	MaxEntries int `mapstructure:"max_entries"`
+
+	// Timeout bounds each query to an upstream server.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
-// TracingConfig contains distributed tracing configuration.
-type TracingConfig struct {
-	// Enabled enables distributed tracing
+// ReloadConfig configures reloading the configuration file without a
+// restart. The file is reloaded on SIGHUP and when it changes; only the
+// settings listed by ReloadableKeys are applied, changes to any other setting
+// are logged and take effect on the next restart.
+type ReloadConfig struct {
+	// WatchInterval is how often the configuration file is checked for
+	// changes. 0 reloads on SIGHUP only (default: 10s)
+	WatchInterval time.Duration `mapstructure:"watch_interval"`
+}
+
+// Provisioners for ProvisioningConfig.DefaultProvisioner.
+const (
+	ProvisionerSimulator = "simulator"
+	ProvisionerCAPI      = "capi"
+)
+
+// ProvisioningConfig configures the O2-IMS infrastructureProvisioning API,
+// which provisions clusters through pluggable provisioners and tracks each
+// provisioning request until it is fulfilled or failed.
+type ProvisioningConfig struct {
+	// Enabled serves /o2ims-infrastructureProvisioning/v1.
 	Enabled bool `mapstructure:"enabled"`
 
-	// Provider specifies the tracing provider ("jaeger", "zipkin", "otlp")
-	Provider string `mapstructure:"provider"`
+	// DefaultProvisioner handles requests that do not name a provisioner.
+	DefaultProvisioner string `mapstructure:"default_provisioner"`
 
-	// Endpoint is the tracing collector endpoint
-	Endpoint string `mapstructure:"endpoint"`
+	// ReconcileInterval is how often in-flight requests are advanced.
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval"`
 
-	// ServiceName is the service name for tracing
-	ServiceName string `mapstructure:"service_name"`
+	// Simulator configures the simulator provisioner, which provisions
+	// nothing and is always available.
+	Simulator ProvisioningSimulatorConfig `mapstructure:"simulator"`
 
-	// SamplingRate is the sampling rate (0.0 to 1.0)
-	SamplingRate float64 `mapstructure:"sampling_rate"`
+	// CAPI configures the Cluster API provisioner.
+	CAPI ProvisioningCAPIConfig `mapstructure:"capi"`
 
-	// EnableBatching enables batch span export
-	EnableBatching bool `mapstructure:"enable_batching"`
+	// Notifications configures the notifications of request state changes.
+	Notifications ProvisioningNotificationsConfig `mapstructure:"notifications"`
+}
 
-	// BatchTimeout is the timeout for batch export
-	BatchTimeout time.Duration `mapstructure:"batch_timeout"`
+// ProvisioningSimulatorConfig configures the simulator provisioner.
+type ProvisioningSimulatorConfig struct {
+	// Delay is how long simulated provisioning and deprovisioning take.
+	Delay time.Duration `mapstructure:"delay"`
 }
 
-// SecurityConfig contains security-related configuration.
-type SecurityConfig struct {
-	// EnableCORS enables CORS support
-	EnableCORS bool `mapstructure:"enable_cors"`
+// ProvisioningCAPIConfig configures the Cluster API provisioner, which
+// creates a topology-based Cluster per request in a management cluster.
+type ProvisioningCAPIConfig struct {
+	// Enabled registers the Cluster API provisioner.
+	Enabled bool `mapstructure:"enabled"`
 
-	// AllowedOrigins is a list of allowed CORS origins
-	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	// Kubeconfig is the kubeconfig of the management cluster. Empty uses the
+	// in-cluster configuration.
+	Kubeconfig string `mapstructure:"kubeconfig"`
 
-	// AllowedMethods is a list of allowed HTTP methods
-	AllowedMethods []string `mapstructure:"allowed_methods"`
+	// Namespace is where Cluster resources are created.
+	Namespace string `mapstructure:"namespace"`
+}
 
-	// AllowedHeaders is a list of allowed HTTP headers
-	AllowedHeaders []string `mapstructure:"allowed_headers"`
+// ProvisioningNotificationsConfig configures the notifications posted when a
+// provisioning request changes state. Notifications are signed with
+// notifications.hmac_secret.
+type ProvisioningNotificationsConfig struct {
+	// Callbacks are the URLs every notification is posted to. None disables
+	// notifications.
+	Callbacks []string `mapstructure:"callbacks"`
 
-	// RateLimitEnabled enables rate limiting
-	RateLimitEnabled bool `mapstructure:"rate_limit_enabled"`
+	// Timeout bounds a single callback POST.
+	Timeout time.Duration `mapstructure:"timeout"`
 
-	// RateLimit contains comprehensive rate limiting configuration
-	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	// MaxRetries is the number of retries after the first failed delivery.
+	MaxRetries int `mapstructure:"max_retries"`
+}
 
-	// AllowInsecureCallbacks allows HTTP (non-HTTPS) webhook callbacks
-	// This should ONLY be enabled in development/testing environments
-	// Production deployments MUST enforce HTTPS for webhook callbacks
-	AllowInsecureCallbacks bool `mapstructure:"allow_insecure_callbacks"`
+// Startup check modes for StartupChecksConfig.Mode.
+const (
+	StartupChecksStrict   = "strict"
+	StartupChecksLenient  = "lenient"
+	StartupChecksDisabled = "disabled"
+)
 
-	// DisableSSRFProtection disables SSRF protection for webhook callbacks
-	// This allows callbacks to localhost and private IP addresses
-	// WARNING: This should ONLY be enabled in development/testing environments
-	// Production deployments MUST keep SSRF protection enabled to prevent attacks
-	DisableSSRFProtection bool `mapstructure:"disable_ssrf_protection"`
+// StartupChecksConfig configures the probes verifying that the Kubernetes
+// adapter and every DMS adapter backend are reachable with the configured
+// endpoints and credentials before the gateway starts serving.
+type StartupChecksConfig struct {
+	// Mode is "strict" to refuse to start when a probe fails, "lenient" to
+	// log the failure and start anyway, or "disabled" to skip the probes.
+	Mode string `mapstructure:"mode"`
 
-	// SecurityHeaders contains configuration for security headers middleware
-	SecurityHeaders SecurityHeadersConfig `mapstructure:"security_headers"`
+	// Timeout bounds each probe.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
-// SecurityHeadersConfig contains configuration for HTTP security headers.
-type SecurityHeadersConfig struct {
-	// Enabled controls whether security headers are added (default: true)
+// CircuitBreakerConfig configures the circuit breakers guarding the IMS
+// adapter and every DMS adapter. After FailureThreshold consecutive backend
+// failures an adapter's breaker opens and its calls fail immediately with
+// 503 Service Unavailable. After OpenTimeout, HalfOpenRequests probe calls
+// are let through; the breaker closes when they succeed.
+type CircuitBreakerConfig struct {
+	// Enabled turns on the circuit breakers.
 	Enabled bool `mapstructure:"enabled"`
 
-	// HSTSMaxAge is the max-age for Strict-Transport-Security header in seconds
-	// Default: 31536000 (1 year)
-	HSTSMaxAge int `mapstructure:"hsts_max_age"`
+	// FailureThreshold is the number of consecutive failed calls that opens
+	// a breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
 
-	// HSTSIncludeSubDomains includes subdomains in HSTS
-	HSTSIncludeSubDomains bool `mapstructure:"hsts_include_subdomains"`
+	// OpenTimeout is how long a breaker stays open before probing the backend.
+	OpenTimeout time.Duration `mapstructure:"open_timeout"`
 
-	// HSTSPreload enables HSTS preload
-	HSTSPreload bool `mapstructure:"hsts_preload"`
+	// HalfOpenRequests is the number of probe calls let through while a
+	// breaker is half-open.
+	HalfOpenRequests int `mapstructure:"half_open_requests"`
+}
 
-	// ContentSecurityPolicy is the Content-Security-Policy header value
-	// Default: "default-src 'none'; frame-ancestors 'none'"
-	ContentSecurityPolicy string `mapstructure:"content_security_policy"`
+// DMSJobsConfig configures DMS jobs. When enabled, creating, updating,
+// deleting, scaling and rolling back NF deployments returns 202 Accepted with
+// a job that GET /o2dms/v1/jobs/{jobId} reports on, while a worker performs
+// the adapter call. Jobs are persisted in Redis and survive gateway restarts.
+type DMSJobsConfig struct {
+	// Enabled makes the mutating NF deployment endpoints asynchronous.
+	Enabled bool `mapstructure:"enabled"`
 
-	// FrameOptions is the X-Frame-Options header value
-	// Default: "DENY"
-	FrameOptions string `mapstructure:"frame_options"`
+	// Workers is the number of jobs each replica executes concurrently.
+	Workers int `mapstructure:"workers"`
 
-	// ReferrerPolicy is the Referrer-Policy header value
-	// Default: "strict-origin-when-cross-origin"
-	ReferrerPolicy string `mapstructure:"referrer_policy"`
+	// QueueSize is the number of jobs that may wait for a worker on each
+	// replica; further requests are rejected with 503.
+	QueueSize int `mapstructure:"queue_size"`
+
+	// LeaseTimeout is how long a job may go without a heartbeat from its
+	// replica before another replica takes it over.
+	LeaseTimeout time.Duration `mapstructure:"lease_timeout"`
+
+	// Retention is how long finished jobs can be queried.
+	Retention time.Duration `mapstructure:"retention"`
 }
 
-// RateLimitConfig contains comprehensive rate limiting configuration.
-type RateLimitConfig struct {
-	// PerTenant configures per-tenant rate limits
-	PerTenant TenantRateLimitConfig `mapstructure:"tenant"`
+// DMSNotificationsConfig configures the DMS notification engine, which polls
+// the DMS adapters for deployment state transitions and posts them to the
+// callbacks of matching DMS subscriptions. Notifications are signed with
+// notifications.hmac_secret.
+type DMSNotificationsConfig struct {
+	// Enabled starts the DMS notification engine.
+	Enabled bool `mapstructure:"enabled"`
 
-	// PerEndpoint configures per-endpoint rate limits
-	PerEndpoint []EndpointRateLimitConfig `mapstructure:"endpoints"`
+	// Interval is how often the adapters are polled for deployment changes.
+	Interval time.Duration `mapstructure:"interval"`
 
-	// Global configures global rate limits
-	Global GlobalRateLimitConfig `mapstructure:"global"`
+	// Timeout bounds a single callback POST.
+	Timeout time.Duration `mapstructure:"timeout"`
 
-	// PerResource configures per-resource-type rate limits
-	PerResource ResourceRateLimitConfig `mapstructure:"resource"`
-}
+	// MaxRetries is the number of retries after the first failed delivery.
+	MaxRetries int `mapstructure:"max_retries"`
 
-// TenantRateLimitConfig configures per-tenant rate limits.
-type TenantRateLimitConfig struct {
-	RequestsPerSecond int `mapstructure:"requests_per_second"`
-	BurstSize         int `mapstructure:"burst_size"`
+	// RetryBackoff is the delay before the first retry; it doubles with
+	// every further retry.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// History is the number of deliveries kept per subscription.
+	History int `mapstructure:"history"`
 }
 
-// EndpointRateLimitConfig configures rate limits for specific endpoints.
-type EndpointRateLimitConfig struct {
-	Path              string `mapstructure:"path"`
-	Method            string `mapstructure:"method"`
-	RequestsPerSecond int    `mapstructure:"requests_per_second"`
-	BurstSize         int    `mapstructure:"burst_size"`
+// DMSNamespacePolicy is a namespace allowlist and denylist of a DMS adapter.
+// Rules are shell patterns such as "kube-*"; deny rules win, and a non-empty
+// allowlist denies every namespace it does not match.
+type DMSNamespacePolicy struct {
+	// Allow lists the namespaces the adapter may act on. Empty allows all.
+	Allow []string `mapstructure:"allow"`
+
+	// Deny lists namespaces the adapter must never act on.
+	Deny []string `mapstructure:"deny"`
 }
 
-// GlobalRateLimitConfig configures global rate limits.
-type GlobalRateLimitConfig struct {
-	RequestsPerSecond     int `mapstructure:"requests_per_second"`
-	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+// DMSStorageConfig selects the DMS subscription storage backend.
+type DMSStorageConfig struct {
+	// Backend is "memory" (default, single replica only), "redis" (the
+	// gateway's Redis), or "kubernetes" (DMSSubscription custom resources).
+	Backend string `mapstructure:"backend"`
+
+	// Namespace holds DMSSubscription objects with the kubernetes backend.
+	// Empty uses kubernetes.namespace, or "default" if that is empty too.
+	Namespace string `mapstructure:"namespace"`
 }
 
-// ResourceRateLimitConfig contains resource-type-specific rate limiting configuration.
-type ResourceRateLimitConfig struct {
-	// Enabled controls whether resource rate limiting is active
+// PricingConfig is the pricing model used to estimate the monthly cost of NF
+// deployments (from their resource requests) and resource pools (from the
+// capacity of their resources).
+type PricingConfig struct {
+	// Enabled adds cost estimates to NF deployments and resource pools and
+	// exports them as metrics.
 	Enabled bool `mapstructure:"enabled"`
 
-	// DeploymentManagers configures limits for deployment manager operations
-	DeploymentManagers ResourceTypeLimitConfig `mapstructure:"deployment_managers"`
+	// Currency is the ISO 4217 currency code of the prices.
+	Currency string `mapstructure:"currency"`
 
-	// ResourcePools configures limits for resource pool operations
-	ResourcePools ResourceTypeLimitConfig `mapstructure:"resource_pools"`
+	// VCPUHour is the price of one vCPU for one hour.
+	VCPUHour float64 `mapstructure:"vcpu_hour"`
 
-	// Resources configures limits for individual resource operations
-	Resources ResourceTypeLimitConfig `mapstructure:"resources"`
+	// MemoryGBHour is the price of one GB (GiB) of memory for one hour.
+	MemoryGBHour float64 `mapstructure:"memory_gb_hour"`
 
-	// ResourceTypes configures limits for resource type operations
-	ResourceTypes ResourceTypeLimitConfig `mapstructure:"resource_types"`
+	// GPUHour is the price of one GPU for one hour.
+	GPUHour float64 `mapstructure:"gpu_hour"`
+}
 
-	// Subscriptions configures limits for subscription operations
-	Subscriptions SubscriptionRateLimitConfig `mapstructure:"subscriptions"`
+// Duplicate subscription policies.
+const (
+	SubscriptionDuplicatesAllow  = "allow"
+	SubscriptionDuplicatesReject = "reject"
+	SubscriptionDuplicatesMerge  = "merge"
+)
+
+// SubscriptionsConfig configures how subscription creation is handled.
+type SubscriptionsConfig struct {
+	// DuplicatePolicy decides what happens when a consumer creates a
+	// subscription with the same callback and filter as one it already has:
+	// "allow" (default) creates another subscription, "reject" fails with
+	// 409 Conflict, and "merge" returns the existing subscription.
+	DuplicatePolicy string `mapstructure:"duplicate_policy"`
+
+	// MaxTTL caps how far in the future the expiresAt of an O2-IMS or O2-DMS
+	// subscription may be set. Zero allows any expiry.
+	MaxTTL time.Duration `mapstructure:"max_ttl"`
+
+	// ExpiryCheckInterval is how often expired subscriptions are deleted.
+	// Zero disables the cleanup; expired subscriptions still receive no
+	// notifications.
+	ExpiryCheckInterval time.Duration `mapstructure:"expiry_check_interval"`
 }
 
-// ResourceTypeLimitConfig defines rate limits for a resource type.
-type ResourceTypeLimitConfig struct {
-	// ReadsPerMinute limits read operations per minute
-	ReadsPerMinute int `mapstructure:"reads_per_minute"`
+// NotificationsConfig configures the notification subsystem, which watches
+// the Kubernetes adapter for resource and resource pool changes and POSTs
+// them to the callbacks of matching subscriptions.
+type NotificationsConfig struct {
+	// Enabled starts the notification controller and webhook workers. It only
+	// takes effect when the gateway serves O2-IMS from the Kubernetes adapter.
+	Enabled bool `mapstructure:"enabled"`
 
-	// WritesPerMinute limits write operations per minute
-	WritesPerMinute int `mapstructure:"writes_per_minute"`
+	// Workers is the number of concurrent webhook delivery workers.
+	Workers int `mapstructure:"workers"`
 
-	// ListPageSizeMax limits the maximum page size for list operations
-	ListPageSizeMax int `mapstructure:"list_page_size_max"`
-}
+	// Timeout bounds a single webhook POST.
+	Timeout time.Duration `mapstructure:"timeout"`
 
-// SubscriptionRateLimitConfig defines rate limits specific to subscriptions.
-type SubscriptionRateLimitConfig struct {
-	// CreatesPerHour limits subscription creations per hour
-	CreatesPerHour int `mapstructure:"creates_per_hour"`
+	// MaxRetries is the number of retries after the first failed delivery
+	// before the notification is moved to the dead-letter queue.
+	MaxRetries int `mapstructure:"max_retries"`
 
-	// MaxActive limits the maximum number of active subscriptions per tenant
-	MaxActive int `mapstructure:"max_active"`
+	// RetryBackoff is the delay before the first retry; it doubles with
+	// every further retry up to MaxBackoff.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	MaxBackoff   time.Duration `mapstructure:"max_backoff"`
 
-	// ReadsPerMinute limits read operations per minute
-	ReadsPerMinute int `mapstructure:"reads_per_minute"`
-}
+	// OrderingTimeout is how long a notification waits for the previous
+	// notification about the same resource before it is delivered anyway.
+	OrderingTimeout time.Duration `mapstructure:"ordering_timeout"`
 
-// ValidationConfig contains OpenAPI request/response validation configuration.
-type ValidationConfig struct {
-	// Enabled enables OpenAPI request validation
-	Enabled bool `mapstructure:"enabled"`
+	// HMACSecret signs every notification (X-O2IMS-Signature header).
+	// Notifications are unsigned when it is empty.
+	HMACSecret string `mapstructure:"hmac_secret" redact:"true"`
 
-	// ValidateResponse enables OpenAPI response validation (use only in development/testing)
-	ValidateResponse bool `mapstructure:"validate_response"`
+	// BusyEventsPerMinute is the cluster event rate from which subscriptions
+	// with an empty filter, which match every event, are logged as warnings.
+	// 0 disables the warning.
+	BusyEventsPerMinute int `mapstructure:"busy_events_per_minute"`
 
-	// SpecPath is the path to a custom OpenAPI specification file
-	// If empty, the embedded spec will be used
-	SpecPath string `mapstructure:"spec_path"`
+	// DeliverabilityWindow is the sliding window over which the callback
+	// deliverability score of each subscription is computed (minimum 1m).
+	DeliverabilityWindow time.Duration `mapstructure:"deliverability_window"`
 
-	// MaxBodySize is the maximum allowed request body size in bytes
-	// Requests exceeding this limit are rejected with 413 Payload Too Large
-	// Default: 1048576 (1MB)
-	MaxBodySize int64 `mapstructure:"max_body_size"`
+	// DigestCheckInterval is how often subscriptions in digest mode are
+	// checked for digests that are due. Digests are sent at most this late
+	// after the end of their period.
+	DigestCheckInterval time.Duration `mapstructure:"digest_check_interval"`
+
+	// QueueSnapshotPath is a file the undelivered notifications are exported
+	// to on shutdown and replayed from on startup, so that they survive a
+	// Redis migration during maintenance. Disabled when empty.
+	QueueSnapshotPath string `mapstructure:"queue_snapshot_path"`
+
+	// CA configures the internal certificate authority for webhook mTLS.
+	CA WebhookCAConfig `mapstructure:"ca"`
+
+	// Autoscale scales the webhook workers with the backlog and the delivery
+	// latency instead of running a fixed number of them.
+	Autoscale WebhookAutoscaleConfig `mapstructure:"autoscale"`
 }
 
-// Load loads configuration from the specified file path and environment variables.
-// Environment variables override file values and should be prefixed with NETWEAVE_
-// (e.g., NETWEAVE_SERVER_PORT=8080).
-//
-// Configuration file resolution order:
-//  1. Explicit configPath parameter (if provided)
-//  2. NETWEAVE_CONFIG environment variable
-//  3. Environment-specific config via NETWEAVE_ENV (dev/staging/prod)
-//  4. Default: config/config.yaml
-//
-// Returns an error if the configuration file cannot be read or parsed.
-//
-// Example:
-//
-//	cfg, err := config.Load("config/config.yaml")
-//	if err != nil {
-//	    return fmt.Errorf("failed to load config: %w", err)
-//	}
-func Load(configPath string) (*Config, error) {
-	v := viper.New()
+// WebhookAutoscaleConfig configures autoscaling of the webhook delivery
+// workers. Workers is the initial pool size while it is enabled.
+type WebhookAutoscaleConfig struct {
+	// Enabled scales the worker pool between MinWorkers and MaxWorkers.
+	Enabled bool `mapstructure:"enabled"`
 
-	// Set configuration file with environment detection
-	configPath = resolveConfigPath(configPath)
-	if configPath != "" {
-		v.SetConfigFile(configPath)
-	} else {
-		// Default configuration file locations
-		v.SetConfigName("config")
-		v.SetConfigType("yaml")
-		v.AddConfigPath("./config")
-		v.AddConfigPath(".")
-		v.AddConfigPath("/etc/netweave")
-	}
+	// MinWorkers and MaxWorkers bound the worker pool.
+	MinWorkers int `mapstructure:"min_workers"`
+	MaxWorkers int `mapstructure:"max_workers"`
 
-	// Enable environment variable overrides
-	v.SetEnvPrefix("NETWEAVE")
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	v.AutomaticEnv()
+	// TargetBacklogPerWorker is the number of undelivered notifications each
+	// worker is expected to handle.
+	TargetBacklogPerWorker int64 `mapstructure:"target_backlog_per_worker"`
 
-	// Set defaults
-	setDefaults(v)
+	// TargetLatency is the mean delivery latency above which a backlog gets
+	// another worker.
+	TargetLatency time.Duration `mapstructure:"target_latency"`
 
-	// Read configuration file
-	if err := v.ReadInConfig(); err != nil {
-		// Config file is optional if all values come from env vars
-		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if !errors.As(err, &configFileNotFoundError) && !os.IsNotExist(err) {
-			return nil, fmt.Errorf("failed to read config file: %w", err)
-		}
-	}
+	// Interval is how often the backlog and latency are checked.
+	Interval time.Duration `mapstructure:"interval"`
 
-	// Unmarshal configuration
-	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
-	}
+	// ScaleDownCooldown is the minimum time after a scaling action before a
+	// worker is removed.
+	ScaleDownCooldown time.Duration `mapstructure:"scale_down_cooldown"`
 
-	// Set environment from NETWEAVE_ENV or detect from config path
-	cfg.Environment = detectEnvironment(configPath)
+	// ClaimIdle is how long a notification may stay read but unacknowledged,
+	// for example by a replica that crashed, before it is delivered again.
+	ClaimIdle time.Duration `mapstructure:"claim_idle"`
+}
 
-	return &cfg, nil
+// WebhookCAConfig configures the internal certificate authority that issues
+// mTLS certificates to subscribers and the gateway's webhook client
+// certificate.
+type WebhookCAConfig struct {
+	// Enabled starts the CA and the certificate enrollment API.
+	Enabled bool `mapstructure:"enabled"`
+
+	// CertFile and KeyFile hold the CA key pair, e.g. a CA Secret issued by
+	// cert-manager. When both are empty a CA is generated and stored in
+	// Redis.
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+
+	// CertValidity is the default validity of issued certificates.
+	CertValidity time.Duration `mapstructure:"cert_validity"`
 }
 
-// resolveConfigPath determines the configuration file path to use.
-// Priority order:
-//  1. Explicit configPath parameter (if non-empty)
-//  2. NETWEAVE_CONFIG environment variable
+// DefaultQuotaConfig contains default quota values for new tenants.
+type DefaultQuotaConfig struct {
+	MaxSubscriptions     int `mapstructure:"max_subscriptions"`
+	MaxResourcePools     int `mapstructure:"max_resource_pools"`
+	MaxDeployments       int `mapstructure:"max_deployments"`
+	MaxUsers             int `mapstructure:"max_users"`
+	MaxRequestsPerMinute int `mapstructure:"max_requests_per_minute"`
+}
+
+// ServerConfig contains HTTP server configuration.
+type ServerConfig struct {
+	// Host is the network interface to bind to (e.g., "0.0.0.0", "localhost")
+	Host string `mapstructure:"host"`
+
+	// Port is the HTTP server port (default: 8080)
+	Port int `mapstructure:"port"`
+
+	// ReadTimeout is the maximum duration for reading the entire request
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+
+	// WriteTimeout is the maximum duration before timing out writes of the response
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+
+	// IdleTimeout is the maximum duration to wait for the next request when keep-alives are enabled
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// ShutdownTimeout is the maximum duration to wait for graceful shutdown
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	// StreamDrainTimeout is how long long-running streams (SSE, log follow,
+	// WebSocket, long poll) are given to disconnect after receiving a reconnect hint during
+	// shutdown. It extends the shutdown window separately from ShutdownTimeout,
+	// which bounds regular requests. 0 closes streams immediately.
+	StreamDrainTimeout time.Duration `mapstructure:"stream_drain_timeout"`
+
+	// StreamReconnectDelay is the reconnect delay suggested to stream clients
+	// when the server is draining (default: 2s).
+	StreamReconnectDelay time.Duration `mapstructure:"stream_reconnect_delay"`
+
+	// ReadCacheTTL is how long resource pools and resources are cached locally
+	// for GET-by-ID requests. Mutations on any replica invalidate the cached
+	// entries through Redis pub/sub. 0 disables the cache.
+	ReadCacheTTL time.Duration `mapstructure:"read_cache_ttl"`
+
+	// ListSnapshotTTL is how long list snapshots taken for consistency=snapshot
+	// pagination are retained in Redis. Clients must fetch all pages within
+	// this window. 0 disables snapshot pagination.
+	ListSnapshotTTL time.Duration `mapstructure:"list_snapshot_ttl"`
+
+	// ListExtensionsMaxBytes caps the JSON size of the extensions of each
+	// object in resource pool, resource and resource type lists. Larger
+	// extensions are cut down according to ListExtensionsMode and the object
+	// is marked with extensionsTruncated. Single-object GETs and lists
+	// requested with ?includeExtensions=full return full extensions.
+	// 0 disables the cap.
+	ListExtensionsMaxBytes int `mapstructure:"list_extensions_max_bytes"`
+
+	// ListExtensionsMode is "truncate" to keep the extension keys that fit
+	// within ListExtensionsMaxBytes, or "omit" to drop oversized extensions.
+	ListExtensionsMode string `mapstructure:"list_extensions_mode"`
+
+	// RequestTimeout bounds the handling of each API request. It can be
+	// changed at runtime through a staged rollout. 0 disables the timeout.
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+
+	// MaxHeaderBytes is the maximum size of request headers
+	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
+
+	// GinMode sets the Gin framework mode ("debug", "release", "test")
+	GinMode string `mapstructure:"gin_mode"`
+
+	// TrustedProxies is a list of IP addresses or CIDRs of reverse proxies and
+	// load balancers whose forwarding headers are trusted for client IP extraction.
+	// Leave empty to ignore forwarding headers and use the connection peer address.
+	// Example: ["10.0.0.0/8", "192.168.1.10"]
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+
+	// RemoteIPHeaders lists the headers inspected, in order, to derive the real
+	// client IP when the request arrives from a trusted proxy.
+	// Default: ["X-Forwarded-For", "X-Real-IP"]
+	RemoteIPHeaders []string `mapstructure:"remote_ip_headers"`
+
+	// Mode selects which APIs the gateway serves and which backends must be
+	// reachable at startup: "ims+dms" (default), "dms-only", or "simulator".
+	Mode string `mapstructure:"mode"`
+}
+
+// Gateway modes.
+const (
+	// GatewayModeIMSAndDMS serves the O2-IMS and O2-DMS APIs. The IMS adapter
+	// (Kubernetes unless ADAPTER_TYPE=mock) must be reachable at startup.
+	GatewayModeIMSAndDMS = "ims+dms"
+
+	// GatewayModeDMSOnly serves only the O2-DMS API. No IMS adapter is
+	// created, so Kubernetes is not contacted at startup.
+	GatewayModeDMSOnly = "dms-only"
+
+	// GatewayModeSimulator serves both APIs from in-memory mock adapters
+	// with sample data, for demos and client development.
+	GatewayModeSimulator = "simulator"
+)
+
+// GatewayMode returns the configured gateway mode, defaulting to ims+dms.
+func (s *ServerConfig) GatewayMode() string {
+	if s.Mode == "" {
+		return GatewayModeIMSAndDMS
+	}
+	return s.Mode
+}
+
+// IMSEnabled reports whether the gateway mode serves the O2-IMS API.
+func (s *ServerConfig) IMSEnabled() bool {
+	return s.GatewayMode() != GatewayModeDMSOnly
+}
+
+// RedisConfig contains Redis client and cluster configuration.
+type RedisConfig struct {
+	// Mode specifies Redis deployment mode: "standalone", "sentinel", "cluster"
+	Mode string `mapstructure:"mode"`
+
+	// Addresses contains Redis server addresses
+	// For standalone: ["localhost:6379"]
+	// For sentinel: ["sentinel1:26379", "sentinel2:26379"]
+	// For cluster: ["node1:6379", "node2:6379", ...]
+	Addresses []string `mapstructure:"addresses"`
+
+	// MasterName is required for Sentinel mode (e.g., "mymaster")
+	MasterName string `mapstructure:"master_name"`
+
+	// Password for Redis authentication (optional, DEPRECATED: use PasswordEnvVar or PasswordFile)
+	// WARNING: Storing passwords in config files is insecure. Use environment variables or secret files instead.
+	Password string `mapstructure:"password" redact:"true"`
+
+	// PasswordEnvVar specifies the environment variable name containing the Redis password
+	// Example: "REDIS_PASSWORD"
+	// This takes priority over PasswordFile if both are set
+	PasswordEnvVar string `mapstructure:"password_env_var"`
+
+	// PasswordFile specifies the path to a file containing the Redis password
+	// Example: "/run/secrets/redis-password" (Kubernetes Secret mount)
+	// The file should contain only the password, whitespace will be trimmed
+	PasswordFile string `mapstructure:"password_file"`
+
+	// SentinelPassword for Sentinel authentication (optional, DEPRECATED: use SentinelPasswordEnvVar
+	// or SentinelPasswordFile)
+	// WARNING: Storing passwords in config files is insecure. Use environment variables or secret files instead.
+	// Best practice: Use different passwords for Sentinel and Redis.
+	SentinelPassword string `mapstructure:"sentinel_password" redact:"true"`
+
+	// SentinelPasswordEnvVar specifies the environment variable name containing the Sentinel password
+	// Example: "SENTINEL_PASSWORD"
+	// This takes priority over SentinelPasswordFile if both are set
+	SentinelPasswordEnvVar string `mapstructure:"sentinel_password_env_var"`
+
+	// SentinelPasswordFile specifies the path to a file containing the Sentinel password
+	// Example: "/run/secrets/sentinel-password" (Kubernetes Secret mount)
+	// The file should contain only the password, whitespace will be trimmed
+	SentinelPasswordFile string `mapstructure:"sentinel_password_file"`
+
+	// DB is the Redis database number (0-15, only for standalone/sentinel)
+	DB int `mapstructure:"db"`
+
+	// PoolSize is the maximum number of socket connections
+	PoolSize int `mapstructure:"pool_size"`
+
+	// MinIdleConns is the minimum number of idle connections
+	MinIdleConns int `mapstructure:"min_idle_conns"`
+
+	// MaxRetries is the maximum number of retries before giving up
+	MaxRetries int `mapstructure:"max_retries"`
+
+	// DialTimeout is the timeout for establishing new connections
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+
+	// ReadTimeout is the timeout for socket reads
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+
+	// WriteTimeout is the timeout for socket writes
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+
+	// PoolTimeout is the timeout when all connections are busy
+	PoolTimeout time.Duration `mapstructure:"pool_timeout"`
+
+	// IdleTimeout is the amount of time after which client closes idle connections
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// EnableTLS enables TLS for Redis connections
+	EnableTLS bool `mapstructure:"enable_tls"`
+
+	// TLSInsecureSkipVerify skips TLS certificate verification (use only for testing)
+	TLSInsecureSkipVerify bool `mapstructure:"tls_insecure_skip_verify"`
+
+	// ReadFallback serves subscription and tenant reads from memory during
+	// Redis outages.
+	ReadFallback RedisReadFallbackConfig `mapstructure:"read_fallback"`
+
+	// PoolStatsInterval is the interval between two exports of the
+	// connection pool stats as metrics.
+	PoolStatsInterval time.Duration `mapstructure:"pool_stats_interval"`
+
+	// PoolAutoResize resizes the connection pools based on wait times.
+	PoolAutoResize RedisPoolAutoResizeConfig `mapstructure:"pool_auto_resize"`
+}
+
+// RedisPoolAutoResizeConfig configures the automatic resizing of the Redis
+// connection pools. A pool starts at pool_size, grows by a quarter when the
+// average wait for a connection stays above wait_threshold (or waits time
+// out) for sustained_intervals stats intervals, and shrinks by a quarter when
+// it stays idle, without waits and at most half used, as long.
+type RedisPoolAutoResizeConfig struct {
+	// Enabled turns automatic resizing on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinSize and MaxSize bound the pool size.
+	MinSize int `mapstructure:"min_size"`
+	MaxSize int `mapstructure:"max_size"`
+
+	// WaitThreshold is the average wait for a connection above which a pool
+	// is under pressure.
+	WaitThreshold time.Duration `mapstructure:"wait_threshold"`
+
+	// SustainedIntervals is the number of consecutive stats intervals a
+	// condition must hold before the pool is resized.
+	SustainedIntervals int `mapstructure:"sustained_intervals"`
+}
+
+// RedisReadFallbackConfig configures the in-memory cache of the subscriptions,
+// tenants, users and roles last read from Redis. While Redis is unavailable,
+// reads are answered from it and marked stale; writes are rejected with 503.
+type RedisReadFallbackConfig struct {
+	// Enabled turns the read fallback on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxStaleness is the age from which cached objects are no longer served.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+
+	// MaxEntries bounds the number of cached objects per store.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// GetPassword retrieves the Redis password from the configured source.
+// Priority order:
+//  1. Environment variable (if PasswordEnvVar is set)
+//  2. File (if PasswordFile is set)
+//  3. Direct password (DEPRECATED, for backward compatibility)
+//
+// Returns empty string if no password is configured (Redis without authentication).
+func (c *RedisConfig) GetPassword() (string, error) {
+	// Priority 1: Environment variable
+	if c.PasswordEnvVar != "" {
+		if pwd := os.Getenv(c.PasswordEnvVar); pwd != "" {
+			return pwd, nil
+		}
+		// Env var specified but not set - this is an error
+		return "", fmt.Errorf("environment variable %s is not set", c.PasswordEnvVar)
+	}
+
+	// Priority 2: Password file
+	if c.PasswordFile != "" {
+		data, err := os.ReadFile(c.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file %s: %w", c.PasswordFile, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	// Priority 3: Direct password (DEPRECATED)
+	return c.Password, nil
+}
+
+// IsUsingDeprecatedSubsystem returns true if the ignored Subsystem field is set.
+// HTTP metrics that used to be named <namespace>_<subsystem>_http_* are named
+// <namespace>_http_* regardless.
+func (c *MetricsConfig) IsUsingDeprecatedSubsystem() bool {
+	return c.Subsystem != ""
+}
+
+// IsUsingDeprecatedPassword returns true if the deprecated direct Password field
+// is being used instead of the recommended PasswordEnvVar or PasswordFile.
+// This method avoids direct access to sensitive password data for deprecation checks.
+func (c *RedisConfig) IsUsingDeprecatedPassword() bool {
+	return c.Password != "" && c.PasswordEnvVar == "" && c.PasswordFile == ""
+}
+
+// IsUsingDeprecatedSentinelPassword returns true if the deprecated direct SentinelPassword
+// field is being used instead of the recommended SentinelPasswordEnvVar or SentinelPasswordFile.
+// This method avoids direct access to sensitive password data for deprecation checks.
+func (c *RedisConfig) IsUsingDeprecatedSentinelPassword() bool {
+	return c.SentinelPassword != "" && c.SentinelPasswordEnvVar == "" && c.SentinelPasswordFile == ""
+}
+
+// GetSentinelPassword retrieves the Sentinel password from the configured source.
+// Priority order:
+//  1. Environment variable (if SentinelPasswordEnvVar is set)
+//  2. File (if SentinelPasswordFile is set)
+//  3. Direct password (DEPRECATED, for backward compatibility)
+//
+// Returns empty string if no password is configured (Sentinel without authentication).
+func (c *RedisConfig) GetSentinelPassword() (string, error) {
+	// Priority 1: Environment variable
+	if c.SentinelPasswordEnvVar != "" {
+		if pwd := os.Getenv(c.SentinelPasswordEnvVar); pwd != "" {
+			return pwd, nil
+		}
+		// Env var specified but not set - this is an error
+		return "", fmt.Errorf("environment variable %s is not set", c.SentinelPasswordEnvVar)
+	}
+
+	// Priority 2: Password file
+	if c.SentinelPasswordFile != "" {
+		data, err := os.ReadFile(c.SentinelPasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read sentinel password file %s: %w", c.SentinelPasswordFile, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	// Priority 3: Direct password (DEPRECATED)
+	return c.SentinelPassword, nil
+}
+
+// KubernetesConfig contains Kubernetes client configuration.
+type KubernetesConfig struct {
+	// ConfigPath is the path to kubeconfig file
+	// Leave empty to use in-cluster config when running in a pod
+	ConfigPath string `mapstructure:"config_path"`
+
+	// Context is the kubeconfig context to use (optional)
+	Context string `mapstructure:"context"`
+
+	// Namespace is the default namespace for operations
+	// Leave empty to use all namespaces
+	Namespace string `mapstructure:"namespace"`
+
+	// QPS is the maximum queries per second to the Kubernetes API
+	QPS float32 `mapstructure:"qps"`
+
+	// Burst is the maximum burst for throttle
+	Burst int `mapstructure:"burst"`
+
+	// Timeout is the timeout for Kubernetes API requests
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// EnableWatch enables Kubernetes watch for real-time updates
+	EnableWatch bool `mapstructure:"enable_watch"`
+
+	// WatchResync is the resync period for watch cache
+	WatchResync time.Duration `mapstructure:"watch_resync"`
+
+	// Hedging sends a second attempt for slow list/get calls to the API server
+	Hedging KubernetesHedgingConfig `mapstructure:"hedging"`
+
+	// ObjectLimits caps the objects created through the gateway per tenant
+	ObjectLimits KubernetesObjectLimitsConfig `mapstructure:"object_limits"`
+}
+
+// KubernetesObjectLimitsConfig caps the number of objects a tenant can create
+// through the gateway. Before each create, the adapter counts the tenant's
+// existing objects and refuses the request with a CapacityExceeded error
+// once the ceiling is reached.
+type KubernetesObjectLimitsConfig struct {
+	// MaxResourcePools caps the namespaces per tenant (0 = no limit)
+	MaxResourcePools int `mapstructure:"max_resource_pools"`
+
+	// MaxResources caps the NetweaveResource objects per tenant (0 = no limit)
+	MaxResources int `mapstructure:"max_resources"`
+
+	// WarnRatio is the share of a ceiling from which creations are reported
+	// as near the limit
+	WarnRatio float64 `mapstructure:"warn_ratio"`
+}
+
+// KubernetesHedgingConfig configures hedged reads against the Kubernetes API
+// server. When a list or get call has not answered within Delay, the same
+// call is sent again and the first response wins, which cuts tail latency
+// caused by a slow API server replica. Reads served from the watch cache are
+// never hedged.
+type KubernetesHedgingConfig struct {
+	// Enabled turns hedging on
+	Enabled bool `mapstructure:"enabled"`
+
+	// Delay is how long a read waits before the second attempt is sent
+	Delay time.Duration `mapstructure:"delay"`
+
+	// MaxInFlight caps the hedge attempts outstanding at once (0 = no cap)
+	MaxInFlight int `mapstructure:"max_in_flight"`
+
+	// BudgetPercent caps hedges as a percentage of reads (0 = no budget)
+	BudgetPercent float64 `mapstructure:"budget_percent"`
+}
+
+// TLSConfig contains TLS/mTLS configuration.
+type TLSConfig struct {
+	// Enabled enables TLS for the HTTP server
+	Enabled bool `mapstructure:"enabled"`
+
+	// CertFile is the path to the TLS certificate file
+	CertFile string `mapstructure:"cert_file"`
+
+	// KeyFile is the path to the TLS private key file
+	KeyFile string `mapstructure:"key_file"`
+
+	// CAFile is the path to the CA certificate file for client verification
+	CAFile string `mapstructure:"ca_file"`
+
+	// ClientAuth specifies the client authentication mode
+	// Options: "none", "request", "require", "verify", "require-and-verify"
+	ClientAuth string `mapstructure:"client_auth"`
+
+	// MinVersion is the minimum TLS version ("1.2", "1.3")
+	MinVersion string `mapstructure:"min_version"`
+
+	// CipherSuites is a list of enabled cipher suites (optional)
+	CipherSuites []string `mapstructure:"cipher_suites"`
+
+	// ReloadInterval is how often the certificate, key and CA files are
+	// checked for changes, e.g. a cert-manager rotation (default: 30s)
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// SPIFFE restricts client certificates to SPIFFE X.509 SVIDs
+	SPIFFE SPIFFEConfig `mapstructure:"spiffe"`
+}
+
+// SPIFFEConfig configures SPIFFE workload identities for mTLS. The gateway
+// does not talk to the Workload API itself: the SPIFFE helper (or the SPIFFE
+// CSI driver) writes the SVID, key and trust bundle to the tls cert_file,
+// key_file and ca_file, which are reloaded when they change.
+type SPIFFEConfig struct {
+	// Enabled accepts only client certificates that are X.509 SVIDs of
+	// TrustDomain
+	Enabled bool `mapstructure:"enabled"`
+
+	// TrustDomain is the trust domain of accepted clients (e.g. "example.org")
+	TrustDomain string `mapstructure:"trust_domain"`
+
+	// AllowedIDs optionally lists the accepted SPIFFE IDs
+	// (e.g. "spiffe://example.org/ns/smo/sa/smo"); empty accepts the whole
+	// trust domain
+	AllowedIDs []string `mapstructure:"allowed_ids"`
+}
+
+// ClientAuthType returns the crypto/tls client authentication mode of
+// ClientAuth.
+func (c *TLSConfig) ClientAuthType() tls.ClientAuthType {
+	switch c.ClientAuth {
+	case tlsClientAuthRequest:
+		return tls.RequestClientCert
+	case tlsClientAuthRequire:
+		return tls.RequireAnyClientCert
+	case tlsClientAuthVerify:
+		return tls.VerifyClientCertIfGiven
+	case tlsClientAuthRequireAndVerify:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
+
+// MinTLSVersion returns the crypto/tls version of MinVersion.
+func (c *TLSConfig) MinTLSVersion() uint16 {
+	if c.MinVersion == "1.2" {
+		return tls.VersionTLS12
+	}
+	return tls.VersionTLS13
+}
+
+// ObservabilityConfig contains logging, metrics, and tracing configuration.
+type ObservabilityConfig struct {
+	Logging         LoggingConfig         `mapstructure:"logging"`
+	Metrics         MetricsConfig         `mapstructure:"metrics"`
+	Tracing         TracingConfig         `mapstructure:"tracing"`
+	VersionAdoption VersionAdoptionConfig `mapstructure:"version_adoption"`
+	DebugTap        DebugTapConfig        `mapstructure:"debug_tap"`
+	Health          HealthConfig          `mapstructure:"health"`
+}
+
+// HealthConfig configures the health and readiness checks.
+type HealthConfig struct {
+	// ProbeInterval is the interval at which a background prober runs the
+	// checks; /health and /ready serve its last results. 0 runs the checks on
+	// every request (default: 10s)
+	ProbeInterval time.Duration `mapstructure:"probe_interval"`
+}
+
+// DebugTapConfig limits the debug taps platform admins can start at runtime
+// to capture the sanitized request and response bodies of matching requests.
+type DebugTapConfig struct {
+	// Enabled allows starting debug taps via /admin/debug/taps (default: true)
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxTaps is the maximum number of taps kept at once (default: 5)
+	MaxTaps int `mapstructure:"max_taps"`
+
+	// MaxDuration is the longest a tap may capture (default: 1h)
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// BufferSize is the number of captures each tap keeps; older captures
+	// are overwritten (default: 100)
+	BufferSize int `mapstructure:"buffer_size"`
+
+	// MaxBodyBytes is how much of each request and response body is
+	// captured (default: 65536)
+	MaxBodyBytes int `mapstructure:"max_body_bytes"`
+}
+
+// VersionAdoptionConfig contains API version adoption reporting configuration.
+type VersionAdoptionConfig struct {
+	// RetentionHours is how many hours of per-version call counts are kept (default: 24)
+	RetentionHours int `mapstructure:"retention_hours"`
+
+	// DeprecatedWarnThreshold is the number of calls per hour to a deprecated API
+	// version above which a warning is logged (default: 1000)
+	DeprecatedWarnThreshold int `mapstructure:"deprecated_warn_threshold"`
+}
+
+// LoggingConfig contains structured logging configuration.
+type LoggingConfig struct {
+	// Level sets the log level ("debug", "info", "warn", "error", "fatal")
+	Level string `mapstructure:"level"`
+
+	// Format sets the log format ("json", "console")
+	Format string `mapstructure:"format"`
+
+	// OutputPaths is a list of output destinations (e.g., ["stdout", "/var/log/app.log"])
+	OutputPaths []string `mapstructure:"output_paths"`
+
+	// ErrorOutputPaths is a list of error output destinations
+	ErrorOutputPaths []string `mapstructure:"error_output_paths"`
+
+	// EnableCaller adds caller information to log entries
+	EnableCaller bool `mapstructure:"enable_caller"`
+
+	// EnableStacktrace adds stacktrace on errors
+	EnableStacktrace bool `mapstructure:"enable_stacktrace"`
+
+	// Development enables development mode (more verbose, console format)
+	Development bool `mapstructure:"development"`
+
+	// Redaction removes secrets from log entries before they reach any output.
+	Redaction LogRedactionConfig `mapstructure:"redaction"`
+}
+
+// LogRedactionConfig configures redaction of sensitive values in logs.
+type LogRedactionConfig struct {
+	// Enabled redacts fields with sensitive names (password, token, secret,
+	// api_key, authorization, ...) and values that look like credentials
+	// (bearer tokens, JWTs, private keys, URL passwords, token query parameters).
+	Enabled bool `mapstructure:"enabled"`
+
+	// Fields lists additional field names whose values are always redacted.
+	Fields []string `mapstructure:"fields"`
+
+	// Patterns lists additional regular expressions whose matches are redacted
+	// from every string value and log message.
+	Patterns []string `mapstructure:"patterns"`
+}
+
+// MetricsConfig contains Prometheus metrics configuration.
+type MetricsConfig struct {
+	// Enabled enables Prometheus metrics collection
+	Enabled bool `mapstructure:"enabled"`
+
+	// Path is the HTTP path for metrics endpoint (default: "/metrics")
+	Path string `mapstructure:"path"`
+
+	// Port is the port for metrics server (0 = use main server port)
+	Port int `mapstructure:"port"`
+
+	// Namespace is the Prometheus metrics namespace
+	Namespace string `mapstructure:"namespace"`
+
+	// Deprecated: Subsystem is ignored; HTTP metrics are named after
+	// Namespace only. It is kept so that existing configuration files still
+	// load, and a warning is logged when it is set (see BREAKING_CHANGES.md).
+	Subsystem string `mapstructure:"subsystem"`
+
+	// EnableGoMetrics enables Go runtime metrics
+	EnableGoMetrics bool `mapstructure:"enable_go_metrics"`
+
+	// EnableProcessMetrics enables process metrics
+	EnableProcessMetrics bool `mapstructure:"enable_process_metrics"`
+
+	// NativeHistograms makes histograms also emit Prometheus native
+	// histograms, scraped over the protobuf format.
+	NativeHistograms bool `mapstructure:"native_histograms"`
+}
+
+// TracingConfig contains distributed tracing configuration.
+type TracingConfig struct {
+	// Enabled enables distributed tracing
+	Enabled bool `mapstructure:"enabled"`
+
+	// Provider specifies the tracing provider ("jaeger", "zipkin", "otlp")
+	Provider string `mapstructure:"provider"`
+
+	// Endpoint is the tracing collector endpoint
+	Endpoint string `mapstructure:"endpoint"`
+
+	// ServiceName is the service name for tracing
+	ServiceName string `mapstructure:"service_name"`
+
+	// SamplingRate is the sampling rate (0.0 to 1.0)
+	SamplingRate float64 `mapstructure:"sampling_rate"`
+
+	// EnableBatching enables batch span export
+	EnableBatching bool `mapstructure:"enable_batching"`
+
+	// BatchTimeout is the timeout for batch export
+	BatchTimeout time.Duration `mapstructure:"batch_timeout"`
+}
+
+// SecurityConfig contains security-related configuration.
+type SecurityConfig struct {
+	// EnableCORS enables CORS support
+	EnableCORS bool `mapstructure:"enable_cors"`
+
+	// AllowedOrigins is a list of allowed CORS origins
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+
+	// AllowedMethods is a list of allowed HTTP methods
+	AllowedMethods []string `mapstructure:"allowed_methods"`
+
+	// AllowedHeaders is a list of allowed HTTP headers
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
+
+	// RateLimitEnabled enables rate limiting
+	RateLimitEnabled bool `mapstructure:"rate_limit_enabled"`
+
+	// RateLimit contains comprehensive rate limiting configuration
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
+	// AllowInsecureCallbacks allows HTTP (non-HTTPS) webhook callbacks
+	// This should ONLY be enabled in development/testing environments
+	// Production deployments MUST enforce HTTPS for webhook callbacks
+	AllowInsecureCallbacks bool `mapstructure:"allow_insecure_callbacks"`
+
+	// DisableSSRFProtection disables SSRF protection for webhook callbacks
+	// This allows callbacks to localhost and private IP addresses
+	// WARNING: This should ONLY be enabled in development/testing environments
+	// Production deployments MUST keep SSRF protection enabled to prevent attacks
+	DisableSSRFProtection bool `mapstructure:"disable_ssrf_protection"`
+
+	// CallbackAllowlist restricts webhook callbacks to matching hosts when
+	// non-empty. Entries are hostnames, "*.example.com" wildcards, IP addresses or CIDRs.
+	CallbackAllowlist []string `mapstructure:"callback_allowlist"`
+
+	// CallbackDenylist rejects webhook callbacks to matching hosts, in the
+	// same format as CallbackAllowlist. It applies even when SSRF protection is disabled.
+	CallbackDenylist []string `mapstructure:"callback_denylist"`
+
+	// CallbackRevalidationInterval is how often stored subscription callbacks
+	// are checked against the current callback policy; violators are suspended.
+	// 0 disables the periodic check.
+	CallbackRevalidationInterval time.Duration `mapstructure:"callback_revalidation_interval"`
+
+	// SecurityHeaders contains configuration for security headers middleware
+	SecurityHeaders SecurityHeadersConfig `mapstructure:"security_headers"`
+}
+
+// SecurityHeadersConfig contains configuration for HTTP security headers.
+type SecurityHeadersConfig struct {
+	// Enabled controls whether security headers are added (default: true)
+	Enabled bool `mapstructure:"enabled"`
+
+	// HSTSMaxAge is the max-age for Strict-Transport-Security header in seconds
+	// Default: 31536000 (1 year)
+	HSTSMaxAge int `mapstructure:"hsts_max_age"`
+
+	// HSTSIncludeSubDomains includes subdomains in HSTS
+	HSTSIncludeSubDomains bool `mapstructure:"hsts_include_subdomains"`
+
+	// HSTSPreload enables HSTS preload
+	HSTSPreload bool `mapstructure:"hsts_preload"`
+
+	// ContentSecurityPolicy is the Content-Security-Policy header value
+	// Default: "default-src 'none'; frame-ancestors 'none'"
+	ContentSecurityPolicy string `mapstructure:"content_security_policy"`
+
+	// FrameOptions is the X-Frame-Options header value
+	// Default: "DENY"
+	FrameOptions string `mapstructure:"frame_options"`
+
+	// ReferrerPolicy is the Referrer-Policy header value
+	// Default: "strict-origin-when-cross-origin"
+	ReferrerPolicy string `mapstructure:"referrer_policy"`
+}
+
+// RateLimitConfig contains comprehensive rate limiting configuration.
+type RateLimitConfig struct {
+	// PerTenant configures per-tenant rate limits
+	PerTenant TenantRateLimitConfig `mapstructure:"tenant"`
+
+	// PerClient configures per-client rate limits
+	PerClient ClientRateLimitConfig `mapstructure:"client"`
+
+	// PerEndpoint configures per-endpoint rate limits
+	PerEndpoint []EndpointRateLimitConfig `mapstructure:"endpoints"`
+
+	// RouteGroups configures rate limits for groups of routes sharing a path prefix
+	RouteGroups []RouteGroupRateLimitConfig `mapstructure:"route_groups"`
+
+	// Global configures global rate limits
+	Global GlobalRateLimitConfig `mapstructure:"global"`
+
+	// PerResource configures per-resource-type rate limits
+	PerResource ResourceRateLimitConfig `mapstructure:"resource"`
+}
+
+// TenantRateLimitConfig configures per-tenant rate limits.
+type TenantRateLimitConfig struct {
+	RequestsPerSecond int `mapstructure:"requests_per_second"`
+	BurstSize         int `mapstructure:"burst_size"`
+}
+
+// ClientRateLimitConfig configures per-client rate limits. A client is the
+// authenticated user, the client certificate subject or the client IP.
+type ClientRateLimitConfig struct {
+	RequestsPerSecond int `mapstructure:"requests_per_second"`
+	BurstSize         int `mapstructure:"burst_size"`
+}
+
+// RouteGroupRateLimitConfig configures per-tenant rate limits for the routes
+// under a path prefix.
+type RouteGroupRateLimitConfig struct {
+	Name              string `mapstructure:"name"`
+	PathPrefix        string `mapstructure:"path_prefix"`
+	RequestsPerSecond int    `mapstructure:"requests_per_second"`
+	BurstSize         int    `mapstructure:"burst_size"`
+}
+
+// EndpointRateLimitConfig configures rate limits for specific endpoints.
+type EndpointRateLimitConfig struct {
+	Path              string `mapstructure:"path"`
+	Method            string `mapstructure:"method"`
+	RequestsPerSecond int    `mapstructure:"requests_per_second"`
+	BurstSize         int    `mapstructure:"burst_size"`
+}
+
+// GlobalRateLimitConfig configures global rate limits.
+type GlobalRateLimitConfig struct {
+	RequestsPerSecond     int `mapstructure:"requests_per_second"`
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+}
+
+// ResourceRateLimitConfig contains resource-type-specific rate limiting configuration.
+type ResourceRateLimitConfig struct {
+	// Enabled controls whether resource rate limiting is active
+	Enabled bool `mapstructure:"enabled"`
+
+	// DeploymentManagers configures limits for deployment manager operations
+	DeploymentManagers ResourceTypeLimitConfig `mapstructure:"deployment_managers"`
+
+	// ResourcePools configures limits for resource pool operations
+	ResourcePools ResourceTypeLimitConfig `mapstructure:"resource_pools"`
+
+	// Resources configures limits for individual resource operations
+	Resources ResourceTypeLimitConfig `mapstructure:"resources"`
+
+	// ResourceTypes configures limits for resource type operations
+	ResourceTypes ResourceTypeLimitConfig `mapstructure:"resource_types"`
+
+	// Subscriptions configures limits for subscription operations
+	Subscriptions SubscriptionRateLimitConfig `mapstructure:"subscriptions"`
+}
+
+// ResourceTypeLimitConfig defines rate limits for a resource type.
+type ResourceTypeLimitConfig struct {
+	// ReadsPerMinute limits read operations per minute
+	ReadsPerMinute int `mapstructure:"reads_per_minute"`
+
+	// WritesPerMinute limits write operations per minute
+	WritesPerMinute int `mapstructure:"writes_per_minute"`
+
+	// ListPageSizeMax limits the maximum page size for list operations
+	ListPageSizeMax int `mapstructure:"list_page_size_max"`
+}
+
+// SubscriptionRateLimitConfig defines rate limits specific to subscriptions.
+type SubscriptionRateLimitConfig struct {
+	// CreatesPerHour limits subscription creations per hour
+	CreatesPerHour int `mapstructure:"creates_per_hour"`
+
+	// MaxActive limits the maximum number of active subscriptions per tenant
+	MaxActive int `mapstructure:"max_active"`
+
+	// ReadsPerMinute limits read operations per minute
+	ReadsPerMinute int `mapstructure:"reads_per_minute"`
+}
+
+// ValidationConfig contains OpenAPI request/response validation configuration.
+type ValidationConfig struct {
+	// Enabled enables OpenAPI request validation
+	Enabled bool `mapstructure:"enabled"`
+
+	// ValidateResponse enables OpenAPI response validation (use only in development/testing)
+	ValidateResponse bool `mapstructure:"validate_response"`
+
+	// SpecPath is the path to a custom OpenAPI specification file
+	// If empty, the embedded spec will be used
+	SpecPath string `mapstructure:"spec_path"`
+
+	// MaxBodySize is the maximum allowed request body size in bytes
+	// Requests exceeding this limit are rejected with 413 Payload Too Large
+	// Default: 1048576 (1MB)
+	MaxBodySize int64 `mapstructure:"max_body_size"`
+
+	// StrictSpec enforces the exact O-RAN O2-IMS attribute set.
+	StrictSpec StrictSpecConfig `mapstructure:"strict_spec"`
+
+	// Rules are operator-defined validation rules checked when resource pools,
+	// resources and NF deployments are created or updated.
+	Rules []ValidationRuleConfig `mapstructure:"rules"`
+}
+
+// Validation rule types for ValidationRuleConfig.Type.
+const (
+	ValidationRuleTypeField  = "field"
+	ValidationRuleTypePlugin = "plugin"
+)
+
+// ValidationRuleConfig configures a validation rule.
+type ValidationRuleConfig struct {
+	// Name identifies the rule in violations, logs and metrics. Names must be
+	// unique.
+	Name string `mapstructure:"name"`
+
+	// Type is "field" (built-in check of a single request field) or "plugin"
+	// (in-process Go validator registered under Plugin).
+	Type string `mapstructure:"type"`
+
+	// Plugin is the registered plugin name for plugin rules.
+	Plugin string `mapstructure:"plugin"`
+
+	// Kinds restricts the rule to object kinds (resourcePool, resource,
+	// nfDeployment). Empty matches all kinds.
+	Kinds []string `mapstructure:"kinds"`
+
+	// Operations restricts the rule to "create" and/or "update". Empty matches both.
+	Operations []string `mapstructure:"operations"`
+
+	// Field is the dot-separated path of the checked request field for field
+	// rules (e.g. "extensions.siteCode").
+	Field string `mapstructure:"field"`
+
+	// Required rejects requests without the field.
+	Required bool `mapstructure:"required"`
+
+	// Pattern is a regular expression the whole field value must match.
+	Pattern string `mapstructure:"pattern"`
+
+	// Values lists the allowed field values.
+	Values []string `mapstructure:"values"`
+
+	// MaxLength limits the length of the field value in characters.
+	MaxLength int `mapstructure:"max_length"`
+
+	// Message replaces the generated violation message.
+	Message string `mapstructure:"message"`
+
+	// Timeout bounds each check. Zero uses the default of 2s.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// FailurePolicy is "reject" (default) or "ignore" and controls requests
+	// whose rule could not run, such as a plugin returning an error.
+	FailurePolicy string `mapstructure:"failure_policy"`
+}
+
+// Object kinds checked in strict spec mode.
+const (
+	StrictSpecKindOCloud            = "oCloud"
+	StrictSpecKindDeploymentManager = "deploymentManager"
+	StrictSpecKindResourcePool      = "resourcePool"
+	StrictSpecKindResource          = "resource"
+	StrictSpecKindResourceType      = "resourceType"
+	StrictSpecKindSubscription      = "subscription"
+)
+
+// StrictSpecConfig configures the strict O-RAN spec mode, for SMO interop labs
+// that require the exact O2-IMS attribute set. Requests lacking attributes the
+// specification requires are rejected, and responses get the mandated
+// attributes: missing ones with a defined default are filled in, and the
+// others are logged as compliance errors.
+type StrictSpecConfig struct {
+	// Enabled turns on the strict spec mode.
+	Enabled bool `mapstructure:"enabled"`
+
+	// OCloudID fills the oCloudId attribute of responses that lack it.
+	// Empty leaves missing oCloudIds as compliance errors.
+	OCloudID string `mapstructure:"ocloud_id"`
+
+	// GlobalCloudID is the globalCloudId, a UUID assigned by the SMO, of the
+	// O-Cloud. Required in strict spec mode.
+	GlobalCloudID string `mapstructure:"global_cloud_id"`
+
+	// RequiredExtensions lists, per object kind (oCloud, deploymentManager,
+	// resourcePool, resource, resourceType), the extension keys every object
+	// must carry. Missing keys are logged as compliance errors.
+	RequiredExtensions map[string][]string `mapstructure:"required_extensions"`
+}
+
+// Load loads configuration from the specified file path and environment variables.
+// Environment variables override file values and should be prefixed with NETWEAVE_
+// (e.g., NETWEAVE_SERVER_PORT=8080).
+//
+// Configuration file resolution order:
+//  1. Explicit configPath parameter (if provided)
+//  2. NETWEAVE_CONFIG environment variable
+//  3. Environment-specific config via NETWEAVE_ENV (dev/staging/prod)
+//  4. Default: config/config.yaml
+//
+// Returns an error if the configuration file cannot be read or parsed.
+//
+// Example:
+//
+//	cfg, err := config.Load("config/config.yaml")
+//	if err != nil {
+//	    return fmt.Errorf("failed to load config: %w", err)
+//	}
+func Load(configPath string) (*Config, error) {
+	v := viper.New()
+
+	// Set configuration file with environment detection
+	configPath = resolveConfigPath(configPath)
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+	} else {
+		// Default configuration file locations
+		v.SetConfigName("config")
+		v.SetConfigType("yaml")
+		v.AddConfigPath("./config")
+		v.AddConfigPath(".")
+		v.AddConfigPath("/etc/netweave")
+	}
+
+	// Enable environment variable overrides
+	v.SetEnvPrefix("NETWEAVE")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	// Set defaults
+	setDefaults(v)
+
+	// Read configuration file
+	if err := v.ReadInConfig(); err != nil {
+		// Config file is optional if all values come from env vars
+		var configFileNotFoundError viper.ConfigFileNotFoundError
+		if !errors.As(err, &configFileNotFoundError) && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	// Unmarshal configuration
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// Set environment from NETWEAVE_ENV or detect from config path
+	cfg.Environment = detectEnvironment(configPath)
+
+	return &cfg, nil
+}
+
+// resolveConfigPath determines the configuration file path to use.
+// Priority order:
+//  1. Explicit configPath parameter (if non-empty)
+//  2. NETWEAVE_CONFIG environment variable
 //  3. Environment-specific config via NETWEAVE_ENV (dev/staging/prod)
 //  4. Empty string (use viper defaults)
 //
-// Security: All paths are validated to prevent directory traversal and arbitrary file inclusion.
-func resolveConfigPath(configPath string) string {
-	// Priority 1: Explicit path provided
-	if configPath != "" && configPath != DefaultConfigPath {
-		if err := validateConfigPath(configPath); err != nil {
-			// Log error but don't expose path details in error message
-			// Return empty to fall back to defaults
-			return ""
+// Security: All paths are validated to prevent directory traversal and arbitrary file inclusion.
+func resolveConfigPath(configPath string) string {
+	// Priority 1: Explicit path provided
+	if configPath != "" && configPath != DefaultConfigPath {
+		if err := validateConfigPath(configPath); err != nil {
+			// Log error but don't expose path details in error message
+			// Return empty to fall back to defaults
+			return ""
+		}
+		return configPath
+	}
+
+	// Priority 2: NETWEAVE_CONFIG environment variable
+	if envConfig := os.Getenv("NETWEAVE_CONFIG"); envConfig != "" {
+		if err := validateConfigPath(envConfig); err != nil {
+			// Security: Don't load arbitrary files from environment variables
+			// Return empty to fall back to defaults
+			return ""
+		}
+		return envConfig
+	}
+
+	// Priority 3: Environment-specific config via NETWEAVE_ENV
+	if env := os.Getenv("NETWEAVE_ENV"); env != "" {
+		envConfigPath := fmt.Sprintf("config/config.%s.yaml", env)
+		// Check if environment-specific config exists
+		if _, err := os.Stat(envConfigPath); err == nil {
+			return envConfigPath
+		}
+	}
+
+	// Priority 4: Use default or empty (viper will search default paths)
+	return ""
+}
+
+// validateConfigPath validates that a configuration file path is safe to load.
+// It prevents directory traversal attacks and arbitrary file inclusion.
+// Security checks:
+//   - Must have .yaml or .yml extension (prevents loading arbitrary files)
+//   - Path must not contain directory traversal (../../../etc/passwd)
+//   - For production safety, relative paths should be in ./config/ or current dir
+func validateConfigPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("config path is empty")
+	}
+
+	// Validate file extension
+	if err := validateConfigExtension(path); err != nil {
+		return err
+	}
+
+	// Check for directory traversal
+	if err := validateNoTraversal(path); err != nil {
+		return err
+	}
+
+	// Clean and validate normalized path
+	cleanPath := filepath.Clean(path)
+	if strings.Contains(cleanPath, "..") {
+		return fmt.Errorf("config path contains directory traversal after normalization")
+	}
+
+	// Validate absolute paths
+	if filepath.IsAbs(cleanPath) {
+		return validateAbsolutePath(cleanPath)
+	}
+
+	// Relative paths are allowed
+	return nil
+}
+
+// validateConfigExtension ensures the config file has a valid extension.
+func validateConfigExtension(path string) error {
+	ext := filepath.Ext(path)
+	if ext != ".yaml" && ext != ".yml" {
+		return fmt.Errorf("config file must have .yaml or .yml extension")
+	}
+	return nil
+}
+
+// validateNoTraversal checks for directory traversal attempts.
+func validateNoTraversal(path string) error {
+	if strings.Contains(path, "..") {
+		return fmt.Errorf("config path contains directory traversal")
+	}
+	return nil
+}
+
+// validateAbsolutePath validates absolute paths and checks for symlinks.
+func validateAbsolutePath(cleanPath string) error {
+	// For absolute paths, check if it exists and validate if it's a symlink
+	info, err := os.Lstat(cleanPath)
+	if err != nil {
+		// Path doesn't exist yet - this is valid for config files that will be created
+		// We've already validated the extension, so just return
+		if os.IsNotExist(err) {
+			return nil
+		}
+		// Other errors (like permission denied) should be reported
+		return fmt.Errorf("cannot access config path: %w", err)
+	}
+
+	// If it's a symlink, validate the target
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := filepath.EvalSymlinks(cleanPath)
+		if err != nil {
+			return fmt.Errorf("cannot resolve symlink: %w", err)
+		}
+		// Ensure symlink target also has valid extension
+		return validateConfigExtension(target)
+	}
+
+	return nil
+}
+
+// detectEnvironment determines the environment from the config path or NETWEAVE_ENV.
+func detectEnvironment(configPath string) string {
+	// Check NETWEAVE_ENV first
+	if env := os.Getenv("NETWEAVE_ENV"); env != "" {
+		return env
+	}
+
+	// Detect from config path
+	switch {
+	case strings.Contains(configPath, ".dev."):
+		return EnvDevelopment
+	case strings.Contains(configPath, ".staging."):
+		return EnvStaging
+	case strings.Contains(configPath, ".prod."):
+		return EnvProduction
+	default:
+		return EnvDevelopment // Default to dev
+	}
+}
+
+// setDefaults sets default values for all configuration options.
+func setDefaults(v *viper.Viper) {
+	// Server defaults
+	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.port", 8080)
+	v.SetDefault("server.read_timeout", "30s")
+	v.SetDefault("server.write_timeout", "30s")
+	v.SetDefault("server.idle_timeout", "120s")
+	v.SetDefault("server.shutdown_timeout", "30s")
+	v.SetDefault("server.stream_drain_timeout", "60s")
+	v.SetDefault("server.stream_reconnect_delay", "2s")
+	v.SetDefault("server.read_cache_ttl", "30s")
+	v.SetDefault("server.list_snapshot_ttl", "5m")
+	v.SetDefault("server.list_extensions_max_bytes", 0)
+	v.SetDefault("server.list_extensions_mode", ListExtensionsTruncate)
+	v.SetDefault("server.request_timeout", "0s")
+	v.SetDefault("server.max_header_bytes", 1048576) // 1MB
+	v.SetDefault("server.gin_mode", "release")
+	v.SetDefault("server.trusted_proxies", []string{})
+	v.SetDefault("server.remote_ip_headers", []string{"X-Forwarded-For", "X-Real-IP"})
+	v.SetDefault("server.mode", GatewayModeIMSAndDMS)
+
+	// Redis defaults
+	v.SetDefault("redis.mode", "standalone")
+	v.SetDefault("redis.addresses", []string{"localhost:6379"})
+	v.SetDefault("redis.db", 0)
+	v.SetDefault("redis.pool_size", 10)
+	v.SetDefault("redis.min_idle_conns", 5)
+	v.SetDefault("redis.max_retries", 3)
+	v.SetDefault("redis.dial_timeout", "5s")
+	v.SetDefault("redis.read_timeout", "3s")
+	v.SetDefault("redis.write_timeout", "3s")
+	v.SetDefault("redis.pool_timeout", "4s")
+	v.SetDefault("redis.idle_timeout", "5m")
+	v.SetDefault("redis.enable_tls", false)
+	v.SetDefault("redis.tls_insecure_skip_verify", false)
+	v.SetDefault("redis.read_fallback.enabled", true)
+	v.SetDefault("redis.read_fallback.max_staleness", "5m")
+	v.SetDefault("redis.read_fallback.max_entries", 10000)
+	v.SetDefault("redis.pool_stats_interval", "10s")
+	v.SetDefault("redis.pool_auto_resize.enabled", false)
+	v.SetDefault("redis.pool_auto_resize.min_size", 10)
+	v.SetDefault("redis.pool_auto_resize.max_size", 100)
+	v.SetDefault("redis.pool_auto_resize.wait_threshold", "5ms")
+	v.SetDefault("redis.pool_auto_resize.sustained_intervals", 3)
+
+	// Kubernetes defaults
+	v.SetDefault("kubernetes.config_path", "") // Use in-cluster config
+	v.SetDefault("kubernetes.qps", 50.0)
+	v.SetDefault("kubernetes.burst", 100)
+	v.SetDefault("kubernetes.timeout", "30s")
+	v.SetDefault("kubernetes.enable_watch", true)
+	v.SetDefault("kubernetes.watch_resync", "10m")
+	v.SetDefault("kubernetes.hedging.enabled", false)
+	v.SetDefault("kubernetes.hedging.delay", "50ms")
+	v.SetDefault("kubernetes.hedging.max_in_flight", 10)
+	v.SetDefault("kubernetes.hedging.budget_percent", 10.0)
+	v.SetDefault("kubernetes.object_limits.max_resource_pools", 0)
+	v.SetDefault("kubernetes.object_limits.max_resources", 0)
+	v.SetDefault("kubernetes.object_limits.warn_ratio", 0.8)
+
+	// TLS defaults
+	v.SetDefault("tls.enabled", false)
+	v.SetDefault("tls.client_auth", "none")
+	v.SetDefault("tls.min_version", "1.3")
+	v.SetDefault("tls.reload_interval", "30s")
+	v.SetDefault("tls.spiffe.enabled", false)
+	v.SetDefault("tls.spiffe.trust_domain", "")
+	v.SetDefault("tls.spiffe.allowed_ids", []string{})
+
+	// Configuration reload defaults
+	v.SetDefault("reload.watch_interval", "10s")
+
+	// Logging defaults
+	v.SetDefault("observability.logging.level", "info")
+	v.SetDefault("observability.logging.format", "json")
+	v.SetDefault("observability.logging.output_paths", []string{"stdout"})
+	v.SetDefault("observability.logging.error_output_paths", []string{"stderr"})
+	v.SetDefault("observability.logging.enable_caller", true)
+	v.SetDefault("observability.logging.enable_stacktrace", false)
+	v.SetDefault("observability.logging.development", false)
+	v.SetDefault("observability.logging.redaction.enabled", true)
+
+	// Metrics defaults
+	v.SetDefault("observability.metrics.enabled", true)
+	v.SetDefault("observability.metrics.path", "/metrics")
+	v.SetDefault("observability.metrics.port", 0) // Use main server port
+	v.SetDefault("observability.metrics.namespace", "netweave")
+	v.SetDefault("observability.metrics.enable_go_metrics", true)
+	v.SetDefault("observability.metrics.enable_process_metrics", true)
+	v.SetDefault("observability.metrics.native_histograms", false)
+
+	// Tracing defaults
+	v.SetDefault("observability.tracing.enabled", false)
+	v.SetDefault("observability.tracing.provider", "otlp")
+	v.SetDefault("observability.tracing.service_name", "netweave-gateway")
+	v.SetDefault("observability.tracing.sampling_rate", 0.1)
+	v.SetDefault("observability.tracing.enable_batching", true)
+	v.SetDefault("observability.tracing.batch_timeout", "5s")
+
+	// Version adoption defaults
+	v.SetDefault("observability.version_adoption.retention_hours", 24)
+	v.SetDefault("observability.version_adoption.deprecated_warn_threshold", 1000)
+	v.SetDefault("observability.debug_tap.enabled", true)
+	v.SetDefault("observability.debug_tap.max_taps", 5)
+	v.SetDefault("observability.debug_tap.max_duration", "1h")
+	v.SetDefault("observability.debug_tap.buffer_size", 100)
+	v.SetDefault("observability.debug_tap.max_body_bytes", 65536)
+	v.SetDefault("observability.health.probe_interval", "10s")
+
+	// Security defaults
+	v.SetDefault("security.enable_cors", false)
+	v.SetDefault("security.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE"})
+	v.SetDefault("security.rate_limit_enabled", true)
+	v.SetDefault("security.rate_limit.tenant.requests_per_second", 1000)
+	v.SetDefault("security.rate_limit.tenant.burst_size", 2000)
+	v.SetDefault("security.rate_limit.global.requests_per_second", 10000)
+	v.SetDefault("security.rate_limit.global.max_concurrent_requests", 1000)
+	v.SetDefault("security.allow_insecure_callbacks", false)
+	v.SetDefault("security.callback_revalidation_interval", "1h")
+
+	// Validation defaults
+	v.SetDefault("validation.enabled", true)
+	v.SetDefault("validation.validate_response", false)
+	v.SetDefault("validation.spec_path", "")
+	v.SetDefault("validation.max_body_size", 1048576) // 1MB default
+	v.SetDefault("validation.strict_spec.enabled", false)
+	v.SetDefault("validation.strict_spec.ocloud_id", "")
+	v.SetDefault("validation.strict_spec.global_cloud_id", "")
+
+	// Rollout defaults
+	v.SetDefault("rollout.percent", 10)
+	v.SetDefault("rollout.bake_time", "5m")
+	v.SetDefault("rollout.min_requests", 100)
+	v.SetDefault("rollout.max_error_rate_increase", 0.05)
+
+	// DMS defaults
+	v.SetDefault("dms.storage.backend", DMSStorageMemory)
+	v.SetDefault("dms.storage.namespace", "")
+	v.SetDefault("dms.notifications.enabled", true)
+	v.SetDefault("dms.notifications.interval", "30s")
+	v.SetDefault("dms.notifications.timeout", "10s")
+	v.SetDefault("dms.notifications.max_retries", 3)
+	v.SetDefault("dms.notifications.retry_backoff", "1s")
+	v.SetDefault("dms.notifications.history", 100)
+	v.SetDefault("dms.jobs.enabled", true)
+	v.SetDefault("dms.jobs.workers", 4)
+	v.SetDefault("dms.jobs.queue_size", 1000)
+	v.SetDefault("dms.jobs.lease_timeout", "2m")
+	v.SetDefault("dms.jobs.retention", "24h")
+	v.SetDefault("dms.helm.repository_url", "")
+	v.SetDefault("dms.registrations.sync_interval", "30s")
+
+	// Startup check defaults
+	v.SetDefault("startup_checks.mode", StartupChecksLenient)
+	v.SetDefault("startup_checks.timeout", "15s")
+
+	// Adapter cache defaults
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.backend", CacheBackendMemory)
+	v.SetDefault("cache.max_entries", 1000)
+	v.SetDefault("cache.ttl.resource_pools", "60s")
+	v.SetDefault("cache.ttl.resources", "30s")
+	v.SetDefault("cache.ttl.resource_types", "300s")
+
+	// Adapter circuit breaker defaults
+	v.SetDefault("circuit_breaker.enabled", true)
+	v.SetDefault("circuit_breaker.failure_threshold", 5)
+	v.SetDefault("circuit_breaker.open_timeout", "30s")
+	v.SetDefault("circuit_breaker.half_open_requests", 1)
+
+	// Inventory export defaults
+	v.SetDefault("export.enabled", false)
+	v.SetDefault("export.interval", "24h")
+	v.SetDefault("export.format", ExportFormatParquet)
+	v.SetDefault("export.kinds", []string{ExportKindResources, ExportKindResourcePools})
+	v.SetDefault("export.object_store.prefix", "netweave/inventory")
+
+	// Compliance self-check defaults
+	v.SetDefault("compliance.enabled", false)
+	v.SetDefault("compliance.interval", "24h")
+	v.SetDefault("compliance.check_timeout", "10s")
+
+	// DNS resolver defaults
+	v.SetDefault("dns.cache_ttl", "30s")
+	v.SetDefault("dns.negative_ttl", "5s")
+	v.SetDefault("dns.max_entries", 1000)
+	v.SetDefault("dns.timeout", "5s")
+
+	// Infrastructure provisioning defaults
+	v.SetDefault("provisioning.enabled", false)
+	v.SetDefault("provisioning.default_provisioner", ProvisionerSimulator)
+	v.SetDefault("provisioning.reconcile_interval", "10s")
+	v.SetDefault("provisioning.simulator.delay", "30s")
+	v.SetDefault("provisioning.capi.enabled", false)
+	v.SetDefault("provisioning.capi.namespace", "default")
+	v.SetDefault("provisioning.notifications.timeout", "10s")
+	v.SetDefault("provisioning.notifications.max_retries", 3)
+
+	// Pricing defaults
+	v.SetDefault("pricing.enabled", false)
+	v.SetDefault("pricing.currency", "USD")
+	v.SetDefault("pricing.vcpu_hour", 0.0)
+	v.SetDefault("pricing.memory_gb_hour", 0.0)
+	v.SetDefault("pricing.gpu_hour", 0.0)
+
+	// Subscription defaults
+	v.SetDefault("subscriptions.duplicate_policy", SubscriptionDuplicatesAllow)
+	v.SetDefault("subscriptions.expiry_check_interval", "1m")
+
+	// Notification defaults
+	v.SetDefault("notifications.enabled", true)
+	v.SetDefault("notifications.workers", 10)
+	v.SetDefault("notifications.timeout", "10s")
+	v.SetDefault("notifications.max_retries", 3)
+	v.SetDefault("notifications.retry_backoff", "1s")
+	v.SetDefault("notifications.max_backoff", "5m")
+	v.SetDefault("notifications.ordering_timeout", "2m")
+	v.SetDefault("notifications.hmac_secret", "")
+	v.SetDefault("notifications.busy_events_per_minute", 600)
+	v.SetDefault("notifications.deliverability_window", "1h")
+	v.SetDefault("notifications.digest_check_interval", "1m")
+	v.SetDefault("notifications.queue_snapshot_path", "")
+	v.SetDefault("notifications.autoscale.enabled", false)
+	v.SetDefault("notifications.autoscale.min_workers", 1)
+	v.SetDefault("notifications.autoscale.max_workers", 50)
+	v.SetDefault("notifications.autoscale.target_backlog_per_worker", 100)
+	v.SetDefault("notifications.autoscale.target_latency", "2s")
+	v.SetDefault("notifications.autoscale.interval", "15s")
+	v.SetDefault("notifications.autoscale.scale_down_cooldown", "2m")
+	v.SetDefault("notifications.autoscale.claim_idle", "10m")
+	v.SetDefault("notifications.ca.enabled", false)
+	v.SetDefault("notifications.ca.cert_validity", "2160h")
+
+	// Multi-tenancy defaults
+	v.SetDefault("multi_tenancy.enabled", false)
+	v.SetDefault("multi_tenancy.require_mtls", true)
+	v.SetDefault("multi_tenancy.initialize_default_roles", true)
+	v.SetDefault("multi_tenancy.audit_log_retention_days", 30)
+	v.SetDefault("multi_tenancy.auth_policies", []map[string]interface{}{
+		{
+			"paths": []string{"/health", "/healthz", "/ready", "/readyz", "/metrics", "/", "/o2ims"},
+			"level": AuthLevelAnonymous,
+		},
+	})
+	v.SetDefault("multi_tenancy.default_tenant_quota.max_subscriptions", 100)
+	v.SetDefault("multi_tenancy.default_tenant_quota.max_resource_pools", 50)
+	v.SetDefault("multi_tenancy.default_tenant_quota.max_deployments", 200)
+	v.SetDefault("multi_tenancy.default_tenant_quota.max_users", 20)
+	v.SetDefault("multi_tenancy.default_tenant_quota.max_requests_per_minute", 1000)
+}
+
+// Validate validates the configuration and returns an error if any values are invalid.
+// This should be called after Load() to ensure the configuration is valid before use.
+// It enforces environment-specific requirements for production and staging environments.
+//
+// Example:
+//
+//	cfg, err := config.Load("config/config.yaml")
+//	if err != nil {
+//	    return err
+//	}
+//	if err := cfg.Validate(); err != nil {
+//	    return fmt.Errorf("invalid configuration: %w", err)
+//	}
+func (c *Config) Validate() error {
+	if err := c.validateServer(); err != nil {
+		return err
+	}
+
+	if err := c.validateRedis(); err != nil {
+		return err
+	}
+
+	if err := c.validateTLS(); err != nil {
+		return err
+	}
+
+	if err := c.validateKubernetesHedging(); err != nil {
+		return err
+	}
+
+	if err := c.validateKubernetesObjectLimits(); err != nil {
+		return err
+	}
+
+	if err := c.validateObservability(); err != nil {
+		return err
+	}
+
+	if err := c.validateSecurity(); err != nil {
+		return err
+	}
+
+	if err := c.validateAuthPolicies(); err != nil {
+		return err
+	}
+
+	if err := c.validateIdentityMappings(); err != nil {
+		return err
+	}
+
+	if err := c.validateHooks(); err != nil {
+		return err
+	}
+
+	if err := c.validateRollout(); err != nil {
+		return err
+	}
+
+	if err := c.validateDMS(); err != nil {
+		return err
+	}
+
+	if err := c.validatePricing(); err != nil {
+		return err
+	}
+
+	if err := c.validateSubscriptions(); err != nil {
+		return err
+	}
+
+	if err := c.validateNotifications(); err != nil {
+		return err
+	}
+
+	if err := c.validateStartupChecks(); err != nil {
+		return err
+	}
+
+	if err := c.validateCache(); err != nil {
+		return err
+	}
+
+	if err := c.validateCircuitBreaker(); err != nil {
+		return err
+	}
+
+	if err := c.validateExport(); err != nil {
+		return err
+	}
+
+	if err := c.validateCompliance(); err != nil {
+		return err
+	}
+
+	if err := c.validateDNS(); err != nil {
+		return err
+	}
+
+	if err := c.validateProvisioning(); err != nil {
+		return err
+	}
+
+	if c.Reload.WatchInterval < 0 {
+		return fmt.Errorf("reload watch_interval cannot be negative, got %s", c.Reload.WatchInterval)
+	}
+
+	if err := c.validateStrictSpec(); err != nil {
+		return err
+	}
+
+	if err := c.validateValidationRules(); err != nil {
+		return err
+	}
+
+	if err := c.validateEnvironmentRules(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateEnvironmentRules enforces environment-specific configuration requirements.
+func (c *Config) validateEnvironmentRules() error {
+	switch c.Environment {
+	case EnvProduction:
+		return c.validateProductionRules()
+	case EnvStaging:
+		return c.validateStagingRules()
+	case EnvDevelopment:
+		// Development has no strict requirements
+		return nil
+	default:
+		// Unknown environment, treat as development
+		return nil
+	}
+}
+
+// validateProductionRules enforces strict requirements for production.
+func (c *Config) validateProductionRules() error {
+	// TLS/mTLS must be enabled
+	if !c.TLS.Enabled {
+		return fmt.Errorf("TLS must be enabled in production (environment: %s)", c.Environment)
+	}
+	if c.TLS.ClientAuth != tlsClientAuthRequireAndVerify {
+		return fmt.Errorf(
+			"mTLS with require-and-verify must be enabled in production (got: %s)",
+			c.TLS.ClientAuth,
+		)
+	}
+
+	// Rate limiting must be enabled
+	if !c.Security.RateLimitEnabled {
+		return fmt.Errorf("rate limiting must be enabled in production")
+	}
+
+	// Logging must not be in development mode
+	if c.Observability.Logging.Development {
+		return fmt.Errorf("logging development mode must be disabled in production")
+	}
+	if c.Observability.Logging.Level == "debug" {
+		return fmt.Errorf("debug logging level is not recommended for production")
+	}
+
+	// Response validation should be disabled for performance
+	if c.Validation.ValidateResponse {
+		return fmt.Errorf("response validation should be disabled in production for performance")
+	}
+
+	// CORS should typically be disabled or restricted
+	if c.Security.EnableCORS && len(c.Security.AllowedOrigins) == 0 {
+		return fmt.Errorf("CORS enabled in production but allowed_origins is empty")
+	}
+
+	return nil
+}
+
+// validateStagingRules enforces requirements for staging.
+func (c *Config) validateStagingRules() error {
+	// TLS should be enabled
+	if !c.TLS.Enabled {
+		return fmt.Errorf("TLS should be enabled in staging (environment: %s)", c.Environment)
+	}
+
+	// Rate limiting should be enabled
+	if !c.Security.RateLimitEnabled {
+		return fmt.Errorf("rate limiting should be enabled in staging")
+	}
+
+	return nil
+}
+
+// validateServer validates the server configuration.
+func (c *Config) validateServer() error {
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		return fmt.Errorf("invalid server port: %d (must be 1-65535)", c.Server.Port)
+	}
+
+	if c.Server.GinMode != "debug" && c.Server.GinMode != "release" && c.Server.GinMode != "test" {
+		return fmt.Errorf("invalid gin_mode: %s (must be debug, release, or test)", c.Server.GinMode)
+	}
+
+	if c.Server.StreamDrainTimeout < 0 {
+		return fmt.Errorf("stream_drain_timeout cannot be negative")
+	}
+	if c.Server.StreamReconnectDelay < 0 {
+		return fmt.Errorf("stream_reconnect_delay cannot be negative")
+	}
+	if c.Server.ReadCacheTTL < 0 {
+		return fmt.Errorf("read_cache_ttl cannot be negative")
+	}
+	if c.Server.ListSnapshotTTL < 0 {
+		return fmt.Errorf("list_snapshot_ttl cannot be negative")
+	}
+	if c.Server.ListExtensionsMaxBytes < 0 {
+		return fmt.Errorf("list_extensions_max_bytes cannot be negative")
+	}
+	switch c.Server.ListExtensionsMode {
+	case "", ListExtensionsTruncate, ListExtensionsOmit:
+	default:
+		return fmt.Errorf("invalid list_extensions_mode %q (must be %s or %s)",
+			c.Server.ListExtensionsMode, ListExtensionsTruncate, ListExtensionsOmit)
+	}
+	if c.Server.RequestTimeout < 0 {
+		return fmt.Errorf("request_timeout cannot be negative")
+	}
+
+	switch c.Server.Mode {
+	case "", GatewayModeIMSAndDMS, GatewayModeDMSOnly, GatewayModeSimulator:
+	default:
+		return fmt.Errorf("invalid server.mode %q (must be one of %s, %s, %s)",
+			c.Server.Mode, GatewayModeIMSAndDMS, GatewayModeDMSOnly, GatewayModeSimulator)
+	}
+
+	return c.validateTrustedProxies()
+}
+
+// validateTrustedProxies validates that each trusted proxy entry is an IP address or CIDR.
+func (c *Config) validateTrustedProxies() error {
+	for i, proxy := range c.Server.TrustedProxies {
+		if strings.Contains(proxy, "/") {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return fmt.Errorf("invalid trusted_proxies[%d]: %s (must be an IP address or CIDR)", i, proxy)
+			}
+			continue
+		}
+		if net.ParseIP(proxy) == nil {
+			return fmt.Errorf("invalid trusted_proxies[%d]: %s (must be an IP address or CIDR)", i, proxy)
 		}
-		return configPath
 	}
 
-	// Priority 2: NETWEAVE_CONFIG environment variable
-	if envConfig := os.Getenv("NETWEAVE_CONFIG"); envConfig != "" {
-		if err := validateConfigPath(envConfig); err != nil {
-			// Security: Don't load arbitrary files from environment variables
-			// Return empty to fall back to defaults
-			return ""
+	for i, header := range c.Server.RemoteIPHeaders {
+		if strings.TrimSpace(header) == "" {
+			return fmt.Errorf("remote_ip_headers[%d] cannot be empty", i)
 		}
-		return envConfig
 	}
 
-	// Priority 3: Environment-specific config via NETWEAVE_ENV
-	if env := os.Getenv("NETWEAVE_ENV"); env != "" {
-		envConfigPath := fmt.Sprintf("config/config.%s.yaml", env)
-		// Check if environment-specific config exists
-		if _, err := os.Stat(envConfigPath); err == nil {
-			return envConfigPath
+	return nil
+}
+
+// validateRedis validates the Redis configuration.
+func (c *Config) validateRedis() error {
+	if c.Redis.Mode != "standalone" && c.Redis.Mode != "sentinel" && c.Redis.Mode != "cluster" {
+		return fmt.Errorf("invalid redis mode: %s (must be standalone, sentinel, or cluster)", c.Redis.Mode)
+	}
+
+	if len(c.Redis.Addresses) == 0 {
+		return fmt.Errorf("redis addresses cannot be empty")
+	}
+
+	if c.Redis.Mode == "sentinel" && c.Redis.MasterName == "" {
+		return fmt.Errorf("redis master_name is required for sentinel mode")
+	}
+
+	if c.Redis.DB < 0 || c.Redis.DB > 15 {
+		return fmt.Errorf("invalid redis db: %d (must be 0-15)", c.Redis.DB)
+	}
+
+	if fallback := c.Redis.ReadFallback; fallback.Enabled {
+		if fallback.MaxStaleness <= 0 {
+			return fmt.Errorf("redis.read_fallback.max_staleness must be positive, got %s", fallback.MaxStaleness)
+		}
+		if fallback.MaxEntries <= 0 {
+			return fmt.Errorf("redis.read_fallback.max_entries must be positive, got %d", fallback.MaxEntries)
 		}
 	}
 
-	// Priority 4: Use default or empty (viper will search default paths)
-	return ""
+	if c.Redis.PoolStatsInterval < 0 {
+		return fmt.Errorf("redis.pool_stats_interval cannot be negative, got %s", c.Redis.PoolStatsInterval)
+	}
+
+	if resize := c.Redis.PoolAutoResize; resize.Enabled {
+		if resize.MinSize <= 0 || resize.MaxSize < resize.MinSize {
+			return fmt.Errorf("invalid redis.pool_auto_resize bounds: min_size %d, max_size %d "+
+				"(must be 0 < min_size <= max_size)", resize.MinSize, resize.MaxSize)
+		}
+		if resize.WaitThreshold <= 0 {
+			return fmt.Errorf("redis.pool_auto_resize.wait_threshold must be positive, got %s", resize.WaitThreshold)
+		}
+		if resize.SustainedIntervals <= 0 {
+			return fmt.Errorf("redis.pool_auto_resize.sustained_intervals must be positive, got %d",
+				resize.SustainedIntervals)
+		}
+	}
+
+	return nil
 }
 
-// validateConfigPath validates that a configuration file path is safe to load.
-// It prevents directory traversal attacks and arbitrary file inclusion.
-// Security checks:
-//   - Must have .yaml or .yml extension (prevents loading arbitrary files)
-//   - Path must not contain directory traversal (../../../etc/passwd)
-//   - For production safety, relative paths should be in ./config/ or current dir
-func validateConfigPath(path string) error {
-	if path == "" {
-		return fmt.Errorf("config path is empty")
+// validateTLS validates the TLS configuration.
+func (c *Config) validateTLS() error {
+	if !c.TLS.Enabled {
+		return nil
 	}
 
-	// Validate file extension
-	if err := validateConfigExtension(path); err != nil {
+	if err := c.validateTLSFiles(); err != nil {
 		return err
 	}
 
-	// Check for directory traversal
-	if err := validateNoTraversal(path); err != nil {
+	if err := c.validateTLSClientAuth(); err != nil {
 		return err
 	}
 
-	// Clean and validate normalized path
-	cleanPath := filepath.Clean(path)
-	if strings.Contains(cleanPath, "..") {
-		return fmt.Errorf("config path contains directory traversal after normalization")
+	if c.TLS.MinVersion != "1.2" && c.TLS.MinVersion != "1.3" {
+		return fmt.Errorf("invalid tls min_version: %s (must be 1.2 or 1.3)", c.TLS.MinVersion)
 	}
 
-	// Validate absolute paths
-	if filepath.IsAbs(cleanPath) {
-		return validateAbsolutePath(cleanPath)
+	if c.TLS.ReloadInterval < 0 {
+		return fmt.Errorf("tls reload_interval cannot be negative, got %s", c.TLS.ReloadInterval)
 	}
 
-	// Relative paths are allowed
+	return c.validateSPIFFE()
+}
+
+// validateSPIFFE validates the SPIFFE client identity settings.
+func (c *Config) validateSPIFFE() error {
+	spiffe := c.TLS.SPIFFE
+	if !spiffe.Enabled {
+		return nil
+	}
+	if spiffe.TrustDomain == "" || strings.Contains(spiffe.TrustDomain, "/") {
+		return fmt.Errorf("tls spiffe.trust_domain must be a trust domain name such as example.org, got %q",
+			spiffe.TrustDomain)
+	}
+	if c.TLS.ClientAuth != tlsClientAuthVerify && c.TLS.ClientAuth != tlsClientAuthRequireAndVerify {
+		return fmt.Errorf("tls spiffe requires client_auth verify or require-and-verify, got %s", c.TLS.ClientAuth)
+	}
+	prefix := "spiffe://" + spiffe.TrustDomain + "/"
+	for _, id := range spiffe.AllowedIDs {
+		if !strings.HasPrefix(id, prefix) {
+			return fmt.Errorf("tls spiffe.allowed_ids entry %q is not in trust domain %s", id, spiffe.TrustDomain)
+		}
+	}
 	return nil
 }
 
-// validateConfigExtension ensures the config file has a valid extension.
-func validateConfigExtension(path string) error {
-	ext := filepath.Ext(path)
-	if ext != ".yaml" && ext != ".yml" {
-		return fmt.Errorf("config file must have .yaml or .yml extension")
+// validateTLSFiles validates TLS certificate and key files.
+func (c *Config) validateTLSFiles() error {
+	if c.TLS.CertFile == "" {
+		return fmt.Errorf("tls cert_file is required when TLS is enabled")
+	}
+
+	if c.TLS.KeyFile == "" {
+		return fmt.Errorf("tls key_file is required when TLS is enabled")
+	}
+
+	if _, err := os.Stat(c.TLS.CertFile); os.IsNotExist(err) {
+		return fmt.Errorf("tls cert_file does not exist: %s", c.TLS.CertFile)
+	}
+
+	if _, err := os.Stat(c.TLS.KeyFile); os.IsNotExist(err) {
+		return fmt.Errorf("tls key_file does not exist: %s", c.TLS.KeyFile)
 	}
+
 	return nil
 }
 
-// validateNoTraversal checks for directory traversal attempts.
-func validateNoTraversal(path string) error {
-	if strings.Contains(path, "..") {
-		return fmt.Errorf("config path contains directory traversal")
+// validateTLSClientAuth validates TLS client authentication settings.
+func (c *Config) validateTLSClientAuth() error {
+	validModes := map[string]bool{
+		tlsClientAuthNone:             true,
+		tlsClientAuthRequest:          true,
+		tlsClientAuthRequire:          true,
+		tlsClientAuthVerify:           true,
+		tlsClientAuthRequireAndVerify: true,
+	}
+
+	if !validModes[c.TLS.ClientAuth] {
+		return fmt.Errorf("invalid tls client_auth: %s", c.TLS.ClientAuth)
+	}
+
+	if c.TLS.ClientAuth == tlsClientAuthNone {
+		return nil
+	}
+
+	if c.TLS.CAFile == "" {
+		return fmt.Errorf("tls ca_file is required when client authentication is enabled")
 	}
+
+	if _, err := os.Stat(c.TLS.CAFile); os.IsNotExist(err) {
+		return fmt.Errorf("tls ca_file does not exist: %s", c.TLS.CAFile)
+	}
+
 	return nil
 }
 
-// validateAbsolutePath validates absolute paths and checks for symlinks.
-func validateAbsolutePath(cleanPath string) error {
-	// For absolute paths, check if it exists and validate if it's a symlink
-	info, err := os.Lstat(cleanPath)
-	if err != nil {
-		// Path doesn't exist yet - this is valid for config files that will be created
-		// We've already validated the extension, so just return
-		if os.IsNotExist(err) {
-			return nil
-		}
-		// Other errors (like permission denied) should be reported
-		return fmt.Errorf("cannot access config path: %w", err)
+// validateObservability validates the observability configuration.
+func (c *Config) validateObservability() error {
+	if err := c.validateLogging(); err != nil {
+		return err
+	}
+
+	if err := c.validateMetrics(); err != nil {
+		return err
+	}
+
+	if err := c.validateTracing(); err != nil {
+		return err
+	}
+
+	if c.Observability.VersionAdoption.RetentionHours < 0 {
+		return fmt.Errorf("invalid version_adoption retention_hours: %d (must be >= 0)",
+			c.Observability.VersionAdoption.RetentionHours)
+	}
+
+	if c.Observability.VersionAdoption.DeprecatedWarnThreshold < 0 {
+		return fmt.Errorf("invalid version_adoption deprecated_warn_threshold: %d (must be >= 0)",
+			c.Observability.VersionAdoption.DeprecatedWarnThreshold)
 	}
 
-	// If it's a symlink, validate the target
-	if info.Mode()&os.ModeSymlink != 0 {
-		target, err := filepath.EvalSymlinks(cleanPath)
-		if err != nil {
-			return fmt.Errorf("cannot resolve symlink: %w", err)
+	if c.Observability.Health.ProbeInterval < 0 {
+		return fmt.Errorf("invalid health probe_interval: %s (must be >= 0)", c.Observability.Health.ProbeInterval)
+	}
+
+	if tap := c.Observability.DebugTap; tap.Enabled {
+		if tap.MaxTaps < 1 || tap.BufferSize < 1 || tap.MaxBodyBytes < 1 {
+			return fmt.Errorf("debug_tap max_taps, buffer_size and max_body_bytes must be positive when enabled")
+		}
+		if tap.MaxDuration < time.Minute {
+			return fmt.Errorf("invalid debug_tap max_duration: %s (must be at least 1m)", tap.MaxDuration)
 		}
-		// Ensure symlink target also has valid extension
-		return validateConfigExtension(target)
 	}
 
 	return nil
 }
 
-// detectEnvironment determines the environment from the config path or NETWEAVE_ENV.
-func detectEnvironment(configPath string) string {
-	// Check NETWEAVE_ENV first
-	if env := os.Getenv("NETWEAVE_ENV"); env != "" {
-		return env
+// validateLogging validates the logging configuration.
+func (c *Config) validateLogging() error {
+	validLogLevels := map[string]bool{
+		"debug": true, "info": true, "warn": true, "error": true, "fatal": true,
+	}
+	if !validLogLevels[c.Observability.Logging.Level] {
+		return fmt.Errorf("invalid logging level: %s", c.Observability.Logging.Level)
 	}
 
-	// Detect from config path
-	switch {
-	case strings.Contains(configPath, ".dev."):
-		return EnvDevelopment
-	case strings.Contains(configPath, ".staging."):
-		return EnvStaging
-	case strings.Contains(configPath, ".prod."):
-		return EnvProduction
-	default:
-		return EnvDevelopment // Default to dev
+	if c.Observability.Logging.Format != "json" && c.Observability.Logging.Format != "console" {
+		return fmt.Errorf("invalid logging format: %s (must be json or console)", c.Observability.Logging.Format)
 	}
-}
 
-// setDefaults sets default values for all configuration options.
-func setDefaults(v *viper.Viper) {
-	// Server defaults
-	v.SetDefault("server.host", "0.0.0.0")
-	v.SetDefault("server.port", 8080)
-	v.SetDefault("server.read_timeout", "30s")
-	v.SetDefault("server.write_timeout", "30s")
-	v.SetDefault("server.idle_timeout", "120s")
-	v.SetDefault("server.shutdown_timeout", "30s")
-	v.SetDefault("server.max_header_bytes", 1048576) // 1MB
-	v.SetDefault("server.gin_mode", "release")
+	for _, pattern := range c.Observability.Logging.Redaction.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid logging redaction pattern %q: %w", pattern, err)
+		}
+	}
 
-	// Redis defaults
-	v.SetDefault("redis.mode", "standalone")
-	v.SetDefault("redis.addresses", []string{"localhost:6379"})
-	v.SetDefault("redis.db", 0)
-	v.SetDefault("redis.pool_size", 10)
-	v.SetDefault("redis.min_idle_conns", 5)
-	v.SetDefault("redis.max_retries", 3)
-	v.SetDefault("redis.dial_timeout", "5s")
-	v.SetDefault("redis.read_timeout", "3s")
-	v.SetDefault("redis.write_timeout", "3s")
-	v.SetDefault("redis.pool_timeout", "4s")
-	v.SetDefault("redis.idle_timeout", "5m")
-	v.SetDefault("redis.enable_tls", false)
-	v.SetDefault("redis.tls_insecure_skip_verify", false)
+	return nil
+}
 
-	// Kubernetes defaults
-	v.SetDefault("kubernetes.config_path", "") // Use in-cluster config
-	v.SetDefault("kubernetes.qps", 50.0)
-	v.SetDefault("kubernetes.burst", 100)
-	v.SetDefault("kubernetes.timeout", "30s")
-	v.SetDefault("kubernetes.enable_watch", true)
-	v.SetDefault("kubernetes.watch_resync", "10m")
+// validateMetrics validates the metrics configuration.
+func (c *Config) validateMetrics() error {
+	if !c.Observability.Metrics.Enabled {
+		return nil
+	}
 
-	// TLS defaults
-	v.SetDefault("tls.enabled", false)
-	v.SetDefault("tls.client_auth", "none")
-	v.SetDefault("tls.min_version", "1.3")
+	if c.Observability.Metrics.Path == "" {
+		return fmt.Errorf("metrics path cannot be empty when metrics are enabled")
+	}
 
-	// Logging defaults
-	v.SetDefault("observability.logging.level", "info")
-	v.SetDefault("observability.logging.format", "json")
-	v.SetDefault("observability.logging.output_paths", []string{"stdout"})
-	v.SetDefault("observability.logging.error_output_paths", []string{"stderr"})
-	v.SetDefault("observability.logging.enable_caller", true)
-	v.SetDefault("observability.logging.enable_stacktrace", false)
-	v.SetDefault("observability.logging.development", false)
+	if c.Observability.Metrics.Port < 0 || c.Observability.Metrics.Port > 65535 {
+		return fmt.Errorf("invalid metrics port: %d", c.Observability.Metrics.Port)
+	}
 
-	// Metrics defaults
-	v.SetDefault("observability.metrics.enabled", true)
-	v.SetDefault("observability.metrics.path", "/metrics")
-	v.SetDefault("observability.metrics.port", 0) // Use main server port
-	v.SetDefault("observability.metrics.namespace", "netweave")
-	v.SetDefault("observability.metrics.subsystem", "gateway")
-	v.SetDefault("observability.metrics.enable_go_metrics", true)
-	v.SetDefault("observability.metrics.enable_process_metrics", true)
+	return nil
+}
 
-	// Tracing defaults
-	v.SetDefault("observability.tracing.enabled", false)
-	v.SetDefault("observability.tracing.provider", "otlp")
-	v.SetDefault("observability.tracing.service_name", "netweave-gateway")
-	v.SetDefault("observability.tracing.sampling_rate", 0.1)
-	v.SetDefault("observability.tracing.enable_batching", true)
-	v.SetDefault("observability.tracing.batch_timeout", "5s")
+// validateTracing validates the tracing configuration.
+func (c *Config) validateTracing() error {
+	if !c.Observability.Tracing.Enabled {
+		return nil
+	}
 
-	// Security defaults
-	v.SetDefault("security.enable_cors", false)
-	v.SetDefault("security.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE"})
-	v.SetDefault("security.rate_limit_enabled", true)
-	v.SetDefault("security.rate_limit.tenant.requests_per_second", 1000)
-	v.SetDefault("security.rate_limit.tenant.burst_size", 2000)
-	v.SetDefault("security.rate_limit.global.requests_per_second", 10000)
-	v.SetDefault("security.rate_limit.global.max_concurrent_requests", 1000)
-	v.SetDefault("security.allow_insecure_callbacks", false)
+	validProviders := map[string]bool{"jaeger": true, "zipkin": true, "otlp": true}
+	if !validProviders[c.Observability.Tracing.Provider] {
+		return fmt.Errorf("invalid tracing provider: %s", c.Observability.Tracing.Provider)
+	}
 
-	// Validation defaults
-	v.SetDefault("validation.enabled", true)
-	v.SetDefault("validation.validate_response", false)
-	v.SetDefault("validation.spec_path", "")
-	v.SetDefault("validation.max_body_size", 1048576) // 1MB default
+	if c.Observability.Tracing.Endpoint == "" {
+		return fmt.Errorf("tracing endpoint is required when tracing is enabled")
+	}
 
-	// Multi-tenancy defaults
-	v.SetDefault("multi_tenancy.enabled", false)
-	v.SetDefault("multi_tenancy.require_mtls", true)
-	v.SetDefault("multi_tenancy.initialize_default_roles", true)
-	v.SetDefault("multi_tenancy.audit_log_retention_days", 30)
-	v.SetDefault("multi_tenancy.skip_auth_paths", []string{
-		"/health", "/healthz", "/ready", "/readyz", "/metrics", "/", "/o2ims",
-	})
-	v.SetDefault("multi_tenancy.default_tenant_quota.max_subscriptions", 100)
-	v.SetDefault("multi_tenancy.default_tenant_quota.max_resource_pools", 50)
-	v.SetDefault("multi_tenancy.default_tenant_quota.max_deployments", 200)
-	v.SetDefault("multi_tenancy.default_tenant_quota.max_users", 20)
-	v.SetDefault("multi_tenancy.default_tenant_quota.max_requests_per_minute", 1000)
+	if c.Observability.Tracing.SamplingRate < 0.0 || c.Observability.Tracing.SamplingRate > 1.0 {
+		return fmt.Errorf("invalid tracing sampling_rate: %f (must be 0.0-1.0)", c.Observability.Tracing.SamplingRate)
+	}
+
+	return nil
 }
 
-// Validate validates the configuration and returns an error if any values are invalid.
-// This should be called after Load() to ensure the configuration is valid before use.
-// It enforces environment-specific requirements for production and staging environments.
-//
-// Example:
-//
-//	cfg, err := config.Load("config/config.yaml")
-//	if err != nil {
-//	    return err
-//	}
-//	if err := cfg.Validate(); err != nil {
-//	    return fmt.Errorf("invalid configuration: %w", err)
-//	}
-func (c *Config) Validate() error {
-	if err := c.validateServer(); err != nil {
+// validateSecurity validates the security configuration.
+func (c *Config) validateSecurity() error {
+	if err := c.validateCallbackPolicy(); err != nil {
 		return err
 	}
+	if !c.Security.RateLimitEnabled {
+		return nil
+	}
 
-	if err := c.validateRedis(); err != nil {
+	if err := c.validateTenantRateLimit(); err != nil {
 		return err
 	}
-
-	if err := c.validateTLS(); err != nil {
+	if err := c.validateClientRateLimit(); err != nil {
 		return err
 	}
-
-	if err := c.validateObservability(); err != nil {
+	if err := c.validateGlobalRateLimit(); err != nil {
 		return err
 	}
-
-	if err := c.validateSecurity(); err != nil {
+	if err := c.validateEndpointRateLimits(); err != nil {
 		return err
 	}
+	return c.validateRouteGroupRateLimits()
+}
 
-	if err := c.validateEnvironmentRules(); err != nil {
-		return err
+// validateCallbackPolicy validates the callback allowlist, denylist and
+// revalidation interval.
+func (c *Config) validateCallbackPolicy() error {
+	if c.Security.CallbackRevalidationInterval < 0 {
+		return fmt.Errorf("security.callback_revalidation_interval must not be negative")
+	}
+	lists := []struct {
+		field   string
+		entries []string
+	}{
+		{"callback_allowlist", c.Security.CallbackAllowlist},
+		{"callback_denylist", c.Security.CallbackDenylist},
+	}
+	for _, list := range lists {
+		for i, entry := range list.entries {
+			if err := validateHostPattern(entry); err != nil {
+				return fmt.Errorf("security.%s[%d]: %w", list.field, i, err)
+			}
+		}
 	}
+	return nil
+}
 
+// validateHostPattern checks a callback allowlist or denylist entry: a
+// hostname, a "*." wildcard followed by a domain, an IP address or a CIDR.
+func validateHostPattern(pattern string) error {
+	switch {
+	case pattern == "":
+		return fmt.Errorf("entry must not be empty")
+	case strings.Contains(pattern, "/"):
+		if _, _, err := net.ParseCIDR(pattern); err != nil {
+			return fmt.Errorf("invalid CIDR %q", pattern)
+		}
+	case strings.Contains(strings.TrimPrefix(pattern, "*."), "*"),
+		strings.ContainsAny(pattern, ":") && net.ParseIP(pattern) == nil,
+		strings.ContainsAny(pattern, " @?#"):
+		return fmt.Errorf("invalid host pattern %q", pattern)
+	}
 	return nil
 }
 
-// validateEnvironmentRules enforces environment-specific configuration requirements.
-func (c *Config) validateEnvironmentRules() error {
-	switch c.Environment {
-	case EnvProduction:
-		return c.validateProductionRules()
-	case EnvStaging:
-		return c.validateStagingRules()
-	case EnvDevelopment:
-		// Development has no strict requirements
-		return nil
-	default:
-		// Unknown environment, treat as development
-		return nil
+// validateAuthPolicies validates the authentication policy matrix.
+func (c *Config) validateAuthPolicies() error {
+	for i, policy := range c.MultiTenancy.AuthPolicies {
+		if len(policy.Paths) == 0 {
+			return fmt.Errorf("auth_policies[%d] must list at least one path", i)
+		}
+		switch policy.Level {
+		case AuthLevelAnonymous, AuthLevelAuthenticated:
+		case AuthLevelRole:
+			if len(policy.Roles) == 0 {
+				return fmt.Errorf("auth_policies[%d] with level %q must list at least one role", i, AuthLevelRole)
+			}
+		default:
+			return fmt.Errorf("auth_policies[%d] invalid level %q (must be anonymous, authenticated, or role)",
+				i, policy.Level)
+		}
 	}
+	return nil
 }
 
-// validateProductionRules enforces strict requirements for production.
-func (c *Config) validateProductionRules() error {
-	// TLS/mTLS must be enabled
-	if !c.TLS.Enabled {
-		return fmt.Errorf("TLS must be enabled in production (environment: %s)", c.Environment)
+// validateIdentityMappings validates the mTLS identity mapping rules.
+func (c *Config) validateIdentityMappings() error {
+	names := make(map[string]bool, len(c.MultiTenancy.IdentityMappings))
+	for i, mapping := range c.MultiTenancy.IdentityMappings {
+		if mapping.Name == "" {
+			return fmt.Errorf("identity_mappings[%d] name is required", i)
+		}
+		if names[mapping.Name] {
+			return fmt.Errorf("identity_mappings[%d] duplicate name %q", i, mapping.Name)
+		}
+		names[mapping.Name] = true
+
+		switch mapping.Attribute {
+		case CertAttributeCN, CertAttributeOU, CertAttributeSANDNS, CertAttributeSANURI, CertAttributeSANEmail:
+		default:
+			return fmt.Errorf("identity_mappings[%d] invalid attribute %q "+
+				"(must be cn, ou, san_dns, san_uri, or san_email)", i, mapping.Attribute)
+		}
+		if mapping.Pattern == "" {
+			return fmt.Errorf("identity_mappings[%d] pattern is required", i)
+		}
+		if _, err := regexp.Compile(mapping.Pattern); err != nil {
+			return fmt.Errorf("identity_mappings[%d] invalid pattern: %w", i, err)
+		}
+		if mapping.Tenant == "" {
+			return fmt.Errorf("identity_mappings[%d] tenant is required", i)
+		}
+		if mapping.Role == "" {
+			return fmt.Errorf("identity_mappings[%d] role is required", i)
+		}
 	}
-	if c.TLS.ClientAuth != tlsClientAuthRequireAndVerify {
-		return fmt.Errorf(
-			"mTLS with require-and-verify must be enabled in production (got: %s)",
-			c.TLS.ClientAuth,
-		)
+	return nil
+}
+
+// validateHooks validates lifecycle hook configuration.
+func (c *Config) validateHooks() error {
+	names := make(map[string]bool, len(c.Hooks))
+	for i, hook := range c.Hooks {
+		if hook.Name == "" {
+			return fmt.Errorf("hooks[%d] name is required", i)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("hooks[%d] duplicate name %q", i, hook.Name)
+		}
+		names[hook.Name] = true
+
+		switch hook.Type {
+		case HookTypeWebhook:
+			if hook.URL == "" {
+				return fmt.Errorf("hooks[%d] webhook url is required", i)
+			}
+			parsed, err := url.Parse(hook.URL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("hooks[%d] invalid url %q (must be an absolute http or https URL)", i, hook.URL)
+			}
+		case HookTypePlugin:
+			if hook.Plugin == "" {
+				return fmt.Errorf("hooks[%d] plugin name is required", i)
+			}
+		default:
+			return fmt.Errorf("hooks[%d] invalid type %q (must be webhook or plugin)", i, hook.Type)
+		}
+
+		if err := validateHookValues(i, "phase", hook.Phases, "pre", "post"); err != nil {
+			return err
+		}
+		if err := validateHookValues(i, "operation", hook.Operations, "create", "delete"); err != nil {
+			return err
+		}
+		if hook.Timeout < 0 {
+			return fmt.Errorf("hooks[%d] timeout cannot be negative", i)
+		}
+		switch hook.FailurePolicy {
+		case "", "ignore", "abort":
+		default:
+			return fmt.Errorf("hooks[%d] invalid failure_policy %q (must be ignore or abort)", i, hook.FailurePolicy)
+		}
 	}
+	return nil
+}
 
-	// Rate limiting must be enabled
-	if !c.Security.RateLimitEnabled {
-		return fmt.Errorf("rate limiting must be enabled in production")
+// validateRollout validates the default rollout policy.
+func (c *Config) validateRollout() error {
+	if c.Rollout.Percent < 0 || c.Rollout.Percent > 99 {
+		return fmt.Errorf("rollout.percent must be between 1 and 99")
+	}
+	if c.Rollout.BakeTime < 0 {
+		return fmt.Errorf("rollout.bake_time cannot be negative")
+	}
+	if c.Rollout.MinRequests < 0 {
+		return fmt.Errorf("rollout.min_requests cannot be negative")
+	}
+	if c.Rollout.MaxErrorRateIncrease < 0 || c.Rollout.MaxErrorRateIncrease > 1 {
+		return fmt.Errorf("rollout.max_error_rate_increase must be between 0 and 1")
+	}
+	return nil
+}
+
+// validateStartupChecks validates the startup check configuration.
+func (c *Config) validateStartupChecks() error {
+	switch c.StartupChecks.Mode {
+	case StartupChecksStrict, StartupChecksLenient:
+		if c.StartupChecks.Timeout <= 0 {
+			return fmt.Errorf("startup_checks.timeout must be positive, got %s", c.StartupChecks.Timeout)
+		}
+	case "", StartupChecksDisabled:
+	default:
+		return fmt.Errorf("invalid startup_checks.mode %q (must be %s, %s, or %s)",
+			c.StartupChecks.Mode, StartupChecksStrict, StartupChecksLenient, StartupChecksDisabled)
+	}
+	return nil
+}
+
+// validateCache validates the adapter cache configuration.
+func (c *Config) validateCache() error {
+	if !c.Cache.Enabled {
+		return nil
+	}
+	switch c.Cache.Backend {
+	case CacheBackendMemory:
+		if c.Cache.MaxEntries <= 0 {
+			return fmt.Errorf("cache.max_entries must be positive, got %d", c.Cache.MaxEntries)
+		}
+	case CacheBackendRedis:
+	default:
+		return fmt.Errorf("invalid cache.backend %q (must be %s or %s)",
+			c.Cache.Backend, CacheBackendMemory, CacheBackendRedis)
 	}
 
-	// Logging must not be in development mode
-	if c.Observability.Logging.Development {
-		return fmt.Errorf("logging development mode must be disabled in production")
+	if c.Cache.TTL.ResourcePools < 0 {
+		return fmt.Errorf("cache.ttl.resource_pools cannot be negative")
 	}
-	if c.Observability.Logging.Level == "debug" {
-		return fmt.Errorf("debug logging level is not recommended for production")
+	if c.Cache.TTL.Resources < 0 {
+		return fmt.Errorf("cache.ttl.resources cannot be negative")
 	}
-
-	// Response validation should be disabled for performance
-	if c.Validation.ValidateResponse {
-		return fmt.Errorf("response validation should be disabled in production for performance")
+	if c.Cache.TTL.ResourceTypes < 0 {
+		return fmt.Errorf("cache.ttl.resource_types cannot be negative")
 	}
+	return nil
+}
 
-	// CORS should typically be disabled or restricted
-	if c.Security.EnableCORS && len(c.Security.AllowedOrigins) == 0 {
-		return fmt.Errorf("CORS enabled in production but allowed_origins is empty")
+// validateCircuitBreaker validates the adapter circuit breaker configuration.
+func (c *Config) validateCircuitBreaker() error {
+	if !c.CircuitBreaker.Enabled {
+		return nil
+	}
+	if c.CircuitBreaker.FailureThreshold <= 0 {
+		return fmt.Errorf("circuit_breaker.failure_threshold must be positive, got %d",
+			c.CircuitBreaker.FailureThreshold)
+	}
+	if c.CircuitBreaker.OpenTimeout <= 0 {
+		return fmt.Errorf("circuit_breaker.open_timeout must be positive, got %s", c.CircuitBreaker.OpenTimeout)
+	}
+	if c.CircuitBreaker.HalfOpenRequests <= 0 {
+		return fmt.Errorf("circuit_breaker.half_open_requests must be positive, got %d",
+			c.CircuitBreaker.HalfOpenRequests)
 	}
-
 	return nil
 }
 
-// validateStagingRules enforces requirements for staging.
-func (c *Config) validateStagingRules() error {
-	// TLS should be enabled
-	if !c.TLS.Enabled {
-		return fmt.Errorf("TLS should be enabled in staging (environment: %s)", c.Environment)
+// validateKubernetesHedging validates the hedged Kubernetes read configuration.
+func (c *Config) validateKubernetesHedging() error {
+	h := c.Kubernetes.Hedging
+	if !h.Enabled {
+		return nil
 	}
-
-	// Rate limiting should be enabled
-	if !c.Security.RateLimitEnabled {
-		return fmt.Errorf("rate limiting should be enabled in staging")
+	if h.Delay <= 0 {
+		return fmt.Errorf("kubernetes.hedging.delay must be positive, got %s", h.Delay)
+	}
+	if h.MaxInFlight < 0 {
+		return fmt.Errorf("kubernetes.hedging.max_in_flight must not be negative, got %d", h.MaxInFlight)
+	}
+	if h.BudgetPercent < 0 || h.BudgetPercent > 100 {
+		return fmt.Errorf("kubernetes.hedging.budget_percent must be between 0 and 100, got %g", h.BudgetPercent)
 	}
-
 	return nil
 }
 
-// validateServer validates the server configuration.
-func (c *Config) validateServer() error {
-	if c.Server.Port < 1 || c.Server.Port > 65535 {
-		return fmt.Errorf("invalid server port: %d (must be 1-65535)", c.Server.Port)
+// validateKubernetesObjectLimits validates the per-tenant object ceilings.
+func (c *Config) validateKubernetesObjectLimits() error {
+	l := c.Kubernetes.ObjectLimits
+	if l.MaxResourcePools < 0 {
+		return fmt.Errorf("kubernetes.object_limits.max_resource_pools must not be negative, got %d",
+			l.MaxResourcePools)
 	}
-
-	if c.Server.GinMode != "debug" && c.Server.GinMode != "release" && c.Server.GinMode != "test" {
-		return fmt.Errorf("invalid gin_mode: %s (must be debug, release, or test)", c.Server.GinMode)
+	if l.MaxResources < 0 {
+		return fmt.Errorf("kubernetes.object_limits.max_resources must not be negative, got %d", l.MaxResources)
+	}
+	if l.WarnRatio < 0 || l.WarnRatio > 1 {
+		return fmt.Errorf("kubernetes.object_limits.warn_ratio must be between 0 and 1, got %g", l.WarnRatio)
 	}
-
 	return nil
 }
 
-// validateRedis validates the Redis configuration.
-func (c *Config) validateRedis() error {
-	if c.Redis.Mode != "standalone" && c.Redis.Mode != "sentinel" && c.Redis.Mode != "cluster" {
-		return fmt.Errorf("invalid redis mode: %s (must be standalone, sentinel, or cluster)", c.Redis.Mode)
+// validateExport validates the scheduled inventory export configuration.
+func (c *Config) validateExport() error {
+	if !c.Export.Enabled {
+		return nil
 	}
-
-	if len(c.Redis.Addresses) == 0 {
-		return fmt.Errorf("redis addresses cannot be empty")
+	if c.Export.Interval < time.Minute {
+		return fmt.Errorf("export.interval must be at least 1m, got %s", c.Export.Interval)
 	}
-
-	if c.Redis.Mode == "sentinel" && c.Redis.MasterName == "" {
-		return fmt.Errorf("redis master_name is required for sentinel mode")
+	switch c.Export.Format {
+	case ExportFormatCSV, ExportFormatParquet:
+	default:
+		return fmt.Errorf("invalid export.format %q (must be %s or %s)",
+			c.Export.Format, ExportFormatCSV, ExportFormatParquet)
 	}
-
-	if c.Redis.DB < 0 || c.Redis.DB > 15 {
-		return fmt.Errorf("invalid redis db: %d (must be 0-15)", c.Redis.DB)
+	if len(c.Export.Kinds) == 0 {
+		return fmt.Errorf("export.kinds cannot be empty")
+	}
+	for _, kind := range c.Export.Kinds {
+		if kind != ExportKindResources && kind != ExportKindResourcePools {
+			return fmt.Errorf("invalid export.kinds entry %q (must be %s or %s)",
+				kind, ExportKindResources, ExportKindResourcePools)
+		}
+	}
+	for _, column := range append(slices.Clone(c.Export.ResourceColumns), c.Export.ResourcePoolColumns...) {
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("export columns cannot be empty")
+		}
+	}
+	if c.Export.ObjectStore.Bucket == "" {
+		return fmt.Errorf("export.object_store.bucket is required when export is enabled")
+	}
+	if c.Export.ObjectStore.Region == "" && c.Export.ObjectStore.Endpoint == "" {
+		return fmt.Errorf("export.object_store.region or export.object_store.endpoint is required")
 	}
-
 	return nil
 }
 
-// validateTLS validates the TLS configuration.
-func (c *Config) validateTLS() error {
-	if !c.TLS.Enabled {
+// validateCompliance validates the scheduled compliance self-check
+// configuration.
+func (c *Config) validateCompliance() error {
+	if !c.Compliance.Enabled {
 		return nil
 	}
-
-	if err := c.validateTLSFiles(); err != nil {
-		return err
+	if c.Compliance.Interval < time.Minute {
+		return fmt.Errorf("compliance.interval must be at least 1m, got %s", c.Compliance.Interval)
 	}
-
-	if err := c.validateTLSClientAuth(); err != nil {
-		return err
+	if c.Compliance.CheckTimeout < 0 {
+		return fmt.Errorf("compliance.check_timeout cannot be negative, got %s", c.Compliance.CheckTimeout)
 	}
-
-	if c.TLS.MinVersion != "1.2" && c.TLS.MinVersion != "1.3" {
-		return fmt.Errorf("invalid tls min_version: %s (must be 1.2 or 1.3)", c.TLS.MinVersion)
+	if c.Compliance.RegressionThreshold < 0 || c.Compliance.RegressionThreshold > 100 {
+		return fmt.Errorf("compliance.regression_threshold must be between 0 and 100, got %g",
+			c.Compliance.RegressionThreshold)
+	}
+	if c.Compliance.BaseURL != "" {
+		parsed, err := url.Parse(c.Compliance.BaseURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid compliance.base_url %q (must be an http or https URL)", c.Compliance.BaseURL)
+		}
+	}
+	for name := range c.Compliance.Headers {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("compliance.headers names cannot be empty")
+		}
 	}
-
 	return nil
 }
 
-// validateTLSFiles validates TLS certificate and key files.
-func (c *Config) validateTLSFiles() error {
-	if c.TLS.CertFile == "" {
-		return fmt.Errorf("tls cert_file is required when TLS is enabled")
+// validateDNS validates the outbound DNS resolver configuration.
+func (c *Config) validateDNS() error {
+	for _, server := range c.DNS.Servers {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			return fmt.Errorf("invalid dns.servers entry %q (must be host:port): %w", server, err)
+		}
 	}
-
-	if c.TLS.KeyFile == "" {
-		return fmt.Errorf("tls key_file is required when TLS is enabled")
+	if c.DNS.CacheTTL < 0 || c.DNS.NegativeTTL < 0 {
+		return fmt.Errorf("dns.cache_ttl and dns.negative_ttl cannot be negative")
+	}
+	if c.DNS.MaxEntries < 0 {
+		return fmt.Errorf("dns.max_entries cannot be negative, got %d", c.DNS.MaxEntries)
+	}
+	if c.DNS.Timeout < 0 {
+		return fmt.Errorf("dns.timeout cannot be negative, got %s", c.DNS.Timeout)
 	}
+	return nil
+}
 
-	if _, err := os.Stat(c.TLS.CertFile); os.IsNotExist(err) {
-		return fmt.Errorf("tls cert_file does not exist: %s", c.TLS.CertFile)
+// uuidPattern matches a UUID in its canonical textual form.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateStrictSpec validates the strict O-RAN spec mode configuration.
+func (c *Config) validateStrictSpec() error {
+	strict := c.Validation.StrictSpec
+	if !strict.Enabled {
+		return nil
+	}
+	if !uuidPattern.MatchString(strict.GlobalCloudID) {
+		return fmt.Errorf("validation.strict_spec.global_cloud_id must be a UUID when strict_spec is enabled, got %q",
+			strict.GlobalCloudID)
+	}
+	kinds := []string{
+		StrictSpecKindOCloud, StrictSpecKindDeploymentManager, StrictSpecKindResourcePool,
+		StrictSpecKindResource, StrictSpecKindResourceType,
 	}
+	for kind, keys := range strict.RequiredExtensions {
+		if !slices.Contains(kinds, kind) {
+			return fmt.Errorf("invalid validation.strict_spec.required_extensions kind %q (must be one of %s)",
+				kind, strings.Join(kinds, ", "))
+		}
+		for _, key := range keys {
+			if key == "" {
+				return fmt.Errorf("validation.strict_spec.required_extensions.%s has an empty key", kind)
+			}
+		}
+	}
+	return nil
+}
 
-	if _, err := os.Stat(c.TLS.KeyFile); os.IsNotExist(err) {
-		return fmt.Errorf("tls key_file does not exist: %s", c.TLS.KeyFile)
+// validateValidationRules validates the validation rule configuration.
+func (c *Config) validateValidationRules() error {
+	names := make(map[string]bool, len(c.Validation.Rules))
+	for i, rule := range c.Validation.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("validation.rules[%d] name is required", i)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("validation.rules[%d] duplicate name %q", i, rule.Name)
+		}
+		names[rule.Name] = true
+
+		switch rule.Type {
+		case ValidationRuleTypeField:
+			if rule.Field == "" {
+				return fmt.Errorf("validation.rules[%d] field is required", i)
+			}
+			if !rule.Required && rule.Pattern == "" && len(rule.Values) == 0 && rule.MaxLength == 0 {
+				return fmt.Errorf(
+					"validation.rules[%d] must set at least one of required, pattern, values or max_length", i)
+			}
+			if rule.Pattern != "" {
+				if _, err := regexp.Compile(rule.Pattern); err != nil {
+					return fmt.Errorf("validation.rules[%d] invalid pattern: %w", i, err)
+				}
+			}
+			if rule.MaxLength < 0 {
+				return fmt.Errorf("validation.rules[%d] max_length cannot be negative", i)
+			}
+		case ValidationRuleTypePlugin:
+			if rule.Plugin == "" {
+				return fmt.Errorf("validation.rules[%d] plugin name is required", i)
+			}
+		default:
+			return fmt.Errorf("validation.rules[%d] invalid type %q (must be field or plugin)", i, rule.Type)
+		}
+
+		kinds := []string{"resourcePool", "resource", "nfDeployment"}
+		for _, kind := range rule.Kinds {
+			if !slices.Contains(kinds, kind) {
+				return fmt.Errorf("validation.rules[%d] invalid kind %q (must be one of %s)",
+					i, kind, strings.Join(kinds, ", "))
+			}
+		}
+		for _, op := range rule.Operations {
+			if op != "create" && op != "update" {
+				return fmt.Errorf("validation.rules[%d] invalid operation %q (must be create or update)", i, op)
+			}
+		}
+		if rule.Timeout < 0 {
+			return fmt.Errorf("validation.rules[%d] timeout cannot be negative", i)
+		}
+		switch rule.FailurePolicy {
+		case "", "reject", "ignore":
+		default:
+			return fmt.Errorf("validation.rules[%d] invalid failure_policy %q (must be reject or ignore)",
+				i, rule.FailurePolicy)
+		}
 	}
+	return nil
+}
 
+// validateProvisioning validates the infrastructure provisioning configuration.
+func (c *Config) validateProvisioning() error {
+	p := c.Provisioning
+	if !p.Enabled {
+		return nil
+	}
+	switch p.DefaultProvisioner {
+	case ProvisionerSimulator:
+	case ProvisionerCAPI:
+		if !p.CAPI.Enabled {
+			return fmt.Errorf("provisioning.default_provisioner is %s but provisioning.capi.enabled is false",
+				ProvisionerCAPI)
+		}
+	default:
+		return fmt.Errorf("invalid provisioning.default_provisioner %q (must be %s or %s)",
+			p.DefaultProvisioner, ProvisionerSimulator, ProvisionerCAPI)
+	}
+	if p.ReconcileInterval <= 0 {
+		return fmt.Errorf("provisioning.reconcile_interval must be positive, got %s", p.ReconcileInterval)
+	}
+	if p.Simulator.Delay < 0 {
+		return fmt.Errorf("provisioning.simulator.delay cannot be negative, got %s", p.Simulator.Delay)
+	}
+	if p.CAPI.Enabled && p.CAPI.Namespace == "" {
+		return fmt.Errorf("provisioning.capi.namespace is required when the Cluster API provisioner is enabled")
+	}
+	for _, callback := range p.Notifications.Callbacks {
+		u, err := url.Parse(callback)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid provisioning.notifications.callbacks entry %q (must be an http(s) URL)",
+				callback)
+		}
+	}
+	if p.Notifications.Timeout < 0 || p.Notifications.MaxRetries < 0 {
+		return fmt.Errorf("provisioning.notifications.timeout and max_retries cannot be negative")
+	}
 	return nil
 }
 
-// validateTLSClientAuth validates TLS client authentication settings.
-func (c *Config) validateTLSClientAuth() error {
-	validModes := map[string]bool{
-		tlsClientAuthNone:             true,
-		tlsClientAuthRequest:          true,
-		tlsClientAuthRequire:          true,
-		tlsClientAuthVerify:           true,
-		tlsClientAuthRequireAndVerify: true,
+// validateDMS validates the DMS subsystem configuration.
+func (c *Config) validateDMS() error {
+	switch c.DMS.Storage.Backend {
+	case "", DMSStorageMemory, DMSStorageRedis, DMSStorageKubernetes:
+	default:
+		return fmt.Errorf("invalid dms.storage.backend %q (must be one of %s, %s, %s)",
+			c.DMS.Storage.Backend, DMSStorageMemory, DMSStorageRedis, DMSStorageKubernetes)
+	}
+
+	for name, policy := range c.DMS.NamespacePolicies {
+		for _, rule := range append(slices.Clone(policy.Allow), policy.Deny...) {
+			if rule == "" {
+				return fmt.Errorf("dms.namespace_policies.%s: namespace rule cannot be empty", name)
+			}
+			if _, err := path.Match(rule, ""); err != nil {
+				return fmt.Errorf("dms.namespace_policies.%s: invalid namespace rule %q: %w", name, rule, err)
+			}
+		}
 	}
 
-	if !validModes[c.TLS.ClientAuth] {
-		return fmt.Errorf("invalid tls client_auth: %s", c.TLS.ClientAuth)
+	if err := c.validateDMSAdapters(); err != nil {
+		return err
 	}
 
-	if c.TLS.ClientAuth == tlsClientAuthNone {
-		return nil
+	if repoURL := c.DMS.Helm.RepositoryURL; repoURL != "" {
+		parsed, err := url.Parse(repoURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid dms.helm.repository_url %q (must be an absolute http or https URL)", repoURL)
+		}
 	}
 
-	if c.TLS.CAFile == "" {
-		return fmt.Errorf("tls ca_file is required when client authentication is enabled")
+	if c.DMS.Registrations.SyncInterval < 0 {
+		return fmt.Errorf("dms.registrations.sync_interval must be non-negative, got %s",
+			c.DMS.Registrations.SyncInterval)
 	}
 
-	if _, err := os.Stat(c.TLS.CAFile); os.IsNotExist(err) {
-		return fmt.Errorf("tls ca_file does not exist: %s", c.TLS.CAFile)
+	if j := c.DMS.Jobs; j.Enabled {
+		if j.Workers <= 0 || j.QueueSize <= 0 {
+			return fmt.Errorf("dms.jobs.workers and queue_size must be positive")
+		}
+		if j.LeaseTimeout <= 0 || j.Retention <= 0 {
+			return fmt.Errorf("dms.jobs.lease_timeout and retention must be positive")
+		}
 	}
 
+	n := c.DMS.Notifications
+	if !n.Enabled {
+		return nil
+	}
+	if n.Interval <= 0 {
+		return fmt.Errorf("dms.notifications.interval must be positive, got %s", n.Interval)
+	}
+	if n.Timeout < 0 || n.RetryBackoff < 0 {
+		return fmt.Errorf("dms.notifications.timeout and retry_backoff must be non-negative")
+	}
+	if n.MaxRetries < 0 {
+		return fmt.Errorf("dms.notifications.max_retries must be non-negative, got %d", n.MaxRetries)
+	}
+	if n.History <= 0 {
+		return fmt.Errorf("dms.notifications.history must be positive, got %d", n.History)
+	}
 	return nil
 }
 
-// validateObservability validates the observability configuration.
-func (c *Config) validateObservability() error {
-	if err := c.validateLogging(); err != nil {
-		return err
-	}
+// dmsAdapterNamePattern matches valid names of additional DMS adapters.
+var dmsAdapterNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
 
-	if err := c.validateMetrics(); err != nil {
-		return err
+// validateDMSAdapters validates the additional DMS adapters. Their types are
+// checked when the adapters are created.
+func (c *Config) validateDMSAdapters() error {
+	names := make(map[string]bool, len(c.DMS.Adapters))
+	for i, a := range c.DMS.Adapters {
+		if !dmsAdapterNamePattern.MatchString(a.Name) {
+			return fmt.Errorf("dms.adapters[%d].name %q must be a lowercase DNS label", i, a.Name)
+		}
+		if names[a.Name] {
+			return fmt.Errorf("dms.adapters[%d]: duplicate adapter name %q", i, a.Name)
+		}
+		names[a.Name] = true
+		if a.Type == "" {
+			return fmt.Errorf("dms.adapters[%d].type is required", i)
+		}
 	}
+	return nil
+}
 
-	if err := c.validateTracing(); err != nil {
-		return err
+// validatePricing validates the cost estimation pricing model.
+func (c *Config) validatePricing() error {
+	prices := []struct {
+		name  string
+		value float64
+	}{
+		{"pricing.vcpu_hour", c.Pricing.VCPUHour},
+		{"pricing.memory_gb_hour", c.Pricing.MemoryGBHour},
+		{"pricing.gpu_hour", c.Pricing.GPUHour},
+	}
+	for _, p := range prices {
+		if p.value < 0 || math.IsNaN(p.value) || math.IsInf(p.value, 0) {
+			return fmt.Errorf("%s must be a non-negative number", p.name)
+		}
 	}
 
+	if c.Pricing.Enabled && !isCurrencyCode(c.Pricing.Currency) {
+		return fmt.Errorf("pricing.currency must be a three-letter ISO 4217 code, got %q", c.Pricing.Currency)
+	}
 	return nil
 }
 
-// validateLogging validates the logging configuration.
-func (c *Config) validateLogging() error {
-	validLogLevels := map[string]bool{
-		"debug": true, "info": true, "warn": true, "error": true, "fatal": true,
+// validateSubscriptions validates subscription handling options.
+func (c *Config) validateSubscriptions() error {
+	switch c.Subscriptions.DuplicatePolicy {
+	case "", SubscriptionDuplicatesAllow, SubscriptionDuplicatesReject, SubscriptionDuplicatesMerge:
+	default:
+		return fmt.Errorf("invalid subscriptions.duplicate_policy %q (must be one of %s, %s, %s)",
+			c.Subscriptions.DuplicatePolicy,
+			SubscriptionDuplicatesAllow, SubscriptionDuplicatesReject, SubscriptionDuplicatesMerge)
 	}
-	if !validLogLevels[c.Observability.Logging.Level] {
-		return fmt.Errorf("invalid logging level: %s", c.Observability.Logging.Level)
+	if c.Subscriptions.MaxTTL < 0 {
+		return fmt.Errorf("subscriptions.max_ttl cannot be negative, got %s", c.Subscriptions.MaxTTL)
 	}
-
-	if c.Observability.Logging.Format != "json" && c.Observability.Logging.Format != "console" {
-		return fmt.Errorf("invalid logging format: %s (must be json or console)", c.Observability.Logging.Format)
+	if c.Subscriptions.ExpiryCheckInterval < 0 {
+		return fmt.Errorf("subscriptions.expiry_check_interval cannot be negative, got %s",
+			c.Subscriptions.ExpiryCheckInterval)
 	}
-
 	return nil
 }
 
-// validateMetrics validates the metrics configuration.
-func (c *Config) validateMetrics() error {
-	if !c.Observability.Metrics.Enabled {
-		return nil
+// validateNotifications validates webhook notification delivery options.
+func (c *Config) validateNotifications() error {
+	n := c.Notifications
+	if n.Workers < 0 {
+		return fmt.Errorf("notifications.workers must be non-negative, got %d", n.Workers)
 	}
-
-	if c.Observability.Metrics.Path == "" {
-		return fmt.Errorf("metrics path cannot be empty when metrics are enabled")
+	if n.MaxRetries < 0 {
+		return fmt.Errorf("notifications.max_retries must be non-negative, got %d", n.MaxRetries)
 	}
-
-	if c.Observability.Metrics.Port < 0 || c.Observability.Metrics.Port > 65535 {
-		return fmt.Errorf("invalid metrics port: %d", c.Observability.Metrics.Port)
+	if n.BusyEventsPerMinute < 0 {
+		return fmt.Errorf("notifications.busy_events_per_minute must be non-negative, got %d", n.BusyEventsPerMinute)
 	}
-
-	return nil
+	if n.Timeout < 0 || n.RetryBackoff < 0 || n.MaxBackoff < 0 || n.OrderingTimeout < 0 {
+		return fmt.Errorf("notifications.timeout, retry_backoff, max_backoff and ordering_timeout must be non-negative")
+	}
+	if n.RetryBackoff > 0 && n.MaxBackoff > 0 && n.RetryBackoff > n.MaxBackoff {
+		return fmt.Errorf("notifications.retry_backoff (%s) must not exceed notifications.max_backoff (%s)",
+			n.RetryBackoff, n.MaxBackoff)
+	}
+	if n.DeliverabilityWindow != 0 && n.DeliverabilityWindow < time.Minute {
+		return fmt.Errorf("notifications.deliverability_window must be at least 1m, got %s", n.DeliverabilityWindow)
+	}
+	if n.DigestCheckInterval < 0 || n.DigestCheckInterval > time.Hour {
+		return fmt.Errorf("notifications.digest_check_interval must be between 0 and 1h, got %s", n.DigestCheckInterval)
+	}
+	if n.QueueSnapshotPath != "" {
+		if info, err := os.Stat(filepath.Dir(n.QueueSnapshotPath)); err != nil || !info.IsDir() {
+			return fmt.Errorf("notifications.queue_snapshot_path directory %s does not exist",
+				filepath.Dir(n.QueueSnapshotPath))
+		}
+	}
+	if err := c.validateWebhookAutoscale(); err != nil {
+		return err
+	}
+	return c.validateWebhookCA()
 }
 
-// validateTracing validates the tracing configuration.
-func (c *Config) validateTracing() error {
-	if !c.Observability.Tracing.Enabled {
+// validateWebhookAutoscale validates the webhook worker autoscaling options.
+func (c *Config) validateWebhookAutoscale() error {
+	a := c.Notifications.Autoscale
+	if !a.Enabled {
 		return nil
 	}
-
-	validProviders := map[string]bool{"jaeger": true, "zipkin": true, "otlp": true}
-	if !validProviders[c.Observability.Tracing.Provider] {
-		return fmt.Errorf("invalid tracing provider: %s", c.Observability.Tracing.Provider)
+	if a.MinWorkers < 1 {
+		return fmt.Errorf("notifications.autoscale.min_workers must be at least 1, got %d", a.MinWorkers)
 	}
-
-	if c.Observability.Tracing.Endpoint == "" {
-		return fmt.Errorf("tracing endpoint is required when tracing is enabled")
+	if a.MaxWorkers < a.MinWorkers {
+		return fmt.Errorf("notifications.autoscale.max_workers (%d) must be >= min_workers (%d)",
+			a.MaxWorkers, a.MinWorkers)
 	}
-
-	if c.Observability.Tracing.SamplingRate < 0.0 || c.Observability.Tracing.SamplingRate > 1.0 {
-		return fmt.Errorf("invalid tracing sampling_rate: %f (must be 0.0-1.0)", c.Observability.Tracing.SamplingRate)
+	if a.TargetBacklogPerWorker < 1 {
+		return fmt.Errorf("notifications.autoscale.target_backlog_per_worker must be at least 1, got %d",
+			a.TargetBacklogPerWorker)
+	}
+	if a.TargetLatency < 0 || a.Interval < 0 || a.ScaleDownCooldown < 0 || a.ClaimIdle < 0 {
+		return fmt.Errorf("notifications.autoscale.target_latency, interval, scale_down_cooldown " +
+			"and claim_idle must be non-negative")
 	}
-
 	return nil
 }
 
-// validateSecurity validates the security configuration.
-func (c *Config) validateSecurity() error {
-	if !c.Security.RateLimitEnabled {
+// validateWebhookCA validates the webhook CA options.
+func (c *Config) validateWebhookCA() error {
+	ca := c.Notifications.CA
+	if !ca.Enabled {
 		return nil
 	}
+	if (ca.CertFile == "") != (ca.KeyFile == "") {
+		return fmt.Errorf("notifications.ca.cert_file and notifications.ca.key_file must be set together")
+	}
+	for _, file := range []string{ca.CertFile, ca.KeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("notifications.ca file %s: %w", file, err)
+		}
+	}
+	if ca.CertValidity < 0 || ca.CertValidity > maxWebhookCertValidity {
+		return fmt.Errorf("notifications.ca.cert_validity must be between 0s and %s, got %s",
+			maxWebhookCertValidity, ca.CertValidity)
+	}
+	return nil
+}
 
-	if err := c.validateTenantRateLimit(); err != nil {
-		return err
+// isCurrencyCode reports whether code looks like an ISO 4217 code (three uppercase letters).
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
 	}
-	if err := c.validateGlobalRateLimit(); err != nil {
-		return err
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateHookValues checks that every value is one of allowed.
+func validateHookValues(index int, field string, values []string, allowed ...string) error {
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return fmt.Errorf("hooks[%d] invalid %s %q (must be one of %s)",
+				index, field, value, strings.Join(allowed, ", "))
+		}
 	}
-	return c.validateEndpointRateLimits()
+	return nil
 }
 
 // validateTenantRateLimit validates per-tenant rate limit configuration.
@@ -1191,6 +3515,23 @@ func (c *Config) validateTenantRateLimit() error {
 	return nil
 }
 
+// validateClientRateLimit validates per-client rate limit configuration.
+func (c *Config) validateClientRateLimit() error {
+	if c.Security.RateLimit.PerClient.RequestsPerSecond < 0 {
+		return fmt.Errorf(
+			"invalid client requests_per_second: %d (must be >= 0)",
+			c.Security.RateLimit.PerClient.RequestsPerSecond,
+		)
+	}
+	if c.Security.RateLimit.PerClient.BurstSize < 0 {
+		return fmt.Errorf(
+			"invalid client burst_size: %d (must be >= 0)",
+			c.Security.RateLimit.PerClient.BurstSize,
+		)
+	}
+	return nil
+}
+
 // validateGlobalRateLimit validates global rate limit configuration.
 func (c *Config) validateGlobalRateLimit() error {
 	if c.Security.RateLimit.Global.RequestsPerSecond < 0 {
@@ -1226,3 +3567,27 @@ func (c *Config) validateEndpointRateLimits() error {
 	}
 	return nil
 }
+
+// validateRouteGroupRateLimits validates per-route-group rate limit configuration.
+func (c *Config) validateRouteGroupRateLimits() error {
+	names := make(map[string]bool, len(c.Security.RateLimit.RouteGroups))
+	for i, group := range c.Security.RateLimit.RouteGroups {
+		if group.Name == "" {
+			return fmt.Errorf("route_groups[%d] name cannot be empty", i)
+		}
+		if names[group.Name] {
+			return fmt.Errorf("route_groups[%d] name %q is not unique", i, group.Name)
+		}
+		names[group.Name] = true
+		if !strings.HasPrefix(group.PathPrefix, "/") {
+			return fmt.Errorf("route_groups[%d] path_prefix %q must start with /", i, group.PathPrefix)
+		}
+		if group.RequestsPerSecond < 0 {
+			return fmt.Errorf("route_groups[%d] requests_per_second: %d (must be >= 0)", i, group.RequestsPerSecond)
+		}
+		if group.BurstSize < 0 {
+			return fmt.Errorf("route_groups[%d] burst_size: %d (must be >= 0)", i, group.BurstSize)
+		}
+	}
+	return nil
+}
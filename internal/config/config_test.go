@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -188,6 +189,9 @@ func TestLoadWithoutConfigFile(t *testing.T) {
 	// Verify defaults are applied
 	assert.Equal(t, "0.0.0.0", cfg.Server.Host)
 	assert.Equal(t, 8080, cfg.Server.Port)
+	require.Len(t, cfg.MultiTenancy.AuthPolicies, 1)
+	assert.Equal(t, config.AuthLevelAnonymous, cfg.MultiTenancy.AuthPolicies[0].Level)
+	assert.Contains(t, cfg.MultiTenancy.AuthPolicies[0].Paths, "/health")
 }
 
 // TestValidateValidConfig tests validation with a fully valid configuration.
@@ -268,6 +272,47 @@ func TestValidateInvalidServerPortTooHigh(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid server port")
 }
 
+// TestValidateInvalidGatewayMode tests validation with an unknown gateway mode.
+func TestValidateInvalidGatewayMode(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Port:    8080,
+			GinMode: "release",
+			Mode:    "ims-only",
+		},
+		Redis: config.RedisConfig{
+			Mode:      "standalone",
+			Addresses: []string{"localhost:6379"},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid server.mode")
+}
+
+// TestServerConfig_GatewayMode tests the gateway mode defaults and IMS capability.
+func TestServerConfig_GatewayMode(t *testing.T) {
+	tests := []struct {
+		mode     string
+		wantMode string
+		wantIMS  bool
+	}{
+		{"", config.GatewayModeIMSAndDMS, true},
+		{config.GatewayModeIMSAndDMS, config.GatewayModeIMSAndDMS, true},
+		{config.GatewayModeDMSOnly, config.GatewayModeDMSOnly, false},
+		{config.GatewayModeSimulator, config.GatewayModeSimulator, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantMode, func(t *testing.T) {
+			cfg := config.ServerConfig{Mode: tt.mode}
+			assert.Equal(t, tt.wantMode, cfg.GatewayMode())
+			assert.Equal(t, tt.wantIMS, cfg.IMSEnabled())
+		})
+	}
+}
+
 // TestValidateInvalidGinMode tests validation with invalid gin mode.
 func TestValidateInvalidGinMode(t *testing.T) {
 	cfg := &config.Config{
@@ -275,15 +320,1439 @@ func TestValidateInvalidGinMode(t *testing.T) {
 			Port:    8080,
 			GinMode: "invalid",
 		},
-		Redis: config.RedisConfig{
-			Mode:      "standalone",
-			Addresses: []string{"localhost:6379"},
+		Redis: config.RedisConfig{
+			Mode:      "standalone",
+			Addresses: []string{"localhost:6379"},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid gin_mode")
+}
+
+// TestValidateTrustedProxies tests validation of trusted proxy entries.
+func TestValidateTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		headers []string
+		wantErr string
+	}{
+		{name: "empty", proxies: nil},
+		{name: "ip and cidr", proxies: []string{"10.0.0.1", "192.168.0.0/16", "fd00::/8"}},
+		{name: "invalid ip", proxies: []string{"not-an-ip"}, wantErr: "invalid trusted_proxies[0]"},
+		{name: "invalid cidr", proxies: []string{"10.0.0.0/33"}, wantErr: "invalid trusted_proxies[0]"},
+		{name: "empty header", headers: []string{"X-Real-IP", " "}, wantErr: "remote_ip_headers[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{
+					Port:            8080,
+					GinMode:         "release",
+					TrustedProxies:  tt.proxies,
+					RemoteIPHeaders: tt.headers,
+				},
+				Redis: config.RedisConfig{
+					Mode:      "standalone",
+					Addresses: []string{"localhost:6379"},
+				},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateRedisReadFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		fallback config.RedisReadFallbackConfig
+		wantErr  string
+	}{
+		{name: "disabled"},
+		{name: "valid", fallback: config.RedisReadFallbackConfig{
+			Enabled: true, MaxStaleness: 5 * time.Minute, MaxEntries: 100,
+		}},
+		{
+			name:     "no staleness",
+			fallback: config.RedisReadFallbackConfig{Enabled: true, MaxEntries: 100},
+			wantErr:  "redis.read_fallback.max_staleness must be positive",
+		},
+		{
+			name:     "no entries",
+			fallback: config.RedisReadFallbackConfig{Enabled: true, MaxStaleness: time.Minute},
+			wantErr:  "redis.read_fallback.max_entries must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis: config.RedisConfig{
+					Mode:         "standalone",
+					Addresses:    []string{"localhost:6379"},
+					ReadFallback: tt.fallback,
+				},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateRedisPoolAutoResize(t *testing.T) {
+	valid := config.RedisPoolAutoResizeConfig{
+		Enabled: true, MinSize: 10, MaxSize: 100, WaitThreshold: 5 * time.Millisecond, SustainedIntervals: 3,
+	}
+	tests := []struct {
+		name    string
+		modify  func(*config.RedisConfig)
+		wantErr string
+	}{
+		{name: "disabled", modify: func(*config.RedisConfig) {}},
+		{name: "valid", modify: func(r *config.RedisConfig) { r.PoolAutoResize = valid }},
+		{
+			name: "max below min",
+			modify: func(r *config.RedisConfig) {
+				r.PoolAutoResize = valid
+				r.PoolAutoResize.MaxSize = 5
+			},
+			wantErr: "invalid redis.pool_auto_resize bounds",
+		},
+		{
+			name: "no wait threshold",
+			modify: func(r *config.RedisConfig) {
+				r.PoolAutoResize = valid
+				r.PoolAutoResize.WaitThreshold = 0
+			},
+			wantErr: "redis.pool_auto_resize.wait_threshold must be positive",
+		},
+		{
+			name: "no sustained intervals",
+			modify: func(r *config.RedisConfig) {
+				r.PoolAutoResize = valid
+				r.PoolAutoResize.SustainedIntervals = 0
+			},
+			wantErr: "redis.pool_auto_resize.sustained_intervals must be positive",
+		},
+		{
+			name:    "negative stats interval",
+			modify:  func(r *config.RedisConfig) { r.PoolStatsInterval = -time.Second },
+			wantErr: "redis.pool_stats_interval cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+			}
+			tt.modify(&cfg.Redis)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateAuthPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []config.AuthPolicyConfig
+		wantErr  string
+	}{
+		{name: "empty", policies: nil},
+		{name: "anonymous read-only", policies: []config.AuthPolicyConfig{
+			{Paths: []string{"/o2ims-infrastructureInventory/v1/*"}, Methods: []string{"GET"}, Level: "anonymous"},
+			{Paths: []string{"/admin/*"}, Level: "role", Roles: []string{"platform-admin"}},
+		}},
+		{
+			name:     "no paths",
+			policies: []config.AuthPolicyConfig{{Level: "anonymous"}},
+			wantErr:  "auth_policies[0] must list at least one path",
+		},
+		{
+			name:     "role without roles",
+			policies: []config.AuthPolicyConfig{{Paths: []string{"/admin/*"}, Level: "role"}},
+			wantErr:  "must list at least one role",
+		},
+		{
+			name:     "invalid level",
+			policies: []config.AuthPolicyConfig{{Paths: []string{"/"}, Level: "public"}},
+			wantErr:  "auth_policies[0] invalid level",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis: config.RedisConfig{
+					Mode:      "standalone",
+					Addresses: []string{"localhost:6379"},
+				},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				MultiTenancy: config.MultiTenancyConfig{AuthPolicies: tt.policies},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateIdentityMappings(t *testing.T) {
+	valid := config.IdentityMappingConfig{
+		Name: "spiffe", Attribute: "san_uri", Pattern: `^spiffe://ran\.example/ns/(?P<tenant>[a-z0-9-]+)/`,
+		Tenant: "${tenant}", Role: "operator",
+	}
+	tests := []struct {
+		name    string
+		mutate  func(m *config.IdentityMappingConfig)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*config.IdentityMappingConfig) {}},
+		{name: "no name", mutate: func(m *config.IdentityMappingConfig) { m.Name = "" }, wantErr: "name is required"},
+		{
+			name:    "invalid attribute",
+			mutate:  func(m *config.IdentityMappingConfig) { m.Attribute = "serial" },
+			wantErr: "identity_mappings[0] invalid attribute",
+		},
+		{
+			name:    "invalid pattern",
+			mutate:  func(m *config.IdentityMappingConfig) { m.Pattern = "tenant-(" },
+			wantErr: "identity_mappings[0] invalid pattern",
+		},
+		{
+			name:    "no tenant",
+			mutate:  func(m *config.IdentityMappingConfig) { m.Tenant = "" },
+			wantErr: "tenant is required",
+		},
+		{name: "no role", mutate: func(m *config.IdentityMappingConfig) { m.Role = "" }, wantErr: "role is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := valid
+			tt.mutate(&mapping)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis: config.RedisConfig{
+					Mode:      "standalone",
+					Addresses: []string{"localhost:6379"},
+				},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				MultiTenancy: config.MultiTenancyConfig{IdentityMappings: []config.IdentityMappingConfig{mapping}},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		cfg := &config.Config{
+			Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+			Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+			Observability: config.ObservabilityConfig{
+				Logging: config.LoggingConfig{Level: "info", Format: "json"},
+			},
+			MultiTenancy: config.MultiTenancyConfig{IdentityMappings: []config.IdentityMappingConfig{valid, valid}},
+		}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `identity_mappings[1] duplicate name "spiffe"`)
+	})
+}
+
+func TestValidateHooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		hooks   []config.HookConfig
+		wantErr string
+	}{
+		{name: "empty", hooks: nil},
+		{name: "webhook and plugin", hooks: []config.HookConfig{
+			{Name: "cmdb", Type: "webhook", URL: "https://cmdb.example.com/hooks", Phases: []string{"post"}},
+			{Name: "approval", Type: "plugin", Plugin: "approval", Operations: []string{"delete"}, FailurePolicy: "abort"},
+		}},
+		{
+			name:    "missing name",
+			hooks:   []config.HookConfig{{Type: "plugin", Plugin: "p"}},
+			wantErr: "hooks[0] name is required",
+		},
+		{
+			name: "duplicate name",
+			hooks: []config.HookConfig{
+				{Name: "h", Type: "plugin", Plugin: "p"},
+				{Name: "h", Type: "plugin", Plugin: "p"},
+			},
+			wantErr: "hooks[1] duplicate name",
+		},
+		{
+			name:    "relative webhook url",
+			hooks:   []config.HookConfig{{Name: "h", Type: "webhook", URL: "/hooks"}},
+			wantErr: "hooks[0] invalid url",
+		},
+		{
+			name:    "plugin without name",
+			hooks:   []config.HookConfig{{Name: "h", Type: "plugin"}},
+			wantErr: "plugin name is required",
+		},
+		{
+			name:    "invalid type",
+			hooks:   []config.HookConfig{{Name: "h", Type: "grpc"}},
+			wantErr: "invalid type",
+		},
+		{
+			name:    "invalid phase",
+			hooks:   []config.HookConfig{{Name: "h", Type: "plugin", Plugin: "p", Phases: []string{"during"}}},
+			wantErr: "invalid phase",
+		},
+		{
+			name:    "invalid failure policy",
+			hooks:   []config.HookConfig{{Name: "h", Type: "plugin", Plugin: "p", FailurePolicy: "retry"}},
+			wantErr: "invalid failure_policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis: config.RedisConfig{
+					Mode:      "standalone",
+					Addresses: []string{"localhost:6379"},
+				},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Hooks: tt.hooks,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDMSStorageBackend(t *testing.T) {
+	for _, backend := range []string{"", "memory", "redis", "kubernetes", "etcd"} {
+		t.Run(backend, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DMS: config.DMSConfig{Storage: config.DMSStorageConfig{Backend: backend}},
+			}
+
+			err := cfg.Validate()
+			if backend == "etcd" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "dms.storage.backend")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDMSNamespacePolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies map[string]config.DMSNamespacePolicy
+		wantErr  string
+	}{
+		{name: "none"},
+		{
+			name: "valid",
+			policies: map[string]config.DMSNamespacePolicy{
+				"*":    {Deny: []string{"kube-*", "flux-system"}},
+				"helm": {Allow: []string{"team-*"}, Deny: []string{"kube-system"}},
+			},
+		},
+		{
+			name:     "bad pattern",
+			policies: map[string]config.DMSNamespacePolicy{"helm": {Deny: []string{"kube-["}}},
+			wantErr:  "dms.namespace_policies.helm",
+		},
+		{
+			name:     "empty rule",
+			policies: map[string]config.DMSNamespacePolicy{"helm": {Allow: []string{""}}},
+			wantErr:  "cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DMS: config.DMSConfig{NamespacePolicies: tt.policies},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDMSAdapters(t *testing.T) {
+	tests := []struct {
+		name     string
+		adapters []config.DMSAdapterConfig
+		wantErr  string
+	}{
+		{name: "none"},
+		{
+			name: "valid",
+			adapters: []config.DMSAdapterConfig{
+				{Name: "helm-edge", Type: "helm", RepositoryURL: "https://charts.example.com"},
+				{Name: "flux-east", Type: "flux", Kubeconfig: "/etc/kube/east"},
+			},
+		},
+		{
+			name:     "invalid name",
+			adapters: []config.DMSAdapterConfig{{Name: "Helm Edge", Type: "helm"}},
+			wantErr:  "dms.adapters[0].name",
+		},
+		{
+			name: "duplicate name",
+			adapters: []config.DMSAdapterConfig{
+				{Name: "edge", Type: "helm"},
+				{Name: "edge", Type: "flux"},
+			},
+			wantErr: "duplicate adapter name",
+		},
+		{
+			name:     "missing type",
+			adapters: []config.DMSAdapterConfig{{Name: "edge"}},
+			wantErr:  "dms.adapters[0].type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DMS: config.DMSConfig{Adapters: tt.adapters},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateWebhookAutoscale(t *testing.T) {
+	valid := config.WebhookAutoscaleConfig{
+		Enabled: true, MinWorkers: 1, MaxWorkers: 50, TargetBacklogPerWorker: 100,
+		TargetLatency: 2 * time.Second, Interval: 15 * time.Second,
+		ScaleDownCooldown: 2 * time.Minute, ClaimIdle: 10 * time.Minute,
+	}
+	tests := []struct {
+		name    string
+		mutate  func(a *config.WebhookAutoscaleConfig)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*config.WebhookAutoscaleConfig) {}},
+		{name: "disabled ignores settings", mutate: func(a *config.WebhookAutoscaleConfig) {
+			*a = config.WebhookAutoscaleConfig{}
+		}},
+		{name: "zero min workers", mutate: func(a *config.WebhookAutoscaleConfig) { a.MinWorkers = 0 },
+			wantErr: "notifications.autoscale.min_workers"},
+		{name: "max below min", mutate: func(a *config.WebhookAutoscaleConfig) { a.MaxWorkers = 0 },
+			wantErr: "notifications.autoscale.max_workers"},
+		{name: "zero target backlog", mutate: func(a *config.WebhookAutoscaleConfig) { a.TargetBacklogPerWorker = 0 },
+			wantErr: "notifications.autoscale.target_backlog_per_worker"},
+		{name: "negative claim idle", mutate: func(a *config.WebhookAutoscaleConfig) { a.ClaimIdle = -time.Second },
+			wantErr: "claim_idle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			autoscale := valid
+			tt.mutate(&autoscale)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Notifications: config.NotificationsConfig{Autoscale: autoscale},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDMSNotifications(t *testing.T) {
+	valid := config.DMSNotificationsConfig{
+		Enabled: true, Interval: 30 * time.Second, Timeout: 10 * time.Second,
+		MaxRetries: 3, RetryBackoff: time.Second, History: 100,
+	}
+	tests := []struct {
+		name    string
+		mutate  func(n *config.DMSNotificationsConfig)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*config.DMSNotificationsConfig) {}},
+		{name: "disabled ignores settings", mutate: func(n *config.DMSNotificationsConfig) {
+			*n = config.DMSNotificationsConfig{}
+		}},
+		{name: "zero interval", mutate: func(n *config.DMSNotificationsConfig) { n.Interval = 0 },
+			wantErr: "dms.notifications.interval"},
+		{name: "negative retries", mutate: func(n *config.DMSNotificationsConfig) { n.MaxRetries = -1 },
+			wantErr: "dms.notifications.max_retries"},
+		{name: "negative timeout", mutate: func(n *config.DMSNotificationsConfig) { n.Timeout = -time.Second },
+			wantErr: "dms.notifications.timeout"},
+		{name: "zero history", mutate: func(n *config.DMSNotificationsConfig) { n.History = 0 },
+			wantErr: "dms.notifications.history"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications := valid
+			tt.mutate(&notifications)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DMS: config.DMSConfig{Notifications: notifications},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDMSJobs(t *testing.T) {
+	valid := config.DMSJobsConfig{
+		Enabled: true, Workers: 4, QueueSize: 1000, LeaseTimeout: 2 * time.Minute, Retention: 24 * time.Hour,
+	}
+	tests := []struct {
+		name    string
+		mutate  func(j *config.DMSJobsConfig)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*config.DMSJobsConfig) {}},
+		{name: "disabled ignores settings", mutate: func(j *config.DMSJobsConfig) { *j = config.DMSJobsConfig{} }},
+		{name: "zero workers", mutate: func(j *config.DMSJobsConfig) { j.Workers = 0 }, wantErr: "dms.jobs.workers"},
+		{name: "zero lease timeout", mutate: func(j *config.DMSJobsConfig) { j.LeaseTimeout = 0 },
+			wantErr: "dms.jobs.lease_timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := valid
+			tt.mutate(&jobs)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DMS: config.DMSConfig{Jobs: jobs},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateStartupChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		checks  config.StartupChecksConfig
+		helm    config.DMSHelmConfig
+		wantErr string
+	}{
+		{name: "strict", checks: config.StartupChecksConfig{Mode: "strict", Timeout: 15 * time.Second}},
+		{name: "disabled ignores timeout", checks: config.StartupChecksConfig{Mode: "disabled"}},
+		{
+			name:    "invalid mode",
+			checks:  config.StartupChecksConfig{Mode: "paranoid", Timeout: time.Second},
+			wantErr: "invalid startup_checks.mode",
+		},
+		{
+			name:    "lenient without timeout",
+			checks:  config.StartupChecksConfig{Mode: "lenient"},
+			wantErr: "startup_checks.timeout must be positive",
+		},
+		{name: "helm repository", helm: config.DMSHelmConfig{RepositoryURL: "https://charts.example.com/stable"}},
+		{
+			name:    "relative helm repository",
+			helm:    config.DMSHelmConfig{RepositoryURL: "charts.example.com"},
+			wantErr: "invalid dms.helm.repository_url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DMS:           config.DMSConfig{Helm: tt.helm},
+				StartupChecks: tt.checks,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCache(t *testing.T) {
+	ttl := config.CacheTTLConfig{ResourcePools: time.Minute, Resources: 30 * time.Second}
+	tests := []struct {
+		name    string
+		cache   config.CacheConfig
+		wantErr string
+	}{
+		{name: "disabled", cache: config.CacheConfig{Backend: "memcached"}},
+		{name: "memory", cache: config.CacheConfig{Enabled: true, Backend: "memory", MaxEntries: 100, TTL: ttl}},
+		{name: "redis", cache: config.CacheConfig{Enabled: true, Backend: "redis", TTL: ttl}},
+		{
+			name:    "invalid backend",
+			cache:   config.CacheConfig{Enabled: true, Backend: "memcached", TTL: ttl},
+			wantErr: "invalid cache.backend",
+		},
+		{
+			name:    "memory without max entries",
+			cache:   config.CacheConfig{Enabled: true, Backend: "memory", TTL: ttl},
+			wantErr: "cache.max_entries must be positive",
+		},
+		{
+			name: "negative ttl",
+			cache: config.CacheConfig{
+				Enabled: true, Backend: "redis", TTL: config.CacheTTLConfig{ResourceTypes: -time.Second},
+			},
+			wantErr: "cache.ttl.resource_types cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Cache: tt.cache,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCircuitBreaker(t *testing.T) {
+	valid := config.CircuitBreakerConfig{
+		Enabled: true, FailureThreshold: 5, OpenTimeout: 30 * time.Second, HalfOpenRequests: 1,
+	}
+	tests := []struct {
+		name    string
+		modify  func(*config.CircuitBreakerConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*config.CircuitBreakerConfig) {}},
+		{name: "disabled", modify: func(b *config.CircuitBreakerConfig) { *b = config.CircuitBreakerConfig{} }},
+		{
+			name:    "zero threshold",
+			modify:  func(b *config.CircuitBreakerConfig) { b.FailureThreshold = 0 },
+			wantErr: "circuit_breaker.failure_threshold must be positive",
+		},
+		{
+			name:    "zero open timeout",
+			modify:  func(b *config.CircuitBreakerConfig) { b.OpenTimeout = 0 },
+			wantErr: "circuit_breaker.open_timeout must be positive",
+		},
+		{
+			name:    "no half-open requests",
+			modify:  func(b *config.CircuitBreakerConfig) { b.HalfOpenRequests = 0 },
+			wantErr: "circuit_breaker.half_open_requests must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := valid
+			tt.modify(&breaker)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				CircuitBreaker: breaker,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateKubernetesHedging(t *testing.T) {
+	valid := config.KubernetesHedgingConfig{
+		Enabled: true, Delay: 50 * time.Millisecond, MaxInFlight: 10, BudgetPercent: 10,
+	}
+	tests := []struct {
+		name    string
+		modify  func(*config.KubernetesHedgingConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*config.KubernetesHedgingConfig) {}},
+		{name: "disabled", modify: func(h *config.KubernetesHedgingConfig) { *h = config.KubernetesHedgingConfig{} }},
+		{name: "no caps", modify: func(h *config.KubernetesHedgingConfig) { h.MaxInFlight, h.BudgetPercent = 0, 0 }},
+		{
+			name:    "zero delay",
+			modify:  func(h *config.KubernetesHedgingConfig) { h.Delay = 0 },
+			wantErr: "kubernetes.hedging.delay must be positive",
+		},
+		{
+			name:    "negative max in flight",
+			modify:  func(h *config.KubernetesHedgingConfig) { h.MaxInFlight = -1 },
+			wantErr: "kubernetes.hedging.max_in_flight must not be negative",
+		},
+		{
+			name:    "budget above 100",
+			modify:  func(h *config.KubernetesHedgingConfig) { h.BudgetPercent = 150 },
+			wantErr: "kubernetes.hedging.budget_percent must be between 0 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hedging := valid
+			tt.modify(&hedging)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Kubernetes: config.KubernetesConfig{Hedging: hedging},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateKubernetesObjectLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  config.KubernetesObjectLimitsConfig
+		wantErr string
+	}{
+		{name: "unset", limits: config.KubernetesObjectLimitsConfig{}},
+		{
+			name:   "valid",
+			limits: config.KubernetesObjectLimitsConfig{MaxResourcePools: 20, MaxResources: 500, WarnRatio: 0.8},
+		},
+		{
+			name:    "negative max resource pools",
+			limits:  config.KubernetesObjectLimitsConfig{MaxResourcePools: -1},
+			wantErr: "kubernetes.object_limits.max_resource_pools must not be negative",
+		},
+		{
+			name:    "negative max resources",
+			limits:  config.KubernetesObjectLimitsConfig{MaxResources: -1},
+			wantErr: "kubernetes.object_limits.max_resources must not be negative",
+		},
+		{
+			name:    "warn ratio above 1",
+			limits:  config.KubernetesObjectLimitsConfig{WarnRatio: 1.5},
+			wantErr: "kubernetes.object_limits.warn_ratio must be between 0 and 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Kubernetes: config.KubernetesConfig{ObjectLimits: tt.limits},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateExport(t *testing.T) {
+	valid := config.ExportConfig{
+		Enabled:     true,
+		Interval:    time.Hour,
+		Format:      config.ExportFormatParquet,
+		Kinds:       []string{config.ExportKindResources, config.ExportKindResourcePools},
+		ObjectStore: config.ExportObjectStoreConfig{Bucket: "inventory", Region: "eu-west-1"},
+	}
+	tests := []struct {
+		name    string
+		modify  func(*config.ExportConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*config.ExportConfig) {}},
+		{name: "disabled", modify: func(e *config.ExportConfig) { *e = config.ExportConfig{Format: "xml"} }},
+		{
+			name:    "short interval",
+			modify:  func(e *config.ExportConfig) { e.Interval = time.Second },
+			wantErr: "export.interval must be at least 1m",
+		},
+		{
+			name:    "invalid format",
+			modify:  func(e *config.ExportConfig) { e.Format = "xml" },
+			wantErr: "invalid export.format",
+		},
+		{
+			name:    "invalid kind",
+			modify:  func(e *config.ExportConfig) { e.Kinds = []string{"subscriptions"} },
+			wantErr: "invalid export.kinds entry",
+		},
+		{
+			name:    "empty column",
+			modify:  func(e *config.ExportConfig) { e.ResourceColumns = []string{"resourceId", " "} },
+			wantErr: "export columns cannot be empty",
+		},
+		{
+			name:    "missing bucket",
+			modify:  func(e *config.ExportConfig) { e.ObjectStore.Bucket = "" },
+			wantErr: "export.object_store.bucket is required",
+		},
+		{
+			name: "endpoint without region",
+			modify: func(e *config.ExportConfig) {
+				e.ObjectStore = config.ExportObjectStoreConfig{Bucket: "inventory", Endpoint: "http://minio:9000"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := valid
+			export.Kinds = slices.Clone(valid.Kinds)
+			tt.modify(&export)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Export: export,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCompliance(t *testing.T) {
+	valid := config.ComplianceConfig{Enabled: true, Interval: 24 * time.Hour, CheckTimeout: 10 * time.Second}
+	tests := []struct {
+		name    string
+		modify  func(*config.ComplianceConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*config.ComplianceConfig) {}},
+		{name: "disabled", modify: func(cc *config.ComplianceConfig) { *cc = config.ComplianceConfig{} }},
+		{
+			name: "base URL and headers",
+			modify: func(cc *config.ComplianceConfig) {
+				cc.BaseURL = "https://gateway.example.com:8443"
+				cc.Headers = map[string]string{"Authorization": "Bearer token"}
+				cc.RegressionThreshold = 5
+			},
+		},
+		{
+			name:    "short interval",
+			modify:  func(cc *config.ComplianceConfig) { cc.Interval = time.Second },
+			wantErr: "compliance.interval must be at least 1m",
+		},
+		{
+			name:    "negative check timeout",
+			modify:  func(cc *config.ComplianceConfig) { cc.CheckTimeout = -time.Second },
+			wantErr: "compliance.check_timeout cannot be negative",
+		},
+		{
+			name:    "threshold out of range",
+			modify:  func(cc *config.ComplianceConfig) { cc.RegressionThreshold = 101 },
+			wantErr: "compliance.regression_threshold must be between 0 and 100",
+		},
+		{
+			name:    "invalid base URL",
+			modify:  func(cc *config.ComplianceConfig) { cc.BaseURL = "localhost:8080" },
+			wantErr: "invalid compliance.base_url",
+		},
+		{
+			name:    "empty header name",
+			modify:  func(cc *config.ComplianceConfig) { cc.Headers = map[string]string{" ": "value"} },
+			wantErr: "compliance.headers names cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compliance := valid
+			tt.modify(&compliance)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Compliance: compliance,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDNS(t *testing.T) {
+	tests := []struct {
+		name    string
+		dns     config.DNSConfig
+		wantErr string
+	}{
+		{name: "defaults", dns: config.DNSConfig{}},
+		{
+			name: "upstream servers",
+			dns:  config.DNSConfig{Servers: []string{"10.0.0.53:53", "[2001:db8::53]:53"}, CacheTTL: time.Minute},
+		},
+		{
+			name:    "server without port",
+			dns:     config.DNSConfig{Servers: []string{"10.0.0.53"}},
+			wantErr: "invalid dns.servers entry",
+		},
+		{
+			name:    "negative ttl",
+			dns:     config.DNSConfig{NegativeTTL: -time.Second},
+			wantErr: "cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				DNS: tt.dns,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateProvisioning(t *testing.T) {
+	valid := config.ProvisioningConfig{
+		Enabled:            true,
+		DefaultProvisioner: config.ProvisionerSimulator,
+		ReconcileInterval:  10 * time.Second,
+	}
+	tests := []struct {
+		name    string
+		modify  func(*config.ProvisioningConfig)
+		wantErr string
+	}{
+		{name: "simulator", modify: func(*config.ProvisioningConfig) {}},
+		{name: "disabled is not validated", modify: func(p *config.ProvisioningConfig) {
+			p.Enabled = false
+			p.DefaultProvisioner = "unknown"
+		}},
+		{name: "capi", modify: func(p *config.ProvisioningConfig) {
+			p.DefaultProvisioner = config.ProvisionerCAPI
+			p.CAPI = config.ProvisioningCAPIConfig{Enabled: true, Namespace: "clusters"}
+		}},
+		{
+			name:    "unknown provisioner",
+			modify:  func(p *config.ProvisioningConfig) { p.DefaultProvisioner = "metal3" },
+			wantErr: "invalid provisioning.default_provisioner",
+		},
+		{
+			name:    "capi default without capi",
+			modify:  func(p *config.ProvisioningConfig) { p.DefaultProvisioner = config.ProvisionerCAPI },
+			wantErr: "provisioning.capi.enabled is false",
+		},
+		{
+			name:    "zero interval",
+			modify:  func(p *config.ProvisioningConfig) { p.ReconcileInterval = 0 },
+			wantErr: "reconcile_interval must be positive",
+		},
+		{
+			name: "invalid callback",
+			modify: func(p *config.ProvisioningConfig) {
+				p.Notifications.Callbacks = []string{"smo.example.com/notify"}
+			},
+			wantErr: "invalid provisioning.notifications.callbacks entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioning := valid
+			tt.modify(&provisioning)
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Provisioning: provisioning,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDMSHelmConfig_GetRepositoryPassword(t *testing.T) {
+	t.Setenv("HELM_REPO_PASSWORD", "from-env")
+	cfg := config.DMSHelmConfig{RepositoryPasswordEnvVar: "HELM_REPO_PASSWORD"}
+	pwd, err := cfg.GetRepositoryPassword()
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", pwd)
+
+	file := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0o600))
+	cfg = config.DMSHelmConfig{RepositoryPasswordFile: file}
+	pwd, err = cfg.GetRepositoryPassword()
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", pwd)
+
+	cfg = config.DMSHelmConfig{RepositoryPasswordEnvVar: "HELM_REPO_PASSWORD_UNSET"}
+	_, err = cfg.GetRepositoryPassword()
+	require.Error(t, err)
+}
+
+func TestDMSAdapterConfig_GetPassword(t *testing.T) {
+	t.Setenv("OSM_PASSWORD", "from-env")
+	cfg := config.DMSAdapterConfig{PasswordEnvVar: "OSM_PASSWORD", PasswordFile: "/nonexistent"}
+	pwd, err := cfg.GetPassword()
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", pwd)
+
+	pwd, err = (&config.DMSAdapterConfig{}).GetPassword()
+	require.NoError(t, err)
+	assert.Empty(t, pwd)
+}
+
+func TestValidatePricing(t *testing.T) {
+	tests := []struct {
+		name    string
+		pricing config.PricingConfig
+		wantErr string
+	}{
+		{name: "disabled zero value"},
+		{
+			name:    "enabled",
+			pricing: config.PricingConfig{Enabled: true, Currency: "EUR", VCPUHour: 0.04, MemoryGBHour: 0.005, GPUHour: 2.5},
+		},
+		{
+			name:    "negative price",
+			pricing: config.PricingConfig{VCPUHour: -1},
+			wantErr: "pricing.vcpu_hour",
+		},
+		{
+			name:    "invalid currency",
+			pricing: config.PricingConfig{Enabled: true, Currency: "usd"},
+			wantErr: "pricing.currency",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Pricing: tt.pricing,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSubscriptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		subscriptions config.SubscriptionsConfig
+		wantErr       string
+	}{
+		{name: "zero value"},
+		{
+			name:          "allow",
+			subscriptions: config.SubscriptionsConfig{DuplicatePolicy: config.SubscriptionDuplicatesAllow},
+		},
+		{
+			name:          "reject",
+			subscriptions: config.SubscriptionsConfig{DuplicatePolicy: config.SubscriptionDuplicatesReject},
+		},
+		{
+			name:          "merge",
+			subscriptions: config.SubscriptionsConfig{DuplicatePolicy: config.SubscriptionDuplicatesMerge},
+		},
+		{
+			name:          "unknown",
+			subscriptions: config.SubscriptionsConfig{DuplicatePolicy: "dedupe"},
+			wantErr:       "subscriptions.duplicate_policy",
+		},
+		{
+			name:          "expiry",
+			subscriptions: config.SubscriptionsConfig{MaxTTL: 30 * 24 * time.Hour, ExpiryCheckInterval: time.Minute},
+		},
+		{
+			name:          "negative max TTL",
+			subscriptions: config.SubscriptionsConfig{MaxTTL: -time.Hour},
+			wantErr:       "subscriptions.max_ttl cannot be negative",
+		},
+		{
+			name:          "negative expiry check interval",
+			subscriptions: config.SubscriptionsConfig{ExpiryCheckInterval: -time.Minute},
+			wantErr:       "subscriptions.expiry_check_interval cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Subscriptions: tt.subscriptions,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateCallbackPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		security config.SecurityConfig
+		wantErr  string
+	}{
+		{name: "zero value"},
+		{
+			name: "valid lists",
+			security: config.SecurityConfig{
+				CallbackAllowlist:            []string{"smo.example.com", "*.example.com", "198.51.100.0/24"},
+				CallbackDenylist:             []string{"203.0.113.7", "2001:db8::/32"},
+				CallbackRevalidationInterval: time.Hour,
+			},
+		},
+		{
+			name:     "empty entry",
+			security: config.SecurityConfig{CallbackDenylist: []string{""}},
+			wantErr:  "security.callback_denylist[0]",
+		},
+		{
+			name:     "invalid CIDR",
+			security: config.SecurityConfig{CallbackAllowlist: []string{"10.0.0.0/33"}},
+			wantErr:  "security.callback_allowlist[0]",
+		},
+		{
+			name:     "inner wildcard",
+			security: config.SecurityConfig{CallbackDenylist: []string{"smo.*.example.com"}},
+			wantErr:  "security.callback_denylist[0]",
+		},
+		{
+			name:     "URL instead of host",
+			security: config.SecurityConfig{CallbackDenylist: []string{"https://smo.example.com"}},
+			wantErr:  "security.callback_denylist[0]",
+		},
+		{
+			name:     "negative interval",
+			security: config.SecurityConfig{CallbackRevalidationInterval: -time.Minute},
+			wantErr:  "security.callback_revalidation_interval",
 		},
 	}
 
-	err := cfg.Validate()
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid gin_mode")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Security: tt.security,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateNotifications(t *testing.T) {
+	tests := []struct {
+		name          string
+		notifications config.NotificationsConfig
+		wantErr       bool
+	}{
+		{name: "zero value"},
+		{
+			name: "valid",
+			notifications: config.NotificationsConfig{
+				Enabled: true, Workers: 4, Timeout: 5 * time.Second, MaxRetries: 2,
+				RetryBackoff: time.Second, MaxBackoff: time.Minute,
+			},
+		},
+		{name: "negative workers", notifications: config.NotificationsConfig{Workers: -1}, wantErr: true},
+		{name: "negative retries", notifications: config.NotificationsConfig{MaxRetries: -1}, wantErr: true},
+		{name: "negative timeout", notifications: config.NotificationsConfig{Timeout: -time.Second}, wantErr: true},
+		{
+			name:          "negative busy event rate",
+			notifications: config.NotificationsConfig{BusyEventsPerMinute: -1},
+			wantErr:       true,
+		},
+		{
+			name:          "negative ordering timeout",
+			notifications: config.NotificationsConfig{OrderingTimeout: -time.Second},
+			wantErr:       true,
+		},
+		{
+			name:          "backoff above maximum",
+			notifications: config.NotificationsConfig{RetryBackoff: time.Minute, MaxBackoff: time.Second},
+			wantErr:       true,
+		},
+		{
+			name:          "deliverability window below a minute",
+			notifications: config.NotificationsConfig{DeliverabilityWindow: 30 * time.Second},
+			wantErr:       true,
+		},
+		{
+			name:          "digest check interval above an hour",
+			notifications: config.NotificationsConfig{DigestCheckInterval: 2 * time.Hour},
+			wantErr:       true,
+		},
+		{
+			name:          "generated CA",
+			notifications: config.NotificationsConfig{CA: config.WebhookCAConfig{Enabled: true}},
+		},
+		{
+			name: "CA cert file without key file",
+			notifications: config.NotificationsConfig{
+				CA: config.WebhookCAConfig{Enabled: true, CertFile: "ca.crt"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing CA files",
+			notifications: config.NotificationsConfig{
+				CA: config.WebhookCAConfig{
+					Enabled: true, CertFile: "/nonexistent/ca.crt", KeyFile: "/nonexistent/ca.key",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "CA cert validity above a year",
+			notifications: config.NotificationsConfig{
+				CA: config.WebhookCAConfig{Enabled: true, CertValidity: 400 * 24 * time.Hour},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Notifications: tt.notifications,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "notifications.")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
 }
 
 // TestValidateInvalidRedisMode tests validation with invalid redis mode.
@@ -408,6 +1877,31 @@ func TestValidateInvalidLoggingFormat(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid logging format")
 }
 
+// TestValidateInvalidLogRedactionPattern tests validation with an invalid redaction pattern.
+func TestValidateInvalidLogRedactionPattern(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Port:    8080,
+			GinMode: "release",
+		},
+		Redis: config.RedisConfig{
+			Mode:      "standalone",
+			Addresses: []string{"localhost:6379"},
+		},
+		Observability: config.ObservabilityConfig{
+			Logging: config.LoggingConfig{
+				Level:     "info",
+				Format:    "json",
+				Redaction: config.LogRedactionConfig{Enabled: true, Patterns: []string{"ssn-[0-9"}},
+			},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid logging redaction pattern")
+}
+
 // TestValidateInvalidMetricsPort tests validation with invalid metrics port.
 func TestValidateInvalidMetricsPort(t *testing.T) {
 	cfg := &config.Config{
@@ -562,6 +2056,236 @@ func TestValidateInvalidRateLimitBurstSize(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid tenant burst_size")
 }
 
+// TestValidateRateLimitClientsAndRouteGroups tests validation of per-client and route group rate limits.
+func TestValidateRateLimitClientsAndRouteGroups(t *testing.T) {
+	tests := []struct {
+		name      string
+		rateLimit config.RateLimitConfig
+		wantErr   string
+	}{
+		{
+			name: "valid",
+			rateLimit: config.RateLimitConfig{
+				PerClient: config.ClientRateLimitConfig{RequestsPerSecond: 10, BurstSize: 20},
+				RouteGroups: []config.RouteGroupRateLimitConfig{
+					{Name: "dms", PathPrefix: "/o2dms", RequestsPerSecond: 50, BurstSize: 100},
+				},
+			},
+		},
+		{
+			name:      "negative client rate",
+			rateLimit: config.RateLimitConfig{PerClient: config.ClientRateLimitConfig{RequestsPerSecond: -1}},
+			wantErr:   "invalid client requests_per_second",
+		},
+		{
+			name: "missing group name",
+			rateLimit: config.RateLimitConfig{RouteGroups: []config.RouteGroupRateLimitConfig{
+				{PathPrefix: "/o2dms", RequestsPerSecond: 1},
+			}},
+			wantErr: "route_groups[0] name cannot be empty",
+		},
+		{
+			name: "duplicate group name",
+			rateLimit: config.RateLimitConfig{RouteGroups: []config.RouteGroupRateLimitConfig{
+				{Name: "api", PathPrefix: "/o2dms", RequestsPerSecond: 1},
+				{Name: "api", PathPrefix: "/o2ims", RequestsPerSecond: 1},
+			}},
+			wantErr: "route_groups[1] name \"api\" is not unique",
+		},
+		{
+			name: "relative path prefix",
+			rateLimit: config.RateLimitConfig{RouteGroups: []config.RouteGroupRateLimitConfig{
+				{Name: "dms", PathPrefix: "o2dms", RequestsPerSecond: 1},
+			}},
+			wantErr: "must start with /",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Security: config.SecurityConfig{RateLimitEnabled: true, RateLimit: tt.rateLimit},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateStrictSpec(t *testing.T) {
+	const globalCloudID = "0f3c8a51-6a8f-4b4e-9a57-1d2b0c7e5f10"
+	tests := []struct {
+		name    string
+		strict  config.StrictSpecConfig
+		wantErr string
+	}{
+		{name: "disabled", strict: config.StrictSpecConfig{}},
+		{
+			name: "valid",
+			strict: config.StrictSpecConfig{
+				Enabled:            true,
+				GlobalCloudID:      globalCloudID,
+				RequiredExtensions: map[string][]string{config.StrictSpecKindResourcePool: {"siteId"}},
+			},
+		},
+		{
+			name:    "missing global cloud ID",
+			strict:  config.StrictSpecConfig{Enabled: true},
+			wantErr: "global_cloud_id must be a UUID",
+		},
+		{
+			name:    "malformed global cloud ID",
+			strict:  config.StrictSpecConfig{Enabled: true, GlobalCloudID: "cloud-1"},
+			wantErr: "global_cloud_id must be a UUID",
+		},
+		{
+			name: "unknown kind",
+			strict: config.StrictSpecConfig{
+				Enabled:            true,
+				GlobalCloudID:      globalCloudID,
+				RequiredExtensions: map[string][]string{"node": {"siteId"}},
+			},
+			wantErr: "invalid validation.strict_spec.required_extensions kind \"node\"",
+		},
+		{
+			name: "empty key",
+			strict: config.StrictSpecConfig{
+				Enabled:            true,
+				GlobalCloudID:      globalCloudID,
+				RequiredExtensions: map[string][]string{config.StrictSpecKindResource: {""}},
+			},
+			wantErr: "required_extensions.resource has an empty key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Validation: config.ValidationConfig{StrictSpec: tt.strict},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateValidationRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []config.ValidationRuleConfig
+		wantErr string
+	}{
+		{name: "empty", rules: nil},
+		{name: "field and plugin", rules: []config.ValidationRuleConfig{
+			{
+				Name: "site-code", Type: "field", Field: "extensions.siteCode", Required: true,
+				Pattern: `[A-Z]{3}[0-9]`, Kinds: []string{"resourcePool", "resource"},
+			},
+			{Name: "naming", Type: "plugin", Plugin: "naming", Operations: []string{"create"}, FailurePolicy: "ignore"},
+		}},
+		{
+			name:    "missing name",
+			rules:   []config.ValidationRuleConfig{{Type: "plugin", Plugin: "p"}},
+			wantErr: "validation.rules[0] name is required",
+		},
+		{
+			name: "duplicate name",
+			rules: []config.ValidationRuleConfig{
+				{Name: "r", Type: "plugin", Plugin: "p"},
+				{Name: "r", Type: "plugin", Plugin: "p"},
+			},
+			wantErr: "validation.rules[1] duplicate name",
+		},
+		{
+			name:    "field rule without field",
+			rules:   []config.ValidationRuleConfig{{Name: "r", Type: "field", Required: true}},
+			wantErr: "validation.rules[0] field is required",
+		},
+		{
+			name:    "field rule without check",
+			rules:   []config.ValidationRuleConfig{{Name: "r", Type: "field", Field: "name"}},
+			wantErr: "must set at least one of",
+		},
+		{
+			name:    "invalid pattern",
+			rules:   []config.ValidationRuleConfig{{Name: "r", Type: "field", Field: "name", Pattern: "("}},
+			wantErr: "validation.rules[0] invalid pattern",
+		},
+		{
+			name:    "plugin without name",
+			rules:   []config.ValidationRuleConfig{{Name: "r", Type: "plugin"}},
+			wantErr: "plugin name is required",
+		},
+		{
+			name:    "invalid type",
+			rules:   []config.ValidationRuleConfig{{Name: "r", Type: "wasm"}},
+			wantErr: "invalid type \"wasm\"",
+		},
+		{
+			name: "invalid kind",
+			rules: []config.ValidationRuleConfig{
+				{Name: "r", Type: "plugin", Plugin: "p", Kinds: []string{"subscription"}},
+			},
+			wantErr: "invalid kind \"subscription\"",
+		},
+		{
+			name: "invalid operation",
+			rules: []config.ValidationRuleConfig{
+				{Name: "r", Type: "plugin", Plugin: "p", Operations: []string{"delete"}},
+			},
+			wantErr: "invalid operation \"delete\"",
+		},
+		{
+			name:    "invalid failure policy",
+			rules:   []config.ValidationRuleConfig{{Name: "r", Type: "plugin", Plugin: "p", FailurePolicy: "abort"}},
+			wantErr: "invalid failure_policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				Observability: config.ObservabilityConfig{
+					Logging: config.LoggingConfig{Level: "info", Format: "json"},
+				},
+				Validation: config.ValidationConfig{Rules: tt.rules},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 // TestValidateTLSConfig tests TLS-specific validation.
 func TestValidateTLSConfig(t *testing.T) {
 	// Create temporary TLS files for testing
@@ -741,6 +2465,46 @@ func TestValidateTLSConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "SPIFFE without verified client certificates",
+			config: &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				TLS: config.TLSConfig{
+					Enabled:    true,
+					CertFile:   certFile,
+					KeyFile:    keyFile,
+					CAFile:     caFile,
+					ClientAuth: "require",
+					MinVersion: "1.3",
+					SPIFFE:     config.SPIFFEConfig{Enabled: true, TrustDomain: "example.org"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "tls spiffe requires client_auth",
+		},
+		{
+			name: "SPIFFE allowed ID outside the trust domain",
+			config: &config.Config{
+				Server: config.ServerConfig{Port: 8080, GinMode: "release"},
+				Redis:  config.RedisConfig{Mode: "standalone", Addresses: []string{"localhost:6379"}},
+				TLS: config.TLSConfig{
+					Enabled:    true,
+					CertFile:   certFile,
+					KeyFile:    keyFile,
+					CAFile:     caFile,
+					ClientAuth: "require-and-verify",
+					MinVersion: "1.3",
+					SPIFFE: config.SPIFFEConfig{
+						Enabled:     true,
+						TrustDomain: "example.org",
+						AllowedIDs:  []string{"spiffe://other.org/smo"},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "not in trust domain",
+		},
 	}
 
 	for _, tt := range tests {
@@ -790,6 +2554,7 @@ redis:
 	assert.Equal(t, float32(50.0), cfg.Kubernetes.QPS)
 	assert.Equal(t, 100, cfg.Kubernetes.Burst)
 	assert.True(t, cfg.Kubernetes.EnableWatch)
+	assert.True(t, cfg.Observability.Logging.Redaction.Enabled)
 
 	assert.False(t, cfg.TLS.Enabled)
 	assert.Equal(t, "1.3", cfg.TLS.MinVersion)
@@ -798,10 +2563,36 @@ redis:
 	assert.Equal(t, "json", cfg.Observability.Logging.Format)
 	assert.True(t, cfg.Observability.Metrics.Enabled)
 	assert.Equal(t, "/metrics", cfg.Observability.Metrics.Path)
+	assert.False(t, cfg.Observability.Metrics.IsUsingDeprecatedSubsystem())
 
 	assert.True(t, cfg.Security.RateLimitEnabled)
 	assert.Equal(t, 1000, cfg.Security.RateLimit.PerTenant.RequestsPerSecond)
 	assert.Equal(t, 2000, cfg.Security.RateLimit.PerTenant.BurstSize)
+
+	assert.Equal(t, config.DMSStorageMemory, cfg.DMS.Storage.Backend)
+	assert.True(t, cfg.DMS.Jobs.Enabled)
+	assert.Equal(t, 24*time.Hour, cfg.DMS.Jobs.Retention)
+	assert.Equal(t, 30*time.Second, cfg.DMS.Registrations.SyncInterval)
+	assert.False(t, cfg.Pricing.Enabled)
+	assert.Equal(t, "USD", cfg.Pricing.Currency)
+	assert.Equal(t, config.SubscriptionDuplicatesAllow, cfg.Subscriptions.DuplicatePolicy)
+	assert.Equal(t, time.Minute, cfg.Subscriptions.ExpiryCheckInterval)
+	assert.True(t, cfg.Notifications.Enabled)
+	assert.Equal(t, 10, cfg.Notifications.Workers)
+	assert.Equal(t, 3, cfg.Notifications.MaxRetries)
+	assert.Equal(t, 5*time.Minute, cfg.Notifications.MaxBackoff)
+	assert.Equal(t, 2*time.Minute, cfg.Notifications.OrderingTimeout)
+	assert.Equal(t, 600, cfg.Notifications.BusyEventsPerMinute)
+	assert.Equal(t, time.Hour, cfg.Notifications.DeliverabilityWindow)
+	assert.Equal(t, time.Minute, cfg.Notifications.DigestCheckInterval)
+	assert.False(t, cfg.Notifications.CA.Enabled)
+	assert.Equal(t, 90*24*time.Hour, cfg.Notifications.CA.CertValidity)
+	assert.True(t, cfg.Redis.ReadFallback.Enabled)
+	assert.Equal(t, 5*time.Minute, cfg.Redis.ReadFallback.MaxStaleness)
+	assert.Equal(t, 10*time.Second, cfg.Redis.PoolStatsInterval)
+	assert.False(t, cfg.Redis.PoolAutoResize.Enabled)
+	assert.Equal(t, 3, cfg.Redis.PoolAutoResize.SustainedIntervals)
+	assert.Equal(t, config.GatewayModeIMSAndDMS, cfg.Server.Mode)
 }
 
 // TestRedisConfig_GetPassword tests the GetPassword method with various configurations.
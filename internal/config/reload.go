@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// reloadableKeys are the settings applied when the configuration is reloaded
+// while the gateway runs.
+var reloadableKeys = []string{
+	"observability.logging.level",
+	"observability.metrics.enabled",
+	"server.request_timeout",
+	"security.rate_limit.tenant.requests_per_second",
+	"security.rate_limit.tenant.burst_size",
+	"security.rate_limit.global.requests_per_second",
+	"multi_tenancy.skip_auth_paths",
+	"multi_tenancy.auth_policies",
+	"security.disable_ssrf_protection",
+	"security.callback_allowlist",
+	"security.callback_denylist",
+}
+
+// ReloadableKeys returns the dotted mapstructure keys of the settings that
+// take effect when the configuration is reloaded. Changes to any other
+// setting take effect on the next restart.
+func ReloadableKeys() []string {
+	return slices.Clone(reloadableKeys)
+}
+
+// IsReloadable reports whether a change to the dotted mapstructure key takes
+// effect when the configuration is reloaded.
+func IsReloadable(key string) bool {
+	return slices.Contains(reloadableKeys, key)
+}
+
+// WithReloadableSettings returns a copy of running with the reloadable
+// settings of updated: the configuration in effect after updated is reloaded.
+func WithReloadableSettings(running, updated *Config) *Config {
+	next := *running
+	next.Observability.Logging.Level = updated.Observability.Logging.Level
+	next.Observability.Metrics.Enabled = updated.Observability.Metrics.Enabled
+	next.Server.RequestTimeout = updated.Server.RequestTimeout
+	next.Security.RateLimit.PerTenant.RequestsPerSecond = updated.Security.RateLimit.PerTenant.RequestsPerSecond
+	next.Security.RateLimit.PerTenant.BurstSize = updated.Security.RateLimit.PerTenant.BurstSize
+	next.Security.RateLimit.Global.RequestsPerSecond = updated.Security.RateLimit.Global.RequestsPerSecond
+	next.MultiTenancy.SkipAuthPaths = updated.MultiTenancy.SkipAuthPaths
+	next.MultiTenancy.AuthPolicies = updated.MultiTenancy.AuthPolicies
+	next.Security.DisableSSRFProtection = updated.Security.DisableSSRFProtection
+	next.Security.CallbackAllowlist = updated.Security.CallbackAllowlist
+	next.Security.CallbackDenylist = updated.Security.CallbackDenylist
+	return &next
+}
+
+// FilePath returns the configuration file Load reads for configPath: the
+// resolved path, or else the first config.yaml of the default locations.
+// It returns "" when the configuration comes from the environment only.
+func FilePath(configPath string) string {
+	if resolved := resolveConfigPath(configPath); resolved != "" {
+		return resolved
+	}
+	for _, dir := range []string{"./config", ".", "/etc/netweave"} {
+		candidate := filepath.Join(dir, "config.yaml")
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/config"
+)
+
+func TestChangedKeys(t *testing.T) {
+	old := &config.Config{
+		Server: config.ServerConfig{Port: 8080},
+		Redis:  config.RedisConfig{Password: "s3cret"},
+	}
+	updated := *old
+	updated.Server.Port = 9090
+	updated.Redis.Password = "n3w"
+
+	assert.Empty(t, config.ChangedKeys(old, old))
+	assert.Equal(t, []string{"redis.password", "server.port"}, config.ChangedKeys(old, &updated),
+		"changed secrets are detected although they are redacted")
+}
+
+func TestWithReloadableSettings(t *testing.T) {
+	running := &config.Config{
+		Server: config.ServerConfig{Port: 8080, RequestTimeout: 30 * time.Second},
+		Observability: config.ObservabilityConfig{
+			Logging: config.LoggingConfig{Level: "info"},
+			Metrics: config.MetricsConfig{Enabled: true},
+		},
+	}
+	updated := &config.Config{
+		Server: config.ServerConfig{Port: 9090, RequestTimeout: 10 * time.Second},
+		Observability: config.ObservabilityConfig{
+			Logging: config.LoggingConfig{Level: "debug"},
+		},
+		Security: config.SecurityConfig{
+			DisableSSRFProtection: true,
+			CallbackAllowlist:     []string{"*.example.com"},
+			CallbackDenylist:      []string{"10.0.0.0/8"},
+			RateLimit: config.RateLimitConfig{
+				PerTenant: config.TenantRateLimitConfig{RequestsPerSecond: 5, BurstSize: 10},
+				Global:    config.GlobalRateLimitConfig{RequestsPerSecond: 50},
+			},
+		},
+		MultiTenancy: config.MultiTenancyConfig{
+			SkipAuthPaths: []string{"/version"},
+			AuthPolicies:  []config.AuthPolicyConfig{{Paths: []string{"/docs"}, Level: "anonymous"}},
+		},
+	}
+
+	next := config.WithReloadableSettings(running, updated)
+
+	// Every reloadable setting is taken over, and only those.
+	remaining := config.ChangedKeys(next, updated)
+	assert.Equal(t, []string{"server.port"}, remaining)
+	for _, key := range config.ReloadableKeys() {
+		assert.True(t, config.IsReloadable(key))
+		assert.NotContains(t, remaining, key)
+	}
+	assert.False(t, config.IsReloadable("server.port"))
+	assert.Equal(t, 8080, running.Server.Port, "the running configuration is not modified")
+	assert.Equal(t, "info", running.Observability.Logging.Level)
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/piwi3910/netweave/internal/redact"
+)
+
+// RedactedValue replaces sensitive values in configuration snapshots.
+const RedactedValue = redact.Value
+
+// Redacted returns cfg as nested maps keyed by the configuration file keys,
+// with the values of fields tagged redact:"true" replaced by RedactedValue.
+// It is what the configuration printer and GET /admin/config show.
+func Redacted(cfg *Config) map[string]interface{} {
+	if cfg == nil {
+		return map[string]interface{}{}
+	}
+	out, _ := redact.Tree(cfg, "mapstructure").(map[string]interface{})
+	return out
+}
+
+// RedactedValues flattens cfg into dotted mapstructure keys (e.g. "server.port")
+// with string values. The values of fields tagged redact:"true" are replaced
+// with RedactedValue so the result is safe to persist and expose via admin APIs.
+func RedactedValues(cfg *Config) map[string]string {
+	values := make(map[string]string)
+	if cfg == nil {
+		return values
+	}
+	flattenValue("", reflect.ValueOf(*cfg), false, true, values)
+	return values
+}
+
+// ChangedKeys returns the sorted dotted mapstructure keys whose values differ
+// between old and updated. Unlike RedactedValues, changes to redacted fields
+// are detected.
+func ChangedKeys(old, updated *Config) []string {
+	before := make(map[string]string)
+	after := make(map[string]string)
+	flattenValue("", reflect.ValueOf(*old), false, false, before)
+	flattenValue("", reflect.ValueOf(*updated), false, false, after)
+
+	var keys []string
+	for key, value := range after {
+		if before[key] != value {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsRedactedKey reports whether the dotted mapstructure key (e.g.
+// "redis.password") names a field tagged redact:"true", or a value inside one.
+func IsRedactedKey(key string) bool {
+	typ := reflect.TypeOf(Config{})
+	for _, name := range strings.Split(key, ".") {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+		field, ok := fieldByTag(typ, name)
+		if !ok {
+			return false
+		}
+		if redact.Sensitive(field) {
+			return true
+		}
+		typ = field.Type
+	}
+	return false
+}
+
+// fieldByTag returns the field of typ whose mapstructure name is name.
+func fieldByTag(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if strings.Split(field.Tag.Get("mapstructure"), ",")[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// HashValues returns a stable SHA-256 hash of flattened configuration values.
+func HashValues(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(h, "%s=%s\n", key, values[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// flattenValue adds the values of v to out. When redacting, the values of
+// fields tagged redact:"true" are replaced with RedactedValue.
+func flattenValue(prefix string, v reflect.Value, sensitive, redacting bool, out map[string]string) {
+	if sensitive {
+		out[prefix] = ""
+		if !v.IsZero() && !((v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0) {
+			out[prefix] = RedactedValue
+		}
+		return
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			out[prefix] = ""
+			return
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		out[prefix] = formatValue(v, redacting)
+		return
+	}
+
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		if !field.IsExported() || tag == "-" || tag == "" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+		flattenValue(key, v.Field(i), redacting && redact.Sensitive(field), redacting, out)
+	}
+}
+
+// formatValue returns the string form of a leaf value. Slices, arrays and
+// maps are encoded as JSON; when redacting, they go through redact.Tree, so
+// fields tagged redact:"true" in the structs they hold are masked as well.
+func formatValue(v reflect.Value, redacting bool) string {
+	switch val := v.Interface().(type) {
+	case time.Duration:
+		return val.String()
+	case string:
+		return val
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		value := v.Interface()
+		if redacting {
+			value = redact.Tree(value, "mapstructure")
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
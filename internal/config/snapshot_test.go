@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/config"
+)
+
+func TestRedactedValues(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Port:           8080,
+			ReadTimeout:    30 * time.Second,
+			TrustedProxies: []string{"10.0.0.0/8"},
+		},
+		Redis: config.RedisConfig{
+			Password:         "s3cret",
+			PasswordEnvVar:   "REDIS_PASSWORD",
+			PasswordFile:     "/run/secrets/redis",
+			SentinelPassword: "",
+		},
+		DMS: config.DMSConfig{
+			Adapters: []config.DMSAdapterConfig{
+				{Name: "helm-prod", Type: "helm", PasswordEnvVar: "HELM_PASSWORD"},
+			},
+		},
+		Environment: "prod",
+	}
+
+	values := config.RedactedValues(cfg)
+
+	assert.Equal(t, "8080", values["server.port"])
+	assert.Equal(t, "30s", values["server.read_timeout"])
+	assert.Equal(t, `["10.0.0.0/8"]`, values["server.trusted_proxies"])
+	assert.Equal(t, config.RedactedValue, values["redis.password"])
+	assert.Empty(t, values["redis.sentinel_password"], "empty secrets stay empty")
+	assert.Equal(t, "REDIS_PASSWORD", values["redis.password_env_var"])
+	assert.Equal(t, "/run/secrets/redis", values["redis.password_file"])
+	assert.NotContains(t, values, "environment", "fields without mapstructure names are skipped")
+	assert.Contains(t, values["dms.adapters"], `"password_env_var":"HELM_PASSWORD"`,
+		"structs in slices are keyed like the configuration file")
+
+	for _, value := range values {
+		assert.NotContains(t, value, "s3cret")
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &config.Config{
+		Notifications: config.NotificationsConfig{HMACSecret: "hmac-s3cret", Workers: 4},
+		Compliance: config.ComplianceConfig{
+			Headers: map[string]string{"Authorization": "Bearer tok3n"},
+		},
+	}
+
+	redacted := config.Redacted(cfg)
+	notifications, ok := redacted["notifications"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, config.RedactedValue, notifications["hmac_secret"])
+	assert.Equal(t, 4, notifications["workers"])
+
+	data, err := json.Marshal(redacted)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hmac-s3cret")
+	assert.NotContains(t, string(data), "tok3n")
+	assert.Equal(t, config.RedactedValue, config.RedactedValues(cfg)["compliance.headers"])
+}
+
+// TestSecretFieldsAreRedacted guards the redaction schema: every
+// configuration field named like a secret must be tagged redact:"true".
+func TestSecretFieldsAreRedacted(t *testing.T) {
+	secretNames := []string{"password", "secret", "token", "api_key", "private_key"}
+
+	var walk func(prefix string, typ reflect.Type)
+	walk = func(prefix string, typ reflect.Type) {
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return
+		}
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			for _, secret := range secretNames {
+				if name == secret || strings.HasSuffix(name, "_"+secret) {
+					assert.Equal(t, "true", field.Tag.Get("redact"), "%s%s is not redacted", prefix, name)
+				}
+			}
+			walk(prefix+name+".", field.Type)
+		}
+	}
+	walk("", reflect.TypeOf(config.Config{}))
+}
+
+func TestHashValues(t *testing.T) {
+	a := map[string]string{"server.port": "8080", "server.host": "0.0.0.0"}
+	b := map[string]string{"server.host": "0.0.0.0", "server.port": "8080"}
+	c := map[string]string{"server.host": "0.0.0.0", "server.port": "9090"}
+
+	assert.Equal(t, config.HashValues(a), config.HashValues(b))
+	assert.NotEqual(t, config.HashValues(a), config.HashValues(c))
+}
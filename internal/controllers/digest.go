@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	redis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/digest"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const (
+	// DigestKeyPrefix prefixes the Redis keys accumulating the events of
+	// subscriptions in digest mode.
+	DigestKeyPrefix = "o2ims:digest:"
+
+	// DigestKeyTTL is how long accumulated events are kept after the last
+	// one, beyond the longest digest interval. It only matters for deleted
+	// subscriptions, whose digests are never sent.
+	DigestKeyTTL = digest.MaxInterval + time.Hour
+
+	// DefaultDigestCheckInterval is how often subscriptions are checked for
+	// digests that are due.
+	DefaultDigestCheckInterval = time.Minute
+)
+
+// NotificationEventTypes lists the event types of resource notifications.
+var NotificationEventTypes = []string{
+	"o2ims.Resource." + string(EventTypeCreated),
+	"o2ims.Resource." + string(EventTypeUpdated),
+	"o2ims.Resource." + string(EventTypeDeleted),
+	"o2ims.ResourcePool." + string(EventTypeCreated),
+	"o2ims.ResourcePool." + string(EventTypeUpdated),
+	"o2ims.ResourcePool." + string(EventTypeDeleted),
+}
+
+// takeDigestScript returns and deletes the accumulated events of a
+// subscription once the digest period of its first event has ended, and
+// returns nil before. An interval of 0 takes the events immediately.
+var takeDigestScript = redis.NewScript(`
+local start = tonumber(redis.call('GET', KEYS[1]))
+if not start then
+	return false
+end
+local interval = tonumber(ARGV[2])
+if interval > 0 and tonumber(ARGV[1]) < start - (start % interval) + interval then
+	return false
+end
+local counts = redis.call('HGETALL', KEYS[2])
+local changes = redis.call('HGETALL', KEYS[3])
+redis.call('DEL', KEYS[1], KEYS[2], KEYS[3])
+return {tostring(start), counts, changes}
+`)
+
+// digestKeys returns the Redis keys holding the start, the event counts and
+// the changes of a subscription's pending digest. The hash tag keeps them in
+// one Redis Cluster slot.
+func digestKeys(subscriptionID string) []string {
+	prefix := DigestKeyPrefix + "{" + subscriptionID + "}:"
+	return []string{prefix + "start", prefix + "counts", prefix + "changes"}
+}
+
+// notify queues an event for delivery, or adds it to the pending digest of
+// a subscription in digest mode.
+func (c *SubscriptionController) notify(ctx context.Context, sub *storage.Subscription, event *ResourceEvent) error {
+	if sub.Digest == nil || sub.Digest.Immediate(event.EventType) {
+		if err := c.queueEvent(ctx, event); err != nil {
+			return err
+		}
+		EventsQueuedTotal.WithLabelValues(sub.ID, event.ResourceTypeID).Inc()
+		return nil
+	}
+
+	if err := c.accumulate(ctx, event); err != nil {
+		return err
+	}
+	EventsDigestedTotal.WithLabelValues(sub.ID, event.ResourceTypeID).Inc()
+	return nil
+}
+
+// accumulate adds an event to the pending digest of its subscription.
+func (c *SubscriptionController) accumulate(ctx context.Context, event *ResourceEvent) error {
+	change, err := json.Marshal(&digest.Change{
+		ObjectRef:        event.ObjectRef,
+		ResourceTypeID:   event.ResourceTypeID,
+		ResourcePoolID:   event.ResourcePoolID,
+		GlobalResourceID: event.GlobalResourceID,
+		LastEventType:    event.EventType,
+		LastChangedAt:    event.Timestamp.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest change: %w", err)
+	}
+
+	keys := digestKeys(event.SubscriptionID)
+	pipe := c.RedisClient.TxPipeline()
+	pipe.SetNX(ctx, keys[0], event.Timestamp.Unix(), 0)
+	pipe.HIncrBy(ctx, keys[1], event.EventType, 1)
+	pipe.HSet(ctx, keys[2], event.ObjectRef, change)
+	for _, key := range keys {
+		pipe.Expire(ctx, key, DigestKeyTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to add event to digest: %w", err)
+	}
+
+	c.Logger.Debug("event added to digest",
+		zap.String("subscription", event.SubscriptionID),
+		zap.String("event_type", event.EventType))
+	return nil
+}
+
+// runDigests sends the digests that are due until the controller stops.
+func (c *SubscriptionController) runDigests(ctx context.Context) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.digestCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.SendDueDigests(ctx, time.Now())
+		}
+	}
+}
+
+// SendDueDigests queues a digest notification for every subscription whose
+// digest period ended before now. Events still pending for a subscription
+// that left digest mode are sent right away. Every replica may run it: the
+// pending events of a subscription are taken atomically, so each digest is
+// sent once.
+func (c *SubscriptionController) SendDueDigests(ctx context.Context, now time.Time) {
+	subs, err := c.Store.List(ctx)
+	if err != nil {
+		c.Logger.Error("failed to list subscriptions for digests",
+			zap.Error(err))
+		return
+	}
+
+	for _, sub := range subs {
+		if err := c.sendDigest(ctx, sub, now); err != nil {
+			c.Logger.Error("failed to send digest",
+				zap.String("subscription", sub.ID),
+				zap.Error(err))
+		}
+	}
+}
+
+// sendDigest queues the digest of a subscription if it is due.
+func (c *SubscriptionController) sendDigest(ctx context.Context, sub *storage.Subscription, now time.Time) error {
+	var interval time.Duration
+	if sub.Digest != nil {
+		period, err := sub.Digest.Period()
+		if err != nil {
+			return err
+		}
+		interval = period
+	}
+
+	summary, err := c.takeDigest(ctx, sub.ID, interval, now)
+	if err != nil || summary == nil {
+		return err
+	}
+
+	event := &ResourceEvent{
+		SubscriptionID: sub.ID,
+		EventType:      digest.EventType,
+		ObjectRef:      "/o2ims-infrastructureInventory/v1/subscriptions/" + sub.ID,
+		Timestamp:      now.UTC(),
+		NotificationID: uuid.New().String(),
+		CallbackURL:    sub.Callback,
+		Transform:      sub.Transform,
+		Digest:         summary,
+	}
+	// Digests are not ordered: they never overlap and carry their period.
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest: %w", err)
+	}
+	if err := c.RedisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: EventStreamKey,
+		MaxLen: MaxStreamLength,
+		Approx: true,
+		Values: map[string]interface{}{"event": string(data)},
+	}).Err(); err != nil {
+		return fmt.Errorf("failed to add digest to stream: %w", err)
+	}
+
+	DigestsSentTotal.WithLabelValues(sub.ID).Inc()
+	c.Logger.Info("digest queued",
+		zap.String("subscription", sub.ID),
+		zap.Int64("events", summary.EventCount),
+		zap.Int("changes", len(summary.Changes)))
+	return nil
+}
+
+// takeDigest takes the pending events of a subscription if its digest is due
+// and summarizes them. It returns nil when nothing is due.
+func (c *SubscriptionController) takeDigest(
+	ctx context.Context,
+	subscriptionID string,
+	interval time.Duration,
+	now time.Time,
+) (*digest.Summary, error) {
+	result, err := takeDigestScript.Run(ctx, c.RedisClient, digestKeys(subscriptionID),
+		now.Unix(), int64(interval/time.Second)).Slice()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to take digest: %w", err)
+	}
+	if len(result) != 3 {
+		return nil, fmt.Errorf("unexpected digest result of length %d", len(result))
+	}
+
+	startUnix, err := strconv.ParseInt(fmt.Sprint(result[0]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest start: %w", err)
+	}
+	start := time.Unix(startUnix, 0)
+	end := now
+	if interval > 0 {
+		end = digest.PeriodEnd(start, interval)
+		start = end.Add(-interval)
+	}
+
+	counts := make(map[string]int64)
+	for field, value := range pairs(result[1]) {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest count of %s: %w", field, err)
+		}
+		counts[field] = count
+	}
+	var changes []digest.Change
+	for ref, value := range pairs(result[2]) {
+		var change digest.Change
+		if err := json.Unmarshal([]byte(value), &change); err != nil {
+			c.Logger.Warn("skipping invalid digest change",
+				zap.String("subscription", subscriptionID),
+				zap.String("object", ref),
+				zap.Error(err))
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	return digest.NewSummary(start, end, counts, changes), nil
+}
+
+// pairs returns the field/value pairs of an HGETALL reply.
+func pairs(reply interface{}) map[string]string {
+	values, _ := reply.([]interface{})
+	result := make(map[string]string, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		result[fmt.Sprint(values[i])] = fmt.Sprint(values[i+1])
+	}
+	return result
+}
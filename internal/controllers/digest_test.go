@@ -0,0 +1,149 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/digest"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// queuedEvents returns the events on the notification stream.
+func queuedEvents(t *testing.T, rdb *redis.Client) []controllers.ResourceEvent {
+	t.Helper()
+	msgs, err := rdb.XRange(context.Background(), controllers.EventStreamKey, "-", "+").Result()
+	require.NoError(t, err)
+	events := make([]controllers.ResourceEvent, 0, len(msgs))
+	for _, msg := range msgs {
+		var event controllers.ResourceEvent
+		require.NoError(t, json.Unmarshal([]byte(msg.Values["event"].(string)), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestSubscriptionController_Digest(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	sub := &storage.Subscription{
+		ID:       "sub-digest",
+		Callback: "https://smo.example.com/notify",
+		Digest: &digest.Settings{
+			Interval:            "1h",
+			ImmediateEventTypes: []string{"o2ims.Resource.Deleted"},
+		},
+	}
+	ctrl, err := controllers.NewSubscriptionController(&controllers.Config{
+		K8sClient:   fake.NewClientset(),
+		Store:       &mockStore{subscriptions: []*storage.Subscription{sub}},
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		OCloudID:    "test-ocloud",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	node1 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	node2 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}
+	ctrl.ProcessNodeEvent(ctx, node1, controllers.EventTypeCreated)
+	ctrl.ProcessNodeEvent(ctx, node2, controllers.EventTypeCreated)
+	ctrl.ProcessNodeEvent(ctx, node1, controllers.EventTypeUpdated)
+	ctrl.ProcessNodeEvent(ctx, node2, controllers.EventTypeDeleted)
+
+	// Only the immediate event type is delivered right away.
+	events := queuedEvents(t, rdb)
+	require.Len(t, events, 1)
+	assert.Equal(t, "o2ims.Resource.Deleted", events[0].EventType)
+
+	// The digest is sent once its period has ended.
+	now := time.Now()
+	ctrl.SendDueDigests(ctx, now)
+	require.Len(t, queuedEvents(t, rdb), 1)
+
+	ctrl.SendDueDigests(ctx, now.Add(time.Hour))
+	events = queuedEvents(t, rdb)
+	require.Len(t, events, 2)
+	sent := events[1]
+	assert.Equal(t, digest.EventType, sent.EventType)
+	assert.Equal(t, "sub-digest", sent.SubscriptionID)
+	assert.Equal(t, "https://smo.example.com/notify", sent.CallbackURL)
+	assert.Zero(t, sent.SequenceNumber)
+	require.NotNil(t, sent.Digest)
+	assert.Equal(t, int64(3), sent.Digest.EventCount)
+	assert.Equal(t, map[string]int64{
+		"o2ims.Resource.Created": 2,
+		"o2ims.Resource.Updated": 1,
+	}, sent.Digest.EventCounts)
+	assert.Equal(t, time.Hour, sent.Digest.PeriodEnd.Sub(sent.Digest.PeriodStart))
+	assert.True(t, sent.Digest.PeriodEnd.After(now))
+
+	require.Len(t, sent.Digest.Changes, 2)
+	assert.Equal(t, "/o2ims/v1/resources/node-1", sent.Digest.Changes[0].ObjectRef)
+	assert.Equal(t, "node-1", sent.Digest.Changes[0].GlobalResourceID)
+	assert.Equal(t, "o2ims.Resource.Updated", sent.Digest.Changes[0].LastEventType)
+	assert.Equal(t, "o2ims.Resource.Created", sent.Digest.Changes[1].LastEventType)
+
+	// The accumulated events are gone once sent.
+	ctrl.SendDueDigests(ctx, now.Add(2*time.Hour))
+	assert.Len(t, queuedEvents(t, rdb), 2)
+	for _, key := range mr.Keys() {
+		assert.NotContains(t, key, controllers.DigestKeyPrefix)
+	}
+}
+
+func TestSubscriptionController_DigestModeLeft(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	sub := &storage.Subscription{
+		ID:       "sub-digest",
+		Callback: "https://smo.example.com/notify",
+		Digest:   &digest.Settings{Interval: "24h"},
+	}
+	ctrl, err := controllers.NewSubscriptionController(&controllers.Config{
+		K8sClient:   fake.NewClientset(),
+		Store:       &mockStore{subscriptions: []*storage.Subscription{sub}},
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		OCloudID:    "test-ocloud",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "edge"}}
+	ctrl.ProcessNamespaceEvent(ctx, ns, controllers.EventTypeCreated)
+	assert.Empty(t, queuedEvents(t, rdb))
+
+	// Events still pending when the subscription leaves digest mode are
+	// sent at the next check.
+	sub.Digest = nil
+	ctrl.SendDueDigests(ctx, time.Now())
+	events := queuedEvents(t, rdb)
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0].Digest)
+	assert.Equal(t, map[string]int64{"o2ims.ResourcePool.Created": 1}, events[0].Digest.EventCounts)
+
+	ctrl.ProcessNamespaceEvent(ctx, ns, controllers.EventTypeUpdated)
+	events = queuedEvents(t, rdb)
+	require.Len(t, events, 2)
+	assert.Equal(t, "o2ims.ResourcePool.Updated", events[1].EventType)
+}
@@ -24,6 +24,25 @@ var (
 		[]string{"subscription_id", "resource_type"},
 	)
 
+	// EventsDigestedTotal tracks the events added to the digests of
+	// subscriptions in digest mode instead of being queued.
+	EventsDigestedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_subscription_events_digested_total",
+			Help: "Total number of subscription events accumulated for digest delivery",
+		},
+		[]string{"subscription_id", "resource_type"},
+	)
+
+	// DigestsSentTotal tracks the digest notifications queued for delivery.
+	DigestsSentTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_subscription_digests_total",
+			Help: "Total number of digest notifications queued for delivery",
+		},
+		[]string{"subscription_id"},
+	)
+
 	// ActiveSubscriptionsGauge tracks the current number of active subscriptions.
 	ActiveSubscriptionsGauge = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -32,6 +51,24 @@ var (
 		},
 	)
 
+	// EventFanOut tracks the number of subscriptions each event matched.
+	EventFanOut = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "o2ims_subscription_event_fanout",
+			Help:    "Number of subscriptions matched by each processed event",
+			Buckets: []float64{0, 1, 2, 5, 10, 25, 50, 100, 250},
+		},
+	)
+
+	// BroadSubscriptionWarningsTotal tracks the warnings about subscriptions
+	// with an empty filter in a busy cluster.
+	BroadSubscriptionWarningsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "o2ims_subscription_broad_filter_warnings_total",
+			Help: "Total number of warnings about subscriptions matching every event in a busy cluster",
+		},
+	)
+
 	// InformerSyncDuration tracks the time taken for informer cache sync.
 	InformerSyncDuration = promauto.NewHistogram(
 		prometheus.HistogramOpts{
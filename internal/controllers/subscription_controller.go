@@ -18,7 +18,9 @@ import (
 	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/piwi3910/netweave/internal/digest"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/transform"
 )
 
 const (
@@ -30,6 +32,14 @@ const (
 
 	// InformerResyncPeriod is the resync interval for Kubernetes informers.
 	InformerResyncPeriod = 30 * time.Second
+
+	// SequenceKeyPrefix prefixes the Redis counters that number the events of
+	// each ordering key.
+	SequenceKeyPrefix = "o2ims:events:seq:"
+
+	// SequenceTTL is how long an idle ordering key keeps its sequence. A key
+	// with no events for this long starts again at 1.
+	SequenceTTL = 24 * time.Hour
 )
 
 // EventType represents the type of resource event.
@@ -74,6 +84,28 @@ type ResourceEvent struct {
 
 	// CallbackURL is the webhook endpoint to deliver to.
 	CallbackURL string `json:"callbackUrl"`
+
+	// Transform is the transformation of the subscription, applied by the
+	// webhook worker. It is never part of the delivered notification.
+	Transform *transform.Template `json:"transform,omitempty"`
+
+	// SequenceNumber numbers the events of one resource for one subscription,
+	// starting at 1. Events are delivered in sequence order; consumers can use
+	// it to detect gaps. Zero means the event is not ordered.
+	SequenceNumber int64 `json:"sequenceNumber,omitempty"`
+
+	// Extensions carries event-specific details that have no dedicated field,
+	// such as the activation time of a signing secret rotation.
+	Extensions map[string]string `json:"extensions,omitempty"`
+
+	// Digest is the summary carried by digest notifications.
+	Digest *digest.Summary `json:"digest,omitempty"`
+}
+
+// OrderingKey identifies the events that must be delivered in order: those
+// of one resource for one subscription.
+func (e *ResourceEvent) OrderingKey() string {
+	return e.SubscriptionID + "/" + e.ResourceTypeID + "/" + e.GlobalResourceID
 }
 
 // SubscriptionController watches Kubernetes resources and delivers webhook notifications.
@@ -85,7 +117,7 @@ type SubscriptionController struct {
 	Store storage.Store // Exported for testing
 
 	// redisClient is used for event queue operations.
-	RedisClient *redis.Client // Exported for testing
+	RedisClient redis.UniversalClient // Exported for testing
 
 	// logger provides structured logging.
 	Logger *zap.Logger // Exported for testing
@@ -93,6 +125,19 @@ type SubscriptionController struct {
 	// oCloudID is the identifier of the parent O-Cloud.
 	OCloudID string // Exported for testing
 
+	// Stats records which subscriptions match each event (optional).
+	Stats storage.SubscriptionStatsStore
+
+	// BusyEventsPerMinute is the event rate from which subscriptions with an
+	// empty filter are warned about; 0 disables the warning.
+	BusyEventsPerMinute int
+
+	// matches measures the event rate for broad filter warnings.
+	matches matchTracker
+
+	// digestCheckInterval is how often due digests are sent.
+	digestCheckInterval time.Duration
+
 	// informerFactory creates Kubernetes informers.
 	informerFactory informers.SharedInformerFactory
 
@@ -112,13 +157,29 @@ type Config struct {
 	Store storage.Store
 
 	// RedisClient is used for event queue operations.
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
 
 	// Logger is the logger to use.
 	Logger *zap.Logger
 
 	// OCloudID is the identifier of the parent O-Cloud.
 	OCloudID string
+
+	// InformerFactory is an already running informer factory to share, such
+	// as the Kubernetes adapter's (optional). Sharing it avoids a second
+	// watch on nodes and namespaces. If nil, the controller creates its own.
+	InformerFactory informers.SharedInformerFactory
+
+	// Stats records which subscriptions match each event (optional).
+	Stats storage.SubscriptionStatsStore
+
+	// BusyEventsPerMinute is the event rate from which subscriptions with an
+	// empty filter are warned about; 0 disables the warning.
+	BusyEventsPerMinute int
+
+	// DigestCheckInterval is how often subscriptions in digest mode are
+	// checked for digests that are due (default: 1m).
+	DigestCheckInterval time.Duration
 }
 
 // NewSubscriptionController creates a new SubscriptionController.
@@ -142,16 +203,27 @@ func NewSubscriptionController(cfg *Config) (*SubscriptionController, error) {
 		return nil, fmt.Errorf("oCloudID cannot be empty")
 	}
 
-	factory := informers.NewSharedInformerFactory(cfg.K8sClient, InformerResyncPeriod)
+	factory := cfg.InformerFactory
+	if factory == nil {
+		factory = informers.NewSharedInformerFactory(cfg.K8sClient, InformerResyncPeriod)
+	}
+
+	digestCheckInterval := cfg.DigestCheckInterval
+	if digestCheckInterval == 0 {
+		digestCheckInterval = DefaultDigestCheckInterval
+	}
 
 	return &SubscriptionController{
-		K8sClient:       cfg.K8sClient,
-		Store:           cfg.Store,
-		RedisClient:     cfg.RedisClient,
-		Logger:          cfg.Logger,
-		OCloudID:        cfg.OCloudID,
-		informerFactory: factory,
-		stopCh:          make(chan struct{}),
+		K8sClient:           cfg.K8sClient,
+		Store:               cfg.Store,
+		RedisClient:         cfg.RedisClient,
+		Logger:              cfg.Logger,
+		OCloudID:            cfg.OCloudID,
+		Stats:               cfg.Stats,
+		BusyEventsPerMinute: cfg.BusyEventsPerMinute,
+		digestCheckInterval: digestCheckInterval,
+		informerFactory:     factory,
+		stopCh:              make(chan struct{}),
 	}, nil
 }
 
@@ -164,7 +236,7 @@ func (c *SubscriptionController) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to setup informers: %w", err)
 	}
 
-	// Start informers
+	// Start informers (those of a shared factory that already run are left as they are)
 	c.informerFactory.Start(c.stopCh)
 
 	// Wait for informer caches to sync
@@ -176,6 +248,10 @@ func (c *SubscriptionController) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
+	// Send the digests of subscriptions in digest mode
+	c.wg.Add(1)
+	go c.runDigests(ctx)
+
 	c.Logger.Info("subscription controller started successfully")
 
 	// Wait for context cancellation
@@ -377,8 +453,10 @@ func (c *SubscriptionController) ProcessNodeEvent(ctx context.Context, node *cor
 	}
 
 	// Find matching subscriptions and queue events
+	var matched []*storage.Subscription
 	for _, sub := range subs {
 		if c.MatchesFilter(sub, "k8s-node", resourcePoolID, node.Name) {
+			matched = append(matched, sub)
 			event := &ResourceEvent{
 				SubscriptionID:   sub.ID,
 				EventType:        fmt.Sprintf("o2ims.Resource.%s", eventType),
@@ -389,18 +467,17 @@ func (c *SubscriptionController) ProcessNodeEvent(ctx context.Context, node *cor
 				Timestamp:        time.Now(),
 				NotificationID:   fmt.Sprintf("notif-%s-%d", node.Name, time.Now().UnixNano()),
 				CallbackURL:      sub.Callback,
+				Transform:        sub.Transform,
 			}
 
-			if err := c.queueEvent(ctx, event); err != nil {
+			if err := c.notify(ctx, sub, event); err != nil {
 				c.Logger.Error("failed to queue event",
 					zap.Error(err),
 					zap.String("subscription", sub.ID))
-			} else {
-				// Track queued event
-				EventsQueuedTotal.WithLabelValues(sub.ID, "k8s-node").Inc()
 			}
 		}
 	}
+	c.recordMatches(ctx, matched)
 }
 
 // ProcessNamespaceEvent finds matching subscriptions and queues webhook notifications.
@@ -420,8 +497,10 @@ func (c *SubscriptionController) ProcessNamespaceEvent(ctx context.Context, ns *
 	ActiveSubscriptionsGauge.Set(float64(len(subs)))
 
 	// Find matching subscriptions and queue events
+	var matched []*storage.Subscription
 	for _, sub := range subs {
 		if c.MatchesFilter(sub, "k8s-namespace", "", ns.Name) {
+			matched = append(matched, sub)
 			event := &ResourceEvent{
 				SubscriptionID:   sub.ID,
 				EventType:        fmt.Sprintf("o2ims.ResourcePool.%s", eventType),
@@ -431,30 +510,41 @@ func (c *SubscriptionController) ProcessNamespaceEvent(ctx context.Context, ns *
 				Timestamp:        time.Now(),
 				NotificationID:   fmt.Sprintf("notif-%s-%d", ns.Name, time.Now().UnixNano()),
 				CallbackURL:      sub.Callback,
+				Transform:        sub.Transform,
 			}
 
-			if err := c.queueEvent(ctx, event); err != nil {
+			if err := c.notify(ctx, sub, event); err != nil {
 				c.Logger.Error("failed to queue event",
 					zap.Error(err),
 					zap.String("subscription", sub.ID))
-			} else {
-				// Track queued event
-				EventsQueuedTotal.WithLabelValues(sub.ID, "k8s-namespace").Inc()
 			}
 		}
 	}
+	c.recordMatches(ctx, matched)
 }
 
 // MatchesFilter checks if a resource matches the subscription filter.
+// Suspended and expired subscriptions match nothing.
 func (c *SubscriptionController) MatchesFilter(
 	sub *storage.Subscription,
 	resourceTypeID, resourcePoolID, resourceID string,
 ) bool {
-	return sub.Filter.MatchesFilter(resourcePoolID, resourceTypeID, resourceID)
+	return !sub.Suspended() && !sub.Expired(time.Now()) &&
+		sub.Filter.MatchesFilter(resourcePoolID, resourceTypeID, resourceID)
 }
 
-// queueEvent adds an event to the Redis Stream for webhook delivery.
+// queueEvent numbers an event within its ordering key and adds it to the
+// Redis Stream for webhook delivery. If numbering fails the event is queued
+// unordered rather than dropped.
 func (c *SubscriptionController) queueEvent(ctx context.Context, event *ResourceEvent) error {
+	seq, err := c.nextSequence(ctx, event.OrderingKey())
+	if err != nil {
+		c.Logger.Warn("failed to assign event sequence number; queueing unordered",
+			zap.String("subscription", event.SubscriptionID),
+			zap.Error(err))
+	}
+	event.SequenceNumber = seq
+
 	// Marshal event to JSON
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -482,6 +572,17 @@ func (c *SubscriptionController) queueEvent(ctx context.Context, event *Resource
 	return nil
 }
 
+// nextSequence returns the next sequence number of an ordering key.
+func (c *SubscriptionController) nextSequence(ctx context.Context, key string) (int64, error) {
+	pipe := c.RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, SequenceKeyPrefix+key)
+	pipe.Expire(ctx, SequenceKeyPrefix+key, SequenceTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to increment sequence: %w", err)
+	}
+	return incr.Val(), nil
+}
+
 // GetNodeByName retrieves a Kubernetes node by name (helper for testing).
 func (c *SubscriptionController) GetNodeByName(ctx context.Context, name string) (*corev1.Node, error) {
 	node, err := c.K8sClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
@@ -12,7 +12,9 @@ import (
 	redis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -201,6 +203,53 @@ func TestSubscriptionController_ProcessNodeEvent(t *testing.T) {
 	assert.Equal(t, "https://smo.example.com/notify", event.CallbackURL)
 }
 
+func TestSubscriptionController_SequenceNumbers(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	store := &mockStore{
+		subscriptions: []*storage.Subscription{
+			{ID: "sub-123", Callback: "https://smo.example.com/notify"},
+		},
+	}
+	ctrl, err := controllers.NewSubscriptionController(&controllers.Config{
+		K8sClient:   fake.NewClientset(),
+		Store:       store,
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		OCloudID:    "test-ocloud",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	node1 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	node2 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}
+	ctrl.ProcessNodeEvent(ctx, node1, controllers.EventTypeCreated)
+	ctrl.ProcessNodeEvent(ctx, node2, controllers.EventTypeCreated)
+	ctrl.ProcessNodeEvent(ctx, node1, controllers.EventTypeUpdated)
+
+	streams, err := rdb.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{controllers.EventStreamKey, "0"},
+	}).Result()
+	require.NoError(t, err)
+	require.Len(t, streams, 1)
+
+	sequences := make(map[string][]int64)
+	for _, msg := range streams[0].Messages {
+		var event controllers.ResourceEvent
+		require.NoError(t, json.Unmarshal([]byte(msg.Values["event"].(string)), &event))
+		sequences[event.GlobalResourceID] = append(sequences[event.GlobalResourceID], event.SequenceNumber)
+	}
+
+	// Sequence numbers count per subscription and resource.
+	assert.Equal(t, []int64{1, 2}, sequences["node-1"])
+	assert.Equal(t, []int64{1}, sequences["node-2"])
+	assert.Positive(t, mr.TTL(controllers.SequenceKeyPrefix+"sub-123/k8s-node/node-1"))
+}
+
 func TestSubscriptionController_ProcessNamespaceEvent(t *testing.T) {
 	// Setup miniredis for event queue
 	mr := miniredis.RunT(t)
@@ -276,6 +325,53 @@ func TestSubscriptionController_ProcessNamespaceEvent(t *testing.T) {
 	assert.Equal(t, "https://smo.example.com/notify", event.CallbackURL)
 }
 
+func TestSubscriptionController_SubscriptionStats(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	broad := &storage.Subscription{ID: "sub-all", Callback: "https://smo.example.com/all"}
+	narrow := &storage.Subscription{
+		ID:       "sub-ns",
+		Callback: "https://smo.example.com/ns",
+		Filter:   storage.SubscriptionFilter{ResourceID: "ns-1"},
+	}
+	stats := storage.NewInMemorySubscriptionStatsStore()
+	core, logs := observer.New(zap.WarnLevel)
+
+	ctrl, err := controllers.NewSubscriptionController(&controllers.Config{
+		K8sClient:           fake.NewClientset(),
+		Store:               &mockStore{subscriptions: []*storage.Subscription{broad, narrow}},
+		RedisClient:         rdb,
+		Logger:              zap.New(core),
+		OCloudID:            "test-ocloud",
+		Stats:               stats,
+		BusyEventsPerMinute: 3,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, name := range []string{"ns-1", "ns-2", "ns-3", "ns-4"} {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		ctrl.ProcessNamespaceEvent(ctx, ns, controllers.EventTypeCreated)
+	}
+
+	snapshot, err := stats.Snapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), snapshot.TotalEvents)
+	assert.Equal(t, int64(4), snapshot.For("sub-all").MatchedEvents)
+	assert.InDelta(t, 1.0, snapshot.For("sub-all").MatchRatio, 0.001)
+	assert.InDelta(t, 1.25, snapshot.For("sub-all").AverageFanOut, 0.001)
+	assert.Equal(t, int64(1), snapshot.For("sub-ns").MatchedEvents)
+	assert.InDelta(t, 2.0, snapshot.For("sub-ns").AverageFanOut, 0.001)
+
+	warnings := logs.FilterMessageSnippet("empty filter").All()
+	require.Len(t, warnings, 1, "the broad subscription is warned about once the cluster is busy, then rate limited")
+	assert.Equal(t, "sub-all", warnings[0].ContextMap()["subscription_id"])
+}
+
 func TestSubscriptionController_MatchesFilter(t *testing.T) {
 	ctrl := &controllers.SubscriptionController{}
 
@@ -371,6 +467,17 @@ func TestSubscriptionController_MatchesFilter(t *testing.T) {
 			resourceID:   "node-1",
 			wantMatch:    false,
 		},
+		{
+			name: "suspended subscription matches nothing",
+			sub: &storage.Subscription{
+				Filter:      storage.SubscriptionFilter{},
+				SuspendedAt: &time.Time{},
+			},
+			resourceType: "k8s-node",
+			resourcePool: "pool-1",
+			resourceID:   "node-1",
+			wantMatch:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// BroadFilterWarnInterval is how often a subscription with an empty filter is
+// warned about while the cluster stays busy.
+const BroadFilterWarnInterval = time.Hour
+
+// eventRateWindow is the window the cluster event rate is measured over.
+const eventRateWindow = 60
+
+// matchTracker measures the cluster event rate and remembers which broad
+// subscriptions were warned about recently.
+type matchTracker struct {
+	mu sync.Mutex
+
+	// counts holds the events of each second of the last minute, indexed by
+	// Unix second modulo the window; seconds records which second a slot
+	// currently counts.
+	counts  [eventRateWindow]int
+	seconds [eventRateWindow]int64
+
+	// warned holds when each broad subscription was last warned about.
+	warned map[string]time.Time
+}
+
+// observe counts an event at now and returns the events of the last minute.
+func (m *matchTracker) observe(now time.Time) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sec := now.Unix()
+	slot := sec % eventRateWindow
+	if m.seconds[slot] != sec {
+		m.seconds[slot] = sec
+		m.counts[slot] = 0
+	}
+	m.counts[slot]++
+
+	total := 0
+	for i, count := range m.counts {
+		if sec-m.seconds[i] < eventRateWindow {
+			total += count
+		}
+	}
+	return total
+}
+
+// shouldWarn reports whether a broad subscription is due for a warning and,
+// if so, records the warning.
+func (m *matchTracker) shouldWarn(subscriptionID string, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if last, ok := m.warned[subscriptionID]; ok && now.Sub(last) < BroadFilterWarnInterval {
+		return false
+	}
+	if m.warned == nil {
+		m.warned = make(map[string]time.Time)
+	}
+	m.warned[subscriptionID] = now
+	return true
+}
+
+// recordMatches records the subscriptions an event matched and warns about
+// subscriptions whose empty filter matches everything while the cluster
+// produces at least BusyEventsPerMinute events per minute.
+func (c *SubscriptionController) recordMatches(ctx context.Context, matched []*storage.Subscription) {
+	now := time.Now()
+	EventFanOut.Observe(float64(len(matched)))
+
+	if c.Stats != nil {
+		ids := make([]string, 0, len(matched))
+		for _, sub := range matched {
+			ids = append(ids, sub.ID)
+		}
+		if err := c.Stats.RecordEvent(ctx, ids, now); err != nil {
+			c.Logger.Warn("failed to record subscription match statistics", zap.Error(err))
+		}
+	}
+
+	rate := c.matches.observe(now)
+	if c.BusyEventsPerMinute <= 0 || rate < c.BusyEventsPerMinute {
+		return
+	}
+	for _, sub := range matched {
+		if !sub.Filter.MatchesEverything() || !c.matches.shouldWarn(sub.ID, now) {
+			continue
+		}
+		BroadSubscriptionWarningsTotal.Inc()
+		c.Logger.Warn("subscription with an empty filter matches every event in a busy cluster",
+			zap.String("subscription_id", sub.ID),
+			zap.String("tenant_id", sub.TenantID),
+			zap.Int("events_per_minute", rate),
+			zap.Int("matched_subscriptions", len(matched)))
+	}
+}
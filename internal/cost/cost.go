@@ -0,0 +1,83 @@
+// Package cost estimates the running cost of infrastructure capacity from a
+// configured pricing model.
+//
+// Capacity is priced per vCPU-hour, GB-hour of memory, and GPU-hour, and
+// estimates are projected over an average month of HoursPerMonth hours. The
+// estimates are indicative: they use requested or provisioned capacity, not
+// measured consumption.
+package cost
+
+import (
+	"math"
+)
+
+// HoursPerMonth is the number of hours in an average month (365 * 24 / 12).
+const HoursPerMonth = 730
+
+// bytesPerGB is the number of bytes in a GB of memory. Memory is priced in
+// binary gigabytes (GiB), the unit Kubernetes and cloud providers bill in.
+const bytesPerGB = 1 << 30
+
+// Pricing is the price of one hour of each unit of capacity.
+type Pricing struct {
+	// Currency is the ISO 4217 currency code of the prices (e.g. "USD").
+	Currency string
+
+	// VCPUHour is the price of one vCPU for one hour.
+	VCPUHour float64
+
+	// MemoryGBHour is the price of one GB of memory for one hour.
+	MemoryGBHour float64
+
+	// GPUHour is the price of one GPU for one hour.
+	GPUHour float64
+}
+
+// Usage is an amount of requested or provisioned capacity.
+type Usage struct {
+	CPUMillicores int64
+	MemoryBytes   int64
+	GPUs          int64
+}
+
+// Add returns the sum of u and other.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		CPUMillicores: u.CPUMillicores + other.CPUMillicores,
+		MemoryBytes:   u.MemoryBytes + other.MemoryBytes,
+		GPUs:          u.GPUs + other.GPUs,
+	}
+}
+
+// Estimate is the estimated monthly cost of an amount of capacity.
+type Estimate struct {
+	// MonthlyCost is the estimated cost per month, rounded to cents.
+	MonthlyCost float64 `json:"monthlyCost"`
+
+	// Currency is the ISO 4217 currency code of MonthlyCost.
+	Currency string `json:"currency"`
+
+	// VCPUs, MemoryGB, and GPUs are the capacity the estimate is based on.
+	VCPUs    float64 `json:"vcpus"`
+	MemoryGB float64 `json:"memoryGb"`
+	GPUs     int64   `json:"gpus"`
+}
+
+// Estimate returns the estimated monthly cost of u.
+func (p Pricing) Estimate(u Usage) *Estimate {
+	vcpus := float64(u.CPUMillicores) / 1000
+	memoryGB := float64(u.MemoryBytes) / bytesPerGB
+	hourly := vcpus*p.VCPUHour + memoryGB*p.MemoryGBHour + float64(u.GPUs)*p.GPUHour
+
+	return &Estimate{
+		MonthlyCost: roundCents(hourly * HoursPerMonth),
+		Currency:    p.Currency,
+		VCPUs:       vcpus,
+		MemoryGB:    math.Round(memoryGB*1000) / 1000,
+		GPUs:        u.GPUs,
+	}
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,66 @@
+package cost_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/cost"
+)
+
+func TestPricingEstimate(t *testing.T) {
+	pricing := cost.Pricing{Currency: "EUR", VCPUHour: 0.04, MemoryGBHour: 0.005, GPUHour: 2.5}
+
+	tests := []struct {
+		name  string
+		usage cost.Usage
+		want  cost.Estimate
+	}{
+		{
+			name: "zero usage",
+			want: cost.Estimate{Currency: "EUR"},
+		},
+		{
+			name:  "cpu and memory",
+			usage: cost.Usage{CPUMillicores: 2500, MemoryBytes: 4 << 30},
+			// (2.5*0.04 + 4*0.005) * 730 = 87.6
+			want: cost.Estimate{MonthlyCost: 87.6, Currency: "EUR", VCPUs: 2.5, MemoryGB: 4},
+		},
+		{
+			name:  "gpus",
+			usage: cost.Usage{CPUMillicores: 1000, MemoryBytes: 512 << 20, GPUs: 2},
+			// (0.04 + 0.5*0.005 + 2*2.5) * 730 = 3681.025
+			want: cost.Estimate{MonthlyCost: 3681.03, Currency: "EUR", VCPUs: 1, MemoryGB: 0.5, GPUs: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, &tt.want, pricing.Estimate(tt.usage))
+		})
+	}
+}
+
+func TestUsageAdd(t *testing.T) {
+	sum := cost.Usage{CPUMillicores: 500, MemoryBytes: 100, GPUs: 1}.
+		Add(cost.Usage{CPUMillicores: 1500, MemoryBytes: 28, GPUs: 0})
+
+	assert.Equal(t, cost.Usage{CPUMillicores: 2000, MemoryBytes: 128, GPUs: 1}, sum)
+}
+
+func TestRecordAndForget(t *testing.T) {
+	estimate := &cost.Estimate{MonthlyCost: 42.5, Currency: "USD"}
+
+	cost.RecordNFDeployment("dep-cost-test", "ns", estimate)
+	assert.InDelta(t, 42.5,
+		testutil.ToFloat64(cost.NFDeploymentMonthlyCost.WithLabelValues("dep-cost-test", "ns", "USD")), 0)
+
+	cost.ForgetNFDeployment("dep-cost-test")
+	assert.Equal(t, 0, testutil.CollectAndCount(cost.NFDeploymentMonthlyCost))
+
+	cost.RecordResourcePool("pool-cost-test", estimate)
+	assert.Equal(t, 1, testutil.CollectAndCount(cost.ResourcePoolMonthlyCost))
+	cost.ForgetResourcePool("pool-cost-test")
+	assert.Equal(t, 0, testutil.CollectAndCount(cost.ResourcePoolMonthlyCost))
+}
@@ -0,0 +1,52 @@
+package cost
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// NFDeploymentMonthlyCost reports the estimated monthly cost of each NF
+	// deployment, updated whenever the deployment is returned by the DMS API.
+	NFDeploymentMonthlyCost = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "cost",
+			Name:      "nf_deployment_estimated_monthly",
+			Help:      "Estimated monthly cost of an NF deployment from its resource requests",
+		},
+		[]string{"nf_deployment_id", "namespace", "currency"},
+	)
+
+	// ResourcePoolMonthlyCost reports the estimated monthly cost of each
+	// resource pool, updated whenever the pool is returned by the IMS API.
+	ResourcePoolMonthlyCost = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "cost",
+			Name:      "resource_pool_estimated_monthly",
+			Help:      "Estimated monthly cost of a resource pool from the capacity of its resources",
+		},
+		[]string{"resource_pool_id", "currency"},
+	)
+)
+
+// RecordNFDeployment sets the cost gauge of an NF deployment.
+func RecordNFDeployment(id, namespace string, estimate *Estimate) {
+	NFDeploymentMonthlyCost.WithLabelValues(id, namespace, estimate.Currency).Set(estimate.MonthlyCost)
+}
+
+// ForgetNFDeployment removes the cost gauge of a deleted NF deployment.
+func ForgetNFDeployment(id string) {
+	NFDeploymentMonthlyCost.DeletePartialMatch(prometheus.Labels{"nf_deployment_id": id})
+}
+
+// RecordResourcePool sets the cost gauge of a resource pool.
+func RecordResourcePool(id string, estimate *Estimate) {
+	ResourcePoolMonthlyCost.WithLabelValues(id, estimate.Currency).Set(estimate.MonthlyCost)
+}
+
+// ForgetResourcePool removes the cost gauge of a deleted resource pool.
+func ForgetResourcePool(id string) {
+	ResourcePoolMonthlyCost.DeletePartialMatch(prometheus.Labels{"resource_pool_id": id})
+}
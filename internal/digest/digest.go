@@ -0,0 +1,161 @@
+// Package digest defines the digest mode of O2-IMS subscriptions. A
+// subscription in digest mode is not notified of every event as it happens:
+// the events are accumulated and a single summary notification, with the
+// number of events by type and the objects that changed, is delivered once
+// per interval. Event types the subscription lists as immediate are still
+// delivered individually as they happen.
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// EventType is the notificationEventType of digest notifications.
+	EventType = "o2ims.Subscription.Digest"
+
+	// MinInterval and MaxInterval bound the digest interval.
+	MinInterval = time.Minute
+	MaxInterval = 24 * time.Hour
+
+	// MaxChanges bounds the changed objects listed in one digest. The
+	// changes beyond it are counted in Summary.ChangesOmitted.
+	MaxChanges = 1000
+)
+
+// Settings configures the digest mode of a subscription.
+type Settings struct {
+	// Interval is how often the digest is delivered, as a duration such as
+	// "1h". Digest periods are aligned to multiples of the interval since
+	// the Unix epoch, so hourly digests cover full UTC hours.
+	Interval string `json:"interval"`
+
+	// ImmediateEventTypes lists event types that are delivered individually
+	// as they happen instead of being accumulated, e.g.
+	// "o2ims.Resource.Deleted".
+	ImmediateEventTypes []string `json:"immediateEventTypes,omitempty"`
+}
+
+// Period returns the parsed digest interval.
+func (s *Settings) Period() (time.Duration, error) {
+	interval, err := time.ParseDuration(s.Interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid digest interval %q: %w", s.Interval, err)
+	}
+	return interval, nil
+}
+
+// Validate checks the settings. eventTypes lists the event types a
+// subscription can receive; immediate event types must be among them.
+func (s *Settings) Validate(eventTypes []string) error {
+	interval, err := s.Period()
+	if err != nil {
+		return err
+	}
+	if interval < MinInterval || interval > MaxInterval {
+		return fmt.Errorf("digest interval must be between %s and %s, got %s", MinInterval, MaxInterval, interval)
+	}
+	if interval%time.Second != 0 {
+		return fmt.Errorf("digest interval must be a whole number of seconds, got %s", interval)
+	}
+
+	known := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		known[eventType] = true
+	}
+	seen := make(map[string]bool, len(s.ImmediateEventTypes))
+	for _, eventType := range s.ImmediateEventTypes {
+		if !known[eventType] {
+			return fmt.Errorf("unknown immediate event type %q", eventType)
+		}
+		if seen[eventType] {
+			return fmt.Errorf("duplicate immediate event type %q", eventType)
+		}
+		seen[eventType] = true
+	}
+	return nil
+}
+
+// Immediate reports whether events of eventType bypass the digest.
+func (s *Settings) Immediate(eventType string) bool {
+	for _, immediate := range s.ImmediateEventTypes {
+		if immediate == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// PeriodEnd returns the end of the digest period containing t, for an
+// interval of whole seconds.
+func PeriodEnd(t time.Time, interval time.Duration) time.Time {
+	seconds := int64(interval / time.Second)
+	unix := t.Unix()
+	return time.Unix(unix-unix%seconds+seconds, 0).UTC()
+}
+
+// Change is an object that changed during a digest period.
+type Change struct {
+	// ObjectRef is the API path of the object.
+	ObjectRef string `json:"objectRef"`
+
+	// ResourceTypeID identifies the type of the object.
+	ResourceTypeID string `json:"resourceTypeId"`
+
+	// ResourcePoolID identifies the resource pool of the object (if any).
+	ResourcePoolID string `json:"resourcePoolId,omitempty"`
+
+	// GlobalResourceID is the global identifier of the object.
+	GlobalResourceID string `json:"globalResourceId"`
+
+	// LastEventType is the type of the last event about the object.
+	LastEventType string `json:"lastEventType"`
+
+	// LastChangedAt is when the last event about the object occurred.
+	LastChangedAt time.Time `json:"lastChangedAt"`
+}
+
+// Summary is the content of a digest notification.
+type Summary struct {
+	// PeriodStart and PeriodEnd delimit the period the digest covers.
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+
+	// EventCount is the number of events accumulated in the period.
+	EventCount int64 `json:"eventCount"`
+
+	// EventCounts is the number of events by event type.
+	EventCounts map[string]int64 `json:"eventCounts"`
+
+	// Changes lists the objects that changed, by object reference. Objects
+	// changed several times are listed once, with their last event.
+	Changes []Change `json:"changes"`
+
+	// ChangesOmitted is the number of changed objects beyond MaxChanges
+	// that are not listed.
+	ChangesOmitted int `json:"changesOmitted,omitempty"`
+}
+
+// NewSummary builds the summary of a digest period from the accumulated
+// event counts and changes.
+func NewSummary(start, end time.Time, counts map[string]int64, changes []Change) *Summary {
+	summary := &Summary{
+		PeriodStart: start.UTC(),
+		PeriodEnd:   end.UTC(),
+		EventCounts: counts,
+		Changes:     changes,
+	}
+	for _, count := range counts {
+		summary.EventCount += count
+	}
+	sort.Slice(summary.Changes, func(i, j int) bool {
+		return summary.Changes[i].ObjectRef < summary.Changes[j].ObjectRef
+	})
+	if len(summary.Changes) > MaxChanges {
+		summary.ChangesOmitted = len(summary.Changes) - MaxChanges
+		summary.Changes = summary.Changes[:MaxChanges]
+	}
+	return summary
+}
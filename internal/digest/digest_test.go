@@ -0,0 +1,96 @@
+package digest_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/digest"
+)
+
+var eventTypes = []string{"o2ims.Resource.Created", "o2ims.Resource.Deleted"}
+
+func TestSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings digest.Settings
+		wantErr  string
+	}{
+		{name: "hourly", settings: digest.Settings{Interval: "1h"}},
+		{
+			name:     "immediate types",
+			settings: digest.Settings{Interval: "15m", ImmediateEventTypes: []string{"o2ims.Resource.Deleted"}},
+		},
+		{
+			name:     "unparsable interval",
+			settings: digest.Settings{Interval: "hourly"},
+			wantErr:  "invalid digest interval",
+		},
+		{name: "too short", settings: digest.Settings{Interval: "30s"}, wantErr: "between 1m0s and 24h0m0s"},
+		{name: "too long", settings: digest.Settings{Interval: "48h"}, wantErr: "between 1m0s and 24h0m0s"},
+		{name: "fractional seconds", settings: digest.Settings{Interval: "90.5s"}, wantErr: "whole number of seconds"},
+		{
+			name:     "unknown immediate type",
+			settings: digest.Settings{Interval: "1h", ImmediateEventTypes: []string{"o2ims.Node.Created"}},
+			wantErr:  "unknown immediate event type",
+		},
+		{
+			name: "duplicate immediate type",
+			settings: digest.Settings{
+				Interval:            "1h",
+				ImmediateEventTypes: []string{"o2ims.Resource.Deleted", "o2ims.Resource.Deleted"},
+			},
+			wantErr: "duplicate immediate event type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate(eventTypes)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSettings_Immediate(t *testing.T) {
+	settings := digest.Settings{Interval: "1h", ImmediateEventTypes: []string{"o2ims.Resource.Deleted"}}
+	assert.True(t, settings.Immediate("o2ims.Resource.Deleted"))
+	assert.False(t, settings.Immediate("o2ims.Resource.Created"))
+}
+
+func TestPeriodEnd(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 42, 17, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC), digest.PeriodEnd(at, time.Hour))
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 45, 0, 0, time.UTC), digest.PeriodEnd(at, 15*time.Minute))
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), digest.PeriodEnd(at, 24*time.Hour))
+
+	// A period ending exactly at t is over; t starts the next one.
+	end := digest.PeriodEnd(at, time.Hour)
+	assert.Equal(t, end.Add(time.Hour), digest.PeriodEnd(end, time.Hour))
+}
+
+func TestNewSummary(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	var changes []digest.Change
+	for i := range digest.MaxChanges + 5 {
+		changes = append(changes, digest.Change{ObjectRef: fmt.Sprintf("/o2ims/v1/resources/node-%04d", i)})
+	}
+	changes[0], changes[len(changes)-1] = changes[len(changes)-1], changes[0]
+
+	summary := digest.NewSummary(start, start.Add(time.Hour),
+		map[string]int64{"o2ims.Resource.Created": 3, "o2ims.Resource.Deleted": 2}, changes)
+
+	assert.Equal(t, int64(5), summary.EventCount)
+	assert.Len(t, summary.Changes, digest.MaxChanges)
+	assert.Equal(t, 5, summary.ChangesOmitted)
+	assert.Equal(t, "/o2ims/v1/resources/node-0000", summary.Changes[0].ObjectRef)
+	assert.Equal(t, "/o2ims/v1/resources/node-0999", summary.Changes[digest.MaxChanges-1].ObjectRef)
+}
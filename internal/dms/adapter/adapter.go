@@ -27,6 +27,22 @@ var (
 
 	// ErrOperationNotSupported is returned when an operation is not supported.
 	ErrOperationNotSupported = errors.New("operation not supported")
+
+	// ErrNoOperationInProgress is returned when cancelling a deployment that has
+	// no in-progress operation.
+	ErrNoOperationInProgress = errors.New("no operation in progress")
+
+	// ErrDeploymentSuspended is returned when reconciling a deployment whose
+	// reconciliation is suspended.
+	ErrDeploymentSuspended = errors.New("deployment is suspended")
+
+	// ErrPackageInUse is returned when deleting a deployment package that
+	// deployments still reference.
+	ErrPackageInUse = errors.New("deployment package is in use")
+
+	// ErrSyncWindowClosed is returned when an operation would sync a deployment
+	// while the backend's sync windows do not allow it.
+	ErrSyncWindowClosed = errors.New("sync window closed")
 )
 
 // Capability represents a feature that a DMS adapter supports.
@@ -55,8 +71,40 @@ const (
 
 	// CapabilityMetrics indicates support for deployment metrics and monitoring.
 	CapabilityMetrics Capability = "metrics"
+
+	// CapabilityOperationCancel indicates support for aborting in-progress deployment operations.
+	// Adapters advertising it must implement OperationCanceller.
+	CapabilityOperationCancel Capability = "operation-cancel"
+
+	// CapabilityReleaseNotes indicates support for retrieving rendered release notes
+	// (e.g. Helm NOTES.txt). Adapters advertising it must implement NotesProvider.
+	CapabilityReleaseNotes Capability = "release-notes"
+
+	// CapabilityPreview indicates support for rendering an update without applying it
+	// (e.g. a Helm dry-run). Adapters advertising it must implement DeploymentPreviewer.
+	CapabilityPreview Capability = "preview"
+
+	// CapabilitySuspend indicates support for pausing and resuming the reconciliation
+	// of a deployment (e.g. Flux spec.suspend). Adapters advertising it must implement
+	// DeploymentSuspender.
+	CapabilitySuspend Capability = "suspend"
+
+	// CapabilitySyncPolicy indicates support for reading and changing how a GitOps
+	// backend syncs a deployment (e.g. ArgoCD spec.syncPolicy). Adapters advertising
+	// it must implement SyncPolicyManager.
+	CapabilitySyncPolicy Capability = "sync-policy"
 )
 
+// HasCapability reports whether the adapter advertises the given capability.
+func HasCapability(adp DMSAdapterMetadata, capability Capability) bool {
+	for _, c := range adp.Capabilities() {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
 // Filter provides criteria for filtering O2-DMS resources.
 // Filters are used in List operations to narrow down results based on
 // deployment attributes, labels, namespace, and custom extensions.
@@ -180,12 +228,22 @@ type Deployment struct {
 	// Description provides additional context.
 	Description string `json:"description,omitempty"`
 
+	// Notes are the rendered post-install notes of the current revision
+	// (e.g. Helm NOTES.txt). Only populated when retrieving a single deployment.
+	Notes string `json:"notes,omitempty"`
+
 	// CreatedAt is the timestamp when the deployment was created.
 	CreatedAt time.Time `json:"createdAt"`
 
 	// UpdatedAt is the timestamp of the last update.
 	UpdatedAt time.Time `json:"updatedAt"`
 
+	// Values are the effective configuration values of the current revision
+	// (e.g. chart defaults merged with the Helm release values). They are used
+	// to estimate the deployment's resource requests and are not exposed by the
+	// API. Nil when the adapter does not report them.
+	Values map[string]interface{} `json:"-"`
+
 	// Extensions provides vendor-specific additional metadata.
 	// Uses map[string]interface{} to support arbitrary JSON-compatible values
 	// as required by the O2-IMS specification for vendor-specific extensions.
@@ -301,6 +359,64 @@ type DeploymentHistory struct {
 	Revisions []DeploymentRevision `json:"revisions"`
 }
 
+// DeploymentNotes contains the rendered release notes of a deployment revision.
+type DeploymentNotes struct {
+	// DeploymentID is the deployment identifier.
+	DeploymentID string `json:"deploymentId"`
+
+	// Revision is the revision the notes were rendered for.
+	Revision int `json:"revision"`
+
+	// Notes is the rendered notes text. Empty if the package has no notes.
+	Notes string `json:"notes"`
+}
+
+// Resource changes reported in a ResourceDiff.
+const (
+	ResourceAdded     = "added"
+	ResourceRemoved   = "removed"
+	ResourceModified  = "modified"
+	ResourceUnchanged = "unchanged"
+)
+
+// DeploymentPreview contains what an update would deploy, compared with the
+// current revision.
+type DeploymentPreview struct {
+	// DeploymentID is the deployment identifier.
+	DeploymentID string `json:"deploymentId"`
+
+	// CurrentRevision is the revision the update is compared with.
+	CurrentRevision int `json:"currentRevision"`
+
+	// Manifests are the rendered manifests the update would apply. The
+	// contents of Secrets are omitted.
+	Manifests string `json:"manifests"`
+
+	// Diff lists every resource of the current and the updated revision,
+	// ordered by kind, namespace and name.
+	Diff []ResourceDiff `json:"diff"`
+}
+
+// ResourceDiff describes how an update changes one resource.
+type ResourceDiff struct {
+	// Kind is the resource kind, e.g. "Deployment".
+	Kind string `json:"kind"`
+
+	// Namespace is the resource namespace, empty if not set in the manifest.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the resource name.
+	Name string `json:"name"`
+
+	// Change is one of ResourceAdded, ResourceRemoved, ResourceModified or
+	// ResourceUnchanged.
+	Change string `json:"change"`
+
+	// Diff is a unified diff of the resource manifest. Empty for unchanged
+	// resources and for Secrets, whose contents are not disclosed.
+	Diff string `json:"diff,omitempty"`
+}
+
 // DeploymentRevision represents a single revision in deployment history.
 type DeploymentRevision struct {
 	// Revision is the revision number.
@@ -421,6 +537,123 @@ type DMSAdapterLifecycle interface {
 	Close() error
 }
 
+// ConfigProber is an optional interface for adapters that can verify their
+// backend configuration, such as endpoints and repository credentials, more
+// thoroughly than Health. The gateway probes adapters at startup.
+type ConfigProber interface {
+	// ProbeConfig checks that the backend accepts the configured endpoints
+	// and credentials. Returns an error naming the setting at fault.
+	ProbeConfig(ctx context.Context) error
+}
+
+// OperationCanceller is an optional interface for adapters that can abort a
+// long-running install, upgrade, rollback, or sync. Adapters run at most one
+// operation per deployment, so operations are identified by deployment ID.
+type OperationCanceller interface {
+	// CancelOperation aborts the in-progress operation of the given deployment.
+	// Returns ErrDeploymentNotFound if the deployment doesn't exist and
+	// ErrNoOperationInProgress if it has nothing to cancel.
+	CancelOperation(ctx context.Context, id string) error
+}
+
+// NotesProvider is an optional interface for adapters that keep the rendered
+// post-install notes of a deployment, such as Helm's NOTES.txt.
+type NotesProvider interface {
+	// GetDeploymentNotes returns the rendered notes of the current revision.
+	// Returns ErrDeploymentNotFound if the deployment doesn't exist.
+	GetDeploymentNotes(ctx context.Context, id string) (*DeploymentNotes, error)
+}
+
+// DeploymentPreviewer is an optional interface for adapters that can render
+// an update without applying it, so it can be reviewed first.
+type DeploymentPreviewer interface {
+	// PreviewDeployment renders the given update of a deployment and compares
+	// it with the current revision. Nothing is changed. Returns
+	// ErrDeploymentNotFound if the deployment doesn't exist and a
+	// *ValuesValidationError if the values do not match the package schema.
+	PreviewDeployment(ctx context.Context, id string, update *DeploymentUpdate) (*DeploymentPreview, error)
+}
+
+// DeploymentSuspender is an optional interface for adapters that continuously
+// reconcile deployments and can pause that reconciliation, e.g. for the
+// duration of a maintenance window.
+type DeploymentSuspender interface {
+	// SuspendDeployment stops the backend from reconciling the deployment until
+	// it is resumed. Suspending a suspended deployment is a no-op. Returns
+	// ErrDeploymentNotFound if the deployment doesn't exist.
+	SuspendDeployment(ctx context.Context, id string) error
+
+	// ResumeDeployment restarts the reconciliation of a suspended deployment.
+	// Resuming a deployment that isn't suspended is a no-op. Returns
+	// ErrDeploymentNotFound if the deployment doesn't exist.
+	ResumeDeployment(ctx context.Context, id string) error
+}
+
+// ReconcileOptions configures a requested reconciliation.
+type ReconcileOptions struct {
+	// Wait makes ReconcileDeployment wait until the backend handled the
+	// request, up to Timeout.
+	Wait bool
+
+	// Timeout bounds the wait. Zero uses the adapter's reconcile timeout.
+	Timeout time.Duration
+}
+
+// ReconcileResult is the outcome of a requested reconciliation.
+type ReconcileResult struct {
+	// RequestedAt is when the reconciliation was requested.
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// Completed is true when the backend handled the request within the wait
+	// timeout. It is always false when the caller did not wait.
+	Completed bool `json:"completed"`
+
+	// Status and Message report the readiness of the deployment after the
+	// reconciliation. Only set when Completed is true.
+	Status  DeploymentStatus `json:"status,omitempty"`
+	Message string           `json:"message,omitempty"`
+}
+
+// DeploymentReconciler is an optional interface for GitOps adapters that can
+// reconcile a deployment on demand instead of waiting for the next interval.
+type DeploymentReconciler interface {
+	// ReconcileDeployment requests an immediate reconciliation of the
+	// deployment and, when opts.Wait is set, waits for the backend to handle
+	// it. A wait that times out is not an error: the result reports
+	// Completed false and the reconciliation still happens. Returns
+	// ErrDeploymentNotFound if the deployment doesn't exist.
+	ReconcileDeployment(ctx context.Context, id string, opts *ReconcileOptions) (*ReconcileResult, error)
+}
+
+// SyncPolicy describes how a GitOps backend syncs a deployment.
+type SyncPolicy struct {
+	// Automated syncs the deployment whenever its source changes. When false,
+	// the deployment only syncs on request.
+	Automated bool `json:"automated"`
+
+	// Prune deletes resources that are no longer in the source during
+	// automated syncs.
+	Prune bool `json:"prune"`
+
+	// SelfHeal reverts changes made to the deployed resources outside the
+	// source during automated syncs.
+	SelfHeal bool `json:"selfHeal"`
+}
+
+// SyncPolicyManager is an optional interface for GitOps adapters whose
+// deployments can switch between automated and manual sync, e.g. for the
+// duration of a change freeze.
+type SyncPolicyManager interface {
+	// GetSyncPolicy returns the sync policy of the deployment. Returns
+	// ErrDeploymentNotFound if the deployment doesn't exist.
+	GetSyncPolicy(ctx context.Context, id string) (*SyncPolicy, error)
+
+	// SetSyncPolicy replaces the sync policy of the deployment and returns the
+	// stored policy. Prune and SelfHeal only apply to automated syncs. Returns
+	// ErrDeploymentNotFound if the deployment doesn't exist.
+	SetSyncPolicy(ctx context.Context, id string, policy *SyncPolicy) (*SyncPolicy, error)
+}
+
 // DMSAdapter defines the interface that all DMS backend implementations must provide.
 // Implementations include Helm, ArgoCD, Flux, ONAP-LCM, OSM-LCM, etc.
 // Each adapter translates O2-DMS operations to backend-specific API calls.
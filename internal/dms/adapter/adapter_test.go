@@ -111,6 +111,26 @@ func TestCapabilityConstants(t *testing.T) {
 			capability: adapter.CapabilityMetrics,
 			expected:   "metrics",
 		},
+		{
+			name:       "operation cancel capability",
+			capability: adapter.CapabilityOperationCancel,
+			expected:   "operation-cancel",
+		},
+		{
+			name:       "release notes capability",
+			capability: adapter.CapabilityReleaseNotes,
+			expected:   "release-notes",
+		},
+		{
+			name:       "preview capability",
+			capability: adapter.CapabilityPreview,
+			expected:   "preview",
+		},
+		{
+			name:       "suspend capability",
+			capability: adapter.CapabilitySuspend,
+			expected:   "suspend",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,102 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"path"
+)
+
+// ErrNamespaceDenied is matched by every NamespaceDeniedError.
+var ErrNamespaceDenied = errors.New("namespace denied")
+
+// NamespaceDeniedError is returned when a namespace policy forbids access to
+// a namespace. Rule names the rule that matched.
+type NamespaceDeniedError struct {
+	// Namespace is the namespace that was denied.
+	Namespace string
+
+	// Rule is the matched deny rule ("deny:<pattern>"), or "allowlist" when
+	// the namespace matched no allow rule.
+	Rule string
+}
+
+// Error implements error.
+func (e *NamespaceDeniedError) Error() string {
+	return fmt.Sprintf("namespace %q is denied by rule %q", e.Namespace, e.Rule)
+}
+
+// Is reports whether target is ErrNamespaceDenied.
+func (e *NamespaceDeniedError) Is(target error) bool {
+	return target == ErrNamespaceDenied
+}
+
+// NamespacePolicy restricts the namespaces a DMS adapter may act on through
+// the API. Rules are shell patterns as understood by path.Match, such as
+// "kube-system" or "kube-*". Deny rules win over allow rules; when Allow is
+// not empty, a namespace must match one of its rules. A nil policy allows
+// every namespace.
+type NamespacePolicy struct {
+	// Allow lists the namespaces the adapter may act on. Empty allows all.
+	Allow []string
+
+	// Deny lists namespaces the adapter must never act on.
+	Deny []string
+}
+
+// Validate checks that every rule is a valid pattern.
+func (p *NamespacePolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range append(append([]string{}, p.Allow...), p.Deny...) {
+		if rule == "" {
+			return errors.New("namespace rule cannot be empty")
+		}
+		if _, err := path.Match(rule, ""); err != nil {
+			return fmt.Errorf("invalid namespace rule %q: %w", rule, err)
+		}
+	}
+	return nil
+}
+
+// Check returns a *NamespaceDeniedError if namespace is not allowed.
+func (p *NamespacePolicy) Check(namespace string) error {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range p.Deny {
+		if matchNamespaceRule(rule, namespace) {
+			return &NamespaceDeniedError{Namespace: namespace, Rule: "deny:" + rule}
+		}
+	}
+	if len(p.Allow) == 0 {
+		return nil
+	}
+	for _, rule := range p.Allow {
+		if matchNamespaceRule(rule, namespace) {
+			return nil
+		}
+	}
+	return &NamespaceDeniedError{Namespace: namespace, Rule: "allowlist"}
+}
+
+// FilterDeployments returns the deployments in allowed namespaces.
+func (p *NamespacePolicy) FilterDeployments(deployments []*Deployment) []*Deployment {
+	if p == nil {
+		return deployments
+	}
+	allowed := make([]*Deployment, 0, len(deployments))
+	for _, d := range deployments {
+		if p.Check(d.Namespace) == nil {
+			allowed = append(allowed, d)
+		}
+	}
+	return allowed
+}
+
+// matchNamespaceRule reports whether namespace matches rule. Rules are
+// validated up front, so match errors count as no match.
+func matchNamespaceRule(rule, namespace string) bool {
+	matched, err := path.Match(rule, namespace)
+	return err == nil && matched
+}
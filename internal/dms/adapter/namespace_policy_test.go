@@ -0,0 +1,76 @@
+package adapter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+func TestNamespacePolicy_Check(t *testing.T) {
+	policy := &adapter.NamespacePolicy{
+		Allow: []string{"ran-*", "core"},
+		Deny:  []string{"ran-system"},
+	}
+
+	tests := []struct {
+		namespace string
+		rule      string
+	}{
+		{namespace: "ran-du"},
+		{namespace: "core"},
+		{namespace: "ran-system", rule: "deny:ran-system"},
+		{namespace: "kube-system", rule: "allowlist"},
+		{namespace: "", rule: "allowlist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			err := policy.Check(tt.namespace)
+			if tt.rule == "" {
+				require.NoError(t, err)
+				return
+			}
+			var denied *adapter.NamespaceDeniedError
+			require.ErrorAs(t, err, &denied)
+			require.ErrorIs(t, err, adapter.ErrNamespaceDenied)
+			assert.Equal(t, tt.namespace, denied.Namespace)
+			assert.Equal(t, tt.rule, denied.Rule)
+		})
+	}
+
+	// Without allow rules only deny rules apply.
+	denyOnly := &adapter.NamespacePolicy{Deny: []string{"kube-*"}}
+	require.NoError(t, denyOnly.Check("default"))
+	require.ErrorIs(t, denyOnly.Check("kube-public"), adapter.ErrNamespaceDenied)
+
+	var unrestricted *adapter.NamespacePolicy
+	require.NoError(t, unrestricted.Check("kube-system"))
+}
+
+func TestNamespacePolicy_Validate(t *testing.T) {
+	var unrestricted *adapter.NamespacePolicy
+	require.NoError(t, unrestricted.Validate())
+	require.NoError(t, (&adapter.NamespacePolicy{Allow: []string{"ran-*"}, Deny: []string{"kube-system"}}).Validate())
+	require.Error(t, (&adapter.NamespacePolicy{Deny: []string{""}}).Validate())
+	require.Error(t, (&adapter.NamespacePolicy{Allow: []string{"ran-["}}).Validate())
+}
+
+func TestNamespacePolicy_FilterDeployments(t *testing.T) {
+	deployments := []*adapter.Deployment{
+		{ID: "a", Namespace: "default"},
+		{ID: "b", Namespace: "kube-system"},
+		{ID: "c", Namespace: "ran"},
+	}
+
+	policy := &adapter.NamespacePolicy{Deny: []string{"kube-system"}}
+	filtered := policy.FilterDeployments(deployments)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "a", filtered[0].ID)
+	assert.Equal(t, "c", filtered[1].ID)
+
+	var unrestricted *adapter.NamespacePolicy
+	assert.Equal(t, deployments, unrestricted.FilterDeployments(deployments))
+}
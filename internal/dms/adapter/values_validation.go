@@ -0,0 +1,50 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidValues is matched by every ValuesValidationError.
+var ErrInvalidValues = errors.New("invalid deployment values")
+
+// ValuesViolation is one violation of a deployment package's values schema.
+type ValuesViolation struct {
+	// Path is the JSON pointer of the offending value, e.g. "/service/port".
+	// The empty string denotes the values document itself.
+	Path string `json:"path"`
+
+	// Message describes the violation, e.g. "got string, want integer".
+	Message string `json:"message"`
+}
+
+// ValuesValidationError is returned when the values of a deployment request
+// or update do not satisfy the values schema of its package. Adapters return
+// it before changing anything, so the request can be corrected and retried.
+type ValuesValidationError struct {
+	// Package identifies the deployment package whose schema was violated.
+	Package string
+
+	// Violations lists every violation found.
+	Violations []ValuesViolation
+}
+
+// Error implements error.
+func (e *ValuesValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		path := v.Path
+		if path == "" {
+			path = "/"
+		}
+		violations[i] = path + ": " + v.Message
+	}
+	return fmt.Sprintf("values do not match the schema of package %s: %s",
+		e.Package, strings.Join(violations, "; "))
+}
+
+// Is reports whether target is ErrInvalidValues.
+func (e *ValuesValidationError) Is(target error) bool {
+	return target == ErrInvalidValues
+}
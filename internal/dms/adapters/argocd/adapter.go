@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -50,6 +51,15 @@ const (
 
 	// ApplicationResource is the ArgoCD Application resource name.
 	ApplicationResource = "applications"
+
+	// OperationPhaseRunning is the operation state phase of an in-progress sync.
+	OperationPhaseRunning = "Running"
+
+	// OperationPhaseTerminating is the operation state phase requesting sync termination.
+	OperationPhaseTerminating = "Terminating"
+
+	// listPageSize is the largest number of Applications requested per list call.
+	listPageSize = 500
 )
 
 // ApplicationGVR is the GroupVersionResource for ArgoCD Applications.
@@ -176,6 +186,8 @@ func (a *Adapter) Capabilities() []adapter.Capability {
 		adapter.CapabilityRollback,
 		adapter.CapabilityHealthChecks,
 		adapter.CapabilityMetrics,
+		adapter.CapabilityOperationCancel,
+		adapter.CapabilitySyncPolicy,
 	}
 }
 
@@ -303,32 +315,45 @@ func (a *Adapter) ListDeployments(ctx context.Context, filter *adapter.Filter) (
 		return nil, err
 	}
 
-	apps, err := a.listApplications(ctx, filter)
-	if err != nil {
-		return nil, err
+	var limit, offset int
+	var status adapter.DeploymentStatus
+	if filter != nil {
+		limit, offset, status = filter.Limit, filter.Offset, filter.Status
 	}
 
-	deployments := make([]*adapter.Deployment, 0, len(apps))
-	for _, app := range apps {
-		deployment := a.TransformApplicationToDeployment(app)
+	// The Kubernetes API pages with continue tokens rather than offsets, so the
+	// Applications before the offset are fetched and skipped a page at a time.
+	// Without a status filter every Application counts, and a single request
+	// returns the whole page in most cases.
+	pageSize := int64(listPageSize)
+	if limit > 0 && status == "" {
+		pageSize = min(int64(offset+limit), listPageSize)
+	}
 
-		// Apply status filter if specified
-		if filter != nil && filter.Status != "" && deployment.Status != filter.Status {
-			continue
+	deployments := make([]*adapter.Deployment, 0, limit)
+	skipped := 0
+	err := a.pageApplications(ctx, filter, pageSize, func(app *unstructured.Unstructured) bool {
+		deployment := a.TransformApplicationToDeployment(app)
+		if status != "" && deployment.Status != status {
+			return true
+		}
+		if skipped < offset {
+			skipped++
+			return true
 		}
-
 		deployments = append(deployments, deployment)
-	}
-
-	// Apply pagination
-	if filter != nil {
-		deployments = a.ApplyPagination(deployments, filter.Limit, filter.Offset)
+		return limit == 0 || len(deployments) < limit
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return deployments, nil
 }
 
 // GetDeployment retrieves a specific ArgoCD Application by name.
+// Unlike ListDeployments, it also reports the sync windows of the
+// Application's project that apply to it.
 func (a *Adapter) GetDeployment(ctx context.Context, id string) (*adapter.Deployment, error) {
 	if err := a.Initialize(ctx); err != nil {
 		return nil, err
@@ -339,7 +364,15 @@ func (a *Adapter) GetDeployment(ctx context.Context, id string) (*adapter.Deploy
 		return nil, fmt.Errorf("deployment not found: %s: %w", id, err)
 	}
 
-	return a.TransformApplicationToDeployment(app), nil
+	deployment := a.TransformApplicationToDeployment(app)
+	windows, err := a.syncWindows(ctx, app, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	deployment.Extensions["argocd.syncWindows"] = windows
+	deployment.Extensions["argocd.syncAllowed"] = manualSyncAllowed(windows)
+
+	return deployment, nil
 }
 
 // CreateDeployment creates a new ArgoCD Application.
@@ -564,6 +597,8 @@ func (a *Adapter) ScaleDeployment(ctx context.Context, id string, replicas int)
 }
 
 // RollbackDeployment rolls back an ArgoCD Application to a previous revision.
+// Returns ErrSyncWindowClosed if the Application's sync windows do not allow
+// syncing it now.
 func (a *Adapter) RollbackDeployment(ctx context.Context, id string, revision int) error {
 	if err := a.Initialize(ctx); err != nil {
 		return err
@@ -585,6 +620,10 @@ func (a *Adapter) RollbackDeployment(ctx context.Context, id string, revision in
 		return fmt.Errorf("revision %d not found in history", revision)
 	}
 
+	if err := a.checkSyncWindows(ctx, app); err != nil {
+		return err
+	}
+
 	// Get the target revision's source
 	targetHistory, ok := history[revision].(map[string]interface{})
 	if !ok {
@@ -612,6 +651,43 @@ func (a *Adapter) RollbackDeployment(ctx context.Context, id string, revision in
 	return nil
 }
 
+// CancelOperation terminates the running sync of an ArgoCD Application. Like
+// `argocd app terminate-op`, it marks the operation state as Terminating and
+// lets the application controller stop the sync.
+func (a *Adapter) CancelOperation(ctx context.Context, id string) error {
+	if err := a.Initialize(ctx); err != nil {
+		return err
+	}
+
+	app, err := a.getApplication(ctx, id)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+		}
+		return err
+	}
+
+	phase, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "phase")
+	if phase != OperationPhaseRunning {
+		return fmt.Errorf("%w: %s", adapter.ErrNoOperationInProgress, id)
+	}
+
+	if err := unstructured.SetNestedField(
+		app.Object, OperationPhaseTerminating, "status", "operationState", "phase",
+	); err != nil {
+		return fmt.Errorf("failed to set operation phase: %w", err)
+	}
+
+	_, err = a.DynamicClient.Resource(ApplicationGVR).
+		Namespace(a.Config.Namespace).
+		Update(ctx, app, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to terminate ArgoCD sync: %w", err)
+	}
+
+	return nil
+}
+
 // GetDeploymentStatus retrieves detailed status for an ArgoCD Application.
 func (a *Adapter) GetDeploymentStatus(ctx context.Context, id string) (*adapter.DeploymentStatusDetail, error) {
 	if err := a.Initialize(ctx); err != nil {
@@ -739,27 +815,53 @@ func (a *Adapter) listApplications(
 	ctx context.Context,
 	filter *adapter.Filter,
 ) ([]*unstructured.Unstructured, error) {
+	var apps []*unstructured.Unstructured
+	err := a.pageApplications(ctx, filter, listPageSize, func(app *unstructured.Unstructured) bool {
+		apps = append(apps, app)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+// pageApplications lists the ArgoCD Applications matching the filter's
+// namespace and labels in pages of pageSize and calls visit for each of them,
+// until visit returns false or the list is exhausted. Only the current page
+// is held in memory.
+func (a *Adapter) pageApplications(
+	ctx context.Context,
+	filter *adapter.Filter,
+	pageSize int64,
+	visit func(app *unstructured.Unstructured) bool,
+) error {
 	namespace := a.Config.Namespace
 	if filter != nil && filter.Namespace != "" {
 		namespace = filter.Namespace
 	}
 
-	opts := metav1.ListOptions{}
+	opts := metav1.ListOptions{Limit: pageSize}
 	if filter != nil && len(filter.Labels) > 0 {
 		opts.LabelSelector = BuildLabelSelector(filter.Labels)
 	}
 
-	list, err := a.DynamicClient.Resource(ApplicationGVR).Namespace(namespace).List(ctx, opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list ArgoCD Applications: %w", err)
-	}
-
-	apps := make([]*unstructured.Unstructured, 0, len(list.Items))
-	for i := range list.Items {
-		apps = append(apps, &list.Items[i])
+	for {
+		list, err := a.DynamicClient.Resource(ApplicationGVR).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list ArgoCD Applications: %w", err)
+		}
+		for i := range list.Items {
+			if !visit(&list.Items[i]) {
+				return nil
+			}
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
 	}
-
-	return apps, nil
 }
 
 // getApplication retrieves a single ArgoCD Application by name.
@@ -820,6 +922,7 @@ func (a *Adapter) TransformApplicationToDeployment(app *unstructured.Unstructure
 			"argocd.targetRevision": source.TargetRevision,
 			"argocd.healthStatus":   healthStatus,
 			"argocd.syncStatus":     syncStatus,
+			"argocd.syncPolicy":     extractSyncPolicy(app),
 		},
 	}
 }
@@ -3,6 +3,7 @@ package argocd_test
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -10,10 +11,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
 
 	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
 )
@@ -940,6 +943,109 @@ func TestBuildLabelSelector(t *testing.T) {
 	}
 }
 
+// TestListDeployments_Paging tests that ListDeployments pages through the
+// Kubernetes API and stops requesting Applications once the page is filled.
+func TestListDeployments_Paging(t *testing.T) {
+	const total = 1200
+
+	apps := make([]unstructured.Unstructured, 0, total)
+	for i := range total {
+		health := "Healthy"
+		if i%2 == 1 {
+			health = "Degraded"
+		}
+		app := createTestApplication(fmt.Sprintf("app-%04d", i), "https://github.com/example/repo", "app",
+			health, "Synced")
+		apps = append(apps, *app)
+	}
+
+	// newAdapter returns an adapter whose client pages the Applications like
+	// the API server, using the index of the next Application as continue
+	// token, and the number of Applications it returned.
+	newAdapter := func(t *testing.T) (*argocd.Adapter, *int) {
+		t.Helper()
+		adp := createFakeAdapter(t)
+		fetched := 0
+		adp.DynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "applications",
+			func(action clienttesting.Action) (bool, runtime.Object, error) {
+				opts := action.(clienttesting.ListActionImpl).ListOptions
+				start := 0
+				if opts.Continue != "" {
+					start, _ = strconv.Atoi(opts.Continue)
+				}
+				end := total
+				if opts.Limit > 0 {
+					end = min(start+int(opts.Limit), total)
+				}
+
+				list := &unstructured.UnstructuredList{Items: apps[start:end]}
+				list.SetAPIVersion("argoproj.io/v1alpha1")
+				list.SetKind("ApplicationList")
+				if end < total {
+					list.SetContinue(strconv.Itoa(end))
+				}
+				fetched += end - start
+				return true, list, nil
+			})
+		return adp, &fetched
+	}
+
+	names := func(deployments []*dmsadapter.Deployment) []string {
+		result := make([]string, 0, len(deployments))
+		for _, d := range deployments {
+			result = append(result, d.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		filter      *dmsadapter.Filter
+		want        []string
+		wantCount   int
+		wantFetched int
+	}{
+		{
+			name:        "limit and offset",
+			filter:      &dmsadapter.Filter{Limit: 3, Offset: 10},
+			want:        []string{"app-0010", "app-0011", "app-0012"},
+			wantFetched: 13,
+		},
+		{
+			name:        "status filter",
+			filter:      &dmsadapter.Filter{Status: dmsadapter.DeploymentStatusDeployed, Limit: 2, Offset: 1},
+			want:        []string{"app-0002", "app-0004"},
+			wantFetched: 500,
+		},
+		{
+			name:        "status filter across pages",
+			filter:      &dmsadapter.Filter{Status: dmsadapter.DeploymentStatusDeployed, Limit: 2, Offset: 299},
+			want:        []string{"app-0598", "app-0600"},
+			wantFetched: 1000,
+		},
+		{
+			name:        "no pagination",
+			filter:      nil,
+			wantCount:   total,
+			wantFetched: total,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adp, fetched := newAdapter(t)
+			deployments, err := adp.ListDeployments(context.Background(), tt.filter)
+			require.NoError(t, err)
+			if tt.want != nil {
+				assert.Equal(t, tt.want, names(deployments))
+			} else {
+				assert.Len(t, deployments, tt.wantCount)
+			}
+			assert.Equal(t, tt.wantFetched, *fetched)
+		})
+	}
+}
+
 // TestApplyPagination tests pagination logic.
 func TestApplyPagination(t *testing.T) {
 	adp, _ := argocd.NewAdapter(&argocd.Config{})
@@ -1460,3 +1566,218 @@ func TestConfigDefaults(t *testing.T) {
 		})
 	}
 }
+
+// TestCancelOperation tests terminating a running ArgoCD sync.
+func TestCancelOperation(t *testing.T) {
+	withPhase := func(name, phase string) *unstructured.Unstructured {
+		app := createTestApplication(name, "https://github.com/example/repo", name, "Progressing", "OutOfSync")
+		require.NoError(t, unstructured.SetNestedField(app.Object, phase, "status", "operationState", "phase"))
+		return app
+	}
+
+	tests := []struct {
+		name     string
+		apps     []runtime.Object
+		deployID string
+		wantErr  error
+	}{
+		{
+			name:     "terminate running sync",
+			apps:     []runtime.Object{withPhase("syncing", argocd.OperationPhaseRunning)},
+			deployID: "syncing",
+		},
+		{
+			name:     "completed sync has nothing to cancel",
+			apps:     []runtime.Object{withPhase("synced", "Succeeded")},
+			deployID: "synced",
+			wantErr:  dmsadapter.ErrNoOperationInProgress,
+		},
+		{
+			name:     "application not found",
+			deployID: "nonexistent",
+			wantErr:  dmsadapter.ErrDeploymentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adp := createFakeAdapter(t, tt.apps...)
+			err := adp.CancelOperation(context.Background(), tt.deployID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			app, err := adp.DynamicClient.Resource(argocd.ApplicationGVR).Namespace(adp.Config.Namespace).
+				Get(context.Background(), tt.deployID, metav1.GetOptions{})
+			require.NoError(t, err)
+			phase, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "phase")
+			assert.Equal(t, argocd.OperationPhaseTerminating, phase)
+		})
+	}
+}
+
+func TestSyncPolicy(t *testing.T) {
+	ctx := context.Background()
+	app := createTestApplication("policy-app", "https://github.com/example/repo", "apps/policy", "Healthy", "Synced")
+	require.NoError(t, unstructured.SetNestedMap(app.Object, map[string]interface{}{
+		"automated":   map[string]interface{}{"prune": true, "selfHeal": true},
+		"syncOptions": []interface{}{"CreateNamespace=true"},
+	}, "spec", "syncPolicy"))
+	adp := createFakeAdapter(t, app)
+
+	policy, err := adp.GetSyncPolicy(ctx, "policy-app")
+	require.NoError(t, err)
+	assert.Equal(t, &dmsadapter.SyncPolicy{Automated: true, Prune: true, SelfHeal: true}, policy)
+
+	policy, err = adp.SetSyncPolicy(ctx, "policy-app", &dmsadapter.SyncPolicy{Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, &dmsadapter.SyncPolicy{}, policy)
+
+	stored, err := adp.DynamicClient.Resource(argocd.ApplicationGVR).Namespace(adp.Config.Namespace).
+		Get(ctx, "policy-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, found, _ := unstructured.NestedMap(stored.Object, "spec", "syncPolicy", "automated")
+	assert.False(t, found)
+	options, _, _ := unstructured.NestedStringSlice(stored.Object, "spec", "syncPolicy", "syncOptions")
+	assert.Equal(t, []string{"CreateNamespace=true"}, options)
+
+	policy, err = adp.SetSyncPolicy(ctx, "policy-app", &dmsadapter.SyncPolicy{Automated: true, SelfHeal: true})
+	require.NoError(t, err)
+	assert.Equal(t, &dmsadapter.SyncPolicy{Automated: true, SelfHeal: true}, policy)
+
+	deployment, err := adp.GetDeployment(ctx, "policy-app")
+	require.NoError(t, err)
+	assert.Equal(t, policy, deployment.Extensions["argocd.syncPolicy"])
+
+	_, err = adp.GetSyncPolicy(ctx, "nonexistent")
+	require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+	_, err = adp.SetSyncPolicy(ctx, "nonexistent", &dmsadapter.SyncPolicy{})
+	require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+}
+
+// createTestProject creates a test ArgoCD AppProject with the given sync windows.
+func createTestProject(name string, windows ...map[string]interface{}) *unstructured.Unstructured {
+	syncWindows := make([]interface{}, 0, len(windows))
+	for _, w := range windows {
+		syncWindows = append(syncWindows, w)
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "AppProject",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "argocd.argocd",
+			},
+			"spec": map[string]interface{}{
+				"syncWindows": syncWindows,
+			},
+		},
+	}
+}
+
+func TestSyncWindows(t *testing.T) {
+	now := time.Now().UTC()
+	window := func(
+		kind, schedule string, manualSync bool, selector string, values ...interface{},
+	) map[string]interface{} {
+		return map[string]interface{}{
+			"kind":       kind,
+			"schedule":   schedule,
+			"duration":   "1h",
+			"manualSync": manualSync,
+			selector:     values,
+		}
+	}
+	const never = "0 0 31 2 *"
+	thisHour := fmt.Sprintf("0 %d * * *", now.Hour())
+	today := fmt.Sprintf("* * 31 * %d", int(now.Weekday()))
+
+	tests := []struct {
+		name        string
+		windows     []map[string]interface{}
+		wantActive  []bool
+		wantAllowed bool
+	}{
+		{name: "no windows", wantActive: []bool{}, wantAllowed: true},
+		{
+			name:        "active deny window",
+			windows:     []map[string]interface{}{window("deny", "* * * * *", false, "applications", "window-*")},
+			wantActive:  []bool{true},
+			wantAllowed: false,
+		},
+		{
+			name:        "active deny window allowing manual sync",
+			windows:     []map[string]interface{}{window("deny", thisHour, true, "namespaces", "default")},
+			wantActive:  []bool{true},
+			wantAllowed: true,
+		},
+		{
+			name:        "inactive deny window",
+			windows:     []map[string]interface{}{window("deny", never, false, "applications", "*")},
+			wantActive:  []bool{false},
+			wantAllowed: true,
+		},
+		{
+			name: "no open allow window",
+			windows: []map[string]interface{}{
+				window("allow", never, false, "clusters", "https://kubernetes.default.svc"),
+			},
+			wantActive:  []bool{false},
+			wantAllowed: false,
+		},
+		{
+			name:        "open allow window matching day of week",
+			windows:     []map[string]interface{}{window("allow", today, false, "applications", "window-app")},
+			wantActive:  []bool{true},
+			wantAllowed: true,
+		},
+		{
+			name:        "window for other applications",
+			windows:     []map[string]interface{}{window("deny", "* * * * *", false, "applications", "other-*")},
+			wantActive:  []bool{},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			app := createTestApplication(
+				"window-app", "https://github.com/example/repo", "apps/window", "Healthy", "Synced",
+			)
+			adp := createFakeAdapter(t, app, createTestProject("default", tt.windows...))
+
+			deployment, err := adp.GetDeployment(ctx, "window-app")
+			require.NoError(t, err)
+			windows, ok := deployment.Extensions["argocd.syncWindows"].([]argocd.SyncWindow)
+			require.True(t, ok)
+			active := make([]bool, 0, len(windows))
+			for _, w := range windows {
+				active = append(active, w.Active)
+			}
+			assert.Equal(t, tt.wantActive, active)
+			assert.Equal(t, tt.wantAllowed, deployment.Extensions["argocd.syncAllowed"])
+
+			err = adp.RollbackDeployment(ctx, "window-app", 0)
+			if tt.wantAllowed {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, dmsadapter.ErrSyncWindowClosed)
+			}
+		})
+	}
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		app := createTestApplication("window-app", "https://github.com/example/repo", "apps/window", "Healthy", "Synced")
+		adp := createFakeAdapter(t, app,
+			createTestProject("default", window("deny", "*/0 * * * *", false, "applications", "*")))
+
+		_, err := adp.GetDeployment(context.Background(), "window-app")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid sync window")
+	})
+}
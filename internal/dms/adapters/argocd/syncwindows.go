@@ -0,0 +1,358 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+const (
+	// AppProjectResource is the ArgoCD AppProject resource name.
+	AppProjectResource = "appprojects"
+
+	// SyncWindowAllow is the kind of sync window during which syncs may run.
+	SyncWindowAllow = "allow"
+
+	// SyncWindowDeny is the kind of sync window during which syncs may not run.
+	SyncWindowDeny = "deny"
+)
+
+// AppProjectGVR is the GroupVersionResource for ArgoCD AppProjects.
+var AppProjectGVR = schema.GroupVersionResource{
+	Group:    ApplicationGroup,
+	Version:  ApplicationVersion,
+	Resource: AppProjectResource,
+}
+
+// SyncWindow is an ArgoCD sync window, as configured in spec.syncWindows of
+// an AppProject. A window opens at every time matching Schedule and stays
+// open for Duration.
+type SyncWindow struct {
+	Kind         string   `json:"kind"`
+	Schedule     string   `json:"schedule"`
+	Duration     string   `json:"duration"`
+	TimeZone     string   `json:"timeZone,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+	Namespaces   []string `json:"namespaces,omitempty"`
+	Clusters     []string `json:"clusters,omitempty"`
+	ManualSync   bool     `json:"manualSync"`
+	Active       bool     `json:"active"`
+}
+
+// GetSyncPolicy returns the sync policy of an ArgoCD Application.
+func (a *Adapter) GetSyncPolicy(ctx context.Context, id string) (*adapter.SyncPolicy, error) {
+	if err := a.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	app, err := a.getApplication(ctx, id)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+		}
+		return nil, err
+	}
+
+	return extractSyncPolicy(app), nil
+}
+
+// SetSyncPolicy replaces the sync policy of an ArgoCD Application. A manual
+// policy removes spec.syncPolicy.automated, along with its prune and selfHeal
+// settings; other sync policy fields such as syncOptions are kept.
+func (a *Adapter) SetSyncPolicy(
+	ctx context.Context,
+	id string,
+	policy *adapter.SyncPolicy,
+) (*adapter.SyncPolicy, error) {
+	if err := a.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return nil, fmt.Errorf("sync policy cannot be nil")
+	}
+
+	app, err := a.getApplication(ctx, id)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+		}
+		return nil, err
+	}
+
+	if policy.Automated {
+		automated := map[string]interface{}{
+			"prune":    policy.Prune,
+			"selfHeal": policy.SelfHeal,
+		}
+		if err := unstructured.SetNestedField(app.Object, automated, "spec", "syncPolicy", "automated"); err != nil {
+			return nil, fmt.Errorf("failed to set sync policy: %w", err)
+		}
+	} else {
+		unstructured.RemoveNestedField(app.Object, "spec", "syncPolicy", "automated")
+	}
+
+	result, err := a.DynamicClient.Resource(ApplicationGVR).
+		Namespace(a.Config.Namespace).
+		Update(ctx, app, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update ArgoCD Application sync policy: %w", err)
+	}
+
+	return extractSyncPolicy(result), nil
+}
+
+// extractSyncPolicy reads the sync policy of an Application.
+func extractSyncPolicy(app *unstructured.Unstructured) *adapter.SyncPolicy {
+	automated, found, _ := unstructured.NestedMap(app.Object, "spec", "syncPolicy", "automated")
+	if !found {
+		return &adapter.SyncPolicy{}
+	}
+
+	// ArgoCD 3 can keep the automated block while disabling it.
+	enabled, found, _ := unstructured.NestedBool(automated, "enabled")
+	prune, _, _ := unstructured.NestedBool(automated, "prune")
+	selfHeal, _, _ := unstructured.NestedBool(automated, "selfHeal")
+	return &adapter.SyncPolicy{
+		Automated: !found || enabled,
+		Prune:     prune,
+		SelfHeal:  selfHeal,
+	}
+}
+
+// syncWindows returns the sync windows of the Application's project that
+// apply to it, with their state at now. A missing project has no windows.
+func (a *Adapter) syncWindows(
+	ctx context.Context,
+	app *unstructured.Unstructured,
+	now time.Time,
+) ([]SyncWindow, error) {
+	project, _, _ := unstructured.NestedString(app.Object, "spec", "project")
+	if project == "" {
+		project = a.Config.DefaultProject
+	}
+
+	proj, err := a.DynamicClient.Resource(AppProjectGVR).
+		Namespace(a.Config.Namespace).
+		Get(ctx, project, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ArgoCD project %s: %w", project, err)
+	}
+
+	entries, _, _ := unstructured.NestedSlice(proj.Object, "spec", "syncWindows")
+	windows := make([]SyncWindow, 0, len(entries))
+	for _, entry := range entries {
+		spec, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		window := parseSyncWindow(spec)
+		if !window.appliesTo(app) {
+			continue
+		}
+		window.Active, err = window.activeAt(now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sync window %q in project %s: %w", window.Schedule, project, err)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// parseSyncWindow reads a sync window from an AppProject.
+func parseSyncWindow(spec map[string]interface{}) SyncWindow {
+	window := SyncWindow{}
+	window.Kind, _, _ = unstructured.NestedString(spec, "kind")
+	window.Schedule, _, _ = unstructured.NestedString(spec, "schedule")
+	window.Duration, _, _ = unstructured.NestedString(spec, "duration")
+	window.TimeZone, _, _ = unstructured.NestedString(spec, "timeZone")
+	window.Applications, _, _ = unstructured.NestedStringSlice(spec, "applications")
+	window.Namespaces, _, _ = unstructured.NestedStringSlice(spec, "namespaces")
+	window.Clusters, _, _ = unstructured.NestedStringSlice(spec, "clusters")
+	window.ManualSync, _, _ = unstructured.NestedBool(spec, "manualSync")
+	return window
+}
+
+// appliesTo reports whether the window selects the Application by name,
+// destination namespace, or destination cluster.
+func (w *SyncWindow) appliesTo(app *unstructured.Unstructured) bool {
+	namespace, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "namespace")
+	server, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "server")
+	cluster, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "name")
+
+	return matchesAny(w.Applications, app.GetName()) ||
+		matchesAny(w.Namespaces, namespace) ||
+		matchesAny(w.Clusters, server) ||
+		matchesAny(w.Clusters, cluster)
+}
+
+// matchesAny reports whether value matches one of the glob patterns.
+func matchesAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// activeAt reports whether the window is open at now.
+func (w *SyncWindow) activeAt(now time.Time) (bool, error) {
+	schedule, err := parseCronSchedule(w.Schedule)
+	if err != nil {
+		return false, err
+	}
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil || duration <= 0 {
+		return false, fmt.Errorf("invalid duration %q", w.Duration)
+	}
+	if w.TimeZone != "" {
+		loc, err := time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return false, fmt.Errorf("invalid time zone %q: %w", w.TimeZone, err)
+		}
+		now = now.In(loc)
+	}
+
+	// The window is open if it opened less than duration ago.
+	start := now.Truncate(time.Minute)
+	for elapsed := now.Sub(start); elapsed < duration; elapsed += time.Minute {
+		if schedule.matches(start) {
+			return true, nil
+		}
+		start = start.Add(-time.Minute)
+	}
+	return false, nil
+}
+
+// manualSyncAllowed reports whether the windows let an operator sync now,
+// following ArgoCD: an open deny window blocks syncs unless it allows manual
+// syncs, and so does having allow windows of which none is open.
+func manualSyncAllowed(windows []SyncWindow) bool {
+	hasAllow, allowOpen, allowManual := false, false, false
+	for _, w := range windows {
+		switch w.Kind {
+		case SyncWindowDeny:
+			if w.Active && !w.ManualSync {
+				return false
+			}
+		case SyncWindowAllow:
+			hasAllow = true
+			allowOpen = allowOpen || w.Active
+			allowManual = allowManual || w.ManualSync
+		}
+	}
+	return !hasAllow || allowOpen || allowManual
+}
+
+// checkSyncWindows returns ErrSyncWindowClosed if the sync windows of the
+// Application do not allow syncing it now.
+func (a *Adapter) checkSyncWindows(ctx context.Context, app *unstructured.Unstructured) error {
+	windows, err := a.syncWindows(ctx, app, time.Now())
+	if err != nil {
+		return err
+	}
+	if !manualSyncAllowed(windows) {
+		return fmt.Errorf("%w: %s", adapter.ErrSyncWindowClosed, app.GetName())
+	}
+	return nil
+}
+
+// cronSchedule is a parsed five-field cron schedule, as used by sync windows.
+type cronSchedule struct {
+	minute, hour, dom, month, dow [64]bool
+	domAny, dowAny                bool
+}
+
+// cronFieldBounds are the ranges of the minute, hour, day of month, month and
+// day of week fields.
+var cronFieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// parseCronSchedule parses a cron schedule of numeric fields supporting *,
+// ranges, steps and lists.
+func parseCronSchedule(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFieldBounds) {
+		return nil, fmt.Errorf("expected 5 fields in schedule %q", spec)
+	}
+
+	s := &cronSchedule{}
+	sets := []*[64]bool{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, field := range fields {
+		if err := parseCronField(field, cronFieldBounds[i][0], cronFieldBounds[i][1], sets[i]); err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	return s, nil
+}
+
+// parseCronField marks the values selected by a cron field.
+func parseCronField(field string, low, high int, set *[64]bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if before, after, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(after)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = before, n
+		}
+
+		from, to := low, high
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = strconv.Atoi(first); err != nil {
+				return fmt.Errorf("invalid value in %q", part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(last); err != nil {
+					return fmt.Errorf("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				to = high
+			}
+		}
+		if from < low || to > high || from > to {
+			return fmt.Errorf("%q is out of range %d-%d", part, low, high)
+		}
+
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// matches reports whether the schedule fires at t. As in cron, when both the
+// day of month and the day of week are restricted, either may match.
+func (s *cronSchedule) matches(t time.Time) bool {
+	if !s.minute[t.Minute()] || !s.hour[t.Hour()] || !s.month[int(t.Month())] {
+		return false
+	}
+	domMatch := s.dom[t.Day()]
+	dowMatch := s.dow[int(t.Weekday())]
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
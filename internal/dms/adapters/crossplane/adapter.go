@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/httperror"
 )
 
 const (
@@ -178,6 +180,17 @@ func (c *Adapter) Capabilities() []adapter.Capability {
 	}
 }
 
+// ErrorRules returns the HTTP translations of the adapter's sentinel errors.
+func (c *Adapter) ErrorRules() []httperror.Rule {
+	return []httperror.Rule{
+		{Err: ErrDeploymentNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrPackageNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrInvalidName, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrMissingCompositionRef, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrOperationNotSupported, Status: http.StatusNotImplemented, Code: httperror.CodeNotImplemented},
+	}
+}
+
 // ListDeploymentPackages retrieves all Crossplane Compositions.
 func (c *Adapter) ListDeploymentPackages(
 	ctx context.Context,
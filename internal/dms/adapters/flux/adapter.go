@@ -14,20 +14,24 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/httperror"
 )
 
 // Sentinel errors for Flux adapter operations.
@@ -68,6 +72,14 @@ const (
 	// DefaultInterval is the default reconciliation interval.
 	DefaultInterval = 5 * time.Minute
 
+	// DefaultPollInterval is the default interval at which a requested
+	// reconciliation is polled for completion.
+	DefaultPollInterval = 2 * time.Second
+
+	// ReconcileRequestedAtAnnotation requests an immediate reconciliation
+	// from the Flux controllers.
+	ReconcileRequestedAtAnnotation = "reconcile.fluxcd.io/requestedAt"
+
 	// HelmReleaseGroup is the Flux HelmRelease API group.
 	HelmReleaseGroup = "helm.toolkit.fluxcd.io"
 
@@ -162,9 +174,14 @@ type Config struct {
 	// Defaults to the same as Namespace.
 	SourceNamespace string
 
-	// ReconcileTimeout is the timeout for reconciliation operations.
+	// ReconcileTimeout is the timeout for reconciliation operations. It is
+	// also the longest a requested reconciliation is waited for.
 	ReconcileTimeout time.Duration
 
+	// PollInterval is how often a requested reconciliation is checked for
+	// completion. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
 	// Interval is the default reconciliation interval for new resources.
 	Interval time.Duration
 
@@ -201,6 +218,9 @@ func NewAdapter(config *Config) (*Adapter, error) {
 	if config.Interval == 0 {
 		config.Interval = DefaultInterval
 	}
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
 	if config.TargetNamespace == "" {
 		config.TargetNamespace = "default"
 	}
@@ -310,6 +330,19 @@ func (f *Adapter) Capabilities() []adapter.Capability {
 		adapter.CapabilityRollback,
 		adapter.CapabilityHealthChecks,
 		adapter.CapabilityMetrics,
+		adapter.CapabilityOperationCancel,
+		adapter.CapabilitySuspend,
+	}
+}
+
+// ErrorRules returns the HTTP translations of the adapter's sentinel errors.
+func (f *Adapter) ErrorRules() []httperror.Rule {
+	return []httperror.Rule{
+		{Err: ErrDeploymentNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrPackageNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrInvalidName, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrInvalidPath, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrOperationNotSupported, Status: http.StatusNotImplemented, Code: httperror.CodeNotImplemented},
 	}
 }
 
@@ -414,8 +447,10 @@ func (f *Adapter) searchAllRepositories(ctx context.Context, id string) (*adapte
 	return f.searchHelmRepositories(ctx, id)
 }
 
-// UploadDeploymentPackage creates a reference to a Flux source.
-// Flux uses Git and Helm repositories as package sources.
+// UploadDeploymentPackage creates a Flux source for the package.
+// Git packages become GitRepositories and Helm packages become HelmRepositories
+// in the source namespace, so deployments can reference them by name through
+// the flux.sourceRef extension.
 func (f *Adapter) UploadDeploymentPackage(
 	ctx context.Context, pkg *adapter.DeploymentPackageUpload,
 ) (*adapter.DeploymentPackage, error) {
@@ -429,6 +464,9 @@ func (f *Adapter) UploadDeploymentPackage(
 	if pkg == nil {
 		return nil, fmt.Errorf("package cannot be nil")
 	}
+	if err := ValidateName(pkg.Name); err != nil {
+		return nil, err
+	}
 
 	repoURL, _ := pkg.Extensions["flux.url"].(string)
 	if repoURL == "" {
@@ -440,26 +478,220 @@ func (f *Adapter) UploadDeploymentPackage(
 		repoType = "git"
 	}
 
-	return &adapter.DeploymentPackage{
-		ID:          GeneratePackageID(repoType, repoURL),
-		Name:        pkg.Name,
-		Version:     pkg.Version,
-		PackageType: fmt.Sprintf("flux-%s", repoType),
-		Description: pkg.Description,
-		UploadedAt:  time.Now(),
-		Extensions: map[string]interface{}{
-			"flux.url":    repoURL,
-			"flux.type":   repoType,
-			"flux.branch": pkg.Version,
+	switch repoType {
+	case "git":
+		return f.createGitRepository(ctx, pkg, repoURL)
+	case "helm":
+		return f.createHelmRepository(ctx, pkg, repoURL)
+	default:
+		return nil, fmt.Errorf("unsupported flux.type %q for Flux packages (must be git or helm)", repoType)
+	}
+}
+
+// createGitRepository creates a Flux GitRepository for an uploaded package.
+// The flux.tag extension takes precedence over flux.branch, which defaults to
+// the package version.
+func (f *Adapter) createGitRepository(
+	ctx context.Context, pkg *adapter.DeploymentPackageUpload, repoURL string,
+) (*adapter.DeploymentPackage, error) {
+	ref := map[string]interface{}{}
+	if tag, _ := pkg.Extensions["flux.tag"].(string); tag != "" {
+		ref["tag"] = tag
+	} else {
+		branch, _ := pkg.Extensions["flux.branch"].(string)
+		if branch == "" {
+			branch = pkg.Version
+		}
+		if branch != "" {
+			ref["branch"] = branch
+		}
+	}
+
+	spec := map[string]interface{}{
+		"url":      repoURL,
+		"interval": f.Config.Interval.String(),
+	}
+	if len(ref) > 0 {
+		spec["ref"] = ref
+	}
+	setSourceSecretRef(spec, pkg)
+
+	repo := newSourceObject("GitRepository", pkg.Name, f.Config.SourceNamespace, spec)
+	result, err := f.DynamicClient.Resource(GitRepositoryGVR).
+		Namespace(f.Config.SourceNamespace).Create(ctx, repo, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Flux GitRepository: %w", err)
+	}
+
+	return f.transformGitRepoToPackage(result), nil
+}
+
+// createHelmRepository creates a Flux HelmRepository for an uploaded package.
+// The flux.repoType extension selects the repository type (e.g. "oci").
+func (f *Adapter) createHelmRepository(
+	ctx context.Context, pkg *adapter.DeploymentPackageUpload, repoURL string,
+) (*adapter.DeploymentPackage, error) {
+	spec := map[string]interface{}{
+		"url":      repoURL,
+		"interval": f.Config.Interval.String(),
+	}
+	if repoType, _ := pkg.Extensions["flux.repoType"].(string); repoType != "" && repoType != "default" {
+		spec["type"] = repoType
+	}
+	setSourceSecretRef(spec, pkg)
+
+	repo := newSourceObject("HelmRepository", pkg.Name, f.Config.SourceNamespace, spec)
+	result, err := f.DynamicClient.Resource(HelmRepositoryGVR).
+		Namespace(f.Config.SourceNamespace).Create(ctx, repo, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Flux HelmRepository: %w", err)
+	}
+
+	return f.transformHelmRepoToPackage(result), nil
+}
+
+// setSourceSecretRef references the credentials Secret named by the
+// flux.secretRef extension, if any.
+func setSourceSecretRef(spec map[string]interface{}, pkg *adapter.DeploymentPackageUpload) {
+	if secretRef, _ := pkg.Extensions["flux.secretRef"].(string); secretRef != "" {
+		spec["secretRef"] = map[string]interface{}{"name": secretRef}
+	}
+}
+
+// newSourceObject builds a Flux source resource of the given kind.
+func newSourceObject(kind, name, namespace string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": fmt.Sprintf("%s/%s", GitRepositoryGroup, GitRepositoryVersion),
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": spec,
 		},
-	}, nil
+	}
+}
+
+// DeleteDeploymentPackage deletes the GitRepository or HelmRepository behind a
+// package. A source that a HelmRelease or Kustomization still references is
+// not deleted, since Flux would stop reconciling those deployments.
+func (f *Adapter) DeleteDeploymentPackage(ctx context.Context, id string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	if err := f.Initialize(ctx); err != nil {
+		return err
+	}
+
+	source, gvr, err := f.findSource(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	refs, err := f.sourceReferences(ctx, source)
+	if err != nil {
+		return err
+	}
+	if len(refs) > 0 {
+		return fmt.Errorf("%w: %s %s is referenced by %s",
+			adapter.ErrPackageInUse, source.GetKind(), source.GetName(), strings.Join(refs, ", "))
+	}
+
+	err = f.DynamicClient.Resource(gvr).Namespace(source.GetNamespace()).
+		Delete(ctx, source.GetName(), metav1.DeleteOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", adapter.ErrPackageNotFound, id)
+		}
+		return fmt.Errorf("failed to delete Flux %s: %w", source.GetKind(), err)
+	}
+
+	return nil
+}
+
+// findSource returns the GitRepository or HelmRepository behind a package ID.
+func (f *Adapter) findSource(
+	ctx context.Context, id string,
+) (*unstructured.Unstructured, schema.GroupVersionResource, error) {
+	if !strings.HasPrefix(id, "helm-") {
+		gitRepos, err := f.listGitRepositories(ctx, nil)
+		if err != nil {
+			return nil, schema.GroupVersionResource{}, err
+		}
+		for _, repo := range gitRepos {
+			if f.transformGitRepoToPackage(repo).ID == id {
+				return repo, GitRepositoryGVR, nil
+			}
+		}
+	}
+
+	if !strings.HasPrefix(id, "git-") {
+		helmRepos, err := f.listHelmRepositories(ctx, nil)
+		if err != nil {
+			return nil, schema.GroupVersionResource{}, err
+		}
+		for _, repo := range helmRepos {
+			if f.transformHelmRepoToPackage(repo).ID == id {
+				return repo, HelmRepositoryGVR, nil
+			}
+		}
+	}
+
+	return nil, schema.GroupVersionResource{}, fmt.Errorf("%w: %s", adapter.ErrPackageNotFound, id)
 }
 
-// DeleteDeploymentPackage is not directly supported in Flux.
-// Source resources should be managed directly in the cluster.
-func (f *Adapter) DeleteDeploymentPackage(_ context.Context, _ string) error {
-	return fmt.Errorf("%w: Flux does not support package deletion through this adapter "+
-		"- manage source resources directly", ErrOperationNotSupported)
+// sourceReferences lists the HelmReleases and Kustomizations, in the
+// deployment and source namespaces, whose sourceRef names the source.
+func (f *Adapter) sourceReferences(ctx context.Context, source *unstructured.Unstructured) ([]string, error) {
+	namespaces := []string{f.Config.Namespace}
+	if f.Config.SourceNamespace != f.Config.Namespace {
+		namespaces = append(namespaces, f.Config.SourceNamespace)
+	}
+
+	var refs []string
+	for _, namespace := range namespaces {
+		releases, err := f.DynamicClient.Resource(HelmReleaseGVR).Namespace(namespace).
+			List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Flux HelmReleases: %w", err)
+		}
+		for i := range releases.Items {
+			hr := &releases.Items[i]
+			if referencesSource(hr, source, "spec", "chart", "spec", "sourceRef") {
+				refs = append(refs, fmt.Sprintf("HelmRelease %s/%s", namespace, hr.GetName()))
+			}
+		}
+
+		kustomizations, err := f.DynamicClient.Resource(KustomizationGVR).Namespace(namespace).
+			List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Flux Kustomizations: %w", err)
+		}
+		for i := range kustomizations.Items {
+			ks := &kustomizations.Items[i]
+			if referencesSource(ks, source, "spec", "sourceRef") {
+				refs = append(refs, fmt.Sprintf("Kustomization %s/%s", namespace, ks.GetName()))
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// referencesSource reports whether the sourceRef found at path in obj names
+// source. A sourceRef without a namespace refers to the namespace of obj.
+func referencesSource(obj, source *unstructured.Unstructured, path ...string) bool {
+	ref, found, err := unstructured.NestedStringMap(obj.Object, path...)
+	if !found || err != nil {
+		return false
+	}
+	namespace := ref["namespace"]
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	return ref["kind"] == source.GetKind() && ref["name"] == source.GetName() &&
+		namespace == source.GetNamespace()
 }
 
 // ListDeployments retrieves all Flux deployments (HelmReleases and Kustomizations).
@@ -743,6 +975,218 @@ func (f *Adapter) addReconciliationAnnotation(obj *unstructured.Unstructured) er
 	return nil
 }
 
+// CancelOperation aborts an in-progress reconciliation by suspending the
+// HelmRelease or Kustomization. Flux stops reconciling (and retrying) a
+// suspended resource until it is resumed.
+func (f *Adapter) CancelOperation(ctx context.Context, id string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	if err := f.Initialize(ctx); err != nil {
+		return err
+	}
+
+	// Try HelmRelease first.
+	if hr, err := f.getHelmRelease(ctx, id); err == nil {
+		return f.suspendReconciliation(ctx, hr, HelmReleaseGVR)
+	}
+
+	// Try Kustomization.
+	if ks, err := f.getKustomization(ctx, id); err == nil {
+		return f.suspendReconciliation(ctx, ks, KustomizationGVR)
+	}
+
+	return fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+}
+
+// suspendReconciliation sets spec.suspend on a Flux resource that is still reconciling.
+func (f *Adapter) suspendReconciliation(
+	ctx context.Context, obj *unstructured.Unstructured, gvr schema.GroupVersionResource,
+) error {
+	suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	status, _ := f.ExtractFluxStatus(conditions)
+	if suspended || status == adapter.DeploymentStatusDeployed {
+		return fmt.Errorf("%w: %s", adapter.ErrNoOperationInProgress, obj.GetName())
+	}
+
+	if err := unstructured.SetNestedField(obj.Object, true, "spec", "suspend"); err != nil {
+		return fmt.Errorf("failed to set suspend: %w", err)
+	}
+
+	_, err := f.DynamicClient.Resource(gvr).Namespace(f.Config.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to suspend reconciliation: %w", err)
+	}
+	return nil
+}
+
+// SuspendDeployment suspends the reconciliation of a HelmRelease or
+// Kustomization by patching spec.suspend, like `flux suspend`.
+func (f *Adapter) SuspendDeployment(ctx context.Context, id string) error {
+	return f.setSuspend(ctx, id, true)
+}
+
+// ResumeDeployment resumes the reconciliation of a suspended HelmRelease or
+// Kustomization and requests an immediate reconciliation, like `flux resume`.
+func (f *Adapter) ResumeDeployment(ctx context.Context, id string) error {
+	return f.setSuspend(ctx, id, false)
+}
+
+// setSuspend sets spec.suspend on the HelmRelease or Kustomization of a deployment.
+func (f *Adapter) setSuspend(ctx context.Context, id string, suspend bool) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	if err := f.Initialize(ctx); err != nil {
+		return err
+	}
+
+	// Try HelmRelease first.
+	if _, err := f.getHelmRelease(ctx, id); err == nil {
+		return f.patchSuspend(ctx, id, HelmReleaseGVR, suspend)
+	}
+
+	// Try Kustomization.
+	if _, err := f.getKustomization(ctx, id); err == nil {
+		return f.patchSuspend(ctx, id, KustomizationGVR, suspend)
+	}
+
+	return fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+}
+
+// patchSuspend merge-patches spec.suspend of a Flux resource. Resuming also
+// sets the reconcile annotation so Flux doesn't wait for the next interval.
+func (f *Adapter) patchSuspend(
+	ctx context.Context, name string, gvr schema.GroupVersionResource, suspend bool,
+) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{"suspend": suspend},
+	}
+	if !suspend {
+		patch["metadata"] = map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"reconcile.fluxcd.io/requestedAt": time.Now().Format(time.RFC3339),
+			},
+		}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal suspend patch: %w", err)
+	}
+
+	_, err = f.DynamicClient.Resource(gvr).Namespace(f.Config.Namespace).
+		Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch suspend: %w", err)
+	}
+	return nil
+}
+
+// ReconcileDeployment requests an immediate reconciliation of a HelmRelease
+// or Kustomization by setting the reconcile.fluxcd.io/requestedAt annotation,
+// like `flux reconcile`. When waiting, it polls until the controller records
+// the request in status.lastHandledReconcileAt and reports the resulting
+// Ready condition.
+func (f *Adapter) ReconcileDeployment(
+	ctx context.Context, id string, opts *adapter.ReconcileOptions,
+) (*adapter.ReconcileResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := f.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &adapter.ReconcileOptions{}
+	}
+
+	// Try HelmRelease first, then Kustomization.
+	var (
+		obj *unstructured.Unstructured
+		gvr schema.GroupVersionResource
+	)
+	if hr, err := f.getHelmRelease(ctx, id); err == nil {
+		obj, gvr = hr, HelmReleaseGVR
+	} else if ks, err := f.getKustomization(ctx, id); err == nil {
+		obj, gvr = ks, KustomizationGVR
+	} else {
+		return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+	}
+
+	// Flux ignores the annotation on suspended resources.
+	if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); suspended {
+		return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentSuspended, id)
+	}
+
+	requestedAt := time.Now()
+	token := requestedAt.Format(time.RFC3339Nano)
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{ReconcileRequestedAtAnnotation: token},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reconcile patch: %w", err)
+	}
+	_, err = f.DynamicClient.Resource(gvr).Namespace(f.Config.Namespace).
+		Patch(ctx, id, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request reconciliation: %w", err)
+	}
+
+	result := &adapter.ReconcileResult{RequestedAt: requestedAt}
+	if !opts.Wait {
+		return result, nil
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 || timeout > f.Config.ReconcileTimeout {
+		timeout = f.Config.ReconcileTimeout
+	}
+	return result, f.waitForReconcile(ctx, id, gvr, token, timeout, result)
+}
+
+// waitForReconcile polls a Flux resource until it handled the reconcile
+// request token, then records its Ready condition in result. Timing out
+// leaves result.Completed false without an error.
+func (f *Adapter) waitForReconcile(
+	ctx context.Context, name string, gvr schema.GroupVersionResource,
+	token string, timeout time.Duration, result *adapter.ReconcileResult,
+) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(f.Config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		obj, err := f.DynamicClient.Resource(gvr).Namespace(f.Config.Namespace).
+			Get(waitCtx, name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			return fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, name)
+		case err != nil && waitCtx.Err() == nil:
+			return fmt.Errorf("failed to get reconciliation status: %w", err)
+		case err == nil:
+			handled, _, _ := unstructured.NestedString(obj.Object, "status", "lastHandledReconcileAt")
+			if handled == token {
+				conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+				result.Completed = true
+				result.Status, result.Message = f.ExtractFluxStatus(conditions)
+				return nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			// The caller's own cancellation is an error; the wait timeout isn't.
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetDeploymentStatus retrieves detailed status for a Flux deployment.
 func (f *Adapter) GetDeploymentStatus(ctx context.Context, id string) (*adapter.DeploymentStatusDetail, error) {
 	if err := checkContext(ctx); err != nil {
@@ -1210,6 +1654,7 @@ func (f *Adapter) TransformHelmReleaseToDeployment(hr *unstructured.Unstructured
 	chartVersion, _, _ := unstructured.NestedString(hr.Object, "spec", "chart", "spec", "version")
 	sourceRef, _, _ := unstructured.NestedString(hr.Object, "spec", "chart", "spec", "sourceRef", "name")
 	targetNamespace, _, _ := unstructured.NestedString(hr.Object, "spec", "targetNamespace")
+	suspended, _, _ := unstructured.NestedBool(hr.Object, "spec", "suspend")
 
 	// Extract status
 	conditions, _, _ := unstructured.NestedSlice(hr.Object, "status", "conditions")
@@ -1247,6 +1692,7 @@ func (f *Adapter) TransformHelmReleaseToDeployment(hr *unstructured.Unstructured
 			"flux.sourceRef":       sourceRef,
 			"flux.targetNamespace": targetNamespace,
 			"flux.message":         message,
+			"flux.suspended":       suspended,
 		},
 	}
 }
@@ -1260,6 +1706,7 @@ func (f *Adapter) TransformKustomizationToDeployment(ks *unstructured.Unstructur
 	path, _, _ := unstructured.NestedString(ks.Object, "spec", "path")
 	sourceRef, _, _ := unstructured.NestedString(ks.Object, "spec", "sourceRef", "name")
 	targetNamespace, _, _ := unstructured.NestedString(ks.Object, "spec", "targetNamespace")
+	suspended, _, _ := unstructured.NestedBool(ks.Object, "spec", "suspend")
 
 	// Extract status
 	conditions, _, _ := unstructured.NestedSlice(ks.Object, "status", "conditions")
@@ -1293,6 +1740,7 @@ func (f *Adapter) TransformKustomizationToDeployment(ks *unstructured.Unstructur
 			"flux.targetNamespace":     targetNamespace,
 			"flux.lastAppliedRevision": lastAppliedRevision,
 			"flux.message":             message,
+			"flux.suspended":           suspended,
 		},
 	}
 }
@@ -1303,6 +1751,7 @@ func (f *Adapter) transformGitRepoToPackage(repo *unstructured.Unstructured) *ad
 	url, _, _ := unstructured.NestedString(repo.Object, "spec", "url")
 	branch, _, _ := unstructured.NestedString(repo.Object, "spec", "ref", "branch")
 	tag, _, _ := unstructured.NestedString(repo.Object, "spec", "ref", "tag")
+	secretRef, _, _ := unstructured.NestedString(repo.Object, "spec", "secretRef", "name")
 
 	version := branch
 	if tag != "" {
@@ -1319,9 +1768,10 @@ func (f *Adapter) transformGitRepoToPackage(repo *unstructured.Unstructured) *ad
 		Description: fmt.Sprintf("Flux GitRepository: %s", url),
 		UploadedAt:  creationTimestamp,
 		Extensions: map[string]interface{}{
-			"flux.url":    url,
-			"flux.branch": branch,
-			"flux.tag":    tag,
+			"flux.url":       url,
+			"flux.branch":    branch,
+			"flux.tag":       tag,
+			"flux.secretRef": secretRef,
 		},
 	}
 }
@@ -1331,6 +1781,7 @@ func (f *Adapter) transformHelmRepoToPackage(repo *unstructured.Unstructured) *a
 	name, _, _ := unstructured.NestedString(repo.Object, "metadata", "name")
 	url, _, _ := unstructured.NestedString(repo.Object, "spec", "url")
 	repoType, _, _ := unstructured.NestedString(repo.Object, "spec", "type")
+	secretRef, _, _ := unstructured.NestedString(repo.Object, "spec", "secretRef", "name")
 
 	if repoType == "" {
 		repoType = "default"
@@ -1346,8 +1797,9 @@ func (f *Adapter) transformHelmRepoToPackage(repo *unstructured.Unstructured) *a
 		Description: fmt.Sprintf("Flux HelmRepository: %s", url),
 		UploadedAt:  creationTimestamp,
 		Extensions: map[string]interface{}{
-			"flux.url":      url,
-			"flux.repoType": repoType,
+			"flux.url":       url,
+			"flux.repoType":  repoType,
+			"flux.secretRef": secretRef,
 		},
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -109,6 +110,7 @@ func TestAdapterMetadata(t *testing.T) {
 		assert.Contains(t, caps, dmsadapter.CapabilityGitOps)
 		assert.Contains(t, caps, dmsadapter.CapabilityRollback)
 		assert.Contains(t, caps, dmsadapter.CapabilityHealthChecks)
+		assert.Contains(t, caps, dmsadapter.CapabilitySuspend)
 	})
 
 	t.Run("SupportsRollback", func(t *testing.T) {
@@ -870,6 +872,203 @@ func TestRollbackDeployment(t *testing.T) {
 	}
 }
 
+// TestCancelOperation tests suspending an in-progress reconciliation.
+func TestCancelOperation(t *testing.T) {
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		deployID string
+		wantGVR  schema.GroupVersionResource
+		wantErr  error
+	}{
+		{
+			name:     "suspend reconciling helmrelease",
+			objects:  []runtime.Object{createTestHelmRelease("hr-pending", "nginx", false)},
+			deployID: "hr-pending",
+			wantGVR:  flux.HelmReleaseGVR,
+		},
+		{
+			name:     "ready helmrelease has nothing to cancel",
+			objects:  []runtime.Object{createTestHelmRelease("hr-ready", "nginx", true)},
+			deployID: "hr-ready",
+			wantErr:  dmsadapter.ErrNoOperationInProgress,
+		},
+		{
+			name:     "ready kustomization has nothing to cancel",
+			objects:  []runtime.Object{createTestKustomization("ks-ready")},
+			deployID: "ks-ready",
+			wantErr:  dmsadapter.ErrNoOperationInProgress,
+		},
+		{
+			name:     "deployment not found",
+			objects:  []runtime.Object{},
+			deployID: "nonexistent",
+			wantErr:  dmsadapter.ErrDeploymentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adp := createFakeAdapter(t, tt.objects...)
+			err := adp.CancelOperation(context.Background(), tt.deployID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			obj, err := adp.DynamicClient.Resource(tt.wantGVR).Namespace(adp.Config.Namespace).
+				Get(context.Background(), tt.deployID, metav1.GetOptions{})
+			require.NoError(t, err)
+			suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+			assert.True(t, suspended)
+
+			// A suspended deployment has nothing left to cancel.
+			assert.ErrorIs(t, adp.CancelOperation(context.Background(), tt.deployID), dmsadapter.ErrNoOperationInProgress)
+		})
+	}
+}
+
+// TestSuspendResumeDeployment tests pausing and resuming reconciliation.
+func TestSuspendResumeDeployment(t *testing.T) {
+	tests := []struct {
+		name     string
+		object   runtime.Object
+		deployID string
+		gvr      schema.GroupVersionResource
+	}{
+		{
+			name:     "helmrelease",
+			object:   createTestHelmRelease("hr-app", "nginx", true),
+			deployID: "hr-app",
+			gvr:      flux.HelmReleaseGVR,
+		},
+		{
+			name:     "kustomization",
+			object:   createTestKustomization("ks-app"),
+			deployID: "ks-app",
+			gvr:      flux.KustomizationGVR,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			adp := createFakeAdapter(t, tt.object)
+			get := func() *unstructured.Unstructured {
+				obj, err := adp.DynamicClient.Resource(tt.gvr).Namespace(adp.Config.Namespace).
+					Get(ctx, tt.deployID, metav1.GetOptions{})
+				require.NoError(t, err)
+				return obj
+			}
+
+			require.NoError(t, adp.SuspendDeployment(ctx, tt.deployID))
+			suspended, _, _ := unstructured.NestedBool(get().Object, "spec", "suspend")
+			assert.True(t, suspended)
+
+			deployment, err := adp.GetDeployment(ctx, tt.deployID)
+			require.NoError(t, err)
+			assert.Equal(t, true, deployment.Extensions["flux.suspended"])
+
+			// Suspending twice is a no-op.
+			require.NoError(t, adp.SuspendDeployment(ctx, tt.deployID))
+
+			require.NoError(t, adp.ResumeDeployment(ctx, tt.deployID))
+			obj := get()
+			suspended, _, _ = unstructured.NestedBool(obj.Object, "spec", "suspend")
+			assert.False(t, suspended)
+			assert.Contains(t, obj.GetAnnotations(), "reconcile.fluxcd.io/requestedAt")
+		})
+	}
+
+	t.Run("deployment not found", func(t *testing.T) {
+		adp := createFakeAdapter(t)
+		require.ErrorIs(t, adp.SuspendDeployment(context.Background(), "nonexistent"),
+			dmsadapter.ErrDeploymentNotFound)
+		require.ErrorIs(t, adp.ResumeDeployment(context.Background(), "nonexistent"),
+			dmsadapter.ErrDeploymentNotFound)
+	})
+}
+
+func TestReconcileDeployment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request without waiting", func(t *testing.T) {
+		adp := createFakeAdapter(t, createTestKustomization("ks-app"))
+
+		result, err := adp.ReconcileDeployment(ctx, "ks-app", nil)
+		require.NoError(t, err)
+		assert.False(t, result.Completed)
+
+		obj, err := adp.DynamicClient.Resource(flux.KustomizationGVR).Namespace(adp.Config.Namespace).
+			Get(ctx, "ks-app", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, result.RequestedAt.Format(time.RFC3339Nano),
+			obj.GetAnnotations()[flux.ReconcileRequestedAtAnnotation])
+	})
+
+	t.Run("wait for the controller", func(t *testing.T) {
+		adp := createFakeAdapter(t, createTestHelmRelease("hr-app", "nginx", true))
+		adp.Config.PollInterval = 10 * time.Millisecond
+		helmReleases := adp.DynamicClient.Resource(flux.HelmReleaseGVR).Namespace(adp.Config.Namespace)
+
+		// Acknowledge the request like the helm-controller does.
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case <-time.After(5 * time.Millisecond):
+				}
+				obj, err := helmReleases.Get(ctx, "hr-app", metav1.GetOptions{})
+				if err != nil {
+					continue
+				}
+				token := obj.GetAnnotations()[flux.ReconcileRequestedAtAnnotation]
+				if token == "" {
+					continue
+				}
+				_ = unstructured.SetNestedField(obj.Object, token, "status", "lastHandledReconcileAt")
+				_, _ = helmReleases.Update(ctx, obj, metav1.UpdateOptions{})
+				return
+			}
+		}()
+
+		result, err := adp.ReconcileDeployment(ctx, "hr-app",
+			&dmsadapter.ReconcileOptions{Wait: true, Timeout: 5 * time.Second})
+		require.NoError(t, err)
+		assert.True(t, result.Completed)
+		assert.Equal(t, dmsadapter.DeploymentStatusDeployed, result.Status)
+	})
+
+	t.Run("wait times out", func(t *testing.T) {
+		adp := createFakeAdapter(t, createTestHelmRelease("hr-app", "nginx", true))
+		adp.Config.PollInterval = 10 * time.Millisecond
+
+		result, err := adp.ReconcileDeployment(ctx, "hr-app",
+			&dmsadapter.ReconcileOptions{Wait: true, Timeout: 50 * time.Millisecond})
+		require.NoError(t, err)
+		assert.False(t, result.Completed)
+	})
+
+	t.Run("suspended", func(t *testing.T) {
+		adp := createFakeAdapter(t, createTestKustomization("ks-app"))
+		require.NoError(t, adp.SuspendDeployment(ctx, "ks-app"))
+
+		_, err := adp.ReconcileDeployment(ctx, "ks-app", nil)
+		require.ErrorIs(t, err, dmsadapter.ErrDeploymentSuspended)
+	})
+
+	t.Run("deployment not found", func(t *testing.T) {
+		adp := createFakeAdapter(t)
+		_, err := adp.ReconcileDeployment(ctx, "nonexistent", nil)
+		require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+	})
+}
+
 // TestGetDeploymentStatus tests retrieving deployment status.
 func TestGetDeploymentStatus(t *testing.T) {
 	healthyHR := createTestHelmRelease("healthy-hr", "nginx", true)
@@ -1117,6 +1316,27 @@ func TestUploadDeploymentPackage(t *testing.T) {
 			wantErr:     true,
 			errContains: "flux.url extension is required",
 		},
+		{
+			name: "invalid name",
+			pkg: &dmsadapter.DeploymentPackageUpload{
+				Name:       "My_Repo",
+				Extensions: map[string]interface{}{"flux.url": "https://github.com/example/repo"},
+			},
+			wantErr:     true,
+			errContains: "lowercase alphanumeric",
+		},
+		{
+			name: "unsupported type",
+			pkg: &dmsadapter.DeploymentPackageUpload{
+				Name: "my-bucket",
+				Extensions: map[string]interface{}{
+					"flux.url":  "s3://bucket",
+					"flux.type": "bucket",
+				},
+			},
+			wantErr:     true,
+			errContains: "unsupported flux.type",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1138,12 +1358,130 @@ func TestUploadDeploymentPackage(t *testing.T) {
 	}
 }
 
-// TestDeleteDeploymentPackage tests that package deletion is not supported.
-func TestDeleteDeploymentPackage(t *testing.T) {
+// TestUploadDeploymentPackage_CreatesSources tests that uploaded packages are
+// backed by Flux source resources that can be listed and referenced.
+func TestUploadDeploymentPackage_CreatesSources(t *testing.T) {
+	ctx := context.Background()
 	adp := createFakeAdapter(t)
-	err := adp.DeleteDeploymentPackage(context.Background(), "any-id")
+
+	gitPkg, err := adp.UploadDeploymentPackage(ctx, &dmsadapter.DeploymentPackageUpload{
+		Name:    "my-repo",
+		Version: "main",
+		Extensions: map[string]interface{}{
+			"flux.url":       "https://github.com/example/repo",
+			"flux.type":      "git",
+			"flux.tag":       "v1.2.0",
+			"flux.secretRef": "git-credentials",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "flux-git", gitPkg.PackageType)
+	assert.Equal(t, "v1.2.0", gitPkg.Version)
+
+	gitRepo, err := adp.DynamicClient.Resource(flux.GitRepositoryGVR).
+		Namespace("flux.flux-system").Get(ctx, "my-repo", metav1.GetOptions{})
+	require.NoError(t, err)
+	url, _, _ := unstructured.NestedString(gitRepo.Object, "spec", "url")
+	assert.Equal(t, "https://github.com/example/repo", url)
+	tag, _, _ := unstructured.NestedString(gitRepo.Object, "spec", "ref", "tag")
+	assert.Equal(t, "v1.2.0", tag)
+	_, hasBranch, _ := unstructured.NestedString(gitRepo.Object, "spec", "ref", "branch")
+	assert.False(t, hasBranch)
+	secretRef, _, _ := unstructured.NestedString(gitRepo.Object, "spec", "secretRef", "name")
+	assert.Equal(t, "git-credentials", secretRef)
+
+	helmPkg, err := adp.UploadDeploymentPackage(ctx, &dmsadapter.DeploymentPackageUpload{
+		Name: "podinfo",
+		Extensions: map[string]interface{}{
+			"flux.url":      "oci://ghcr.io/stefanprodan/charts",
+			"flux.type":     "helm",
+			"flux.repoType": "oci",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "flux-helm", helmPkg.PackageType)
+	assert.Equal(t, "oci", helmPkg.Extensions["flux.repoType"])
+
+	helmRepo, err := adp.DynamicClient.Resource(flux.HelmRepositoryGVR).
+		Namespace("flux.flux-system").Get(ctx, "podinfo", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, hasSecret, _ := unstructured.NestedMap(helmRepo.Object, "spec", "secretRef")
+	assert.False(t, hasSecret)
+
+	// Uploaded packages are visible through the package API.
+	got, err := adp.GetDeploymentPackage(ctx, gitPkg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "my-repo", got.Name)
+
+	// Uploading the same name twice fails instead of silently overwriting.
+	_, err = adp.UploadDeploymentPackage(ctx, &dmsadapter.DeploymentPackageUpload{
+		Name:       "my-repo",
+		Extensions: map[string]interface{}{"flux.url": "https://github.com/example/other"},
+	})
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "does not support package deletion")
+	assert.Contains(t, err.Error(), "failed to create Flux GitRepository")
+}
+
+// TestDeleteDeploymentPackage tests deleting Flux sources and the check for
+// deployments that still reference them.
+func TestDeleteDeploymentPackage(t *testing.T) {
+	ctx := context.Background()
+	gitID := flux.GeneratePackageID("git", "https://github.com/example/infra")
+	helmID := flux.GeneratePackageID("helm", "https://charts.bitnami.com/bitnami")
+
+	t.Run("deletes unreferenced sources", func(t *testing.T) {
+		adp := createFakeAdapter(t,
+			createTestGitRepository("infra", "flux.flux-system", "https://github.com/example/infra", "main"),
+			createTestHelmRepository("charts", "flux.flux-system", "https://charts.bitnami.com/bitnami"),
+		)
+
+		require.NoError(t, adp.DeleteDeploymentPackage(ctx, gitID))
+		require.NoError(t, adp.DeleteDeploymentPackage(ctx, helmID))
+
+		packages, err := adp.ListDeploymentPackages(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, packages)
+	})
+
+	t.Run("refuses a HelmRepository referenced by a HelmRelease", func(t *testing.T) {
+		adp := createFakeAdapter(t,
+			createTestHelmRepository("bitnami", "flux.flux-system", "https://charts.bitnami.com/bitnami"),
+			createTestHelmRelease("nginx", "nginx", true),
+		)
+
+		err := adp.DeleteDeploymentPackage(ctx, helmID)
+		require.ErrorIs(t, err, dmsadapter.ErrPackageInUse)
+		assert.Contains(t, err.Error(), "HelmRelease flux.flux-system/nginx")
+
+		_, err = adp.GetDeploymentPackage(ctx, helmID)
+		require.NoError(t, err, "referenced source must not be deleted")
+	})
+
+	t.Run("refuses a GitRepository referenced by a Kustomization", func(t *testing.T) {
+		adp := createFakeAdapter(t,
+			createTestGitRepository("infra-repo", "flux.flux-system", "https://github.com/example/infra", "main"),
+			createTestKustomization("apps"),
+		)
+
+		err := adp.DeleteDeploymentPackage(ctx, gitID)
+		require.ErrorIs(t, err, dmsadapter.ErrPackageInUse)
+		assert.Contains(t, err.Error(), "Kustomization flux.flux-system/apps")
+	})
+
+	t.Run("source of the same name but another kind is not a reference", func(t *testing.T) {
+		adp := createFakeAdapter(t,
+			createTestGitRepository("bitnami", "flux.flux-system", "https://github.com/example/infra", "main"),
+			createTestHelmRelease("nginx", "nginx", true),
+		)
+
+		require.NoError(t, adp.DeleteDeploymentPackage(ctx, gitID))
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		adp := createFakeAdapter(t)
+		err := adp.DeleteDeploymentPackage(ctx, "git-https-github-com-missing")
+		require.ErrorIs(t, err, dmsadapter.ErrPackageNotFound)
+	})
 }
 
 // TestHealth tests the health check functionality.
@@ -1795,13 +2133,6 @@ func TestTypedErrors(t *testing.T) {
 		assert.ErrorIs(t, err, flux.ErrPackageNotFound)
 	})
 
-	t.Run("ErrOperationNotSupported", func(t *testing.T) {
-		adp := createFakeAdapter(t)
-		err := adp.DeleteDeploymentPackage(context.Background(), "any-id")
-		require.Error(t, err)
-		assert.ErrorIs(t, err, flux.ErrOperationNotSupported)
-	})
-
 	t.Run("ErrInvalidName on CreateDeployment", func(t *testing.T) {
 		adp := createFakeAdapter(t)
 		_, err := adp.CreateDeployment(context.Background(), &dmsadapter.DeploymentRequest{
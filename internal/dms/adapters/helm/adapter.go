@@ -15,6 +15,7 @@ import (
 
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/release"
@@ -167,6 +168,9 @@ func (h *Adapter) Capabilities() []adapter.Capability {
 		adapter.CapabilityScaling,
 		adapter.CapabilityHealthChecks,
 		adapter.CapabilityMetrics,
+		adapter.CapabilityOperationCancel,
+		adapter.CapabilityReleaseNotes,
+		adapter.CapabilityPreview,
 	}
 }
 
@@ -364,31 +368,55 @@ func (h *Adapter) ListDeployments(ctx context.Context, filter *adapter.Filter) (
 		return nil, err
 	}
 
-	releases, err := h.fetchAllReleases()
+	client := action.NewList(h.ActionCfg)
+	client.All = true
+	client.AllNamespaces = true
+
+	// Helm filters releases by state and cuts the page before they are
+	// returned, so only the releases of the page are transformed. Helm cannot
+	// filter by namespace; with a namespace filter the page is cut here.
+	pushdown := filter != nil && filter.Namespace == ""
+	if pushdown {
+		client.Limit = filter.Limit
+		client.Offset = filter.Offset
+		if filter.Status != "" {
+			client.StateMask &= ReleaseStates(filter.Status)
+		}
+	}
+
+	releases, err := client.Run()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to list Helm releases: %w", err)
 	}
 
 	deployments := h.FilterAndTransformReleases(releases, filter)
 
-	if filter != nil {
+	if filter != nil && !pushdown {
 		deployments = h.ApplyPagination(deployments, filter.Limit, filter.Offset)
 	}
 
 	return deployments, nil
 }
 
-// fetchAllReleases retrieves all Helm releases.
-func (h *Adapter) fetchAllReleases() ([]*release.Release, error) {
-	client := action.NewList(h.ActionCfg)
-	client.All = true
-	client.AllNamespaces = true
-
-	releases, err := client.Run()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list Helm releases: %w", err)
+// ReleaseStates returns the Helm release states TransformHelmStatus maps to
+// status, so that a status filter can be applied by Helm.
+func ReleaseStates(status adapter.DeploymentStatus) action.ListStates {
+	switch status {
+	case adapter.DeploymentStatusPending:
+		return action.ListPendingInstall
+	case adapter.DeploymentStatusDeploying:
+		return action.ListPendingUpgrade
+	case adapter.DeploymentStatusDeployed:
+		return action.ListDeployed
+	case adapter.DeploymentStatusFailed:
+		return action.ListFailed | action.ListSuperseded | action.ListUnknown
+	case adapter.DeploymentStatusRollingBack:
+		return action.ListPendingRollback
+	case adapter.DeploymentStatusDeleting:
+		return action.ListUninstalling | action.ListUninstalled
+	default:
+		return 0
 	}
-	return releases, nil
 }
 
 // FilterAndTransformReleases transforms releases and applies filters.
@@ -441,7 +469,11 @@ func (h *Adapter) GetDeployment(ctx context.Context, id string) (*adapter.Deploy
 		return nil, fmt.Errorf("failed to get Helm release: %w", err)
 	}
 
-	return h.TransformReleaseToDeployment(rel), nil
+	deployment := h.TransformReleaseToDeployment(rel)
+	if rel.Info != nil {
+		deployment.Notes = rel.Info.Notes
+	}
+	return deployment, nil
 }
 
 // CreateDeployment installs a new Helm release.
@@ -484,6 +516,10 @@ func (h *Adapter) CreateDeployment(
 		return nil, fmt.Errorf("failed to load chart: %w", err)
 	}
 
+	if err := ValidateValues(chartRequested, req.PackageID, req.Values); err != nil {
+		return nil, err
+	}
+
 	// Install release
 	rel, err := client.RunWithContext(ctx, chartRequested, req.Values)
 	if err != nil {
@@ -519,6 +555,12 @@ func (h *Adapter) UpdateDeployment(
 		return nil, fmt.Errorf("failed to get current release: %w", err)
 	}
 
+	if chrt := currentRelease.Chart; chrt != nil && chrt.Metadata != nil {
+		if err := ValidateValues(chrt, chrt.Metadata.Name, update.Values); err != nil {
+			return nil, err
+		}
+	}
+
 	// Upgrade with new values
 	rel, err := client.RunWithContext(ctx, id, currentRelease.Chart, update.Values)
 	if err != nil {
@@ -611,6 +653,69 @@ func (h *Adapter) RollbackDeployment(ctx context.Context, id string, revision in
 	return nil
 }
 
+// CancelOperation aborts a pending Helm operation. A release stuck in
+// pending-install is uninstalled; a pending upgrade or rollback is rolled back
+// to the previous revision.
+func (h *Adapter) CancelOperation(ctx context.Context, id string) error {
+	if err := h.Initialize(ctx); err != nil {
+		return err
+	}
+
+	rel, err := action.NewGet(h.ActionCfg).Run(id)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+		}
+		return fmt.Errorf("failed to get Helm release: %w", err)
+	}
+
+	if rel.Info == nil || !rel.Info.Status.IsPending() {
+		return fmt.Errorf("%w: release %s", adapter.ErrNoOperationInProgress, id)
+	}
+
+	if rel.Info.Status == release.StatusPendingInstall {
+		client := action.NewUninstall(h.ActionCfg)
+		client.Timeout = h.Config.Timeout
+		if _, err := client.Run(id); err != nil {
+			return fmt.Errorf("helm uninstall of pending release failed: %w", err)
+		}
+		return nil
+	}
+
+	client := action.NewRollback(h.ActionCfg)
+	client.Timeout = h.Config.Timeout
+	client.CleanupOnFail = true
+	if err := client.Run(id); err != nil {
+		return fmt.Errorf("helm rollback of pending release failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeploymentNotes returns the rendered NOTES.txt of the current release revision.
+func (h *Adapter) GetDeploymentNotes(ctx context.Context, id string) (*adapter.DeploymentNotes, error) {
+	if err := h.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	rel, err := action.NewGet(h.ActionCfg).Run(id)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get Helm release: %w", err)
+	}
+
+	notes := &adapter.DeploymentNotes{
+		DeploymentID: rel.Name,
+		Revision:     rel.Version,
+	}
+	if rel.Info != nil {
+		notes.Notes = rel.Info.Notes
+	}
+	return notes, nil
+}
+
 // GetDeploymentStatus retrieves detailed status for a deployment.
 func (h *Adapter) GetDeploymentStatus(ctx context.Context, id string) (*adapter.DeploymentStatusDetail, error) {
 	if err := h.Initialize(ctx); err != nil {
@@ -811,6 +916,22 @@ func (h *Adapter) Health(ctx context.Context) error {
 	return nil
 }
 
+// ProbeConfig verifies Kubernetes connectivity and, when a chart repository
+// is configured, that its index can be downloaded with the configured
+// credentials.
+func (h *Adapter) ProbeConfig(ctx context.Context) error {
+	if err := h.Health(ctx); err != nil {
+		return err
+	}
+	if h.Config.RepositoryURL == "" {
+		return nil
+	}
+	if err := h.LoadRepositoryIndex(ctx); err != nil {
+		return fmt.Errorf("helm repository %s: %w", h.Config.RepositoryURL, err)
+	}
+	return nil
+}
+
 // Test helper exports for testing private functions
 
 // TestBuildPackageList exports buildPackageList for testing.
@@ -903,6 +1024,7 @@ func (h *Adapter) TransformReleaseToDeployment(rel *release.Release) *adapter.De
 		Description: rel.Info.Description,
 		CreatedAt:   rel.Info.FirstDeployed.Time,
 		UpdatedAt:   rel.Info.LastDeployed.Time,
+		Values:      effectiveValues(rel),
 		Extensions: map[string]interface{}{
 			"helm.releaseName":  rel.Name,
 			"helm.revision":     rel.Version,
@@ -914,6 +1036,16 @@ func (h *Adapter) TransformReleaseToDeployment(rel *release.Release) *adapter.De
 	}
 }
 
+// effectiveValues returns the chart default values overridden by the release
+// values, falling back to the release values if they cannot be merged.
+func effectiveValues(rel *release.Release) map[string]interface{} {
+	values, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return rel.Config
+	}
+	return values
+}
+
 // TransformReleaseToStatus converts a Helm release to detailed status.
 func (h *Adapter) TransformReleaseToStatus(rel *release.Release) *adapter.DeploymentStatusDetail {
 	status := &adapter.DeploymentStatusDetail{
@@ -250,7 +250,14 @@ func TestHelmAdapter_TransformReleaseToDeployment(t *testing.T) {
 				Version:    "1.0.0",
 				AppVersion: "1.0.0",
 			},
+			Values: map[string]interface{}{
+				"replicaCount": 1,
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"cpu": "100m"},
+				},
+			},
 		},
+		Config: map[string]interface{}{"replicaCount": 3},
 	}
 
 	deployment := adapter.TransformReleaseToDeployment(rel)
@@ -263,6 +270,11 @@ func TestHelmAdapter_TransformReleaseToDeployment(t *testing.T) {
 	assert.Equal(t, 3, deployment.Version)
 	assert.Equal(t, "Test deployment", deployment.Description)
 
+	// Release values override the chart defaults.
+	assert.Equal(t, 3, deployment.Values["replicaCount"])
+	assert.Equal(t, map[string]interface{}{"requests": map[string]interface{}{"cpu": "100m"}},
+		deployment.Values["resources"])
+
 	// Check extensions
 	assert.NotNil(t, deployment.Extensions)
 	assert.Equal(t, "test-release", deployment.Extensions["helm.releaseName"])
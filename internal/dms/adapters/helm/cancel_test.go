@@ -0,0 +1,96 @@
+package helm_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/helm"
+)
+
+// newMemoryAdapter returns a Helm adapter backed by in-memory release storage.
+func newMemoryAdapter(t *testing.T, releases ...*release.Release) *helm.Adapter {
+	t.Helper()
+
+	adp, err := helm.NewAdapter(&helm.Config{Namespace: "test"})
+	require.NoError(t, err)
+
+	store := storage.Init(driver.NewMemory())
+	for _, rel := range releases {
+		require.NoError(t, store.Create(rel))
+	}
+
+	adp.ActionCfg = &action.Configuration{
+		Releases:     store,
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...interface{}) {},
+	}
+	adp.Initialized = true
+	return adp
+}
+
+func testRelease(name string, version int, status release.Status) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: "test",
+		Version:   version,
+		Info:      &release.Info{Status: status},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "app", Version: "1.0.0", APIVersion: chart.APIVersionV2},
+		},
+	}
+}
+
+func TestHelmAdapter_CancelOperation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("advertises capability", func(t *testing.T) {
+		adp := newMemoryAdapter(t)
+		assert.True(t, dmsadapter.HasCapability(adp, dmsadapter.CapabilityOperationCancel))
+	})
+
+	t.Run("release not found", func(t *testing.T) {
+		adp := newMemoryAdapter(t)
+		err := adp.CancelOperation(ctx, "missing")
+		assert.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+	})
+
+	t.Run("deployed release has nothing to cancel", func(t *testing.T) {
+		adp := newMemoryAdapter(t, testRelease("app", 1, release.StatusDeployed))
+		err := adp.CancelOperation(ctx, "app")
+		assert.ErrorIs(t, err, dmsadapter.ErrNoOperationInProgress)
+	})
+
+	t.Run("pending install is uninstalled", func(t *testing.T) {
+		adp := newMemoryAdapter(t, testRelease("app", 1, release.StatusPendingInstall))
+		require.NoError(t, adp.CancelOperation(ctx, "app"))
+
+		_, err := action.NewGet(adp.ActionCfg).Run("app")
+		assert.ErrorIs(t, err, driver.ErrReleaseNotFound)
+	})
+
+	t.Run("pending upgrade is rolled back", func(t *testing.T) {
+		adp := newMemoryAdapter(t,
+			testRelease("app", 1, release.StatusDeployed),
+			testRelease("app", 2, release.StatusPendingUpgrade),
+		)
+		require.NoError(t, adp.CancelOperation(ctx, "app"))
+
+		rel, err := action.NewGet(adp.ActionCfg).Run("app")
+		require.NoError(t, err)
+		assert.Equal(t, 3, rel.Version)
+		assert.Equal(t, release.StatusDeployed, rel.Info.Status)
+	})
+}
@@ -0,0 +1,143 @@
+package helm_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/helm"
+)
+
+// manyReleases returns n releases named app-0000 and up; every third one has
+// failed.
+func manyReleases(n int) []*release.Release {
+	releases := make([]*release.Release, 0, n)
+	for i := range n {
+		status := release.StatusDeployed
+		if i%3 == 0 {
+			status = release.StatusFailed
+		}
+		rel := testRelease(fmt.Sprintf("app-%04d", i), 1, status)
+		rel.Config = map[string]interface{}{
+			"replicaCount": i,
+			"image":        map[string]interface{}{"repository": "registry.example.com/app", "tag": "1.0.0"},
+		}
+		releases = append(releases, rel)
+	}
+	return releases
+}
+
+func deploymentNames(deployments []*dmsadapter.Deployment) []string {
+	names := make([]string, 0, len(deployments))
+	for _, d := range deployments {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+func TestHelmAdapter_ListDeployments_Pagination(t *testing.T) {
+	ctx := context.Background()
+	adp := newMemoryAdapter(t, manyReleases(12)...)
+
+	tests := []struct {
+		name   string
+		filter *dmsadapter.Filter
+		want   []string
+	}{
+		{
+			name:   "limit and offset",
+			filter: &dmsadapter.Filter{Limit: 3, Offset: 4},
+			want:   []string{"app-0004", "app-0005", "app-0006"},
+		},
+		{
+			name:   "offset without limit",
+			filter: &dmsadapter.Filter{Offset: 10},
+			want:   []string{"app-0010", "app-0011"},
+		},
+		{
+			name:   "offset past the end",
+			filter: &dmsadapter.Filter{Limit: 5, Offset: 20},
+			want:   []string{},
+		},
+		{
+			name:   "status filter",
+			filter: &dmsadapter.Filter{Status: dmsadapter.DeploymentStatusFailed, Limit: 2, Offset: 1},
+			want:   []string{"app-0003", "app-0006"},
+		},
+		{
+			name:   "status without matching releases",
+			filter: &dmsadapter.Filter{Status: dmsadapter.DeploymentStatusRollingBack, Limit: 2},
+			want:   []string{},
+		},
+		{
+			name:   "namespace filter falls back to in-memory pagination",
+			filter: &dmsadapter.Filter{Namespace: "test", Limit: 2, Offset: 1},
+			want:   []string{"app-0001", "app-0002"},
+		},
+		{
+			name:   "other namespace",
+			filter: &dmsadapter.Filter{Namespace: "other", Limit: 2},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployments, err := adp.ListDeployments(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, deploymentNames(deployments))
+		})
+	}
+}
+
+func TestHelmAdapter_ListDeployments_PaginationMemory(t *testing.T) {
+	ctx := context.Background()
+	adp := newMemoryAdapter(t, manyReleases(2000)...)
+
+	allocated := func(filter *dmsadapter.Filter) uint64 {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		deployments, err := adp.ListDeployments(ctx, filter)
+		runtime.ReadMemStats(&after)
+		require.NoError(t, err)
+		require.NotEmpty(t, deployments)
+		return after.TotalAlloc - before.TotalAlloc
+	}
+
+	// Warm up lazily initialized state so it is not attributed to either call.
+	allocated(&dmsadapter.Filter{Limit: 1})
+
+	full := allocated(&dmsadapter.Filter{Namespace: "test", Limit: 10, Offset: 1500})
+	paged := allocated(&dmsadapter.Filter{Limit: 10, Offset: 1500})
+	t.Logf("in-memory pagination allocated %d bytes, pushed down %d bytes", full, paged)
+	assert.Less(t, paged, full/4, "pushed down pagination only transforms the releases of the page")
+}
+
+func TestReleaseStates(t *testing.T) {
+	adp, err := helm.NewAdapter(&helm.Config{Namespace: "test"})
+	require.NoError(t, err)
+
+	// Every Helm state is selected by the status TransformHelmStatus maps it to.
+	states := map[release.Status]action.ListStates{
+		release.StatusUnknown:         action.ListUnknown,
+		release.StatusDeployed:        action.ListDeployed,
+		release.StatusUninstalled:     action.ListUninstalled,
+		release.StatusSuperseded:      action.ListSuperseded,
+		release.StatusFailed:          action.ListFailed,
+		release.StatusUninstalling:    action.ListUninstalling,
+		release.StatusPendingInstall:  action.ListPendingInstall,
+		release.StatusPendingUpgrade:  action.ListPendingUpgrade,
+		release.StatusPendingRollback: action.ListPendingRollback,
+	}
+	for status, state := range states {
+		assert.NotZero(t, helm.ReleaseStates(adp.TransformHelmStatus(status))&state, status)
+	}
+	assert.Zero(t, helm.ReleaseStates("unknown-status"))
+}
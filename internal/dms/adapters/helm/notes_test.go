@@ -0,0 +1,42 @@
+package helm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/release"
+
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+func TestHelmAdapter_GetDeploymentNotes(t *testing.T) {
+	ctx := context.Background()
+
+	previous := testRelease("my-app", 1, release.StatusSuperseded)
+	previous.Info.Notes = "Old instructions"
+	current := testRelease("my-app", 2, release.StatusDeployed)
+	current.Info.Notes = "Access the app at http://my-app.test.svc"
+	adp := newMemoryAdapter(t, previous, current)
+
+	assert.True(t, dmsadapter.HasCapability(adp, dmsadapter.CapabilityReleaseNotes))
+
+	notes, err := adp.GetDeploymentNotes(ctx, "my-app")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", notes.DeploymentID)
+	assert.Equal(t, 2, notes.Revision)
+	assert.Equal(t, "Access the app at http://my-app.test.svc", notes.Notes)
+
+	deployment, err := adp.GetDeployment(ctx, "my-app")
+	require.NoError(t, err)
+	assert.Equal(t, "Access the app at http://my-app.test.svc", deployment.Notes)
+
+	deployments, err := adp.ListDeployments(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Empty(t, deployments[0].Notes)
+
+	_, err = adp.GetDeploymentNotes(ctx, "missing")
+	require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+}
@@ -0,0 +1,193 @@
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+// PreviewDeployment renders the upgrade UpdateDeployment would perform as a
+// Helm dry-run and diffs its manifests against those of the current release.
+// Nothing is changed in the cluster or the release history.
+func (h *Adapter) PreviewDeployment(
+	ctx context.Context,
+	id string,
+	update *adapter.DeploymentUpdate,
+) (*adapter.DeploymentPreview, error) {
+	if err := h.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	if update == nil {
+		return nil, fmt.Errorf("deployment update cannot be nil")
+	}
+
+	currentRelease, err := action.NewGet(h.ActionCfg).Run(id)
+	if err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get Helm release: %w", err)
+	}
+
+	if chrt := currentRelease.Chart; chrt != nil && chrt.Metadata != nil {
+		if err := ValidateValues(chrt, chrt.Metadata.Name, update.Values); err != nil {
+			return nil, err
+		}
+	}
+
+	client := action.NewUpgrade(h.ActionCfg)
+	client.DryRun = true
+	client.Namespace = currentRelease.Namespace
+
+	rel, err := client.RunWithContext(ctx, id, currentRelease.Chart, update.Values)
+	if err != nil {
+		return nil, fmt.Errorf("helm upgrade dry-run failed: %w", err)
+	}
+
+	current := splitResources(currentRelease.Manifest)
+	updated := splitResources(rel.Manifest)
+
+	manifests := make([]string, 0, len(updated))
+	for _, key := range sortedKeys(updated) {
+		manifests = append(manifests, updated[key].redacted())
+	}
+
+	return &adapter.DeploymentPreview{
+		DeploymentID:    currentRelease.Name,
+		CurrentRevision: currentRelease.Version,
+		Manifests:       strings.Join(manifests, "---\n"),
+		Diff:            diffResources(current, updated),
+	}, nil
+}
+
+// manifestResource is one resource of a rendered release manifest.
+type manifestResource struct {
+	kind      string
+	namespace string
+	name      string
+	manifest  string
+}
+
+// key orders resources by kind, namespace and name.
+func (r manifestResource) key() string {
+	return r.kind + "/" + r.namespace + "/" + r.name
+}
+
+// redacted returns the manifest, without the data of Secrets.
+func (r manifestResource) redacted() string {
+	if r.kind != "Secret" {
+		return r.manifest
+	}
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(r.manifest), &obj); err != nil {
+		return ""
+	}
+	delete(obj, "data")
+	delete(obj, "stringData")
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// splitResources splits a release manifest into its resources, keyed by
+// manifestResource.key.
+func splitResources(manifest string) map[string]manifestResource {
+	resources := make(map[string]manifestResource)
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		var head struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		resource := manifestResource{
+			kind:      head.Kind,
+			namespace: head.Metadata.Namespace,
+			name:      head.Metadata.Name,
+			manifest:  strings.TrimSpace(doc) + "\n",
+		}
+		resources[resource.key()] = resource
+	}
+	return resources
+}
+
+// diffResources compares the resources of two manifests.
+func diffResources(current, updated map[string]manifestResource) []adapter.ResourceDiff {
+	keys := sortedKeys(current)
+	for key := range updated {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	diffs := make([]adapter.ResourceDiff, 0, len(keys))
+	for _, key := range keys {
+		before, inCurrent := current[key]
+		after, inUpdated := updated[key]
+
+		resource := after
+		change := adapter.ResourceModified
+		switch {
+		case !inUpdated:
+			resource, change = before, adapter.ResourceRemoved
+		case !inCurrent:
+			change = adapter.ResourceAdded
+		case before.manifest == after.manifest:
+			change = adapter.ResourceUnchanged
+		}
+
+		diff := adapter.ResourceDiff{
+			Kind:      resource.kind,
+			Namespace: resource.namespace,
+			Name:      resource.name,
+			Change:    change,
+		}
+		if change != adapter.ResourceUnchanged && resource.kind != "Secret" {
+			diff.Diff = unifiedDiff(key, before.manifest, after.manifest)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// unifiedDiff returns the unified diff between two manifests of a resource.
+func unifiedDiff(name, before, after string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "current/" + name,
+		ToFile:   "updated/" + name,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+func sortedKeys(resources map[string]manifestResource) []string {
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+package helm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+const previewCurrentManifest = `---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  level: info
+---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+data:
+  token: b2xk
+---
+# Source: app/templates/legacy.yaml
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: app-legacy
+`
+
+func previewRelease() *release.Release {
+	rel := testRelease("app", 1, release.StatusDeployed)
+	rel.Manifest = previewCurrentManifest
+	rel.Chart.Values = map[string]interface{}{"level": "info", "token": "old", "extra": false}
+	rel.Chart.Templates = []*chart.File{
+		{Name: "templates/configmap.yaml", Data: []byte(
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\ndata:\n  level: {{ .Values.level }}\n")},
+		{Name: "templates/secret.yaml", Data: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n" +
+			"  name: app-secret\ndata:\n  token: {{ .Values.token | b64enc }}\n")},
+		{Name: "templates/extra.yaml", Data: []byte(
+			"{{ if .Values.extra }}apiVersion: v1\nkind: Service\nmetadata:\n  name: app-extra\n{{ end }}")},
+	}
+	return rel
+}
+
+func TestHelmAdapter_PreviewDeployment(t *testing.T) {
+	ctx := context.Background()
+	adp := newMemoryAdapter(t, previewRelease())
+
+	assert.True(t, dmsadapter.HasCapability(adp, dmsadapter.CapabilityPreview))
+
+	preview, err := adp.PreviewDeployment(ctx, "app", &dmsadapter.DeploymentUpdate{
+		Values: map[string]interface{}{"level": "debug", "token": "new", "extra": true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "app", preview.DeploymentID)
+	assert.Equal(t, 1, preview.CurrentRevision)
+	assert.Contains(t, preview.Manifests, "level: debug")
+	assert.Contains(t, preview.Manifests, "name: app-secret")
+	assert.NotContains(t, preview.Manifests, "bmV3")
+
+	changes := make(map[string]string)
+	for _, diff := range preview.Diff {
+		changes[diff.Kind+"/"+diff.Name] = diff.Change
+		switch diff.Kind {
+		case "ConfigMap":
+			assert.Contains(t, diff.Diff, "-  level: info\n+  level: debug\n")
+		case "Secret":
+			assert.Empty(t, diff.Diff)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"ConfigMap/app-config":      dmsadapter.ResourceModified,
+		"Secret/app-secret":         dmsadapter.ResourceModified,
+		"Service/app-extra":         dmsadapter.ResourceAdded,
+		"ServiceAccount/app-legacy": dmsadapter.ResourceRemoved,
+	}, changes)
+
+	// The dry-run leaves the release history untouched.
+	history, err := adp.GetDeploymentHistory(ctx, "app")
+	require.NoError(t, err)
+	assert.Len(t, history.Revisions, 1)
+
+	_, err = adp.PreviewDeployment(ctx, "missing", &dmsadapter.DeploymentUpdate{})
+	require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+}
+
+func TestHelmAdapter_PreviewDeployment_InvalidValues(t *testing.T) {
+	rel := previewRelease()
+	rel.Chart.Schema = []byte(`{"type": "object", "properties": {"level": {"enum": ["info", "debug"]}}}`)
+	adp := newMemoryAdapter(t, rel)
+
+	_, err := adp.PreviewDeployment(context.Background(), "app", &dmsadapter.DeploymentUpdate{
+		Values: map[string]interface{}{"level": "verbose"},
+	})
+	require.ErrorIs(t, err, dmsadapter.ErrInvalidValues)
+}
@@ -0,0 +1,108 @@
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+// valuesSchemaURL is the URL the values schema of a chart is compiled under.
+const valuesSchemaURL = "file:///values.schema.json"
+
+// ValidateValues checks values, merged with the chart's default values as
+// Helm merges them, against the values.schema.json of chrt and of each of its
+// enabled subcharts. Violations are returned as an
+// *adapter.ValuesValidationError, so a request can be rejected before Helm
+// starts installing or upgrading. Schemas that cannot be compiled, e.g.
+// because they reference remote schemas, are left to Helm.
+func ValidateValues(chrt *chart.Chart, packageID string, values map[string]interface{}) error {
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, values); err != nil {
+		return fmt.Errorf("failed to process chart dependencies: %w", err)
+	}
+	merged, err := chartutil.CoalesceValues(chrt, values)
+	if err != nil {
+		return fmt.Errorf("failed to merge chart values: %w", err)
+	}
+
+	violations := chartViolations(chrt, merged.AsMap(), "")
+	if len(violations) > 0 {
+		return &adapter.ValuesValidationError{Package: packageID, Violations: violations}
+	}
+	return nil
+}
+
+// chartViolations validates the values of chrt, found at prefix in the
+// values of the parent chart, and of its subcharts.
+func chartViolations(chrt *chart.Chart, values map[string]interface{}, prefix string) []adapter.ValuesViolation {
+	var violations []adapter.ValuesViolation
+	if len(chrt.Schema) > 0 {
+		violations = append(violations, schemaViolations(chrt.Schema, values, prefix)...)
+	}
+	for _, subchart := range chrt.Dependencies() {
+		subValues, _ := values[subchart.Name()].(map[string]interface{})
+		if subValues == nil {
+			subValues = map[string]interface{}{}
+		}
+		violations = append(violations, chartViolations(subchart, subValues, prefix+"/"+subchart.Name())...)
+	}
+	return violations
+}
+
+// schemaViolations validates values against schemaJSON and returns the
+// violations with their paths prefixed by prefix.
+func schemaViolations(schemaJSON []byte, values map[string]interface{}, prefix string) []adapter.ValuesViolation {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schemaJSON))
+	if err != nil {
+		return nil
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(valuesSchemaURL, doc); err != nil {
+		return nil
+	}
+	schema, err := compiler.Compile(valuesSchemaURL)
+	if err != nil {
+		return nil
+	}
+
+	err = schema.Validate(values)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+
+	var violations []adapter.ValuesViolation
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			message := e.BasicOutput().Error.String()
+			violations = append(violations, adapter.ValuesViolation{
+				Path:    prefix + jsonPointer(e.InstanceLocation),
+				Message: message,
+			})
+			return
+		}
+		for _, cause := range e.Causes {
+			collect(cause)
+		}
+	}
+	collect(validationErr)
+	return violations
+}
+
+// jsonPointer formats a value location as a JSON pointer.
+func jsonPointer(location []string) string {
+	var sb strings.Builder
+	for _, token := range location {
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+		sb.WriteString("/" + token)
+	}
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+package helm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/helm"
+)
+
+const testValuesSchema = `{
+  "type": "object",
+  "required": ["image"],
+  "properties": {
+    "image": {"type": "string"},
+    "replicaCount": {"type": "integer", "minimum": 1},
+    "service": {
+      "type": "object",
+      "properties": {"port": {"type": "integer"}},
+      "additionalProperties": false
+    }
+  }
+}`
+
+func schemaChart() *chart.Chart {
+	cache := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "cache", Version: "1.0.0", APIVersion: chart.APIVersionV2},
+		Values:   map[string]interface{}{"size": 64},
+		Schema:   []byte(`{"type": "object", "properties": {"size": {"type": "integer", "maximum": 1024}}}`),
+	}
+	app := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name: "app", Version: "1.0.0", APIVersion: chart.APIVersionV2,
+			Dependencies: []*chart.Dependency{{Name: "cache", Version: "1.0.0"}},
+		},
+		Values: map[string]interface{}{"replicaCount": 1, "image": "nginx"},
+		Schema: []byte(testValuesSchema),
+	}
+	app.SetDependencies(cache)
+	return app
+}
+
+func TestValidateValues(t *testing.T) {
+	t.Run("valid values merged with chart defaults", func(t *testing.T) {
+		err := helm.ValidateValues(schemaChart(), "app", map[string]interface{}{"replicaCount": float64(3)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("violations of chart and subchart schemas", func(t *testing.T) {
+		err := helm.ValidateValues(schemaChart(), "app", map[string]interface{}{
+			"replicaCount": float64(0),
+			"service":      map[string]interface{}{"port": "http", "extra": true},
+			"cache":        map[string]interface{}{"size": float64(4096)},
+		})
+		require.ErrorIs(t, err, dmsadapter.ErrInvalidValues)
+
+		var invalid *dmsadapter.ValuesValidationError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "app", invalid.Package)
+		paths := make([]string, len(invalid.Violations))
+		for i, v := range invalid.Violations {
+			paths[i] = v.Path
+			assert.NotEmpty(t, v.Message)
+		}
+		assert.ElementsMatch(t, []string{"/replicaCount", "/service/port", "/service", "/cache/size"}, paths)
+	})
+
+	t.Run("chart without schema", func(t *testing.T) {
+		chrt := &chart.Chart{Metadata: &chart.Metadata{Name: "plain", Version: "1.0.0", APIVersion: chart.APIVersionV2}}
+		assert.NoError(t, helm.ValidateValues(chrt, "plain", map[string]interface{}{"anything": "goes"}))
+	})
+
+	t.Run("uncompilable schema is left to helm", func(t *testing.T) {
+		chrt := schemaChart()
+		chrt.Schema = []byte(`{"$ref": "https://schemas.example.com/values.json"}`)
+		assert.NoError(t, helm.ValidateValues(chrt, "app", nil))
+	})
+}
+
+func TestHelmAdapter_UpdateDeployment_InvalidValues(t *testing.T) {
+	rel := testRelease("app", 1, release.StatusDeployed)
+	rel.Chart = schemaChart()
+	adp := newMemoryAdapter(t, rel)
+
+	_, err := adp.UpdateDeployment(context.Background(), "app", &dmsadapter.DeploymentUpdate{
+		Values: map[string]interface{}{"image": float64(42)},
+	})
+	var invalid *dmsadapter.ValuesValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []dmsadapter.ValuesViolation{{Path: "/image", Message: "got number, want string"}},
+		invalid.Violations)
+
+	current, err := adp.GetDeployment(context.Background(), "app")
+	require.NoError(t, err)
+	assert.Equal(t, 1, current.Version, "the release is not upgraded")
+}
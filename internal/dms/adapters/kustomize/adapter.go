@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/httperror"
 )
 
 const (
@@ -165,6 +167,17 @@ func (k *Adapter) Capabilities() []adapter.Capability {
 	}
 }
 
+// ErrorRules returns the HTTP translations of the adapter's sentinel errors.
+func (k *Adapter) ErrorRules() []httperror.Rule {
+	return []httperror.Rule{
+		{Err: ErrDeploymentNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrPackageNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrInvalidName, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrInvalidPath, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrOperationNotSupported, Status: http.StatusNotImplemented, Code: httperror.CodeNotImplemented},
+	}
+}
+
 // ListDeploymentPackages retrieves all Kustomize bases/overlays.
 func (k *Adapter) ListDeploymentPackages(
 	ctx context.Context,
@@ -0,0 +1,303 @@
+// Package manifests provides an O2-DMS adapter that deploys plain Kubernetes
+// manifests with server-side apply, for network functions that ship no Helm
+// chart. Deployment packages are tarballs or Git paths of manifests, optionally
+// assembled by a kustomization. No controller is needed in the cluster: the
+// adapter applies the objects under its own field manager, labels each of them
+// with its deployment and records the applied objects, so that updates prune
+// objects removed from the package and deletes remove them all.
+package manifests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/httperror"
+)
+
+const (
+	// AdapterName is the unique identifier for the manifests adapter.
+	AdapterName = "manifests"
+
+	// AdapterVersion is the version of the adapter.
+	AdapterVersion = "1.0.0"
+
+	// DefaultNamespace is the default namespace for deployments and packages.
+	DefaultNamespace = "default"
+
+	// DefaultTimeout is the default timeout for operations.
+	DefaultTimeout = 5 * time.Minute
+
+	// PackageType is the type of the adapter's deployment packages.
+	PackageType = "manifests"
+
+	// FieldManager is the server-side apply field manager of the adapter.
+	FieldManager = "netweave-manifests"
+
+	// ManagedByLabel and ManagedByValue mark the objects the adapter applied
+	// and its package and deployment records.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "netweave-manifests"
+
+	// DeploymentLabel names the deployment an applied object belongs to.
+	DeploymentLabel = "o2dms.netweave.io/deployment"
+
+	// recordLabel distinguishes the adapter's package and deployment records.
+	recordLabel          = "o2dms.netweave.io/record"
+	recordTypePackage    = "package"
+	recordTypeDeployment = "deployment"
+
+	// maxNameLength bounds deployment names, which are used as label values.
+	maxNameLength = 63
+)
+
+// Typed errors for the manifests adapter. Missing deployments and packages
+// are reported with adapter.ErrDeploymentNotFound and adapter.ErrPackageNotFound.
+var (
+	// ErrInvalidName is returned when a deployment name is invalid.
+	ErrInvalidName = errors.New("invalid name")
+
+	// ErrInvalidPackage is returned when a deployment package can't be read.
+	ErrInvalidPackage = errors.New("invalid deployment package")
+
+	// ErrInvalidManifest is returned when a manifest of a package can't be
+	// applied as part of a deployment.
+	ErrInvalidManifest = errors.New("invalid manifest")
+
+	// ErrPackageExists is returned when a package with the same name and
+	// version was already uploaded.
+	ErrPackageExists = errors.New("deployment package already exists")
+
+	// ErrDeploymentExists is returned when a deployment with the same name
+	// exists in any namespace.
+	ErrDeploymentExists = errors.New("deployment already exists")
+
+	// ErrValuesNotSupported is returned when a deployment request carries
+	// values, which plain manifests have no way to consume.
+	ErrValuesNotSupported = errors.New("deployment values are not supported by plain manifests")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Adapter implements the DMS adapter interface for plain manifests.
+type Adapter struct {
+	Config        *Config           // Exported for testing
+	DynamicClient dynamic.Interface // Exported for testing
+	Mapper        meta.RESTMapper   // Exported for testing
+	FetchGit      GitFetcher        // Exported for testing
+	InitOnce      sync.Once         // Exported for testing
+	initErr       error
+}
+
+// Config contains configuration for the manifests adapter.
+type Config struct {
+	// Kubeconfig is the path to the Kubernetes config file.
+	Kubeconfig string
+
+	// Namespace holds the package records and is the default namespace of
+	// deployments.
+	Namespace string
+
+	// Timeout is the default timeout for operations.
+	Timeout time.Duration
+
+	// Force makes server-side apply take over fields owned by other field
+	// managers instead of failing with a conflict.
+	Force bool
+
+	// AllowClusterScoped permits packages to contain cluster-scoped objects
+	// such as CustomResourceDefinitions or ClusterRoles. Namespaced objects
+	// are always confined to the deployment's namespace.
+	AllowClusterScoped bool
+}
+
+// NewAdapter creates a new manifests adapter instance.
+func NewAdapter(config *Config) (*Adapter, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	if config.Namespace == "" {
+		config.Namespace = DefaultNamespace
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	return &Adapter{
+		Config:   config,
+		FetchGit: CloneGit,
+	}, nil
+}
+
+// initialize performs lazy initialization of the Kubernetes clients.
+func (m *Adapter) initialize() error {
+	// Skip initialization if the clients are already set (e.g., in tests)
+	if m.DynamicClient != nil && m.Mapper != nil {
+		return nil
+	}
+
+	m.InitOnce.Do(func() {
+		var cfg *rest.Config
+		var err error
+
+		if m.Config.Kubeconfig != "" {
+			cfg, err = clientcmd.BuildConfigFromFlags("", m.Config.Kubeconfig)
+		} else {
+			cfg, err = rest.InClusterConfig()
+		}
+		if err != nil {
+			m.initErr = fmt.Errorf("failed to create Kubernetes config: %w", err)
+			return
+		}
+
+		m.DynamicClient, err = dynamic.NewForConfig(cfg)
+		if err != nil {
+			m.initErr = fmt.Errorf("failed to create dynamic client: %w", err)
+			return
+		}
+
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			m.initErr = fmt.Errorf("failed to create discovery client: %w", err)
+			return
+		}
+		m.Mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	})
+
+	return m.initErr
+}
+
+// Name returns the adapter name.
+func (m *Adapter) Name() string {
+	return AdapterName
+}
+
+// Version returns the adapter version.
+func (m *Adapter) Version() string {
+	return AdapterVersion
+}
+
+// Capabilities returns the capabilities supported by the manifests adapter.
+func (m *Adapter) Capabilities() []adapter.Capability {
+	return []adapter.Capability{
+		adapter.CapabilityPackageManagement,
+		adapter.CapabilityDeploymentLifecycle,
+		adapter.CapabilityHealthChecks,
+	}
+}
+
+// ErrorRules returns the HTTP translations of the adapter's sentinel errors.
+func (m *Adapter) ErrorRules() []httperror.Rule {
+	return []httperror.Rule{
+		{Err: ErrInvalidName, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrInvalidPackage, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrInvalidManifest, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrValuesNotSupported, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrPackageExists, Status: http.StatusConflict, Code: httperror.CodeConflict},
+		{Err: ErrDeploymentExists, Status: http.StatusConflict, Code: httperror.CodeConflict},
+	}
+}
+
+// ScaleDeployment is not supported: the package is the desired state, and
+// the next update would revert a scaled replica count.
+func (m *Adapter) ScaleDeployment(ctx context.Context, _ string, replicas int) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if replicas < 0 {
+		return fmt.Errorf("replicas must be non-negative")
+	}
+
+	return fmt.Errorf("manifests adapter %w: change the replicas in the package and update the deployment",
+		adapter.ErrOperationNotSupported)
+}
+
+// RollbackDeployment is not supported: update the deployment to the package
+// of the earlier version instead.
+func (m *Adapter) RollbackDeployment(ctx context.Context, _ string, revision int) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if revision < 0 {
+		return fmt.Errorf("revision must be non-negative")
+	}
+
+	return fmt.Errorf("manifests adapter %w: update the deployment to an earlier package instead",
+		adapter.ErrOperationNotSupported)
+}
+
+// SupportsRollback returns false as deployments keep no previous revisions.
+func (m *Adapter) SupportsRollback() bool {
+	return false
+}
+
+// SupportsScaling returns false as the package defines the replica counts.
+func (m *Adapter) SupportsScaling() bool {
+	return false
+}
+
+// SupportsGitOps returns false as deployments are applied once per request
+// rather than reconciled continuously.
+func (m *Adapter) SupportsGitOps() bool {
+	return false
+}
+
+// Health performs a health check on the Kubernetes cluster.
+func (m *Adapter) Health(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return fmt.Errorf("manifests adapter not healthy: %w", err)
+	}
+
+	_, err := m.DynamicClient.Resource(schema.GroupVersionResource{
+		Group:    "",
+		Version:  "v1",
+		Resource: "namespaces",
+	}).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+
+	return nil
+}
+
+// Close cleanly shuts down the adapter.
+func (m *Adapter) Close() error {
+	m.DynamicClient = nil
+	return nil
+}
+
+// ValidateName validates a deployment name, which must be a DNS-1123 label.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name cannot be empty: %w", ErrInvalidName)
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("name too long (max %d chars): %w", maxNameLength, ErrInvalidName)
+	}
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("name must be DNS-1123 compliant: %w", ErrInvalidName)
+	}
+	return nil
+}
@@ -0,0 +1,451 @@
+package manifests_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/manifests"
+	"github.com/piwi3910/netweave/internal/httperror"
+)
+
+var (
+	configMapGVR  = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+const (
+	configMapManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: upf-config
+data:
+  mode: standalone
+`
+	deploymentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: upf
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: upf
+        image: upf:1.0
+`
+	clusterRoleManifest = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: upf-reader
+`
+)
+
+// newTestAdapter creates an adapter backed by a fake dynamic client that
+// serves ConfigMaps, Deployments and ClusterRoles.
+func newTestAdapter(t *testing.T, config *manifests.Config) (*manifests.Adapter, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			configMapGVR:  "ConfigMapList",
+			deploymentGVR: "DeploymentList",
+			{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}: "ClusterRoleList",
+			{Version: "v1", Resource: "namespaces"}:                                       "NamespaceList",
+		})
+	// The fake client only applies to existing objects; create them instead.
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(k8stesting.PatchAction)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		object := &unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		tracker := client.Tracker()
+		gvr, namespace := patch.GetResource(), patch.GetNamespace()
+		if _, err := tracker.Get(gvr, namespace, object.GetName()); apierrors.IsNotFound(err) {
+			return true, object, tracker.Create(gvr, object, namespace)
+		}
+		return true, object, tracker.Update(gvr, object, namespace)
+	})
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		meta.RESTScopeRoot)
+
+	if config == nil {
+		config = &manifests.Config{}
+	}
+	adp, err := manifests.NewAdapter(config)
+	require.NoError(t, err)
+	adp.DynamicClient = client
+	adp.Mapper = mapper
+	return adp, client
+}
+
+// packageArchive returns a gzip-compressed tarball of files.
+func packageArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// writeFiles writes files below dir.
+func writeFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0o750); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uploadArchive(
+	t *testing.T, adp *manifests.Adapter, name, version string, files map[string]string,
+) *dmsadapter.DeploymentPackage {
+	t.Helper()
+
+	pkg, err := adp.UploadDeploymentPackage(context.Background(), &dmsadapter.DeploymentPackageUpload{
+		Name:    name,
+		Version: version,
+		Content: packageArchive(t, files),
+	})
+	require.NoError(t, err)
+	return pkg
+}
+
+func TestNewAdapter(t *testing.T) {
+	_, err := manifests.NewAdapter(nil)
+	require.Error(t, err)
+
+	adp, err := manifests.NewAdapter(&manifests.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, manifests.DefaultNamespace, adp.Config.Namespace)
+	assert.Equal(t, manifests.DefaultTimeout, adp.Config.Timeout)
+	assert.Equal(t, manifests.AdapterName, adp.Name())
+	assert.False(t, adp.SupportsRollback())
+	assert.False(t, adp.SupportsScaling())
+	assert.False(t, adp.SupportsGitOps())
+}
+
+func TestUploadDeploymentPackage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("plain manifests", func(t *testing.T) {
+		adp, _ := newTestAdapter(t, nil)
+		pkg := uploadArchive(t, adp, "upf", "1.0.0", map[string]string{
+			"manifests/config.yaml": configMapManifest,
+			"manifests/deploy.yaml": deploymentManifest,
+			"README.md":             "not a manifest",
+		})
+		assert.Equal(t, "upf-1-0-0", pkg.ID)
+		assert.Equal(t, manifests.PackageType, pkg.PackageType)
+
+		got, err := adp.GetDeploymentPackage(ctx, pkg.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "upf", got.Name)
+
+		list, err := adp.ListDeploymentPackages(ctx, nil)
+		require.NoError(t, err)
+		assert.Len(t, list, 1)
+
+		_, err = adp.UploadDeploymentPackage(ctx, &dmsadapter.DeploymentPackageUpload{
+			Name: "upf", Version: "1.0.0", Content: packageArchive(t, map[string]string{"a.yaml": configMapManifest}),
+		})
+		require.ErrorIs(t, err, manifests.ErrPackageExists)
+	})
+
+	t.Run("kustomization", func(t *testing.T) {
+		adp, _ := newTestAdapter(t, nil)
+		pkg, err := adp.UploadDeploymentPackage(ctx, &dmsadapter.DeploymentPackageUpload{
+			Name: "upf",
+			Content: packageArchive(t, map[string]string{
+				"base/kustomization.yaml":    "resources:\n- deploy.yaml\n",
+				"base/deploy.yaml":           deploymentManifest,
+				"overlay/kustomization.yaml": "resources:\n- ../base\nnamePrefix: edge-\n",
+			}),
+			Extensions: map[string]interface{}{manifests.ExtensionPath: "overlay"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "upf-latest", pkg.ID)
+		assert.Equal(t, "overlay", pkg.Extensions[manifests.ExtensionPath])
+	})
+
+	t.Run("invalid packages", func(t *testing.T) {
+		adp, _ := newTestAdapter(t, nil)
+		for name, upload := range map[string]*dmsadapter.DeploymentPackageUpload{
+			"no manifests": {Name: "a", Content: packageArchive(t, map[string]string{"README.md": "hi"})},
+			"remote base": {Name: "b", Content: packageArchive(t, map[string]string{
+				"kustomization.yaml": "resources:\n- https://github.com/example/repo//base\n",
+			})},
+			"remote patch": {Name: "c", Content: packageArchive(t, map[string]string{
+				"kustomization.yaml": "resources:\n- cm.yaml\npatches:\n- path: https://example.com/patch.yaml\n",
+				"cm.yaml":            configMapManifest,
+			})},
+			"missing path": {
+				Name:       "d",
+				Content:    packageArchive(t, map[string]string{"cm.yaml": configMapManifest}),
+				Extensions: map[string]interface{}{manifests.ExtensionPath: "missing"},
+			},
+			"escaping path": {
+				Name:       "e",
+				Content:    packageArchive(t, map[string]string{"cm.yaml": configMapManifest}),
+				Extensions: map[string]interface{}{manifests.ExtensionPath: "../etc"},
+			},
+			"not an archive": {Name: "f", Content: []byte("plain text")},
+			"plain git url": {Name: "g", Extensions: map[string]interface{}{
+				manifests.ExtensionGitURL: "git://example.com/r",
+			}},
+			"option git ref": {Name: "h", Extensions: map[string]interface{}{
+				manifests.ExtensionGitURL: "https://example.com/r.git",
+				manifests.ExtensionGitRef: "--upload-pack=evil",
+			}},
+			"no content": {Name: "i"},
+		} {
+			_, err := adp.UploadDeploymentPackage(ctx, upload)
+			require.ErrorIs(t, err, manifests.ErrInvalidPackage, name)
+		}
+	})
+}
+
+func TestDeploymentLifecycle(t *testing.T) {
+	ctx := context.Background()
+	adp, client := newTestAdapter(t, &manifests.Config{Namespace: "netweave"})
+	v1 := uploadArchive(t, adp, "upf", "1.0.0", map[string]string{
+		"config.yaml": configMapManifest,
+		"deploy.yaml": deploymentManifest,
+	})
+	v2 := uploadArchive(t, adp, "upf", "2.0.0", map[string]string{"deploy.yaml": deploymentManifest})
+
+	deployment, err := adp.CreateDeployment(ctx, &dmsadapter.DeploymentRequest{
+		Name:      "upf-edge",
+		Namespace: "edge",
+		PackageID: v1.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dmsadapter.DeploymentStatusDeployed, deployment.Status)
+	assert.Equal(t, "edge", deployment.Namespace)
+	assert.Equal(t, 1, deployment.Version)
+
+	applied, err := client.Resource(deploymentGVR).Namespace("edge").Get(ctx, "upf", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "upf-edge", applied.GetLabels()[manifests.DeploymentLabel])
+	assert.Equal(t, manifests.ManagedByValue, applied.GetLabels()[manifests.ManagedByLabel])
+
+	_, err = adp.CreateDeployment(ctx, &dmsadapter.DeploymentRequest{Name: "upf-edge", PackageID: v1.ID})
+	require.ErrorIs(t, err, manifests.ErrDeploymentExists)
+
+	t.Run("list and get", func(t *testing.T) {
+		list, err := adp.ListDeployments(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "upf-edge", list[0].ID)
+
+		list, err = adp.ListDeployments(ctx, &dmsadapter.Filter{Namespace: "other"})
+		require.NoError(t, err)
+		assert.Empty(t, list)
+
+		got, err := adp.GetDeployment(ctx, "upf-edge")
+		require.NoError(t, err)
+		assert.Equal(t, v1.ID, got.PackageID)
+
+		_, err = adp.GetDeployment(ctx, "missing")
+		require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+	})
+
+	t.Run("status follows the workload rollout", func(t *testing.T) {
+		status, err := adp.GetDeploymentStatus(ctx, "upf-edge")
+		require.NoError(t, err)
+		assert.Equal(t, dmsadapter.DeploymentStatusDeploying, status.Status)
+		assert.Equal(t, 50, status.Progress)
+		require.Len(t, status.Conditions, 2)
+		assert.Equal(t, "False", status.Conditions[1].Status)
+		assert.Contains(t, status.Message, "Deployment/edge/upf")
+
+		require.NoError(t, unstructured.SetNestedField(applied.Object, map[string]interface{}{
+			"observedGeneration": int64(0),
+			"updatedReplicas":    int64(2),
+			"availableReplicas":  int64(2),
+		}, "status"))
+		_, err = client.Resource(deploymentGVR).Namespace("edge").Update(ctx, applied, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		status, err = adp.GetDeploymentStatus(ctx, "upf-edge")
+		require.NoError(t, err)
+		assert.Equal(t, dmsadapter.DeploymentStatusDeployed, status.Status)
+		assert.Equal(t, 100, status.Progress)
+	})
+
+	t.Run("package in use", func(t *testing.T) {
+		err := adp.DeleteDeploymentPackage(ctx, v1.ID)
+		require.ErrorIs(t, err, dmsadapter.ErrPackageInUse)
+	})
+
+	t.Run("update prunes removed objects", func(t *testing.T) {
+		updated, err := adp.UpdateDeployment(ctx, "upf-edge", &dmsadapter.DeploymentUpdate{
+			Extensions: map[string]interface{}{manifests.ExtensionPackageID: v2.ID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Version)
+		assert.Equal(t, v2.ID, updated.PackageID)
+
+		_, err = client.Resource(configMapGVR).Namespace("edge").Get(ctx, "upf-config", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = client.Resource(deploymentGVR).Namespace("edge").Get(ctx, "upf", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		require.NoError(t, adp.DeleteDeploymentPackage(ctx, v1.ID))
+	})
+
+	t.Run("delete removes the objects", func(t *testing.T) {
+		require.NoError(t, adp.DeleteDeployment(ctx, "upf-edge"))
+
+		_, err := client.Resource(deploymentGVR).Namespace("edge").Get(ctx, "upf", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = adp.GetDeployment(ctx, "upf-edge")
+		require.ErrorIs(t, err, dmsadapter.ErrDeploymentNotFound)
+		require.NoError(t, adp.DeleteDeploymentPackage(ctx, v2.ID))
+	})
+}
+
+func TestCreateDeployment_Rejected(t *testing.T) {
+	ctx := context.Background()
+	adp, _ := newTestAdapter(t, nil)
+	otherNamespace := uploadArchive(t, adp, "other", "1", map[string]string{
+		"cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n  namespace: kube-system\n",
+	})
+	clusterScoped := uploadArchive(t, adp, "rbac", "1", map[string]string{"role.yaml": clusterRoleManifest})
+	unknownKind := uploadArchive(t, adp, "crd", "1", map[string]string{
+		"x.yaml": "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: x\n",
+	})
+
+	tests := []struct {
+		name string
+		req  *dmsadapter.DeploymentRequest
+		err  error
+	}{
+		{"invalid name", &dmsadapter.DeploymentRequest{Name: "Bad_Name", PackageID: "p"}, manifests.ErrInvalidName},
+		{"values", &dmsadapter.DeploymentRequest{
+			Name: "a", PackageID: clusterScoped.ID, Values: map[string]interface{}{"replicas": 3},
+		}, manifests.ErrValuesNotSupported},
+		{"missing package", &dmsadapter.DeploymentRequest{Name: "a", PackageID: "p"}, dmsadapter.ErrPackageNotFound},
+		{"other namespace", &dmsadapter.DeploymentRequest{Name: "a", PackageID: otherNamespace.ID},
+			manifests.ErrInvalidManifest},
+		{"cluster-scoped", &dmsadapter.DeploymentRequest{Name: "a", PackageID: clusterScoped.ID},
+			manifests.ErrInvalidManifest},
+		{"unknown kind", &dmsadapter.DeploymentRequest{Name: "a", PackageID: unknownKind.ID},
+			manifests.ErrInvalidManifest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := adp.CreateDeployment(ctx, tt.req)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	list, err := adp.ListDeployments(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	t.Run("cluster-scoped allowed", func(t *testing.T) {
+		adp.Config.AllowClusterScoped = true
+		deployment, err := adp.CreateDeployment(ctx, &dmsadapter.DeploymentRequest{
+			Name: "rbac", PackageID: clusterScoped.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, dmsadapter.DeploymentStatusDeployed, deployment.Status)
+	})
+}
+
+func TestGitPackage(t *testing.T) {
+	ctx := context.Background()
+	adp, client := newTestAdapter(t, nil)
+	var fetched manifests.GitSource
+	adp.FetchGit = func(_ context.Context, src manifests.GitSource, dir string) error {
+		fetched = src
+		return writeFiles(dir, map[string]string{"deploy/cm.yaml": configMapManifest})
+	}
+
+	pkg, err := adp.UploadDeploymentPackage(ctx, &dmsadapter.DeploymentPackageUpload{
+		Name: "upf",
+		Extensions: map[string]interface{}{
+			manifests.ExtensionGitURL: "https://example.com/upf.git",
+			manifests.ExtensionGitRef: "v1.0.0",
+			manifests.ExtensionPath:   "deploy",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "git", pkg.Extensions[manifests.ExtensionSource])
+
+	_, err = adp.CreateDeployment(ctx, &dmsadapter.DeploymentRequest{Name: "upf", PackageID: pkg.ID})
+	require.NoError(t, err)
+	assert.Equal(t, manifests.GitSource{URL: "https://example.com/upf.git", Ref: "v1.0.0"}, fetched)
+
+	_, err = client.Resource(configMapGVR).Namespace(manifests.DefaultNamespace).Get(
+		ctx, "upf-config", metav1.GetOptions{})
+	require.NoError(t, err)
+}
+
+func TestUnsupportedOperations(t *testing.T) {
+	ctx := context.Background()
+	adp, _ := newTestAdapter(t, nil)
+
+	require.ErrorIs(t, adp.ScaleDeployment(ctx, "upf", 3), dmsadapter.ErrOperationNotSupported)
+	require.ErrorIs(t, adp.RollbackDeployment(ctx, "upf", 1), dmsadapter.ErrOperationNotSupported)
+}
+
+func TestErrorRules(t *testing.T) {
+	adp, _ := newTestAdapter(t, nil)
+	translator := httperror.Default.With(adp.ErrorRules()...)
+
+	for err, status := range map[error]int{
+		manifests.ErrInvalidPackage:     http.StatusBadRequest,
+		manifests.ErrInvalidManifest:    http.StatusBadRequest,
+		manifests.ErrValuesNotSupported: http.StatusBadRequest,
+		manifests.ErrPackageExists:      http.StatusConflict,
+		manifests.ErrDeploymentExists:   http.StatusConflict,
+	} {
+		assert.Equal(t, status, translator.Translate(err).Status, err.Error())
+	}
+}
@@ -0,0 +1,816 @@
+package manifests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+// ExtensionPackageID selects the package of an updated deployment.
+const ExtensionPackageID = "manifests.packageId"
+
+// ObjectRef identifies an object applied for a deployment.
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// String formats the reference as Kind/namespace/name.
+func (r ObjectRef) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// preparedObject is an object ready to be applied.
+type preparedObject struct {
+	object *unstructured.Unstructured
+	gvr    schema.GroupVersionResource
+	ref    ObjectRef
+}
+
+// deploymentRecordName returns the name of the ConfigMap recording a deployment.
+func deploymentRecordName(name string) string {
+	return "manifests-deployment-" + name
+}
+
+// recordSelector selects the adapter's records of the given type.
+func recordSelector(recordType string) string {
+	return ManagedByLabel + "=" + ManagedByValue + "," + recordLabel + "=" + recordType
+}
+
+// recordData returns the data of a record.
+func recordData(cm *unstructured.Unstructured) map[string]string {
+	data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+	if data == nil {
+		data = map[string]string{}
+	}
+	return data
+}
+
+// ListDeployments retrieves all deployments.
+func (m *Adapter) ListDeployments(
+	ctx context.Context,
+	filter *adapter.Filter,
+) ([]*adapter.Deployment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	namespace := ""
+	if filter != nil {
+		namespace = filter.Namespace
+	}
+	records, err := m.listDeploymentRecords(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := make([]*adapter.Deployment, 0, len(records))
+	for i := range records {
+		deployment := transformDeployment(&records[i])
+		if filter != nil && filter.Status != "" && deployment.Status != filter.Status {
+			continue
+		}
+		deployments = append(deployments, deployment)
+	}
+
+	if filter != nil {
+		deployments = paginate(deployments, filter.Limit, filter.Offset)
+	}
+	return deployments, nil
+}
+
+// GetDeployment retrieves a specific deployment by ID.
+func (m *Adapter) GetDeployment(
+	ctx context.Context,
+	id string,
+) (*adapter.Deployment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	record, err := m.getDeploymentRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return transformDeployment(record), nil
+}
+
+// CreateDeployment renders the package and applies its objects to the
+// deployment's namespace. A deployment whose objects fail to apply is kept
+// as failed, so that deleting it removes the objects applied so far.
+func (m *Adapter) CreateDeployment(
+	ctx context.Context,
+	req *adapter.DeploymentRequest,
+) (*adapter.Deployment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("deployment request cannot be nil")
+	}
+	if err := ValidateName(req.Name); err != nil {
+		return nil, err
+	}
+	if req.PackageID == "" {
+		return nil, fmt.Errorf("packageId is required: %w", ErrInvalidPackage)
+	}
+	if len(req.Values) > 0 {
+		return nil, ErrValuesNotSupported
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Config.Timeout)
+	defer cancel()
+
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = m.Config.Namespace
+	}
+
+	if _, err := m.getDeploymentRecord(ctx, req.Name); err == nil {
+		return nil, fmt.Errorf("deployment %s: %w", req.Name, ErrDeploymentExists)
+	}
+
+	objects, err := m.renderPackage(ctx, req.PackageID)
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := m.prepare(objects, req.Name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// The record claims the name before anything is applied.
+	now := time.Now().UTC().Format(time.RFC3339)
+	record := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      deploymentRecordName(req.Name),
+			"namespace": namespace,
+			"labels": map[string]interface{}{
+				ManagedByLabel:  ManagedByValue,
+				recordLabel:     recordTypeDeployment,
+				DeploymentLabel: req.Name,
+			},
+		},
+		"data": map[string]interface{}{
+			"name":        req.Name,
+			"packageId":   req.PackageID,
+			"status":      string(adapter.DeploymentStatusDeploying),
+			"revision":    "1",
+			"description": req.Description,
+			"inventory":   "[]",
+			"createdAt":   now,
+			"updatedAt":   now,
+		},
+	}}
+	record, err = m.DynamicClient.Resource(configMapGVR).Namespace(namespace).Create(
+		ctx, record, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("deployment %s: %w", req.Name, ErrDeploymentExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	applied, applyErr := m.apply(ctx, prepared)
+	record, err = m.finishRecord(ctx, record, applied, applyErr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if applyErr != nil {
+		return nil, applyErr
+	}
+	return transformDeployment(record), nil
+}
+
+// UpdateDeployment re-applies the deployment's package, or the package given
+// by the manifests.packageId extension, and deletes the objects that are no
+// longer part of it.
+func (m *Adapter) UpdateDeployment(
+	ctx context.Context,
+	id string,
+	update *adapter.DeploymentUpdate,
+) (*adapter.Deployment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if update == nil {
+		return nil, fmt.Errorf("update cannot be nil")
+	}
+	if len(update.Values) > 0 {
+		return nil, ErrValuesNotSupported
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Config.Timeout)
+	defer cancel()
+
+	record, err := m.getDeploymentRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	data := recordData(record)
+
+	packageID := data["packageId"]
+	if p, ok := update.Extensions[ExtensionPackageID].(string); ok && p != "" {
+		packageID = p
+	}
+	objects, err := m.renderPackage(ctx, packageID)
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := m.prepare(objects, id, record.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	data["packageId"] = packageID
+	data["revision"] = strconv.Itoa(parseRevision(data["revision"]) + 1)
+	if update.Description != "" {
+		data["description"] = update.Description
+	}
+	if err := unstructured.SetNestedStringMap(record.Object, data, "data"); err != nil {
+		return nil, fmt.Errorf("failed to update deployment: %w", err)
+	}
+
+	previous := inventory(record)
+	applied, applyErr := m.apply(ctx, prepared)
+	if applyErr == nil {
+		applyErr = m.prune(ctx, id, previous, applied)
+	}
+	record, err = m.finishRecord(ctx, record, applied, applyErr, previous)
+	if err != nil {
+		return nil, err
+	}
+	if applyErr != nil {
+		return nil, applyErr
+	}
+	return transformDeployment(record), nil
+}
+
+// DeleteDeployment deletes the objects of a deployment and its record.
+func (m *Adapter) DeleteDeployment(
+	ctx context.Context,
+	id string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Config.Timeout)
+	defer cancel()
+
+	record, err := m.getDeploymentRecord(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := m.prune(ctx, id, inventory(record), nil); err != nil {
+		return err
+	}
+
+	err = m.DynamicClient.Resource(configMapGVR).Namespace(record.GetNamespace()).Delete(
+		ctx, record.GetName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete deployment: %w", err)
+	}
+	return nil
+}
+
+// GetDeploymentStatus checks the objects of a deployment in the cluster. A
+// deployment is deployed once all of its objects exist and its workloads are
+// ready.
+func (m *Adapter) GetDeploymentStatus(
+	ctx context.Context,
+	id string,
+) (*adapter.DeploymentStatusDetail, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	record, err := m.getDeploymentRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	deployment := transformDeployment(record)
+	refs := inventory(record)
+
+	detail := &adapter.DeploymentStatusDetail{
+		DeploymentID: id,
+		Status:       deployment.Status,
+		Message:      recordData(record)["message"],
+		UpdatedAt:    deployment.UpdatedAt,
+		Extensions:   deployment.Extensions,
+	}
+	applied := adapter.DeploymentCondition{
+		Type:               "Applied",
+		Status:             "True",
+		Reason:             "ApplySucceeded",
+		Message:            fmt.Sprintf("revision %d applied %d objects", deployment.Version, len(refs)),
+		LastTransitionTime: deployment.UpdatedAt,
+	}
+	if deployment.Status == adapter.DeploymentStatusFailed {
+		applied.Status, applied.Reason, applied.Message = "False", "ApplyFailed", detail.Message
+		detail.Conditions = []adapter.DeploymentCondition{applied}
+		return detail, nil
+	}
+
+	var notReady []string
+	for _, ref := range refs {
+		ready, reason, err := m.objectReady(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		if !ready {
+			notReady = append(notReady, ref.String()+" ("+reason+")")
+		}
+	}
+
+	ready := adapter.DeploymentCondition{
+		Type:               "Ready",
+		Status:             "True",
+		Reason:             "ObjectsReady",
+		Message:            fmt.Sprintf("%d/%d objects ready", len(refs)-len(notReady), len(refs)),
+		LastTransitionTime: deployment.UpdatedAt,
+	}
+	detail.Status = adapter.DeploymentStatusDeployed
+	detail.Progress = 100
+	if len(notReady) > 0 {
+		ready.Status, ready.Reason = "False", "ObjectsNotReady"
+		ready.Message += "; not ready: " + strings.Join(notReady, ", ")
+		detail.Status = adapter.DeploymentStatusDeploying
+		detail.Progress = (len(refs) - len(notReady)) * 100 / len(refs)
+	}
+	detail.Message = ready.Message
+	detail.Conditions = []adapter.DeploymentCondition{applied, ready}
+	return detail, nil
+}
+
+// GetDeploymentHistory returns the current revision; previous revisions are
+// not kept.
+func (m *Adapter) GetDeploymentHistory(
+	ctx context.Context,
+	id string,
+) (*adapter.DeploymentHistory, error) {
+	deployment, err := m.GetDeployment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &adapter.DeploymentHistory{
+		DeploymentID: id,
+		Revisions: []adapter.DeploymentRevision{
+			{
+				Revision:    deployment.Version,
+				Version:     deployment.PackageID,
+				DeployedAt:  deployment.UpdatedAt,
+				Status:      deployment.Status,
+				Description: deployment.Description,
+			},
+		},
+	}, nil
+}
+
+// GetDeploymentLogs returns the deployment and its applied objects as JSON.
+func (m *Adapter) GetDeploymentLogs(
+	ctx context.Context,
+	id string,
+	_ *adapter.LogOptions,
+) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	record, err := m.getDeploymentRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	deployment := transformDeployment(record)
+
+	info := map[string]interface{}{
+		"deploymentId": deployment.ID,
+		"packageId":    deployment.PackageID,
+		"status":       deployment.Status,
+		"revision":     deployment.Version,
+		"message":      recordData(record)["message"],
+		"objects":      inventory(record),
+		"updatedAt":    deployment.UpdatedAt,
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal deployment logs: %w", err)
+	}
+	return data, nil
+}
+
+// listDeploymentRecords lists the deployment records of a namespace, or of
+// all namespaces.
+func (m *Adapter) listDeploymentRecords(ctx context.Context, namespace string) ([]unstructured.Unstructured, error) {
+	records, err := m.DynamicClient.Resource(configMapGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: recordSelector(recordTypeDeployment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	return records.Items, nil
+}
+
+// getDeploymentRecord finds the record of a deployment in any namespace.
+func (m *Adapter) getDeploymentRecord(ctx context.Context, id string) (*unstructured.Unstructured, error) {
+	records, err := m.DynamicClient.Resource(configMapGVR).Namespace("").List(ctx, metav1.ListOptions{
+		LabelSelector: recordSelector(recordTypeDeployment) + "," + DeploymentLabel + "=" + id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	for i := range records.Items {
+		if records.Items[i].GetName() == deploymentRecordName(id) {
+			return &records.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("deployment %s: %w", id, adapter.ErrDeploymentNotFound)
+}
+
+// finishRecord stores the outcome of an apply in a deployment record. The
+// inventory keeps the previous objects when the apply failed, so none of
+// them is orphaned.
+func (m *Adapter) finishRecord(
+	ctx context.Context, record *unstructured.Unstructured, applied []ObjectRef, applyErr error, previous []ObjectRef,
+) (*unstructured.Unstructured, error) {
+	data := recordData(record)
+	refs := applied
+	if applyErr != nil {
+		refs = mergeRefs(previous, applied)
+		data["status"] = string(adapter.DeploymentStatusFailed)
+		data["message"] = applyErr.Error()
+	} else {
+		data["status"] = string(adapter.DeploymentStatusDeployed)
+		data["message"] = ""
+	}
+	encoded, err := json.Marshal(refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode inventory: %w", err)
+	}
+	data["inventory"] = string(encoded)
+	data["updatedAt"] = time.Now().UTC().Format(time.RFC3339)
+	if err := unstructured.SetNestedStringMap(record.Object, data, "data"); err != nil {
+		return nil, fmt.Errorf("failed to update deployment: %w", err)
+	}
+
+	updated, err := m.DynamicClient.Resource(configMapGVR).Namespace(record.GetNamespace()).Update(
+		ctx, record, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record deployment: %w", err)
+	}
+	return updated, nil
+}
+
+// prepare resolves the resources of objects and labels them for the
+// deployment. Namespaced objects go to the deployment's namespace; objects
+// naming another namespace, and cluster-scoped objects unless allowed, are
+// rejected.
+func (m *Adapter) prepare(
+	objects []*unstructured.Unstructured, deploymentName, namespace string,
+) ([]preparedObject, error) {
+	prepared := make([]preparedObject, 0, len(objects))
+	seen := make(map[ObjectRef]bool, len(objects))
+	for _, object := range objects {
+		gvk := object.GroupVersionKind()
+		if gvk.Kind == "" || gvk.Version == "" || object.GetName() == "" {
+			return nil, fmt.Errorf("object without apiVersion, kind or name: %w", ErrInvalidManifest)
+		}
+
+		mapping, err := m.restMapping(gvk)
+		if err != nil {
+			return nil, err
+		}
+		ref := ObjectRef{APIVersion: object.GetAPIVersion(), Kind: gvk.Kind, Name: object.GetName()}
+
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if ns := object.GetNamespace(); ns != "" && ns != namespace {
+				return nil, fmt.Errorf("%s/%s is in namespace %s, not in the deployment's namespace %s: %w",
+					gvk.Kind, object.GetName(), ns, namespace, ErrInvalidManifest)
+			}
+			object.SetNamespace(namespace)
+			ref.Namespace = namespace
+		} else {
+			if !m.Config.AllowClusterScoped {
+				return nil, fmt.Errorf("cluster-scoped %s/%s not allowed: %w",
+					gvk.Kind, object.GetName(), ErrInvalidManifest)
+			}
+			object.SetNamespace("")
+		}
+
+		if seen[ref] {
+			return nil, fmt.Errorf("%s is defined twice: %w", ref, ErrInvalidManifest)
+		}
+		seen[ref] = true
+
+		labels := object.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[ManagedByLabel] = ManagedByValue
+		labels[DeploymentLabel] = deploymentName
+		object.SetLabels(labels)
+
+		prepared = append(prepared, preparedObject{object: object, gvr: mapping.Resource, ref: ref})
+	}
+
+	// Namespaces and CRDs go first, so that the objects depending on them apply.
+	sort.SliceStable(prepared, func(i, j int) bool {
+		return applyOrder(prepared[i].ref.Kind) < applyOrder(prepared[j].ref.Kind)
+	})
+	return prepared, nil
+}
+
+// applyOrder ranks kinds that other objects depend on first.
+func applyOrder(kind string) int {
+	switch kind {
+	case "Namespace":
+		return 0
+	case "CustomResourceDefinition":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// restMapping resolves the resource of a kind, refreshing the discovery
+// cache once for kinds installed since it was filled.
+func (m *Adapter) restMapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := m.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		if resettable, ok := m.Mapper.(meta.ResettableRESTMapper); ok {
+			resettable.Reset()
+			mapping, err = m.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("kind %s is not served by the cluster: %w", gvk, ErrInvalidManifest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", gvk, err)
+	}
+	return mapping, nil
+}
+
+// apply applies objects with server-side apply and returns the objects
+// applied before any error.
+func (m *Adapter) apply(ctx context.Context, objects []preparedObject) ([]ObjectRef, error) {
+	applied := make([]ObjectRef, 0, len(objects))
+	for _, p := range objects {
+		_, err := m.resource(p.gvr, p.ref.Namespace).Apply(ctx, p.ref.Name, p.object, metav1.ApplyOptions{
+			FieldManager: FieldManager,
+			Force:        m.Config.Force,
+		})
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply %s: %w", p.ref, err)
+		}
+		applied = append(applied, p.ref)
+	}
+	return applied, nil
+}
+
+// prune deletes the objects of previous that are not in current, in reverse
+// apply order. Objects another deployment or tool took over are left alone.
+func (m *Adapter) prune(ctx context.Context, deploymentName string, previous, current []ObjectRef) error {
+	keep := make(map[ObjectRef]bool, len(current))
+	for _, ref := range current {
+		keep[ref] = true
+	}
+
+	for i := len(previous) - 1; i >= 0; i-- {
+		ref := previous[i]
+		if keep[ref] {
+			continue
+		}
+		gvr, err := m.refResource(ref)
+		if err != nil {
+			// The kind is gone from the cluster, and so is the object.
+			continue
+		}
+		client := m.resource(gvr, ref.Namespace)
+		object, err := client.Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", ref, err)
+		}
+		if object.GetLabels()[DeploymentLabel] != deploymentName {
+			continue
+		}
+		err = client.Delete(ctx, ref.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s: %w", ref, err)
+		}
+	}
+	return nil
+}
+
+// objectReady reports whether an applied object exists and, for workloads,
+// whether its rollout completed.
+func (m *Adapter) objectReady(ctx context.Context, ref ObjectRef) (bool, string, error) {
+	gvr, err := m.refResource(ref)
+	if err != nil {
+		return false, "kind not served", nil //nolint:nilerr // reported as not ready
+	}
+	object, err := m.resource(gvr, ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, "missing", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get %s: %w", ref, err)
+	}
+	return workloadReady(object)
+}
+
+// workloadReady checks the rollout of Deployments, StatefulSets and
+// DaemonSets; other objects are ready once they exist.
+func workloadReady(object *unstructured.Unstructured) (bool, string, error) {
+	if object.GroupVersionKind().Group != "apps" {
+		return true, "", nil
+	}
+
+	generation := object.GetGeneration()
+	observed, _, _ := unstructured.NestedInt64(object.Object, "status", "observedGeneration")
+	if observed < generation {
+		return false, "rollout pending", nil
+	}
+
+	var want, have int64
+	switch object.GetKind() {
+	case "Deployment":
+		want = specReplicas(object)
+		have, _, _ = unstructured.NestedInt64(object.Object, "status", "availableReplicas")
+		if updated, _, _ := unstructured.NestedInt64(object.Object, "status", "updatedReplicas"); updated < want {
+			return false, fmt.Sprintf("%d/%d replicas updated", updated, want), nil
+		}
+	case "StatefulSet":
+		want = specReplicas(object)
+		have, _, _ = unstructured.NestedInt64(object.Object, "status", "readyReplicas")
+	case "DaemonSet":
+		want, _, _ = unstructured.NestedInt64(object.Object, "status", "desiredNumberScheduled")
+		have, _, _ = unstructured.NestedInt64(object.Object, "status", "numberReady")
+	default:
+		return true, "", nil
+	}
+	if have < want {
+		return false, fmt.Sprintf("%d/%d replicas ready", have, want), nil
+	}
+	return true, "", nil
+}
+
+// specReplicas returns the desired replicas of a workload (default 1).
+func specReplicas(object *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(object.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// refResource resolves the resource of an applied object.
+func (m *Adapter) refResource(ref ObjectRef) (schema.GroupVersionResource, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid apiVersion %q: %w", ref.APIVersion, err)
+	}
+	mapping, err := m.restMapping(gv.WithKind(ref.Kind))
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return mapping.Resource, nil
+}
+
+// resource returns the client of a resource, namespaced if namespace is set.
+func (m *Adapter) resource(gvr schema.GroupVersionResource, namespace string) dynamic.ResourceInterface {
+	if namespace == "" {
+		return m.DynamicClient.Resource(gvr)
+	}
+	return m.DynamicClient.Resource(gvr).Namespace(namespace)
+}
+
+// inventory returns the objects recorded for a deployment.
+func inventory(record *unstructured.Unstructured) []ObjectRef {
+	var refs []ObjectRef
+	_ = json.Unmarshal([]byte(recordData(record)["inventory"]), &refs)
+	return refs
+}
+
+// mergeRefs returns the union of two inventories, a first.
+func mergeRefs(a, b []ObjectRef) []ObjectRef {
+	merged := append([]ObjectRef{}, a...)
+	seen := make(map[ObjectRef]bool, len(a))
+	for _, ref := range a {
+		seen[ref] = true
+	}
+	for _, ref := range b {
+		if !seen[ref] {
+			merged = append(merged, ref)
+		}
+	}
+	return merged
+}
+
+// transformDeployment converts a deployment record to a deployment.
+func transformDeployment(record *unstructured.Unstructured) *adapter.Deployment {
+	data := recordData(record)
+
+	status := adapter.DeploymentStatus(data["status"])
+	if status == "" {
+		status = adapter.DeploymentStatusDeployed
+	}
+	createdAt := record.GetCreationTimestamp().Time
+	if t, err := time.Parse(time.RFC3339, data["createdAt"]); err == nil {
+		createdAt = t
+	}
+	updatedAt := createdAt
+	if t, err := time.Parse(time.RFC3339, data["updatedAt"]); err == nil {
+		updatedAt = t
+	}
+
+	return &adapter.Deployment{
+		ID:          data["name"],
+		Name:        data["name"],
+		PackageID:   data["packageId"],
+		Namespace:   record.GetNamespace(),
+		Status:      status,
+		Version:     parseRevision(data["revision"]),
+		Description: data["description"],
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		Extensions: map[string]interface{}{
+			"manifests.objects": len(inventory(record)),
+		},
+	}
+}
+
+// parseRevision parses a stored revision (default 1).
+func parseRevision(raw string) int {
+	if revision, err := strconv.Atoi(raw); err == nil && revision > 0 {
+		return revision
+	}
+	return 1
+}
+
+// paginate applies limit and offset to a list.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return items[offset:end]
+}
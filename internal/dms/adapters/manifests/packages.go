@@ -0,0 +1,501 @@
+package manifests
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+)
+
+const (
+	// MaxPackageSize bounds uploaded package archives, which are kept in a
+	// ConfigMap (1 MiB including the base64 encoding).
+	MaxPackageSize = 512 * 1024
+
+	// maxExtractedSize bounds the manifests extracted from a package.
+	maxExtractedSize = 16 * 1024 * 1024
+
+	// Package sources.
+	sourceArchive = "archive"
+	sourceGit     = "git"
+
+	// packageRoot is where a package is loaded in its in-memory file system.
+	packageRoot = "/package"
+
+	// packageContentKey is the binaryData key of an uploaded archive.
+	packageContentKey = "package.tar.gz"
+)
+
+// Extensions of package uploads and packages.
+const (
+	// ExtensionGitURL is the HTTPS URL of the Git repository of a package.
+	ExtensionGitURL = "manifests.git.url"
+
+	// ExtensionGitRef is the branch or tag to check out (default branch if empty).
+	ExtensionGitRef = "manifests.git.ref"
+
+	// ExtensionPath is the directory of the manifests in the archive or repository.
+	ExtensionPath = "manifests.path"
+
+	// ExtensionSource is "archive" or "git".
+	ExtensionSource = "manifests.source"
+)
+
+var (
+	configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	packageIDPattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// GitSource locates the manifests of a package in a Git repository.
+type GitSource struct {
+	URL string
+	Ref string
+}
+
+// GitFetcher checks out src into the empty directory dir.
+type GitFetcher func(ctx context.Context, src GitSource, dir string) error
+
+// CloneGit is the default GitFetcher. It makes a shallow clone of src.Ref with
+// the git command line client, which must be installed; Ref must be a branch
+// or tag, not a commit.
+func CloneGit(ctx context.Context, src GitSource, dir string) error {
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if src.Ref != "" {
+		args = append(args, "--branch", src.Ref)
+	}
+	args = append(args, "--", src.URL, dir)
+
+	// The URL is restricted to HTTPS and the ref may not start with a dash.
+	cmd := exec.CommandContext(ctx, "git", args...) //nolint:gosec // arguments are validated on upload
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone of %s failed: %w: %s", src.URL, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// GeneratePackageID derives the ID of a package from its name and version.
+func GeneratePackageID(name, version string) string {
+	id := packageIDPattern.ReplaceAllString(strings.ToLower(name+"-"+version), "-")
+	return strings.Trim(id, "-")
+}
+
+// packageRecordName returns the name of the ConfigMap recording a package.
+func packageRecordName(id string) string {
+	return "manifests-package-" + id
+}
+
+// ListDeploymentPackages retrieves all uploaded packages.
+func (m *Adapter) ListDeploymentPackages(
+	ctx context.Context,
+	filter *adapter.Filter,
+) ([]*adapter.DeploymentPackage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	cms, err := m.DynamicClient.Resource(configMapGVR).Namespace(m.Config.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: recordSelector(recordTypePackage),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+
+	packages := make([]*adapter.DeploymentPackage, 0, len(cms.Items))
+	for i := range cms.Items {
+		packages = append(packages, transformPackage(&cms.Items[i]))
+	}
+
+	if filter != nil {
+		packages = paginate(packages, filter.Limit, filter.Offset)
+	}
+	return packages, nil
+}
+
+// GetDeploymentPackage retrieves a specific package by ID.
+func (m *Adapter) GetDeploymentPackage(
+	ctx context.Context,
+	id string,
+) (*adapter.DeploymentPackage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	cm, err := m.getPackageRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return transformPackage(cm), nil
+}
+
+// UploadDeploymentPackage stores a package: either a tar archive (optionally
+// gzip-compressed) of manifests in Content, or a Git repository given by the
+// manifests.git.url extension. Archives are checked to render on upload; Git
+// packages are fetched whenever they are deployed.
+func (m *Adapter) UploadDeploymentPackage(
+	ctx context.Context,
+	pkg *adapter.DeploymentPackageUpload,
+) (*adapter.DeploymentPackage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if pkg == nil {
+		return nil, fmt.Errorf("package cannot be nil")
+	}
+	if pkg.Name == "" {
+		return nil, fmt.Errorf("package name is required: %w", ErrInvalidPackage)
+	}
+
+	if err := m.initialize(); err != nil {
+		return nil, err
+	}
+
+	version := pkg.Version
+	if version == "" {
+		version = "latest"
+	}
+	id := GeneratePackageID(pkg.Name, version)
+	data := map[string]interface{}{
+		"name":        pkg.Name,
+		"version":     version,
+		"description": pkg.Description,
+		"uploadedAt":  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	manifestPath, _ := pkg.Extensions[ExtensionPath].(string)
+	if err := ValidatePath(manifestPath); err != nil {
+		return nil, err
+	}
+	data["path"] = manifestPath
+
+	cm := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      packageRecordName(id),
+			"namespace": m.Config.Namespace,
+			"labels": map[string]interface{}{
+				ManagedByLabel: ManagedByValue,
+				recordLabel:    recordTypePackage,
+			},
+		},
+	}}
+
+	if len(pkg.Content) > 0 {
+		if len(pkg.Content) > MaxPackageSize {
+			return nil, fmt.Errorf("package archive exceeds %d bytes: %w", MaxPackageSize, ErrInvalidPackage)
+		}
+		files, err := extractArchive(pkg.Content)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := render(files, path.Join(packageRoot, manifestPath)); err != nil {
+			return nil, err
+		}
+		data["source"] = sourceArchive
+		data["size"] = strconv.Itoa(len(pkg.Content))
+		cm.Object["binaryData"] = map[string]interface{}{
+			packageContentKey: base64.StdEncoding.EncodeToString(pkg.Content),
+		}
+	} else {
+		src, err := gitSource(pkg.Extensions)
+		if err != nil {
+			return nil, err
+		}
+		data["source"] = sourceGit
+		data["gitURL"] = src.URL
+		data["gitRef"] = src.Ref
+	}
+	cm.Object["data"] = data
+
+	created, err := m.DynamicClient.Resource(configMapGVR).Namespace(m.Config.Namespace).Create(
+		ctx, cm, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("package %s: %w", id, ErrPackageExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store package: %w", err)
+	}
+	return transformPackage(created), nil
+}
+
+// DeleteDeploymentPackage deletes a package that no deployment uses.
+func (m *Adapter) DeleteDeploymentPackage(
+	ctx context.Context,
+	id string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context cancelled: %w", err)
+	}
+
+	if err := m.initialize(); err != nil {
+		return err
+	}
+
+	if _, err := m.getPackageRecord(ctx, id); err != nil {
+		return err
+	}
+
+	records, err := m.listDeploymentRecords(ctx, "")
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		if recordData(&records[i])["packageId"] == id {
+			return fmt.Errorf("package %s is used by deployment %s: %w",
+				id, recordData(&records[i])["name"], adapter.ErrPackageInUse)
+		}
+	}
+
+	err = m.DynamicClient.Resource(configMapGVR).Namespace(m.Config.Namespace).Delete(
+		ctx, packageRecordName(id), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("package %s: %w", id, adapter.ErrPackageNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete package: %w", err)
+	}
+	return nil
+}
+
+// getPackageRecord returns the ConfigMap recording a package.
+func (m *Adapter) getPackageRecord(ctx context.Context, id string) (*unstructured.Unstructured, error) {
+	cm, err := m.DynamicClient.Resource(configMapGVR).Namespace(m.Config.Namespace).Get(
+		ctx, packageRecordName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("package %s: %w", id, adapter.ErrPackageNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get package: %w", err)
+	}
+	return cm, nil
+}
+
+// renderPackage loads a package and renders its manifests.
+func (m *Adapter) renderPackage(ctx context.Context, id string) ([]*unstructured.Unstructured, error) {
+	cm, err := m.getPackageRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	data := recordData(cm)
+
+	var files filesys.FileSystem
+	switch data["source"] {
+	case sourceArchive:
+		encoded, _, _ := unstructured.NestedString(cm.Object, "binaryData", packageContentKey)
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("package %s: corrupt archive: %w", id, ErrInvalidPackage)
+		}
+		if files, err = extractArchive(content); err != nil {
+			return nil, err
+		}
+	case sourceGit:
+		if files, err = m.fetchGit(ctx, GitSource{URL: data["gitURL"], Ref: data["gitRef"]}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("package %s: unknown source %q: %w", id, data["source"], ErrInvalidPackage)
+	}
+
+	return render(files, path.Join(packageRoot, data["path"]))
+}
+
+// fetchGit checks out a Git source and loads it into memory.
+func (m *Adapter) fetchGit(ctx context.Context, src GitSource) (filesys.FileSystem, error) {
+	dir, err := os.MkdirTemp("", "netweave-manifests-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	checkout := filepath.Join(dir, "checkout")
+	if err := m.FetchGit(ctx, src, checkout); err != nil {
+		return nil, err
+	}
+
+	files := filesys.MakeFsInMemory()
+	var total int64
+	err = filepath.WalkDir(checkout, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Symlinks and other special files could point outside the checkout.
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if total += info.Size(); total > maxExtractedSize {
+			return fmt.Errorf("repository exceeds %d bytes: %w", maxExtractedSize, ErrInvalidPackage)
+		}
+		content, err := os.ReadFile(name) //nolint:gosec // walking the checkout
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(checkout, name)
+		if err != nil {
+			return err
+		}
+		return files.WriteFile(path.Join(packageRoot, filepath.ToSlash(rel)), content)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", src.URL, err)
+	}
+	return files, nil
+}
+
+// extractArchive loads the regular files of a tar archive, optionally
+// gzip-compressed, into memory.
+func extractArchive(content []byte) (filesys.FileSystem, error) {
+	var reader io.Reader = bytes.NewReader(content)
+	if len(content) > 2 && content[0] == 0x1f && content[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		defer func() { _ = gz.Close() }()
+		reader = gz
+	}
+
+	files := filesys.MakeFsInMemory()
+	archive := tar.NewReader(io.LimitReader(reader, maxExtractedSize))
+	count := 0
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		// Links and other special entries are skipped.
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		body, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+		}
+		// Cleaning against the root keeps "../" entries inside the package.
+		name := path.Join(packageRoot, path.Clean("/"+header.Name))
+		if err := files.WriteFile(name, body); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("archive contains no files: %w", ErrInvalidPackage)
+	}
+	return files, nil
+}
+
+// gitSource reads and validates the Git source of a package upload.
+func gitSource(extensions map[string]interface{}) (GitSource, error) {
+	rawURL, _ := extensions[ExtensionGitURL].(string)
+	ref, _ := extensions[ExtensionGitRef].(string)
+	if rawURL == "" {
+		return GitSource{}, fmt.Errorf("either an archive or the %s extension is required: %w",
+			ExtensionGitURL, ErrInvalidPackage)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return GitSource{}, fmt.Errorf("%s must be an https URL: %w", ExtensionGitURL, ErrInvalidPackage)
+	}
+	if strings.HasPrefix(ref, "-") || strings.ContainsAny(ref, " \t\n") {
+		return GitSource{}, fmt.Errorf("invalid %s %q: %w", ExtensionGitRef, ref, ErrInvalidPackage)
+	}
+	return GitSource{URL: rawURL, Ref: ref}, nil
+}
+
+// transformPackage converts a package record to a package.
+func transformPackage(cm *unstructured.Unstructured) *adapter.DeploymentPackage {
+	data := recordData(cm)
+	uploadedAt := cm.GetCreationTimestamp().Time
+	if t, err := time.Parse(time.RFC3339, data["uploadedAt"]); err == nil {
+		uploadedAt = t
+	}
+
+	extensions := map[string]interface{}{
+		ExtensionSource: data["source"],
+	}
+	if data["path"] != "" {
+		extensions[ExtensionPath] = data["path"]
+	}
+	if data["source"] == sourceGit {
+		extensions[ExtensionGitURL] = data["gitURL"]
+		if data["gitRef"] != "" {
+			extensions[ExtensionGitRef] = data["gitRef"]
+		}
+	}
+	if size, err := strconv.Atoi(data["size"]); err == nil {
+		extensions["manifests.size"] = size
+	}
+
+	return &adapter.DeploymentPackage{
+		ID:          strings.TrimPrefix(cm.GetName(), packageRecordName("")),
+		Name:        data["name"],
+		Version:     data["version"],
+		PackageType: PackageType,
+		Description: data["description"],
+		UploadedAt:  uploadedAt,
+		Extensions:  extensions,
+	}
+}
+
+// ValidatePath validates the directory of the manifests within a package.
+func ValidatePath(manifestPath string) error {
+	if manifestPath == "" {
+		return nil
+	}
+	if strings.HasPrefix(manifestPath, "/") {
+		return fmt.Errorf("absolute paths not allowed: %w", ErrInvalidPackage)
+	}
+	for _, part := range strings.Split(manifestPath, "/") {
+		if part == ".." {
+			return fmt.Errorf("path cannot contain '..': %w", ErrInvalidPackage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package manifests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// render returns the objects of the manifests in dir: the output of its
+// kustomization if it has one, otherwise the objects of every YAML or JSON
+// file below dir, in file name order.
+func render(files filesys.FileSystem, dir string) ([]*unstructured.Unstructured, error) {
+	if !files.IsDir(dir) {
+		return nil, fmt.Errorf("directory %s not found in package: %w",
+			strings.TrimPrefix(dir, packageRoot+"/"), ErrInvalidPackage)
+	}
+
+	var (
+		objects []*unstructured.Unstructured
+		err     error
+	)
+	if kustomizationFile(files, dir) != "" {
+		objects, err = build(files, dir)
+	} else {
+		objects, err = readManifests(files, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("package contains no manifests: %w", ErrInvalidPackage)
+	}
+	return objects, nil
+}
+
+// kustomizationFile returns the kustomization file of dir, or "" if it has none.
+func kustomizationFile(files filesys.FileSystem, dir string) string {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if file := path.Join(dir, name); files.Exists(file) {
+			return file
+		}
+	}
+	return ""
+}
+
+// build runs the kustomization in dir. Loading is restricted to the package,
+// and plugins, Helm charts and remote bases are not available.
+func build(files filesys.FileSystem, dir string) ([]*unstructured.Unstructured, error) {
+	if err := checkLocalReferences(files); err != nil {
+		return nil, err
+	}
+
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build failed: %w: %w", ErrInvalidPackage, err)
+	}
+
+	output, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("kustomize output: %w: %w", ErrInvalidManifest, err)
+	}
+	return decodeManifests(output)
+}
+
+// checkLocalReferences rejects kustomizations of the package that reference
+// anything outside of it. Kustomize would otherwise fetch remote bases and
+// files from the gateway.
+func checkLocalReferences(files filesys.FileSystem) error {
+	return files.Walk(packageRoot, func(file string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() || kustomizationFile(files, path.Dir(file)) != file {
+			return err
+		}
+		content, err := files.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var kustomization types.Kustomization
+		if err := yaml.Unmarshal(content, &kustomization); err != nil {
+			return fmt.Errorf("%s: %w: %w", relativeName(file), ErrInvalidPackage, err)
+		}
+
+		dir := path.Dir(file)
+		// Bases and plugin configurations are directories or files that
+		// kustomize would clone or download when they don't exist locally.
+		for _, group := range [][]string{
+			kustomization.Resources, kustomization.Components, kustomization.Bases,
+			kustomization.Generators, kustomization.Transformers, kustomization.Validators,
+		} {
+			for _, ref := range group {
+				if !files.Exists(path.Join(dir, ref)) {
+					return fmt.Errorf("%s: %q is not part of the package: %w",
+						relativeName(file), ref, ErrInvalidPackage)
+				}
+			}
+		}
+		for _, ref := range fileReferences(&kustomization) {
+			if isRemote(ref) {
+				return fmt.Errorf("%s: remote file %q not allowed: %w", relativeName(file), ref, ErrInvalidPackage)
+			}
+		}
+		return nil
+	})
+}
+
+// fileReferences returns the files a kustomization loads.
+func fileReferences(k *types.Kustomization) []string {
+	refs := append([]string{}, k.Crds...)
+	refs = append(refs, k.Configurations...)
+	for _, patch := range k.PatchesStrategicMerge {
+		refs = append(refs, string(patch))
+	}
+	for _, patch := range append(append([]types.Patch{}, k.Patches...), k.PatchesJson6902...) {
+		refs = append(refs, patch.Path)
+	}
+	for _, replacement := range k.Replacements {
+		refs = append(refs, replacement.Path)
+	}
+	generators := make([]types.GeneratorArgs, 0, len(k.ConfigMapGenerator)+len(k.SecretGenerator))
+	for _, generator := range k.ConfigMapGenerator {
+		generators = append(generators, generator.GeneratorArgs)
+	}
+	for _, generator := range k.SecretGenerator {
+		generators = append(generators, generator.GeneratorArgs)
+	}
+	for _, generator := range generators {
+		for _, source := range generator.FileSources {
+			// File sources take the form [{key}=]{path}.
+			refs = append(refs, source[strings.Index(source, "=")+1:])
+		}
+		refs = append(refs, generator.EnvSources...)
+		refs = append(refs, generator.EnvSource)
+	}
+	return append(refs, k.OpenAPI["path"])
+}
+
+// isRemote reports whether kustomize loads ref over HTTP.
+func isRemote(ref string) bool {
+	u, err := url.Parse(ref)
+	return err == nil && u.Scheme != ""
+}
+
+// readManifests decodes the YAML and JSON files below dir.
+func readManifests(files filesys.FileSystem, dir string) ([]*unstructured.Unstructured, error) {
+	var names []string
+	err := files.Walk(dir, func(file string, info fs.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		switch strings.ToLower(path.Ext(file)) {
+		case ".yaml", ".yml", ".json":
+			names = append(names, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package: %w", err)
+	}
+	sort.Strings(names)
+
+	var objects []*unstructured.Unstructured
+	for _, name := range names {
+		content, err := files.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", relativeName(name), err)
+		}
+		decoded, err := decodeManifests(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", relativeName(name), err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// decodeManifests decodes the documents of a YAML or JSON manifest, expanding
+// List objects.
+func decodeManifests(content []byte) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), len(content)+1)
+	var objects []*unstructured.Unstructured
+	for {
+		// Objects are decoded from JSON so that their numbers are int64, as
+		// the unstructured helpers expect.
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+		}
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) == 0 || string(trimmed) == "null" {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		if err := u.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+		}
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, u)
+	}
+}
+
+// relativeName returns the name of a package file relative to the package.
+func relativeName(file string) string {
+	return strings.TrimPrefix(file, packageRoot+"/")
+}
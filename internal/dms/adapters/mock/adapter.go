@@ -212,7 +212,8 @@ func (a *Adapter) DeleteDeploymentPackage(_ context.Context, id string) error {
 	// Check if package is in use
 	for _, deployment := range a.deployments {
 		if deployment.PackageID == id {
-			return fmt.Errorf("cannot delete package in use by deployment: %s", deployment.ID)
+			return fmt.Errorf("%w: cannot delete package in use by deployment: %s",
+				adapter.ErrPackageInUse, deployment.ID)
 		}
 	}
 
@@ -271,6 +272,7 @@ func (a *Adapter) CreateDeployment(_ context.Context, req *adapter.DeploymentReq
 		Description: req.Description,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Values:      req.Values,
 		Extensions:  req.Extensions,
 	}
 
@@ -296,6 +298,9 @@ func (a *Adapter) UpdateDeployment(_ context.Context, id string, update *adapter
 	dep.Version++
 	dep.UpdatedAt = time.Now()
 	dep.Description = update.Description
+	if update.Values != nil {
+		dep.Values = update.Values
+	}
 	if update.Extensions != nil {
 		dep.Extensions = update.Extensions
 	}
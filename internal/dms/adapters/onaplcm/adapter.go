@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/httperror"
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 const (
@@ -111,7 +113,8 @@ func NewAdapter(config *Config) (*Adapter, error) {
 func (o *Adapter) Initialize() error {
 	o.initOnce.Do(func() {
 		o.httpClient = &http.Client{
-			Timeout: o.Config.Timeout,
+			Timeout:   o.Config.Timeout,
+			Transport: resolver.NewTransport(),
 		}
 	})
 
@@ -138,6 +141,18 @@ func (o *Adapter) Capabilities() []adapter.Capability {
 	}
 }
 
+// ErrorRules returns the HTTP translations of the adapter's sentinel errors.
+func (o *Adapter) ErrorRules() []httperror.Rule {
+	return []httperror.Rule{
+		{Err: ErrDeploymentNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrPackageNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: ErrInvalidName, Status: http.StatusBadRequest, Code: httperror.CodeBadRequest},
+		{Err: ErrOperationNotSupported, Status: http.StatusNotImplemented, Code: httperror.CodeNotImplemented},
+		{Err: ErrConnectionFailed, Status: http.StatusBadGateway, Code: httperror.CodeBadGateway},
+		{Err: ErrAuthenticationFailed, Status: http.StatusBadGateway, Code: httperror.CodeBadGateway},
+	}
+}
+
 // ListDeploymentPackages retrieves all available VNF/CNF packages.
 func (o *Adapter) ListDeploymentPackages(
 	ctx context.Context,
@@ -0,0 +1,297 @@
+// Package breaker provides a DMS adapter decorator passing the backend calls
+// of an O2-DMS adapter through a circuit breaker, so that requests fail fast
+// with circuitbreaker.ErrOpen while the backend (e.g. the Kubernetes API
+// server or a Helm repository) is down instead of each waiting for its
+// timeout.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/httperror"
+)
+
+// Adapter wraps an adapter.DMSAdapter and calls its backend operations
+// through a circuit breaker. Name, Version, the capability checks, Health,
+// ProbeConfig and Close are passed through, so health checks always reach
+// the backend and report its actual state.
+//
+// Adapter implements every optional DMS interface. Operations the wrapped
+// adapter doesn't implement return adapter.ErrOperationNotSupported; the
+// handlers only call them on adapters reporting the matching capability.
+//
+// Only errors the handlers would report as a server error count as
+// failures; missing deployments, invalid values and unsupported operations
+// don't open the breaker.
+type Adapter struct {
+	adapter.DMSAdapter
+
+	breaker *circuitbreaker.Breaker
+	set     *circuitbreaker.Set
+}
+
+// New wraps inner with the breaker named "dms-adapter/<name>" of set, where
+// name is the name inner is registered under.
+func New(name string, inner adapter.DMSAdapter, set *circuitbreaker.Set) *Adapter {
+	translator := httperror.Default.For(inner)
+	return &Adapter{
+		DMSAdapter: inner,
+		set:        set,
+		breaker: set.Breaker("dms-adapter/"+name, func(err error) bool {
+			var invalid *adapter.ValuesValidationError
+			if errors.As(err, &invalid) {
+				return false
+			}
+			status := translator.Translate(err).Status
+			return status >= http.StatusInternalServerError && status != http.StatusNotImplemented
+		}),
+	}
+}
+
+// Close closes the wrapped adapter and drops its breaker.
+func (a *Adapter) Close() error {
+	a.set.Remove(a.breaker.Name())
+	return a.DMSAdapter.Close()
+}
+
+// ErrorRules returns the error translations of the wrapped adapter.
+func (a *Adapter) ErrorRules() []httperror.Rule {
+	if provider, ok := a.DMSAdapter.(httperror.RuleProvider); ok {
+		return provider.ErrorRules()
+	}
+	return nil
+}
+
+// ProbeConfig probes the configuration of the wrapped adapter, or checks its
+// health if it can't probe its configuration.
+func (a *Adapter) ProbeConfig(ctx context.Context) error {
+	if prober, ok := a.DMSAdapter.(adapter.ConfigProber); ok {
+		return prober.ProbeConfig(ctx)
+	}
+	return a.DMSAdapter.Health(ctx)
+}
+
+// ListDeploymentPackages lists deployment packages through the breaker.
+func (a *Adapter) ListDeploymentPackages(
+	ctx context.Context,
+	filter *adapter.Filter,
+) ([]*adapter.DeploymentPackage, error) {
+	return circuitbreaker.Call(a.breaker, func() ([]*adapter.DeploymentPackage, error) {
+		return a.DMSAdapter.ListDeploymentPackages(ctx, filter)
+	})
+}
+
+// GetDeploymentPackage retrieves a deployment package through the breaker.
+func (a *Adapter) GetDeploymentPackage(ctx context.Context, id string) (*adapter.DeploymentPackage, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentPackage, error) {
+		return a.DMSAdapter.GetDeploymentPackage(ctx, id)
+	})
+}
+
+// UploadDeploymentPackage uploads a deployment package through the breaker.
+func (a *Adapter) UploadDeploymentPackage(
+	ctx context.Context,
+	pkg *adapter.DeploymentPackageUpload,
+) (*adapter.DeploymentPackage, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentPackage, error) {
+		return a.DMSAdapter.UploadDeploymentPackage(ctx, pkg)
+	})
+}
+
+// DeleteDeploymentPackage deletes a deployment package through the breaker.
+func (a *Adapter) DeleteDeploymentPackage(ctx context.Context, id string) error {
+	return a.breaker.Do(func() error {
+		return a.DMSAdapter.DeleteDeploymentPackage(ctx, id)
+	})
+}
+
+// ListDeployments lists deployments through the breaker.
+func (a *Adapter) ListDeployments(ctx context.Context, filter *adapter.Filter) ([]*adapter.Deployment, error) {
+	return circuitbreaker.Call(a.breaker, func() ([]*adapter.Deployment, error) {
+		return a.DMSAdapter.ListDeployments(ctx, filter)
+	})
+}
+
+// GetDeployment retrieves a deployment through the breaker.
+func (a *Adapter) GetDeployment(ctx context.Context, id string) (*adapter.Deployment, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Deployment, error) {
+		return a.DMSAdapter.GetDeployment(ctx, id)
+	})
+}
+
+// CreateDeployment creates a deployment through the breaker.
+func (a *Adapter) CreateDeployment(ctx context.Context, req *adapter.DeploymentRequest) (*adapter.Deployment, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Deployment, error) {
+		return a.DMSAdapter.CreateDeployment(ctx, req)
+	})
+}
+
+// UpdateDeployment updates a deployment through the breaker.
+func (a *Adapter) UpdateDeployment(
+	ctx context.Context,
+	id string,
+	update *adapter.DeploymentUpdate,
+) (*adapter.Deployment, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.Deployment, error) {
+		return a.DMSAdapter.UpdateDeployment(ctx, id, update)
+	})
+}
+
+// DeleteDeployment deletes a deployment through the breaker.
+func (a *Adapter) DeleteDeployment(ctx context.Context, id string) error {
+	return a.breaker.Do(func() error {
+		return a.DMSAdapter.DeleteDeployment(ctx, id)
+	})
+}
+
+// ScaleDeployment scales a deployment through the breaker.
+func (a *Adapter) ScaleDeployment(ctx context.Context, id string, replicas int) error {
+	return a.breaker.Do(func() error {
+		return a.DMSAdapter.ScaleDeployment(ctx, id, replicas)
+	})
+}
+
+// RollbackDeployment rolls back a deployment through the breaker.
+func (a *Adapter) RollbackDeployment(ctx context.Context, id string, revision int) error {
+	return a.breaker.Do(func() error {
+		return a.DMSAdapter.RollbackDeployment(ctx, id, revision)
+	})
+}
+
+// GetDeploymentStatus retrieves the status of a deployment through the breaker.
+func (a *Adapter) GetDeploymentStatus(ctx context.Context, id string) (*adapter.DeploymentStatusDetail, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentStatusDetail, error) {
+		return a.DMSAdapter.GetDeploymentStatus(ctx, id)
+	})
+}
+
+// GetDeploymentHistory retrieves the history of a deployment through the breaker.
+func (a *Adapter) GetDeploymentHistory(ctx context.Context, id string) (*adapter.DeploymentHistory, error) {
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentHistory, error) {
+		return a.DMSAdapter.GetDeploymentHistory(ctx, id)
+	})
+}
+
+// GetDeploymentLogs retrieves the logs of a deployment through the breaker.
+func (a *Adapter) GetDeploymentLogs(ctx context.Context, id string, opts *adapter.LogOptions) ([]byte, error) {
+	return circuitbreaker.Call(a.breaker, func() ([]byte, error) {
+		return a.DMSAdapter.GetDeploymentLogs(ctx, id, opts)
+	})
+}
+
+// CancelOperation cancels the operation of a deployment through the breaker.
+func (a *Adapter) CancelOperation(ctx context.Context, id string) error {
+	canceller, ok := a.DMSAdapter.(adapter.OperationCanceller)
+	if !ok {
+		return adapter.ErrOperationNotSupported
+	}
+	return a.breaker.Do(func() error {
+		return canceller.CancelOperation(ctx, id)
+	})
+}
+
+// GetDeploymentNotes retrieves the notes of a deployment through the breaker.
+func (a *Adapter) GetDeploymentNotes(ctx context.Context, id string) (*adapter.DeploymentNotes, error) {
+	provider, ok := a.DMSAdapter.(adapter.NotesProvider)
+	if !ok {
+		return nil, adapter.ErrOperationNotSupported
+	}
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentNotes, error) {
+		return provider.GetDeploymentNotes(ctx, id)
+	})
+}
+
+// PreviewDeployment previews an update of a deployment through the breaker.
+func (a *Adapter) PreviewDeployment(
+	ctx context.Context,
+	id string,
+	update *adapter.DeploymentUpdate,
+) (*adapter.DeploymentPreview, error) {
+	previewer, ok := a.DMSAdapter.(adapter.DeploymentPreviewer)
+	if !ok {
+		return nil, adapter.ErrOperationNotSupported
+	}
+	return circuitbreaker.Call(a.breaker, func() (*adapter.DeploymentPreview, error) {
+		return previewer.PreviewDeployment(ctx, id, update)
+	})
+}
+
+// SuspendDeployment suspends the reconciliation of a deployment through the breaker.
+func (a *Adapter) SuspendDeployment(ctx context.Context, id string) error {
+	suspender, ok := a.DMSAdapter.(adapter.DeploymentSuspender)
+	if !ok {
+		return adapter.ErrOperationNotSupported
+	}
+	return a.breaker.Do(func() error {
+		return suspender.SuspendDeployment(ctx, id)
+	})
+}
+
+// ResumeDeployment resumes the reconciliation of a deployment through the breaker.
+func (a *Adapter) ResumeDeployment(ctx context.Context, id string) error {
+	suspender, ok := a.DMSAdapter.(adapter.DeploymentSuspender)
+	if !ok {
+		return adapter.ErrOperationNotSupported
+	}
+	return a.breaker.Do(func() error {
+		return suspender.ResumeDeployment(ctx, id)
+	})
+}
+
+// ReconcileDeployment reconciles a deployment through the breaker.
+func (a *Adapter) ReconcileDeployment(
+	ctx context.Context,
+	id string,
+	opts *adapter.ReconcileOptions,
+) (*adapter.ReconcileResult, error) {
+	reconciler, ok := a.DMSAdapter.(adapter.DeploymentReconciler)
+	if !ok {
+		return nil, adapter.ErrOperationNotSupported
+	}
+	return circuitbreaker.Call(a.breaker, func() (*adapter.ReconcileResult, error) {
+		return reconciler.ReconcileDeployment(ctx, id, opts)
+	})
+}
+
+// GetSyncPolicy retrieves the sync policy of a deployment through the breaker.
+func (a *Adapter) GetSyncPolicy(ctx context.Context, id string) (*adapter.SyncPolicy, error) {
+	manager, ok := a.DMSAdapter.(adapter.SyncPolicyManager)
+	if !ok {
+		return nil, adapter.ErrOperationNotSupported
+	}
+	return circuitbreaker.Call(a.breaker, func() (*adapter.SyncPolicy, error) {
+		return manager.GetSyncPolicy(ctx, id)
+	})
+}
+
+// SetSyncPolicy replaces the sync policy of a deployment through the breaker.
+func (a *Adapter) SetSyncPolicy(
+	ctx context.Context,
+	id string,
+	policy *adapter.SyncPolicy,
+) (*adapter.SyncPolicy, error) {
+	manager, ok := a.DMSAdapter.(adapter.SyncPolicyManager)
+	if !ok {
+		return nil, adapter.ErrOperationNotSupported
+	}
+	return circuitbreaker.Call(a.breaker, func() (*adapter.SyncPolicy, error) {
+		return manager.SetSyncPolicy(ctx, id, policy)
+	})
+}
+
+// Interface checks.
+var (
+	_ adapter.DMSAdapter           = (*Adapter)(nil)
+	_ adapter.ConfigProber         = (*Adapter)(nil)
+	_ adapter.OperationCanceller   = (*Adapter)(nil)
+	_ adapter.NotesProvider        = (*Adapter)(nil)
+	_ adapter.DeploymentPreviewer  = (*Adapter)(nil)
+	_ adapter.DeploymentSuspender  = (*Adapter)(nil)
+	_ adapter.DeploymentReconciler = (*Adapter)(nil)
+	_ adapter.SyncPolicyManager    = (*Adapter)(nil)
+	_ httperror.RuleProvider       = (*Adapter)(nil)
+)
@@ -0,0 +1,113 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/mock"
+	"github.com/piwi3910/netweave/internal/dms/breaker"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+)
+
+// flakyAdapter fails its deployment lists while down.
+type flakyAdapter struct {
+	*mock.Adapter
+	down  bool
+	lists int
+}
+
+func (a *flakyAdapter) ListDeployments(ctx context.Context, filter *adapter.Filter) ([]*adapter.Deployment, error) {
+	a.lists++
+	if a.down {
+		return nil, errors.New("dial tcp 10.0.0.1:443: connect: connection refused")
+	}
+	return a.Adapter.ListDeployments(ctx, filter)
+}
+
+func newSet() *circuitbreaker.Set {
+	return circuitbreaker.NewSet(circuitbreaker.Settings{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		HalfOpenRequests: 1,
+	}, zap.NewNop())
+}
+
+func TestAdapter_OpensOnBackendFailures(t *testing.T) {
+	ctx := context.Background()
+	set := newSet()
+	inner := &flakyAdapter{Adapter: mock.NewAdapter(false), down: true}
+	adp := breaker.New("flaky", inner, set)
+
+	for range 2 {
+		_, err := adp.ListDeployments(ctx, nil)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, circuitbreaker.ErrOpen)
+	}
+
+	_, err := adp.ListDeployments(ctx, nil)
+	require.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Equal(t, 2, inner.lists, "open breaker must not call the backend")
+	assert.Equal(t, circuitbreaker.StateOpen, set.Statuses()["dms-adapter/flaky"].State)
+
+	// Health checks bypass the breaker.
+	assert.NoError(t, adp.Health(ctx))
+	assert.NoError(t, adp.ProbeConfig(ctx))
+}
+
+func TestAdapter_IgnoresCallerErrors(t *testing.T) {
+	ctx := context.Background()
+	set := newSet()
+	adp := breaker.New("mock", mock.NewAdapter(false), set)
+
+	for range 3 {
+		_, err := adp.GetDeployment(ctx, "missing")
+		require.ErrorIs(t, err, adapter.ErrDeploymentNotFound)
+		require.ErrorIs(t, adp.CancelOperation(ctx, "missing"), adapter.ErrOperationNotSupported)
+	}
+	assert.Equal(t, circuitbreaker.StateClosed, set.Statuses()["dms-adapter/mock"].State)
+}
+
+func TestAdapter_OptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	adp := breaker.New("mock", mock.NewAdapter(false), newSet())
+
+	// The mock adapter implements none of the optional interfaces.
+	_, err := adp.GetDeploymentNotes(ctx, "dep")
+	require.ErrorIs(t, err, adapter.ErrOperationNotSupported)
+	_, err = adp.PreviewDeployment(ctx, "dep", &adapter.DeploymentUpdate{})
+	require.ErrorIs(t, err, adapter.ErrOperationNotSupported)
+	require.ErrorIs(t, adp.SuspendDeployment(ctx, "dep"), adapter.ErrOperationNotSupported)
+	_, err = adp.ReconcileDeployment(ctx, "dep", &adapter.ReconcileOptions{})
+	require.ErrorIs(t, err, adapter.ErrOperationNotSupported)
+	_, err = adp.GetSyncPolicy(ctx, "dep")
+	require.ErrorIs(t, err, adapter.ErrOperationNotSupported)
+	assert.Nil(t, adp.ErrorRules())
+
+	// Capabilities are those of the wrapped adapter.
+	assert.Equal(t, mock.NewAdapter(false).Capabilities(), adp.Capabilities())
+}
+
+func TestRegistry_Decorate(t *testing.T) {
+	ctx := context.Background()
+	set := newSet()
+	reg := registry.NewRegistry(zap.NewNop(), &registry.Config{
+		Decorate: func(name string, plugin adapter.DMSAdapter) adapter.DMSAdapter {
+			return breaker.New(name, plugin, set)
+		},
+	})
+
+	require.NoError(t, reg.Register(ctx, "edge", "mock", mock.NewAdapter(false), nil, true))
+	assert.IsType(t, &breaker.Adapter{}, reg.Get("edge"))
+	assert.Contains(t, set.Statuses(), "dms-adapter/edge")
+
+	require.NoError(t, reg.Unregister("edge"))
+	assert.NotContains(t, set.Statuses(), "dms-adapter/edge")
+}
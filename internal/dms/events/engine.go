@@ -0,0 +1,472 @@
+// Package events emits O2-DMS subscription notifications.
+//
+// The Engine periodically lists the NF deployments of every enabled DMS
+// adapter (Helm, ArgoCD, Flux, ...), compares them with the previous listing,
+// and turns the differences into NFDeploymentCreated, NFDeploymentUpdated,
+// NFDeploymentStatusChanged and NFDeploymentDeleted events. Each event is
+// posted to the callback of every DMS subscription whose filter matches it,
+// and the outcome of each delivery is recorded per subscription.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/resolver"
+	"github.com/piwi3910/netweave/internal/timeutil"
+	"github.com/piwi3910/netweave/internal/workers"
+)
+
+// Engine defaults.
+const (
+	DefaultInterval     = 30 * time.Second
+	DefaultTimeout      = 10 * time.Second
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = time.Second
+)
+
+// maxErrorBodyBytes caps the callback response body kept in delivery errors.
+const maxErrorBodyBytes = 512
+
+// Config configures the DMS notification engine.
+type Config struct {
+	// Interval is how often adapters are polled for deployment changes.
+	Interval time.Duration
+
+	// Timeout bounds a single callback POST.
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after the first failed delivery.
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry; it doubles with
+	// every further retry.
+	RetryBackoff time.Duration
+
+	// HMACSecret signs every notification (X-O2DMS-Signature header).
+	// Notifications are unsigned when it is empty.
+	HMACSecret string
+
+	// DialGuard, when set, refuses deliveries to callbacks resolving to
+	// private, loopback or metadata addresses at connection time.
+	DialGuard *resolver.Guard
+}
+
+// Event is a deployment state transition detected by the engine.
+type Event struct {
+	// Type is the DMS event type.
+	Type models.DMSEventType
+
+	// Adapter is the name of the adapter managing the deployment.
+	Adapter string
+
+	// Deployment is the deployment after the transition, or its last known
+	// state when it was deleted.
+	Deployment *adapter.Deployment
+
+	// PreviousStatus is the status before a status change.
+	PreviousStatus adapter.DeploymentStatus
+
+	// Timestamp is when the transition was detected.
+	Timestamp time.Time
+}
+
+// Engine detects DMS deployment state transitions and notifies subscribers.
+type Engine struct {
+	registry   *registry.Registry
+	store      storage.Store
+	deliveries storage.DeliveryStore
+	config     Config
+	client     *http.Client
+	logger     *zap.Logger
+
+	mu sync.Mutex
+	// primed is set once the first listing has been taken; deployments that
+	// exist when the engine starts do not produce events.
+	primed bool
+	// snapshot holds the last listed deployments by adapter and ID.
+	snapshot map[string]map[string]*adapter.Deployment
+}
+
+// NewEngine creates a DMS notification engine. Unset config fields take their
+// defaults.
+func NewEngine(
+	reg *registry.Registry,
+	store storage.Store,
+	deliveries storage.DeliveryStore,
+	config Config,
+	logger *zap.Logger,
+) *Engine {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultRetryBackoff
+	}
+
+	transport := resolver.NewTransport()
+	if config.DialGuard != nil {
+		transport = config.DialGuard.Transport()
+	}
+
+	return &Engine{
+		registry:   reg,
+		store:      store,
+		deliveries: deliveries,
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout, Transport: transport},
+		logger:     logger,
+		snapshot:   make(map[string]map[string]*adapter.Deployment),
+	}
+}
+
+// Start polls the adapters and delivers notifications at the configured
+// interval until ctx is canceled.
+func (e *Engine) Start(ctx context.Context) {
+	e.logger.Info("starting DMS notification engine", zap.Duration("interval", e.config.Interval))
+
+	go func() {
+		ticker := time.NewTicker(e.config.Interval)
+		defer ticker.Stop()
+		for {
+			e.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				e.logger.Info("DMS notification engine stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce polls the adapters once and delivers the resulting notifications.
+func (e *Engine) RunOnce(ctx context.Context) {
+	events := e.Poll(ctx)
+	if len(events) == 0 {
+		return
+	}
+	e.Dispatch(ctx, events)
+}
+
+// Poll lists the deployments of every enabled adapter and returns the state
+// transitions since the previous poll. The first poll only records the
+// current state. Deployments of an adapter that cannot be listed keep their
+// last known state, so listing errors are not reported as deletions.
+func (e *Engine) Poll(ctx context.Context) []*Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := timeutil.Now()
+	current := make(map[string]map[string]*adapter.Deployment)
+	var events []*Event
+	for _, meta := range e.registry.ListMetadata() {
+		if !meta.Enabled {
+			continue
+		}
+		plugin := e.registry.Get(meta.Name)
+		if plugin == nil {
+			continue
+		}
+
+		deployments, err := plugin.ListDeployments(ctx, nil)
+		if err != nil {
+			pollErrors.WithLabelValues(meta.Name).Inc()
+			e.logger.Warn("failed to list deployments for DMS notifications",
+				zap.String("adapter", meta.Name), zap.Error(err))
+			if previous, ok := e.snapshot[meta.Name]; ok {
+				current[meta.Name] = previous
+			}
+			continue
+		}
+
+		// Deployments outside the adapter's namespace policy are not exposed
+		// through the API, so they do not produce notifications either.
+		deployments = e.registry.NamespacePolicyOf(plugin).FilterDeployments(deployments)
+		listed := make(map[string]*adapter.Deployment, len(deployments))
+		for _, d := range deployments {
+			// Adapters may return shared objects; keep a copy to compare against.
+			deploymentCopy := *d
+			listed[d.ID] = &deploymentCopy
+		}
+		current[meta.Name] = listed
+
+		if e.primed {
+			events = append(events, diff(meta.Name, e.snapshot[meta.Name], listed, now)...)
+		}
+	}
+
+	e.snapshot = current
+	e.primed = true
+
+	for _, ev := range events {
+		eventsDetected.WithLabelValues(string(ev.Type)).Inc()
+	}
+	return events
+}
+
+// diff returns the transitions between two listings of an adapter, ordered
+// by deployment ID.
+func diff(adapterName string, previous, current map[string]*adapter.Deployment, now time.Time) []*Event {
+	var events []*Event
+	for _, id := range sortedKeys(current) {
+		d := current[id]
+		prev, existed := previous[id]
+		if !existed {
+			events = append(events, &Event{
+				Type: models.DMSEventTypeDeploymentCreated, Adapter: adapterName, Deployment: d, Timestamp: now,
+			})
+			continue
+		}
+		if prev.Status != d.Status {
+			events = append(events, &Event{
+				Type: models.DMSEventTypeDeploymentStatusChanged, Adapter: adapterName, Deployment: d,
+				PreviousStatus: prev.Status, Timestamp: now,
+			})
+		}
+		if prev.Version != d.Version || (prev.Status == d.Status && !prev.UpdatedAt.Equal(d.UpdatedAt)) {
+			events = append(events, &Event{
+				Type: models.DMSEventTypeDeploymentUpdated, Adapter: adapterName, Deployment: d, Timestamp: now,
+			})
+		}
+	}
+
+	for _, id := range sortedKeys(previous) {
+		if _, exists := current[id]; !exists {
+			events = append(events, &Event{
+				Type: models.DMSEventTypeDeploymentDeleted, Adapter: adapterName, Deployment: previous[id],
+				Timestamp: now,
+			})
+		}
+	}
+	return events
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]*adapter.Deployment) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Matches reports whether an event passes the filter of a subscription. Empty
+// filter criteria match everything.
+func Matches(sub *models.DMSSubscription, ev *Event) bool {
+	f := sub.Filter
+	if f == nil {
+		return true
+	}
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, ev.Type) {
+		return false
+	}
+	if len(f.NFDeploymentIDs) > 0 && !slices.Contains(f.NFDeploymentIDs, ev.Deployment.ID) {
+		return false
+	}
+	if len(f.NFDeploymentDescriptorIDs) > 0 && !slices.Contains(f.NFDeploymentDescriptorIDs, ev.Deployment.PackageID) {
+		return false
+	}
+	if f.Namespace != "" && f.Namespace != ev.Deployment.Namespace {
+		return false
+	}
+	return true
+}
+
+// Dispatch delivers events to the callbacks of the matching subscriptions.
+// Each subscription receives its events in order; subscriptions are served
+// concurrently so a slow callback does not delay the others.
+func (e *Engine) Dispatch(ctx context.Context, events []*Event) {
+	subs, err := e.store.List(ctx)
+	if err != nil {
+		e.logger.Error("failed to list DMS subscriptions for notifications", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	var wg sync.WaitGroup
+	for _, sub := range subs {
+		// Expired subscriptions await deletion by the expiry cleanup.
+		if sub.Expired(now) {
+			continue
+		}
+
+		var matched []*Event
+		for _, ev := range events {
+			if Matches(sub, ev) {
+				matched = append(matched, ev)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(sub *models.DMSSubscription, matched []*Event) {
+			defer wg.Done()
+			for _, ev := range matched {
+				if ctx.Err() != nil {
+					return
+				}
+				e.record(ctx, e.deliver(ctx, sub, ev))
+			}
+		}(sub, matched)
+	}
+	wg.Wait()
+}
+
+// record stores a delivery and updates the delivery metrics.
+func (e *Engine) record(ctx context.Context, delivery *models.DMSDelivery) {
+	notificationsDelivered.WithLabelValues(string(delivery.EventType), string(delivery.Status)).Inc()
+	if e.deliveries == nil {
+		return
+	}
+	if err := e.deliveries.Record(ctx, delivery); err != nil {
+		e.logger.Warn("failed to record DMS notification delivery",
+			zap.String("subscription_id", delivery.SubscriptionID), zap.Error(err))
+	}
+}
+
+// deliver posts a notification to a subscription callback, retrying failed
+// attempts with exponential backoff.
+func (e *Engine) deliver(ctx context.Context, sub *models.DMSSubscription, ev *Event) *models.DMSDelivery {
+	delivery := &models.DMSDelivery{
+		DeliveryID:     uuid.New().String(),
+		SubscriptionID: sub.SubscriptionID,
+		EventType:      ev.Type,
+		NFDeploymentID: ev.Deployment.ID,
+		CreatedAt:      ev.Timestamp,
+	}
+
+	payload, err := json.Marshal(buildNotification(sub, ev))
+	if err != nil {
+		delivery.Status = models.DMSDeliveryStatusFailed
+		delivery.LastError = fmt.Sprintf("failed to marshal notification: %v", err)
+		delivery.CompletedAt = timeutil.Now()
+		return delivery
+	}
+
+	// All attempts connect to the callback addresses of the first resolution.
+	ctx = resolver.Pin(ctx)
+
+	backoff := e.config.RetryBackoff
+	for attempt := 0; attempt <= e.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				delivery.Status = models.DMSDeliveryStatusFailed
+				delivery.LastError = fmt.Sprintf("delivery canceled: %v", ctx.Err())
+				delivery.CompletedAt = timeutil.Now()
+				return delivery
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		delivery.Attempts++
+		status, err := e.post(ctx, sub.Callback, delivery, payload)
+		delivery.HTTPStatusCode = status
+		if err == nil {
+			delivery.Status = models.DMSDeliveryStatusDelivered
+			delivery.LastError = ""
+			delivery.CompletedAt = timeutil.Now()
+			return delivery
+		}
+
+		delivery.LastError = err.Error()
+		e.logger.Warn("DMS notification delivery failed",
+			zap.String("subscription_id", sub.SubscriptionID),
+			zap.String("event_type", string(ev.Type)),
+			zap.Int("attempt", delivery.Attempts),
+			zap.String("callback", handlers.RedactURL(sub.Callback)),
+			zap.Error(err))
+		if errors.Is(err, resolver.ErrBlocked) {
+			break
+		}
+	}
+
+	delivery.Status = models.DMSDeliveryStatusFailed
+	delivery.CompletedAt = timeutil.Now()
+	return delivery
+}
+
+// buildNotification builds the O2-DMS notification payload of an event.
+func buildNotification(sub *models.DMSSubscription, ev *Event) *models.DMSNotification {
+	extensions := map[string]interface{}{"adapter": ev.Adapter}
+	if ev.Type == models.DMSEventTypeDeploymentStatusChanged {
+		extensions["previousStatus"] = handlers.ConvertDeploymentStatus(ev.PreviousStatus)
+	}
+
+	return &models.DMSNotification{
+		SubscriptionID:         sub.SubscriptionID,
+		ConsumerSubscriptionID: sub.ConsumerSubscriptionID,
+		EventType:              ev.Type,
+		NFDeployment:           handlers.ConvertToNFDeployment(ev.Deployment),
+		Timestamp:              ev.Timestamp,
+		Extensions:             extensions,
+	}
+}
+
+// post sends one delivery attempt and returns the response status code.
+func (e *Engine) post(ctx context.Context, callback string, delivery *models.DMSDelivery, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callback, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "O2-DMS-Gateway/1.0")
+	req.Header.Set("X-O2DMS-Event-Type", string(delivery.EventType))
+	req.Header.Set("X-O2DMS-Notification-ID", delivery.DeliveryID)
+	req.Header.Set("X-O2DMS-Subscription-ID", delivery.SubscriptionID)
+
+	// Signed like O2-IMS notifications: the timestamp is signed with the body
+	// so receivers can reject replayed notifications.
+	if e.config.HMACSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("X-O2DMS-Timestamp", timestamp)
+		req.Header.Set("X-O2DMS-Signature", workers.SignWithSecret(e.config.HMACSecret, timestamp, payload))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			e.logger.Warn("failed to close response body", zap.Error(closeErr))
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return resp.StatusCode, fmt.Errorf("callback returned non-2xx status: %d, body: %s",
+			resp.StatusCode, string(body))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,214 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/mock"
+	"github.com/piwi3910/netweave/internal/dms/events"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/workers"
+)
+
+// fakeAdapter serves a settable deployment listing.
+type fakeAdapter struct {
+	*mock.Adapter
+
+	mu          sync.Mutex
+	deployments []*adapter.Deployment
+	listErr     error
+}
+
+func (f *fakeAdapter) ListDeployments(_ context.Context, _ *adapter.Filter) ([]*adapter.Deployment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.deployments, f.listErr
+}
+
+func (f *fakeAdapter) set(err error, deployments ...*adapter.Deployment) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deployments = deployments
+	f.listErr = err
+}
+
+func newEngine(
+	t *testing.T,
+	cfg events.Config,
+) (*events.Engine, *fakeAdapter, storage.Store, *storage.MemoryDeliveryStore) {
+	t.Helper()
+
+	fake := &fakeAdapter{Adapter: mock.NewAdapter(false)}
+	reg := registry.NewRegistry(zap.NewNop(), nil)
+	require.NoError(t, reg.Register(context.Background(), "helm", "helm", fake, nil, true))
+
+	store := storage.NewMemoryStore()
+	deliveries := storage.NewMemoryDeliveryStore(10)
+	return events.NewEngine(reg, store, deliveries, cfg, zap.NewNop()), fake, store, deliveries
+}
+
+func deployment(id string, status adapter.DeploymentStatus, version int) *adapter.Deployment {
+	return &adapter.Deployment{
+		ID: id, Name: id, PackageID: "pkg-" + id, Namespace: "ran",
+		Status: status, Version: version, UpdatedAt: time.Unix(int64(version), 0),
+	}
+}
+
+func eventTypes(evs []*events.Event) []models.DMSEventType {
+	types := make([]models.DMSEventType, 0, len(evs))
+	for _, ev := range evs {
+		types = append(types, ev.Type)
+	}
+	return types
+}
+
+func TestEngine_Poll(t *testing.T) {
+	engine, fake, _, _ := newEngine(t, events.Config{})
+	ctx := context.Background()
+
+	fake.set(nil, deployment("existing", adapter.DeploymentStatusDeployed, 1))
+	assert.Empty(t, engine.Poll(ctx), "deployments existing at start produce no events")
+
+	fake.set(nil,
+		deployment("existing", adapter.DeploymentStatusDeployed, 1),
+		deployment("new", adapter.DeploymentStatusPending, 1))
+	assert.Equal(t, []models.DMSEventType{models.DMSEventTypeDeploymentCreated}, eventTypes(engine.Poll(ctx)))
+
+	fake.set(nil,
+		deployment("existing", adapter.DeploymentStatusDeploying, 2),
+		deployment("new", adapter.DeploymentStatusDeployed, 1))
+	evs := engine.Poll(ctx)
+	assert.Equal(t, []models.DMSEventType{
+		models.DMSEventTypeDeploymentStatusChanged,
+		models.DMSEventTypeDeploymentUpdated,
+		models.DMSEventTypeDeploymentStatusChanged,
+	}, eventTypes(evs))
+	assert.Equal(t, adapter.DeploymentStatusDeployed, evs[0].PreviousStatus)
+	assert.Equal(t, "helm", evs[0].Adapter)
+
+	fake.set(errors.New("helm unavailable"))
+	assert.Empty(t, engine.Poll(ctx), "listing errors are not reported as deletions")
+
+	fake.set(nil, deployment("new", adapter.DeploymentStatusDeployed, 1))
+	evs = engine.Poll(ctx)
+	assert.Equal(t, []models.DMSEventType{models.DMSEventTypeDeploymentDeleted}, eventTypes(evs))
+	assert.Equal(t, "existing", evs[0].Deployment.ID)
+}
+
+func TestMatches(t *testing.T) {
+	ev := &events.Event{
+		Type:       models.DMSEventTypeDeploymentCreated,
+		Deployment: deployment("dep-1", adapter.DeploymentStatusPending, 1),
+	}
+
+	tests := []struct {
+		name   string
+		filter *models.DMSSubscriptionFilter
+		want   bool
+	}{
+		{name: "no filter", want: true},
+		{name: "event type", filter: &models.DMSSubscriptionFilter{
+			EventTypes: []models.DMSEventType{models.DMSEventTypeDeploymentCreated}}, want: true},
+		{name: "other event type", filter: &models.DMSSubscriptionFilter{
+			EventTypes: []models.DMSEventType{models.DMSEventTypeDeploymentDeleted}}},
+		{name: "deployment ID", filter: &models.DMSSubscriptionFilter{NFDeploymentIDs: []string{"dep-1"}}, want: true},
+		{name: "other deployment", filter: &models.DMSSubscriptionFilter{NFDeploymentIDs: []string{"dep-2"}}},
+		{name: "descriptor ID", filter: &models.DMSSubscriptionFilter{
+			NFDeploymentDescriptorIDs: []string{"pkg-dep-1"}}, want: true},
+		{name: "other namespace", filter: &models.DMSSubscriptionFilter{Namespace: "core"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, events.Matches(&models.DMSSubscription{Filter: tt.filter}, ev))
+		})
+	}
+}
+
+func TestEngine_Dispatch(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []*http.Request
+		payloads []models.DMSNotification
+	)
+	callbacks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var notification models.DMSNotification
+		_ = json.Unmarshal(body, &notification)
+
+		mu.Lock()
+		received = append(received, r)
+		payloads = append(payloads, notification)
+		mu.Unlock()
+
+		assert.Equal(t, workers.SignWithSecret("secret", r.Header.Get("X-O2DMS-Timestamp"), body),
+			r.Header.Get("X-O2DMS-Signature"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callbacks.Close()
+
+	engine, fake, store, deliveries := newEngine(t, events.Config{
+		MaxRetries: 1, RetryBackoff: time.Millisecond, HMACSecret: "secret",
+	})
+	ctx := context.Background()
+	for _, sub := range []*models.DMSSubscription{
+		{SubscriptionID: "status", Callback: callbacks.URL + "/status", ConsumerSubscriptionID: "smo-1",
+			Filter: &models.DMSSubscriptionFilter{
+				EventTypes: []models.DMSEventType{models.DMSEventTypeDeploymentStatusChanged}}},
+		{SubscriptionID: "core", Callback: callbacks.URL + "/core",
+			Filter: &models.DMSSubscriptionFilter{Namespace: "core"}},
+		{SubscriptionID: "failing", Callback: callbacks.URL + "/failing"},
+	} {
+		require.NoError(t, store.Create(ctx, sub))
+	}
+
+	fake.set(nil, deployment("dep-1", adapter.DeploymentStatusDeploying, 1))
+	engine.RunOnce(ctx)
+	fake.set(nil, deployment("dep-1", adapter.DeploymentStatusDeployed, 1))
+	engine.RunOnce(ctx)
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "/status", received[0].URL.Path)
+	assert.Equal(t, string(models.DMSEventTypeDeploymentStatusChanged), received[0].Header.Get("X-O2DMS-Event-Type"))
+	assert.Equal(t, "status", received[0].Header.Get("X-O2DMS-Subscription-ID"))
+	assert.Equal(t, "smo-1", payloads[0].ConsumerSubscriptionID)
+	assert.Equal(t, models.NFDeploymentStatusDeployed, payloads[0].NFDeployment.Status)
+	assert.Equal(t, "helm", payloads[0].Extensions["adapter"])
+
+	delivered, err := deliveries.List(ctx, "status")
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, models.DMSDeliveryStatusDelivered, delivered[0].Status)
+	assert.Equal(t, http.StatusNoContent, delivered[0].HTTPStatusCode)
+	assert.Equal(t, received[0].Header.Get("X-O2DMS-Notification-ID"), delivered[0].DeliveryID)
+
+	none, err := deliveries.List(ctx, "core")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	failed, err := deliveries.List(ctx, "failing")
+	require.NoError(t, err)
+	require.Len(t, failed, 1, "the second poll only detected a status change")
+	assert.Equal(t, models.DMSDeliveryStatusFailed, failed[0].Status)
+	assert.Equal(t, 2, failed[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, failed[0].HTTPStatusCode)
+	assert.Contains(t, failed[0].LastError, "503")
+}
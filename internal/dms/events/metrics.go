@@ -0,0 +1,41 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// eventsDetected counts the deployment state transitions detected.
+	eventsDetected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "dms_notifications",
+			Name:      "events_total",
+			Help:      "Total number of DMS deployment events detected by type",
+		},
+		[]string{"event_type"},
+	)
+
+	// notificationsDelivered counts the notification deliveries by outcome.
+	notificationsDelivered = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "dms_notifications",
+			Name:      "deliveries_total",
+			Help:      "Total number of DMS notification deliveries by event type and status",
+		},
+		[]string{"event_type", "status"},
+	)
+
+	// pollErrors counts the failed deployment listings.
+	pollErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "dms_notifications",
+			Name:      "poll_errors_total",
+			Help:      "Total number of failed deployment listings by adapter",
+		},
+		[]string{"adapter"},
+	)
+)
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/dms"
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+)
+
+// AdapterFactory creates the DMS adapters registered through the API. It
+// returns the adapter and the settings the registry reports for it.
+type AdapterFactory func(typ string, config *dms.AdapterConfig) (adapter.DMSAdapter, map[string]interface{}, error)
+
+// adapterNamePattern matches the names adapters can be registered under.
+var adapterNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// SetAdapterFactory replaces the factory of adapters registered through the
+// API, which defaults to dms.NewAdapter.
+func (h *Handler) SetAdapterFactory(factory AdapterFactory) {
+	h.adapterFactory = factory
+}
+
+// SetRegistrationStore persists the adapters registered through the API in
+// registrations, so SyncDMSAdapters registers them again after a restart and
+// on the other replicas.
+func (h *Handler) SetRegistrationStore(registrations storage.RegistrationStore) {
+	h.registrations = registrations
+}
+
+// SyncDMSAdapters makes the adapters registered through the API match the
+// registration store: it registers the adapters persisted in the store, in
+// the order they were registered, replaces those registered again since the
+// last sync, and unregisters those whose registration was removed, for
+// instance by another replica. Adapters that fail to be created or
+// registered, for instance because dms.adapters has an adapter with the same
+// name, are logged and skipped until their registration changes.
+func (h *Handler) SyncDMSAdapters(ctx context.Context) error {
+	if h.registrations == nil {
+		return nil
+	}
+
+	h.registrationsMu.Lock()
+	defer h.registrationsMu.Unlock()
+
+	regs, err := h.registrations.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list DMS adapter registrations: %w", err)
+	}
+	if h.synced == nil {
+		h.synced = make(map[string]time.Time)
+		h.skipped = make(map[string]time.Time)
+	}
+
+	stored := make(map[string]bool, len(regs))
+	for _, reg := range regs {
+		stored[reg.Name] = true
+		if at, ok := h.synced[reg.Name]; ok && at.Equal(reg.RegisteredAt) {
+			continue
+		}
+		if at, ok := h.skipped[reg.Name]; ok && at.Equal(reg.RegisteredAt) {
+			continue
+		}
+		h.syncDMSAdapter(ctx, reg)
+	}
+
+	for name := range h.synced {
+		if stored[name] {
+			continue
+		}
+		logger := h.logger.With(zap.String("name", name))
+		if h.registry.GetDefaultName() == name {
+			logger.Warn("registration of the default DMS adapter was removed; keeping the adapter")
+			continue
+		}
+		delete(h.synced, name)
+		if err := h.registry.Unregister(name); err != nil && !errors.Is(err, registry.ErrPluginNotFound) {
+			logger.Warn("failed to unregister DMS adapter", zap.Error(err))
+			continue
+		}
+		logger.Info("DMS adapter unregistered by sync")
+	}
+	for name := range h.skipped {
+		if !stored[name] {
+			delete(h.skipped, name)
+		}
+	}
+	return nil
+}
+
+// syncDMSAdapter registers the adapter of reg, replacing the adapter of an
+// earlier registration with the same name. registrationsMu must be held.
+func (h *Handler) syncDMSAdapter(ctx context.Context, reg *storage.AdapterRegistration) {
+	logger := h.logger.With(zap.String("name", reg.Name), zap.String("type", reg.Type))
+	delete(h.skipped, reg.Name)
+
+	if _, ok := h.synced[reg.Name]; ok {
+		delete(h.synced, reg.Name)
+		if err := h.registry.Unregister(reg.Name); err != nil && !errors.Is(err, registry.ErrPluginNotFound) {
+			logger.Warn("failed to unregister replaced DMS adapter", zap.Error(err))
+		}
+	}
+
+	adp, adapterConfig, err := h.createAdapter(&reg.RegisterDMSAdapterRequest)
+	if err != nil {
+		h.skipped[reg.Name] = reg.RegisteredAt
+		logger.Warn("failed to restore DMS adapter", zap.Error(err))
+		return
+	}
+	if err := h.registry.Register(ctx, reg.Name, reg.Type, adp, adapterConfig, reg.Default); err != nil {
+		_ = adp.Close()
+		h.skipped[reg.Name] = reg.RegisteredAt
+		logger.Warn("failed to restore DMS adapter", zap.Error(err))
+		return
+	}
+	h.synced[reg.Name] = reg.RegisteredAt
+	logger.Info("DMS adapter restored")
+}
+
+// StartAdapterSync runs SyncDMSAdapters at interval until ctx is canceled, so
+// registrations made on other replicas are applied. It is a no-op without a
+// registration store or with a non-positive interval.
+func (h *Handler) StartAdapterSync(ctx context.Context, interval time.Duration) {
+	if h.registrations == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := h.SyncDMSAdapters(ctx); err != nil && ctx.Err() == nil {
+				h.logger.Error("failed to sync DMS adapters", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// ListDMSAdapters lists the registered DMS adapters with the result of their
+// last health check. With ?refresh=true every adapter is checked first.
+// GET /o2dms/v1/adapters.
+func (h *Handler) ListDMSAdapters(c *gin.Context) {
+	h.logger.Info("listing DMS adapters")
+
+	if refresh, _ := strconv.ParseBool(c.Query("refresh")); refresh {
+		h.registry.CheckHealth(c.Request.Context())
+	}
+
+	metadata := h.registry.ListMetadata()
+	adapters := make([]*models.DMSAdapter, 0, len(metadata))
+	for _, meta := range metadata {
+		adapters = append(adapters, convertToDMSAdapter(meta))
+	}
+	sort.Slice(adapters, func(i, j int) bool { return adapters[i].Name < adapters[j].Name })
+
+	c.JSON(http.StatusOK, models.DMSAdapterListResponse{
+		Adapters: adapters,
+		Total:    len(adapters),
+	})
+}
+
+// GetDMSAdapter retrieves a registered DMS adapter.
+// GET /o2dms/v1/adapters/:adapterName.
+func (h *Handler) GetDMSAdapter(c *gin.Context) {
+	name := c.Param("adapterName")
+
+	meta := h.registry.GetMetadata(name)
+	if meta == nil {
+		h.errorResponse(c, http.StatusNotFound, "NotFound", "DMS adapter not found")
+		return
+	}
+	c.JSON(http.StatusOK, convertToDMSAdapter(meta))
+}
+
+// RegisterDMSAdapter creates a DMS adapter and registers it while the gateway
+// is serving. The adapter is registered even if its initial health check
+// fails; the response reports the result. With a registration store the
+// adapter is registered again after a restart and on the other replicas;
+// otherwise add it to dms.adapters in the configuration to keep it.
+// POST /o2dms/v1/adapters.
+func (h *Handler) RegisterDMSAdapter(c *gin.Context) {
+	var req models.RegisterDMSAdapterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
+		return
+	}
+	h.logger.Info("registering DMS adapter", zap.String("name", req.Name), zap.String("type", req.Type))
+
+	if !adapterNamePattern.MatchString(req.Name) {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Adapter name must be a lowercase DNS label")
+		return
+	}
+	for field, value := range map[string]string{"repositoryUrl": req.RepositoryURL, "apiUrl": req.APIURL} {
+		if value == "" {
+			continue
+		}
+		if parsed, err := url.Parse(value); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") ||
+			parsed.Host == "" {
+			h.errorResponse(c, http.StatusBadRequest, "BadRequest", field+" must be an absolute http or https URL")
+			return
+		}
+	}
+
+	adp, adapterConfig, err := h.createAdapter(&req)
+	if err != nil {
+		h.logger.Warn("failed to create DMS adapter", zap.String("name", req.Name), zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid adapter configuration: "+err.Error())
+		return
+	}
+
+	h.registrationsMu.Lock()
+	defer h.registrationsMu.Unlock()
+
+	err = h.registry.Register(c.Request.Context(), req.Name, req.Type, adp, adapterConfig, req.Default)
+	if err != nil {
+		_ = adp.Close()
+		h.logger.Error("failed to register DMS adapter", zap.String("name", req.Name), zap.Error(err))
+		h.respondError(c, nil, err, "", "Failed to register adapter")
+		return
+	}
+
+	if h.registrations != nil {
+		reg := &storage.AdapterRegistration{RegisterDMSAdapterRequest: req}
+		if err := h.registrations.Save(c.Request.Context(), reg); err != nil {
+			if unregisterErr := h.registry.Unregister(req.Name); unregisterErr != nil {
+				h.logger.Warn("failed to unregister DMS adapter", zap.String("name", req.Name), zap.Error(unregisterErr))
+			}
+			if errors.Is(err, storage.ErrEncryptionKeyRequired) {
+				h.errorResponse(c, http.StatusBadRequest, "BadRequest",
+					"Adapters with a password can't be registered: no encryption key is configured for stored passwords")
+				return
+			}
+			h.logger.Error("failed to persist DMS adapter registration", zap.String("name", req.Name), zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to persist adapter registration")
+			return
+		}
+		if h.synced == nil {
+			h.synced = make(map[string]time.Time)
+			h.skipped = make(map[string]time.Time)
+		}
+		h.synced[req.Name] = reg.RegisteredAt
+	}
+
+	meta := h.registry.GetMetadata(req.Name)
+	if meta == nil {
+		// Unregistered again by a concurrent request.
+		h.errorResponse(c, http.StatusConflict, "Conflict", "Adapter was unregistered concurrently")
+		return
+	}
+	h.logger.Info("DMS adapter registered",
+		zap.String("name", req.Name),
+		zap.String("type", req.Type),
+		zap.Bool("healthy", meta.Healthy))
+
+	c.Header("Location", c.FullPath()+"/"+req.Name)
+	c.JSON(http.StatusCreated, convertToDMSAdapter(meta))
+}
+
+// UnregisterDMSAdapter unregisters and closes a DMS adapter. The default
+// adapter can't be unregistered; register another default adapter first.
+// DELETE /o2dms/v1/adapters/:adapterName.
+func (h *Handler) UnregisterDMSAdapter(c *gin.Context) {
+	name := c.Param("adapterName")
+	h.logger.Info("unregistering DMS adapter", zap.String("name", name))
+
+	if h.registry.GetDefaultName() == name {
+		h.errorResponse(c, http.StatusConflict, "Conflict",
+			"The default adapter can't be unregistered; register another default adapter first")
+		return
+	}
+
+	h.registrationsMu.Lock()
+	defer h.registrationsMu.Unlock()
+
+	// Forget the registration first so a failure leaves the adapter registered.
+	if h.registrations != nil {
+		if err := h.registrations.Delete(c.Request.Context(), name); err != nil {
+			h.logger.Error("failed to delete DMS adapter registration", zap.String("name", name), zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to delete adapter registration")
+			return
+		}
+	}
+
+	if err := h.registry.Unregister(name); err != nil {
+		if !errors.Is(err, registry.ErrPluginNotFound) {
+			h.logger.Error("failed to unregister DMS adapter", zap.String("name", name), zap.Error(err))
+		}
+		h.respondError(c, nil, err, "DMS adapter not found", "Failed to unregister adapter")
+		return
+	}
+	delete(h.synced, name)
+
+	h.logger.Info("DMS adapter unregistered", zap.String("name", name))
+	c.Status(http.StatusNoContent)
+}
+
+// createAdapter creates the adapter of a registration with the adapter
+// factory, which defaults to dms.NewAdapter.
+func (h *Handler) createAdapter(req *models.RegisterDMSAdapterRequest) (
+	adapter.DMSAdapter, map[string]interface{}, error,
+) {
+	factory := h.adapterFactory
+	if factory == nil {
+		factory = dms.NewAdapter
+	}
+	return factory(req.Type, &dms.AdapterConfig{
+		Enabled:       true,
+		IsDefault:     req.Default,
+		Kubeconfig:    req.Kubeconfig,
+		Namespace:     req.Namespace,
+		RepositoryURL: req.RepositoryURL,
+		ONAPURL:       req.APIURL,
+		OSMURL:        req.APIURL,
+		Username:      req.Username,
+		Password:      req.Password,
+	})
+}
+
+// convertToDMSAdapter converts registry metadata to the API model.
+func convertToDMSAdapter(meta *registry.PluginMetadata) *models.DMSAdapter {
+	capabilities := make([]string, 0, len(meta.Capabilities))
+	for _, capability := range meta.Capabilities {
+		capabilities = append(capabilities, string(capability))
+	}
+
+	a := &models.DMSAdapter{
+		Name:            meta.Name,
+		Type:            meta.Type,
+		Version:         meta.Version,
+		Default:         meta.Default,
+		Enabled:         meta.Enabled,
+		Capabilities:    capabilities,
+		Healthy:         meta.Healthy,
+		LastHealthCheck: meta.LastHealthCheck,
+		RegisteredAt:    meta.RegisteredAt,
+		Config:          meta.Config,
+	}
+	if meta.HealthError != nil {
+		a.HealthError = meta.HealthError.Error()
+	}
+	return a
+}
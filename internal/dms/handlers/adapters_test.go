@@ -0,0 +1,237 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/dms"
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+)
+
+func TestDMSAdapterRegistration(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+
+	var created []*dms.AdapterConfig
+	handler.SetAdapterFactory(func(typ string, config *dms.AdapterConfig) (
+		adapter.DMSAdapter, map[string]interface{}, error,
+	) {
+		if typ != dms.TypeHelm && typ != dms.TypeFlux {
+			return nil, nil, dms.ErrUnknownAdapterType
+		}
+		created = append(created, config)
+		adp := newMockAdapter()
+		adp.name = typ
+		if config.Kubeconfig == "/unreachable" {
+			adp.healthy = false
+			adp.healthErr = errors.New("cluster unreachable")
+		}
+		return adp, map[string]interface{}{"namespace": config.Namespace}, nil
+	})
+	router := setupTestRouter(handler)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/o2dms/v1/adapters", `{
+		"name": "helm-edge", "type": "helm", "namespace": "edge",
+		"repositoryUrl": "https://charts.example.com", "username": "ci", "password": "secret"
+	}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, "/o2dms/v1/adapters/helm-edge", w.Header().Get("Location"))
+	assert.NotContains(t, w.Body.String(), "secret")
+	var registered models.DMSAdapter
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registered))
+	assert.Equal(t, "helm-edge", registered.Name)
+	assert.Equal(t, "helm", registered.Type)
+	assert.True(t, registered.Healthy)
+	assert.False(t, registered.Default)
+	require.Len(t, created, 1)
+	assert.Equal(t, "secret", created[0].Password)
+	assert.Equal(t, "https://charts.example.com", created[0].RepositoryURL)
+
+	t.Run("unhealthy adapters are registered", func(t *testing.T) {
+		w := do(http.MethodPost, "/o2dms/v1/adapters",
+			`{"name": "flux-east", "type": "flux", "kubeconfig": "/unreachable"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = do(http.MethodGet, "/o2dms/v1/adapters/flux-east", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var got models.DMSAdapter
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.False(t, got.Healthy)
+		assert.Equal(t, "cluster unreachable", got.HealthError)
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		for body, status := range map[string]int{
+			`{"name": "helm-edge", "type": "helm"}`:                       http.StatusConflict,
+			`{"name": "Helm Edge", "type": "helm"}`:                       http.StatusBadRequest,
+			`{"name": "x", "type": "unknown"}`:                            http.StatusBadRequest,
+			`{"name": "x"}`:                                               http.StatusBadRequest,
+			`{"name": "x", "type": "helm", "repositoryUrl": "file:///x"}`: http.StatusBadRequest,
+		} {
+			w := do(http.MethodPost, "/o2dms/v1/adapters", body)
+			assert.Equal(t, status, w.Code, body)
+		}
+	})
+
+	t.Run("list with health", func(t *testing.T) {
+		w := do(http.MethodGet, "/o2dms/v1/adapters?refresh=true", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var list models.DMSAdapterListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Equal(t, 3, list.Total)
+		assert.Equal(t, "flux-east", list.Adapters[0].Name)
+		assert.Equal(t, "helm-edge", list.Adapters[1].Name)
+		assert.Equal(t, "mock", list.Adapters[2].Name)
+		assert.True(t, list.Adapters[2].Default)
+		assert.Equal(t, "edge", list.Adapters[1].Config["namespace"])
+	})
+
+	t.Run("registered adapters serve requests", func(t *testing.T) {
+		w := do(http.MethodGet, "/o2dms/v1/nfDeployments?adapter=helm-edge", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("default adapter", func(t *testing.T) {
+		w := do(http.MethodPost, "/o2dms/v1/adapters", `{"name": "helm-main", "type": "helm", "default": true}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = do(http.MethodDelete, "/o2dms/v1/adapters/helm-main", "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = do(http.MethodGet, "/o2dms/v1/adapters/mock", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var previous models.DMSAdapter
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &previous))
+		assert.False(t, previous.Default)
+	})
+
+	t.Run("unregister", func(t *testing.T) {
+		w := do(http.MethodDelete, "/o2dms/v1/adapters/helm-edge", "")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = do(http.MethodDelete, "/o2dms/v1/adapters/helm-edge", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		w = do(http.MethodGet, "/o2dms/v1/adapters/helm-edge", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDMSAdapterRegistration_Restore(t *testing.T) {
+	registrations := storage.NewMemoryRegistrationStore()
+	factory := func(typ string, _ *dms.AdapterConfig) (adapter.DMSAdapter, map[string]interface{}, error) {
+		if typ != dms.TypeHelm {
+			return nil, nil, dms.ErrUnknownAdapterType
+		}
+		return newMockAdapter(), nil, nil
+	}
+	newRouter := func() *gin.Engine {
+		handler, _ := setupTestHandler(t)
+		handler.SetAdapterFactory(factory)
+		handler.SetRegistrationStore(registrations)
+		require.NoError(t, handler.SyncDMSAdapters(context.Background()))
+		return setupTestRouter(handler)
+	}
+	do := func(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	router := newRouter()
+	for _, body := range []string{
+		`{"name": "helm-edge", "type": "helm", "password": "secret"}`,
+		`{"name": "helm-main", "type": "helm", "default": true}`,
+		`{"name": "helm-old", "type": "helm"}`,
+	} {
+		w := do(router, http.MethodPost, "/o2dms/v1/adapters", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	w := do(router, http.MethodDelete, "/o2dms/v1/adapters/helm-old", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	regs, err := registrations.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, regs, 2)
+	assert.Equal(t, "secret", regs[0].Password)
+
+	// A restarted gateway registers the persisted adapters again.
+	router = newRouter()
+	w = do(router, http.MethodGet, "/o2dms/v1/adapters", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list models.DMSAdapterListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 3, list.Total)
+	assert.Equal(t, "helm-edge", list.Adapters[0].Name)
+	assert.Equal(t, "helm-main", list.Adapters[1].Name)
+	assert.True(t, list.Adapters[1].Default)
+	assert.Equal(t, "mock", list.Adapters[2].Name)
+}
+
+func TestDMSAdapterRegistration_SyncAcrossReplicas(t *testing.T) {
+	registrations := storage.NewMemoryRegistrationStore()
+	factory := func(string, *dms.AdapterConfig) (adapter.DMSAdapter, map[string]interface{}, error) {
+		return newMockAdapter(), nil, nil
+	}
+	newReplica := func() (*handlers.Handler, *gin.Engine) {
+		handler, _ := setupTestHandler(t)
+		handler.SetAdapterFactory(factory)
+		handler.SetRegistrationStore(registrations)
+		require.NoError(t, handler.SyncDMSAdapters(context.Background()))
+		return handler, setupTestRouter(handler)
+	}
+	do := func(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	_, routerA := newReplica()
+	handlerB, routerB := newReplica()
+
+	w := do(routerA, http.MethodPost, "/o2dms/v1/adapters", `{"name": "helm-edge", "type": "helm"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, do(routerB, http.MethodGet, "/o2dms/v1/adapters/helm-edge", "").Code)
+
+	// The other replica registers the adapter on its next sync.
+	require.NoError(t, handlerB.SyncDMSAdapters(context.Background()))
+	assert.Equal(t, http.StatusOK, do(routerB, http.MethodGet, "/o2dms/v1/adapters/helm-edge", "").Code)
+
+	// Adapters configured on a replica are left alone.
+	assert.Equal(t, http.StatusOK, do(routerB, http.MethodGet, "/o2dms/v1/adapters/mock", "").Code)
+
+	// Unregistering on one replica unregisters on the others.
+	w = do(routerA, http.MethodDelete, "/o2dms/v1/adapters/helm-edge", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.NoError(t, handlerB.SyncDMSAdapters(context.Background()))
+	assert.Equal(t, http.StatusNotFound, do(routerB, http.MethodGet, "/o2dms/v1/adapters/helm-edge", "").Code)
+	assert.Equal(t, http.StatusOK, do(routerB, http.MethodGet, "/o2dms/v1/adapters/mock", "").Code)
+
+	// A registration made again replaces the adapter on the others.
+	w = do(routerA, http.MethodPost, "/o2dms/v1/adapters", `{"name": "helm-edge", "type": "helm", "namespace": "edge"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.NoError(t, handlerB.SyncDMSAdapters(context.Background()))
+	assert.Equal(t, http.StatusOK, do(routerB, http.MethodGet, "/o2dms/v1/adapters/helm-edge", "").Code)
+}
@@ -10,15 +10,23 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/piwi3910/netweave/internal/cost"
 	"github.com/piwi3910/netweave/internal/dms/adapter"
 	"github.com/piwi3910/netweave/internal/dms/models"
 	"github.com/piwi3910/netweave/internal/dms/registry"
 	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/httperror"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/resolver"
+	"github.com/piwi3910/netweave/internal/timeutil"
+	"github.com/piwi3910/netweave/internal/validation"
 	"go.uber.org/zap"
 )
 
@@ -31,22 +39,169 @@ const (
 	DefaultPaginationLimit = 100
 )
 
+// quotaUpdateTimeout bounds the quota bookkeeping done when a deployment
+// operation finishes.
+const quotaUpdateTimeout = 5 * time.Second
+
+// DefaultMaxReconcileWait caps how long a reconcile request waits for the
+// backend, so the response is sent before the server write timeout.
+const DefaultMaxReconcileWait = 25 * time.Second
+
 // Handler provides HTTP handlers for O2-DMS API endpoints.
 type Handler struct {
-	registry *registry.Registry
-	store    storage.Store
-	logger   *zap.Logger
+	registry   *registry.Registry
+	store      storage.Store
+	deliveries storage.DeliveryStore
+	logger     *zap.Logger
+	quotas     *QuotaEnforcer
+	pricing    *cost.Pricing
+	rules      *validation.Engine
+	jobs       *jobRunner
+	translator *httperror.Translator
+
+	adapterFactory AdapterFactory
+	registrations  storage.RegistrationStore
+
+	// registrationsMu serializes syncs with the registrations made through
+	// the API. synced holds the registration time of each adapter registered
+	// from the store, and skipped the registrations that failed to be.
+	registrationsMu sync.Mutex
+	synced          map[string]time.Time
+	skipped         map[string]time.Time
+
+	maxReconcileWait   time.Duration
+	maxSubscriptionTTL time.Duration
 }
 
 // NewHandler creates a new DMS handler.
 func NewHandler(reg *registry.Registry, store storage.Store, logger *zap.Logger) *Handler {
 	return &Handler{
-		registry: reg,
-		store:    store,
-		logger:   logger,
+		registry:         reg,
+		store:            store,
+		logger:           logger,
+		translator:       httperror.Default,
+		maxReconcileWait: DefaultMaxReconcileWait,
+	}
+}
+
+// SetMaxReconcileWait caps how long a reconcile request may wait for the
+// backend. It should stay below the server write timeout.
+func (h *Handler) SetMaxReconcileWait(d time.Duration) {
+	if d > 0 {
+		h.maxReconcileWait = d
 	}
 }
 
+// SetQuotaEnforcer enables per-tenant quota enforcement on deployment create,
+// update, and scale requests.
+func (h *Handler) SetQuotaEnforcer(q *QuotaEnforcer) {
+	h.quotas = q
+}
+
+// SetPricing enables cost estimates on NF deployments, computed from their
+// resource requests with pricing.
+func (h *Handler) SetPricing(pricing *cost.Pricing) {
+	h.pricing = pricing
+}
+
+// SetValidationRules sets the validation rules checked when NF deployments
+// are created or updated.
+func (h *Handler) SetValidationRules(rules *validation.Engine) {
+	h.rules = rules
+}
+
+// SetMaxSubscriptionTTL caps how far in the future the expiresAt of a
+// subscription may be set. Zero allows any expiry.
+func (h *Handler) SetMaxSubscriptionTTL(d time.Duration) {
+	h.maxSubscriptionTTL = d
+}
+
+// SetDeliveryStore enables the delivery status endpoint of DMS subscriptions,
+// backed by the store the DMS notification engine records deliveries in.
+func (h *Handler) SetDeliveryStore(deliveries storage.DeliveryStore) {
+	h.deliveries = deliveries
+}
+
+// toNFDeployment converts an adapter Deployment to an NFDeployment and, when
+// pricing is set, attaches its estimated cost and updates the cost metric.
+func (h *Handler) toNFDeployment(d *adapter.Deployment) *models.NFDeployment {
+	nf := ConvertToNFDeployment(d)
+	if nf == nil || h.pricing == nil || d.Values == nil {
+		return nf
+	}
+
+	usage, err := ParseDeploymentUsage(d.Values)
+	if err != nil {
+		h.logger.Debug("cannot estimate NF deployment cost",
+			zap.String("nf_deployment_id", d.ID), zap.Error(err))
+		return nf
+	}
+
+	nf.EstimatedCost = h.pricing.Estimate(usage.CostUsage())
+	cost.RecordNFDeployment(d.ID, d.Namespace, nf.EstimatedCost)
+	return nf
+}
+
+// reserveQuota reserves tenant quota for a deployment and writes a quota-aware error
+// response on failure. It returns false if the request must be aborted; otherwise
+// the returned function undoes the reservation if the operation fails.
+func (h *Handler) reserveQuota(c *gin.Context, deploymentID string, usage DeploymentUsage) (func(), bool) {
+	if h.quotas == nil {
+		return func() {}, true
+	}
+
+	tenantID := tenantIDFromContext(c)
+	undo, err := h.quotas.Reserve(c.Request.Context(), tenantID, deploymentID, usage)
+	if err == nil {
+		return func() { h.updateQuota(tenantID, deploymentID, "undo reservation", undo) }, true
+	}
+
+	var quotaErr *QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		h.logger.Warn("DMS quota exceeded",
+			zap.String("tenant_id", tenantID),
+			zap.String("resource", quotaErr.Resource),
+			zap.Int64("limit", quotaErr.Limit),
+			zap.Int64("current", quotaErr.Current),
+			zap.Int64("requested", quotaErr.Requested))
+		h.errorResponse(c, http.StatusTooManyRequests, "QuotaExceeded", quotaErr.Error())
+		return nil, false
+	}
+
+	h.logger.Error("failed to check DMS quota", zap.String("tenant_id", tenantID), zap.Error(err))
+	h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to check tenant quota")
+	return nil, false
+}
+
+// updateQuota runs quota bookkeeping that happens after a deployment operation
+// finished, possibly after the request that started it completed, and logs
+// failures.
+func (h *Handler) updateQuota(tenantID, deploymentID, action string, fn func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), quotaUpdateTimeout)
+	defer cancel()
+
+	if err := fn(ctx); err != nil {
+		h.logger.Error("failed to update DMS quota usage",
+			zap.String("tenant_id", tenantID),
+			zap.String("nf_deployment_id", deploymentID),
+			zap.String("action", action),
+			zap.Error(err))
+	}
+}
+
+// lookupQuota returns the usage recorded for a deployment and writes an error
+// response if it cannot be read.
+func (h *Handler) lookupQuota(c *gin.Context, deploymentID string) (DeploymentUsage, bool, bool) {
+	tenantID := tenantIDFromContext(c)
+	usage, found, err := h.quotas.Lookup(c.Request.Context(), tenantID, deploymentID)
+	if err != nil {
+		h.logger.Error("failed to read DMS quota usage", zap.String("tenant_id", tenantID), zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to check tenant quota")
+		return usage, false, false
+	}
+	return usage, found, true
+}
+
 // getAdapterFromQuery retrieves a DMS adapter using the adapter query parameter.
 // Returns adapter.DMSAdapter interface (factory/lookup pattern).
 // Note: Returning interface is idiomatic for factory/lookup methods.
@@ -79,27 +234,138 @@ func (h *Handler) getAdapterFromQuery(c *gin.Context) (adapter.DMSAdapter, error
 	return adp, nil
 }
 
+// adapterNameFromQuery returns the name of the adapter selected by the
+// request: the adapter query parameter or the default adapter.
+func (h *Handler) adapterNameFromQuery(c *gin.Context) string {
+	if name := c.Query("adapter"); name != "" {
+		return name
+	}
+	return h.registry.GetDefaultName()
+}
+
+// namespacePolicy returns the namespace policy of the adapter selected by the
+// request, or nil if its namespaces are unrestricted.
+func (h *Handler) namespacePolicy(c *gin.Context) *adapter.NamespacePolicy {
+	return h.registry.NamespacePolicy(h.adapterNameFromQuery(c))
+}
+
+// defaultNamespace returns the namespace the selected adapter deploys to when
+// a request names none.
+func (h *Handler) defaultNamespace(c *gin.Context) string {
+	meta := h.registry.GetMetadata(h.adapterNameFromQuery(c))
+	if meta == nil {
+		return ""
+	}
+	namespace, _ := meta.Config["namespace"].(string)
+	return namespace
+}
+
+// namespaceDenied writes a 403 response naming the matched rule if err is a
+// namespace policy violation, and reports whether it did.
+func (h *Handler) namespaceDenied(c *gin.Context, err error) bool {
+	var denied *adapter.NamespaceDeniedError
+	if !errors.As(err, &denied) {
+		return false
+	}
+
+	h.logger.Warn("DMS namespace access denied",
+		zap.String("adapter", h.adapterNameFromQuery(c)),
+		zap.String("namespace", denied.Namespace),
+		zap.String("rule", denied.Rule))
+	problem.Write(c, problem.New(http.StatusForbidden, "Forbidden", denied.Error()).
+		With("namespace", denied.Namespace).
+		With("rule", denied.Rule))
+	return true
+}
+
+// invalidValues writes a 400 response listing the schema violations if err
+// reports deployment values rejected by the package's values schema, and
+// reports whether it did.
+func (h *Handler) invalidValues(c *gin.Context, err error) bool {
+	var invalid *adapter.ValuesValidationError
+	if !errors.As(err, &invalid) {
+		return false
+	}
+
+	problem.Write(c, problem.New(http.StatusBadRequest, "BadRequest",
+		"Parameter values do not match the values schema of "+invalid.Package).
+		With("violations", invalid.Violations))
+	return true
+}
+
+// checkValidationRules checks an NF deployment request against the
+// validation rules. When the request violates a rule it writes a 400 response
+// listing the violations and returns false.
+func (h *Handler) checkValidationRules(c *gin.Context, op validation.Operation, id string, req interface{}) bool {
+	if h.rules.Len() == 0 {
+		return true
+	}
+
+	obj, err := validation.NewObject(validation.KindNFDeployment, op, id, req)
+	if err != nil {
+		h.logger.Error("failed to prepare validation", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to validate request")
+		return false
+	}
+
+	violations, err := h.rules.Validate(c.Request.Context(), obj)
+	if err != nil {
+		h.errorResponse(c, http.StatusFailedDependency, "FailedDependency", err.Error())
+		return false
+	}
+	if len(violations) > 0 {
+		problem.Write(c, problem.New(http.StatusBadRequest, "BadRequest", violations[0].Message).
+			With("violations", violations))
+		return false
+	}
+	return true
+}
+
+// authorizeDeployment checks the namespace of an existing deployment against
+// the namespace policy of the adapter. On denial or lookup failure it writes
+// the error response and returns false.
+func (h *Handler) authorizeDeployment(c *gin.Context, adp adapter.DMSAdapter, id string) bool {
+	policy := h.namespacePolicy(c)
+	if policy == nil {
+		return true
+	}
+
+	deployment, err := adp.GetDeployment(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get NF deployment", zap.String("id", id), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to get NF deployment")
+		return false
+	}
+
+	return !h.namespaceDenied(c, policy.Check(deployment.Namespace))
+}
+
 // GetAdapter returns the appropriate DMS adapter for the request.
 // If no adapter name is specified, uses the default adapter.
 // This is exported to satisfy the ireturn linter which flags unexported functions returning interfaces.
 
-// errorResponse sends a standardized error response.
+// errorResponse sends a problem details error response.
 func (h *Handler) errorResponse(c *gin.Context, code int, errType, message string) {
-	c.JSON(code, models.APIError{
-		Error:   errType,
-		Message: message,
-		Code:    code,
-	})
+	problem.Respond(c, code, errType, message)
+}
+
+// respondError sends the error response of a failed adapter or store call.
+// The translator maps sentinel errors, including those the adapter adds, to
+// their status. Not-found errors are reported with notFoundMsg, and errors
+// the translator doesn't know with failMsg. adp is nil for store calls.
+func (h *Handler) respondError(c *gin.Context, adp any, err error, notFoundMsg, failMsg string) {
+	problem := h.translator.For(adp).Describe(err, notFoundMsg, failMsg)
+	h.errorResponse(c, problem.Status, problem.Code, problem.Message)
 }
 
 // handleDelete is a generic delete handler that handles common delete patterns.
 // It calls the delete function and handles errors appropriately.
 func (h *Handler) handleDelete(
 	c *gin.Context,
+	adp adapter.DMSAdapter,
 	paramName string,
 	logMsg string,
 	deleteFn func(context.Context, string) error,
-	notFoundErr error,
 	notFoundMsg string,
 	errorMsg string,
 ) {
@@ -108,11 +374,7 @@ func (h *Handler) handleDelete(
 
 	if err := deleteFn(c.Request.Context(), id); err != nil {
 		h.logger.Error(errorMsg, zap.String("id", id), zap.Error(err))
-		if errors.Is(err, notFoundErr) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", notFoundMsg)
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", errorMsg)
-		}
+		h.respondError(c, adp, err, notFoundMsg, errorMsg)
 		return
 	}
 
@@ -173,14 +435,13 @@ func validateHostNotPrivate(host string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
 	defer cancel()
 
-	resolver := net.Resolver{}
-	ips, err := resolver.LookupIP(ctx, "ip", host)
+	ips, err := resolver.Default().LookupIPAddr(ctx, host)
 	if err != nil {
 		return nil // DNS lookup failed, but we don't block on that
 	}
 
 	for _, ip := range ips {
-		if IsPrivateIP(ip) {
+		if IsPrivateIP(ip.IP) {
 			return errors.New("callback URL cannot point to private IP addresses")
 		}
 	}
@@ -304,6 +565,19 @@ func ValidatePaginationLimit(limit int) int {
 	return limit
 }
 
+// paginateDeployments returns the page of deployments at offset, of at most
+// limit items.
+func paginateDeployments(deployments []*adapter.Deployment, limit, offset int) []*adapter.Deployment {
+	if offset < 0 || offset >= len(deployments) {
+		return []*adapter.Deployment{}
+	}
+	deployments = deployments[offset:]
+	if limit > 0 && len(deployments) > limit {
+		deployments = deployments[:limit]
+	}
+	return deployments
+}
+
 // DNS-1123 validation constants.
 const (
 	// MaxDeploymentNameLength is the maximum length for deployment names (Kubernetes limit).
@@ -358,15 +632,25 @@ func (h *Handler) ListNFDeployments(c *gin.Context) {
 		return
 	}
 
+	policy := h.namespacePolicy(c)
+	if filter.Namespace != "" && h.namespaceDenied(c, policy.Check(filter.Namespace)) {
+		return
+	}
+
 	// Build adapter filter with validated pagination.
+	limit := ValidatePaginationLimit(filter.Limit)
 	adapterFilter := &adapter.Filter{
 		Namespace: filter.Namespace,
-		Limit:     ValidatePaginationLimit(filter.Limit),
+		Limit:     limit,
 		Offset:    filter.Offset,
 	}
 	if filter.Status != "" {
 		adapterFilter.Status = adapter.DeploymentStatus(filter.Status)
 	}
+	if policy != nil {
+		// Denied namespaces are dropped before the page is cut.
+		adapterFilter.Limit, adapterFilter.Offset = 0, 0
+	}
 
 	deployments, err := adp.ListDeployments(c.Request.Context(), adapterFilter)
 	if err != nil {
@@ -374,11 +658,14 @@ func (h *Handler) ListNFDeployments(c *gin.Context) {
 		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to list NF deployments")
 		return
 	}
+	if policy != nil {
+		deployments = paginateDeployments(policy.FilterDeployments(deployments), limit, filter.Offset)
+	}
 
 	// Convert to NF deployments.
 	nfDeployments := make([]*models.NFDeployment, 0, len(deployments))
 	for _, d := range deployments {
-		nfDeployments = append(nfDeployments, ConvertToNFDeployment(d))
+		nfDeployments = append(nfDeployments, h.toNFDeployment(d))
 	}
 
 	c.JSON(http.StatusOK, models.NFDeploymentListResponse{
@@ -402,15 +689,14 @@ func (h *Handler) GetNFDeployment(c *gin.Context) {
 	deployment, err := adp.GetDeployment(c.Request.Context(), nfDeploymentID)
 	if err != nil {
 		h.logger.Error("failed to get NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
-		if errors.Is(err, adapter.ErrDeploymentNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to get NF deployment")
-		}
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to get NF deployment")
+		return
+	}
+	if h.namespaceDenied(c, h.namespacePolicy(c).Check(deployment.Namespace)) {
 		return
 	}
 
-	c.JSON(http.StatusOK, ConvertToNFDeployment(deployment))
+	c.JSON(http.StatusOK, h.toNFDeployment(deployment))
 }
 
 // CreateNFDeployment creates a new NF deployment.
@@ -436,6 +722,31 @@ func (h *Handler) CreateNFDeployment(c *gin.Context) {
 		return
 	}
 
+	if !h.checkValidationRules(c, validation.OperationCreate, "", &req) {
+		return
+	}
+
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = h.defaultNamespace(c)
+	}
+	if h.namespaceDenied(c, h.namespacePolicy(c).Check(namespace)) {
+		return
+	}
+
+	usage, err := ParseDeploymentUsage(req.ParameterValues)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid parameter values: "+err.Error())
+		return
+	}
+
+	// Reserve quota under a provisional key until the adapter assigns the ID.
+	reservationKey := "pending-" + uuid.New().String()
+	undoQuota, ok := h.reserveQuota(c, reservationKey, usage)
+	if !ok {
+		return
+	}
+
 	// Create deployment request.
 	deployReq := &adapter.DeploymentRequest{
 		Name:        req.Name,
@@ -446,18 +757,48 @@ func (h *Handler) CreateNFDeployment(c *gin.Context) {
 		Extensions:  req.Extensions,
 	}
 
+	if h.jobs != nil {
+		tenantID := tenantIDFromContext(c)
+		onDone := func(job *models.DMSJob) {
+			if job.Status != models.DMSJobStatusSucceeded {
+				undoQuota()
+			} else if h.quotas != nil {
+				h.updateQuota(tenantID, job.NFDeploymentID, "rename reservation", func(ctx context.Context) error {
+					return h.quotas.Rename(ctx, tenantID, reservationKey, job.NFDeploymentID)
+				})
+			}
+		}
+		// Adapters that can cancel operations identify deployments by name,
+		// so a running create can be cancelled before the adapter returns.
+		if !h.submitJob(c, models.DMSJobOperationCreate, req.Name, deployReq, onDone) {
+			undoQuota()
+		}
+		return
+	}
+
 	deployment, err := adp.CreateDeployment(c.Request.Context(), deployReq)
 	if err != nil {
+		undoQuota()
+		if h.invalidValues(c, err) {
+			return
+		}
 		h.logger.Error("failed to create NF deployment", zap.Error(err))
 		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to create NF deployment")
 		return
 	}
 
+	if h.quotas != nil {
+		tenantID := tenantIDFromContext(c)
+		h.updateQuota(tenantID, deployment.ID, "rename reservation", func(ctx context.Context) error {
+			return h.quotas.Rename(ctx, tenantID, reservationKey, deployment.ID)
+		})
+	}
+
 	h.logger.Info("NF deployment created",
 		zap.String("nf_deployment_id", deployment.ID),
 		zap.String("name", deployment.Name))
 
-	c.JSON(http.StatusCreated, ConvertToNFDeployment(deployment))
+	c.JSON(http.StatusCreated, h.toNFDeployment(deployment))
 }
 
 // UpdateNFDeployment updates an existing NF deployment.
@@ -478,26 +819,61 @@ func (h *Handler) UpdateNFDeployment(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
+	if !h.checkValidationRules(c, validation.OperationUpdate, nfDeploymentID, &req) {
+		return
+	}
+
+	undoQuota := func() {}
+	if h.quotas != nil && req.ParameterValues != nil {
+		// Values not present in the update keep their previously recorded usage.
+		current, found, ok := h.lookupQuota(c, nfDeploymentID)
+		if !ok {
+			return
+		}
+		if !found {
+			current.Replicas = 1
+		}
+		usage, err := mergeDeploymentUsage(current, req.ParameterValues)
+		if err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid parameter values: "+err.Error())
+			return
+		}
+		if undoQuota, ok = h.reserveQuota(c, nfDeploymentID, usage); !ok {
+			return
+		}
+	}
+
 	update := &adapter.DeploymentUpdate{
 		Values:      req.ParameterValues,
 		Description: req.Description,
 		Extensions:  req.Extensions,
 	}
 
+	if h.jobs != nil {
+		if !h.submitJob(c, models.DMSJobOperationUpdate, nfDeploymentID, update, undoOnFailure(undoQuota)) {
+			undoQuota()
+		}
+		return
+	}
+
 	deployment, err := adp.UpdateDeployment(c.Request.Context(), nfDeploymentID, update)
 	if err != nil {
-		h.logger.Error("failed to update NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
-		if errors.Is(err, adapter.ErrDeploymentNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to update NF deployment")
+		undoQuota()
+		if h.invalidValues(c, err) {
+			return
 		}
+		h.logger.Error("failed to update NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to update NF deployment")
 		return
 	}
 
 	h.logger.Info("NF deployment updated", zap.String("nf_deployment_id", nfDeploymentID))
 
-	c.JSON(http.StatusOK, ConvertToNFDeployment(deployment))
+	c.JSON(http.StatusOK, h.toNFDeployment(deployment))
 }
 
 // DeleteNFDeployment deletes an NF deployment.
@@ -509,12 +885,46 @@ func (h *Handler) DeleteNFDeployment(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeDeployment(c, adp, c.Param("nfDeploymentId")) {
+		return
+	}
+
+	tenantID := tenantIDFromContext(c)
+	if h.jobs != nil {
+		nfDeploymentID := c.Param("nfDeploymentId")
+		h.submitJob(c, models.DMSJobOperationDelete, nfDeploymentID, nil, func(job *models.DMSJob) {
+			if job.Status != models.DMSJobStatusSucceeded {
+				return
+			}
+			if h.quotas != nil {
+				h.updateQuota(tenantID, nfDeploymentID, "release", func(ctx context.Context) error {
+					return h.quotas.Release(ctx, tenantID, nfDeploymentID)
+				})
+			}
+			cost.ForgetNFDeployment(nfDeploymentID)
+		})
+		return
+	}
+
+	deleteFn := func(ctx context.Context, id string) error {
+		if err := adp.DeleteDeployment(ctx, id); err != nil {
+			return err
+		}
+		if h.quotas != nil {
+			h.updateQuota(tenantID, id, "release", func(ctx context.Context) error {
+				return h.quotas.Release(ctx, tenantID, id)
+			})
+		}
+		cost.ForgetNFDeployment(id)
+		return nil
+	}
+
 	h.handleDelete(
 		c,
+		adp,
 		"nfDeploymentId",
 		"deleting NF deployment",
-		adp.DeleteDeployment,
-		adapter.ErrDeploymentNotFound,
+		deleteFn,
 		"NF deployment not found",
 		"failed to delete NF deployment",
 	)
@@ -545,13 +955,33 @@ func (h *Handler) ScaleNFDeployment(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
+	undoQuota := func() {}
+	if h.quotas != nil {
+		usage, _, ok := h.lookupQuota(c, nfDeploymentID)
+		if !ok {
+			return
+		}
+		usage.Replicas = req.Replicas
+		if undoQuota, ok = h.reserveQuota(c, nfDeploymentID, usage); !ok {
+			return
+		}
+	}
+
+	if h.jobs != nil {
+		if !h.submitJob(c, models.DMSJobOperationScale, nfDeploymentID, req, undoOnFailure(undoQuota)) {
+			undoQuota()
+		}
+		return
+	}
+
 	if err := adp.ScaleDeployment(c.Request.Context(), nfDeploymentID, req.Replicas); err != nil {
+		undoQuota()
 		h.logger.Error("failed to scale NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
-		if errors.Is(err, adapter.ErrDeploymentNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to scale NF deployment")
-		}
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to scale NF deployment")
 		return
 	}
 
@@ -589,19 +1019,25 @@ func (h *Handler) RollbackNFDeployment(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
 	// Default to previous revision if not specified.
 	targetRevision := 0
 	if req.TargetRevision != nil {
 		targetRevision = *req.TargetRevision
 	}
 
+	if h.jobs != nil {
+		req.TargetRevision = &targetRevision
+		h.submitJob(c, models.DMSJobOperationRollback, nfDeploymentID, req, nil)
+		return
+	}
+
 	if err := adp.RollbackDeployment(c.Request.Context(), nfDeploymentID, targetRevision); err != nil {
 		h.logger.Error("failed to rollback NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
-		if errors.Is(err, adapter.ErrDeploymentNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to rollback NF deployment")
-		}
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to rollback NF deployment")
 		return
 	}
 
@@ -616,6 +1052,290 @@ func (h *Handler) RollbackNFDeployment(c *gin.Context) {
 	})
 }
 
+// SuspendNFDeployment pauses the reconciliation of an NF deployment, e.g. for
+// a maintenance window. Only adapters advertising CapabilitySuspend support it.
+// POST /o2dms/v1/nfDeployments/:nfDeploymentId/suspend.
+func (h *Handler) SuspendNFDeployment(c *gin.Context) {
+	h.setNFDeploymentSuspended(c, true)
+}
+
+// ResumeNFDeployment resumes the reconciliation of a suspended NF deployment.
+// Only adapters advertising CapabilitySuspend support it.
+// POST /o2dms/v1/nfDeployments/:nfDeploymentId/resume.
+func (h *Handler) ResumeNFDeployment(c *gin.Context) {
+	h.setNFDeploymentSuspended(c, false)
+}
+
+// setNFDeploymentSuspended suspends or resumes the reconciliation of an NF deployment.
+func (h *Handler) setNFDeploymentSuspended(c *gin.Context, suspend bool) {
+	nfDeploymentID := c.Param("nfDeploymentId")
+	action := "resume"
+	if suspend {
+		action = "suspend"
+	}
+	h.logger.Info("changing NF deployment reconciliation",
+		zap.String("nf_deployment_id", nfDeploymentID),
+		zap.String("action", action))
+
+	adp, err := h.getAdapterFromQuery(c)
+	if err != nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error())
+		return
+	}
+
+	suspender, ok := adp.(adapter.DeploymentSuspender)
+	if !ok || !adapter.HasCapability(adp, adapter.CapabilitySuspend) {
+		h.errorResponse(c, http.StatusNotImplemented, "NotImplemented",
+			"Suspend and resume not supported by this adapter")
+		return
+	}
+
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
+	if suspend {
+		err = suspender.SuspendDeployment(c.Request.Context(), nfDeploymentID)
+	} else {
+		err = suspender.ResumeDeployment(c.Request.Context(), nfDeploymentID)
+	}
+	if err != nil {
+		h.logger.Error("failed to "+action+" NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to "+action+" NF deployment")
+		return
+	}
+
+	message := "Reconciliation resumed"
+	if suspend {
+		message = "Reconciliation suspended"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":        message,
+		"nfDeploymentId": nfDeploymentID,
+		"suspended":      suspend,
+	})
+}
+
+// GetNFDeploymentSyncPolicy returns the sync policy of an NF deployment. Only
+// adapters advertising CapabilitySyncPolicy support it.
+// GET /o2dms/v1/nfDeployments/:nfDeploymentId/syncPolicy.
+func (h *Handler) GetNFDeploymentSyncPolicy(c *gin.Context) {
+	nfDeploymentID := c.Param("nfDeploymentId")
+
+	manager, ok := h.syncPolicyManager(c, nfDeploymentID)
+	if !ok {
+		return
+	}
+
+	policy, err := manager.GetSyncPolicy(c.Request.Context(), nfDeploymentID)
+	if err != nil {
+		h.syncPolicyError(c, manager, nfDeploymentID, "get", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdateNFDeploymentSyncPolicy changes the sync policy of an NF deployment,
+// e.g. to switch it to manual sync during a change freeze. Fields omitted from
+// the request keep their current value. Only adapters advertising
+// CapabilitySyncPolicy support it.
+// PATCH /o2dms/v1/nfDeployments/:nfDeploymentId/syncPolicy.
+func (h *Handler) UpdateNFDeploymentSyncPolicy(c *gin.Context) {
+	nfDeploymentID := c.Param("nfDeploymentId")
+
+	var req models.UpdateSyncPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+
+	manager, ok := h.syncPolicyManager(c, nfDeploymentID)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	policy, err := manager.GetSyncPolicy(ctx, nfDeploymentID)
+	if err != nil {
+		h.syncPolicyError(c, manager, nfDeploymentID, "get", err)
+		return
+	}
+	if req.Automated != nil {
+		policy.Automated = *req.Automated
+	}
+	if req.Prune != nil {
+		policy.Prune = *req.Prune
+	}
+	if req.SelfHeal != nil {
+		policy.SelfHeal = *req.SelfHeal
+	}
+
+	updated, err := manager.SetSyncPolicy(ctx, nfDeploymentID, policy)
+	if err != nil {
+		h.syncPolicyError(c, manager, nfDeploymentID, "update", err)
+		return
+	}
+
+	h.logger.Info("NF deployment sync policy updated",
+		zap.String("nf_deployment_id", nfDeploymentID),
+		zap.Bool("automated", updated.Automated),
+		zap.Bool("prune", updated.Prune),
+		zap.Bool("self_heal", updated.SelfHeal))
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// syncPolicyManager resolves the adapter managing the sync policy of an NF
+// deployment, writing the error response if there is none or the caller may
+// not access the deployment.
+func (h *Handler) syncPolicyManager(c *gin.Context, nfDeploymentID string) (adapter.SyncPolicyManager, bool) {
+	adp, err := h.getAdapterFromQuery(c)
+	if err != nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error())
+		return nil, false
+	}
+
+	manager, ok := adp.(adapter.SyncPolicyManager)
+	if !ok || !adapter.HasCapability(adp, adapter.CapabilitySyncPolicy) {
+		h.errorResponse(c, http.StatusNotImplemented, "NotImplemented",
+			"Sync policy management not supported by this adapter")
+		return nil, false
+	}
+
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return nil, false
+	}
+	return manager, true
+}
+
+// syncPolicyError writes the error response of a failed sync policy operation.
+func (h *Handler) syncPolicyError(
+	c *gin.Context, manager adapter.SyncPolicyManager, nfDeploymentID, action string, err error,
+) {
+	h.logger.Error("failed to "+action+" NF deployment sync policy", zap.String("id", nfDeploymentID), zap.Error(err))
+	h.respondError(c, manager, err, "NF deployment not found", "Failed to "+action+" NF deployment sync policy")
+}
+
+// ReconcileNFDeployment requests an immediate reconciliation of an NF
+// deployment. With ?wait=true it waits, up to ?timeout (capped by the maximum
+// reconcile wait), for the backend to handle the request. Only GitOps
+// adapters implementing DeploymentReconciler support it.
+// POST /o2dms/v1/nfDeployments/:nfDeploymentId/reconcile.
+func (h *Handler) ReconcileNFDeployment(c *gin.Context) {
+	nfDeploymentID := c.Param("nfDeploymentId")
+
+	opts := &adapter.ReconcileOptions{Timeout: h.maxReconcileWait}
+	if wait := c.Query("wait"); wait != "" {
+		parsed, err := strconv.ParseBool(wait)
+		if err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid wait parameter: "+wait)
+			return
+		}
+		opts.Wait = parsed
+	}
+	if timeout := c.Query("timeout"); timeout != "" {
+		parsed, err := time.ParseDuration(timeout)
+		if err != nil || parsed <= 0 {
+			h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid timeout parameter: "+timeout)
+			return
+		}
+		opts.Timeout = min(parsed, h.maxReconcileWait)
+	}
+
+	h.logger.Info("reconciling NF deployment",
+		zap.String("nf_deployment_id", nfDeploymentID),
+		zap.Bool("wait", opts.Wait))
+
+	adp, err := h.getAdapterFromQuery(c)
+	if err != nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error())
+		return
+	}
+
+	reconciler, ok := adp.(adapter.DeploymentReconciler)
+	if !ok || !adp.SupportsGitOps() {
+		h.errorResponse(c, http.StatusNotImplemented, "NotImplemented",
+			"On-demand reconciliation not supported by this adapter")
+		return
+	}
+
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
+	result, err := reconciler.ReconcileDeployment(c.Request.Context(), nfDeploymentID, opts)
+	if err != nil {
+		h.logger.Error("failed to reconcile NF deployment", zap.String("id", nfDeploymentID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to reconcile NF deployment")
+		return
+	}
+
+	// Accepted until the backend reports the reconciliation handled.
+	resp := gin.H{
+		"nfDeploymentId": nfDeploymentID,
+		"requestedAt":    result.RequestedAt,
+		"completed":      result.Completed,
+	}
+	if !result.Completed {
+		c.JSON(http.StatusAccepted, resp)
+		return
+	}
+	resp["status"] = result.Status
+	resp["message"] = result.Message
+	c.JSON(http.StatusOK, resp)
+}
+
+// CancelOperation aborts an in-progress deployment operation (install, upgrade,
+// rollback, or sync) and responds with its final state. With DMS jobs enabled,
+// operations are the jobs of the mutating NF deployment endpoints and are
+// identified by job ID. Otherwise they are identified by the ID of the NF
+// deployment they act on and only adapters advertising
+// CapabilityOperationCancel support it.
+// DELETE /o2dms/v1/operations/:operationId.
+func (h *Handler) CancelOperation(c *gin.Context) {
+	operationID := c.Param("operationId")
+	h.logger.Info("cancelling DMS operation", zap.String("operation_id", operationID))
+
+	if h.jobs != nil {
+		h.cancelJob(c, operationID)
+		return
+	}
+
+	adp, err := h.getAdapterFromQuery(c)
+	if err != nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error())
+		return
+	}
+
+	canceller, ok := adp.(adapter.OperationCanceller)
+	if !ok || !adapter.HasCapability(adp, adapter.CapabilityOperationCancel) {
+		h.errorResponse(c, http.StatusNotImplemented, "NotImplemented", "Operation cancellation not supported by this adapter")
+		return
+	}
+
+	if !h.authorizeDeployment(c, adp, operationID) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := canceller.CancelOperation(ctx, operationID); err != nil {
+		h.logger.Error("failed to cancel DMS operation", zap.String("id", operationID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to cancel operation")
+		return
+	}
+	h.logger.Info("DMS operation cancelled", zap.String("operation_id", operationID))
+
+	// The cancellation is synchronous: report the state it left the deployment in.
+	status, err := adp.GetDeploymentStatus(ctx, operationID)
+	if err != nil {
+		h.logger.Error("failed to get NF deployment status", zap.String("id", operationID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to get NF deployment status")
+		return
+	}
+	c.JSON(http.StatusOK, convertToStatusResponse(operationID, status))
+}
+
 // GetNFDeploymentStatus retrieves the status of an NF deployment.
 // GET /o2dms/v1/nfDeployments/:nfDeploymentId/status.
 func (h *Handler) GetNFDeploymentStatus(c *gin.Context) {
@@ -628,20 +1348,122 @@ func (h *Handler) GetNFDeploymentStatus(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
 	status, err := adp.GetDeploymentStatus(c.Request.Context(), nfDeploymentID)
 	if err != nil {
 		h.logger.Error("failed to get NF deployment status", zap.String("id", nfDeploymentID), zap.Error(err))
-		if errors.Is(err, adapter.ErrDeploymentNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to get NF deployment status")
-		}
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to get NF deployment status")
 		return
 	}
 
 	c.JSON(http.StatusOK, convertToStatusResponse(nfDeploymentID, status))
 }
 
+// GetNFDeploymentNotes retrieves the rendered release notes (e.g. Helm NOTES.txt)
+// of the current revision of an NF deployment. Only adapters advertising
+// CapabilityReleaseNotes support it.
+// GET /o2dms/v1/nfDeployments/:nfDeploymentId/notes.
+func (h *Handler) GetNFDeploymentNotes(c *gin.Context) {
+	nfDeploymentID := c.Param("nfDeploymentId")
+	h.logger.Info("getting NF deployment notes", zap.String("nf_deployment_id", nfDeploymentID))
+
+	adp, err := h.getAdapterFromQuery(c)
+	if err != nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error())
+		return
+	}
+
+	provider, ok := adp.(adapter.NotesProvider)
+	if !ok || !adapter.HasCapability(adp, adapter.CapabilityReleaseNotes) {
+		h.errorResponse(c, http.StatusNotImplemented, "NotImplemented", "Release notes not supported by this adapter")
+		return
+	}
+
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
+	notes, err := provider.GetDeploymentNotes(c.Request.Context(), nfDeploymentID)
+	if err != nil {
+		h.logger.Error("failed to get NF deployment notes", zap.String("id", nfDeploymentID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to get NF deployment notes")
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.DeploymentNotesResponse{
+		NFDeploymentID: nfDeploymentID,
+		Revision:       notes.Revision,
+		Notes:          notes.Notes,
+	})
+}
+
+// PreviewNFDeployment renders an update of an NF deployment without applying
+// it and returns the rendered manifests with a per-resource diff against the
+// current revision. The request body is that of UpdateNFDeployment. Only
+// adapters advertising CapabilityPreview support it.
+// POST /o2dms/v1/nfDeployments/:nfDeploymentId/preview.
+func (h *Handler) PreviewNFDeployment(c *gin.Context) {
+	nfDeploymentID := c.Param("nfDeploymentId")
+	h.logger.Info("previewing NF deployment update", zap.String("nf_deployment_id", nfDeploymentID))
+
+	adp, err := h.getAdapterFromQuery(c)
+	if err != nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", err.Error())
+		return
+	}
+
+	previewer, ok := adp.(adapter.DeploymentPreviewer)
+	if !ok || !adapter.HasCapability(adp, adapter.CapabilityPreview) {
+		h.errorResponse(c, http.StatusNotImplemented, "NotImplemented", "Update preview not supported by this adapter")
+		return
+	}
+
+	var req models.UpdateNFDeploymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
+	preview, err := previewer.PreviewDeployment(c.Request.Context(), nfDeploymentID, &adapter.DeploymentUpdate{
+		Values:      req.ParameterValues,
+		Description: req.Description,
+		Extensions:  req.Extensions,
+	})
+	if err != nil {
+		if h.invalidValues(c, err) {
+			return
+		}
+		h.logger.Error("failed to preview NF deployment update", zap.String("id", nfDeploymentID), zap.Error(err))
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to preview NF deployment update")
+		return
+	}
+
+	diff := make([]models.ResourceDiff, len(preview.Diff))
+	for i, d := range preview.Diff {
+		diff[i] = models.ResourceDiff{
+			Kind:      d.Kind,
+			Namespace: d.Namespace,
+			Name:      d.Name,
+			Change:    d.Change,
+			Diff:      d.Diff,
+		}
+	}
+
+	c.JSON(http.StatusOK, &models.DeploymentPreviewResponse{
+		NFDeploymentID:  nfDeploymentID,
+		CurrentRevision: preview.CurrentRevision,
+		Manifests:       preview.Manifests,
+		Diff:            diff,
+	})
+}
+
 // GetNFDeploymentHistory retrieves the history of an NF deployment.
 // GET /o2dms/v1/nfDeployments/:nfDeploymentId/history.
 func (h *Handler) GetNFDeploymentHistory(c *gin.Context) {
@@ -654,14 +1476,14 @@ func (h *Handler) GetNFDeploymentHistory(c *gin.Context) {
 		return
 	}
 
+	if !h.authorizeDeployment(c, adp, nfDeploymentID) {
+		return
+	}
+
 	history, err := adp.GetDeploymentHistory(c.Request.Context(), nfDeploymentID)
 	if err != nil {
 		h.logger.Error("failed to get NF deployment history", zap.String("id", nfDeploymentID), zap.Error(err))
-		if errors.Is(err, adapter.ErrDeploymentNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to get NF deployment history")
-		}
+		h.respondError(c, adp, err, "NF deployment not found", "Failed to get NF deployment history")
 		return
 	}
 
@@ -726,11 +1548,7 @@ func (h *Handler) GetNFDeploymentDescriptor(c *gin.Context) {
 	pkg, err := adp.GetDeploymentPackage(c.Request.Context(), descriptorID)
 	if err != nil {
 		h.logger.Error("failed to get NF deployment descriptor", zap.String("id", descriptorID), zap.Error(err))
-		if errors.Is(err, adapter.ErrPackageNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "NF deployment descriptor not found")
-		} else {
-			h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to get NF deployment descriptor")
-		}
+		h.respondError(c, adp, err, "NF deployment descriptor not found", "Failed to get NF deployment descriptor")
 		return
 	}
 
@@ -788,10 +1606,10 @@ func (h *Handler) DeleteNFDeploymentDescriptor(c *gin.Context) {
 
 	h.handleDelete(
 		c,
+		adp,
 		"nfDeploymentDescriptorId",
 		"deleting NF deployment descriptor",
 		adp.DeleteDeploymentPackage,
-		adapter.ErrPackageNotFound,
 		"NF deployment descriptor not found",
 		"failed to delete NF deployment descriptor",
 	)
@@ -835,12 +1653,8 @@ func (h *Handler) GetDMSSubscription(c *gin.Context) {
 
 	sub, err := h.store.Get(c.Request.Context(), subscriptionID)
 	if err != nil {
-		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "Subscription not found")
-			return
-		}
 		h.logger.Error("failed to get DMS subscription", zap.Error(err))
-		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to get subscription")
+		h.respondError(c, nil, err, "Subscription not found", "Failed to get subscription")
 		return
 	}
 
@@ -872,13 +1686,19 @@ func (h *Handler) CreateDMSSubscription(c *gin.Context) {
 		return
 	}
 
+	if err := timeutil.ValidateExpiry(req.ExpiresAt, timeutil.Now(), h.maxSubscriptionTTL); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid expiresAt: "+err.Error())
+		return
+	}
+
 	sub := &models.DMSSubscription{
 		SubscriptionID:         uuid.New().String(),
 		Callback:               req.Callback,
 		ConsumerSubscriptionID: req.ConsumerSubscriptionID,
 		Filter:                 req.Filter,
-		CreatedAt:              time.Now(),
-		UpdatedAt:              time.Now(),
+		CreatedAt:              timeutil.Now(),
+		UpdatedAt:              timeutil.Now(),
+		ExpiresAt:              req.ExpiresAt,
 		Extensions:             req.Extensions,
 	}
 
@@ -895,6 +1715,106 @@ func (h *Handler) CreateDMSSubscription(c *gin.Context) {
 	c.JSON(http.StatusCreated, sub)
 }
 
+// UpdateDMSSubscription replaces the callback, filter, expiry and extensions
+// of a DMS subscription. A later expiresAt refreshes the subscription; none
+// removes its expiry.
+// PUT /o2dms/v1/subscriptions/:subscriptionId.
+func (h *Handler) UpdateDMSSubscription(c *gin.Context) {
+	subscriptionID := c.Param("subscriptionId")
+	h.logger.Info("updating DMS subscription", zap.String("subscription_id", subscriptionID))
+
+	if h.store == nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", "Subscription storage not configured")
+		return
+	}
+
+	var req models.CreateDMSSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
+		return
+	}
+
+	if err := ValidateCallbackURL(req.Callback); err != nil {
+		h.logger.Warn("invalid callback URL",
+			zap.String("callback", RedactURL(req.Callback)),
+			zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid callback URL: "+err.Error())
+		return
+	}
+
+	if err := timeutil.ValidateExpiry(req.ExpiresAt, timeutil.Now(), h.maxSubscriptionTTL); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "BadRequest", "Invalid expiresAt: "+err.Error())
+		return
+	}
+
+	sub, err := h.store.Get(c.Request.Context(), subscriptionID)
+	if err != nil {
+		h.logger.Error("failed to get DMS subscription", zap.Error(err))
+		h.respondError(c, nil, err, "Subscription not found", "Failed to get subscription")
+		return
+	}
+
+	sub.Callback = req.Callback
+	sub.ConsumerSubscriptionID = req.ConsumerSubscriptionID
+	sub.Filter = req.Filter
+	sub.ExpiresAt = req.ExpiresAt
+	sub.Extensions = req.Extensions
+	sub.UpdatedAt = timeutil.Now()
+	if err := h.store.Update(c.Request.Context(), sub); err != nil {
+		h.logger.Error("failed to update DMS subscription", zap.Error(err))
+		h.respondError(c, nil, err, "Subscription not found", "Failed to update subscription")
+		return
+	}
+
+	h.logger.Info("DMS subscription updated",
+		zap.String("subscription_id", sub.SubscriptionID),
+		zap.String("callback", RedactURL(sub.Callback)))
+
+	c.JSON(http.StatusOK, sub)
+}
+
+// ReapExpiredSubscriptions deletes the DMS subscriptions that expired at now,
+// with their delivery records. It returns the number of remaining and
+// deleted subscriptions.
+func (h *Handler) ReapExpiredSubscriptions(ctx context.Context, now time.Time) (active, expired int, err error) {
+	if h.store == nil {
+		return 0, 0, nil
+	}
+
+	subs, err := h.store.List(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list DMS subscriptions: %w", err)
+	}
+	for _, sub := range subs {
+		if !sub.Expired(now) {
+			active++
+			continue
+		}
+
+		if err := h.store.Delete(ctx, sub.SubscriptionID); err != nil {
+			// Another replica may have deleted it first.
+			if !errors.Is(err, storage.ErrSubscriptionNotFound) {
+				h.logger.Error("failed to delete expired DMS subscription",
+					zap.String("subscription_id", sub.SubscriptionID), zap.Error(err))
+				active++
+			}
+			continue
+		}
+		if h.deliveries != nil {
+			if err := h.deliveries.DeleteSubscription(ctx, sub.SubscriptionID); err != nil {
+				h.logger.Warn("failed to delete DMS subscription deliveries",
+					zap.String("subscription_id", sub.SubscriptionID), zap.Error(err))
+			}
+		}
+		expired++
+		h.logger.Info("expired DMS subscription deleted",
+			zap.String("subscription_id", sub.SubscriptionID),
+			zap.String("callback", RedactURL(sub.Callback)),
+			zap.Timep("expires_at", sub.ExpiresAt))
+	}
+	return active, expired, nil
+}
+
 // DeleteDMSSubscription deletes a DMS subscription.
 // DELETE /o2dms/v1/subscriptions/:subscriptionId.
 func (h *Handler) DeleteDMSSubscription(c *gin.Context) {
@@ -907,19 +1827,57 @@ func (h *Handler) DeleteDMSSubscription(c *gin.Context) {
 	}
 
 	if err := h.store.Delete(c.Request.Context(), subscriptionID); err != nil {
-		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			h.errorResponse(c, http.StatusNotFound, "NotFound", "Subscription not found")
-			return
-		}
 		h.logger.Error("failed to delete DMS subscription", zap.Error(err))
-		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to delete subscription")
+		h.respondError(c, nil, err, "Subscription not found", "Failed to delete subscription")
 		return
 	}
 
+	if h.deliveries != nil {
+		if err := h.deliveries.DeleteSubscription(c.Request.Context(), subscriptionID); err != nil {
+			h.logger.Warn("failed to delete DMS subscription deliveries",
+				zap.String("subscription_id", subscriptionID), zap.Error(err))
+		}
+	}
+
 	h.logger.Info("DMS subscription deleted", zap.String("subscription_id", subscriptionID))
 	c.Status(http.StatusNoContent)
 }
 
+// ListDMSSubscriptionDeliveries lists the notification deliveries of a DMS
+// subscription, most recent first.
+// GET /o2dms/v1/subscriptions/:subscriptionId/deliveries.
+func (h *Handler) ListDMSSubscriptionDeliveries(c *gin.Context) {
+	subscriptionID := c.Param("subscriptionId")
+	h.logger.Info("listing DMS subscription deliveries", zap.String("subscription_id", subscriptionID))
+
+	if h.store == nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", "Subscription storage not configured")
+		return
+	}
+	if h.deliveries == nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", "DMS notifications not enabled")
+		return
+	}
+
+	if _, err := h.store.Get(c.Request.Context(), subscriptionID); err != nil {
+		h.logger.Error("failed to get DMS subscription", zap.Error(err))
+		h.respondError(c, nil, err, "Subscription not found", "Failed to get subscription")
+		return
+	}
+
+	deliveries, err := h.deliveries.List(c.Request.Context(), subscriptionID)
+	if err != nil {
+		h.logger.Error("failed to list DMS subscription deliveries", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to list deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DMSDeliveryListResponse{
+		Deliveries: deliveries,
+		Total:      len(deliveries),
+	})
+}
+
 // API Info Handlers
 
 // GetDeploymentLifecycleInfo returns O2-DMS deployment lifecycle API information.
@@ -950,6 +1908,8 @@ func (h *Handler) GetDeploymentLifecycleInfo(c *gin.Context) {
 			"/nfDeployments",
 			"/nfDeploymentDescriptors",
 			"/subscriptions",
+			"/operations",
+			"/adapters",
 		},
 	})
 }
@@ -968,6 +1928,9 @@ func (h *Handler) Health(ctx context.Context) error {
 	if err := adp.Health(ctx); err != nil {
 		return fmt.Errorf("DMS adapter health check failed: %w", err)
 	}
+	if err := h.store.Ping(ctx); err != nil {
+		return fmt.Errorf("DMS subscription store health check failed: %w", err)
+	}
 	return nil
 }
 
@@ -987,6 +1950,7 @@ func ConvertToNFDeployment(d *adapter.Deployment) *models.NFDeployment {
 		Status:                   ConvertDeploymentStatus(d.Status),
 		Namespace:                d.Namespace,
 		Version:                  d.Version,
+		Notes:                    d.Notes,
 		CreatedAt:                d.CreatedAt,
 		UpdatedAt:                d.UpdatedAt,
 		Extensions:               d.Extensions,
@@ -1044,7 +2008,7 @@ func convertToStatusResponse(id string, status *adapter.DeploymentStatusDetail)
 			Status:             c.Status,
 			Reason:             c.Reason,
 			Message:            c.Message,
-			LastTransitionTime: c.LastTransitionTime.Format(time.RFC3339),
+			LastTransitionTime: timeutil.Format(c.LastTransitionTime),
 		})
 	}
 
@@ -1054,7 +2018,7 @@ func convertToStatusResponse(id string, status *adapter.DeploymentStatusDetail)
 		StatusMessage:  status.Message,
 		Progress:       status.Progress,
 		Conditions:     conditions,
-		UpdatedAt:      status.UpdatedAt.Format(time.RFC3339),
+		UpdatedAt:      timeutil.Format(status.UpdatedAt),
 	}
 }
 
@@ -1069,7 +2033,7 @@ func convertToHistoryResponse(history *adapter.DeploymentHistory) *models.Deploy
 			Revision:    r.Revision,
 			Status:      ConvertDeploymentStatus(r.Status),
 			Description: r.Description,
-			DeployedAt:  r.DeployedAt.Format(time.RFC3339),
+			DeployedAt:  timeutil.Format(r.DeployedAt),
 		})
 	}
 
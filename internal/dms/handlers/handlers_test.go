@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -14,10 +15,14 @@ import (
 	"github.com/piwi3910/netweave/internal/dms/handlers"
 
 	"github.com/gin-gonic/gin"
+	"github.com/piwi3910/netweave/internal/cost"
 	"github.com/piwi3910/netweave/internal/dms/adapter"
 	"github.com/piwi3910/netweave/internal/dms/models"
 	"github.com/piwi3910/netweave/internal/dms/registry"
 	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/httperror"
+	"github.com/piwi3910/netweave/internal/validation"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -46,6 +51,14 @@ type mockAdapter struct {
 	getDeploymentHistoryErr error
 	getPackageErr           error
 	deleteDeploymentPkgErr  error
+
+	// scaleGate, if set, holds ScaleDeployment until it is closed or the
+	// context is canceled.
+	scaleGate chan struct{}
+
+	// createGate, if set, holds CreateDeployment until it is closed or the
+	// context is canceled.
+	createGate chan struct{}
 }
 
 func newMockAdapter() *mockAdapter {
@@ -127,7 +140,14 @@ func (m *mockAdapter) GetDeployment(_ context.Context, id string) (*adapter.Depl
 	return nil, adapter.ErrDeploymentNotFound
 }
 
-func (m *mockAdapter) CreateDeployment(_ context.Context, req *adapter.DeploymentRequest) (*adapter.Deployment, error) {
+func (m *mockAdapter) CreateDeployment(ctx context.Context, req *adapter.DeploymentRequest) (*adapter.Deployment, error) {
+	if m.createGate != nil {
+		select {
+		case <-m.createGate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 	if m.createDeploymentErr != nil {
 		return nil, m.createDeploymentErr
 	}
@@ -178,7 +198,14 @@ func (m *mockAdapter) DeleteDeployment(_ context.Context, id string) error {
 	return adapter.ErrDeploymentNotFound
 }
 
-func (m *mockAdapter) ScaleDeployment(_ context.Context, _ string, _ int) error {
+func (m *mockAdapter) ScaleDeployment(ctx context.Context, _ string, _ int) error {
+	if m.scaleGate != nil {
+		select {
+		case <-m.scaleGate:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 	return m.scaleDeploymentErr
 }
 
@@ -281,6 +308,13 @@ func setupTestRouter(handler *handlers.Handler) *gin.Engine {
 			nfDeployments.POST("/:nfDeploymentId/rollback", handler.RollbackNFDeployment)
 			nfDeployments.GET("/:nfDeploymentId/status", handler.GetNFDeploymentStatus)
 			nfDeployments.GET("/:nfDeploymentId/history", handler.GetNFDeploymentHistory)
+			nfDeployments.GET("/:nfDeploymentId/notes", handler.GetNFDeploymentNotes)
+			nfDeployments.POST("/:nfDeploymentId/preview", handler.PreviewNFDeployment)
+			nfDeployments.POST("/:nfDeploymentId/suspend", handler.SuspendNFDeployment)
+			nfDeployments.POST("/:nfDeploymentId/resume", handler.ResumeNFDeployment)
+			nfDeployments.POST("/:nfDeploymentId/reconcile", handler.ReconcileNFDeployment)
+			nfDeployments.GET("/:nfDeploymentId/syncPolicy", handler.GetNFDeploymentSyncPolicy)
+			nfDeployments.PATCH("/:nfDeploymentId/syncPolicy", handler.UpdateNFDeploymentSyncPolicy)
 		}
 
 		descriptors := v1.Group("/nfDeploymentDescriptors")
@@ -296,7 +330,19 @@ func setupTestRouter(handler *handlers.Handler) *gin.Engine {
 			subscriptions.GET("", handler.ListDMSSubscriptions)
 			subscriptions.POST("", handler.CreateDMSSubscription)
 			subscriptions.GET("/:subscriptionId", handler.GetDMSSubscription)
+			subscriptions.PUT("/:subscriptionId", handler.UpdateDMSSubscription)
 			subscriptions.DELETE("/:subscriptionId", handler.DeleteDMSSubscription)
+			subscriptions.GET("/:subscriptionId/deliveries", handler.ListDMSSubscriptionDeliveries)
+		}
+
+		v1.DELETE("/operations/:operationId", handler.CancelOperation)
+
+		adapters := v1.Group("/adapters")
+		{
+			adapters.GET("", handler.ListDMSAdapters)
+			adapters.POST("", handler.RegisterDMSAdapter)
+			adapters.GET("/:adapterName", handler.GetDMSAdapter)
+			adapters.DELETE("/:adapterName", handler.UnregisterDMSAdapter)
 		}
 	}
 
@@ -380,6 +426,57 @@ func TestGetNFDeployment(t *testing.T) {
 	assert.Equal(t, "test-deployment", deployment.Name)
 }
 
+func TestGetNFDeployment_EstimatedCost(t *testing.T) {
+	handler, mockAdp := setupTestHandler(t)
+	handler.SetPricing(&cost.Pricing{Currency: "USD", VCPUHour: 0.04, MemoryGBHour: 0.005, GPUHour: 2})
+	router := setupTestRouter(handler)
+
+	mockAdp.deployments = []*adapter.Deployment{
+		{
+			ID:        "dep-priced",
+			Name:      "priced",
+			Namespace: "ran",
+			Status:    adapter.DeploymentStatusDeployed,
+			Values: map[string]interface{}{
+				"replicaCount": 2,
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"cpu": "500m", "memory": "1Gi"},
+					"limits":   map[string]interface{}{"nvidia.com/gpu": 1},
+				},
+			},
+		},
+		{ID: "dep-no-values", Name: "unpriced", Status: adapter.DeploymentStatusDeployed},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/o2dms/v1/nfDeployments/dep-priced", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var deployment models.NFDeployment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deployment))
+	require.NotNil(t, deployment.EstimatedCost)
+	// 2 replicas * (0.5*0.04 + 1*0.005 + 1*2) * 730 = 2956.5
+	assert.Equal(t, &cost.Estimate{MonthlyCost: 2956.5, Currency: "USD", VCPUs: 1, MemoryGB: 2, GPUs: 2},
+		deployment.EstimatedCost)
+	assert.InDelta(t, 2956.5,
+		testutil.ToFloat64(cost.NFDeploymentMonthlyCost.WithLabelValues("dep-priced", "ran", "USD")), 0.001)
+
+	// Deployments without reported values have no estimate.
+	req = httptest.NewRequest(http.MethodGet, "/o2dms/v1/nfDeployments/dep-no-values", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "estimatedCost")
+
+	// Deleting the deployment removes its cost metric.
+	req = httptest.NewRequest(http.MethodDelete, "/o2dms/v1/nfDeployments/dep-priced", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Less(t, w.Code, 300)
+	assert.Zero(t, testutil.CollectAndCount(cost.NFDeploymentMonthlyCost))
+}
+
 func TestGetNFDeployment_NotFound(t *testing.T) {
 	handler, _ := setupTestHandler(t)
 	router := setupTestRouter(handler)
@@ -423,6 +520,97 @@ func TestCreateNFDeployment(t *testing.T) {
 	assert.Equal(t, "pkg-1", deployment.NFDeploymentDescriptorID)
 }
 
+func TestCreateNFDeployment_InvalidValues(t *testing.T) {
+	handler, mockAdp := setupTestHandler(t)
+	router := setupTestRouter(handler)
+	mockAdp.createDeploymentErr = &adapter.ValuesValidationError{
+		Package:    "pkg-1",
+		Violations: []adapter.ValuesViolation{{Path: "/service/port", Message: "got string, want integer"}},
+	}
+
+	body, err := json.Marshal(models.CreateNFDeploymentRequest{
+		Name:                     "new-deployment",
+		NFDeploymentDescriptorID: "pkg-1",
+		ParameterValues:          map[string]interface{}{"service": map[string]interface{}{"port": "http"}},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/o2dms/v1/nfDeployments", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.JSONEq(t, `{
+		"type": "urn:netweave:problem:BadRequest",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "Parameter values do not match the values schema of pkg-1",
+		"instance": "/o2dms/v1/nfDeployments",
+		"additionalAttributes": {"violations": [{"path": "/service/port", "message": "got string, want integer"}]},
+		"error": "BadRequest",
+		"message": "Parameter values do not match the values schema of pkg-1",
+		"code": 400
+	}`, w.Body.String())
+}
+
+func TestNFDeployment_ValidationRules(t *testing.T) {
+	rules := validation.NewEngine(zap.NewNop())
+	require.NoError(t, rules.Register(validation.Registration{
+		Name: "max-replicas",
+		Validator: validation.ValidatorFunc(
+			func(_ context.Context, obj *validation.Object) ([]validation.Violation, error) {
+				if replicas, ok := obj.Lookup("parameterValues.replicas"); ok && replicas.(float64) > 3 {
+					return []validation.Violation{
+						{Field: "parameterValues.replicas", Message: "at most 3 replicas"},
+					}, nil
+				}
+				return nil, nil
+			}),
+		Kinds: []validation.Kind{validation.KindNFDeployment},
+	}))
+
+	handler, mockAdp := setupTestHandler(t)
+	handler.SetValidationRules(rules)
+	router := setupTestRouter(handler)
+	mockAdp.deployments = []*adapter.Deployment{{ID: "dep-1", Name: "test-deployment", Namespace: "default"}}
+
+	send := func(method, path string, req interface{}) *httptest.ResponseRecorder {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		r := httptest.NewRequest(method, path, bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := send(http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+		Name:                     "new-deployment",
+		NFDeploymentDescriptorID: "pkg-1",
+		ParameterValues:          map[string]interface{}{"replicas": 5},
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	var problem struct {
+		AdditionalAttributes struct {
+			Violations []validation.Violation `json:"violations"`
+		} `json:"additionalAttributes"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, []validation.Violation{
+		{Rule: "max-replicas", Field: "parameterValues.replicas", Message: "at most 3 replicas"},
+	}, problem.AdditionalAttributes.Violations)
+
+	w = send(http.MethodPut, "/o2dms/v1/nfDeployments/dep-1", models.UpdateNFDeploymentRequest{
+		ParameterValues: map[string]interface{}{"replicas": 4},
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = send(http.MethodPut, "/o2dms/v1/nfDeployments/dep-1", models.UpdateNFDeploymentRequest{
+		ParameterValues: map[string]interface{}{"replicas": 2},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
 func TestCreateNFDeployment_InvalidRequest(t *testing.T) {
 	handler, _ := setupTestHandler(t)
 	router := setupTestRouter(handler)
@@ -821,6 +1009,65 @@ func TestDeleteDMSSubscription(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, getResp.Code)
 }
 
+func TestDMSSubscription_Expiry(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+	handler.SetMaxSubscriptionTTL(48 * time.Hour)
+	router := setupTestRouter(handler)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	send := func(method, path string, req models.CreateDMSSubscriptionRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		httpReq := httptest.NewRequest(method, path, bytes.NewReader(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httpReq)
+		return w
+	}
+	expiresAt := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	w := send(http.MethodPost, "/o2dms/v1/subscriptions",
+		models.CreateDMSSubscriptionRequest{Callback: testCallbackURL, ExpiresAt: expiresAt(-time.Minute)})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = send(http.MethodPost, "/o2dms/v1/subscriptions",
+		models.CreateDMSSubscriptionRequest{Callback: testCallbackURL, ExpiresAt: expiresAt(72 * time.Hour)})
+	assert.Equal(t, http.StatusBadRequest, w.Code, "beyond the maximum TTL")
+
+	w = send(http.MethodPost, "/o2dms/v1/subscriptions",
+		models.CreateDMSSubscriptionRequest{Callback: testCallbackURL, ExpiresAt: expiresAt(time.Hour)})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var expiring models.DMSSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &expiring))
+	w = send(http.MethodPost, "/o2dms/v1/subscriptions",
+		models.CreateDMSSubscriptionRequest{Callback: testCallbackURL, ExpiresAt: expiresAt(time.Hour)})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var refreshed models.DMSSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+
+	w = send(http.MethodPut, "/o2dms/v1/subscriptions/"+refreshed.SubscriptionID,
+		models.CreateDMSSubscriptionRequest{Callback: testCallbackURL, ExpiresAt: expiresAt(24 * time.Hour)})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+	assert.True(t, refreshed.ExpiresAt.Equal(now.Add(24*time.Hour)))
+
+	w = send(http.MethodPut, "/o2dms/v1/subscriptions/nonexistent",
+		models.CreateDMSSubscriptionRequest{Callback: testCallbackURL})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	active, expired, err := handler.ReapExpiredSubscriptions(context.Background(), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 1, expired)
+
+	getReq := httptest.NewRequest(http.MethodGet, "/o2dms/v1/subscriptions/"+expiring.SubscriptionID, nil)
+	getResp := httptest.NewRecorder()
+	router.ServeHTTP(getResp, getReq)
+	assert.Equal(t, http.StatusNotFound, getResp.Code)
+}
+
 func TestGetDMSSubscription_NotFound(t *testing.T) {
 	handler, _ := setupTestHandler(t)
 	router := setupTestRouter(handler)
@@ -833,6 +1080,73 @@ func TestGetDMSSubscription_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestListDMSSubscriptionDeliveries(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+	router := setupTestRouter(handler)
+
+	get := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/o2dms/v1/subscriptions/"+id+"/deliveries", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body, err := json.Marshal(models.CreateDMSSubscriptionRequest{Callback: testCallbackURL})
+	require.NoError(t, err)
+	createReq := httptest.NewRequest(http.MethodPost, "/o2dms/v1/subscriptions", bytes.NewReader(body))
+	createReq.Header.Set("Content-Type", "application/json")
+	createResp := httptest.NewRecorder()
+	router.ServeHTTP(createResp, createReq)
+	require.Equal(t, http.StatusCreated, createResp.Code)
+	var created models.DMSSubscription
+	require.NoError(t, json.Unmarshal(createResp.Body.Bytes(), &created))
+
+	t.Run("notifications disabled", func(t *testing.T) {
+		assert.Equal(t, http.StatusServiceUnavailable, get(created.SubscriptionID).Code)
+	})
+
+	deliveries := storage.NewMemoryDeliveryStore(10)
+	handler.SetDeliveryStore(deliveries)
+	ctx := context.Background()
+	statuses := []models.DMSDeliveryStatus{models.DMSDeliveryStatusFailed, models.DMSDeliveryStatusDelivered}
+	for _, status := range statuses {
+		require.NoError(t, deliveries.Record(ctx, &models.DMSDelivery{
+			DeliveryID:     string(status),
+			SubscriptionID: created.SubscriptionID,
+			EventType:      models.DMSEventTypeDeploymentCreated,
+			NFDeploymentID: "dep-1",
+			Status:         status,
+			Attempts:       1,
+		}))
+	}
+
+	t.Run("most recent first", func(t *testing.T) {
+		w := get(created.SubscriptionID)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp models.DMSDeliveryListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, 2, resp.Total)
+		assert.Equal(t, models.DMSDeliveryStatusDelivered, resp.Deliveries[0].Status)
+		assert.Equal(t, models.DMSDeliveryStatusFailed, resp.Deliveries[1].Status)
+	})
+
+	t.Run("unknown subscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("nonexistent").Code)
+	})
+
+	t.Run("deleted with the subscription", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/o2dms/v1/subscriptions/"+created.SubscriptionID, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		remaining, err := deliveries.List(ctx, created.SubscriptionID)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+}
+
 // Deployment Lifecycle Info Test
 
 func TestGetDeploymentLifecycleInfo(t *testing.T) {
@@ -1009,7 +1323,7 @@ func TestHandler_ScaleDeploymentError(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
 }
 
 func TestHandler_RollbackDeploymentError(t *testing.T) {
@@ -1031,7 +1345,7 @@ func TestHandler_RollbackDeploymentError(t *testing.T) {
 
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
 }
 
 func TestHandler_GetNFDeploymentStatus_NotFound(t *testing.T) {
@@ -1102,6 +1416,22 @@ func TestHandler_DeleteNFDeploymentDescriptor_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestHandler_DeleteNFDeploymentDescriptor_InUse(t *testing.T) {
+	handler, mockAdp := setupTestHandler(t)
+	router := setupTestRouter(handler)
+
+	mockAdp.deleteDeploymentPkgErr = fmt.Errorf("%w: HelmRepository bitnami is referenced by %s",
+		adapter.ErrPackageInUse, "HelmRelease flux-system/nginx")
+
+	req := httptest.NewRequest(http.MethodDelete, "/o2dms/v1/nfDeploymentDescriptors/pkg-1", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "HelmRelease flux-system/nginx")
+}
+
 func TestHandler_DeleteDMSSubscription_NotFound(t *testing.T) {
 	handler, _ := setupTestHandler(t)
 	router := setupTestRouter(handler)
@@ -1198,6 +1528,585 @@ func TestHandler_RollbackNotSupported(t *testing.T) {
 	assert.Equal(t, http.StatusNotImplemented, w.Code)
 }
 
+// Mock adapter that supports operation cancellation
+
+type cancellableAdapter struct {
+	*mockAdapter
+	cancelled []string
+	cancelErr error
+}
+
+func (m *cancellableAdapter) CancelOperation(_ context.Context, id string) error {
+	if m.cancelErr != nil {
+		return m.cancelErr
+	}
+	m.cancelled = append(m.cancelled, id)
+	return nil
+}
+
+func TestHandler_CancelOperation(t *testing.T) {
+	tests := []struct {
+		name       string
+		cancelErr  error
+		capable    bool
+		wantStatus int
+	}{
+		{name: "cancelled", capable: true, wantStatus: http.StatusOK},
+		{
+			name:       "deployment not found",
+			capable:    true,
+			cancelErr:  fmt.Errorf("%w: dep-1", adapter.ErrDeploymentNotFound),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "nothing in progress",
+			capable:    true,
+			cancelErr:  fmt.Errorf("%w: dep-1", adapter.ErrNoOperationInProgress),
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "adapter failure",
+			capable:    true,
+			cancelErr:  errors.New("backend unavailable"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{name: "capability not advertised", capable: false, wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &cancellableAdapter{mockAdapter: newMockAdapter(), cancelErr: tt.cancelErr}
+			adp.deployments = append(adp.deployments, &adapter.Deployment{
+				ID: "dep-1", Status: adapter.DeploymentStatusFailed, UpdatedAt: time.Now(),
+			})
+			if tt.capable {
+				adp.capabilities = append(adp.capabilities, adapter.CapabilityOperationCancel)
+			}
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "cancellable", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			req := httptest.NewRequest(http.MethodDelete, "/o2dms/v1/operations/dep-1", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, []string{"dep-1"}, adp.cancelled)
+				assert.Contains(t, w.Body.String(), `"status":"failed"`)
+			}
+		})
+	}
+}
+
+func TestHandler_CancelOperationNotSupported(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+	router := setupTestRouter(handler)
+
+	req := httptest.NewRequest(http.MethodDelete, "/o2dms/v1/operations/dep-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
+
+// Mock adapter that suspends reconciliation
+
+type suspendableAdapter struct {
+	*mockAdapter
+	suspended  map[string]bool
+	suspendErr error
+}
+
+func (m *suspendableAdapter) SuspendDeployment(_ context.Context, id string) error {
+	if m.suspendErr != nil {
+		return m.suspendErr
+	}
+	m.suspended[id] = true
+	return nil
+}
+
+func (m *suspendableAdapter) ResumeDeployment(_ context.Context, id string) error {
+	if m.suspendErr != nil {
+		return m.suspendErr
+	}
+	m.suspended[id] = false
+	return nil
+}
+
+func TestHandler_SuspendResumeNFDeployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     string
+		suspendErr error
+		capable    bool
+		wantStatus int
+	}{
+		{name: "suspended", action: "suspend", capable: true, wantStatus: http.StatusOK},
+		{name: "resumed", action: "resume", capable: true, wantStatus: http.StatusOK},
+		{
+			name:       "deployment not found",
+			action:     "suspend",
+			capable:    true,
+			suspendErr: fmt.Errorf("%w: dep-1", adapter.ErrDeploymentNotFound),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "adapter failure",
+			action:     "resume",
+			capable:    true,
+			suspendErr: errors.New("backend unavailable"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{name: "capability not advertised", action: "suspend", capable: false, wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &suspendableAdapter{
+				mockAdapter: newMockAdapter(),
+				suspended:   make(map[string]bool),
+				suspendErr:  tt.suspendErr,
+			}
+			if tt.capable {
+				adp.capabilities = append(adp.capabilities, adapter.CapabilitySuspend)
+			}
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "suspendable", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			req := httptest.NewRequest(http.MethodPost, "/o2dms/v1/nfDeployments/dep-1/"+tt.action, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				wantSuspended := tt.action == "suspend"
+				assert.Equal(t, map[string]bool{"dep-1": wantSuspended}, adp.suspended)
+
+				var resp map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, wantSuspended, resp["suspended"])
+			}
+		})
+	}
+}
+
+// Mock adapter that reconciles deployments on demand
+
+type reconcilingAdapter struct {
+	*mockAdapter
+	gitOps       bool
+	completed    bool
+	reconcileErr error
+	gotOpts      *adapter.ReconcileOptions
+}
+
+func (m *reconcilingAdapter) SupportsGitOps() bool { return m.gitOps }
+
+func (m *reconcilingAdapter) ReconcileDeployment(
+	_ context.Context, _ string, opts *adapter.ReconcileOptions,
+) (*adapter.ReconcileResult, error) {
+	m.gotOpts = opts
+	if m.reconcileErr != nil {
+		return nil, m.reconcileErr
+	}
+	result := &adapter.ReconcileResult{RequestedAt: time.Now()}
+	if opts.Wait && m.completed {
+		result.Completed = true
+		result.Status = adapter.DeploymentStatusDeployed
+		result.Message = "ReconciliationSucceeded: applied revision main@sha1:abc"
+	}
+	return result, nil
+}
+
+func TestHandler_ReconcileNFDeployment(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		gitOps       bool
+		completed    bool
+		reconcileErr error
+		wantStatus   int
+		wantTimeout  time.Duration
+	}{
+		{name: "requested", gitOps: true, wantStatus: http.StatusAccepted},
+		{
+			name:        "waited until ready",
+			query:       "?wait=true&timeout=5s",
+			gitOps:      true,
+			completed:   true,
+			wantStatus:  http.StatusOK,
+			wantTimeout: 5 * time.Second,
+		},
+		{
+			name:        "wait timed out",
+			query:       "?wait=true",
+			gitOps:      true,
+			wantStatus:  http.StatusAccepted,
+			wantTimeout: handlers.DefaultMaxReconcileWait,
+		},
+		{
+			name:        "timeout capped",
+			query:       "?wait=true&timeout=1h",
+			gitOps:      true,
+			completed:   true,
+			wantStatus:  http.StatusOK,
+			wantTimeout: handlers.DefaultMaxReconcileWait,
+		},
+		{name: "invalid wait", query: "?wait=maybe", gitOps: true, wantStatus: http.StatusBadRequest},
+		{name: "invalid timeout", query: "?wait=true&timeout=-1s", gitOps: true, wantStatus: http.StatusBadRequest},
+		{
+			name:         "deployment not found",
+			gitOps:       true,
+			reconcileErr: fmt.Errorf("%w: dep-1", adapter.ErrDeploymentNotFound),
+			wantStatus:   http.StatusNotFound,
+		},
+		{
+			name:         "deployment suspended",
+			gitOps:       true,
+			reconcileErr: fmt.Errorf("%w: dep-1", adapter.ErrDeploymentSuspended),
+			wantStatus:   http.StatusConflict,
+		},
+		{name: "not a GitOps adapter", gitOps: false, wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &reconcilingAdapter{
+				mockAdapter:  newMockAdapter(),
+				gitOps:       tt.gitOps,
+				completed:    tt.completed,
+				reconcileErr: tt.reconcileErr,
+			}
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "gitops", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			req := httptest.NewRequest(http.MethodPost, "/o2dms/v1/nfDeployments/dep-1/reconcile"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantTimeout != 0 {
+				require.NotNil(t, adp.gotOpts)
+				assert.True(t, adp.gotOpts.Wait)
+				assert.Equal(t, tt.wantTimeout, adp.gotOpts.Timeout)
+			}
+			if w.Code == http.StatusOK || w.Code == http.StatusAccepted {
+				var resp map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "dep-1", resp["nfDeploymentId"])
+				assert.Equal(t, tt.completed, resp["completed"])
+				if tt.completed {
+					assert.Equal(t, string(adapter.DeploymentStatusDeployed), resp["status"])
+				}
+			}
+		})
+	}
+}
+
+// Mock adapter that manages sync policies
+
+type syncPolicyAdapter struct {
+	*mockAdapter
+	policies map[string]*adapter.SyncPolicy
+	setErr   error
+}
+
+func (m *syncPolicyAdapter) GetSyncPolicy(_ context.Context, id string) (*adapter.SyncPolicy, error) {
+	policy, ok := m.policies[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", adapter.ErrDeploymentNotFound, id)
+	}
+	copied := *policy
+	return &copied, nil
+}
+
+func (m *syncPolicyAdapter) SetSyncPolicy(
+	_ context.Context, id string, policy *adapter.SyncPolicy,
+) (*adapter.SyncPolicy, error) {
+	if m.setErr != nil {
+		return nil, m.setErr
+	}
+	m.policies[id] = policy
+	return policy, nil
+}
+
+func TestHandler_NFDeploymentSyncPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		id         string
+		body       string
+		capable    bool
+		setErr     error
+		wantStatus int
+		want       *adapter.SyncPolicy
+	}{
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			id:         "dep-1",
+			capable:    true,
+			wantStatus: http.StatusOK,
+			want:       &adapter.SyncPolicy{Automated: true, Prune: true, SelfHeal: true},
+		},
+		{
+			name:       "switch to manual keeps omitted fields",
+			method:     http.MethodPatch,
+			id:         "dep-1",
+			body:       `{"automated": false}`,
+			capable:    true,
+			wantStatus: http.StatusOK,
+			want:       &adapter.SyncPolicy{Automated: false, Prune: true, SelfHeal: true},
+		},
+		{
+			name:       "change self-heal",
+			method:     http.MethodPatch,
+			id:         "dep-1",
+			body:       `{"selfHeal": false}`,
+			capable:    true,
+			wantStatus: http.StatusOK,
+			want:       &adapter.SyncPolicy{Automated: true, Prune: true, SelfHeal: false},
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPatch,
+			id:         "dep-1",
+			body:       `{"automated": "no"}`,
+			capable:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "deployment not found",
+			method:     http.MethodGet,
+			id:         "dep-2",
+			capable:    true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "adapter failure",
+			method:     http.MethodPatch,
+			id:         "dep-1",
+			body:       `{}`,
+			capable:    true,
+			setErr:     errors.New("backend unavailable"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{name: "capability not advertised", method: http.MethodGet, id: "dep-1", wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &syncPolicyAdapter{
+				mockAdapter: newMockAdapter(),
+				policies: map[string]*adapter.SyncPolicy{
+					"dep-1": {Automated: true, Prune: true, SelfHeal: true},
+				},
+				setErr: tt.setErr,
+			}
+			if tt.capable {
+				adp.capabilities = append(adp.capabilities, adapter.CapabilitySyncPolicy)
+			}
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "argocd", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			path := "/o2dms/v1/nfDeployments/" + tt.id + "/syncPolicy"
+			req := httptest.NewRequest(tt.method, path, bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.want != nil {
+				var got adapter.SyncPolicy
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, *tt.want, got)
+				assert.Equal(t, tt.want, adp.policies[tt.id])
+			}
+		})
+	}
+}
+
+// Mock adapter that provides release notes
+
+type notesAdapter struct {
+	*mockAdapter
+	notesErr error
+}
+
+func (m *notesAdapter) GetDeploymentNotes(_ context.Context, id string) (*adapter.DeploymentNotes, error) {
+	if m.notesErr != nil {
+		return nil, m.notesErr
+	}
+	return &adapter.DeploymentNotes{DeploymentID: id, Revision: 3, Notes: "Run kubectl get pods"}, nil
+}
+
+func TestHandler_GetNFDeploymentNotes(t *testing.T) {
+	tests := []struct {
+		name       string
+		notesErr   error
+		capable    bool
+		wantStatus int
+	}{
+		{name: "notes returned", capable: true, wantStatus: http.StatusOK},
+		{
+			name:       "deployment not found",
+			capable:    true,
+			notesErr:   fmt.Errorf("%w: dep-1", adapter.ErrDeploymentNotFound),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "adapter failure",
+			capable:    true,
+			notesErr:   errors.New("backend unavailable"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{name: "capability not advertised", capable: false, wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &notesAdapter{mockAdapter: newMockAdapter(), notesErr: tt.notesErr}
+			if tt.capable {
+				adp.capabilities = append(adp.capabilities, adapter.CapabilityReleaseNotes)
+			}
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "notes", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			req := httptest.NewRequest(http.MethodGet, "/o2dms/v1/nfDeployments/dep-1/notes", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp models.DeploymentNotesResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "dep-1", resp.NFDeploymentID)
+				assert.Equal(t, 3, resp.Revision)
+				assert.Equal(t, "Run kubectl get pods", resp.Notes)
+			}
+		})
+	}
+}
+
+// Mock adapter that previews updates
+
+type previewAdapter struct {
+	*mockAdapter
+	previewErr error
+	update     *adapter.DeploymentUpdate
+}
+
+func (m *previewAdapter) PreviewDeployment(
+	_ context.Context,
+	id string,
+	update *adapter.DeploymentUpdate,
+) (*adapter.DeploymentPreview, error) {
+	m.update = update
+	if m.previewErr != nil {
+		return nil, m.previewErr
+	}
+	return &adapter.DeploymentPreview{
+		DeploymentID:    id,
+		CurrentRevision: 2,
+		Manifests:       "kind: ConfigMap\n",
+		Diff: []adapter.ResourceDiff{
+			{Kind: "ConfigMap", Name: "app-config", Change: adapter.ResourceModified, Diff: "-a\n+b\n"},
+		},
+	}, nil
+}
+
+func TestHandler_PreviewNFDeployment(t *testing.T) {
+	tests := []struct {
+		name       string
+		previewErr error
+		capable    bool
+		wantStatus int
+	}{
+		{name: "preview returned", capable: true, wantStatus: http.StatusOK},
+		{
+			name:       "deployment not found",
+			capable:    true,
+			previewErr: fmt.Errorf("%w: dep-1", adapter.ErrDeploymentNotFound),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:    "invalid values",
+			capable: true,
+			previewErr: &adapter.ValuesValidationError{
+				Package:    "app",
+				Violations: []adapter.ValuesViolation{{Path: "/level", Message: "value must be one of"}},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "adapter failure",
+			capable:    true,
+			previewErr: errors.New("render failed"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{name: "capability not advertised", capable: false, wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &previewAdapter{mockAdapter: newMockAdapter(), previewErr: tt.previewErr}
+			if tt.capable {
+				adp.capabilities = append(adp.capabilities, adapter.CapabilityPreview)
+			}
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "preview", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			body := bytes.NewBufferString(`{"parameterValues": {"level": "debug"}}`)
+			req := httptest.NewRequest(http.MethodPost, "/o2dms/v1/nfDeployments/dep-1/preview", body)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, map[string]interface{}{"level": "debug"}, adp.update.Values)
+
+				var resp models.DeploymentPreviewResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "dep-1", resp.NFDeploymentID)
+				assert.Equal(t, 2, resp.CurrentRevision)
+				require.Len(t, resp.Diff, 1)
+				assert.Equal(t, "modified", resp.Diff[0].Change)
+			}
+		})
+	}
+}
+
 func TestHandler_SubscriptionNoStore(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	logger := zap.NewNop()
@@ -2354,6 +3263,76 @@ func TestRollbackNFDeployment_InternalError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestRollbackNFDeployment_SyncWindowClosed(t *testing.T) {
+	handler, mockAdp := setupTestHandler(t)
+	router := setupTestRouter(handler)
+
+	mockAdp.rollbackErr = fmt.Errorf("%w: any-id", adapter.ErrSyncWindowClosed)
+
+	req := httptest.NewRequest(http.MethodPost, "/o2dms/v1/nfDeployments/any-id/rollback", bytes.NewReader([]byte("{}")))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+// ruleAdapter returns sentinel errors of its own and translates them.
+type ruleAdapter struct {
+	*mockAdapter
+}
+
+var (
+	errRuleAdapterNotFound    = errors.New("release not found")
+	errRuleAdapterUnreachable = errors.New("backend unreachable")
+)
+
+func (m *ruleAdapter) ErrorRules() []httperror.Rule {
+	return []httperror.Rule{
+		{Err: errRuleAdapterNotFound, Status: http.StatusNotFound, Code: httperror.CodeNotFound},
+		{Err: errRuleAdapterUnreachable, Status: http.StatusBadGateway, Code: httperror.CodeBadGateway},
+	}
+}
+
+// TestHandler_AdapterErrorRules tests that adapter errors are translated with the adapter's own rules.
+func TestHandler_AdapterErrorRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"adapter not found", fmt.Errorf("dep-1: %w", errRuleAdapterNotFound), http.StatusNotFound, "NotFound"},
+		{"adapter unreachable", errRuleAdapterUnreachable, http.StatusBadGateway, "BadGateway"},
+		{"shared sentinel", adapter.ErrDeploymentNotFound, http.StatusNotFound, "NotFound"},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError, "InternalError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			logger := zap.NewNop()
+
+			adp := &ruleAdapter{mockAdapter: newMockAdapter()}
+			adp.getDeploymentErr = tt.err
+
+			reg := registry.NewRegistry(logger, nil)
+			require.NoError(t, reg.Register(context.Background(), "rules", "mock", adp, nil, true))
+			router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+
+			req := httptest.NewRequest(http.MethodGet, "/o2dms/v1/nfDeployments/dep-1", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			var resp models.APIError
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error)
+		})
+	}
+}
+
 // TestConvertDeploymentStatus tests deployment status conversion.
 func TestConvertDeploymentStatus(t *testing.T) {
 	tests := []struct {
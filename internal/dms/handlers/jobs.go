@@ -0,0 +1,656 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Job runner defaults.
+const (
+	// DefaultJobWorkers is the number of jobs executed concurrently.
+	DefaultJobWorkers = 4
+
+	// DefaultJobQueueSize is the number of jobs that may wait for a worker.
+	DefaultJobQueueSize = 1000
+
+	// DefaultJobLeaseTimeout is how long a job may go without a heartbeat
+	// before another runner takes it over.
+	DefaultJobLeaseTimeout = 2 * time.Minute
+)
+
+// Progress reported while a job runs. Adapter calls are opaque, so progress
+// only distinguishes waiting, executing and finished jobs.
+const (
+	jobProgressRunning  = 10
+	jobProgressFinished = 100
+)
+
+// jobsProcessed counts the finished DMS jobs by operation and status.
+var jobsProcessed = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Subsystem: "dms",
+		Name:      "jobs_total",
+		Help:      "Total number of finished DMS jobs by operation and status",
+	},
+	[]string{"operation", "status"},
+)
+
+// JobConfig configures the asynchronous execution of mutating NF deployment
+// operations.
+type JobConfig struct {
+	// Workers is the number of jobs executed concurrently.
+	Workers int
+
+	// QueueSize is the number of jobs that may wait for a worker; further
+	// submissions are rejected with 503.
+	QueueSize int
+
+	// LeaseTimeout is how long a pending or running job may go without a
+	// heartbeat before another gateway replica takes it over. Pending jobs
+	// taken over are executed; running jobs are failed, since the adapter
+	// call may or may not have completed.
+	LeaseTimeout time.Duration
+}
+
+// jobRunner executes DMS jobs on a pool of workers and keeps their leases
+// alive in the job store.
+type jobRunner struct {
+	store  storage.JobStore
+	cfg    JobConfig
+	queue  chan *models.DMSJob
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// owned holds the IDs of the jobs queued or running on this runner.
+	owned map[string]struct{}
+	// onDone holds completion callbacks of jobs submitted through this
+	// runner. They are not persisted: jobs taken over from another replica
+	// complete without them.
+	onDone map[string]func(*models.DMSJob)
+
+	// stopping is closed by DrainJobs to stop the workers taking new jobs.
+	stopping chan struct{}
+	stopOnce sync.Once
+	// workers tracks the running job workers.
+	workers sync.WaitGroup
+	// cancelJobs interrupts the jobs in progress; stopLeases stops the lease
+	// keeper. Both are set by StartJobs.
+	cancelJobs context.CancelFunc
+	stopLeases context.CancelFunc
+}
+
+// EnableJobs makes the create, update, delete, scale and rollback NF
+// deployment endpoints asynchronous: they validate the request, persist a
+// job in store and return 202 Accepted with the job and a Location header,
+// while a worker started by StartJobs performs the adapter call.
+func (h *Handler) EnableJobs(store storage.JobStore, cfg JobConfig) {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultJobWorkers
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultJobQueueSize
+	}
+	if cfg.LeaseTimeout <= 0 {
+		cfg.LeaseTimeout = DefaultJobLeaseTimeout
+	}
+	h.jobs = &jobRunner{
+		store:    store,
+		cfg:      cfg,
+		queue:    make(chan *models.DMSJob, cfg.QueueSize),
+		logger:   h.logger.Named("jobs"),
+		owned:    make(map[string]struct{}),
+		onDone:   make(map[string]func(*models.DMSJob)),
+		stopping: make(chan struct{}),
+	}
+}
+
+// StartJobs runs the job workers and the lease keeper until ctx is canceled.
+// It is a no-op unless EnableJobs was called.
+func (h *Handler) StartJobs(ctx context.Context) {
+	r := h.jobs
+	if r == nil {
+		return
+	}
+
+	// Jobs run with their own context, so that DrainJobs can let them
+	// finish after ctx is canceled. Canceling ctx still interrupts them.
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	leaseCtx, stopLeases := context.WithCancel(ctx)
+	context.AfterFunc(ctx, cancelJobs)
+
+	r.mu.Lock()
+	r.cancelJobs, r.stopLeases = cancelJobs, stopLeases
+	r.mu.Unlock()
+
+	for range r.cfg.Workers {
+		r.workers.Add(1)
+		go h.jobWorker(ctx, jobCtx)
+	}
+	go h.keepJobLeases(leaseCtx)
+}
+
+// jobWorker executes queued jobs with jobCtx until ctx is canceled or the
+// runner is drained.
+func (h *Handler) jobWorker(ctx, jobCtx context.Context) {
+	defer h.jobs.workers.Done()
+	for {
+		// Check stopping first: select picks at random among ready cases,
+		// and a queued job must not start once the drain began.
+		select {
+		case <-h.jobs.stopping:
+			return
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.jobs.stopping:
+			return
+		case job := <-h.jobs.queue:
+			h.runJob(jobCtx, job)
+		}
+	}
+}
+
+// DrainJobs stops the job workers taking queued jobs and waits for the jobs
+// in progress to finish. If ctx expires first, the running jobs are
+// interrupted and fail, and ctx's error is returned. Jobs still queued stay
+// pending in the job store and are handed over: their lease is expired, so
+// another gateway replica, or this one after a restart, executes them.
+// Completion callbacks of handed over jobs do not run.
+func (h *Handler) DrainJobs(ctx context.Context) error {
+	r := h.jobs
+	if r == nil {
+		return nil
+	}
+	r.stopOnce.Do(func() { close(r.stopping) })
+
+	r.mu.Lock()
+	cancelJobs, stopLeases := r.cancelJobs, r.stopLeases
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.workers.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if cancelJobs != nil {
+			cancelJobs()
+		}
+		<-done
+		err = fmt.Errorf("DMS jobs interrupted: %w", ctx.Err())
+	}
+	if stopLeases != nil {
+		stopLeases()
+	}
+
+	h.handOverQueuedJobs(context.WithoutCancel(ctx))
+	return err
+}
+
+// handOverQueuedJobs empties the queue and expires the leases of the jobs it
+// held, so that other runners claim them right away.
+func (h *Handler) handOverQueuedJobs(ctx context.Context) {
+	r := h.jobs
+	var ids []string
+queued:
+	for {
+		select {
+		case job := <-r.queue:
+			r.release(job.JobID)
+			ids = append(ids, job.JobID)
+		default:
+			break queued
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// A heartbeat at the zero time makes the jobs stale immediately.
+	if err := r.store.Heartbeat(ctx, ids, time.Time{}); err != nil {
+		r.logger.Warn("failed to hand over queued DMS jobs", zap.Int("jobs", len(ids)), zap.Error(err))
+		return
+	}
+	r.logger.Info("handed over queued DMS jobs", zap.Int("jobs", len(ids)))
+}
+
+// keepJobLeases renews the leases of the jobs owned by this runner and takes
+// over jobs whose runner stopped renewing them, for example because its
+// gateway replica was restarted.
+func (h *Handler) keepJobLeases(ctx context.Context) {
+	r := h.jobs
+	ticker := time.NewTicker(r.cfg.LeaseTimeout / 4)
+	defer ticker.Stop()
+
+	for {
+		h.recoverStaleJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.Lock()
+		ids := make([]string, 0, len(r.owned))
+		for id := range r.owned {
+			ids = append(ids, id)
+		}
+		r.mu.Unlock()
+		if err := r.store.Heartbeat(ctx, ids, timeutil.Now()); err != nil {
+			r.logger.Warn("failed to renew DMS job leases", zap.Error(err))
+		}
+	}
+}
+
+// recoverStaleJobs takes over the jobs whose lease expired. Pending jobs are
+// queued again; running jobs are failed because their adapter call was
+// interrupted in an unknown state.
+func (h *Handler) recoverStaleJobs(ctx context.Context) {
+	r := h.jobs
+	stale, err := r.store.ClaimStale(ctx, timeutil.Now().Add(-r.cfg.LeaseTimeout))
+	if err != nil {
+		r.logger.Warn("failed to claim stale DMS jobs", zap.Error(err))
+	}
+
+	for _, job := range stale {
+		if job.Status == models.DMSJobStatusRunning {
+			r.logger.Warn("failing interrupted DMS job",
+				zap.String("job_id", job.JobID), zap.String("operation", string(job.Operation)))
+			h.finishJob(ctx, job, nil,
+				errors.New("interrupted: the gateway running the job stopped before it completed"))
+			continue
+		}
+
+		r.logger.Info("resuming pending DMS job", zap.String("job_id", job.JobID))
+		if err := r.store.Update(ctx, job); err != nil {
+			r.logger.Warn("failed to resume DMS job", zap.String("job_id", job.JobID), zap.Error(err))
+			continue
+		}
+		if !r.enqueue(job, nil) {
+			r.logger.Warn("DMS job queue is full; leaving job for another replica",
+				zap.String("job_id", job.JobID))
+		}
+	}
+}
+
+// enqueue hands a job to the workers and records its completion callback.
+// It returns false if the queue is full.
+func (r *jobRunner) enqueue(job *models.DMSJob, onDone func(*models.DMSJob)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Register the job first: a worker may finish it as soon as it is queued.
+	r.owned[job.JobID] = struct{}{}
+	if onDone != nil {
+		r.onDone[job.JobID] = onDone
+	}
+	select {
+	case r.queue <- job:
+		return true
+	default:
+		delete(r.owned, job.JobID)
+		delete(r.onDone, job.JobID)
+		return false
+	}
+}
+
+// release forgets a finished job and returns its completion callback.
+func (r *jobRunner) release(jobID string) func(*models.DMSJob) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	onDone := r.onDone[jobID]
+	delete(r.onDone, jobID)
+	delete(r.owned, jobID)
+	return onDone
+}
+
+// submitJob persists a job for the operation of the request and queues it.
+// request is the adapter request the worker executes and onDone, if not
+// nil, is called with the finished job. On success it writes the 202 response;
+// otherwise it writes an error response and returns false.
+func (h *Handler) submitJob(
+	c *gin.Context,
+	operation models.DMSJobOperation,
+	nfDeploymentID string,
+	request any,
+	onDone func(*models.DMSJob),
+) bool {
+	data, err := json.Marshal(request)
+	if err != nil {
+		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to encode job request")
+		return false
+	}
+
+	job := &models.DMSJob{
+		JobID:          uuid.New().String(),
+		Operation:      operation,
+		Status:         models.DMSJobStatusPending,
+		Message:        "Waiting for a worker",
+		Adapter:        h.adapterNameFromQuery(c),
+		NFDeploymentID: nfDeploymentID,
+		TenantID:       tenantIDFromContext(c),
+		Request:        data,
+		CreatedAt:      timeutil.Now(),
+	}
+
+	ctx := c.Request.Context()
+	if err := h.jobs.store.Create(ctx, job); err != nil {
+		h.logger.Error("failed to store DMS job", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to create job")
+		return false
+	}
+
+	// The worker owns job once it is queued; respond with the submitted state.
+	accepted := *job
+	if !h.jobs.enqueue(job, onDone) {
+		completed := timeutil.Now()
+		job.Status, job.Error, job.CompletedAt = models.DMSJobStatusFailed, "job queue is full", &completed
+		if err := h.jobs.store.Update(ctx, job); err != nil {
+			h.logger.Warn("failed to store rejected DMS job", zap.String("job_id", job.JobID), zap.Error(err))
+		}
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", "DMS job queue is full")
+		return false
+	}
+
+	h.logger.Info("DMS job submitted",
+		zap.String("job_id", accepted.JobID),
+		zap.String("operation", string(operation)),
+		zap.String("nf_deployment_id", nfDeploymentID))
+
+	c.Header("Location", jobLocation(c, accepted.JobID))
+	c.JSON(http.StatusAccepted, &accepted)
+	return true
+}
+
+// undoOnFailure returns a job completion callback running undo unless the
+// job succeeded.
+func undoOnFailure(undo func()) func(*models.DMSJob) {
+	return func(job *models.DMSJob) {
+		if job.Status != models.DMSJobStatusSucceeded {
+			undo()
+		}
+	}
+}
+
+// jobLocation returns the URL of a job under the API version of the request.
+func jobLocation(c *gin.Context, jobID string) string {
+	base := "/o2dms/v1"
+	if i := strings.Index(c.Request.URL.Path, "/nfDeployments"); i > 0 {
+		base = c.Request.URL.Path[:i]
+	}
+	return base + "/jobs/" + jobID
+}
+
+// runJob executes a job and records its outcome. The job is started only if
+// it is still pending, so jobs cancelled while they were queued are skipped.
+func (h *Handler) runJob(ctx context.Context, job *models.DMSJob) {
+	started := timeutil.Now()
+	job.Status = models.DMSJobStatusRunning
+	job.Progress = jobProgressRunning
+	job.Message = fmt.Sprintf("Executing %s on adapter %s", job.Operation, job.Adapter)
+	job.StartedAt = &started
+	err := h.jobs.store.Transition(ctx, job, models.DMSJobStatusPending)
+	if errors.Is(err, storage.ErrJobConflict) {
+		h.skipJob(ctx, job)
+		return
+	}
+	if err != nil {
+		h.jobs.logger.Warn("failed to record DMS job start", zap.String("job_id", job.JobID), zap.Error(err))
+	}
+
+	result, err := h.executeJob(ctx, job)
+	if err != nil && ctx.Err() != nil {
+		err = errors.New("interrupted: the gateway shut down before the job completed")
+	}
+	h.finishJob(context.WithoutCancel(ctx), job, result, err)
+}
+
+// skipJob releases a queued job that is no longer pending: it was cancelled,
+// or another runner took it over.
+func (h *Handler) skipJob(ctx context.Context, job *models.DMSJob) {
+	current, err := h.jobs.store.Get(ctx, job.JobID)
+	if err != nil || current.Status != models.DMSJobStatusCancelled {
+		h.jobs.logger.Info("skipping DMS job that is no longer pending", zap.String("job_id", job.JobID))
+		h.jobs.release(job.JobID)
+		return
+	}
+
+	h.jobs.logger.Info("skipping cancelled DMS job", zap.String("job_id", job.JobID))
+	jobsProcessed.WithLabelValues(string(current.Operation), string(current.Status)).Inc()
+	if onDone := h.jobs.release(job.JobID); onDone != nil {
+		onDone(current)
+	}
+}
+
+// finishJob records the final status of a running job and runs its
+// completion callback. A job cancelled while it ran keeps its cancelled
+// status, but the callback is given the outcome of the adapter call, which
+// may have completed before the cancellation took effect.
+func (h *Handler) finishJob(ctx context.Context, job *models.DMSJob, result *models.NFDeployment, err error) {
+	completed := timeutil.Now()
+	job.CompletedAt = &completed
+	job.Progress = jobProgressFinished
+	if err != nil {
+		job.Status = models.DMSJobStatusFailed
+		job.Message = fmt.Sprintf("%s failed", job.Operation)
+		job.Error = err.Error()
+	} else {
+		job.Status = models.DMSJobStatusSucceeded
+		job.Message = fmt.Sprintf("%s completed", job.Operation)
+		job.Result = result
+	}
+
+	recorded := job
+	if storeErr := h.jobs.store.Transition(ctx, job, models.DMSJobStatusRunning); storeErr != nil {
+		current, getErr := h.jobs.store.Get(ctx, job.JobID)
+		if errors.Is(storeErr, storage.ErrJobConflict) && getErr == nil {
+			recorded = current
+		} else {
+			h.jobs.logger.Error("failed to record DMS job result", zap.String("job_id", job.JobID), zap.Error(storeErr))
+		}
+	}
+	jobsProcessed.WithLabelValues(string(recorded.Operation), string(recorded.Status)).Inc()
+	h.jobs.logger.Info("DMS job finished",
+		zap.String("job_id", job.JobID),
+		zap.String("operation", string(job.Operation)),
+		zap.String("status", string(recorded.Status)),
+		zap.String("outcome", string(job.Status)),
+		zap.String("error", job.Error))
+
+	if onDone := h.jobs.release(job.JobID); onDone != nil {
+		onDone(job)
+	}
+}
+
+// executeJob performs the adapter call of a job. Create jobs record the ID
+// of the created deployment in the job.
+func (h *Handler) executeJob(ctx context.Context, job *models.DMSJob) (*models.NFDeployment, error) {
+	adp := h.registry.Get(job.Adapter)
+	if adp == nil {
+		return nil, fmt.Errorf("adapter not found: %s", job.Adapter)
+	}
+
+	var (
+		deployment *adapter.Deployment
+		err        error
+	)
+	switch job.Operation {
+	case models.DMSJobOperationCreate:
+		var req adapter.DeploymentRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("invalid job request: %w", err)
+		}
+		if deployment, err = adp.CreateDeployment(ctx, &req); err == nil {
+			job.NFDeploymentID = deployment.ID
+		}
+	case models.DMSJobOperationUpdate:
+		var update adapter.DeploymentUpdate
+		if err := json.Unmarshal(job.Request, &update); err != nil {
+			return nil, fmt.Errorf("invalid job request: %w", err)
+		}
+		deployment, err = adp.UpdateDeployment(ctx, job.NFDeploymentID, &update)
+	case models.DMSJobOperationDelete:
+		err = adp.DeleteDeployment(ctx, job.NFDeploymentID)
+	case models.DMSJobOperationScale:
+		var req models.ScaleNFDeploymentRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("invalid job request: %w", err)
+		}
+		err = adp.ScaleDeployment(ctx, job.NFDeploymentID, req.Replicas)
+	case models.DMSJobOperationRollback:
+		var req models.RollbackNFDeploymentRequest
+		if err := json.Unmarshal(job.Request, &req); err != nil {
+			return nil, fmt.Errorf("invalid job request: %w", err)
+		}
+		targetRevision := 0
+		if req.TargetRevision != nil {
+			targetRevision = *req.TargetRevision
+		}
+		err = adp.RollbackDeployment(ctx, job.NFDeploymentID, targetRevision)
+	default:
+		return nil, fmt.Errorf("unknown job operation: %s", job.Operation)
+	}
+
+	if err != nil {
+		// Jobs report errors the way the synchronous endpoints do.
+		problem := h.translator.For(adp).Translate(err)
+		switch {
+		case problem.Status == http.StatusNotFound:
+			return nil, errors.New("NF deployment not found")
+		case !problem.Internal():
+			return nil, errors.New(problem.Message)
+		}
+		return nil, fmt.Errorf("failed to %s NF deployment: %w", job.Operation, err)
+	}
+	if deployment == nil {
+		return nil, nil
+	}
+	return h.toNFDeployment(deployment), nil
+}
+
+// cancelJob cancels a pending or running job and responds with its final
+// state. Running jobs are cancelled through the adapter, which must support
+// operation cancellation; pending jobs are skipped by the worker that takes
+// them. The job is only marked cancelled if its status did not change in the
+// meantime, for example because a worker started it; the client is then
+// asked to retry. Jobs of other tenants are reported as not found.
+func (h *Handler) cancelJob(c *gin.Context, jobID string) {
+	ctx := c.Request.Context()
+	job, err := h.jobs.store.Get(ctx, jobID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrJobNotFound) {
+			h.logger.Error("failed to get DMS job", zap.String("job_id", jobID), zap.Error(err))
+		}
+		h.respondError(c, nil, err, "Operation not found", "Failed to cancel operation")
+		return
+	}
+	if tenantID := tenantIDFromContext(c); tenantID != "" && job.TenantID != tenantID {
+		h.errorResponse(c, http.StatusNotFound, "NotFound", "Operation not found")
+		return
+	}
+	if job.Status.IsFinished() {
+		h.errorResponse(c, http.StatusConflict, "Conflict", "Operation already "+string(job.Status))
+		return
+	}
+
+	if job.Status == models.DMSJobStatusRunning {
+		adp := h.registry.Get(job.Adapter)
+		if adp == nil {
+			h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", "adapter not found: "+job.Adapter)
+			return
+		}
+		canceller, ok := adp.(adapter.OperationCanceller)
+		if !ok || !adapter.HasCapability(adp, adapter.CapabilityOperationCancel) {
+			h.errorResponse(c, http.StatusNotImplemented, "NotImplemented",
+				"Cancelling running operations is not supported by this adapter")
+			return
+		}
+		if err := canceller.CancelOperation(ctx, job.NFDeploymentID); err != nil {
+			h.logger.Error("failed to cancel DMS job", zap.String("job_id", jobID), zap.Error(err))
+			h.respondError(c, adp, err, "NF deployment not found", "Failed to cancel operation")
+			return
+		}
+	}
+
+	from := job.Status
+	completed := timeutil.Now()
+	job.Status = models.DMSJobStatusCancelled
+	job.Progress = jobProgressFinished
+	job.Message = fmt.Sprintf("%s cancelled", job.Operation)
+	job.CompletedAt = &completed
+	if err := h.jobs.store.Transition(ctx, job, from); err != nil {
+		if errors.Is(err, storage.ErrJobConflict) {
+			h.cancelConflict(c, jobID)
+			return
+		}
+		h.logger.Error("failed to record DMS job cancellation", zap.String("job_id", jobID), zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "InternalError", "Failed to cancel operation")
+		return
+	}
+
+	h.logger.Info("DMS job cancelled", zap.String("job_id", jobID), zap.String("operation", string(job.Operation)))
+	c.JSON(http.StatusOK, job)
+}
+
+// cancelConflict responds to a cancellation that lost the race against a
+// status change of the job.
+func (h *Handler) cancelConflict(c *gin.Context, jobID string) {
+	current, err := h.jobs.store.Get(c.Request.Context(), jobID)
+	if err == nil && current.Status.IsFinished() {
+		h.errorResponse(c, http.StatusConflict, "Conflict", "Operation already "+string(current.Status))
+		return
+	}
+	h.errorResponse(c, http.StatusConflict, "Conflict", "Operation status changed; retry the cancellation")
+}
+
+// GetDMSJob returns the status of an asynchronous DMS job. Jobs of other
+// tenants are reported as not found.
+// GET /o2dms/v1/jobs/:jobId.
+func (h *Handler) GetDMSJob(c *gin.Context) {
+	if h.jobs == nil {
+		h.errorResponse(c, http.StatusServiceUnavailable, "ServiceUnavailable", "DMS jobs are not enabled")
+		return
+	}
+
+	jobID := c.Param("jobId")
+	job, err := h.jobs.store.Get(c.Request.Context(), jobID)
+	if err != nil {
+		h.logger.Error("failed to get DMS job", zap.String("job_id", jobID), zap.Error(err))
+		h.respondError(c, nil, err, "Job not found", "Failed to get job")
+		return
+	}
+
+	if tenantID := tenantIDFromContext(c); tenantID != "" && job.TenantID != tenantID {
+		h.errorResponse(c, http.StatusNotFound, "NotFound", "Job not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,379 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+func setupJobRouter(t *testing.T, jobs storage.JobStore, cfg handlers.JobConfig) (*gin.Engine, *mockAdapter) {
+	t.Helper()
+
+	handler, mockAdp := setupTestHandler(t)
+	handler.EnableJobs(jobs, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	handler.StartJobs(ctx)
+
+	router := setupTestRouter(handler)
+	router.GET("/o2dms/v1/jobs/:jobId", handler.GetDMSJob)
+	return router, mockAdp
+}
+
+func doJobRequest(t *testing.T, router *gin.Engine, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// submitAndWait submits an asynchronous request and polls its job until it finishes.
+func submitAndWait(t *testing.T, router *gin.Engine, method, path string, body any) *models.DMSJob {
+	t.Helper()
+
+	w := doJobRequest(t, router, method, path, body)
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+
+	var accepted models.DMSJob
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+	require.Equal(t, "/o2dms/v1/jobs/"+accepted.JobID, w.Header().Get("Location"))
+	assert.Equal(t, models.DMSJobStatusPending, accepted.Status)
+	assert.Equal(t, "mock", accepted.Adapter)
+
+	return waitForJob(t, router, accepted.JobID)
+}
+
+func waitForJob(t *testing.T, router *gin.Engine, jobID string) *models.DMSJob {
+	t.Helper()
+
+	var job models.DMSJob
+	require.Eventually(t, func() bool {
+		w := doJobRequest(t, router, http.MethodGet, "/o2dms/v1/jobs/"+jobID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+		return job.Status.IsFinished()
+	}, 5*time.Second, 10*time.Millisecond)
+	return &job
+}
+
+func TestDMSJobs(t *testing.T) {
+	router, mockAdp := setupJobRouter(t, storage.NewMemoryJobStore(time.Hour), handlers.JobConfig{Workers: 1})
+
+	job := submitAndWait(t, router, http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+		Name: "upf", NFDeploymentDescriptorID: "pkg-upf", Namespace: "ran",
+		ParameterValues: map[string]interface{}{"replicas": 2},
+	})
+	assert.Equal(t, models.DMSJobStatusSucceeded, job.Status)
+	assert.Equal(t, 100, job.Progress)
+	assert.Equal(t, "dep-upf", job.NFDeploymentID)
+	require.NotNil(t, job.Result)
+	assert.Equal(t, "upf", job.Result.Name)
+	assert.NotNil(t, job.StartedAt)
+	assert.NotNil(t, job.CompletedAt)
+	require.Len(t, mockAdp.deployments, 1)
+
+	job = submitAndWait(t, router, http.MethodPost, "/o2dms/v1/nfDeployments/dep-upf/scale",
+		models.ScaleNFDeploymentRequest{Replicas: 3})
+	assert.Equal(t, models.DMSJobStatusSucceeded, job.Status)
+	assert.Equal(t, models.DMSJobOperationScale, job.Operation)
+
+	mockAdp.rollbackErr = errors.New("no previous revision")
+	job = submitAndWait(t, router, http.MethodPost, "/o2dms/v1/nfDeployments/dep-upf/rollback",
+		models.RollbackNFDeploymentRequest{})
+	assert.Equal(t, models.DMSJobStatusFailed, job.Status)
+	assert.Contains(t, job.Error, "no previous revision")
+
+	job = submitAndWait(t, router, http.MethodDelete, "/o2dms/v1/nfDeployments/dep-upf", nil)
+	assert.Equal(t, models.DMSJobStatusSucceeded, job.Status)
+	assert.Empty(t, mockAdp.deployments)
+
+	job = submitAndWait(t, router, http.MethodDelete, "/o2dms/v1/nfDeployments/dep-upf", nil)
+	assert.Equal(t, models.DMSJobStatusFailed, job.Status)
+	assert.Equal(t, "NF deployment not found", job.Error)
+
+	// Validation errors are still reported synchronously.
+	w := doJobRequest(t, router, http.MethodPost, "/o2dms/v1/nfDeployments",
+		models.CreateNFDeploymentRequest{Name: "Invalid_Name"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = doJobRequest(t, router, http.MethodGet, "/o2dms/v1/jobs/missing", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDMSJobs_Disabled(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+	router := setupTestRouter(handler)
+	router.GET("/o2dms/v1/jobs/:jobId", handler.GetDMSJob)
+
+	w := doJobRequest(t, router, http.MethodGet, "/o2dms/v1/jobs/job-1", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestDMSJobs_RecoverStaleJobs(t *testing.T) {
+	ctx := context.Background()
+	jobs := storage.NewMemoryJobStore(time.Hour)
+
+	// Jobs left behind by a gateway replica that stopped.
+	started := time.Now().UTC()
+	require.NoError(t, jobs.Create(ctx, &models.DMSJob{
+		JobID: "pending", Operation: models.DMSJobOperationCreate, Status: models.DMSJobStatusPending,
+		Adapter: "mock", Request: json.RawMessage(`{"Name":"amf","PackageID":"pkg-amf"}`),
+	}))
+	require.NoError(t, jobs.Create(ctx, &models.DMSJob{
+		JobID: "running", Operation: models.DMSJobOperationDelete, Status: models.DMSJobStatusRunning,
+		Adapter: "mock", NFDeploymentID: "dep-smf", StartedAt: &started,
+	}))
+	require.NoError(t, jobs.Heartbeat(ctx, []string{"pending", "running"}, started.Add(-time.Hour)))
+
+	router, mockAdp := setupJobRouter(t, jobs, handlers.JobConfig{Workers: 1, LeaseTimeout: time.Minute})
+
+	job := waitForJob(t, router, "pending")
+	assert.Equal(t, models.DMSJobStatusSucceeded, job.Status, "pending jobs are resumed")
+	assert.Equal(t, "dep-amf", job.NFDeploymentID)
+	require.Len(t, mockAdp.deployments, 1)
+	assert.Equal(t, adapter.DeploymentStatusDeployed, mockAdp.deployments[0].Status)
+
+	job = waitForJob(t, router, "running")
+	assert.Equal(t, models.DMSJobStatusFailed, job.Status, "interrupted jobs are not executed twice")
+	assert.Contains(t, job.Error, "interrupted")
+}
+
+func TestDMSJobs_Drain(t *testing.T) {
+	// setup creates a deployment whose scale jobs are held by the adapter's
+	// scale gate.
+	setup := func(t *testing.T, jobs storage.JobStore) (*handlers.Handler, *gin.Engine, *mockAdapter) {
+		t.Helper()
+		handler, mockAdp := setupTestHandler(t)
+		handler.EnableJobs(jobs, handlers.JobConfig{Workers: 1, LeaseTimeout: time.Minute})
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		handler.StartJobs(ctx)
+
+		router := setupTestRouter(handler)
+		router.GET("/o2dms/v1/jobs/:jobId", handler.GetDMSJob)
+		submitAndWait(t, router, http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+			Name: "upf", NFDeploymentDescriptorID: "pkg-upf", Namespace: "ran",
+		})
+		mockAdp.scaleGate = make(chan struct{})
+		return handler, router, mockAdp
+	}
+	submitScale := func(t *testing.T, router *gin.Engine) string {
+		t.Helper()
+		w := doJobRequest(t, router, http.MethodPost, "/o2dms/v1/nfDeployments/dep-upf/scale",
+			models.ScaleNFDeploymentRequest{Replicas: 3})
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var accepted models.DMSJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		return accepted.JobID
+	}
+	jobStatus := func(t *testing.T, jobs storage.JobStore, jobID string) models.DMSJobStatus {
+		t.Helper()
+		job, err := jobs.Get(context.Background(), jobID)
+		require.NoError(t, err)
+		return job.Status
+	}
+
+	t.Run("waits for running jobs", func(t *testing.T) {
+		jobs := storage.NewMemoryJobStore(time.Hour)
+		handler, router, mockAdp := setup(t, jobs)
+		jobID := submitScale(t, router)
+		require.Eventually(t, func() bool {
+			return jobStatus(t, jobs, jobID) == models.DMSJobStatusRunning
+		}, 5*time.Second, 10*time.Millisecond)
+
+		drained := make(chan error, 1)
+		go func() { drained <- handler.DrainJobs(context.Background()) }()
+		select {
+		case err := <-drained:
+			t.Fatalf("drain returned before the running job finished: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(mockAdp.scaleGate)
+		require.NoError(t, <-drained)
+		assert.Equal(t, models.DMSJobStatusSucceeded, jobStatus(t, jobs, jobID))
+	})
+
+	t.Run("interrupts running jobs and hands over queued jobs", func(t *testing.T) {
+		jobs := storage.NewMemoryJobStore(time.Hour)
+		handler, router, _ := setup(t, jobs)
+		running := submitScale(t, router)
+		require.Eventually(t, func() bool {
+			return jobStatus(t, jobs, running) == models.DMSJobStatusRunning
+		}, 5*time.Second, 10*time.Millisecond)
+		queued := submitScale(t, router)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := handler.DrainJobs(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		job, err := jobs.Get(context.Background(), running)
+		require.NoError(t, err)
+		assert.Equal(t, models.DMSJobStatusFailed, job.Status)
+		assert.Contains(t, job.Error, "interrupted")
+
+		// The queued job is still pending and can be claimed at once.
+		assert.Equal(t, models.DMSJobStatusPending, jobStatus(t, jobs, queued))
+		stale, err := jobs.ClaimStale(context.Background(), time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		assert.Equal(t, queued, stale[0].JobID)
+	})
+}
+
+func TestDMSJobs_Cancel(t *testing.T) {
+	logger := zap.NewNop()
+	adp := &cancellableAdapter{mockAdapter: newMockAdapter()}
+	adp.capabilities = append(adp.capabilities, adapter.CapabilityOperationCancel)
+	reg := registry.NewRegistry(logger, nil)
+	require.NoError(t, reg.Register(context.Background(), "mock", "mock", adp, nil, true))
+
+	jobs := storage.NewMemoryJobStore(time.Hour)
+	handler := handlers.NewHandler(reg, storage.NewMemoryStore(), logger)
+	handler.EnableJobs(jobs, handlers.JobConfig{Workers: 1, LeaseTimeout: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	handler.StartJobs(ctx)
+	router := setupTestRouter(handler)
+	router.GET("/o2dms/v1/jobs/:jobId", handler.GetDMSJob)
+
+	submitAndWait(t, router, http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+		Name: "upf", NFDeploymentDescriptorID: "pkg-upf", Namespace: "ran",
+	})
+
+	// The first scale job runs until the gate opens; the second one waits.
+	adp.scaleGate = make(chan struct{})
+	submitScale := func() string {
+		w := doJobRequest(t, router, http.MethodPost, "/o2dms/v1/nfDeployments/dep-upf/scale",
+			models.ScaleNFDeploymentRequest{Replicas: 3})
+		require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+		var accepted models.DMSJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		return accepted.JobID
+	}
+	running := submitScale()
+	require.Eventually(t, func() bool {
+		job, err := jobs.Get(context.Background(), running)
+		require.NoError(t, err)
+		return job.Status == models.DMSJobStatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	pending := submitScale()
+
+	cancelJob := func(jobID string) *models.DMSJob {
+		w := doJobRequest(t, router, http.MethodDelete, "/o2dms/v1/operations/"+jobID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var job models.DMSJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+		return &job
+	}
+
+	job := cancelJob(pending)
+	assert.Equal(t, models.DMSJobStatusCancelled, job.Status)
+	assert.NotNil(t, job.CompletedAt)
+	assert.Empty(t, adp.cancelled, "pending jobs are not cancelled through the adapter")
+
+	job = cancelJob(running)
+	assert.Equal(t, models.DMSJobStatusCancelled, job.Status)
+	assert.Equal(t, []string{"dep-upf"}, adp.cancelled)
+
+	// The worker keeps the cancelled status and skips the cancelled pending job.
+	close(adp.scaleGate)
+	require.NoError(t, handler.DrainJobs(context.Background()))
+	assert.Equal(t, models.DMSJobStatusCancelled, waitForJob(t, router, running).Status)
+	assert.Equal(t, models.DMSJobStatusCancelled, waitForJob(t, router, pending).Status)
+
+	w := doJobRequest(t, router, http.MethodDelete, "/o2dms/v1/operations/"+running, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = doJobRequest(t, router, http.MethodDelete, "/o2dms/v1/operations/missing", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDMSJobs_CancelRunningCreate(t *testing.T) {
+	logger := zap.NewNop()
+	adp := &cancellableAdapter{mockAdapter: newMockAdapter()}
+	adp.capabilities = append(adp.capabilities, adapter.CapabilityOperationCancel)
+	reg := registry.NewRegistry(logger, nil)
+	require.NoError(t, reg.Register(context.Background(), "mock", "mock", adp, nil, true))
+
+	jobs := storage.NewMemoryJobStore(time.Hour)
+	handler := handlers.NewHandler(reg, storage.NewMemoryStore(), logger)
+	quotas := handlers.NewQuotaEnforcer(fakeQuotaProvider{
+		"tenant-a": {ID: "tenant-a", Quota: auth.TenantQuota{MaxDeployments: 5}},
+	}, storage.NewMemoryQuotaUsageStore())
+	handler.SetQuotaEnforcer(quotas)
+	handler.EnableJobs(jobs, handlers.JobConfig{Workers: 1, LeaseTimeout: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	handler.StartJobs(ctx)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "tenant-a"))
+		c.Next()
+	})
+	router.POST("/o2dms/v1/nfDeployments", handler.CreateNFDeployment)
+	router.DELETE("/o2dms/v1/operations/:operationId", handler.CancelOperation)
+	router.GET("/o2dms/v1/jobs/:jobId", handler.GetDMSJob)
+
+	adp.createGate = make(chan struct{})
+	w := doJobRequest(t, router, http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+		Name: "upf", NFDeploymentDescriptorID: "pkg-upf", Namespace: "ran",
+	})
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var accepted models.DMSJob
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+	assert.Equal(t, "upf", accepted.NFDeploymentID, "create jobs name their deployment before they run")
+
+	require.Eventually(t, func() bool {
+		job, err := jobs.Get(context.Background(), accepted.JobID)
+		require.NoError(t, err)
+		return job.Status == models.DMSJobStatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	w = doJobRequest(t, router, http.MethodDelete, "/o2dms/v1/operations/"+accepted.JobID, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"upf"}, adp.cancelled)
+
+	// The adapter completed the create before the cancellation took effect:
+	// the job stays cancelled, but the deployment exists and keeps its quota.
+	close(adp.createGate)
+	require.NoError(t, handler.DrainJobs(context.Background()))
+	assert.Equal(t, models.DMSJobStatusCancelled, waitForJob(t, router, accepted.JobID).Status)
+
+	usage, err := quotas.Usage(context.Background(), "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, usage.Deployments)
+	_, found, err := quotas.Lookup(context.Background(), "tenant-a", "dep-upf")
+	require.NoError(t, err)
+	assert.True(t, found)
+}
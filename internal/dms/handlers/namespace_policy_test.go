@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+func TestNFDeployment_NamespacePolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+
+	reg := registry.NewRegistry(logger, nil)
+	mockAdp := newMockAdapter()
+	mockAdp.deployments = []*adapter.Deployment{
+		{ID: "dep-ran", Name: "ran", Namespace: "ran", Status: adapter.DeploymentStatusDeployed},
+		{ID: "dep-system", Name: "system", Namespace: "kube-system", Status: adapter.DeploymentStatusDeployed},
+	}
+	require.NoError(t, reg.Register(context.Background(), "mock", "mock", mockAdp, nil, true))
+	require.NoError(t, reg.SetNamespacePolicy("mock", &adapter.NamespacePolicy{Deny: []string{"kube-*"}}))
+
+	router := setupTestRouter(handlers.NewHandler(reg, storage.NewMemoryStore(), logger))
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			var err error
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	assertDenied := func(t *testing.T, w *httptest.ResponseRecorder) {
+		t.Helper()
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		var details problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+		assert.Equal(t, "Forbidden", details.Error)
+		assert.Equal(t, "kube-system", details.AdditionalAttributes["namespace"])
+		assert.Equal(t, "deny:kube-*", details.AdditionalAttributes["rule"])
+	}
+
+	t.Run("list hides denied namespaces", func(t *testing.T) {
+		w := do(http.MethodGet, "/o2dms/v1/nfDeployments", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp models.NFDeploymentListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.NFDeployments, 1)
+		assert.Equal(t, "dep-ran", resp.NFDeployments[0].NFDeploymentID)
+
+		assertDenied(t, do(http.MethodGet, "/o2dms/v1/nfDeployments?namespace=kube-system", nil))
+	})
+
+	t.Run("operations on denied deployments are forbidden", func(t *testing.T) {
+		assertDenied(t, do(http.MethodGet, "/o2dms/v1/nfDeployments/dep-system", nil))
+		assertDenied(t, do(http.MethodGet, "/o2dms/v1/nfDeployments/dep-system/status", nil))
+		assertDenied(t, do(http.MethodPost, "/o2dms/v1/nfDeployments/dep-system/scale",
+			models.ScaleNFDeploymentRequest{Replicas: 2}))
+		assertDenied(t, do(http.MethodDelete, "/o2dms/v1/nfDeployments/dep-system", nil))
+		assert.Len(t, mockAdp.deployments, 2, "denied deployment is not deleted")
+
+		w := do(http.MethodGet, "/o2dms/v1/nfDeployments/dep-ran", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("create in denied namespace is forbidden", func(t *testing.T) {
+		assertDenied(t, do(http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+			Name:                     "probe",
+			NFDeploymentDescriptorID: "pkg-1",
+			Namespace:                "kube-system",
+		}))
+
+		w := do(http.MethodPost, "/o2dms/v1/nfDeployments", models.CreateNFDeploymentRequest{
+			Name:                     "du",
+			NFDeploymentDescriptorID: "pkg-1",
+			Namespace:                "ran",
+		})
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/cost"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+// Quota resource names reported in quota errors and metrics.
+const (
+	QuotaResourceDeployments = "deployments"
+	QuotaResourceCPU         = "deployment_cpu"
+	QuotaResourceMemory      = "deployment_memory"
+)
+
+// QuotaProvider looks up tenants and their quotas.
+// auth.Store satisfies this interface.
+type QuotaProvider interface {
+	GetTenant(ctx context.Context, id string) (*auth.Tenant, error)
+}
+
+// DeploymentUsage describes the capacity requested by a single deployment.
+// CPU, memory, and GPUs are per replica; totals are multiplied by Replicas.
+// GPUs are not subject to quotas and are only used for cost estimates.
+type DeploymentUsage storage.QuotaUsage
+
+// TotalCPUMillicores returns the aggregate CPU requested across all replicas.
+func (u DeploymentUsage) TotalCPUMillicores() int64 {
+	return u.CPUMillicores * int64(u.Replicas)
+}
+
+// TotalMemoryBytes returns the aggregate memory requested across all replicas.
+func (u DeploymentUsage) TotalMemoryBytes() int64 {
+	return u.MemoryBytes * int64(u.Replicas)
+}
+
+// CostUsage returns the aggregate capacity requested across all replicas.
+func (u DeploymentUsage) CostUsage() cost.Usage {
+	return cost.Usage{
+		CPUMillicores: u.TotalCPUMillicores(),
+		MemoryBytes:   u.TotalMemoryBytes(),
+		GPUs:          u.GPUs * int64(u.Replicas),
+	}
+}
+
+// TenantDeploymentUsage is the aggregate DMS usage of a tenant.
+type TenantDeploymentUsage struct {
+	Deployments   int   `json:"deployments"`
+	CPUMillicores int64 `json:"cpuMillicores"`
+	MemoryBytes   int64 `json:"memoryBytes"`
+}
+
+// QuotaExceededError reports which tenant quota a DMS operation would exceed.
+// It matches auth.ErrQuotaExceeded with errors.Is.
+type QuotaExceededError struct {
+	TenantID  string
+	Resource  string
+	Limit     int64
+	Current   int64
+	Requested int64
+}
+
+// Error implements the error interface.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("tenant %s %s quota exceeded: limit %d, current %d, requested %d",
+		e.TenantID, e.Resource, e.Limit, e.Current, e.Requested)
+}
+
+// Is reports whether target is auth.ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == auth.ErrQuotaExceeded
+}
+
+// QuotaEnforcer enforces per-tenant DMS quotas: the maximum number of deployments
+// and the maximum aggregate requested CPU and memory.
+//
+// Limits come from the tenant quota model (auth.TenantQuota); a zero limit means
+// unlimited. Usage is tracked per deployment as create, update, scale, and delete
+// requests pass through the DMS API, and is kept in a storage.QuotaUsageStore so
+// that it survives restarts and is shared by replicas. Check and record happen
+// in a single store update so concurrent requests cannot overshoot a quota.
+type QuotaEnforcer struct {
+	provider QuotaProvider
+	usage    storage.QuotaUsageStore
+}
+
+// NewQuotaEnforcer creates a QuotaEnforcer using provider to look up tenant quotas
+// and recording usage in usage.
+func NewQuotaEnforcer(provider QuotaProvider, usage storage.QuotaUsageStore) *QuotaEnforcer {
+	return &QuotaEnforcer{
+		provider: provider,
+		usage:    usage,
+	}
+}
+
+// Reserve checks that recording usage for deploymentID keeps the tenant within its
+// quota and records it, replacing any usage previously recorded for the deployment.
+// The returned undo function restores the previous state and must be called if
+// the operation the reservation was made for fails.
+//
+// Tenants unknown to the provider have no quota and are not restricted.
+func (q *QuotaEnforcer) Reserve(
+	ctx context.Context,
+	tenantID, deploymentID string,
+	usage DeploymentUsage,
+) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if tenantID == "" {
+		return noop, nil
+	}
+
+	tenant, err := q.provider.GetTenant(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, auth.ErrTenantNotFound) {
+			return noop, nil
+		}
+		return noop, fmt.Errorf("failed to get tenant quota: %w", err)
+	}
+
+	var (
+		previous storage.QuotaUsage
+		existed  bool
+	)
+	err = q.usage.Update(ctx, tenantID, func(deployments map[string]storage.QuotaUsage) error {
+		previous, existed = deployments[deploymentID]
+
+		current := totals(deployments)
+		next := current
+		if existed {
+			next.CPUMillicores -= DeploymentUsage(previous).TotalCPUMillicores()
+			next.MemoryBytes -= DeploymentUsage(previous).TotalMemoryBytes()
+		} else {
+			next.Deployments++
+		}
+		next.CPUMillicores += usage.TotalCPUMillicores()
+		next.MemoryBytes += usage.TotalMemoryBytes()
+
+		if err := checkQuota(tenantID, tenant.Quota, current, next); err != nil {
+			return err
+		}
+		deployments[deploymentID] = storage.QuotaUsage(usage)
+		return nil
+	})
+	if err != nil {
+		var quotaErr *QuotaExceededError
+		if errors.As(err, &quotaErr) {
+			auth.RecordQuotaExceeded(tenantID, quotaErr.Resource)
+			return noop, err
+		}
+		return noop, fmt.Errorf("failed to record quota usage: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		return q.usage.Update(ctx, tenantID, func(deployments map[string]storage.QuotaUsage) error {
+			if existed {
+				deployments[deploymentID] = previous
+			} else {
+				delete(deployments, deploymentID)
+			}
+			return nil
+		})
+	}, nil
+}
+
+// Rename moves usage recorded under a provisional key to the deployment ID assigned
+// by the adapter.
+func (q *QuotaEnforcer) Rename(ctx context.Context, tenantID, from, to string) error {
+	if from == to {
+		return nil
+	}
+
+	return q.usage.Update(ctx, tenantID, func(deployments map[string]storage.QuotaUsage) error {
+		if usage, ok := deployments[from]; ok {
+			delete(deployments, from)
+			deployments[to] = usage
+		}
+		return nil
+	})
+}
+
+// Lookup returns the usage recorded for a deployment.
+func (q *QuotaEnforcer) Lookup(ctx context.Context, tenantID, deploymentID string) (DeploymentUsage, bool, error) {
+	deployments, err := q.usage.Get(ctx, tenantID)
+	if err != nil {
+		return DeploymentUsage{}, false, err
+	}
+	usage, ok := deployments[deploymentID]
+	return DeploymentUsage(usage), ok, nil
+}
+
+// Release removes the usage recorded for a deleted deployment.
+func (q *QuotaEnforcer) Release(ctx context.Context, tenantID, deploymentID string) error {
+	return q.usage.Update(ctx, tenantID, func(deployments map[string]storage.QuotaUsage) error {
+		delete(deployments, deploymentID)
+		return nil
+	})
+}
+
+// Usage returns the aggregate DMS usage of a tenant.
+func (q *QuotaEnforcer) Usage(ctx context.Context, tenantID string) (TenantDeploymentUsage, error) {
+	deployments, err := q.usage.Get(ctx, tenantID)
+	if err != nil {
+		return TenantDeploymentUsage{}, err
+	}
+	return totals(deployments), nil
+}
+
+func totals(deployments map[string]storage.QuotaUsage) TenantDeploymentUsage {
+	var totals TenantDeploymentUsage
+	for _, usage := range deployments {
+		totals.Deployments++
+		totals.CPUMillicores += DeploymentUsage(usage).TotalCPUMillicores()
+		totals.MemoryBytes += DeploymentUsage(usage).TotalMemoryBytes()
+	}
+	return totals
+}
+
+// checkQuota returns an error if next exceeds a limit and grows relative to current,
+// so tenants already over a lowered quota can still shrink their usage.
+func checkQuota(tenantID string, quota auth.TenantQuota, current, next TenantDeploymentUsage) *QuotaExceededError {
+	checks := []struct {
+		resource      string
+		limit         int64
+		current, next int64
+	}{
+		{QuotaResourceDeployments, int64(quota.MaxDeployments), int64(current.Deployments), int64(next.Deployments)},
+		{QuotaResourceCPU, quota.MaxDeploymentCPUMillicores, current.CPUMillicores, next.CPUMillicores},
+		{QuotaResourceMemory, quota.MaxDeploymentMemoryBytes, current.MemoryBytes, next.MemoryBytes},
+	}
+
+	for _, c := range checks {
+		if c.limit > 0 && c.next > c.limit && c.next > c.current {
+			return &QuotaExceededError{
+				TenantID:  tenantID,
+				Resource:  c.resource,
+				Limit:     c.limit,
+				Current:   c.current,
+				Requested: c.next - c.current,
+			}
+		}
+	}
+	return nil
+}
+
+// gpuResourceNames are the extended resource names of GPUs in resource requests and limits.
+var gpuResourceNames = []string{"nvidia.com/gpu", "amd.com/gpu"}
+
+// ParseDeploymentUsage extracts the requested capacity from deployment parameter
+// values using the common Helm chart conventions:
+//   - replicaCount or replicas (default 1)
+//   - resources.requests.cpu and resources.requests.memory (Kubernetes quantities)
+//   - nvidia.com/gpu or amd.com/gpu in resources.requests or resources.limits
+func ParseDeploymentUsage(values map[string]interface{}) (DeploymentUsage, error) {
+	return mergeDeploymentUsage(DeploymentUsage{Replicas: 1}, values)
+}
+
+// mergeDeploymentUsage overrides the fields of usage that are set in values.
+func mergeDeploymentUsage(usage DeploymentUsage, values map[string]interface{}) (DeploymentUsage, error) {
+	for _, key := range []string{"replicaCount", "replicas"} {
+		if raw, ok := values[key]; ok {
+			replicas, err := toInt(raw)
+			if err != nil || replicas < 0 {
+				return usage, fmt.Errorf("%s must be a non-negative integer", key)
+			}
+			usage.Replicas = replicas
+			break
+		}
+	}
+
+	resources, _ := values["resources"].(map[string]interface{})
+	requests, _ := resources["requests"].(map[string]interface{})
+
+	if raw, ok := requests["cpu"]; ok {
+		qty, err := resource.ParseQuantity(fmt.Sprint(raw))
+		if err != nil {
+			return usage, fmt.Errorf("invalid resources.requests.cpu: %w", err)
+		}
+		usage.CPUMillicores = qty.MilliValue()
+	}
+	if raw, ok := requests["memory"]; ok {
+		qty, err := resource.ParseQuantity(fmt.Sprint(raw))
+		if err != nil {
+			return usage, fmt.Errorf("invalid resources.requests.memory: %w", err)
+		}
+		usage.MemoryBytes = qty.Value()
+	}
+
+	// Extended resources such as GPUs are usually only set as limits; Kubernetes
+	// then requests the same amount.
+	limits, _ := resources["limits"].(map[string]interface{})
+	for _, name := range gpuResourceNames {
+		raw, ok := requests[name]
+		if !ok {
+			raw, ok = limits[name]
+		}
+		if !ok {
+			continue
+		}
+		qty, err := resource.ParseQuantity(fmt.Sprint(raw))
+		if err != nil {
+			return usage, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		usage.GPUs = qty.Value()
+		break
+	}
+
+	return usage, nil
+}
+
+func toInt(v interface{}) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case float64:
+		if n != float64(int(n)) {
+			return 0, errors.New("not an integer")
+		}
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// tenantIDFromContext returns the tenant ID set by the authentication or tenant middleware.
+func tenantIDFromContext(c *gin.Context) string {
+	return requestcontext.Tenant(c.Request.Context())
+}
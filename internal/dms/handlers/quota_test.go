@@ -0,0 +1,233 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+// fakeQuotaProvider returns fixed tenants by ID.
+type fakeQuotaProvider map[string]*auth.Tenant
+
+func (f fakeQuotaProvider) GetTenant(_ context.Context, id string) (*auth.Tenant, error) {
+	if tenant, ok := f[id]; ok {
+		return tenant, nil
+	}
+	return nil, auth.ErrTenantNotFound
+}
+
+func TestParseDeploymentUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]interface{}
+		want    handlers.DeploymentUsage
+		wantErr bool
+	}{
+		{name: "no values", values: nil, want: handlers.DeploymentUsage{Replicas: 1}},
+		{
+			name: "helm conventions",
+			values: map[string]interface{}{
+				"replicaCount": float64(3),
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"cpu": "250m", "memory": "512Mi"},
+				},
+			},
+			want: handlers.DeploymentUsage{Replicas: 3, CPUMillicores: 250, MemoryBytes: 512 << 20},
+		},
+		{name: "replicas alias", values: map[string]interface{}{"replicas": 2}, want: handlers.DeploymentUsage{Replicas: 2}},
+		{
+			name: "gpu limits",
+			values: map[string]interface{}{
+				"resources": map[string]interface{}{
+					"limits": map[string]interface{}{"nvidia.com/gpu": float64(2)},
+				},
+			},
+			want: handlers.DeploymentUsage{Replicas: 1, GPUs: 2},
+		},
+		{name: "fractional replicas", values: map[string]interface{}{"replicas": 1.5}, wantErr: true},
+		{
+			name: "invalid cpu quantity",
+			values: map[string]interface{}{
+				"resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "lots"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := handlers.ParseDeploymentUsage(tt.values)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuotaEnforcer_Reserve(t *testing.T) {
+	provider := fakeQuotaProvider{
+		"tenant-a": {ID: "tenant-a", Quota: auth.TenantQuota{
+			MaxDeployments:             2,
+			MaxDeploymentCPUMillicores: 1000,
+		}},
+	}
+	q := handlers.NewQuotaEnforcer(provider, storage.NewMemoryQuotaUsageStore())
+	ctx := context.Background()
+
+	_, err := q.Reserve(ctx, "tenant-a", "d1", handlers.DeploymentUsage{Replicas: 2, CPUMillicores: 300})
+	require.NoError(t, err)
+
+	// 600m used; 2 x 250m more would exceed 1000m.
+	_, err = q.Reserve(ctx, "tenant-a", "d2", handlers.DeploymentUsage{Replicas: 2, CPUMillicores: 250})
+	var quotaErr *handlers.QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	require.ErrorIs(t, err, auth.ErrQuotaExceeded)
+	assert.Equal(t, handlers.QuotaResourceCPU, quotaErr.Resource)
+	assert.Equal(t, int64(600), quotaErr.Current)
+	assert.Equal(t, int64(500), quotaErr.Requested)
+
+	undo, err := q.Reserve(ctx, "tenant-a", "d2", handlers.DeploymentUsage{Replicas: 1, CPUMillicores: 100})
+	require.NoError(t, err)
+
+	_, err = q.Reserve(ctx, "tenant-a", "d3", handlers.DeploymentUsage{Replicas: 1})
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, handlers.QuotaResourceDeployments, quotaErr.Resource)
+
+	// Undoing a failed operation frees the reservation.
+	require.NoError(t, undo(ctx))
+	usage, err := q.Usage(ctx, "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, handlers.TenantDeploymentUsage{Deployments: 1, CPUMillicores: 600}, usage)
+
+	// Scaling down an existing deployment is always allowed.
+	_, err = q.Reserve(ctx, "tenant-a", "d1", handlers.DeploymentUsage{Replicas: 1, CPUMillicores: 300})
+	require.NoError(t, err)
+
+	require.NoError(t, q.Release(ctx, "tenant-a", "d1"))
+	usage, err = q.Usage(ctx, "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, handlers.TenantDeploymentUsage{}, usage)
+
+	// Tenants without a quota record are not restricted.
+	_, err = q.Reserve(ctx, "unknown", "d1", handlers.DeploymentUsage{Replicas: 100, CPUMillicores: 1000})
+	require.NoError(t, err)
+}
+
+func TestQuotaEnforcer_SharedUsage(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	provider := fakeQuotaProvider{
+		"tenant-a": {ID: "tenant-a", Quota: auth.TenantQuota{MaxDeployments: 1}},
+	}
+	ctx := context.Background()
+
+	// A replica, or the gateway before a restart, records a deployment.
+	first := handlers.NewQuotaEnforcer(provider, storage.NewRedisQuotaUsageStore(client))
+	_, err := first.Reserve(ctx, "tenant-a", "d1", handlers.DeploymentUsage{Replicas: 1})
+	require.NoError(t, err)
+
+	second := handlers.NewQuotaEnforcer(provider, storage.NewRedisQuotaUsageStore(client))
+	_, err = second.Reserve(ctx, "tenant-a", "d2", handlers.DeploymentUsage{Replicas: 1})
+	require.ErrorIs(t, err, auth.ErrQuotaExceeded)
+
+	usage, found, err := second.Lookup(ctx, "tenant-a", "d1")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, handlers.DeploymentUsage{Replicas: 1}, usage)
+}
+
+func TestQuotaEnforcer_ProviderError(t *testing.T) {
+	q := handlers.NewQuotaEnforcer(errQuotaProvider{}, storage.NewMemoryQuotaUsageStore())
+	_, err := q.Reserve(context.Background(), "tenant-a", "d1", handlers.DeploymentUsage{Replicas: 1})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, auth.ErrQuotaExceeded)
+}
+
+type errQuotaProvider struct{}
+
+func (errQuotaProvider) GetTenant(context.Context, string) (*auth.Tenant, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func TestNFDeployment_QuotaEnforcement(t *testing.T) {
+	handler, _ := setupTestHandler(t)
+	handler.SetQuotaEnforcer(handlers.NewQuotaEnforcer(fakeQuotaProvider{
+		"tenant-a": {ID: "tenant-a", Quota: auth.TenantQuota{
+			MaxDeployments:           1,
+			MaxDeploymentMemoryBytes: 1 << 30,
+		}},
+	}, storage.NewMemoryQuotaUsageStore()))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), c.GetHeader("X-Tenant-ID")))
+		c.Next()
+	})
+	router.POST("/nfDeployments", handler.CreateNFDeployment)
+	router.POST("/nfDeployments/:nfDeploymentId/scale", handler.ScaleNFDeployment)
+	router.DELETE("/nfDeployments/:nfDeploymentId", handler.DeleteNFDeployment)
+
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant-ID", "tenant-a")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	create := func(name string) *httptest.ResponseRecorder {
+		return do(http.MethodPost, "/nfDeployments", models.CreateNFDeploymentRequest{
+			Name:                     name,
+			NFDeploymentDescriptorID: "pkg-1",
+			ParameterValues: map[string]interface{}{
+				"replicaCount": 2,
+				"resources":    map[string]interface{}{"requests": map[string]interface{}{"memory": "256Mi"}},
+			},
+		})
+	}
+
+	require.Equal(t, http.StatusCreated, create("first").Code)
+
+	w := create("second")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	var apiErr models.APIError
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+	assert.Equal(t, "QuotaExceeded", apiErr.Error)
+	assert.Contains(t, apiErr.Message, handlers.QuotaResourceDeployments)
+
+	// 8 x 256Mi exceeds the 1Gi memory quota.
+	w = do(http.MethodPost, "/nfDeployments/dep-first/scale", models.ScaleNFDeploymentRequest{Replicas: 8})
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), handlers.QuotaResourceMemory)
+
+	w = do(http.MethodPost, "/nfDeployments/dep-first/scale", models.ScaleNFDeploymentRequest{Replicas: 4})
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	w = do(http.MethodDelete, "/nfDeployments/dep-first", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, http.StatusCreated, create("second").Code)
+}
@@ -3,15 +3,18 @@ package dms
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.uber.org/zap"
 
+	"github.com/piwi3910/netweave/internal/dms/adapter"
 	"github.com/piwi3910/netweave/internal/dms/adapters/argocd"
 	"github.com/piwi3910/netweave/internal/dms/adapters/crossplane"
 	"github.com/piwi3910/netweave/internal/dms/adapters/flux"
 	"github.com/piwi3910/netweave/internal/dms/adapters/helm"
 	"github.com/piwi3910/netweave/internal/dms/adapters/kustomize"
+	"github.com/piwi3910/netweave/internal/dms/adapters/manifests"
 	"github.com/piwi3910/netweave/internal/dms/adapters/onaplcm"
 	"github.com/piwi3910/netweave/internal/dms/adapters/osmlcm"
 	"github.com/piwi3910/netweave/internal/dms/registry"
@@ -52,11 +55,15 @@ type AdapterConfig struct {
 	// OSMURL is the OSM LCM API URL.
 	OSMURL string
 
-	// Username for authentication (ONAP/OSM).
+	// Username for authentication (Helm repository, ONAP/OSM).
 	Username string
 
-	// Password for authentication (ONAP/OSM).
+	// Password for authentication (Helm repository, ONAP/OSM).
 	Password string
+
+	// NamespacePolicy restricts the namespaces the adapter may act on through
+	// the API. Nil allows every namespace.
+	NamespacePolicy *adapter.NamespacePolicy
 }
 
 // AdaptersConfig contains configuration for all DMS adapters.
@@ -65,6 +72,7 @@ type AdaptersConfig struct {
 	ArgoCD     *AdapterConfig
 	Flux       *AdapterConfig
 	Kustomize  *AdapterConfig
+	Manifests  *AdapterConfig
 	Crossplane *AdapterConfig
 	ONAPLCM    *AdapterConfig
 	OSMLCM     *AdapterConfig
@@ -90,6 +98,10 @@ func DefaultAdaptersConfig() *AdaptersConfig {
 			Enabled:   false,
 			Namespace: "default",
 		},
+		Manifests: &AdapterConfig{
+			Enabled:   false,
+			Namespace: "default",
+		},
 		Crossplane: &AdapterConfig{
 			Enabled:   false,
 			Namespace: "crossplane-system",
@@ -103,11 +115,33 @@ func DefaultAdaptersConfig() *AdaptersConfig {
 	}
 }
 
+// Adapter types accepted by NewAdapter and RegisterAdapter.
+const (
+	TypeHelm       = "helm"
+	TypeArgoCD     = "argocd"
+	TypeFlux       = "flux"
+	TypeKustomize  = "kustomize"
+	TypeManifests  = "manifests"
+	TypeCrossplane = "crossplane"
+	TypeONAPLCM    = "onaplcm"
+	TypeOSMLCM     = "osmlcm"
+)
+
+// ErrUnknownAdapterType is returned for adapter types NewAdapter can't build.
+var ErrUnknownAdapterType = errors.New("unknown DMS adapter type")
+
+// AdapterTypes returns the adapter types NewAdapter builds.
+func AdapterTypes() []string {
+	return []string{
+		TypeHelm, TypeArgoCD, TypeFlux, TypeKustomize, TypeManifests, TypeCrossplane, TypeONAPLCM, TypeOSMLCM,
+	}
+}
+
 // adapterRegistration defines a single adapter registration.
 type adapterRegistration struct {
-	name       string
-	config     *AdapterConfig
-	registerFn func(context.Context, *registry.Registry, *AdapterConfig, *zap.Logger) error
+	name   string
+	key    string
+	config *AdapterConfig
 }
 
 // InitializeAdapters initializes and registers all enabled DMS adapters.
@@ -123,248 +157,138 @@ func InitializeAdapters(
 
 	// Define all adapter registrations
 	registrations := []adapterRegistration{
-		{"Helm", config.Helm, registerHelmAdapter},
-		{"ArgoCD", config.ArgoCD, registerArgoCDAdapter},
-		{"Flux", config.Flux, registerFluxAdapter},
-		{"Kustomize", config.Kustomize, registerKustomizeAdapter},
-		{"Crossplane", config.Crossplane, registerCrossplaneAdapter},
-		{"ONAP-LCM", config.ONAPLCM, registerONAPLCMAdapter},
-		{"OSM-LCM", config.OSMLCM, registerOSMLCMAdapter},
+		{"Helm", TypeHelm, config.Helm},
+		{"ArgoCD", TypeArgoCD, config.ArgoCD},
+		{"Flux", TypeFlux, config.Flux},
+		{"Kustomize", TypeKustomize, config.Kustomize},
+		{"Manifests", TypeManifests, config.Manifests},
+		{"Crossplane", TypeCrossplane, config.Crossplane},
+		{"ONAP-LCM", TypeONAPLCM, config.ONAPLCM},
+		{"OSM-LCM", TypeOSMLCM, config.OSMLCM},
 	}
 
 	// Register all enabled adapters
 	for _, r := range registrations {
-		if err := registerAdapter(ctx, reg, r, logger); err != nil {
-			return err
+		if r.config == nil || !r.config.Enabled {
+			continue
 		}
-	}
-
-	logger.Info("DMS adapters initialized successfully")
-	return nil
-}
-
-// registerAdapter registers a single adapter if it's enabled.
-func registerAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	r adapterRegistration,
-	logger *zap.Logger,
-) error {
-	if r.config != nil && r.config.Enabled {
-		if err := r.registerFn(ctx, reg, r.config, logger); err != nil {
+		if err := RegisterAdapter(ctx, reg, r.key, r.key, r.config, logger); err != nil {
 			return fmt.Errorf("failed to register %s adapter: %w", r.name, err)
 		}
 	}
-	return nil
-}
-
-// registerHelmAdapter initializes and registers the Helm adapter.
-func registerHelmAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	config *AdapterConfig,
-	logger *zap.Logger,
-) error {
-	helmConfig := &helm.Config{
-		Kubeconfig:    config.Kubeconfig,
-		Namespace:     config.Namespace,
-		RepositoryURL: config.RepositoryURL,
-	}
-
-	adapter, err := helm.NewAdapter(helmConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create Helm adapter: %w", err)
-	}
 
-	adapterConfig := map[string]interface{}{
-		"namespace":     config.Namespace,
-		"repositoryURL": config.RepositoryURL,
-	}
-
-	if err := reg.Register(ctx, "helm", "helm", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register Helm adapter: %w", err)
-	}
-
-	logger.Info("Helm adapter registered", zap.String("namespace", config.Namespace))
-	return nil
-}
-
-// registerArgoCDAdapter initializes and registers the ArgoCD adapter.
-func registerArgoCDAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	config *AdapterConfig,
-	logger *zap.Logger,
-) error {
-	argoCDConfig := &argocd.Config{
-		Kubeconfig: config.Kubeconfig,
-		Namespace:  config.Namespace,
-	}
-
-	adapter, err := argocd.NewAdapter(argoCDConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create ArgoCD adapter: %w", err)
-	}
-
-	adapterConfig := map[string]interface{}{
-		"namespace": config.Namespace,
-	}
-
-	if err := reg.Register(ctx, "argocd", "argocd", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register ArgoCD adapter: %w", err)
-	}
-
-	logger.Info("ArgoCD adapter registered", zap.String("namespace", config.Namespace))
-	return nil
-}
-
-// registerFluxAdapter initializes and registers the Flux adapter.
-func registerFluxAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	config *AdapterConfig,
-	logger *zap.Logger,
-) error {
-	fluxConfig := &flux.Config{
-		Kubeconfig: config.Kubeconfig,
-		Namespace:  config.Namespace,
-	}
-
-	adapter, err := flux.NewAdapter(fluxConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create Flux adapter: %w", err)
-	}
-
-	adapterConfig := map[string]interface{}{
-		"namespace": config.Namespace,
-	}
-
-	if err := reg.Register(ctx, "flux", "flux", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register Flux adapter: %w", err)
-	}
-
-	logger.Info("Flux adapter registered", zap.String("namespace", config.Namespace))
+	logger.Info("DMS adapters initialized successfully")
 	return nil
 }
 
-// registerKustomizeAdapter initializes and registers the Kustomize adapter.
-func registerKustomizeAdapter(
+// RegisterAdapter builds an adapter of type typ and registers it under name,
+// so that several adapters of one type (e.g. Helm adapters for different
+// repositories or clusters) can be registered side by side. It may be called
+// while the gateway is serving. The adapter becomes the default if
+// config.IsDefault is set.
+func RegisterAdapter(
 	ctx context.Context,
 	reg *registry.Registry,
+	name string,
+	typ string,
 	config *AdapterConfig,
 	logger *zap.Logger,
 ) error {
-	kustomizeConfig := &kustomize.Config{
-		Kubeconfig: config.Kubeconfig,
-		Namespace:  config.Namespace,
-		BaseURL:    config.BaseURL,
-	}
-
-	adapter, err := kustomize.NewAdapter(kustomizeConfig)
+	adp, adapterConfig, err := NewAdapter(typ, config)
 	if err != nil {
-		return fmt.Errorf("failed to create Kustomize adapter: %w", err)
+		return err
 	}
 
-	adapterConfig := map[string]interface{}{
-		"namespace": config.Namespace,
-		"baseURL":   config.BaseURL,
+	if err := reg.Register(ctx, name, typ, adp, adapterConfig, config.IsDefault); err != nil {
+		_ = adp.Close()
+		return err
 	}
-
-	if err := reg.Register(ctx, "kustomize", "kustomize", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register Kustomize adapter: %w", err)
+	if config.NamespacePolicy != nil {
+		if err := reg.SetNamespacePolicy(name, config.NamespacePolicy); err != nil {
+			_ = reg.Unregister(name)
+			return err
+		}
 	}
 
-	logger.Info("Kustomize adapter registered", zap.String("namespace", config.Namespace))
+	logger.Info("DMS adapter registered",
+		zap.String("name", name),
+		zap.String("type", typ),
+		zap.String("namespace", config.Namespace),
+	)
 	return nil
 }
 
-// registerCrossplaneAdapter initializes and registers the Crossplane adapter.
-func registerCrossplaneAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	config *AdapterConfig,
-	logger *zap.Logger,
-) error {
-	crossplaneConfig := &crossplane.Config{
-		Kubeconfig: config.Kubeconfig,
-		Namespace:  config.Namespace,
-	}
-
-	adapter, err := crossplane.NewAdapter(crossplaneConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create Crossplane adapter: %w", err)
+// NewAdapter creates an adapter of type typ. It also returns the settings
+// the registry reports for the adapter, which never include credentials.
+func NewAdapter(typ string, config *AdapterConfig) (adapter.DMSAdapter, map[string]interface{}, error) {
+	if config == nil {
+		return nil, nil, fmt.Errorf("config cannot be nil")
 	}
 
+	var (
+		adp adapter.DMSAdapter
+		err error
+	)
 	adapterConfig := map[string]interface{}{
 		"namespace": config.Namespace,
 	}
 
-	if err := reg.Register(ctx, "crossplane", "crossplane", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register Crossplane adapter: %w", err)
-	}
-
-	logger.Info("Crossplane adapter registered", zap.String("namespace", config.Namespace))
-	return nil
-}
-
-// registerONAPLCMAdapter initializes and registers the ONAP-LCM adapter.
-func registerONAPLCMAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	config *AdapterConfig,
-	logger *zap.Logger,
-) error {
-	onapConfig := &onaplcm.Config{
-		SOEndpoint: config.ONAPURL,
-		Username:   config.Username,
-		Password:   config.Password,
-	}
-
-	adapter, err := onaplcm.NewAdapter(onapConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create ONAP-LCM adapter: %w", err)
-	}
-
-	adapterConfig := map[string]interface{}{
-		"apiURL":   config.ONAPURL,
-		"username": config.Username,
-	}
-
-	if err := reg.Register(ctx, "onaplcm", "onaplcm", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register ONAP-LCM adapter: %w", err)
-	}
-
-	logger.Info("ONAP-LCM adapter registered", zap.String("apiURL", config.ONAPURL))
-	return nil
-}
-
-// registerOSMLCMAdapter initializes and registers the OSM-LCM adapter.
-func registerOSMLCMAdapter(
-	ctx context.Context,
-	reg *registry.Registry,
-	config *AdapterConfig,
-	logger *zap.Logger,
-) error {
-	osmConfig := &osmlcm.Config{
-		NBIEndpoint: config.OSMURL,
-		Username:    config.Username,
-		Password:    config.Password,
+	switch typ {
+	case TypeHelm:
+		adp, err = helm.NewAdapter(&helm.Config{
+			Kubeconfig:         config.Kubeconfig,
+			Namespace:          config.Namespace,
+			RepositoryURL:      config.RepositoryURL,
+			RepositoryUsername: config.Username,
+			RepositoryPassword: config.Password,
+		})
+		adapterConfig["repositoryURL"] = config.RepositoryURL
+	case TypeArgoCD:
+		adp, err = argocd.NewAdapter(&argocd.Config{
+			Kubeconfig: config.Kubeconfig,
+			Namespace:  config.Namespace,
+		})
+	case TypeFlux:
+		adp, err = flux.NewAdapter(&flux.Config{
+			Kubeconfig: config.Kubeconfig,
+			Namespace:  config.Namespace,
+		})
+	case TypeKustomize:
+		adp, err = kustomize.NewAdapter(&kustomize.Config{
+			Kubeconfig: config.Kubeconfig,
+			Namespace:  config.Namespace,
+			BaseURL:    config.BaseURL,
+		})
+		adapterConfig["baseURL"] = config.BaseURL
+	case TypeManifests:
+		adp, err = manifests.NewAdapter(&manifests.Config{
+			Kubeconfig: config.Kubeconfig,
+			Namespace:  config.Namespace,
+		})
+	case TypeCrossplane:
+		adp, err = crossplane.NewAdapter(&crossplane.Config{
+			Kubeconfig: config.Kubeconfig,
+			Namespace:  config.Namespace,
+		})
+	case TypeONAPLCM:
+		adp, err = onaplcm.NewAdapter(&onaplcm.Config{
+			SOEndpoint: config.ONAPURL,
+			Username:   config.Username,
+			Password:   config.Password,
+		})
+		adapterConfig = map[string]interface{}{"apiURL": config.ONAPURL, "username": config.Username}
+	case TypeOSMLCM:
+		adp, err = osmlcm.NewAdapter(&osmlcm.Config{
+			NBIEndpoint: config.OSMURL,
+			Username:    config.Username,
+			Password:    config.Password,
+		})
+		adapterConfig = map[string]interface{}{"apiURL": config.OSMURL, "username": config.Username}
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownAdapterType, typ)
 	}
-
-	adapter, err := osmlcm.NewAdapter(osmConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create OSM-LCM adapter: %w", err)
-	}
-
-	adapterConfig := map[string]interface{}{
-		"apiURL":   config.OSMURL,
-		"username": config.Username,
+		return nil, nil, fmt.Errorf("failed to create %s adapter: %w", typ, err)
 	}
-
-	if err := reg.Register(ctx, "osmlcm", "osmlcm", adapter, adapterConfig, config.IsDefault); err != nil {
-		return fmt.Errorf("failed to register OSM-LCM adapter: %w", err)
-	}
-
-	logger.Info("OSM-LCM adapter registered", zap.String("apiURL", config.OSMURL))
-	return nil
+	return adp, adapterConfig, nil
 }
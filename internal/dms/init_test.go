@@ -76,6 +76,7 @@ func TestInitializeAdapters_DefaultConfig(t *testing.T) {
 	assert.Nil(t, reg.Get("argocd"))
 	assert.Nil(t, reg.Get("flux"))
 	assert.Nil(t, reg.Get("kustomize"))
+	assert.Nil(t, reg.Get("manifests"))
 	assert.Nil(t, reg.Get("crossplane"))
 	assert.Nil(t, reg.Get("onaplcm"))
 	assert.Nil(t, reg.Get("osmlcm"))
@@ -99,6 +100,10 @@ func TestInitializeAdapters_CustomConfig(t *testing.T) {
 			Enabled:   true,
 			Namespace: "kustomize-test",
 		},
+		Manifests: &dms.AdapterConfig{
+			Enabled:   true,
+			Namespace: "manifests-test",
+		},
 		Crossplane: &dms.AdapterConfig{
 			Enabled:   true,
 			Namespace: "crossplane-test",
@@ -118,6 +123,11 @@ func TestInitializeAdapters_CustomConfig(t *testing.T) {
 	require.NotNil(t, kustomizeAdapter)
 	assert.Equal(t, "kustomize", kustomizeAdapter.Name())
 
+	// Verify manifests adapter
+	manifestsAdapter := reg.Get("manifests")
+	require.NotNil(t, manifestsAdapter)
+	assert.Equal(t, "manifests", manifestsAdapter.Name())
+
 	// Verify Crossplane adapter
 	crossplaneAdapter := reg.Get("crossplane")
 	require.NotNil(t, crossplaneAdapter)
@@ -277,6 +287,7 @@ func TestInitializeAdapters_EmptyConfig(t *testing.T) {
 	assert.Nil(t, reg.Get("argocd"))
 	assert.Nil(t, reg.Get("flux"))
 	assert.Nil(t, reg.Get("kustomize"))
+	assert.Nil(t, reg.Get("manifests"))
 	assert.Nil(t, reg.Get("crossplane"))
 	assert.Nil(t, reg.Get("onaplcm"))
 	assert.Nil(t, reg.Get("osmlcm"))
@@ -317,3 +328,49 @@ func TestInitializeAdapters_MetadataVerification(t *testing.T) {
 	assert.NotNil(t, metadata.Config)
 	assert.Equal(t, "test-ns", metadata.Config["namespace"])
 }
+
+func TestRegisterAdapter(t *testing.T) {
+	logger := zap.NewNop()
+	reg := registry.NewRegistry(logger, nil)
+	defer func() { _ = reg.Close() }()
+
+	ctx := context.Background()
+
+	// Several adapters of one type register under their own names.
+	for _, name := range []string{"helm-edge", "helm-core"} {
+		err := dms.RegisterAdapter(ctx, reg, name, dms.TypeHelm, &dms.AdapterConfig{
+			Namespace:     name,
+			RepositoryURL: "https://charts.example.com/" + name,
+			Username:      "ci",
+			Password:      "secret",
+		}, logger)
+		require.NoError(t, err)
+	}
+
+	metadata := reg.GetMetadata("helm-core")
+	require.NotNil(t, metadata)
+	assert.Equal(t, dms.TypeHelm, metadata.Type)
+	assert.Equal(t, "helm-core", metadata.Config["namespace"])
+	assert.NotContains(t, metadata.Config, "password")
+	assert.Len(t, reg.FindByType(dms.TypeHelm), 2)
+
+	err := dms.RegisterAdapter(ctx, reg, "helm-edge", dms.TypeHelm, &dms.AdapterConfig{}, logger)
+	require.ErrorIs(t, err, registry.ErrPluginExists)
+
+	err = dms.RegisterAdapter(ctx, reg, "other", "unknown", &dms.AdapterConfig{}, logger)
+	require.ErrorIs(t, err, dms.ErrUnknownAdapterType)
+	assert.Nil(t, reg.Get("other"))
+}
+
+func TestNewAdapter_AllTypes(t *testing.T) {
+	for _, typ := range dms.AdapterTypes() {
+		adp, config, err := dms.NewAdapter(typ, &dms.AdapterConfig{
+			Namespace: "default",
+			ONAPURL:   "https://onap.example.com",
+			OSMURL:    "https://osm.example.com",
+		})
+		require.NoError(t, err, typ)
+		require.NotNil(t, adp, typ)
+		assert.NotNil(t, config, typ)
+	}
+}
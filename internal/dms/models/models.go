@@ -4,7 +4,10 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/piwi3910/netweave/internal/cost"
 )
 
 // NFDeployment represents an O2-DMS NF Deployment.
@@ -48,12 +51,20 @@ type NFDeployment struct {
 	// ParameterValues contains the deployment parameter values.
 	ParameterValues map[string]interface{} `json:"parameterValues,omitempty" yaml:"parameterValues,omitempty"`
 
+	// Notes are the rendered post-install notes of the current revision (e.g. Helm NOTES.txt).
+	// Only returned when retrieving a single deployment.
+	Notes string `json:"notes,omitempty" yaml:"notes,omitempty"`
+
 	// CreatedAt is the timestamp when the deployment was created.
 	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
 
 	// UpdatedAt is the timestamp of the last update.
 	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
 
+	// EstimatedCost is the estimated monthly cost of the deployment's resource
+	// requests. Only set when cost estimation is enabled.
+	EstimatedCost *cost.Estimate `json:"estimatedCost,omitempty" yaml:"estimatedCost,omitempty"`
+
 	// Extensions contains additional backend-specific or custom fields.
 	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
@@ -220,10 +231,19 @@ type DMSSubscription struct {
 	// UpdatedAt is the timestamp of the last update.
 	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt"`
 
+	// ExpiresAt is when the subscription expires (optional). Expired
+	// subscriptions receive no notifications and are deleted.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+
 	// Extensions contains additional backend-specific or custom fields.
 	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
+// Expired reports whether the subscription expired at now.
+func (s *DMSSubscription) Expired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
 // DMSSubscriptionFilter defines filtering criteria for DMS subscriptions.
 type DMSSubscriptionFilter struct {
 	// NFDeploymentIDs filters to specific NF deployments.
@@ -306,3 +326,179 @@ type DMSNotification struct {
 	// Extensions contains additional event-specific fields.
 	Extensions map[string]interface{} `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
+
+// DMSAdapter describes a registered DMS adapter and the result of its last
+// health check.
+type DMSAdapter struct {
+	// Name is the name requests select the adapter by.
+	Name string `json:"name"`
+
+	// Type is the adapter type (e.g., "helm", "argocd", "flux").
+	Type string `json:"type"`
+
+	// Version is the adapter version.
+	Version string `json:"version"`
+
+	// Default indicates the adapter serves requests that name no adapter.
+	Default bool `json:"default"`
+
+	// Enabled indicates the adapter is enabled.
+	Enabled bool `json:"enabled"`
+
+	// Capabilities lists the features the adapter supports.
+	Capabilities []string `json:"capabilities"`
+
+	// Healthy indicates the adapter passed its last health check.
+	Healthy bool `json:"healthy"`
+
+	// HealthError is the error of the last health check, if it failed.
+	HealthError string `json:"healthError,omitempty"`
+
+	// LastHealthCheck is when the adapter's health was last checked.
+	LastHealthCheck time.Time `json:"lastHealthCheck"`
+
+	// RegisteredAt is when the adapter was registered.
+	RegisteredAt time.Time `json:"registeredAt"`
+
+	// Config contains the adapter's settings, without credentials.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// DMSDeliveryStatus is the outcome of a DMS notification delivery.
+type DMSDeliveryStatus string
+
+const (
+	// DMSDeliveryStatusDelivered indicates the callback accepted the notification.
+	DMSDeliveryStatusDelivered DMSDeliveryStatus = "delivered"
+
+	// DMSDeliveryStatusFailed indicates every delivery attempt failed.
+	DMSDeliveryStatusFailed DMSDeliveryStatus = "failed"
+)
+
+// DMSDelivery records the delivery of a DMS notification to a subscription callback.
+type DMSDelivery struct {
+	// DeliveryID identifies the notification (X-O2DMS-Notification-ID header).
+	DeliveryID string `json:"deliveryId" yaml:"deliveryId"`
+
+	// SubscriptionID is the subscription the notification was delivered to.
+	SubscriptionID string `json:"subscriptionId" yaml:"subscriptionId"`
+
+	// EventType is the type of the delivered event.
+	EventType DMSEventType `json:"eventType" yaml:"eventType"`
+
+	// NFDeploymentID is the NF deployment the event is about.
+	NFDeploymentID string `json:"nfDeploymentId" yaml:"nfDeploymentId"`
+
+	// Status is the outcome of the delivery.
+	Status DMSDeliveryStatus `json:"status" yaml:"status"`
+
+	// Attempts is the number of delivery attempts made.
+	Attempts int `json:"attempts" yaml:"attempts"`
+
+	// HTTPStatusCode is the status code returned by the last attempt, if any.
+	HTTPStatusCode int `json:"httpStatusCode,omitempty" yaml:"httpStatusCode,omitempty"`
+
+	// LastError is the error of the last failed attempt.
+	LastError string `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+
+	// CreatedAt is when the event was detected.
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+
+	// CompletedAt is when the delivery succeeded or was given up.
+	CompletedAt time.Time `json:"completedAt" yaml:"completedAt"`
+}
+
+// DMSJobOperation is the deployment operation a DMS job performs.
+type DMSJobOperation string
+
+const (
+	// DMSJobOperationCreate creates an NF deployment.
+	DMSJobOperationCreate DMSJobOperation = "create"
+
+	// DMSJobOperationUpdate updates an NF deployment.
+	DMSJobOperationUpdate DMSJobOperation = "update"
+
+	// DMSJobOperationDelete deletes an NF deployment.
+	DMSJobOperationDelete DMSJobOperation = "delete"
+
+	// DMSJobOperationScale scales an NF deployment.
+	DMSJobOperationScale DMSJobOperation = "scale"
+
+	// DMSJobOperationRollback rolls back an NF deployment.
+	DMSJobOperationRollback DMSJobOperation = "rollback"
+)
+
+// DMSJobStatus is the state of a DMS job.
+type DMSJobStatus string
+
+const (
+	// DMSJobStatusPending indicates the job waits for a worker.
+	DMSJobStatusPending DMSJobStatus = "pending"
+
+	// DMSJobStatusRunning indicates a worker is executing the adapter call.
+	DMSJobStatusRunning DMSJobStatus = "running"
+
+	// DMSJobStatusSucceeded indicates the adapter call succeeded.
+	DMSJobStatusSucceeded DMSJobStatus = "succeeded"
+
+	// DMSJobStatusFailed indicates the adapter call failed or was interrupted.
+	DMSJobStatusFailed DMSJobStatus = "failed"
+
+	// DMSJobStatusCancelled indicates the job was cancelled through
+	// DELETE /o2dms/v1/operations/{jobId}.
+	DMSJobStatusCancelled DMSJobStatus = "cancelled"
+)
+
+// IsFinished reports whether the job reached a final status.
+func (s DMSJobStatus) IsFinished() bool {
+	return s == DMSJobStatusSucceeded || s == DMSJobStatusFailed || s == DMSJobStatusCancelled
+}
+
+// DMSJob is a long-running deployment operation executed asynchronously.
+// Mutating NF deployment endpoints return it with 202 Accepted and a
+// Location header pointing at GET /o2dms/v1/jobs/{jobId}.
+type DMSJob struct {
+	// JobID is the unique identifier of the job.
+	JobID string `json:"jobId" yaml:"jobId"`
+
+	// Operation is the deployment operation the job performs.
+	Operation DMSJobOperation `json:"operation" yaml:"operation"`
+
+	// Status is the state of the job.
+	Status DMSJobStatus `json:"status" yaml:"status"`
+
+	// Progress is the completion percentage of the job (0-100).
+	Progress int `json:"progress" yaml:"progress"`
+
+	// Message describes the current step of the job.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Error is the reason a failed job failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Adapter is the DMS adapter executing the operation.
+	Adapter string `json:"adapter" yaml:"adapter"`
+
+	// NFDeploymentID is the NF deployment the job acts on. For create jobs it
+	// is the requested deployment name until the adapter assigned the ID.
+	NFDeploymentID string `json:"nfDeploymentId,omitempty" yaml:"nfDeploymentId,omitempty"`
+
+	// TenantID is the tenant that submitted the job.
+	TenantID string `json:"tenantId,omitempty" yaml:"tenantId,omitempty"`
+
+	// Result is the NF deployment after a successful create or update job.
+	Result *NFDeployment `json:"result,omitempty" yaml:"result,omitempty"`
+
+	// Request is the adapter request the job executes. It is persisted with
+	// the job but never returned by the API.
+	Request json.RawMessage `json:"-" yaml:"-"`
+
+	// CreatedAt is when the job was submitted.
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+
+	// StartedAt is when a worker started the job.
+	StartedAt *time.Time `json:"startedAt,omitempty" yaml:"startedAt,omitempty"`
+
+	// CompletedAt is when the job succeeded or failed.
+	CompletedAt *time.Time `json:"completedAt,omitempty" yaml:"completedAt,omitempty"`
+}
@@ -1,6 +1,8 @@
 // Package models contains the O2-DMS data models for the netweave gateway.
 package models
 
+import "time"
+
 // CreateNFDeploymentRequest contains parameters for creating a new NF deployment.
 type CreateNFDeploymentRequest struct {
 	// Name is the deployment name.
@@ -56,6 +58,19 @@ type RollbackNFDeploymentRequest struct {
 	TargetRevision *int `json:"targetRevision,omitempty"`
 }
 
+// UpdateSyncPolicyRequest contains the sync policy fields of an NF deployment
+// to change. Omitted fields keep their current value.
+type UpdateSyncPolicyRequest struct {
+	// Automated switches between automated and manual sync.
+	Automated *bool `json:"automated,omitempty"`
+
+	// Prune deletes resources removed from the source during automated syncs.
+	Prune *bool `json:"prune,omitempty"`
+
+	// SelfHeal reverts out-of-band changes during automated syncs.
+	SelfHeal *bool `json:"selfHeal,omitempty"`
+}
+
 // CreateNFDeploymentDescriptorRequest contains parameters for creating a descriptor.
 type CreateNFDeploymentDescriptorRequest struct {
 	// Name is the descriptor name.
@@ -83,7 +98,8 @@ type CreateNFDeploymentDescriptorRequest struct {
 	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
-// CreateDMSSubscriptionRequest contains parameters for creating a DMS subscription.
+// CreateDMSSubscriptionRequest contains parameters for creating or replacing
+// a DMS subscription.
 type CreateDMSSubscriptionRequest struct {
 	// Callback is the webhook URL where notifications will be sent.
 	Callback string `json:"callback" binding:"required,url"`
@@ -94,10 +110,48 @@ type CreateDMSSubscriptionRequest struct {
 	// Filter specifies which events should trigger notifications.
 	Filter *DMSSubscriptionFilter `json:"filter,omitempty"`
 
+	// ExpiresAt optionally sets when the subscription expires and is
+	// deleted. Replacing the subscription with a later time refreshes it.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// Extensions provides vendor-specific fields.
 	Extensions map[string]interface{} `json:"extensions,omitempty"`
 }
 
+// RegisterDMSAdapterRequest contains parameters for registering a DMS adapter
+// at runtime.
+type RegisterDMSAdapterRequest struct {
+	// Name registers the adapter; requests select it with ?adapter=<name>.
+	Name string `json:"name" binding:"required"`
+
+	// Type is the adapter type (helm, argocd, flux, kustomize, manifests,
+	// crossplane, onaplcm or osmlcm).
+	Type string `json:"type" binding:"required"`
+
+	// Default makes the adapter the default DMS adapter.
+	Default bool `json:"default,omitempty"`
+
+	// Kubeconfig is the path of a kubeconfig on the gateway for the adapter's
+	// cluster. Empty uses the gateway's in-cluster configuration.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// Namespace is the adapter's default namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// RepositoryURL is the chart repository of a Helm adapter.
+	RepositoryURL string `json:"repositoryUrl,omitempty"`
+
+	// APIURL is the endpoint of an ONAP SO (onaplcm) or OSM NBI (osmlcm).
+	APIURL string `json:"apiUrl,omitempty"`
+
+	// Username authenticates to the chart repository or the ONAP/OSM API.
+	Username string `json:"username,omitempty"`
+
+	// Password authenticates to the chart repository or the ONAP/OSM API.
+	// It is never returned.
+	Password string `json:"password,omitempty"`
+}
+
 // NFDeploymentListResponse is the response for listing NF deployments.
 type NFDeploymentListResponse struct {
 	// NFDeployments is the list of NF deployments.
@@ -125,6 +179,26 @@ type DMSSubscriptionListResponse struct {
 	Total int `json:"total"`
 }
 
+// DMSAdapterListResponse is the response for listing the registered DMS
+// adapters.
+type DMSAdapterListResponse struct {
+	// Adapters is the list of adapters, sorted by name.
+	Adapters []*DMSAdapter `json:"adapters"`
+
+	// Total is the total number of adapters.
+	Total int `json:"total"`
+}
+
+// DMSDeliveryListResponse is the response for listing the notification
+// deliveries of a DMS subscription.
+type DMSDeliveryListResponse struct {
+	// Deliveries is the list of deliveries, most recent first.
+	Deliveries []*DMSDelivery `json:"deliveries"`
+
+	// Total is the total number of deliveries.
+	Total int `json:"total"`
+}
+
 // DeploymentHistoryResponse is the response for deployment history.
 type DeploymentHistoryResponse struct {
 	// NFDeploymentID is the deployment identifier.
@@ -149,6 +223,53 @@ type DeploymentRevision struct {
 	DeployedAt string `json:"deployedAt"`
 }
 
+// DeploymentNotesResponse is the response for deployment release notes.
+type DeploymentNotesResponse struct {
+	// NFDeploymentID is the deployment identifier.
+	NFDeploymentID string `json:"nfDeploymentId"`
+
+	// Revision is the revision the notes were rendered for.
+	Revision int `json:"revision"`
+
+	// Notes is the rendered notes text (e.g. Helm NOTES.txt).
+	Notes string `json:"notes"`
+}
+
+// DeploymentPreviewResponse is the response for a deployment update preview.
+type DeploymentPreviewResponse struct {
+	// NFDeploymentID is the deployment identifier.
+	NFDeploymentID string `json:"nfDeploymentId"`
+
+	// CurrentRevision is the revision the update is compared with.
+	CurrentRevision int `json:"currentRevision"`
+
+	// Manifests are the rendered manifests the update would apply, without
+	// the contents of Secrets.
+	Manifests string `json:"manifests"`
+
+	// Diff lists how the update changes each resource.
+	Diff []ResourceDiff `json:"diff"`
+}
+
+// ResourceDiff describes how an update changes one resource.
+type ResourceDiff struct {
+	// Kind is the resource kind, e.g. "Deployment".
+	Kind string `json:"kind"`
+
+	// Namespace is the resource namespace, if set in the manifest.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the resource name.
+	Name string `json:"name"`
+
+	// Change is one of added, removed, modified or unchanged.
+	Change string `json:"change"`
+
+	// Diff is a unified diff of the resource manifest. Omitted for
+	// unchanged resources and Secrets.
+	Diff string `json:"diff,omitempty"`
+}
+
 // DeploymentStatusResponse is the response for deployment status.
 type DeploymentStatusResponse struct {
 	// NFDeploymentID is the deployment identifier.
@@ -4,6 +4,7 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,6 +13,19 @@ import (
 	"go.uber.org/zap"
 )
 
+// AllPlugins is the SetNamespacePolicy key applying a policy to every plugin
+// without a policy of its own.
+const AllPlugins = "*"
+
+// Registry errors.
+var (
+	// ErrPluginExists is returned when registering a name that is taken.
+	ErrPluginExists = errors.New("DMS plugin already registered")
+
+	// ErrPluginNotFound is returned for names that are not registered.
+	ErrPluginNotFound = errors.New("DMS plugin not found")
+)
+
 // Default configuration values for the registry.
 const (
 	// DefaultHealthCheckInterval is the default interval between health checks.
@@ -66,6 +80,13 @@ type Registry struct {
 	DefaultPlugin string
 	logger        *zap.Logger
 
+	// namespacePolicies restrict plugins to namespaces, keyed by plugin name
+	// or AllPlugins.
+	namespacePolicies map[string]*adapter.NamespacePolicy
+
+	// decorate wraps plugins when they are registered; see Config.Decorate.
+	decorate func(name string, plugin adapter.DMSAdapter) adapter.DMSAdapter
+
 	// Health check configuration.
 	HealthCheckInterval time.Duration
 	HealthCheckTimeout  time.Duration
@@ -81,6 +102,11 @@ type Config struct {
 
 	// HealthCheckTimeout is the timeout for each health check.
 	HealthCheckTimeout time.Duration
+
+	// Decorate, if set, wraps every plugin when it is registered, e.g. with
+	// a circuit breaker. Get, List and the health checks return the wrapped
+	// plugin, and Unregister and Close close it.
+	Decorate func(name string, plugin adapter.DMSAdapter) adapter.DMSAdapter
 }
 
 // NewRegistry creates a new DMS plugin registry.
@@ -98,6 +124,8 @@ func NewRegistry(logger *zap.Logger, config *Config) *Registry {
 	return &Registry{
 		Plugins:             make(map[string]adapter.DMSAdapter),
 		meta:                make(map[string]*PluginMetadata),
+		namespacePolicies:   make(map[string]*adapter.NamespacePolicy),
+		decorate:            config.Decorate,
 		logger:              logger,
 		HealthCheckInterval: config.HealthCheckInterval,
 		HealthCheckTimeout:  config.HealthCheckTimeout,
@@ -115,14 +143,18 @@ func (r *Registry) Register(
 	config map[string]interface{},
 	isDefault bool,
 ) error {
-	r.Mu.Lock()
-	defer r.Mu.Unlock()
-
-	if _, exists := r.Plugins[name]; exists {
-		return fmt.Errorf("DMS plugin %s already registered", name)
+	r.Mu.RLock()
+	_, exists := r.Plugins[name]
+	r.Mu.RUnlock()
+	if exists {
+		return fmt.Errorf("%w: %s", ErrPluginExists, name)
+	}
+	if r.decorate != nil {
+		plugin = r.decorate(name, plugin)
 	}
 
-	// Perform initial health check.
+	// Perform initial health check. The registry is not locked meanwhile, so
+	// registering at runtime doesn't stall requests to the other plugins.
 	healthy := true
 	var healthErr error
 	healthCtx, cancel := context.WithTimeout(ctx, r.HealthCheckTimeout)
@@ -151,10 +183,19 @@ func (r *Registry) Register(
 		Config:          config,
 	}
 
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+
+	if _, exists := r.Plugins[name]; exists {
+		return fmt.Errorf("%w: %s", ErrPluginExists, name)
+	}
 	r.Plugins[name] = plugin
 	r.meta[name] = meta
 
 	if isDefault {
+		if previous := r.meta[r.DefaultPlugin]; previous != nil {
+			previous.Default = false
+		}
 		r.DefaultPlugin = name
 	}
 
@@ -177,7 +218,7 @@ func (r *Registry) Unregister(name string) error {
 
 	plugin, exists := r.Plugins[name]
 	if !exists {
-		return fmt.Errorf("DMS plugin %s not found", name)
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, name)
 	}
 
 	if err := plugin.Close(); err != nil {
@@ -231,6 +272,56 @@ func (r *Registry) GetDefaultName() string {
 	return r.DefaultPlugin
 }
 
+// SetNamespacePolicy restricts the namespaces the named plugin may act on
+// through the API. name may be AllPlugins, and the plugin does not have to be
+// registered yet. A nil policy removes the restriction.
+func (r *Registry) SetNamespacePolicy(name string, policy *adapter.NamespacePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid namespace policy for DMS plugin %s: %w", name, err)
+	}
+
+	r.Mu.Lock()
+	defer r.Mu.Unlock()
+
+	if policy == nil {
+		delete(r.namespacePolicies, name)
+		return nil
+	}
+	r.namespacePolicies[name] = policy
+	return nil
+}
+
+// NamespacePolicy returns the namespace policy of the named plugin, falling
+// back to the AllPlugins policy. Returns nil if no policy applies.
+func (r *Registry) NamespacePolicy(name string) *adapter.NamespacePolicy {
+	r.Mu.RLock()
+	defer r.Mu.RUnlock()
+
+	if policy, ok := r.namespacePolicies[name]; ok {
+		return policy
+	}
+	return r.namespacePolicies[AllPlugins]
+}
+
+// NamespacePolicyOf returns the namespace policy of a registered plugin
+// instance, for callers iterating List. Unregistered plugins get the
+// AllPlugins policy.
+func (r *Registry) NamespacePolicyOf(plugin adapter.DMSAdapter) *adapter.NamespacePolicy {
+	r.Mu.RLock()
+	defer r.Mu.RUnlock()
+
+	for name, p := range r.Plugins {
+		if p != plugin {
+			continue
+		}
+		if policy, ok := r.namespacePolicies[name]; ok {
+			return policy
+		}
+		break
+	}
+	return r.namespacePolicies[AllPlugins]
+}
+
 // GetMetadata retrieves metadata for a DMS plugin.
 // Returns nil if the plugin is not found.
 func (r *Registry) GetMetadata(name string) *PluginMetadata {
@@ -386,7 +477,7 @@ func (r *Registry) Enable(name string) error {
 
 	meta := r.meta[name]
 	if meta == nil {
-		return fmt.Errorf("DMS plugin %s not found", name)
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, name)
 	}
 
 	meta.Enabled = true
@@ -405,7 +496,7 @@ func (r *Registry) Disable(name string) error {
 
 	meta := r.meta[name]
 	if meta == nil {
-		return fmt.Errorf("DMS plugin %s not found", name)
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, name)
 	}
 
 	meta.Enabled = false
@@ -423,7 +514,7 @@ func (r *Registry) SetDefault(name string) error {
 	defer r.Mu.Unlock()
 
 	if _, exists := r.Plugins[name]; !exists {
-		return fmt.Errorf("DMS plugin %s not found", name)
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, name)
 	}
 
 	// Clear previous default.
@@ -485,6 +576,12 @@ func (r *Registry) healthCheckLoop(ctx context.Context) {
 	}
 }
 
+// CheckHealth checks the health of all registered DMS plugins now, rather
+// than at the next health check interval.
+func (r *Registry) CheckHealth(ctx context.Context) {
+	r.performHealthChecks(ctx)
+}
+
 // performHealthChecks checks health of all registered DMS plugins.
 func (r *Registry) performHealthChecks(ctx context.Context) {
 	r.Mu.RLock()
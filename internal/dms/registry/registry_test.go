@@ -158,7 +158,7 @@ func TestRegistry_RegisterDuplicate(t *testing.T) {
 
 	// Try to register again with the same name.
 	err = reg.Register(context.Background(), "test-adapter", "mock", mockAdp, nil, false)
-	assert.Error(t, err)
+	require.ErrorIs(t, err, registry.ErrPluginExists)
 	assert.Contains(t, err.Error(), "already registered")
 }
 
@@ -185,6 +185,19 @@ func TestRegistry_RegisterDefault(t *testing.T) {
 	assert.Equal(t, "default-adapter", defaultAdp.Name())
 }
 
+func TestRegistry_RegisterReplacesDefault(t *testing.T) {
+	reg := registry.NewRegistry(zap.NewNop(), nil)
+	defer func() { _ = reg.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, reg.Register(ctx, "first", "mock", newMockDMSAdapter("first"), nil, true))
+	require.NoError(t, reg.Register(ctx, "second", "mock", newMockDMSAdapter("second"), nil, true))
+
+	assert.Equal(t, "second", reg.GetDefaultName())
+	assert.False(t, reg.GetMetadata("first").Default)
+	assert.True(t, reg.GetMetadata("second").Default)
+}
+
 func TestRegistry_Unregister(t *testing.T) {
 	logger := zap.NewNop()
 	reg := registry.NewRegistry(logger, nil)
@@ -955,3 +968,32 @@ func TestRegistry_ListMetadataDeepCopy_MultipleAdapters(t *testing.T) {
 		assert.False(t, exists, "config should not be modified")
 	}
 }
+
+func TestRegistry_NamespacePolicy(t *testing.T) {
+	reg := registry.NewRegistry(zap.NewNop(), nil)
+	defer func() { _ = reg.Close() }()
+	helm := newMockDMSAdapter("helm")
+	flux := newMockDMSAdapter("flux")
+	require.NoError(t, reg.Register(context.Background(), "helm", "helm", helm, nil, true))
+	require.NoError(t, reg.Register(context.Background(), "flux", "flux", flux, nil, false))
+
+	assert.Nil(t, reg.NamespacePolicy("helm"))
+
+	all := &adapter.NamespacePolicy{Deny: []string{"kube-system"}}
+	helmOnly := &adapter.NamespacePolicy{Allow: []string{"ran-*"}}
+	require.NoError(t, reg.SetNamespacePolicy(registry.AllPlugins, all))
+	require.NoError(t, reg.SetNamespacePolicy("helm", helmOnly))
+
+	assert.Same(t, helmOnly, reg.NamespacePolicy("helm"))
+	assert.Same(t, all, reg.NamespacePolicy("flux"))
+	assert.Same(t, helmOnly, reg.NamespacePolicyOf(helm))
+	assert.Same(t, all, reg.NamespacePolicyOf(flux))
+	assert.Same(t, all, reg.NamespacePolicyOf(newMockDMSAdapter("unregistered")))
+
+	err := reg.SetNamespacePolicy("helm", &adapter.NamespacePolicy{Deny: []string{"["}})
+	require.Error(t, err)
+	assert.Same(t, helmOnly, reg.NamespacePolicy("helm"), "an invalid policy is not applied")
+
+	require.NoError(t, reg.SetNamespacePolicy("helm", nil))
+	assert.Same(t, all, reg.NamespacePolicy("helm"))
+}
@@ -0,0 +1,139 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+)
+
+func newFakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{storage.DMSSubscriptionGVR: "DMSSubscriptionList"})
+}
+
+func newStartedCRDStore(t *testing.T, client *dynamicfake.FakeDynamicClient) *storage.CRDStore {
+	t.Helper()
+	store := storage.NewCRDStore(client, "o2ims-system")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, store.Start(ctx))
+	return store
+}
+
+func TestStoreBackends(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	backends := map[string]func(t *testing.T) storage.Store{
+		"memory": func(_ *testing.T) storage.Store { return storage.NewMemoryStore() },
+		"redis":  func(_ *testing.T) storage.Store { return storage.NewRedisStore(client) },
+		"kubernetes": func(t *testing.T) storage.Store {
+			return newStartedCRDStore(t, newFakeDynamicClient())
+		},
+	}
+
+	for name, newStore := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore(t)
+			defer func() { require.NoError(t, store.Close()) }()
+			require.NoError(t, store.Ping(ctx))
+
+			_, err := store.Get(ctx, "missing")
+			require.ErrorIs(t, err, storage.ErrSubscriptionNotFound)
+
+			sub := &models.DMSSubscription{
+				SubscriptionID: "3f1c2a4e-0000-4000-8000-000000000001",
+				Callback:       "https://smo.example.com/dms",
+				Filter:         &models.DMSSubscriptionFilter{NFDeploymentIDs: []string{"nfd-1"}},
+				Extensions:     map[string]interface{}{"team": "ran"},
+			}
+			require.NoError(t, store.Create(ctx, sub))
+			require.ErrorIs(t, store.Create(ctx, &models.DMSSubscription{SubscriptionID: sub.SubscriptionID}),
+				storage.ErrSubscriptionExists)
+
+			got, err := store.Get(ctx, sub.SubscriptionID)
+			require.NoError(t, err)
+			assert.Equal(t, sub.Callback, got.Callback)
+			require.NotNil(t, got.Filter)
+			assert.Equal(t, []string{"nfd-1"}, got.Filter.NFDeploymentIDs)
+			assert.Equal(t, "ran", got.Extensions["team"])
+			createdAt := got.CreatedAt
+			assert.False(t, createdAt.IsZero())
+
+			require.NoError(t, store.Create(ctx, &models.DMSSubscription{
+				SubscriptionID: "Sub_With_Invalid_Name",
+				Callback:       "https://smo.example.com/other",
+			}))
+
+			subs, err := store.List(ctx)
+			require.NoError(t, err)
+			assert.Len(t, subs, 2)
+
+			update := &models.DMSSubscription{SubscriptionID: sub.SubscriptionID, Callback: "https://smo.example.com/v2"}
+			require.NoError(t, store.Update(ctx, update))
+			got, err = store.Get(ctx, sub.SubscriptionID)
+			require.NoError(t, err)
+			assert.Equal(t, "https://smo.example.com/v2", got.Callback)
+			assert.True(t, createdAt.Equal(got.CreatedAt), "update must preserve createdAt")
+
+			require.ErrorIs(t, store.Update(ctx, &models.DMSSubscription{SubscriptionID: "missing"}),
+				storage.ErrSubscriptionNotFound)
+
+			require.NoError(t, store.Delete(ctx, sub.SubscriptionID))
+			require.ErrorIs(t, store.Delete(ctx, sub.SubscriptionID), storage.ErrSubscriptionNotFound)
+			_, err = store.Get(ctx, sub.SubscriptionID)
+			require.ErrorIs(t, err, storage.ErrSubscriptionNotFound)
+
+			got, err = store.Get(ctx, "Sub_With_Invalid_Name")
+			require.NoError(t, err)
+			assert.Equal(t, "https://smo.example.com/other", got.Callback)
+		})
+	}
+}
+
+func TestCRDStore_SharedAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeDynamicClient()
+	replicaA := newStartedCRDStore(t, client)
+	defer func() { _ = replicaA.Close() }()
+	replicaB := newStartedCRDStore(t, client)
+	defer func() { _ = replicaB.Close() }()
+
+	require.NoError(t, replicaA.Create(ctx, &models.DMSSubscription{
+		SubscriptionID: "sub-a",
+		Callback:       "https://smo.example.com/dms",
+	}))
+
+	// Direct reads fall back to the API server until the watch event arrives.
+	got, err := replicaB.Get(ctx, "sub-a")
+	require.NoError(t, err)
+	assert.Equal(t, "https://smo.example.com/dms", got.Callback)
+
+	// Lists are served from the informer cache once the watch event arrives.
+	assert.Eventually(t, func() bool {
+		subs, err := replicaB.List(ctx)
+		return err == nil && len(subs) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCRDStore_NotStarted(t *testing.T) {
+	store := storage.NewCRDStore(newFakeDynamicClient(), "o2ims-system")
+	defer func() { _ = store.Close() }()
+
+	_, err := store.List(context.Background())
+	require.ErrorIs(t, err, storage.ErrStoreNotStarted)
+	require.ErrorIs(t, store.Ping(context.Background()), storage.ErrStoreNotStarted)
+}
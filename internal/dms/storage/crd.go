@@ -0,0 +1,308 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// DMSSubscription CRD identifiers.
+//
+// With the Kubernetes storage backend, DMS subscriptions are persisted as
+// namespaced DMSSubscription objects, so no external datastore is needed.
+const (
+	// DMSSubscriptionGroup is the DMSSubscription API group.
+	DMSSubscriptionGroup = "o2ims.io"
+
+	// DMSSubscriptionVersion is the DMSSubscription API version.
+	DMSSubscriptionVersion = "v1alpha1"
+
+	// DMSSubscriptionKind is the DMSSubscription kind.
+	DMSSubscriptionKind = "DMSSubscription"
+
+	// DMSSubscriptionResource is the DMSSubscription resource name.
+	DMSSubscriptionResource = "dmssubscriptions"
+
+	// dmsSubscriptionIDLabel records the subscription ID on each object.
+	dmsSubscriptionIDLabel = "o2ims.io/subscription-id"
+
+	// crdInformerResync is the informer resync period.
+	crdInformerResync = 10 * time.Minute
+)
+
+// DMSSubscriptionGVR is the GroupVersionResource of the DMSSubscription CRD.
+var DMSSubscriptionGVR = schema.GroupVersionResource{
+	Group:    DMSSubscriptionGroup,
+	Version:  DMSSubscriptionVersion,
+	Resource: DMSSubscriptionResource,
+}
+
+// ErrStoreNotStarted is returned by CRDStore reads before Start has synced the informer.
+var ErrStoreNotStarted = errors.New("subscription store informer has not synced")
+
+// CRDStore is a Kubernetes-backed implementation of the Store interface.
+// Writes go to the API server; reads are served from an informer cache of the
+// namespace, which every replica keeps in sync through watches.
+type CRDStore struct {
+	client    dynamic.Interface
+	namespace string
+	informer  cache.SharedIndexInformer
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewCRDStore creates a DMS subscription store persisting subscriptions as
+// DMSSubscription objects in namespace. Start must be called before use.
+func NewCRDStore(client dynamic.Interface, namespace string) *CRDStore {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, crdInformerResync, namespace, nil)
+	return &CRDStore{
+		client:    client,
+		namespace: namespace,
+		informer:  factory.ForResource(DMSSubscriptionGVR).Informer(),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start runs the informer and waits until its cache has synced or ctx is done.
+// The informer keeps running until Close.
+func (s *CRDStore) Start(ctx context.Context) error {
+	go s.informer.Run(s.stopCh)
+
+	if !cache.WaitForCacheSync(ctx.Done(), s.informer.HasSynced) {
+		return fmt.Errorf("failed to sync %s informer: %w", DMSSubscriptionKind, ErrStoreNotStarted)
+	}
+	return nil
+}
+
+// dmsSubscriptionName returns the object name for a subscription ID. IDs that
+// are not valid DNS-1123 subdomains are hashed.
+func dmsSubscriptionName(id string) string {
+	if len(validation.IsDNS1123Subdomain(id)) == 0 {
+		return id
+	}
+	sum := sha256.Sum256([]byte(id))
+	return "sub-" + hex.EncodeToString(sum[:16])
+}
+
+func (s *CRDStore) resource() dynamic.ResourceInterface {
+	return s.client.Resource(DMSSubscriptionGVR).Namespace(s.namespace)
+}
+
+// toObject converts a subscription to a DMSSubscription object.
+func (s *CRDStore) toObject(sub *models.DMSSubscription) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to convert subscription: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": DMSSubscriptionGroup + "/" + DMSSubscriptionVersion,
+			"kind":       DMSSubscriptionKind,
+			"metadata": map[string]interface{}{
+				"name":      dmsSubscriptionName(sub.SubscriptionID),
+				"namespace": s.namespace,
+			},
+			"spec": spec,
+		},
+	}
+	objLabels := map[string]string{"o2ims.io/managed": "true"}
+	if len(validation.IsValidLabelValue(sub.SubscriptionID)) == 0 {
+		objLabels[dmsSubscriptionIDLabel] = sub.SubscriptionID
+	}
+	obj.SetLabels(objLabels)
+	return obj, nil
+}
+
+// fromObject converts a DMSSubscription object to a subscription.
+func fromObject(obj *unstructured.Unstructured) (*models.DMSSubscription, error) {
+	spec, ok := obj.Object["spec"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s %s has no spec", DMSSubscriptionKind, obj.GetName())
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s spec: %w", DMSSubscriptionKind, err)
+	}
+	var sub models.DMSSubscription
+	if err := json.Unmarshal(data, &sub); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s spec: %w", DMSSubscriptionKind, err)
+	}
+	return &sub, nil
+}
+
+// cached returns the object for id from the informer cache.
+func (s *CRDStore) cached(id string) (*unstructured.Unstructured, bool, error) {
+	if !s.informer.HasSynced() {
+		return nil, false, ErrStoreNotStarted
+	}
+	item, exists, err := s.informer.GetStore().GetByKey(s.namespace + "/" + dmsSubscriptionName(id))
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	obj, ok := item.(*unstructured.Unstructured)
+	return obj, ok, nil
+}
+
+// Create creates a new subscription.
+func (s *CRDStore) Create(ctx context.Context, sub *models.DMSSubscription) error {
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
+
+	obj, err := s.toObject(sub)
+	if err != nil {
+		return err
+	}
+	created, err := s.resource().Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return ErrSubscriptionExists
+		}
+		return fmt.Errorf("failed to create %s: %w", DMSSubscriptionKind, err)
+	}
+
+	// Make the write visible to reads on this replica before the watch event arrives.
+	_ = s.informer.GetStore().Add(created)
+	return nil
+}
+
+// Get retrieves a subscription by ID. Cache misses are confirmed against the
+// API server so subscriptions created on other replicas are found before the
+// watch event arrives.
+func (s *CRDStore) Get(ctx context.Context, id string) (*models.DMSSubscription, error) {
+	obj, ok, err := s.cached(id)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return fromObject(obj)
+	}
+
+	obj, err = s.resource().Get(ctx, dmsSubscriptionName(id), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", DMSSubscriptionKind, err)
+	}
+	return fromObject(obj)
+}
+
+// List retrieves all subscriptions from the informer cache.
+func (s *CRDStore) List(_ context.Context) ([]*models.DMSSubscription, error) {
+	if !s.informer.HasSynced() {
+		return nil, ErrStoreNotStarted
+	}
+
+	items, err := cache.NewGenericLister(s.informer.GetIndexer(), DMSSubscriptionGVR.GroupResource()).
+		ByNamespace(s.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s objects: %w", DMSSubscriptionKind, err)
+	}
+
+	subs := make([]*models.DMSSubscription, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		sub, err := fromObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// Update updates an existing subscription, retrying on resource version conflicts.
+func (s *CRDStore) Update(ctx context.Context, sub *models.DMSSubscription) error {
+	var updated *unstructured.Unstructured
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := s.resource().Get(ctx, dmsSubscriptionName(sub.SubscriptionID), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing, err := fromObject(current)
+		if err != nil {
+			return err
+		}
+
+		// Preserve the original creation time.
+		sub.CreatedAt = existing.CreatedAt
+		timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
+
+		obj, err := s.toObject(sub)
+		if err != nil {
+			return err
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+		updated, err = s.resource().Update(ctx, obj, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrSubscriptionNotFound
+		}
+		return fmt.Errorf("failed to update %s: %w", DMSSubscriptionKind, err)
+	}
+
+	_ = s.informer.GetStore().Update(updated)
+	return nil
+}
+
+// Delete deletes a subscription by ID.
+func (s *CRDStore) Delete(ctx context.Context, id string) error {
+	name := dmsSubscriptionName(id)
+	if err := s.resource().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrSubscriptionNotFound
+		}
+		return fmt.Errorf("failed to delete %s: %w", DMSSubscriptionKind, err)
+	}
+
+	if obj, ok, _ := s.cached(id); ok {
+		_ = s.informer.GetStore().Delete(obj)
+	}
+	return nil
+}
+
+// Ping checks that the informer has synced and the API server is reachable.
+func (s *CRDStore) Ping(ctx context.Context) error {
+	if !s.informer.HasSynced() {
+		return ErrStoreNotStarted
+	}
+	if _, err := s.resource().List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return fmt.Errorf("failed to reach %s API: %w", DMSSubscriptionKind, err)
+	}
+	return nil
+}
+
+// Close stops the informer.
+func (s *CRDStore) Close() error {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+)
+
+// DefaultDeliveryHistory is the number of deliveries kept per subscription.
+const DefaultDeliveryHistory = 100
+
+// DeliveryStore records the delivery status of DMS notifications per subscription.
+type DeliveryStore interface {
+	// Record stores a delivery of a notification to a subscription.
+	Record(ctx context.Context, delivery *models.DMSDelivery) error
+
+	// List returns the deliveries of a subscription, most recent first.
+	List(ctx context.Context, subscriptionID string) ([]*models.DMSDelivery, error)
+
+	// DeleteSubscription forgets the deliveries of a deleted subscription.
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+}
+
+// MemoryDeliveryStore is an in-memory implementation of the DeliveryStore
+// interface. It keeps the most recent deliveries of each subscription.
+type MemoryDeliveryStore struct {
+	mu         sync.RWMutex
+	history    int
+	deliveries map[string][]*models.DMSDelivery
+}
+
+// NewMemoryDeliveryStore creates an in-memory delivery store keeping history
+// deliveries per subscription, or DefaultDeliveryHistory if history is not positive.
+func NewMemoryDeliveryStore(history int) *MemoryDeliveryStore {
+	if history <= 0 {
+		history = DefaultDeliveryHistory
+	}
+	return &MemoryDeliveryStore{
+		history:    history,
+		deliveries: make(map[string][]*models.DMSDelivery),
+	}
+}
+
+// Record stores a delivery, dropping the oldest delivery of the subscription
+// once its history is full.
+func (s *MemoryDeliveryStore) Record(_ context.Context, delivery *models.DMSDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Store a copy to prevent external modification.
+	deliveryCopy := *delivery
+	deliveries := append(s.deliveries[delivery.SubscriptionID], &deliveryCopy)
+	if len(deliveries) > s.history {
+		deliveries = deliveries[len(deliveries)-s.history:]
+	}
+	s.deliveries[delivery.SubscriptionID] = deliveries
+
+	return nil
+}
+
+// List returns the deliveries of a subscription, most recent first.
+func (s *MemoryDeliveryStore) List(_ context.Context, subscriptionID string) ([]*models.DMSDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	deliveries := s.deliveries[subscriptionID]
+	result := make([]*models.DMSDelivery, 0, len(deliveries))
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		deliveryCopy := *deliveries[i]
+		result = append(result, &deliveryCopy)
+	}
+
+	return result, nil
+}
+
+// DeleteSubscription forgets the deliveries of a subscription.
+func (s *MemoryDeliveryStore) DeleteSubscription(_ context.Context, subscriptionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deliveries, subscriptionID)
+	return nil
+}
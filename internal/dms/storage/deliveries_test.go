@@ -0,0 +1,48 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/piwi3910/netweave/internal/dms/storage"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDeliveryStore_KeepsRecentHistory(t *testing.T) {
+	store := storage.NewMemoryDeliveryStore(3)
+	ctx := context.Background()
+
+	for i := range 5 {
+		require.NoError(t, store.Record(ctx, &models.DMSDelivery{
+			DeliveryID:     fmt.Sprintf("delivery-%d", i),
+			SubscriptionID: "sub-1",
+			Status:         models.DMSDeliveryStatusDelivered,
+		}))
+	}
+	require.NoError(t, store.Record(ctx, &models.DMSDelivery{DeliveryID: "other", SubscriptionID: "sub-2"}))
+
+	deliveries, err := store.List(ctx, "sub-1")
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	assert.Equal(t, "delivery-4", deliveries[0].DeliveryID)
+	assert.Equal(t, "delivery-2", deliveries[2].DeliveryID)
+
+	// Returned deliveries are copies.
+	deliveries[0].Status = models.DMSDeliveryStatusFailed
+	again, err := store.List(ctx, "sub-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.DMSDeliveryStatusDelivered, again[0].Status)
+
+	require.NoError(t, store.DeleteSubscription(ctx, "sub-1"))
+	deliveries, err = store.List(ctx, "sub-1")
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	other, err := store.List(ctx, "sub-2")
+	require.NoError(t, err)
+	assert.Len(t, other, 1)
+}
@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// ErrJobNotFound is returned when a DMS job is not found.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobConflict is returned by Transition when a DMS job is no longer in the
+// expected status.
+var ErrJobConflict = errors.New("job status changed")
+
+// jobTransitionAttempts is how often a Redis job transition is retried when
+// the job is written concurrently without its status changing.
+const jobTransitionAttempts = 10
+
+// DefaultJobRetention is how long finished jobs are kept.
+const DefaultJobRetention = 24 * time.Hour
+
+// Redis keys for DMS jobs. Active (pending or running) jobs are indexed in a
+// sorted set scored by the time their runner last reported them alive.
+const (
+	dmsJobKeyPrefix  = "dms:job:"
+	dmsActiveJobsKey = "dms:jobs:active"
+)
+
+// JobStore persists asynchronous DMS jobs so that their status survives
+// gateway restarts.
+//
+// Runners report the jobs they are working on with Heartbeat. Jobs whose
+// runner stopped reporting them are handed to another runner by ClaimStale.
+type JobStore interface {
+	// Create stores a new job and marks it active.
+	Create(ctx context.Context, job *models.DMSJob) error
+
+	// Get retrieves a job by ID. Returns ErrJobNotFound if it does not exist
+	// or has expired.
+	Get(ctx context.Context, id string) (*models.DMSJob, error)
+
+	// Update stores the new state of a job. Unfinished jobs are marked
+	// active; finished jobs are no longer active and expire after the
+	// retention period.
+	Update(ctx context.Context, job *models.DMSJob) error
+
+	// Transition stores the new state of a job only if its stored status is
+	// from, so that concurrent status changes, such as a worker starting a job
+	// while it is cancelled, cannot overwrite each other. Returns
+	// ErrJobConflict if the status differs and ErrJobNotFound if the job does
+	// not exist.
+	Transition(ctx context.Context, job *models.DMSJob, from models.DMSJobStatus) error
+
+	// Heartbeat records that the runner of the given active jobs is alive.
+	Heartbeat(ctx context.Context, ids []string, at time.Time) error
+
+	// ClaimStale returns the active jobs last reported alive before the given
+	// time and stops tracking them as active. Each stale job is returned to
+	// exactly one caller, which then owns it.
+	ClaimStale(ctx context.Context, before time.Time) ([]*models.DMSJob, error)
+}
+
+// MemoryJobStore is an in-memory implementation of the JobStore interface.
+// Jobs are lost when the gateway restarts; use RedisJobStore in production.
+type MemoryJobStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	jobs      map[string]*models.DMSJob
+	active    map[string]time.Time
+}
+
+// NewMemoryJobStore creates an in-memory job store keeping finished jobs for
+// retention, or DefaultJobRetention if retention is not positive.
+func NewMemoryJobStore(retention time.Duration) *MemoryJobStore {
+	if retention <= 0 {
+		retention = DefaultJobRetention
+	}
+	return &MemoryJobStore{
+		retention: retention,
+		jobs:      make(map[string]*models.DMSJob),
+		active:    make(map[string]time.Time),
+	}
+}
+
+// Create stores a new job and drops finished jobs past the retention period.
+func (s *MemoryJobStore) Create(_ context.Context, job *models.DMSJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := timeutil.Now()
+	for id, j := range s.jobs {
+		if j.CompletedAt != nil && now.Sub(*j.CompletedAt) > s.retention {
+			delete(s.jobs, id)
+		}
+	}
+
+	jobCopy := *job
+	s.jobs[job.JobID] = &jobCopy
+	s.active[job.JobID] = now
+	return nil
+}
+
+// Get retrieves a job by ID.
+func (s *MemoryJobStore) Get(_ context.Context, id string) (*models.DMSJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok || (job.CompletedAt != nil && timeutil.Now().Sub(*job.CompletedAt) > s.retention) {
+		return nil, ErrJobNotFound
+	}
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+// Update stores the new state of a job.
+func (s *MemoryJobStore) Update(_ context.Context, job *models.DMSJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.JobID]; !ok {
+		return ErrJobNotFound
+	}
+	jobCopy := *job
+	s.jobs[job.JobID] = &jobCopy
+	if job.Status.IsFinished() {
+		delete(s.active, job.JobID)
+	} else {
+		s.active[job.JobID] = timeutil.Now()
+	}
+	return nil
+}
+
+// Transition stores the new state of a job if its stored status is from.
+func (s *MemoryJobStore) Transition(_ context.Context, job *models.DMSJob, from models.DMSJobStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.jobs[job.JobID]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if current.Status != from {
+		return ErrJobConflict
+	}
+	jobCopy := *job
+	s.jobs[job.JobID] = &jobCopy
+	if job.Status.IsFinished() {
+		delete(s.active, job.JobID)
+	} else {
+		s.active[job.JobID] = timeutil.Now()
+	}
+	return nil
+}
+
+// Heartbeat records that the runner of the given active jobs is alive.
+func (s *MemoryJobStore) Heartbeat(_ context.Context, ids []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := s.active[id]; ok {
+			s.active[id] = at
+		}
+	}
+	return nil
+}
+
+// ClaimStale returns the active jobs last reported alive before the given time.
+func (s *MemoryJobStore) ClaimStale(_ context.Context, before time.Time) ([]*models.DMSJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stale []*models.DMSJob
+	for id, at := range s.active {
+		if !at.Before(before) {
+			continue
+		}
+		delete(s.active, id)
+		if job, ok := s.jobs[id]; ok {
+			jobCopy := *job
+			stale = append(stale, &jobCopy)
+		}
+	}
+	return stale, nil
+}
+
+// redisJob is the persisted form of a job, including its adapter request.
+type redisJob struct {
+	*models.DMSJob
+	Request json.RawMessage `json:"request,omitempty"`
+}
+
+// RedisJobStore is a Redis-backed implementation of the JobStore interface.
+// Jobs are shared by every gateway replica using the same Redis.
+type RedisJobStore struct {
+	client    redis.UniversalClient
+	retention time.Duration
+}
+
+// NewRedisJobStore creates a job store on client keeping finished jobs for
+// retention, or DefaultJobRetention if retention is not positive.
+// The client is owned by the caller.
+func NewRedisJobStore(client redis.UniversalClient, retention time.Duration) *RedisJobStore {
+	if retention <= 0 {
+		retention = DefaultJobRetention
+	}
+	return &RedisJobStore{client: client, retention: retention}
+}
+
+// Create stores a new job and marks it active.
+func (s *RedisJobStore) Create(ctx context.Context, job *models.DMSJob) error {
+	data, err := json.Marshal(redisJob{DMSJob: job, Request: job.Request})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, dmsJobKeyPrefix+job.JobID, data, 0)
+	pipe.ZAdd(ctx, dmsActiveJobsKey, redis.Z{Score: float64(timeutil.Now().UnixMilli()), Member: job.JobID})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a job by ID.
+func (s *RedisJobStore) Get(ctx context.Context, id string) (*models.DMSJob, error) {
+	data, err := s.client.Get(ctx, dmsJobKeyPrefix+id).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	stored := redisJob{DMSJob: &models.DMSJob{}}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+	stored.DMSJob.Request = stored.Request
+	return stored.DMSJob, nil
+}
+
+// Update stores the new state of a job.
+func (s *RedisJobStore) Update(ctx context.Context, job *models.DMSJob) error {
+	data, err := json.Marshal(redisJob{DMSJob: job, Request: job.Request})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	s.writeJob(ctx, pipe, job, data)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	return nil
+}
+
+// Transition stores the new state of a job if its stored status is from. The
+// job key is watched, so the check and the write are atomic across replicas.
+func (s *RedisJobStore) Transition(ctx context.Context, job *models.DMSJob, from models.DMSJobStatus) error {
+	data, err := json.Marshal(redisJob{DMSJob: job, Request: job.Request})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	key := dmsJobKeyPrefix + job.JobID
+	transition := func(tx *redis.Tx) error {
+		stored, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return ErrJobNotFound
+			}
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+		var current models.DMSJob
+		if err := json.Unmarshal(stored, &current); err != nil {
+			return fmt.Errorf("failed to unmarshal job: %w", err)
+		}
+		if current.Status != from {
+			return ErrJobConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			s.writeJob(ctx, pipe, job, data)
+			return nil
+		})
+		return err
+	}
+
+	for range jobTransitionAttempts {
+		err := s.client.Watch(ctx, transition, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return ErrJobConflict
+}
+
+// writeJob queues the commands storing a job on pipe.
+func (s *RedisJobStore) writeJob(ctx context.Context, pipe redis.Pipeliner, job *models.DMSJob, data []byte) {
+	if job.Status.IsFinished() {
+		pipe.Set(ctx, dmsJobKeyPrefix+job.JobID, data, s.retention)
+		pipe.ZRem(ctx, dmsActiveJobsKey, job.JobID)
+	} else {
+		pipe.Set(ctx, dmsJobKeyPrefix+job.JobID, data, 0)
+		pipe.ZAdd(ctx, dmsActiveJobsKey, redis.Z{Score: float64(timeutil.Now().UnixMilli()), Member: job.JobID})
+	}
+}
+
+// Heartbeat records that the runner of the given active jobs is alive. Jobs
+// claimed by another runner in the meantime are not re-activated.
+func (s *RedisJobStore) Heartbeat(ctx context.Context, ids []string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, redis.Z{Score: float64(at.UnixMilli()), Member: id})
+	}
+	if err := s.client.ZAddXX(ctx, dmsActiveJobsKey, members...).Err(); err != nil {
+		return fmt.Errorf("failed to record job heartbeat: %w", err)
+	}
+	return nil
+}
+
+// ClaimStale returns the active jobs last reported alive before the given
+// time. A job is claimed by the caller whose ZREM removes it from the active
+// set, so concurrent replicas never claim the same job.
+func (s *RedisJobStore) ClaimStale(ctx context.Context, before time.Time) ([]*models.DMSJob, error) {
+	ids, err := s.client.ZRangeByScore(ctx, dmsActiveJobsKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(before.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale jobs: %w", err)
+	}
+
+	var stale []*models.DMSJob
+	for _, id := range ids {
+		removed, err := s.client.ZRem(ctx, dmsActiveJobsKey, id).Result()
+		if err != nil {
+			return stale, fmt.Errorf("failed to claim job: %w", err)
+		}
+		if removed == 0 {
+			continue
+		}
+		job, err := s.Get(ctx, id)
+		if errors.Is(err, ErrJobNotFound) {
+			continue
+		}
+		if err != nil {
+			return stale, err
+		}
+		stale = append(stale, job)
+	}
+	return stale, nil
+}
@@ -0,0 +1,143 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+)
+
+func TestJobStores(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	stores := map[string]func() storage.JobStore{
+		"memory": func() storage.JobStore { return storage.NewMemoryJobStore(time.Hour) },
+		"redis": func() storage.JobStore {
+			mr.FlushAll()
+			return storage.NewRedisJobStore(client, time.Hour)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore()
+
+			_, err := store.Get(ctx, "missing")
+			require.ErrorIs(t, err, storage.ErrJobNotFound)
+
+			created := time.Now().UTC().Truncate(time.Millisecond)
+			for _, id := range []string{"job-1", "job-2", "job-3"} {
+				require.NoError(t, store.Create(ctx, &models.DMSJob{
+					JobID: id, Operation: models.DMSJobOperationScale, Status: models.DMSJobStatusPending,
+					Adapter: "helm", NFDeploymentID: "nfd-1", Request: json.RawMessage(`{"replicas":3}`),
+					CreatedAt: created,
+				}))
+			}
+
+			job, err := store.Get(ctx, "job-1")
+			require.NoError(t, err)
+			assert.Equal(t, models.DMSJobStatusPending, job.Status)
+			assert.JSONEq(t, `{"replicas":3}`, string(job.Request), "the adapter request is persisted")
+			assert.True(t, created.Equal(job.CreatedAt))
+
+			// job-1 finishes, job-2 keeps being reported alive, job-3 is abandoned.
+			completed := time.Now().UTC()
+			job.Status, job.Progress, job.CompletedAt = models.DMSJobStatusSucceeded, 100, &completed
+			require.NoError(t, store.Update(ctx, job))
+			later := time.Now().Add(time.Minute)
+			require.NoError(t, store.Heartbeat(ctx, []string{"job-1", "job-2"}, later))
+
+			stale, err := store.ClaimStale(ctx, time.Now().Add(30*time.Second))
+			require.NoError(t, err)
+			require.Len(t, stale, 1)
+			assert.Equal(t, "job-3", stale[0].JobID)
+			assert.JSONEq(t, `{"replicas":3}`, string(stale[0].Request))
+
+			stale, err = store.ClaimStale(ctx, time.Now().Add(30*time.Second))
+			require.NoError(t, err)
+			assert.Empty(t, stale, "a stale job is claimed only once")
+
+			stale, err = store.ClaimStale(ctx, later.Add(time.Second))
+			require.NoError(t, err)
+			require.Len(t, stale, 1, "finished jobs are no longer active")
+			assert.Equal(t, "job-2", stale[0].JobID)
+
+			require.NoError(t, store.Heartbeat(ctx, []string{"job-2"}, later))
+			stale, err = store.ClaimStale(ctx, later.Add(time.Second))
+			require.NoError(t, err)
+			assert.Empty(t, stale, "heartbeats do not re-activate claimed jobs")
+
+			job, err = store.Get(ctx, "job-1")
+			require.NoError(t, err)
+			assert.Equal(t, models.DMSJobStatusSucceeded, job.Status)
+		})
+	}
+
+	t.Run("redis expires finished jobs", func(t *testing.T) {
+		ctx := context.Background()
+		store := storage.NewRedisJobStore(client, time.Hour)
+		completed := time.Now().UTC()
+		job := &models.DMSJob{JobID: "job-expiring", Status: models.DMSJobStatusPending}
+		require.NoError(t, store.Create(ctx, job))
+		job.Status, job.CompletedAt = models.DMSJobStatusFailed, &completed
+		require.NoError(t, store.Update(ctx, job))
+
+		mr.FastForward(2 * time.Hour)
+		_, err := store.Get(ctx, "job-expiring")
+		require.ErrorIs(t, err, storage.ErrJobNotFound)
+	})
+}
+
+func TestJobStores_Transition(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	stores := map[string]func() storage.JobStore{
+		"memory": func() storage.JobStore { return storage.NewMemoryJobStore(time.Hour) },
+		"redis": func() storage.JobStore {
+			mr.FlushAll()
+			return storage.NewRedisJobStore(client, time.Hour)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore()
+
+			job := &models.DMSJob{JobID: "job-1", Operation: models.DMSJobOperationCreate, Status: models.DMSJobStatusPending}
+			require.NoError(t, store.Create(ctx, job))
+
+			// A worker starts the job.
+			running := *job
+			running.Status = models.DMSJobStatusRunning
+			require.NoError(t, store.Transition(ctx, &running, models.DMSJobStatusPending))
+
+			// A cancellation that still saw the job pending loses.
+			cancelled := *job
+			cancelled.Status = models.DMSJobStatusCancelled
+			err := store.Transition(ctx, &cancelled, models.DMSJobStatusPending)
+			require.ErrorIs(t, err, storage.ErrJobConflict)
+
+			got, err := store.Get(ctx, "job-1")
+			require.NoError(t, err)
+			assert.Equal(t, models.DMSJobStatusRunning, got.Status)
+
+			missing := models.DMSJob{JobID: "missing", Status: models.DMSJobStatusRunning}
+			err = store.Transition(ctx, &missing, models.DMSJobStatusPending)
+			require.ErrorIs(t, err, storage.ErrJobNotFound)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 // MemoryStore is an in-memory implementation of the Store interface.
@@ -31,6 +32,8 @@ func (s *MemoryStore) Create(_ context.Context, sub *models.DMSSubscription) err
 		return ErrSubscriptionExists
 	}
 
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
+
 	// Store a copy to prevent external modification.
 	subCopy := *sub
 	s.subscriptions[sub.SubscriptionID] = &subCopy
@@ -73,10 +76,15 @@ func (s *MemoryStore) Update(_ context.Context, sub *models.DMSSubscription) err
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.subscriptions[sub.SubscriptionID]; !exists {
+	existing, exists := s.subscriptions[sub.SubscriptionID]
+	if !exists {
 		return ErrSubscriptionNotFound
 	}
 
+	// Preserve the original creation time.
+	sub.CreatedAt = existing.CreatedAt
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
+
 	// Store a copy to prevent external modification.
 	subCopy := *sub
 	s.subscriptions[sub.SubscriptionID] = &subCopy
@@ -252,3 +252,29 @@ func TestMemoryStore_IsolatedCopies(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "https://example.com/webhook", retrieved.Callback)
 }
+
+func TestMemoryStore_Timestamps(t *testing.T) {
+	store := storage.NewMemoryStore()
+	defer func() { require.NoError(t, store.Close()) }()
+
+	ctx := context.Background()
+	sub := &models.DMSSubscription{SubscriptionID: "sub-1", Callback: "https://example.com/webhook"}
+	require.NoError(t, store.Create(ctx, sub))
+
+	created, err := store.Get(ctx, "sub-1")
+	require.NoError(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.Equal(t, time.UTC, created.CreatedAt.Location())
+	assert.Equal(t, created.CreatedAt, created.UpdatedAt)
+
+	// Updates keep the creation time even if the caller sends a different one.
+	sub.Callback = "https://example.com/new-webhook"
+	sub.CreatedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+	require.NoError(t, store.Update(ctx, sub))
+
+	updated, err := store.Get(ctx, "sub-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.CreatedAt, updated.CreatedAt)
+	assert.False(t, updated.UpdatedAt.Before(created.UpdatedAt))
+	assert.Equal(t, time.UTC, updated.UpdatedAt.Location())
+}
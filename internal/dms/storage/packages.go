@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"regexp"
 	"sync"
-	"time"
 
 	"github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 // Content size limits for package storage.
@@ -209,7 +209,9 @@ func (s *MemoryPackageStore) storePackage(pkg *adapter.DeploymentPackage) {
 	// Store a copy to prevent external modification.
 	pkgCopy := CopyPackage(pkg)
 	if pkgCopy.UploadedAt.IsZero() {
-		pkgCopy.UploadedAt = time.Now()
+		pkgCopy.UploadedAt = timeutil.Now()
+	} else {
+		pkgCopy.UploadedAt = pkgCopy.UploadedAt.UTC()
 	}
 
 	s.Packages[pkg.ID] = pkgCopy
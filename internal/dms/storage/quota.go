@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// dmsQuotaUsageKeyPrefix prefixes the Redis hash of the quota usage of a
+// tenant, keyed by deployment ID.
+const dmsQuotaUsageKeyPrefix = "dms:quota:"
+
+// quotaUpdateAttempts is how often a Redis quota update is retried when a
+// concurrent update of the same tenant wins the race.
+const quotaUpdateAttempts = 10
+
+// ErrQuotaUsageConflict is returned when a quota usage update keeps losing the
+// race against concurrent updates of the same tenant.
+var ErrQuotaUsageConflict = errors.New("quota usage update conflict")
+
+// QuotaUsage is the capacity requested by a single deployment, recorded
+// against the DMS quota of its tenant. CPU, memory, and GPUs are per replica.
+type QuotaUsage struct {
+	Replicas      int   `json:"replicas"`
+	CPUMillicores int64 `json:"cpuMillicores,omitempty"`
+	MemoryBytes   int64 `json:"memoryBytes,omitempty"`
+	GPUs          int64 `json:"gpus,omitempty"`
+}
+
+// QuotaUsageStore records the capacity requested by the deployments of each
+// tenant, so that DMS quotas survive restarts and are shared by replicas.
+type QuotaUsageStore interface {
+	// Update calls fn with the usage of each deployment of a tenant, keyed by
+	// deployment ID, and stores the map as fn leaves it. Updates of the same
+	// tenant are serialized, so fn can check quotas against the usage it is
+	// given. Nothing is stored if fn returns an error, which Update returns.
+	Update(ctx context.Context, tenantID string, fn func(usage map[string]QuotaUsage) error) error
+
+	// Get returns the usage of each deployment of a tenant.
+	Get(ctx context.Context, tenantID string) (map[string]QuotaUsage, error)
+}
+
+// MemoryQuotaUsageStore is an in-memory implementation of the QuotaUsageStore
+// interface. Usage is lost when the gateway restarts and is not shared by
+// replicas; use RedisQuotaUsageStore in production.
+type MemoryQuotaUsageStore struct {
+	mu    sync.Mutex
+	usage map[string]map[string]QuotaUsage
+}
+
+// NewMemoryQuotaUsageStore creates an in-memory quota usage store.
+func NewMemoryQuotaUsageStore() *MemoryQuotaUsageStore {
+	return &MemoryQuotaUsageStore{usage: make(map[string]map[string]QuotaUsage)}
+}
+
+// Update applies fn to the usage of a tenant.
+func (s *MemoryQuotaUsageStore) Update(
+	_ context.Context,
+	tenantID string,
+	fn func(usage map[string]QuotaUsage) error,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage := copyQuotaUsage(s.usage[tenantID])
+	if err := fn(usage); err != nil {
+		return err
+	}
+	if len(usage) == 0 {
+		delete(s.usage, tenantID)
+	} else {
+		s.usage[tenantID] = usage
+	}
+	return nil
+}
+
+// Get returns the usage of a tenant.
+func (s *MemoryQuotaUsageStore) Get(_ context.Context, tenantID string) (map[string]QuotaUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyQuotaUsage(s.usage[tenantID]), nil
+}
+
+// RedisQuotaUsageStore is a Redis-backed implementation of the QuotaUsageStore
+// interface. Usage is shared by every gateway replica using the same Redis.
+type RedisQuotaUsageStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisQuotaUsageStore creates a quota usage store on client. The client
+// is owned by the caller.
+func NewRedisQuotaUsageStore(client redis.UniversalClient) *RedisQuotaUsageStore {
+	return &RedisQuotaUsageStore{client: client}
+}
+
+// Update applies fn to the usage of a tenant. The tenant's hash is watched, so
+// the update is retried if another replica changes it concurrently.
+func (s *RedisQuotaUsageStore) Update(
+	ctx context.Context,
+	tenantID string,
+	fn func(usage map[string]QuotaUsage) error,
+) error {
+	key := dmsQuotaUsageKeyPrefix + tenantID
+	update := func(tx *redis.Tx) error {
+		before, err := readQuotaUsage(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		usage := copyQuotaUsage(before)
+		if err := fn(usage); err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for id := range before {
+				if _, ok := usage[id]; !ok {
+					pipe.HDel(ctx, key, id)
+				}
+			}
+			for id, u := range usage {
+				if prev, ok := before[id]; ok && prev == u {
+					continue
+				}
+				data, err := json.Marshal(u)
+				if err != nil {
+					return fmt.Errorf("failed to marshal quota usage: %w", err)
+				}
+				pipe.HSet(ctx, key, id, data)
+			}
+			return nil
+		})
+		return err
+	}
+
+	for range quotaUpdateAttempts {
+		err := s.client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return ErrQuotaUsageConflict
+}
+
+// Get returns the usage of a tenant.
+func (s *RedisQuotaUsageStore) Get(ctx context.Context, tenantID string) (map[string]QuotaUsage, error) {
+	return readQuotaUsage(ctx, s.client, dmsQuotaUsageKeyPrefix+tenantID)
+}
+
+func readQuotaUsage(ctx context.Context, client redis.Cmdable, key string) (map[string]QuotaUsage, error) {
+	values, err := client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	usage := make(map[string]QuotaUsage, len(values))
+	for id, data := range values {
+		var u QuotaUsage
+		if err := json.Unmarshal([]byte(data), &u); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal quota usage of %s: %w", id, err)
+		}
+		usage[id] = u
+	}
+	return usage, nil
+}
+
+func copyQuotaUsage(usage map[string]QuotaUsage) map[string]QuotaUsage {
+	out := make(map[string]QuotaUsage, len(usage))
+	for id, u := range usage {
+		out[id] = u
+	}
+	return out
+}
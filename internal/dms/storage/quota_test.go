@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/dms/storage"
+)
+
+func TestQuotaUsageStores(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	stores := map[string]func() storage.QuotaUsageStore{
+		"memory": func() storage.QuotaUsageStore { return storage.NewMemoryQuotaUsageStore() },
+		"redis": func() storage.QuotaUsageStore {
+			mr.FlushAll()
+			return storage.NewRedisQuotaUsageStore(client)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore()
+
+			usage, err := store.Get(ctx, "tenant-a")
+			require.NoError(t, err)
+			assert.Empty(t, usage)
+
+			require.NoError(t, store.Update(ctx, "tenant-a", func(u map[string]storage.QuotaUsage) error {
+				u["d1"] = storage.QuotaUsage{Replicas: 2, CPUMillicores: 500}
+				u["d2"] = storage.QuotaUsage{Replicas: 1, MemoryBytes: 1 << 20}
+				return nil
+			}))
+
+			// A failing update stores nothing.
+			errRejected := errors.New("rejected")
+			err = store.Update(ctx, "tenant-a", func(u map[string]storage.QuotaUsage) error {
+				delete(u, "d1")
+				return errRejected
+			})
+			require.ErrorIs(t, err, errRejected)
+
+			require.NoError(t, store.Update(ctx, "tenant-a", func(u map[string]storage.QuotaUsage) error {
+				delete(u, "d2")
+				return nil
+			}))
+
+			usage, err = store.Get(ctx, "tenant-a")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]storage.QuotaUsage{"d1": {Replicas: 2, CPUMillicores: 500}}, usage)
+
+			usage, err = store.Get(ctx, "tenant-b")
+			require.NoError(t, err)
+			assert.Empty(t, usage, "usage is kept per tenant")
+		})
+	}
+}
+
+func TestRedisQuotaUsageStore_ConcurrentUpdates(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Two replicas sharing the Redis.
+	replicas := []storage.QuotaUsageStore{
+		storage.NewRedisQuotaUsageStore(client),
+		storage.NewRedisQuotaUsageStore(client),
+	}
+
+	const perReplica = 3
+	var wg sync.WaitGroup
+	for _, store := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perReplica {
+				err := store.Update(context.Background(), "tenant-a", func(u map[string]storage.QuotaUsage) error {
+					u["d1"] = storage.QuotaUsage{Replicas: u["d1"].Replicas + 1}
+					return nil
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	usage, err := replicas[0].Get(context.Background(), "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, 2*perReplica, usage["d1"].Replicas, "no update is lost")
+}
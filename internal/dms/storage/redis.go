@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Redis keys for DMS subscriptions. They are distinct from the O2-IMS
+// subscription keys so both can share a Redis deployment.
+const (
+	dmsSubscriptionKeyPrefix = "dms:subscription:"
+	dmsSubscriptionSetKey    = "dms:subscriptions"
+)
+
+// RedisStore is a Redis-backed implementation of the Store interface.
+// Subscriptions are shared by every gateway replica using the same Redis.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a DMS subscription store on client.
+// The client is owned by the caller and is not closed by Close.
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Create creates a new subscription.
+func (s *RedisStore) Create(ctx context.Context, sub *models.DMSSubscription) error {
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
+
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+
+	created, err := s.client.SetNX(ctx, dmsSubscriptionKeyPrefix+sub.SubscriptionID, data, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+	if !created {
+		return ErrSubscriptionExists
+	}
+
+	if err := s.client.SAdd(ctx, dmsSubscriptionSetKey, sub.SubscriptionID).Err(); err != nil {
+		return fmt.Errorf("failed to index subscription: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a subscription by ID.
+func (s *RedisStore) Get(ctx context.Context, id string) (*models.DMSSubscription, error) {
+	data, err := s.client.Get(ctx, dmsSubscriptionKeyPrefix+id).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	var sub models.DMSSubscription
+	if err := json.Unmarshal(data, &sub); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
+	}
+	return &sub, nil
+}
+
+// List retrieves all subscriptions.
+func (s *RedisStore) List(ctx context.Context) ([]*models.DMSSubscription, error) {
+	ids, err := s.client.SMembers(ctx, dmsSubscriptionSetKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	subs := make([]*models.DMSSubscription, 0, len(ids))
+	for _, id := range ids {
+		sub, err := s.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrSubscriptionNotFound) {
+				// Deleted between SMEMBERS and GET.
+				continue
+			}
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// Update updates an existing subscription.
+func (s *RedisStore) Update(ctx context.Context, sub *models.DMSSubscription) error {
+	existing, err := s.Get(ctx, sub.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	// Preserve the original creation time.
+	sub.CreatedAt = existing.CreatedAt
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
+
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+
+	updated, err := s.client.SetXX(ctx, dmsSubscriptionKeyPrefix+sub.SubscriptionID, data, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+	if !updated {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// Delete deletes a subscription by ID.
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	deleted, err := s.client.Del(ctx, dmsSubscriptionKeyPrefix+id).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	if err := s.client.SRem(ctx, dmsSubscriptionSetKey, id).Err(); err != nil {
+		return fmt.Errorf("failed to unindex subscription: %w", err)
+	}
+	if deleted == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// Ping checks if the storage is healthy.
+func (s *RedisStore) Ping(ctx context.Context) error {
+	if err := s.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("redis ping failed: %w", err)
+	}
+	return nil
+}
+
+// Close is a no-op; the Redis client is owned by the caller.
+func (s *RedisStore) Close() error {
+	return nil
+}
@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// dmsAdaptersKey is the Redis hash of the DMS adapters registered through the
+// API, keyed by adapter name.
+const dmsAdaptersKey = "dms:adapters"
+
+// ErrEncryptionKeyRequired is returned when saving a registration with a
+// password to a store that has no key to encrypt it with.
+var ErrEncryptionKeyRequired = errors.New("an encryption key is required to persist adapter passwords")
+
+// AdapterRegistration is a DMS adapter registered through the API.
+type AdapterRegistration struct {
+	models.RegisterDMSAdapterRequest
+
+	// RegisteredAt is when the adapter was registered. Registrations are
+	// restored in this order, so the latest default adapter stays the default.
+	RegisteredAt time.Time `json:"registeredAt"`
+}
+
+// RegistrationStore persists the DMS adapters registered through the API so
+// that they are registered again when the gateway restarts.
+type RegistrationStore interface {
+	// Save stores a registration, replacing any with the same name.
+	Save(ctx context.Context, reg *AdapterRegistration) error
+
+	// Delete removes the registration of the named adapter. Deleting a
+	// missing registration is not an error.
+	Delete(ctx context.Context, name string) error
+
+	// List returns the registrations ordered by registration time.
+	List(ctx context.Context) ([]*AdapterRegistration, error)
+}
+
+// MemoryRegistrationStore is an in-memory implementation of the
+// RegistrationStore interface. Registrations are lost when the gateway
+// restarts; use RedisRegistrationStore in production.
+type MemoryRegistrationStore struct {
+	mu            sync.RWMutex
+	registrations map[string]*AdapterRegistration
+}
+
+// NewMemoryRegistrationStore creates an in-memory registration store.
+func NewMemoryRegistrationStore() *MemoryRegistrationStore {
+	return &MemoryRegistrationStore{registrations: make(map[string]*AdapterRegistration)}
+}
+
+// Save stores a registration.
+func (s *MemoryRegistrationStore) Save(_ context.Context, reg *AdapterRegistration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reg.RegisteredAt.IsZero() {
+		reg.RegisteredAt = timeutil.Now()
+	}
+	regCopy := *reg
+	s.registrations[reg.Name] = &regCopy
+	return nil
+}
+
+// Delete removes a registration.
+func (s *MemoryRegistrationStore) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.registrations, name)
+	return nil
+}
+
+// List returns the registrations ordered by registration time.
+func (s *MemoryRegistrationStore) List(_ context.Context) ([]*AdapterRegistration, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	regs := make([]*AdapterRegistration, 0, len(s.registrations))
+	for _, reg := range s.registrations {
+		regCopy := *reg
+		regs = append(regs, &regCopy)
+	}
+	sortRegistrations(regs)
+	return regs, nil
+}
+
+// RedisRegistrationStore is a Redis-backed implementation of the
+// RegistrationStore interface. Registrations are shared by every gateway
+// replica using the same Redis. Adapter passwords are encrypted with
+// AES-256-GCM before they are stored; without an encryption key,
+// registrations with a password are rejected.
+type RedisRegistrationStore struct {
+	client redis.UniversalClient
+	aead   cipher.AEAD
+}
+
+// storedRegistration is the Redis representation of a registration, with the
+// password replaced by its ciphertext.
+type storedRegistration struct {
+	AdapterRegistration
+
+	// EncryptedPassword is the base64 nonce and ciphertext of the password,
+	// sealed with the adapter name as additional data.
+	EncryptedPassword string `json:"encryptedPassword,omitempty"`
+}
+
+// NewRedisRegistrationStore creates a DMS adapter registration store on
+// client. The AES-256 key encrypting adapter passwords is derived from
+// encryptionKey; every replica must use the same one. An empty encryptionKey
+// rejects registrations with a password. The client is owned by the caller.
+func NewRedisRegistrationStore(client redis.UniversalClient, encryptionKey string) *RedisRegistrationStore {
+	store := &RedisRegistrationStore{client: client}
+	if encryptionKey != "" {
+		key := sha256.Sum256([]byte(encryptionKey))
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			panic(fmt.Sprintf("invalid AES key: %v", err)) // a SHA-256 sum is always a valid key
+		}
+		store.aead, err = cipher.NewGCM(block)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create AES-GCM: %v", err))
+		}
+	}
+	return store
+}
+
+// Save stores a registration with its password encrypted.
+func (s *RedisRegistrationStore) Save(ctx context.Context, reg *AdapterRegistration) error {
+	if reg.Password != "" && s.aead == nil {
+		return ErrEncryptionKeyRequired
+	}
+	if reg.RegisteredAt.IsZero() {
+		reg.RegisteredAt = timeutil.Now()
+	}
+	return s.save(ctx, reg)
+}
+
+func (s *RedisRegistrationStore) save(ctx context.Context, reg *AdapterRegistration) error {
+	stored := storedRegistration{AdapterRegistration: *reg}
+	if reg.Password != "" {
+		encrypted, err := s.encrypt(reg.Name, reg.Password)
+		if err != nil {
+			return err
+		}
+		stored.Password = ""
+		stored.EncryptedPassword = encrypted
+	}
+
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal adapter registration: %w", err)
+	}
+	if err := s.client.HSet(ctx, dmsAdaptersKey, reg.Name, data).Err(); err != nil {
+		return fmt.Errorf("failed to save adapter registration: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a registration.
+func (s *RedisRegistrationStore) Delete(ctx context.Context, name string) error {
+	if err := s.client.HDel(ctx, dmsAdaptersKey, name).Err(); err != nil {
+		return fmt.Errorf("failed to delete adapter registration: %w", err)
+	}
+	return nil
+}
+
+// List returns the registrations ordered by registration time, with their
+// passwords decrypted. Registrations stored with a plaintext password by
+// earlier versions are stored again encrypted when the store has a key.
+func (s *RedisRegistrationStore) List(ctx context.Context) ([]*AdapterRegistration, error) {
+	values, err := s.client.HGetAll(ctx, dmsAdaptersKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adapter registrations: %w", err)
+	}
+
+	regs := make([]*AdapterRegistration, 0, len(values))
+	for name, data := range values {
+		var stored storedRegistration
+		if err := json.Unmarshal([]byte(data), &stored); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal adapter registration %s: %w", name, err)
+		}
+		reg := stored.AdapterRegistration
+		switch {
+		case stored.EncryptedPassword != "":
+			if reg.Password, err = s.decrypt(reg.Name, stored.EncryptedPassword); err != nil {
+				return nil, fmt.Errorf("failed to decrypt the password of adapter registration %s: %w", name, err)
+			}
+		case reg.Password != "" && s.aead != nil:
+			if err := s.save(ctx, &reg); err != nil {
+				return nil, fmt.Errorf("failed to encrypt the password of adapter registration %s: %w", name, err)
+			}
+		}
+		regs = append(regs, &reg)
+	}
+	sortRegistrations(regs)
+	return regs, nil
+}
+
+// encrypt seals a password of the named adapter.
+func (s *RedisRegistrationStore) encrypt(name, password string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(password), []byte(name))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt opens a password sealed by encrypt.
+func (s *RedisRegistrationStore) decrypt(name, encrypted string) (string, error) {
+	if s.aead == nil {
+		return "", ErrEncryptionKeyRequired
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	if len(sealed) < s.aead.NonceSize() {
+		return "", errors.New("invalid ciphertext: too short")
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	password, err := s.aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("wrong encryption key or corrupted ciphertext: %w", err)
+	}
+	return string(password), nil
+}
+
+// sortRegistrations orders registrations by registration time, then name.
+func sortRegistrations(regs []*AdapterRegistration) {
+	sort.Slice(regs, func(i, j int) bool {
+		if !regs[i].RegisteredAt.Equal(regs[j].RegisteredAt) {
+			return regs[i].RegisteredAt.Before(regs[j].RegisteredAt)
+		}
+		return regs[i].Name < regs[j].Name
+	})
+}
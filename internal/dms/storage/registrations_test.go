@@ -0,0 +1,130 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/dms/models"
+	"github.com/piwi3910/netweave/internal/dms/storage"
+)
+
+func TestRegistrationStores(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	stores := map[string]func() storage.RegistrationStore{
+		"memory": func() storage.RegistrationStore { return storage.NewMemoryRegistrationStore() },
+		"redis": func() storage.RegistrationStore {
+			mr.FlushAll()
+			return storage.NewRedisRegistrationStore(client, "test-key")
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newStore()
+
+			regs, err := store.List(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, regs)
+
+			registered := time.Now().UTC().Truncate(time.Millisecond)
+			for i, adapterName := range []string{"osm", "argocd"} {
+				require.NoError(t, store.Save(ctx, &storage.AdapterRegistration{
+					RegisterDMSAdapterRequest: models.RegisterDMSAdapterRequest{
+						Name:     adapterName,
+						Type:     adapterName,
+						Password: "secret",
+					},
+					RegisteredAt: registered.Add(time.Duration(i) * time.Second),
+				}))
+			}
+
+			// Saving again replaces the registration.
+			require.NoError(t, store.Save(ctx, &storage.AdapterRegistration{
+				RegisterDMSAdapterRequest: models.RegisterDMSAdapterRequest{Name: "osm", Type: "osmlcm", Default: true},
+				RegisteredAt:              registered,
+			}))
+
+			regs, err = store.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, regs, 2)
+			assert.Equal(t, "osm", regs[0].Name)
+			assert.Equal(t, "osmlcm", regs[0].Type)
+			assert.True(t, regs[0].Default)
+			assert.Equal(t, "argocd", regs[1].Name)
+			assert.Equal(t, "secret", regs[1].Password)
+			assert.True(t, regs[1].RegisteredAt.Equal(registered.Add(time.Second)))
+
+			require.NoError(t, store.Delete(ctx, "osm"))
+			require.NoError(t, store.Delete(ctx, "missing"))
+
+			regs, err = store.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, regs, 1)
+			assert.Equal(t, "argocd", regs[0].Name)
+		})
+	}
+}
+
+func TestRedisRegistrationStore_EncryptsPasswords(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := context.Background()
+
+	store := storage.NewRedisRegistrationStore(client, "test-key")
+	require.NoError(t, store.Save(ctx, &storage.AdapterRegistration{
+		RegisterDMSAdapterRequest: models.RegisterDMSAdapterRequest{Name: "helm-edge", Type: "helm", Password: "secret"},
+	}))
+
+	stored := mr.HGet("dms:adapters", "helm-edge")
+	assert.NotContains(t, stored, "secret")
+	assert.Contains(t, stored, "encryptedPassword")
+
+	regs, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, regs, 1)
+	assert.Equal(t, "secret", regs[0].Password)
+
+	// Replicas with another key can't read the password.
+	_, err = storage.NewRedisRegistrationStore(client, "other-key").List(ctx)
+	require.Error(t, err)
+
+	// Without a key, passwords are not stored at all.
+	keyless := storage.NewRedisRegistrationStore(client, "")
+	err = keyless.Save(ctx, &storage.AdapterRegistration{
+		RegisterDMSAdapterRequest: models.RegisterDMSAdapterRequest{Name: "osm", Type: "osmlcm", Password: "secret"},
+	})
+	require.ErrorIs(t, err, storage.ErrEncryptionKeyRequired)
+	require.NoError(t, keyless.Save(ctx, &storage.AdapterRegistration{
+		RegisterDMSAdapterRequest: models.RegisterDMSAdapterRequest{Name: "osm", Type: "osmlcm"},
+	}))
+}
+
+func TestRedisRegistrationStore_EncryptsLegacyPasswords(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Registrations stored by earlier versions hold the password in plaintext.
+	mr.HSet("dms:adapters", "helm-edge",
+		`{"name":"helm-edge","type":"helm","password":"secret","registeredAt":"2026-01-02T03:04:05Z"}`)
+
+	regs, err := storage.NewRedisRegistrationStore(client, "test-key").List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, regs, 1)
+	assert.Equal(t, "secret", regs[0].Password)
+
+	stored := mr.HGet("dms:adapters", "helm-edge")
+	assert.NotContains(t, stored, "secret")
+	assert.Contains(t, stored, "encryptedPassword")
+}
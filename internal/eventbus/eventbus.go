@@ -0,0 +1,264 @@
+// Package eventbus provides a lightweight in-process publish/subscribe bus
+// that decouples producers of change events (request handlers, adapters,
+// watchers) from their consumers (cache invalidation, long-poll wakeups,
+// notifications).
+//
+// Events are published on typed topics. Every consumer of a topic has its own
+// bounded buffer and goroutine, so a slow consumer never blocks producers or
+// other consumers: when its buffer is full, events for that consumer are
+// dropped and counted. Drain stops the bus and waits for consumers to finish
+// the events already buffered.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// DefaultBufferSize is the number of events buffered per consumer when
+// Subscribe is given a non-positive buffer size.
+const DefaultBufferSize = 256
+
+var (
+	// ErrClosed is returned when publishing or subscribing after Drain.
+	ErrClosed = errors.New("event bus is closed")
+
+	// ErrDuplicateConsumer is returned when a consumer name is subscribed
+	// twice to the same topic.
+	ErrDuplicateConsumer = errors.New("consumer already subscribed to topic")
+)
+
+// Bus owns the topics and consumers of one process.
+type Bus struct {
+	logger *zap.Logger
+
+	// ctx is passed to handlers and cancelled when Drain gives up waiting.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu guards closed and the consumers of every topic. Publishing holds the
+	// read lock so Drain cannot close a buffer during a send.
+	mu      sync.RWMutex
+	closed  bool
+	closers []func()
+	wg      sync.WaitGroup
+}
+
+// New creates an event bus.
+func New(logger *zap.Logger) *Bus {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Bus{logger: logger, ctx: ctx, cancel: cancel}
+}
+
+// Drain stops accepting events and waits until every consumer has handled
+// the events already buffered. If ctx ends first, handlers still running are
+// cancelled and ctx's error is returned. Drain is safe to call more than once.
+func (b *Bus) Drain(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, closeBuffer := range b.closers {
+			closeBuffer()
+		}
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.cancel()
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return fmt.Errorf("event bus drain: %w", ctx.Err())
+	}
+}
+
+// Handler handles one event. Handlers of one consumer are called
+// sequentially, in publish order.
+type Handler[T any] func(ctx context.Context, event T)
+
+// Topic is a named stream of events of type T.
+type Topic[T any] struct {
+	bus       *Bus
+	name      string
+	consumers []*consumer[T] // guarded by bus.mu
+}
+
+// NewTopic creates a topic on bus.
+func NewTopic[T any](bus *Bus, name string) *Topic[T] {
+	return &Topic[T]{bus: bus, name: name}
+}
+
+// Name returns the topic name.
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscribe registers a consumer that handles every event published on the
+// topic from now on. Events are buffered up to bufferSize (DefaultBufferSize
+// if not positive) while the consumer is busy.
+func (t *Topic[T]) Subscribe(name string, bufferSize int, handler Handler[T]) error {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	t.bus.mu.Lock()
+	defer t.bus.mu.Unlock()
+
+	if t.bus.closed {
+		return ErrClosed
+	}
+	for _, c := range t.consumers {
+		if c.name == name {
+			return fmt.Errorf("%w: %s on %s", ErrDuplicateConsumer, name, t.name)
+		}
+	}
+
+	c := &consumer[T]{
+		topic:   t.name,
+		name:    name,
+		handler: handler,
+		events:  make(chan delivery[T], bufferSize),
+	}
+	t.consumers = append(t.consumers, c)
+	t.bus.closers = append(t.bus.closers, func() { close(c.events) })
+
+	t.bus.wg.Add(1)
+	go func() {
+		defer t.bus.wg.Done()
+		c.run(t.bus.ctx, t.bus.logger)
+	}()
+	return nil
+}
+
+// Publish hands event to every consumer of the topic without waiting for it
+// to be handled. Consumers whose buffer is full miss the event.
+func (t *Topic[T]) Publish(event T) error {
+	t.bus.mu.RLock()
+	defer t.bus.mu.RUnlock()
+
+	if t.bus.closed {
+		return ErrClosed
+	}
+	EventsPublished.WithLabelValues(t.name).Inc()
+
+	for _, c := range t.consumers {
+		if !c.offer(delivery[T]{event: event}) {
+			t.bus.logger.Warn("event bus consumer is lagging, dropping event",
+				zap.String("topic", t.name),
+				zap.String("consumer", c.name))
+		}
+	}
+	return nil
+}
+
+// PublishAndWait hands event to every consumer of the topic and waits until
+// all of them have handled it, for producers that must observe the effects
+// of an event (such as a cache invalidation) before continuing. Unlike
+// Publish it waits for buffer space rather than dropping the event; it
+// returns ctx's error if ctx ends first.
+func (t *Topic[T]) PublishAndWait(ctx context.Context, event T) error {
+	var handled sync.WaitGroup
+
+	err := func() error {
+		t.bus.mu.RLock()
+		defer t.bus.mu.RUnlock()
+
+		if t.bus.closed {
+			return ErrClosed
+		}
+		EventsPublished.WithLabelValues(t.name).Inc()
+
+		for _, c := range t.consumers {
+			handled.Add(1)
+			d := delivery[T]{event: event, done: handled.Done}
+			select {
+			case c.events <- d:
+				ConsumerLag.WithLabelValues(t.name, c.name).Inc()
+			case <-ctx.Done():
+				handled.Done()
+				return ctx.Err()
+			}
+		}
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		handled.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// delivery is an event queued for one consumer. done, when set, is called
+// once the consumer has handled the event.
+type delivery[T any] struct {
+	event T
+	done  func()
+}
+
+// consumer handles the events of one topic on its own goroutine.
+type consumer[T any] struct {
+	topic   string
+	name    string
+	handler Handler[T]
+	events  chan delivery[T]
+}
+
+// offer queues d unless the consumer's buffer is full.
+func (c *consumer[T]) offer(d delivery[T]) bool {
+	select {
+	case c.events <- d:
+		ConsumerLag.WithLabelValues(c.topic, c.name).Inc()
+		return true
+	default:
+		EventsDropped.WithLabelValues(c.topic, c.name).Inc()
+		return false
+	}
+}
+
+// run handles events until the buffer is closed and empty.
+func (c *consumer[T]) run(ctx context.Context, logger *zap.Logger) {
+	for d := range c.events {
+		ConsumerLag.WithLabelValues(c.topic, c.name).Dec()
+		c.handle(ctx, logger, d)
+	}
+}
+
+// handle calls the handler, recovering from panics so one bad event does not
+// stop the consumer.
+func (c *consumer[T]) handle(ctx context.Context, logger *zap.Logger, d delivery[T]) {
+	defer func() {
+		if r := recover(); r != nil {
+			EventsFailed.WithLabelValues(c.topic, c.name).Inc()
+			logger.Error("event bus consumer panicked",
+				zap.String("topic", c.topic),
+				zap.String("consumer", c.name),
+				zap.Any("panic", r))
+		}
+		if d.done != nil {
+			d.done()
+		}
+	}()
+	c.handler(ctx, d.event)
+}
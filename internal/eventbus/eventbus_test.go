@@ -0,0 +1,184 @@
+package eventbus_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/eventbus"
+)
+
+// recorder collects the events handled by a consumer.
+type recorder struct {
+	mu     sync.Mutex
+	events []int
+}
+
+func (r *recorder) handle(_ context.Context, event int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) handled() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.events...)
+}
+
+func drain(t *testing.T, bus *eventbus.Bus) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, bus.Drain(ctx))
+}
+
+func TestTopic_PublishToEveryConsumer(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	topic := eventbus.NewTopic[int](bus, "test.fanout")
+
+	published := testutil.ToFloat64(eventbus.EventsPublished.WithLabelValues("test.fanout"))
+	var a, b recorder
+	require.NoError(t, topic.Subscribe("a", 0, a.handle))
+	require.NoError(t, topic.Subscribe("b", 0, b.handle))
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, topic.Publish(i))
+	}
+	drain(t, bus)
+
+	// Drain waits for buffered events; each consumer sees them in order.
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, a.handled())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, b.handled())
+	assert.InDelta(t, published+5, testutil.ToFloat64(eventbus.EventsPublished.WithLabelValues("test.fanout")), 0)
+
+	require.ErrorIs(t, topic.Publish(6), eventbus.ErrClosed)
+	require.ErrorIs(t, topic.Subscribe("c", 0, a.handle), eventbus.ErrClosed)
+}
+
+func TestTopic_SlowConsumerDropsEvents(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	topic := eventbus.NewTopic[int](bus, "test.slow")
+
+	dropped := testutil.ToFloat64(eventbus.EventsDropped.WithLabelValues("test.slow", "slow"))
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var slow, fast recorder
+	require.NoError(t, topic.Subscribe("slow", 2, func(ctx context.Context, event int) {
+		if event == 1 {
+			close(started)
+		}
+		<-release
+		slow.handle(ctx, event)
+	}))
+	require.NoError(t, topic.Subscribe("fast", 10, fast.handle))
+
+	// The slow consumer is busy with event 1 and buffers 2 and 3; 4 and 5
+	// do not fit. The fast consumer is unaffected.
+	require.NoError(t, topic.Publish(1))
+	<-started
+	for i := 2; i <= 5; i++ {
+		require.NoError(t, topic.Publish(i))
+	}
+
+	assert.InDelta(t, 2, testutil.ToFloat64(eventbus.ConsumerLag.WithLabelValues("test.slow", "slow")), 0)
+	assert.InDelta(t, dropped+2, testutil.ToFloat64(eventbus.EventsDropped.WithLabelValues("test.slow", "slow")), 0)
+	assert.Zero(t, testutil.ToFloat64(eventbus.EventsDropped.WithLabelValues("test.slow", "fast")))
+
+	close(release)
+	drain(t, bus)
+
+	assert.Equal(t, []int{1, 2, 3}, slow.handled())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, fast.handled())
+	assert.InDelta(t, 0, testutil.ToFloat64(eventbus.ConsumerLag.WithLabelValues("test.slow", "slow")), 0)
+}
+
+func TestTopic_PublishAndWait(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	topic := eventbus.NewTopic[int](bus, "test.wait")
+
+	var r recorder
+	require.NoError(t, topic.Subscribe("r", 1, r.handle))
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, topic.PublishAndWait(context.Background(), i))
+		assert.Equal(t, i, len(r.handled()), "event %d handled before returning", i)
+	}
+
+	t.Run("context ends first", func(t *testing.T) {
+		release := make(chan struct{})
+		require.NoError(t, topic.Subscribe("blocked", 1, func(context.Context, int) { <-release }))
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, topic.PublishAndWait(ctx, 4), context.DeadlineExceeded)
+	})
+}
+
+func TestTopic_SubscribeErrors(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	topic := eventbus.NewTopic[int](bus, "test.duplicate")
+
+	var r recorder
+	require.NoError(t, topic.Subscribe("r", 0, r.handle))
+	require.ErrorIs(t, topic.Subscribe("r", 0, r.handle), eventbus.ErrDuplicateConsumer)
+
+	// The same consumer name may be used on another topic.
+	require.NoError(t, eventbus.NewTopic[string](bus, "test.other").Subscribe("r", 0, func(context.Context, string) {}))
+	drain(t, bus)
+}
+
+func TestConsumer_RecoversFromPanics(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	topic := eventbus.NewTopic[int](bus, "test.panic")
+
+	failed := testutil.ToFloat64(eventbus.EventsFailed.WithLabelValues("test.panic", "r"))
+	var r recorder
+	require.NoError(t, topic.Subscribe("r", 0, func(ctx context.Context, event int) {
+		if event == 2 {
+			panic("bad event")
+		}
+		r.handle(ctx, event)
+	}))
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, topic.PublishAndWait(context.Background(), i))
+	}
+	drain(t, bus)
+
+	assert.Equal(t, []int{1, 3}, r.handled())
+	assert.InDelta(t, failed+1, testutil.ToFloat64(eventbus.EventsFailed.WithLabelValues("test.panic", "r")), 0)
+}
+
+func TestBus_DrainTimeout(t *testing.T) {
+	bus := eventbus.New(zap.NewNop())
+	topic := eventbus.NewTopic[int](bus, "test.timeout")
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	require.NoError(t, topic.Subscribe("stuck", 0, func(ctx context.Context, _ int) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	}))
+	require.NoError(t, topic.Publish(1))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, bus.Drain(ctx), context.DeadlineExceeded)
+
+	// Handlers still running when the drain gives up are cancelled.
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was not cancelled")
+	}
+}
@@ -0,0 +1,52 @@
+package eventbus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// EventsPublished counts events published per topic.
+	EventsPublished = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "eventbus",
+			Name:      "events_published_total",
+			Help:      "Total number of events published on the internal event bus",
+		},
+		[]string{"topic"},
+	)
+
+	// EventsDropped counts events a consumer missed because its buffer was full.
+	EventsDropped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "eventbus",
+			Name:      "events_dropped_total",
+			Help:      "Total number of events dropped because the consumer's buffer was full",
+		},
+		[]string{"topic", "consumer"},
+	)
+
+	// EventsFailed counts events whose handler panicked.
+	EventsFailed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "eventbus",
+			Name:      "events_failed_total",
+			Help:      "Total number of events whose consumer handler panicked",
+		},
+		[]string{"topic", "consumer"},
+	)
+
+	// ConsumerLag reports the events buffered for a consumer and not yet handled.
+	ConsumerLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "eventbus",
+			Name:      "consumer_lag",
+			Help:      "Number of events buffered for a consumer that it has not handled yet",
+		},
+		[]string{"topic", "consumer"},
+	)
+)
@@ -80,6 +80,11 @@ func (f *SubscriptionFilter) MatchSubscriptions(ctx context.Context, event *Even
 
 // matchesSubscription checks if an event matches a subscription's filter criteria.
 func (f *SubscriptionFilter) matchesSubscription(event *Event, sub *storage.Subscription) bool {
+	// Suspended subscriptions receive no notifications
+	if sub.Suspended() {
+		return false
+	}
+
 	filter := sub.Filter
 
 	// Check resource pool ID filter
@@ -18,6 +18,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/piwi3910/netweave/internal/models"
+	"github.com/piwi3910/netweave/internal/resolver"
 	"github.com/piwi3910/netweave/internal/storage"
 )
 
@@ -155,6 +156,7 @@ func createHTTPClient(config *NotifierConfig) (*http.Client, error) {
 	}
 
 	transport := &http.Transport{
+		DialContext:         resolver.DialContext,
 		TLSClientConfig:     tlsConfig,
 		MaxIdleConns:        100,
 		MaxIdleConnsPerHost: 10,
@@ -214,6 +216,10 @@ func (n *WebhookNotifier) NotifyWithRetry(
 	// Get or create circuit breaker for this callback URL
 	cb := n.getCircuitBreaker(subscription.Callback)
 
+	// Every attempt connects to the addresses of the first resolution, so the
+	// callback host cannot be rebound between retries
+	ctx = resolver.Pin(ctx)
+
 	// Attempt delivery with retries
 	backoff := initialBackoff
 	for attempt := 1; attempt <= n.config.MaxRetries; attempt++ {
@@ -0,0 +1,261 @@
+// Package export writes the resource and resource pool inventory as CSV or
+// Parquet files for analytics pipelines.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/piwi3910/netweave/internal/config"
+)
+
+// MaxColumns bounds the number of columns of an export.
+const MaxColumns = 100
+
+// csvFlushRows is the number of CSV rows buffered before they are flushed
+// to the client.
+const csvFlushRows = 500
+
+// DefaultColumns returns the columns exported for kind when none are
+// selected, or nil for an unknown kind.
+func DefaultColumns(kind string) []string {
+	switch kind {
+	case config.ExportKindResources:
+		return []string{"resourceId", "resourcePoolId", "resourceTypeId", "globalAssetId", "description"}
+	case config.ExportKindResourcePools:
+		return []string{"resourcePoolId", "name", "description", "location", "oCloudId", "globalLocationId"}
+	default:
+		return nil
+	}
+}
+
+// ParseColumns parses a comma-separated column list. A column is an
+// attribute name or a slash-separated path into nested attributes, e.g.
+// extensions/cpu.
+func ParseColumns(value string) ([]string, error) {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, column := range strings.Split(value, ",") {
+		column = strings.TrimSpace(column)
+		if column == "" || strings.HasPrefix(column, "/") || strings.HasSuffix(column, "/") ||
+			strings.ContainsAny(column, "\"\\") {
+			return nil, fmt.Errorf("invalid column %q", column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("duplicate column %q", column)
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	if len(columns) > MaxColumns {
+		return nil, fmt.Errorf("too many columns (maximum %d)", MaxColumns)
+	}
+	return columns, nil
+}
+
+// ContentType returns the media type of an export format.
+func ContentType(format string) string {
+	if format == config.ExportFormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// FileName returns the file name of an export of kind taken at at.
+func FileName(kind, format string, at time.Time) string {
+	return kind + "-" + at.UTC().Format("20060102T150405Z") + "." + format
+}
+
+// rowWriter writes rows of column values; nil values are nulls.
+type rowWriter interface {
+	writeRow(values []*string) error
+	close() error
+}
+
+// Encoder writes inventory objects as rows of the selected columns.
+type Encoder struct {
+	paths [][]string
+	rows  rowWriter
+}
+
+// NewEncoder creates an encoder writing format to w.
+func NewEncoder(w io.Writer, format string, columns []string) (*Encoder, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	paths := make([][]string, len(columns))
+	for i, column := range columns {
+		paths[i] = strings.Split(column, "/")
+	}
+
+	var rows rowWriter
+	var err error
+	switch format {
+	case config.ExportFormatCSV:
+		rows, err = newCSVWriter(w, columns)
+	case config.ExportFormatParquet:
+		rows = newParquetWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("invalid format %q (must be %s or %s)",
+			format, config.ExportFormatCSV, config.ExportFormatParquet)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{paths: paths, rows: rows}, nil
+}
+
+// Write writes obj, an inventory object, as one row. Attributes missing from
+// obj are written as nulls; nested objects and arrays as JSON.
+func (e *Encoder) Write(obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var attributes map[string]interface{}
+	if err := decoder.Decode(&attributes); err != nil {
+		return fmt.Errorf("failed to decode object: %w", err)
+	}
+
+	values := make([]*string, len(e.paths))
+	for i, path := range e.paths {
+		value, err := columnValue(attributes, path)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	return e.rows.writeRow(values)
+}
+
+// Close flushes the buffered rows and completes the file.
+func (e *Encoder) Close() error {
+	return e.rows.close()
+}
+
+// columnValue returns the value at path in attributes as a string, or nil if
+// it is absent.
+func columnValue(attributes map[string]interface{}, path []string) (*string, error) {
+	var value interface{} = attributes
+	for _, key := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		if value, ok = obj[key]; !ok {
+			return nil, nil
+		}
+	}
+
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		text = v
+	case json.Number:
+		text = v.String()
+	case bool:
+		text = fmt.Sprint(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode column %s: %w", strings.Join(path, "/"), err)
+		}
+		text = string(data)
+	}
+	return &text, nil
+}
+
+// csvWriter writes rows as CSV with a header row; nulls are empty fields.
+type csvWriter struct {
+	w       *csv.Writer
+	pending int
+	record  []string
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	writer := &csvWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+	if err := writer.w.Write(columns); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	return writer, nil
+}
+
+func (c *csvWriter) writeRow(values []*string) error {
+	for i, value := range values {
+		c.record[i] = ""
+		if value != nil {
+			c.record[i] = *value
+		}
+	}
+	if err := c.w.Write(c.record); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+	c.pending++
+	if c.pending >= csvFlushRows {
+		c.pending = 0
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// parquetWriter writes rows as a Snappy-compressed Parquet file of optional
+// string columns, in the selected order.
+type parquetWriter struct {
+	w       *parquet.Writer
+	rowType reflect.Type
+}
+
+func newParquetWriter(w io.Writer, columns []string) *parquetWriter {
+	// A struct type with one tagged field per column keeps the columns in the
+	// selected order; parquet.Group would sort them by name.
+	fields := make([]reflect.StructField, len(columns))
+	for i, column := range columns {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Column%d", i),
+			Type: reflect.TypeOf((*string)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`parquet:%q`, column+",optional")),
+		}
+	}
+	rowType := reflect.StructOf(fields)
+	schema := parquet.SchemaOf(reflect.New(rowType).Interface())
+	return &parquetWriter{
+		w:       parquet.NewWriter(w, schema, parquet.Compression(&parquet.Snappy)),
+		rowType: rowType,
+	}
+}
+
+func (p *parquetWriter) writeRow(values []*string) error {
+	row := reflect.New(p.rowType).Elem()
+	for i, value := range values {
+		row.Field(i).Set(reflect.ValueOf(value))
+	}
+	if err := p.w.Write(row.Addr().Interface()); err != nil {
+		return fmt.Errorf("failed to write Parquet row: %w", err)
+	}
+	return nil
+}
+
+func (p *parquetWriter) close() error {
+	if err := p.w.Close(); err != nil {
+		return fmt.Errorf("failed to complete Parquet file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/export"
+)
+
+var testResources = []*adapter.Resource{
+	{
+		ResourceID: "node-1", ResourcePoolID: "pool-a", ResourceTypeID: "compute",
+		Extensions: map[string]interface{}{"cpu": 16, "labels": map[string]interface{}{"zone": "a"}},
+	},
+	{ResourceID: "node-2", ResourcePoolID: "pool-a", Description: `rack "b", slot 3`},
+}
+
+func encode(t *testing.T, format string, columns []string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder, err := export.NewEncoder(&buf, format, columns)
+	require.NoError(t, err)
+	for _, resource := range testResources {
+		require.NoError(t, encoder.Write(resource))
+	}
+	require.NoError(t, encoder.Close())
+	return buf.Bytes()
+}
+
+func TestEncoder_CSV(t *testing.T) {
+	data := encode(t, config.ExportFormatCSV,
+		[]string{"resourceId", "description", "extensions/cpu", "extensions/labels", "extensions/missing"})
+	assert.Equal(t, "resourceId,description,extensions/cpu,extensions/labels,extensions/missing\n"+
+		`node-1,,16,"{""zone"":""a""}",`+"\n"+
+		`node-2,"rack ""b"", slot 3",,,`+"\n", string(data))
+}
+
+func TestEncoder_Parquet(t *testing.T) {
+	columns := []string{"resourceId", "resourcePoolId", "extensions/cpu", "description"}
+	data := encode(t, config.ExportFormatParquet, columns)
+
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), file.NumRows())
+	var names []string
+	for _, field := range file.Schema().Fields() {
+		names = append(names, field.Name())
+		assert.True(t, field.Optional())
+	}
+	assert.Equal(t, columns, names, "columns keep the selected order")
+
+	rows := make([]parquet.Row, 2)
+	reader := parquet.NewReader(file)
+	n, err := reader.ReadRows(rows)
+	if err != nil {
+		require.ErrorIs(t, err, io.EOF)
+	}
+	require.Equal(t, 2, n)
+	assert.Equal(t, "node-1", rows[0][0].String())
+	assert.Equal(t, "16", rows[0][2].String())
+	assert.True(t, rows[0][3].IsNull())
+	assert.True(t, rows[1][2].IsNull())
+	assert.Equal(t, `rack "b", slot 3`, rows[1][3].String())
+}
+
+func TestNewEncoder_Invalid(t *testing.T) {
+	_, err := export.NewEncoder(io.Discard, "xml", []string{"resourceId"})
+	assert.ErrorContains(t, err, "invalid format")
+	_, err = export.NewEncoder(io.Discard, config.ExportFormatCSV, nil)
+	assert.ErrorContains(t, err, "no columns selected")
+}
+
+func TestParseColumns(t *testing.T) {
+	columns, err := export.ParseColumns(" resourceId, extensions/cpu ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"resourceId", "extensions/cpu"}, columns)
+
+	for _, value := range []string{"", "resourceId,", "/cpu", "extensions/", "resourceId,resourceId", `a"b`} {
+		_, err := export.ParseColumns(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, "resourcePools-20260301T123000Z.parquet",
+		export.FileName(config.ExportKindResourcePools, config.ExportFormatParquet, at))
+}
+
+func TestS3Store_Put(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	var gotPath, gotType string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotType = r.URL.Path, r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store, err := export.NewS3Store(context.Background(), config.ExportObjectStoreConfig{
+		Bucket: "inventory", Prefix: "netweave/inventory", Region: "us-east-1",
+		Endpoint: server.URL, UsePathStyle: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Put(context.Background(), "resources/export.csv", "text/csv", []byte("resourceId\n")))
+
+	assert.Equal(t, "/inventory/netweave/inventory/resources/export.csv", gotPath)
+	assert.Equal(t, "text/csv", gotType)
+	assert.Equal(t, "resourceId\n", string(gotBody))
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/resolver"
+)
+
+// ObjectStore stores export files.
+type ObjectStore interface {
+	// Put stores data under key.
+	Put(ctx context.Context, key, contentType string, data []byte) error
+}
+
+// S3Store stores export files in an S3-compatible bucket.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store creates a store writing to the configured bucket. Credentials
+// come from the default AWS credential chain.
+func NewS3Store(ctx context.Context, cfg config.ExportObjectStoreConfig) (*S3Store, error) {
+	httpClient := awshttp.NewBuildableClient().WithTransportOptions(func(t *http.Transport) {
+		t.DialContext = resolver.DialContext
+	})
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(cfg.Region), awsconfig.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	})
+	return &S3Store{client: client, bucket: cfg.Bucket, prefix: cfg.Prefix}, nil
+}
+
+// Put stores data under the configured prefix followed by key.
+func (s *S3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	objectKey := path.Join(s.prefix, key)
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(objectKey),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload s3://%s/%s: %w", s.bucket, objectKey, err)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 // Time is a custom scalar type for time.Time.
@@ -19,14 +21,14 @@ func MarshalTime(t Time) graphql.Marshaler {
 		return graphql.Null
 	}
 	return graphql.WriterFunc(func(w io.Writer) {
-		_, _ = io.WriteString(w, fmt.Sprintf(`"%s"`, time.Time(t).Format(time.RFC3339)))
+		_, _ = io.WriteString(w, fmt.Sprintf(`"%s"`, timeutil.Format(time.Time(t))))
 	})
 }
 
 // UnmarshalTime unmarshals GraphQL Time scalar.
 func UnmarshalTime(v interface{}) (Time, error) {
 	if str, ok := v.(string); ok {
-		t, err := time.Parse(time.RFC3339, str)
+		t, err := timeutil.Parse(str)
 		return Time(t), err
 	}
 	return Time{}, fmt.Errorf("time must be a string in RFC3339 format")
@@ -62,6 +62,16 @@ func PlaygroundHandler(endpoint string) gin.HandlerFunc {
 	}
 }
 
+// DrainCloseReason returns middleware that makes WebSocket subscriptions ended
+// by a server drain close with a reason asking the client to reconnect.
+func DrainCloseReason() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := transport.AppendCloseReason(c.Request.Context(), "server is draining; reconnect")
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 // GinHandler wraps a GraphQL handler for use with Gin.
 //
 // Example:
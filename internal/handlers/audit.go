@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/piwi3910/netweave/internal/auth"
-	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/history"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+	"github.com/piwi3910/netweave/internal/timeutil"
 	"go.uber.org/zap"
 )
 
@@ -33,16 +38,132 @@ func NewAuditHandler(store auth.Store, logger *zap.Logger) *AuditHandler {
 }
 
 // ListAuditEvents handles GET /audit/events.
-// Lists audit events with optional filtering.
+// Lists audit events filtered by tenantId, actor, type, action, outcome,
+// resourceType, since and until, sorted by time (default), actor or resource
+// in the given order, one page of ?limit= events at a time. The next page is
+// requested with the returned nextCursor. The offset parameter selects the
+// legacy offset paging, which only filters by tenant.
 func (h *AuditHandler) ListAuditEvents(c *gin.Context) {
 	ctx := c.Request.Context()
 	tenantID := auth.TenantIDFromContext(ctx)
 	isPlatformAdmin := auth.IsPlatformAdminFromContext(ctx)
 
+	// Determine which tenant's events to return.
+	var filterTenantID string
+	if isPlatformAdmin {
+		// Platform admins can filter by any tenant or view all.
+		filterTenantID = c.Query("tenantId")
+	} else {
+		// Regular users can only see their own tenant's events.
+		filterTenantID = tenantID
+	}
+
+	if _, ok := c.GetQuery("offset"); ok {
+		h.listAuditEventsByOffset(c, filterTenantID)
+		return
+	}
+
+	q, err := parseAuditQuery(c, filterTenantID)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	h.logger.Info("querying audit events",
+		zap.String("tenant_id", filterTenantID),
+		zap.String("sort", q.Sort),
+		zap.Int("limit", q.Limit),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
+	)
+
+	page, err := h.store.QueryEvents(ctx, q)
+	if errors.Is(err, history.ErrInvalidCursor) {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid cursor")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to query audit events", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve audit events")
+		return
+	}
+
+	body := gin.H{
+		"events": page.Events,
+		"limit":  q.Limit,
+		"total":  len(page.Events),
+	}
+	if page.NextCursor != "" {
+		body["nextCursor"] = page.NextCursor
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// auditFilterParams maps query parameters to the audit filter fields.
+var auditFilterParams = map[string]string{
+	"actor":        auth.AuditFilterActor,
+	"type":         auth.AuditFilterType,
+	"action":       auth.AuditFilterAction,
+	"outcome":      auth.AuditFilterOutcome,
+	"resourceType": auth.AuditFilterResourceType,
+}
+
+// parseAuditQuery parses the filter, sort and paging parameters of an audit
+// event query restricted to tenantID, unless it is empty.
+func parseAuditQuery(c *gin.Context, tenantID string) (history.Query, error) {
+	q := history.Query{
+		Filters: make(map[string]string),
+		Sort:    c.DefaultQuery("sort", history.SortTime),
+		Cursor:  c.Query("cursor"),
+	}
+	if tenantID != "" {
+		q.Filters[auth.AuditFilterTenant] = tenantID
+	}
+	for param, field := range auditFilterParams {
+		if value := c.Query(param); value != "" {
+			q.Filters[field] = value
+		}
+	}
+
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		q.Ascending = true
+	case "desc":
+	default:
+		return q, fmt.Errorf("invalid order %q: must be asc or desc", c.Query("order"))
+	}
+
+	// An invalid limit falls back to the default, as with offset paging.
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		q.Limit = limit
+	}
+
+	var err error
+	if since := c.Query("since"); since != "" {
+		if q.Since, err = timeutil.Parse(since); err != nil {
+			return q, fmt.Errorf("invalid since parameter: %w", err)
+		}
+	}
+	if until := c.Query("until"); until != "" {
+		if q.Until, err = timeutil.Parse(until); err != nil {
+			return q, fmt.Errorf("invalid until parameter: %w", err)
+		}
+	}
+
+	if err := q.Validate(); err != nil {
+		return q, err
+	}
+	q.Normalize()
+	return q, nil
+}
+
+// listAuditEventsByOffset lists the audit events of tenantID with the legacy
+// limit and offset paging.
+func (h *AuditHandler) listAuditEventsByOffset(c *gin.Context, filterTenantID string) {
+	ctx := c.Request.Context()
+
 	// Parse query parameters.
 	limitStr := c.DefaultQuery("limit", "50")
 	offsetStr := c.DefaultQuery("offset", "0")
-	queryTenantID := c.Query("tenantId")
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit < 1 {
@@ -57,31 +178,17 @@ func (h *AuditHandler) ListAuditEvents(c *gin.Context) {
 		offset = 0
 	}
 
-	// Determine which tenant's events to return.
-	var filterTenantID string
-	if isPlatformAdmin {
-		// Platform admins can filter by any tenant or view all.
-		filterTenantID = queryTenantID
-	} else {
-		// Regular users can only see their own tenant's events.
-		filterTenantID = tenantID
-	}
-
 	h.logger.Info("listing audit events",
 		zap.String("tenant_id", filterTenantID),
 		zap.Int("limit", limit),
 		zap.Int("offset", offset),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	events, err := h.store.ListEvents(ctx, filterTenantID, limit, offset)
 	if err != nil {
 		h.logger.Error("failed to list audit events", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve audit events",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve audit events")
 		return
 	}
 
@@ -102,11 +209,7 @@ func (h *AuditHandler) ListAuditEventsByType(c *gin.Context) {
 	eventType := c.Param("eventType")
 
 	if eventType == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Event type is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Event type is required")
 		return
 	}
 
@@ -123,17 +226,13 @@ func (h *AuditHandler) ListAuditEventsByType(c *gin.Context) {
 	h.logger.Info("listing audit events by type",
 		zap.String("event_type", eventType),
 		zap.Int("limit", limit),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	events, err := h.store.ListEventsByType(ctx, auth.AuditEventType(eventType), limit)
 	if err != nil {
 		h.logger.Error("failed to list audit events by type", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve audit events",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve audit events")
 		return
 	}
 
@@ -162,11 +261,7 @@ func (h *AuditHandler) ListAuditEventsByUser(c *gin.Context) {
 	targetUserID := c.Param("userId")
 
 	if targetUserID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "User ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "User ID is required")
 		return
 	}
 
@@ -181,17 +276,13 @@ func (h *AuditHandler) ListAuditEventsByUser(c *gin.Context) {
 	h.logger.Info("listing audit events by user",
 		zap.String("target_user_id", targetUserID),
 		zap.Int("limit", limit),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	events, err := h.store.ListEventsByUser(ctx, targetUserID, limit)
 	if err != nil {
 		h.logger.Error("failed to list audit events by user", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve audit events",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve audit events")
 		return
 	}
 
@@ -227,11 +318,7 @@ func (h *AuditHandler) checkUserAccessPermission(ctx context.Context, c *gin.Con
 		}
 	}
 
-	c.JSON(http.StatusForbidden, models.ErrorResponse{
-		Error:   "Forbidden",
-		Message: "Access denied to audit events for this user",
-		Code:    http.StatusForbidden,
-	})
+	problem.Respond(c, http.StatusForbidden, "Forbidden", "Access denied to audit events for this user")
 	return false
 }
 
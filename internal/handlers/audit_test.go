@@ -168,6 +168,30 @@ func TestAuditHandler_ListAuditEvents(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name:            "invalid sort",
+			tenantID:        "tenant-1",
+			queryParams:     "?sort=size",
+			isPlatformAdmin: false,
+			setupStore:      func(_ *mockAuthStore) {},
+			wantStatus:      http.StatusBadRequest,
+		},
+		{
+			name:            "invalid order",
+			tenantID:        "tenant-1",
+			queryParams:     "?sort=actor&order=up",
+			isPlatformAdmin: false,
+			setupStore:      func(_ *mockAuthStore) {},
+			wantStatus:      http.StatusBadRequest,
+		},
+		{
+			name:            "invalid time window",
+			tenantID:        "tenant-1",
+			queryParams:     "?since=2026-10-02T00:00:00Z&until=2026-10-01T00:00:00Z",
+			isPlatformAdmin: false,
+			setupStore:      func(_ *mockAuthStore) {},
+			wantStatus:      http.StatusBadRequest,
+		},
 		{
 			name:            "list empty events",
 			tenantID:        "tenant-1",
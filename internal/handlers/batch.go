@@ -16,6 +16,8 @@ import (
 	"github.com/piwi3910/netweave/internal/adapter"
 	"github.com/piwi3910/netweave/internal/o2ims/models"
 	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 	"github.com/piwi3910/netweave/internal/storage"
 )
 
@@ -211,7 +213,7 @@ func (h *BatchHandler) executeBatch(
 
 	h.logger.Info(
 		fmt.Sprintf("batch %s", config.operationName),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.Int("item_count", config.itemCount),
 		zap.Bool("atomic", config.atomic),
 	)
@@ -219,11 +221,7 @@ func (h *BatchHandler) executeBatch(
 	// Validate batch size
 	if err := h.validateBatchSize(config.itemCount); err != nil {
 		h.logger.Warn("invalid batch size", zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return
 	}
 
@@ -487,11 +485,7 @@ func (h *BatchHandler) determineStatusCode(successCount, failureCount int) int {
 // handleBindError handles JSON binding errors.
 func (h *BatchHandler) handleBindError(c *gin.Context, err error) {
 	h.logger.Warn("invalid batch request body", zap.Error(err))
-	c.JSON(http.StatusBadRequest, models.ErrorResponse{
-		Error:   "BadRequest",
-		Message: "Invalid request body: " + err.Error(),
-		Code:    http.StatusBadRequest,
-	})
+	problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 }
 
 // sendAtomicValidationFailure sends a failure response for atomic validation.
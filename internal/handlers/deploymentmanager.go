@@ -10,6 +10,8 @@ import (
 	"github.com/piwi3910/netweave/internal/adapter"
 	internalmodels "github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // DeploymentManagerHandler handles Deployment Manager API endpoints.
@@ -47,7 +49,7 @@ func (h *DeploymentManagerHandler) ListDeploymentManagers(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	h.Logger.Info("listing deployment managers",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	// Parse query parameters
@@ -67,11 +69,7 @@ func (h *DeploymentManagerHandler) ListDeploymentManagers(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve deployment managers",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve deployment managers")
 		return
 	}
 
@@ -130,16 +128,12 @@ func (h *DeploymentManagerHandler) GetDeploymentManager(c *gin.Context) {
 
 	h.Logger.Info("getting deployment manager",
 		zap.String("deployment_manager_id", deploymentManagerID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	// Validate deployment manager ID
 	if deploymentManagerID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Deployment manager ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Deployment manager ID cannot be empty")
 		return
 	}
 
@@ -152,11 +146,7 @@ func (h *DeploymentManagerHandler) GetDeploymentManager(c *gin.Context) {
 				zap.String("deployment_manager_id", deploymentManagerID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Deployment manager not found: " + deploymentManagerID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Deployment manager not found: "+deploymentManagerID)
 			return
 		}
 
@@ -165,11 +155,7 @@ func (h *DeploymentManagerHandler) GetDeploymentManager(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve deployment manager",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve deployment manager")
 		return
 	}
 
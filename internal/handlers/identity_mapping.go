@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+	"go.uber.org/zap"
+)
+
+// IdentityMappingHandler handles the identity mapping dry-run endpoint.
+type IdentityMappingHandler struct {
+	mapper *auth.Middleware
+	logger *zap.Logger
+}
+
+// NewIdentityMappingHandler creates a new IdentityMappingHandler evaluating
+// the identity mapping rules of mapper.
+func NewIdentityMappingHandler(mapper *auth.Middleware, logger *zap.Logger) *IdentityMappingHandler {
+	if mapper == nil {
+		panic("auth middleware cannot be nil")
+	}
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return &IdentityMappingHandler{
+		mapper: mapper,
+		logger: logger,
+	}
+}
+
+// IdentityMappingTestRequest describes the client certificate to map, either
+// as a PEM-encoded certificate or as its subject DN and alternative names.
+type IdentityMappingTestRequest struct {
+	Certificate    string   `json:"certificate,omitempty"`
+	Subject        string   `json:"subject,omitempty"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+}
+
+// IdentityMappingTestResponse is the result of an identity mapping dry run.
+type IdentityMappingTestResponse struct {
+	// Subject is the normalized subject the certificate is known by.
+	Subject string `json:"subject"`
+
+	// Matched reports whether an identity mapping rule matched. Unmatched
+	// certificates are authenticated as the stored user with their subject.
+	Matched bool `json:"matched"`
+
+	// Mapping is the mapping of the rule that matched.
+	Mapping *auth.IdentityMapping `json:"mapping,omitempty"`
+
+	// Accepted reports whether the mapped tenant and role exist and the
+	// tenant is active, so the certificate would be authenticated.
+	Accepted bool `json:"accepted"`
+
+	// Reason explains why a matched mapping would be rejected.
+	Reason string `json:"reason,omitempty"`
+}
+
+// TestIdentityMapping handles POST /admin/identity-mappings/test.
+// Evaluates the identity mapping rules against a certificate without
+// authenticating it.
+func (h *IdentityMappingHandler) TestIdentityMapping(c *gin.Context) {
+	var req IdentityMappingTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
+		return
+	}
+
+	cert, err := h.certificateInfo(&req)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	resp := IdentityMappingTestResponse{Subject: h.mapper.BuildSubject(cert)}
+	resp.Mapping = h.mapper.MapIdentity(cert)
+	if resp.Mapping != nil {
+		resp.Matched = true
+		if _, _, err := h.mapper.ResolveIdentityMapping(c.Request.Context(), resp.Mapping); err != nil {
+			resp.Reason = err.Error()
+		} else {
+			resp.Accepted = true
+		}
+	}
+
+	h.logger.Info("identity mapping tested",
+		zap.String("subject", auth.SanitizeForLogging(resp.Subject, 200)),
+		zap.Bool("matched", resp.Matched),
+		zap.Bool("accepted", resp.Accepted),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
+	)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// certificateInfo returns the certificate described by req.
+func (h *IdentityMappingHandler) certificateInfo(req *IdentityMappingTestRequest) (*auth.CertificateInfo, error) {
+	if req.Certificate != "" {
+		block, _ := pem.Decode([]byte(req.Certificate))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, errors.New("certificate must be a PEM-encoded certificate")
+		}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.New("failed to parse certificate")
+		}
+		return h.mapper.CertificateInfoFromX509(parsed), nil
+	}
+
+	if req.Subject == "" {
+		return nil, errors.New("certificate or subject is required")
+	}
+	cert := h.mapper.ParseDNHeader(req.Subject)
+	if cert == nil {
+		return nil, errors.New("subject must be a valid DN with a common name")
+	}
+	cert.DNSNames = req.DNSNames
+	cert.URIs = req.URIs
+	cert.EmailAddresses = req.EmailAddresses
+	return cert, nil
+}
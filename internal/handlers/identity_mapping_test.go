@@ -0,0 +1,134 @@
+package handlers_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/handlers"
+)
+
+func selfSignedPEM(t *testing.T, template *x509.Certificate) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(1)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestIdentityMappingHandler_TestIdentityMapping(t *testing.T) {
+	store := newMockAuthStore()
+	store.tenants["ran-east"] = &auth.Tenant{ID: "ran-east", Status: auth.TenantStatusActive}
+	store.roles["role-operator"] = &auth.Role{ID: "role-operator", Name: auth.RoleOperator}
+	mw := auth.NewMiddleware(store, &auth.MiddlewareConfig{
+		Enabled: true,
+		IdentityMappings: []auth.IdentityMappingRule{
+			{
+				Name: "spiffe", Attribute: auth.CertAttributeSANURI,
+				Pattern: `^spiffe://ran\.example/ns/([a-z0-9-]+)/`, Tenant: "$1", Role: auth.RoleOperator,
+			},
+		},
+	}, zap.NewNop())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/admin/identity-mappings/test",
+		handlers.NewIdentityMappingHandler(mw, zap.NewNop()).TestIdentityMapping)
+
+	certPEM := selfSignedPEM(t, &x509.Certificate{
+		Subject: pkix.Name{CommonName: "du-1", Organization: []string{"RAN"}},
+		URIs:    []*url.URL{{Scheme: "spiffe", Host: "ran.example", Path: "/ns/ran-east/sa/du"}},
+	})
+
+	tests := []struct {
+		name       string
+		body       handlers.IdentityMappingTestRequest
+		wantStatus int
+		want       handlers.IdentityMappingTestResponse
+	}{
+		{
+			name:       "PEM certificate",
+			body:       handlers.IdentityMappingTestRequest{Certificate: certPEM},
+			wantStatus: http.StatusOK,
+			want: handlers.IdentityMappingTestResponse{
+				Subject: "CN=du-1,O=RAN", Matched: true, Accepted: true,
+				Mapping: &auth.IdentityMapping{
+					Rule: "spiffe", Attribute: auth.CertAttributeSANURI,
+					Value: "spiffe://ran.example/ns/ran-east/sa/du", TenantID: "ran-east", Role: auth.RoleOperator,
+				},
+			},
+		},
+		{
+			name: "unknown tenant",
+			body: handlers.IdentityMappingTestRequest{
+				Subject: "CN=du-2", URIs: []string{"spiffe://ran.example/ns/ran-north/sa/du"},
+			},
+			wantStatus: http.StatusOK,
+			want: handlers.IdentityMappingTestResponse{
+				Subject: "CN=du-2", Matched: true, Reason: "failed to get mapped tenant: tenant not found",
+				Mapping: &auth.IdentityMapping{
+					Rule: "spiffe", Attribute: auth.CertAttributeSANURI,
+					Value: "spiffe://ran.example/ns/ran-north/sa/du", TenantID: "ran-north", Role: auth.RoleOperator,
+				},
+			},
+		},
+		{
+			name:       "no match",
+			body:       handlers.IdentityMappingTestRequest{Subject: "CN=du-3,OU=ops"},
+			wantStatus: http.StatusOK,
+			want:       handlers.IdentityMappingTestResponse{Subject: "CN=du-3,OU=ops"},
+		},
+		{
+			name:       "invalid certificate",
+			body:       handlers.IdentityMappingTestRequest{Certificate: "not a certificate"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "nothing to map",
+			body:       handlers.IdentityMappingTestRequest{},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/admin/identity-mappings/test", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got handlers.IdentityMappingTestResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, tt.want, got)
+			assert.Empty(t, store.events, "dry runs are not audited as authentications")
+		})
+	}
+}
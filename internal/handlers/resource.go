@@ -9,8 +9,11 @@ import (
 
 	"github.com/piwi3910/netweave/internal/adapter"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/httperror"
 	internalmodels "github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // ResourceHandler handles Resource API endpoints.
@@ -35,20 +38,18 @@ func NewResourceHandler(adp adapter.Adapter, logger *zap.Logger) *ResourceHandle
 	}
 }
 
+// errorStatus returns the HTTP status the shared error translator assigns to
+// an error returned by adp.
+func errorStatus(adp adapter.Adapter, err error) int {
+	return httperror.Default.For(adp).Translate(err).Status
+}
+
 // handleGetError handles errors in Get* endpoints with standard error responses.
-func handleGetError(c *gin.Context, err error, entityType, entityID string) {
-	if strings.Contains(err.Error(), "not found") {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: entityType + " not found: " + entityID,
-			Code:    http.StatusNotFound,
-		})
+func handleGetError(c *gin.Context, adp adapter.Adapter, err error, entityType, entityID string) {
+	if errorStatus(adp, err) == http.StatusNotFound {
+		problem.Respond(c, http.StatusNotFound, "NotFound", entityType+" not found: "+entityID)
 	} else {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve " + entityType,
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+entityType)
 	}
 }
 
@@ -70,7 +71,7 @@ func (h *ResourceHandler) ListResources(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.Logger.Info("listing resources",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -96,11 +97,7 @@ func (h *ResourceHandler) ListResources(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve resources",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resources")
 		return
 	}
 
@@ -147,7 +144,7 @@ func (h *ResourceHandler) ListResourcesV2(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.Logger.Info("listing resources with v2 filtering",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -158,11 +155,7 @@ func (h *ResourceHandler) ListResourcesV2(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid filter parameters: " + err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid filter parameters: "+err.Error())
 		return
 	}
 
@@ -180,11 +173,7 @@ func (h *ResourceHandler) ListResourcesV2(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve resources",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resources")
 		return
 	}
 
@@ -300,17 +289,13 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 	)
 
 	if resourceID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Resource ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Resource ID cannot be empty")
 		return
 	}
 
 	resource, err := h.Adapter.GetResource(ctx, resourceID)
 	if err != nil {
-		handleGetError(c, err, "Resource", resourceID)
+		handleGetError(c, h.Adapter, err, "Resource", resourceID)
 		return
 	}
 
@@ -322,11 +307,7 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 			zap.String("resource_tenant_id", resource.TenantID),
 		)
 
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "Resource not found: " + resourceID,
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Resource not found: "+resourceID)
 		return
 	}
 
@@ -53,7 +53,7 @@ func (m *mockResourceAdapter) GetResourcePool(_ context.Context, poolID string)
 			return pool, nil
 		}
 	}
-	return nil, errors.New("not found")
+	return nil, adapter.ErrResourcePoolNotFound
 }
 
 func (m *mockResourceAdapter) CreateResourcePool(
@@ -92,7 +92,7 @@ func (m *mockResourceAdapter) GetResource(_ context.Context, resourceID string)
 			return resource, nil
 		}
 	}
-	return nil, errors.New("not found")
+	return nil, adapter.ErrResourceNotFound
 }
 
 func (m *mockResourceAdapter) CreateResource(_ context.Context, resource *adapter.Resource) (*adapter.Resource, error) {
@@ -115,7 +115,7 @@ func (m *mockResourceAdapter) UpdateResource(
 			return resource, nil
 		}
 	}
-	return nil, errors.New("resource not found")
+	return nil, adapter.ErrResourceNotFound
 }
 
 func (m *mockResourceAdapter) DeleteResource(_ context.Context, _ string) error {
@@ -135,7 +135,7 @@ func (m *mockResourceAdapter) GetResourceType(_ context.Context, typeID string)
 			return rt, nil
 		}
 	}
-	return nil, errors.New("not found")
+	return nil, adapter.ErrResourceTypeNotFound
 }
 
 func (m *mockResourceAdapter) CreateSubscription(
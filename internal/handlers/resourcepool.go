@@ -11,6 +11,8 @@ import (
 	"github.com/piwi3910/netweave/internal/auth"
 	internalmodels "github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // ResourcePoolHandler handles Resource Pool API endpoints.
@@ -51,7 +53,7 @@ func (h *ResourcePoolHandler) ListResourcePools(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.logger.Info("listing resource pools",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -76,11 +78,7 @@ func (h *ResourcePoolHandler) ListResourcePools(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve resource pools",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resource pools")
 		return
 	}
 
@@ -127,7 +125,7 @@ func (h *ResourcePoolHandler) ListResourcePoolsV2(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.logger.Info("listing resource pools with v2 filtering",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -138,11 +136,7 @@ func (h *ResourcePoolHandler) ListResourcePoolsV2(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid filter parameters: " + err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid filter parameters: "+err.Error())
 		return
 	}
 
@@ -160,11 +154,7 @@ func (h *ResourcePoolHandler) ListResourcePoolsV2(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve resource pools",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resource pools")
 		return
 	}
 
@@ -279,17 +269,13 @@ func (h *ResourcePoolHandler) GetResourcePool(c *gin.Context) {
 	)
 
 	if resourcePoolID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Resource pool ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Resource pool ID cannot be empty")
 		return
 	}
 
 	pool, err := h.adapter.GetResourcePool(ctx, resourcePoolID)
 	if err != nil {
-		handleGetError(c, err, "Resource pool", resourcePoolID)
+		handleGetError(c, h.adapter, err, "Resource pool", resourcePoolID)
 		return
 	}
 
@@ -301,11 +287,7 @@ func (h *ResourcePoolHandler) GetResourcePool(c *gin.Context) {
 			zap.String("pool_tenant_id", pool.TenantID),
 		)
 
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "Resource pool not found: " + resourcePoolID,
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 		return
 	}
 
@@ -337,7 +319,7 @@ func (h *ResourcePoolHandler) CreateResourcePool(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.logger.Info("creating resource pool",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -347,21 +329,13 @@ func (h *ResourcePoolHandler) CreateResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate required fields
 	if pool.Name == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Resource pool name is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Resource pool name is required")
 		return
 	}
 
@@ -380,16 +354,12 @@ func (h *ResourcePoolHandler) CreateResourcePool(c *gin.Context) {
 	// Create resource pool via adapter
 	createdPool, err := h.adapter.CreateResourcePool(ctx, adapterPool)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
+		if errorStatus(h.adapter, err) == http.StatusConflict {
 			h.logger.Warn("resource pool already exists",
 				zap.String("name", pool.Name),
 			)
 
-			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error:   "Conflict",
-				Message: "Resource pool already exists",
-				Code:    http.StatusConflict,
-			})
+			problem.Respond(c, http.StatusConflict, "Conflict", "Resource pool already exists")
 			return
 		}
 
@@ -398,11 +368,7 @@ func (h *ResourcePoolHandler) CreateResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to create resource pool",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to create resource pool")
 		return
 	}
 
@@ -446,17 +412,13 @@ func (h *ResourcePoolHandler) UpdateResourcePool(c *gin.Context) {
 
 	h.logger.Info("updating resource pool",
 		zap.String("resource_pool_id", resourcePoolID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
 	// Validate resource pool ID
 	if resourcePoolID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Resource pool ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Resource pool ID cannot be empty")
 		return
 	}
 
@@ -466,27 +428,19 @@ func (h *ResourcePoolHandler) UpdateResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// First verify tenant ownership
 	existingPool, err := h.adapter.GetResourcePool(ctx, resourcePoolID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errorStatus(h.adapter, err) == http.StatusNotFound {
 			h.logger.Warn("resource pool not found",
 				zap.String("resource_pool_id", resourcePoolID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Resource pool not found: " + resourcePoolID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 			return
 		}
 
@@ -495,11 +449,7 @@ func (h *ResourcePoolHandler) UpdateResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to update resource pool",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to update resource pool")
 		return
 	}
 
@@ -511,11 +461,7 @@ func (h *ResourcePoolHandler) UpdateResourcePool(c *gin.Context) {
 			zap.String("pool_tenant_id", existingPool.TenantID),
 		)
 
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "Resource pool not found: " + resourcePoolID,
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 		return
 	}
 
@@ -534,16 +480,12 @@ func (h *ResourcePoolHandler) UpdateResourcePool(c *gin.Context) {
 	// Update resource pool via adapter
 	updatedPool, err := h.adapter.UpdateResourcePool(ctx, resourcePoolID, adapterPool)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errorStatus(h.adapter, err) == http.StatusNotFound {
 			h.logger.Warn("resource pool not found",
 				zap.String("resource_pool_id", resourcePoolID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Resource pool not found: " + resourcePoolID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 			return
 		}
 
@@ -552,11 +494,7 @@ func (h *ResourcePoolHandler) UpdateResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to update resource pool",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to update resource pool")
 		return
 	}
 
@@ -597,33 +535,25 @@ func (h *ResourcePoolHandler) DeleteResourcePool(c *gin.Context) {
 
 	h.logger.Info("deleting resource pool",
 		zap.String("resource_pool_id", resourcePoolID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
 	// Validate resource pool ID
 	if resourcePoolID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Resource pool ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Resource pool ID cannot be empty")
 		return
 	}
 
 	// First verify tenant ownership
 	existingPool, err := h.adapter.GetResourcePool(ctx, resourcePoolID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errorStatus(h.adapter, err) == http.StatusNotFound {
 			h.logger.Warn("resource pool not found",
 				zap.String("resource_pool_id", resourcePoolID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Resource pool not found: " + resourcePoolID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 			return
 		}
 
@@ -632,11 +562,7 @@ func (h *ResourcePoolHandler) DeleteResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to delete resource pool",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to delete resource pool")
 		return
 	}
 
@@ -648,27 +574,19 @@ func (h *ResourcePoolHandler) DeleteResourcePool(c *gin.Context) {
 			zap.String("pool_tenant_id", existingPool.TenantID),
 		)
 
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "Resource pool not found: " + resourcePoolID,
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 		return
 	}
 
 	// Delete resource pool via adapter
 	err = h.adapter.DeleteResourcePool(ctx, resourcePoolID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errorStatus(h.adapter, err) == http.StatusNotFound {
 			h.logger.Warn("resource pool not found",
 				zap.String("resource_pool_id", resourcePoolID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Resource pool not found: " + resourcePoolID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
 			return
 		}
 
@@ -677,11 +595,7 @@ func (h *ResourcePoolHandler) DeleteResourcePool(c *gin.Context) {
 				zap.String("resource_pool_id", resourcePoolID),
 			)
 
-			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error:   "Conflict",
-				Message: "Resource pool cannot be deleted: has active resources",
-				Code:    http.StatusConflict,
-			})
+			problem.Respond(c, http.StatusConflict, "Conflict", "Resource pool cannot be deleted: has active resources")
 			return
 		}
 
@@ -690,11 +604,7 @@ func (h *ResourcePoolHandler) DeleteResourcePool(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to delete resource pool",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to delete resource pool")
 		return
 	}
 
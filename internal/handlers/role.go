@@ -6,7 +6,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/piwi3910/netweave/internal/auth"
-	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 	"go.uber.org/zap"
 )
 
@@ -41,7 +42,7 @@ func (h *RoleHandler) ListRoles(c *gin.Context) {
 	h.logger.Info("listing roles",
 		zap.String("tenant_id", tenantID),
 		zap.Bool("is_platform_admin", isPlatformAdmin),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	var roles []*auth.Role
@@ -57,11 +58,7 @@ func (h *RoleHandler) ListRoles(c *gin.Context) {
 
 	if err != nil {
 		h.logger.Error("failed to list roles", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve roles",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve roles")
 		return
 	}
 
@@ -80,22 +77,14 @@ func (h *RoleHandler) GetRole(c *gin.Context) {
 	isPlatformAdmin := auth.IsPlatformAdminFromContext(ctx)
 
 	if roleID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Role ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Role ID is required")
 		return
 	}
 
 	role, err := h.store.GetRole(ctx, roleID)
 	if err != nil {
 		if errors.Is(err, auth.ErrRoleNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Role not found: " + roleID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Role not found: "+roleID)
 			return
 		}
 
@@ -103,21 +92,13 @@ func (h *RoleHandler) GetRole(c *gin.Context) {
 			zap.String("role_id", roleID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve role",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve role")
 		return
 	}
 
 	// Check access: platform admins can see all, others only their tenant's roles.
 	if !isPlatformAdmin && role.TenantID != "" && role.TenantID != tenantID {
-		c.JSON(http.StatusForbidden, models.ErrorResponse{
-			Error:   "Forbidden",
-			Message: "Access denied to role from different tenant",
-			Code:    http.StatusForbidden,
-		})
+		problem.Respond(c, http.StatusForbidden, "Forbidden", "Access denied to role from different tenant")
 		return
 	}
 
@@ -155,6 +136,7 @@ func (h *RoleHandler) ListPermissions(c *gin.Context) {
 		auth.PermissionRoleUpdate,
 		auth.PermissionRoleDelete,
 		auth.PermissionAuditRead,
+		auth.PermissionDMSAdapterManage,
 	}
 
 	// Convert to response format.
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -15,7 +14,10 @@ import (
 	"github.com/piwi3910/netweave/internal/auth"
 	internalmodels "github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 // SubscriptionHandler handles Subscription API endpoints.
@@ -61,7 +63,7 @@ func (h *SubscriptionHandler) ListSubscriptions(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.Logger.Info("listing subscriptions",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -83,11 +85,7 @@ func (h *SubscriptionHandler) ListSubscriptions(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve subscriptions",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve subscriptions")
 		return
 	}
 
@@ -165,7 +163,7 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	h.Logger.Info("creating subscription",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
@@ -182,22 +180,15 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 				h.Logger.Warn("subscription quota exceeded",
 					zap.String("tenant_id", tenantID),
 				)
-				c.JSON(http.StatusTooManyRequests, models.ErrorResponse{
-					Error:   "QuotaExceeded",
-					Message: "Subscription quota exceeded for tenant",
-					Code:    http.StatusTooManyRequests,
-				})
+				problem.Respond(c, http.StatusTooManyRequests, "QuotaExceeded",
+					"Subscription quota exceeded for tenant")
 				return
 			}
 			h.Logger.Error("failed to check subscription quota",
 				zap.String("tenant_id", tenantID),
 				zap.Error(err),
 			)
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "InternalError",
-				Message: "Failed to check subscription quota",
-				Code:    http.StatusInternalServerError,
-			})
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to check subscription quota")
 			return
 		}
 	}
@@ -237,11 +228,7 @@ func (h *SubscriptionHandler) parseAndValidateRequest(c *gin.Context) (*models.S
 	// Parse request body
 	if err := c.ShouldBindJSON(&sub); err != nil {
 		h.Logger.Warn("invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body: " + err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return nil, fmt.Errorf("failed to bind JSON: %w", err)
 	}
 
@@ -256,11 +243,7 @@ func (h *SubscriptionHandler) parseAndValidateRequest(c *gin.Context) (*models.S
 // validateCallbackURL validates the callback URL forma.
 func (h *SubscriptionHandler) validateCallbackURL(c *gin.Context, callback string) error {
 	if callback == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Callback URL is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Callback URL is required")
 		return fmt.Errorf("callback URL is required")
 	}
 
@@ -270,11 +253,8 @@ func (h *SubscriptionHandler) validateCallbackURL(c *gin.Context, callback strin
 			zap.String("callback", callback),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid callback URL: must be a valid HTTP or HTTPS URL",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest",
+			"Invalid callback URL: must be a valid HTTP or HTTPS URL")
 		return fmt.Errorf("invalid callback URL")
 	}
 
@@ -304,7 +284,7 @@ func (h *SubscriptionHandler) convertToStorageSubscription(
 		Callback:               sub.Callback,
 		ConsumerSubscriptionID: sub.ConsumerSubscriptionID,
 		Filter:                 storageFilter,
-		CreatedAt:              time.Now(),
+		CreatedAt:              timeutil.Now(),
 	}
 }
 
@@ -320,20 +300,12 @@ func (h *SubscriptionHandler) StoreSubscription(
 			h.Logger.Warn("subscription already exists",
 				zap.String("consumer_subscription_id", storageSub.ConsumerSubscriptionID),
 			)
-			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error:   "Conflict",
-				Message: "Subscription already exists",
-				Code:    http.StatusConflict,
-			})
+			problem.Respond(c, http.StatusConflict, "Conflict", "Subscription already exists")
 			return fmt.Errorf("subscription already exists: %w", err)
 		}
 
 		h.Logger.Error("failed to create subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to create subscription",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to create subscription")
 		return fmt.Errorf("failed to create subscription in storage: %w", err)
 	}
 
@@ -377,17 +349,13 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 
 	h.Logger.Info("getting subscription",
 		zap.String("subscription_id", subscriptionID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
 	// Validate subscription ID
 	if subscriptionID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Subscription ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Subscription ID cannot be empty")
 		return
 	}
 
@@ -399,11 +367,7 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 				zap.String("subscription_id", subscriptionID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Subscription not found: " + subscriptionID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 			return
 		}
 
@@ -412,11 +376,7 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve subscription",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve subscription")
 		return
 	}
 
@@ -428,11 +388,7 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 			zap.String("subscription_tenant_id", storageSub.TenantID),
 		)
 
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "Subscription not found: " + subscriptionID,
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 		return
 	}
 
@@ -475,17 +431,13 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 
 	h.Logger.Info("deleting subscription",
 		zap.String("subscription_id", subscriptionID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		zap.String("tenant_id", tenantID),
 	)
 
 	// Validate subscription ID
 	if subscriptionID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Subscription ID cannot be empty",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Subscription ID cannot be empty")
 		return
 	}
 
@@ -498,11 +450,7 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 					zap.String("subscription_id", subscriptionID),
 				)
 
-				c.JSON(http.StatusNotFound, models.ErrorResponse{
-					Error:   "NotFound",
-					Message: "Subscription not found: " + subscriptionID,
-					Code:    http.StatusNotFound,
-				})
+				problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 				return
 			}
 
@@ -511,11 +459,7 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 				zap.Error(err),
 			)
 
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "InternalError",
-				Message: "Failed to delete subscription",
-				Code:    http.StatusInternalServerError,
-			})
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to delete subscription")
 			return
 		}
 
@@ -527,11 +471,7 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 				zap.String("subscription_tenant_id", storageSub.TenantID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Subscription not found: " + subscriptionID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 			return
 		}
 	}
@@ -544,11 +484,7 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 				zap.String("subscription_id", subscriptionID),
 			)
 
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Subscription not found: " + subscriptionID,
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 			return
 		}
 
@@ -557,11 +493,7 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 			zap.Error(err),
 		)
 
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to delete subscription",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to delete subscription")
 		return
 	}
 
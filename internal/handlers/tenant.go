@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/mail"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -13,7 +14,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/piwi3910/netweave/internal/auth"
-	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+	"github.com/piwi3910/netweave/internal/timeutil"
 	"go.uber.org/zap"
 )
 
@@ -52,6 +56,36 @@ func validateEmail(email string) error {
 	return nil
 }
 
+// validateNotificationCallback validates the notification callback URL of a
+// tenant. The callback policy is applied when the callback is called.
+func validateNotificationCallback(callback string) error {
+	if callback == "" {
+		return nil // Callback is optional
+	}
+	parsed, err := url.Parse(callback)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("notificationCallback must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// storageRetryAfter is the Retry-After hint of requests rejected because
+// the auth store is unavailable.
+const storageRetryAfter = 5 * time.Second
+
+// respondTenantStoreError answers a failed tenant store call: 503 while the
+// auth store is unavailable (tenant writes are rejected during Redis
+// outages), 500 with message otherwise.
+func respondTenantStoreError(c *gin.Context, err error, message string) {
+	if errors.Is(err, auth.ErrStorageUnavailable) {
+		middleware.RespondBackpressure(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+			"Tenant storage is temporarily unavailable; retry later",
+			middleware.Backpressure{RetryAfter: storageRetryAfter})
+		return
+	}
+	problem.Respond(c, http.StatusInternalServerError, "InternalError", message)
+}
+
 // NewTenantHandler creates a new TenantHandler.
 func NewTenantHandler(store auth.Store, logger *zap.Logger) *TenantHandler {
 	if store == nil {
@@ -69,21 +103,23 @@ func NewTenantHandler(store auth.Store, logger *zap.Logger) *TenantHandler {
 
 // CreateTenantRequest represents the request body for creating a tenant.
 type CreateTenantRequest struct {
-	Name         string            `json:"name" binding:"required"`
-	Description  string            `json:"description,omitempty"`
-	ContactEmail string            `json:"contactEmail,omitempty"`
-	Metadata     map[string]string `json:"metadata,omitempty"`
-	Quota        *auth.TenantQuota `json:"quota,omitempty"`
+	Name                 string            `json:"name" binding:"required"`
+	Description          string            `json:"description,omitempty"`
+	ContactEmail         string            `json:"contactEmail,omitempty"`
+	NotificationCallback string            `json:"notificationCallback,omitempty"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
+	Quota                *auth.TenantQuota `json:"quota,omitempty"`
 }
 
 // UpdateTenantRequest represents the request body for updating a tenant.
 type UpdateTenantRequest struct {
-	Name         string            `json:"name,omitempty"`
-	Description  string            `json:"description,omitempty"`
-	ContactEmail string            `json:"contactEmail,omitempty"`
-	Metadata     map[string]string `json:"metadata,omitempty"`
-	Status       auth.TenantStatus `json:"status,omitempty"`
-	Quota        *auth.TenantQuota `json:"quota,omitempty"`
+	Name                 string            `json:"name,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	ContactEmail         string            `json:"contactEmail,omitempty"`
+	NotificationCallback string            `json:"notificationCallback,omitempty"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
+	Status               auth.TenantStatus `json:"status,omitempty"`
+	Quota                *auth.TenantQuota `json:"quota,omitempty"`
 }
 
 // ListTenants handles GET /admin/tenants.
@@ -92,17 +128,13 @@ func (h *TenantHandler) ListTenants(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	h.logger.Info("listing tenants",
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	tenants, err := h.store.ListTenants(ctx)
 	if err != nil {
 		h.logger.Error("failed to list tenants", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve tenants",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to retrieve tenants")
 		return
 	}
 
@@ -120,33 +152,28 @@ func (h *TenantHandler) CreateTenant(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
 		return
 	}
 
 	// Validate tenant name
 	if err := validateTenantName(req.Name); err != nil {
 		h.logger.Warn("invalid tenant name", zap.String("name", req.Name), zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return
 	}
 
 	// Validate email if provided
 	if err := validateEmail(req.ContactEmail); err != nil {
 		h.logger.Warn("invalid email", zap.String("email", req.ContactEmail), zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	// Validate notification callback if provided
+	if err := validateNotificationCallback(req.NotificationCallback); err != nil {
+		h.logger.Warn("invalid notification callback", zap.Error(err))
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return
 	}
 
@@ -157,32 +184,25 @@ func (h *TenantHandler) CreateTenant(c *gin.Context) {
 	}
 
 	tenant := &auth.Tenant{
-		ID:           uuid.New().String(),
-		Name:         req.Name,
-		Description:  req.Description,
-		Status:       auth.TenantStatusActive,
-		Quota:        quota,
-		Usage:        auth.TenantUsage{},
-		ContactEmail: req.ContactEmail,
-		Metadata:     req.Metadata,
+		ID:                   uuid.New().String(),
+		Name:                 req.Name,
+		Description:          req.Description,
+		Status:               auth.TenantStatusActive,
+		Quota:                quota,
+		Usage:                auth.TenantUsage{},
+		ContactEmail:         req.ContactEmail,
+		NotificationCallback: req.NotificationCallback,
+		Metadata:             req.Metadata,
 	}
 
 	if err := h.store.CreateTenant(ctx, tenant); err != nil {
 		if errors.Is(err, auth.ErrTenantExists) {
-			c.JSON(http.StatusConflict, models.ErrorResponse{
-				Error:   "Conflict",
-				Message: "Tenant already exists",
-				Code:    http.StatusConflict,
-			})
+			problem.Respond(c, http.StatusConflict, "Conflict", "Tenant already exists")
 			return
 		}
 
 		h.logger.Error("failed to create tenant", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to create tenant",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to create tenant")
 		return
 	}
 
@@ -192,7 +212,7 @@ func (h *TenantHandler) CreateTenant(c *gin.Context) {
 	h.logger.Info("tenant created",
 		zap.String("tenant_id", tenant.ID),
 		zap.String("name", tenant.Name),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	c.JSON(http.StatusCreated, tenant)
@@ -205,22 +225,14 @@ func (h *TenantHandler) GetTenant(c *gin.Context) {
 	tenantID := c.Param("tenantId")
 
 	if tenantID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Tenant ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Tenant ID is required")
 		return
 	}
 
 	tenant, err := h.store.GetTenant(ctx, tenantID)
 	if err != nil {
 		if errors.Is(err, auth.ErrTenantNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Tenant not found",
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Tenant not found")
 			return
 		}
 
@@ -228,11 +240,7 @@ func (h *TenantHandler) GetTenant(c *gin.Context) {
 			zap.String("tenant_id", tenantID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve tenant",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to retrieve tenant")
 		return
 	}
 
@@ -243,11 +251,7 @@ func (h *TenantHandler) validateUpdateTenantRequest(c *gin.Context, req *UpdateT
 	if req.Name != "" {
 		if err := validateTenantName(req.Name); err != nil {
 			h.logger.Warn("invalid tenant name", zap.String("name", req.Name), zap.Error(err))
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "BadRequest",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 			return err
 		}
 	}
@@ -255,15 +259,17 @@ func (h *TenantHandler) validateUpdateTenantRequest(c *gin.Context, req *UpdateT
 	if req.ContactEmail != "" {
 		if err := validateEmail(req.ContactEmail); err != nil {
 			h.logger.Warn("invalid email", zap.String("email", req.ContactEmail), zap.Error(err))
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "BadRequest",
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			})
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 			return err
 		}
 	}
 
+	if err := validateNotificationCallback(req.NotificationCallback); err != nil {
+		h.logger.Warn("invalid notification callback", zap.Error(err))
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return err
+	}
+
 	return nil
 }
 
@@ -271,20 +277,12 @@ func (h *TenantHandler) getTenantForUpdate(ctx context.Context, c *gin.Context,
 	tenant, err := h.store.GetTenant(ctx, tenantID)
 	if err != nil {
 		if errors.Is(err, auth.ErrTenantNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Tenant not found",
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Tenant not found")
 			return nil, fmt.Errorf("tenant not found: %w", err)
 		}
 
 		h.logger.Error("failed to get tenant", zap.String("tenant_id", tenantID), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve tenant",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to retrieve tenant")
 		return nil, fmt.Errorf("failed to get tenant: %w", err)
 	}
 
@@ -301,6 +299,9 @@ func (h *TenantHandler) applyTenantUpdates(tenant *auth.Tenant, req *UpdateTenan
 	if req.ContactEmail != "" {
 		tenant.ContactEmail = req.ContactEmail
 	}
+	if req.NotificationCallback != "" {
+		tenant.NotificationCallback = req.NotificationCallback
+	}
 	if req.Metadata != nil {
 		tenant.Metadata = req.Metadata
 	}
@@ -319,22 +320,14 @@ func (h *TenantHandler) UpdateTenant(c *gin.Context) {
 	tenantID := c.Param("tenantId")
 
 	if tenantID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Tenant ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Tenant ID is required")
 		return
 	}
 
 	var req UpdateTenantRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
 		return
 	}
 
@@ -354,11 +347,7 @@ func (h *TenantHandler) UpdateTenant(c *gin.Context) {
 			zap.String("tenant_id", tenantID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to update tenant",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to update tenant")
 		return
 	}
 
@@ -366,7 +355,7 @@ func (h *TenantHandler) UpdateTenant(c *gin.Context) {
 
 	h.logger.Info("tenant updated",
 		zap.String("tenant_id", tenant.ID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	c.JSON(http.StatusOK, tenant)
@@ -379,11 +368,7 @@ func (h *TenantHandler) DeleteTenant(c *gin.Context) {
 	tenantID := c.Param("tenantId")
 
 	if tenantID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Tenant ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Tenant ID is required")
 		return
 	}
 
@@ -391,20 +376,12 @@ func (h *TenantHandler) DeleteTenant(c *gin.Context) {
 	tenant, err := h.store.GetTenant(ctx, tenantID)
 	if err != nil {
 		if errors.Is(err, auth.ErrTenantNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "Tenant not found",
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Tenant not found")
 			return
 		}
 
 		h.logger.Error("failed to get tenant", zap.String("tenant_id", tenantID), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve tenant",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to retrieve tenant")
 		return
 	}
 
@@ -417,17 +394,13 @@ func (h *TenantHandler) DeleteTenant(c *gin.Context) {
 				zap.String("tenant_id", tenantID),
 				zap.Error(err),
 			)
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "InternalError",
-				Message: "Failed to mark tenant for deletion",
-				Code:    http.StatusInternalServerError,
-			})
+			respondTenantStoreError(c, err, "Failed to mark tenant for deletion")
 			return
 		}
 
 		h.logger.Info("tenant marked for deletion",
 			zap.String("tenant_id", tenantID),
-			zap.String("request_id", c.GetString("request_id")),
+			zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 		)
 
 		c.JSON(http.StatusAccepted, gin.H{
@@ -444,11 +417,7 @@ func (h *TenantHandler) DeleteTenant(c *gin.Context) {
 			zap.String("tenant_id", tenantID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to delete tenant",
-			Code:    http.StatusInternalServerError,
-		})
+		respondTenantStoreError(c, err, "Failed to delete tenant")
 		return
 	}
 
@@ -457,7 +426,7 @@ func (h *TenantHandler) DeleteTenant(c *gin.Context) {
 
 	h.logger.Info("tenant deleted",
 		zap.String("tenant_id", tenantID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	c.Status(http.StatusNoContent)
@@ -468,11 +437,7 @@ func (h *TenantHandler) DeleteTenant(c *gin.Context) {
 func (h *TenantHandler) GetCurrentTenant(c *gin.Context) {
 	tenant := auth.TenantFromContext(c.Request.Context())
 	if tenant == nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "Tenant not found in context",
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Tenant not found in context")
 		return
 	}
 
@@ -493,16 +458,16 @@ func (h *TenantHandler) logAuditEvent(
 	event := &auth.AuditEvent{
 		ID:           uuid.New().String(),
 		Type:         eventType,
-		TenantID:     c.GetString("tenant_id"),
+		TenantID:     requestcontext.Tenant(c.Request.Context()),
 		UserID:       "",
 		Subject:      "",
 		ResourceType: resourceType,
 		ResourceID:   resourceID,
 		Action:       action,
 		Details:      details,
-		ClientIP:     c.ClientIP(),
+		ClientIP:     middleware.GetClientIP(c),
 		UserAgent:    c.Request.UserAgent(),
-		Timestamp:    time.Now().UTC(),
+		Timestamp:    timeutil.Now(),
 	}
 
 	if user != nil {
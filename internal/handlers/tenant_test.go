@@ -13,6 +13,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/history"
 	"github.com/piwi3910/netweave/internal/o2ims/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -213,6 +214,10 @@ func (m *mockAuthStore) ListEventsByUser(_ context.Context, _ string, _ int) ([]
 	return m.events, nil
 }
 
+func (m *mockAuthStore) QueryEvents(_ context.Context, _ history.Query) (*auth.AuditEventPage, error) {
+	return &auth.AuditEventPage{Events: m.events}, nil
+}
+
 func (m *mockAuthStore) Ping(_ context.Context) error {
 	return nil
 }
@@ -366,6 +371,29 @@ func TestTenantHandler_CreateTenant(t *testing.T) {
 				assert.Equal(t, 50, response.Quota.MaxResourcePools)
 			},
 		},
+		{
+			name: "create tenant with notification callback",
+			requestBody: handlers.CreateTenantRequest{
+				Name:                 "Notified Tenant",
+				NotificationCallback: "https://smo.example.com/tenant-events",
+			},
+			wantStatus: http.StatusCreated,
+			validateBody: func(t *testing.T, body []byte) {
+				t.Helper()
+				var response auth.Tenant
+				err := json.Unmarshal(body, &response)
+				require.NoError(t, err)
+				assert.Equal(t, "https://smo.example.com/tenant-events", response.NotificationCallback)
+			},
+		},
+		{
+			name: "create with invalid notification callback",
+			requestBody: handlers.CreateTenantRequest{
+				Name:                 "Notified Tenant",
+				NotificationCallback: "ftp://smo.example.com/tenant-events",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name:        "create with invalid JSON",
 			requestBody: `{invalid json}`,
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	imsadapter "github.com/piwi3910/netweave/internal/adapter"
@@ -12,6 +11,7 @@ import (
 	"github.com/piwi3910/netweave/internal/dms/registry"
 	"github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
 	"go.uber.org/zap"
 )
 
@@ -328,6 +328,7 @@ func (h *TMForumHandler) ListTMF638Services(c *gin.Context) {
 			)
 			continue
 		}
+		deployments = h.dmsRegistry.NamespacePolicyOf(dmsAdapter).FilterDeployments(deployments)
 
 		for _, dep := range deployments {
 			tmfService := TransformDeploymentToTMF638Service(dep, baseURL)
@@ -351,6 +352,9 @@ func (h *TMForumHandler) GetTMF638Service(c *gin.Context) {
 	for _, dmsAdapter := range adapters {
 		dep, err := dmsAdapter.GetDeployment(ctx, serviceID)
 		if err == nil {
+			if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+				return
+			}
 			tmfService := TransformDeploymentToTMF638Service(dep, baseURL)
 			c.JSON(http.StatusOK, tmfService)
 			return
@@ -393,6 +397,10 @@ func (h *TMForumHandler) CreateTMF638Service(c *gin.Context) {
 		return
 	}
 
+	if h.namespaceDenied(c, dmsAdapter, h.deploymentNamespace(deploymentReq)) {
+		return
+	}
+
 	deployment, err := dmsAdapter.CreateDeployment(ctx, deploymentReq)
 	if err != nil {
 		h.logger.Error("failed to create deployment",
@@ -433,6 +441,9 @@ func (h *TMForumHandler) UpdateTMF638Service(c *gin.Context) {
 		if err != nil {
 			continue
 		}
+		if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+			return
+		}
 
 		// Apply updates
 		applyTMF638ServiceUpdate(dep, &updateReq)
@@ -460,6 +471,15 @@ func (h *TMForumHandler) DeleteTMF638Service(c *gin.Context) {
 	// Try to delete deployment from all adapters
 	adapters := h.dmsRegistry.List()
 	for _, dmsAdapter := range adapters {
+		if policy := h.dmsRegistry.NamespacePolicyOf(dmsAdapter); policy != nil {
+			dep, err := dmsAdapter.GetDeployment(ctx, serviceID)
+			if err != nil {
+				continue
+			}
+			if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+				return
+			}
+		}
 		err := dmsAdapter.DeleteDeployment(ctx, serviceID)
 		if err == nil {
 			c.Status(http.StatusNoContent)
@@ -473,6 +493,44 @@ func (h *TMForumHandler) DeleteTMF638Service(c *gin.Context) {
 	})
 }
 
+// namespaceDenied checks namespace against the namespace policy of dmsAdapter.
+// If the namespace is denied, it writes a 403 response naming the matched rule
+// and returns true.
+func (h *TMForumHandler) namespaceDenied(c *gin.Context, dmsAdapter dmsadapter.DMSAdapter, namespace string) bool {
+	err := h.dmsRegistry.NamespacePolicyOf(dmsAdapter).Check(namespace)
+	var denied *dmsadapter.NamespaceDeniedError
+	if !errors.As(err, &denied) {
+		return false
+	}
+
+	h.logger.Warn("DMS namespace access denied",
+		zap.String("adapter", dmsAdapter.Name()),
+		zap.String("namespace", denied.Namespace),
+		zap.String("rule", denied.Rule),
+	)
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":     "Forbidden",
+		"message":   denied.Error(),
+		"namespace": denied.Namespace,
+		"rule":      denied.Rule,
+	})
+	return true
+}
+
+// deploymentNamespace returns the namespace req deploys to on the default DMS
+// adapter.
+func (h *TMForumHandler) deploymentNamespace(req *dmsadapter.DeploymentRequest) string {
+	if req.Namespace != "" {
+		return req.Namespace
+	}
+	meta := h.dmsRegistry.GetMetadata(h.dmsRegistry.GetDefaultName())
+	if meta == nil {
+		return ""
+	}
+	namespace, _ := meta.Config["namespace"].(string)
+	return namespace
+}
+
 // ========================================
 // TMF641 - Service Ordering Management
 // ========================================
@@ -501,6 +559,7 @@ func (h *TMForumHandler) ListTMF641ServiceOrders(c *gin.Context) {
 			)
 			continue
 		}
+		deployments = h.dmsRegistry.NamespacePolicyOf(dmsAdapter).FilterDeployments(deployments)
 
 		for _, dep := range deployments {
 			order := TransformDeploymentToTMF641ServiceOrder(dep, baseURL)
@@ -533,6 +592,9 @@ func (h *TMForumHandler) GetTMF641ServiceOrder(c *gin.Context) {
 	for _, dmsAdapter := range adapters {
 		dep, err := dmsAdapter.GetDeployment(ctx, orderID)
 		if err == nil {
+			if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+				return
+			}
 			order := TransformDeploymentToTMF641ServiceOrder(dep, baseURL)
 			c.JSON(http.StatusOK, order)
 			return
@@ -587,6 +649,10 @@ func (h *TMForumHandler) CreateTMF641ServiceOrder(c *gin.Context) {
 
 	deploymentReq := TransformTMF641ServiceOrderToDeployment(&createReq, &firstItem)
 
+	if h.namespaceDenied(c, dmsAdapter, h.deploymentNamespace(deploymentReq)) {
+		return
+	}
+
 	deployment, err := dmsAdapter.CreateDeployment(ctx, deploymentReq)
 	if err != nil {
 		h.logger.Error("failed to create deployment for service order",
@@ -627,6 +693,9 @@ func (h *TMForumHandler) UpdateTMF641ServiceOrder(c *gin.Context) {
 		if err != nil {
 			continue
 		}
+		if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+			return
+		}
 
 		// Apply updates
 		applyTMF641ServiceOrderUpdate(dep, &updateReq)
@@ -652,6 +721,15 @@ func (h *TMForumHandler) DeleteTMF641ServiceOrder(c *gin.Context) {
 	// Try to delete deployment from all adapters
 	adapters := h.dmsRegistry.List()
 	for _, dmsAdapter := range adapters {
+		if policy := h.dmsRegistry.NamespacePolicyOf(dmsAdapter); policy != nil {
+			dep, err := dmsAdapter.GetDeployment(ctx, orderID)
+			if err != nil {
+				continue
+			}
+			if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+				return
+			}
+		}
 		err := dmsAdapter.DeleteDeployment(ctx, orderID)
 		if err == nil {
 			c.Status(http.StatusNoContent)
@@ -765,7 +843,7 @@ func (h *TMForumHandler) RegisterTMF688Hub(c *gin.Context) {
 		Callback:       hubReq.Callback,
 		Query:          hubReq.Query,
 		SubscriptionID: createdSub.SubscriptionID,
-		CreatedAt:      time.Now(),
+		CreatedAt:      timeutil.Now(),
 	}
 
 	// Store hub registration
@@ -956,6 +1034,7 @@ func (h *TMForumHandler) ListTMF640ServiceActivations(c *gin.Context) {
 			)
 			continue
 		}
+		deployments = h.dmsRegistry.NamespacePolicyOf(dmsAdapter).FilterDeployments(deployments)
 
 		for _, dep := range deployments {
 			activation := transformDeploymentToActivation(dep, baseURL)
@@ -979,6 +1058,9 @@ func (h *TMForumHandler) GetTMF640ServiceActivation(c *gin.Context) {
 	for _, dmsAdapter := range adapters {
 		dep, err := dmsAdapter.GetDeployment(ctx, activationID)
 		if err == nil {
+			if h.namespaceDenied(c, dmsAdapter, dep.Namespace) {
+				return
+			}
 			activation := transformDeploymentToActivation(dep, baseURL)
 			c.JSON(http.StatusOK, activation)
 			return
@@ -9,6 +9,7 @@ import (
 	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
 	"github.com/piwi3910/netweave/internal/models"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 const (
@@ -414,7 +415,7 @@ func applyTMF638ServiceUpdate(dep *dmsadapter.Deployment, update *models.TMF638S
 		dep.Extensions["serviceType"] = *update.ServiceType
 	}
 
-	dep.UpdatedAt = time.Now()
+	dep.UpdatedAt = timeutil.Now()
 }
 
 // ========================================
@@ -657,7 +658,7 @@ func applyTMF641ServiceOrderUpdate(dep *dmsadapter.Deployment, update *models.TM
 		dep.Extensions["orderCategory"] = *update.Category
 	}
 
-	dep.UpdatedAt = time.Now()
+	dep.UpdatedAt = timeutil.Now()
 }
 
 // generateDeploymentName generates a deployment name from order details.
@@ -747,6 +748,11 @@ func mapResourceEventTypeToTMF688(eventType string) string {
 // ShouldPublishEventToHub determines if an event should be published to a specific hub
 // based on the hub's query filter.
 func ShouldPublishEventToHub(event *controllers.ResourceEvent, hub *storage.HubRegistration) bool {
+	// Digests summarize events for the callback of one subscription
+	if event.Digest != nil {
+		return false
+	}
+
 	// Parse hub query to get filter
 	filter, err := ParseTMF688Query(hub.Query)
 	if err != nil {
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"net/http"
 	"net/mail"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/piwi3910/netweave/internal/auth"
-	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+	"github.com/piwi3910/netweave/internal/timeutil"
 	"go.uber.org/zap"
 )
 
@@ -58,17 +60,13 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	if tenantID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Tenant context required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Tenant context required")
 		return
 	}
 
 	h.logger.Info("listing users",
 		zap.String("tenant_id", tenantID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	users, err := h.store.ListUsersByTenant(ctx, tenantID)
@@ -77,11 +75,7 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 			zap.String("tenant_id", tenantID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve users",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve users")
 		return
 	}
 
@@ -99,22 +93,14 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	tenant := auth.TenantFromContext(ctx)
 
 	if tenantID == "" || tenant == nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Tenant context required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Tenant context required")
 		return
 	}
 
 	var req CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
 		return
 	}
 
@@ -139,7 +125,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		zap.String("user_id", user.ID),
 		zap.String("tenant_id", tenantID),
 		zap.String("subject", user.Subject),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	c.JSON(http.StatusCreated, user)
@@ -223,22 +209,14 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	userID := c.Param("userId")
 
 	if userID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "User ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "User ID is required")
 		return
 	}
 
 	user, err := h.store.GetUser(ctx, userID)
 	if err != nil {
 		if errors.Is(err, auth.ErrUserNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "User not found",
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "User not found")
 			return
 		}
 
@@ -246,21 +224,13 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 			zap.String("user_id", userID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve user",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve user")
 		return
 	}
 
 	// Ensure user belongs to the requesting tenant (unless platform admin).
 	if !auth.IsPlatformAdminFromContext(ctx) && user.TenantID != tenantID {
-		c.JSON(http.StatusForbidden, models.ErrorResponse{
-			Error:   "Forbidden",
-			Message: "Access denied to user from different tenant",
-			Code:    http.StatusForbidden,
-		})
+		problem.Respond(c, http.StatusForbidden, "Forbidden", "Access denied to user from different tenant")
 		return
 	}
 
@@ -275,22 +245,14 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	userID := c.Param("userId")
 
 	if userID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "User ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "User ID is required")
 		return
 	}
 
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Invalid request body",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body")
 		return
 	}
 
@@ -307,16 +269,14 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	if err := h.store.UpdateUser(ctx, user); err != nil {
 		h.logger.Error("failed to update user", zap.String("user_id", userID), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to update user",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to update user")
 		return
 	}
 
 	h.logAuditEvent(c, auth.AuditEventUserUpdated, user.ID, "user", "update", nil)
-	h.logger.Info("user updated", zap.String("user_id", user.ID), zap.String("request_id", c.GetString("request_id")))
+	h.logger.Info("user updated",
+		zap.String("user_id", user.ID),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())))
 	c.JSON(http.StatusOK, user)
 }
 
@@ -406,18 +366,10 @@ func (h *UserHandler) respondWithError(c *gin.Context, err error) {
 		if errorCode == "" {
 			errorCode = getErrorCode(hErr.status)
 		}
-		c.JSON(hErr.status, models.ErrorResponse{
-			Error:   errorCode,
-			Message: hErr.message,
-			Code:    hErr.status,
-		})
+		problem.Respond(c, hErr.status, errorCode, hErr.message)
 		return
 	}
-	c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-		Error:   "InternalError",
-		Message: "Internal server error",
-		Code:    http.StatusInternalServerError,
-	})
+	problem.Respond(c, http.StatusInternalServerError, "InternalError", "Internal server error")
 }
 
 // getErrorCode returns the appropriate error code string for HTTP status codes.
@@ -449,21 +401,13 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	userID := c.Param("userId")
 
 	if userID == "" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "User ID is required",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "User ID is required")
 		return
 	}
 
 	// Prevent self-deletion.
 	if currentUser != nil && currentUser.UserID == userID {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "BadRequest",
-			Message: "Cannot delete your own user account",
-			Code:    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Cannot delete your own user account")
 		return
 	}
 
@@ -471,30 +415,18 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	user, err := h.store.GetUser(ctx, userID)
 	if err != nil {
 		if errors.Is(err, auth.ErrUserNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "NotFound",
-				Message: "User not found",
-				Code:    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "User not found")
 			return
 		}
 
 		h.logger.Error("failed to get user", zap.String("user_id", userID), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve user",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve user")
 		return
 	}
 
 	// Ensure user belongs to the requesting tenant (unless platform admin).
 	if !auth.IsPlatformAdminFromContext(ctx) && user.TenantID != tenantID {
-		c.JSON(http.StatusForbidden, models.ErrorResponse{
-			Error:   "Forbidden",
-			Message: "Access denied to user from different tenant",
-			Code:    http.StatusForbidden,
-		})
+		problem.Respond(c, http.StatusForbidden, "Forbidden", "Access denied to user from different tenant")
 		return
 	}
 
@@ -503,11 +435,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 			zap.String("user_id", userID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to delete user",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to delete user")
 		return
 	}
 
@@ -523,7 +451,7 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	h.logger.Info("user deleted",
 		zap.String("user_id", userID),
-		zap.String("request_id", c.GetString("request_id")),
+		zap.String("request_id", requestcontext.RequestID(c.Request.Context())),
 	)
 
 	c.Status(http.StatusNoContent)
@@ -536,11 +464,7 @@ func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 	authUser := auth.UserFromContext(ctx)
 
 	if authUser == nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "NotFound",
-			Message: "User not found in context",
-			Code:    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "User not found in context")
 		return
 	}
 
@@ -551,11 +475,7 @@ func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 			zap.String("user_id", authUser.UserID),
 			zap.Error(err),
 		)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "InternalError",
-			Message: "Failed to retrieve user",
-			Code:    http.StatusInternalServerError,
-		})
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve user")
 		return
 	}
 
@@ -581,9 +501,9 @@ func (h *UserHandler) logAuditEvent(
 		ResourceID:   resourceID,
 		Action:       action,
 		Details:      details,
-		ClientIP:     c.ClientIP(),
+		ClientIP:     middleware.GetClientIP(c),
 		UserAgent:    c.Request.UserAgent(),
-		Timestamp:    time.Now().UTC(),
+		Timestamp:    timeutil.Now(),
 	}
 
 	if user != nil {
@@ -0,0 +1,369 @@
+// Package history is the query layer shared by the gateway's Redis-backed
+// history stores, such as the audit log. Entries are indexed in sorted sets
+// scored by their timestamp: one set of every entry and one set per value of
+// each filterable field. A Query is answered from the sets alone: filters
+// intersect the sets of their values, time windows and cursors become score
+// ranges, so only the entries of the returned page are loaded. Sorting by a
+// field other than time is done on the server over the matching entries.
+package history
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/piwi3910/netweave/internal/models"
+)
+
+// Sort orders of a query.
+const (
+	// SortTime orders entries by their timestamp.
+	SortTime = "time"
+
+	// SortActor orders entries by the actor that caused them.
+	SortActor = "actor"
+
+	// SortResource orders entries by the resource they concern.
+	SortResource = "resource"
+)
+
+// Query limits.
+const (
+	// DefaultLimit is the page size when a query has no limit.
+	DefaultLimit = 50
+
+	// MaxLimit caps the page size of a query.
+	MaxLimit = 1000
+
+	// MaxSortScan caps the number of matching entries sorted on the server
+	// for a sort other than SortTime. The most recent entries are sorted.
+	MaxSortScan = 10000
+
+	// intersectionTTL bounds the lifetime of the temporary set intersecting
+	// the filters of a query, should it not be deleted after the query.
+	intersectionTTL = time.Minute
+)
+
+// ErrInvalidCursor is returned for a cursor that is malformed or was issued
+// for another query.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Query selects, orders and pages history entries.
+type Query struct {
+	// Filters restricts entries to those whose field has the value, by
+	// field name. Only fields indexed by the Index can be filtered on.
+	Filters map[string]string
+
+	// Since and Until restrict entries to a time window. Zero means
+	// unbounded.
+	Since time.Time
+	Until time.Time
+
+	// Sort is SortTime (the default), SortActor or SortResource.
+	Sort string
+
+	// Ascending orders entries oldest or lowest first. The default is
+	// newest or highest first.
+	Ascending bool
+
+	// Limit is the page size: DefaultLimit when zero, at most MaxLimit.
+	Limit int
+
+	// Cursor continues the query after the page that returned it.
+	Cursor string
+}
+
+// Normalize applies the defaults and bounds to q.
+func (q *Query) Normalize() {
+	if q.Sort == "" {
+		q.Sort = SortTime
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	q.Limit = min(q.Limit, MaxLimit)
+}
+
+// Validate checks the sort order and time window of q.
+func (q *Query) Validate() error {
+	switch q.Sort {
+	case "", SortTime, SortActor, SortResource:
+	default:
+		return fmt.Errorf("invalid sort %q: must be one of %s, %s, %s", q.Sort, SortTime, SortActor, SortResource)
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && q.Until.Before(q.Since) {
+		return errors.New("until must not be before since")
+	}
+	return nil
+}
+
+// fingerprint identifies everything but the cursor and limit of q, so a
+// cursor is only accepted by the query that issued it.
+func (q *Query) fingerprint() string {
+	fields := make([]string, 0, len(q.Filters))
+	for field, value := range q.Filters {
+		fields = append(fields, field+"="+value)
+	}
+	sort.Strings(fields)
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%t|%d|%d|%s", q.Sort, q.Ascending, unixNano(q.Since), unixNano(q.Until),
+		strings.Join(fields, "&"))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Page is one page of entry IDs answering a query.
+type Page struct {
+	// IDs are the entries of the page, in query order.
+	IDs []string
+
+	// NextCursor continues the query after the page. It is empty on the
+	// last page.
+	NextCursor string
+}
+
+// Index maintains and queries the sorted sets of one history store.
+type Index struct {
+	// Client is the Redis client holding the sets.
+	Client redis.UniversalClient
+
+	// AllKey is the set of every entry.
+	AllKey string
+
+	// FieldPrefixes maps each filterable field to the key prefix of the
+	// sets of its values. The set of value v is FieldPrefixes[field]+v.
+	FieldPrefixes map[string]string
+
+	// TempPrefix prefixes the temporary sets intersecting filters.
+	TempPrefix string
+}
+
+// Add queues the indexing of entry id at time at with its field values on
+// pipe, and the removal of entries older than retention from the same sets.
+// Empty field values are not indexed.
+func (ix *Index) Add(
+	ctx context.Context, pipe redis.Pipeliner, id string, at time.Time,
+	fields map[string]string, retention time.Duration,
+) {
+	member := redis.Z{Score: float64(at.UnixNano()), Member: id}
+	cutoff := strconv.FormatFloat(float64(time.Now().Add(-retention).UnixNano()), 'f', -1, 64)
+	keys := []string{ix.AllKey}
+	for field, value := range fields {
+		if prefix, ok := ix.FieldPrefixes[field]; ok && value != "" {
+			keys = append(keys, prefix+value)
+		}
+	}
+	for _, key := range keys {
+		pipe.ZAdd(ctx, key, member)
+		pipe.ZRemRangeByScore(ctx, key, "-inf", cutoff)
+	}
+}
+
+// Query returns the page of entry IDs selected by q, which must be
+// normalized, ordered by time. For another sort, use Matching and SortPage.
+func (ix *Index) Query(ctx context.Context, q Query) (*Page, error) {
+	if err := ix.checkFilters(q); err != nil {
+		return nil, err
+	}
+	key, cleanup, err := ix.matchKey(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	minScore, maxScore := scoreRange(q)
+	var offset int64
+	if q.Cursor != "" {
+		last, lastID, err := ix.decodeTimeCursor(q)
+		if err != nil {
+			return nil, err
+		}
+		// Continue at the score of the last entry, skipping the entries
+		// sharing that score which were already returned.
+		bound := strconv.FormatFloat(last, 'f', -1, 64)
+		if q.Ascending {
+			minScore = bound
+		} else {
+			maxScore = bound
+		}
+		tied, err := ix.Client.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: bound, Max: bound}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query history index: %w", err)
+		}
+		for _, id := range tied {
+			if (q.Ascending && id <= lastID) || (!q.Ascending && id >= lastID) {
+				offset++
+			}
+		}
+	}
+
+	rangeBy := &redis.ZRangeBy{Min: minScore, Max: maxScore, Offset: offset, Count: int64(q.Limit) + 1}
+	var entries []redis.Z
+	if q.Ascending {
+		entries, err = ix.Client.ZRangeByScoreWithScores(ctx, key, rangeBy).Result()
+	} else {
+		entries, err = ix.Client.ZRevRangeByScoreWithScores(ctx, key, rangeBy).Result()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history index: %w", err)
+	}
+
+	page := &Page{IDs: make([]string, 0, min(len(entries), q.Limit))}
+	for i, entry := range entries {
+		if i == q.Limit {
+			last := entries[i-1]
+			page.NextCursor, err = models.EncodeCursor(map[string]interface{}{
+				"q":     q.fingerprint(),
+				"score": strconv.FormatFloat(last.Score, 'f', -1, 64),
+				"id":    last.Member,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode cursor: %w", err)
+			}
+			break
+		}
+		id, _ := entry.Member.(string)
+		page.IDs = append(page.IDs, id)
+	}
+	return page, nil
+}
+
+// Matching returns the IDs of the most recent entries selected by the
+// filters and time window of q, newest first, at most MaxSortScan of them.
+func (ix *Index) Matching(ctx context.Context, q Query) ([]string, error) {
+	if err := ix.checkFilters(q); err != nil {
+		return nil, err
+	}
+	key, cleanup, err := ix.matchKey(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	minScore, maxScore := scoreRange(q)
+	ids, err := ix.Client.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: minScore, Max: maxScore, Count: MaxSortScan,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history index: %w", err)
+	}
+	return ids, nil
+}
+
+// checkFilters rejects filters on fields the index does not maintain.
+func (ix *Index) checkFilters(q Query) error {
+	for field := range q.Filters {
+		if _, ok := ix.FieldPrefixes[field]; !ok {
+			return fmt.Errorf("cannot filter on %q", field)
+		}
+	}
+	return nil
+}
+
+// matchKey returns the set of the entries matching the filters of q. With
+// more than one filter, the sets of their values are intersected into a
+// temporary set, deleted by the returned cleanup.
+func (ix *Index) matchKey(ctx context.Context, q Query) (string, func(), error) {
+	keys := make([]string, 0, len(q.Filters))
+	for field, value := range q.Filters {
+		keys = append(keys, ix.FieldPrefixes[field]+value)
+	}
+	switch len(keys) {
+	case 0:
+		return ix.AllKey, func() {}, nil
+	case 1:
+		return keys[0], func() {}, nil
+	}
+
+	temp := ix.TempPrefix + uuid.New().String()
+	pipe := ix.Client.TxPipeline()
+	pipe.ZInterStore(ctx, temp, &redis.ZStore{Keys: keys, Aggregate: "MAX"})
+	pipe.Expire(ctx, temp, intersectionTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", nil, fmt.Errorf("failed to intersect history filters: %w", err)
+	}
+	return temp, func() { ix.Client.Del(context.WithoutCancel(ctx), temp) }, nil
+}
+
+// decodeTimeCursor returns the score and ID of the last entry of the page
+// that issued the cursor of q.
+func (ix *Index) decodeTimeCursor(q Query) (float64, string, error) {
+	data, err := models.DecodeCursor(q.Cursor)
+	if err != nil || data["q"] != q.fingerprint() {
+		return 0, "", ErrInvalidCursor
+	}
+	scoreStr, _ := data["score"].(string)
+	id, _ := data["id"].(string)
+	score, err := strconv.ParseFloat(scoreStr, 64)
+	if err != nil || id == "" || math.IsNaN(score) {
+		return 0, "", ErrInvalidCursor
+	}
+	return score, id, nil
+}
+
+// scoreRange returns the score range of the time window of q.
+func scoreRange(q Query) (string, string) {
+	minScore, maxScore := "-inf", "+inf"
+	if !q.Since.IsZero() {
+		minScore = strconv.FormatFloat(float64(q.Since.UnixNano()), 'f', -1, 64)
+	}
+	if !q.Until.IsZero() {
+		maxScore = strconv.FormatFloat(float64(q.Until.UnixNano()), 'f', -1, 64)
+	}
+	return minScore, maxScore
+}
+
+// unixNano returns the nanoseconds of t since the epoch, or 0 for the zero time.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// SortPage orders entries, the newest first as returned by Matching, by the
+// sort key of q and returns the page selected by the limit and cursor of q.
+// Entries with equal keys stay ordered newest first. The returned cursor is
+// empty on the last page.
+func SortPage[T any](q Query, entries []T, key func(T) string) ([]T, string, error) {
+	offset := 0
+	if q.Cursor != "" {
+		data, err := models.DecodeCursor(q.Cursor)
+		if err != nil || data["q"] != q.fingerprint() {
+			return nil, "", ErrInvalidCursor
+		}
+		value, ok := data["offset"].(float64)
+		if !ok || value < 0 {
+			return nil, "", ErrInvalidCursor
+		}
+		offset = int(value)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if q.Ascending {
+			return key(entries[i]) < key(entries[j])
+		}
+		return key(entries[i]) > key(entries[j])
+	})
+	if offset >= len(entries) {
+		return []T{}, "", nil
+	}
+	end := min(offset+q.Limit, len(entries))
+	if end == len(entries) {
+		return entries[offset:end], "", nil
+	}
+	cursor, err := models.EncodeCursor(map[string]interface{}{"q": q.fingerprint(), "offset": end})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return entries[offset:end], cursor, nil
+}
@@ -0,0 +1,123 @@
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/history"
+)
+
+func setupIndex(t *testing.T) *history.Index {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	return &history.Index{
+		Client:        redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		AllKey:        "h:all",
+		FieldPrefixes: map[string]string{"kind": "h:kind:", "owner": "h:owner:"},
+		TempPrefix:    "h:tmp:",
+	}
+}
+
+func add(t *testing.T, ix *history.Index, id string, at time.Time, fields map[string]string) {
+	t.Helper()
+	ctx := context.Background()
+	pipe := ix.Client.TxPipeline()
+	ix.Add(ctx, pipe, id, at, fields, time.Hour)
+	_, err := pipe.Exec(ctx)
+	require.NoError(t, err)
+}
+
+// collect pages through q and returns every ID in order.
+func collect(t *testing.T, ix *history.Index, q history.Query) []string {
+	t.Helper()
+	var ids []string
+	for {
+		page, err := ix.Query(context.Background(), q)
+		require.NoError(t, err)
+		ids = append(ids, page.IDs...)
+		if page.NextCursor == "" {
+			return ids
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+func TestIndex_Query(t *testing.T) {
+	ix := setupIndex(t)
+	now := time.Now()
+	// b, c and d share a timestamp, so the cursor has to break the tie.
+	add(t, ix, "a", now.Add(-3*time.Minute), map[string]string{"kind": "x", "owner": "o1"})
+	add(t, ix, "b", now.Add(-2*time.Minute), map[string]string{"kind": "y", "owner": "o1"})
+	add(t, ix, "c", now.Add(-2*time.Minute), map[string]string{"kind": "x", "owner": "o2"})
+	add(t, ix, "d", now.Add(-2*time.Minute), map[string]string{"kind": "x", "owner": "o1"})
+	add(t, ix, "e", now.Add(-time.Minute), map[string]string{"kind": "x"})
+
+	t.Run("descending across ties", func(t *testing.T) {
+		q := history.Query{Limit: 2}
+		q.Normalize()
+		assert.Equal(t, []string{"e", "d", "c", "b", "a"}, collect(t, ix, q))
+	})
+
+	t.Run("ascending across ties", func(t *testing.T) {
+		q := history.Query{Limit: 1, Ascending: true}
+		q.Normalize()
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, collect(t, ix, q))
+	})
+
+	t.Run("intersects filters", func(t *testing.T) {
+		q := history.Query{Filters: map[string]string{"kind": "x", "owner": "o1"}}
+		q.Normalize()
+		assert.Equal(t, []string{"d", "a"}, collect(t, ix, q))
+
+		// The temporary intersection is removed after the query.
+		keys, err := ix.Client.Keys(context.Background(), "h:tmp:*").Result()
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("rejects unknown filters", func(t *testing.T) {
+		_, err := ix.Query(context.Background(), history.Query{Filters: map[string]string{"color": "red"}})
+		require.Error(t, err)
+	})
+
+	t.Run("rejects a cursor of another query", func(t *testing.T) {
+		q := history.Query{Limit: 1}
+		q.Normalize()
+		page, err := ix.Query(context.Background(), q)
+		require.NoError(t, err)
+
+		q.Filters = map[string]string{"kind": "x"}
+		q.Cursor = page.NextCursor
+		_, err = ix.Query(context.Background(), q)
+		require.ErrorIs(t, err, history.ErrInvalidCursor)
+	})
+}
+
+func TestQuery_Validate(t *testing.T) {
+	now := time.Now()
+	require.NoError(t, (&history.Query{Sort: history.SortResource}).Validate())
+	require.Error(t, (&history.Query{Sort: "size"}).Validate())
+	require.Error(t, (&history.Query{Since: now, Until: now.Add(-time.Second)}).Validate())
+}
+
+func TestSortPage(t *testing.T) {
+	q := history.Query{Sort: history.SortActor, Ascending: true, Limit: 2}
+	entries := []string{"carol", "alice", "bob"}
+
+	page, cursor, err := history.SortPage(q, entries, func(s string) string { return s })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, page)
+	require.NotEmpty(t, cursor)
+
+	q.Cursor = cursor
+	page, cursor, err = history.SortPage(q, []string{"carol", "alice", "bob"}, func(s string) string { return s })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol"}, page)
+	assert.Empty(t, cursor)
+}
@@ -0,0 +1,270 @@
+// Package hooks runs integrator-supplied lifecycle hooks around gateway operations.
+//
+// Hooks are invoked before ("pre") and after ("post") selected create and delete
+// operations, for example to keep an external CMDB in sync. A hook is either an
+// HTTP webhook configured at startup or an in-process Go plugin registered with
+// RegisterPlugin. Each hook has its own timeout and failure policy: a failing
+// pre hook with the "abort" policy rejects the operation, while all other
+// failures are logged and counted but do not affect the operation.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Phase is the point in an operation at which a hook runs.
+type Phase string
+
+const (
+	// PhasePre runs before the operation is performed.
+	PhasePre Phase = "pre"
+
+	// PhasePost runs after the operation succeeded.
+	PhasePost Phase = "post"
+)
+
+// Operation is a gateway operation hooks can be attached to.
+type Operation string
+
+const (
+	// OperationCreate is the creation of an object.
+	OperationCreate Operation = "create"
+
+	// OperationDelete is the deletion of an object.
+	OperationDelete Operation = "delete"
+)
+
+// Object types passed in Event.ObjectType.
+const (
+	ObjectTypeResourcePool = "resourcePool"
+	ObjectTypeResource     = "resource"
+	ObjectTypeSubscription = "subscription"
+)
+
+// FailurePolicy controls what happens when a hook fails or times out.
+type FailurePolicy string
+
+const (
+	// FailurePolicyIgnore logs the failure and lets the operation proceed.
+	FailurePolicyIgnore FailurePolicy = "ignore"
+
+	// FailurePolicyAbort rejects the operation when a pre hook fails.
+	// Post hooks run after the operation completed and cannot abort it.
+	FailurePolicyAbort FailurePolicy = "abort"
+)
+
+// DefaultTimeout bounds a hook invocation when its registration sets no timeout.
+const DefaultTimeout = 5 * time.Second
+
+// ErrAborted is returned by Runner.Run when a pre hook with the abort policy fails.
+var ErrAborted = errors.New("operation aborted by lifecycle hook")
+
+// Event describes the operation a hook is invoked for.
+type Event struct {
+	// Phase is the point in the operation the hook runs at.
+	Phase Phase `json:"phase"`
+
+	// Operation is the operation being performed.
+	Operation Operation `json:"operation"`
+
+	// ObjectType is the type of object operated on (e.g. "resourcePool").
+	ObjectType string `json:"objectType"`
+
+	// ObjectID is the ID of the object, when known.
+	ObjectID string `json:"objectId,omitempty"`
+
+	// Object is the request body for pre-create hooks and the created object for
+	// post-create hooks. It is empty for delete operations.
+	Object interface{} `json:"object,omitempty"`
+
+	// Timestamp is when the hook was invoked.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Hook is invoked for matching lifecycle events.
+// Returning an error marks the invocation as failed.
+type Hook interface {
+	Invoke(ctx context.Context, event *Event) error
+}
+
+// HookFunc adapts a function to the Hook interface.
+type HookFunc func(ctx context.Context, event *Event) error
+
+// Invoke calls f(ctx, event).
+func (f HookFunc) Invoke(ctx context.Context, event *Event) error {
+	return f(ctx, event)
+}
+
+// Registration attaches a hook to a set of lifecycle events.
+type Registration struct {
+	// Name identifies the hook in logs, errors, and metrics.
+	Name string
+
+	// Hook is invoked for matching events.
+	Hook Hook
+
+	// Phases restricts the hook to these phases. Empty matches all phases.
+	Phases []Phase
+
+	// Operations restricts the hook to these operations. Empty matches all operations.
+	Operations []Operation
+
+	// ObjectTypes restricts the hook to these object types. Empty matches all types.
+	ObjectTypes []string
+
+	// Timeout bounds each invocation. Zero uses DefaultTimeout.
+	Timeout time.Duration
+
+	// FailurePolicy controls failure handling. Empty means FailurePolicyIgnore.
+	FailurePolicy FailurePolicy
+}
+
+// Validate checks that the registration is well formed.
+func (r *Registration) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("hook name is required")
+	}
+	if r.Hook == nil {
+		return fmt.Errorf("hook %q has no implementation", r.Name)
+	}
+	if r.Timeout < 0 {
+		return fmt.Errorf("hook %q timeout cannot be negative", r.Name)
+	}
+	for _, phase := range r.Phases {
+		if phase != PhasePre && phase != PhasePost {
+			return fmt.Errorf("hook %q has invalid phase %q (must be pre or post)", r.Name, phase)
+		}
+	}
+	for _, op := range r.Operations {
+		if op != OperationCreate && op != OperationDelete {
+			return fmt.Errorf("hook %q has invalid operation %q (must be create or delete)", r.Name, op)
+		}
+	}
+	switch r.FailurePolicy {
+	case "", FailurePolicyIgnore, FailurePolicyAbort:
+	default:
+		return fmt.Errorf("hook %q has invalid failure policy %q (must be ignore or abort)", r.Name, r.FailurePolicy)
+	}
+	return nil
+}
+
+// matches reports whether the registration applies to the event.
+func (r *Registration) matches(event *Event) bool {
+	return (len(r.Phases) == 0 || slices.Contains(r.Phases, event.Phase)) &&
+		(len(r.Operations) == 0 || slices.Contains(r.Operations, event.Operation)) &&
+		(len(r.ObjectTypes) == 0 || slices.Contains(r.ObjectTypes, event.ObjectType))
+}
+
+// Runner invokes registered hooks in registration order.
+// A nil *Runner has no hooks, so callers need not check whether hooks are configured.
+type Runner struct {
+	logger        *zap.Logger
+	registrations []Registration
+}
+
+// NewRunner creates a Runner without hooks.
+func NewRunner(logger *zap.Logger) *Runner {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Runner{logger: logger}
+}
+
+// Register adds a hook. Registration is not safe for concurrent use with Run
+// and is expected to happen during startup.
+func (r *Runner) Register(reg Registration) error {
+	if err := reg.Validate(); err != nil {
+		return err
+	}
+	if reg.Timeout == 0 {
+		reg.Timeout = DefaultTimeout
+	}
+	if reg.FailurePolicy == "" {
+		reg.FailurePolicy = FailurePolicyIgnore
+	}
+	r.registrations = append(r.registrations, reg)
+	return nil
+}
+
+// Len returns the number of registered hooks.
+func (r *Runner) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.registrations)
+}
+
+// Run invokes every hook matching the event. Hooks run sequentially so a pre
+// hook can veto the operation before later hooks observe it. Run returns an
+// error wrapping ErrAborted when a pre hook with the abort policy fails; all
+// other failures are logged and Run returns nil.
+func (r *Runner) Run(ctx context.Context, event *Event) error {
+	if r == nil {
+		return nil
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	for i := range r.registrations {
+		reg := &r.registrations[i]
+		if !reg.matches(event) {
+			continue
+		}
+
+		err := r.invoke(ctx, reg, event)
+		if err == nil {
+			continue
+		}
+
+		if reg.FailurePolicy == FailurePolicyAbort && event.Phase == PhasePre {
+			r.logger.Warn("lifecycle hook aborted operation",
+				zap.String("hook", reg.Name),
+				zap.String("operation", string(event.Operation)),
+				zap.String("object_type", event.ObjectType),
+				zap.String("object_id", event.ObjectID),
+				zap.Error(err))
+			return fmt.Errorf("%w: hook %s: %w", ErrAborted, reg.Name, err)
+		}
+
+		r.logger.Warn("lifecycle hook failed",
+			zap.String("hook", reg.Name),
+			zap.String("phase", string(event.Phase)),
+			zap.String("operation", string(event.Operation)),
+			zap.String("object_type", event.ObjectType),
+			zap.String("object_id", event.ObjectID),
+			zap.Error(err))
+	}
+	return nil
+}
+
+// invoke runs a single hook with its timeout and records metrics.
+func (r *Runner) invoke(ctx context.Context, reg *Registration, event *Event) error {
+	ctx, cancel := context.WithTimeout(ctx, reg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := reg.Hook.Invoke(ctx, event)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	result := "success"
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		result = "timeout"
+	case err != nil:
+		result = "failure"
+	}
+	HookInvocations.WithLabelValues(reg.Name, string(event.Phase), string(event.Operation), result).Inc()
+	HookDuration.WithLabelValues(reg.Name, string(event.Phase), string(event.Operation)).
+		Observe(time.Since(start).Seconds())
+
+	return err
+}
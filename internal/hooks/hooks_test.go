@@ -0,0 +1,194 @@
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/hooks"
+)
+
+func TestRegistration_Validate(t *testing.T) {
+	noop := hooks.HookFunc(func(context.Context, *hooks.Event) error { return nil })
+
+	tests := []struct {
+		name    string
+		reg     hooks.Registration
+		wantErr bool
+	}{
+		{name: "minimal", reg: hooks.Registration{Name: "h", Hook: noop}},
+		{
+			name: "full",
+			reg: hooks.Registration{
+				Name:          "h",
+				Hook:          noop,
+				Phases:        []hooks.Phase{hooks.PhasePre},
+				Operations:    []hooks.Operation{hooks.OperationDelete},
+				Timeout:       time.Second,
+				FailurePolicy: hooks.FailurePolicyAbort,
+			},
+		},
+		{name: "no name", reg: hooks.Registration{Hook: noop}, wantErr: true},
+		{name: "no hook", reg: hooks.Registration{Name: "h"}, wantErr: true},
+		{name: "invalid phase", reg: hooks.Registration{Name: "h", Hook: noop, Phases: []hooks.Phase{"during"}}, wantErr: true},
+		{
+			name:    "invalid operation",
+			reg:     hooks.Registration{Name: "h", Hook: noop, Operations: []hooks.Operation{"update"}},
+			wantErr: true,
+		},
+		{name: "invalid policy", reg: hooks.Registration{Name: "h", Hook: noop, FailurePolicy: "retry"}, wantErr: true},
+		{name: "negative timeout", reg: hooks.Registration{Name: "h", Hook: noop, Timeout: -time.Second}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.reg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	var calls []string
+	record := func(name string, err error) hooks.Hook {
+		return hooks.HookFunc(func(_ context.Context, event *hooks.Event) error {
+			calls = append(calls, name+":"+string(event.Phase))
+			return err
+		})
+	}
+
+	runner := hooks.NewRunner(nil)
+	require.NoError(t, runner.Register(hooks.Registration{Name: "all", Hook: record("all", nil)}))
+	require.NoError(t, runner.Register(hooks.Registration{
+		Name:        "pools-only",
+		Hook:        record("pools-only", nil),
+		ObjectTypes: []string{hooks.ObjectTypeResourcePool},
+	}))
+	require.NoError(t, runner.Register(hooks.Registration{
+		Name:          "ignored-failure",
+		Hook:          record("ignored-failure", errors.New("cmdb unavailable")),
+		Operations:    []hooks.Operation{hooks.OperationCreate},
+		FailurePolicy: hooks.FailurePolicyIgnore,
+	}))
+	require.NoError(t, runner.Register(hooks.Registration{
+		Name:          "veto",
+		Hook:          record("veto", errors.New("change window closed")),
+		Operations:    []hooks.Operation{hooks.OperationDelete},
+		FailurePolicy: hooks.FailurePolicyAbort,
+	}))
+	require.NoError(t, runner.Register(hooks.Registration{Name: "after-veto", Hook: record("after-veto", nil)}))
+	assert.Equal(t, 5, runner.Len())
+
+	t.Run("ignored failure does not abort", func(t *testing.T) {
+		calls = nil
+		err := runner.Run(context.Background(), &hooks.Event{
+			Phase: hooks.PhasePre, Operation: hooks.OperationCreate, ObjectType: hooks.ObjectTypeResource,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"all:pre", "ignored-failure:pre", "after-veto:pre"}, calls)
+	})
+
+	t.Run("abort policy rejects pre phase", func(t *testing.T) {
+		calls = nil
+		err := runner.Run(context.Background(), &hooks.Event{
+			Phase: hooks.PhasePre, Operation: hooks.OperationDelete, ObjectType: hooks.ObjectTypeResourcePool,
+		})
+		require.ErrorIs(t, err, hooks.ErrAborted)
+		assert.Contains(t, err.Error(), "change window closed")
+		assert.Equal(t, []string{"all:pre", "pools-only:pre", "veto:pre"}, calls)
+	})
+
+	t.Run("abort policy cannot abort post phase", func(t *testing.T) {
+		calls = nil
+		err := runner.Run(context.Background(), &hooks.Event{
+			Phase: hooks.PhasePost, Operation: hooks.OperationDelete, ObjectType: hooks.ObjectTypeResourcePool,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"all:post", "pools-only:post", "veto:post", "after-veto:post"}, calls)
+	})
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	runner := hooks.NewRunner(nil)
+	require.NoError(t, runner.Register(hooks.Registration{
+		Name: "slow",
+		Hook: hooks.HookFunc(func(ctx context.Context, _ *hooks.Event) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		Timeout:       10 * time.Millisecond,
+		FailurePolicy: hooks.FailurePolicyAbort,
+	}))
+
+	timeouts := testutil.ToFloat64(hooks.HookInvocations.WithLabelValues("slow", "pre", "create", "timeout"))
+	err := runner.Run(context.Background(), &hooks.Event{Phase: hooks.PhasePre, Operation: hooks.OperationCreate})
+	require.ErrorIs(t, err, hooks.ErrAborted)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, timeouts+1,
+		testutil.ToFloat64(hooks.HookInvocations.WithLabelValues("slow", "pre", "create", "timeout")))
+}
+
+func TestRunner_Nil(t *testing.T) {
+	var runner *hooks.Runner
+	assert.NoError(t, runner.Run(context.Background(), &hooks.Event{Phase: hooks.PhasePre}))
+	assert.Equal(t, 0, runner.Len())
+}
+
+func TestWebhookHook(t *testing.T) {
+	var received hooks.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.ObjectID == "reject-me" {
+			http.Error(w, "not in CMDB", http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	hook := hooks.NewWebhookHook(srv.URL, srv.Client())
+
+	err := hook.Invoke(context.Background(), &hooks.Event{
+		Phase:      hooks.PhasePost,
+		Operation:  hooks.OperationCreate,
+		ObjectType: hooks.ObjectTypeResourcePool,
+		ObjectID:   "pool-1",
+		Object:     map[string]string{"name": "Pool"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hooks.PhasePost, received.Phase)
+	assert.Equal(t, "pool-1", received.ObjectID)
+	assert.Equal(t, map[string]interface{}{"name": "Pool"}, received.Object)
+
+	err = hook.Invoke(context.Background(), &hooks.Event{Phase: hooks.PhasePre, ObjectID: "reject-me"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Contains(t, err.Error(), "not in CMDB")
+}
+
+func TestPlugins(t *testing.T) {
+	hook := hooks.HookFunc(func(context.Context, *hooks.Event) error { return nil })
+	hooks.RegisterPlugin("test-plugin", hook)
+
+	got, ok := hooks.LookupPlugin("test-plugin")
+	require.True(t, ok)
+	assert.NotNil(t, got)
+
+	_, ok = hooks.LookupPlugin("missing")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { hooks.RegisterPlugin("test-plugin", hook) })
+}
@@ -0,0 +1,32 @@
+package hooks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// HookInvocations tracks lifecycle hook invocations by result
+	// (success, failure, or timeout).
+	HookInvocations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "hooks",
+			Name:      "invocations_total",
+			Help:      "Total number of lifecycle hook invocations",
+		},
+		[]string{"hook", "phase", "operation", "result"},
+	)
+
+	// HookDuration tracks lifecycle hook invocation latency.
+	HookDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "o2ims",
+			Subsystem: "hooks",
+			Name:      "duration_seconds",
+			Help:      "Lifecycle hook invocation duration in seconds",
+			Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1.0, 2.0, 5.0, 10.0},
+		},
+		[]string{"hook", "phase", "operation"},
+	)
+)
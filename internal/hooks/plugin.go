@@ -0,0 +1,37 @@
+package hooks
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = make(map[string]Hook)
+)
+
+// RegisterPlugin makes an in-process hook available by name, so configuration
+// can attach it with type "plugin". Integrators typically call it from an init
+// function in a package linked into the gateway binary. It panics if the name
+// is empty, the hook is nil, or the name is already registered.
+func RegisterPlugin(name string, hook Hook) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	if name == "" || hook == nil {
+		panic("hooks: RegisterPlugin requires a name and a hook")
+	}
+	if _, exists := plugins[name]; exists {
+		panic(fmt.Sprintf("hooks: plugin %q registered twice", name))
+	}
+	plugins[name] = hook
+}
+
+// LookupPlugin returns the hook registered under name.
+func LookupPlugin(name string) (Hook, bool) {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	hook, ok := plugins[name]
+	return hook, ok
+}
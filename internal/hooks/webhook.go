@@ -0,0 +1,63 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/piwi3910/netweave/internal/resolver"
+)
+
+// maxWebhookErrorBody limits how much of a failed webhook response is included in errors.
+const maxWebhookErrorBody = 512
+
+// WebhookHook invokes a hook by POSTing the event as JSON to a URL.
+// Any non-2xx response fails the invocation; the response body is included in
+// the error so aborting hooks can explain the rejection.
+type WebhookHook struct {
+	url    string
+	client *http.Client
+}
+
+// defaultWebhookClient is used by webhook hooks created without a client.
+var defaultWebhookClient = &http.Client{Transport: resolver.NewTransport()}
+
+// NewWebhookHook creates a webhook hook. A nil client uses a client dialing
+// through the shared outbound resolver; invocation timeouts come from the
+// hook registration.
+func NewWebhookHook(url string, client *http.Client) *WebhookHook {
+	if client == nil {
+		client = defaultWebhookClient
+	}
+	return &WebhookHook{url: url, client: client}
+}
+
+// Invoke sends the event to the webhook.
+func (h *WebhookHook) Invoke(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "O2-IMS-Gateway/1.0")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBody))
+		return fmt.Errorf("webhook returned non-2xx status: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Package httperror translates the sentinel errors returned by adapters and
+// stores into HTTP status codes and problem codes. The O2-IMS and O2-DMS
+// handlers share one translation so that the same failure is reported the
+// same way by every endpoint.
+package httperror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/registry"
+	dmsstorage "github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/webhookca"
+)
+
+// Problem codes reported in the error field of error responses.
+const (
+	CodeBadRequest         = "BadRequest"
+	CodeNotFound           = "NotFound"
+	CodeConflict           = "Conflict"
+	CodeQuotaExceeded      = "QuotaExceeded"
+	CodeCapacityExceeded   = "CapacityExceeded"
+	CodeInternalError      = "InternalError"
+	CodeNotImplemented     = "NotImplemented"
+	CodeBadGateway         = "BadGateway"
+	CodeServiceUnavailable = "ServiceUnavailable"
+)
+
+// Rule translates errors matching Err, as reported by errors.Is, into an
+// HTTP status and problem code. Message, if set, replaces the error's own
+// message in the response.
+type Rule struct {
+	Err     error
+	Status  int
+	Code    string
+	Message string
+}
+
+// Problem is the translation of an error.
+type Problem struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Internal reports whether the error matched no rule. Its details must not
+// be sent to the client.
+func (p Problem) Internal() bool {
+	return p.Code == CodeInternalError
+}
+
+// RuleProvider is implemented by adapters that return sentinel errors of
+// their own, to have them translated like the shared ones.
+type RuleProvider interface {
+	// ErrorRules returns the translations of the adapter's sentinel errors.
+	ErrorRules() []Rule
+}
+
+// Translator translates errors using an ordered list of rules; the first
+// matching rule wins.
+type Translator struct {
+	rules []Rule
+}
+
+// New creates a translator with the given rules.
+func New(rules ...Rule) *Translator {
+	return &Translator{rules: rules}
+}
+
+// With returns a translator that tries rules before those of t, so they can
+// add sentinel errors or map shared ones differently. t is not modified.
+func (t *Translator) With(rules ...Rule) *Translator {
+	if len(rules) == 0 {
+		return t
+	}
+	combined := make([]Rule, 0, len(rules)+len(t.rules))
+	combined = append(combined, rules...)
+	combined = append(combined, t.rules...)
+	return &Translator{rules: combined}
+}
+
+// For returns the translator for errors of the given adapter, extended with
+// its rules if it implements RuleProvider.
+func (t *Translator) For(adp any) *Translator {
+	if provider, ok := adp.(RuleProvider); ok {
+		return t.With(provider.ErrorRules()...)
+	}
+	return t
+}
+
+// Translate returns the problem err translates to. Errors matching no rule
+// are internal errors with an empty message, which the caller fills in.
+func (t *Translator) Translate(err error) Problem {
+	for _, rule := range t.rules {
+		if !errors.Is(err, rule.Err) {
+			continue
+		}
+		message := rule.Message
+		if message == "" {
+			message = err.Error()
+		}
+		return Problem{Status: rule.Status, Code: rule.Code, Message: message}
+	}
+	return Problem{Status: http.StatusInternalServerError, Code: CodeInternalError}
+}
+
+// Describe translates err for an error response about a single object.
+// Not-found errors are described by notFoundMsg, which names the object, and
+// errors matching no rule by failMsg.
+func (t *Translator) Describe(err error, notFoundMsg, failMsg string) Problem {
+	problem := t.Translate(err)
+	switch {
+	case problem.Internal():
+		problem.Message = failMsg
+	case problem.Status == http.StatusNotFound && notFoundMsg != "":
+		problem.Message = notFoundMsg
+	}
+	return problem
+}
+
+// Default translates the sentinel errors of the O2-IMS and O2-DMS adapters
+// and of the stores behind the handlers.
+var Default = New(
+	// O2-IMS adapters
+	Rule{Err: adapter.ErrResourceNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: adapter.ErrResourcePoolNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: adapter.ErrResourceTypeNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: adapter.ErrSubscriptionNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: adapter.ErrDeploymentManagerNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: adapter.ErrResourceExists, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{Err: adapter.ErrResourcePoolExists, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{Err: adapter.ErrSubscriptionExists, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{Err: adapter.ErrInvalidResource, Status: http.StatusBadRequest, Code: CodeBadRequest},
+	Rule{Err: adapter.ErrResourceTypeRequired, Status: http.StatusBadRequest, Code: CodeBadRequest},
+	Rule{Err: adapter.ErrResourcePoolRequired, Status: http.StatusBadRequest, Code: CodeBadRequest},
+	Rule{Err: adapter.ErrNotImplemented, Status: http.StatusNotImplemented, Code: CodeNotImplemented},
+	Rule{Err: adapter.ErrCapacityExceeded, Status: http.StatusConflict, Code: CodeCapacityExceeded},
+
+	// O2-DMS adapters
+	Rule{Err: dmsadapter.ErrDeploymentNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: dmsadapter.ErrPackageNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: dmsadapter.ErrOperationNotSupported, Status: http.StatusNotImplemented, Code: CodeNotImplemented},
+	Rule{
+		Err: dmsadapter.ErrNoOperationInProgress, Status: http.StatusConflict, Code: CodeConflict,
+		Message: "NF deployment has no operation in progress",
+	},
+	Rule{
+		Err: dmsadapter.ErrDeploymentSuspended, Status: http.StatusConflict, Code: CodeConflict,
+		Message: "NF deployment reconciliation is suspended; resume it first",
+	},
+	Rule{Err: dmsadapter.ErrPackageInUse, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{
+		Err: dmsadapter.ErrSyncWindowClosed, Status: http.StatusConflict, Code: CodeConflict,
+		Message: "NF deployment sync windows do not allow syncing now",
+	},
+
+	// Adapter circuit breakers
+	Rule{Err: circuitbreaker.ErrOpen, Status: http.StatusServiceUnavailable, Code: CodeServiceUnavailable},
+
+	// O2-DMS adapter registry
+	Rule{Err: registry.ErrPluginNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: registry.ErrPluginExists, Status: http.StatusConflict, Code: CodeConflict},
+
+	// Subscription and job stores
+	Rule{Err: storage.ErrSubscriptionNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: storage.ErrSubscriptionExists, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{Err: storage.ErrInvalidCallback, Status: http.StatusBadRequest, Code: CodeBadRequest},
+	Rule{Err: storage.ErrInvalidID, Status: http.StatusBadRequest, Code: CodeBadRequest},
+	Rule{Err: storage.ErrHubNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: storage.ErrHubExists, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{Err: storage.ErrListSnapshotNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: storage.ErrReconciliationNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{
+		Err: storage.ErrStorageUnavailable, Status: http.StatusServiceUnavailable, Code: CodeServiceUnavailable,
+		Message: "Storage is temporarily unavailable; retry later",
+	},
+	Rule{Err: storage.ErrCertificateNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: storage.ErrCertificateExists, Status: http.StatusConflict, Code: CodeConflict},
+	Rule{Err: dmsstorage.ErrSubscriptionNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: dmsstorage.ErrJobNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+
+	// Webhook CA
+	Rule{Err: webhookca.ErrInvalidRequest, Status: http.StatusBadRequest, Code: CodeBadRequest},
+	Rule{Err: webhookca.ErrCertificateRevoked, Status: http.StatusConflict, Code: CodeConflict},
+
+	// Tenants
+	Rule{Err: auth.ErrTenantNotFound, Status: http.StatusNotFound, Code: CodeNotFound},
+	Rule{Err: auth.ErrQuotaExceeded, Status: http.StatusTooManyRequests, Code: CodeQuotaExceeded},
+	Rule{
+		Err: auth.ErrStorageUnavailable, Status: http.StatusServiceUnavailable, Code: CodeServiceUnavailable,
+		Message: "Storage is temporarily unavailable; retry later",
+	},
+)
@@ -0,0 +1,175 @@
+package httperror_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/circuitbreaker"
+	dmsadapter "github.com/piwi3910/netweave/internal/dms/adapter"
+	"github.com/piwi3910/netweave/internal/dms/adapters/crossplane"
+	"github.com/piwi3910/netweave/internal/dms/adapters/flux"
+	"github.com/piwi3910/netweave/internal/dms/adapters/kustomize"
+	"github.com/piwi3910/netweave/internal/dms/adapters/onaplcm"
+	"github.com/piwi3910/netweave/internal/dms/adapters/osmlcm"
+	dmsstorage "github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/httperror"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/webhookca"
+)
+
+func TestDefault_Translate(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{adapter.ErrResourceNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{adapter.ErrResourcePoolNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{adapter.ErrResourceTypeNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{adapter.ErrSubscriptionNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{adapter.ErrDeploymentManagerNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{adapter.ErrResourceExists, http.StatusConflict, httperror.CodeConflict},
+		{adapter.ErrResourcePoolExists, http.StatusConflict, httperror.CodeConflict},
+		{adapter.ErrSubscriptionExists, http.StatusConflict, httperror.CodeConflict},
+		{adapter.ErrInvalidResource, http.StatusBadRequest, httperror.CodeBadRequest},
+		{adapter.ErrResourceTypeRequired, http.StatusBadRequest, httperror.CodeBadRequest},
+		{adapter.ErrResourcePoolRequired, http.StatusBadRequest, httperror.CodeBadRequest},
+		{adapter.ErrNotImplemented, http.StatusNotImplemented, httperror.CodeNotImplemented},
+		{
+			fmt.Errorf("create: %w", &adapter.CapacityError{Kind: "resource", Count: 5, Limit: 5}),
+			http.StatusConflict, httperror.CodeCapacityExceeded,
+		},
+		{dmsadapter.ErrDeploymentNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{dmsadapter.ErrPackageNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{dmsadapter.ErrOperationNotSupported, http.StatusNotImplemented, httperror.CodeNotImplemented},
+		{dmsadapter.ErrNoOperationInProgress, http.StatusConflict, httperror.CodeConflict},
+		{dmsadapter.ErrDeploymentSuspended, http.StatusConflict, httperror.CodeConflict},
+		{dmsadapter.ErrPackageInUse, http.StatusConflict, httperror.CodeConflict},
+		{dmsadapter.ErrSyncWindowClosed, http.StatusConflict, httperror.CodeConflict},
+		{circuitbreaker.ErrOpen, http.StatusServiceUnavailable, httperror.CodeServiceUnavailable},
+		{storage.ErrSubscriptionNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{storage.ErrSubscriptionExists, http.StatusConflict, httperror.CodeConflict},
+		{storage.ErrInvalidCallback, http.StatusBadRequest, httperror.CodeBadRequest},
+		{storage.ErrInvalidID, http.StatusBadRequest, httperror.CodeBadRequest},
+		{storage.ErrHubNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{storage.ErrHubExists, http.StatusConflict, httperror.CodeConflict},
+		{storage.ErrListSnapshotNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{storage.ErrReconciliationNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{storage.ErrStorageUnavailable, http.StatusServiceUnavailable, httperror.CodeServiceUnavailable},
+		{storage.ErrCertificateNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{storage.ErrCertificateExists, http.StatusConflict, httperror.CodeConflict},
+		{dmsstorage.ErrSubscriptionNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{dmsstorage.ErrJobNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{webhookca.ErrInvalidRequest, http.StatusBadRequest, httperror.CodeBadRequest},
+		{webhookca.ErrCertificateRevoked, http.StatusConflict, httperror.CodeConflict},
+		{auth.ErrTenantNotFound, http.StatusNotFound, httperror.CodeNotFound},
+		{auth.ErrQuotaExceeded, http.StatusTooManyRequests, httperror.CodeQuotaExceeded},
+		{auth.ErrStorageUnavailable, http.StatusServiceUnavailable, httperror.CodeServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			for _, err := range []error{tt.err, fmt.Errorf("operation failed: %w", tt.err)} {
+				problem := httperror.Default.Translate(err)
+				assert.Equal(t, tt.status, problem.Status)
+				assert.Equal(t, tt.code, problem.Code)
+				assert.NotEmpty(t, problem.Message)
+				assert.False(t, problem.Internal())
+			}
+		})
+	}
+}
+
+func TestDefault_TranslateUnknown(t *testing.T) {
+	problem := httperror.Default.Translate(errors.New("database connection failed"))
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, httperror.CodeInternalError, problem.Code)
+	assert.Empty(t, problem.Message)
+	assert.True(t, problem.Internal())
+}
+
+func TestTranslator_Messages(t *testing.T) {
+	problem := httperror.Default.Translate(fmt.Errorf("pool-1: %w", adapter.ErrResourcePoolNotFound))
+	assert.Equal(t, "pool-1: resource pool not found", problem.Message)
+
+	problem = httperror.Default.Translate(dmsadapter.ErrDeploymentSuspended)
+	assert.Equal(t, "NF deployment reconciliation is suspended; resume it first", problem.Message)
+}
+
+func TestTranslator_Describe(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"not found", dmsadapter.ErrDeploymentNotFound, http.StatusNotFound, "NF deployment not found"},
+		{"conflict", dmsadapter.ErrPackageInUse, http.StatusConflict, dmsadapter.ErrPackageInUse.Error()},
+		{"unknown", errors.New("etcd timeout"), http.StatusInternalServerError, "Failed to get NF deployment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := httperror.Default.Describe(tt.err, "NF deployment not found", "Failed to get NF deployment")
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, tt.message, problem.Message)
+		})
+	}
+
+	problem := httperror.Default.Describe(dmsadapter.ErrDeploymentNotFound, "", "failed")
+	assert.Equal(t, dmsadapter.ErrDeploymentNotFound.Error(), problem.Message)
+}
+
+func TestTranslator_With(t *testing.T) {
+	errCustom := errors.New("custom failure")
+	translator := httperror.Default.With(
+		httperror.Rule{Err: errCustom, Status: http.StatusBadGateway, Code: httperror.CodeBadGateway},
+		httperror.Rule{Err: dmsadapter.ErrOperationNotSupported, Status: http.StatusBadRequest, Code: "Unsupported"},
+	)
+
+	assert.Equal(t, http.StatusBadGateway, translator.Translate(errCustom).Status)
+	assert.Equal(t, "Unsupported", translator.Translate(dmsadapter.ErrOperationNotSupported).Code)
+	assert.Equal(t, http.StatusNotFound, translator.Translate(dmsadapter.ErrDeploymentNotFound).Status)
+
+	// The shared translator is not modified.
+	assert.Equal(t, http.StatusInternalServerError, httperror.Default.Translate(errCustom).Status)
+	assert.Equal(t, http.StatusNotImplemented, httperror.Default.Translate(dmsadapter.ErrOperationNotSupported).Status)
+	assert.Same(t, httperror.Default, httperror.Default.With())
+}
+
+func TestTranslator_For(t *testing.T) {
+	assert.Same(t, httperror.Default, httperror.Default.For(nil))
+	assert.Same(t, httperror.Default, httperror.Default.For("not an adapter"))
+
+	tests := []struct {
+		name     string
+		provider httperror.RuleProvider
+		err      error
+		status   int
+	}{
+		{"flux not found", &flux.Adapter{}, flux.ErrDeploymentNotFound, http.StatusNotFound},
+		{"flux invalid path", &flux.Adapter{}, flux.ErrInvalidPath, http.StatusBadRequest},
+		{
+			"kustomize not supported", &kustomize.Adapter{}, kustomize.ErrOperationNotSupported,
+			http.StatusNotImplemented,
+		},
+		{"crossplane composition", &crossplane.Adapter{}, crossplane.ErrMissingCompositionRef, http.StatusBadRequest},
+		{"osm connection", &osmlcm.Adapter{}, osmlcm.ErrConnectionFailed, http.StatusBadGateway},
+		{"onap authentication", &onaplcm.Adapter{}, onaplcm.ErrAuthenticationFailed, http.StatusBadGateway},
+		{"onap package", &onaplcm.Adapter{}, onaplcm.ErrPackageNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("request failed: %w", tt.err)
+			assert.Equal(t, http.StatusInternalServerError, httperror.Default.Translate(err).Status)
+			assert.Equal(t, tt.status, httperror.Default.For(tt.provider).Translate(err).Status)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/lifecycle"
+)
+
+func TestManager_Drain(t *testing.T) {
+	t.Run("drains stages in order", func(t *testing.T) {
+		m := lifecycle.New(zap.NewNop())
+
+		var (
+			mu    sync.Mutex
+			order []string
+		)
+		record := func(name string) lifecycle.DrainFunc {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				return nil
+			}
+		}
+		m.Register("informers", lifecycle.StageCaches, record("informers"))
+		m.Register("webhooks", lifecycle.StageWork, record("webhooks"))
+
+		require.NoError(t, m.Drain(context.Background()))
+		assert.Equal(t, []string{"webhooks", "informers"}, order)
+
+		// Later calls do nothing.
+		require.NoError(t, m.Drain(context.Background()))
+		assert.Len(t, order, 2)
+	})
+
+	t.Run("drains a stage concurrently", func(t *testing.T) {
+		m := lifecycle.New(zap.NewNop())
+
+		// Each component waits for the other; a sequential drain would
+		// time out.
+		var wg sync.WaitGroup
+		wg.Add(2)
+		wait := func(ctx context.Context) error {
+			wg.Done()
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		m.Register("jobs", lifecycle.StageWork, wait)
+		m.Register("webhooks", lifecycle.StageWork, wait)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, m.Drain(ctx))
+	})
+
+	t.Run("reports failed components and keeps going", func(t *testing.T) {
+		m := lifecycle.New(zap.NewNop())
+
+		stopped := false
+		m.Register("jobs", lifecycle.StageWork, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		m.Register("informers", lifecycle.StageCaches, func(context.Context) error {
+			stopped = true
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := m.Drain(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "jobs")
+		assert.True(t, stopped, "later stages run after the timeout")
+	})
+}
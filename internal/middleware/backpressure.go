@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// Backpressure describes the limit a throttled or shed request ran into, so
+// that every 429 and 503 response tells the client when to retry in the same
+// way.
+type Backpressure struct {
+	// Limit is the size of the exhausted limit, e.g. the burst of a token
+	// bucket. Zero means the rejection is not caused by a counted limit and
+	// the X-RateLimit-* headers are omitted.
+	Limit int64
+
+	// Remaining is the number of requests left under the limit.
+	Remaining int64
+
+	// Reset is when the limit is fully available again.
+	Reset time.Time
+
+	// RetryAfter is how long the client should wait before retrying.
+	RetryAfter time.Duration
+}
+
+// RetryAfterSeconds converts d to a Retry-After value: whole seconds,
+// rounded up, and at least 1.
+func RetryAfterSeconds(d time.Duration) int64 {
+	seconds := int64(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// SetRateLimitHeaders sets the X-RateLimit-* headers describing b. When
+// several limits apply to a request, the headers describe the one with the
+// fewest remaining requests.
+func SetRateLimitHeaders(c *gin.Context, b Backpressure) {
+	if b.Limit <= 0 {
+		return
+	}
+	if current, err := strconv.ParseInt(c.Writer.Header().Get("X-RateLimit-Remaining"), 10, 64); err == nil &&
+		current < b.Remaining {
+		return
+	}
+	c.Header("X-RateLimit-Limit", strconv.FormatInt(b.Limit, 10))
+	c.Header("X-RateLimit-Remaining", strconv.FormatInt(b.Remaining, 10))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(b.Reset.Unix(), 10))
+}
+
+// RespondBackpressure rejects a throttled (429) or shed (503) request with
+// the Retry-After and X-RateLimit-* headers of b and an error body in the
+// gateway's error format.
+func RespondBackpressure(c *gin.Context, status int, errorCode, message string, b Backpressure) {
+	if b.Limit > 0 {
+		// The exhausted limit is the one the client has to wait for.
+		c.Writer.Header().Del("X-RateLimit-Remaining")
+		SetRateLimitHeaders(c, b)
+	}
+	c.Header("Retry-After", strconv.FormatInt(RetryAfterSeconds(b.RetryAfter), 10))
+	problem.Abort(c, status, errorCode, message)
+}
@@ -0,0 +1,65 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+)
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, int64(1), middleware.RetryAfterSeconds(0))
+	assert.Equal(t, int64(1), middleware.RetryAfterSeconds(200*time.Millisecond))
+	assert.Equal(t, int64(3), middleware.RetryAfterSeconds(2100*time.Millisecond))
+	assert.Equal(t, int64(60), middleware.RetryAfterSeconds(time.Minute))
+}
+
+func TestRespondBackpressure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reset := time.Unix(1705234567, 0)
+
+	t.Run("limit exhausted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		// An earlier, less restrictive bucket already set headers.
+		middleware.SetRateLimitHeaders(c, middleware.Backpressure{Limit: 100, Remaining: 0, Reset: reset})
+		middleware.SetRateLimitHeaders(c, middleware.Backpressure{Limit: 50, Remaining: 10, Reset: reset})
+		assert.Equal(t, "100", w.Header().Get("X-RateLimit-Limit"), "headers keep the most restrictive bucket")
+
+		middleware.RespondBackpressure(c, http.StatusTooManyRequests, "RateLimitExceeded", "Rate limit exceeded",
+			middleware.Backpressure{Limit: 10, Remaining: 0, Reset: reset, RetryAfter: 1500 * time.Millisecond})
+
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+		assert.Equal(t, "10", w.Header().Get("X-RateLimit-Limit"), "headers describe the exhausted limit")
+		assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "1705234567", w.Header().Get("X-RateLimit-Reset"))
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type": "urn:netweave:problem:RateLimitExceeded",
+			"title": "Too Many Requests",
+			"status": 429,
+			"detail": "Rate limit exceeded",
+			"error": "RateLimitExceeded",
+			"message": "Rate limit exceeded",
+			"code": 429
+		}`, w.Body.String())
+	})
+
+	t.Run("no counted limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		middleware.RespondBackpressure(c, http.StatusServiceUnavailable, "ServiceUnavailable", "Draining",
+			middleware.Backpressure{RetryAfter: 5 * time.Second})
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "5", w.Header().Get("Retry-After"))
+		assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+	})
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIPKey is the Gin context key under which the derived client IP is stored.
+const ClientIPKey = "client_ip"
+
+// DefaultRemoteIPHeaders are the headers inspected for the real client IP
+// when a request arrives from a trusted proxy.
+var DefaultRemoteIPHeaders = []string{"X-Forwarded-For", "X-Real-IP"}
+
+// TrustedProxyConfig contains configuration for trusted proxy handling.
+type TrustedProxyConfig struct {
+	// TrustedProxies is a list of IP addresses or CIDRs of trusted reverse proxies.
+	// When empty, forwarding headers are ignored and the connection peer address is used.
+	TrustedProxies []string
+
+	// RemoteIPHeaders lists the headers inspected, in order, for the real client IP.
+	// Default: ["X-Forwarded-For", "X-Real-IP"]
+	RemoteIPHeaders []string
+}
+
+// ConfigureTrustedProxies applies the trusted proxy configuration to the Gin engine.
+// Gin walks X-Forwarded-For from right to left and stops at the first address that
+// is not a trusted proxy, so spoofed left-most entries are never used.
+//
+// An empty TrustedProxies list disables forwarding header processing entirely, which
+// is safer than Gin's default of trusting every peer.
+func ConfigureTrustedProxies(router *gin.Engine, config *TrustedProxyConfig) error {
+	if router == nil {
+		return fmt.Errorf("router cannot be nil")
+	}
+	if config == nil {
+		config = &TrustedProxyConfig{}
+	}
+
+	headers := config.RemoteIPHeaders
+	if len(headers) == 0 {
+		headers = DefaultRemoteIPHeaders
+	}
+	router.RemoteIPHeaders = headers
+	router.ForwardedByClientIP = true
+
+	var proxies []string
+	if len(config.TrustedProxies) > 0 {
+		proxies = config.TrustedProxies
+	}
+	if err := router.SetTrustedProxies(proxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	return nil
+}
+
+// ClientIP returns a Gin middleware that derives the real client IP once per
+// request and stores it in the Gin context under ClientIPKey, so that audit
+// logging, rate limiting, and request logs all observe the same address.
+func ClientIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ClientIPKey, c.ClientIP())
+		c.Next()
+	}
+}
+
+// GetClientIP returns the client IP stored by the ClientIP middleware,
+// falling back to Gin's derivation when the middleware has not run.
+func GetClientIP(c *gin.Context) string {
+	if ip, exists := c.Get(ClientIPKey); exists {
+		if s, ok := ip.(string); ok && s != "" {
+			return s
+		}
+	}
+	return c.ClientIP()
+}
@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		config     *middleware.TrustedProxyConfig
+		remoteAddr string
+		headers    map[string]string
+		expectedIP string
+	}{
+		{
+			name:       "no trusted proxies ignores forwarding headers",
+			config:     &middleware.TrustedProxyConfig{},
+			remoteAddr: "10.0.0.5:4321",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			expectedIP: "10.0.0.5",
+		},
+		{
+			name:       "trusted proxy uses X-Forwarded-For",
+			config:     &middleware.TrustedProxyConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+			remoteAddr: "10.0.0.5:4321",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "spoofed left-most entry is skipped",
+			config:     &middleware.TrustedProxyConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+			remoteAddr: "10.0.0.5:4321",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 203.0.113.7, 10.0.0.9"},
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "untrusted peer cannot spoof",
+			config:     &middleware.TrustedProxyConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+			remoteAddr: "198.51.100.1:4321",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			expectedIP: "198.51.100.1",
+		},
+		{
+			name: "custom header",
+			config: &middleware.TrustedProxyConfig{
+				TrustedProxies:  []string{"10.0.0.5"},
+				RemoteIPHeaders: []string{"X-Real-IP"},
+			},
+			remoteAddr: "10.0.0.5:4321",
+			headers:    map[string]string{"X-Real-IP": "203.0.113.8", "X-Forwarded-For": "1.2.3.4"},
+			expectedIP: "203.0.113.8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			require.NoError(t, middleware.ConfigureTrustedProxies(router, tt.config))
+			router.Use(middleware.ClientIP())
+
+			var stored any
+			router.GET("/test", func(c *gin.Context) {
+				stored, _ = c.Get(middleware.ClientIPKey)
+				c.String(http.StatusOK, middleware.GetClientIP(c))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedIP, w.Body.String())
+			assert.Equal(t, tt.expectedIP, stored)
+		})
+	}
+}
+
+func TestConfigureTrustedProxiesInvalid(t *testing.T) {
+	router := gin.New()
+	err := middleware.ConfigureTrustedProxies(router, &middleware.TrustedProxyConfig{
+		TrustedProxies: []string{"not-an-ip"},
+	})
+	require.Error(t, err)
+
+	require.Error(t, middleware.ConfigureTrustedProxies(nil, nil))
+}
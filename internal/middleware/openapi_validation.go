@@ -18,6 +18,8 @@ import (
 	"github.com/getkin/kin-openapi/routers/gorillamux"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
 )
 
 // DefaultMaxBodySize is the default maximum request body size (1MB).
@@ -252,11 +254,8 @@ func (v *OpenAPIValidator) validateRequest(c *gin.Context) error {
 				zap.Int64("content_length", c.Request.ContentLength),
 				zap.Int64("max_body_size", maxBodySize),
 			)
-			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-				"error":   "RequestEntityTooLarge",
-				"message": fmt.Sprintf("Request body exceeds maximum size of %d bytes", maxBodySize),
-				"code":    http.StatusRequestEntityTooLarge,
-			})
+			problem.Abort(c, http.StatusRequestEntityTooLarge, "RequestEntityTooLarge",
+				fmt.Sprintf("Request body exceeds maximum size of %d bytes", maxBodySize))
 			return fmt.Errorf("request body too large: %d > %d", c.Request.ContentLength, maxBodySize)
 		}
 
@@ -265,11 +264,7 @@ func (v *OpenAPIValidator) validateRequest(c *gin.Context) error {
 		bodyBytes, err := io.ReadAll(limitedReader)
 		if err != nil {
 			v.logger.Error("failed to read request body", zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"error":   "InternalError",
-				"message": "Failed to read request body",
-				"code":    http.StatusInternalServerError,
-			})
+			problem.Abort(c, http.StatusInternalServerError, "InternalError", "Failed to read request body")
 			return fmt.Errorf("failed to read request body: %w", err)
 		}
 
@@ -279,11 +274,8 @@ func (v *OpenAPIValidator) validateRequest(c *gin.Context) error {
 				zap.Int("body_size", len(bodyBytes)),
 				zap.Int64("max_body_size", maxBodySize),
 			)
-			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-				"error":   "RequestEntityTooLarge",
-				"message": fmt.Sprintf("Request body exceeds maximum size of %d bytes", maxBodySize),
-				"code":    http.StatusRequestEntityTooLarge,
-			})
+			problem.Abort(c, http.StatusRequestEntityTooLarge, "RequestEntityTooLarge",
+				fmt.Sprintf("Request body exceeds maximum size of %d bytes", maxBodySize))
 			return fmt.Errorf("request body too large: %d > %d", len(bodyBytes), maxBodySize)
 		}
 
@@ -299,11 +291,7 @@ func (v *OpenAPIValidator) validateRequest(c *gin.Context) error {
 		)
 
 		errorMessage := FormatValidationError(err)
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"error":   "ValidationError",
-			"message": errorMessage,
-			"code":    http.StatusBadRequest,
-		})
+		problem.Abort(c, http.StatusBadRequest, "ValidationError", errorMessage)
 		return fmt.Errorf("request validation failed: %w", err)
 	}
 
@@ -5,12 +5,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // RateLimiter provides distributed rate limiting using Redis.
@@ -19,6 +24,10 @@ type RateLimiter struct {
 	Client redis.UniversalClient // Exported for testing
 	Logger *zap.Logger           // Exported for testing
 	Config *RateLimitConfig      // Exported for testing
+
+	// inFlight counts the requests of this replica being served, for
+	// GlobalLimitConfig.MaxConcurrentRequests.
+	inFlight atomic.Int64
 }
 
 // RateLimitConfig contains rate limiting configuration.
@@ -29,9 +38,15 @@ type RateLimitConfig struct {
 	// PerTenant configures per-tenant rate limits
 	PerTenant TenantLimitConfig
 
+	// PerClient configures per-client rate limits
+	PerClient ClientLimitConfig
+
 	// PerEndpoint configures per-endpoint rate limits
 	PerEndpoint []EndpointLimitConfig
 
+	// RouteGroups configures rate limits for groups of routes sharing a path prefix
+	RouteGroups []RouteGroupLimitConfig
+
 	// Global configures global rate limits
 	Global GlobalLimitConfig
 
@@ -45,6 +60,23 @@ type TenantLimitConfig struct {
 	BurstSize         int
 }
 
+// ClientLimitConfig configures per-client rate limits. A client is identified
+// by GetClientID, so several clients of one tenant have separate buckets.
+type ClientLimitConfig struct {
+	RequestsPerSecond int
+	BurstSize         int
+}
+
+// RouteGroupLimitConfig configures rate limits for the routes under a path
+// prefix, e.g. "/o2dms" or "/o2ims-infrastructureInventory/v1/subscriptions".
+// Each tenant has its own bucket per group.
+type RouteGroupLimitConfig struct {
+	Name              string
+	PathPrefix        string
+	RequestsPerSecond int
+	BurstSize         int
+}
+
 // EndpointLimitConfig configures rate limits for specific endpoints.
 type EndpointLimitConfig struct {
 	Path              string
@@ -59,6 +91,66 @@ type GlobalLimitConfig struct {
 	MaxConcurrentRequests int
 }
 
+// Rate limit scopes, reported in throttling responses and metrics.
+const (
+	RateLimitScopeEndpoint   = "endpoint"
+	RateLimitScopeRouteGroup = "route_group"
+	RateLimitScopeClient     = "client"
+	RateLimitScopeTenant     = "tenant"
+	RateLimitScopeGlobal     = "global"
+	// RateLimitScopeConcurrency reports requests shed because the replica
+	// serves MaxConcurrentRequests requests already.
+	RateLimitScopeConcurrency = "concurrency"
+)
+
+// shedRetryAfter is the Retry-After hint of requests shed because the
+// replica is at its concurrency limit.
+const shedRetryAfter = time.Second
+
+// RateLimitThrottled counts the requests rejected by the rate limiter.
+var RateLimitThrottled = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "o2ims_rate_limit_throttled_total",
+		Help: "Total number of requests rejected by rate limiting",
+	},
+	[]string{"scope", "path"},
+)
+
+// RateLimitFailOpen counts the requests allowed because a rate limit check failed.
+var RateLimitFailOpen = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "o2ims_rate_limit_fail_open_total",
+		Help: "Total number of requests allowed due to rate limit check failures (fail-open behavior)",
+	},
+	[]string{"scope"},
+)
+
+// rateLimitOverrideKey is the Gin context key holding a per-request RateLimitOverride.
+const rateLimitOverrideKey = "rate_limit_override"
+
+// RateLimitOverride replaces the configured per-tenant and global limits for a
+// single request, e.g. while new limits are rolled out to part of the traffic.
+type RateLimitOverride struct {
+	PerTenant TenantLimitConfig
+	Global    GlobalLimitConfig
+}
+
+// SetRateLimitOverride applies override to the limits checked for the request.
+// It must run before the rate limit middleware.
+func SetRateLimitOverride(c *gin.Context, override RateLimitOverride) {
+	c.Set(rateLimitOverrideKey, override)
+}
+
+// limitsFor returns the per-tenant and global limits that apply to the request.
+func (rl *RateLimiter) limitsFor(c *gin.Context) (TenantLimitConfig, GlobalLimitConfig) {
+	if value, exists := c.Get(rateLimitOverrideKey); exists {
+		if override, ok := value.(RateLimitOverride); ok {
+			return override.PerTenant, override.Global
+		}
+	}
+	return rl.Config.PerTenant, rl.Config.Global
+}
+
 // NewRateLimiter creates a new rate limiter with the given configuration.
 func NewRateLimiter(config *RateLimitConfig, logger *zap.Logger) (*RateLimiter, error) {
 	if config == nil {
@@ -101,24 +193,53 @@ func (rl *RateLimiter) Middleware() gin.HandlerFunc {
 
 		// Check endpoint-specific limits first
 		if endpointLimit := rl.GetEndpointLimit(c.Request.Method, c.FullPath()); endpointLimit != nil {
-			if !rl.checkLimit(ctx, c, fmt.Sprintf("endpoint:%s:%s:%s", tenantID, c.Request.Method, c.FullPath()),
+			if !rl.checkLimit(ctx, c, RateLimitScopeEndpoint,
+				fmt.Sprintf("endpoint:%s:%s:%s", tenantID, c.Request.Method, c.FullPath()),
 				endpointLimit.RequestsPerSecond, endpointLimit.BurstSize) {
 				return
 			}
 		}
 
+		// Check route group limits
+		if group := rl.GetRouteGroup(c.Request.URL.Path); group != nil && group.RequestsPerSecond > 0 {
+			if !rl.checkLimit(ctx, c, RateLimitScopeRouteGroup, fmt.Sprintf("group:%s:%s", group.Name, tenantID),
+				group.RequestsPerSecond, group.BurstSize) {
+				return
+			}
+		}
+
+		// Check per-client limits
+		if rl.Config.PerClient.RequestsPerSecond > 0 {
+			if !rl.checkLimit(ctx, c, RateLimitScopeClient, "client:"+GetClientID(c),
+				rl.Config.PerClient.RequestsPerSecond, rl.Config.PerClient.BurstSize) {
+				return
+			}
+		}
+
+		tenantLimit, globalLimit := rl.limitsFor(c)
+
 		// Check per-tenant limits
-		if rl.Config.PerTenant.RequestsPerSecond > 0 {
-			if !rl.checkLimit(ctx, c, fmt.Sprintf("tenant:%s", tenantID),
-				rl.Config.PerTenant.RequestsPerSecond, rl.Config.PerTenant.BurstSize) {
+		if tenantLimit.RequestsPerSecond > 0 {
+			if !rl.checkLimit(ctx, c, RateLimitScopeTenant, fmt.Sprintf("tenant:%s", tenantID),
+				tenantLimit.RequestsPerSecond, tenantLimit.BurstSize) {
 				return
 			}
 		}
 
 		// Check global limits
-		if rl.Config.Global.RequestsPerSecond > 0 {
-			if !rl.checkLimit(ctx, c, "global",
-				rl.Config.Global.RequestsPerSecond, rl.Config.Global.BurstSize()) {
+		if globalLimit.RequestsPerSecond > 0 {
+			if !rl.checkLimit(ctx, c, RateLimitScopeGlobal, "global",
+				globalLimit.RequestsPerSecond, globalLimit.BurstSize()) {
+				return
+			}
+		}
+
+		// Shed load beyond the concurrency limit of this replica
+		if globalLimit.MaxConcurrentRequests > 0 {
+			inFlight := rl.inFlight.Add(1)
+			defer rl.inFlight.Add(-1)
+			if inFlight > int64(globalLimit.MaxConcurrentRequests) {
+				rl.shed(c, int64(globalLimit.MaxConcurrentRequests))
 				return
 			}
 		}
@@ -127,10 +248,29 @@ func (rl *RateLimiter) Middleware() gin.HandlerFunc {
 	}
 }
 
+// shed rejects a request arriving while the replica serves limit requests
+// already with 503 Service Unavailable.
+func (rl *RateLimiter) shed(c *gin.Context, limit int64) {
+	rl.Logger.Warn("concurrency limit exceeded, shedding request",
+		zap.Int64("limit", limit),
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.FullPath()),
+		zap.String("client_ip", GetClientIP(c)),
+	)
+	RateLimitThrottled.WithLabelValues(RateLimitScopeConcurrency, c.FullPath()).Inc()
+	RespondBackpressure(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+		"Server is at its concurrency limit; retry later", Backpressure{
+			Limit:      limit,
+			Remaining:  0,
+			Reset:      time.Now().Add(shedRetryAfter),
+			RetryAfter: shedRetryAfter,
+		})
+}
+
 // checkLimit checks if the request is within the rate limit using token bucket algorithm.
 // Returns true if allowed, false if rate limit exceeded.
 func (rl *RateLimiter) checkLimit(
-	ctx context.Context, c *gin.Context, key string, requestsPerSecond, burstSize int,
+	ctx context.Context, c *gin.Context, scope, key string, requestsPerSecond, burstSize int,
 ) bool {
 	now := time.Now().Unix()
 	windowSize := int64(1) // 1 second window
@@ -172,6 +312,7 @@ func (rl *RateLimiter) checkLimit(
 			zap.String("key", key),
 			zap.Error(err),
 		)
+		RateLimitFailOpen.WithLabelValues(scope).Inc()
 		// Fail open: allow request if Redis fails
 		return true
 	}
@@ -186,27 +327,33 @@ func (rl *RateLimiter) checkLimit(
 	remaining := resultSlice[1].(int64)
 	limit := resultSlice[2].(int64)
 
-	// Set rate limit headers
-	c.Header("X-RateLimit-Limit", strconv.FormatInt(limit, 10))
-	c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
-	c.Header("X-RateLimit-Reset", strconv.FormatInt(now+windowSize, 10))
+	// The bucket refills at requestsPerSecond: the next token arrives after
+	// 1/requestsPerSecond seconds and the bucket is full once the missing
+	// tokens have been refilled.
+	refill := func(tokens int64) time.Duration {
+		return time.Duration(tokens) * time.Second / time.Duration(requestsPerSecond)
+	}
+	backpressure := Backpressure{
+		Limit:      limit,
+		Remaining:  remaining,
+		Reset:      time.Unix(now, 0).Add(refill(limit - remaining)),
+		RetryAfter: refill(1),
+	}
+	SetRateLimitHeaders(c, backpressure)
 
 	if !allowed {
-		c.Header("Retry-After", strconv.FormatInt(windowSize, 10))
-
 		rl.Logger.Warn("rate limit exceeded",
+			zap.String("scope", scope),
 			zap.String("key", key),
 			zap.String("tenant", GetTenantID(c)),
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.FullPath()),
-			zap.String("client_ip", c.ClientIP()),
+			zap.String("client_ip", GetClientIP(c)),
 		)
 
-		c.JSON(http.StatusTooManyRequests, gin.H{
-			"error":       "rate limit exceeded",
-			"retry_after": windowSize,
-		})
-		c.Abort()
+		RateLimitThrottled.WithLabelValues(scope, c.FullPath()).Inc()
+		RespondBackpressure(c, http.StatusTooManyRequests, "RateLimitExceeded",
+			fmt.Sprintf("Rate limit exceeded (%s limit)", scope), backpressure)
 		return false
 	}
 
@@ -223,19 +370,59 @@ func (rl *RateLimiter) GetEndpointLimit(method, path string) *EndpointLimitConfi
 	return nil
 }
 
+// GetRouteGroup returns the route group with the longest path prefix matching
+// path, or nil if none matches.
+func (rl *RateLimiter) GetRouteGroup(path string) *RouteGroupLimitConfig {
+	var match *RouteGroupLimitConfig
+	for i := range rl.Config.RouteGroups {
+		group := &rl.Config.RouteGroups[i]
+		if !pathHasPrefix(path, group.PathPrefix) {
+			continue
+		}
+		if match == nil || len(group.PathPrefix) > len(match.PathPrefix) {
+			match = group
+		}
+	}
+	return match
+}
+
+// pathHasPrefix reports whether path is prefix or lies below it.
+func pathHasPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// GetClientID identifies the client of a request for per-client limits: the
+// authenticated user if the request was authenticated, otherwise the subject
+// of the client certificate, otherwise the client IP.
+func GetClientID(c *gin.Context) string {
+	if c.Request == nil {
+		return "ip:" + GetClientIP(c)
+	}
+	if id := requestcontext.Identity(c.Request.Context()).UserID; id != "" {
+		return "user:" + id
+	}
+	if c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0 {
+		if subject := c.Request.TLS.PeerCertificates[0].Subject.String(); subject != "" {
+			return "cert:" + subject
+		}
+	}
+	return "ip:" + GetClientIP(c)
+}
+
 // GetTenantID extracts the tenant ID from the Gin context.
 // It first checks for a tenant ID in the context (set by auth middleware),
 // then falls back to client IP as a default identifier.
 func GetTenantID(c *gin.Context) string {
 	// Try to get tenant from auth context
-	if tenantID, exists := c.Get("tenant_id"); exists {
-		if id, ok := tenantID.(string); ok && id != "" {
+	if c.Request != nil {
+		if id := requestcontext.Tenant(c.Request.Context()); id != "" {
 			return id
 		}
 	}
 
 	// Fallback to client IP
-	return c.ClientIP()
+	return GetClientIP(c)
 }
 
 // BurstSize returns the burst size for global limits.
@@ -1,14 +1,19 @@
 package middleware_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -215,6 +220,189 @@ func TestMiddleware(t *testing.T) {
 		router.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("override replaces configured limits", func(t *testing.T) {
+		mr.FlushAll()
+		config := &middleware.RateLimitConfig{
+			Enabled: true,
+			PerTenant: middleware.TenantLimitConfig{
+				RequestsPerSecond: 100,
+				BurstSize:         100,
+			},
+			RedisClient: redisClient,
+		}
+
+		rl, err := middleware.NewRateLimiter(config, logger)
+		require.NoError(t, err)
+
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			middleware.SetRateLimitOverride(c, middleware.RateLimitOverride{
+				PerTenant: middleware.TenantLimitConfig{RequestsPerSecond: 1, BurstSize: 1},
+			})
+		})
+		router.Use(rl.Middleware())
+		router.GET("/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+
+		w1 := httptest.NewRecorder()
+		router.ServeHTTP(w1, httptest.NewRequest(http.MethodGet, "/test", nil))
+		assert.Equal(t, http.StatusOK, w1.Code)
+		assert.Equal(t, "1", w1.Header().Get("X-RateLimit-Limit"))
+
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, httptest.NewRequest(http.MethodGet, "/test", nil))
+		assert.Equal(t, http.StatusTooManyRequests, w2.Code)
+	})
+
+	t.Run("route group rate limit", func(t *testing.T) {
+		mr.FlushAll()
+		config := &middleware.RateLimitConfig{
+			Enabled: true,
+			RouteGroups: []middleware.RouteGroupLimitConfig{
+				{Name: "dms", PathPrefix: "/o2dms", RequestsPerSecond: 1, BurstSize: 1},
+			},
+			RedisClient: redisClient,
+		}
+
+		rl, err := middleware.NewRateLimiter(config, logger)
+		require.NoError(t, err)
+
+		router := gin.New()
+		router.Use(rl.Middleware())
+		router.GET("/o2dms/v1/nfDeployments", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+		router.GET("/o2ims/v1/resources", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+		throttledCounter := middleware.RateLimitThrottled.WithLabelValues(
+			middleware.RateLimitScopeRouteGroup, "/o2dms/v1/nfDeployments")
+		throttled := testutil.ToFloat64(throttledCounter)
+
+		w1 := httptest.NewRecorder()
+		router.ServeHTTP(w1, httptest.NewRequest(http.MethodGet, "/o2dms/v1/nfDeployments", nil))
+		assert.Equal(t, http.StatusOK, w1.Code)
+
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, httptest.NewRequest(http.MethodGet, "/o2dms/v1/nfDeployments", nil))
+		assert.Equal(t, http.StatusTooManyRequests, w2.Code)
+		assert.Equal(t, "0", w2.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "1", w2.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{
+			"type": "urn:netweave:problem:RateLimitExceeded",
+			"title": "Too Many Requests",
+			"status": 429,
+			"detail": "Rate limit exceeded (route_group limit)",
+			"instance": "/o2dms/v1/nfDeployments",
+			"error": "RateLimitExceeded",
+			"message": "Rate limit exceeded (route_group limit)",
+			"code": 429
+		}`, w2.Body.String())
+		assert.InDelta(t, throttled+1, testutil.ToFloat64(throttledCounter), 0)
+
+		w3 := httptest.NewRecorder()
+		router.ServeHTTP(w3, httptest.NewRequest(http.MethodGet, "/o2ims/v1/resources", nil))
+		assert.Equal(t, http.StatusOK, w3.Code, "routes outside the group are not limited")
+	})
+
+	t.Run("per-client rate limit", func(t *testing.T) {
+		mr.FlushAll()
+		config := &middleware.RateLimitConfig{
+			Enabled:     true,
+			PerClient:   middleware.ClientLimitConfig{RequestsPerSecond: 1, BurstSize: 1},
+			PerTenant:   middleware.TenantLimitConfig{RequestsPerSecond: 100, BurstSize: 100},
+			RedisClient: redisClient,
+		}
+
+		rl, err := middleware.NewRateLimiter(config, logger)
+		require.NoError(t, err)
+
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			ctx := requestcontext.WithTenant(c.Request.Context(), "tenant-a")
+			ctx = requestcontext.WithIdentity(ctx, requestcontext.Caller{UserID: c.GetHeader("X-Test-User")})
+			c.Request = c.Request.WithContext(ctx)
+		})
+		router.Use(rl.Middleware())
+		router.GET("/test", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+		request := func(user string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("X-Test-User", user)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w1 := request("user-1")
+		assert.Equal(t, http.StatusOK, w1.Code)
+		assert.Equal(t, "1", w1.Header().Get("X-RateLimit-Limit"), "headers describe the most restrictive bucket")
+		assert.Equal(t, "0", w1.Header().Get("X-RateLimit-Remaining"))
+
+		assert.Equal(t, http.StatusTooManyRequests, request("user-1").Code)
+		assert.Equal(t, http.StatusOK, request("user-2").Code, "clients of a tenant have separate buckets")
+	})
+
+	t.Run("concurrency limit sheds load", func(t *testing.T) {
+		mr.FlushAll()
+		config := &middleware.RateLimitConfig{
+			Enabled:     true,
+			Global:      middleware.GlobalLimitConfig{MaxConcurrentRequests: 1},
+			RedisClient: redisClient,
+		}
+
+		rl, err := middleware.NewRateLimiter(config, logger)
+		require.NoError(t, err)
+
+		entered := make(chan struct{})
+		release := make(chan struct{})
+		router := gin.New()
+		router.Use(rl.Middleware())
+		router.GET("/slow", func(c *gin.Context) {
+			close(entered)
+			<-release
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+		router.GET("/fast", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		})
+
+		done := make(chan int)
+		go func() {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+			done <- w.Code
+		}()
+		<-entered
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+		assert.NotEmpty(t, w.Header().Get("X-RateLimit-Reset"))
+		assert.JSONEq(t, `{
+			"type": "urn:netweave:problem:ServiceUnavailable",
+			"title": "Service Unavailable",
+			"status": 503,
+			"detail": "Server is at its concurrency limit; retry later",
+			"instance": "/fast",
+			"error": "ServiceUnavailable",
+			"message": "Server is at its concurrency limit; retry later",
+			"code": 503
+		}`, w.Body.String())
+
+		close(release)
+		assert.Equal(t, http.StatusOK, <-done)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+		assert.Equal(t, http.StatusOK, w.Code, "finished requests free their slot")
+	})
 }
 
 // TestGetEndpointLimit tests endpoint limit lookup.
@@ -276,6 +464,76 @@ func TestGetEndpointLimit(t *testing.T) {
 	})
 }
 
+// TestGetRouteGroup tests route group lookup.
+func TestGetRouteGroup(t *testing.T) {
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { require.NoError(t, redisClient.Close()) }()
+
+	rl, err := middleware.NewRateLimiter(&middleware.RateLimitConfig{
+		Enabled: true,
+		RouteGroups: []middleware.RouteGroupLimitConfig{
+			{Name: "ims", PathPrefix: "/o2ims-infrastructureInventory/v1"},
+			{Name: "subscriptions", PathPrefix: "/o2ims-infrastructureInventory/v1/subscriptions/"},
+		},
+		RedisClient: redisClient,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/o2ims-infrastructureInventory/v1/resources", "ims"},
+		{"/o2ims-infrastructureInventory/v1/subscriptions", "subscriptions"},
+		{"/o2ims-infrastructureInventory/v1/subscriptions/sub-1", "subscriptions"},
+		{"/o2ims-infrastructureInventory/v10/resources", ""},
+		{"/health", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			group := rl.GetRouteGroup(tt.path)
+			if tt.want == "" {
+				assert.Nil(t, group)
+				return
+			}
+			require.NotNil(t, group)
+			assert.Equal(t, tt.want, group.Name)
+		})
+	}
+}
+
+// TestGetClientID tests client identification.
+func TestGetClientID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newContext := func() *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/test", nil)
+		c.Request.RemoteAddr = testRemoteAddr
+		return c
+	}
+
+	t.Run("uses the authenticated user", func(t *testing.T) {
+		c := newContext()
+		c.Request = c.Request.WithContext(requestcontext.WithIdentity(c.Request.Context(),
+			requestcontext.Caller{UserID: "user-1"}))
+		assert.Equal(t, "user:user-1", middleware.GetClientID(c))
+	})
+
+	t.Run("uses the client certificate subject", func(t *testing.T) {
+		c := newContext()
+		c.Request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{
+			{Subject: pkix.Name{CommonName: "smo-1", Organization: []string{"operator"}}},
+		}}
+		assert.Equal(t, "cert:CN=smo-1,O=operator", middleware.GetClientID(c))
+	})
+
+	t.Run("falls back to client IP", func(t *testing.T) {
+		assert.Equal(t, "ip:192.168.1.100", middleware.GetClientID(newContext()))
+	})
+}
+
 // TestGetTenantID tests tenant ID extraction.
 func TestGetTenantID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -283,7 +541,8 @@ func TestGetTenantID(t *testing.T) {
 	t.Run("extracts tenant from context", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("tenant_id", "tenant-123")
+		c.Request = httptest.NewRequest(http.MethodGet, "/test", nil)
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "tenant-123"))
 
 		tenantID := middleware.GetTenantID(c)
 		assert.Equal(t, "tenant-123", tenantID)
@@ -302,18 +561,18 @@ func TestGetTenantID(t *testing.T) {
 	t.Run("handles empty tenant ID", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("tenant_id", "")
 		c.Request = httptest.NewRequest(http.MethodGet, "/test", nil)
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), ""))
 		c.Request.RemoteAddr = testRemoteAddr
 
 		tenantID := middleware.GetTenantID(c)
 		assert.Contains(t, tenantID, "192.168.1.100")
 	})
 
-	t.Run("handles non-string tenant ID", func(t *testing.T) {
+	t.Run("ignores untyped gin keys", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("tenant_id", 123)
+		c.Set("tenant_id", "tenant-123")
 		c.Request = httptest.NewRequest(http.MethodGet, "/test", nil)
 		c.Request.RemoteAddr = testRemoteAddr
 
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+const (
+	// RequestIDHeader carries the ID of one API call. An incoming value is
+	// kept; otherwise one is generated. It is always echoed in the response.
+	RequestIDHeader = "X-Request-ID"
+
+	// CorrelationIDHeader carries the ID of a client operation that may span
+	// several API calls. It defaults to the request ID and is echoed in the
+	// response.
+	CorrelationIDHeader = "X-Correlation-ID"
+
+	// maxRequestIDLength bounds client-supplied IDs so they cannot bloat logs.
+	maxRequestIDLength = 128
+)
+
+// RequestID returns a Gin middleware that assigns every request a request ID
+// and a correlation ID. Valid IDs supplied by the client are propagated;
+// otherwise they are generated. The IDs are stored in the request context,
+// where requestcontext.RequestID and requestcontext.CorrelationID read them
+// and requestcontext.Logger and observability.LoggerFromContext include them
+// in log lines, and are set as response headers.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if !validRequestID(correlationID) {
+			correlationID = requestID
+		}
+
+		ctx := requestcontext.WithRequestID(c.Request.Context(), requestID)
+		ctx = requestcontext.WithCorrelationID(ctx, correlationID)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Header(RequestIDHeader, requestID)
+		c.Header(CorrelationIDHeader, correlationID)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the request ID stored by the RequestID middleware, or
+// "" when the middleware has not run.
+func GetRequestID(c *gin.Context) string {
+	return requestcontext.RequestID(c.Request.Context())
+}
+
+// validRequestID reports whether a client-supplied ID is safe to log and echo:
+// non-empty, bounded, and limited to visible ASCII without separators that
+// could be used for log or header injection.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		ch := id[i]
+		if ch <= ' ' || ch > '~' || ch == '"' || ch == '\\' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/observability"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name              string
+		headers           map[string]string
+		wantRequestID     string
+		wantCorrelationID string
+	}{
+		{
+			name: "generated when absent",
+		},
+		{
+			name:              "incoming request ID is propagated",
+			headers:           map[string]string{"X-Request-ID": "req-123"},
+			wantRequestID:     "req-123",
+			wantCorrelationID: "req-123",
+		},
+		{
+			name:              "incoming correlation ID is propagated",
+			headers:           map[string]string{"X-Request-ID": "req-123", "X-Correlation-ID": "op-9"},
+			wantRequestID:     "req-123",
+			wantCorrelationID: "op-9",
+		},
+		{
+			name:    "invalid request ID is replaced",
+			headers: map[string]string{"X-Request-ID": "bad id\" injected=1"},
+		},
+		{
+			name:    "oversized request ID is replaced",
+			headers: map[string]string{"X-Request-ID": strings.Repeat("a", 129)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ginRequestID, ctxRequestID, ctxCorrelationID string
+
+			router := gin.New()
+			router.Use(middleware.RequestID())
+			router.GET("/", func(c *gin.Context) {
+				ginRequestID = middleware.GetRequestID(c)
+				ctxRequestID = observability.RequestIDFromContext(c.Request.Context())
+				ctxCorrelationID = observability.CorrelationIDFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(middleware.RequestIDHeader)
+			correlationID := w.Header().Get(middleware.CorrelationIDHeader)
+			if tt.wantRequestID == "" {
+				_, err := uuid.Parse(requestID)
+				require.NoError(t, err, "generated request ID %q", requestID)
+				assert.Equal(t, requestID, correlationID, "correlation ID defaults to the request ID")
+			} else {
+				assert.Equal(t, tt.wantRequestID, requestID)
+				assert.Equal(t, tt.wantCorrelationID, correlationID)
+			}
+
+			assert.Equal(t, requestID, ginRequestID)
+			assert.Equal(t, requestID, ctxRequestID)
+			assert.Equal(t, correlationID, ctxCorrelationID)
+		})
+	}
+}
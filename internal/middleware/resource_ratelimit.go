@@ -15,6 +15,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // ResourceType represents the type of O2-IMS resource.
@@ -385,12 +388,11 @@ func (rl *ResourceRateLimiter) CheckPageSize(c *gin.Context, resourceType Resour
 
 	maxSize := rl.GetMaxPageSize(resourceType)
 	if pageSize > maxSize {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":    "page size exceeds maximum",
-			"max_size": maxSize,
-			"received": pageSize,
-		})
 		c.Abort()
+		problem.Write(c, problem.New(http.StatusBadRequest, "BadRequest",
+			fmt.Sprintf("Page size %d exceeds the maximum of %d", pageSize, maxSize)).
+			With("max_size", maxSize).
+			With("received", pageSize))
 		return false
 	}
 
@@ -448,7 +450,7 @@ func (rl *ResourceRateLimiter) checkResourceLimit(
 
 	key := fmt.Sprintf("rate:%s:%s:%s", tenantID, resourceType, operation)
 
-	allowed, remaining, err := rl.checkRedisLimit(ctx, key, limit, window)
+	allowed, remaining, reset, err := rl.checkRedisLimit(ctx, key, limit, window)
 	if err != nil {
 		rl.Logger.Error("resource rate limit check failed",
 			zap.String("key", key),
@@ -460,35 +462,32 @@ func (rl *ResourceRateLimiter) checkResourceLimit(
 		return true
 	}
 
-	// Set rate limit headers
-	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
-	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
-	c.Header("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(window).Unix(), 10))
+	// Set rate limit headers. The oldest request in the window drops out at
+	// reset, freeing a request.
+	backpressure := Backpressure{
+		Limit:      int64(limit),
+		Remaining:  int64(remaining),
+		Reset:      reset,
+		RetryAfter: time.Until(reset),
+	}
+	SetRateLimitHeaders(c, backpressure)
 	c.Header("X-RateLimit-Resource", string(resourceType))
 
 	if !allowed {
-		retryAfter := int(window.Seconds())
-		c.Header("Retry-After", strconv.Itoa(retryAfter))
-
 		rl.Logger.Warn("resource rate limit exceeded",
 			zap.String("tenant", tenantID),
 			zap.String("resourceType", string(resourceType)),
 			zap.String("operation", string(operation)),
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.FullPath()),
-			zap.String("client_ip", c.ClientIP()),
+			zap.String("client_ip", GetClientIP(c)),
 		)
 
 		// Record metric
 		rl.Metrics.Hits.WithLabelValues(string(resourceType), string(operation), tenantID).Inc()
 
-		c.JSON(http.StatusTooManyRequests, gin.H{
-			"error":         "resource rate limit exceeded",
-			"resource_type": resourceType,
-			"operation":     operation,
-			"retry_after":   retryAfter,
-		})
-		c.Abort()
+		RespondBackpressure(c, http.StatusTooManyRequests, "RateLimitExceeded",
+			fmt.Sprintf("Resource rate limit exceeded for %s %s operations", resourceType, operation), backpressure)
 		return false
 	}
 
@@ -549,13 +548,15 @@ func (rl *ResourceRateLimiter) getSubscriptionLimits(operation OperationType) (i
 	}
 }
 
-// checkRedisLimit performs the rate limit check using Redis.
+// checkRedisLimit performs the rate limit check using Redis. It returns
+// whether the request is allowed, the requests remaining in the window and
+// when the oldest request in the window drops out of it.
 func (rl *ResourceRateLimiter) checkRedisLimit(
 	ctx context.Context,
 	key string,
 	limit int,
 	window time.Duration,
-) (bool, int, error) {
+) (bool, int, time.Time, error) {
 	windowSeconds := int64(window.Seconds())
 	now := time.Now().Unix()
 
@@ -572,30 +573,39 @@ func (rl *ResourceRateLimiter) checkRedisLimit(
 		-- Count current requests in window
 		local current = redis.call('ZCARD', key)
 
+		local allowed = 0
+		local remaining = 0
 		if current < limit then
 			-- Add the current request
 			redis.call('ZADD', key, now, now .. ':' .. math.random())
 			redis.call('EXPIRE', key, window)
-			return {1, limit - current - 1}
-		else
-			return {0, 0}
+			allowed = 1
+			remaining = limit - current - 1
+		end
+
+		local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+		local oldest_ts = now
+		if oldest[2] then
+			oldest_ts = tonumber(oldest[2])
 		end
+		return {allowed, remaining, oldest_ts}
 	`
 
 	result, err := rl.Client.Eval(ctx, script, []string{key}, now, limit, windowSeconds).Result()
 	if err != nil {
-		return false, 0, fmt.Errorf("redis eval failed: %w", err)
+		return false, 0, time.Time{}, fmt.Errorf("redis eval failed: %w", err)
 	}
 
 	resultSlice, ok := result.([]interface{})
-	if !ok || len(resultSlice) < 2 {
-		return false, 0, fmt.Errorf("invalid redis result format")
+	if !ok || len(resultSlice) < 3 {
+		return false, 0, time.Time{}, fmt.Errorf("invalid redis result format")
 	}
 
 	allowed := resultSlice[0].(int64) == 1
 	remaining := int(resultSlice[1].(int64))
+	reset := time.Unix(resultSlice[2].(int64), 0).Add(window)
 
-	return allowed, remaining, nil
+	return allowed, remaining, reset, nil
 }
 
 // ExtractResourceType determines the resource type from the request path.
@@ -647,8 +657,8 @@ func IsCollectionPath(path string) bool {
 // GetResourceTenantID extracts the tenant ID for resource rate limiting.
 func GetResourceTenantID(c *gin.Context) string {
 	// Try to get tenant from auth context
-	if tenantID, exists := c.Get("tenant_id"); exists {
-		if id, ok := tenantID.(string); ok && id != "" {
+	if c.Request != nil {
+		if id := requestcontext.Tenant(c.Request.Context()); id != "" {
 			return id
 		}
 	}
@@ -659,5 +669,5 @@ func GetResourceTenantID(c *gin.Context) string {
 	}
 
 	// Fallback to client IP
-	return strings.ReplaceAll(c.ClientIP(), ":", "_")
+	return strings.ReplaceAll(GetClientIP(c), ":", "_")
 }
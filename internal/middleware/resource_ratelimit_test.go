@@ -3,11 +3,13 @@ package middleware_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -132,7 +134,7 @@ func TestGetResourceTenantID(t *testing.T) {
 		{
 			name: "tenant from context",
 			setupContext: func(c *gin.Context) {
-				c.Set("tenant_id", "tenant-123")
+				c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "tenant-123"))
 			},
 			expectedID: "tenant-123",
 		},
@@ -348,7 +350,7 @@ func TestResourceRateLimiter_SlidingWindow(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest(http.MethodGet, "/o2ims/v1/deploymentManagers/dm-123", nil)
-		c.Set("tenant_id", "test-tenant")
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "test-tenant"))
 
 		mw := rl.Middleware()
 		mw(c)
@@ -364,7 +366,20 @@ func TestResourceRateLimiter_SlidingWindow(t *testing.T) {
 	// 4th request should be rate limited
 	w := makeRequest()
 	assert.Equal(t, http.StatusTooManyRequests, w.Code, "Request 4 should be rate limited")
-	assert.Contains(t, w.Body.String(), "resource rate limit exceeded")
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, retryAfter, 1, "the oldest request leaves the one-minute window in about a minute")
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.JSONEq(t, `{
+		"type": "urn:netweave:problem:RateLimitExceeded",
+		"title": "Too Many Requests",
+		"status": 429,
+		"detail": "Resource rate limit exceeded for deploymentManagers read operations",
+		"instance": "/o2ims/v1/deploymentManagers/dm-123",
+		"error": "RateLimitExceeded",
+		"message": "Resource rate limit exceeded for deploymentManagers read operations",
+		"code": 429
+	}`, w.Body.String())
 }
 
 func TestResourceRateLimiter_RateLimitHeaders(t *testing.T) {
@@ -390,7 +405,7 @@ func TestResourceRateLimiter_RateLimitHeaders(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest(http.MethodGet, "/o2ims/v1/resources/res-123", nil)
-	c.Set("tenant_id", "test-tenant")
+	c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "test-tenant"))
 
 	mw := rl.Middleware()
 	mw(c)
@@ -435,7 +450,7 @@ func TestResourceRateLimiter_FailOpen(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest(http.MethodGet, "/o2ims/v1/resources/res-123", nil)
-	c.Set("tenant_id", "test-tenant")
+	c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "test-tenant"))
 
 	mw := rl.Middleware()
 	mw(c)
@@ -469,7 +484,7 @@ func TestResourceRateLimiter_Disabled(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request = httptest.NewRequest(http.MethodGet, "/o2ims/v1/resources/res-123", nil)
-		c.Set("tenant_id", "test-tenant")
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "test-tenant"))
 
 		mw := rl.Middleware()
 		mw(c)
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FilterQueryParam is the O-RAN O2 query parameter carrying a filter expression.
+const FilterQueryParam = "filter"
+
+// filterExpressionOperators maps O-RAN filter expression operators onto filter
+// operators. "contains" is accepted as an alias of the standard "cont".
+var filterExpressionOperators = map[string]FilterOperator{
+	"eq":       OpEquals,
+	"neq":      OpNotEquals,
+	"gt":       OpGreaterThan,
+	"gte":      OpGreaterThanOrEqual,
+	"lt":       OpLessThan,
+	"lte":      OpLessThanOrEqual,
+	"cont":     OpContains,
+	"contains": OpContains,
+	"in":       OpIn,
+	"nin":      OpNotIn,
+}
+
+// ParseFilterExpression parses an O-RAN O2 filter expression, the value of
+// the "filter" query parameter, into conditions that must all match.
+//
+// An expression is one or more "(operator,attribute,value[,value...])" terms
+// separated by ";". Nested attributes are separated by "/" and returned in
+// dot notation (extensions/region becomes extensions.region); everything
+// after "labels/" is a single label key, so label keys may contain "/".
+// Values containing ",", ";", "(" or ")" are enclosed in single quotes, with
+// two single quotes standing for one.
+//
+// Supported operators: eq, neq, gt, gte, lt, lte, cont (alias contains), in
+// and nin. in and nin take one or more values, all others exactly one.
+//
+// Example:
+//
+//	// ?filter=(eq,resourceTypeId,node);(in,extensions/region,eu-west,eu-north)
+//	conditions, err := ParseFilterExpression(params.Get("filter"))
+func ParseFilterExpression(expr string) ([]FilterCondition, error) {
+	terms, err := splitFilterTerms(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make([]FilterCondition, 0, len(terms))
+	for _, term := range terms {
+		condition, err := parseFilterTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// splitFilterTerms splits an expression into the comma-separated items of
+// each parenthesized term, resolving quoting.
+func splitFilterTerms(expr string) ([][]string, error) {
+	var terms [][]string
+	var items []string
+	var item strings.Builder
+	inTerm, inQuote, expectSeparator := false, false, false
+
+	for i := 0; i < len(expr); i++ {
+		ch := expr[i]
+		switch {
+		case inQuote:
+			if ch != '\'' {
+				item.WriteByte(ch)
+			} else if i+1 < len(expr) && expr[i+1] == '\'' {
+				item.WriteByte('\'')
+				i++
+			} else {
+				inQuote = false
+			}
+		case !inTerm:
+			switch {
+			case ch == ' ':
+			case ch == ';' && expectSeparator:
+				expectSeparator = false
+			case ch == '(' && !expectSeparator:
+				inTerm = true
+			default:
+				return nil, fmt.Errorf("unexpected %q at position %d in filter expression", ch, i)
+			}
+		case ch == '\'':
+			inQuote = true
+		case ch == ',':
+			items = append(items, item.String())
+			item.Reset()
+		case ch == ')':
+			terms = append(terms, append(items, item.String()))
+			items = nil
+			item.Reset()
+			inTerm, expectSeparator = false, true
+		case ch == '(' || ch == ';':
+			return nil, fmt.Errorf("unexpected %q at position %d in filter expression; quote values containing it", ch, i)
+		default:
+			item.WriteByte(ch)
+		}
+	}
+
+	switch {
+	case inQuote:
+		return nil, errors.New("unterminated quote in filter expression")
+	case inTerm:
+		return nil, errors.New("missing ')' in filter expression")
+	case len(terms) == 0:
+		return nil, errors.New("empty filter expression")
+	case !expectSeparator:
+		return nil, errors.New("filter expression must not end with ';'")
+	}
+	return terms, nil
+}
+
+// parseFilterTerm converts the items of one term into a condition.
+func parseFilterTerm(items []string) (FilterCondition, error) {
+	if len(items) < 3 {
+		return FilterCondition{}, fmt.Errorf("filter term (%s) needs an operator, an attribute and a value",
+			strings.Join(items, ","))
+	}
+
+	name := strings.TrimSpace(items[0])
+	operator, ok := filterExpressionOperators[name]
+	if !ok {
+		return FilterCondition{}, fmt.Errorf("unsupported filter operator %q", name)
+	}
+
+	field, err := parseAttributePath(strings.TrimSpace(items[1]))
+	if err != nil {
+		return FilterCondition{}, err
+	}
+
+	values := items[2:]
+	if operator == OpIn || operator == OpNotIn {
+		return FilterCondition{Field: field, Operator: operator, Values: values}, nil
+	}
+	if len(values) != 1 {
+		return FilterCondition{}, fmt.Errorf("filter operator %q takes exactly one value, got %d", name, len(values))
+	}
+	return FilterCondition{Field: field, Operator: operator, Value: values[0]}, nil
+}
+
+// parseAttributePath converts a "/"-separated attribute path to dot notation.
+func parseAttributePath(path string) (string, error) {
+	if key, ok := strings.CutPrefix(path, "labels/"); ok && key != "" {
+		return "labels." + key, nil
+	}
+
+	parts := strings.Split(path, "/")
+	for _, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("invalid filter attribute %q", path)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// MatchesConditions reports whether data satisfies every condition. Fields
+// are looked up with GetNestedField; a condition on a missing field does not
+// match.
+func MatchesConditions(conditions []FilterCondition, data map[string]interface{}) bool {
+	for _, condition := range conditions {
+		value, ok := GetNestedField(data, condition.Field)
+		if !ok || !ApplyCondition(condition, value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/models"
+)
+
+func TestParseFilterExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want []models.FilterCondition
+	}{
+		{
+			name: "single term",
+			expr: "(eq,resourceTypeId,node)",
+			want: []models.FilterCondition{{Field: "resourceTypeId", Operator: models.OpEquals, Value: "node"}},
+		},
+		{
+			name: "several terms and nested attribute",
+			expr: "(neq,name,foo); (gt,extensions/cpus,4)",
+			want: []models.FilterCondition{
+				{Field: "name", Operator: models.OpNotEquals, Value: "foo"},
+				{Field: "extensions.cpus", Operator: models.OpGreaterThan, Value: "4"},
+			},
+		},
+		{
+			name: "in with several values",
+			expr: "(in,resourcePoolId,pool-a,pool-b)",
+			want: []models.FilterCondition{
+				{Field: "resourcePoolId", Operator: models.OpIn, Values: []string{"pool-a", "pool-b"}},
+			},
+		},
+		{
+			name: "cont and contains",
+			expr: "(cont,description,edge);(contains,name,x)",
+			want: []models.FilterCondition{
+				{Field: "description", Operator: models.OpContains, Value: "edge"},
+				{Field: "name", Operator: models.OpContains, Value: "x"},
+			},
+		},
+		{
+			name: "quoted value",
+			expr: "(eq,description,'rack (1,2); o''clock')",
+			want: []models.FilterCondition{
+				{Field: "description", Operator: models.OpEquals, Value: "rack (1,2); o'clock"},
+			},
+		},
+		{
+			name: "label key with slash",
+			expr: "(eq,labels/topology.kubernetes.io/zone,a)",
+			want: []models.FilterCondition{
+				{Field: "labels.topology.kubernetes.io/zone", Operator: models.OpEquals, Value: "a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.ParseFilterExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilterExpression_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"eq,name,x",
+		"(eq,name,x",
+		"(eq,name,x);",
+		"(eq,name,x)(eq,name,y)",
+		"(eq,name)",
+		"(eq,name,x,y)",
+		"(like,name,x)",
+		"(eq,extensions//cpus,1)",
+		"(eq,name,'x)",
+		"(eq,name,a(b)",
+	} {
+		_, err := models.ParseFilterExpression(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseAdvancedFilter_FilterExpression(t *testing.T) {
+	filter, err := models.ParseAdvancedFilter(map[string][]string{
+		"filter":         {"(eq,resourceTypeId,node)"},
+		"location[cont]": {"eu"},
+	})
+	require.Error(t, err, "cont is an expression operator, not a bracket operator")
+	assert.Nil(t, filter)
+
+	filter, err = models.ParseAdvancedFilter(map[string][]string{
+		"filter":   {"(eq,resourceTypeId,node)"},
+		"location": {"eu"},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []models.FilterCondition{
+		{Field: "resourceTypeId", Operator: models.OpEquals, Value: "node"},
+		{Field: "location", Operator: models.OpEquals, Value: "eu"},
+	}, filter.Conditions)
+
+	_, err = models.ParseAdvancedFilter(map[string][]string{"filter": {"(eq,resourceTypeId"}})
+	require.Error(t, err)
+}
+
+func TestMatchesConditions(t *testing.T) {
+	data := map[string]interface{}{
+		"name":       "edge-1",
+		"extensions": map[string]interface{}{"cpus": float64(8)},
+	}
+	conditions, err := models.ParseFilterExpression("(contains,name,edge);(gte,extensions/cpus,8)")
+	require.NoError(t, err)
+	assert.True(t, models.MatchesConditions(conditions, data))
+
+	conditions, err = models.ParseFilterExpression("(eq,missing,x)")
+	require.NoError(t, err)
+	assert.False(t, models.MatchesConditions(conditions, data))
+}
@@ -120,6 +120,10 @@ type AdvancedFilter struct {
 // Cursor pagination:
 //   - ?cursor=<token>&limit=50
 //
+// O-RAN filter expressions (see ParseFilterExpression) are added to the
+// conditions:
+//   - ?filter=(eq,resourceTypeId,node);(gt,extensions/cpus,4)
+//
 // Example:
 //
 //	// URL: /resources?capacity[gt]=100&location[contains]=us&sort=name,-capacity&limit=50
@@ -135,6 +139,14 @@ func ParseAdvancedFilter(params url.Values) (*AdvancedFilter, error) {
 		return nil, err
 	}
 
+	if expr := params.Get(FilterQueryParam); expr != "" {
+		conditions, err := ParseFilterExpression(expr)
+		if err != nil {
+			return nil, err
+		}
+		filter.Conditions = append(filter.Conditions, conditions...)
+	}
+
 	parseMultiFieldSort(params, filter)
 
 	if err := parseCursorPagination(params, filter); err != nil {
@@ -218,7 +230,9 @@ func isReservedParam(key string) bool {
 	reserved := map[string]bool{
 		"sort": true, "sortBy": true, "sortOrder": true,
 		"limit": true, "offset": true,
-		"cursor": true, "fields": true,
+		"cursor": true, "fields": true, "consistency": true,
+		"watch": true, "waitFor": true, "timeout": true,
+		FilterQueryParam: true,
 		// Legacy v1 parameters - these are handled separately in filter parsing.
 	}
 
@@ -77,7 +77,7 @@ type ListResponse struct {
 	NextCursor string      `json:"nextCursor,omitempty"`
 }
 
-// ErrorResponse represents an API error response.
+// ErrorResponse is the error body returned by every O2-IMS endpoint.
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
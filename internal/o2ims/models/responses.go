@@ -0,0 +1,156 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ListEnvelope is the envelope of O2-IMS list endpoints. Items are serialized
+// under the collection name given by Kind, e.g.
+//
+//	{"resourcePools": [...], "total": 2, "resourceVersion": "..."}
+type ListEnvelope[T any] struct {
+	// Kind is the collection name the items are serialized under
+	// (e.g. "resourcePools", "resources").
+	Kind string
+
+	// Items are the listed objects.
+	Items []T
+
+	// Total is the total number of objects. For paginated snapshot lists it
+	// counts every page, not only Items.
+	Total int
+
+	// ResourceVersion is the watermark of the listed items for ?waitFor=
+	// long polling. Omitted when empty.
+	ResourceVersion string
+
+	// SnapshotCreatedAt is when the list snapshot was taken (consistency=snapshot only).
+	SnapshotCreatedAt *time.Time
+
+	// NextCursor is the cursor of the next page; omitted on the last page.
+	NextCursor string
+}
+
+// MarshalJSON serializes the list with its items under Kind.
+func (l ListEnvelope[T]) MarshalJSON() ([]byte, error) {
+	if l.Kind == "" {
+		return nil, fmt.Errorf("list envelope has no kind")
+	}
+
+	items := l.Items
+	if items == nil {
+		items = []T{}
+	}
+
+	fields := map[string]interface{}{
+		l.Kind:  items,
+		"total": l.Total,
+	}
+	if l.ResourceVersion != "" {
+		fields["resourceVersion"] = l.ResourceVersion
+	}
+	if l.SnapshotCreatedAt != nil {
+		fields["snapshotCreatedAt"] = l.SnapshotCreatedAt
+	}
+	if l.NextCursor != "" {
+		fields["nextCursor"] = l.NextCursor
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes a list whose items are serialized under Kind, which
+// must be set before decoding.
+func (l *ListEnvelope[T]) UnmarshalJSON(data []byte) error {
+	if l.Kind == "" {
+		return fmt.Errorf("list envelope has no kind")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	decode := func(key string, v interface{}) error {
+		raw, ok := fields[key]
+		if !ok {
+			return nil
+		}
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return nil
+	}
+
+	if err := decode(l.Kind, &l.Items); err != nil {
+		return err
+	}
+	if err := decode("total", &l.Total); err != nil {
+		return err
+	}
+	if err := decode("resourceVersion", &l.ResourceVersion); err != nil {
+		return err
+	}
+	if err := decode("snapshotCreatedAt", &l.SnapshotCreatedAt); err != nil {
+		return err
+	}
+	return decode("nextCursor", &l.NextCursor)
+}
+
+// GatewayInfo is the response of the gateway root endpoint (GET /).
+type GatewayInfo struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	APIVersion  string `json:"api_version"`
+
+	// Mode is the gateway mode (server.mode), which decides the APIs served.
+	Mode      string           `json:"mode"`
+	Endpoints GatewayEndpoints `json:"endpoints"`
+}
+
+// GatewayEndpoints lists the base paths served by the gateway.
+type GatewayEndpoints struct {
+	Health    string `json:"health"`
+	Ready     string `json:"ready"`
+	Metrics   string `json:"metrics"`
+	O2IMSBase string `json:"o2ims_base,omitempty"`
+	O2DMSBase string `json:"o2dms_base"`
+	O2SMOBase string `json:"o2smo_base"`
+}
+
+// APIInfo is the response of the O2-IMS API information endpoint.
+type APIInfo struct {
+	APIVersion string   `json:"api_version"`
+	BasePath   string   `json:"base_path"`
+	Resources  []string `json:"resources"`
+	Features   []string `json:"features"`
+}
+
+// OCloudInfrastructure describes the O-Cloud served by the gateway.
+type OCloudInfrastructure struct {
+	OCloudID    string `json:"oCloudId"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ServiceURI  string `json:"serviceUri"`
+}
+
+// TenantQuotas is the response of the v3 tenant quota endpoints.
+type TenantQuotas struct {
+	TenantID string             `json:"tenantId"`
+	Quotas   TenantQuotaDetails `json:"quotas"`
+
+	// UpdatedAt is set by quota updates (RFC 3339).
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// TenantQuotaDetails are the quota limits of a tenant and, when known, its usage.
+type TenantQuotaDetails struct {
+	MaxSubscriptions  int  `json:"maxSubscriptions"`
+	MaxResourcePools  int  `json:"maxResourcePools"`
+	MaxResources      int  `json:"maxResources"`
+	UsedSubscriptions *int `json:"usedSubscriptions,omitempty"`
+	UsedResourcePools *int `json:"usedResourcePools,omitempty"`
+	UsedResources     *int `json:"usedResources,omitempty"`
+}
@@ -0,0 +1,170 @@
+package models_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// openAPISpecs are the O2-IMS specifications the response DTOs must conform to:
+// the published API spec and the spec served and validated by the gateway.
+var openAPISpecs = []string{
+	"../../../api/openapi/o2ims.yaml",
+	"../../server/openapi/o2ims.yaml",
+}
+
+func loadSpec(t *testing.T, path string) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromFile(path)
+	require.NoError(t, err)
+	return doc
+}
+
+// assertConforms marshals v and checks it against the named schema: it must
+// validate, and every top-level field must be declared by the schema so
+// misspelled or undocumented fields are caught.
+func assertConforms(t *testing.T, doc *openapi3.T, schemaName string, v interface{}) {
+	t.Helper()
+
+	ref, ok := doc.Components.Schemas[schemaName]
+	require.True(t, ok, "schema %s not found", schemaName)
+	schema := ref.Value
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, schema.VisitJSON(decoded), "%s: %s", schemaName, data)
+
+	fields, ok := decoded.(map[string]interface{})
+	require.True(t, ok, "%s is not an object", schemaName)
+	for field := range fields {
+		assert.Contains(t, schema.Properties, field, "%s has undeclared field %q", schemaName, field)
+	}
+}
+
+func TestResponseDTOs_ConformToOpenAPI(t *testing.T) {
+	created := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	pools := []*adapter.ResourcePool{
+		{ResourcePoolID: "pool-1", Name: "edge", OCloudID: "ocloud-1", Location: "dc-1"},
+		{ResourcePoolID: "pool-2", Name: "core", OCloudID: "ocloud-1"},
+	}
+	resources := []*adapter.Resource{
+		{ResourceID: "node-1", ResourceTypeID: "compute", ResourcePoolID: "pool-1"},
+	}
+	resourceTypes := []*adapter.ResourceType{
+		{ResourceTypeID: "compute", Name: "Compute Node"},
+	}
+	deploymentManagers := []*adapter.DeploymentManager{
+		{
+			DeploymentManagerID: "dm-1", Name: "cluster", OCloudID: "ocloud-1",
+			ServiceURI: "https://k8s.example.com",
+		},
+	}
+
+	cases := []struct {
+		schema string
+		value  interface{}
+	}{
+		{"ErrorResponse", models.ErrorResponse{
+			Error: "NotFound", Message: "Resource pool not found: pool-9", Code: http.StatusNotFound,
+		}},
+		{"ErrorResponse", problem.New(http.StatusNotFound, "NotFound", "Resource pool not found: pool-9").
+			With("requestId", "req-1")},
+		{"ResourcePoolListResponse", models.ListEnvelope[*adapter.ResourcePool]{
+			Kind: "resourcePools", Items: pools, Total: len(pools), ResourceVersion: "abc123",
+		}},
+		{"ResourcePoolListResponse", models.ListEnvelope[*adapter.ResourcePool]{
+			Kind: "resourcePools", Items: pools[:1], Total: len(pools),
+			SnapshotCreatedAt: &created, NextCursor: "cursor-1",
+		}},
+		{"ResourceListResponse", models.ListEnvelope[*adapter.Resource]{
+			Kind: "resources", Items: resources, Total: len(resources),
+		}},
+		{"ResourceTypeListResponse", models.ListEnvelope[*adapter.ResourceType]{
+			Kind: "resourceTypes", Items: resourceTypes, Total: len(resourceTypes),
+		}},
+		{"DeploymentManagerListResponse", models.ListEnvelope[*adapter.DeploymentManager]{
+			Kind: "deploymentManagers", Items: deploymentManagers, Total: 1,
+		}},
+		{"SubscriptionListResponse", models.ListEnvelope[*adapter.Subscription]{
+			Kind: "subscriptions", Total: 0,
+		}},
+		{"OCloudInfrastructure", models.OCloudInfrastructure{
+			OCloudID: "ocloud-1", Name: "Primary O-Cloud", ServiceURI: "https://o2ims.example.com",
+		}},
+		{"ApiInfo", models.APIInfo{
+			APIVersion: "v1",
+			BasePath:   "/o2ims-infrastructureInventory/v1",
+			Resources:  []string{"subscriptions", "resourcePools"},
+			Features:   []string{"Cursor-based pagination"},
+		}},
+	}
+
+	for _, path := range openAPISpecs {
+		doc := loadSpec(t, path)
+		for _, tc := range cases {
+			t.Run(path+"/"+tc.schema, func(t *testing.T) {
+				assertConforms(t, doc, tc.schema, tc.value)
+			})
+		}
+	}
+}
+
+func TestTenantQuotas_ConformToOpenAPI(t *testing.T) {
+	used := 3
+	doc := loadSpec(t, openAPISpecs[0])
+
+	assertConforms(t, doc, "TenantQuotas", models.TenantQuotas{
+		TenantID: "tenant-1",
+		Quotas: models.TenantQuotaDetails{
+			MaxSubscriptions: 100, MaxResourcePools: 50, MaxResources: 1000, UsedSubscriptions: &used,
+		},
+	})
+	assertConforms(t, doc, "TenantQuotas", models.TenantQuotas{
+		TenantID:  "tenant-1",
+		Quotas:    models.TenantQuotaDetails{MaxSubscriptions: 10},
+		UpdatedAt: "2026-01-02T15:04:05Z",
+	})
+}
+
+func TestListEnvelope_JSON(t *testing.T) {
+	created := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	list := models.ListEnvelope[string]{
+		Kind: "names", Items: []string{"a", "b"}, Total: 5,
+		SnapshotCreatedAt: &created, NextCursor: "next",
+	}
+
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"names":["a","b"],"total":5,"snapshotCreatedAt":"2026-01-02T15:04:05Z","nextCursor":"next"}`,
+		string(data))
+
+	decoded := models.ListEnvelope[string]{Kind: "names"}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, list, decoded)
+
+	t.Run("nil items marshal as an empty array", func(t *testing.T) {
+		data, err := json.Marshal(models.ListEnvelope[string]{Kind: "names"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"names":[],"total":0}`, string(data))
+	})
+
+	t.Run("kind is required", func(t *testing.T) {
+		_, err := json.Marshal(models.ListEnvelope[string]{Items: []string{"a"}})
+		require.Error(t, err)
+		require.Error(t, json.Unmarshal(data, &models.ListEnvelope[string]{}))
+	})
+}
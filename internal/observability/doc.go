@@ -29,7 +29,11 @@
 //
 //	metrics := observability.InitMetrics("o2ims")
 //
-// Record HTTP request metrics:
+// Record HTTP request metrics for every route with the middleware:
+//
+//	router.Use(observability.HTTPMetricsMiddleware(metrics))
+//
+// or for a single request:
 //
 //	metrics.RecordHTTPRequest("GET", "/api/v1/subscriptions", 200, duration, responseSize)
 //
@@ -49,10 +53,12 @@
 //
 //	healthChecker := observability.NewHealthChecker("v1.0.0")
 //
-//	// Register Redis health check
-//	healthChecker.RegisterReadinessCheck("redis", observability.RedisHealthCheck(func(ctx context.Context) error {
-//	    return redisClient.Ping(ctx).Err()
-//	}))
+//	// Register Redis readiness check; without Redis the gateway is degraded
+//	// (read-only inventory) rather than unready
+//	healthChecker.RegisterReadinessCheckWithCriticality("redis", observability.CriticalityInformational,
+//	    observability.RedisHealthCheck(func(ctx context.Context) error {
+//	        return redisClient.Ping(ctx).Err()
+//	    }))
 //
 //	// Register Kubernetes health check
 //	healthChecker.RegisterReadinessCheck("kubernetes",
@@ -67,6 +73,10 @@
 //	http.HandleFunc("/ready", healthChecker.ReadinessHandler())
 //	http.HandleFunc("/live", observability.LivenessHandler())
 //
+// The readiness response reports the status (healthy, degraded or unhealthy)
+// and the criticality and latency of each check. With SetMetrics, check
+// latencies and outcomes are also exported as metrics.
+//
 // # Complete Example
 //
 //	func main() {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 )
@@ -21,15 +22,38 @@ const (
 	StatusDegraded HealthStatus = "degraded"
 )
 
+// Criticality is the impact of a failing readiness check.
+type Criticality string
+
+const (
+	// CriticalityCritical marks a check the gateway cannot serve traffic
+	// without; its failure makes the gateway not ready.
+	CriticalityCritical Criticality = "critical"
+	// CriticalityInformational marks a check the gateway can serve traffic
+	// without, e.g. read-only inventory with Redis down; its failure makes
+	// the gateway degraded but still ready.
+	CriticalityInformational Criticality = "informational"
+)
+
+// Probe labels of the health check metrics.
+const (
+	probeHealth    = "health"
+	probeReadiness = "readiness"
+)
+
 // HealthCheck represents a health check function.
 type HealthCheck func(ctx context.Context) error
 
 // ComponentHealth represents the health status of a single componen.
 type ComponentHealth struct {
-	Status  HealthStatus `json:"status"`
-	Message string       `json:"message,omitempty"`
-	Error   string       `json:"error,omitempty"`
-	Latency string       `json:"latency,omitempty"`
+	Status    HealthStatus `json:"status"`
+	Message   string       `json:"message,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	Latency   string       `json:"latency,omitempty"`
+	LatencyMs float64      `json:"latencyMs,omitempty"`
+
+	// Criticality is the criticality of a readiness check.
+	Criticality Criticality `json:"criticality,omitempty"`
 }
 
 // HealthResponse represents the overall health check response.
@@ -42,11 +66,28 @@ type HealthResponse struct {
 
 // ReadinessResponse represents the readiness check response.
 type ReadinessResponse struct {
-	Ready      bool                       `json:"ready"`
+	Ready bool `json:"ready"`
+
+	// Status is healthy when every check passes, degraded when only
+	// informational checks fail (the gateway is still ready), and unhealthy
+	// when a critical check fails.
+	Status HealthStatus `json:"status"`
+
+	// Degraded lists the failing informational checks.
+	Degraded []string `json:"degraded,omitempty"`
+
 	Timestamp  time.Time                  `json:"timestamp"`
 	Components map[string]ComponentHealth `json:"components"`
+
+	// Details holds state reported alongside the checks, such as the adapter
+	// circuit breakers, which doesn't affect readiness.
+	Details map[string]interface{} `json:"details,omitempty"`
 }
 
+// ReadinessDetail returns state reported in the details of the readiness
+// response.
+type ReadinessDetail func() interface{}
+
 // HealthChecker manages health and readiness checks.
 type HealthChecker struct {
 	mu              sync.RWMutex
@@ -54,8 +95,22 @@ type HealthChecker struct {
 	ReadinessChecks map[string]HealthCheck // Exported for testing
 	Version         string                 // Exported for testing
 	Timeout         time.Duration          // Exported for testing
+
+	readinessDetails     map[string]ReadinessDetail
+	readinessCriticality map[string]Criticality
+	metrics              *Metrics
+
+	// The results of the background prober, served while fresh.
+	probeInterval   time.Duration
+	cachedHealth    *HealthResponse
+	cachedReadiness *ReadinessResponse
 }
 
+// probeStaleIntervals is the number of probe intervals after which cached
+// results are considered stale, e.g. because the prober stopped, and the
+// checks run on demand again.
+const probeStaleIntervals = 3
+
 // NewHealthChecker creates a new health checker.
 func NewHealthChecker(version string) *HealthChecker {
 	return &HealthChecker{
@@ -63,6 +118,9 @@ func NewHealthChecker(version string) *HealthChecker {
 		ReadinessChecks: make(map[string]HealthCheck),
 		Version:         version,
 		Timeout:         5 * time.Second, // Default timeout
+
+		readinessDetails:     make(map[string]ReadinessDetail),
+		readinessCriticality: make(map[string]Criticality),
 	}
 }
 
@@ -73,11 +131,38 @@ func (hc *HealthChecker) RegisterHealthCheck(name string, check HealthCheck) {
 	hc.HealthChecks[name] = check
 }
 
-// RegisterReadinessCheck registers a readiness check for a componen.
+// RegisterReadinessCheck registers a critical readiness check for a componen.
 func (hc *HealthChecker) RegisterReadinessCheck(name string, check HealthCheck) {
+	hc.RegisterReadinessCheckWithCriticality(name, CriticalityCritical, check)
+}
+
+// RegisterReadinessCheckWithCriticality registers a readiness check for a
+// component with the given criticality.
+func (hc *HealthChecker) RegisterReadinessCheckWithCriticality(
+	name string,
+	criticality Criticality,
+	check HealthCheck,
+) {
 	hc.mu.Lock()
 	defer hc.mu.Unlock()
 	hc.ReadinessChecks[name] = check
+	hc.readinessCriticality[name] = criticality
+}
+
+// SetMetrics sets the metrics the latency and outcome of every check are
+// recorded to.
+func (hc *HealthChecker) SetMetrics(m *Metrics) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.metrics = m
+}
+
+// RegisterReadinessDetail registers state reported under name in the details
+// of the readiness response.
+func (hc *HealthChecker) RegisterReadinessDetail(name string, detail ReadinessDetail) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.readinessDetails[name] = detail
 }
 
 // SetTimeout sets the timeout for health checks.
@@ -87,6 +172,79 @@ func (hc *HealthChecker) SetTimeout(timeout time.Duration) {
 	hc.Timeout = timeout
 }
 
+// StartProber runs the health and readiness checks every interval in the
+// background until ctx is canceled. Health and Readiness then serve the last
+// results instantly, so probe latency no longer depends on backend latency.
+// Until the first run completes, the checks run on demand.
+func (hc *HealthChecker) StartProber(ctx context.Context, interval time.Duration) {
+	hc.mu.Lock()
+	hc.probeInterval = interval
+	hc.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			hc.probe(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// probe runs the checks and caches the results.
+func (hc *HealthChecker) probe(ctx context.Context) {
+	health := hc.CheckHealth(ctx)
+	readiness := hc.CheckReadiness(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.cachedHealth = health
+	hc.cachedReadiness = readiness
+}
+
+// fresh reports whether results taken at timestamp may still be served.
+// Callers hold hc.mu.
+func (hc *HealthChecker) fresh(timestamp time.Time) bool {
+	return hc.probeInterval > 0 && time.Since(timestamp) < probeStaleIntervals*hc.probeInterval
+}
+
+// Health returns the health status last computed by the prober, whose
+// Timestamp tells when the checks ran, or runs the checks if there is no
+// fresh result.
+func (hc *HealthChecker) Health(ctx context.Context) *HealthResponse {
+	hc.mu.RLock()
+	cached := hc.cachedHealth
+	fresh := cached != nil && hc.fresh(cached.Timestamp)
+	hc.mu.RUnlock()
+	if !fresh {
+		return hc.CheckHealth(ctx)
+	}
+	health := *cached
+	return &health
+}
+
+// Readiness returns the readiness status last computed by the prober, whose
+// Timestamp tells when the checks ran, or runs the checks if there is no
+// fresh result.
+func (hc *HealthChecker) Readiness(ctx context.Context) *ReadinessResponse {
+	hc.mu.RLock()
+	cached := hc.cachedReadiness
+	fresh := cached != nil && hc.fresh(cached.Timestamp)
+	hc.mu.RUnlock()
+	if !fresh {
+		return hc.CheckReadiness(ctx)
+	}
+	readiness := *cached
+	return &readiness
+}
+
 // CheckHealth performs all health checks and returns the health status.
 func (hc *HealthChecker) CheckHealth(ctx context.Context) *HealthResponse {
 	hc.mu.RLock()
@@ -95,12 +253,18 @@ func (hc *HealthChecker) CheckHealth(ctx context.Context) *HealthResponse {
 		checks[name] = check
 	}
 	timeout := hc.Timeout
+	metrics := hc.metrics
 	hc.mu.RUnlock()
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	components := hc.ExecuteChecks(ctx, checks)
+	if metrics != nil {
+		for name, component := range components {
+			metrics.RecordHealthCheck(probeHealth, name, "", component)
+		}
+	}
 
 	// Determine overall status
 	overallStatus := StatusHealthy
@@ -129,7 +293,19 @@ func (hc *HealthChecker) CheckReadiness(ctx context.Context) *ReadinessResponse
 	for name, check := range hc.ReadinessChecks {
 		checks[name] = check
 	}
+	var details map[string]interface{}
+	if len(hc.readinessDetails) > 0 {
+		details = make(map[string]interface{}, len(hc.readinessDetails))
+		for name, detail := range hc.readinessDetails {
+			details[name] = detail()
+		}
+	}
+	criticality := make(map[string]Criticality, len(checks))
+	for name := range checks {
+		criticality[name] = hc.readinessCriticality[name]
+	}
 	timeout := hc.Timeout
+	metrics := hc.metrics
 	hc.mu.RUnlock()
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
@@ -137,19 +313,44 @@ func (hc *HealthChecker) CheckReadiness(ctx context.Context) *ReadinessResponse
 
 	components := hc.ExecuteChecks(ctx, checks)
 
-	// Determine overall readiness - all components must be healthy
-	ready := true
-	for _, component := range components {
-		if component.Status != StatusHealthy {
-			ready = false
-			break
+	// Critical checks must be healthy; failing informational checks only
+	// degrade the gateway.
+	status := StatusHealthy
+	var degraded []string
+	for name, component := range components {
+		if criticality[name] == "" {
+			criticality[name] = CriticalityCritical
+		}
+		component.Criticality = criticality[name]
+		components[name] = component
+		if metrics != nil {
+			metrics.RecordHealthCheck(probeReadiness, name, component.Criticality, component)
+		}
+
+		if component.Status == StatusHealthy {
+			continue
+		}
+		if component.Criticality == CriticalityCritical {
+			status = StatusUnhealthy
+			continue
 		}
+		degraded = append(degraded, name)
+		if status == StatusHealthy {
+			status = StatusDegraded
+		}
+	}
+	sort.Strings(degraded)
+	if metrics != nil {
+		metrics.SetReadinessStatus(status)
 	}
 
 	return &ReadinessResponse{
-		Ready:      ready,
+		Ready:      status != StatusUnhealthy,
+		Status:     status,
+		Degraded:   degraded,
 		Timestamp:  time.Now(),
 		Components: components,
+		Details:    details,
 	}
 }
 
@@ -177,8 +378,9 @@ func (hc *HealthChecker) ExecuteChecks(ctx context.Context, checks map[string]He
 			latency := time.Since(start)
 
 			health := ComponentHealth{
-				Status:  StatusHealthy,
-				Latency: latency.String(),
+				Status:    StatusHealthy,
+				Latency:   latency.String(),
+				LatencyMs: float64(latency.Microseconds()) / 1000,
 			}
 
 			if err != nil {
@@ -215,7 +417,7 @@ func (hc *HealthChecker) ExecuteChecks(ctx context.Context, checks map[string]He
 // HealthHandler returns an HTTP handler for the health endpoin.
 func (hc *HealthChecker) HealthHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		health := hc.CheckHealth(r.Context())
+		health := hc.Health(r.Context())
 
 		statusCode := http.StatusOK
 		if health.Status == StatusUnhealthy {
@@ -234,7 +436,7 @@ func (hc *HealthChecker) HealthHandler() http.HandlerFunc {
 // ReadinessHandler returns an HTTP handler for the readiness endpoin.
 func (hc *HealthChecker) ReadinessHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		readiness := hc.CheckReadiness(r.Context())
+		readiness := hc.Readiness(r.Context())
 
 		statusCode := http.StatusOK
 		if !readiness.Ready {
@@ -6,11 +6,14 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/piwi3910/netweave/internal/observability"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -179,6 +182,118 @@ func TestCheckReadinessWithNotReadyComponent(t *testing.T) {
 	assert.Contains(t, k8sComp.Error, "k8s not reachable")
 }
 
+func TestCheckReadinessDegraded(t *testing.T) {
+	hc := observability.NewHealthChecker("v1.0.0")
+	hc.RegisterReadinessCheck("adapter", func(_ context.Context) error {
+		return nil
+	})
+	hc.RegisterReadinessCheckWithCriticality("redis", observability.CriticalityInformational,
+		func(_ context.Context) error {
+			return errors.New("connection refused")
+		})
+
+	response := hc.CheckReadiness(context.Background())
+
+	require.NotNil(t, response)
+	assert.True(t, response.Ready, "informational checks don't fail readiness")
+	assert.Equal(t, observability.StatusDegraded, response.Status)
+	assert.Equal(t, []string{"redis"}, response.Degraded)
+	assert.Equal(t, observability.CriticalityInformational, response.Components["redis"].Criticality)
+	assert.Equal(t, observability.StatusUnhealthy, response.Components["redis"].Status)
+	assert.Equal(t, observability.CriticalityCritical, response.Components["adapter"].Criticality)
+
+	hc.RegisterReadinessCheck("adapter", func(_ context.Context) error {
+		return errors.New("adapter down")
+	})
+	response = hc.CheckReadiness(context.Background())
+	assert.False(t, response.Ready)
+	assert.Equal(t, observability.StatusUnhealthy, response.Status)
+}
+
+func TestCheckReadinessMetrics(t *testing.T) {
+	m := observability.NewMetrics("test", prometheus.NewRegistry())
+	hc := observability.NewHealthChecker("v1.0.0")
+	hc.SetMetrics(m)
+	hc.RegisterReadinessCheck("adapter", func(_ context.Context) error {
+		return nil
+	})
+	hc.RegisterReadinessCheckWithCriticality("redis", observability.CriticalityInformational,
+		func(_ context.Context) error {
+			return errors.New("connection refused")
+		})
+
+	response := hc.CheckReadiness(context.Background())
+	assert.GreaterOrEqual(t, response.Components["adapter"].LatencyMs, 0.0)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.HealthCheckUp.WithLabelValues("readiness", "adapter", "critical")))
+	assert.Equal(t, float64(0),
+		testutil.ToFloat64(m.HealthCheckUp.WithLabelValues("readiness", "redis", "informational")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.ReadinessStatus.WithLabelValues("degraded")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.ReadinessStatus.WithLabelValues("healthy")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.HealthCheckDuration))
+}
+
+func TestCheckReadinessDetails(t *testing.T) {
+	hc := observability.NewHealthChecker("v1.0.0")
+
+	response := hc.CheckReadiness(context.Background())
+	assert.Nil(t, response.Details)
+
+	hc.RegisterReadinessCheck("redis", func(_ context.Context) error {
+		return nil
+	})
+	hc.RegisterReadinessDetail("circuitBreakers", func() interface{} {
+		return map[string]string{"ims-adapter/kubernetes": "open"}
+	})
+
+	response = hc.CheckReadiness(context.Background())
+
+	require.NotNil(t, response)
+	assert.True(t, response.Ready, "details don't affect readiness")
+	assert.Equal(t, map[string]string{"ims-adapter/kubernetes": "open"}, response.Details["circuitBreakers"])
+}
+
+func TestHealthCheckerProber(t *testing.T) {
+	hc := observability.NewHealthChecker("v1.0.0")
+	var calls atomic.Int32
+	var failing atomic.Bool
+	check := func(_ context.Context) error {
+		calls.Add(1)
+		if failing.Load() {
+			return errors.New("backend down")
+		}
+		return nil
+	}
+	hc.RegisterHealthCheck("backend", check)
+	hc.RegisterReadinessCheck("backend", check)
+
+	// Without a prober, the checks run on demand.
+	hc.Readiness(context.Background())
+	assert.Equal(t, int32(1), calls.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hc.StartProber(ctx, time.Hour)
+	// Once the first probe completes, the cached results are served without
+	// running the checks.
+	require.Eventually(t, func() bool {
+		before := calls.Load()
+		hc.Readiness(context.Background())
+		return calls.Load() == before
+	}, time.Second, time.Millisecond)
+	failing.Store(true)
+	before := calls.Load()
+	readiness := hc.Readiness(context.Background())
+	health := hc.Health(context.Background())
+	assert.True(t, readiness.Ready)
+	assert.Equal(t, observability.StatusHealthy, health.Status)
+	assert.Equal(t, before, calls.Load())
+
+	// Callers get copies of the cached results.
+	readiness.Ready = false
+	assert.True(t, hc.Readiness(context.Background()).Ready)
+}
+
 func TestExecuteChecksEmpty(t *testing.T) {
 	hc := observability.NewHealthChecker("v1.0.0")
 	ctx := context.Background()
@@ -0,0 +1,94 @@
+package observability
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UnmatchedRoute is the route label of requests that matched no route, such
+// as 404s for arbitrary paths. Labelling them with the raw path would let
+// clients create unbounded label values.
+const UnmatchedRoute = "unmatched"
+
+// NoAPIVersion is the api_version label of requests to no API version.
+const NoAPIVersion = "none"
+
+// HTTPMetricsOption configures HTTPMetricsMiddleware.
+type HTTPMetricsOption func(*httpMetricsOptions)
+
+type httpMetricsOptions struct {
+	apiVersion func(c *gin.Context) string
+}
+
+// WithAPIVersionLabel sets the function that returns the api_version label of
+// a request. It is called after the request is handled, and must return a
+// bounded set of values. Without it, every request is labelled NoAPIVersion.
+func WithAPIVersionLabel(apiVersion func(c *gin.Context) string) HTTPMetricsOption {
+	return func(o *httpMetricsOptions) {
+		o.apiVersion = apiVersion
+	}
+}
+
+// RouteLabel returns the route template of a request for metric labels, e.g.
+// "/o2ims-infrastructureInventory/v1/resourcePools/:resourcePoolId" rather
+// than the request path, or UnmatchedRoute if no route matched.
+func RouteLabel(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return UnmatchedRoute
+}
+
+// StatusClass returns the class of an HTTP status code for metric labels:
+// "1xx" to "5xx", or "unknown" for codes outside that range.
+func StatusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// HTTPMetricsMiddleware returns a Gin middleware that records the request
+// count, latency and response size of every request, labelled with the
+// method, the route template, the status code and class, and the API version,
+// and tracks the requests in flight. Handlers do not need to call RecordHTTPRequest themselves.
+//
+// With exemplars enabled, the W3C trace context of the request (the
+// traceparent header) is added to the request context unless it already
+// carries a span, and the trace ID of sampled requests is attached to the
+// duration observation as an exemplar.
+func HTTPMetricsMiddleware(m *Metrics, opts ...HTTPMetricsOption) gin.HandlerFunc {
+	options := &httpMetricsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	traceContext := propagation.TraceContext{}
+	return func(c *gin.Context) {
+		start := time.Now()
+		m.HTTPInFlightInc()
+		defer m.HTTPInFlightDec()
+
+		ctx := c.Request.Context()
+		if m.exemplars && !trace.SpanContextFromContext(ctx).IsValid() {
+			ctx = traceContext.Extract(ctx, propagation.HeaderCarrier(c.Request.Header))
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+
+		size := c.Writer.Size()
+		if size < 0 {
+			size = 0
+		}
+		apiVersion := NoAPIVersion
+		if options.apiVersion != nil {
+			apiVersion = options.apiVersion(c)
+		}
+		m.RecordHTTPRequestContext(ctx, c.Request.Method, RouteLabel(c), apiVersion, c.Writer.Status(), time.Since(start), size)
+	}
+}
@@ -0,0 +1,164 @@
+package observability_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/observability"
+)
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", observability.StatusClass(http.StatusOK))
+	assert.Equal(t, "2xx", observability.StatusClass(http.StatusNoContent))
+	assert.Equal(t, "4xx", observability.StatusClass(http.StatusNotFound))
+	assert.Equal(t, "5xx", observability.StatusClass(http.StatusServiceUnavailable))
+	assert.Equal(t, "unknown", observability.StatusClass(0))
+	assert.Equal(t, "unknown", observability.StatusClass(600))
+}
+
+func TestHTTPMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := observability.NewMetrics("test", prometheus.NewRegistry())
+
+	var inFlight float64
+	router := gin.New()
+	router.Use(observability.HTTPMetricsMiddleware(m))
+	router.GET("/resourcePools/:resourcePoolId", func(c *gin.Context) {
+		inFlight = testutil.ToFloat64(m.HTTPRequestsInFlight)
+		c.String(http.StatusOK, "pool")
+	})
+
+	for _, path := range []string{"/resourcePools/pool-1", "/resourcePools/pool-2", "/unknown/pool-3"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, float64(1), inFlight)
+	assert.Zero(t, testutil.ToFloat64(m.HTTPRequestsInFlight))
+
+	// Requests are labelled with the route template, not the path.
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		m.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/resourcePools/:resourcePoolId", "200", "2xx",
+			observability.NoAPIVersion)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		m.HTTPRequestsTotal.WithLabelValues(http.MethodGet, observability.UnmatchedRoute, "404", "4xx",
+			observability.NoAPIVersion)))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.HTTPRequestsTotal))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.HTTPResponseSizeBytes))
+}
+
+func TestHTTPMetricsMiddleware_APIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := observability.NewMetrics("test", prometheus.NewRegistry())
+
+	router := gin.New()
+	router.Use(observability.HTTPMetricsMiddleware(m, observability.WithAPIVersionLabel(func(c *gin.Context) string {
+		return c.GetString("version")
+	})))
+	router.GET("/v3/resources", func(c *gin.Context) {
+		c.Set("version", "v3")
+		c.Status(http.StatusNoContent)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v3/resources", nil))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		m.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/v3/resources", "204", "2xx", "v3")))
+}
+
+// durationHistogram returns the gathered HTTP duration histogram of a route.
+func durationHistogram(t *testing.T, registry *prometheus.Registry, route string) *dto.Histogram {
+	t.Helper()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "test_http_request_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "path" && label.GetValue() == route {
+					return metric.GetHistogram()
+				}
+			}
+		}
+	}
+	t.Fatalf("no duration histogram for route %s", route)
+	return nil
+}
+
+// bucketExemplars returns the trace IDs of the exemplars of a histogram.
+func bucketExemplars(histogram *dto.Histogram) []string {
+	var traceIDs []string
+	for _, bucket := range histogram.GetBucket() {
+		for _, label := range bucket.GetExemplar().GetLabel() {
+			if label.GetName() == observability.ExemplarTraceIDLabel {
+				traceIDs = append(traceIDs, label.GetValue())
+			}
+		}
+	}
+	return traceIDs
+}
+
+func TestHTTPMetricsMiddleware_Exemplars(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	serve := func(m *observability.Metrics, traceparent string) {
+		router := gin.New()
+		router.Use(observability.HTTPMetricsMiddleware(m))
+		router.GET("/resources/:resourceId", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/resources/r-1", nil)
+		if traceparent != "" {
+			req.Header.Set("traceparent", traceparent)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	t.Run("sampled trace", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		m := observability.NewMetrics("test", registry, observability.WithExemplars())
+		serve(m, "00-"+traceID+"-00f067aa0ba902b7-01")
+		assert.Equal(t, []string{traceID}, bucketExemplars(durationHistogram(t, registry, "/resources/:resourceId")))
+	})
+
+	t.Run("unsampled trace", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		m := observability.NewMetrics("test", registry, observability.WithExemplars())
+		serve(m, "00-"+traceID+"-00f067aa0ba902b7-00")
+		assert.Empty(t, bucketExemplars(durationHistogram(t, registry, "/resources/:resourceId")))
+	})
+
+	t.Run("exemplars disabled", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		m := observability.NewMetrics("test", registry)
+		serve(m, "00-"+traceID+"-00f067aa0ba902b7-01")
+		assert.Empty(t, bucketExemplars(durationHistogram(t, registry, "/resources/:resourceId")))
+	})
+}
+
+func TestNewMetrics_NativeHistograms(t *testing.T) {
+	for _, native := range []bool{false, true} {
+		registry := prometheus.NewRegistry()
+		var opts []observability.MetricsOption
+		if native {
+			opts = append(opts, observability.WithNativeHistograms())
+		}
+		m := observability.NewMetrics("test", registry, opts...)
+		m.RecordHTTPRequest(http.MethodGet, "/resources", http.StatusOK, 30*time.Millisecond, 512)
+
+		histogram := durationHistogram(t, registry, "/resources")
+		// Classic buckets are kept either way.
+		assert.NotEmpty(t, histogram.GetBucket())
+		assert.Equal(t, native, histogram.Schema != nil, "native histogram schema")
+	}
+}
@@ -0,0 +1,186 @@
+package observability
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log modules whose level can be changed independently of the global level.
+const (
+	ModuleServer   = "server"
+	ModuleAdapters = "adapters"
+	ModuleDMS      = "dms"
+	ModuleAuth     = "auth"
+)
+
+// ErrUnknownLogModule is returned when a log level is set for a module that
+// is not one of the Module* constants.
+var ErrUnknownLogModule = errors.New("unknown log module")
+
+// moduleLevel is the level override of one module. Until set, the module
+// follows the global level.
+type moduleLevel struct {
+	level zap.AtomicLevel
+	set   atomic.Bool
+}
+
+var (
+	// globalLevel is the level of loggers outside any module and of modules
+	// without an override.
+	globalLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+	moduleLevels = map[string]*moduleLevel{
+		ModuleServer:   {level: zap.NewAtomicLevel()},
+		ModuleAdapters: {level: zap.NewAtomicLevel()},
+		ModuleDMS:      {level: zap.NewAtomicLevel()},
+		ModuleAuth:     {level: zap.NewAtomicLevel()},
+	}
+)
+
+// LogLevelStatus reports the effective log levels.
+type LogLevelStatus struct {
+	// Level is the global log level.
+	Level string `json:"level"`
+
+	// Modules is the effective level of each module.
+	Modules map[string]string `json:"modules"`
+
+	// Overrides lists the modules whose level was set explicitly rather than
+	// following the global level.
+	Overrides []string `json:"overrides"`
+}
+
+// LogModules returns the names of the modules with their own log level.
+func LogModules() []string {
+	modules := make([]string, 0, len(moduleLevels))
+	for module := range moduleLevels {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// ParseLogLevel parses a level name ("debug", "info", "warn", "error", ...).
+func ParseLogLevel(level string) (zapcore.Level, error) {
+	var l zapcore.Level
+	// zap parses an empty level as info; require it to be explicit.
+	if level == "" {
+		return l, errors.New("log level must not be empty")
+	}
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return l, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return l, nil
+}
+
+// SetLogLevel changes a log level at runtime. An empty module sets the global
+// level; otherwise only the named module is changed. An empty level clears a
+// module override so the module follows the global level again.
+func SetLogLevel(module, level string) error {
+	if module == "" {
+		l, err := ParseLogLevel(level)
+		if err != nil {
+			return err
+		}
+		globalLevel.SetLevel(l)
+		return nil
+	}
+
+	ml, ok := moduleLevels[module]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownLogModule, module)
+	}
+	if level == "" {
+		ml.set.Store(false)
+		return nil
+	}
+	l, err := ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	ml.level.SetLevel(l)
+	ml.set.Store(true)
+	return nil
+}
+
+// GetLogLevels returns the current global and per-module log levels.
+func GetLogLevels() LogLevelStatus {
+	status := LogLevelStatus{
+		Level:     globalLevel.String(),
+		Modules:   make(map[string]string, len(moduleLevels)),
+		Overrides: []string{},
+	}
+	for _, module := range LogModules() {
+		status.Modules[module] = effectiveLevel(module).String()
+		if moduleLevels[module].set.Load() {
+			status.Overrides = append(status.Overrides, module)
+		}
+	}
+	return status
+}
+
+// effectiveLevel returns the level a module logs at. An empty module uses the
+// global level.
+func effectiveLevel(module string) zapcore.Level {
+	if ml, ok := moduleLevels[module]; ok && ml.set.Load() {
+		return ml.level.Level()
+	}
+	return globalLevel.Level()
+}
+
+// BaseLevel returns the level to build loggers with. It enables every
+// level; the runtime levels are applied by DynamicLevel and ModuleLogger, so
+// a module can log below the global level.
+func BaseLevel() zap.AtomicLevel {
+	return zap.NewAtomicLevelAt(zapcore.DebugLevel)
+}
+
+// DynamicLevel returns an option that filters a logger by the runtime global
+// level. Build loggers with BaseLevel and this option so SetLogLevel takes
+// effect without a restart.
+func DynamicLevel() zap.Option {
+	return levelOption("")
+}
+
+// ModuleLogger returns a named logger for a module whose entries are filtered
+// by the module's runtime level instead of the global level.
+func ModuleLogger(logger *zap.Logger, module string) *zap.Logger {
+	return logger.WithOptions(levelOption(module)).Named(module)
+}
+
+func levelOption(module string) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		// Replace any level filter of the parent logger rather than stacking
+		// on it, so a module can be more verbose than the global level.
+		if lc, ok := core.(*levelCore); ok {
+			core = lc.Core
+		}
+		return &levelCore{Core: core, module: module}
+	})
+}
+
+// levelCore drops entries below the runtime level of its module.
+type levelCore struct {
+	zapcore.Core
+	module string
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return level >= effectiveLevel(c.module) && c.Core.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), module: c.module}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < effectiveLevel(c.module) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
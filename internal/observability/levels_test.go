@@ -0,0 +1,90 @@
+package observability_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/piwi3910/netweave/internal/observability"
+)
+
+// resetLogLevels restores the default levels after a test.
+func resetLogLevels(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		require.NoError(t, observability.SetLogLevel("", "info"))
+		for _, module := range observability.LogModules() {
+			require.NoError(t, observability.SetLogLevel(module, ""))
+		}
+	})
+}
+
+func TestSetLogLevel(t *testing.T) {
+	resetLogLevels(t)
+
+	core, logs := observer.New(observability.BaseLevel())
+	logger := zap.New(core, observability.DynamicLevel())
+	dmsLogger := observability.ModuleLogger(logger, observability.ModuleDMS)
+	authLogger := observability.ModuleLogger(logger, observability.ModuleAuth).With(zap.String("k", "v"))
+
+	logger.Debug("global debug dropped")
+	dmsLogger.Debug("dms debug dropped")
+
+	// A module can be more verbose than the global level.
+	require.NoError(t, observability.SetLogLevel(observability.ModuleDMS, "debug"))
+	logger.Debug("global debug still dropped")
+	dmsLogger.Debug("dms debug")
+	authLogger.Debug("auth debug dropped")
+
+	// Modules without an override follow the global level.
+	require.NoError(t, observability.SetLogLevel("", "error"))
+	authLogger.Warn("auth warn dropped")
+	dmsLogger.Debug("dms debug after global change")
+
+	// Clearing the override makes the module follow the global level again.
+	require.NoError(t, observability.SetLogLevel(observability.ModuleDMS, ""))
+	dmsLogger.Warn("dms warn dropped")
+	dmsLogger.Error("dms error")
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.LoggerName+": "+entry.Message)
+	}
+	assert.Equal(t, []string{
+		"dms: dms debug",
+		"dms: dms debug after global change",
+		"dms: dms error",
+	}, messages)
+}
+
+func TestSetLogLevel_Errors(t *testing.T) {
+	resetLogLevels(t)
+
+	require.ErrorIs(t, observability.SetLogLevel("database", "debug"), observability.ErrUnknownLogModule)
+	require.Error(t, observability.SetLogLevel("", "verbose"))
+	require.Error(t, observability.SetLogLevel("", ""))
+	require.Error(t, observability.SetLogLevel(observability.ModuleServer, "loud"))
+
+	assert.Equal(t, "info", observability.GetLogLevels().Level)
+}
+
+func TestGetLogLevels(t *testing.T) {
+	resetLogLevels(t)
+
+	require.NoError(t, observability.SetLogLevel("", "warn"))
+	require.NoError(t, observability.SetLogLevel(observability.ModuleAdapters, "debug"))
+
+	assert.Equal(t, observability.LogLevelStatus{
+		Level: "warn",
+		Modules: map[string]string{
+			"adapters": "debug",
+			"auth":     "warn",
+			"dms":      "warn",
+			"server":   "warn",
+		},
+		Overrides: []string{"adapters"},
+	}, observability.GetLogLevels())
+}
@@ -7,6 +7,8 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
 // Logger is a wrapper around zap.Logger with additional convenience methods.
@@ -15,7 +17,7 @@ type Logger struct {
 }
 
 // loggerContextKey is the context key for storing logger instances.
-type loggerContextKey struct{}
+var loggerContextKey = requestcontext.NewKey[*Logger]("observability_logger")
 
 var (
 	// GlobalLogger is the default logger instance. Exported for testing.
@@ -48,10 +50,16 @@ func InitLogger(env string) (*Logger, error) {
 		config.Level = zap.NewAtomicLevelAt(level)
 	}
 
+	redactor, err := NewRedactor(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log redactor: %w", err)
+	}
+
 	// Build the logger
 	zapLogger, err := config.Build(
 		zap.AddCallerSkip(1), // Skip wrapper functions in stack trace
 		zap.AddStacktrace(zapcore.ErrorLevel),
+		RedactionOption(redactor),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build logger: %w", err)
@@ -97,29 +105,51 @@ func (l *Logger) WithComponent(component string) *Logger {
 	return &Logger{Logger: l.With(zap.String("component", component))}
 }
 
-// ContextWithLogger adds the logger to the contex.
+// ContextWithLogger adds the logger to the contex. It is also the logger
+// returned by requestcontext.Logger.
 func ContextWithLogger(ctx context.Context, logger *Logger) context.Context {
-	return context.WithValue(ctx, loggerContextKey{}, logger)
+	if logger != nil {
+		ctx = requestcontext.WithLogger(ctx, logger.Logger)
+	}
+	return loggerContextKey.With(ctx, logger)
 }
 
-// LoggerFromContext retrieves the logger from context
-// Returns the global logger if not found in contex.
+// LoggerFromContext retrieves the logger from context, with the request and
+// correlation IDs of ctx. Returns the global logger if not found in contex.
 func LoggerFromContext(ctx context.Context) *Logger {
-	if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
-		return logger
+	if logger := loggerContextKey.Value(ctx); logger != nil {
+		return logger.WithContext(ctx)
 	}
-	return GetLogger()
+	return GetLogger().WithContext(ctx)
 }
 
-// ExtractContextFields extracts logging fields from context
-// This can be extended to include request ID, trace ID, user ID, etc.
-func ExtractContextFields(_ context.Context) []zap.Field {
-	var fields []zap.Field
+// ContextWithRequestID adds the request ID to the context.
+// It is equivalent to requestcontext.WithRequestID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return requestcontext.WithRequestID(ctx, requestID)
+}
 
-	// Example: Extract request ID if available
-	// if requestID := ctx.Value("requestID"); requestID != nil {
-	//     fields = append(fields, zap.String("requestID", requestID.(string)))
-	// }
+// RequestIDFromContext returns the request ID of the context, or "" if none.
+func RequestIDFromContext(ctx context.Context) string {
+	return requestcontext.RequestID(ctx)
+}
+
+// ContextWithCorrelationID adds the correlation ID, which spans every request
+// made for one client operation, to the context.
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return requestcontext.WithCorrelationID(ctx, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID of the context, or "" if none.
+func CorrelationIDFromContext(ctx context.Context) string {
+	return requestcontext.CorrelationID(ctx)
+}
+
+// ExtractContextFields extracts logging fields from context: the request ID
+// and correlation ID when present.
+// This can be extended to include trace ID, user ID, etc.
+func ExtractContextFields(ctx context.Context) []zap.Field {
+	fields := requestcontext.Fields(ctx)
 
 	// Example: Extract trace ID from OpenTelemetry context
 	// if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestInitLogger(t *testing.T) {
@@ -348,3 +349,23 @@ func BenchmarkLogRequest(b *testing.B) {
 		logger.LogRequest("GET", "/api/v1/test", 200, 10.5)
 	}
 }
+
+func TestLoggerFromContextIncludesRequestIDs(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := &observability.Logger{Logger: zap.New(core)}
+
+	ctx := observability.ContextWithLogger(context.Background(), logger)
+	ctx = observability.ContextWithRequestID(ctx, "req-1")
+	ctx = observability.ContextWithCorrelationID(ctx, "op-1")
+
+	assert.Equal(t, "req-1", observability.RequestIDFromContext(ctx))
+	assert.Equal(t, "op-1", observability.CorrelationIDFromContext(ctx))
+
+	observability.LoggerFromContext(ctx).Info("handled")
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{
+		"request_id":     "req-1",
+		"correlation_id": "op-1",
+	}, logs.All()[0].ContextMap())
+}
@@ -1,12 +1,14 @@
 package observability
 
 import (
+	"context"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -52,6 +54,68 @@ type Metrics struct {
 	BatchItemsProcessed    *prometheus.CounterVec
 	BatchRollbacksTotal    *prometheus.CounterVec
 	BatchConcurrentWorkers prometheus.Gauge
+
+	// Health check metrics
+	HealthCheckDuration *prometheus.HistogramVec
+	HealthCheckUp       *prometheus.GaugeVec
+	ReadinessStatus     *prometheus.GaugeVec
+
+	// exemplars attaches trace ID exemplars to HTTP duration observations.
+	exemplars bool
+}
+
+const (
+	// nativeHistogramBucketFactor bounds the growth factor between native
+	// histogram buckets, i.e. a resolution of about 10%.
+	nativeHistogramBucketFactor = 1.1
+
+	// nativeHistogramMaxBuckets caps the buckets of one native histogram;
+	// beyond it the resolution is reduced.
+	nativeHistogramMaxBuckets = 160
+
+	// nativeHistogramMinResetDuration is the minimum time between resets of
+	// a native histogram that reached nativeHistogramMaxBuckets.
+	nativeHistogramMinResetDuration = time.Hour
+
+	// ExemplarTraceIDLabel is the exemplar label carrying the trace ID.
+	ExemplarTraceIDLabel = "trace_id"
+)
+
+// metricsOptions holds the optional features of Metrics.
+type metricsOptions struct {
+	nativeHistograms bool
+	exemplars        bool
+}
+
+// MetricsOption is a functional option for configuring Metrics.
+type MetricsOption func(*metricsOptions)
+
+// WithNativeHistograms makes histograms emit native (sparse) histograms in
+// addition to their classic buckets. Native histograms are only scraped by
+// Prometheus with native histograms enabled, over the protobuf format.
+func WithNativeHistograms() MetricsOption {
+	return func(o *metricsOptions) {
+		o.nativeHistograms = true
+	}
+}
+
+// WithExemplars attaches the trace ID of sampled requests as an exemplar to
+// HTTP duration observations. Exemplars are only exposed in the OpenMetrics
+// format.
+func WithExemplars() MetricsOption {
+	return func(o *metricsOptions) {
+		o.exemplars = true
+	}
+}
+
+// histogram returns opts with native histograms configured when enabled.
+func (o *metricsOptions) histogram(opts prometheus.HistogramOpts) prometheus.HistogramOpts {
+	if o.nativeHistograms {
+		opts.NativeHistogramBucketFactor = nativeHistogramBucketFactor
+		opts.NativeHistogramMaxBucketNumber = nativeHistogramMaxBuckets
+		opts.NativeHistogramMinResetDuration = nativeHistogramMinResetDuration
+	}
+	return opts
 }
 
 var (
@@ -65,9 +129,9 @@ var (
 // Thread-safe using sync.Once to prevent race conditions.
 // Returns the existing metrics instance if already initialized (idempotent).
 // For tests, use InitMetricsWithRegistry or NewMetrics with a custom registry instead.
-func InitMetrics(namespace string) *Metrics {
+func InitMetrics(namespace string, opts ...MetricsOption) *Metrics {
 	metricsOnce.Do(func() {
-		GlobalMetrics = NewMetrics(namespace, prometheus.DefaultRegisterer)
+		GlobalMetrics = NewMetrics(namespace, prometheus.DefaultRegisterer, opts...)
 	})
 
 	return GlobalMetrics
@@ -77,8 +141,8 @@ func InitMetrics(namespace string) *Metrics {
 // This is intended for tests to avoid registry conflicts.
 // Unlike InitMetrics, this does NOT use sync.Once and allows re-initialization.
 // The created metrics instance is stored in GlobalMetrics.
-func InitMetricsWithRegistry(namespace string, registerer prometheus.Registerer) *Metrics {
-	GlobalMetrics = NewMetrics(namespace, registerer)
+func InitMetricsWithRegistry(namespace string, registerer prometheus.Registerer, opts ...MetricsOption) *Metrics {
+	GlobalMetrics = NewMetrics(namespace, registerer, opts...)
 	return GlobalMetrics
 }
 
@@ -86,14 +150,21 @@ func InitMetricsWithRegistry(namespace string, registerer prometheus.Registerer)
 // This allows tests to create isolated metrics instances without conflicts.
 // The namespace parameter defaults to "o2ims" if empty.
 // The registerer parameter should be a prometheus.Registry for tests or prometheus.DefaultRegisterer for production.
-func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
+func NewMetrics(namespace string, registerer prometheus.Registerer, opts ...MetricsOption) *Metrics {
 	if namespace == "" {
 		namespace = "o2ims"
 	}
 
+	options := &metricsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	factory := promauto.With(registerer)
 
 	return &Metrics{
+		exemplars: options.exemplars,
+
 		// HTTP metrics
 		HTTPRequestsTotal: factory.NewCounterVec(
 			prometheus.CounterOpts{
@@ -101,17 +172,17 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 				Name:      "http_requests_total",
 				Help:      "Total number of HTTP requests",
 			},
-			[]string{"method", "path", "status"},
+			[]string{"method", "path", "status", "status_class", "api_version"},
 		),
 
 		HTTPRequestDuration: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "http_request_duration_seconds",
 				Help:      "HTTP request latency in seconds",
 				Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
-			},
-			[]string{"method", "path", "status"},
+			}),
+			[]string{"method", "path", "status", "status_class", "api_version"},
 		),
 
 		HTTPRequestsInFlight: factory.NewGauge(
@@ -123,12 +194,12 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 		),
 
 		HTTPResponseSizeBytes: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "http_response_size_bytes",
 				Help:      "HTTP response size in bytes",
 				Buckets:   prometheus.ExponentialBuckets(100, 10, 8),
-			},
+			}),
 			[]string{"method", "path"},
 		),
 
@@ -143,12 +214,12 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 		),
 
 		AdapterOperationDuration: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "adapter_operation_duration_seconds",
 				Help:      "Adapter operation duration in seconds",
 				Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
-			},
+			}),
 			[]string{"adapter", "operation"},
 		),
 
@@ -180,12 +251,12 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 		),
 
 		WebhookDeliveryDuration: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "webhook_delivery_duration_seconds",
 				Help:      "Webhook delivery latency in seconds",
 				Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
-			},
+			}),
 			[]string{"status"},
 		),
 
@@ -209,12 +280,12 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 		),
 
 		RedisOperationDuration: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "redis_operation_duration_seconds",
 				Help:      "Redis operation duration in seconds",
 				Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25},
-			},
+			}),
 			[]string{"operation"},
 		),
 
@@ -246,12 +317,12 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 		),
 
 		K8sOperationDuration: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "k8s_operation_duration_seconds",
 				Help:      "Kubernetes API operation duration in seconds",
 				Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
-			},
+			}),
 			[]string{"operation", "resource"},
 		),
 
@@ -284,12 +355,12 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 		),
 
 		BatchOperationDuration: factory.NewHistogramVec(
-			prometheus.HistogramOpts{
+			options.histogram(prometheus.HistogramOpts{
 				Namespace: namespace,
 				Name:      "batch_operation_duration_seconds",
 				Help:      "Batch operation duration in seconds",
 				Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60},
-			},
+			}),
 			[]string{"operation"},
 		),
 
@@ -318,6 +389,35 @@ func NewMetrics(namespace string, registerer prometheus.Registerer) *Metrics {
 				Help:      "Number of concurrent workers processing batch items",
 			},
 		),
+
+		// Health check metrics
+		HealthCheckDuration: factory.NewHistogramVec(
+			options.histogram(prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "health_check_duration_seconds",
+				Help:      "Health and readiness check duration in seconds",
+				Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+			}),
+			[]string{"probe", "check"},
+		),
+
+		HealthCheckUp: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "health_check_up",
+				Help:      "Whether the last health or readiness check passed (1) or failed (0)",
+			},
+			[]string{"probe", "check", "criticality"},
+		),
+
+		ReadinessStatus: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "readiness_status",
+				Help:      "Current readiness status (1 for the current status: healthy, degraded or unhealthy)",
+			},
+			[]string{"status"},
+		),
 	}
 }
 
@@ -329,14 +429,55 @@ func GetMetrics() *Metrics {
 	return GlobalMetrics
 }
 
-// RecordHTTPRequest records HTTP request metrics.
+// RecordHTTPRequest records HTTP request metrics of a request to no API
+// version. The path should be a route template (see RouteLabel) so that the
+// label values stay bounded. The status is recorded both as the code and by
+// class (see StatusClass).
 func (m *Metrics) RecordHTTPRequest(method, path string, statusCode int, duration time.Duration, responseSize int) {
-	status := strconv.Itoa(statusCode)
-	m.HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
-	m.HTTPRequestDuration.WithLabelValues(method, path, status).Observe(duration.Seconds())
+	m.RecordHTTPRequestContext(context.Background(), method, path, NoAPIVersion, statusCode, duration, responseSize)
+}
+
+// RecordHTTPRequestContext records HTTP request metrics like RecordHTTPRequest,
+// labelled with the API version of the request.
+// With exemplars enabled, the duration observation carries the ID of the
+// sampled trace of ctx as an exemplar.
+func (m *Metrics) RecordHTTPRequestContext(
+	ctx context.Context,
+	method, path, apiVersion string,
+	statusCode int,
+	duration time.Duration,
+	responseSize int,
+) {
+	status, class := strconv.Itoa(statusCode), StatusClass(statusCode)
+	m.HTTPRequestsTotal.WithLabelValues(method, path, status, class, apiVersion).Inc()
+	observer := m.HTTPRequestDuration.WithLabelValues(method, path, status, class, apiVersion)
+	if exemplar := m.exemplar(ctx); exemplar != nil {
+		observer.(prometheus.ExemplarObserver).ObserveWithExemplar(duration.Seconds(), exemplar)
+	} else {
+		observer.Observe(duration.Seconds())
+	}
 	m.HTTPResponseSizeBytes.WithLabelValues(method, path).Observe(float64(responseSize))
 }
 
+// exemplar returns the exemplar labels for the trace of ctx, or nil if
+// exemplars are disabled or ctx carries no sampled trace.
+func (m *Metrics) exemplar(ctx context.Context) prometheus.Labels {
+	if !m.exemplars {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() || !spanContext.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{ExemplarTraceIDLabel: spanContext.TraceID().String()}
+}
+
+// Exemplars reports whether HTTP duration observations carry trace ID
+// exemplars.
+func (m *Metrics) Exemplars() bool {
+	return m.exemplars
+}
+
 // RecordAdapterOperation records adapter operation metrics.
 func (m *Metrics) RecordAdapterOperation(adapter, operation string, duration time.Duration, err error) {
 	status := statusSuccess
@@ -445,6 +586,28 @@ func (m *Metrics) RecordBatchRollback(operation, reason string, count int) {
 	m.BatchRollbacksTotal.WithLabelValues(operation, reason).Add(float64(count))
 }
 
+// RecordHealthCheck records the latency and outcome of a health (probe
+// "health") or readiness (probe "readiness") check.
+func (m *Metrics) RecordHealthCheck(probe, check string, criticality Criticality, result ComponentHealth) {
+	m.HealthCheckDuration.WithLabelValues(probe, check).Observe(result.LatencyMs / 1000)
+	up := 0.0
+	if result.Status == StatusHealthy {
+		up = 1
+	}
+	m.HealthCheckUp.WithLabelValues(probe, check, string(criticality)).Set(up)
+}
+
+// SetReadinessStatus sets the current readiness status.
+func (m *Metrics) SetReadinessStatus(status HealthStatus) {
+	for _, s := range []HealthStatus{StatusHealthy, StatusDegraded, StatusUnhealthy} {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+		m.ReadinessStatus.WithLabelValues(string(s)).Set(value)
+	}
+}
+
 // SetBatchConcurrentWorkers sets the current number of concurrent batch workers.
 func (m *Metrics) SetBatchConcurrentWorkers(count int) {
 	m.BatchConcurrentWorkers.Set(float64(count))
@@ -75,7 +75,7 @@ func TestRecordHTTPRequest(t *testing.T) {
 				Name:      "http_requests_total",
 				Help:      "Total number of HTTP requests",
 			},
-			[]string{"method", "path", "status"},
+			[]string{"method", "path", "status", "status_class", "api_version"},
 		),
 		HTTPRequestDuration: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -84,7 +84,7 @@ func TestRecordHTTPRequest(t *testing.T) {
 				Help:      "HTTP request duration in seconds",
 				Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
 			},
-			[]string{"method", "path", "status"},
+			[]string{"method", "path", "status", "status_class", "api_version"},
 		),
 		HTTPResponseSizeBytes: prometheus.NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -105,7 +105,7 @@ func TestRecordHTTPRequest(t *testing.T) {
 	m.RecordHTTPRequest("GET", "/api/v1/subscriptions", 200, 50*time.Millisecond, 1024)
 
 	// Verify counter incremented
-	count := testutil.ToFloat64(m.HTTPRequestsTotal.WithLabelValues("GET", "/api/v1/subscriptions", "200"))
+	count := testutil.ToFloat64(m.HTTPRequestsTotal.WithLabelValues("GET", "/api/v1/subscriptions", "200", "2xx", "none"))
 	assert.Equal(t, float64(1), count)
 }
 
@@ -0,0 +1,120 @@
+// Package problem renders error responses as RFC 7807 problem details
+// (application/problem+json), so that every O2-IMS, O2-DMS and tenant
+// administration endpoint reports failures in the same machine-parseable
+// shape:
+//
+//	{
+//	  "type": "urn:netweave:problem:NotFound",
+//	  "title": "Not Found",
+//	  "status": 404,
+//	  "detail": "Resource pool not found: pool-1",
+//	  "instance": "/o2ims-infrastructureInventory/v1/resourcePools/pool-1",
+//	  "additionalAttributes": {"requestId": "5f0c..."},
+//	  "error": "NotFound",
+//	  "message": "Resource pool not found: pool-1",
+//	  "code": 404
+//	}
+//
+// additionalAttributes is the extension member of the O2 ProblemDetails
+// schema. The error, message and code members repeat the problem code,
+// detail and status under the names of the earlier error body, so existing
+// clients keep working.
+//
+// Handlers call Respond, or Abort in middleware; errors returned by adapters
+// and stores are first translated to a status and problem code by the
+// httperror package.
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+// ContentType is the media type of problem details responses.
+const ContentType = "application/problem+json"
+
+// TypePrefix prefixes the problem code to form the type URI.
+const TypePrefix = "urn:netweave:problem:"
+
+// Details is an RFC 7807 problem details document.
+type Details struct {
+	// Type is a URI identifying the problem code.
+	Type string `json:"type"`
+
+	// Title is the standard text of the HTTP status.
+	Title string `json:"title"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+
+	// Detail explains this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is the path of the request that failed.
+	Instance string `json:"instance,omitempty"`
+
+	// AdditionalAttributes carries O2 extension fields such as the request
+	// ID and problem-specific context.
+	AdditionalAttributes map[string]interface{} `json:"additionalAttributes,omitempty"`
+
+	// Error is the problem code, kept for clients of the earlier error body.
+	Error string `json:"error"`
+
+	// Message repeats Detail, kept for clients of the earlier error body.
+	Message string `json:"message"`
+
+	// Code repeats Status, kept for clients of the earlier error body.
+	Code int `json:"code"`
+}
+
+// New returns the problem details for a problem code such as "NotFound".
+func New(status int, code, detail string) *Details {
+	return &Details{
+		Type:    TypePrefix + code,
+		Title:   http.StatusText(status),
+		Status:  status,
+		Detail:  detail,
+		Error:   code,
+		Message: detail,
+		Code:    status,
+	}
+}
+
+// With adds an additional attribute and returns d.
+func (d *Details) With(key string, value interface{}) *Details {
+	if d.AdditionalAttributes == nil {
+		d.AdditionalAttributes = make(map[string]interface{})
+	}
+	d.AdditionalAttributes[key] = value
+	return d
+}
+
+// Write sends d as the response, filling in the request path as the
+// instance and the request ID as an additional attribute.
+func Write(c *gin.Context, d *Details) {
+	if d.Instance == "" && c.Request != nil {
+		d.Instance = c.Request.URL.Path
+	}
+	if c.Request != nil {
+		if requestID := requestcontext.RequestID(c.Request.Context()); requestID != "" {
+			d.With("requestId", requestID)
+		}
+	}
+	c.Header("Content-Type", ContentType)
+	c.JSON(d.Status, d)
+}
+
+// Respond sends the problem details for a problem code.
+func Respond(c *gin.Context, status int, code, detail string) {
+	Write(c, New(status, code, detail))
+}
+
+// Abort stops the handler chain and sends the problem details for a
+// problem code.
+func Abort(c *gin.Context, status int, code, detail string) {
+	c.Abort()
+	Respond(c, status, code, detail)
+}
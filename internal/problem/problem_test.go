@@ -0,0 +1,66 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+func TestNew(t *testing.T) {
+	d := problem.New(http.StatusNotFound, "NotFound", "Resource pool not found: pool-1")
+
+	assert.Equal(t, &problem.Details{
+		Type:    "urn:netweave:problem:NotFound",
+		Title:   "Not Found",
+		Status:  http.StatusNotFound,
+		Detail:  "Resource pool not found: pool-1",
+		Error:   "NotFound",
+		Message: "Resource pool not found: pool-1",
+		Code:    http.StatusNotFound,
+	}, d)
+
+	d.With("namespace", "kube-system")
+	assert.Equal(t, map[string]interface{}{"namespace": "kube-system"}, d.AdditionalAttributes)
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(requestcontext.WithRequestID(c.Request.Context(), "req-1"))
+	})
+	router.GET("/pools/:id", func(c *gin.Context) {
+		problem.Abort(c, http.StatusForbidden, "Forbidden", "Access denied")
+	}, func(c *gin.Context) {
+		t.Error("handler chain was not aborted")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pools/pool-1", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, map[string]interface{}{
+		"type":                 "urn:netweave:problem:Forbidden",
+		"title":                "Forbidden",
+		"status":               float64(http.StatusForbidden),
+		"detail":               "Access denied",
+		"instance":             "/pools/pool-1",
+		"additionalAttributes": map[string]interface{}{"requestId": "req-1"},
+		"error":                "Forbidden",
+		"message":              "Access denied",
+		"code":                 float64(http.StatusForbidden),
+	}, got)
+}
@@ -0,0 +1,280 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// CAPIName is the name of the Cluster API provisioner.
+const CAPIName = "capi"
+
+// CAPIRequestLabel labels the Clusters created for provisioning requests with
+// the request ID.
+const CAPIRequestLabel = "netweave.io/provisioning-request-id"
+
+// Template parameters of the Cluster API provisioner.
+const (
+	capiControlPlaneReplicas = "controlPlaneReplicas"
+	capiWorkerClass          = "workerClass"
+	capiWorkerReplicas       = "workerReplicas"
+	capiVariables            = "variables"
+
+	defaultWorkerClass = "default-worker"
+)
+
+// ClusterGVR is the GroupVersionResource of Cluster API Clusters.
+var ClusterGVR = schema.GroupVersionResource{
+	Group:    "cluster.x-k8s.io",
+	Version:  "v1beta1",
+	Resource: "clusters",
+}
+
+// CAPIProvisioner provisions clusters with Cluster API. Each request becomes
+// a topology-based Cluster named after the request, whose ClusterClass is the
+// request's template name and whose Kubernetes version is its template
+// version. Supported template parameters are controlPlaneReplicas,
+// workerClass, workerReplicas and variables (ClusterClass variable values by
+// name).
+type CAPIProvisioner struct {
+	client    dynamic.Interface
+	namespace string
+}
+
+// NewCAPIProvisioner creates a Cluster API provisioner creating Clusters in
+// namespace through client.
+func NewCAPIProvisioner(client dynamic.Interface, namespace string) *CAPIProvisioner {
+	return &CAPIProvisioner{client: client, namespace: namespace}
+}
+
+// NewCAPIProvisionerFromKubeconfig creates a Cluster API provisioner for the
+// management cluster of kubeconfig, or the in-cluster configuration when
+// kubeconfig is empty.
+func NewCAPIProvisionerFromKubeconfig(kubeconfig, namespace string) (*CAPIProvisioner, error) {
+	var (
+		restConfig *rest.Config
+		err        error
+	)
+	if kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build config from kubeconfig: %w", err)
+		}
+	} else {
+		restConfig, err = rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get in-cluster config: %w", err)
+		}
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return NewCAPIProvisioner(client, namespace), nil
+}
+
+// Name returns CAPIName.
+func (p *CAPIProvisioner) Name() string {
+	return CAPIName
+}
+
+// Validate checks that the request name is a valid Cluster name and that the
+// template parameters are well formed.
+func (p *CAPIProvisioner) Validate(req *Request) error {
+	if errs := validation.IsDNS1123Label(req.Name); len(errs) > 0 {
+		return fmt.Errorf("name %q is not a valid cluster name: %s", req.Name, strings.Join(errs, "; "))
+	}
+	if req.TemplateVersion == "" {
+		return fmt.Errorf("templateVersion (the Kubernetes version) is required")
+	}
+	for _, key := range []string{capiControlPlaneReplicas, capiWorkerReplicas} {
+		if _, err := replicasParameter(req.TemplateParameters, key); err != nil {
+			return err
+		}
+	}
+	if v, ok := req.TemplateParameters[capiWorkerClass]; ok {
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("templateParameters.%s must be a string", capiWorkerClass)
+		}
+	}
+	if v, ok := req.TemplateParameters[capiVariables]; ok {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return fmt.Errorf("templateParameters.%s must be an object", capiVariables)
+		}
+	}
+	return nil
+}
+
+// Provision creates the Cluster of the request, or updates its topology if
+// it already exists.
+func (p *CAPIProvisioner) Provision(ctx context.Context, req *Request) error {
+	clusters := p.client.Resource(ClusterGVR).Namespace(p.namespace)
+	topology := clusterTopology(req)
+
+	existing, err := clusters.Get(ctx, req.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		cluster := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": ClusterGVR.GroupVersion().String(),
+			"kind":       "Cluster",
+			"metadata": map[string]interface{}{
+				"name":      req.Name,
+				"namespace": p.namespace,
+				"labels":    map[string]interface{}{CAPIRequestLabel: req.ProvisioningRequestID},
+			},
+			"spec": map[string]interface{}{"topology": topology},
+		}}
+		if _, err := clusters.Create(ctx, cluster, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create cluster: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	if owner := existing.GetLabels()[CAPIRequestLabel]; owner != req.ProvisioningRequestID {
+		return fmt.Errorf("cluster %s/%s already exists and does not belong to this request", p.namespace, req.Name)
+	}
+	if err := unstructured.SetNestedMap(existing.Object, topology, "spec", "topology"); err != nil {
+		return fmt.Errorf("failed to set cluster topology: %w", err)
+	}
+	if _, err := clusters.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update cluster: %w", err)
+	}
+	return nil
+}
+
+// Observe maps the phase and Ready condition of the Cluster to a provisioning phase.
+func (p *CAPIProvisioner) Observe(ctx context.Context, req *Request) (*Observation, error) {
+	cluster, err := p.getCluster(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	phase, _, _ := unstructured.NestedString(cluster.Object, "status", "phase")
+	if cluster.GetDeletionTimestamp() != nil {
+		return &Observation{Phase: PhaseDeleting, Message: "Cluster is being deleted"}, nil
+	}
+	if phase == "Failed" {
+		message, _, _ := unstructured.NestedString(cluster.Object, "status", "failureMessage")
+		return &Observation{Phase: PhaseFailed, Message: "Cluster provisioning failed: " + message}, nil
+	}
+	if phase == "Provisioned" && clusterReady(cluster) {
+		return &Observation{
+			Phase:     PhaseFulfilled,
+			Message:   "Cluster provisioned",
+			Resources: &ProvisionedResources{NodeClusterID: p.namespace + "/" + cluster.GetName()},
+		}, nil
+	}
+	if phase == "" {
+		phase = "Pending"
+	}
+	return &Observation{Phase: PhaseProgressing, Message: "Cluster phase: " + phase}, nil
+}
+
+// Deprovision deletes the Cluster of the request.
+func (p *CAPIProvisioner) Deprovision(ctx context.Context, req *Request) error {
+	if _, err := p.getCluster(ctx, req); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	err := p.client.Resource(ClusterGVR).Namespace(p.namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete cluster: %w", err)
+	}
+	return nil
+}
+
+// getCluster returns the Cluster of the request. A Cluster of the same name
+// created for another request is reported as not found.
+func (p *CAPIProvisioner) getCluster(ctx context.Context, req *Request) (*unstructured.Unstructured, error) {
+	cluster, err := p.client.Resource(ClusterGVR).Namespace(p.namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: cluster %s/%s", ErrNotFound, p.namespace, req.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	if cluster.GetLabels()[CAPIRequestLabel] != req.ProvisioningRequestID {
+		return nil, fmt.Errorf("%w: cluster %s/%s", ErrNotFound, p.namespace, req.Name)
+	}
+	return cluster, nil
+}
+
+// clusterReady reports whether the Ready condition of a Cluster is true.
+func clusterReady(cluster *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(cluster.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" {
+			return condition["status"] == "True"
+		}
+	}
+	return false
+}
+
+// clusterTopology builds the spec.topology of the Cluster of a request.
+func clusterTopology(req *Request) map[string]interface{} {
+	topology := map[string]interface{}{
+		"class":   req.TemplateName,
+		"version": req.TemplateVersion,
+	}
+
+	if replicas, _ := replicasParameter(req.TemplateParameters, capiControlPlaneReplicas); replicas > 0 {
+		topology["controlPlane"] = map[string]interface{}{"replicas": replicas}
+	}
+
+	if replicas, _ := replicasParameter(req.TemplateParameters, capiWorkerReplicas); replicas > 0 {
+		class, _ := req.TemplateParameters[capiWorkerClass].(string)
+		if class == "" {
+			class = defaultWorkerClass
+		}
+		topology["workers"] = map[string]interface{}{
+			"machineDeployments": []interface{}{
+				map[string]interface{}{"class": class, "name": "md-0", "replicas": replicas},
+			},
+		}
+	}
+
+	if values, ok := req.TemplateParameters[capiVariables].(map[string]interface{}); ok && len(values) > 0 {
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		variables := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			variables = append(variables, map[string]interface{}{"name": name, "value": values[name]})
+		}
+		topology["variables"] = variables
+	}
+	return topology
+}
+
+// replicasParameter returns a replica count template parameter, or 0 if it is
+// not set. JSON numbers decode as float64.
+func replicasParameter(params map[string]interface{}, key string) (int64, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := v.(float64)
+	if !ok || n < 0 || n != float64(int64(n)) {
+		return 0, fmt.Errorf("templateParameters.%s must be a non-negative integer", key)
+	}
+	return int64(n), nil
+}
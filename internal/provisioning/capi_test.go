@@ -0,0 +1,146 @@
+package provisioning_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/piwi3910/netweave/internal/provisioning"
+)
+
+func newFakeClusterClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{provisioning.ClusterGVR: "ClusterList"})
+}
+
+func capiRequest() *provisioning.Request {
+	return &provisioning.Request{
+		ProvisioningRequestID: "6f0b2d34-7f3c-4d7e-9d1a-0a5c2f0e8b11",
+		Name:                  "edge-1",
+		TemplateName:          "edge-class",
+		TemplateVersion:       "v1.30.2",
+		TemplateParameters: map[string]interface{}{
+			"controlPlaneReplicas": float64(3),
+			"workerReplicas":       float64(2),
+			"variables":            map[string]interface{}{"region": "eu-west", "flavor": "m1"},
+		},
+	}
+}
+
+func TestCAPIProvisioner_Validate(t *testing.T) {
+	p := provisioning.NewCAPIProvisioner(newFakeClusterClient(), "clusters")
+
+	require.NoError(t, p.Validate(capiRequest()))
+
+	tests := []struct {
+		name   string
+		mutate func(r *provisioning.Request)
+	}{
+		{name: "invalid cluster name", mutate: func(r *provisioning.Request) { r.Name = "Edge_1" }},
+		{name: "missing version", mutate: func(r *provisioning.Request) { r.TemplateVersion = "" }},
+		{name: "fractional replicas", mutate: func(r *provisioning.Request) {
+			r.TemplateParameters["workerReplicas"] = 1.5
+		}},
+		{name: "negative replicas", mutate: func(r *provisioning.Request) {
+			r.TemplateParameters["controlPlaneReplicas"] = float64(-1)
+		}},
+		{name: "worker class not a string", mutate: func(r *provisioning.Request) {
+			r.TemplateParameters["workerClass"] = float64(1)
+		}},
+		{name: "variables not an object", mutate: func(r *provisioning.Request) {
+			r.TemplateParameters["variables"] = "region=eu-west"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := capiRequest()
+			tt.mutate(req)
+			assert.Error(t, p.Validate(req))
+		})
+	}
+}
+
+func TestCAPIProvisioner_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClusterClient()
+	p := provisioning.NewCAPIProvisioner(client, "clusters")
+	req := capiRequest()
+	clusters := client.Resource(provisioning.ClusterGVR).Namespace("clusters")
+
+	_, err := p.Observe(ctx, req)
+	require.ErrorIs(t, err, provisioning.ErrNotFound)
+
+	require.NoError(t, p.Provision(ctx, req))
+
+	cluster, err := clusters.Get(ctx, "edge-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, req.ProvisioningRequestID, cluster.GetLabels()[provisioning.CAPIRequestLabel])
+	class, _, _ := unstructured.NestedString(cluster.Object, "spec", "topology", "class")
+	assert.Equal(t, "edge-class", class)
+	replicas, _, _ := unstructured.NestedInt64(cluster.Object, "spec", "topology", "controlPlane", "replicas")
+	assert.Equal(t, int64(3), replicas)
+	variables, _, _ := unstructured.NestedSlice(cluster.Object, "spec", "topology", "variables")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "flavor", "value": "m1"},
+		map[string]interface{}{"name": "region", "value": "eu-west"},
+	}, variables)
+
+	obs, err := p.Observe(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.PhaseProgressing, obs.Phase)
+
+	// Provisioning an existing Cluster of the request updates its topology.
+	req.TemplateVersion = "v1.31.0"
+	require.NoError(t, p.Provision(ctx, req))
+
+	cluster, err = clusters.Get(ctx, "edge-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	version, _, _ := unstructured.NestedString(cluster.Object, "spec", "topology", "version")
+	assert.Equal(t, "v1.31.0", version)
+
+	require.NoError(t, unstructured.SetNestedField(cluster.Object, "Provisioned", "status", "phase"))
+	require.NoError(t, unstructured.SetNestedSlice(cluster.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	_, err = clusters.Update(ctx, cluster, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	obs, err = p.Observe(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.PhaseFulfilled, obs.Phase)
+	require.NotNil(t, obs.Resources)
+	assert.Equal(t, "clusters/edge-1", obs.Resources.NodeClusterID)
+
+	require.NoError(t, p.Deprovision(ctx, req))
+	_, err = p.Observe(ctx, req)
+	require.ErrorIs(t, err, provisioning.ErrNotFound)
+	require.NoError(t, p.Deprovision(ctx, req))
+}
+
+func TestCAPIProvisioner_ForeignCluster(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClusterClient()
+	p := provisioning.NewCAPIProvisioner(client, "clusters")
+
+	other := capiRequest()
+	other.ProvisioningRequestID = "0e6a4c1f-2b8d-4a53-9c7e-5d3f1b2a4c68"
+	require.NoError(t, p.Provision(ctx, other))
+
+	req := capiRequest()
+	require.Error(t, p.Provision(ctx, req))
+
+	_, err := p.Observe(ctx, req)
+	require.ErrorIs(t, err, provisioning.ErrNotFound)
+
+	// Deprovisioning must leave the other request's Cluster alone.
+	require.NoError(t, p.Deprovision(ctx, req))
+	_, err = p.Observe(ctx, other)
+	require.NoError(t, err)
+}
@@ -0,0 +1,135 @@
+package provisioning
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// Handler serves the O2-IMS infrastructureProvisioning API.
+type Handler struct {
+	manager *Manager
+	logger  *zap.Logger
+}
+
+// NewHandler creates a handler serving the requests of manager.
+func NewHandler(manager *Manager, logger *zap.Logger) *Handler {
+	return &Handler{manager: manager, logger: logger}
+}
+
+// ListProvisioningRequests lists all provisioning requests, oldest first.
+// GET /o2ims-infrastructureProvisioning/v1/provisioningRequests.
+func (h *Handler) ListProvisioningRequests(c *gin.Context) {
+	requests, err := h.manager.List(c.Request.Context())
+	if err != nil {
+		h.errorResponse(c, err, "Failed to list provisioning requests")
+		return
+	}
+	c.JSON(http.StatusOK, models.ListResponse{Items: requests, TotalCount: len(requests)})
+}
+
+// CreateProvisioningRequest creates a provisioning request. Provisioning
+// starts in the background; the request is returned in the PENDING phase.
+// POST /o2ims-infrastructureProvisioning/v1/provisioningRequests.
+func (h *Handler) CreateProvisioningRequest(c *gin.Context) {
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	created, err := h.manager.Create(c.Request.Context(), &req)
+	if err != nil {
+		h.errorResponse(c, err, "Failed to create provisioning request")
+		return
+	}
+
+	h.logger.Info("provisioning request created",
+		zap.String("provisioning_request_id", created.ProvisioningRequestID),
+		zap.String("provisioner", created.Provisioner),
+		zap.String("template", created.TemplateName))
+	c.Header("Location", c.FullPath()+"/"+created.ProvisioningRequestID)
+	c.JSON(http.StatusCreated, created)
+}
+
+// GetProvisioningRequest returns a provisioning request with its status.
+// GET /o2ims-infrastructureProvisioning/v1/provisioningRequests/:provisioningRequestId.
+func (h *Handler) GetProvisioningRequest(c *gin.Context) {
+	req, err := h.manager.Get(c.Request.Context(), c.Param("provisioningRequestId"))
+	if err != nil {
+		h.errorResponse(c, err, "Failed to get provisioning request")
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+// UpdateProvisioningRequest replaces the template of a FULFILLED or FAILED
+// provisioning request and provisions it again.
+// PUT /o2ims-infrastructureProvisioning/v1/provisioningRequests/:provisioningRequestId.
+func (h *Handler) UpdateProvisioningRequest(c *gin.Context) {
+	id := c.Param("provisioningRequestId")
+
+	var spec Request
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		h.badRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+	if spec.ProvisioningRequestID != "" && spec.ProvisioningRequestID != id {
+		h.badRequest(c, "provisioningRequestId does not match the URL")
+		return
+	}
+
+	updated, err := h.manager.Update(c.Request.Context(), id, &spec)
+	if err != nil {
+		h.errorResponse(c, err, "Failed to update provisioning request")
+		return
+	}
+
+	h.logger.Info("provisioning request updated", zap.String("provisioning_request_id", id))
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteProvisioningRequest starts deprovisioning a request. The request stays
+// in the DELETING phase until its infrastructure is torn down.
+// DELETE /o2ims-infrastructureProvisioning/v1/provisioningRequests/:provisioningRequestId.
+func (h *Handler) DeleteProvisioningRequest(c *gin.Context) {
+	id := c.Param("provisioningRequestId")
+
+	req, err := h.manager.Delete(c.Request.Context(), id)
+	if err != nil {
+		h.errorResponse(c, err, "Failed to delete provisioning request")
+		return
+	}
+
+	h.logger.Info("provisioning request deletion started", zap.String("provisioning_request_id", id))
+	c.JSON(http.StatusAccepted, req)
+}
+
+// badRequest sends a 400 Bad Request response.
+func (h *Handler) badRequest(c *gin.Context, message string) {
+	problem.Respond(c, http.StatusBadRequest, "BadRequest", message)
+}
+
+// errorResponse maps a Manager error to an error response. Unexpected errors
+// are logged and reported with the generic message.
+func (h *Handler) errorResponse(c *gin.Context, err error, message string) {
+	var status int
+	var code string
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status, code, message = http.StatusNotFound, "NotFound", err.Error()
+	case errors.Is(err, ErrAlreadyExists), errors.Is(err, ErrInvalidTransition):
+		status, code, message = http.StatusConflict, "Conflict", err.Error()
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrUnknownProvisioner):
+		status, code, message = http.StatusBadRequest, "BadRequest", err.Error()
+	default:
+		h.logger.Error(message, zap.Error(err))
+		status, code = http.StatusInternalServerError, "InternalError"
+	}
+	problem.Respond(c, status, code, message)
+}
@@ -0,0 +1,136 @@
+package provisioning_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/provisioning"
+)
+
+const provisioningRequestsPath = "/o2ims-infrastructureProvisioning/v1/provisioningRequests"
+
+func newTestRouter(mgr *provisioning.Manager) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := provisioning.NewHandler(mgr, zap.NewNop())
+	router := gin.New()
+	router.GET(provisioningRequestsPath, h.ListProvisioningRequests)
+	router.POST(provisioningRequestsPath, h.CreateProvisioningRequest)
+	router.GET(provisioningRequestsPath+"/:provisioningRequestId", h.GetProvisioningRequest)
+	router.PUT(provisioningRequestsPath+"/:provisioningRequestId", h.UpdateProvisioningRequest)
+	router.DELETE(provisioningRequestsPath+"/:provisioningRequestId", h.DeleteProvisioningRequest)
+	return router
+}
+
+func serve(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_ProvisioningRequests(t *testing.T) {
+	mgr, _ := newTestManager(t)
+	router := newTestRouter(mgr)
+
+	w := serve(router, http.MethodPost, provisioningRequestsPath,
+		map[string]interface{}{"name": "edge-1", "templateName": "small"})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created provisioning.Request
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, provisioning.PhasePending, created.Status.Phase)
+	assert.Equal(t, provisioningRequestsPath+"/"+created.ProvisioningRequestID, w.Header().Get("Location"))
+	path := provisioningRequestsPath + "/" + created.ProvisioningRequestID
+
+	w = serve(router, http.MethodGet, provisioningRequestsPath, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Items      []provisioning.Request `json:"items"`
+		TotalCount int                    `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 1, list.TotalCount)
+
+	// Not settled yet.
+	w = serve(router, http.MethodPut, path, map[string]interface{}{"templateName": "large"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	mgr.Reconcile(context.Background())
+	mgr.Reconcile(context.Background())
+
+	w = serve(router, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var got provisioning.Request
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, provisioning.PhaseFulfilled, got.Status.Phase)
+
+	w = serve(router, http.MethodPut, path, map[string]interface{}{"templateName": "large"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serve(router, http.MethodDelete, path, nil)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, provisioning.PhaseDeleting, got.Status.Phase)
+}
+
+func TestHandler_ProvisioningRequestErrors(t *testing.T) {
+	mgr, _ := newTestManager(t)
+	router := newTestRouter(mgr)
+	missing := provisioningRequestsPath + "/6f0b2d34-7f3c-4d7e-9d1a-0a5c2f0e8b11"
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		wantStatus int
+	}{
+		{
+			name:       "create without template",
+			method:     http.MethodPost,
+			path:       provisioningRequestsPath,
+			body:       map[string]interface{}{"name": "edge-1"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "create with unknown provisioner",
+			method:     http.MethodPost,
+			path:       provisioningRequestsPath,
+			body:       map[string]interface{}{"name": "edge-1", "templateName": "small", "provisioner": "metal3"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "get missing", method: http.MethodGet, path: missing, wantStatus: http.StatusNotFound},
+		{name: "delete missing", method: http.MethodDelete, path: missing, wantStatus: http.StatusNotFound},
+		{
+			name:       "update with mismatched ID",
+			method:     http.MethodPut,
+			path:       missing,
+			body:       map[string]interface{}{"provisioningRequestId": "other", "templateName": "small"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, tt.method, tt.path, tt.body)
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			var resp models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
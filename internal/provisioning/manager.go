@@ -0,0 +1,409 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// DefaultReconcileInterval is how often in-flight requests are advanced when
+// Config.ReconcileInterval is not set.
+const DefaultReconcileInterval = 10 * time.Second
+
+// transitionsTotal counts the phase changes of provisioning requests.
+var transitionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Subsystem: "provisioning",
+		Name:      "transitions_total",
+		Help:      "Total number of provisioning request phase changes by provisioner and phase",
+	},
+	[]string{"provisioner", "phase"},
+)
+
+// Config configures the Manager.
+type Config struct {
+	// DefaultProvisioner handles requests that do not name a provisioner.
+	// Defaults to SimulatorName.
+	DefaultProvisioner string
+
+	// ReconcileInterval is how often in-flight requests are advanced. Changes
+	// made through the Manager are picked up immediately.
+	ReconcileInterval time.Duration
+}
+
+// Manager validates provisioning requests, stores them and advances them
+// through their phases by driving their provisioners.
+type Manager struct {
+	store        Store
+	config       Config
+	provisioners map[string]Provisioner
+	notifier     Notifier
+	logger       *zap.Logger
+
+	// mu serializes the read-modify-write of requests. Provisioners are
+	// called without it; their outcome is discarded if the request changed
+	// phase in the meantime.
+	mu sync.Mutex
+
+	// wake triggers a reconciliation ahead of the next interval.
+	wake chan struct{}
+}
+
+// NewManager creates a Manager. Provisioners are added with Register.
+func NewManager(store Store, config Config, logger *zap.Logger) *Manager {
+	if config.DefaultProvisioner == "" {
+		config.DefaultProvisioner = SimulatorName
+	}
+	if config.ReconcileInterval <= 0 {
+		config.ReconcileInterval = DefaultReconcileInterval
+	}
+	return &Manager{
+		store:        store,
+		config:       config,
+		provisioners: make(map[string]Provisioner),
+		logger:       logger,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Register adds a provisioner. It must be called before Start.
+func (m *Manager) Register(p Provisioner) {
+	m.provisioners[p.Name()] = p
+}
+
+// SetNotifier sets the notifier told about every phase change.
+func (m *Manager) SetNotifier(n Notifier) {
+	m.notifier = n
+}
+
+// Provisioners returns the names of the registered provisioners.
+func (m *Manager) Provisioners() []string {
+	names := make([]string, 0, len(m.provisioners))
+	for name := range m.provisioners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Create validates and stores a new request in the PENDING phase. Clients may
+// choose the request ID, which must be a UUID; one is generated otherwise. The
+// request's status and creation time are set by the Manager.
+func (m *Manager) Create(ctx context.Context, req *Request) (*Request, error) {
+	if req.Provisioner == "" {
+		req.Provisioner = m.config.DefaultProvisioner
+	}
+	if err := m.validate(req); err != nil {
+		return nil, err
+	}
+	if req.ProvisioningRequestID == "" {
+		req.ProvisioningRequestID = uuid.New().String()
+	} else if _, err := uuid.Parse(req.ProvisioningRequestID); err != nil {
+		return nil, fmt.Errorf("%w: provisioningRequestId must be a UUID", ErrInvalidRequest)
+	}
+
+	now := timeutil.Now()
+	req.CreatedAt = now
+	req.Status = Status{Phase: PhasePending, Message: "Provisioning request accepted", UpdateTime: now}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.store.Create(ctx, req); err != nil {
+		return nil, err
+	}
+	transitionsTotal.WithLabelValues(req.Provisioner, string(PhasePending)).Inc()
+	m.notify(EventTypeStateChanged, req, "")
+	m.kick()
+	return req, nil
+}
+
+// Get returns a request by ID.
+func (m *Manager) Get(ctx context.Context, id string) (*Request, error) {
+	return m.store.Get(ctx, id)
+}
+
+// List returns all requests, oldest first.
+func (m *Manager) List(ctx context.Context) ([]*Request, error) {
+	return m.store.List(ctx)
+}
+
+// Update replaces the template of a settled (FULFILLED or FAILED) request and
+// provisions it again. The name and provisioner of a request cannot change.
+func (m *Manager) Update(ctx context.Context, id string, spec *Request) (*Request, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !current.Status.Phase.Settled() {
+		return nil, fmt.Errorf("%w: cannot update a request in phase %s", ErrInvalidTransition, current.Status.Phase)
+	}
+	if spec.Name != "" && spec.Name != current.Name {
+		return nil, fmt.Errorf("%w: name cannot be changed", ErrInvalidRequest)
+	}
+	if spec.Provisioner != "" && spec.Provisioner != current.Provisioner {
+		return nil, fmt.Errorf("%w: provisioner cannot be changed", ErrInvalidRequest)
+	}
+
+	updated := current.clone()
+	updated.Description = spec.Description
+	updated.TemplateName = spec.TemplateName
+	updated.TemplateVersion = spec.TemplateVersion
+	updated.TemplateParameters = spec.TemplateParameters
+	if err := m.validate(updated); err != nil {
+		return nil, err
+	}
+
+	previous := m.setPhase(updated, PhasePending, "Provisioning request updated")
+	if err := m.store.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	m.notify(EventTypeStateChanged, updated, previous)
+	m.kick()
+	return updated, nil
+}
+
+// Delete moves a request to the DELETING phase. The request is removed once
+// its provisioner has torn down the infrastructure.
+func (m *Manager) Delete(ctx context.Context, id string) (*Request, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, err := m.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status.Phase == PhaseDeleting {
+		return current, nil
+	}
+
+	previous := m.setPhase(current, PhaseDeleting, "Deprovisioning requested")
+	if err := m.store.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	m.notify(EventTypeStateChanged, current, previous)
+	m.kick()
+	return current, nil
+}
+
+// Start reconciles requests at the configured interval, and whenever a
+// request is created, updated or deleted, until ctx is canceled.
+func (m *Manager) Start(ctx context.Context) {
+	m.logger.Info("starting provisioning manager",
+		zap.Strings("provisioners", m.Provisioners()),
+		zap.Duration("interval", m.config.ReconcileInterval))
+	go func() {
+		ticker := time.NewTicker(m.config.ReconcileInterval)
+		defer ticker.Stop()
+		for {
+			m.Reconcile(ctx)
+
+			select {
+			case <-ctx.Done():
+				m.logger.Info("provisioning manager stopped")
+				return
+			case <-ticker.C:
+			case <-m.wake:
+			}
+		}
+	}()
+}
+
+// Reconcile advances every in-flight request by one step.
+func (m *Manager) Reconcile(ctx context.Context) {
+	requests, err := m.store.List(ctx)
+	if err != nil {
+		m.logger.Error("failed to list provisioning requests", zap.Error(err))
+		return
+	}
+	for _, req := range requests {
+		if ctx.Err() != nil {
+			return
+		}
+		if !req.Status.Phase.Settled() {
+			m.step(ctx, req)
+		}
+	}
+}
+
+// step advances one request: PENDING requests are handed to their
+// provisioner, PROGRESSING requests are observed until they settle, and
+// DELETING requests are removed once their infrastructure is gone.
+func (m *Manager) step(ctx context.Context, req *Request) {
+	p, ok := m.provisioners[req.Provisioner]
+	if !ok {
+		if req.Status.Phase == PhaseDeleting {
+			// Nothing can tear the infrastructure down; forget the request.
+			m.remove(ctx, req)
+			return
+		}
+		m.transition(ctx, req, PhaseFailed, fmt.Sprintf("Provisioner %q is not available", req.Provisioner), nil)
+		return
+	}
+
+	logger := m.logger.With(
+		zap.String("provisioning_request_id", req.ProvisioningRequestID),
+		zap.String("provisioner", req.Provisioner))
+
+	switch req.Status.Phase {
+	case PhasePending:
+		if err := p.Provision(ctx, req); err != nil {
+			logger.Warn("provisioning failed", zap.Error(err))
+			m.transition(ctx, req, PhaseFailed, "Provisioning failed: "+err.Error(), nil)
+			return
+		}
+		m.transition(ctx, req, PhaseProgressing, "Provisioning started", nil)
+
+	case PhaseProgressing:
+		obs, err := p.Observe(ctx, req)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			m.transition(ctx, req, PhaseFailed, "Provisioned infrastructure no longer exists", nil)
+		case err != nil:
+			// Retried on the next reconciliation.
+			logger.Warn("failed to observe provisioning progress", zap.Error(err))
+		case obs.Phase == PhaseDeleting:
+			// Torn down outside the API.
+			m.transition(ctx, req, PhaseFailed, obs.Message, nil)
+		default:
+			m.transition(ctx, req, obs.Phase, obs.Message, obs.Resources)
+		}
+
+	case PhaseDeleting:
+		if err := p.Deprovision(ctx, req); err != nil {
+			logger.Warn("deprovisioning failed", zap.Error(err))
+			m.transition(ctx, req, PhaseDeleting, "Deprovisioning failed: "+err.Error(), nil)
+			return
+		}
+		if _, err := p.Observe(ctx, req); errors.Is(err, ErrNotFound) {
+			m.remove(ctx, req)
+		}
+
+	case PhaseFulfilled, PhaseFailed:
+	}
+}
+
+// transition moves a request observed in snapshot's phase to a new phase,
+// unless the request changed phase since, e.g. because it was deleted.
+func (m *Manager) transition(
+	ctx context.Context,
+	snapshot *Request,
+	phase Phase,
+	message string,
+	resources *ProvisionedResources,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, err := m.store.Get(ctx, snapshot.ProvisioningRequestID)
+	if err != nil || current.Status.Phase != snapshot.Status.Phase {
+		return
+	}
+	if !CanTransition(current.Status.Phase, phase) {
+		m.logger.Warn("ignoring invalid provisioning phase transition",
+			zap.String("provisioning_request_id", current.ProvisioningRequestID),
+			zap.String("from", string(current.Status.Phase)),
+			zap.String("to", string(phase)))
+		return
+	}
+	if phase == current.Status.Phase && message == current.Status.Message && resources == nil {
+		return
+	}
+
+	if resources != nil {
+		current.Status.ProvisionedResources = resources
+	}
+	previous := m.setPhase(current, phase, message)
+	if err := m.store.Update(ctx, current); err != nil {
+		m.logger.Error("failed to update provisioning request",
+			zap.String("provisioning_request_id", current.ProvisioningRequestID), zap.Error(err))
+		return
+	}
+	if previous != phase {
+		m.notify(EventTypeStateChanged, current, previous)
+	}
+}
+
+// remove deletes a request whose infrastructure is gone.
+func (m *Manager) remove(ctx context.Context, snapshot *Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, err := m.store.Get(ctx, snapshot.ProvisioningRequestID)
+	if err != nil || current.Status.Phase != PhaseDeleting {
+		return
+	}
+	if err := m.store.Delete(ctx, current.ProvisioningRequestID); err != nil {
+		m.logger.Error("failed to delete provisioning request",
+			zap.String("provisioning_request_id", current.ProvisioningRequestID), zap.Error(err))
+		return
+	}
+	m.logger.Info("provisioning request deleted",
+		zap.String("provisioning_request_id", current.ProvisioningRequestID))
+	m.notify(EventTypeDeleted, current, PhaseDeleting)
+}
+
+// setPhase sets the phase and message of a request and returns its previous phase.
+func (m *Manager) setPhase(req *Request, phase Phase, message string) Phase {
+	previous := req.Status.Phase
+	req.Status.Phase = phase
+	req.Status.Message = message
+	req.Status.UpdateTime = timeutil.Now()
+	if previous != phase {
+		transitionsTotal.WithLabelValues(req.Provisioner, string(phase)).Inc()
+	}
+	return previous
+}
+
+// validate checks the fields every request needs and lets its provisioner
+// check the rest.
+func (m *Manager) validate(req *Request) error {
+	if req.Name == "" || req.TemplateName == "" {
+		return fmt.Errorf("%w: name and templateName are required", ErrInvalidRequest)
+	}
+	p, ok := m.provisioners[req.Provisioner]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownProvisioner, req.Provisioner)
+	}
+	if err := p.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// notify tells the notifier about a phase change or deletion.
+func (m *Manager) notify(eventType EventType, req *Request, previous Phase) {
+	if m.notifier == nil {
+		return
+	}
+	m.notifier.Notify(&Notification{
+		NotificationID:        uuid.New().String(),
+		EventType:             eventType,
+		ProvisioningRequestID: req.ProvisioningRequestID,
+		PreviousPhase:         previous,
+		ProvisioningRequest:   req.clone(),
+		Timestamp:             timeutil.Now(),
+	})
+}
+
+// kick triggers a reconciliation without waiting for the next interval.
+func (m *Manager) kick() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
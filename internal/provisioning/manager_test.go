@@ -0,0 +1,168 @@
+package provisioning_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/provisioning"
+)
+
+// recordingNotifier keeps every notification it is given.
+type recordingNotifier struct {
+	mu            sync.Mutex
+	notifications []*provisioning.Notification
+}
+
+func (r *recordingNotifier) Notify(n *provisioning.Notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, n)
+}
+
+// phases returns the phase of the request reported by each notification.
+func (r *recordingNotifier) phases() []provisioning.Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	phases := make([]provisioning.Phase, 0, len(r.notifications))
+	for _, n := range r.notifications {
+		phases = append(phases, n.ProvisioningRequest.Status.Phase)
+	}
+	return phases
+}
+
+func newTestManager(t *testing.T) (*provisioning.Manager, *recordingNotifier) {
+	t.Helper()
+	mgr := provisioning.NewManager(provisioning.NewMemoryStore(), provisioning.Config{}, zap.NewNop())
+	mgr.Register(provisioning.NewSimulator(0))
+	notifier := &recordingNotifier{}
+	mgr.SetNotifier(notifier)
+	return mgr, notifier
+}
+
+func TestManager_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	mgr, notifier := newTestManager(t)
+
+	req, err := mgr.Create(ctx, &provisioning.Request{Name: "edge-1", TemplateName: "small"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, req.ProvisioningRequestID)
+	assert.Equal(t, provisioning.SimulatorName, req.Provisioner)
+	assert.Equal(t, provisioning.PhasePending, req.Status.Phase)
+
+	mgr.Reconcile(ctx)
+	mgr.Reconcile(ctx)
+
+	got, err := mgr.Get(ctx, req.ProvisioningRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.PhaseFulfilled, got.Status.Phase)
+	require.NotNil(t, got.Status.ProvisionedResources)
+	assert.Equal(t, "simulated-"+req.ProvisioningRequestID, got.Status.ProvisionedResources.NodeClusterID)
+
+	// Updates are only accepted once the request settled.
+	updated, err := mgr.Update(ctx, req.ProvisioningRequestID,
+		&provisioning.Request{TemplateName: "large", TemplateVersion: "v2"})
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.PhasePending, updated.Status.Phase)
+	assert.Equal(t, "large", updated.TemplateName)
+	_, err = mgr.Update(ctx, req.ProvisioningRequestID, &provisioning.Request{TemplateName: "large"})
+	require.ErrorIs(t, err, provisioning.ErrInvalidTransition)
+
+	mgr.Reconcile(ctx)
+	mgr.Reconcile(ctx)
+
+	deleting, err := mgr.Delete(ctx, req.ProvisioningRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.PhaseDeleting, deleting.Status.Phase)
+
+	mgr.Reconcile(ctx)
+	_, err = mgr.Get(ctx, req.ProvisioningRequestID)
+	require.ErrorIs(t, err, provisioning.ErrNotFound)
+
+	assert.Equal(t, []provisioning.Phase{
+		provisioning.PhasePending, provisioning.PhaseProgressing, provisioning.PhaseFulfilled,
+		provisioning.PhasePending, provisioning.PhaseProgressing, provisioning.PhaseFulfilled,
+		provisioning.PhaseDeleting, provisioning.PhaseDeleting,
+	}, notifier.phases())
+	assert.Equal(t, provisioning.EventTypeDeleted, notifier.notifications[len(notifier.notifications)-1].EventType)
+}
+
+func TestManager_SimulatedFailure(t *testing.T) {
+	ctx := context.Background()
+	mgr, _ := newTestManager(t)
+
+	req, err := mgr.Create(ctx, &provisioning.Request{
+		Name:               "edge-1",
+		TemplateName:       "small",
+		TemplateParameters: map[string]interface{}{"simulateFailure": true},
+	})
+	require.NoError(t, err)
+
+	mgr.Reconcile(ctx)
+	mgr.Reconcile(ctx)
+
+	got, err := mgr.Get(ctx, req.ProvisioningRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, provisioning.PhaseFailed, got.Status.Phase)
+	assert.Equal(t, "Simulated provisioning failure", got.Status.Message)
+}
+
+func TestManager_Create_Validation(t *testing.T) {
+	ctx := context.Background()
+	mgr, _ := newTestManager(t)
+
+	tests := []struct {
+		name    string
+		req     *provisioning.Request
+		wantErr error
+	}{
+		{
+			name:    "missing name",
+			req:     &provisioning.Request{TemplateName: "small"},
+			wantErr: provisioning.ErrInvalidRequest,
+		},
+		{
+			name:    "missing template",
+			req:     &provisioning.Request{Name: "edge-1"},
+			wantErr: provisioning.ErrInvalidRequest,
+		},
+		{
+			name:    "unknown provisioner",
+			req:     &provisioning.Request{Name: "edge-1", TemplateName: "small", Provisioner: "metal3"},
+			wantErr: provisioning.ErrUnknownProvisioner,
+		},
+		{
+			name:    "ID is not a UUID",
+			req:     &provisioning.Request{ProvisioningRequestID: "edge-1", Name: "edge-1", TemplateName: "small"},
+			wantErr: provisioning.ErrInvalidRequest,
+		},
+		{
+			name: "provisioner rejects parameters",
+			req: &provisioning.Request{
+				Name:               "edge-1",
+				TemplateName:       "small",
+				TemplateParameters: map[string]interface{}{"simulateFailure": "yes"},
+			},
+			wantErr: provisioning.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mgr.Create(ctx, tt.req)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("duplicate ID", func(t *testing.T) {
+		id := "6f0b2d34-7f3c-4d7e-9d1a-0a5c2f0e8b11"
+		_, err := mgr.Create(ctx, &provisioning.Request{ProvisioningRequestID: id, Name: "a", TemplateName: "small"})
+		require.NoError(t, err)
+		_, err = mgr.Create(ctx, &provisioning.Request{ProvisioningRequestID: id, Name: "b", TemplateName: "small"})
+		require.ErrorIs(t, err, provisioning.ErrAlreadyExists)
+	})
+}
@@ -0,0 +1,240 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
+	"github.com/piwi3910/netweave/internal/workers"
+)
+
+// EventType is the type of a provisioning notification.
+type EventType string
+
+const (
+	// EventTypeStateChanged reports that a request moved to a new phase,
+	// including its creation in the PENDING phase.
+	EventTypeStateChanged EventType = "ProvisioningRequestStateChanged"
+
+	// EventTypeDeleted reports that a request was removed after its
+	// infrastructure was torn down.
+	EventTypeDeleted EventType = "ProvisioningRequestDeleted"
+)
+
+// Notification reports a phase change or deletion of a provisioning request.
+type Notification struct {
+	// NotificationID uniquely identifies the notification.
+	NotificationID string `json:"notificationId"`
+
+	// EventType is the type of the notification.
+	EventType EventType `json:"eventType"`
+
+	// ProvisioningRequestID identifies the request.
+	ProvisioningRequestID string `json:"provisioningRequestId"`
+
+	// PreviousPhase is the phase of the request before the change. It is
+	// empty for newly created requests.
+	PreviousPhase Phase `json:"previousPhase,omitempty"`
+
+	// ProvisioningRequest is the request after the change, or its last state
+	// when it was deleted.
+	ProvisioningRequest *Request `json:"provisioningRequest"`
+
+	// Timestamp is when the change happened.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier is told about every phase change and deletion of a request.
+// Notify must not block the Manager.
+type Notifier interface {
+	Notify(n *Notification)
+}
+
+// Webhook notifier defaults.
+const (
+	DefaultWebhookTimeout      = 10 * time.Second
+	DefaultWebhookQueueSize    = 256
+	DefaultWebhookRetryBackoff = time.Second
+)
+
+// maxErrorBodyBytes caps the callback response body kept in delivery errors.
+const maxErrorBodyBytes = 512
+
+// notificationsDelivered counts the webhook deliveries by event type and outcome.
+var notificationsDelivered = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Subsystem: "provisioning",
+		Name:      "notifications_total",
+		Help:      "Total number of provisioning notification deliveries by event type and status",
+	},
+	[]string{"event_type", "status"},
+)
+
+// WebhookConfig configures a WebhookNotifier.
+type WebhookConfig struct {
+	// Callbacks are the URLs every notification is posted to.
+	Callbacks []string
+
+	// Timeout bounds a single callback POST.
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after the first failed delivery.
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry; it doubles with
+	// every further retry.
+	RetryBackoff time.Duration
+
+	// QueueSize is the number of notifications that may wait for delivery;
+	// further notifications are dropped.
+	QueueSize int
+
+	// HMACSecret signs every notification (X-O2IMS-Signature header).
+	// Notifications are unsigned when it is empty.
+	HMACSecret string
+}
+
+// WebhookNotifier posts notifications to a fixed list of callbacks, one
+// notification at a time so every callback receives them in order.
+type WebhookNotifier struct {
+	config WebhookConfig
+	client *http.Client
+	queue  chan *Notification
+	logger *zap.Logger
+}
+
+// NewWebhookNotifier creates a webhook notifier. Unset config fields take
+// their defaults. Deliveries start with Start.
+func NewWebhookNotifier(config WebhookConfig, logger *zap.Logger) *WebhookNotifier {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultWebhookTimeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultWebhookRetryBackoff
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultWebhookQueueSize
+	}
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout, Transport: resolver.NewTransport()},
+		queue:  make(chan *Notification, config.QueueSize),
+		logger: logger,
+	}
+}
+
+// Notify queues a notification for delivery, dropping it if the queue is full.
+func (w *WebhookNotifier) Notify(n *Notification) {
+	select {
+	case w.queue <- n:
+	default:
+		notificationsDelivered.WithLabelValues(string(n.EventType), "dropped").Inc()
+		w.logger.Warn("provisioning notification queue is full, dropping notification",
+			zap.String("provisioning_request_id", n.ProvisioningRequestID),
+			zap.String("event_type", string(n.EventType)))
+	}
+}
+
+// Start delivers queued notifications until ctx is canceled.
+func (w *WebhookNotifier) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-w.queue:
+				w.deliver(ctx, n)
+			}
+		}
+	}()
+}
+
+// deliver posts a notification to every callback, retrying failed attempts
+// with exponential backoff.
+func (w *WebhookNotifier) deliver(ctx context.Context, n *Notification) {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		w.logger.Error("failed to marshal provisioning notification", zap.Error(err))
+		return
+	}
+
+	for _, callback := range w.config.Callbacks {
+		// All attempts connect to the callback addresses of the first resolution.
+		pinned := resolver.Pin(ctx)
+
+		status := "failed"
+		backoff := w.config.RetryBackoff
+		for attempt := 0; attempt <= w.config.MaxRetries; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				backoff *= 2
+			}
+
+			err := w.post(pinned, callback, n, payload)
+			if err == nil {
+				status = "delivered"
+				break
+			}
+			w.logger.Warn("provisioning notification delivery failed",
+				zap.String("provisioning_request_id", n.ProvisioningRequestID),
+				zap.String("event_type", string(n.EventType)),
+				zap.Int("attempt", attempt+1),
+				zap.Error(err))
+		}
+		notificationsDelivered.WithLabelValues(string(n.EventType), status).Inc()
+	}
+}
+
+// post sends one delivery attempt.
+func (w *WebhookNotifier) post(ctx context.Context, callback string, n *Notification, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callback, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "O2-IMS-Gateway/1.0")
+	req.Header.Set("X-O2IMS-Event-Type", string(n.EventType))
+	req.Header.Set("X-O2IMS-Notification-ID", n.NotificationID)
+
+	// Signed like O2-IMS subscription notifications.
+	if w.config.HMACSecret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("X-O2IMS-Timestamp", timestamp)
+		req.Header.Set("X-O2IMS-Signature", workers.SignWithSecret(w.config.HMACSecret, timestamp, payload))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			w.logger.Warn("failed to close response body", zap.Error(closeErr))
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("callback returned non-2xx status: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
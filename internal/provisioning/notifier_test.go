@@ -0,0 +1,76 @@
+package provisioning_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/provisioning"
+	"github.com/piwi3910/netweave/internal/workers"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts atomic.Int32
+	received := make(chan *provisioning.Notification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to exercise the retry.
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, string(provisioning.EventTypeStateChanged), r.Header.Get("X-O2IMS-Event-Type"))
+		assert.Equal(t, workers.SignWithSecret("secret", r.Header.Get("X-O2IMS-Timestamp"), body),
+			r.Header.Get("X-O2IMS-Signature"))
+
+		var n provisioning.Notification
+		assert.NoError(t, json.Unmarshal(body, &n))
+		received <- &n
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	notifier := provisioning.NewWebhookNotifier(provisioning.WebhookConfig{
+		Callbacks:    []string{srv.URL},
+		MaxRetries:   1,
+		RetryBackoff: time.Millisecond,
+		HMACSecret:   "secret",
+	}, zap.NewNop())
+	notifier.Start(ctx)
+
+	notifier.Notify(&provisioning.Notification{
+		NotificationID:        "n-1",
+		EventType:             provisioning.EventTypeStateChanged,
+		ProvisioningRequestID: "r-1",
+		PreviousPhase:         provisioning.PhasePending,
+		ProvisioningRequest: &provisioning.Request{
+			ProvisioningRequestID: "r-1",
+			Status:                provisioning.Status{Phase: provisioning.PhaseProgressing},
+		},
+	})
+
+	select {
+	case n := <-received:
+		assert.Equal(t, "n-1", n.NotificationID)
+		assert.Equal(t, provisioning.PhasePending, n.PreviousPhase)
+		require.NotNil(t, n.ProvisioningRequest)
+		assert.Equal(t, provisioning.PhaseProgressing, n.ProvisioningRequest.Status.Phase)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not delivered")
+	}
+	assert.Equal(t, int32(2), attempts.Load())
+}
@@ -0,0 +1,41 @@
+package provisioning
+
+import "context"
+
+// Provisioner builds and tears down the infrastructure of provisioning
+// requests. The Manager calls it repeatedly while a request is in flight, so
+// every method must be idempotent.
+type Provisioner interface {
+	// Name returns the unique name requests use to select the provisioner.
+	Name() string
+
+	// Validate checks that the provisioner can handle the request, e.g. that
+	// its template parameters are well formed. Errors are returned to the
+	// client that created or updated the request.
+	Validate(req *Request) error
+
+	// Provision starts, or updates, the infrastructure of the request.
+	Provision(ctx context.Context, req *Request) error
+
+	// Observe reports the progress of the infrastructure of the request.
+	// Returns ErrNotFound once it no longer exists.
+	Observe(ctx context.Context, req *Request) (*Observation, error)
+
+	// Deprovision starts tearing down the infrastructure of the request.
+	// Deprovisioning infrastructure that no longer exists is not an error.
+	Deprovision(ctx context.Context, req *Request) error
+}
+
+// Observation is the progress of a request's infrastructure as seen by its
+// provisioner.
+type Observation struct {
+	// Phase is PhaseProgressing, PhaseFulfilled or PhaseFailed, or
+	// PhaseDeleting while the infrastructure is being torn down.
+	Phase Phase
+
+	// Message describes the progress or the failure.
+	Message string
+
+	// Resources identifies the infrastructure once it is provisioned.
+	Resources *ProvisionedResources
+}
@@ -0,0 +1,156 @@
+// Package provisioning implements the O2-IMS infrastructureProvisioning API.
+//
+// A provisioning request asks for infrastructure, typically a cluster, to be
+// built from a template. Requests are handed to a pluggable Provisioner (the
+// simulator, or Cluster API) and advanced by the Manager through the phases
+// PENDING, PROGRESSING and FULFILLED or FAILED; deleting a request moves it to
+// DELETING until the provisioner has torn the infrastructure down. Every phase
+// change is reported to the configured Notifier.
+package provisioning
+
+import (
+	"errors"
+	"maps"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a provisioning request, or the
+	// infrastructure provisioned for it, does not exist.
+	ErrNotFound = errors.New("provisioning request not found")
+
+	// ErrAlreadyExists is returned when creating a request whose ID is taken.
+	ErrAlreadyExists = errors.New("provisioning request already exists")
+
+	// ErrInvalidTransition is returned when a request cannot move to the
+	// requested phase, e.g. when updating a request that is still in flight.
+	ErrInvalidTransition = errors.New("invalid provisioning phase transition")
+
+	// ErrUnknownProvisioner is returned when a request names a provisioner
+	// that is not registered.
+	ErrUnknownProvisioner = errors.New("unknown provisioner")
+
+	// ErrInvalidRequest is returned when a request is rejected by validation.
+	ErrInvalidRequest = errors.New("invalid provisioning request")
+)
+
+// Phase is the provisioning phase of a request.
+type Phase string
+
+const (
+	// PhasePending indicates the request is accepted but not yet handed to
+	// its provisioner.
+	PhasePending Phase = "PENDING"
+
+	// PhaseProgressing indicates the provisioner is building the infrastructure.
+	PhaseProgressing Phase = "PROGRESSING"
+
+	// PhaseFulfilled indicates the infrastructure is provisioned and ready.
+	PhaseFulfilled Phase = "FULFILLED"
+
+	// PhaseFailed indicates provisioning failed.
+	PhaseFailed Phase = "FAILED"
+
+	// PhaseDeleting indicates the infrastructure is being torn down. The
+	// request is removed once it is gone.
+	PhaseDeleting Phase = "DELETING"
+)
+
+// transitions lists the phases each phase may move to. Updating a settled
+// (FULFILLED or FAILED) request starts it over from PENDING.
+var transitions = map[Phase][]Phase{
+	PhasePending:     {PhaseProgressing, PhaseFailed, PhaseDeleting},
+	PhaseProgressing: {PhaseFulfilled, PhaseFailed, PhaseDeleting},
+	PhaseFulfilled:   {PhasePending, PhaseFailed, PhaseDeleting},
+	PhaseFailed:      {PhasePending, PhaseDeleting},
+	PhaseDeleting:    {},
+}
+
+// CanTransition reports whether a request may move from one phase to another.
+// Staying in the same phase is always allowed.
+func CanTransition(from, to Phase) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Settled reports whether the phase is final until the request is updated or
+// deleted, so the Manager has nothing left to do for it.
+func (p Phase) Settled() bool {
+	return p == PhaseFulfilled || p == PhaseFailed
+}
+
+// Request is an O2-IMS provisioning request.
+type Request struct {
+	// ProvisioningRequestID uniquely identifies the request.
+	ProvisioningRequestID string `json:"provisioningRequestId"`
+
+	// Name is the human-readable name of the request. Provisioners may use it
+	// to name the infrastructure they create.
+	Name string `json:"name"`
+
+	// Description describes the request.
+	Description string `json:"description,omitempty"`
+
+	// TemplateName names the template the infrastructure is built from,
+	// e.g. a Cluster API ClusterClass.
+	TemplateName string `json:"templateName"`
+
+	// TemplateVersion is the template version, e.g. a Kubernetes version.
+	TemplateVersion string `json:"templateVersion,omitempty"`
+
+	// TemplateParameters are the provisioner-specific template inputs.
+	TemplateParameters map[string]interface{} `json:"templateParameters,omitempty"`
+
+	// Provisioner is the name of the provisioner handling the request.
+	Provisioner string `json:"provisioner"`
+
+	// Status is the observed state of the request.
+	Status Status `json:"status"`
+
+	// CreatedAt is when the request was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Status is the observed state of a provisioning request.
+type Status struct {
+	// Phase is the current provisioning phase.
+	Phase Phase `json:"provisioningPhase"`
+
+	// Message describes the current phase, e.g. why provisioning failed.
+	Message string `json:"message,omitempty"`
+
+	// UpdateTime is when the status last changed.
+	UpdateTime time.Time `json:"updateTime"`
+
+	// ProvisionedResources identifies the provisioned infrastructure once the
+	// request is fulfilled.
+	ProvisionedResources *ProvisionedResources `json:"provisionedResources,omitempty"`
+}
+
+// ProvisionedResources identifies the infrastructure provisioned for a request.
+type ProvisionedResources struct {
+	// NodeClusterID identifies the provisioned cluster.
+	NodeClusterID string `json:"oCloudNodeClusterId,omitempty"`
+
+	// InfrastructureAssetIDs identifies the provisioned assets, e.g. nodes.
+	InfrastructureAssetIDs []string `json:"oCloudInfrastructureAssetIds,omitempty"`
+}
+
+// clone returns a copy of the request that shares no maps or slices with it.
+func (r *Request) clone() *Request {
+	out := *r
+	out.TemplateParameters = maps.Clone(r.TemplateParameters)
+	if r.Status.ProvisionedResources != nil {
+		resources := *r.Status.ProvisionedResources
+		resources.InfrastructureAssetIDs = append([]string(nil), resources.InfrastructureAssetIDs...)
+		out.Status.ProvisionedResources = &resources
+	}
+	return &out
+}
@@ -0,0 +1,106 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SimulatorName is the name of the simulator provisioner.
+const SimulatorName = "simulator"
+
+// simulateFailureParameter is the template parameter that makes the simulator
+// fail a request instead of fulfilling it.
+const simulateFailureParameter = "simulateFailure"
+
+// Simulator is a provisioner that builds nothing. Requests are fulfilled, or
+// failed when their simulateFailure template parameter is true, once the
+// configured delay has passed; deprovisioning takes the same delay. It lets
+// clients exercise the provisioning workflow without infrastructure.
+type Simulator struct {
+	delay time.Duration
+
+	mu       sync.Mutex
+	clusters map[string]*simulatedCluster
+}
+
+// simulatedCluster is the simulated infrastructure of one request.
+type simulatedCluster struct {
+	provisionedAt time.Time
+	deletingAt    time.Time
+	fail          bool
+}
+
+// NewSimulator creates a simulator whose operations take delay.
+func NewSimulator(delay time.Duration) *Simulator {
+	return &Simulator{delay: delay, clusters: make(map[string]*simulatedCluster)}
+}
+
+// Name returns SimulatorName.
+func (s *Simulator) Name() string {
+	return SimulatorName
+}
+
+// Validate checks the simulateFailure template parameter.
+func (s *Simulator) Validate(req *Request) error {
+	if v, ok := req.TemplateParameters[simulateFailureParameter]; ok {
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("templateParameters.%s must be a boolean", simulateFailureParameter)
+		}
+	}
+	return nil
+}
+
+// Provision starts simulating the provisioning of the request.
+func (s *Simulator) Provision(_ context.Context, req *Request) error {
+	fail, _ := req.TemplateParameters[simulateFailureParameter].(bool)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clusters[req.ProvisioningRequestID] = &simulatedCluster{provisionedAt: time.Now(), fail: fail}
+	return nil
+}
+
+// Observe reports the simulated progress of the request.
+func (s *Simulator) Observe(_ context.Context, req *Request) (*Observation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.clusters[req.ProvisioningRequestID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, req.ProvisioningRequestID)
+	}
+
+	if !cluster.deletingAt.IsZero() {
+		if time.Since(cluster.deletingAt) >= s.delay {
+			delete(s.clusters, req.ProvisioningRequestID)
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, req.ProvisioningRequestID)
+		}
+		return &Observation{Phase: PhaseDeleting, Message: "Simulating deprovisioning"}, nil
+	}
+
+	switch {
+	case time.Since(cluster.provisionedAt) < s.delay:
+		return &Observation{Phase: PhaseProgressing, Message: "Simulating provisioning"}, nil
+	case cluster.fail:
+		return &Observation{Phase: PhaseFailed, Message: "Simulated provisioning failure"}, nil
+	default:
+		return &Observation{
+			Phase:     PhaseFulfilled,
+			Message:   "Simulated cluster provisioned",
+			Resources: &ProvisionedResources{NodeClusterID: "simulated-" + req.ProvisioningRequestID},
+		}, nil
+	}
+}
+
+// Deprovision starts simulating the teardown of the request.
+func (s *Simulator) Deprovision(_ context.Context, req *Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cluster, ok := s.clusters[req.ProvisioningRequestID]; ok && cluster.deletingAt.IsZero() {
+		cluster.deletingAt = time.Now()
+	}
+	return nil
+}
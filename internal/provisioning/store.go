@@ -0,0 +1,104 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Store persists provisioning requests.
+type Store interface {
+	// Create stores a new request. Returns ErrAlreadyExists if its ID is taken.
+	Create(ctx context.Context, req *Request) error
+
+	// Get returns a request by ID. Returns ErrNotFound if it doesn't exist.
+	Get(ctx context.Context, id string) (*Request, error)
+
+	// List returns all requests, oldest first.
+	List(ctx context.Context) ([]*Request, error)
+
+	// Update replaces a stored request. Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, req *Request) error
+
+	// Delete removes a request. Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryStore is an in-memory Store. Requests are lost on restart and are not
+// shared between gateway replicas.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	requests map[string]*Request
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{requests: make(map[string]*Request)}
+}
+
+// Create stores a new request.
+func (s *MemoryStore) Create(_ context.Context, req *Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.requests[req.ProvisioningRequestID]; exists {
+		return fmt.Errorf("%w: %s", ErrAlreadyExists, req.ProvisioningRequestID)
+	}
+	s.requests[req.ProvisioningRequestID] = req.clone()
+	return nil
+}
+
+// Get returns a request by ID.
+func (s *MemoryStore) Get(_ context.Context, id string) (*Request, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	req, ok := s.requests[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return req.clone(), nil
+}
+
+// List returns all requests, oldest first.
+func (s *MemoryStore) List(_ context.Context) ([]*Request, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := make([]*Request, 0, len(s.requests))
+	for _, req := range s.requests {
+		requests = append(requests, req.clone())
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if !requests[i].CreatedAt.Equal(requests[j].CreatedAt) {
+			return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+		}
+		return requests[i].ProvisioningRequestID < requests[j].ProvisioningRequestID
+	})
+	return requests, nil
+}
+
+// Update replaces a stored request.
+func (s *MemoryStore) Update(_ context.Context, req *Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.requests[req.ProvisioningRequestID]; !exists {
+		return fmt.Errorf("%w: %s", ErrNotFound, req.ProvisioningRequestID)
+	}
+	s.requests[req.ProvisioningRequestID] = req.clone()
+	return nil
+}
+
+// Delete removes a request.
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.requests[id]; !exists {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	delete(s.requests, id)
+	return nil
+}
@@ -0,0 +1,246 @@
+// Package readfallback keeps the objects last read from Redis in a bounded
+// in-memory cache, so reads can still be answered, marked stale, during a
+// short Redis outage. Writes are never cached: stores reject them while Redis
+// is unavailable.
+package readfallback
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+// Fallback read results.
+const (
+	ResultServed   = "served"
+	ResultMiss     = "miss"
+	ResultTooStale = "too_stale"
+)
+
+var (
+	// Reads tracks reads that hit a Redis outage, by whether the cache could
+	// answer them.
+	Reads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "storage",
+			Name:      "fallback_reads_total",
+			Help:      "Total number of reads answered or missed by the read fallback cache during Redis outages",
+		},
+		[]string{"store", "result"},
+	)
+
+	// RejectedWrites tracks writes rejected because Redis was unavailable.
+	RejectedWrites = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "storage",
+			Name:      "fallback_rejected_writes_total",
+			Help:      "Total number of writes rejected because Redis was unavailable",
+		},
+		[]string{"store"},
+	)
+)
+
+// Cache is a bounded cache of JSON-encoded objects keyed by their Redis key.
+// Entries are refreshed by every successful read; when the cache is full the
+// entry refreshed longest ago is evicted. A nil Cache is valid and caches
+// nothing.
+type Cache struct {
+	store        string
+	maxStaleness time.Duration
+	maxEntries   int
+	now          func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front is the most recently refreshed entry
+}
+
+type cacheEntry struct {
+	key    string
+	data   []byte
+	readAt time.Time
+}
+
+// New creates a cache for the named store (e.g. "subscriptions") that serves
+// entries read at most maxStaleness ago and holds at most maxEntries entries.
+func New(store string, maxStaleness time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		store:        store,
+		maxStaleness: maxStaleness,
+		maxEntries:   max(maxEntries, 1),
+		now:          time.Now,
+		entries:      make(map[string]*list.Element),
+		order:        list.New(),
+	}
+}
+
+// SetClock replaces the clock of the cache. It is meant for tests.
+func (c *Cache) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+// Put stores the value read from Redis under key. Values that cannot be
+// encoded are not cached.
+func (c *Cache) Put(key string, v any) {
+	if c == nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &cacheEntry{key: key, data: data, readAt: c.now()}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Delete drops the entries of keys, e.g. after they were written.
+func (c *Cache) Delete(keys ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// DeletePrefix drops every entry whose key starts with prefix, e.g. the
+// cached index sets after a write changed their membership.
+func (c *Cache) DeletePrefix(prefix string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Serve decodes the entry of key into dst during a Redis outage. It returns
+// false if there is no entry or it is older than the maximum staleness. A
+// served read marks the request in ctx as stale (see WithTracker).
+func (c *Cache) Serve(ctx context.Context, key string, dst any) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	var entry *cacheEntry
+	if ok {
+		entry = elem.Value.(*cacheEntry)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		Reads.WithLabelValues(c.store, ResultMiss).Inc()
+		return false
+	}
+	age := c.now().Sub(entry.readAt)
+	if age > c.maxStaleness {
+		Reads.WithLabelValues(c.store, ResultTooStale).Inc()
+		return false
+	}
+	if err := json.Unmarshal(entry.data, dst); err != nil {
+		Reads.WithLabelValues(c.store, ResultMiss).Inc()
+		return false
+	}
+	Reads.WithLabelValues(c.store, ResultServed).Inc()
+	markStale(ctx, age)
+	return true
+}
+
+// RecordRejectedWrite counts a write rejected because Redis was unavailable.
+func (c *Cache) RecordRejectedWrite() {
+	if c == nil {
+		return
+	}
+	RejectedWrites.WithLabelValues(c.store).Inc()
+}
+
+// IsUnavailable reports whether err means Redis could not be reached, as
+// opposed to an error about the request itself.
+func IsUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, redis.ErrClosed) ||
+		errors.Is(err, redis.ErrPoolTimeout) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// staleTracker records the age of the oldest stale read of a request.
+type staleTracker struct {
+	mu    sync.Mutex
+	stale bool
+	age   time.Duration
+}
+
+type trackerKey struct{}
+
+// WithTracker returns a context that records whether reads made with it were
+// served stale. Use StaleAge to read the result.
+func WithTracker(ctx context.Context) context.Context {
+	return context.WithValue(ctx, trackerKey{}, &staleTracker{})
+}
+
+// StaleAge returns the age of the oldest stale data read with ctx, and
+// whether any was read.
+func StaleAge(ctx context.Context) (time.Duration, bool) {
+	tracker, ok := ctx.Value(trackerKey{}).(*staleTracker)
+	if !ok {
+		return 0, false
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return tracker.age, tracker.stale
+}
+
+func markStale(ctx context.Context, age time.Duration) {
+	tracker, ok := ctx.Value(trackerKey{}).(*staleTracker)
+	if !ok {
+		return
+	}
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.stale = true
+	tracker.age = max(tracker.age, age)
+}
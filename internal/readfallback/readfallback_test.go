@@ -0,0 +1,68 @@
+package readfallback_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/readfallback"
+)
+
+type object struct {
+	Name string `json:"name"`
+}
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	cache := readfallback.New("test", time.Minute, 2)
+	cache.SetClock(func() time.Time { return now })
+
+	cache.Put("a", &object{Name: "a"})
+	cache.Put("b", &object{Name: "b"})
+
+	ctx := readfallback.WithTracker(context.Background())
+	_, stale := readfallback.StaleAge(ctx)
+	assert.False(t, stale)
+
+	now = now.Add(30 * time.Second)
+	cache.Put("b", &object{Name: "b2"})
+	var got object
+	assert.True(t, cache.Serve(ctx, "a", &got))
+	assert.Equal(t, "a", got.Name)
+	age, stale := readfallback.StaleAge(ctx)
+	assert.True(t, stale)
+	assert.Equal(t, 30*time.Second, age, "the age of the oldest object served")
+
+	// "a" was refreshed longest ago, so it is evicted first.
+	cache.Put("c", &object{Name: "c"})
+	assert.False(t, cache.Serve(ctx, "a", &got))
+	assert.True(t, cache.Serve(ctx, "b", &got))
+	assert.Equal(t, "b2", got.Name)
+
+	now = now.Add(2 * time.Minute)
+	assert.False(t, cache.Serve(ctx, "c", &got), "entries older than the maximum staleness are not served")
+
+	cache.Put("c", &object{Name: "c"})
+	cache.DeletePrefix("c")
+	assert.False(t, cache.Serve(ctx, "c", &got))
+
+	var disabled *readfallback.Cache
+	disabled.Put("a", &object{})
+	assert.False(t, disabled.Serve(ctx, "a", &got))
+}
+
+func TestIsUnavailable(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	assert.True(t, readfallback.IsUnavailable(fmt.Errorf("failed to get: %w", refused)))
+	assert.True(t, readfallback.IsUnavailable(redis.ErrClosed))
+	assert.True(t, readfallback.IsUnavailable(context.DeadlineExceeded))
+	assert.False(t, readfallback.IsUnavailable(context.Canceled))
+	assert.False(t, readfallback.IsUnavailable(redis.Nil))
+	assert.False(t, readfallback.IsUnavailable(nil))
+}
@@ -0,0 +1,109 @@
+// Package redact is the single redaction schema of the gateway's admin
+// surfaces. Struct fields holding secrets are tagged redact:"true", and every
+// surface that exposes a struct (the configuration printer, GET /admin/config,
+// the configuration history) converts it with Tree, which replaces the values
+// of tagged fields with Value. A new secret field is then masked everywhere
+// by tagging it once.
+package redact
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Value replaces the values of redacted fields.
+const Value = "[REDACTED]"
+
+// TagName is the struct tag marking fields whose values are redacted.
+const TagName = "redact"
+
+// Sensitive reports whether the values of field are redacted.
+func Sensitive(field reflect.StructField) bool {
+	return field.Tag.Get(TagName) == "true"
+}
+
+// Tree converts v, typically a struct or a pointer to one, into generic
+// values safe to expose: structs become maps keyed by the name in their
+// nameTag struct tag (for example "mapstructure" or "json"), slices and
+// arrays become []interface{}, maps become map[string]interface{} and
+// durations their string form. Fields without a name or named "-" are
+// skipped. Fields tagged redact:"true" become Value when they are set and
+// "" otherwise, so that the result still tells whether a secret is set.
+func Tree(v interface{}, nameTag string) interface{} {
+	return tree(reflect.ValueOf(v), nameTag)
+}
+
+func tree(v reflect.Value, nameTag string) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		return tree(v.Elem(), nameTag)
+	}
+
+	switch val := v.Interface().(type) {
+	case time.Duration:
+		return val.String()
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+			if text, err := m.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+		out := make(map[string]interface{})
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get(nameTag), ",")[0]
+			if !field.IsExported() || name == "" || name == "-" {
+				continue
+			}
+			if Sensitive(field) {
+				out[name] = masked(v.Field(i))
+				continue
+			}
+			out[name] = tree(v.Field(i), nameTag)
+		}
+		return out
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []interface{}{}
+		}
+		out := make([]interface{}, v.Len())
+		for i := range v.Len() {
+			out[i] = tree(v.Index(i), nameTag)
+		}
+		return out
+
+	case reflect.Map:
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = tree(iter.Value(), nameTag)
+		}
+		return out
+
+	default:
+		return v.Interface()
+	}
+}
+
+// masked returns the redacted form of the value of a sensitive field.
+func masked(v reflect.Value) string {
+	if v.IsZero() || ((v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0) {
+		return ""
+	}
+	return Value
+}
@@ -0,0 +1,53 @@
+package redact_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/redact"
+)
+
+type credentials struct {
+	User     string            `json:"user"`
+	Password string            `json:"password" redact:"true"`
+	Token    string            `json:"token" redact:"true"`
+	Headers  map[string]string `json:"headers" redact:"true"`
+	Internal string            `json:"-"`
+}
+
+type settings struct {
+	Name    string         `json:"name"`
+	Timeout time.Duration  `json:"timeout"`
+	Creds   *credentials   `json:"creds"`
+	Backups []credentials  `json:"backups"`
+	Labels  map[string]int `json:"labels"`
+	Hidden  string
+}
+
+func TestTree(t *testing.T) {
+	v := settings{
+		Name:    "gw",
+		Timeout: 5 * time.Second,
+		Creds:   &credentials{User: "admin", Password: "s3cret", Headers: map[string]string{"X-Key": "k"}, Internal: "x"},
+		Backups: []credentials{{User: "backup", Token: "t0ken"}},
+		Labels:  map[string]int{"a": 1},
+		Hidden:  "untagged",
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"name":    "gw",
+		"timeout": "5s",
+		"creds": map[string]interface{}{
+			"user":     "admin",
+			"password": redact.Value,
+			"token":    "",
+			"headers":  redact.Value,
+		},
+		"backups": []interface{}{
+			map[string]interface{}{"user": "backup", "password": "", "token": redact.Value, "headers": ""},
+		},
+		"labels": map[string]interface{}{"a": 1},
+	}, redact.Tree(&v, "json"))
+}
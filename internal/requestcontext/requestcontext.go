@@ -0,0 +1,173 @@
+// Package requestcontext holds the values that travel with a request through
+// the middleware chain, the handlers and the adapters: the request and
+// correlation IDs, the caller identity, the tenant, the API version and the
+// request logger.
+//
+// Every value is stored in the request's context.Context under a typed key,
+// so values of different packages cannot collide and reading a value never
+// needs a type assertion. The accessors return the zero value (or, for the
+// logger, a usable fallback) when a value is absent. Gin handlers read and
+// write the values through the request context:
+//
+//	tenantID := requestcontext.Tenant(c.Request.Context())
+//	c.Request = c.Request.WithContext(requestcontext.WithTenant(c.Request.Context(), "acme"))
+//
+// Packages with values of their own declare typed keys with NewKey.
+package requestcontext
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Key is a context key for values of type T. Keys are compared by identity,
+// so two keys never collide even if they share a name.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a new key for values of type T. The name is only used in
+// String, for debugging.
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String returns the name of the key.
+func (k *Key[T]) String() string {
+	return "requestcontext." + k.name
+}
+
+// With returns a copy of ctx carrying value under the key.
+func (k *Key[T]) With(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// Lookup returns the value of ctx under the key and whether it is set.
+func (k *Key[T]) Lookup(ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(k).(T)
+	return value, ok
+}
+
+// Value returns the value of ctx under the key, or the zero value of T.
+func (k *Key[T]) Value(ctx context.Context) T {
+	value, _ := k.Lookup(ctx)
+	return value
+}
+
+// Caller is the authenticated identity behind a request. The zero value is
+// an anonymous caller.
+type Caller struct {
+	// UserID is the ID of the authenticated user.
+	UserID string
+
+	// TenantID is the tenant the user belongs to; empty for platform users.
+	TenantID string
+
+	// Subject is the subject of the client certificate or token.
+	Subject string
+
+	// PlatformAdmin reports whether the caller is a platform administrator.
+	PlatformAdmin bool
+}
+
+// Authenticated reports whether the caller was authenticated.
+func (c Caller) Authenticated() bool {
+	return c.UserID != "" || c.Subject != ""
+}
+
+// The keys of the values shared across packages.
+var (
+	requestIDKey     = NewKey[string]("request_id")
+	correlationIDKey = NewKey[string]("correlation_id")
+	identityKey      = NewKey[Caller]("identity")
+	tenantKey        = NewKey[string]("tenant")
+	apiVersionKey    = NewKey[string]("api_version")
+	loggerKey        = NewKey[*zap.Logger]("logger")
+)
+
+// WithRequestID returns a copy of ctx carrying the ID of one API call.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return requestIDKey.With(ctx, requestID)
+}
+
+// RequestID returns the request ID of ctx, or "" if none.
+func RequestID(ctx context.Context) string {
+	return requestIDKey.Value(ctx)
+}
+
+// WithCorrelationID returns a copy of ctx carrying the ID of the client
+// operation, which may span several API calls.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return correlationIDKey.With(ctx, correlationID)
+}
+
+// CorrelationID returns the correlation ID of ctx, or "" if none.
+func CorrelationID(ctx context.Context) string {
+	return correlationIDKey.Value(ctx)
+}
+
+// WithIdentity returns a copy of ctx carrying the caller identity.
+func WithIdentity(ctx context.Context, caller Caller) context.Context {
+	return identityKey.With(ctx, caller)
+}
+
+// Identity returns the caller identity of ctx; it is anonymous if the
+// request was not authenticated.
+func Identity(ctx context.Context) Caller {
+	return identityKey.Value(ctx)
+}
+
+// WithTenant returns a copy of ctx carrying the ID of the tenant the request
+// acts on.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return tenantKey.With(ctx, tenantID)
+}
+
+// Tenant returns the ID of the tenant the request acts on, or "" if none.
+// It is the caller's tenant unless a middleware selected another one, such
+// as the tenant of a v3 request or of an admin route.
+func Tenant(ctx context.Context) string {
+	return tenantKey.Value(ctx)
+}
+
+// WithAPIVersion returns a copy of ctx carrying the API version requested.
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return apiVersionKey.With(ctx, version)
+}
+
+// APIVersion returns the API version of ctx (e.g. "v1"), or "" if none.
+func APIVersion(ctx context.Context) string {
+	return apiVersionKey.Value(ctx)
+}
+
+// WithLogger returns a copy of ctx carrying the logger of the request.
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return loggerKey.With(ctx, logger)
+}
+
+// Logger returns the logger of ctx, or the global zap logger if none, with
+// the request and correlation IDs of ctx as fields. It never returns nil.
+func Logger(ctx context.Context) *zap.Logger {
+	logger, ok := loggerKey.Lookup(ctx)
+	if !ok || logger == nil {
+		logger = zap.L()
+	}
+	if fields := Fields(ctx); len(fields) > 0 {
+		return logger.With(fields...)
+	}
+	return logger
+}
+
+// Fields returns the logging fields identifying the request of ctx: its
+// request ID and correlation ID, when present.
+func Fields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if requestID := RequestID(ctx); requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if correlationID := CorrelationID(ctx); correlationID != "" {
+		fields = append(fields, zap.String("correlation_id", correlationID))
+	}
+	return fields
+}
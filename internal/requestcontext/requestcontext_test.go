@@ -0,0 +1,67 @@
+package requestcontext_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+func TestKey(t *testing.T) {
+	first := requestcontext.NewKey[string]("name")
+	second := requestcontext.NewKey[string]("name")
+
+	ctx := first.With(context.Background(), "value")
+	assert.Equal(t, "value", first.Value(ctx))
+
+	// Keys with the same name do not collide.
+	_, ok := second.Lookup(ctx)
+	assert.False(t, ok)
+	assert.Empty(t, second.Value(ctx))
+}
+
+func TestAccessors_ZeroValues(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Empty(t, requestcontext.RequestID(ctx))
+	assert.Empty(t, requestcontext.CorrelationID(ctx))
+	assert.Empty(t, requestcontext.Tenant(ctx))
+	assert.Empty(t, requestcontext.APIVersion(ctx))
+	assert.False(t, requestcontext.Identity(ctx).Authenticated())
+	assert.NotNil(t, requestcontext.Logger(ctx))
+}
+
+func TestAccessors(t *testing.T) {
+	ctx := requestcontext.WithRequestID(context.Background(), "req-1")
+	ctx = requestcontext.WithCorrelationID(ctx, "corr-1")
+	ctx = requestcontext.WithTenant(ctx, "tenant-a")
+	ctx = requestcontext.WithAPIVersion(ctx, "v3")
+	ctx = requestcontext.WithIdentity(ctx, requestcontext.Caller{UserID: "user-1", TenantID: "tenant-a"})
+
+	assert.Equal(t, "req-1", requestcontext.RequestID(ctx))
+	assert.Equal(t, "corr-1", requestcontext.CorrelationID(ctx))
+	assert.Equal(t, "tenant-a", requestcontext.Tenant(ctx))
+	assert.Equal(t, "v3", requestcontext.APIVersion(ctx))
+	assert.True(t, requestcontext.Identity(ctx).Authenticated())
+	assert.Equal(t, "user-1", requestcontext.Identity(ctx).UserID)
+}
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	ctx := requestcontext.WithLogger(context.Background(), zap.New(core))
+	ctx = requestcontext.WithRequestID(ctx, "req-1")
+	ctx = requestcontext.WithCorrelationID(ctx, "corr-1")
+
+	requestcontext.Logger(ctx).Info("handled")
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, "corr-1", fields["correlation_id"])
+}
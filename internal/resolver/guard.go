@@ -0,0 +1,227 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reasons a Guard blocks an address, reported in BlockedError and the
+// blocked dials metric.
+const (
+	ReasonLoopback    = "loopback"
+	ReasonPrivate     = "private"
+	ReasonLinkLocal   = "link_local"
+	ReasonMetadata    = "metadata"
+	ReasonUnspecified = "unspecified"
+	ReasonMulticast   = "multicast"
+)
+
+// ErrBlocked is wrapped by the errors of connections a Guard refused.
+var ErrBlocked = errors.New("address blocked")
+
+// BlockedDials counts connections refused by a Guard.
+var BlockedDials = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Subsystem: "egress",
+		Name:      "blocked_dials_total",
+		Help:      "Total number of outbound connections refused because the target resolved to a blocked address",
+	},
+	[]string{"client", "reason"},
+)
+
+// BlockedError reports a connection a Guard refused.
+type BlockedError struct {
+	// Host is the host name of the dialed address.
+	Host string
+
+	// IP is the blocked address the host resolved to.
+	IP net.IP
+
+	// Reason is the kind of blocked address, one of the Reason constants.
+	Reason string
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("connection to %s refused: resolves to %s address %s", e.Host, e.Reason, e.IP)
+}
+
+// Unwrap returns ErrBlocked.
+func (e *BlockedError) Unwrap() error {
+	return ErrBlocked
+}
+
+// metadataIPs are the instance metadata endpoints of the cloud providers.
+var metadataIPs = []net.IP{
+	net.ParseIP("169.254.169.254"), // AWS, Azure, GCP, OpenStack
+	net.ParseIP("fd00:ec2::254"),   // AWS (IPv6)
+	net.ParseIP("100.100.100.200"), // Alibaba Cloud
+}
+
+// privateNets are the private address ranges (RFC 1918 and IPv6 ULA).
+var privateNets = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+	mustParseCIDR("fc00::/7"),
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(fmt.Sprintf("invalid CIDR %s: %v", cidr, err))
+	}
+	return network
+}
+
+// BlockedReason returns why a callback must not connect to ip, or "" when ip
+// is a public unicast address. It is the single predicate for callback
+// addresses, used both when a callback is registered and when it is dialed.
+func BlockedReason(ip net.IP) string {
+	for _, metadata := range metadataIPs {
+		if metadata.Equal(ip) {
+			return ReasonMetadata
+		}
+	}
+	switch {
+	case ip.IsLoopback():
+		return ReasonLoopback
+	case ip.IsUnspecified():
+		return ReasonUnspecified
+	case ip.IsLinkLocalUnicast():
+		return ReasonLinkLocal
+	case ip.IsMulticast():
+		return ReasonMulticast
+	}
+	for _, network := range privateNets {
+		if network.Contains(ip) {
+			return ReasonPrivate
+		}
+	}
+	return ""
+}
+
+// Guard dials through the shared resolver like DialContext, but refuses to
+// connect when the host resolves to a loopback, private, link-local, cloud
+// metadata or other non-public address. The check runs on the addresses the
+// connection is then made to, immediately before connecting, so a callback
+// host that passed validation when it was registered cannot be rebound to an
+// internal address for the delivery. Within a context returned by Pin, the
+// check and every connection use the addresses of the first resolution.
+type Guard struct {
+	// Client names the client in the blocked dials metric, e.g. "webhook".
+	Client string
+
+	// OnBlocked, when set, is called for every refused connection, e.g. to
+	// write an audit record.
+	OnBlocked func(ctx context.Context, err *BlockedError)
+
+	// Proxy returns the proxy for a request of the transport. Nil means
+	// http.ProxyFromEnvironment.
+	Proxy func(req *http.Request) (*url.URL, error)
+
+	// Disabled, when set and returning true, turns the address check off,
+	// e.g. while SSRF protection is disabled by a configuration reload.
+	Disabled func() bool
+
+	// proxies holds the addresses of the proxies handed out by the
+	// transport's Proxy function, which are dialed without the check.
+	proxies sync.Map
+}
+
+// DialContext connects to addr unless its host resolves to a blocked
+// address, in which case it returns a *BlockedError. It can be used as
+// http.Transport.DialContext. Connections to a proxy returned by the
+// transport of Transport are not checked: the proxy is configured by the
+// operator and the target behind it was checked by the Proxy function.
+func (g *Guard) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if _, ok := g.proxies.Load(addr); ok {
+		return Default().DialContext(ctx, network, addr)
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	r := Default()
+	addrs, err := g.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	return r.dialAddrs(ctx, network, addr, port, addrs)
+}
+
+// lookup resolves host and returns a *BlockedError when any of its addresses
+// is blocked. A host with any blocked address is refused as a whole, as
+// callback validation does, rather than falling back to its public addresses.
+func (g *Guard) lookup(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addrs, err := Default().LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if g.Disabled != nil && g.Disabled() {
+		return addrs, nil
+	}
+	for _, ip := range addrs {
+		if reason := BlockedReason(ip.IP); reason != "" {
+			blocked := &BlockedError{Host: host, IP: ip.IP, Reason: reason}
+			BlockedDials.WithLabelValues(g.Client, reason).Inc()
+			if g.OnBlocked != nil {
+				g.OnBlocked(ctx, blocked)
+			}
+			return nil, blocked
+		}
+	}
+	return addrs, nil
+}
+
+// proxy returns the proxy for req from Proxy or the environment. Before a request is handed to a proxy,
+// which then connects to the target on the gateway's behalf, the target's
+// addresses are checked like a direct connection would be.
+func (g *Guard) proxy(req *http.Request) (*url.URL, error) {
+	proxyFunc := g.Proxy
+	if proxyFunc == nil {
+		proxyFunc = http.ProxyFromEnvironment
+	}
+	proxyURL, err := proxyFunc(req)
+	if err != nil || proxyURL == nil {
+		return proxyURL, err
+	}
+	if _, err := g.lookup(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	g.proxies.Store(proxyAddr(proxyURL), struct{}{})
+	return proxyURL, nil
+}
+
+// proxyAddr returns the host:port the transport dials for proxyURL.
+func proxyAddr(proxyURL *url.URL) string {
+	if port := proxyURL.Port(); port != "" {
+		return net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+	port := "80"
+	switch proxyURL.Scheme {
+	case "https":
+		port = "443"
+	case "socks5", "socks5h":
+		port = "1080"
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
+
+// Transport returns a copy of http.DefaultTransport dialing through the
+// guard. Proxies from Proxy or the environment are honoured; requests through a
+// proxy are checked against the target's addresses before they are sent.
+func (g *Guard) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = g.proxy
+	transport.DialContext = g.DialContext
+	return transport
+}
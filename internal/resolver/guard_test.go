@@ -0,0 +1,151 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/resolver"
+)
+
+func TestBlockedReason(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "192.0.2.1", want: ""},
+		{ip: "2001:db8::1", want: ""},
+		{ip: "8.8.8.8", want: ""},
+		{ip: "2001:4860:4860::8888", want: ""},
+		{ip: "127.255.255.254", want: resolver.ReasonLoopback},
+		{ip: "127.0.0.1", want: resolver.ReasonLoopback},
+		{ip: "::1", want: resolver.ReasonLoopback},
+		{ip: "10.1.2.3", want: resolver.ReasonPrivate},
+		{ip: "172.20.0.1", want: resolver.ReasonPrivate},
+		{ip: "192.168.1.1", want: resolver.ReasonPrivate},
+		{ip: "fd12:3456::1", want: resolver.ReasonPrivate},
+		{ip: "169.254.1.1", want: resolver.ReasonLinkLocal},
+		{ip: "fe80::1", want: resolver.ReasonLinkLocal},
+		{ip: "169.254.169.254", want: resolver.ReasonMetadata},
+		{ip: "fd00:ec2::254", want: resolver.ReasonMetadata},
+		{ip: "100.100.100.200", want: resolver.ReasonMetadata},
+		{ip: "0.0.0.0", want: resolver.ReasonUnspecified},
+		{ip: "224.0.0.1", want: resolver.ReasonMulticast},
+		{ip: "::ffff:10.0.0.1", want: resolver.ReasonPrivate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolver.BlockedReason(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestGuard_DialContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	upstream := newFakeUpstream("192.0.2.1")
+	previous := resolver.Default()
+	resolver.SetDefault(resolver.New(resolver.Config{LookupIPAddr: upstream.lookup}))
+	t.Cleanup(func() { resolver.SetDefault(previous) })
+
+	var blocked []*resolver.BlockedError
+	guard := &resolver.Guard{
+		Client: "test",
+		OnBlocked: func(_ context.Context, err *resolver.BlockedError) {
+			blocked = append(blocked, err)
+		},
+	}
+	client := &http.Client{Transport: guard.Transport()}
+	get := func(ctx context.Context, url string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	t.Run("refuses a host rebound to loopback", func(t *testing.T) {
+		// The callback passed validation with a public address and was
+		// rebound before the delivery.
+		hosts, err := resolver.Default().LookupHost(context.Background(), "callback.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"192.0.2.1"}, hosts)
+		upstream.answer.Store("127.0.0.1")
+
+		err = get(context.Background(), "http://callback.example.com:"+port+"/")
+		require.ErrorIs(t, err, resolver.ErrBlocked)
+		var blockedErr *resolver.BlockedError
+		require.True(t, errors.As(err, &blockedErr))
+		assert.Equal(t, "callback.example.com", blockedErr.Host)
+		assert.Equal(t, resolver.ReasonLoopback, blockedErr.Reason)
+		require.Len(t, blocked, 1)
+		assert.Equal(t, "127.0.0.1", blocked[0].IP.String())
+	})
+
+	t.Run("refuses IP literals", func(t *testing.T) {
+		blocked = nil
+		err := get(context.Background(), "http://169.254.169.254/latest/meta-data/")
+		require.ErrorIs(t, err, resolver.ErrBlocked)
+		require.Len(t, blocked, 1)
+		assert.Equal(t, resolver.ReasonMetadata, blocked[0].Reason)
+	})
+
+	t.Run("checks the pinned addresses", func(t *testing.T) {
+		blocked = nil
+		upstream.answer.Store("127.0.0.1")
+		ctx := resolver.Pin(context.Background())
+		require.ErrorIs(t, get(ctx, "http://pinned.example.com:"+port+"/"), resolver.ErrBlocked)
+
+		// Rebinding the host to a public address does not change the
+		// addresses the pinned context connects to.
+		upstream.answer.Store("192.0.2.1")
+		require.ErrorIs(t, get(ctx, "http://pinned.example.com:"+port+"/"), resolver.ErrBlocked)
+		assert.Len(t, blocked, 2)
+	})
+}
+
+func TestGuard_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	upstream := newFakeUpstream("192.0.2.1")
+	previous := resolver.Default()
+	resolver.SetDefault(resolver.New(resolver.Config{LookupIPAddr: upstream.lookup}))
+	t.Cleanup(func() { resolver.SetDefault(previous) })
+
+	guard := &resolver.Guard{Client: "test", Proxy: http.ProxyURL(proxyURL)}
+	client := &http.Client{Transport: guard.Transport()}
+
+	// The proxy listens on loopback but is configured by the operator.
+	resp, err := client.Get("http://callback.example.com/notify")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, []string{"http://callback.example.com/notify"}, proxied)
+
+	// A target rebound to a blocked address is refused before it reaches
+	// the proxy.
+	upstream.answer.Store("10.0.0.1")
+	_, err = client.Get("http://rebound.example.com/notify")
+	require.ErrorIs(t, err, resolver.ErrBlocked)
+	assert.Len(t, proxied, 1)
+}
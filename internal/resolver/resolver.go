@@ -0,0 +1,308 @@
+// Package resolver provides the DNS resolver shared by the gateway's
+// outbound HTTP clients: webhook and notification delivery, SMO and O2-DMS
+// backend calls, and adapter endpoints. It caches positive and negative
+// answers, can query configured upstream servers instead of the system
+// resolver, and can pin the resolution of a host for the lifetime of a
+// context so a callback host cannot be rebound to another address between
+// the policy check and the connection, or between delivery retries.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Lookup results, reported in the lookup metric.
+const (
+	ResultHit         = "hit"
+	ResultNegativeHit = "negative_hit"
+	ResultMiss        = "miss"
+	ResultPinned      = "pinned"
+)
+
+// Lookups counts host lookups by how they were answered.
+var Lookups = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Subsystem: "dns",
+		Name:      "lookups_total",
+		Help:      "Total number of outbound DNS lookups by cache result",
+	},
+	[]string{"result"},
+)
+
+// Config configures a Resolver.
+type Config struct {
+	// Servers lists upstream DNS servers as host:port, queried in turn.
+	// Empty uses the system resolver.
+	Servers []string
+
+	// CacheTTL is how long resolved addresses are cached. Zero disables
+	// positive caching.
+	CacheTTL time.Duration
+
+	// NegativeTTL is how long unknown hosts (NXDOMAIN) are cached. Zero
+	// disables negative caching.
+	NegativeTTL time.Duration
+
+	// MaxEntries bounds the number of cached hosts. Zero means 1000.
+	MaxEntries int
+
+	// Timeout bounds each query to an upstream server. Zero means 5s.
+	Timeout time.Duration
+
+	// LookupIPAddr replaces the upstream lookup. Exported for testing.
+	LookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Defaults applied to a zero Config.
+const (
+	DefaultMaxEntries = 1000
+	DefaultTimeout    = 5 * time.Second
+)
+
+type cacheEntry struct {
+	addrs   []net.IPAddr
+	err     error
+	expires time.Time
+}
+
+// Resolver resolves host names with caching. It is safe for concurrent use.
+type Resolver struct {
+	lookup      func(ctx context.Context, host string) ([]net.IPAddr, error)
+	cacheTTL    time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+	dialer      *net.Dialer
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// New creates a Resolver from cfg.
+func New(cfg Config) *Resolver {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	lookup := cfg.LookupIPAddr
+	if lookup == nil {
+		lookup = newUpstream(cfg.Servers, timeout).LookupIPAddr
+	}
+
+	return &Resolver{
+		lookup:      lookup,
+		cacheTTL:    cfg.CacheTTL,
+		negativeTTL: cfg.NegativeTTL,
+		maxEntries:  maxEntries,
+		dialer:      &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		cache:       make(map[string]cacheEntry),
+	}
+}
+
+// newUpstream returns the system resolver, or a resolver querying servers
+// in turn.
+func newUpstream(servers []string, timeout time.Duration) *net.Resolver {
+	if len(servers) == 0 {
+		return net.DefaultResolver
+	}
+	var next atomic.Uint64
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			server := servers[(next.Add(1)-1)%uint64(len(servers))]
+			dialer := net.Dialer{Timeout: timeout}
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// LookupIPAddr returns the addresses of host. IP literals are returned as
+// is. Within a context returned by Pin, every lookup of a host returns the
+// addresses of the first one.
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+
+	pins, _ := ctx.Value(pinsKey{}).(*pins)
+	if pins != nil {
+		pins.mu.Lock()
+		defer pins.mu.Unlock()
+		if addrs, ok := pins.hosts[host]; ok {
+			Lookups.WithLabelValues(ResultPinned).Inc()
+			return addrs, nil
+		}
+	}
+
+	addrs, err := r.cachedLookup(ctx, host)
+	if err == nil && pins != nil {
+		pins.hosts[host] = addrs
+	}
+	return addrs, err
+}
+
+// LookupHost returns the addresses of host as strings.
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, err := r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, len(addrs))
+	for i, addr := range addrs {
+		hosts[i] = addr.String()
+	}
+	return hosts, nil
+}
+
+// cachedLookup answers a lookup from the cache or the upstream resolver.
+func (r *Resolver) cachedLookup(ctx context.Context, host string) ([]net.IPAddr, error) {
+	now := time.Now()
+	r.mu.Lock()
+	entry, ok := r.cache[host]
+	r.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		if entry.err != nil {
+			Lookups.WithLabelValues(ResultNegativeHit).Inc()
+			return nil, entry.err
+		}
+		Lookups.WithLabelValues(ResultHit).Inc()
+		return entry.addrs, nil
+	}
+
+	Lookups.WithLabelValues(ResultMiss).Inc()
+	addrs, err := r.lookup(ctx, host)
+	var dnsErr *net.DNSError
+	switch {
+	case err == nil && len(addrs) > 0 && r.cacheTTL > 0:
+		r.store(host, cacheEntry{addrs: addrs, expires: now.Add(r.cacheTTL)})
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound && r.negativeTTL > 0:
+		r.store(host, cacheEntry{err: err, expires: now.Add(r.negativeTTL)})
+	}
+	if err == nil && len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs, err
+}
+
+// store caches entry for host, evicting expired entries, or an arbitrary
+// one, when the cache is full.
+func (r *Resolver) store(host string, entry cacheEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cache[host]; !ok && len(r.cache) >= r.maxEntries {
+		now := time.Now()
+		for key, cached := range r.cache {
+			if !now.Before(cached.expires) {
+				delete(r.cache, key)
+			}
+		}
+		for key := range r.cache {
+			if len(r.cache) < r.maxEntries {
+				break
+			}
+			delete(r.cache, key)
+		}
+	}
+	r.cache[host] = entry
+}
+
+// DialContext connects to addr, resolving its host with the resolver and
+// trying each address in turn. It can be used as http.Transport.DialContext.
+func (r *Resolver) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	return r.dialAddrs(ctx, network, addr, port, addrs)
+}
+
+// dialAddrs connects to the first of the resolved addresses of addr that
+// accepts the connection.
+func (r *Resolver) dialAddrs(
+	ctx context.Context, network, addr, port string, addrs []net.IPAddr,
+) (net.Conn, error) {
+	var lastErr error
+	for _, ip := range addrs {
+		conn, err := r.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("dial %s: %w", addr, lastErr)
+}
+
+type pinsKey struct{}
+
+// pins holds the addresses pinned in a context.
+type pins struct {
+	mu    sync.Mutex
+	hosts map[string][]net.IPAddr
+}
+
+// Pin returns a context in which every host resolves to the addresses of its
+// first lookup, e.g. for the policy check and all attempts of one webhook
+// delivery. This protects against DNS rebinding: a host that passed a check
+// cannot be answered with another address for the connection. Pinning an
+// already pinned context keeps its pins.
+func Pin(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(pinsKey{}).(*pins); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, pinsKey{}, &pins{hosts: make(map[string][]net.IPAddr)})
+}
+
+var (
+	defaultResolver atomic.Pointer[Resolver]
+	systemResolver  = New(Config{})
+)
+
+// Default returns the shared resolver: the one set by SetDefault, or an
+// uncached system resolver.
+func Default() *Resolver {
+	if r := defaultResolver.Load(); r != nil {
+		return r
+	}
+	return systemResolver
+}
+
+// SetDefault makes r the shared resolver.
+func SetDefault(r *Resolver) {
+	defaultResolver.Store(r)
+}
+
+// DialContext dials addr through the shared resolver. Transports using it
+// pick up a resolver set later with SetDefault.
+func DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return Default().DialContext(ctx, network, addr)
+}
+
+// NewTransport returns a copy of http.DefaultTransport dialing through the
+// shared resolver.
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = DialContext
+	return transport
+}
@@ -0,0 +1,134 @@
+package resolver_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/resolver"
+)
+
+// fakeUpstream answers lookups with the address in answer and counts them.
+type fakeUpstream struct {
+	answer atomic.Value
+	calls  atomic.Int32
+}
+
+func newFakeUpstream(ip string) *fakeUpstream {
+	u := &fakeUpstream{}
+	u.answer.Store(ip)
+	return u
+}
+
+func (u *fakeUpstream) lookup(_ context.Context, host string) ([]net.IPAddr, error) {
+	u.calls.Add(1)
+	ip := u.answer.Load().(string)
+	if ip == "" {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+}
+
+func TestResolver_PositiveCache(t *testing.T) {
+	upstream := newFakeUpstream("192.0.2.1")
+	r := resolver.New(resolver.Config{CacheTTL: time.Minute, LookupIPAddr: upstream.lookup})
+
+	for range 3 {
+		hosts, err := r.LookupHost(context.Background(), "smo.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"192.0.2.1"}, hosts)
+	}
+	assert.Equal(t, int32(1), upstream.calls.Load())
+}
+
+func TestResolver_NegativeCache(t *testing.T) {
+	upstream := newFakeUpstream("")
+	r := resolver.New(resolver.Config{NegativeTTL: time.Minute, LookupIPAddr: upstream.lookup})
+
+	for range 2 {
+		_, err := r.LookupIPAddr(context.Background(), "missing.example.com")
+		var dnsErr *net.DNSError
+		require.ErrorAs(t, err, &dnsErr)
+		assert.True(t, dnsErr.IsNotFound)
+	}
+	assert.Equal(t, int32(1), upstream.calls.Load())
+}
+
+func TestResolver_NoCache(t *testing.T) {
+	upstream := newFakeUpstream("192.0.2.1")
+	r := resolver.New(resolver.Config{LookupIPAddr: upstream.lookup})
+
+	for range 2 {
+		_, err := r.LookupIPAddr(context.Background(), "smo.example.com")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), upstream.calls.Load())
+
+	// IP literals are never looked up.
+	hosts, err := r.LookupHost(context.Background(), "2001:db8::1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2001:db8::1"}, hosts)
+	assert.Equal(t, int32(2), upstream.calls.Load())
+}
+
+func TestResolver_MaxEntries(t *testing.T) {
+	upstream := newFakeUpstream("192.0.2.1")
+	r := resolver.New(resolver.Config{CacheTTL: time.Minute, MaxEntries: 1, LookupIPAddr: upstream.lookup})
+
+	for _, host := range []string{"a.example.com", "b.example.com", "a.example.com"} {
+		_, err := r.LookupIPAddr(context.Background(), host)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), upstream.calls.Load())
+}
+
+func TestPin(t *testing.T) {
+	upstream := newFakeUpstream("192.0.2.1")
+	r := resolver.New(resolver.Config{LookupIPAddr: upstream.lookup})
+
+	ctx := resolver.Pin(context.Background())
+	hosts, err := r.LookupHost(ctx, "hooks.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1"}, hosts)
+
+	// The host is rebound, but the pinned context keeps the first answer.
+	upstream.answer.Store("10.0.0.1")
+	hosts, err = r.LookupHost(resolver.Pin(ctx), "hooks.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1"}, hosts)
+
+	hosts, err = r.LookupHost(context.Background(), "hooks.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, hosts)
+}
+
+func TestResolver_DialContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	upstream := newFakeUpstream("127.0.0.1")
+	r := resolver.New(resolver.Config{LookupIPAddr: upstream.lookup})
+	transport := resolver.NewTransport()
+	transport.DialContext = r.DialContext
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+		"http://callback.example.com:"+port+"/", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, int32(1), upstream.calls.Load())
+}
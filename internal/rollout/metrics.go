@@ -0,0 +1,32 @@
+package rollout
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// Transitions tracks rollout state transitions
+	// (in_progress, promoted, or rolled_back).
+	Transitions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "rollout",
+			Name:      "transitions_total",
+			Help:      "Total number of settings rollout state transitions",
+		},
+		[]string{"rollout", "state"},
+	)
+
+	// CanaryPercent reports the percentage of requests served with canary
+	// settings (0 when no rollout is in progress).
+	CanaryPercent = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "rollout",
+			Name:      "canary_percent",
+			Help:      "Percentage of requests served with canary settings",
+		},
+		[]string{"rollout"},
+	)
+)
@@ -0,0 +1,353 @@
+// Package rollout provides staged rollout of runtime-tunable settings.
+//
+// A Controller serves a stable value to most requests and a canary value to a
+// configured percentage of them. Outcomes reported for each request are
+// compared per variant: when the canary error rate rises above the stable
+// error rate by more than the allowed margin the canary is rolled back, and
+// once it has served enough requests for the bake time it is promoted to stable.
+package rollout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Variant identifies which settings a request was served with.
+type Variant string
+
+// Variants.
+const (
+	VariantStable Variant = "stable"
+	VariantCanary Variant = "canary"
+)
+
+// State is the rollout state of a controller.
+type State string
+
+// Rollout states.
+const (
+	// StateIdle means only stable settings are in use.
+	StateIdle State = "idle"
+	// StateInProgress means canary settings serve a percentage of requests.
+	StateInProgress State = "in_progress"
+	// StatePromoted means the last canary became the stable settings.
+	StatePromoted State = "promoted"
+	// StateRolledBack means the last canary was discarded.
+	StateRolledBack State = "rolled_back"
+)
+
+// Policy defaults.
+const (
+	DefaultPercent              = 10
+	DefaultBakeTime             = 5 * time.Minute
+	DefaultMinRequests          = 100
+	DefaultMaxErrorRateIncrease = 0.05
+)
+
+var (
+	// ErrInProgress is returned when starting a rollout while another is in progress.
+	ErrInProgress = errors.New("a rollout is already in progress")
+
+	// ErrNotInProgress is returned when promoting or rolling back without a rollout in progress.
+	ErrNotInProgress = errors.New("no rollout in progress")
+)
+
+// Policy controls how canary settings are rolled out.
+type Policy struct {
+	// Percent of requests served with the canary settings (1-99).
+	Percent int `json:"percent"`
+
+	// BakeTime is how long the canary must run before it is promoted.
+	BakeTime time.Duration `json:"bakeTime"`
+
+	// MinRequests is the number of canary requests required before the canary
+	// is evaluated, both for rollback and for promotion.
+	MinRequests int64 `json:"minRequests"`
+
+	// MaxErrorRateIncrease is the largest allowed difference between the canary
+	// and stable error rates (0-1) before the canary is rolled back.
+	MaxErrorRateIncrease float64 `json:"maxErrorRateIncrease"`
+}
+
+// DefaultPolicy returns the default rollout policy.
+func DefaultPolicy() Policy {
+	return Policy{
+		Percent:              DefaultPercent,
+		BakeTime:             DefaultBakeTime,
+		MinRequests:          DefaultMinRequests,
+		MaxErrorRateIncrease: DefaultMaxErrorRateIncrease,
+	}
+}
+
+// Validate checks the policy values.
+func (p Policy) Validate() error {
+	if p.Percent < 1 || p.Percent > 99 {
+		return fmt.Errorf("percent must be between 1 and 99, got %d", p.Percent)
+	}
+	if p.BakeTime < 0 {
+		return errors.New("bake time cannot be negative")
+	}
+	if p.MinRequests < 1 {
+		return errors.New("min requests must be at least 1")
+	}
+	if p.MaxErrorRateIncrease < 0 || p.MaxErrorRateIncrease > 1 {
+		return fmt.Errorf("max error rate increase must be between 0 and 1, got %g", p.MaxErrorRateIncrease)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the policy with the bake time as a duration string.
+func (p Policy) MarshalJSON() ([]byte, error) {
+	type policy Policy
+	return json.Marshal(struct {
+		policy
+		BakeTime string `json:"bakeTime"`
+	}{policy: policy(p), BakeTime: p.BakeTime.String()})
+}
+
+// Stats counts request outcomes for a variant.
+type Stats struct {
+	Requests int64 `json:"requests"`
+	Errors   int64 `json:"errors"`
+}
+
+// ErrorRate returns the fraction of failed requests.
+func (s Stats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// Status is a point-in-time view of a controller.
+type Status[T any] struct {
+	State       State      `json:"state"`
+	Stable      T          `json:"stable"`
+	Canary      *T         `json:"canary,omitempty"`
+	Policy      *Policy    `json:"policy,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	StableStats Stats      `json:"stableStats"`
+	CanaryStats Stats      `json:"canaryStats"`
+	Reason      string     `json:"reason,omitempty"`
+}
+
+// Controller rolls out new settings of type T to a percentage of requests.
+// It is safe for concurrent use.
+type Controller[T any] struct {
+	mu          sync.Mutex
+	name        string
+	logger      *zap.Logger
+	stable      T
+	canary      *T
+	policy      Policy
+	startedAt   time.Time
+	stableStats Stats
+	canaryStats Stats
+	state       State
+	reason      string
+	seq         uint64
+}
+
+// NewController creates a controller serving initial as the stable settings.
+// The name labels metrics and log entries.
+func NewController[T any](name string, initial T, logger *zap.Logger) *Controller[T] {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Controller[T]{
+		name:   name,
+		logger: logger,
+		stable: initial,
+		state:  StateIdle,
+	}
+}
+
+// Start begins rolling out canary settings according to policy.
+// Returns ErrInProgress if a rollout is already in progress.
+func (c *Controller[T]) Start(canary T, policy Policy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid rollout policy: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canary != nil {
+		return ErrInProgress
+	}
+
+	c.canary = &canary
+	c.policy = policy
+	c.startedAt = time.Now().UTC()
+	c.stableStats = Stats{}
+	c.canaryStats = Stats{}
+	c.state = StateInProgress
+	c.reason = ""
+	c.seq = 0
+
+	Transitions.WithLabelValues(c.name, string(StateInProgress)).Inc()
+	CanaryPercent.WithLabelValues(c.name).Set(float64(policy.Percent))
+	c.logger.Info("settings rollout started",
+		zap.String("rollout", c.name),
+		zap.Int("percent", policy.Percent),
+		zap.Duration("bake_time", policy.BakeTime))
+	return nil
+}
+
+// Select returns the settings to serve a request with and the variant they
+// belong to. While a rollout is in progress, Percent out of every 100
+// requests receive the canary settings.
+func (c *Controller[T]) Select() (T, Variant) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canary == nil {
+		return c.stable, VariantStable
+	}
+
+	c.seq++
+	if int(c.seq%100) < c.policy.Percent {
+		return *c.canary, VariantCanary
+	}
+	return c.stable, VariantStable
+}
+
+// Observe records the outcome of a request served with the given variant and
+// rolls the canary back or promotes it when the policy says so. Outcomes
+// reported after the rollout ended are ignored.
+func (c *Controller[T]) Observe(variant Variant, failed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canary == nil {
+		return
+	}
+
+	stats := &c.stableStats
+	if variant == VariantCanary {
+		stats = &c.canaryStats
+	}
+	stats.Requests++
+	if failed {
+		stats.Errors++
+	}
+
+	if c.canaryStats.Requests < c.policy.MinRequests {
+		return
+	}
+
+	canaryRate := c.canaryStats.ErrorRate()
+	stableRate := c.stableStats.ErrorRate()
+	if canaryRate-stableRate > c.policy.MaxErrorRateIncrease {
+		c.finishLocked(StateRolledBack, fmt.Sprintf(
+			"canary error rate %.2f%% exceeded stable error rate %.2f%% by more than %.2f%%",
+			canaryRate*100, stableRate*100, c.policy.MaxErrorRateIncrease*100))
+		return
+	}
+
+	if time.Since(c.startedAt) >= c.policy.BakeTime {
+		c.finishLocked(StatePromoted, "canary completed bake time within error budget")
+	}
+}
+
+// Promote makes the canary settings stable immediately.
+// Returns ErrNotInProgress if no rollout is in progress.
+func (c *Controller[T]) Promote(reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canary == nil {
+		return ErrNotInProgress
+	}
+	c.finishLocked(StatePromoted, reason)
+	return nil
+}
+
+// Rollback discards the canary settings.
+// Returns ErrNotInProgress if no rollout is in progress.
+func (c *Controller[T]) Rollback(reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canary == nil {
+		return ErrNotInProgress
+	}
+	c.finishLocked(StateRolledBack, reason)
+	return nil
+}
+
+// finishLocked ends the rollout. Must be called with c.mu held.
+func (c *Controller[T]) finishLocked(state State, reason string) {
+	if state == StatePromoted {
+		c.stable = *c.canary
+	}
+	c.canary = nil
+	c.state = state
+	c.reason = reason
+
+	Transitions.WithLabelValues(c.name, string(state)).Inc()
+	CanaryPercent.WithLabelValues(c.name).Set(0)
+
+	fields := []zap.Field{
+		zap.String("rollout", c.name),
+		zap.String("reason", reason),
+		zap.Int64("canary_requests", c.canaryStats.Requests),
+		zap.Int64("canary_errors", c.canaryStats.Errors),
+		zap.Int64("stable_requests", c.stableStats.Requests),
+		zap.Int64("stable_errors", c.stableStats.Errors),
+	}
+	if state == StateRolledBack {
+		c.logger.Warn("settings rollout rolled back", fields...)
+	} else {
+		c.logger.Info("settings rollout promoted", fields...)
+	}
+}
+
+// SetStable replaces the stable settings without a rollout, e.g. after a
+// configuration reload. Returns ErrInProgress if a rollout is in progress, as
+// its canary was derived from the current stable settings.
+func (c *Controller[T]) SetStable(stable T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.canary != nil {
+		return ErrInProgress
+	}
+	c.stable = stable
+	return nil
+}
+
+// Stable returns the current stable settings.
+func (c *Controller[T]) Stable() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stable
+}
+
+// Status returns the current rollout status.
+func (c *Controller[T]) Status() Status[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := Status[T]{
+		State:       c.state,
+		Stable:      c.stable,
+		StableStats: c.stableStats,
+		CanaryStats: c.canaryStats,
+		Reason:      c.reason,
+	}
+	if c.canary != nil {
+		canary := *c.canary
+		policy := c.policy
+		startedAt := c.startedAt
+		status.Canary = &canary
+		status.Policy = &policy
+		status.StartedAt = &startedAt
+	}
+	return status
+}
@@ -0,0 +1,145 @@
+package rollout_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/rollout"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(p *rollout.Policy)
+		wantErr bool
+	}{
+		{name: "default", mutate: func(*rollout.Policy) {}},
+		{name: "zero percent", mutate: func(p *rollout.Policy) { p.Percent = 0 }, wantErr: true},
+		{name: "full percent", mutate: func(p *rollout.Policy) { p.Percent = 100 }, wantErr: true},
+		{name: "negative bake time", mutate: func(p *rollout.Policy) { p.BakeTime = -time.Second }, wantErr: true},
+		{name: "no min requests", mutate: func(p *rollout.Policy) { p.MinRequests = 0 }, wantErr: true},
+		{name: "error rate above 1", mutate: func(p *rollout.Policy) { p.MaxErrorRateIncrease = 1.5 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := rollout.DefaultPolicy()
+			tt.mutate(&policy)
+			err := policy.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestController_SelectsConfiguredPercentage(t *testing.T) {
+	ctrl := rollout.NewController("test-select", "v1", nil)
+
+	value, variant := ctrl.Select()
+	assert.Equal(t, "v1", value)
+	assert.Equal(t, rollout.VariantStable, variant)
+
+	require.NoError(t, ctrl.Start("v2", rollout.Policy{Percent: 25, BakeTime: time.Hour, MinRequests: 1000}))
+	require.ErrorIs(t, ctrl.Start("v3", rollout.DefaultPolicy()), rollout.ErrInProgress)
+
+	canary := 0
+	for range 200 {
+		value, variant := ctrl.Select()
+		if variant == rollout.VariantCanary {
+			assert.Equal(t, "v2", value)
+			canary++
+		} else {
+			assert.Equal(t, "v1", value)
+		}
+	}
+	assert.Equal(t, 50, canary)
+}
+
+func TestController_RollsBackOnErrorRateIncrease(t *testing.T) {
+	ctrl := rollout.NewController("test-rollback", 1, nil)
+	require.NoError(t, ctrl.Start(2, rollout.Policy{
+		Percent: 50, BakeTime: time.Hour, MinRequests: 10, MaxErrorRateIncrease: 0.1,
+	}))
+
+	rolledBack := testutil.ToFloat64(rollout.Transitions.WithLabelValues("test-rollback", "rolled_back"))
+	for range 20 {
+		ctrl.Observe(rollout.VariantStable, false)
+	}
+	for i := range 10 {
+		ctrl.Observe(rollout.VariantCanary, i%3 == 0)
+	}
+
+	status := ctrl.Status()
+	assert.Equal(t, rollout.StateRolledBack, status.State)
+	assert.Nil(t, status.Canary)
+	assert.Contains(t, status.Reason, "error rate")
+	assert.Equal(t, 1, ctrl.Stable())
+	assert.Equal(t, rolledBack+1,
+		testutil.ToFloat64(rollout.Transitions.WithLabelValues("test-rollback", "rolled_back")))
+
+	// Outcomes reported after the rollout ended are ignored.
+	ctrl.Observe(rollout.VariantCanary, true)
+	assert.Equal(t, int64(10), ctrl.Status().CanaryStats.Requests)
+}
+
+func TestController_PromotesAfterBakeTime(t *testing.T) {
+	ctrl := rollout.NewController("test-promote", "v1", nil)
+	require.NoError(t, ctrl.Start("v2", rollout.Policy{
+		Percent: 10, BakeTime: 20 * time.Millisecond, MinRequests: 5, MaxErrorRateIncrease: 0.05,
+	}))
+
+	// Enough canary requests but the bake time has not elapsed.
+	for range 5 {
+		ctrl.Observe(rollout.VariantCanary, false)
+	}
+	assert.Equal(t, rollout.StateInProgress, ctrl.Status().State)
+
+	time.Sleep(30 * time.Millisecond)
+	ctrl.Observe(rollout.VariantCanary, false)
+
+	status := ctrl.Status()
+	assert.Equal(t, rollout.StatePromoted, status.State)
+	assert.Equal(t, "v2", status.Stable)
+	value, variant := ctrl.Select()
+	assert.Equal(t, "v2", value)
+	assert.Equal(t, rollout.VariantStable, variant)
+}
+
+func TestController_ManualPromoteAndRollback(t *testing.T) {
+	ctrl := rollout.NewController("test-manual", "v1", nil)
+	require.ErrorIs(t, ctrl.Promote("manual"), rollout.ErrNotInProgress)
+	require.ErrorIs(t, ctrl.Rollback("manual"), rollout.ErrNotInProgress)
+
+	require.NoError(t, ctrl.Start("v2", rollout.DefaultPolicy()))
+	require.NoError(t, ctrl.Rollback("manual"))
+	assert.Equal(t, "v1", ctrl.Stable())
+
+	require.NoError(t, ctrl.Start("v3", rollout.DefaultPolicy()))
+	require.NoError(t, ctrl.Promote("manual"))
+	assert.Equal(t, "v3", ctrl.Stable())
+	assert.Equal(t, "manual", ctrl.Status().Reason)
+}
+
+func TestController_SetStable(t *testing.T) {
+	ctrl := rollout.NewController("test-set-stable", "v1", nil)
+	require.NoError(t, ctrl.SetStable("v2"))
+	assert.Equal(t, "v2", ctrl.Stable())
+
+	require.NoError(t, ctrl.Start("v3", rollout.DefaultPolicy()))
+	require.ErrorIs(t, ctrl.SetStable("v4"), rollout.ErrInProgress)
+	assert.Equal(t, "v2", ctrl.Stable())
+}
+
+func TestPolicy_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(rollout.DefaultPolicy())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"percent":10,"bakeTime":"5m0s","minRequests":100,"maxErrorRateIncrease":0.05}`, string(data))
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/redact"
+	"github.com/piwi3910/netweave/internal/rollout"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// AdminStats is the state of a gateway instance shown by GET /admin/stats and
+// included in support bundles. Both render it with redact.Tree, so fields
+// tagged redact:"true" in it or in the types it holds are masked.
+type AdminStats struct {
+	GeneratedAt     time.Time                       `json:"generatedAt"`
+	Adapter         string                          `json:"adapter,omitempty"`
+	Runtime         RuntimeStats                    `json:"runtime"`
+	Subscriptions   SubscriptionCounts              `json:"subscriptions"`
+	RuntimeSettings rollout.Status[RuntimeSettings] `json:"runtimeSettings"`
+	VersionAdoption *VersionAdoptionReport          `json:"versionAdoption,omitempty"`
+}
+
+// RuntimeStats describes the Go runtime of the gateway process.
+type RuntimeStats struct {
+	GoVersion      string `json:"goVersion"`
+	CPUs           int    `json:"cpus"`
+	Goroutines     int    `json:"goroutines"`
+	HeapAllocBytes uint64 `json:"heapAllocBytes"`
+	HeapObjects    uint64 `json:"heapObjects"`
+	GCCycles       uint32 `json:"gcCycles"`
+}
+
+// SubscriptionCounts counts the stored O2-IMS subscriptions.
+type SubscriptionCounts struct {
+	Total     int `json:"total"`
+	Suspended int `json:"suspended"`
+}
+
+// supportBundleHistoryLimit is the number of configuration snapshots included
+// in a support bundle.
+const supportBundleHistoryLimit = DefaultConfigHistoryLimit
+
+// supportBundleFile is a JSON file of a support bundle.
+type supportBundleFile struct {
+	name    string
+	content interface{}
+}
+
+// adminStats collects the stats of this gateway instance.
+func (s *Server) adminStats(ctx context.Context) (*AdminStats, error) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := &AdminStats{
+		GeneratedAt: timeutil.Now(),
+		Runtime: RuntimeStats{
+			GoVersion:      runtime.Version(),
+			CPUs:           runtime.NumCPU(),
+			Goroutines:     runtime.NumGoroutine(),
+			HeapAllocBytes: mem.HeapAlloc,
+			HeapObjects:    mem.HeapObjects,
+			GCCycles:       mem.NumGC,
+		},
+		RuntimeSettings: s.runtimeSettings.Status(),
+	}
+	if s.adapter != nil {
+		stats.Adapter = s.adapter.Name()
+	}
+	if s.versionAdoption != nil {
+		stats.VersionAdoption = s.versionAdoption.Report(0)
+	}
+	if s.store != nil {
+		subs, err := s.store.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		stats.Subscriptions.Total = len(subs)
+		for _, sub := range subs {
+			if sub.Suspended() {
+				stats.Subscriptions.Suspended++
+			}
+		}
+	}
+	return stats, nil
+}
+
+// handleAdminStats returns the stats of this gateway instance, redacted.
+// GET /admin/stats.
+func (s *Server) handleAdminStats(c *gin.Context) {
+	stats, err := s.adminStats(c.Request.Context())
+	if err != nil {
+		s.requestLogger(c).Error("failed to collect admin stats", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to collect stats")
+		return
+	}
+	c.JSON(http.StatusOK, redact.Tree(stats, "json"))
+}
+
+// handleSupportBundle returns a zip archive for support cases holding the
+// redacted configuration, the stats and the recent configuration history.
+// Every file goes through the same redaction as the admin endpoints.
+// GET /admin/support-bundle.
+func (s *Server) handleSupportBundle(c *gin.Context) {
+	ctx := c.Request.Context()
+	stats, err := s.adminStats(ctx)
+	if err != nil {
+		s.requestLogger(c).Error("failed to collect admin stats for support bundle", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to collect stats")
+		return
+	}
+
+	files := []supportBundleFile{
+		{"config.json", config.Redacted(s.config)},
+		{"stats.json", redact.Tree(stats, "json")},
+	}
+	if s.configHistory != nil {
+		snapshots, err := s.configHistory.List(ctx, supportBundleHistoryLimit)
+		if err != nil {
+			s.requestLogger(c).Error("failed to list config history for support bundle", zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError",
+				"Failed to retrieve configuration history")
+			return
+		}
+		files = append(files, supportBundleFile{"config-history.json", configHistoryResponse(snapshots)})
+	}
+
+	c.Header("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="netweave-support-%s.zip"`, stats.GeneratedAt.UTC().Format("20060102T150405Z")))
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+
+	archive := zip.NewWriter(c.Writer)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			s.requestLogger(c).Error("failed to write support bundle", zap.Error(err))
+			return
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.content); err != nil {
+			s.requestLogger(c).Error("failed to write support bundle", zap.Error(err))
+			return
+		}
+	}
+	if err := archive.Close(); err != nil {
+		s.requestLogger(c).Error("failed to write support bundle", zap.Error(err))
+	}
+}
@@ -0,0 +1,82 @@
+package server_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestAdminStats(t *testing.T) {
+	srv, _, _ := setupRevalidationTestServer(t)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, "/admin/stats", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var stats struct {
+		Runtime struct {
+			Goroutines int `json:"goroutines"`
+		} `json:"runtime"`
+		Subscriptions   server.SubscriptionCounts `json:"subscriptions"`
+		RuntimeSettings struct {
+			State  string `json:"state"`
+			Stable struct {
+				RequestTimeout string `json:"requestTimeout"`
+			} `json:"stable"`
+		} `json:"runtimeSettings"`
+	}
+	require.NoError(t, json.Unmarshal(body, &stats))
+	assert.Positive(t, stats.Runtime.Goroutines)
+	assert.Equal(t, server.SubscriptionCounts{Total: 2}, stats.Subscriptions)
+	assert.Equal(t, "idle", stats.RuntimeSettings.State)
+	assert.Equal(t, "0s", stats.RuntimeSettings.Stable.RequestTimeout)
+}
+
+func TestSupportBundle_Redacted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Redis:         config.RedisConfig{Password: "redis-s3cret"},
+		Notifications: config.NotificationsConfig{HMACSecret: "hmac-s3cret"},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), newMockResourceAdapter(), &mockStore{})
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, "/admin/support-bundle", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	assert.Equal(t, "application/zip", resp.Header().Get("Content-Type"))
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		files[file.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+		_ = r.Close()
+	}
+	require.Contains(t, files, "config.json")
+	require.Contains(t, files, "stats.json")
+	assert.NotContains(t, files, "config-history.json", "config history is not enabled")
+
+	for name, content := range files {
+		assert.NotContains(t, string(content), "s3cret", name)
+	}
+	var redacted struct {
+		Redis         map[string]interface{} `json:"redis"`
+		Notifications map[string]interface{} `json:"notifications"`
+	}
+	require.NoError(t, json.Unmarshal(files["config.json"], &redacted))
+	assert.Equal(t, config.RedactedValue, redacted.Redis["password"])
+	assert.Equal(t, config.RedactedValue, redacted.Notifications["hmac_secret"])
+}
@@ -3,9 +3,50 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/config"
 	"github.com/piwi3910/netweave/internal/handlers"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
+// AuthPoliciesFromConfig converts the configured auth policy matrix into
+// middleware policy rules. Deprecated skip_auth_paths entries become anonymous
+// rules evaluated first.
+func AuthPoliciesFromConfig(cfg *config.MultiTenancyConfig) []auth.PolicyRule {
+	policies := make([]auth.PolicyRule, 0, len(cfg.SkipAuthPaths)+len(cfg.AuthPolicies))
+	for _, path := range cfg.SkipAuthPaths {
+		policies = append(policies, auth.PolicyRule{Paths: []string{path}, Level: auth.AuthLevelAnonymous})
+	}
+	for _, policy := range cfg.AuthPolicies {
+		roles := make([]auth.RoleName, len(policy.Roles))
+		for i, role := range policy.Roles {
+			roles[i] = auth.RoleName(role)
+		}
+		policies = append(policies, auth.PolicyRule{
+			Paths:   policy.Paths,
+			Methods: policy.Methods,
+			Level:   auth.AuthLevel(policy.Level),
+			Roles:   roles,
+		})
+	}
+	return policies
+}
+
+// IdentityMappingsFromConfig converts the configured identity mapping rules
+// into auth middleware rules.
+func IdentityMappingsFromConfig(cfg *config.MultiTenancyConfig) []auth.IdentityMappingRule {
+	rules := make([]auth.IdentityMappingRule, 0, len(cfg.IdentityMappings))
+	for _, mapping := range cfg.IdentityMappings {
+		rules = append(rules, auth.IdentityMappingRule{
+			Name:      mapping.Name,
+			Attribute: auth.CertificateAttribute(mapping.Attribute),
+			Pattern:   mapping.Pattern,
+			Tenant:    mapping.Tenant,
+			Role:      auth.RoleName(mapping.Role),
+		})
+	}
+	return rules
+}
+
 // AuthHandlers contains all handlers for authentication and authorization.
 type AuthHandlers struct {
 	Tenant *handlers.TenantHandler
@@ -22,6 +63,7 @@ func (s *Server) SetupAuthRoutes(authStore auth.Store, authMw *auth.Middleware)
 	userHandler := handlers.NewUserHandler(authStore, s.logger)
 	roleHandler := handlers.NewRoleHandler(authStore, s.logger)
 	auditHandler := handlers.NewAuditHandler(authStore, s.logger)
+	identityMappingHandler := handlers.NewIdentityMappingHandler(authMw, s.logger)
 
 	// Platform Admin Routes (/admin/*)
 	// These require platform-admin role.
@@ -45,6 +87,9 @@ func (s *Server) SetupAuthRoutes(authStore auth.Store, authMw *auth.Middleware)
 
 		// Platform-level audit logs.
 		admin.GET("/audit/events", auditHandler.ListAuditEvents)
+
+		// Dry run of the mTLS identity mapping rules.
+		admin.POST("/identity-mappings/test", identityMappingHandler.TestIdentityMapping)
 	}
 
 	// Tenant Routes (/tenant/*)
@@ -103,9 +148,8 @@ func (s *Server) wrapWithTenantContext(handler gin.HandlerFunc) gin.HandlerFunc
 	return func(c *gin.Context) {
 		tenantID := c.Param("tenantId")
 		if tenantID != "" {
-			c.Set("tenant_id", tenantID)
 			// Update context with tenant ID for handlers that use context.
-			ctx := auth.ContextWithRequestID(c.Request.Context(), c.GetString("request_id"))
+			ctx := requestcontext.WithTenant(c.Request.Context(), tenantID)
 			// Create a minimal authenticated user for context.
 			user := auth.UserFromContext(c.Request.Context())
 			if user != nil {
@@ -119,8 +163,8 @@ func (s *Server) wrapWithTenantContext(handler gin.HandlerFunc) gin.HandlerFunc
 					IsPlatformAdmin: user.IsPlatformAdmin,
 				}
 				ctx = auth.ContextWithUser(ctx, adminUser)
-				c.Request = c.Request.WithContext(ctx)
 			}
+			c.Request = c.Request.WithContext(ctx)
 		}
 		handler(c)
 	}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/resolver"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Callback probe step names.
+const (
+	ProbeStepURL  = "url"
+	ProbeStepDNS  = "dns"
+	ProbeStepTCP  = "tcp"
+	ProbeStepTLS  = "tls"
+	ProbeStepHTTP = "http"
+)
+
+// Callback probe step statuses.
+const (
+	ProbeStatusOK      = "ok"
+	ProbeStatusFailed  = "failed"
+	ProbeStatusSkipped = "skipped"
+)
+
+// DefaultCallbackProbeTimeout bounds each step of a callback reachability probe.
+const DefaultCallbackProbeTimeout = 5 * time.Second
+
+// CallbackDiagnostics is the result of a live reachability probe of a subscription callback.
+// Results are sanitized: resolved addresses and raw network errors are never exposed,
+// only step outcomes and coarse failure categories.
+type CallbackDiagnostics struct {
+	CheckedAt time.Time             `json:"checkedAt"`
+	Reachable bool                  `json:"reachable"`
+	Steps     []CallbackProbeResult `json:"steps"`
+}
+
+// CallbackProbeResult describes the outcome of a single probe step.
+type CallbackProbeResult struct {
+	Step       string `json:"step"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// CallbackProbeOptions configures a callback reachability probe.
+type CallbackProbeOptions struct {
+	// Timeout bounds each probe step. Defaults to DefaultCallbackProbeTimeout.
+	Timeout time.Duration
+
+	// AllowPrivate permits probing callbacks that resolve to private or loopback
+	// addresses. It must only be set when SSRF protection is disabled.
+	AllowPrivate bool
+}
+
+// SubscriptionWithDiagnostics is the GET subscription response when ?diagnose=true.
+type SubscriptionWithDiagnostics struct {
+	*adapter.Subscription
+	Diagnostics *CallbackDiagnostics `json:"diagnostics"`
+}
+
+// DiagnoseCallback performs a live reachability probe of callbackURL: DNS resolution,
+// TCP connect, TLS handshake (for https), and an HTTP HEAD request. Probing stops at the
+// first failed step; remaining steps are reported as skipped.
+//
+// The TCP, TLS, and HTTP steps connect to the address validated during the DNS step so
+// that the probe cannot be redirected to an internal address by DNS rebinding.
+func DiagnoseCallback(ctx context.Context, callbackURL string, opts CallbackProbeOptions) *CallbackDiagnostics {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultCallbackProbeTimeout
+	}
+
+	p := &callbackProber{
+		opts:  opts,
+		diags: &CallbackDiagnostics{CheckedAt: timeutil.Now()},
+	}
+	p.run(ctx, callbackURL)
+
+	return p.diags
+}
+
+// callbackProber carries state between probe steps.
+type callbackProber struct {
+	opts   CallbackProbeOptions
+	diags  *CallbackDiagnostics
+	parsed *url.URL
+	addr   string
+}
+
+func (p *callbackProber) run(ctx context.Context, callbackURL string) {
+	steps := []struct {
+		name string
+		fn   func(context.Context) (string, error)
+	}{
+		{ProbeStepURL, func(context.Context) (string, error) { return p.parseURL(callbackURL) }},
+		{ProbeStepDNS, p.resolve},
+		{ProbeStepTCP, p.dialTCP},
+		{ProbeStepTLS, p.handshakeTLS},
+		{ProbeStepHTTP, p.head},
+	}
+
+	failed := false
+	for _, step := range steps {
+		if failed {
+			p.record(step.name, ProbeStatusSkipped, 0, "previous step failed")
+			continue
+		}
+		if step.name == ProbeStepTLS && p.parsed.Scheme != "https" {
+			p.record(step.name, ProbeStatusSkipped, 0, "callback does not use https")
+			continue
+		}
+
+		start := time.Now()
+		stepCtx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+		detail, err := step.fn(stepCtx)
+		cancel()
+
+		if err != nil {
+			failed = true
+			p.record(step.name, ProbeStatusFailed, time.Since(start), err.Error())
+			continue
+		}
+		p.record(step.name, ProbeStatusOK, time.Since(start), detail)
+	}
+
+	p.diags.Reachable = !failed
+}
+
+func (p *callbackProber) record(step, status string, d time.Duration, detail string) {
+	p.diags.Steps = append(p.diags.Steps, CallbackProbeResult{
+		Step:       step,
+		Status:     status,
+		DurationMs: d.Milliseconds(),
+		Detail:     detail,
+	})
+}
+
+func (p *callbackProber) parseURL(callbackURL string) (string, error) {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || parsed.Host == "" {
+		return "", errors.New("callback URL is malformed")
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", errors.New("callback URL must use http or https scheme")
+	}
+	p.parsed = parsed
+	return "", nil
+}
+
+func (p *callbackProber) resolve(ctx context.Context) (string, error) {
+	host := p.parsed.Hostname()
+	port := p.parsed.Port()
+	if port == "" {
+		port = "80"
+		if p.parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := resolver.Default().LookupIPAddr(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			return "", errors.New("hostname could not be resolved")
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+
+	if !p.opts.AllowPrivate {
+		for _, ip := range ips {
+			if resolver.BlockedReason(ip) != "" {
+				return "", errors.New("hostname resolves to a private or loopback address blocked by SSRF policy")
+			}
+		}
+	}
+
+	p.addr = net.JoinHostPort(ips[0].String(), port)
+	return fmt.Sprintf("resolved %d address(es)", len(ips)), nil
+}
+
+func (p *callbackProber) dialTCP(ctx context.Context) (string, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return "", errors.New(classifyProbeError(err))
+	}
+	_ = conn.Close()
+	return "connection established", nil
+}
+
+func (p *callbackProber) handshakeTLS(ctx context.Context) (string, error) {
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			ServerName: p.parsed.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return "", errors.New(classifyProbeError(err))
+	}
+	defer func() { _ = conn.Close() }()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	return "negotiated " + tls.VersionName(state.Version), nil
+}
+
+func (p *callbackProber) head(ctx context.Context) (string, error) {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, p.addr)
+		},
+		TLSClientConfig: &tls.Config{
+			ServerName: p.parsed.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		},
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.parsed.String(), nil)
+	if err != nil {
+		return "", errors.New("failed to build HEAD request")
+	}
+	req.Header.Set("User-Agent", "netweave-callback-diagnostics")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.New(classifyProbeError(err))
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return "", fmt.Errorf("HEAD returned HTTP %d", resp.StatusCode)
+	}
+	return fmt.Sprintf("HEAD returned HTTP %d", resp.StatusCode), nil
+}
+
+// classifyProbeError maps a network error to a coarse, sanitized category.
+func classifyProbeError(err error) string {
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCertErr x509.CertificateInvalidError
+	var recordErr tls.RecordHeaderError
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded):
+		return "timed out"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection refused"
+	case errors.Is(err, syscall.ECONNRESET):
+		return "connection reset by peer"
+	case errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH):
+		return "host unreachable"
+	case errors.As(err, &unknownAuthErr):
+		return "certificate signed by unknown authority"
+	case errors.As(err, &hostnameErr):
+		return "certificate does not match hostname"
+	case errors.As(err, &invalidCertErr):
+		return "certificate is invalid or expired"
+	case errors.As(err, &certErr):
+		return "certificate verification failed"
+	case errors.As(err, &recordErr):
+		return "endpoint does not speak TLS"
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timed out"
+	}
+	if strings.Contains(err.Error(), "tls:") {
+		return "TLS handshake failed"
+	}
+	return "connection failed"
+}
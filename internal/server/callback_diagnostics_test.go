@@ -0,0 +1,125 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func probeStatuses(diags *server.CallbackDiagnostics) map[string]string {
+	statuses := make(map[string]string, len(diags.Steps))
+	for _, step := range diags.Steps {
+		statuses[step.Step] = step.Status
+	}
+	return statuses
+}
+
+func TestDiagnoseCallback(t *testing.T) {
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpSrv.Close()
+
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer tlsSrv.Close()
+
+	// Reserve a port and close it so connections are refused.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	tests := []struct {
+		name          string
+		callback      string
+		allowPrivate  bool
+		wantReachable bool
+		wantStatuses  map[string]string
+		wantDetail    map[string]string
+	}{
+		{
+			name:          "reachable http callback",
+			callback:      httpSrv.URL + "/notify",
+			allowPrivate:  true,
+			wantReachable: true,
+			wantStatuses: map[string]string{
+				server.ProbeStepDNS:  server.ProbeStatusOK,
+				server.ProbeStepTCP:  server.ProbeStatusOK,
+				server.ProbeStepTLS:  server.ProbeStatusSkipped,
+				server.ProbeStepHTTP: server.ProbeStatusOK,
+			},
+			wantDetail: map[string]string{server.ProbeStepHTTP: "HEAD returned HTTP 204"},
+		},
+		{
+			name:     "private address blocked by SSRF policy",
+			callback: httpSrv.URL + "/notify",
+			wantStatuses: map[string]string{
+				server.ProbeStepDNS: server.ProbeStatusFailed,
+				server.ProbeStepTCP: server.ProbeStatusSkipped,
+			},
+		},
+		{
+			name:         "connection refused",
+			callback:     "http://" + closedAddr + "/notify",
+			allowPrivate: true,
+			wantStatuses: map[string]string{
+				server.ProbeStepTCP:  server.ProbeStatusFailed,
+				server.ProbeStepHTTP: server.ProbeStatusSkipped,
+			},
+			wantDetail: map[string]string{server.ProbeStepTCP: "connection refused"},
+		},
+		{
+			name:         "untrusted certificate",
+			callback:     tlsSrv.URL + "/notify",
+			allowPrivate: true,
+			wantStatuses: map[string]string{
+				server.ProbeStepTCP: server.ProbeStatusOK,
+				server.ProbeStepTLS: server.ProbeStatusFailed,
+			},
+		},
+		{
+			name:     "invalid scheme",
+			callback: "ftp://example.com/notify",
+			wantStatuses: map[string]string{
+				server.ProbeStepURL: server.ProbeStatusFailed,
+				server.ProbeStepDNS: server.ProbeStatusSkipped,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := server.DiagnoseCallback(context.Background(), tt.callback, server.CallbackProbeOptions{
+				Timeout:      2 * time.Second,
+				AllowPrivate: tt.allowPrivate,
+			})
+
+			require.NotNil(t, diags)
+			assert.Equal(t, tt.wantReachable, diags.Reachable)
+			assert.Len(t, diags.Steps, 5)
+
+			statuses := probeStatuses(diags)
+			for step, status := range tt.wantStatuses {
+				assert.Equal(t, status, statuses[step], "step %s", step)
+			}
+
+			for _, step := range diags.Steps {
+				if want, ok := tt.wantDetail[step.Step]; ok {
+					assert.Equal(t, want, step.Detail)
+				}
+				// Sanitized output must never leak resolved addresses.
+				assert.NotContains(t, step.Detail, "127.0.0.1")
+			}
+		})
+	}
+}
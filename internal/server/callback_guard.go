@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
+)
+
+// CallbackDialGuard returns the guard the named notification client dials
+// subscription callbacks through, or nil without a configuration. It repeats
+// the private address check of ValidateCallback on the addresses each
+// delivery connects to, so a callback host rebound to an internal address
+// after it was registered is refused. The check is skipped while SSRF
+// protection is disabled, which a configuration reload can change. Refused
+// deliveries are logged and written to the audit log.
+func (s *Server) CallbackDialGuard(client string) *resolver.Guard {
+	if s.config == nil {
+		return nil
+	}
+	return &resolver.Guard{
+		Client: client,
+		Disabled: func() bool {
+			return s.callbackSecuritySettings().disableSSRFProtection
+		},
+		OnBlocked: func(ctx context.Context, err *resolver.BlockedError) {
+			s.logger.Warn("refused webhook delivery to a blocked callback address",
+				zap.String("client", client),
+				zap.String("host", err.Host),
+				zap.String("address", err.IP.String()),
+				zap.String("reason", err.Reason))
+			if s.auditLogger != nil {
+				s.auditLogger.LogWebhookBlocked(ctx, client, err.Host, err.IP.String(), err.Reason)
+			}
+		},
+	}
+}
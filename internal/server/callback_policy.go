@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/resolver"
+)
+
+// hostPattern is a compiled callback allowlist or denylist entry.
+type hostPattern struct {
+	// host is an exact hostname or IP address.
+	host string
+
+	// suffix is the domain suffix of a "*.example.com" wildcard, including
+	// the leading dot. It does not match the bare domain.
+	suffix string
+
+	// network is set for CIDR entries.
+	network *net.IPNet
+}
+
+// compileHostPatterns compiles allowlist or denylist entries. Invalid entries
+// are rejected by configuration validation and skipped here.
+func compileHostPatterns(entries []string) []hostPattern {
+	patterns := make([]hostPattern, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case strings.Contains(entry, "/"):
+			if _, network, err := net.ParseCIDR(entry); err == nil {
+				patterns = append(patterns, hostPattern{network: network})
+			}
+		case strings.HasPrefix(entry, "*."):
+			patterns = append(patterns, hostPattern{suffix: entry[1:]})
+		case entry != "":
+			patterns = append(patterns, hostPattern{host: entry})
+		}
+	}
+	return patterns
+}
+
+// matchesName reports whether the pattern matches a hostname.
+func (p hostPattern) matchesName(host string) bool {
+	switch {
+	case p.host != "":
+		return host == p.host
+	case p.suffix != "":
+		return strings.HasSuffix(host, p.suffix)
+	}
+	return false
+}
+
+// hasNetworks reports whether any pattern is a CIDR.
+func hasNetworks(patterns []hostPattern) bool {
+	for _, p := range patterns {
+		if p.network != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// deniedHost reports whether the host name or any of its addresses matches a pattern.
+func deniedHost(patterns []hostPattern, host string, ips []net.IP) bool {
+	for _, p := range patterns {
+		if p.matchesName(host) {
+			return true
+		}
+		for _, ip := range ips {
+			if p.network != nil && p.network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allowedHost reports whether the host name matches a pattern or all of its
+// addresses fall into allowed networks.
+func allowedHost(patterns []hostPattern, host string, ips []net.IP) bool {
+	for _, p := range patterns {
+		if p.matchesName(host) {
+			return true
+		}
+	}
+	if len(ips) == 0 {
+		return false
+	}
+	for _, ip := range ips {
+		inNetwork := false
+		for _, p := range patterns {
+			if p.network != nil && p.network.Contains(ip) {
+				inNetwork = true
+				break
+			}
+		}
+		if !inNetwork {
+			return false
+		}
+	}
+	return true
+}
+
+// callbackSecurity holds the callback security settings. They are replaced
+// as a whole when the configuration is reloaded.
+type callbackSecurity struct {
+	disableSSRFProtection bool
+	allow                 []hostPattern
+	deny                  []hostPattern
+}
+
+// newCallbackSecurity compiles the callback security settings of cfg.
+func newCallbackSecurity(cfg *config.SecurityConfig) *callbackSecurity {
+	return &callbackSecurity{
+		disableSSRFProtection: cfg.DisableSSRFProtection,
+		allow:                 compileHostPatterns(cfg.CallbackAllowlist),
+		deny:                  compileHostPatterns(cfg.CallbackDenylist),
+	}
+}
+
+// callbackSecuritySettings returns the current callback security settings,
+// compiling the configured ones on first use.
+func (s *Server) callbackSecuritySettings() *callbackSecurity {
+	if sec := s.callbackSecurity.Load(); sec != nil {
+		return sec
+	}
+	var cfg config.SecurityConfig
+	if s.config != nil {
+		cfg = s.config.Security
+	}
+	s.callbackSecurity.CompareAndSwap(nil, newCallbackSecurity(&cfg))
+	return s.callbackSecurity.Load()
+}
+
+// checkCallbackPolicy checks a callback host against the configured callback
+// allowlist and denylist. Hostnames are only resolved when a list holds CIDRs;
+// as with SSRF protection, a host that cannot be resolved is only checked by name.
+func (s *Server) checkCallbackPolicy(ctx context.Context, hostname string) error {
+	sec := s.callbackSecuritySettings()
+	allow, deny := sec.allow, sec.deny
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(hostname, "."))
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else if hasNetworks(allow) || hasNetworks(deny) {
+		addrs, _ := resolver.Default().LookupIPAddr(ctx, host)
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	if deniedHost(deny, host, ips) {
+		return fmt.Errorf("callback host %s is denied by the callback policy", host)
+	}
+	if len(allow) > 0 && !allowedHost(allow, host, ips) {
+		return fmt.Errorf("callback host %s is not in the callback allowlist", host)
+	}
+	return nil
+}
@@ -0,0 +1,446 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	dmshandlers "github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// callbackRevalidationActor is the audit log user of suspensions made by the
+// revalidation job.
+const callbackRevalidationActor = "system:callback-revalidation"
+
+// NotificationTypeSubscriptionSuspended is the notification type of
+// SubscriptionSuspendedNotification.
+const NotificationTypeSubscriptionSuspended = "SubscriptionSuspended"
+
+// tenantNotificationTimeout bounds a call to a tenant notification callback.
+const tenantNotificationTimeout = 10 * time.Second
+
+// tenantNotificationClient calls tenant notification callbacks. Redirects are
+// not followed, as their targets were not checked against the callback policy.
+var tenantNotificationClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Callback revalidation metrics.
+var (
+	callbackRevalidationRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "callback_revalidation_runs_total",
+			Help:      "Total number of callback revalidation runs by result",
+		},
+		[]string{"result"},
+	)
+
+	callbackRevalidationChecked = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "callback_revalidation_checked",
+			Help:      "Number of subscription callbacks checked by the last revalidation run",
+		},
+	)
+
+	callbackRevalidationViolations = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "callback_policy_violations",
+			Help:      "Number of subscription callbacks violating the callback policy in the last revalidation run",
+		},
+	)
+
+	subscriptionsSuspended = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "suspended",
+			Help:      "Number of suspended subscriptions after the last revalidation run",
+		},
+	)
+
+	subscriptionSuspensions = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "suspensions_total",
+			Help:      "Total number of subscriptions suspended for violating the callback policy",
+		},
+	)
+
+	suspensionNotifications = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "suspension_notifications_total",
+			Help:      "Total number of suspension notifications sent to tenant notification callbacks by result",
+		},
+		[]string{"result"},
+	)
+)
+
+// CallbackRevalidationReport summarizes a check of the stored subscription
+// callbacks against the current callback policy.
+type CallbackRevalidationReport struct {
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+
+	// Checked is the number of subscriptions checked.
+	Checked int `json:"checked"`
+
+	// Violations is the number of callbacks violating the policy, including
+	// those of subscriptions that were already suspended.
+	Violations int `json:"violations"`
+
+	// Suspended is the number of subscriptions suspended by this run.
+	Suspended int `json:"suspended"`
+
+	// TotalSuspended is the number of suspended subscriptions after the run.
+	TotalSuspended int `json:"totalSuspended"`
+
+	// Errors is the number of violators that could not be suspended.
+	Errors int `json:"errors"`
+}
+
+// SuspendedSubscription is a subscription suspended for violating the callback policy.
+type SuspendedSubscription struct {
+	SubscriptionID string    `json:"subscriptionId"`
+	TenantID       string    `json:"tenantId,omitempty"`
+	Callback       string    `json:"callback"`
+	Reason         string    `json:"reason"`
+	SuspendedAt    time.Time `json:"suspendedAt"`
+}
+
+// SubscriptionSuspendedNotification is posted to the notification callback of
+// the tenant owning a subscription that was suspended.
+type SubscriptionSuspendedNotification struct {
+	NotificationType string    `json:"notificationType"`
+	TenantID         string    `json:"tenantId"`
+	SubscriptionID   string    `json:"subscriptionId"`
+	Callback         string    `json:"callback"`
+	Reason           string    `json:"reason"`
+	SuspendedAt      time.Time `json:"suspendedAt"`
+}
+
+// SuspendedSubscriptionsReport lists the suspended subscriptions.
+type SuspendedSubscriptionsReport struct {
+	Subscriptions []SuspendedSubscription `json:"subscriptions"`
+	Total         int                     `json:"total"`
+
+	// LastRun is the last revalidation run of this gateway instance, if any.
+	LastRun *CallbackRevalidationReport `json:"lastRun,omitempty"`
+}
+
+// callbackRevalidation serializes revalidation runs and keeps the last report.
+type callbackRevalidation struct {
+	running sync.Mutex
+
+	mu      sync.Mutex
+	lastRun *CallbackRevalidationReport
+}
+
+// last returns the report of the last run, or nil.
+func (r *callbackRevalidation) last() *CallbackRevalidationReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastRun
+}
+
+// RevalidateCallbacks checks every stored subscription callback against the
+// current SSRF protection, callback allowlist and denylist, so that policy
+// changes and DNS changes apply to existing subscriptions. Violators are
+// suspended and their tenant is notified through the audit log and its
+// notification callback. Suspended subscriptions stay suspended until their
+// callback is updated.
+func (s *Server) RevalidateCallbacks(ctx context.Context) (*CallbackRevalidationReport, error) {
+	s.revalidation.running.Lock()
+	defer s.revalidation.running.Unlock()
+
+	report := &CallbackRevalidationReport{StartedAt: timeutil.Now()}
+	subs, err := s.store.List(ctx)
+	if err != nil {
+		callbackRevalidationRuns.WithLabelValues("error").Inc()
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	for _, sub := range subs {
+		report.Checked++
+		violation := s.ValidateCallback(ctx, &adapter.Subscription{Callback: sub.Callback})
+		if violation == nil {
+			if sub.Suspended() {
+				report.TotalSuspended++
+			}
+			continue
+		}
+
+		report.Violations++
+		if sub.Suspended() {
+			report.TotalSuspended++
+			continue
+		}
+		if err := s.suspendSubscription(ctx, sub, violation.Error()); err != nil {
+			s.logger.Error("failed to suspend subscription with disallowed callback",
+				zap.String("subscription_id", sub.ID),
+				zap.Error(err))
+			report.Errors++
+			continue
+		}
+		report.Suspended++
+		report.TotalSuspended++
+	}
+	report.CompletedAt = timeutil.Now()
+
+	callbackRevalidationRuns.WithLabelValues("success").Inc()
+	callbackRevalidationChecked.Set(float64(report.Checked))
+	callbackRevalidationViolations.Set(float64(report.Violations))
+	subscriptionsSuspended.Set(float64(report.TotalSuspended))
+
+	s.revalidation.mu.Lock()
+	s.revalidation.lastRun = report
+	s.revalidation.mu.Unlock()
+
+	return report, nil
+}
+
+// StartCallbackRevalidation revalidates stored callbacks at the configured
+// interval until ctx is canceled. The first run starts immediately, so policy
+// changes apply as soon as the gateway restarts with them.
+func (s *Server) StartCallbackRevalidation(ctx context.Context) {
+	interval := s.config.Security.CallbackRevalidationInterval
+	if interval <= 0 || s.store == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			report, err := s.RevalidateCallbacks(ctx)
+			switch {
+			case err != nil:
+				s.logger.Error("callback revalidation failed", zap.Error(err))
+			case report.Violations > 0:
+				s.logger.Warn("callback revalidation found policy violations",
+					zap.Int("checked", report.Checked),
+					zap.Int("violations", report.Violations),
+					zap.Int("suspended", report.Suspended))
+			default:
+				s.logger.Debug("callback revalidation completed", zap.Int("checked", report.Checked))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// suspendSubscription suspends a subscription and notifies its tenant.
+func (s *Server) suspendSubscription(ctx context.Context, sub *storage.Subscription, reason string) error {
+	now := timeutil.Now()
+	sub.SuspendedAt = &now
+	sub.SuspensionReason = reason
+	if err := s.store.Update(ctx, sub); err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+	subscriptionSuspensions.Inc()
+
+	s.logger.Warn("subscription suspended: callback violates the callback policy",
+		zap.String("subscription_id", sub.ID),
+		zap.String("tenant_id", sub.TenantID),
+		zap.String("callback", sub.Callback),
+		zap.String("reason", reason))
+
+	if s.auditLogger != nil {
+		s.auditLogger.LogSubscriptionOperation(ctx, auth.AuditEventSubscriptionSuspended, sub.ID, sub.Callback,
+			&auth.AuthenticatedUser{UserID: callbackRevalidationActor, TenantID: sub.TenantID},
+			map[string]string{
+				"reason":    reason,
+				"tenant_id": sub.TenantID,
+			})
+	}
+
+	s.notifyTenantOfSuspension(ctx, sub)
+	return nil
+}
+
+// notifyTenantOfSuspension posts a SubscriptionSuspendedNotification to the
+// notification callback of the tenant owning a suspended subscription, if the
+// tenant set one. Failures are logged and counted; the suspension stands.
+func (s *Server) notifyTenantOfSuspension(ctx context.Context, sub *storage.Subscription) {
+	tenants := s.tenantGetter()
+	if tenants == nil || sub.TenantID == "" {
+		return
+	}
+	logger := s.logger.With(
+		zap.String("subscription_id", sub.ID),
+		zap.String("tenant_id", sub.TenantID))
+
+	tenant, err := tenants.GetTenant(ctx, sub.TenantID)
+	if err != nil {
+		logger.Warn("failed to look up tenant to notify of subscription suspension", zap.Error(err))
+		suspensionNotifications.WithLabelValues("failed").Inc()
+		return
+	}
+	if tenant.NotificationCallback == "" {
+		return
+	}
+
+	err = s.postTenantNotification(ctx, tenant.NotificationCallback, &SubscriptionSuspendedNotification{
+		NotificationType: NotificationTypeSubscriptionSuspended,
+		TenantID:         sub.TenantID,
+		SubscriptionID:   sub.ID,
+		Callback:         dmshandlers.RedactURL(sub.Callback),
+		Reason:           sub.SuspensionReason,
+		SuspendedAt:      *sub.SuspendedAt,
+	})
+	if err != nil {
+		logger.Warn("failed to notify tenant of subscription suspension",
+			zap.String("notification_callback", dmshandlers.RedactURL(tenant.NotificationCallback)),
+			zap.Error(err))
+		suspensionNotifications.WithLabelValues("failed").Inc()
+		return
+	}
+	suspensionNotifications.WithLabelValues("delivered").Inc()
+}
+
+// postTenantNotification posts a notification to a tenant notification
+// callback, which must satisfy the callback policy like subscription callbacks.
+func (s *Server) postTenantNotification(ctx context.Context, callback string, notification interface{}) error {
+	if err := s.ValidateCallback(ctx, &adapter.Subscription{Callback: callback}); err != nil {
+		return fmt.Errorf("notification callback rejected: %w", err)
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tenantNotificationTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callback, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tenantNotificationClient.Do(req)
+	if err != nil {
+		// Drop the URL from the error, it may carry credentials.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to call notification callback: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification callback returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// resumeSubscription lifts the suspension of a subscription whose callback was
+// updated to one that passed validation.
+func (s *Server) resumeSubscription(c *gin.Context, subscriptionID, callback string) {
+	if s.store == nil {
+		return
+	}
+	ctx := c.Request.Context()
+	sub, err := s.store.Get(ctx, subscriptionID)
+	if err != nil || !sub.Suspended() {
+		return
+	}
+
+	sub.Callback = callback
+	sub.SuspendedAt = nil
+	sub.SuspensionReason = ""
+	if err := s.store.Update(ctx, sub); err != nil {
+		s.requestLogger(c).Error("failed to resume subscription",
+			zap.String("subscription_id", subscriptionID),
+			zap.Error(err))
+		return
+	}
+
+	s.requestLogger(c).Info("subscription resumed", zap.String("subscription_id", subscriptionID))
+	s.logAuditEvent(ctx, c, auth.AuditEventSubscriptionResumed, "subscription", subscriptionID,
+		"subscription_resumed", map[string]string{"callback": callback})
+}
+
+// handleSuspendedSubscriptions lists the subscriptions suspended for violating
+// the callback policy, with the last revalidation run.
+// GET /admin/subscriptions/suspended.
+func (s *Server) handleSuspendedSubscriptions(c *gin.Context) {
+	subs, err := s.store.List(c.Request.Context())
+	if err != nil {
+		s.requestLogger(c).Error("failed to list subscriptions for suspension report", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to list subscriptions")
+		return
+	}
+
+	report := &SuspendedSubscriptionsReport{
+		Subscriptions: []SuspendedSubscription{},
+		LastRun:       s.revalidation.last(),
+	}
+	for _, sub := range subs {
+		if !sub.Suspended() {
+			continue
+		}
+		report.Subscriptions = append(report.Subscriptions, SuspendedSubscription{
+			SubscriptionID: sub.ID,
+			TenantID:       sub.TenantID,
+			Callback:       sub.Callback,
+			Reason:         sub.SuspensionReason,
+			SuspendedAt:    *sub.SuspendedAt,
+		})
+	}
+	sort.Slice(report.Subscriptions, func(i, j int) bool {
+		a, b := report.Subscriptions[i], report.Subscriptions[j]
+		if !a.SuspendedAt.Equal(b.SuspendedAt) {
+			return a.SuspendedAt.Before(b.SuspendedAt)
+		}
+		return a.SubscriptionID < b.SubscriptionID
+	})
+	report.Total = len(report.Subscriptions)
+
+	c.JSON(http.StatusOK, report)
+}
+
+// handleRevalidateCallbacks runs a callback revalidation immediately.
+// POST /admin/subscriptions/revalidate.
+func (s *Server) handleRevalidateCallbacks(c *gin.Context) {
+	report, err := s.RevalidateCallbacks(c.Request.Context())
+	if err != nil {
+		s.requestLogger(c).Error("callback revalidation failed", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError",
+			"Failed to revalidate subscription callbacks")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
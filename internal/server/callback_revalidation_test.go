@@ -0,0 +1,179 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// setupRevalidationTestServer creates a server with a Redis-backed
+// subscription store holding a compliant and a soon to be denied subscription.
+func setupRevalidationTestServer(t *testing.T) (*server.Server, *storage.RedisStore, *config.Config) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{
+		Addr:         mr.Addr(),
+		MaxRetries:   1,
+		DialTimeout:  time.Second,
+		ReadTimeout:  time.Second,
+		WriteTimeout: time.Second,
+		PoolSize:     5,
+	})
+	t.Cleanup(func() { _ = store.Close() })
+
+	ctx := context.Background()
+	require.NoError(t, store.Create(ctx, &storage.Subscription{
+		ID: "sub-good", TenantID: "tenant-a", Callback: "https://smo.example.com/notify",
+	}))
+	require.NoError(t, store.Create(ctx, &storage.Subscription{
+		ID: "sub-bad", TenantID: "tenant-b", Callback: "https://hooks.example.net/notify",
+	}))
+
+	// SSRF protection is disabled so no DNS lookups are made; the denylist
+	// still applies.
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Security: config.SecurityConfig{DisableSSRFProtection: true},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, store)
+	return srv, store, cfg
+}
+
+func TestRevalidateCallbacks(t *testing.T) {
+	srv, store, cfg := setupRevalidationTestServer(t)
+	ctx := context.Background()
+
+	report, err := srv.RevalidateCallbacks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Checked)
+	assert.Zero(t, report.Violations)
+
+	// A new denylist entry applies to the existing subscription.
+	updated := *cfg
+	updated.Security.CallbackDenylist = []string{"*.example.net"}
+	_, err = srv.ReloadConfig(ctx, &updated)
+	require.NoError(t, err)
+	report, err = srv.RevalidateCallbacks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Checked)
+	assert.Equal(t, 1, report.Violations)
+	assert.Equal(t, 1, report.Suspended)
+	assert.Equal(t, 1, report.TotalSuspended)
+
+	bad, err := store.Get(ctx, "sub-bad")
+	require.NoError(t, err)
+	require.True(t, bad.Suspended())
+	assert.Contains(t, bad.SuspensionReason, "denied by the callback policy")
+	good, err := store.Get(ctx, "sub-good")
+	require.NoError(t, err)
+	assert.False(t, good.Suspended())
+
+	// Already suspended subscriptions are not suspended again.
+	report, err = srv.RevalidateCallbacks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Violations)
+	assert.Zero(t, report.Suspended)
+	assert.Equal(t, 1, report.TotalSuspended)
+
+	t.Run("admin listing", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet, "/admin/subscriptions/suspended", nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var listing server.SuspendedSubscriptionsReport
+		require.NoError(t, json.Unmarshal(body, &listing))
+		require.Equal(t, 1, listing.Total)
+		assert.Equal(t, "sub-bad", listing.Subscriptions[0].SubscriptionID)
+		assert.Equal(t, "tenant-b", listing.Subscriptions[0].TenantID)
+		assert.NotEmpty(t, listing.Subscriptions[0].Reason)
+		require.NotNil(t, listing.LastRun)
+		assert.Equal(t, 2, listing.LastRun.Checked)
+	})
+
+	t.Run("new subscriptions to denied hosts are rejected", func(t *testing.T) {
+		resp, _ := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath,
+			map[string]string{"callback": "https://other.example.net/notify"})
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("updating the callback resumes the subscription", func(t *testing.T) {
+		resp, _ := doResourceRequest(t, srv, http.MethodPut, subscriptionsPath+"/sub-bad",
+			map[string]string{"callback": "https://smo.example.com/tenant-b"})
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		bad, err := store.Get(ctx, "sub-bad")
+		require.NoError(t, err)
+		assert.False(t, bad.Suspended())
+		assert.Empty(t, bad.SuspensionReason)
+		assert.Equal(t, "https://smo.example.com/tenant-b", bad.Callback)
+
+		resp, body := doResourceRequest(t, srv, http.MethodPost, "/admin/subscriptions/revalidate", nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var report server.CallbackRevalidationReport
+		require.NoError(t, json.Unmarshal(body, &report))
+		assert.Zero(t, report.Violations)
+		assert.Zero(t, report.TotalSuspended)
+	})
+}
+
+func TestRevalidateCallbacks_NotifiesTenant(t *testing.T) {
+	srv, _, cfg := setupRevalidationTestServer(t)
+	ctx := context.Background()
+
+	notifications := make(chan server.SubscriptionSuspendedNotification, 1)
+	tenantCallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification server.SubscriptionSuspendedNotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+		notifications <- notification
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(tenantCallback.Close)
+
+	mr := miniredis.RunT(t)
+	authStore := auth.NewRedisStoreWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	t.Cleanup(func() { _ = authStore.Close() })
+	require.NoError(t, authStore.CreateTenant(ctx, &auth.Tenant{
+		ID:                   "tenant-b",
+		Name:                 "tenant-b",
+		Status:               auth.TenantStatusActive,
+		Quota:                auth.DefaultQuota(),
+		NotificationCallback: tenantCallback.URL + "/tenant-events",
+	}))
+	srv.AuthStore = authStore
+
+	updated := *cfg
+	updated.Security.CallbackDenylist = []string{"*.example.net"}
+	_, err := srv.ReloadConfig(ctx, &updated)
+	require.NoError(t, err)
+	report, err := srv.RevalidateCallbacks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Suspended)
+
+	select {
+	case notification := <-notifications:
+		assert.Equal(t, server.NotificationTypeSubscriptionSuspended, notification.NotificationType)
+		assert.Equal(t, "tenant-b", notification.TenantID)
+		assert.Equal(t, "sub-bad", notification.SubscriptionID)
+		assert.Equal(t, "https://hooks.example.net/notify", notification.Callback)
+		assert.Contains(t, notification.Reason, "denied by the callback policy")
+		assert.False(t, notification.SuspendedAt.IsZero())
+	default:
+		t.Fatal("tenant was not notified of the suspension")
+	}
+}
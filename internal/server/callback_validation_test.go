@@ -2,16 +2,18 @@ package server_test
 
 import (
 	"context"
-	"net"
 	"testing"
+	"time"
 
 	"github.com/piwi3910/netweave/internal/server"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/piwi3910/netweave/internal/adapter"
 	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // TestValidateCallback tests the callback URL validation including SSRF protection.
@@ -151,6 +153,86 @@ func TestValidateCallback(t *testing.T) {
 	}
 }
 
+// TestValidateCallback_Policy tests the callback allowlist and denylist.
+func TestValidateCallback_Policy(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		denylist  []string
+		callback  string
+		errMsg    string
+	}{
+		{name: "no lists", callback: "https://smo.example.com/notify"},
+		{
+			name:     "denied wildcard",
+			denylist: []string{"*.example.net"},
+			callback: "https://hooks.example.net/notify",
+			errMsg:   "denied by the callback policy",
+		},
+		{
+			name:     "wildcard does not match the bare domain",
+			denylist: []string{"*.example.net"},
+			callback: "https://example.net/notify",
+		},
+		{
+			name:     "denied exact host is case insensitive",
+			denylist: []string{"smo.example.com"},
+			callback: "https://SMO.example.com/notify",
+			errMsg:   "denied by the callback policy",
+		},
+		{
+			name:     "denied CIDR",
+			denylist: []string{"203.0.113.0/24"},
+			callback: "https://203.0.113.10/notify",
+			errMsg:   "denied by the callback policy",
+		},
+		{
+			name:      "allowlisted host",
+			allowlist: []string{"*.example.com"},
+			callback:  "https://smo.example.com/notify",
+		},
+		{
+			name:      "host outside the allowlist",
+			allowlist: []string{"*.example.com"},
+			callback:  "https://smo.example.org/notify",
+			errMsg:    "not in the callback allowlist",
+		},
+		{
+			name:      "allowlisted CIDR",
+			allowlist: []string{"198.51.100.0/24"},
+			callback:  "https://198.51.100.7:8443/notify",
+		},
+		{
+			name:      "denylist wins over allowlist",
+			allowlist: []string{"*.example.com"},
+			denylist:  []string{"blocked.example.com"},
+			callback:  "https://blocked.example.com/notify",
+			errMsg:    "denied by the callback policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The lists apply even when SSRF protection is disabled.
+			s := server.NewTestServer(&config.Config{
+				Security: config.SecurityConfig{
+					DisableSSRFProtection: true,
+					CallbackAllowlist:     tt.allowlist,
+					CallbackDenylist:      tt.denylist,
+				},
+			})
+
+			err := s.ValidateCallback(context.Background(), &adapter.Subscription{Callback: tt.callback})
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
 // TestValidateCallbackHost tests the hostname validation for SSRF protection.
 func TestValidateCallbackHost(t *testing.T) {
 	tests := []struct {
@@ -232,54 +314,32 @@ func TestValidateCallbackHost(t *testing.T) {
 	}
 }
 
-// TestIsPrivateIP tests the private IP detection logic.
-func TestIsPrivateIP(t *testing.T) {
-	tests := []struct {
-		name      string
-		ip        string
-		isPrivate bool
-	}{
-		// Loopback addresses
-		{"IPv4 loopback", "127.0.0.1", true},
-		{"IPv4 loopback 127.x", "127.255.255.254", true},
-		{"IPv6 loopback", "::1", true},
-
-		// Private IPv4 ranges (RFC 1918)
-		{"Private 10.0.0.0", "10.0.0.0", true},
-		{"Private 10.x.x.x", "10.123.45.67", true},
-		{"Private 10.255.255.255", "10.255.255.255", true},
-		{"Private 172.16.0.0", "172.16.0.0", true},
-		{"Private 172.16.x.x", "172.16.123.45", true},
-		{"Private 172.31.255.255", "172.31.255.255", true},
-		{"Private 192.168.0.0", "192.168.0.0", true},
-		{"Private 192.168.x.x", "192.168.123.45", true},
-		{"Private 192.168.255.255", "192.168.255.255", true},
-
-		// Link-local
-		{"Link-local 169.254.x.x", "169.254.1.1", true},
-
-		// Public IPv4 addresses
-		{"Public Google DNS", "8.8.8.8", false},
-		{"Public Cloudflare DNS", "1.1.1.1", false},
-		{"Public example", "93.184.216.34", false},
+// TestCallbackDialGuard tests the delivery-time guard against DNS rebinding.
+func TestCallbackDialGuard(t *testing.T) {
+	t.Run("disabled without SSRF protection", func(t *testing.T) {
+		s := server.NewTestServer(&config.Config{
+			Security: config.SecurityConfig{DisableSSRFProtection: true},
+		})
+		guard := s.CallbackDialGuard("webhook")
+		require.NotNil(t, guard)
 
-		// IPv6 private ranges
-		{"IPv6 ULA fc00::", "fc00::1", true},
-		{"IPv6 ULA fd00::", "fd00::1234", true},
-		{"IPv6 link-local fe80::", "fe80::1", true},
+		// The address check is skipped, so the dial fails only if nothing listens.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		conn, err := guard.DialContext(ctx, "tcp", "127.0.0.1:1")
+		if conn != nil {
+			_ = conn.Close()
+		}
+		assert.NotErrorIs(t, err, resolver.ErrBlocked)
+	})
 
-		// Public IPv6
-		{"Public IPv6 Google", "2001:4860:4860::8888", false},
-	}
+	t.Run("refuses blocked addresses", func(t *testing.T) {
+		s, _ := server.NewTestServerWithMetrics(&config.Config{}, zap.NewNop(), nil, nil)
+		guard := s.CallbackDialGuard("webhook")
+		require.NotNil(t, guard)
+		assert.Equal(t, "webhook", guard.Client)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ip := net.ParseIP(tt.ip)
-			require.NotNil(t, ip, "Failed to parse IP: %s", tt.ip)
-
-			result := server.IsPrivateIP(ip)
-			assert.Equal(t, tt.isPrivate, result,
-				"IP %s should be private=%v but got %v", tt.ip, tt.isPrivate, result)
-		})
-	}
+		_, err := guard.DialContext(context.Background(), "tcp", "127.0.0.1:80")
+		require.ErrorIs(t, err, resolver.ErrBlocked)
+	})
 }
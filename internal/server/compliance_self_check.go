@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/tools/compliance"
+)
+
+// complianceSelfCheckDelay is the time between the start of the scheduler
+// and the first self-check, giving the listener time to start.
+const complianceSelfCheckDelay = time.Minute
+
+var (
+	complianceScore = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Name:      "compliance_score",
+			Help:      "Compliance score (0-100) of the last self-check by specification",
+		},
+		[]string{"spec"},
+	)
+
+	complianceSelfChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Name:      "compliance_self_checks_total",
+			Help:      "Total number of scheduled compliance self-checks by result",
+		},
+		[]string{"result"},
+	)
+
+	complianceRegressions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Name:      "compliance_regressions_total",
+			Help:      "Total number of compliance score regressions detected by the self-check by specification",
+		},
+		[]string{"spec"},
+	)
+)
+
+// StartComplianceSelfCheck periodically runs the O-RAN compliance checks
+// against the gateway itself, until ctx is canceled. Every replica checks its
+// own listener.
+func (s *Server) StartComplianceSelfCheck(ctx context.Context) {
+	cfg := s.config.Compliance
+	if !cfg.Enabled {
+		return
+	}
+
+	go func() {
+		timer := time.NewTimer(complianceSelfCheckDelay)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			if _, err := s.RunComplianceSelfCheck(ctx); err != nil {
+				s.logger.Error("compliance self-check failed", zap.Error(err))
+			}
+			timer.Reset(cfg.Interval)
+		}
+	}()
+}
+
+// RunComplianceSelfCheck runs the compliance checks once, exports the score
+// of each specification and logs an error for every specification whose
+// score dropped from the previous run by more than the regression threshold.
+// It must not be called concurrently.
+func (s *Server) RunComplianceSelfCheck(ctx context.Context) ([]compliance.Result, error) {
+	cfg := s.config.Compliance
+	client, err := s.complianceHTTPClient()
+	if err != nil {
+		complianceSelfChecks.WithLabelValues("failure").Inc()
+		return nil, err
+	}
+
+	checker := compliance.NewChecker(s.complianceBaseURL(), s.logger.Named("compliance"))
+	checker.SetHTTPClient(client)
+	checker.SetCheckTimeout(cfg.CheckTimeout)
+	results, err := checker.CheckAll(ctx)
+	if err != nil {
+		complianceSelfChecks.WithLabelValues("failure").Inc()
+		return nil, err
+	}
+	complianceSelfChecks.WithLabelValues("success").Inc()
+
+	scores := make(map[string]float64, len(results))
+	for _, result := range results {
+		scores[result.SpecName] = result.ComplianceScore
+		complianceScore.WithLabelValues(result.SpecName).Set(result.ComplianceScore)
+
+		previous, ok := s.complianceScores[result.SpecName]
+		switch {
+		case ok && previous-result.ComplianceScore > cfg.RegressionThreshold:
+			complianceRegressions.WithLabelValues(result.SpecName).Inc()
+			s.logger.Error("compliance score regressed",
+				zap.String("spec", result.SpecName),
+				zap.Float64("previous_score", previous),
+				zap.Float64("score", result.ComplianceScore),
+				zap.Strings("failed_endpoints", result.MissingFeatures))
+		default:
+			s.logger.Info("compliance self-check completed",
+				zap.String("spec", result.SpecName),
+				zap.Float64("score", result.ComplianceScore),
+				zap.Int("failed_endpoints", result.FailedEndpoints))
+		}
+	}
+	s.complianceScores = scores
+
+	return results, nil
+}
+
+// complianceBaseURL returns the URL the self-check is sent to.
+func (s *Server) complianceBaseURL() string {
+	if s.config.Compliance.BaseURL != "" {
+		return s.config.Compliance.BaseURL
+	}
+	scheme := "http"
+	if s.config.TLS.Enabled {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://localhost:%d", scheme, s.config.Server.Port)
+}
+
+// complianceHTTPClient returns the client of the self-check. With TLS
+// enabled it trusts the configured CA and presents the server certificate,
+// so the check passes a listener requiring client certificates.
+func (s *Server) complianceHTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg := s.config.TLS; tlsCfg.Enabled {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if tlsCfg.CAFile != "" {
+			caCert, err := os.ReadFile(tlsCfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file: %w", err)
+			}
+			roots := x509.NewCertPool()
+			if !roots.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("no certificates found in CA file %s", tlsCfg.CAFile)
+			}
+			transport.TLSClientConfig.RootCAs = roots
+		}
+		if tlsCfg.CertFile != "" && tlsCfg.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+	return &http.Client{
+		Transport: &headerTransport{base: transport, headers: s.config.Compliance.Headers},
+	}, nil
+}
+
+// headerTransport adds fixed headers to every request.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) > 0 {
+		req = req.Clone(req.Context())
+		for name, value := range t.headers {
+			req.Header.Set(name, value)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+)
+
+func TestRunComplianceSelfCheck(t *testing.T) {
+	// The gateway under test implements every endpoint until the DMS API
+	// breaks.
+	var dmsBroken atomic.Bool
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer self-check" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case dmsBroken.Load() && strings.HasPrefix(r.URL.Path, "/o2dms"):
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.Method == http.MethodPost && !strings.HasSuffix(r.URL.Path, "/scale") &&
+			!strings.HasSuffix(r.URL.Path, "/rollback") && !strings.HasSuffix(r.URL.Path, "/upgrade"):
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer gateway.Close()
+
+	srv := &Server{
+		config: &config.Config{Compliance: config.ComplianceConfig{
+			Enabled:             true,
+			BaseURL:             gateway.URL,
+			Headers:             map[string]string{"Authorization": "Bearer self-check"},
+			RegressionThreshold: 10,
+		}},
+		logger: zap.NewNop(),
+	}
+	regressions := func(spec string) float64 {
+		return testutil.ToFloat64(complianceRegressions.WithLabelValues(spec))
+	}
+	imsRegressions, dmsRegressions := regressions("O2-IMS"), regressions("O2-DMS")
+
+	results, err := srv.RunComplianceSelfCheck(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.InDelta(t, 100, result.ComplianceScore, 0.001, result.SpecName)
+		assert.InDelta(t, 100, testutil.ToFloat64(complianceScore.WithLabelValues(result.SpecName)), 0.001)
+	}
+
+	dmsBroken.Store(true)
+	_, err = srv.RunComplianceSelfCheck(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, testutil.ToFloat64(complianceScore.WithLabelValues("O2-DMS")))
+	assert.InDelta(t, 1, regressions("O2-DMS")-dmsRegressions, 0.001)
+	assert.InDelta(t, 0, regressions("O2-IMS")-imsRegressions, 0.001)
+
+	// The regression is reported once; the next run compares with the
+	// degraded score.
+	_, err = srv.RunComplianceSelfCheck(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 1, regressions("O2-DMS")-dmsRegressions, 0.001)
+}
+
+func TestComplianceBaseURL(t *testing.T) {
+	srv := &Server{config: &config.Config{Server: config.ServerConfig{Port: 8443}}}
+	assert.Equal(t, "http://localhost:8443", srv.complianceBaseURL())
+
+	srv.config.TLS.Enabled = true
+	assert.Equal(t, "https://localhost:8443", srv.complianceBaseURL())
+
+	srv.config.Compliance.BaseURL = "https://gateway.example.com"
+	assert.Equal(t, "https://gateway.example.com", srv.complianceBaseURL())
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// Config snapshot reasons.
+const (
+	ConfigSnapshotReasonStartup = "startup"
+	ConfigSnapshotReasonReload  = "reload"
+)
+
+// DefaultConfigHistoryLimit is the default number of snapshots returned by the history endpoint.
+const DefaultConfigHistoryLimit = 20
+
+// ConfigHistoryResponse is the response body for GET /admin/config/history.
+type ConfigHistoryResponse struct {
+	CurrentHash string                    `json:"currentHash,omitempty"`
+	Snapshots   []*storage.ConfigSnapshot `json:"snapshots"`
+}
+
+// SetConfigHistoryStore sets the store used to persist configuration snapshots.
+// This enables GET /admin/config/history.
+func (s *Server) SetConfigHistoryStore(store storage.ConfigHistoryStore) {
+	s.configHistory = store
+}
+
+// RecordConfigSnapshot persists a redacted snapshot of cfg with the diff from the
+// previous snapshot. It should be called on startup and after every config reload.
+// Nothing is recorded if the configuration is unchanged.
+func (s *Server) RecordConfigSnapshot(ctx context.Context, cfg *config.Config, reason string) error {
+	if s.configHistory == nil {
+		return fmt.Errorf("config history store is not configured")
+	}
+
+	values := config.RedactedValues(cfg)
+	snapshot, recorded, err := storage.RecordConfigSnapshot(ctx, s.configHistory, values, config.HashValues(values), reason)
+	if err != nil {
+		return fmt.Errorf("failed to record config snapshot: %w", err)
+	}
+
+	if recorded {
+		s.logger.Info("configuration snapshot recorded",
+			zap.String("hash", snapshot.Hash),
+			zap.String("reason", reason),
+			zap.Int("changes", len(snapshot.Changes)),
+		)
+	}
+	return nil
+}
+
+// redactConfigChanges masks the values of redacted keys in changes. Values
+// are redacted when a snapshot is recorded, but snapshots recorded before a
+// field was tagged redact:"true" hold it in clear.
+func redactConfigChanges(changes []storage.ConfigChange) []storage.ConfigChange {
+	out := make([]storage.ConfigChange, len(changes))
+	for i, change := range changes {
+		if config.IsRedactedKey(change.Key) {
+			change.Old = maskConfigValue(change.Old)
+			change.New = maskConfigValue(change.New)
+		}
+		out[i] = change
+	}
+	return out
+}
+
+// maskConfigValue returns config.RedactedValue for a non-empty value.
+func maskConfigValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return config.RedactedValue
+}
+
+// handleGetConfig returns the configuration in effect with its secrets
+// redacted, keyed like the configuration file.
+// GET /admin/config.
+func (s *Server) handleGetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, config.Redacted(s.config))
+}
+
+// handleConfigHistory lists configuration snapshots, newest first.
+// GET /admin/config/history?limit=N.
+func (s *Server) handleConfigHistory(c *gin.Context) {
+	if s.configHistory == nil {
+		problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable", "configuration history is not enabled")
+		return
+	}
+
+	limit := DefaultConfigHistoryLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "limit must be a positive integer")
+			return
+		}
+		limit = min(parsed, storage.DefaultConfigHistoryMaxEntries)
+	}
+
+	snapshots, err := s.configHistory.List(c.Request.Context(), limit)
+	if err != nil {
+		s.requestLogger(c).Error("failed to list config history", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve configuration history")
+		return
+	}
+
+	c.JSON(http.StatusOK, configHistoryResponse(snapshots))
+}
+
+// configHistoryResponse lists snapshots, newest first, with their redacted diffs.
+func configHistoryResponse(snapshots []*storage.ConfigSnapshot) *ConfigHistoryResponse {
+	resp := &ConfigHistoryResponse{Snapshots: make([]*storage.ConfigSnapshot, 0, len(snapshots))}
+	for _, snapshot := range snapshots {
+		// Only the diffs are listed; the full redacted values are internal.
+		listed := *snapshot
+		listed.Values = nil
+		listed.Changes = redactConfigChanges(snapshot.Changes)
+		resp.Snapshots = append(resp.Snapshots, &listed)
+	}
+	if len(snapshots) > 0 {
+		resp.CurrentHash = snapshots[0].Hash
+	}
+	return resp
+}
@@ -0,0 +1,106 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestHandleConfigHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Redis:  config.RedisConfig{Password: "s3cret"},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	// Without a store the endpoint is unavailable.
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config/history", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	srv.SetConfigHistoryStore(storage.NewInMemoryConfigHistoryStore(0))
+	ctx := context.Background()
+	require.NoError(t, srv.RecordConfigSnapshot(ctx, cfg, server.ConfigSnapshotReasonStartup))
+	require.NoError(t, srv.RecordConfigSnapshot(ctx, cfg, server.ConfigSnapshotReasonStartup))
+
+	changed := *cfg
+	changed.Server.Port = 9090
+	require.NoError(t, srv.RecordConfigSnapshot(ctx, &changed, server.ConfigSnapshotReasonReload))
+
+	w = httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config/history", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "s3cret")
+
+	var resp server.ConfigHistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Snapshots, 2, "unchanged config is not recorded twice")
+
+	latest := resp.Snapshots[0]
+	assert.Equal(t, resp.CurrentHash, latest.Hash)
+	assert.Equal(t, server.ConfigSnapshotReasonReload, latest.Reason)
+	assert.Nil(t, latest.Values)
+	require.Len(t, latest.Changes, 1)
+	assert.Equal(t, storage.ConfigChange{Key: "server.port", Old: "8080", New: "9090"}, latest.Changes[0])
+
+	w = httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config/history?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandleConfigHistory_RedactsEarlierSnapshots(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode}}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	// A snapshot recorded before compliance.headers was redacted holds the
+	// header in clear; the diff against it must not reveal it.
+	history := storage.NewInMemoryConfigHistoryStore(0)
+	ctx := context.Background()
+	_, _, err := storage.RecordConfigSnapshot(ctx, history,
+		map[string]string{"compliance.headers": `{"Authorization":"Bearer tok3n"}`}, "old", server.ConfigSnapshotReasonStartup)
+	require.NoError(t, err)
+	srv.SetConfigHistoryStore(history)
+	require.NoError(t, srv.RecordConfigSnapshot(ctx, cfg, server.ConfigSnapshotReasonReload))
+
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config/history", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "tok3n")
+	assert.Contains(t, w.Body.String(), config.RedactedValue)
+}
+
+func TestHandleGetConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Redis:         config.RedisConfig{Password: "s3cret"},
+		Notifications: config.NotificationsConfig{HMACSecret: "hmac-s3cret"},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "s3cret")
+
+	var got struct {
+		Server map[string]interface{} `json:"server"`
+		Redis  map[string]interface{} `json:"redis"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, float64(8080), got.Server["port"])
+	assert.Equal(t, config.RedactedValue, got.Redis["password"])
+}
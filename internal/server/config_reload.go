@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/rollout"
+)
+
+// Configuration reload results, the result label of o2ims_config_reloads_total.
+const (
+	configReloadApplied   = "applied"
+	configReloadUnchanged = "unchanged"
+	configReloadFailed    = "failed"
+)
+
+// configReloads counts configuration reloads by result.
+var configReloads = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Name:      "config_reloads_total",
+		Help:      "Total number of configuration reloads by result",
+	},
+	[]string{"result"},
+)
+
+// policySetter is implemented by auth middlewares whose policy matrix can be
+// replaced while the gateway runs.
+type policySetter interface {
+	SetPolicies(rules []auth.PolicyRule)
+}
+
+// ConfigReload is the outcome of a configuration reload.
+type ConfigReload struct {
+	// Applied lists the changed settings that took effect or, with
+	// RolloutStarted, whose rollout started.
+	Applied []string
+
+	// RestartRequired lists the changed settings that take effect on the
+	// next restart.
+	RestartRequired []string
+
+	// Deferred lists the changed request timeout and rate limit settings that
+	// were not applied because a runtime settings rollout is in progress. The
+	// next reload after the rollout finishes applies them.
+	Deferred []string
+
+	// RolloutStarted reports whether the changed request timeout and rate
+	// limits are being rolled out to a percentage of requests first (see
+	// rollout.Controller) rather than applied to every request.
+	RolloutStarted bool
+}
+
+// ReloadConfig applies the settings of cfg that can change while the gateway
+// runs (see config.ReloadableKeys) and differ from the configuration in
+// effect. Other changed settings are reported in RestartRequired and not
+// applied. The configuration in effect afterwards is recorded in the
+// configuration history and the reload is written to the audit log. cfg must
+// be validated. Changes to the request timeout and rate limits are rolled out
+// with the rollout policy of cfg, or deferred while a rollout is in progress.
+func (s *Server) ReloadConfig(ctx context.Context, cfg *config.Config) (*ConfigReload, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	running := s.appliedConfig
+	if running == nil {
+		running = s.config
+	}
+
+	result := &ConfigReload{}
+	for _, key := range config.ChangedKeys(running, cfg) {
+		if s.reloadable(key) {
+			result.Applied = append(result.Applied, key)
+		} else {
+			result.RestartRequired = append(result.RestartRequired, key)
+		}
+	}
+	if len(result.Applied) == 0 && len(result.RestartRequired) == 0 {
+		configReloads.WithLabelValues(configReloadUnchanged).Inc()
+		s.logger.Info("configuration reloaded without changes")
+		return result, nil
+	}
+
+	if err := s.applyConfig(running, cfg, result); err != nil {
+		configReloads.WithLabelValues(configReloadFailed).Inc()
+		return nil, err
+	}
+
+	next := config.WithReloadableSettings(running, cfg)
+	if !s.reloadable("observability.metrics.enabled") {
+		next.Observability.Metrics.Enabled = running.Observability.Metrics.Enabled
+	}
+	if len(result.Deferred) > 0 {
+		// Keep the settings in effect so the next reload applies them.
+		next.Server.RequestTimeout = running.Server.RequestTimeout
+		next.Security.RateLimit = running.Security.RateLimit
+	}
+	s.appliedConfig = next
+	configReloads.WithLabelValues(configReloadApplied).Inc()
+
+	s.logger.Info("configuration reloaded",
+		zap.Strings("applied", result.Applied),
+		zap.Strings("restart_required", result.RestartRequired),
+		zap.Bool("rollout_started", result.RolloutStarted))
+	if len(result.RestartRequired) > 0 {
+		s.logger.Warn("changed settings take effect on the next restart",
+			zap.Strings("settings", result.RestartRequired))
+	}
+	if len(result.Deferred) > 0 {
+		s.logger.Warn("changed settings are not applied while a runtime settings rollout is in progress",
+			zap.Strings("settings", result.Deferred))
+	}
+
+	if s.configHistory != nil {
+		if err := s.RecordConfigSnapshot(ctx, next, ConfigSnapshotReasonReload); err != nil {
+			s.logger.Warn("failed to record reloaded configuration", zap.Error(err))
+		}
+	}
+	if s.auditLogger != nil {
+		s.auditLogger.LogAdminOperation(ctx, auth.AuditEventConfigReloaded, "config.reload", nil, map[string]string{
+			"applied":          strings.Join(result.Applied, ","),
+			"restart_required": strings.Join(result.RestartRequired, ","),
+			"deferred":         strings.Join(result.Deferred, ","),
+		})
+	}
+	return result, nil
+}
+
+// reloadable reports whether a change to key can be applied. Metrics can be
+// paused and resumed, but not started when they were disabled at startup.
+func (s *Server) reloadable(key string) bool {
+	if key == "observability.metrics.enabled" && !s.config.Observability.Metrics.Enabled {
+		return false
+	}
+	return config.IsReloadable(key)
+}
+
+// applyConfig applies the changed reloadable settings of cfg, listed in
+// result.Applied. Changed runtime settings are rolled out rather than applied
+// to every request at once; while another rollout is in progress they are
+// moved to result.Deferred. Starting the rollout comes first as it is the
+// step most likely to fail.
+func (s *Server) applyConfig(running, cfg *config.Config, result *ConfigReload) error {
+	changed := result.Applied
+	settings := RuntimeSettingsFromConfig(cfg)
+	if s.runtimeSettings != nil && settings != RuntimeSettingsFromConfig(running) {
+		err := s.runtimeSettings.Start(settings, rolloutPolicyFromConfig(&cfg.Rollout))
+		switch {
+		case err == nil:
+			result.RolloutStarted = true
+		case errors.Is(err, rollout.ErrInProgress):
+			result.Applied = slices.DeleteFunc(slices.Clone(changed), isRuntimeSettingsKey)
+			for _, key := range changed {
+				if isRuntimeSettingsKey(key) {
+					result.Deferred = append(result.Deferred, key)
+				}
+			}
+		default:
+			return fmt.Errorf("cannot roll out request timeout or rate limits: %w", err)
+		}
+	}
+
+	if slices.Contains(changed, "observability.logging.level") {
+		if err := observability.SetLogLevel("", cfg.Observability.Logging.Level); err != nil {
+			return fmt.Errorf("failed to set log level: %w", err)
+		}
+	}
+
+	if slices.Contains(changed, "multi_tenancy.skip_auth_paths") || slices.Contains(changed, "multi_tenancy.auth_policies") {
+		if setter, ok := s.authMw.(policySetter); ok {
+			setter.SetPolicies(AuthPoliciesFromConfig(&cfg.MultiTenancy))
+		}
+	}
+
+	s.callbackSecurity.Store(newCallbackSecurity(&cfg.Security))
+	if s.reloadable("observability.metrics.enabled") {
+		s.metricsDisabled.Store(!cfg.Observability.Metrics.Enabled)
+	}
+	return nil
+}
+
+// StartConfigReload reloads the configuration loaded from path (see
+// config.Load) on SIGHUP and, with a reload watch interval, whenever the
+// contents of its file change. A configuration that fails to load or validate
+// is logged and the one in effect is kept. It returns immediately; reloading
+// stops when ctx is canceled.
+func (s *Server) StartConfigReload(ctx context.Context, path string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	file := config.FilePath(path)
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if interval := s.config.Reload.WatchInterval; interval > 0 && file != "" {
+		ticker = time.NewTicker(interval)
+		tick = ticker.C
+	}
+
+	go func() {
+		defer signal.Stop(hup)
+		if ticker != nil {
+			defer ticker.Stop()
+		}
+
+		lastHash := fileHash(file)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				lastHash = fileHash(file)
+				s.reloadConfigFile(ctx, path, "sighup")
+			case <-tick:
+				// ConfigMap volumes replace the file through a symlink swap,
+				// so compare contents rather than modification times.
+				hash := fileHash(file)
+				if hash == lastHash {
+					continue
+				}
+				lastHash = hash
+				s.reloadConfigFile(ctx, path, "file_change")
+			}
+		}
+	}()
+}
+
+// reloadConfigFile loads, validates and applies the configuration file.
+func (s *Server) reloadConfigFile(ctx context.Context, path, trigger string) {
+	logger := s.logger.With(zap.String("path", path), zap.String("trigger", trigger))
+
+	cfg, err := config.Load(path)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		configReloads.WithLabelValues(configReloadFailed).Inc()
+		logger.Error("failed to reload configuration; keeping the current one", zap.Error(err))
+		return
+	}
+
+	if _, err := s.ReloadConfig(ctx, cfg); err != nil {
+		logger.Error("failed to apply reloaded configuration", zap.Error(err))
+	}
+}
+
+// fileHash returns the SHA-256 hash of the file at path, or the zero hash if
+// it cannot be read.
+func fileHash(path string) [sha256.Size]byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,196 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func reloadTestConfig() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode, RequestTimeout: 30 * time.Second},
+		Observability: config.ObservabilityConfig{
+			Logging: config.LoggingConfig{Level: "info"},
+			Metrics: config.MetricsConfig{Enabled: true, Path: "/metrics"},
+		},
+		Security: config.SecurityConfig{
+			RateLimit: config.RateLimitConfig{
+				PerTenant: config.TenantRateLimitConfig{RequestsPerSecond: 100, BurstSize: 200},
+			},
+		},
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	levelBefore := observability.GetLogLevels().Level
+	t.Cleanup(func() { _ = observability.SetLogLevel("", levelBefore) })
+
+	running := reloadTestConfig()
+	srv, _ := server.NewTestServerWithMetrics(running, zap.NewNop(), &mockAdapter{}, &mockStore{})
+	history := storage.NewInMemoryConfigHistoryStore(0)
+	srv.SetConfigHistoryStore(history)
+	ctx := context.Background()
+
+	updated := reloadTestConfig()
+	updated.Observability.Logging.Level = "debug"
+	updated.Security.RateLimit.PerTenant.RequestsPerSecond = 50
+	updated.Security.CallbackDenylist = []string{"*.internal.example.com"}
+	updated.Server.Port = 9090
+
+	result, err := srv.ReloadConfig(ctx, updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"observability.logging.level",
+		"security.callback_denylist",
+		"security.rate_limit.tenant.requests_per_second",
+	}, result.Applied)
+	assert.Equal(t, []string{"server.port"}, result.RestartRequired)
+
+	assert.Equal(t, "debug", observability.GetLogLevels().Level)
+	err = srv.ValidateCallback(ctx, &adapter.Subscription{Callback: "https://hooks.internal.example.com/notify"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "denied by the callback policy")
+
+	snapshots, err := history.List(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, server.ConfigSnapshotReasonReload, snapshots[0].Reason)
+	assert.Equal(t, "8080", snapshots[0].Values["server.port"], "the snapshot holds the configuration in effect")
+	assert.Equal(t, "debug", snapshots[0].Values["observability.logging.level"])
+
+	// Reloading the same file again only reports the pending restart.
+	result, err = srv.ReloadConfig(ctx, updated)
+	require.NoError(t, err)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, []string{"server.port"}, result.RestartRequired)
+}
+
+func TestReloadConfig_MetricsToggle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv, _ := server.NewTestServerWithMetrics(reloadTestConfig(), zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	updated := reloadTestConfig()
+	updated.Observability.Metrics.Enabled = false
+	result, err := srv.ReloadConfig(context.Background(), updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"observability.metrics.enabled"}, result.Applied)
+
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	result, err = srv.ReloadConfig(context.Background(), reloadTestConfig())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"observability.metrics.enabled"}, result.Applied)
+
+	w = httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReloadConfig_RollsOutRuntimeSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	running := reloadTestConfig()
+	running.Rollout.Percent = 20
+	srv, _ := server.NewTestServerWithMetrics(running, zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	updated := reloadTestConfig()
+	updated.Rollout.Percent = 20
+	updated.Server.RequestTimeout = 5 * time.Second
+	result, err := srv.ReloadConfig(context.Background(), updated)
+	require.NoError(t, err)
+	assert.True(t, result.RolloutStarted)
+	assert.Equal(t, []string{"server.request_timeout"}, result.Applied)
+
+	status := getRolloutStatus(t, srv)
+	assert.Equal(t, "in_progress", status.State)
+	assert.Equal(t, "30s", status.Stable.RequestTimeout, "the stable settings are kept until the canary is promoted")
+	require.NotNil(t, status.Canary)
+	assert.Equal(t, "5s", status.Canary.RequestTimeout)
+	assert.Equal(t, 20, status.Policy.Percent)
+
+	// Reloads that leave the runtime settings unchanged start no rollout.
+	updated.Observability.Logging.Level = "info"
+	updated.Security.DisableSSRFProtection = true
+	result, err = srv.ReloadConfig(context.Background(), updated)
+	require.NoError(t, err)
+	assert.False(t, result.RolloutStarted)
+	assert.Empty(t, result.Deferred)
+}
+
+func TestReloadConfig_RolloutInProgress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv, _ := server.NewTestServerWithMetrics(reloadTestConfig(), zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/config/rollout",
+		strings.NewReader(`{"settings":{"requestTimeout":"10s"}}`)))
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	updated := reloadTestConfig()
+	updated.Server.RequestTimeout = 5 * time.Second
+	updated.Security.DisableSSRFProtection = true
+	result, err := srv.ReloadConfig(context.Background(), updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"security.disable_ssrf_protection"}, result.Applied)
+	assert.Equal(t, []string{"server.request_timeout"}, result.Deferred)
+	assert.False(t, result.RolloutStarted)
+
+	// The other settings were applied; the rollout in progress was left alone.
+	err = srv.ValidateCallback(context.Background(), &adapter.Subscription{Callback: "https://127.0.0.1/notify"})
+	require.NoError(t, err)
+	assert.Equal(t, "10s", getRolloutStatus(t, srv).Canary.RequestTimeout)
+
+	// The deferred settings are rolled out by the next reload once the
+	// rollout has finished.
+	w = httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/config/rollout/rollback", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	result, err = srv.ReloadConfig(context.Background(), updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"server.request_timeout"}, result.Applied)
+	assert.True(t, result.RolloutStarted)
+	assert.Equal(t, "5s", getRolloutStatus(t, srv).Canary.RequestTimeout)
+}
+
+// rolloutStatus is the part of GET /admin/config/rollout checked by the
+// reload tests.
+type rolloutStatus struct {
+	State  string `json:"state"`
+	Stable struct {
+		RequestTimeout string `json:"requestTimeout"`
+	} `json:"stable"`
+	Canary *struct {
+		RequestTimeout string `json:"requestTimeout"`
+	} `json:"canary"`
+	Policy *struct {
+		Percent int `json:"percent"`
+	} `json:"policy"`
+}
+
+func getRolloutStatus(t *testing.T, srv *server.Server) rolloutStatus {
+	t.Helper()
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config/rollout", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var status rolloutStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	return status
+}
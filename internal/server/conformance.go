@@ -0,0 +1,134 @@
+package server
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+// O2 interface profiles advertised in the deploymentManager profileSupport section.
+const (
+	// ProfileO2IMSInventory is the O2-IMS infrastructure inventory profile.
+	ProfileO2IMSInventory = "o2ims-inventory"
+
+	// ProfileO2IMSSubscriptions is the O2-IMS inventory change subscription profile.
+	ProfileO2IMSSubscriptions = "o2ims-subscriptions"
+
+	// ProfileO2IMSMonitoring is the O2-IMS infrastructure monitoring profile.
+	ProfileO2IMSMonitoring = "o2ims-monitoring"
+
+	// ProfileO2DMS is the O2-DMS deployment management profile.
+	ProfileO2DMS = "o2dms"
+)
+
+// Optional gateway extensions advertised in the deploymentManager profileSupport section.
+const (
+	ExtensionEnhancedFiltering = "enhanced-filtering"
+	ExtensionFieldSelection    = "field-selection"
+	ExtensionCursorPagination  = "cursor-pagination"
+	ExtensionBatchOperations   = "batch-operations"
+	ExtensionMultiTenancy      = "multi-tenancy"
+	ExtensionGraphQL           = "graphql"
+	ExtensionTMForum           = "tmforum"
+	ExtensionSMOIntegration    = "smo-integration"
+	ExtensionIdentifierIndex   = "resource-identifier-index"
+)
+
+// ProfileSupport derives the gateway's O2 conformance from the features that are
+// actually registered: served API versions, adapter capabilities, and the
+// optional subsystems configured on this server.
+func (s *Server) ProfileSupport() *adapter.ProfileSupport {
+	profile := &adapter.ProfileSupport{
+		Profiles:    []string{ProfileO2IMSInventory},
+		APIVersions: s.apiVersionSupport(),
+	}
+
+	for _, capability := range s.adapter.Capabilities() {
+		profile.Capabilities = append(profile.Capabilities, string(capability))
+		switch capability {
+		case adapter.CapabilitySubscriptions:
+			profile.Profiles = append(profile.Profiles, ProfileO2IMSSubscriptions)
+		case adapter.CapabilityMetrics, adapter.CapabilityHealthChecks:
+			if !slices.Contains(profile.Profiles, ProfileO2IMSMonitoring) {
+				profile.Profiles = append(profile.Profiles, ProfileO2IMSMonitoring)
+			}
+		case adapter.CapabilityResourcePools, adapter.CapabilityResources,
+			adapter.CapabilityResourceTypes, adapter.CapabilityDeploymentManagers:
+			// Covered by the inventory profile.
+		}
+	}
+	if s.dmsHandler != nil {
+		profile.Profiles = append(profile.Profiles, ProfileO2DMS)
+	}
+
+	profile.Extensions = s.enabledExtensions()
+	return profile
+}
+
+// apiVersionSupport lists the API versions still served, in version order.
+func (s *Server) apiVersionSupport() []adapter.APIVersionSupport {
+	versionConfig := s.versionConfig
+	if versionConfig == nil {
+		versionConfig = NewVersionConfig()
+	}
+
+	versions := make([]adapter.APIVersionSupport, 0, len(versionConfig.Versions))
+	for _, v := range versionConfig.Versions {
+		if v.Status == VersionStatusSunset {
+			continue
+		}
+		versions = append(versions, adapter.APIVersionSupport{Version: v.Version, Status: v.Status})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return ExtractVersionNumber(versions[i].Version) < ExtractVersionNumber(versions[j].Version)
+	})
+	return versions
+}
+
+// enabledExtensions lists the optional gateway extensions registered on this server.
+func (s *Server) enabledExtensions() []string {
+	var extensions []string
+
+	features := GetV2Features()
+	if features.EnhancedFiltering {
+		extensions = append(extensions, ExtensionEnhancedFiltering)
+	}
+	if features.FieldSelection {
+		extensions = append(extensions, ExtensionFieldSelection)
+	}
+	if features.CursorPagination {
+		extensions = append(extensions, ExtensionCursorPagination)
+	}
+	if features.BatchOperations {
+		extensions = append(extensions, ExtensionBatchOperations)
+	}
+	if s.tenantHandler != nil {
+		extensions = append(extensions, ExtensionMultiTenancy)
+	}
+
+	// The GraphQL endpoint is always registered alongside the REST API.
+	extensions = append(extensions, ExtensionGraphQL)
+
+	if s.tmfHandler != nil {
+		extensions = append(extensions, ExtensionTMForum)
+	}
+	if s.smoRegistry != nil {
+		extensions = append(extensions, ExtensionSMOIntegration)
+	}
+	if s.resourceIndex != nil {
+		extensions = append(extensions, ExtensionIdentifierIndex)
+	}
+	return extensions
+}
+
+// withProfileSupport returns a copy of dm with its profileSupport section populated,
+// leaving the adapter's object untouched.
+func (s *Server) withProfileSupport(dm *adapter.DeploymentManager) *adapter.DeploymentManager {
+	if dm == nil {
+		return nil
+	}
+	out := *dm
+	out.ProfileSupport = s.ProfileSupport()
+	return &out
+}
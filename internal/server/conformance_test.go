@@ -0,0 +1,96 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// deploymentManagerAdapter returns a fixed deployment manager and capability set.
+type deploymentManagerAdapter struct {
+	mockAdapter
+	capabilities []adapter.Capability
+}
+
+func (m *deploymentManagerAdapter) Capabilities() []adapter.Capability {
+	return m.capabilities
+}
+
+func (m *deploymentManagerAdapter) GetDeploymentManager(
+	_ context.Context,
+	id string,
+) (*adapter.DeploymentManager, error) {
+	return &adapter.DeploymentManager{
+		DeploymentManagerID: id,
+		Name:                "test-cluster",
+		OCloudID:            "ocloud-1",
+		ServiceURI:          "https://api.example.com/o2ims",
+		Capabilities:        []string{"resource-pools"},
+	}, nil
+}
+
+func TestDeploymentManager_ProfileSupport(t *testing.T) {
+	adp := &deploymentManagerAdapter{capabilities: []adapter.Capability{
+		adapter.CapabilityResourcePools,
+		adapter.CapabilitySubscriptions,
+		adapter.CapabilityMetrics,
+		adapter.CapabilityHealthChecks,
+	}}
+	srv := setupResourceTestServer(t, adp)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet,
+		"/o2ims-infrastructureInventory/v1/deploymentManagers/default", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var dm adapter.DeploymentManager
+	require.NoError(t, json.Unmarshal(body, &dm))
+	assert.Equal(t, []string{"resource-pools"}, dm.Capabilities)
+	require.NotNil(t, dm.ProfileSupport)
+
+	profile := dm.ProfileSupport
+	assert.Equal(t, []string{
+		server.ProfileO2IMSInventory,
+		server.ProfileO2IMSSubscriptions,
+		server.ProfileO2IMSMonitoring,
+	}, profile.Profiles)
+	assert.Equal(t, []adapter.APIVersionSupport{
+		{Version: "v1", Status: server.VersionStatusStable},
+		{Version: "v2", Status: server.VersionStatusStable},
+		{Version: "v3", Status: server.VersionStatusStable},
+	}, profile.APIVersions)
+	assert.Equal(t, []string{"resource-pools", "subscriptions", "metrics", "health-checks"}, profile.Capabilities)
+	assert.Contains(t, profile.Extensions, server.ExtensionBatchOperations)
+	assert.Contains(t, profile.Extensions, server.ExtensionGraphQL)
+	assert.NotContains(t, profile.Extensions, server.ExtensionMultiTenancy)
+	assert.NotContains(t, profile.Extensions, server.ExtensionIdentifierIndex)
+}
+
+func TestDeploymentManager_ProfileSupportTracksRegisteredFeatures(t *testing.T) {
+	adp := &deploymentManagerAdapter{capabilities: []adapter.Capability{adapter.CapabilityResources}}
+	srv := setupResourceTestServer(t, adp)
+	srv.SetResourceIndex(storage.NewInMemoryResourceIndex())
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet,
+		"/o2ims-infrastructureInventory/v1/deploymentManagers", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result struct {
+		DeploymentManagers []adapter.DeploymentManager `json:"deploymentManagers"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Len(t, result.DeploymentManagers, 1)
+
+	profile := result.DeploymentManagers[0].ProfileSupport
+	require.NotNil(t, profile)
+	assert.Equal(t, []string{server.ProfileO2IMSInventory}, profile.Profiles)
+	assert.Equal(t, []string{"resources"}, profile.Capabilities)
+	assert.Contains(t, profile.Extensions, server.ExtensionIdentifierIndex)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/cost"
+)
+
+// capacityExtension is the resource extension holding the capacity that is
+// priced for resource pool cost estimates.
+const capacityExtension = "kubernetes.io/capacity"
+
+// capacityGPUKeys are the GPU entries of the capacity extension.
+var capacityGPUKeys = []string{"nvidia.com/gpu", "amd.com/gpu"}
+
+// SetPricing enables cost estimates on resource pools and NF deployments.
+// It must be called before SetupDMS for NF deployments to be priced.
+func (s *Server) SetPricing(pricing *cost.Pricing) {
+	s.pricing = pricing
+}
+
+// resourceCapacity returns the priced capacity of a resource from its
+// capacity extension (Kubernetes quantities). Resources that do not report
+// capacity, or report it in another form, count as zero.
+func resourceCapacity(r *adapter.Resource) (cost.Usage, error) {
+	var usage cost.Usage
+	capacity, _ := r.Extensions[capacityExtension].(map[string]interface{})
+
+	quantity := func(key string) (*resource.Quantity, error) {
+		raw, ok := capacity[key]
+		if !ok {
+			return nil, nil
+		}
+		qty, err := resource.ParseQuantity(fmt.Sprint(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s of resource %s: %w", capacityExtension, key, r.ResourceID, err)
+		}
+		return &qty, nil
+	}
+
+	cpu, err := quantity("cpu")
+	if err != nil {
+		return usage, err
+	}
+	if cpu != nil {
+		usage.CPUMillicores = cpu.MilliValue()
+	}
+
+	memory, err := quantity("memory")
+	if err != nil {
+		return usage, err
+	}
+	if memory != nil {
+		usage.MemoryBytes = memory.Value()
+	}
+
+	for _, key := range capacityGPUKeys {
+		gpus, err := quantity(key)
+		if err != nil {
+			return usage, err
+		}
+		if gpus != nil {
+			usage.GPUs += gpus.Value()
+		}
+	}
+
+	return usage, nil
+}
+
+// withPoolCosts returns copies of pools with their estimated cost, computed
+// from the capacity of the resources in each pool, and updates the pool cost
+// metrics. Pools are returned unchanged when pricing is not enabled or the
+// resources cannot be listed.
+func (s *Server) withPoolCosts(
+	ctx context.Context, pools []*adapter.ResourcePool, filter *adapter.Filter,
+) []*adapter.ResourcePool {
+	if s.pricing == nil || len(pools) == 0 {
+		return pools
+	}
+
+	resourceFilter := &adapter.Filter{}
+	if filter != nil {
+		resourceFilter.TenantID = filter.TenantID
+	}
+	if len(pools) == 1 {
+		resourceFilter.ResourcePoolID = pools[0].ResourcePoolID
+	}
+
+	resources, err := s.adapter.ListResources(ctx, resourceFilter)
+	if err != nil {
+		s.logger.Warn("cannot estimate resource pool costs", zap.Error(err))
+		return pools
+	}
+
+	usage := make(map[string]cost.Usage, len(pools))
+	for _, r := range resources {
+		capacity, err := resourceCapacity(r)
+		if err != nil {
+			s.logger.Debug("skipping resource in cost estimate", zap.Error(err))
+			continue
+		}
+		usage[r.ResourcePoolID] = usage[r.ResourcePoolID].Add(capacity)
+	}
+
+	priced := make([]*adapter.ResourcePool, 0, len(pools))
+	for _, pool := range pools {
+		// Copy so cached and adapter-owned pools are not modified.
+		p := *pool
+		p.EstimatedCost = s.pricing.Estimate(usage[pool.ResourcePoolID])
+		cost.RecordResourcePool(p.ResourcePoolID, p.EstimatedCost)
+		priced = append(priced, &p)
+	}
+	return priced
+}
+
+// listResourcePools lists the resource pools visible to the request via the
+// adapter, evaluating the filter conditions adapters do not support, with
+// their estimated cost when pricing is enabled.
+func (s *Server) listResourcePools(ctx context.Context, filter *adapter.Filter) ([]*adapter.ResourcePool, error) {
+	pushed, residual := splitListFilter(filter)
+	pools, err := scopedList(ctx, s, usageResourcePools, pushed, s.adapter.ListResourcePools, poolOwner)
+	if err != nil {
+		return nil, err
+	}
+	if pools, err = filterListItems(pools, residual, filter); err != nil {
+		return nil, err
+	}
+	return s.withPoolCosts(ctx, pools, filter), nil
+}
@@ -0,0 +1,108 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/cost"
+)
+
+// capacityAdapter serves resource pools whose resources report Kubernetes capacity.
+type capacityAdapter struct {
+	inventoryAdapter
+}
+
+func (m *capacityAdapter) GetResourcePool(_ context.Context, id string) (*adapter.ResourcePool, error) {
+	for _, pool := range m.pools {
+		if pool.ResourcePoolID == id {
+			return pool, nil
+		}
+	}
+	return nil, adapter.ErrResourcePoolNotFound
+}
+
+func (m *capacityAdapter) ListResources(_ context.Context, filter *adapter.Filter) ([]*adapter.Resource, error) {
+	var resources []*adapter.Resource
+	for _, r := range m.resources {
+		if filter.ResourcePoolID == "" || r.ResourcePoolID == filter.ResourcePoolID {
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+func newCapacityAdapter() *capacityAdapter {
+	node := func(id, pool string, capacity map[string]interface{}) *adapter.Resource {
+		return &adapter.Resource{
+			ResourceID:     id,
+			ResourceTypeID: "compute",
+			ResourcePoolID: pool,
+			Extensions:     map[string]interface{}{"kubernetes.io/capacity": capacity},
+		}
+	}
+	return &capacityAdapter{inventoryAdapter{
+		pools: []*adapter.ResourcePool{
+			{ResourcePoolID: "pool-gpu", Name: "gpu", OCloudID: "ocloud-1"},
+			{ResourcePoolID: "pool-empty", Name: "empty", OCloudID: "ocloud-1"},
+		},
+		resources: []*adapter.Resource{
+			node("node-1", "pool-gpu", map[string]interface{}{"cpu": "8", "memory": "32Gi", "nvidia.com/gpu": "1"}),
+			node("node-2", "pool-gpu", map[string]interface{}{"cpu": "4", "memory": "16Gi", "pods": "110"}),
+			{ResourceID: "storage-1", ResourceTypeID: "storage", ResourcePoolID: "pool-gpu"},
+		},
+	}}
+}
+
+func TestResourcePools_EstimatedCost(t *testing.T) {
+	adp := newCapacityAdapter()
+	srv := setupResourceTestServer(t, adp)
+	srv.SetPricing(&cost.Pricing{Currency: "USD", VCPUHour: 0.04, MemoryGBHour: 0.005, GPUHour: 2})
+
+	// (12*0.04 + 48*0.005 + 1*2) * 730 = 1985.6
+	want := &cost.Estimate{MonthlyCost: 1985.6, Currency: "USD", VCPUs: 12, MemoryGB: 48, GPUs: 1}
+
+	t.Run("get", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet,
+			"/o2ims-infrastructureInventory/v1/resourcePools/pool-gpu", nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+		var pool adapter.ResourcePool
+		require.NoError(t, json.Unmarshal(body, &pool))
+		assert.Equal(t, want, pool.EstimatedCost)
+		assert.InDelta(t, 1985.6,
+			testutil.ToFloat64(cost.ResourcePoolMonthlyCost.WithLabelValues("pool-gpu", "USD")), 0.001)
+
+		// The adapter's pool is not modified.
+		assert.Nil(t, adp.pools[0].EstimatedCost)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet,
+			"/o2ims-infrastructureInventory/v1/resourcePools", nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+		var list struct {
+			ResourcePools []adapter.ResourcePool `json:"resourcePools"`
+		}
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Len(t, list.ResourcePools, 2)
+		assert.Equal(t, want, list.ResourcePools[0].EstimatedCost)
+		assert.Equal(t, &cost.Estimate{Currency: "USD"}, list.ResourcePools[1].EstimatedCost)
+	})
+}
+
+func TestResourcePools_NoPricing(t *testing.T) {
+	srv := setupResourceTestServer(t, newCapacityAdapter())
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet,
+		"/o2ims-infrastructureInventory/v1/resourcePools/pool-gpu", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	assert.NotContains(t, string(body), "estimatedCost")
+}
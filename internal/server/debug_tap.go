@@ -0,0 +1,432 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+// defaultDebugTapDuration is how long a tap captures when no duration is given.
+const defaultDebugTapDuration = 15 * time.Minute
+
+// debugTapAdminPrefix is the path of the debug tap admin API, whose own
+// requests are never captured.
+const debugTapAdminPrefix = "/admin/debug"
+
+// DebugTap captures the sanitized request and response bodies of the requests
+// matching all of its non-empty criteria until it expires.
+type DebugTap struct {
+	TapID string `json:"tapId"`
+
+	// Route matches the route pattern (e.g. /o2ims-infrastructureInventory/v1/subscriptions/:subscriptionId)
+	// or the request path; a trailing "*" matches every path with that prefix.
+	Route string `json:"route,omitempty"`
+
+	Method    string    `json:"method,omitempty"`
+	TenantID  string    `json:"tenantId,omitempty"`
+	RequestID string    `json:"requestId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Active    bool      `json:"active"`
+
+	// Captured counts every capture, including those overwritten in the buffer.
+	Captured int `json:"captured"`
+
+	// Captures holds the most recent captures, oldest first. Only returned
+	// for a single tap.
+	Captures []DebugTapCapture `json:"captures,omitempty"`
+}
+
+// DebugTapCapture is one captured request and its response. Sensitive headers
+// and values are redacted as in logs.
+type DebugTapCapture struct {
+	CapturedAt            time.Time         `json:"capturedAt"`
+	RequestID             string            `json:"requestId"`
+	TenantID              string            `json:"tenantId,omitempty"`
+	Method                string            `json:"method"`
+	Path                  string            `json:"path"`
+	Route                 string            `json:"route,omitempty"`
+	Status                int               `json:"status"`
+	DurationMs            int64             `json:"durationMs"`
+	RequestHeaders        map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody           string            `json:"requestBody,omitempty"`
+	RequestBodyTruncated  bool              `json:"requestBodyTruncated,omitempty"`
+	ResponseBody          string            `json:"responseBody,omitempty"`
+	ResponseBodyTruncated bool              `json:"responseBodyTruncated,omitempty"`
+}
+
+// debugTapRequest is the body of POST /admin/debug/taps. Duration is a Go
+// duration such as "10m".
+type debugTapRequest struct {
+	Route     string `json:"route"`
+	Method    string `json:"method"`
+	TenantID  string `json:"tenantId"`
+	RequestID string `json:"requestId"`
+	Duration  string `json:"duration"`
+}
+
+// debugTap is a tap with its ring buffer of captures.
+type debugTap struct {
+	DebugTap
+	captures []DebugTapCapture
+	next     int
+}
+
+// matchesRequest checks the criteria known before the request is handled.
+func (t *debugTap) matchesRequest(c *gin.Context, now time.Time) bool {
+	if !now.Before(t.ExpiresAt) {
+		return false
+	}
+	if t.Method != "" && !strings.EqualFold(t.Method, c.Request.Method) {
+		return false
+	}
+	if t.RequestID != "" && t.RequestID != middleware.GetRequestID(c) {
+		return false
+	}
+	if t.Route == "" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(t.Route, "*"); ok {
+		return strings.HasPrefix(c.Request.URL.Path, prefix)
+	}
+	return t.Route == c.FullPath() || t.Route == c.Request.URL.Path
+}
+
+func (t *debugTap) add(capture DebugTapCapture) {
+	if len(t.captures) < cap(t.captures) {
+		t.captures = append(t.captures, capture)
+	} else {
+		t.captures[t.next] = capture
+	}
+	t.next = (t.next + 1) % cap(t.captures)
+	t.Captured++
+}
+
+// view returns the tap as reported by the admin API.
+func (t *debugTap) view(now time.Time, withCaptures bool) DebugTap {
+	view := t.DebugTap
+	view.Active = now.Before(t.ExpiresAt)
+	if withCaptures {
+		view.Captures = make([]DebugTapCapture, 0, len(t.captures))
+		if len(t.captures) == cap(t.captures) {
+			view.Captures = append(view.Captures, t.captures[t.next:]...)
+			view.Captures = append(view.Captures, t.captures[:t.next]...)
+		} else {
+			view.Captures = append(view.Captures, t.captures...)
+		}
+	}
+	return view
+}
+
+// debugTaps holds the debug taps of the gateway.
+type debugTaps struct {
+	cfg      config.DebugTapConfig
+	redactor *observability.Redactor
+
+	// armed is set while at least one tap exists, so requests skip the
+	// lock when nothing is tapped.
+	armed atomic.Bool
+
+	mu   sync.Mutex
+	taps map[string]*debugTap
+}
+
+// newDebugTaps creates the tap registry. Bodies are redacted with the
+// fields and patterns configured for log redaction.
+func newDebugTaps(cfg *config.Config) *debugTaps {
+	redaction := cfg.Observability.Logging.Redaction
+	redactor, err := observability.NewRedactor(redaction.Fields, redaction.Patterns)
+	if err != nil {
+		redactor, _ = observability.NewRedactor(nil, nil)
+	}
+	return &debugTaps{
+		cfg:      cfg.Observability.DebugTap,
+		redactor: redactor,
+		taps:     make(map[string]*debugTap),
+	}
+}
+
+// matching returns the taps matching the request so far, or nil.
+func (d *debugTaps) matching(c *gin.Context, now time.Time) []*debugTap {
+	if !d.armed.Load() || strings.HasPrefix(c.Request.URL.Path, debugTapAdminPrefix) {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var taps []*debugTap
+	for _, tap := range d.taps {
+		if tap.matchesRequest(c, now) {
+			taps = append(taps, tap)
+		}
+	}
+	return taps
+}
+
+// record adds the capture to the taps whose tenant matches.
+func (d *debugTaps) record(taps []*debugTap, capture DebugTapCapture) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, tap := range taps {
+		if _, exists := d.taps[tap.TapID]; !exists {
+			continue
+		}
+		if tap.TenantID != "" && tap.TenantID != capture.TenantID {
+			continue
+		}
+		tap.add(capture)
+	}
+}
+
+// start adds a tap, evicting the oldest expired tap when the registry is
+// full. Returns false if every kept tap is still active.
+func (d *debugTaps) start(tap *debugTap, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.taps) >= d.cfg.MaxTaps {
+		var oldest *debugTap
+		for _, t := range d.taps {
+			if now.Before(t.ExpiresAt) {
+				continue
+			}
+			if oldest == nil || t.ExpiresAt.Before(oldest.ExpiresAt) {
+				oldest = t
+			}
+		}
+		if oldest == nil {
+			return false
+		}
+		delete(d.taps, oldest.TapID)
+	}
+	d.taps[tap.TapID] = tap
+	d.armed.Store(true)
+	return true
+}
+
+func (d *debugTaps) stop(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.taps[id]; !ok {
+		return false
+	}
+	delete(d.taps, id)
+	d.armed.Store(len(d.taps) > 0)
+	return true
+}
+
+func (d *debugTaps) get(id string, now time.Time) (DebugTap, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	tap, ok := d.taps[id]
+	if !ok {
+		return DebugTap{}, false
+	}
+	return tap.view(now, true), true
+}
+
+func (d *debugTaps) list(now time.Time) []DebugTap {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	taps := make([]DebugTap, 0, len(d.taps))
+	for _, tap := range d.taps {
+		taps = append(taps, tap.view(now, false))
+	}
+	sort.Slice(taps, func(i, j int) bool { return taps[i].CreatedAt.Before(taps[j].CreatedAt) })
+	return taps
+}
+
+// redactHeaders returns the request headers with sensitive values redacted.
+func (d *debugTaps) redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if d.redactor.IsSensitiveField(name) {
+			headers[name] = observability.RedactedValue
+			continue
+		}
+		headers[name] = d.redactor.RedactString(strings.Join(values, ", "))
+	}
+	return headers
+}
+
+// debugTapWriter keeps the first bytes of a response body.
+type debugTapWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (w *debugTapWriter) capture(data []byte) {
+	if room := w.limit - w.body.Len(); len(data) > room {
+		w.body.Write(data[:room])
+		w.truncated = true
+		return
+	}
+	w.body.Write(data)
+}
+
+func (w *debugTapWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *debugTapWriter) WriteString(data string) (int, error) {
+	w.capture([]byte(data))
+	return w.ResponseWriter.WriteString(data)
+}
+
+// debugTapMiddleware captures the requests matching a debug tap. Requests
+// are only buffered while a tap matches them, and bodies only up to
+// MaxBodyBytes; the rest of a request body is streamed to the handler as is.
+func (s *Server) debugTapMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		taps := s.debugTaps.matching(c, start)
+		if len(taps) == 0 {
+			c.Next()
+			return
+		}
+
+		limit := s.debugTaps.cfg.MaxBodyBytes
+		var requestBody []byte
+		requestTruncated := false
+		if c.Request.Body != nil {
+			// Read one byte past the limit to detect truncation.
+			requestBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, int64(limit)+1))
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(requestBody), c.Request.Body), c.Request.Body}
+			if len(requestBody) > limit {
+				requestBody, requestTruncated = requestBody[:limit], true
+			}
+		}
+
+		writer := &debugTapWriter{ResponseWriter: c.Writer, limit: limit}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		capture := DebugTapCapture{
+			CapturedAt:            start.UTC(),
+			RequestID:             middleware.GetRequestID(c),
+			TenantID:              requestcontext.Tenant(c.Request.Context()),
+			Method:                c.Request.Method,
+			Path:                  c.Request.URL.Path,
+			Route:                 c.FullPath(),
+			Status:                writer.Status(),
+			DurationMs:            time.Since(start).Milliseconds(),
+			RequestHeaders:        s.debugTaps.redactHeaders(c.Request.Header),
+			RequestBodyTruncated:  requestTruncated,
+			ResponseBodyTruncated: writer.truncated,
+		}
+		if len(requestBody) > 0 {
+			capture.RequestBody = s.debugTaps.redactor.RedactBody(requestBody)
+		}
+		if writer.body.Len() > 0 {
+			capture.ResponseBody = s.debugTaps.redactor.RedactBody(writer.body.Bytes())
+		}
+		s.debugTaps.record(taps, capture)
+	}
+}
+
+// handleStartDebugTap starts a time-boxed debug tap.
+// POST /admin/debug/taps.
+func (s *Server) handleStartDebugTap(c *gin.Context) {
+	var req debugTapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+
+	if req.Route == "" && req.TenantID == "" && req.RequestID == "" {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest",
+			"At least one of route, tenantId or requestId must be set")
+		return
+	}
+
+	duration := defaultDebugTapDuration
+	if req.Duration != "" {
+		parsed, err := time.ParseDuration(req.Duration)
+		if err != nil || parsed <= 0 {
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid duration: "+req.Duration)
+			return
+		}
+		duration = parsed
+	}
+	if maxDuration := s.debugTaps.cfg.MaxDuration; duration > maxDuration {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Duration exceeds the maximum of "+maxDuration.String())
+		return
+	}
+
+	now := time.Now().UTC()
+	tap := &debugTap{
+		DebugTap: DebugTap{
+			TapID:     uuid.New().String(),
+			Route:     req.Route,
+			Method:    strings.ToUpper(req.Method),
+			TenantID:  req.TenantID,
+			RequestID: req.RequestID,
+			CreatedAt: now,
+			ExpiresAt: now.Add(duration),
+		},
+		captures: make([]DebugTapCapture, 0, s.debugTaps.cfg.BufferSize),
+	}
+	if !s.debugTaps.start(tap, now) {
+		problem.Respond(c, http.StatusConflict, "Conflict", "Too many active debug taps; stop one first")
+		return
+	}
+
+	s.requestLogger(c).Info("debug tap started",
+		zap.String("tap_id", tap.TapID),
+		zap.String("route", tap.Route),
+		zap.String("method", tap.Method),
+		zap.String("tenant_id", tap.TenantID),
+		zap.String("tapped_request_id", tap.RequestID),
+		zap.Time("expires_at", tap.ExpiresAt),
+	)
+
+	c.JSON(http.StatusCreated, tap.view(now, false))
+}
+
+// handleListDebugTaps lists the debug taps without their captures.
+// GET /admin/debug/taps.
+func (s *Server) handleListDebugTaps(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"taps": s.debugTaps.list(time.Now())})
+}
+
+// handleGetDebugTap returns a debug tap with its captures.
+// GET /admin/debug/taps/:tapId.
+func (s *Server) handleGetDebugTap(c *gin.Context) {
+	tap, ok := s.debugTaps.get(c.Param("tapId"), time.Now())
+	if !ok {
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Debug tap not found")
+		return
+	}
+	c.JSON(http.StatusOK, tap)
+}
+
+// handleStopDebugTap stops a debug tap and discards its captures.
+// DELETE /admin/debug/taps/:tapId.
+func (s *Server) handleStopDebugTap(c *gin.Context) {
+	if !s.debugTaps.stop(c.Param("tapId")) {
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Debug tap not found")
+		return
+	}
+	s.requestLogger(c).Info("debug tap stopped", zap.String("tap_id", c.Param("tapId")))
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+const (
+	debugTapsPath     = "/admin/debug/taps"
+	tappedResources   = "/o2ims-infrastructureInventory/v1/resources"
+	tappedPoolsPrefix = "/o2ims-infrastructureInventory/v1/resourcePools"
+)
+
+func newDebugTapServer(t *testing.T) *server.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Observability: config.ObservabilityConfig{
+			DebugTap: config.DebugTapConfig{
+				Enabled:      true,
+				MaxTaps:      2,
+				MaxDuration:  time.Hour,
+				BufferSize:   2,
+				MaxBodyBytes: 1024,
+			},
+		},
+	}
+	adp := &filteringAdapter{
+		resources: []*adapter.Resource{{ResourceID: "node-1", ResourcePoolID: "pool-a"}},
+		pools:     []*adapter.ResourcePool{{ResourcePoolID: "pool-a", Name: "Pool A"}},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), adp, &mockStore{})
+	return srv
+}
+
+func startDebugTap(t *testing.T, srv *server.Server, body map[string]string) server.DebugTap {
+	t.Helper()
+	resp, respBody := doResourceRequest(t, srv, http.MethodPost, debugTapsPath, body)
+	require.Equal(t, http.StatusCreated, resp.Code, string(respBody))
+	var tap server.DebugTap
+	require.NoError(t, json.Unmarshal(respBody, &tap))
+	return tap
+}
+
+func getDebugTap(t *testing.T, srv *server.Server, id string) server.DebugTap {
+	t.Helper()
+	resp, body := doResourceRequest(t, srv, http.MethodGet, debugTapsPath+"/"+id, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var tap server.DebugTap
+	require.NoError(t, json.Unmarshal(body, &tap))
+	return tap
+}
+
+func TestDebugTap_CapturesMatchingRequests(t *testing.T) {
+	srv := newDebugTapServer(t)
+
+	tap := startDebugTap(t, srv, map[string]string{"route": tappedResources, "duration": "10m"})
+	assert.True(t, tap.Active)
+	assert.WithinDuration(t, tap.CreatedAt.Add(10*time.Minute), tap.ExpiresAt, time.Second)
+
+	resp, _ := doResourceRequest(t, srv, http.MethodPost, tappedResources, map[string]interface{}{
+		"resourcePoolId": "pool-a",
+		"extensions":     map[string]string{"password": "hunter2"},
+	})
+	createStatus := resp.Code
+	doResourceRequest(t, srv, http.MethodGet, tappedResources, nil)
+	doResourceRequest(t, srv, http.MethodGet, tappedPoolsPrefix, nil)
+
+	tap = getDebugTap(t, srv, tap.TapID)
+	assert.Equal(t, 2, tap.Captured)
+	require.Len(t, tap.Captures, 2)
+
+	created := tap.Captures[0]
+	assert.Equal(t, http.MethodPost, created.Method)
+	assert.Equal(t, createStatus, created.Status)
+	assert.Contains(t, created.RequestBody, `"password":"[REDACTED]"`)
+	assert.NotContains(t, created.RequestBody, "hunter2")
+
+	listed := tap.Captures[1]
+	assert.Equal(t, http.MethodGet, listed.Method)
+	assert.Equal(t, http.StatusOK, listed.Status)
+	assert.Contains(t, listed.ResponseBody, "node-1")
+}
+
+func TestDebugTap_RingBufferAndPrefix(t *testing.T) {
+	srv := newDebugTapServer(t)
+
+	tap := startDebugTap(t, srv, map[string]string{"route": tappedPoolsPrefix + "*", "method": "get"})
+	for range 3 {
+		doResourceRequest(t, srv, http.MethodGet, tappedPoolsPrefix, nil)
+	}
+	doResourceRequest(t, srv, http.MethodGet, tappedPoolsPrefix+"/pool-a", nil)
+
+	tap = getDebugTap(t, srv, tap.TapID)
+	assert.Equal(t, 4, tap.Captured)
+	require.Len(t, tap.Captures, 2)
+	assert.Equal(t, tappedPoolsPrefix, tap.Captures[0].Path)
+	assert.Equal(t, tappedPoolsPrefix+"/pool-a", tap.Captures[1].Path)
+}
+
+func TestDebugTap_TenantMismatchNotCaptured(t *testing.T) {
+	srv := newDebugTapServer(t)
+
+	tap := startDebugTap(t, srv, map[string]string{"tenantId": "tenant-a"})
+	doResourceRequest(t, srv, http.MethodGet, tappedResources, nil)
+
+	assert.Zero(t, getDebugTap(t, srv, tap.TapID).Captured)
+}
+
+func TestDebugTap_AdminAPI(t *testing.T) {
+	srv := newDebugTapServer(t)
+
+	for _, body := range []map[string]string{
+		{},
+		{"route": tappedResources, "duration": "soon"},
+		{"route": tappedResources, "duration": "2h"},
+	} {
+		resp, _ := doResourceRequest(t, srv, http.MethodPost, debugTapsPath, body)
+		assert.Equal(t, http.StatusBadRequest, resp.Code, body)
+	}
+
+	first := startDebugTap(t, srv, map[string]string{"requestId": "req-1"})
+	startDebugTap(t, srv, map[string]string{"tenantId": "tenant-a"})
+	resp, _ := doResourceRequest(t, srv, http.MethodPost, debugTapsPath, map[string]string{"route": tappedResources})
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, debugTapsPath, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var list struct {
+		Taps []server.DebugTap `json:"taps"`
+	}
+	require.NoError(t, json.Unmarshal(body, &list))
+	assert.Len(t, list.Taps, 2)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodDelete, debugTapsPath+"/"+first.TapID, nil)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, debugTapsPath+"/"+first.TapID, nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	resp, _ = doResourceRequest(t, srv, http.MethodDelete, debugTapsPath+"/"+first.TapID, nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
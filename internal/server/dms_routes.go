@@ -2,6 +2,8 @@ package server
 
 import (
 	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/auth"
 	dmshandlers "github.com/piwi3910/netweave/internal/dms/handlers"
 )
 
@@ -26,7 +28,7 @@ func (s *Server) setupDMSRoutes(handler *dmshandlers.Handler) {
 	// O2-DMS API v3 routes (multi-tenancy)
 	v3 := s.router.Group("/o2dms/v3")
 	{
-		v3.Use(TenantMiddleware())
+		v3.Use(TenantMiddleware(s.tenantGetter()))
 		s.setupDMSV3Routes(v3, handler)
 	}
 
@@ -47,6 +49,15 @@ func (s *Server) setupDMSV1Routes(v1 *gin.RouterGroup, handler *dmshandlers.Hand
 
 	// DMS Subscription Management
 	s.setupDMSSubscriptionRoutes(v1, handler)
+
+	// In-progress operation management (cancellation)
+	s.setupDMSOperationRoutes(v1, handler)
+
+	// Asynchronous deployment operation status
+	v1.GET("/jobs/:jobId", handler.GetDMSJob)
+
+	// Adapter registration at runtime
+	s.setupDMSAdapterRoutes(v1, handler)
 }
 
 // setupDMSV2Routes configures the O2-DMS API v2 endpoints with enhanced features.
@@ -163,10 +174,40 @@ func (s *Server) setupNFDeploymentRoutes(v1 *gin.RouterGroup, handler *dmshandle
 		// Lifecycle operations
 		nfDeployments.POST("/:nfDeploymentId/scale", handler.ScaleNFDeployment)
 		nfDeployments.POST("/:nfDeploymentId/rollback", handler.RollbackNFDeployment)
+		nfDeployments.POST("/:nfDeploymentId/preview", handler.PreviewNFDeployment)
+		nfDeployments.POST("/:nfDeploymentId/suspend", handler.SuspendNFDeployment)
+		nfDeployments.POST("/:nfDeploymentId/resume", handler.ResumeNFDeployment)
+		nfDeployments.POST("/:nfDeploymentId/reconcile", handler.ReconcileNFDeployment)
+		nfDeployments.GET("/:nfDeploymentId/syncPolicy", handler.GetNFDeploymentSyncPolicy)
+		nfDeployments.PATCH("/:nfDeploymentId/syncPolicy", handler.UpdateNFDeploymentSyncPolicy)
 
 		// Status and history
 		nfDeployments.GET("/:nfDeploymentId/status", handler.GetNFDeploymentStatus)
 		nfDeployments.GET("/:nfDeploymentId/history", handler.GetNFDeploymentHistory)
+		nfDeployments.GET("/:nfDeploymentId/notes", handler.GetNFDeploymentNotes)
+	}
+}
+
+// setupDMSOperationRoutes configures routes for in-progress deployment operations.
+func (s *Server) setupDMSOperationRoutes(v1 *gin.RouterGroup, handler *dmshandlers.Handler) {
+	operations := v1.Group("/operations")
+	{
+		operations.DELETE("/:operationId", handler.CancelOperation)
+	}
+}
+
+// setupDMSAdapterRoutes configures the routes listing, registering and
+// unregistering DMS adapters. Registering creates clients from arbitrary
+// kubeconfig paths and endpoints, so changes require the dmsAdapters:manage
+// permission.
+func (s *Server) setupDMSAdapterRoutes(v1 *gin.RouterGroup, handler *dmshandlers.Handler) {
+	adapters := v1.Group("/adapters")
+	{
+		adapters.GET("", handler.ListDMSAdapters)
+		adapters.POST("", s.withPermission(string(auth.PermissionDMSAdapterManage), handler.RegisterDMSAdapter))
+		adapters.GET("/:adapterName", handler.GetDMSAdapter)
+		adapters.DELETE("/:adapterName",
+			s.withPermission(string(auth.PermissionDMSAdapterManage), handler.UnregisterDMSAdapter))
 	}
 }
 
@@ -188,7 +229,9 @@ func (s *Server) setupDMSSubscriptionRoutes(v1 *gin.RouterGroup, handler *dmshan
 		subscriptions.GET("", handler.ListDMSSubscriptions)
 		subscriptions.POST("", handler.CreateDMSSubscription)
 		subscriptions.GET("/:subscriptionId", handler.GetDMSSubscription)
+		subscriptions.PUT("/:subscriptionId", handler.UpdateDMSSubscription)
 		subscriptions.DELETE("/:subscriptionId", handler.DeleteDMSSubscription)
+		subscriptions.GET("/:subscriptionId/deliveries", handler.ListDMSSubscriptionDeliveries)
 	}
 }
 
@@ -203,6 +246,8 @@ func (s *Server) HandleDMSAPIInfo(c *gin.Context) {
 			"nfDeployments",
 			"nfDeploymentDescriptors",
 			"subscriptions",
+			"jobs",
+			"adapters",
 		},
 		"operations": []string{
 			"instantiate",
@@ -135,11 +135,13 @@ func TestHandleDMSAPIInfo(t *testing.T) {
 
 	resources, ok := response["resources"].([]interface{})
 	require.True(t, ok)
-	assert.Len(t, resources, 4)
+	assert.Len(t, resources, 6)
 	assert.Contains(t, resources, "deploymentLifecycle")
 	assert.Contains(t, resources, "nfDeployments")
 	assert.Contains(t, resources, "nfDeploymentDescriptors")
 	assert.Contains(t, resources, "subscriptions")
+	assert.Contains(t, resources, "jobs")
+	assert.Contains(t, resources, "adapters")
 
 	operations, ok := response["operations"].([]interface{})
 	require.True(t, ok)
@@ -200,6 +202,8 @@ func TestSetupDMSRoutes(t *testing.T) {
 	assert.Contains(t, routePaths["/o2dms/v1/subscriptions"], "POST")
 	assert.Contains(t, routePaths["/o2dms/v1/subscriptions/:subscriptionId"], http.MethodGet)
 	assert.Contains(t, routePaths["/o2dms/v1/subscriptions/:subscriptionId"], "DELETE")
+	assert.Contains(t, routePaths["/o2dms/v1/subscriptions/:subscriptionId/deliveries"], http.MethodGet)
+	assert.Contains(t, routePaths["/o2dms/v1/jobs/:jobId"], http.MethodGet)
 }
 
 func TestDMSRoutesIntegration(t *testing.T) {
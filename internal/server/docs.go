@@ -2,9 +2,19 @@
 package server
 
 import (
+	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/problem"
 )
 
 // Swagger UI version and CDN configuration with SRI hashes for security.
@@ -32,8 +42,22 @@ const (
 		"img-src 'self' data: https:; " +
 		"font-src 'self' https://unpkg.com; " +
 		"connect-src 'self'"
+
+	// ClientCertificateScheme is the security scheme added to the served
+	// specification when authentication is enabled.
+	ClientCertificateScheme = "clientCertificate"
+
+	// ViewerExtension is the info extension describing the authenticated
+	// viewer of the served specification.
+	ViewerExtension = "x-viewer"
 )
 
+// docsViewer identifies the client of a documentation request without
+// requiring authentication; *auth.Middleware implements it.
+type docsViewer interface {
+	Viewer(c *gin.Context) (*auth.AuthenticatedUser, *auth.Tenant)
+}
+
 // SetupDocsRoutes configures documentation endpoints.
 // This includes the OpenAPI specification and Swagger UI for interactive API exploration.
 func (s *Server) SetupDocsRoutes() {
@@ -44,6 +68,9 @@ func (s *Server) SetupDocsRoutes() {
 		docs.GET("/openapi.yaml", s.HandleOpenAPIYAML)
 		docs.GET("/openapi.json", s.HandleOpenAPIJSON)
 
+		// Postman v2.1 collection generated from the specification
+		docs.GET("/postman.json", s.HandlePostmanCollection)
+
 		// Swagger UI
 		docs.GET("", s.HandleSwaggerUIRedirect)
 		docs.GET("/", s.HandleSwaggerUI)
@@ -55,18 +82,169 @@ func (s *Server) SetupDocsRoutes() {
 }
 
 // HandleOpenAPIYAML serves the OpenAPI specification in YAML format.
+// Relative server URLs are served with scheme, host and port server
+// variables defaulting to the values the client used to reach the gateway.
+// When authentication is enabled the specification declares the client
+// certificate security scheme, and an authenticated viewer gets a private
+// copy naming their tenant and role.
 func (s *Server) HandleOpenAPIYAML(c *gin.Context) {
 	if len(s.openAPISpec) == 0 {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "NotFound",
-			"message": "OpenAPI specification not loaded",
-			"code":    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "OpenAPI specification not loaded")
 		return
 	}
+
+	spec, cacheControl := s.openAPISpec, "public, max-age=3600"
+	rendered, private, err := s.renderOpenAPISpec(c)
+	switch {
+	case err != nil:
+		if s.logger != nil {
+			s.requestLogger(c).Warn("failed to render OpenAPI specification, serving it unchanged", zap.Error(err))
+		}
+	case rendered != nil:
+		spec = rendered
+		c.Header("Vary", "Host, X-Forwarded-Proto, X-Forwarded-Client-Cert, X-SSL-Client-DN")
+		if private {
+			cacheControl = "private, max-age=3600"
+		}
+	}
+
 	c.Header("Content-Type", "application/x-yaml")
-	c.Header("Cache-Control", "public, max-age=3600")
-	c.Data(http.StatusOK, "application/x-yaml", s.openAPISpec)
+	c.Header("Cache-Control", cacheControl)
+	c.Data(http.StatusOK, "application/x-yaml", spec)
+}
+
+// renderOpenAPISpec adapts the loaded specification to the request. It
+// returns nil if the specification needs no changes, and whether the result
+// is specific to the authenticated viewer.
+func (s *Server) renderOpenAPISpec(c *gin.Context) ([]byte, bool, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(s.openAPISpec)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+
+	var user *auth.AuthenticatedUser
+	var tenant *auth.Tenant
+	viewer, authEnabled := s.authMw.(docsViewer)
+	if authEnabled {
+		user, tenant = viewer.Viewer(c)
+	}
+
+	changed := addServerVariables(spec.Servers, c, tenant)
+	for _, item := range spec.Paths.Map() {
+		if addServerVariables(item.Servers, c, tenant) {
+			changed = true
+		}
+	}
+	if authEnabled {
+		addClientCertificateScheme(spec)
+		changed = true
+	}
+	if user != nil {
+		spec.Info.Extensions = setExtension(spec.Info.Extensions, ViewerExtension, viewerInfo(user, tenant))
+	}
+	if !changed {
+		return nil, false, nil
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode OpenAPI specification: %w", err)
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode OpenAPI specification: %w", err)
+	}
+	return data, user != nil, nil
+}
+
+// addServerVariables turns the relative URLs of servers into absolute URLs
+// with scheme, host and port variables defaulting to the values the client
+// used to reach the gateway. Server descriptions name the viewer's tenant,
+// if any. It reports whether any server was changed.
+func addServerVariables(servers openapi3.Servers, c *gin.Context, tenant *auth.Tenant) bool {
+	scheme, host, port := requestServerAddress(c)
+	changed := false
+	for _, server := range servers {
+		if !strings.HasPrefix(server.URL, "/") {
+			continue
+		}
+		server.URL = "{scheme}://{host}:{port}" + server.URL
+		server.Variables = map[string]*openapi3.ServerVariable{
+			"scheme": {Enum: []string{"http", "https"}, Default: scheme, Description: "URL scheme"},
+			"host":   {Default: host, Description: "Gateway host name"},
+			"port":   {Default: port, Description: "Gateway port"},
+		}
+		if tenant != nil {
+			server.Description = strings.TrimSpace(fmt.Sprintf("%s (tenant %s)", server.Description, tenant.Name))
+		}
+		changed = true
+	}
+	return changed
+}
+
+// requestServerAddress returns the scheme, host and port the client used to
+// reach the gateway.
+func requestServerAddress(c *gin.Context) (string, string, string) {
+	scheme := requestScheme(c)
+	host, port, err := net.SplitHostPort(c.Request.Host)
+	if err != nil {
+		host, port = c.Request.Host, "80"
+		if scheme == "https" {
+			port = "443"
+		}
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return scheme, host, port
+}
+
+// addClientCertificateScheme declares that every operation authenticates the
+// client with its certificate. OpenAPI 3.0 has no mutual TLS scheme, so it is
+// described as the header a TLS-terminating proxy forwards the certificate in.
+func addClientCertificateScheme(spec *openapi3.T) {
+	if spec.Components == nil {
+		spec.Components = &openapi3.Components{}
+	}
+	if spec.Components.SecuritySchemes == nil {
+		spec.Components.SecuritySchemes = openapi3.SecuritySchemes{}
+	}
+	spec.Components.SecuritySchemes[ClientCertificateScheme] = &openapi3.SecuritySchemeRef{
+		Value: &openapi3.SecurityScheme{
+			Type: "apiKey",
+			In:   "header",
+			Name: "X-Forwarded-Client-Cert",
+			Description: "Mutual TLS. Clients connect with their client certificate; " +
+				"a TLS-terminating proxy forwards it to the gateway in this header.",
+		},
+	}
+	spec.Security = openapi3.SecurityRequirements{
+		openapi3.NewSecurityRequirement().Authenticate(ClientCertificateScheme),
+	}
+}
+
+// viewerInfo describes the authenticated viewer in the served specification.
+func viewerInfo(user *auth.AuthenticatedUser, tenant *auth.Tenant) map[string]interface{} {
+	info := map[string]interface{}{
+		"subject":  user.Subject,
+		"tenantId": user.TenantID,
+	}
+	if tenant != nil {
+		info["tenantName"] = tenant.Name
+	}
+	if user.Role != nil {
+		info["role"] = string(user.Role.Name)
+	}
+	return info
+}
+
+// setExtension sets an extension, allocating the map if needed.
+func setExtension(extensions map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	if extensions == nil {
+		extensions = make(map[string]interface{})
+	}
+	extensions[name] = value
+	return extensions
 }
 
 // HandleOpenAPIJSON redirects to the YAML endpoint.
@@ -83,7 +261,9 @@ func (s *Server) HandleSwaggerUIRedirect(c *gin.Context) {
 	c.Redirect(http.StatusMovedPermanently, "/docs/")
 }
 
-// HandleSwaggerUI serves the Swagger UI HTML page.
+// HandleSwaggerUI serves the Swagger UI HTML page. The page loads the
+// specification with the viewer's credentials, so it shows the server
+// variables and security schemes rendered for them.
 // Security features:
 // - Pinned CDN versions to prevent supply chain attacks
 // - Content Security Policy header to restrict resource loading
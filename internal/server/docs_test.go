@@ -6,11 +6,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/piwi3910/netweave/internal/auth"
 	"github.com/piwi3910/netweave/internal/server"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 // createTestServer creates a minimal server.server for testing documentation handlers.
@@ -299,3 +302,107 @@ func TestSwaggerUICSPConstants(t *testing.T) {
 	assert.Contains(t, server.SwaggerUICSP, "connect-src 'self'")
 	assert.Contains(t, server.SwaggerUICSP, "https://unpkg.com")
 }
+
+// viewerAuthMiddleware identifies clients presenting an X-SSL-Client-DN header
+// as a tenant admin of tenant-1.
+type viewerAuthMiddleware struct {
+	mockAuthMiddleware
+}
+
+func (m *viewerAuthMiddleware) Viewer(c *gin.Context) (*auth.AuthenticatedUser, *auth.Tenant) {
+	dn := c.GetHeader("X-SSL-Client-DN")
+	if dn == "" {
+		return nil, nil
+	}
+	return &auth.AuthenticatedUser{
+			UserID: "user-1", TenantID: "tenant-1", Subject: dn,
+			Role: &auth.Role{Name: auth.RoleTenantAdmin},
+		},
+		&auth.Tenant{ID: "tenant-1", Name: "Acme"}
+}
+
+func TestHandleOpenAPIYAML_Rendered(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testSpec := []byte(`openapi: 3.0.3
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: /o2ims-infrastructureInventory/v1
+    description: Inventory API
+paths:
+  /reconcile:
+    servers:
+      - url: /o2ims-infrastructureInventory/v3
+    post:
+      responses:
+        '200':
+          description: OK
+`)
+	fetch := func(
+		t *testing.T, srv *server.Server, configure func(*http.Request),
+	) (*httptest.ResponseRecorder, *openapi3.T) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil)
+		req.Host = "gateway.example:8443"
+		if configure != nil {
+			configure(req)
+		}
+		w := httptest.NewRecorder()
+		srv.Router().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		spec, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+		require.NoError(t, err)
+		return w, spec
+	}
+	newServer := func(authMw server.AuthMiddleware) *server.Server {
+		srv := server.NewTestServerWithRouter(gin.New(), zap.NewNop())
+		if authMw != nil {
+			srv.SetupAuth(&mockAuthStore{}, authMw)
+		}
+		srv.SetOpenAPISpec(testSpec)
+		srv.Router().GET("/openapi.yaml", srv.HandleOpenAPIYAML)
+		return srv
+	}
+
+	t.Run("server variables", func(t *testing.T) {
+		w, spec := fetch(t, newServer(nil), func(req *http.Request) {
+			req.Header.Set("X-Forwarded-Proto", "https")
+		})
+		assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+		require.Len(t, spec.Servers, 1)
+		srv := spec.Servers[0]
+		assert.Equal(t, "{scheme}://{host}:{port}/o2ims-infrastructureInventory/v1", srv.URL)
+		assert.Equal(t, "https", srv.Variables["scheme"].Default)
+		assert.Equal(t, []string{"http", "https"}, srv.Variables["scheme"].Enum)
+		assert.Equal(t, "gateway.example", srv.Variables["host"].Default)
+		assert.Equal(t, "8443", srv.Variables["port"].Default)
+		assert.Equal(t, "{scheme}://{host}:{port}/o2ims-infrastructureInventory/v3",
+			spec.Paths.Find("/reconcile").Servers[0].URL)
+		assert.Empty(t, spec.Security, "no security scheme without authentication")
+	})
+
+	t.Run("anonymous viewer", func(t *testing.T) {
+		w, spec := fetch(t, newServer(&viewerAuthMiddleware{}), nil)
+		assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+		assert.Contains(t, spec.Components.SecuritySchemes, server.ClientCertificateScheme)
+		require.Len(t, spec.Security, 1)
+		assert.Contains(t, spec.Security[0], server.ClientCertificateScheme)
+		assert.NotContains(t, spec.Info.Extensions, server.ViewerExtension)
+		assert.Equal(t, "Inventory API", spec.Servers[0].Description)
+	})
+
+	t.Run("authenticated viewer", func(t *testing.T) {
+		w, spec := fetch(t, newServer(&viewerAuthMiddleware{}), func(req *http.Request) {
+			req.Header.Set("X-SSL-Client-DN", "CN=alice,O=Acme")
+		})
+		assert.Equal(t, "private, max-age=3600", w.Header().Get("Cache-Control"))
+		assert.Equal(t, "Inventory API (tenant Acme)", spec.Servers[0].Description)
+		assert.Equal(t, map[string]interface{}{
+			"subject":    "CN=alice,O=Acme",
+			"tenantId":   "tenant-1",
+			"tenantName": "Acme",
+			"role":       string(auth.RoleTenantAdmin),
+		}, spec.Info.Extensions[server.ViewerExtension])
+	})
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/resolver"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Egress target resolution defaults.
+const (
+	// DefaultEgressResolveTimeout bounds the DNS lookup of each callback host.
+	DefaultEgressResolveTimeout = 5 * time.Second
+
+	// egressResolveConcurrency is the number of callback hosts resolved in parallel.
+	egressResolveConcurrency = 8
+)
+
+// EgressResolver resolves a hostname to IP addresses.
+type EgressResolver func(ctx context.Context, host string) ([]string, error)
+
+// EgressTargetReport lists the hosts and addresses the gateway sends
+// notifications to, for generating outbound firewall rules.
+type EgressTargetReport struct {
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Targets     []EgressTarget `json:"targets"`
+
+	// Addresses are the distinct resolved IP addresses across all targets.
+	Addresses []string `json:"addresses"`
+
+	// ChangedTargets is the number of targets that resolved to new addresses.
+	ChangedTargets int `json:"changedTargets"`
+}
+
+// EgressTarget is a distinct callback host:port across active subscriptions.
+type EgressTarget struct {
+	Host          string   `json:"host"`
+	Port          string   `json:"port"`
+	Schemes       []string `json:"schemes"`
+	Subscriptions int      `json:"subscriptions"`
+
+	// Addresses are the IP addresses the host resolved to at ResolvedAt.
+	Addresses  []string  `json:"addresses"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+
+	// NewAddresses are addresses not seen for this host before the last
+	// resolution. They likely need new firewall rules.
+	NewAddresses []string `json:"newAddresses,omitempty"`
+	Changed      bool     `json:"changed"`
+
+	// ResolutionError is set when the host could not be resolved.
+	ResolutionError string `json:"resolutionError,omitempty"`
+}
+
+// egressResolution is the cached resolution of a callback host.
+type egressResolution struct {
+	addresses    []string
+	newAddresses []string
+	resolvedAt   time.Time
+	err          string
+}
+
+// EgressTargetTracker resolves subscription callback hosts and remembers every
+// address each host has resolved to, so that hosts moving to new addresses
+// can be flagged. Resolutions are cached until refreshed.
+type EgressTargetTracker struct {
+	mu          sync.Mutex
+	resolver    EgressResolver
+	timeout     time.Duration
+	resolutions map[string]*egressResolution
+	seen        map[string]map[string]struct{}
+	logger      *zap.Logger
+}
+
+// NewEgressTargetTracker creates a tracker using lookup for DNS lookups.
+// A nil lookup uses the shared outbound resolver.
+func NewEgressTargetTracker(lookup EgressResolver, logger *zap.Logger) *EgressTargetTracker {
+	if lookup == nil {
+		lookup = resolver.Default().LookupHost
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &EgressTargetTracker{
+		resolver:    lookup,
+		timeout:     DefaultEgressResolveTimeout,
+		resolutions: make(map[string]*egressResolution),
+		seen:        make(map[string]map[string]struct{}),
+		logger:      logger,
+	}
+}
+
+// SetEgressTargetTracker replaces the tracker used by GET /admin/egress-targets.
+func (s *Server) SetEgressTargetTracker(tracker *EgressTargetTracker) {
+	s.egressTargets = tracker
+}
+
+// Report builds the egress target report for subs. Hosts without a cached
+// resolution are resolved; when refresh is set every host is re-resolved.
+func (t *EgressTargetTracker) Report(ctx context.Context, subs []*storage.Subscription, refresh bool) *EgressTargetReport {
+	targets := groupEgressTargets(subs)
+
+	hosts := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		hosts[target.Host] = struct{}{}
+	}
+	t.resolveHosts(ctx, hosts, refresh)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := &EgressTargetReport{
+		GeneratedAt: timeutil.Now(),
+		Targets:     make([]EgressTarget, 0, len(targets)),
+		Addresses:   []string{},
+	}
+	addresses := make(map[string]struct{})
+	for _, target := range targets {
+		if res := t.resolutions[target.Host]; res != nil {
+			target.Addresses = res.addresses
+			target.NewAddresses = res.newAddresses
+			target.Changed = len(res.newAddresses) > 0
+			target.ResolvedAt = res.resolvedAt
+			target.ResolutionError = res.err
+		}
+		if target.Changed {
+			report.ChangedTargets++
+		}
+		for _, addr := range target.Addresses {
+			addresses[addr] = struct{}{}
+		}
+		report.Targets = append(report.Targets, *target)
+	}
+	for addr := range addresses {
+		report.Addresses = append(report.Addresses, addr)
+	}
+	sort.Strings(report.Addresses)
+
+	return report
+}
+
+// resolveHosts resolves the hosts that have no cached resolution, or all of
+// them when refresh is set.
+func (t *EgressTargetTracker) resolveHosts(ctx context.Context, hosts map[string]struct{}, refresh bool) {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, egressResolveConcurrency)
+	)
+	for host := range hosts {
+		t.mu.Lock()
+		_, cached := t.resolutions[host]
+		t.mu.Unlock()
+		if cached && !refresh {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			t.resolve(ctx, host)
+		}()
+	}
+	wg.Wait()
+}
+
+// resolve looks up host and records addresses not seen for it before.
+func (t *EgressTargetTracker) resolve(ctx context.Context, host string) {
+	var (
+		addrs []string
+		err   error
+	)
+	if ip := net.ParseIP(host); ip != nil {
+		addrs = []string{ip.String()}
+	} else {
+		lookupCtx, cancel := context.WithTimeout(ctx, t.timeout)
+		addrs, err = t.resolver(lookupCtx, host)
+		cancel()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := &egressResolution{resolvedAt: timeutil.Now()}
+	if err != nil || len(addrs) == 0 {
+		// Keep the last known addresses so existing firewall rules stay listed.
+		if prev := t.resolutions[host]; prev != nil {
+			res.addresses = prev.addresses
+		}
+		res.err = "hostname could not be resolved"
+		t.resolutions[host] = res
+		return
+	}
+
+	res.addresses = normalizeAddresses(addrs)
+	seen, known := t.seen[host]
+	if !known {
+		seen = make(map[string]struct{}, len(res.addresses))
+		t.seen[host] = seen
+	}
+	for _, addr := range res.addresses {
+		if _, ok := seen[addr]; !ok {
+			if known {
+				res.newAddresses = append(res.newAddresses, addr)
+			}
+			seen[addr] = struct{}{}
+		}
+	}
+	t.resolutions[host] = res
+
+	if len(res.newAddresses) > 0 {
+		t.logger.Warn("callback host resolved to new addresses",
+			zap.String("host", host),
+			zap.Strings("new_addresses", res.newAddresses))
+	}
+}
+
+// groupEgressTargets groups subscription callbacks by host and port, sorted
+// by host then port. Callbacks that are not valid http(s) URLs are skipped.
+func groupEgressTargets(subs []*storage.Subscription) []*EgressTarget {
+	byKey := make(map[string]*EgressTarget)
+	for _, sub := range subs {
+		if sub == nil {
+			continue
+		}
+		parsed, err := url.Parse(sub.Callback)
+		if err != nil || parsed.Hostname() == "" {
+			continue
+		}
+		scheme := strings.ToLower(parsed.Scheme)
+		if scheme != "http" && scheme != "https" {
+			continue
+		}
+		host := strings.ToLower(parsed.Hostname())
+		port := parsed.Port()
+		if port == "" {
+			port = "80"
+			if scheme == "https" {
+				port = "443"
+			}
+		}
+
+		key := net.JoinHostPort(host, port)
+		target, ok := byKey[key]
+		if !ok {
+			target = &EgressTarget{Host: host, Port: port, Addresses: []string{}}
+			byKey[key] = target
+		}
+		target.Subscriptions++
+		if !slices.Contains(target.Schemes, scheme) {
+			target.Schemes = append(target.Schemes, scheme)
+			sort.Strings(target.Schemes)
+		}
+	}
+
+	targets := make([]*EgressTarget, 0, len(byKey))
+	for _, target := range byKey {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Host != targets[j].Host {
+			return targets[i].Host < targets[j].Host
+		}
+		return targets[i].Port < targets[j].Port
+	})
+	return targets
+}
+
+// normalizeAddresses returns the distinct addresses in canonical form, sorted.
+func normalizeAddresses(addrs []string) []string {
+	set := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil {
+			addr = ip.String()
+		}
+		set[addr] = struct{}{}
+	}
+	out := make([]string, 0, len(set))
+	for addr := range set {
+		out = append(out, addr)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// handleEgressTargets lists the distinct callback hosts and resolved IP
+// addresses across active subscriptions.
+// GET /admin/egress-targets?refresh=true.
+func (s *Server) handleEgressTargets(c *gin.Context) {
+	refresh := false
+	switch c.Query("refresh") {
+	case "", "false":
+	case "true":
+		refresh = true
+	default:
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "refresh must be true or false")
+		return
+	}
+
+	ctx := c.Request.Context()
+	subs, err := s.store.List(ctx)
+	if err != nil {
+		s.logger.Error("failed to list subscriptions for egress targets", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to list subscriptions")
+		return
+	}
+
+	c.JSON(http.StatusOK, s.egressTargets.Report(ctx, subs, refresh))
+}
@@ -0,0 +1,125 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// egressStore serves a fixed subscription list.
+type egressStore struct {
+	mockStore
+	subs []*storage.Subscription
+}
+
+func (m *egressStore) List(_ context.Context) ([]*storage.Subscription, error) {
+	return m.subs, nil
+}
+
+// fakeResolver serves DNS answers that tests can change between lookups.
+type fakeResolver struct {
+	mu      sync.Mutex
+	answers map[string][]string
+	lookups int
+}
+
+func (r *fakeResolver) set(host string, addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.answers[host] = addrs
+}
+
+func (r *fakeResolver) lookup(_ context.Context, host string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	if addrs, ok := r.answers[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestEgressTargets(t *testing.T) {
+	const path = "/admin/egress-targets"
+
+	gin.SetMode(gin.TestMode)
+	store := &egressStore{subs: []*storage.Subscription{
+		{ID: "sub-1", Callback: "https://smo.example.com/notify"},
+		{ID: "sub-2", Callback: "https://SMO.example.com/other"},
+		{ID: "sub-3", Callback: "http://smo.example.com:8080/notify"},
+		{ID: "sub-4", Callback: "https://198.51.100.7/hook"},
+		{ID: "sub-5", Callback: "https://gone.example.com/notify"},
+	}}
+	cfg := &config.Config{Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode}}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), newMockResourceAdapter(), store)
+
+	resolver := &fakeResolver{answers: map[string][]string{}}
+	resolver.set("smo.example.com", "203.0.113.10", "203.0.113.11")
+	srv.SetEgressTargetTracker(server.NewEgressTargetTracker(resolver.lookup, zap.NewNop()))
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var report server.EgressTargetReport
+	require.NoError(t, json.Unmarshal(body, &report))
+
+	require.Len(t, report.Targets, 4)
+	assert.Equal(t, "198.51.100.7", report.Targets[0].Host)
+	assert.Equal(t, []string{"198.51.100.7"}, report.Targets[0].Addresses)
+
+	gone := report.Targets[1]
+	assert.Equal(t, "gone.example.com", gone.Host)
+	assert.NotEmpty(t, gone.ResolutionError)
+	assert.Empty(t, gone.Addresses)
+
+	smo := report.Targets[2]
+	assert.Equal(t, "smo.example.com", smo.Host)
+	assert.Equal(t, "443", smo.Port)
+	assert.Equal(t, 2, smo.Subscriptions)
+	assert.Equal(t, []string{"https"}, smo.Schemes)
+	assert.Equal(t, []string{"203.0.113.10", "203.0.113.11"}, smo.Addresses)
+	assert.False(t, smo.Changed)
+	assert.Equal(t, "8080", report.Targets[3].Port)
+
+	assert.Equal(t, []string{"198.51.100.7", "203.0.113.10", "203.0.113.11"}, report.Addresses)
+	assert.Zero(t, report.ChangedTargets)
+	assert.Equal(t, 2, resolver.lookups)
+
+	// Cached resolutions are reused until refreshed.
+	resolver.set("smo.example.com", "203.0.113.11", "203.0.113.12")
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 2, resolver.lookups)
+
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?refresh=true", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	report = server.EgressTargetReport{}
+	require.NoError(t, json.Unmarshal(body, &report))
+	smo = report.Targets[2]
+	assert.Equal(t, []string{"203.0.113.11", "203.0.113.12"}, smo.Addresses)
+	assert.Equal(t, []string{"203.0.113.12"}, smo.NewAddresses)
+	assert.True(t, smo.Changed)
+	assert.Equal(t, 2, report.ChangedTargets)
+
+	// Returning to a previously seen address is not flagged.
+	resolver.set("smo.example.com", "203.0.113.10")
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?refresh=true", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	report = server.EgressTargetReport{}
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.False(t, report.Targets[2].Changed)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, path+"?refresh=yes", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
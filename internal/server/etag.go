@@ -0,0 +1,107 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// objectETag returns the entity tag of an object: a quoted hash of its JSON
+// encoding. It identifies the version of the object's own attributes, so
+// derived data added to responses (e.g. cost estimates) and attribute
+// selection do not change it.
+func objectETag(obj interface{}) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagListMatches reports whether an If-Match or If-None-Match header value
+// lists etag or is "*". Weak comparison ignores the W/ prefix of listed tags;
+// strong comparison never matches a weak tag.
+func etagListMatches(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if weakTag, ok := strings.CutPrefix(tag, "W/"); ok {
+			if !weak {
+				continue
+			}
+			tag = weakTag
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// serveObject responds with obj and the ETag of etagSource, or with 304 Not
+// Modified if the client's If-None-Match lists that ETag. etagSource is the
+// object as stored; obj may add derived data to it.
+func (s *Server) serveObject(c *gin.Context, obj, etagSource interface{}) {
+	etag, err := objectETag(etagSource)
+	if err != nil {
+		s.requestLogger(c).Warn("failed to compute ETag", zap.Error(err))
+		c.JSON(http.StatusOK, obj)
+		return
+	}
+
+	c.Header("ETag", etag)
+	if match := c.GetHeader("If-None-Match"); match != "" && etagListMatches(match, etag, true) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, obj)
+}
+
+// checkIfMatch enforces the If-Match precondition of an update against the
+// current version of the object. It responds with 412 Precondition Failed and
+// returns false if the client's ETag is stale. Requests without If-Match
+// always pass.
+func (s *Server) checkIfMatch(c *gin.Context, current interface{}) bool {
+	match := c.GetHeader("If-Match")
+	if match == "" {
+		return true
+	}
+	etag, err := objectETag(current)
+	if err != nil {
+		s.requestLogger(c).Error("failed to compute ETag", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to evaluate If-Match")
+		return false
+	}
+	if etagListMatches(match, etag, false) {
+		return true
+	}
+
+	s.requestLogger(c).Info("update rejected by If-Match precondition",
+		zap.String("if_match", SanitizeForLogging(match)),
+		zap.String("etag", etag))
+	c.Header("ETag", etag)
+	problem.Respond(c, http.StatusPreconditionFailed, "PreconditionFailed",
+		"The object was modified since it was read; fetch it again and retry")
+	return false
+}
+
+// setETag sets the ETag header of an update response to the ETag of the
+// updated object.
+func (s *Server) setETag(c *gin.Context, obj interface{}) {
+	etag, err := objectETag(obj)
+	if err != nil {
+		s.requestLogger(c).Warn("failed to compute ETag", zap.Error(err))
+		return
+	}
+	c.Header("ETag", etag)
+}
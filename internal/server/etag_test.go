@@ -0,0 +1,118 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+// doConditionalRequest sends a request with the given conditional headers.
+func doConditionalRequest(
+	t *testing.T,
+	srv *server.Server,
+	method, path string,
+	body interface{},
+	headers map[string]string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	var reqBody bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &reqBody)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp := httptest.NewRecorder()
+	srv.Router().ServeHTTP(resp, req)
+	return resp
+}
+
+func TestResourceETag(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resources/550e8400-e29b-41d4-a716-446655440000"
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+
+	resp := doConditionalRequest(t, srv, http.MethodGet, path, nil, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	etag := resp.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp = doConditionalRequest(t, srv, http.MethodGet, path, nil, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.Bytes())
+	assert.Equal(t, etag, resp.Header().Get("ETag"))
+
+	resp = doConditionalRequest(t, srv, http.MethodGet, path, nil,
+		map[string]string{"If-None-Match": `"other", W/` + etag})
+	assert.Equal(t, http.StatusNotModified, resp.Code, "If-None-Match uses weak comparison")
+
+	resp = doConditionalRequest(t, srv, http.MethodGet, path, nil, map[string]string{"If-None-Match": `"other"`})
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	update := adapter.Resource{Description: "Updated resource"}
+	resp = doConditionalRequest(t, srv, http.MethodPut, path, update, map[string]string{"If-Match": etag})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	newETag := resp.Header().Get("ETag")
+	require.NotEmpty(t, newETag)
+	assert.NotEqual(t, etag, newETag)
+
+	resp = doConditionalRequest(t, srv, http.MethodPut, path, update, map[string]string{"If-Match": etag})
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code, "stale ETags are rejected")
+	assert.Equal(t, newETag, resp.Header().Get("ETag"))
+
+	resp = doConditionalRequest(t, srv, http.MethodGet, path, nil, map[string]string{"If-None-Match": newETag})
+	assert.Equal(t, http.StatusNotModified, resp.Code, "GET returns the ETag of the update response")
+}
+
+// gettablePoolAdapter serves GetResourcePool from the pools of a mockResourcePoolAdapter.
+type gettablePoolAdapter struct {
+	*mockResourcePoolAdapter
+}
+
+func (a gettablePoolAdapter) GetResourcePool(_ context.Context, id string) (*adapter.ResourcePool, error) {
+	if pool, ok := a.pools[id]; ok {
+		return pool, nil
+	}
+	return nil, adapter.ErrResourcePoolNotFound
+}
+
+func TestResourcePoolETag(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools/existing-pool"
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode}}
+	adp := gettablePoolAdapter{newMockResourcePoolAdapter()}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), adp, &mockStore{})
+
+	resp := doConditionalRequest(t, srv, http.MethodGet, path, nil, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	etag := resp.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp = doConditionalRequest(t, srv, http.MethodGet, path, nil, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+
+	update := adapter.ResourcePool{Name: "Renamed Pool", Location: "us-west-1"}
+	resp = doConditionalRequest(t, srv, http.MethodPut, path, update, map[string]string{"If-Match": `"stale"`})
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	assert.Equal(t, etag, resp.Header().Get("ETag"))
+
+	resp = doConditionalRequest(t, srv, http.MethodPut, path, update, map[string]string{"If-Match": etag})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+
+	resp = doConditionalRequest(t, srv, http.MethodPut, path, update, nil)
+	assert.Equal(t, http.StatusOK, resp.Code, "updates without If-Match are unconditional")
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/export"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+var inventoryExports = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "o2ims",
+		Subsystem: "inventory",
+		Name:      "exports_total",
+		Help:      "Total number of scheduled inventory exports by kind and result",
+	},
+	[]string{"kind", "result"},
+)
+
+// SetExportStore enables the scheduled inventory export to store. claims
+// elects the replica running each export.
+func (s *Server) SetExportStore(store export.ObjectStore, claims storage.RunClaims) {
+	s.exportStore = store
+	s.exportClaims = claims
+}
+
+// handleExportInventory streams the resource or resource pool inventory
+// visible to the client as CSV or Parquet. ?format= selects csv (default) or
+// parquet, ?kind= resources (default) or resourcePools, and ?columns= a
+// comma-separated list of attributes, with slash-separated paths selecting
+// nested attributes such as extensions/cpu.
+// GET /o2ims-infrastructureInventory/v1/export.
+func (s *Server) handleExportInventory(c *gin.Context) {
+	format := c.DefaultQuery("format", config.ExportFormatCSV)
+	kind := c.DefaultQuery("kind", config.ExportKindResources)
+	columns, err := exportColumns(format, kind, c.Query("columns"))
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	objects, err := s.exportObjects(c.Request.Context(), kind)
+	if err != nil {
+		s.requestLogger(c).Error("failed to list inventory for export", zap.String("kind", kind), zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve inventory")
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s"`, export.FileName(kind, format, time.Now())))
+	c.Status(http.StatusOK)
+	// The response is committed; a failure can only cut the file short.
+	if err := writeExport(c.Writer, format, columns, objects); err != nil {
+		s.requestLogger(c).Error("inventory export failed", zap.String("kind", kind), zap.Error(err))
+		_ = c.Error(err)
+	}
+}
+
+// exportColumns validates the format and kind of an export and returns the
+// selected columns, or the default columns of kind if none are selected.
+func exportColumns(format, kind, selected string) ([]string, error) {
+	if format != config.ExportFormatCSV && format != config.ExportFormatParquet {
+		return nil, fmt.Errorf("invalid format parameter %q (must be %s or %s)",
+			format, config.ExportFormatCSV, config.ExportFormatParquet)
+	}
+	defaults := export.DefaultColumns(kind)
+	if defaults == nil {
+		return nil, fmt.Errorf("invalid kind parameter %q (must be %s or %s)",
+			kind, config.ExportKindResources, config.ExportKindResourcePools)
+	}
+	if selected == "" {
+		return defaults, nil
+	}
+	columns, err := export.ParseColumns(selected)
+	if err != nil {
+		return nil, fmt.Errorf("invalid columns parameter: %w", err)
+	}
+	return columns, nil
+}
+
+// exportObjects lists every object of kind visible to ctx.
+func (s *Server) exportObjects(ctx context.Context, kind string) ([]interface{}, error) {
+	var objects []interface{}
+	switch kind {
+	case config.ExportKindResourcePools:
+		pools, err := s.listResourcePools(ctx, &adapter.Filter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, pool := range pools {
+			objects = append(objects, pool)
+		}
+	default:
+		resources, err := s.listResources(ctx, &adapter.Filter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources {
+			objects = append(objects, resource)
+		}
+	}
+	return objects, nil
+}
+
+// writeExport encodes objects as rows of columns in format to w.
+func writeExport(w io.Writer, format string, columns []string, objects []interface{}) error {
+	encoder, err := export.NewEncoder(w, format, columns)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		if err := encoder.Write(obj); err != nil {
+			return err
+		}
+	}
+	return encoder.Close()
+}
+
+// StartScheduledExport periodically writes the full inventory of the
+// configured kinds to the export object store, until ctx is canceled. Each
+// export is claimed by one replica, so replicas sharing the claim store
+// write every export once.
+func (s *Server) StartScheduledExport(ctx context.Context) {
+	cfg := s.config.Export
+	if !cfg.Enabled || s.exportStore == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for {
+			s.ExportInventory(ctx, time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ExportInventory runs the scheduled export of the interval containing now.
+// Objects are stored under <kind>/<kind>-<interval start>.<format>.
+func (s *Server) ExportInventory(ctx context.Context, now time.Time) {
+	cfg := s.config.Export
+	slot := now.UTC().Truncate(cfg.Interval)
+	if s.exportClaims != nil {
+		claimed, err := s.exportClaims.Claim(ctx, "export:"+slot.Format(time.RFC3339), cfg.Interval)
+		if err != nil {
+			s.logger.Error("failed to claim scheduled inventory export", zap.Error(err))
+			return
+		}
+		if !claimed {
+			s.logger.Debug("scheduled inventory export already claimed by another replica",
+				zap.Time("slot", slot))
+			return
+		}
+	}
+
+	for _, kind := range cfg.Kinds {
+		key, rows, err := s.exportKind(ctx, kind, slot)
+		if err != nil {
+			inventoryExports.WithLabelValues(kind, "failure").Inc()
+			s.logger.Error("scheduled inventory export failed", zap.String("kind", kind), zap.Error(err))
+			continue
+		}
+		inventoryExports.WithLabelValues(kind, "success").Inc()
+		s.logger.Info("scheduled inventory export completed",
+			zap.String("kind", kind), zap.String("key", key), zap.Int("rows", rows))
+	}
+}
+
+// exportKind writes the inventory of kind to the export object store and
+// returns the object key and number of rows.
+func (s *Server) exportKind(ctx context.Context, kind string, slot time.Time) (string, int, error) {
+	cfg := s.config.Export
+	selected := cfg.ResourceColumns
+	if kind == config.ExportKindResourcePools {
+		selected = cfg.ResourcePoolColumns
+	}
+	columns, err := exportColumns(cfg.Format, kind, strings.Join(selected, ","))
+	if err != nil {
+		return "", 0, err
+	}
+
+	objects, err := s.exportObjects(ctx, kind)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list inventory: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := writeExport(&buf, cfg.Format, columns, objects); err != nil {
+		return "", 0, err
+	}
+
+	key := kind + "/" + export.FileName(kind, cfg.Format, slot)
+	if err := s.exportStore.Put(ctx, key, export.ContentType(cfg.Format), buf.Bytes()); err != nil {
+		return "", 0, err
+	}
+	return key, len(objects), nil
+}
@@ -0,0 +1,126 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const exportPath = "/o2ims-infrastructureInventory/v1/export"
+
+type memoryObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (m *memoryObjectStore) Put(_ context.Context, key, contentType string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	m.types[key] = contentType
+	return nil
+}
+
+func objectsAsStrings(objects map[string][]byte) map[string]string {
+	out := make(map[string]string, len(objects))
+	for key, data := range objects {
+		out[key] = string(data)
+	}
+	return out
+}
+
+func newExportAdapter() *filteringAdapter {
+	return &filteringAdapter{
+		resources: []*adapter.Resource{
+			{ResourceID: "node-1", ResourcePoolID: "pool-a", Extensions: map[string]interface{}{"cpu": 16}},
+			{ResourceID: "node-2", ResourcePoolID: "pool-b", Description: "spare"},
+		},
+		pools: []*adapter.ResourcePool{{ResourcePoolID: "pool-a", Name: "Pool A", Location: "dc-1"}},
+	}
+}
+
+func newExportServer(t *testing.T, exportCfg config.ExportConfig) *server.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Export: exportCfg,
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), newExportAdapter(), &mockStore{})
+	return srv
+}
+
+func TestHandleExportInventory(t *testing.T) {
+	srv := newExportServer(t, config.ExportConfig{})
+
+	t.Run("csv with selected columns", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet,
+			exportPath+"?columns=resourceId,description,extensions/cpu", nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename="resources-\d{8}T\d{6}Z\.csv"$`,
+			resp.Header().Get("Content-Disposition"))
+		assert.Equal(t, "resourceId,description,extensions/cpu\nnode-1,,16\nnode-2,spare,\n", string(body))
+	})
+
+	t.Run("parquet resource pools with default columns", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet, exportPath+"?format=parquet&kind=resourcePools", nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		assert.Equal(t, "application/vnd.apache.parquet", resp.Header().Get("Content-Type"))
+
+		file, err := parquet.OpenFile(bytes.NewReader(body), int64(len(body)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), file.NumRows())
+		assert.Len(t, file.Schema().Fields(), 6)
+	})
+
+	for _, query := range []string{"?format=xml", "?kind=deployments", "?columns=resourceId,,name"} {
+		t.Run("invalid "+query, func(t *testing.T) {
+			resp, body := doResourceRequest(t, srv, http.MethodGet, exportPath+query, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, string(body), "InvalidParameter")
+		})
+	}
+}
+
+func TestExportInventory_Scheduled(t *testing.T) {
+	srv := newExportServer(t, config.ExportConfig{
+		Enabled:             true,
+		Interval:            time.Hour,
+		Format:              config.ExportFormatCSV,
+		Kinds:               []string{config.ExportKindResources, config.ExportKindResourcePools},
+		ResourceColumns:     []string{"resourceId", "resourcePoolId"},
+		ResourcePoolColumns: []string{"resourcePoolId", "location"},
+	})
+	store := &memoryObjectStore{objects: map[string][]byte{}, types: map[string]string{}}
+	srv.SetExportStore(store, storage.NewInMemoryRunClaims())
+
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	srv.ExportInventory(context.Background(), now)
+
+	assert.Equal(t, map[string]string{
+		"resources/resources-20260301T120000Z.csv":         "resourceId,resourcePoolId\nnode-1,pool-a\nnode-2,pool-b\n",
+		"resourcePools/resourcePools-20260301T120000Z.csv": "resourcePoolId,location\npool-a,dc-1\n",
+	}, objectsAsStrings(store.objects))
+	assert.Equal(t, "text/csv; charset=utf-8", store.types["resources/resources-20260301T120000Z.csv"])
+
+	// Another run in the same interval, e.g. by a second replica, is skipped.
+	store.objects = map[string][]byte{}
+	srv.ExportInventory(context.Background(), now.Add(10*time.Minute))
+	assert.Empty(t, store.objects)
+}
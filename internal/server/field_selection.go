@@ -0,0 +1,269 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// Attribute selector query parameters (O-RAN O2 IMS).
+const (
+	// AllFieldsParam requests every attribute of the returned objects.
+	AllFieldsParam = "all_fields"
+
+	// FieldsParam lists the only attributes to return.
+	FieldsParam = "fields"
+
+	// ExcludeFieldsParam lists attributes to leave out.
+	ExcludeFieldsParam = "exclude_fields"
+
+	// fieldPathSeparator separates the segments of a nested attribute path,
+	// e.g. "extensions/node.kubernetes.io/instance-type".
+	fieldPathSeparator = "/"
+)
+
+// fieldSelection is the attribute selection of a request.
+type fieldSelection struct {
+	fields  []string
+	exclude []string
+}
+
+// parseFieldSelection parses the attribute selector parameters of a request.
+// It returns nil when every attribute is returned.
+func parseFieldSelection(c *gin.Context) (*fieldSelection, error) {
+	fields, err := parseFieldPaths(c, FieldsParam)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := parseFieldPaths(c, ExcludeFieldsParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, all := c.GetQuery(AllFieldsParam); all {
+		if fields != nil || exclude != nil {
+			return nil, fmt.Errorf("%s cannot be combined with %s or %s", AllFieldsParam, FieldsParam, ExcludeFieldsParam)
+		}
+		return nil, nil
+	}
+	if fields == nil && exclude == nil {
+		return nil, nil
+	}
+	return &fieldSelection{fields: fields, exclude: exclude}, nil
+}
+
+// parseFieldPaths parses a comma-separated list of attribute paths. It returns
+// nil when the parameter is absent.
+func parseFieldPaths(c *gin.Context, param string) ([]string, error) {
+	value, ok := c.GetQuery(param)
+	if !ok {
+		return nil, nil
+	}
+
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if strings.HasPrefix(path, fieldPathSeparator) || strings.HasSuffix(path, fieldPathSeparator) ||
+			strings.Contains(path, fieldPathSeparator+fieldPathSeparator) {
+			return nil, fmt.Errorf("invalid %s parameter: malformed attribute path %q", param, path)
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("invalid %s parameter: no attribute given", param)
+	}
+	return paths, nil
+}
+
+// project applies the selection to an object.
+func (fs *fieldSelection) project(obj map[string]interface{}) map[string]interface{} {
+	if len(fs.fields) > 0 {
+		selected := make(map[string]interface{})
+		for _, path := range fs.fields {
+			selectFieldPath(obj, selected, path)
+		}
+		obj = selected
+	}
+	for _, path := range fs.exclude {
+		excludeFieldPath(obj, path)
+	}
+	return obj
+}
+
+// selectFieldPath copies the attribute at path from src into dst. Keys may
+// contain the separator themselves (e.g. Kubernetes label keys), so path is
+// matched against the keys of each level rather than split up front.
+func selectFieldPath(src, dst map[string]interface{}, path string) {
+	for key, value := range src {
+		if key == path {
+			mergeField(dst, key, value)
+			continue
+		}
+		rest, ok := strings.CutPrefix(path, key+fieldPathSeparator)
+		if !ok {
+			continue
+		}
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		selected := make(map[string]interface{})
+		selectFieldPath(nested, selected, rest)
+		if len(selected) > 0 {
+			mergeField(dst, key, selected)
+		}
+	}
+}
+
+// mergeField sets dst[key] to value, merging nested objects so that the
+// selections of overlapping paths add up.
+func mergeField(dst map[string]interface{}, key string, value interface{}) {
+	existing, ok := dst[key].(map[string]interface{})
+	incoming, isMap := value.(map[string]interface{})
+	if !ok || !isMap {
+		dst[key] = value
+		return
+	}
+	for k, v := range incoming {
+		mergeField(existing, k, v)
+	}
+}
+
+// excludeFieldPath removes the attribute at path from obj.
+func excludeFieldPath(obj map[string]interface{}, path string) {
+	for key, value := range obj {
+		if key == path {
+			delete(obj, key)
+			continue
+		}
+		rest, ok := strings.CutPrefix(path, key+fieldPathSeparator)
+		if !ok {
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			excludeFieldPath(nested, rest)
+		}
+	}
+}
+
+// projectResponse applies the selection to a JSON response body: to every
+// item under listKind of a list envelope, or to the object itself when
+// listKind is empty.
+func (fs *fieldSelection) projectResponse(body []byte, listKind string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if listKind == "" {
+		obj = fs.project(obj)
+	} else {
+		items, ok := obj[listKind].([]interface{})
+		if !ok {
+			return nil, errors.New("response has no " + listKind + " list")
+		}
+		for i, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				items[i] = fs.project(itemObj)
+			}
+		}
+	}
+
+	projected, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	return projected, nil
+}
+
+// withFieldSelection applies the ?fields=, ?exclude_fields= and ?all_fields=
+// attribute selectors to the successful responses of handler. listKind names
+// the collection of a list endpoint and is empty for single objects. Objects
+// are projected after the adapter returned them, so adapters need no support.
+func (s *Server) withFieldSelection(listKind string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		selection, err := parseFieldSelection(c)
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		if selection == nil {
+			handler(c)
+			return
+		}
+
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		handler(c)
+		c.Writer = writer.ResponseWriter
+
+		body := writer.body.Bytes()
+		if writer.status == http.StatusOK && len(body) > 0 {
+			projected, err := selection.projectResponse(body, listKind)
+			if err != nil {
+				s.requestLogger(c).Warn("failed to apply attribute selection", zap.Error(err))
+			} else {
+				body = projected
+			}
+		}
+
+		c.Writer.WriteHeader(writer.status)
+		if len(body) > 0 {
+			_, _ = c.Writer.Write(body)
+		}
+	}
+}
+
+// bufferedResponseWriter holds back a response so it can be rewritten before
+// it is sent. Headers go straight to the underlying writer.
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+// WriteHeader records the status code.
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+// WriteHeaderNow is a no-op; the status is sent with the rewritten body.
+func (w *bufferedResponseWriter) WriteHeaderNow() {}
+
+// Write buffers the body.
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// WriteString buffers the body.
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// Status returns the recorded status code.
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+// Size returns the number of buffered body bytes.
+func (w *bufferedResponseWriter) Size() int {
+	return w.body.Len()
+}
+
+// Written reports whether a body was buffered.
+func (w *bufferedResponseWriter) Written() bool {
+	return w.body.Len() > 0
+}
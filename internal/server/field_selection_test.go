@@ -0,0 +1,133 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+// gettableAdapter serves GetResource from the listed resources.
+type gettableAdapter struct {
+	filteringAdapter
+}
+
+func (a *gettableAdapter) GetResource(_ context.Context, id string) (*adapter.Resource, error) {
+	for _, r := range a.resources {
+		if r.ResourceID == id {
+			return r, nil
+		}
+	}
+	return nil, adapter.ErrResourceNotFound
+}
+
+func TestFieldSelection(t *testing.T) {
+	adp := &gettableAdapter{filteringAdapter{resources: []*adapter.Resource{{
+		ResourceID:     "node-1",
+		ResourceTypeID: "compute-node",
+		ResourcePoolID: "pool-a",
+		Description:    "worker",
+		Extensions: map[string]interface{}{
+			"labels": map[string]interface{}{
+				"node.kubernetes.io/instance-type": "m5.large",
+				"kubernetes.io/hostname":           "node-1",
+			},
+			"cpu": "4",
+		},
+	}}}}
+	srv := setupFilterTestServer(t, adp, &mockStore{})
+
+	get := func(t *testing.T, path, query string) map[string]interface{} {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, path+"?"+query, nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		var obj map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &obj))
+		return obj
+	}
+	firstResource := func(t *testing.T, query string) map[string]interface{} {
+		t.Helper()
+		list := get(t, "/o2ims-infrastructureInventory/v1/resources", query)
+		items, ok := list["resources"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, items, 1)
+		assert.InDelta(t, 1, list["total"], 0)
+		return items[0].(map[string]interface{})
+	}
+
+	t.Run("fields with nested paths", func(t *testing.T) {
+		query := fieldsQuery("resourceId,extensions/labels/node.kubernetes.io/instance-type")
+		want := map[string]interface{}{
+			"resourceId": "node-1",
+			"extensions": map[string]interface{}{
+				"labels": map[string]interface{}{"node.kubernetes.io/instance-type": "m5.large"},
+			},
+		}
+		assert.Equal(t, want, firstResource(t, query))
+		assert.Equal(t, want, get(t, "/o2ims-infrastructureInventory/v1/resources/node-1", query))
+	})
+
+	t.Run("overlapping paths add up", func(t *testing.T) {
+		obj := firstResource(t, fieldsQuery("extensions/labels/kubernetes.io/hostname,extensions"))
+		assert.Equal(t, map[string]interface{}{"extensions": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"node.kubernetes.io/instance-type": "m5.large",
+				"kubernetes.io/hostname":           "node-1",
+			},
+			"cpu": "4",
+		}}, obj)
+	})
+
+	t.Run("exclude_fields", func(t *testing.T) {
+		obj := firstResource(t, "exclude_fields="+url.QueryEscape("description,extensions/labels"))
+		assert.NotContains(t, obj, "description")
+		assert.Equal(t, map[string]interface{}{"cpu": "4"}, obj["extensions"])
+		assert.Equal(t, "node-1", obj["resourceId"])
+	})
+
+	t.Run("all_fields", func(t *testing.T) {
+		obj := firstResource(t, "all_fields")
+		assert.Equal(t, "worker", obj["description"])
+		assert.Contains(t, obj, "extensions")
+	})
+
+	t.Run("unknown attributes are ignored", func(t *testing.T) {
+		assert.Empty(t, firstResource(t, fieldsQuery("nope,extensions/nope")))
+	})
+
+	t.Run("errors are not projected", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet,
+			"/o2ims-infrastructureInventory/v1/resources/missing?"+fieldsQuery("resourceId"), nil)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Contains(t, string(body), "NotFound")
+	})
+
+	for _, query := range []string{
+		"all_fields&fields=resourceId",
+		"all_fields&exclude_fields=description",
+		"fields=",
+		"fields=" + url.QueryEscape("extensions//cpu"),
+		"exclude_fields=" + url.QueryEscape("/resourceId"),
+	} {
+		for _, path := range []string{
+			"/o2ims-infrastructureInventory/v1/resources",
+			"/o2ims-infrastructureInventory/v1/resources/node-1",
+			"/o2ims-infrastructureInventory/v1/resourcePools",
+			"/o2ims-infrastructureInventory/v1/resourcePools/pool-a/resources",
+		} {
+			resp, _ := doResourceRequest(t, srv, http.MethodGet, path+"?"+query, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.Code, path+"?"+query)
+		}
+	}
+}
+
+// fieldsQuery returns a ?fields= query selecting fields.
+func fieldsQuery(fields string) string {
+	return "fields=" + url.QueryEscape(fields)
+}
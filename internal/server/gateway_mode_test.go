@@ -0,0 +1,65 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	dmsregistry "github.com/piwi3910/netweave/internal/dms/registry"
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestDMSOnlyMode_RegistersOnlyDMSRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode, Mode: config.GatewayModeDMSOnly},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), nil, &mockStore{})
+
+	reg := dmsregistry.NewRegistry(zap.NewNop(), nil)
+	require.NoError(t, reg.Register(context.Background(), "test-adapter", "mock", newMockDMSAdapter(), nil, true))
+	srv.SetupDMS(reg)
+
+	for _, path := range []string{
+		"/o2ims",
+		"/o2ims-infrastructureInventory/v1/resourcePools",
+		"/o2ims-infrastructureInventory/v1/subscriptions",
+		"/tmf-api/resourceInventoryManagement/v4/resource",
+	} {
+		resp, _ := doResourceRequest(t, srv, http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusNotFound, resp.Code, path)
+	}
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, "/o2dms/v1/nfDeployments", nil)
+	assert.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	resp, body = doResourceRequest(t, srv, http.MethodGet, "/", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var info o2imsmodels.GatewayInfo
+	require.NoError(t, json.Unmarshal(body, &info))
+	assert.Equal(t, config.GatewayModeDMSOnly, info.Mode)
+	assert.Empty(t, info.Endpoints.O2IMSBase)
+	assert.Equal(t, "/o2dms/v1", info.Endpoints.O2DMSBase)
+}
+
+func TestDefaultMode_RegistersIMSRoutes(t *testing.T) {
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+
+	resp, _ := doResourceRequest(t, srv, http.MethodGet, "/o2ims", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, "/", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var info o2imsmodels.GatewayInfo
+	require.NoError(t, json.Unmarshal(body, &info))
+	assert.Equal(t, config.GatewayModeIMSAndDMS, info.Mode)
+	assert.Equal(t, "/o2ims-infrastructureInventory/v1", info.Endpoints.O2IMSBase)
+}
@@ -33,7 +33,8 @@ func (s *Server) setupGraphQLRoutes() {
 
 	// GraphQL query endpoint (POST /graphql)
 	// Handles all GraphQL queries, mutations, and subscriptions
-	s.router.POST("/graphql", gqlserver.GinHandler(gqlSrv))
+	// Subscriptions end, and their clients reconnect, once the server drains.
+	s.router.POST("/graphql", gqlserver.DrainCloseReason(), EndStreamOnDrain(), gqlserver.GinHandler(gqlSrv))
 
 	// GraphQL playground UI (GET /graphql)
 	// Only enabled in development mode for security
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/hooks"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// HooksFromConfig builds a hook runner from the configured lifecycle hooks.
+// Plugin hooks must have been registered with hooks.RegisterPlugin beforehand.
+func HooksFromConfig(cfgs []config.HookConfig, logger *zap.Logger) (*hooks.Runner, error) {
+	runner := hooks.NewRunner(logger)
+	for _, cfg := range cfgs {
+		var hook hooks.Hook
+		switch cfg.Type {
+		case config.HookTypeWebhook:
+			hook = hooks.NewWebhookHook(cfg.URL, nil)
+		case config.HookTypePlugin:
+			plugin, ok := hooks.LookupPlugin(cfg.Plugin)
+			if !ok {
+				return nil, fmt.Errorf("hook %q: plugin %q is not registered", cfg.Name, cfg.Plugin)
+			}
+			hook = plugin
+		default:
+			return nil, fmt.Errorf("hook %q: unsupported type %q", cfg.Name, cfg.Type)
+		}
+
+		reg := hooks.Registration{
+			Name:          cfg.Name,
+			Hook:          hook,
+			ObjectTypes:   cfg.ObjectTypes,
+			Timeout:       cfg.Timeout,
+			FailurePolicy: hooks.FailurePolicy(cfg.FailurePolicy),
+		}
+		for _, phase := range cfg.Phases {
+			reg.Phases = append(reg.Phases, hooks.Phase(phase))
+		}
+		for _, op := range cfg.Operations {
+			reg.Operations = append(reg.Operations, hooks.Operation(op))
+		}
+		if err := runner.Register(reg); err != nil {
+			return nil, err
+		}
+	}
+	return runner, nil
+}
+
+// SetHooks sets the lifecycle hooks invoked around create and delete operations.
+func (s *Server) SetHooks(runner *hooks.Runner) {
+	s.hooks = runner
+}
+
+// runPreHooks invokes pre-operation hooks. When an aborting hook fails it
+// writes a 424 response and returns false; the caller must stop processing.
+func (s *Server) runPreHooks(
+	c *gin.Context, op hooks.Operation, objectType, objectID string, object interface{},
+) bool {
+	err := s.hooks.Run(c.Request.Context(), &hooks.Event{
+		Phase:      hooks.PhasePre,
+		Operation:  op,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Object:     object,
+	})
+	if err == nil {
+		return true
+	}
+
+	problem.Respond(c, http.StatusFailedDependency, "FailedDependency", err.Error())
+	return false
+}
+
+// runPostHooks invokes post-operation hooks. Failures are logged by the runner
+// and never affect the completed operation.
+func (s *Server) runPostHooks(
+	c *gin.Context, op hooks.Operation, objectType, objectID string, object interface{},
+) {
+	_ = s.hooks.Run(c.Request.Context(), &hooks.Event{
+		Phase:      hooks.PhasePost,
+		Operation:  op,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Object:     object,
+	})
+}
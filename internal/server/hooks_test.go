@@ -0,0 +1,99 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/hooks"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestLifecycleHooks_Resources(t *testing.T) {
+	const resourceID = "660e8400-e29b-41d4-a716-446655440000"
+
+	var (
+		mu     sync.Mutex
+		events []hooks.Event
+	)
+	recorder := hooks.HookFunc(func(_ context.Context, event *hooks.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, *event)
+		return nil
+	})
+
+	runner := hooks.NewRunner(zap.NewNop())
+	require.NoError(t, runner.Register(hooks.Registration{Name: "recorder", Hook: recorder}))
+	require.NoError(t, runner.Register(hooks.Registration{
+		Name: "deny-delete",
+		Hook: hooks.HookFunc(func(context.Context, *hooks.Event) error {
+			return errors.New("change window closed")
+		}),
+		Phases:        []hooks.Phase{hooks.PhasePre},
+		Operations:    []hooks.Operation{hooks.OperationDelete},
+		FailurePolicy: hooks.FailurePolicyAbort,
+	}))
+
+	adp := newMockResourceAdapter()
+	srv := setupResourceTestServer(t, adp)
+	srv.SetHooks(runner)
+
+	resp, _ := doResourceRequest(t, srv, http.MethodPost, "/o2ims-infrastructureInventory/v1/resources", adapter.Resource{
+		ResourceID:     resourceID,
+		ResourceTypeID: "compute-node",
+		ResourcePoolID: "pool-1",
+	})
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, hooks.PhasePre, events[0].Phase)
+	assert.Equal(t, hooks.OperationCreate, events[0].Operation)
+	assert.Equal(t, hooks.ObjectTypeResource, events[0].ObjectType)
+	assert.Equal(t, resourceID, events[0].ObjectID)
+	assert.Equal(t, hooks.PhasePost, events[1].Phase)
+	created, ok := events[1].Object.(*adapter.Resource)
+	require.True(t, ok)
+	assert.Equal(t, "pool-1", created.ResourcePoolID)
+
+	// An aborting pre-delete hook rejects the request before the adapter is called.
+	events = nil
+	resp, body := doResourceRequest(t, srv, http.MethodDelete,
+		"/o2ims-infrastructureInventory/v1/resources/"+resourceID, nil)
+	require.Equal(t, http.StatusFailedDependency, resp.Code)
+	assert.Contains(t, string(body), "change window closed")
+	require.Len(t, events, 1)
+	assert.Equal(t, hooks.PhasePre, events[0].Phase)
+
+	adp.mu.Lock()
+	_, exists := adp.resources[resourceID]
+	adp.mu.Unlock()
+	assert.True(t, exists)
+}
+
+func TestHooksFromConfig(t *testing.T) {
+	hooks.RegisterPlugin("server-test-plugin", hooks.HookFunc(func(context.Context, *hooks.Event) error {
+		return nil
+	}))
+
+	runner, err := server.HooksFromConfig([]config.HookConfig{
+		{Name: "cmdb", Type: config.HookTypeWebhook, URL: "https://cmdb.example.com/hooks"},
+		{Name: "local", Type: config.HookTypePlugin, Plugin: "server-test-plugin", Phases: []string{"pre"}},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 2, runner.Len())
+
+	_, err = server.HooksFromConfig([]config.HookConfig{
+		{Name: "missing", Type: config.HookTypePlugin, Plugin: "not-registered"},
+	}, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
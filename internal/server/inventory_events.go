@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/eventbus"
+)
+
+// inventoryChangesTopic is the event bus topic of inventory changes.
+const inventoryChangesTopic = "inventory.changes"
+
+// Consumers of inventory changes.
+const (
+	consumerCacheInvalidation = "cache-invalidation"
+	consumerLongPoll          = "long-poll"
+)
+
+// InventoryChange describes a change to resource pools or resources.
+type InventoryChange struct {
+	// ObjectType is the changed collection ("resourcePools" or "resources").
+	ObjectType string
+
+	// ObjectID is the changed object. It is empty when the change affects
+	// several objects, e.g. a batch operation.
+	ObjectID string
+
+	// Created reports that the change only added objects, so no existing
+	// object was modified.
+	Created bool
+}
+
+// setupEventBus creates the event bus and subscribes the built-in consumers
+// of inventory changes: read cache invalidation and long-poll wakeups.
+func (s *Server) setupEventBus() {
+	if s.eventBus != nil {
+		return
+	}
+	s.eventBus = eventbus.New(s.logger)
+	s.inventoryChanges = eventbus.NewTopic[InventoryChange](s.eventBus, inventoryChangesTopic)
+
+	// Subscribing to a new bus cannot fail.
+	_ = s.inventoryChanges.Subscribe(consumerCacheInvalidation, 0, s.invalidateCacheOnChange)
+	_ = s.inventoryChanges.Subscribe(consumerLongPoll, 0, s.wakeLongPollsOnChange)
+}
+
+// PublishInventoryChange publishes a change detected outside the API, such as
+// by an adapter or backend watcher, to the inventory change consumers.
+func (s *Server) PublishInventoryChange(change InventoryChange) {
+	if err := s.inventoryChanges.Publish(change); err != nil {
+		s.logger.Debug("inventory change not published", zap.Error(err))
+	}
+}
+
+// InventoryChangeMiddleware publishes an inventory change after every
+// successful mutation of resource pools or resources. It waits until the
+// change has been handled so a client reading back its own write never sees
+// a stale cached object.
+func (s *Server) InventoryChangeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+
+		for _, change := range inventoryChanges(c) {
+			if err := s.inventoryChanges.PublishAndWait(c.Request.Context(), change); err != nil {
+				s.logger.Warn("failed to publish inventory change",
+					zap.String("type", change.ObjectType),
+					zap.String("id", change.ObjectID),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// inventoryTypesBySegment maps route segments to the inventory collections
+// they mutate. TMF639 "resource" IDs may refer to either a resource pool or a
+// resource.
+var inventoryTypesBySegment = map[string][]string{
+	cacheTypeResourcePools: {cacheTypeResourcePools},
+	cacheTypeResources:     {cacheTypeResources},
+	"resource":             {cacheTypeResourcePools, cacheTypeResources},
+}
+
+// inventoryChanges maps a mutating route to the inventory changes it makes.
+// Routes on a collection create objects; routes on an object (including its
+// sub-resources) change that object; batch routes change any object.
+func inventoryChanges(c *gin.Context) []InventoryChange {
+	segments := strings.Split(strings.Trim(c.FullPath(), "/"), "/")
+	// Skip the API base path, e.g. "o2ims-infrastructureInventory/v1".
+	for i, segment := range segments {
+		if ExtractVersionFromPath("/"+segment) != "" {
+			segments = segments[i+1:]
+			break
+		}
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+
+	var (
+		objectTypes []string
+		change      InventoryChange
+	)
+	switch {
+	case segments[0] == "batch":
+		if len(segments) < 2 {
+			return nil
+		}
+		objectTypes = inventoryTypesBySegment[segments[1]]
+	case len(segments) == 1:
+		objectTypes = inventoryTypesBySegment[segments[0]]
+		change.Created = true
+	case strings.HasPrefix(segments[1], ":"):
+		objectTypes = inventoryTypesBySegment[segments[0]]
+		change.ObjectID = c.Param(strings.TrimPrefix(segments[1], ":"))
+		if change.ObjectID == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	changes := make([]InventoryChange, 0, len(objectTypes))
+	for _, objectType := range objectTypes {
+		change.ObjectType = objectType
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// invalidateCacheOnChange drops cached copies of changed objects. Objects
+// that were only created cannot be cached yet.
+func (s *Server) invalidateCacheOnChange(ctx context.Context, change InventoryChange) {
+	if s.readCache == nil || change.Created {
+		return
+	}
+	if change.ObjectID == "" {
+		s.invalidateCache(ctx, change.ObjectType)
+		return
+	}
+	s.invalidateCache(ctx, change.ObjectType, change.ObjectID)
+}
+
+// wakeLongPollsOnChange wakes long-poll list requests waiting on the changed
+// collection.
+func (s *Server) wakeLongPollsOnChange(_ context.Context, change InventoryChange) {
+	s.listChanges.notify(change.ObjectType)
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+const (
+	// IncludeExtensionsParam selects how much of the extensions of listed
+	// objects is returned.
+	IncludeExtensionsParam = "includeExtensions"
+
+	// IncludeExtensionsFull returns the full extensions of listed objects.
+	IncludeExtensionsFull = "full"
+
+	// extensionsField is the attribute holding the extensions of an object.
+	extensionsField = "extensions"
+
+	// ExtensionsTruncatedField marks listed objects whose extensions were cut down.
+	ExtensionsTruncatedField = "extensionsTruncated"
+)
+
+// extensionsLimit cuts down the extensions of listed objects to a size budget.
+type extensionsLimit struct {
+	maxBytes int
+	omit     bool
+}
+
+// limitObject cuts down the extensions of obj if their JSON encoding exceeds
+// the budget, and reports whether it did. In truncate mode the keys that fit
+// are kept in key order; the others are dropped.
+func (l *extensionsLimit) limitObject(obj map[string]interface{}) (bool, error) {
+	extensions, ok := obj[extensionsField].(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	encoded, err := json.Marshal(extensions)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode extensions: %w", err)
+	}
+	if len(encoded) <= l.maxBytes {
+		return false, nil
+	}
+
+	obj[ExtensionsTruncatedField] = true
+	if l.omit {
+		delete(obj, extensionsField)
+		return true, nil
+	}
+
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kept := make(map[string]interface{})
+	size := len("{}")
+	for _, key := range keys {
+		value, err := json.Marshal(extensions[key])
+		if err != nil {
+			return false, fmt.Errorf("failed to encode extension %q: %w", key, err)
+		}
+		// "key":value plus the separating comma.
+		entrySize := len(key) + len(value) + len(`"":,`)
+		if size+entrySize > l.maxBytes {
+			continue
+		}
+		kept[key] = extensions[key]
+		size += entrySize
+	}
+	obj[extensionsField] = kept
+	return true, nil
+}
+
+// limitResponse cuts down the extensions of every item under listKind of a
+// JSON list envelope. It returns the rewritten body and the number of items
+// whose extensions were cut down.
+func (l *extensionsLimit) limitResponse(body []byte, listKind string) ([]byte, int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	items, ok := obj[listKind].([]interface{})
+	if !ok {
+		return nil, 0, errors.New("response has no " + listKind + " list")
+	}
+	truncated := 0
+	for _, item := range items {
+		itemObj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cut, err := l.limitObject(itemObj)
+		if err != nil {
+			return nil, 0, err
+		}
+		if cut {
+			truncated++
+		}
+	}
+	if truncated == 0 {
+		return body, 0, nil
+	}
+
+	limited, err := json.Marshal(obj)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to encode response: %w", err)
+	}
+	return limited, truncated, nil
+}
+
+// withExtensionsLimit cuts down oversized extensions of the objects listed
+// under listKind by handler to server.list_extensions_max_bytes, unless the
+// client asks for ?includeExtensions=full. Objects whose extensions were cut
+// down carry "extensionsTruncated": true; their full extensions are returned
+// by the single-object GET.
+func (s *Server) withExtensionsLimit(listKind string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		include, requested := c.GetQuery(IncludeExtensionsParam)
+		if requested && include != IncludeExtensionsFull {
+			message := fmt.Sprintf("invalid %s parameter %q (must be %s)",
+				IncludeExtensionsParam, include, IncludeExtensionsFull)
+			problem.Respond(c, http.StatusBadRequest, "InvalidParameter", message)
+			return
+		}
+		if requested || s.config.Server.ListExtensionsMaxBytes <= 0 {
+			handler(c)
+			return
+		}
+		limit := &extensionsLimit{
+			maxBytes: s.config.Server.ListExtensionsMaxBytes,
+			omit:     s.config.Server.ListExtensionsMode == config.ListExtensionsOmit,
+		}
+
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		handler(c)
+		c.Writer = writer.ResponseWriter
+
+		body := writer.body.Bytes()
+		if writer.status == http.StatusOK && len(body) > 0 {
+			limited, truncated, err := limit.limitResponse(body, listKind)
+			if err != nil {
+				s.requestLogger(c).Warn("failed to limit extensions size", zap.Error(err))
+			} else if truncated > 0 {
+				s.requestLogger(c).Debug("truncated oversized extensions",
+					zap.String("kind", listKind), zap.Int("objects", truncated))
+				body = limited
+			}
+		}
+
+		c.Writer.WriteHeader(writer.status)
+		if len(body) > 0 {
+			_, _ = c.Writer.Write(body)
+		}
+	}
+}
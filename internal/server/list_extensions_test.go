@@ -0,0 +1,88 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestListExtensionsLimit(t *testing.T) {
+	const resourcesPath = "/o2ims-infrastructureInventory/v1/resources"
+	gin.SetMode(gin.TestMode)
+	large := map[string]interface{}{
+		"cpu":         "4",
+		"annotations": strings.Repeat("x", 200),
+		"zone":        "zone-a",
+	}
+	adp := &gettableAdapter{filteringAdapter{resources: []*adapter.Resource{
+		{ResourceID: "node-1", ResourcePoolID: "pool-a", Extensions: map[string]interface{}{"cpu": "2"}},
+		{ResourceID: "node-2", ResourcePoolID: "pool-a", Extensions: large},
+	}}}
+
+	newServer := func(mode string) *server.Server {
+		cfg := &config.Config{Server: config.ServerConfig{
+			Port: 8080, GinMode: gin.TestMode, ListExtensionsMaxBytes: 64, ListExtensionsMode: mode,
+		}}
+		srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), adp, &mockStore{})
+		return srv
+	}
+	listResources := func(t *testing.T, srv *server.Server, query string) []map[string]interface{} {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, resourcesPath+query, nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		var list struct {
+			Resources []map[string]interface{} `json:"resources"`
+		}
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Len(t, list.Resources, 2)
+		return list.Resources
+	}
+
+	t.Run("truncate", func(t *testing.T) {
+		srv := newServer(config.ListExtensionsTruncate)
+		items := listResources(t, srv, "")
+		assert.Equal(t, map[string]interface{}{"cpu": "2"}, items[0]["extensions"])
+		assert.NotContains(t, items[0], "extensionsTruncated")
+		assert.Equal(t, map[string]interface{}{"cpu": "4", "zone": "zone-a"}, items[1]["extensions"])
+		assert.Equal(t, true, items[1]["extensionsTruncated"])
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, resourcesPath+"/node-2", nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var single map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &single))
+		assert.Equal(t, large, single["extensions"], "single-object GETs return full extensions")
+	})
+
+	t.Run("omit", func(t *testing.T) {
+		items := listResources(t, newServer(config.ListExtensionsOmit), "")
+		assert.NotContains(t, items[1], "extensions")
+		assert.Equal(t, true, items[1]["extensionsTruncated"])
+	})
+
+	t.Run("full on request", func(t *testing.T) {
+		srv := newServer(config.ListExtensionsTruncate)
+		items := listResources(t, srv, "?includeExtensions=full")
+		assert.Equal(t, large, items[1]["extensions"])
+		assert.NotContains(t, items[1], "extensionsTruncated")
+
+		resp, _ := doResourceRequest(t, srv, http.MethodGet,
+			resourcesPath+"?includeExtensions=all", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("combined with attribute selection", func(t *testing.T) {
+		items := listResources(t, newServer(config.ListExtensionsTruncate), "?fields=resourceId,extensions/cpu")
+		assert.Equal(t, map[string]interface{}{"cpu": "4"}, items[1]["extensions"])
+		assert.NotContains(t, items[1], "extensionsTruncated", "only the selected attributes count")
+	})
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/models"
+)
+
+// adapterFilterFields are the condition fields every adapter evaluates (see
+// adapter.MatchesFilter). Conditions on other attributes are evaluated by the
+// gateway on the listed objects.
+var adapterFilterFields = map[string]bool{
+	"resourcePoolId": true,
+	"resourceTypeId": true,
+	"location":       true,
+}
+
+// parseFilterExpression parses the O-RAN ?filter= expression of a request.
+// It returns nil when the parameter is absent.
+func parseFilterExpression(c *gin.Context) ([]models.FilterCondition, error) {
+	expr := c.Query(models.FilterQueryParam)
+	if expr == "" {
+		return nil, nil
+	}
+	conditions, err := models.ParseFilterExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter parameter: %w", err)
+	}
+	return conditions, nil
+}
+
+// isAdapterFilterField reports whether adapters evaluate conditions on field.
+func isAdapterFilterField(field string) bool {
+	return adapterFilterFields[field] || strings.HasPrefix(field, "labels.")
+}
+
+// splitListFilter pushes the advanced conditions adapters can evaluate down to
+// the adapter and returns the remaining conditions for the gateway. Pagination
+// moves to the gateway as well when conditions remain, so pages are cut after
+// filtering. filter itself is not modified.
+func splitListFilter(filter *adapter.Filter) (*adapter.Filter, []models.FilterCondition) {
+	if filter == nil || filter.AdvancedFilter == nil {
+		return filter, nil
+	}
+
+	var pushed, residual []models.FilterCondition
+	for _, condition := range filter.AdvancedFilter.Conditions {
+		if isAdapterFilterField(condition.Field) {
+			pushed = append(pushed, condition)
+		} else {
+			residual = append(residual, condition)
+		}
+	}
+	if len(residual) == 0 {
+		return filter, nil
+	}
+
+	adv := *filter.AdvancedFilter
+	adv.Conditions = pushed
+	split := *filter
+	split.AdvancedFilter = &adv
+	split.Limit, split.Offset = 0, 0
+	return &split, residual
+}
+
+// filterListItems keeps the items whose JSON representation satisfies every
+// condition, then applies the pagination of filter.
+func filterListItems[T any](items []T, conditions []models.FilterCondition, filter *adapter.Filter) ([]T, error) {
+	if len(conditions) == 0 {
+		return items, nil
+	}
+
+	matched := make([]T, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode item for filtering: %w", err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to decode item for filtering: %w", err)
+		}
+		if models.MatchesConditions(conditions, fields) {
+			matched = append(matched, item)
+		}
+	}
+
+	if filter == nil {
+		return matched, nil
+	}
+	return adapter.ApplyPagination(matched, filter.Limit, filter.Offset), nil
+}
+
+// listResources lists the resources visible to the request via the adapter,
+// evaluating the filter conditions adapters do not support on the returned resources.
+func (s *Server) listResources(ctx context.Context, filter *adapter.Filter) ([]*adapter.Resource, error) {
+	pushed, residual := splitListFilter(filter)
+	resources, err := scopedList(ctx, s, usageResources, pushed, s.adapter.ListResources, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return filterListItems(resources, residual, filter)
+}
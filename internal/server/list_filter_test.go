@@ -0,0 +1,162 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// filteringAdapter evaluates filters the way real adapters do and records
+// the filter it received.
+type filteringAdapter struct {
+	mockAdapter
+	resources []*adapter.Resource
+	pools     []*adapter.ResourcePool
+	received  *adapter.Filter
+}
+
+func (a *filteringAdapter) ListResources(_ context.Context, filter *adapter.Filter) ([]*adapter.Resource, error) {
+	a.received = filter
+	var out []*adapter.Resource
+	for _, r := range a.resources {
+		if adapter.MatchesFilter(filter, r.ResourcePoolID, r.ResourceTypeID, "", nil) {
+			out = append(out, r)
+		}
+	}
+	return adapter.ApplyPagination(out, filter.Limit, filter.Offset), nil
+}
+
+func (a *filteringAdapter) ListResourcePools(
+	_ context.Context, filter *adapter.Filter,
+) ([]*adapter.ResourcePool, error) {
+	a.received = filter
+	var out []*adapter.ResourcePool
+	for _, p := range a.pools {
+		if adapter.MatchesFilter(filter, p.ResourcePoolID, "", p.Location, nil) {
+			out = append(out, p)
+		}
+	}
+	return adapter.ApplyPagination(out, filter.Limit, filter.Offset), nil
+}
+
+func setupFilterTestServer(t *testing.T, adp adapter.Adapter, store storage.Store) *server.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode}}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), adp, store)
+	return srv
+}
+
+// subscriptionListStore serves a fixed subscription list.
+type subscriptionListStore struct {
+	mockStore
+	subs []*storage.Subscription
+}
+
+func (s *subscriptionListStore) List(_ context.Context) ([]*storage.Subscription, error) {
+	return s.subs, nil
+}
+
+func listIDs(t *testing.T, srv *server.Server, path, filter, kind, idField string) (int, []string) {
+	t.Helper()
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path+"?filter="+url.QueryEscape(filter), nil)
+	if resp.Code != http.StatusOK {
+		return resp.Code, nil
+	}
+	var envelope map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &envelope))
+	var items []map[string]interface{}
+	require.NoError(t, json.Unmarshal(envelope[kind], &items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item[idField].(string))
+	}
+	return resp.Code, ids
+}
+
+func TestListResources_FilterExpression(t *testing.T) {
+	adp := &filteringAdapter{resources: []*adapter.Resource{
+		{ResourceID: "r1", ResourceTypeID: "node", ResourcePoolID: "pool-a", Description: "edge server",
+			Extensions: map[string]interface{}{"cpus": 8}},
+		{ResourceID: "r2", ResourceTypeID: "node", ResourcePoolID: "pool-b", Description: "core server",
+			Extensions: map[string]interface{}{"cpus": 2}},
+		{ResourceID: "r3", ResourceTypeID: "switch", ResourcePoolID: "pool-a", Description: "edge switch"},
+	}}
+	srv := setupFilterTestServer(t, adp, &mockStore{})
+	const path = "/o2ims-infrastructureInventory/v1/resources"
+
+	code, ids := listIDs(t, srv, path, "(eq,resourceTypeId,node)", "resources", "resourceId")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"r1", "r2"}, ids)
+	require.NotNil(t, adp.received.AdvancedFilter, "supported conditions are pushed down to the adapter")
+	assert.Len(t, adp.received.AdvancedFilter.Conditions, 1)
+
+	_, ids = listIDs(t, srv, path, "(neq,resourceTypeId,node);(in,resourcePoolId,pool-a,pool-c)", "resources", "resourceId")
+	assert.Equal(t, []string{"r3"}, ids)
+
+	// Attributes adapters do not evaluate are filtered by the gateway.
+	_, ids = listIDs(t, srv, path, "(contains,description,edge);(eq,resourceTypeId,node)", "resources", "resourceId")
+	assert.Equal(t, []string{"r1"}, ids)
+	assert.Len(t, adp.received.AdvancedFilter.Conditions, 1)
+	assert.Zero(t, adp.received.Limit, "pagination moves to the gateway")
+
+	_, ids = listIDs(t, srv, path, "(gt,extensions/cpus,4)", "resources", "resourceId")
+	assert.Equal(t, []string{"r1"}, ids)
+
+	code, _ = listIDs(t, srv, path, "(like,resourceTypeId,node)", "resources", "resourceId")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = listIDs(t, srv, path, "(eq,resourceTypeId", "resources", "resourceId")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListResourcePools_FilterExpression(t *testing.T) {
+	adp := &filteringAdapter{pools: []*adapter.ResourcePool{
+		{ResourcePoolID: "pool-a", Name: "edge-1", Location: "eu-west"},
+		{ResourcePoolID: "pool-b", Name: "core-1", Location: "eu-north"},
+	}}
+	srv := setupFilterTestServer(t, adp, &mockStore{})
+
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	_, ids := listIDs(t, srv, path, "(eq,location,eu-north)", "resourcePools", "resourcePoolId")
+	assert.Equal(t, []string{"pool-b"}, ids)
+
+	_, ids = listIDs(t, srv, path, "(contains,name,edge);(neq,location,eu-north)", "resourcePools", "resourcePoolId")
+	assert.Equal(t, []string{"pool-a"}, ids)
+}
+
+func TestListSubscriptions_FilterExpression(t *testing.T) {
+	store := &subscriptionListStore{subs: []*storage.Subscription{
+		{ID: "sub-all", Callback: "https://smo.example.com/notify"},
+		{
+			ID:       "sub-pool",
+			Callback: "https://smo.example.com/pools",
+			Filter:   storage.SubscriptionFilter{ResourcePoolID: "pool-a"},
+		},
+	}}
+	srv := setupFilterTestServer(t, &mockAdapter{}, store)
+	const path = "/o2ims-infrastructureInventory/v1/subscriptions"
+
+	code, ids := listIDs(t, srv, path, "(eq,filter/resourcePoolId,pool-a)", "subscriptions", "subscriptionId")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"sub-pool"}, ids)
+
+	_, ids = listIDs(t, srv, path, "(eq,callback,https://smo.example.com/notify)", "subscriptions", "subscriptionId")
+	assert.Equal(t, []string{"sub-all"}, ids)
+
+	code, _ = listIDs(t, srv, path, "(eq,callback)", "subscriptions", "subscriptionId")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/models"
+)
+
+// List pagination parameters.
+const (
+	// NextPageMarkerParam is the O-RAN O2 query parameter and response header
+	// carrying the opaque marker of the next page of a list.
+	NextPageMarkerParam = "nextpage_opaque_marker"
+
+	// DefaultListPageSize is the page size when a list request has no limit.
+	DefaultListPageSize = 100
+
+	// MaxListPageSize caps the limit of a list request.
+	MaxListPageSize = 1000
+
+	// markerKindKey is the marker field binding it to one collection.
+	markerKindKey = "kind"
+
+	// markerOffsetKey is the marker field carrying the offset of the next page.
+	markerOffsetKey = "offset"
+)
+
+// listPage is the page requested by ?limit= and ?nextpage_opaque_marker=.
+type listPage struct {
+	kind   string
+	limit  int
+	offset int
+
+	// hasMarker is set when the offset comes from a marker.
+	hasMarker bool
+}
+
+// parseListPage parses the pagination parameters of a list of kind. Markers
+// are only accepted by the collection that issued them.
+func parseListPage(c *gin.Context, kind string) (listPage, error) {
+	page := listPage{kind: kind, limit: DefaultListPageSize}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return page, fmt.Errorf("invalid limit parameter: %q", limitStr)
+		}
+		page.limit = min(limit, MaxListPageSize)
+	}
+
+	marker := c.Query(NextPageMarkerParam)
+	if marker == "" {
+		return page, nil
+	}
+	data, err := models.DecodeCursor(marker)
+	if err != nil {
+		return page, fmt.Errorf("invalid %s: %w", NextPageMarkerParam, err)
+	}
+	if data[markerKindKey] != kind {
+		return page, fmt.Errorf("invalid %s: marker was not issued for %s", NextPageMarkerParam, kind)
+	}
+	offset, ok := data[markerOffsetKey].(float64)
+	if !ok || offset < 0 {
+		return page, fmt.Errorf("invalid %s: bad offset", NextPageMarkerParam)
+	}
+	page.offset = int(offset)
+	page.hasMarker = true
+	return page, nil
+}
+
+// apply restricts filter to the page. The adapter is asked for one item more
+// than the page holds, so a following page is detected without counting the
+// whole collection. Without a marker, an offset already on the filter is kept.
+func (p *listPage) apply(filter *adapter.Filter) {
+	if p.hasMarker {
+		filter.Offset = p.offset
+	} else {
+		p.offset = filter.Offset
+	}
+	filter.Limit = p.limit + 1
+}
+
+// cutListPage trims items listed with an applied page filter to the page and
+// sets the nextpage_opaque_marker response header when another page follows.
+func cutListPage[T any](c *gin.Context, page listPage, items []T) ([]T, error) {
+	if len(items) <= page.limit {
+		return items, nil
+	}
+
+	marker, err := models.EncodeCursor(map[string]interface{}{
+		markerKindKey:   page.kind,
+		markerOffsetKey: page.offset + page.limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode next page marker: %w", err)
+	}
+	c.Header(NextPageMarkerParam, marker)
+	return items[:page.limit], nil
+}
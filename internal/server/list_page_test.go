@@ -0,0 +1,107 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+// listPage fetches one page of a list and returns the item IDs and the marker
+// of the next page.
+func listPage(t *testing.T, srv *server.Server, path, query, kind, idField string) ([]string, string) {
+	t.Helper()
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path+"?"+query, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var envelope map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &envelope))
+	var items []map[string]interface{}
+	require.NoError(t, json.Unmarshal(envelope[kind], &items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item[idField].(string))
+	}
+	return ids, resp.Header().Get(server.NextPageMarkerParam)
+}
+
+func TestListResources_NextPageMarker(t *testing.T) {
+	adp := &filteringAdapter{}
+	for i := 1; i <= 5; i++ {
+		adp.resources = append(adp.resources, &adapter.Resource{
+			ResourceID:     fmt.Sprintf("r%d", i),
+			ResourceTypeID: "node",
+			Description:    fmt.Sprintf("server %d", i%2),
+		})
+	}
+	srv := setupFilterTestServer(t, adp, &mockStore{})
+	const path = "/o2ims-infrastructureInventory/v1/resources"
+
+	ids, marker := listPage(t, srv, path, "limit=2", "resources", "resourceId")
+	assert.Equal(t, []string{"r1", "r2"}, ids)
+	require.NotEmpty(t, marker)
+	assert.Equal(t, 3, adp.received.Limit, "the adapter is asked for one extra item")
+
+	ids, marker = listPage(t, srv, path, "limit=2&nextpage_opaque_marker="+url.QueryEscape(marker), "resources", "resourceId")
+	assert.Equal(t, []string{"r3", "r4"}, ids)
+	assert.Equal(t, 2, adp.received.Offset)
+	require.NotEmpty(t, marker)
+
+	ids, marker = listPage(t, srv, path, "limit=2&nextpage_opaque_marker="+url.QueryEscape(marker), "resources", "resourceId")
+	assert.Equal(t, []string{"r5"}, ids)
+	assert.Empty(t, marker, "no marker on the last page")
+
+	// Pages are cut after conditions evaluated by the gateway.
+	filter := "filter=" + url.QueryEscape("(contains,description,server 1)")
+	ids, marker = listPage(t, srv, path, filter+"&limit=2", "resources", "resourceId")
+	assert.Equal(t, []string{"r1", "r3"}, ids)
+	ids, marker = listPage(t, srv, path, filter+"&limit=2&nextpage_opaque_marker="+url.QueryEscape(marker),
+		"resources", "resourceId")
+	assert.Equal(t, []string{"r5"}, ids)
+	assert.Empty(t, marker)
+}
+
+func TestListResourcePools_NextPageMarker(t *testing.T) {
+	adp := &filteringAdapter{pools: []*adapter.ResourcePool{
+		{ResourcePoolID: "pool-a"}, {ResourcePoolID: "pool-b"}, {ResourcePoolID: "pool-c"},
+	}}
+	srv := setupFilterTestServer(t, adp, &mockStore{})
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	ids, marker := listPage(t, srv, path, "limit=2", "resourcePools", "resourcePoolId")
+	assert.Equal(t, []string{"pool-a", "pool-b"}, ids)
+	require.NotEmpty(t, marker)
+
+	// A marker is only valid for the list that issued it.
+	resp, _ := doResourceRequest(t, srv, http.MethodGet,
+		"/o2ims-infrastructureInventory/v1/resources?nextpage_opaque_marker="+url.QueryEscape(marker), nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	ids, marker = listPage(t, srv, path, "limit=2&nextpage_opaque_marker="+url.QueryEscape(marker),
+		"resourcePools", "resourcePoolId")
+	assert.Equal(t, []string{"pool-c"}, ids)
+	assert.Empty(t, marker)
+}
+
+func TestListPage_InvalidParameters(t *testing.T) {
+	srv := setupFilterTestServer(t, &filteringAdapter{}, &mockStore{})
+
+	for _, query := range []string{"limit=0", "limit=abc", "nextpage_opaque_marker=not-a-marker"} {
+		for _, path := range []string{
+			"/o2ims-infrastructureInventory/v1/resources",
+			"/o2ims-infrastructureInventory/v1/resourcePools",
+			"/o2ims-infrastructureInventory/v1/resourceTypes",
+			"/o2ims-infrastructureInventory/v1/resourcePools/pool-a/resources",
+		} {
+			resp, _ := doResourceRequest(t, srv, http.MethodGet, path+"?"+query, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.Code, path+"?"+query)
+		}
+	}
+}
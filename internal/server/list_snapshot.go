@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/models"
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Snapshot pagination parameters.
+const (
+	// consistencySnapshot is the consistency query parameter value requesting
+	// repeatable reads across the pages of a list.
+	consistencySnapshot = "snapshot"
+
+	// snapshotCursorKey is the cursor field carrying the snapshot ID.
+	snapshotCursorKey = "snapshot"
+
+	// snapshotCursorOffsetKey is the cursor field carrying the next offset.
+	snapshotCursorOffsetKey = "offset"
+
+	defaultSnapshotPageSize = 100
+	maxSnapshotPageSize     = 1000
+)
+
+// snapshotPageRequest holds the pagination state of a snapshot list request.
+type snapshotPageRequest struct {
+	// snapshotID is empty on the first page, when the snapshot is taken.
+	snapshotID string
+	offset     int
+	limit      int
+}
+
+// SetListSnapshotStore enables consistency=snapshot pagination on list endpoints.
+// Snapshots must be visible to every replica serving the API.
+func (s *Server) SetListSnapshotStore(store storage.ListSnapshotStore) {
+	s.listSnapshots = store
+}
+
+// parseSnapshotPageRequest reports whether the request asks for snapshot
+// consistency, either with ?consistency=snapshot or with a cursor issued by a
+// previous snapshot page.
+func parseSnapshotPageRequest(c *gin.Context) (snapshotPageRequest, bool, error) {
+	req := snapshotPageRequest{limit: defaultSnapshotPageSize}
+
+	consistency := c.Query("consistency")
+	if consistency != "" && consistency != consistencySnapshot {
+		return req, false, fmt.Errorf("unsupported consistency %q (supported: %s)", consistency, consistencySnapshot)
+	}
+
+	if cursor := c.Query("cursor"); cursor != "" {
+		data, err := models.DecodeCursor(cursor)
+		if err != nil {
+			return req, false, fmt.Errorf("invalid cursor: %w", err)
+		}
+		if id, ok := data[snapshotCursorKey].(string); ok && id != "" {
+			offset, _ := data[snapshotCursorOffsetKey].(float64)
+			if offset < 0 {
+				return req, false, errors.New("invalid cursor: negative offset")
+			}
+			req.snapshotID = id
+			req.offset = int(offset)
+		} else if consistency == consistencySnapshot {
+			return req, false, errors.New("invalid cursor: cursor was not issued for a snapshot")
+		}
+	}
+
+	if req.snapshotID == "" && consistency != consistencySnapshot {
+		return req, false, nil
+	}
+	if c.Query("waitFor") != "" {
+		return req, false, errors.New("waitFor cannot be combined with snapshot consistency")
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return req, false, fmt.Errorf("invalid limit parameter: %q", limitStr)
+		}
+		req.limit = min(limit, maxSnapshotPageSize)
+	}
+
+	return req, true, nil
+}
+
+// serveListSnapshot writes a page of a snapshot-consistent list. The first page
+// calls list for the full result set and saves it as a snapshot; the returned
+// nextCursor embeds the snapshot ID so later pages read the same items even if
+// the backend changes in between. Snapshots expire after the configured TTL.
+func (s *Server) serveListSnapshot(
+	c *gin.Context, req snapshotPageRequest, kind string, list func(ctx context.Context) (interface{}, error),
+) {
+	if s.listSnapshots == nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", "snapshot consistency is not enabled")
+		return
+	}
+
+	ctx := c.Request.Context()
+	tenantID := auth.TenantIDFromContext(ctx)
+
+	var (
+		snapshot *storage.ListSnapshot
+		err      error
+	)
+	if req.snapshotID == "" {
+		snapshot, req.snapshotID, err = s.takeListSnapshot(ctx, kind, tenantID, list)
+	} else {
+		snapshot, err = s.listSnapshots.Load(ctx, req.snapshotID)
+		if err == nil && (snapshot.Kind != kind || snapshot.TenantID != tenantID) {
+			err = storage.ErrListSnapshotNotFound
+		}
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrListSnapshotNotFound) {
+			problem.Respond(c, http.StatusGone, "SnapshotExpired",
+				"List snapshot has expired; restart pagination without a cursor")
+			return
+		}
+		s.requestLogger(c).Error("failed to serve list snapshot", zap.String("kind", kind), zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+kind)
+		return
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(snapshot.Items, &items); err != nil {
+		s.requestLogger(c).Error("failed to decode list snapshot", zap.String("kind", kind), zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+kind)
+		return
+	}
+
+	start := min(req.offset, len(items))
+	end := min(start+req.limit, len(items))
+	page := items[start:end]
+	if page == nil {
+		page = []json.RawMessage{}
+	}
+
+	response := o2imsmodels.ListEnvelope[json.RawMessage]{
+		Kind:              kind,
+		Items:             page,
+		Total:             snapshot.Total,
+		SnapshotCreatedAt: &snapshot.CreatedAt,
+	}
+	if end < len(items) {
+		nextCursor, err := models.EncodeCursor(map[string]interface{}{
+			snapshotCursorKey:       req.snapshotID,
+			snapshotCursorOffsetKey: end,
+		})
+		if err != nil {
+			s.requestLogger(c).Error("failed to encode snapshot cursor", zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+kind)
+			return
+		}
+		response.NextCursor = nextCursor
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// takeListSnapshot lists the full result set and saves it as a snapshot.
+func (s *Server) takeListSnapshot(
+	ctx context.Context, kind, tenantID string, list func(ctx context.Context) (interface{}, error),
+) (*storage.ListSnapshot, string, error) {
+	result, err := list(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+
+	items, err := json.Marshal(result)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal %s: %w", kind, err)
+	}
+	var decoded []json.RawMessage
+	if err := json.Unmarshal(items, &decoded); err != nil {
+		return nil, "", fmt.Errorf("failed to count %s: %w", kind, err)
+	}
+
+	snapshot := &storage.ListSnapshot{
+		Kind:      kind,
+		TenantID:  tenantID,
+		Items:     items,
+		Total:     len(decoded),
+		CreatedAt: timeutil.Now(),
+	}
+	id, err := s.listSnapshots.Save(ctx, snapshot)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to save %s snapshot: %w", kind, err)
+	}
+	return snapshot, id, nil
+}
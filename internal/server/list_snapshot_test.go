@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/models"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// mutablePoolAdapter serves a resource pool list that tests can change between pages.
+type mutablePoolAdapter struct {
+	mockAdapter
+	mu    sync.Mutex
+	pools []*adapter.ResourcePool
+}
+
+func (m *mutablePoolAdapter) ListResourcePools(_ context.Context, filter *adapter.Filter) ([]*adapter.ResourcePool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pools := append([]*adapter.ResourcePool(nil), m.pools...)
+	if filter != nil {
+		pools = adapter.ApplyPagination(pools, filter.Limit, filter.Offset)
+	}
+	return pools, nil
+}
+
+func (m *mutablePoolAdapter) setPools(ids ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pools = nil
+	for _, id := range ids {
+		m.pools = append(m.pools, &adapter.ResourcePool{ResourcePoolID: id, Name: id})
+	}
+}
+
+type poolSnapshotPage struct {
+	ResourcePools []adapter.ResourcePool `json:"resourcePools"`
+	Total         int                    `json:"total"`
+	NextCursor    string                 `json:"nextCursor"`
+}
+
+func TestListResourcePools_SnapshotConsistency(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	adp := &mutablePoolAdapter{}
+	adp.setPools("pool-a", "pool-b", "pool-c")
+	srv := setupResourceTestServer(t, adp)
+	srv.SetListSnapshotStore(storage.NewInMemoryListSnapshotStore(time.Minute))
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path+"?consistency=snapshot&limit=2", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var first poolSnapshotPage
+	require.NoError(t, json.Unmarshal(body, &first))
+	assert.Equal(t, 3, first.Total)
+	require.Len(t, first.ResourcePools, 2)
+	assert.Equal(t, "pool-a", first.ResourcePools[0].ResourcePoolID)
+	assert.Equal(t, "pool-b", first.ResourcePools[1].ResourcePoolID)
+	require.NotEmpty(t, first.NextCursor)
+
+	// Changes between pages are not visible within the pagination session.
+	adp.setPools("pool-0", "pool-b", "pool-c", "pool-d")
+
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?limit=2&cursor="+first.NextCursor, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var second poolSnapshotPage
+	require.NoError(t, json.Unmarshal(body, &second))
+	assert.Equal(t, 3, second.Total)
+	require.Len(t, second.ResourcePools, 1)
+	assert.Equal(t, "pool-c", second.ResourcePools[0].ResourcePoolID)
+	assert.Empty(t, second.NextCursor)
+
+	// A new session sees the current state.
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?consistency=snapshot", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var fresh poolSnapshotPage
+	require.NoError(t, json.Unmarshal(body, &fresh))
+	assert.Equal(t, 4, fresh.Total)
+	assert.Len(t, fresh.ResourcePools, 4)
+}
+
+func TestListResourcePools_SnapshotErrors(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	adp := &mutablePoolAdapter{}
+	adp.setPools("pool-a")
+	srv := setupResourceTestServer(t, adp)
+
+	// Snapshot pagination requires a snapshot store.
+	resp, _ := doResourceRequest(t, srv, http.MethodGet, path+"?consistency=snapshot", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	srv.SetListSnapshotStore(storage.NewInMemoryListSnapshotStore(time.Minute))
+
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, path+"?consistency=strong", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, path+"?consistency=snapshot&limit=0", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// Expired or unknown snapshots ask the client to restart pagination.
+	cursor, err := models.EncodeCursor(map[string]interface{}{"snapshot": "expired", "offset": 10})
+	require.NoError(t, err)
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path+"?cursor="+cursor, nil)
+	assert.Equal(t, http.StatusGone, resp.Code)
+	assert.Contains(t, string(body), "SnapshotExpired")
+
+	// Snapshot cursors are bound to the listed collection.
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?consistency=snapshot&limit=1", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	var page poolSnapshotPage
+	require.NoError(t, json.Unmarshal(body, &page))
+	assert.Empty(t, page.NextCursor)
+
+	adp.setPools("pool-a", "pool-b")
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?consistency=snapshot&limit=1", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.NotEmpty(t, page.NextCursor)
+	resp, _ = doResourceRequest(t, srv, http.MethodGet,
+		"/o2ims-infrastructureInventory/v1/resources?cursor="+page.NextCursor, nil)
+	assert.Equal(t, http.StatusGone, resp.Code)
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// logLevelRequest is the body of PUT /admin/loglevel. Level changes the
+// global level; Modules changes the level of individual modules, where an
+// empty level makes the module follow the global level again.
+type logLevelRequest struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// validate checks every requested level so a request is applied completely
+// or not at all.
+func (r *logLevelRequest) validate() error {
+	if r.Level == "" && len(r.Modules) == 0 {
+		return fmt.Errorf("level or modules must be set")
+	}
+	if r.Level != "" {
+		if _, err := observability.ParseLogLevel(r.Level); err != nil {
+			return err
+		}
+	}
+	modules := observability.LogModules()
+	for module, level := range r.Modules {
+		if !slices.Contains(modules, module) {
+			return fmt.Errorf("%w: %s (must be one of %v)", observability.ErrUnknownLogModule, module, modules)
+		}
+		if level == "" {
+			continue
+		}
+		if _, err := observability.ParseLogLevel(level); err != nil {
+			return fmt.Errorf("module %s: %w", module, err)
+		}
+	}
+	return nil
+}
+
+// handleGetLogLevel returns the global and per-module log levels.
+// GET /admin/loglevel.
+func (s *Server) handleGetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, observability.GetLogLevels())
+}
+
+// handleSetLogLevel changes log levels without restarting the gateway.
+// PUT /admin/loglevel.
+func (s *Server) handleSetLogLevel(c *gin.Context) {
+	var req logLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	// Validated above, so setting the levels cannot fail.
+	if req.Level != "" {
+		_ = observability.SetLogLevel("", req.Level)
+	}
+	for module, level := range req.Modules {
+		_ = observability.SetLogLevel(module, level)
+	}
+
+	status := observability.GetLogLevels()
+	s.requestLogger(c).Info("log levels changed",
+		zap.String("level", status.Level),
+		zap.Any("modules", status.Modules),
+	)
+
+	c.JSON(http.StatusOK, status)
+}
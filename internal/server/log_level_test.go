@@ -0,0 +1,64 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/observability"
+)
+
+func TestLogLevelEndpoint(t *testing.T) {
+	const path = "/admin/loglevel"
+
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+	t.Cleanup(func() {
+		require.NoError(t, observability.SetLogLevel("", "info"))
+		for _, module := range observability.LogModules() {
+			require.NoError(t, observability.SetLogLevel(module, ""))
+		}
+	})
+
+	resp, body := doResourceRequest(t, srv, http.MethodPut, path,
+		map[string]interface{}{"level": "warn", "modules": map[string]string{"dms": "debug"}})
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var status observability.LogLevelStatus
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "warn", status.Level)
+	assert.Equal(t, "debug", status.Modules["dms"])
+	assert.Equal(t, "warn", status.Modules["server"])
+	assert.Equal(t, []string{"dms"}, status.Overrides)
+
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "debug", status.Modules["dms"])
+
+	t.Run("invalid requests change nothing", func(t *testing.T) {
+		for name, req := range map[string]map[string]interface{}{
+			"empty":          {},
+			"invalid level":  {"level": "verbose"},
+			"unknown module": {"level": "debug", "modules": map[string]string{"database": "debug"}},
+			"invalid module level": {
+				"level": "debug", "modules": map[string]string{"auth": "loud"},
+			},
+		} {
+			resp, body := doResourceRequest(t, srv, http.MethodPut, path, req)
+			assert.Equal(t, http.StatusBadRequest, resp.Code, "%s: %s", name, body)
+		}
+		assert.Equal(t, "warn", observability.GetLogLevels().Level)
+	})
+
+	t.Run("empty module level follows the global level", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodPut, path,
+			map[string]interface{}{"modules": map[string]string{"dms": ""}})
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		require.NoError(t, json.Unmarshal(body, &status))
+		assert.Equal(t, "warn", status.Modules["dms"])
+		assert.Empty(t, status.Overrides)
+	})
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// Long-poll parameters.
+const (
+	// DefaultLongPollTimeout is how long a long-poll list request waits for a
+	// change when no timeout is given.
+	DefaultLongPollTimeout = 30 * time.Second
+
+	// MaxLongPollTimeout caps the timeout a client may request.
+	MaxLongPollTimeout = 5 * time.Minute
+
+	// longPollRecheckInterval is how often a waiting request re-lists the
+	// collection to detect changes made on other replicas or in the backend.
+	longPollRecheckInterval = 5 * time.Second
+)
+
+// longPollRequest holds the parameters of a long-poll list request.
+type longPollRequest struct {
+	// waitFor is the resourceVersion the client already has.
+	waitFor string
+	timeout time.Duration
+}
+
+// listChangeNotifier wakes long-poll requests waiting on a collection when it
+// is mutated through this replica.
+type listChangeNotifier struct {
+	mu      sync.Mutex
+	waiters map[string]chan struct{}
+}
+
+func newListChangeNotifier() *listChangeNotifier {
+	return &listChangeNotifier{waiters: make(map[string]chan struct{})}
+}
+
+// changed returns a channel that is closed on the next change of kind.
+func (n *listChangeNotifier) changed(kind string) <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	ch, ok := n.waiters[kind]
+	if !ok {
+		ch = make(chan struct{})
+		n.waiters[kind] = ch
+	}
+	return ch
+}
+
+// notify wakes every request waiting on kind.
+func (n *listChangeNotifier) notify(kind string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if ch, ok := n.waiters[kind]; ok {
+		close(ch)
+		delete(n.waiters, kind)
+	}
+}
+
+// parseLongPollRequest reports whether the request asks for long-poll
+// semantics with ?waitFor={resourceVersion}. The optional timeout is a
+// duration ("30s") or a number of seconds. ?watch=true streaming is not
+// supported on list endpoints.
+func (s *Server) parseLongPollRequest(c *gin.Context) (longPollRequest, bool, error) {
+	req := longPollRequest{timeout: DefaultLongPollTimeout}
+
+	switch watch := c.Query("watch"); watch {
+	case "", "false":
+	case "true":
+		return req, false, errors.New("watch=true is not supported; use waitFor for long polling or subscriptions for push notifications")
+	default:
+		return req, false, fmt.Errorf("invalid watch parameter: %q", watch)
+	}
+
+	req.waitFor = c.Query("waitFor")
+	timeoutStr := c.Query("timeout")
+	if req.waitFor == "" {
+		if timeoutStr != "" {
+			return req, false, errors.New("timeout requires waitFor")
+		}
+		return req, false, nil
+	}
+
+	if timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			seconds, convErr := strconv.Atoi(timeoutStr)
+			if convErr != nil {
+				return req, false, fmt.Errorf("invalid timeout parameter: %q", timeoutStr)
+			}
+			timeout = time.Duration(seconds) * time.Second
+		}
+		if timeout <= 0 {
+			return req, false, fmt.Errorf("invalid timeout parameter: %q", timeoutStr)
+		}
+		req.timeout = min(timeout, MaxLongPollTimeout)
+	}
+
+	// Respond before the server write timeout closes the connection.
+	if wt := s.config.Server.WriteTimeout; wt > time.Second {
+		req.timeout = min(req.timeout, wt-time.Second)
+	}
+
+	return req, true, nil
+}
+
+// listResponse builds a list response with the resourceVersion watermark of items.
+func listResponse[T any](kind string, items []T) (*o2imsmodels.ListEnvelope[T], error) {
+	version, err := listResourceVersion(items)
+	if err != nil {
+		return nil, err
+	}
+	return &o2imsmodels.ListEnvelope[T]{
+		Kind:            kind,
+		Items:           items,
+		Total:           len(items),
+		ResourceVersion: version,
+	}, nil
+}
+
+// listResourceVersion returns an opaque watermark of a list: a digest of its
+// content. Any change to the listed items changes the watermark, regardless of
+// which replica or backend made it.
+func listResourceVersion[T any](items []T) (string, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal list: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:12]), nil
+}
+
+// serveList writes a list response with its resourceVersion watermark.
+func serveList[T any](s *Server, c *gin.Context, kind string, items []T) {
+	response, err := listResponse(kind, items)
+	if err != nil {
+		s.requestLogger(c).Error("failed to build list response", zap.String("kind", kind), zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+kind)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// serveLongPoll responds as soon as the list differs from the client's
+// resourceVersion, or with 304 Not Modified once the timeout elapses or the
+// server starts draining. Waiting requests are woken by mutations through this
+// replica and re-list the collection periodically to pick up other changes.
+func serveLongPoll[T any](
+	s *Server, c *gin.Context, req longPollRequest, kind string, list func(ctx context.Context) ([]T, error),
+) {
+	ctx := c.Request.Context()
+	deadline := time.NewTimer(req.timeout)
+	defer deadline.Stop()
+
+	for {
+		// Register before listing so a change between the two is not missed.
+		changed := s.listChanges.changed(kind)
+
+		items, err := list(ctx)
+		if err != nil {
+			s.requestLogger(c).Error("failed to list for long poll", zap.String("kind", kind), zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+kind)
+			return
+		}
+
+		response, err := listResponse(kind, items)
+		if err != nil {
+			s.requestLogger(c).Error("failed to build list response", zap.String("kind", kind), zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve "+kind)
+			return
+		}
+		if response.ResourceVersion != req.waitFor {
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		recheck := time.NewTimer(longPollRecheckInterval)
+		select {
+		case <-changed:
+		case <-recheck.C:
+		case <-deadline.C:
+			recheck.Stop()
+			c.Status(http.StatusNotModified)
+			return
+		case <-DrainNotice(c):
+			// Let the client poll again, through another replica.
+			recheck.Stop()
+			c.Header("Connection", "close")
+			c.Status(http.StatusNotModified)
+			return
+		case <-ctx.Done():
+			recheck.Stop()
+			c.Status(http.StatusNotModified)
+			return
+		}
+		recheck.Stop()
+	}
+}
@@ -0,0 +1,151 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+// deletablePoolAdapter removes pools from the mutable list on delete.
+type deletablePoolAdapter struct {
+	mutablePoolAdapter
+}
+
+func (m *deletablePoolAdapter) DeleteResourcePool(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, pool := range m.pools {
+		if pool.ResourcePoolID == id {
+			m.pools = append(m.pools[:i], m.pools[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+type poolListPage struct {
+	Total           int    `json:"total"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+func TestListResourcePools_LongPoll(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	adp := &deletablePoolAdapter{}
+	adp.setPools("pool-a", "pool-b")
+	srv := setupResourceTestServer(t, adp)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var page poolListPage
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.NotEmpty(t, page.ResourceVersion)
+	version := page.ResourceVersion
+
+	// Unchanged lists report the same watermark.
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(body, &page))
+	assert.Equal(t, version, page.ResourceVersion)
+
+	// No change before the timeout returns 304.
+	start := time.Now()
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?watch=false&waitFor="+version+"&timeout=100ms", nil)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, body)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// A stale watermark returns immediately.
+	resp, body = doResourceRequest(t, srv, http.MethodGet, path+"?waitFor=stale&timeout=10", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.NoError(t, json.Unmarshal(body, &page))
+	assert.Equal(t, version, page.ResourceVersion)
+
+	// A mutation through the API wakes waiting requests.
+	done := make(chan poolListPage, 1)
+	go func() {
+		resp, body := doResourceRequest(t, srv, http.MethodGet, path+"?waitFor="+version+"&timeout=20s", nil)
+		var changed poolListPage
+		if resp.Code == http.StatusOK {
+			_ = json.Unmarshal(body, &changed)
+		}
+		done <- changed
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	resp, body = doResourceRequest(t, srv, http.MethodDelete, path+"/pool-a", nil)
+	require.Less(t, resp.Code, http.StatusBadRequest, string(body))
+
+	select {
+	case changed := <-done:
+		assert.Equal(t, 1, changed.Total)
+		assert.NotEqual(t, version, changed.ResourceVersion)
+	case <-time.After(3 * time.Second):
+		t.Fatal("long poll was not woken by the mutation")
+	}
+}
+
+func TestListResourcePools_LongPollDrain(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	adp := &deletablePoolAdapter{}
+	adp.setPools("pool-a")
+	srv, _ := server.NewTestServerWithMetrics(&config.Config{
+		Server: config.ServerConfig{GinMode: gin.TestMode, StreamDrainTimeout: 5 * time.Second},
+	}, zap.NewNop(), adp, &mockStore{})
+	srv.SetHTTPServer(&http.Server{Handler: srv.Router(), ReadHeaderTimeout: time.Second})
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var page poolListPage
+	require.NoError(t, json.Unmarshal(body, &page))
+
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		resp, _ := doResourceRequest(t, srv, http.MethodGet, path+"?waitFor="+page.ResourceVersion+"&timeout=20s", nil)
+		done <- resp
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// Shutdown answers waiting requests so clients poll another replica.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, srv.ShutdownWithContext(ctx))
+	select {
+	case resp := <-done:
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+		assert.Equal(t, "close", resp.Header().Get("Connection"))
+	case <-time.After(3 * time.Second):
+		t.Fatal("long poll was not answered on drain")
+	}
+}
+
+func TestListResourcePools_LongPollInvalidParameters(t *testing.T) {
+	const path = "/o2ims-infrastructureInventory/v1/resourcePools"
+
+	adp := &deletablePoolAdapter{}
+	srv := setupResourceTestServer(t, adp)
+
+	for _, query := range []string{
+		"?watch=true",
+		"?watch=maybe",
+		"?timeout=5s",
+		"?waitFor=abc&timeout=soon",
+		"?waitFor=abc&timeout=-1s",
+		"?waitFor=abc&consistency=snapshot",
+	} {
+		resp, _ := doResourceRequest(t, srv, http.MethodGet, path+query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Code, query)
+	}
+}
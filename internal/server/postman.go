@@ -0,0 +1,402 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// PostmanSchemaURL identifies the Postman collection format served by /docs/postman.json.
+const PostmanSchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// postmanExampleDepth limits how deep request body examples are generated from schemas.
+const postmanExampleDepth = 5
+
+// postmanMethods is the order in which operations of a path are listed.
+var postmanMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// PostmanCollection is a Postman v2.1 collection. Insomnia imports the same format.
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanFolder   `json:"item"`
+	Variable []PostmanVariable `json:"variable"`
+	Auth     *PostmanAuth      `json:"auth,omitempty"`
+}
+
+// PostmanInfo describes a Postman collection.
+type PostmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// PostmanFolder groups the requests of one OpenAPI tag.
+type PostmanFolder struct {
+	Name string        `json:"name"`
+	Item []PostmanItem `json:"item"`
+}
+
+// PostmanItem is a single request of a Postman collection.
+type PostmanItem struct {
+	Name    string         `json:"name"`
+	Request PostmanRequest `json:"request"`
+}
+
+// PostmanRequest describes the HTTP request of a Postman item.
+type PostmanRequest struct {
+	Method      string          `json:"method"`
+	Description string          `json:"description,omitempty"`
+	Header      []PostmanHeader `json:"header"`
+	URL         PostmanURL      `json:"url"`
+	Body        *PostmanBody    `json:"body,omitempty"`
+}
+
+// PostmanHeader is a request header.
+type PostmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PostmanURL is a request URL with its path and query variables.
+type PostmanURL struct {
+	Raw      string              `json:"raw"`
+	Host     []string            `json:"host"`
+	Path     []string            `json:"path"`
+	Query    []PostmanQueryParam `json:"query,omitempty"`
+	Variable []PostmanVariable   `json:"variable,omitempty"`
+}
+
+// PostmanQueryParam is an optional query parameter, disabled by default.
+type PostmanQueryParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled"`
+}
+
+// PostmanVariable is a collection or path variable.
+type PostmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// PostmanBody is a raw JSON request body.
+type PostmanBody struct {
+	Mode    string                 `json:"mode"`
+	Raw     string                 `json:"raw"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// PostmanAuth is the collection-level authentication.
+type PostmanAuth struct {
+	Type string `json:"type"`
+}
+
+// HandlePostmanCollection serves the loaded OpenAPI specification converted
+// to a Postman v2.1 collection. The baseUrl variable points at the gateway
+// that served the request.
+// GET /docs/postman.json.
+func (s *Server) HandlePostmanCollection(c *gin.Context) {
+	if len(s.openAPISpec) == 0 {
+		problem.Respond(c, http.StatusNotFound, "NotFound", "OpenAPI specification not loaded")
+		return
+	}
+
+	spec, err := openapi3.NewLoader().LoadFromData(s.openAPISpec)
+	if err != nil {
+		if s.logger != nil {
+			s.requestLogger(c).Error("failed to parse OpenAPI specification", zap.Error(err))
+		}
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to parse OpenAPI specification")
+		return
+	}
+
+	collection := BuildPostmanCollection(spec, requestBaseURL(c), s.authMw != nil)
+
+	c.Header("Content-Disposition", `attachment; filename="postman.json"`)
+	c.JSON(http.StatusOK, collection)
+}
+
+// requestBaseURL returns the scheme and host the client used to reach the gateway.
+func requestBaseURL(c *gin.Context) string {
+	return requestScheme(c) + "://" + c.Request.Host
+}
+
+// requestScheme returns the scheme the client used to reach the gateway.
+func requestScheme(c *gin.Context) string {
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		return proto
+	}
+	if c.Request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// BuildPostmanCollection converts spec to a Postman collection whose requests
+// target baseURL. Operations are grouped in folders by their first tag. When
+// mtls is set, the collection describes how to configure the client
+// certificate, which Postman stores per host outside the collection.
+func BuildPostmanCollection(spec *openapi3.T, baseURL string, mtls bool) *PostmanCollection {
+	collection := &PostmanCollection{
+		Info: PostmanInfo{Schema: PostmanSchemaURL},
+		Item: []PostmanFolder{},
+		Variable: []PostmanVariable{
+			{Key: "baseUrl", Value: strings.TrimSuffix(baseURL, "/"), Description: "Gateway scheme and host"},
+		},
+	}
+	if spec.Info != nil {
+		collection.Info.Name = spec.Info.Title
+		collection.Info.Description = spec.Info.Description
+	}
+
+	if mtls {
+		collection.Auth = &PostmanAuth{Type: "noauth"}
+		collection.Info.Description = strings.TrimSpace(collection.Info.Description + "\n\n" +
+			"This gateway authenticates clients with mTLS. In Postman, add your client certificate and key " +
+			"under Settings > Certificates for the host in {{baseUrl}}; in Insomnia, under Collection " +
+			"Settings > Client Certificates.")
+	}
+
+	defaultServer := serverPath(spec.Servers)
+	folders := make(map[string]*PostmanFolder)
+	var folderOrder []string
+
+	paths := spec.Paths.Map()
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+
+	for _, path := range keys {
+		item := paths[path]
+		basePath := defaultServer
+		if len(item.Servers) > 0 {
+			basePath = serverPath(item.Servers)
+		}
+
+		for _, method := range postmanMethods {
+			op := item.GetOperation(method)
+			if op == nil {
+				continue
+			}
+
+			tag := "default"
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			folder, ok := folders[tag]
+			if !ok {
+				folder = &PostmanFolder{Name: tag}
+				folders[tag] = folder
+				folderOrder = append(folderOrder, tag)
+			}
+
+			params := append(openapi3.Parameters{}, item.Parameters...)
+			params = append(params, op.Parameters...)
+			folder.Item = append(folder.Item, PostmanItem{
+				Name:    postmanItemName(method, path, op),
+				Request: postmanRequest(method, basePath+path, op, params),
+			})
+		}
+	}
+
+	for _, tag := range folderOrder {
+		collection.Item = append(collection.Item, *folders[tag])
+	}
+	return collection
+}
+
+// serverPath returns the path of the first server URL, which may be absolute
+// or relative to the gateway.
+func serverPath(servers openapi3.Servers) string {
+	if len(servers) == 0 || servers[0] == nil {
+		return ""
+	}
+	u := servers[0].URL
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		if slash := strings.Index(u, "/"); slash >= 0 {
+			u = u[slash:]
+		} else {
+			u = ""
+		}
+	}
+	return strings.TrimSuffix(u, "/")
+}
+
+func postmanItemName(method, path string, op *openapi3.Operation) string {
+	if op.Summary != "" {
+		return op.Summary
+	}
+	if op.OperationID != "" {
+		return op.OperationID
+	}
+	return method + " " + path
+}
+
+// postmanRequest builds the request for an operation on path. Path parameters
+// become Postman path variables and query parameters are added disabled.
+func postmanRequest(method, path string, op *openapi3.Operation, params openapi3.Parameters) PostmanRequest {
+	req := PostmanRequest{
+		Method:      method,
+		Description: op.Description,
+		Header:      []PostmanHeader{{Key: "Accept", Value: "application/json"}},
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segment = ":" + strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		}
+		segments = append(segments, segment)
+	}
+	req.URL = PostmanURL{
+		Host: []string{"{{baseUrl}}"},
+		Path: segments,
+	}
+
+	var query []string
+	for _, ref := range params {
+		param := ref.Value
+		if param == nil {
+			continue
+		}
+		switch param.In {
+		case openapi3.ParameterInPath:
+			req.URL.Variable = append(req.URL.Variable, PostmanVariable{
+				Key:         param.Name,
+				Value:       "",
+				Description: param.Description,
+			})
+		case openapi3.ParameterInQuery:
+			req.URL.Query = append(req.URL.Query, PostmanQueryParam{
+				Key:         param.Name,
+				Value:       parameterExample(param),
+				Description: param.Description,
+				Disabled:    !param.Required,
+			})
+			if param.Required {
+				query = append(query, param.Name+"="+parameterExample(param))
+			}
+		}
+	}
+
+	req.URL.Raw = "{{baseUrl}}/" + strings.Join(segments, "/")
+	if len(query) > 0 {
+		req.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if media := op.RequestBody.Value.Content.Get("application/json"); media != nil {
+			example := media.Example
+			if example == nil {
+				example = schemaExample(media.Schema, postmanExampleDepth)
+			}
+			raw, err := json.MarshalIndent(example, "", "  ")
+			if err != nil {
+				raw = []byte("{}")
+			}
+			req.Header = append(req.Header, PostmanHeader{Key: "Content-Type", Value: "application/json"})
+			req.Body = &PostmanBody{
+				Mode:    "raw",
+				Raw:     string(raw),
+				Options: map[string]interface{}{"raw": map[string]string{"language": "json"}},
+			}
+		}
+	}
+
+	return req
+}
+
+// parameterExample returns the example or default value of a query parameter.
+func parameterExample(param *openapi3.Parameter) string {
+	if param.Example != nil {
+		return fmt.Sprint(param.Example)
+	}
+	if param.Schema != nil && param.Schema.Value != nil {
+		if param.Schema.Value.Example != nil {
+			return fmt.Sprint(param.Schema.Value.Example)
+		}
+		if param.Schema.Value.Default != nil {
+			return fmt.Sprint(param.Schema.Value.Default)
+		}
+	}
+	return ""
+}
+
+// schemaExample generates an example value for a schema, preferring the
+// schema's own example and otherwise filling in placeholder values.
+func schemaExample(ref *openapi3.SchemaRef, depth int) interface{} {
+	if ref == nil || ref.Value == nil || depth <= 0 {
+		return nil
+	}
+	schema := ref.Value
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if len(schema.AllOf) > 0 {
+		merged := map[string]interface{}{}
+		for _, sub := range schema.AllOf {
+			if obj, ok := schemaExample(sub, depth).(map[string]interface{}); ok {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	if len(schema.OneOf) > 0 {
+		return schemaExample(schema.OneOf[0], depth)
+	}
+	if len(schema.AnyOf) > 0 {
+		return schemaExample(schema.AnyOf[0], depth)
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+
+	switch {
+	case schema.Type.Is(openapi3.TypeArray):
+		if item := schemaExample(schema.Items, depth-1); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case schema.Type.Is(openapi3.TypeString):
+		if schema.Format != "" {
+			return schema.Format
+		}
+		return "string"
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		return 0
+	case schema.Type.Is(openapi3.TypeBoolean):
+		return false
+	case schema.Type.Is(openapi3.TypeObject) || len(schema.Properties) > 0:
+		obj := make(map[string]interface{}, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			if prop.Value != nil && prop.Value.ReadOnly {
+				continue
+			}
+			if value := schemaExample(prop, depth-1); value != nil {
+				obj[name] = value
+			}
+		}
+		return obj
+	}
+	return nil
+}
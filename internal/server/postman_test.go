@@ -0,0 +1,95 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func findPostmanItem(collection *server.PostmanCollection, method string, path ...string) *server.PostmanItem {
+	for _, folder := range collection.Item {
+		for i, item := range folder.Item {
+			if item.Request.Method != method || len(item.Request.URL.Path) != len(path) {
+				continue
+			}
+			match := true
+			for j := range path {
+				if item.Request.URL.Path[j] != path[j] {
+					match = false
+					break
+				}
+			}
+			if match {
+				return &folder.Item[i]
+			}
+		}
+	}
+	return nil
+}
+
+func TestHandlePostmanCollection(t *testing.T) {
+	spec, err := os.ReadFile("openapi/o2ims.yaml")
+	require.NoError(t, err)
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+	srv.SetOpenAPISpec(spec)
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/postman.json", nil)
+	req.Host = "gateway.example.com:8443"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	resp := httptest.NewRecorder()
+	srv.Router().ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var collection server.PostmanCollection
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &collection))
+	assert.Equal(t, server.PostmanSchemaURL, collection.Info.Schema)
+	assert.NotEmpty(t, collection.Info.Name)
+	require.Len(t, collection.Variable, 1)
+	assert.Equal(t, "https://gateway.example.com:8443", collection.Variable[0].Value)
+	assert.Nil(t, collection.Auth)
+	assert.NotEmpty(t, collection.Item)
+
+	get := findPostmanItem(&collection, http.MethodGet,
+		"o2ims-infrastructureInventory", "v1", "resourcePools", ":resourcePoolId")
+	require.NotNil(t, get)
+	assert.Equal(t, "{{baseUrl}}/o2ims-infrastructureInventory/v1/resourcePools/:resourcePoolId", get.Request.URL.Raw)
+	require.Len(t, get.Request.URL.Variable, 1)
+	assert.Equal(t, "resourcePoolId", get.Request.URL.Variable[0].Key)
+
+	list := findPostmanItem(&collection, http.MethodGet, "o2ims-infrastructureInventory", "v1", "resources")
+	require.NotNil(t, list)
+	require.NotEmpty(t, list.Request.URL.Query)
+	for _, q := range list.Request.URL.Query {
+		assert.True(t, q.Disabled, "optional query parameter %s should be disabled", q.Key)
+	}
+
+	// Path-level servers override the base path.
+	reconcile := findPostmanItem(&collection, http.MethodPost, "o2ims-infrastructureInventory", "v3", "reconcile")
+	require.NotNil(t, reconcile)
+	require.NotNil(t, reconcile.Request.Body)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(reconcile.Request.Body.Raw), &body))
+	assert.Contains(t, body, "resourcePools")
+
+	create := findPostmanItem(&collection, http.MethodPost, "o2ims-infrastructureInventory", "v1", "subscriptions")
+	require.NotNil(t, create)
+	require.NotNil(t, create.Request.Body)
+	assert.Contains(t, create.Request.Body.Raw, "callback")
+}
+
+func TestHandlePostmanCollection_NoSpec(t *testing.T) {
+	srv := createTestServer()
+	srv.Router().GET("/docs/postman.json", srv.HandlePostmanCollection)
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/postman.json", nil)
+	resp := httptest.NewRecorder()
+	srv.Router().ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
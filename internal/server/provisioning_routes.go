@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/provisioning"
+)
+
+// SetupProvisioning enables the O2-IMS infrastructureProvisioning API served
+// by mgr. When provisioning notification callbacks are configured, phase
+// changes are posted to them once StartProvisioning is called.
+func (s *Server) SetupProvisioning(mgr *provisioning.Manager) {
+	logger := observability.ModuleLogger(s.logger, observability.ModuleServer).Named("provisioning")
+
+	s.provisioning = mgr
+	if s.config != nil && len(s.config.Provisioning.Notifications.Callbacks) > 0 {
+		n := s.config.Provisioning.Notifications
+		s.provisioningNotifier = provisioning.NewWebhookNotifier(provisioning.WebhookConfig{
+			Callbacks:  n.Callbacks,
+			Timeout:    n.Timeout,
+			MaxRetries: n.MaxRetries,
+			HMACSecret: s.config.Notifications.HMACSecret,
+		}, logger.Named("notifications"))
+		mgr.SetNotifier(s.provisioningNotifier)
+	}
+
+	s.setupProvisioningRoutes(provisioning.NewHandler(mgr, logger))
+
+	logger.Info("infrastructure provisioning API initialized",
+		zap.Strings("provisioners", mgr.Provisioners()),
+		zap.Bool("notifications", s.provisioningNotifier != nil),
+	)
+}
+
+// StartProvisioning runs the provisioning reconcile loop and notification
+// delivery until ctx is canceled. It is a no-op when SetupProvisioning was not
+// called.
+func (s *Server) StartProvisioning(ctx context.Context) {
+	if s.provisioningNotifier != nil {
+		s.provisioningNotifier.Start(ctx)
+	}
+	if s.provisioning != nil {
+		s.provisioning.Start(ctx)
+	}
+}
+
+// setupProvisioningRoutes registers the O2-IMS infrastructureProvisioning API.
+// Provisioning creates infrastructure, so it is restricted to platform admins
+// when authentication is enabled.
+func (s *Server) setupProvisioningRoutes(handler *provisioning.Handler) {
+	group := s.router.Group("/o2ims-infrastructureProvisioning/v1")
+	if s.authMw != nil {
+		group.Use(s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin())
+	}
+
+	group.GET("/provisioningRequests", handler.ListProvisioningRequests)
+	group.POST("/provisioningRequests", handler.CreateProvisioningRequest)
+	group.GET("/provisioningRequests/:provisioningRequestId", handler.GetProvisioningRequest)
+	group.PUT("/provisioningRequests/:provisioningRequestId", handler.UpdateProvisioningRequest)
+	group.DELETE("/provisioningRequests/:provisioningRequestId", handler.DeleteProvisioningRequest)
+}
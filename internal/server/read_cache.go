@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/models"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// Object types held in the local read cache.
+const (
+	cacheTypeResourcePools = "resourcePools"
+	cacheTypeResources     = "resources"
+)
+
+// SetReadCache enables the local read cache for resource pool and resource
+// lookups by ID. When bus is non-nil, mutations are published so other replicas
+// drop their cached copies, and invalidations from other replicas are applied
+// until Shutdown; otherwise only this replica's cache is invalidated.
+func (s *Server) SetReadCache(cache *storage.LocalCache, bus *storage.CacheInvalidationBus) {
+	s.readCache = cache
+	s.cacheBus = bus
+	s.startCacheInvalidation()
+}
+
+// startCacheInvalidation starts applying invalidations published by other replicas.
+func (s *Server) startCacheInvalidation() {
+	if s.stopCacheBus != nil {
+		s.stopCacheBus()
+		s.stopCacheBus = nil
+	}
+	if s.cacheBus == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopCacheBus = cancel
+	go func() {
+		if err := s.cacheBus.Run(ctx); err != nil {
+			s.logger.Error("cache invalidation subscriber stopped; cached entries expire by TTL only",
+				zap.Error(err))
+		}
+	}()
+}
+
+// getResourcePool returns a resource pool, served from the read cache when enabled.
+// Callers own the returned pool: the cache keeps a copy of its own.
+func (s *Server) getResourcePool(ctx context.Context, id string) (*adapter.ResourcePool, error) {
+	if s.readCache != nil {
+		if pool, ok := s.readCache.Get(cacheTypeResourcePools, id); ok {
+			return copyResourcePool(pool.(*adapter.ResourcePool)), nil
+		}
+	}
+
+	pool, err := s.adapter.GetResourcePool(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.readCache != nil {
+		s.readCache.Set(cacheTypeResourcePools, id, copyResourcePool(pool))
+	}
+	return pool, nil
+}
+
+// getResource returns a resource, served from the read cache when enabled.
+// Callers own the returned resource: the cache keeps a copy of its own.
+func (s *Server) getResource(ctx context.Context, id string) (*adapter.Resource, error) {
+	if s.readCache != nil {
+		if resource, ok := s.readCache.Get(cacheTypeResources, id); ok {
+			return copyResource(resource.(*adapter.Resource)), nil
+		}
+	}
+
+	resource, err := s.adapter.GetResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.readCache != nil {
+		s.readCache.Set(cacheTypeResources, id, copyResource(resource))
+	}
+	return resource, nil
+}
+
+// copyResourcePool returns a deep copy of a resource pool.
+func copyResourcePool(pool *adapter.ResourcePool) *adapter.ResourcePool {
+	poolCopy := *pool
+	if pool.EstimatedCost != nil {
+		estimate := *pool.EstimatedCost
+		poolCopy.EstimatedCost = &estimate
+	}
+	poolCopy.Extensions = copyExtensions(pool.Extensions)
+	return &poolCopy
+}
+
+// copyResource returns a deep copy of a resource.
+func copyResource(resource *adapter.Resource) *adapter.Resource {
+	resourceCopy := *resource
+	resourceCopy.Extensions = copyExtensions(resource.Extensions)
+	return &resourceCopy
+}
+
+// copyExtensions returns a deep copy of an extensions map, keeping nil maps nil.
+func copyExtensions(extensions map[string]interface{}) map[string]interface{} {
+	if extensions == nil {
+		return nil
+	}
+	copied, _ := models.DeepCopyValue(extensions).(map[string]interface{})
+	return copied
+}
+
+// invalidateCache drops cached objects locally and, with a bus, on other replicas.
+// Publish failures are logged; other replicas fall back to the cache TTL.
+func (s *Server) invalidateCache(ctx context.Context, cacheType string, keys ...string) {
+	if s.cacheBus == nil {
+		s.readCache.Invalidate(cacheType, keys...)
+		return
+	}
+	if err := s.cacheBus.Publish(ctx, cacheType, keys...); err != nil {
+		s.logger.Warn("failed to publish cache invalidation",
+			zap.String("type", cacheType),
+			zap.Strings("keys", keys),
+			zap.Error(err))
+	}
+}
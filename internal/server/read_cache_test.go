@@ -0,0 +1,130 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// getCountingAdapter counts resource lookups that reach the adapter.
+type getCountingAdapter struct {
+	*mockResourceAdapter
+	gets atomic.Int32
+}
+
+func (m *getCountingAdapter) GetResource(ctx context.Context, id string) (*adapter.Resource, error) {
+	m.gets.Add(1)
+	return m.mockResourceAdapter.GetResource(ctx, id)
+}
+
+func TestReadCache_InvalidatedByMutations(t *testing.T) {
+	const resourcePath = "/o2ims-infrastructureInventory/v1/resources/550e8400-e29b-41d4-a716-446655440000"
+
+	adp := &getCountingAdapter{mockResourceAdapter: newMockResourceAdapter()}
+	srv := setupResourceTestServer(t, adp)
+	srv.SetReadCache(storage.NewLocalCache(time.Minute), nil)
+
+	getDescription := func() string {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, resourcePath, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var resource adapter.Resource
+		require.NoError(t, json.Unmarshal(body, &resource))
+		return resource.Description
+	}
+
+	// Repeated reads are served from the cache.
+	assert.Equal(t, "Test resource", getDescription())
+	assert.Equal(t, "Test resource", getDescription())
+	assert.Equal(t, int32(1), adp.gets.Load())
+
+	// An update invalidates the cached resource.
+	resp, _ := doResourceRequest(t, srv, http.MethodPut, resourcePath, adapter.Resource{Description: "Updated"})
+	require.Equal(t, http.StatusOK, resp.Code)
+	gets := adp.gets.Load()
+	assert.Equal(t, "Updated", getDescription())
+	assert.Equal(t, gets+1, adp.gets.Load())
+
+	// Failed mutations leave the cache alone.
+	resp, _ = doResourceRequest(t, srv, http.MethodPut,
+		"/o2ims-infrastructureInventory/v1/resources/nonexistent", adapter.Resource{Description: "x"})
+	require.Equal(t, http.StatusNotFound, resp.Code)
+	gets = adp.gets.Load()
+	assert.Equal(t, "Updated", getDescription())
+	assert.Equal(t, gets, adp.gets.Load())
+
+	// A delete invalidates the cached resource.
+	resp, _ = doResourceRequest(t, srv, http.MethodDelete, resourcePath, nil)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+	gets = adp.gets.Load()
+	getDescription()
+	assert.Equal(t, gets+1, adp.gets.Load())
+}
+
+func TestReadCache_InvalidatedByPublishedChanges(t *testing.T) {
+	const (
+		resourceID   = "550e8400-e29b-41d4-a716-446655440000"
+		resourcePath = "/o2ims-infrastructureInventory/v1/resources/" + resourceID
+	)
+
+	adp := &getCountingAdapter{mockResourceAdapter: newMockResourceAdapter()}
+	srv := setupResourceTestServer(t, adp)
+	srv.SetReadCache(storage.NewLocalCache(time.Minute), nil)
+
+	get := func() {
+		t.Helper()
+		resp, _ := doResourceRequest(t, srv, http.MethodGet, resourcePath, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+	}
+	get()
+	get()
+	require.Equal(t, int32(1), adp.gets.Load())
+
+	// A change detected outside the API, e.g. by a backend watcher, drops the
+	// cached resource once the cache invalidation consumer has handled it.
+	srv.PublishInventoryChange(server.InventoryChange{ObjectType: "resources", ObjectID: resourceID})
+	assert.Eventually(t, func() bool {
+		get()
+		return adp.gets.Load() > 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReadCache_KeepsOwnCopies(t *testing.T) {
+	const (
+		resourceID   = "550e8400-e29b-41d4-a716-446655440000"
+		resourcePath = "/o2ims-infrastructureInventory/v1/resources/" + resourceID
+	)
+
+	adp := &getCountingAdapter{mockResourceAdapter: newMockResourceAdapter()}
+	srv := setupResourceTestServer(t, adp)
+	srv.SetReadCache(storage.NewLocalCache(time.Minute), nil)
+
+	getDescription := func() string {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, resourcePath, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var resource adapter.Resource
+		require.NoError(t, json.Unmarshal(body, &resource))
+		return resource.Description
+	}
+	require.Equal(t, "Test resource", getDescription())
+
+	// Changing the object the adapter returned, as handlers do with the
+	// objects they are given, does not change the cached copy.
+	adp.mu.Lock()
+	adp.resources[resourceID].Description = "Changed in place"
+	adp.mu.Unlock()
+
+	assert.Equal(t, "Test resource", getDescription())
+	assert.Equal(t, int32(1), adp.gets.Load())
+}
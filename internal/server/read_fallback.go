@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/readfallback"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// storageRetryAfter is the Retry-After hint of requests rejected because
+// Redis is unavailable.
+const storageRetryAfter = 5 * time.Second
+
+// staleWarning is the Warning header of responses built from stale data
+// (RFC 7234 warn-code 110).
+const staleWarning = `110 - "Response is Stale"`
+
+// staleReadMiddleware marks responses built from objects the read fallback
+// cache served during a Redis outage: they carry a Warning header and an Age
+// header with the age in seconds of the oldest object served.
+func (s *Server) staleReadMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := readfallback.WithTracker(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		writer := &staleReadWriter{ResponseWriter: c.Writer, ctx: ctx}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+	}
+}
+
+// staleReadWriter adds the stale markers before the response header is written.
+type staleReadWriter struct {
+	gin.ResponseWriter
+	ctx context.Context
+}
+
+func (w *staleReadWriter) markStale() {
+	if w.Written() {
+		return
+	}
+	if age, stale := readfallback.StaleAge(w.ctx); stale {
+		w.Header().Set("Warning", staleWarning)
+		w.Header().Set("Age", strconv.Itoa(int(age.Seconds())))
+	}
+}
+
+func (w *staleReadWriter) WriteHeader(code int) {
+	w.markStale()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *staleReadWriter) WriteHeaderNow() {
+	w.markStale()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *staleReadWriter) Write(data []byte) (int, error) {
+	w.markStale()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *staleReadWriter) WriteString(data string) (int, error) {
+	w.markStale()
+	return w.ResponseWriter.WriteString(data)
+}
+
+// isStorageUnavailable reports whether err means Redis could not be reached.
+func isStorageUnavailable(err error) bool {
+	return errors.Is(err, storage.ErrStorageUnavailable) || errors.Is(err, auth.ErrStorageUnavailable)
+}
+
+// respondStorageUnavailable answers a request that needs Redis while it is
+// unavailable and the read fallback cache cannot answer it.
+func respondStorageUnavailable(c *gin.Context) {
+	middleware.RespondBackpressure(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+		"Storage is temporarily unavailable; retry later", middleware.Backpressure{RetryAfter: storageRetryAfter})
+}
+
+// subscriptionStoreWritable rejects a subscription write with 503 while Redis
+// is unavailable, before it has side effects on the adapter. A request that
+// already read stale data is rejected without contacting Redis again.
+func (s *Server) subscriptionStoreWritable(c *gin.Context) bool {
+	if s.store == nil {
+		return true
+	}
+	ctx := c.Request.Context()
+	if _, stale := readfallback.StaleAge(ctx); !stale {
+		if err := s.store.Ping(ctx); !isStorageUnavailable(err) {
+			return true
+		}
+	}
+	s.requestLogger(c).Warn("rejecting subscription write while storage is unavailable")
+	respondStorageUnavailable(c)
+	return false
+}
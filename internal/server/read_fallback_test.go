@@ -0,0 +1,79 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/readfallback"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestReadFallbackDuringRedisOutage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{
+		Addr:         mr.Addr(),
+		MaxRetries:   -1,
+		DialTimeout:  time.Second,
+		ReadTimeout:  time.Second,
+		WriteTimeout: time.Second,
+		PoolSize:     5,
+	})
+	t.Cleanup(func() { _ = store.Close() })
+	store.EnableReadFallback(readfallback.New("subscriptions", time.Minute, 100))
+	require.NoError(t, store.Create(context.Background(), &storage.Subscription{
+		ID: "sub-1", Callback: "https://smo.example.com/notify",
+	}))
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Redis: config.RedisConfig{ReadFallback: config.RedisReadFallbackConfig{
+			Enabled: true, MaxStaleness: time.Minute, MaxEntries: 100,
+		}},
+		Security: config.SecurityConfig{DisableSSRFProtection: true},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, store)
+
+	resp, _ := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("Warning"))
+
+	mr.Close()
+
+	t.Run("cached reads are served and marked stale", func(t *testing.T) {
+		resp, body := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath, nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		assert.Contains(t, string(body), "sub-1")
+		assert.Equal(t, `110 - "Response is Stale"`, resp.Header().Get("Warning"))
+		assert.NotEmpty(t, resp.Header().Get("Age"))
+
+		resp, _ = doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/sub-1", nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.NotEmpty(t, resp.Header().Get("Warning"))
+	})
+
+	t.Run("uncached reads fail with 503", func(t *testing.T) {
+		resp, _ := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/sub-2", nil)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		assert.Equal(t, "5", resp.Header().Get("Retry-After"))
+	})
+
+	t.Run("writes are rejected with 503", func(t *testing.T) {
+		resp, _ := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath,
+			map[string]string{"callback": "https://smo.example.com/other"})
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+		resp, _ = doResourceRequest(t, srv, http.MethodDelete, subscriptionsPath+"/sub-1", nil)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	})
+}
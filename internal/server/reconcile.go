@@ -0,0 +1,400 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Reconciliation modes.
+const (
+	// ReconcileModeFull treats the manifest as the complete expected inventory,
+	// so objects missing from it are reported as extra.
+	ReconcileModeFull = "full"
+
+	// ReconcileModePartial only checks the objects listed in the manifest.
+	ReconcileModePartial = "partial"
+)
+
+// Reconciliation object types.
+const (
+	reconcileObjectResourcePool = "resourcePool"
+	reconcileObjectResource     = "resource"
+)
+
+// maxReconcileItems caps the number of objects in a reconciliation manifest.
+const maxReconcileItems = 10000
+
+// ReconcileRequest is the SMO's expected inventory manifest.
+// POST /o2ims-infrastructureInventory/v3/reconcile.
+type ReconcileRequest struct {
+	// ResourcePools and Resources list the expected objects. Only the
+	// attributes present on each object are compared. A category that is
+	// omitted from the request is not reconciled.
+	ResourcePools []map[string]interface{} `json:"resourcePools"`
+	Resources     []map[string]interface{} `json:"resources"`
+
+	// Mode is "full" (default) or "partial".
+	Mode string `json:"mode,omitempty"`
+
+	// CreateTasks records a reconciliation task for every discrepancy.
+	CreateTasks bool `json:"createTasks,omitempty"`
+}
+
+// ReconcileReport is the categorized diff between the expected and actual inventory.
+type ReconcileReport struct {
+	ReconciliationID string           `json:"reconciliationId"`
+	GeneratedAt      time.Time        `json:"generatedAt"`
+	Mode             string           `json:"mode"`
+	Summary          ReconcileSummary `json:"summary"`
+	ResourcePools    *ReconcileDiff   `json:"resourcePools,omitempty"`
+	Resources        *ReconcileDiff   `json:"resources,omitempty"`
+
+	// Tasks are the reconciliation tasks created when createTasks was set.
+	Tasks []*storage.ReconciliationTask `json:"tasks,omitempty"`
+}
+
+// ReconcileSummary counts the reconciled objects by outcome.
+type ReconcileSummary struct {
+	Matched    int `json:"matched"`
+	Missing    int `json:"missing"`
+	Extra      int `json:"extra"`
+	Mismatched int `json:"mismatched"`
+}
+
+// ReconcileDiff lists the discrepancies of one object type.
+type ReconcileDiff struct {
+	// Missing are expected objects that do not exist.
+	Missing []string `json:"missing"`
+
+	// Extra are existing objects that are not expected. Always empty in partial mode.
+	Extra []string `json:"extra"`
+
+	// Mismatched are objects whose attributes differ from the expected values.
+	Mismatched []ReconcileMismatch `json:"mismatched"`
+}
+
+// ReconcileMismatch lists the attribute differences of one object.
+type ReconcileMismatch struct {
+	ID          string                        `json:"id"`
+	Differences []storage.AttributeDifference `json:"differences"`
+}
+
+// SetReconciliationStore enables createTasks on the reconcile endpoint.
+// Tasks must be visible to every replica serving the API.
+func (s *Server) SetReconciliationStore(store storage.ReconciliationStore) {
+	s.reconciliations = store
+}
+
+// validateReconcileRequest checks the mode and that every manifest object has a unique ID.
+func validateReconcileRequest(req *ReconcileRequest) error {
+	switch req.Mode {
+	case "":
+		req.Mode = ReconcileModeFull
+	case ReconcileModeFull, ReconcileModePartial:
+	default:
+		return fmt.Errorf("invalid mode %q (supported: %s, %s)", req.Mode, ReconcileModeFull, ReconcileModePartial)
+	}
+	if req.ResourcePools == nil && req.Resources == nil {
+		return errors.New("manifest must contain resourcePools or resources")
+	}
+	if len(req.ResourcePools)+len(req.Resources) > maxReconcileItems {
+		return fmt.Errorf("manifest exceeds %d objects", maxReconcileItems)
+	}
+	if err := validateManifestIDs(req.ResourcePools, "resourcePoolId"); err != nil {
+		return err
+	}
+	return validateManifestIDs(req.Resources, "resourceId")
+}
+
+func validateManifestIDs(objects []map[string]interface{}, idKey string) error {
+	seen := make(map[string]struct{}, len(objects))
+	for i, obj := range objects {
+		id, _ := obj[idKey].(string)
+		if id == "" {
+			return fmt.Errorf("%s is required (item %d)", idKey, i)
+		}
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("duplicate %s %q", idKey, id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// diffInventory compares expected objects with actual ones, both keyed by
+// ID. Only attributes present on an expected object are compared; extensions
+// are compared key by key.
+func diffInventory(
+	expected []map[string]interface{}, actual map[string]map[string]interface{}, idKey string, reportExtra bool,
+) (*ReconcileDiff, int) {
+	diff := &ReconcileDiff{
+		Missing:    []string{},
+		Extra:      []string{},
+		Mismatched: []ReconcileMismatch{},
+	}
+	matched := 0
+
+	expectedIDs := make(map[string]struct{}, len(expected))
+	for _, exp := range expected {
+		id, _ := exp[idKey].(string)
+		expectedIDs[id] = struct{}{}
+
+		act, ok := actual[id]
+		if !ok {
+			diff.Missing = append(diff.Missing, id)
+			continue
+		}
+		if differences := diffAttributes(exp, act, idKey); len(differences) > 0 {
+			diff.Mismatched = append(diff.Mismatched, ReconcileMismatch{ID: id, Differences: differences})
+		} else {
+			matched++
+		}
+	}
+
+	if reportExtra {
+		for id := range actual {
+			if _, ok := expectedIDs[id]; !ok {
+				diff.Extra = append(diff.Extra, id)
+			}
+		}
+		sort.Strings(diff.Extra)
+	}
+
+	return diff, matched
+}
+
+// diffAttributes returns the attributes of expected whose values differ in
+// actual, sorted by attribute name.
+func diffAttributes(expected, actual map[string]interface{}, idKey string) []storage.AttributeDifference {
+	var differences []storage.AttributeDifference
+	for attr, want := range expected {
+		if attr == idKey {
+			continue
+		}
+		if attr == "extensions" {
+			wantExt, _ := want.(map[string]interface{})
+			gotExt, _ := actual[attr].(map[string]interface{})
+			for key, wantValue := range wantExt {
+				if gotValue := gotExt[key]; !reflect.DeepEqual(wantValue, gotValue) {
+					differences = append(differences, storage.AttributeDifference{
+						Attribute: "extensions." + key,
+						Expected:  wantValue,
+						Actual:    gotValue,
+					})
+				}
+			}
+			continue
+		}
+		if got := actual[attr]; !reflect.DeepEqual(want, got) {
+			differences = append(differences, storage.AttributeDifference{Attribute: attr, Expected: want, Actual: got})
+		}
+	}
+	sort.Slice(differences, func(i, j int) bool { return differences[i].Attribute < differences[j].Attribute })
+	return differences
+}
+
+// inventoryByID converts objects to their JSON form keyed by idKey, so they
+// compare with manifest values decoded from JSON.
+func inventoryByID[T any](objects []*T, idKey string) (map[string]map[string]interface{}, error) {
+	byID := make(map[string]map[string]interface{}, len(objects))
+	for _, obj := range objects {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal inventory object: %w", err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inventory object: %w", err)
+		}
+		id, _ := fields[idKey].(string)
+		byID[id] = fields
+	}
+	return byID, nil
+}
+
+// reconcileTasks creates a pending task for every discrepancy in diff.
+func reconcileTasks(objectType string, diff *ReconcileDiff) []*storage.ReconciliationTask {
+	var tasks []*storage.ReconciliationTask
+	newTask := func(action, id string, differences []storage.AttributeDifference) {
+		tasks = append(tasks, &storage.ReconciliationTask{
+			TaskID:      uuid.New().String(),
+			Action:      action,
+			ObjectType:  objectType,
+			ObjectID:    id,
+			Differences: differences,
+			Status:      storage.ReconciliationTaskStatusPending,
+		})
+	}
+	for _, id := range diff.Missing {
+		newTask(storage.ReconciliationActionCreate, id, nil)
+	}
+	for _, m := range diff.Mismatched {
+		newTask(storage.ReconciliationActionUpdate, m.ID, m.Differences)
+	}
+	for _, id := range diff.Extra {
+		newTask(storage.ReconciliationActionDelete, id, nil)
+	}
+	return tasks
+}
+
+// reconcile builds the reconciliation report for req against the adapter inventory.
+func (s *Server) reconcile(ctx context.Context, req *ReconcileRequest, tenantID string) (*ReconcileReport, error) {
+	report := &ReconcileReport{
+		ReconciliationID: uuid.New().String(),
+		GeneratedAt:      timeutil.Now(),
+		Mode:             req.Mode,
+	}
+	reportExtra := req.Mode == ReconcileModeFull
+	filter := &adapter.Filter{TenantID: tenantID}
+
+	if req.ResourcePools != nil {
+		pools, err := s.adapter.ListResourcePools(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource pools: %w", err)
+		}
+		actual, err := inventoryByID(pools, "resourcePoolId")
+		if err != nil {
+			return nil, err
+		}
+		diff, matched := diffInventory(req.ResourcePools, actual, "resourcePoolId", reportExtra)
+		report.ResourcePools = diff
+		report.Summary.add(diff, matched)
+		if req.CreateTasks {
+			report.Tasks = append(report.Tasks, reconcileTasks(reconcileObjectResourcePool, diff)...)
+		}
+	}
+
+	if req.Resources != nil {
+		resources, err := s.adapter.ListResources(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+		actual, err := inventoryByID(resources, "resourceId")
+		if err != nil {
+			return nil, err
+		}
+		diff, matched := diffInventory(req.Resources, actual, "resourceId", reportExtra)
+		report.Resources = diff
+		report.Summary.add(diff, matched)
+		if req.CreateTasks {
+			report.Tasks = append(report.Tasks, reconcileTasks(reconcileObjectResource, diff)...)
+		}
+	}
+
+	return report, nil
+}
+
+func (sum *ReconcileSummary) add(diff *ReconcileDiff, matched int) {
+	sum.Matched += matched
+	sum.Missing += len(diff.Missing)
+	sum.Extra += len(diff.Extra)
+	sum.Mismatched += len(diff.Mismatched)
+}
+
+// handleReconcile compares an expected inventory manifest with the actual
+// inventory and returns the discrepancies, optionally recording
+// reconciliation tasks for them.
+// POST /o2ims-infrastructureInventory/v3/reconcile.
+func (s *Server) handleReconcile(c *gin.Context) {
+	var req ReconcileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+	if err := validateReconcileRequest(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+	if req.CreateTasks && s.reconciliations == nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "reconciliation tasks are not enabled")
+		return
+	}
+
+	ctx := c.Request.Context()
+	tenantID := reconcileTenantID(ctx)
+
+	report, err := s.reconcile(ctx, &req, tenantID)
+	if err != nil {
+		s.requestLogger(c).Error("failed to reconcile inventory", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to reconcile inventory")
+		return
+	}
+
+	if req.CreateTasks {
+		if report.Tasks == nil {
+			report.Tasks = []*storage.ReconciliationTask{}
+		}
+		err := s.reconciliations.Save(ctx, &storage.Reconciliation{
+			ID:        report.ReconciliationID,
+			TenantID:  tenantID,
+			CreatedAt: report.GeneratedAt,
+			Tasks:     report.Tasks,
+		})
+		if err != nil {
+			s.requestLogger(c).Error("failed to save reconciliation tasks", zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to save reconciliation tasks")
+			return
+		}
+	}
+
+	s.requestLogger(c).Info("inventory reconciled",
+		zap.String("reconciliation_id", report.ReconciliationID),
+		zap.String("mode", report.Mode),
+		zap.Int("missing", report.Summary.Missing),
+		zap.Int("extra", report.Summary.Extra),
+		zap.Int("mismatched", report.Summary.Mismatched),
+		zap.Int("tasks", len(report.Tasks)))
+
+	c.JSON(http.StatusOK, report)
+}
+
+// handleGetReconciliationTasks returns the tasks recorded by a reconciliation.
+// GET /o2ims-infrastructureInventory/v3/reconcile/:reconciliationId/tasks.
+func (s *Server) handleGetReconciliationTasks(c *gin.Context) {
+	if s.reconciliations == nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "reconciliation tasks are not enabled")
+		return
+	}
+
+	ctx := c.Request.Context()
+	reconciliation, err := s.reconciliations.Load(ctx, c.Param("reconciliationId"))
+	if err == nil && reconciliation.TenantID != reconcileTenantID(ctx) {
+		err = storage.ErrReconciliationNotFound
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrReconciliationNotFound) {
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Reconciliation not found or expired")
+			return
+		}
+		s.requestLogger(c).Error("failed to load reconciliation tasks", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve reconciliation tasks")
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliation)
+}
+
+// reconcileTenantID returns the tenant whose inventory is reconciled. Platform
+// admins reconcile the whole inventory.
+func reconcileTenantID(ctx context.Context) string {
+	if auth.IsPlatformAdminFromContext(ctx) {
+		return ""
+	}
+	return auth.TenantIDFromContext(ctx)
+}
@@ -0,0 +1,175 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const reconcilePath = "/o2ims-infrastructureInventory/v3/reconcile"
+
+// inventoryAdapter serves a fixed resource pool and resource inventory.
+type inventoryAdapter struct {
+	mockAdapter
+	pools     []*adapter.ResourcePool
+	resources []*adapter.Resource
+}
+
+func (m *inventoryAdapter) ListResourcePools(_ context.Context, _ *adapter.Filter) ([]*adapter.ResourcePool, error) {
+	return m.pools, nil
+}
+
+func (m *inventoryAdapter) ListResources(_ context.Context, _ *adapter.Filter) ([]*adapter.Resource, error) {
+	return m.resources, nil
+}
+
+func newInventoryAdapter() *inventoryAdapter {
+	return &inventoryAdapter{
+		pools: []*adapter.ResourcePool{
+			{ResourcePoolID: "pool-1", Name: "edge", Location: "dc-1", OCloudID: "ocloud-1"},
+			{ResourcePoolID: "pool-2", Name: "core", OCloudID: "ocloud-1"},
+		},
+		resources: []*adapter.Resource{
+			{
+				ResourceID: "res-1", ResourceTypeID: "compute", ResourcePoolID: "pool-1",
+				Extensions: map[string]interface{}{"cpu": 32, "rack": "r1"},
+			},
+			{ResourceID: "res-2", ResourceTypeID: "compute", ResourcePoolID: "pool-1"},
+			{ResourceID: "res-3", ResourceTypeID: "storage", ResourcePoolID: "pool-2"},
+		},
+	}
+}
+
+func TestReconcile_Report(t *testing.T) {
+	srv := setupResourceTestServer(t, newInventoryAdapter())
+
+	resp, body := doResourceRequest(t, srv, http.MethodPost, reconcilePath, map[string]interface{}{
+		"resourcePools": []map[string]interface{}{
+			{"resourcePoolId": "pool-1", "name": "edge", "location": "dc-2"},
+			{"resourcePoolId": "pool-9"},
+		},
+		"resources": []map[string]interface{}{
+			{"resourceId": "res-1", "resourceTypeId": "compute", "extensions": map[string]interface{}{"cpu": 64, "rack": "r1"}},
+			{"resourceId": "res-2", "resourcePoolId": "pool-1"},
+		},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var report server.ReconcileReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.NotEmpty(t, report.ReconciliationID)
+	assert.Equal(t, server.ReconcileModeFull, report.Mode)
+	assert.Equal(t, server.ReconcileSummary{Matched: 1, Missing: 1, Extra: 2, Mismatched: 2}, report.Summary)
+	assert.Empty(t, report.Tasks)
+
+	require.NotNil(t, report.ResourcePools)
+	assert.Equal(t, []string{"pool-9"}, report.ResourcePools.Missing)
+	assert.Equal(t, []string{"pool-2"}, report.ResourcePools.Extra)
+	require.Len(t, report.ResourcePools.Mismatched, 1)
+	assert.Equal(t, "pool-1", report.ResourcePools.Mismatched[0].ID)
+	assert.Equal(t, []storage.AttributeDifference{
+		{Attribute: "location", Expected: "dc-2", Actual: "dc-1"},
+	}, report.ResourcePools.Mismatched[0].Differences)
+
+	require.NotNil(t, report.Resources)
+	assert.Empty(t, report.Resources.Missing)
+	assert.Equal(t, []string{"res-3"}, report.Resources.Extra)
+	require.Len(t, report.Resources.Mismatched, 1)
+	assert.Equal(t, []storage.AttributeDifference{
+		{Attribute: "extensions.cpu", Expected: float64(64), Actual: float64(32)},
+	}, report.Resources.Mismatched[0].Differences)
+}
+
+func TestReconcile_PartialModeSkipsExtraAndOmittedCategories(t *testing.T) {
+	srv := setupResourceTestServer(t, newInventoryAdapter())
+
+	resp, body := doResourceRequest(t, srv, http.MethodPost, reconcilePath, map[string]interface{}{
+		"mode":      "partial",
+		"resources": []map[string]interface{}{{"resourceId": "res-3", "resourceTypeId": "storage"}},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var report server.ReconcileReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.Equal(t, server.ReconcileSummary{Matched: 1}, report.Summary)
+	assert.Nil(t, report.ResourcePools)
+	require.NotNil(t, report.Resources)
+	assert.Empty(t, report.Resources.Extra)
+}
+
+func TestReconcile_CreateTasks(t *testing.T) {
+	srv := setupResourceTestServer(t, newInventoryAdapter())
+
+	manifest := map[string]interface{}{
+		"createTasks": true,
+		"resourcePools": []map[string]interface{}{
+			{"resourcePoolId": "pool-1", "name": "edge-renamed"},
+			{"resourcePoolId": "pool-2"},
+			{"resourcePoolId": "pool-3"},
+		},
+	}
+
+	// Tasks require a reconciliation store.
+	resp, _ := doResourceRequest(t, srv, http.MethodPost, reconcilePath, manifest)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	srv.SetReconciliationStore(storage.NewInMemoryReconciliationStore(time.Minute))
+	resp, body := doResourceRequest(t, srv, http.MethodPost, reconcilePath, manifest)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var report server.ReconcileReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	require.Len(t, report.Tasks, 2)
+	assert.Equal(t, storage.ReconciliationActionCreate, report.Tasks[0].Action)
+	assert.Equal(t, "pool-3", report.Tasks[0].ObjectID)
+	assert.Equal(t, storage.ReconciliationActionUpdate, report.Tasks[1].Action)
+	assert.Equal(t, "pool-1", report.Tasks[1].ObjectID)
+	assert.Equal(t, "resourcePool", report.Tasks[1].ObjectType)
+	assert.Equal(t, storage.ReconciliationTaskStatusPending, report.Tasks[1].Status)
+
+	resp, body = doResourceRequest(t, srv, http.MethodGet,
+		reconcilePath+"/"+report.ReconciliationID+"/tasks", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var rec storage.Reconciliation
+	require.NoError(t, json.Unmarshal(body, &rec))
+	assert.Equal(t, report.ReconciliationID, rec.ID)
+	require.Len(t, rec.Tasks, 2)
+	assert.Equal(t, report.Tasks[0].TaskID, rec.Tasks[0].TaskID)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, reconcilePath+"/unknown/tasks", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestReconcile_InvalidManifest(t *testing.T) {
+	srv := setupResourceTestServer(t, newInventoryAdapter())
+
+	tests := []struct {
+		name string
+		body interface{}
+	}{
+		{name: "empty manifest", body: map[string]interface{}{}},
+		{name: "invalid mode", body: map[string]interface{}{"mode": "merge", "resources": []interface{}{}}},
+		{name: "missing ID", body: map[string]interface{}{
+			"resources": []map[string]interface{}{{"description": "no id"}},
+		}},
+		{name: "duplicate ID", body: map[string]interface{}{
+			"resourcePools": []map[string]interface{}{{"resourcePoolId": "pool-1"}, {"resourcePoolId": "pool-1"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := doResourceRequest(t, srv, http.MethodPost, reconcilePath, tt.body)
+			assert.Equal(t, http.StatusBadRequest, resp.Code, string(body))
+		})
+	}
+}
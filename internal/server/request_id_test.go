@@ -0,0 +1,48 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestLoggingMiddleware_IncludesRequestIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	core, logs := observer.New(zap.InfoLevel)
+	router := gin.New()
+	srv := server.NewTestServerWithRouter(router, zap.New(core))
+	router.Use(srv.RecoveryMiddleware(), middleware.RequestID(), srv.LoggingMiddleware())
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/panic", func(*gin.Context) { panic("boom") })
+
+	for _, path := range []string{"/ok", "/panic"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(middleware.RequestIDHeader, "req-"+path[1:])
+		req.Header.Set(middleware.CorrelationIDHeader, "op-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, "req-"+path[1:], w.Header().Get(middleware.RequestIDHeader))
+	}
+
+	// Every log line of a request carries its IDs, including the panic log.
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		assert.Equal(t, "op-1", fields["correlation_id"], entry.Message)
+		assert.NotEmpty(t, fields["request_id"], entry.Message)
+	}
+	assert.Equal(t, "req-ok", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, "panic recovered", entries[1].Message)
+	assert.Equal(t, "req-panic", entries[1].ContextMap()["request_id"])
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// resourceIndexRescanInterval is how long index misses are trusted after a
+// full scan of a tenant's resources. The scan indexed every resource, so
+// unknown identifiers are answered as not found until it expires, rather
+// than each one triggering another scan. Resources discovered by the backend
+// in the meantime can be missed for that long; resources created through the
+// API are indexed right away.
+const resourceIndexRescanInterval = 30 * time.Second
+
+// indexScans records when the resources of each tenant were last scanned to
+// repair the resource index.
+type indexScans struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// recent reports whether the resources of tenantID were scanned within
+// resourceIndexRescanInterval.
+func (r *indexScans) recent(tenantID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last, ok := r.last[tenantID]
+	return ok && time.Since(last) < resourceIndexRescanInterval
+}
+
+// record notes a scan of the resources of tenantID and forgets expired scans.
+func (r *indexScans) record(tenantID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.last == nil {
+		r.last = make(map[string]time.Time)
+	}
+	for tenant, last := range r.last {
+		if now.Sub(last) >= resourceIndexRescanInterval {
+			delete(r.last, tenant)
+		}
+	}
+	r.last[tenantID] = now
+}
+
+// SetResourceIndex sets the secondary index used to look up resources by
+// globalAssetId and serial number. Without an index those lookups scan all resources.
+func (s *Server) SetResourceIndex(index storage.ResourceIndex) {
+	s.resourceIndex = index
+}
+
+// identifierQuery returns the first indexed identifier present in the request query.
+func identifierQuery(get func(string) string) (string, string, bool) {
+	for _, key := range storage.IndexedResourceKeys {
+		if value := get(key); value != "" {
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// findResourcesByIdentifier returns the resources whose identifier key equals value.
+// The index is consulted first; on a miss or a stale entry it falls back to
+// scanning all resources and repairs the index with what it finds. Misses
+// shortly after a scan are not scanned again; see resourceIndexRescanInterval.
+func (s *Server) findResourcesByIdentifier(
+	ctx context.Context,
+	key, value, tenantID string,
+) ([]*adapter.Resource, error) {
+	if s.resourceIndex != nil {
+		id, err := s.resourceIndex.Lookup(ctx, key, value)
+		switch {
+		case err == nil:
+			resource, getErr := s.adapter.GetResource(ctx, id)
+			if getErr == nil && resourceMatches(resource, key, value, tenantID) {
+				return []*adapter.Resource{resource}, nil
+			}
+			if getErr != nil && !errors.Is(getErr, adapter.ErrResourceNotFound) {
+				return nil, getErr
+			}
+			s.unindexResource(ctx, id)
+		case errors.Is(err, storage.ErrResourceIndexMiss):
+			if s.indexScans.recent(tenantID) {
+				return []*adapter.Resource{}, nil
+			}
+		default:
+			s.logger.Warn("resource index lookup failed, scanning resources", zap.Error(err))
+		}
+	}
+
+	resources, err := s.adapter.ListResources(ctx, &adapter.Filter{TenantID: tenantID})
+	if err != nil {
+		return nil, err
+	}
+	s.indexResources(ctx, resources...)
+	if s.resourceIndex != nil {
+		s.indexScans.record(tenantID)
+	}
+
+	matches := make([]*adapter.Resource, 0, 1)
+	for _, resource := range resources {
+		if resourceMatches(resource, key, value, tenantID) {
+			matches = append(matches, resource)
+		}
+	}
+	return matches, nil
+}
+
+// resourceMatches reports whether a resource has the given identifier and is
+// visible to the tenant.
+func resourceMatches(resource *adapter.Resource, key, value, tenantID string) bool {
+	if resource == nil {
+		return false
+	}
+	if tenantID != "" && resource.TenantID != "" && resource.TenantID != tenantID {
+		return false
+	}
+	return storage.ResourceIdentifiers(resource.GlobalAssetID, resource.Extensions)[key] == value
+}
+
+// indexResources records resource identifiers in the index. Index failures are
+// logged and otherwise ignored; the index is only an optimization.
+func (s *Server) indexResources(ctx context.Context, resources ...*adapter.Resource) {
+	if s.resourceIndex == nil || len(resources) == 0 {
+		return
+	}
+
+	entries := make([]storage.ResourceIndexEntry, 0, len(resources))
+	for _, resource := range resources {
+		if resource == nil || resource.ResourceID == "" {
+			continue
+		}
+		entries = append(entries, storage.ResourceIndexEntry{
+			ResourceID:  resource.ResourceID,
+			Identifiers: storage.ResourceIdentifiers(resource.GlobalAssetID, resource.Extensions),
+		})
+	}
+
+	if err := s.resourceIndex.Index(ctx, entries...); err != nil {
+		s.logger.Warn("failed to update resource index", zap.Error(err))
+	}
+}
+
+// unindexResource drops a resource from the index.
+func (s *Server) unindexResource(ctx context.Context, resourceID string) {
+	if s.resourceIndex == nil {
+		return
+	}
+	if err := s.resourceIndex.Remove(ctx, resourceID); err != nil {
+		s.logger.Warn("failed to remove resource from index",
+			zap.String("resource_id", resourceID), zap.Error(err))
+	}
+}
@@ -0,0 +1,110 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// scanCountingAdapter counts full resource scans.
+type scanCountingAdapter struct {
+	*mockResourceAdapter
+	scans atomic.Int32
+}
+
+func (m *scanCountingAdapter) ListResources(_ context.Context, _ *adapter.Filter) ([]*adapter.Resource, error) {
+	m.scans.Add(1)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resources := make([]*adapter.Resource, 0, len(m.resources))
+	for _, r := range m.resources {
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func TestListResources_ByGlobalAssetID(t *testing.T) {
+	adp := &scanCountingAdapter{mockResourceAdapter: newMockResourceAdapter()}
+	srv := setupResourceTestServer(t, adp)
+	srv.SetResourceIndex(storage.NewInMemoryResourceIndex())
+
+	lookup := func(query string) []adapter.Resource {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, "/o2ims-infrastructureInventory/v1/resources?"+query, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var result struct {
+			Resources []adapter.Resource `json:"resources"`
+			Total     int                `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(body, &result))
+		assert.Len(t, result.Resources, result.Total)
+		return result.Resources
+	}
+
+	// First lookup misses the index, scans, and populates it.
+	found := lookup("globalAssetId=urn:test:asset:123")
+	require.Len(t, found, 1)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", found[0].ResourceID)
+	assert.Equal(t, int32(1), adp.scans.Load())
+
+	// Second lookup is served from the index.
+	found = lookup("globalAssetId=urn:test:asset:123")
+	require.Len(t, found, 1)
+	assert.Equal(t, int32(1), adp.scans.Load())
+
+	// Created resources are indexed immediately, including serial numbers.
+	created, resp := createTestResource(t, srv, adapter.Resource{
+		ResourceTypeID: "machine",
+		ResourcePoolID: "pool-1",
+		GlobalAssetID:  "urn:test:asset:456",
+		Extensions:     map[string]interface{}{"serialNumber": "SN-456"},
+	})
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	found = lookup("serialNumber=SN-456")
+	require.Len(t, found, 1)
+	assert.Equal(t, created.ResourceID, found[0].ResourceID)
+	assert.Equal(t, int32(1), adp.scans.Load())
+
+	// Unknown identifiers return an empty list.
+	assert.Empty(t, lookup("globalAssetId=urn:test:asset:missing"))
+	assert.Equal(t, int32(1), adp.scans.Load())
+}
+
+func TestListResources_ByGlobalAssetIDMissesDoNotRescan(t *testing.T) {
+	adp := &scanCountingAdapter{mockResourceAdapter: newMockResourceAdapter()}
+	srv := setupResourceTestServer(t, adp)
+	srv.SetResourceIndex(storage.NewInMemoryResourceIndex())
+
+	// The first miss scans and indexes every resource; later misses trust the
+	// index instead of scanning again.
+	for i := range 5 {
+		resp, body := doResourceRequest(t, srv, http.MethodGet,
+			fmt.Sprintf("/o2ims-infrastructureInventory/v1/resources?globalAssetId=urn:test:asset:missing-%d", i), nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, string(body), `"total":0`)
+	}
+	assert.Equal(t, int32(1), adp.scans.Load())
+}
+
+func TestListResources_ByGlobalAssetIDWithoutIndex(t *testing.T) {
+	adp := &scanCountingAdapter{mockResourceAdapter: newMockResourceAdapter()}
+	srv := setupResourceTestServer(t, adp)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet,
+		"/o2ims-infrastructureInventory/v1/resources?globalAssetId=urn:test:asset:123", nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, string(body), `"total":1`)
+	assert.Equal(t, int32(1), adp.scans.Load())
+}
@@ -5,21 +5,31 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
 	"github.com/piwi3910/netweave/internal/adapter"
 	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/cost"
+	"github.com/piwi3910/netweave/internal/hooks"
+	"github.com/piwi3910/netweave/internal/httperror"
+	"github.com/piwi3910/netweave/internal/middleware"
 	"github.com/piwi3910/netweave/internal/models"
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+	"github.com/piwi3910/netweave/internal/resolver"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/validation"
 )
 
 // withPermission wraps a handler with permission-based authorization.
@@ -63,33 +73,201 @@ func (s *Server) setupRoutes() {
 	}
 
 	// Initialize version configuration
-	versionConfig := NewVersionConfig()
+	if s.versionConfig == nil {
+		s.versionConfig = NewVersionConfig()
+	}
 
-	// O2-IMS API v1 routes (O-RAN compliant)
-	// Base path: /o2ims-infrastructureInventory/v1 (per O-RAN O2 IMS specification)
-	// Includes all features: basic operations, batch operations, and multi-tenancy support
-	v1 := s.router.Group("/o2ims-infrastructureInventory/v1")
-	v1.Use(VersioningMiddleware(versionConfig))
+	// Initialize API version adoption tracking
+	if s.versionAdoption == nil {
+		s.versionAdoption = NewVersionAdoptionTracker(
+			s.config.Observability.VersionAdoption.RetentionHours,
+			s.config.Observability.VersionAdoption.DeprecatedWarnThreshold,
+			s.logger,
+		)
+	}
 
-	// Apply tenant middleware if multi-tenancy is enabled
-	if s.tenantHandler != nil {
-		v1.Use(TenantMiddleware())
+	// Initialize long-poll change notification for list endpoints
+	if s.listChanges == nil {
+		s.listChanges = newListChangeNotifier()
 	}
 
-	s.setupV1Routes(v1)
+	// Initialize the event bus delivering inventory changes to consumers
+	s.setupEventBus()
+
+	// Initialize callback egress target tracking
+	if s.egressTargets == nil {
+		s.egressTargets = NewEgressTargetTracker(nil, s.logger)
+	}
+
+	// O2-IMS API routes, unless the gateway runs without an IMS adapter (dms-only mode)
+	if s.adapter != nil {
+		s.setupIMSRoutes()
+	}
 
 	// TMForum API routes (handler will be set when DMS is initialized)
 	s.setupTMForumRoutesEarly()
 
+	// API version adoption report (platform admin only when auth is configured)
+	if s.authMw != nil {
+		s.router.GET("/admin/versions/adoption",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleVersionAdoptionReport)
+	} else {
+		s.router.GET("/admin/versions/adoption", s.handleVersionAdoptionReport)
+	}
+
+	// Redacted configuration and its snapshot history (platform admin only when auth is configured)
+	if s.authMw != nil {
+		s.router.GET("/admin/config",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleGetConfig)
+		s.router.GET("/admin/config/history",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleConfigHistory)
+	} else {
+		s.router.GET("/admin/config", s.handleGetConfig)
+		s.router.GET("/admin/config/history", s.handleConfigHistory)
+	}
+
+	// Instance stats and support bundle, redacted like the configuration
+	// (platform admin only when auth is configured)
+	if s.authMw != nil {
+		s.router.GET("/admin/stats",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleAdminStats)
+		s.router.GET("/admin/support-bundle",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleSupportBundle)
+	} else {
+		s.router.GET("/admin/stats", s.handleAdminStats)
+		s.router.GET("/admin/support-bundle", s.handleSupportBundle)
+	}
+
+	// Staged rollout of runtime settings (platform admin only when auth is configured)
+	rolloutGroup := s.router.Group("/admin/config/rollout")
+	if s.authMw != nil {
+		rolloutGroup.Use(s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin())
+	}
+	rolloutGroup.GET("", s.handleGetRollout)
+	rolloutGroup.POST("", s.handleStartRollout)
+	rolloutGroup.POST("/promote", s.handlePromoteRollout)
+	rolloutGroup.POST("/rollback", s.handleRollbackRollout)
+
+	// Outbound notification targets for firewall rules (platform admin only when auth is configured)
+	if s.authMw != nil {
+		s.router.GET("/admin/egress-targets",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleEgressTargets)
+	} else {
+		s.router.GET("/admin/egress-targets", s.handleEgressTargets)
+	}
+
+	// Duplicate subscription report (platform admin only when auth is configured)
+	if s.authMw != nil {
+		s.router.GET("/admin/subscriptions/duplicates",
+			s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin(), s.handleSubscriptionDuplicates)
+	} else {
+		s.router.GET("/admin/subscriptions/duplicates", s.handleSubscriptionDuplicates)
+	}
+
+	// Subscriptions suspended for disallowed callbacks (platform admin only when auth is configured)
+	suspensionsGroup := s.router.Group("/admin/subscriptions")
+	if s.authMw != nil {
+		suspensionsGroup.Use(s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin())
+	}
+	suspensionsGroup.GET("/suspended", s.handleSuspendedSubscriptions)
+	suspensionsGroup.POST("/revalidate", s.handleRevalidateCallbacks)
+	suspensionsGroup.GET("/deliverability", s.handleSubscriptionDeliverability)
+
+	// Webhook signing secret rotation (platform admin only when auth is configured)
+	signingKeysGroup := s.router.Group("/admin/webhooks/signing-keys")
+	if s.authMw != nil {
+		signingKeysGroup.Use(s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin())
+	}
+	signingKeysGroup.GET("", s.handleGetSigningKeys)
+	signingKeysGroup.POST("/rotate", s.handleRotateSigningKey)
+
+	// Webhook CA certificate and revocation list (public, for notification endpoints)
+	s.router.GET("/webhooks/ca.pem", s.handleWebhookCACertificate)
+	s.router.GET("/webhooks/crl.pem", s.handleWebhookCRL)
+
+	// Runtime log levels (platform admin only when auth is configured)
+	logLevelGroup := s.router.Group("/admin/loglevel")
+	if s.authMw != nil {
+		logLevelGroup.Use(s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin())
+	}
+	logLevelGroup.GET("", s.handleGetLogLevel)
+	logLevelGroup.PUT("", s.handleSetLogLevel)
+
+	// Debug taps capturing request and response bodies (platform admin only when auth is configured)
+	if s.debugTaps != nil {
+		debugTapGroup := s.router.Group(debugTapAdminPrefix + "/taps")
+		if s.authMw != nil {
+			debugTapGroup.Use(s.authMw.AuthenticationMiddleware(), s.authMw.RequirePlatformAdmin())
+		}
+		debugTapGroup.GET("", s.handleListDebugTaps)
+		debugTapGroup.POST("", s.handleStartDebugTap)
+		debugTapGroup.GET("/:tapId", s.handleGetDebugTap)
+		debugTapGroup.DELETE("/:tapId", s.handleStopDebugTap)
+	}
+
 	// API information endpoint
-	s.router.GET("/o2ims", s.handleAPIInfo)
+	if s.adapter != nil {
+		s.router.GET("/o2ims", s.handleAPIInfo)
+	}
 	s.router.GET("/", s.handleRoot)
 
 	// Documentation endpoints (Swagger UI, OpenAPI spec)
 	s.SetupDocsRoutes()
 
-	// GraphQL API endpoint
-	s.setupGraphQLRoutes()
+	// GraphQL API endpoint (queries inventory through the IMS adapter)
+	if s.adapter != nil {
+		s.setupGraphQLRoutes()
+	}
+}
+
+// setupIMSRoutes configures the O2-IMS API v1 and v3 route groups.
+func (s *Server) setupIMSRoutes() {
+	// O2-IMS API v1 routes (O-RAN compliant)
+	// Base path: /o2ims-infrastructureInventory/v1 (per O-RAN O2 IMS specification)
+	// Includes all features: basic operations, batch operations, and multi-tenancy support
+	v1 := s.router.Group("/o2ims-infrastructureInventory/v1")
+	v1.Use(VersioningMiddleware(s.versionConfig))
+	v1.Use(VersionAdoptionMiddleware(s.versionAdoption))
+	v1.Use(s.InventoryChangeMiddleware())
+
+	// Authenticate API requests according to the auth policy matrix
+	if s.authMw != nil {
+		v1.Use(s.authMw.AuthenticationMiddleware())
+	}
+
+	// Apply tenant middleware if multi-tenancy is enabled
+	if s.tenantHandler != nil {
+		v1.Use(TenantMiddleware(s.tenantGetter()))
+	}
+
+	s.setupV1Routes(v1)
+
+	// O2-IMS API v3 routes: inventory reconciliation for SMOs
+	// Base path: /o2ims-infrastructureInventory/v3
+	v3 := s.router.Group("/o2ims-infrastructureInventory/v3")
+	v3.Use(VersioningMiddleware(s.versionConfig))
+	v3.Use(VersionAdoptionMiddleware(s.versionAdoption))
+	v3.Use(s.InventoryChangeMiddleware())
+	if s.authMw != nil {
+		v3.Use(s.authMw.AuthenticationMiddleware())
+	}
+	if s.tenantHandler != nil {
+		v3.Use(TenantMiddleware(s.tenantGetter()))
+	}
+
+	s.setupV3Routes(v3)
+}
+
+// setupV3Routes configures the O2-IMS API v3 endpoints.
+func (s *Server) setupV3Routes(v3 *gin.RouterGroup) {
+	// Differential sync of the SMO's expected inventory
+	// Endpoint: /reconcile
+	reconcile := v3.Group("/reconcile")
+	{
+		// Reconciling records tasks to change the inventory, so it needs write access
+		reconcile.POST("", s.withPermission("resources:update", s.handleReconcile))
+		reconcile.GET("/:reconciliationId/tasks", s.withPermission("resources:read", s.handleGetReconciliationTasks))
+	}
 }
 
 // setupV1Routes configures the O2-IMS API v1 endpoints.
@@ -98,33 +276,59 @@ func (s *Server) setupV1Routes(v1 *gin.RouterGroup) {
 	// Endpoint: /subscriptions
 	subscriptions := v1.Group("/subscriptions")
 	{
-		subscriptions.GET("", s.withPermission("subscriptions:read", s.handleListSubscriptions))
-		subscriptions.POST("", s.withPermission("subscriptions:create", s.handleCreateSubscription))
-		subscriptions.GET("/:subscriptionId", s.withPermission("subscriptions:read", s.handleGetSubscription))
-		subscriptions.PUT("/:subscriptionId", s.withPermission("subscriptions:create", s.handleUpdateSubscription))
+		subscriptions.GET("", s.withPermission("subscriptions:read",
+			s.withStrictSpec(config.StrictSpecKindSubscription, "subscriptions", s.handleListSubscriptions)))
+		subscriptions.POST("", s.withPermission("subscriptions:create",
+			s.withStrictSpec(config.StrictSpecKindSubscription, "", s.handleCreateSubscription)))
+		subscriptions.GET("/:subscriptionId", s.withPermission("subscriptions:read",
+			s.withStrictSpec(config.StrictSpecKindSubscription, "", s.handleGetSubscription)))
+		subscriptions.PUT("/:subscriptionId", s.withPermission("subscriptions:create",
+			s.withStrictSpec(config.StrictSpecKindSubscription, "", s.handleUpdateSubscription)))
 		subscriptions.DELETE("/:subscriptionId", s.withPermission("subscriptions:delete", s.handleDeleteSubscription))
+
+		// Webhook mTLS certificates issued by the webhook CA
+		subscriptions.GET("/:subscriptionId/certificates",
+			s.withPermission("subscriptions:read", s.handleListCertificates))
+		subscriptions.POST("/:subscriptionId/certificates",
+			s.withPermission("subscriptions:create", s.handleEnrollCertificate))
+		subscriptions.POST("/:subscriptionId/certificates/:serialNumber/rotate",
+			s.withPermission("subscriptions:create", s.handleRotateCertificate))
+		subscriptions.POST("/:subscriptionId/certificates/:serialNumber/revoke",
+			s.withPermission("subscriptions:delete", s.handleRevokeCertificate))
 	}
 
 	// Resource Pool Management
 	// Endpoint: /resourcePools
 	resourcePools := v1.Group("/resourcePools")
 	{
-		resourcePools.GET("", s.withPermission("resourcePools:read", s.handleListResourcePools))
-		resourcePools.POST("", s.withPermission("resourcePools:create", s.handleCreateResourcePool))
-		resourcePools.GET("/:resourcePoolId", s.withPermission("resourcePools:read", s.handleGetResourcePool))
-		resourcePools.PUT("/:resourcePoolId", s.withPermission("resourcePools:update", s.handleUpdateResourcePool))
+		resourcePools.GET("", s.withPermission("resourcePools:read", s.withExtensionsLimit("resourcePools",
+			s.withFieldSelection("resourcePools",
+				s.withStrictSpec(config.StrictSpecKindResourcePool, "resourcePools", s.handleListResourcePools)))))
+		resourcePools.POST("", s.withPermission("resourcePools:create",
+			s.withStrictSpec(config.StrictSpecKindResourcePool, "", s.handleCreateResourcePool)))
+		resourcePools.GET("/:resourcePoolId", s.withPermission("resourcePools:read", s.withFieldSelection("",
+			s.withStrictSpec(config.StrictSpecKindResourcePool, "", s.handleGetResourcePool))))
+		resourcePools.PUT("/:resourcePoolId", s.withPermission("resourcePools:update",
+			s.withStrictSpec(config.StrictSpecKindResourcePool, "", s.handleUpdateResourcePool)))
 		resourcePools.DELETE("/:resourcePoolId", s.withPermission("resourcePools:delete", s.handleDeleteResourcePool))
-		resourcePools.GET("/:resourcePoolId/resources", s.withPermission("resourcePools:read", s.handleListResourcesInPool))
+		resourcePools.GET("/:resourcePoolId/resources", s.withPermission("resourcePools:read",
+			s.withExtensionsLimit("resources", s.withFieldSelection("resources",
+				s.withStrictSpec(config.StrictSpecKindResource, "resources", s.handleListResourcesInPool)))))
 	}
 
 	// Resource Management
 	// Endpoint: /resources
 	resources := v1.Group("/resources")
 	{
-		resources.GET("", s.withPermission("resources:read", s.handleListResources))
-		resources.POST("", s.withPermission("resources:create", s.handleCreateResource))
-		resources.GET("/:resourceId", s.withPermission("resources:read", s.handleGetResource))
-		resources.PUT("/:resourceId", s.withPermission("resources:update", s.handleUpdateResource))
+		resources.GET("", s.withPermission("resources:read", s.withExtensionsLimit("resources",
+			s.withFieldSelection("resources",
+				s.withStrictSpec(config.StrictSpecKindResource, "resources", s.handleListResources)))))
+		resources.POST("", s.withPermission("resources:create",
+			s.withStrictSpec(config.StrictSpecKindResource, "", s.handleCreateResource)))
+		resources.GET("/:resourceId", s.withPermission("resources:read", s.withFieldSelection("",
+			s.withStrictSpec(config.StrictSpecKindResource, "", s.handleGetResource))))
+		resources.PUT("/:resourceId", s.withPermission("resources:update",
+			s.withStrictSpec(config.StrictSpecKindResource, "", s.handleUpdateResource)))
 		resources.DELETE("/:resourceId", s.withPermission("resources:delete", s.handleDeleteResource))
 	}
 
@@ -132,21 +336,30 @@ func (s *Server) setupV1Routes(v1 *gin.RouterGroup) {
 	// Endpoint: /resourceTypes
 	resourceTypes := v1.Group("/resourceTypes")
 	{
-		resourceTypes.GET("", s.withPermission("resourceTypes:read", s.handleListResourceTypes))
-		resourceTypes.GET("/:resourceTypeId", s.withPermission("resourceTypes:read", s.handleGetResourceType))
+		resourceTypes.GET("", s.withPermission("resourceTypes:read", s.withExtensionsLimit("resourceTypes",
+			s.withStrictSpec(config.StrictSpecKindResourceType, "resourceTypes", s.handleListResourceTypes))))
+		resourceTypes.GET("/:resourceTypeId", s.withPermission("resourceTypes:read",
+			s.withStrictSpec(config.StrictSpecKindResourceType, "", s.handleGetResourceType)))
 	}
 
 	// Deployment Manager Management
 	// Endpoint: /deploymentManagers
 	deploymentManagers := v1.Group("/deploymentManagers")
 	{
-		deploymentManagers.GET("", s.withPermission("deploymentManagers:read", s.handleListDeploymentManagers))
-		deploymentManagers.GET("/:deploymentManagerId", s.withPermission("deploymentManagers:read", s.handleGetDeploymentManager))
+		deploymentManagers.GET("", s.withPermission("deploymentManagers:read", s.withStrictSpec(
+			config.StrictSpecKindDeploymentManager, "deploymentManagers", s.handleListDeploymentManagers)))
+		deploymentManagers.GET("/:deploymentManagerId", s.withPermission("deploymentManagers:read",
+			s.withStrictSpec(config.StrictSpecKindDeploymentManager, "", s.handleGetDeploymentManager)))
 	}
 
+	// Inventory export for analytics pipelines
+	// Endpoint: /export
+	v1.GET("/export", s.withPermission("resources:read", s.handleExportInventory))
+
 	// O-Cloud Infrastructure Information
 	// Endpoint: /oCloudInfrastructure
-	v1.GET("/oCloudInfrastructure", s.withPermission("deploymentManagers:read", s.handleGetOCloudInfrastructure))
+	v1.GET("/oCloudInfrastructure", s.withPermission("deploymentManagers:read",
+		s.withStrictSpec(config.StrictSpecKindOCloud, "", s.handleGetOCloudInfrastructure)))
 
 	// Batch Operations
 	// Endpoint: /batch/*
@@ -191,7 +404,7 @@ func (s *Server) setupV1Routes(v1 *gin.RouterGroup) {
 // handleHealth returns the health status of the server.
 // This endpoint is used by load balancers and monitoring systems.
 func (s *Server) handleHealth(c *gin.Context) {
-	health := s.healthCheck.CheckHealth(c.Request.Context())
+	health := s.healthCheck.Health(c.Request.Context())
 
 	statusCode := http.StatusOK
 	if health.Status == "unhealthy" {
@@ -204,7 +417,13 @@ func (s *Server) handleHealth(c *gin.Context) {
 // handleReadiness returns the readiness status of the server.
 // This endpoint checks if the server is ready to accept traffic.
 func (s *Server) handleReadiness(c *gin.Context) {
-	readiness := s.healthCheck.CheckReadiness(c.Request.Context())
+	readiness := s.healthCheck.Readiness(c.Request.Context())
+
+	// Stop receiving new traffic from load balancers while streams drain.
+	if s.streamDrainer != nil && s.streamDrainer.Draining() {
+		readiness.Ready = false
+		readiness.Status = observability.StatusUnhealthy
+	}
 
 	statusCode := http.StatusOK
 	if !readiness.Ready {
@@ -214,9 +433,18 @@ func (s *Server) handleReadiness(c *gin.Context) {
 	c.JSON(statusCode, readiness)
 }
 
-// handleMetrics serves Prometheus metrics.
+// handleMetrics serves Prometheus metrics. With exemplars enabled, scrapers
+// that accept it get the OpenMetrics format, the only one carrying exemplars.
 func (s *Server) handleMetrics(c *gin.Context) {
+	if s.metricsDisabled.Load() {
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Metrics are disabled")
+		return
+	}
 	handler := promhttp.Handler()
+	if s.httpMetrics != nil && s.httpMetrics.Exemplars() {
+		handler = promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+			promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+	}
 	handler.ServeHTTP(c.Writer, c.Request)
 }
 
@@ -224,21 +452,24 @@ func (s *Server) handleMetrics(c *gin.Context) {
 
 // handleRoot returns basic API information.
 func (s *Server) handleRoot(c *gin.Context) {
-	endpoints := gin.H{
-		"health":     "/health",
-		"ready":      "/ready",
-		"metrics":    s.config.Observability.Metrics.Path,
-		"o2ims_base": "/o2ims-infrastructureInventory/v1",
-		"o2dms_base": "/o2dms/v1",
-		"o2smo_base": "/o2smo/v1",
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"name":        "O2-IMS Gateway",
-		"version":     "1.0.0",
-		"description": "ORAN O2-IMS, O2-DMS, and O2-SMO compliant API gateway for Kubernetes",
-		"api_version": "v1",
-		"endpoints":   endpoints,
+	endpoints := o2imsmodels.GatewayEndpoints{
+		Health:    "/health",
+		Ready:     "/ready",
+		Metrics:   s.config.Observability.Metrics.Path,
+		O2DMSBase: "/o2dms/v1",
+		O2SMOBase: "/o2smo/v1",
+	}
+	if s.adapter != nil {
+		endpoints.O2IMSBase = "/o2ims-infrastructureInventory/v1"
+	}
+
+	c.JSON(http.StatusOK, o2imsmodels.GatewayInfo{
+		Name:        "O2-IMS Gateway",
+		Version:     "1.0.0",
+		Description: "ORAN O2-IMS, O2-DMS, and O2-SMO compliant API gateway for Kubernetes",
+		APIVersion:  "v1",
+		Mode:        s.config.Server.GatewayMode(),
+		Endpoints:   endpoints,
 	})
 }
 
@@ -272,11 +503,11 @@ func (s *Server) handleAPIInfo(c *gin.Context) {
 		features = append(features, "Multi-tenancy support with tenant isolation and quotas")
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"api_version": "v1",
-		"base_path":   "/o2ims-infrastructureInventory/v1",
-		"resources":   resources,
-		"features":    features,
+	c.JSON(http.StatusOK, o2imsmodels.APIInfo{
+		APIVersion: "v1",
+		BasePath:   "/o2ims-infrastructureInventory/v1",
+		Resources:  resources,
+		Features:   features,
 	})
 }
 
@@ -290,12 +521,17 @@ func (s *Server) handleListSubscriptions(c *gin.Context) {
 	// Extract tenant ID from authenticated context for tenant isolation
 	tenantID := auth.TenantIDFromContext(ctx)
 
-	s.logger.Info("listing subscriptions",
+	s.requestLogger(c).Info("listing subscriptions",
 		zap.String("tenant_id", tenantID))
 
+	conditions, err := parseFilterExpression(c)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
 	// Get subscriptions from storage with tenant isolation
 	var subs []*storage.Subscription
-	var err error
 
 	if tenantID != "" && !auth.IsPlatformAdminFromContext(ctx) {
 		// Regular tenant user: only see their own subscriptions
@@ -306,12 +542,12 @@ func (s *Server) handleListSubscriptions(c *gin.Context) {
 	}
 
 	if err != nil {
-		s.logger.Error("failed to list subscriptions", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve subscriptions",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to list subscriptions", zap.Error(err))
+		if isStorageUnavailable(err) {
+			respondStorageUnavailable(c)
+			return
+		}
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve subscriptions")
 		return
 	}
 
@@ -327,12 +563,27 @@ func (s *Server) handleListSubscriptions(c *gin.Context) {
 				ResourceTypeID: sub.Filter.ResourceTypeID,
 				ResourceID:     sub.Filter.ResourceID,
 			},
+			ExpiresAt: sub.ExpiresAt,
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"subscriptions": result,
-		"total":         len(result),
+	// Apply the ?filter= expression to the subscription attributes.
+	if result, err = filterListItems(result, conditions, nil); err != nil {
+		s.requestLogger(c).Error("failed to filter subscriptions", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve subscriptions")
+		return
+	}
+
+	// Optional admin view with the filter match statistics (?stats=true)
+	if c.Query("stats") == "true" {
+		s.writeSubscriptionsWithStats(c, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, o2imsmodels.ListEnvelope[*adapter.Subscription]{
+		Kind:  "subscriptions",
+		Items: result,
+		Total: len(result),
 	})
 }
 
@@ -344,26 +595,51 @@ func (s *Server) handleCreateSubscription(c *gin.Context) {
 	// Extract tenant ID from authenticated context
 	tenantID := auth.TenantIDFromContext(ctx)
 
-	s.logger.Info("creating subscription",
+	s.requestLogger(c).Info("creating subscription",
 		zap.String("tenant_id", tenantID))
 
 	var req adapter.Subscription
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate callback URL early for fast failure (SSRF protection)
 	if err := s.ValidateCallback(ctx, &req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := ValidateTransform(ctx, &req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := ValidateDigest(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := s.validateSubscriptionExpiry(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if !s.subscriptionStoreWritable(c) {
+		return
+	}
+
+	signingSecret, secretGenerated, err := takeSigningSecret(&req)
+	if err != nil {
+		respondSigningSecretError(c, err)
+		return
+	}
+
+	if !s.runPreHooks(c, hooks.OperationCreate, hooks.ObjectTypeSubscription, "", &req) {
+		return
+	}
+
+	if !s.checkDuplicateSubscription(c, tenantID, &req) {
 		return
 	}
 
@@ -371,23 +647,16 @@ func (s *Server) handleCreateSubscription(c *gin.Context) {
 	if tenantID != "" && s.AuthStore != nil {
 		if err := s.AuthStore.IncrementUsage(ctx, tenantID, "subscriptions"); err != nil {
 			if errors.Is(err, auth.ErrQuotaExceeded) {
-				s.logger.Warn("subscription quota exceeded",
+				s.requestLogger(c).Warn("subscription quota exceeded",
 					zap.String("tenant_id", tenantID))
-				c.JSON(http.StatusTooManyRequests, gin.H{
-					"error":   "QuotaExceeded",
-					"message": "Subscription quota exceeded for tenant",
-					"code":    http.StatusTooManyRequests,
-				})
+				problem.Respond(c, http.StatusTooManyRequests, "QuotaExceeded",
+					"Subscription quota exceeded for tenant")
 				return
 			}
-			s.logger.Error("failed to check subscription quota",
+			s.requestLogger(c).Error("failed to check subscription quota",
 				zap.String("tenant_id", tenantID),
 				zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "InternalError",
-				"message": "Failed to check subscription quota",
-				"code":    http.StatusInternalServerError,
-			})
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to check subscription quota")
 			return
 		}
 	}
@@ -417,28 +686,13 @@ func (s *Server) handleCreateSubscription(c *gin.Context) {
 		// Rollback quota increment on failure
 		if tenantID != "" && s.AuthStore != nil {
 			if decErr := s.AuthStore.DecrementUsage(ctx, tenantID, "subscriptions"); decErr != nil {
-				s.logger.Error("failed to rollback subscription quota",
+				s.requestLogger(c).Error("failed to rollback subscription quota",
 					zap.String("tenant_id", tenantID),
 					zap.Error(decErr))
 			}
 		}
 
-		// Check for conflict error (subscription already exists)
-		if errors.Is(err, adapter.ErrSubscriptionExists) {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Conflict",
-				"message": "Subscription already exists",
-				"code":    http.StatusConflict,
-			})
-			return
-		}
-
-		s.logger.Error("failed to create subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to create subscription",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to create subscription", "", "Failed to create subscription")
 		return
 	}
 
@@ -448,6 +702,10 @@ func (s *Server) handleCreateSubscription(c *gin.Context) {
 		Callback:               created.Callback,
 		ConsumerSubscriptionID: created.ConsumerSubscriptionID,
 		TenantID:               tenantID,
+		Transform:              req.Transform,
+		Digest:                 req.Digest,
+		ExpiresAt:              req.ExpiresAt,
+		SigningSecret:          signingSecret,
 	}
 	if created.Filter != nil {
 		storageSub.Filter = storage.SubscriptionFilter{
@@ -458,26 +716,32 @@ func (s *Server) handleCreateSubscription(c *gin.Context) {
 	}
 
 	if err := s.store.Create(ctx, storageSub); err != nil {
-		s.logger.Error("failed to store subscription", zap.Error(err))
+		s.requestLogger(c).Error("failed to store subscription", zap.Error(err))
 		// Attempt to clean up adapter subscription (best effort)
 		_ = s.adapter.DeleteSubscription(ctx, created.SubscriptionID)
 		// Rollback quota increment
 		if tenantID != "" && s.AuthStore != nil {
 			if decErr := s.AuthStore.DecrementUsage(ctx, tenantID, "subscriptions"); decErr != nil {
-				s.logger.Error("failed to rollback subscription quota",
+				s.requestLogger(c).Error("failed to rollback subscription quota",
 					zap.String("tenant_id", tenantID),
 					zap.Error(decErr))
 			}
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to store subscription",
-			"code":    http.StatusInternalServerError,
-		})
+		if isStorageUnavailable(err) {
+			respondStorageUnavailable(c)
+			return
+		}
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to store subscription")
 		return
 	}
 
-	s.logger.Info("subscription created",
+	created.Transform = req.Transform
+	created.Digest = req.Digest
+	created.ExpiresAt = req.ExpiresAt
+	if secretGenerated {
+		created.SigningSecret = signingSecret
+	}
+	s.requestLogger(c).Info("subscription created",
 		zap.String("subscription_id", created.SubscriptionID),
 		zap.String("callback", created.Callback))
 
@@ -497,6 +761,8 @@ func (s *Server) handleCreateSubscription(c *gin.Context) {
 		)
 	}
 
+	s.runPostHooks(c, hooks.OperationCreate, hooks.ObjectTypeSubscription, created.SubscriptionID, created)
+
 	c.JSON(http.StatusCreated, created)
 }
 
@@ -509,47 +775,52 @@ func (s *Server) handleGetSubscription(c *gin.Context) {
 	// Extract tenant ID from authenticated context for tenant isolation
 	tenantID := auth.TenantIDFromContext(ctx)
 
-	s.logger.Info("getting subscription",
+	s.requestLogger(c).Info("getting subscription",
 		zap.String("subscription_id", subscriptionID),
 		zap.String("tenant_id", tenantID))
 
 	// Get subscription from storage
 	sub, err := s.store.Get(ctx, subscriptionID)
 	if err != nil {
-		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Subscription not found: " + subscriptionID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
-
-		s.logger.Error("failed to get subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve subscription",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to get subscription",
+			"Subscription not found: "+subscriptionID, "Failed to retrieve subscription")
 		return
 	}
 
 	// Tenant isolation: verify subscription belongs to tenant (unless platform admin)
 	if tenantID != "" && !auth.IsPlatformAdminFromContext(ctx) && sub.TenantID != tenantID {
-		s.logger.Warn("tenant attempting to access subscription from different tenant",
+		s.requestLogger(c).Warn("tenant attempting to access subscription from different tenant",
 			zap.String("tenant_id", tenantID),
 			zap.String("subscription_tenant_id", sub.TenantID),
 			zap.String("subscription_id", subscriptionID))
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "NotFound",
-			"message": "Subscription not found: " + subscriptionID,
-			"code":    http.StatusNotFound,
-		})
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 		return
 	}
 
 	// Convert to adapter subscription for response
-	result := &adapter.Subscription{
+	result := subscriptionResponse(sub)
+
+	// Optional live reachability probe of the callback (?diagnose=true)
+	if c.Query("diagnose") == "true" {
+		diagnostics := DiagnoseCallback(ctx, sub.Callback, CallbackProbeOptions{
+			AllowPrivate: s.callbackSecuritySettings().disableSSRFProtection,
+		})
+		s.requestLogger(c).Info("callback diagnostics completed",
+			zap.String("subscription_id", subscriptionID),
+			zap.Bool("reachable", diagnostics.Reachable))
+		c.JSON(http.StatusOK, &SubscriptionWithDiagnostics{
+			Subscription: result,
+			Diagnostics:  diagnostics,
+		})
+		return
+	}
+
+	s.serveObject(c, result, result)
+}
+
+// subscriptionResponse converts a stored subscription to its API representation.
+func subscriptionResponse(sub *storage.Subscription) *adapter.Subscription {
+	return &adapter.Subscription{
 		SubscriptionID:         sub.ID,
 		Callback:               sub.Callback,
 		ConsumerSubscriptionID: sub.ConsumerSubscriptionID,
@@ -558,9 +829,10 @@ func (s *Server) handleGetSubscription(c *gin.Context) {
 			ResourceTypeID: sub.Filter.ResourceTypeID,
 			ResourceID:     sub.Filter.ResourceID,
 		},
+		Transform: sub.Transform,
+		Digest:    sub.Digest,
+		ExpiresAt: sub.ExpiresAt,
 	}
-
-	c.JSON(http.StatusOK, result)
 }
 
 // handleUpdateSubscription updates an existing subscription.
@@ -574,63 +846,73 @@ func (s *Server) handleUpdateSubscription(c *gin.Context) {
 	// Extract tenant ID from authenticated context for tenant isolation
 	tenantID := auth.TenantIDFromContext(ctx)
 
-	s.logger.Info("updating subscription",
+	s.requestLogger(c).Info("updating subscription",
 		zap.String("subscription_id", subscriptionID),
 		zap.String("tenant_id", tenantID))
 
-	// Tenant isolation: verify subscription belongs to tenant before update
+	// Tenant isolation: verify subscription belongs to tenant before update.
+	// The stored subscription also keeps the signing secret across the
+	// adapter update, which does not persist it.
+	var existing *storage.Subscription
 	if s.store != nil {
 		sub, err := s.store.Get(ctx, subscriptionID)
 		if err != nil {
-			if errors.Is(err, storage.ErrSubscriptionNotFound) {
-				c.JSON(http.StatusNotFound, gin.H{
-					"error":   "NotFound",
-					"message": "Subscription not found: " + subscriptionID,
-					"code":    http.StatusNotFound,
-				})
-				return
-			}
-			s.logger.Error("failed to get subscription for tenant check", zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error":   "InternalError",
-				"message": "Failed to verify subscription ownership",
-				"code":    http.StatusInternalServerError,
-			})
+			s.respondError(c, err, "failed to get subscription for tenant check",
+				"Subscription not found: "+subscriptionID, "Failed to verify subscription ownership")
 			return
 		}
 
 		// Verify subscription belongs to tenant (unless platform admin)
 		if tenantID != "" && !auth.IsPlatformAdminFromContext(ctx) && sub.TenantID != tenantID {
-			s.logger.Warn("tenant attempting to update subscription from different tenant",
+			s.requestLogger(c).Warn("tenant attempting to update subscription from different tenant",
 				zap.String("tenant_id", tenantID),
 				zap.String("subscription_tenant_id", sub.TenantID),
 				zap.String("subscription_id", subscriptionID))
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Subscription not found: " + subscriptionID,
-				"code":    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
+			return
+		}
+
+		// Optimistic concurrency: reject updates based on a stale read
+		if !s.checkIfMatch(c, subscriptionResponse(sub)) {
+			return
+		}
+
+		if !s.subscriptionStoreWritable(c) {
 			return
 		}
+		existing = sub
 	}
 
 	var req adapter.Subscription
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate callback URL early for fast failure
 	if err := s.ValidateCallback(ctx, &req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := ValidateTransform(ctx, &req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := ValidateDigest(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := s.validateSubscriptionExpiry(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	signingSecret, secretGenerated, err := takeSigningSecret(&req)
+	if err != nil {
+		respondSigningSecretError(c, err)
 		return
 	}
 
@@ -638,29 +920,29 @@ func (s *Server) handleUpdateSubscription(c *gin.Context) {
 	// The adapter handles validation and persistence to its backend storage
 	updated, err := s.adapter.UpdateSubscription(c.Request.Context(), subscriptionID, &req)
 	if err != nil {
-		// Check for not found error using sentinel error
-		if errors.Is(err, adapter.ErrSubscriptionNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Subscription not found: " + subscriptionID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
-
-		s.logger.Error("failed to update subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to update subscription",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to update subscription",
+			"Subscription not found: "+subscriptionID, "Failed to update subscription")
 		return
 	}
 
-	s.logger.Info("subscription updated",
+	s.requestLogger(c).Info("subscription updated",
 		zap.String("subscription_id", subscriptionID),
 		zap.String("callback", updated.Callback))
 
+	// The adapters do not persist transforms, digest settings and expiry; a
+	// PUT without them removes them. A PUT with a signing secret rotates it,
+	// one without keeps it.
+	s.storeSubscriptionDelivery(c, subscriptionID, &req, existing, signingSecret)
+	updated.Transform = req.Transform
+	updated.Digest = req.Digest
+	updated.ExpiresAt = req.ExpiresAt
+	if secretGenerated {
+		updated.SigningSecret = signingSecret
+	}
+
+	// The new callback passed validation, so a suspension no longer applies
+	s.resumeSubscription(c, subscriptionID, updated.Callback)
+
 	// Log audit event for subscription update
 	s.logAuditEvent(
 		c.Request.Context(),
@@ -674,6 +956,12 @@ func (s *Server) handleUpdateSubscription(c *gin.Context) {
 		},
 	)
 
+	// The ETag is that of the subscription as GET returns it.
+	if s.store != nil {
+		if stored, err := s.store.Get(ctx, subscriptionID); err == nil {
+			s.setETag(c, subscriptionResponse(stored))
+		}
+	}
 	c.JSON(http.StatusOK, updated)
 }
 
@@ -686,7 +974,7 @@ func (s *Server) handleDeleteSubscription(c *gin.Context) {
 	// Extract tenant ID from authenticated context
 	tenantID := auth.TenantIDFromContext(ctx)
 
-	s.logger.Info("deleting subscription",
+	s.requestLogger(c).Info("deleting subscription",
 		zap.String("subscription_id", subscriptionID),
 		zap.String("tenant_id", tenantID))
 
@@ -699,27 +987,27 @@ func (s *Server) handleDeleteSubscription(c *gin.Context) {
 
 			// Tenant isolation: verify subscription belongs to tenant (unless platform admin)
 			if tenantID != "" && !auth.IsPlatformAdminFromContext(ctx) && sub.TenantID != tenantID {
-				s.logger.Warn("tenant attempting to delete subscription from different tenant",
+				s.requestLogger(c).Warn("tenant attempting to delete subscription from different tenant",
 					zap.String("tenant_id", tenantID),
 					zap.String("subscription_tenant_id", sub.TenantID),
 					zap.String("subscription_id", subscriptionID))
-				c.JSON(http.StatusNotFound, gin.H{
-					"error":   "NotFound",
-					"message": "Subscription not found: " + subscriptionID,
-					"code":    http.StatusNotFound,
-				})
+				problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
 				return
 			}
 		} else if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Subscription not found: " + subscriptionID,
-				"code":    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
+			return
+		}
+
+		if !s.subscriptionStoreWritable(c) {
 			return
 		}
 	}
 
+	if !s.runPreHooks(c, hooks.OperationDelete, hooks.ObjectTypeSubscription, subscriptionID, nil) {
+		return
+	}
+
 	// Delete from adapter
 	if err := s.adapter.DeleteSubscription(ctx, subscriptionID); err != nil {
 		// Audit log the failure
@@ -738,12 +1026,8 @@ func (s *Server) handleDeleteSubscription(c *gin.Context) {
 			)
 		}
 
-		s.logger.Error("failed to delete subscription from adapter", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to delete subscription",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to delete subscription from adapter", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to delete subscription")
 		return
 	}
 
@@ -765,35 +1049,14 @@ func (s *Server) handleDeleteSubscription(c *gin.Context) {
 			)
 		}
 
-		if errors.Is(err, storage.ErrSubscriptionNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Subscription not found: " + subscriptionID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
-
-		s.logger.Error("failed to delete subscription from storage", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to delete subscription",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to delete subscription from storage",
+			"Subscription not found: "+subscriptionID, "Failed to delete subscription")
 		return
 	}
 
-	// Decrement tenant quota after successful deletion
-	if storedTenantID != "" && s.AuthStore != nil {
-		if err := s.AuthStore.DecrementUsage(ctx, storedTenantID, "subscriptions"); err != nil {
-			s.logger.Error("failed to decrement subscription quota",
-				zap.String("tenant_id", storedTenantID),
-				zap.Error(err))
-			// Don't fail the delete operation if quota decrement fails
-		}
-	}
+	s.releaseSubscription(ctx, s.requestLogger(c), subscriptionID, storedTenantID)
 
-	s.logger.Info("subscription deleted", zap.String("subscription_id", subscriptionID))
+	s.requestLogger(c).Info("subscription deleted", zap.String("subscription_id", subscriptionID))
 
 	// Audit log the successful deletion
 	if s.auditLogger != nil {
@@ -810,6 +1073,8 @@ func (s *Server) handleDeleteSubscription(c *gin.Context) {
 		)
 	}
 
+	s.runPostHooks(c, hooks.OperationDelete, hooks.ObjectTypeSubscription, subscriptionID, nil)
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -840,11 +1105,19 @@ func (s *Server) parseFilterFromRequest(c *gin.Context) (*adapter.Filter, error)
 		}, nil
 	}
 
-	// For v1, create basic filter (no advanced features).
-	return &adapter.Filter{
+	// For v1, create basic filter; only the O-RAN ?filter= expression adds conditions.
+	conditions, err := parseFilterExpression(c)
+	if err != nil {
+		return nil, err
+	}
+	filter := &adapter.Filter{
 		TenantID: tenantID,
 		Limit:    100, // Default limit for v1.
-	}, nil
+	}
+	if conditions != nil {
+		filter.AdvancedFilter = &models.AdvancedFilter{Conditions: conditions, Limit: filter.Limit}
+	}
+	return filter, nil
 }
 
 // Resource Pool handlers
@@ -852,85 +1125,121 @@ func (s *Server) parseFilterFromRequest(c *gin.Context) (*adapter.Filter, error)
 // handleListResourcePools lists all resource pools.
 // GET /o2ims/v1/resourcePools, /v2/resourcePools, /v3/resourcePools.
 func (s *Server) handleListResourcePools(c *gin.Context) {
-	s.logger.Info("listing resource pools")
+	s.requestLogger(c).Info("listing resource pools")
 
 	// Parse filter from request (supports v1 basic and v2+ advanced filtering).
 	filter, err := s.parseFilterFromRequest(c)
 	if err != nil {
-		s.logger.Error("failed to parse filter", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "InvalidParameter",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
+		s.requestLogger(c).Error("failed to parse filter", zap.Error(err))
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	// ?limit= and ?nextpage_opaque_marker= select the page of a plain list.
+	page, err := parseListPage(c, "resourcePools")
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	// consistency=snapshot serves every page from a snapshot taken on the first page.
+	snapshotReq, useSnapshot, err := parseSnapshotPageRequest(c)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	if useSnapshot {
+		filter.Limit, filter.Offset = 0, 0
+		s.serveListSnapshot(c, snapshotReq, "resourcePools", func(ctx context.Context) (interface{}, error) {
+			return s.listResourcePools(ctx, filter)
 		})
 		return
 	}
 
-	// List resource pools via adapter.
-	pools, err := s.adapter.ListResourcePools(c.Request.Context(), filter)
+	// ?waitFor={resourceVersion} long-polls until the list changes or the timeout elapses.
+	longPollReq, useLongPoll, err := s.parseLongPollRequest(c)
 	if err != nil {
-		s.logger.Error("failed to list resource pools", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve resource pools",
-			"code":    http.StatusInternalServerError,
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	if useLongPoll {
+		serveLongPoll(s, c, longPollReq, "resourcePools", func(ctx context.Context) ([]*adapter.ResourcePool, error) {
+			return s.listResourcePools(ctx, filter)
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"resourcePools": pools,
-		"total":         len(pools),
-	})
+	// List one page of resource pools via adapter.
+	page.apply(filter)
+	pools, err := s.listResourcePools(c.Request.Context(), filter)
+	if err == nil {
+		pools, err = cutListPage(c, page, pools)
+	}
+	if err != nil {
+		s.requestLogger(c).Error("failed to list resource pools", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resource pools")
+		return
+	}
+
+	serveList(s, c, "resourcePools", pools)
 }
 
 // handleGetResourcePool retrieves a specific resource pool.
 // GET /o2ims/v1/resourcePools/:resourcePoolId.
 func (s *Server) handleGetResourcePool(c *gin.Context) {
 	resourcePoolID := c.Param("resourcePoolId")
-	s.logger.Info("getting resource pool", zap.String("resource_pool_id", resourcePoolID))
+	s.requestLogger(c).Info("getting resource pool", zap.String("resource_pool_id", resourcePoolID))
 
-	// Get resource pool via adapter
-	pool, err := s.adapter.GetResourcePool(c.Request.Context(), resourcePoolID)
+	// Get resource pool via the read cache or adapter
+	pool, err := s.getResourcePool(c.Request.Context(), resourcePoolID)
 	if err != nil {
-		s.logger.Error("failed to get resource pool", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "NotFound",
-			"message": "Resource pool not found: " + resourcePoolID,
-			"code":    http.StatusNotFound,
-		})
+		s.requestLogger(c).Error("failed to get resource pool", zap.Error(err))
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Resource pool not found: "+resourcePoolID)
+		return
+	}
+
+	if _, ok := s.authorizeOwner(c, usageResourcePools, resourcePoolID, pool.TenantID,
+		"Resource pool not found: "+resourcePoolID); !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, pool)
+	priced := s.withPoolCosts(c.Request.Context(), []*adapter.ResourcePool{pool}, nil)[0]
+	s.serveObject(c, priced, pool)
 }
 
 // handleListResourcesInPool lists resources in a specific pool.
 // GET /o2ims/v1/resourcePools/:resourcePoolId/resources.
 func (s *Server) handleListResourcesInPool(c *gin.Context) {
 	resourcePoolID := c.Param("resourcePoolId")
-	s.logger.Info("listing resources in pool", zap.String("resource_pool_id", resourcePoolID))
+	s.requestLogger(c).Info("listing resources in pool", zap.String("resource_pool_id", resourcePoolID))
 
-	// Create filter for this resource pool
+	page, err := parseListPage(c, "resourcePools/"+resourcePoolID+"/resources")
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	// Create filter for one page of this resource pool
 	filter := &adapter.Filter{
 		ResourcePoolID: resourcePoolID,
 	}
+	page.apply(filter)
 
 	// List resources via adapter
-	resources, err := s.adapter.ListResources(c.Request.Context(), filter)
+	resources, err := scopedList(c.Request.Context(), s, usageResources, filter, s.adapter.ListResources, resourceOwner)
+	if err == nil {
+		resources, err = cutListPage(c, page, resources)
+	}
 	if err != nil {
-		s.logger.Error("failed to list resources in pool", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve resources",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to list resources in pool", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resources")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"resources": resources,
-		"total":     len(resources),
+	c.JSON(http.StatusOK, o2imsmodels.ListEnvelope[*adapter.Resource]{
+		Kind:  "resources",
+		Items: resources,
+		Total: len(resources),
 	})
 }
 
@@ -1065,25 +1374,17 @@ func ValidateResourcePoolFields(pool *adapter.ResourcePool) error {
 // handleCreateResourcePool creates a new resource pool.
 // POST /o2ims/v1/resourcePools.
 func (s *Server) handleCreateResourcePool(c *gin.Context) {
-	s.logger.Info("creating resource pool")
+	s.requestLogger(c).Info("creating resource pool")
 
 	var req adapter.ResourcePool
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate resource pool fields
 	if err := ValidateResourcePoolFields(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return
 	}
 
@@ -1107,9 +1408,30 @@ func (s *Server) handleCreateResourcePool(c *gin.Context) {
 		req.ResourcePoolID = "pool-" + sanitizedName + "-" + uuid.New().String()
 	}
 
+	if !s.checkValidationRules(c, validation.KindResourcePool, validation.OperationCreate, req.ResourcePoolID, &req) {
+		return
+	}
+
+	if !s.runPreHooks(c, hooks.OperationCreate, hooks.ObjectTypeResourcePool, req.ResourcePoolID, &req) {
+		return
+	}
+
+	// Pools created by a tenant count against its quota and are owned by it
+	tenantID := s.ownerScope(c.Request.Context())
+	if tenantID != "" {
+		if !s.reserveUsage(c, tenantID, usageResourcePools, "Resource pool") {
+			return
+		}
+		req.TenantID = tenantID
+	}
+
 	// Create resource pool via adapter
 	created, err := s.adapter.CreateResourcePool(c.Request.Context(), &req)
 	if err != nil {
+		if tenantID != "" {
+			s.releaseUsage(c.Request.Context(), tenantID, usageResourcePools)
+		}
+
 		// Audit log the failure
 		if s.auditLogger != nil {
 			user := auth.UserFromContext(c.Request.Context())
@@ -1127,26 +1449,15 @@ func (s *Server) handleCreateResourcePool(c *gin.Context) {
 			)
 		}
 
-		// Check for duplicate resource pool using sentinel error
-		if errors.Is(err, adapter.ErrResourcePoolExists) {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Conflict",
-				"message": "Resource pool with ID " + SanitizeForLogging(req.ResourcePoolID) + " already exists",
-				"code":    http.StatusConflict,
-			})
-			return
-		}
-
-		s.logger.Error("failed to create resource pool", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to create resource pool",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to create resource pool", "", "Failed to create resource pool")
 		return
 	}
 
-	s.logger.Info("resource pool created",
+	if tenantID != "" {
+		s.recordOwner(c.Request.Context(), usageResourcePools, created.ResourcePoolID, tenantID)
+	}
+
+	s.requestLogger(c).Info("resource pool created",
 		zap.String("resource_pool_id", created.ResourcePoolID),
 		zap.String("name", SanitizeForLogging(created.Name)))
 
@@ -1166,6 +1477,8 @@ func (s *Server) handleCreateResourcePool(c *gin.Context) {
 		)
 	}
 
+	s.runPostHooks(c, hooks.OperationCreate, hooks.ObjectTypeResourcePool, created.ResourcePoolID, created)
+
 	// Set Location header for REST compliance
 	c.Header("Location", "/o2ims/v1/resourcePools/"+created.ResourcePoolID)
 	c.JSON(http.StatusCreated, created)
@@ -1175,28 +1488,42 @@ func (s *Server) handleCreateResourcePool(c *gin.Context) {
 // PUT /o2ims/v1/resourcePools/:resourcePoolId.
 func (s *Server) handleUpdateResourcePool(c *gin.Context) {
 	resourcePoolID := c.Param("resourcePoolId")
-	s.logger.Info("updating resource pool", zap.String("resource_pool_id", resourcePoolID))
+	s.requestLogger(c).Info("updating resource pool", zap.String("resource_pool_id", resourcePoolID))
 
 	var req adapter.ResourcePool
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate field constraints
 	if err := ValidateResourcePoolFields(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if _, ok := s.authorizeOwner(c, usageResourcePools, resourcePoolID, "",
+		"Resource pool not found: "+resourcePoolID); !ok {
+		return
+	}
+
+	if !s.checkValidationRules(c, validation.KindResourcePool, validation.OperationUpdate, resourcePoolID, &req) {
 		return
 	}
 
+	// Optimistic concurrency: reject updates based on a stale read
+	if c.GetHeader("If-Match") != "" {
+		current, err := s.adapter.GetResourcePool(c.Request.Context(), resourcePoolID)
+		if err != nil {
+			s.respondError(c, err, "failed to get resource pool for If-Match",
+				"Resource pool not found: "+resourcePoolID, "Failed to update resource pool")
+			return
+		}
+		if !s.checkIfMatch(c, current) {
+			return
+		}
+	}
+
 	// Update resource pool via adapter
 	updated, err := s.adapter.UpdateResourcePool(c.Request.Context(), resourcePoolID, &req)
 	if err != nil {
@@ -1217,26 +1544,12 @@ func (s *Server) handleUpdateResourcePool(c *gin.Context) {
 			)
 		}
 
-		// Check for not found error using sentinel error
-		if errors.Is(err, adapter.ErrResourcePoolNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Resource pool not found: " + resourcePoolID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
-
-		s.logger.Error("failed to update resource pool", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to update resource pool",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to update resource pool",
+			"Resource pool not found: "+resourcePoolID, "Failed to update resource pool")
 		return
 	}
 
-	s.logger.Info("resource pool updated",
+	s.requestLogger(c).Info("resource pool updated",
 		zap.String("resource_pool_id", updated.ResourcePoolID),
 		zap.String("name", SanitizeForLogging(updated.Name)))
 
@@ -1256,6 +1569,7 @@ func (s *Server) handleUpdateResourcePool(c *gin.Context) {
 		)
 	}
 
+	s.setETag(c, updated)
 	c.JSON(http.StatusOK, updated)
 }
 
@@ -1263,6 +1577,15 @@ func (s *Server) handleUpdateResourcePool(c *gin.Context) {
 // DELETE /o2ims/v1/resourcePools/:resourcePoolId.
 func (s *Server) handleDeleteResourcePool(c *gin.Context) {
 	resourcePoolID := c.Param("resourcePoolId")
+	owner, ok := s.authorizeOwner(c, usageResourcePools, resourcePoolID, "",
+		"Resource pool not found: "+resourcePoolID)
+	if !ok {
+		return
+	}
+	if !s.runPreHooks(c, hooks.OperationDelete, hooks.ObjectTypeResourcePool, resourcePoolID, nil) {
+		return
+	}
+
 	if err := s.adapter.DeleteResourcePool(c.Request.Context(), resourcePoolID); err != nil {
 		// Audit log the failure
 		if s.auditLogger != nil {
@@ -1280,20 +1603,8 @@ func (s *Server) handleDeleteResourcePool(c *gin.Context) {
 			)
 		}
 
-		if errors.Is(err, adapter.ErrResourcePoolNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Resource pool not found: " + resourcePoolID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
-		s.logger.Error("failed to delete resource pool", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to delete resource pool",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to delete resource pool",
+			"Resource pool not found: "+resourcePoolID, "Failed to delete resource pool")
 		return
 	}
 
@@ -1311,6 +1622,10 @@ func (s *Server) handleDeleteResourcePool(c *gin.Context) {
 		)
 	}
 
+	s.forgetOwner(c.Request.Context(), usageResourcePools, resourcePoolID, owner)
+	cost.ForgetResourcePool(resourcePoolID)
+	s.runPostHooks(c, hooks.OperationDelete, hooks.ObjectTypeResourcePool, resourcePoolID, nil)
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -1424,67 +1739,99 @@ func validateResourceFields(resource *adapter.Resource) error {
 // handleListResources lists all resources.
 // GET /o2ims/v1/resources.
 func (s *Server) handleListResources(c *gin.Context) {
-	s.logger.Info("listing resources")
+	s.requestLogger(c).Info("listing resources")
+
+	// Lookups by external identifier (e.g. ?globalAssetId=) are served from the resource index.
+	if key, value, ok := identifierQuery(c.Query); ok {
+		ctx := c.Request.Context()
+		resources, err := s.findResourcesByIdentifier(ctx, key, value, auth.TenantIDFromContext(ctx))
+		if err != nil {
+			s.requestLogger(c).Error("failed to find resources by identifier", zap.String("key", key), zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resources")
+			return
+		}
+
+		serveList(s, c, "resources", resources)
+		return
+	}
 
 	// Parse filter from request (supports v1 basic and v2+ advanced filtering).
 	filter, err := s.parseFilterFromRequest(c)
 	if err != nil {
-		s.logger.Error("failed to parse filter", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "InvalidParameter",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
+		s.requestLogger(c).Error("failed to parse filter", zap.Error(err))
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	// ?limit= and ?nextpage_opaque_marker= select the page of a plain list.
+	page, err := parseListPage(c, "resources")
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	// consistency=snapshot serves every page from a snapshot taken on the first page.
+	snapshotReq, useSnapshot, err := parseSnapshotPageRequest(c)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	if useSnapshot {
+		filter.Limit, filter.Offset = 0, 0
+		s.serveListSnapshot(c, snapshotReq, "resources", func(ctx context.Context) (interface{}, error) {
+			return s.listResources(ctx, filter)
 		})
 		return
 	}
 
-	// List resources via adapter.
-	resources, err := s.adapter.ListResources(c.Request.Context(), filter)
+	// ?waitFor={resourceVersion} long-polls until the list changes or the timeout elapses.
+	longPollReq, useLongPoll, err := s.parseLongPollRequest(c)
 	if err != nil {
-		s.logger.Error("failed to list resources", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve resources",
-			"code":    http.StatusInternalServerError,
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	if useLongPoll {
+		serveLongPoll(s, c, longPollReq, "resources", func(ctx context.Context) ([]*adapter.Resource, error) {
+			return s.listResources(ctx, filter)
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"resources": resources,
-		"total":     len(resources),
-	})
+	// List one page of resources via adapter.
+	page.apply(filter)
+	resources, err := s.listResources(c.Request.Context(), filter)
+	if err == nil {
+		resources, err = cutListPage(c, page, resources)
+	}
+	if err != nil {
+		s.requestLogger(c).Error("failed to list resources", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resources")
+		return
+	}
+
+	serveList(s, c, "resources", resources)
 }
 
 // handleGetResource retrieves a specific resource.
 // GET /o2ims/v1/resources/:resourceId.
 func (s *Server) handleGetResource(c *gin.Context) {
 	resourceID := c.Param("resourceId")
-	s.logger.Info("getting resource", zap.String("resource_id", resourceID))
+	s.requestLogger(c).Info("getting resource", zap.String("resource_id", resourceID))
 
-	// Get resource via adapter
-	resource, err := s.adapter.GetResource(c.Request.Context(), resourceID)
+	// Get resource via the read cache or adapter
+	resource, err := s.getResource(c.Request.Context(), resourceID)
 	if err != nil {
-		// Use sentinel error for better error detection
-		if errors.Is(err, adapter.ErrResourceNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Resource not found: " + resourceID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
+		s.respondError(c, err, "failed to get resource",
+			"Resource not found: "+resourceID, "Failed to retrieve resource")
+		return
+	}
 
-		s.logger.Error("failed to get resource", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve resource",
-			"code":    http.StatusInternalServerError,
-		})
+	if _, ok := s.authorizeOwner(c, usageResources, resourceID, resource.TenantID,
+		"Resource not found: "+resourceID); !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, resource)
+	s.serveObject(c, resource, resource)
 }
 
 // validateCreateRequest validates required fields and constraints for resource creation.
@@ -1503,25 +1850,17 @@ func validateCreateRequest(req *adapter.Resource) error {
 // handleCreateResource creates a new resource.
 // POST /o2ims/v1/resources.
 func (s *Server) handleCreateResource(c *gin.Context) {
-	s.logger.Info("creating resource")
+	s.requestLogger(c).Info("creating resource")
 
 	var req adapter.Resource
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
 	// Validate required fields and constraints
 	if err := validateCreateRequest(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return
 	}
 
@@ -1532,20 +1871,37 @@ func (s *Server) handleCreateResource(c *gin.Context) {
 		// Validate client-provided resource ID is a valid UUID
 		// This prevents path traversal attacks (e.g., "../../../etc/passwd")
 		if _, err := uuid.Parse(req.ResourceID); err != nil {
-			s.logger.Warn("invalid resource ID format",
+			s.requestLogger(c).Warn("invalid resource ID format",
 				zap.String("resource_id", SanitizeForLogging(req.ResourceID)))
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "BadRequest",
-				"message": "resourceId must be a valid UUID",
-				"code":    http.StatusBadRequest,
-			})
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "resourceId must be a valid UUID")
 			return
 		}
 	}
 
+	if !s.checkValidationRules(c, validation.KindResource, validation.OperationCreate, req.ResourceID, &req) {
+		return
+	}
+
+	if !s.runPreHooks(c, hooks.OperationCreate, hooks.ObjectTypeResource, req.ResourceID, &req) {
+		return
+	}
+
+	// Resources created by a tenant count against its quota and are owned by it
+	tenantID := s.ownerScope(c.Request.Context())
+	if tenantID != "" {
+		if !s.reserveUsage(c, tenantID, usageResources, "Resource") {
+			return
+		}
+		req.TenantID = tenantID
+	}
+
 	// Create resource via adapter
 	created, err := s.adapter.CreateResource(c.Request.Context(), &req)
 	if err != nil {
+		if tenantID != "" {
+			s.releaseUsage(c.Request.Context(), tenantID, usageResources)
+		}
+
 		// Audit log the failure
 		if s.auditLogger != nil {
 			user := auth.UserFromContext(c.Request.Context())
@@ -1562,29 +1918,20 @@ func (s *Server) handleCreateResource(c *gin.Context) {
 			)
 		}
 
-		// Check if error indicates duplicate resource
-		if errors.Is(err, adapter.ErrResourceExists) {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Conflict",
-				"message": "Resource with ID " + SanitizeForLogging(req.ResourceID) + " already exists",
-				"code":    http.StatusConflict,
-			})
-			return
-		}
-
-		s.logger.Error("failed to create resource", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to create resource",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to create resource", "", "Failed to create resource")
 		return
 	}
 
-	s.logger.Info("resource created",
+	if tenantID != "" {
+		s.recordOwner(c.Request.Context(), usageResources, created.ResourceID, tenantID)
+	}
+
+	s.requestLogger(c).Info("resource created",
 		zap.String("resource_id", created.ResourceID),
 		zap.String("resource_type_id", SanitizeForLogging(created.ResourceTypeID)))
 
+	s.indexResources(c.Request.Context(), created)
+
 	// Audit log the resource creation
 	if s.auditLogger != nil {
 		user := auth.UserFromContext(c.Request.Context())
@@ -1601,6 +1948,8 @@ func (s *Server) handleCreateResource(c *gin.Context) {
 		)
 	}
 
+	s.runPostHooks(c, hooks.OperationCreate, hooks.ObjectTypeResource, created.ResourceID, created)
+
 	// Set Location header for REST compliance
 	c.Header("Location", "/o2ims/v1/resources/"+created.ResourceID)
 	c.JSON(http.StatusCreated, created)
@@ -1610,15 +1959,11 @@ func (s *Server) handleCreateResource(c *gin.Context) {
 // PUT /o2ims/v1/resources/:resourceId.
 func (s *Server) handleUpdateResource(c *gin.Context) {
 	resourceID := c.Param("resourceId")
-	s.logger.Info("updating resource", zap.String("resource_id", resourceID))
+	s.requestLogger(c).Info("updating resource", zap.String("resource_id", resourceID))
 
 	var req adapter.Resource
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
 		return
 	}
 
@@ -1627,6 +1972,15 @@ func (s *Server) handleUpdateResource(c *gin.Context) {
 	if err != nil || existing == nil {
 		return // Response already sent
 	}
+	if _, ok := s.authorizeOwner(c, usageResources, resourceID, existing.TenantID,
+		"Resource not found: "+resourceID); !ok {
+		return
+	}
+
+	// Optimistic concurrency: reject updates based on a stale read
+	if !s.checkIfMatch(c, existing) {
+		return
+	}
 
 	// Validate request
 	if err := s.validateUpdateRequest(c, &req, existing); err != nil {
@@ -1641,9 +1995,19 @@ func (s *Server) handleDeleteResource(c *gin.Context) {
 	resourceID := c.Param("resourceId")
 
 	// Get resource info before deletion for audit logging
-	var resourceTypeID string
+	var resourceTypeID, reportedTenantID string
 	if existing, err := s.adapter.GetResource(c.Request.Context(), resourceID); err == nil && existing != nil {
 		resourceTypeID = existing.ResourceTypeID
+		reportedTenantID = existing.TenantID
+	}
+
+	owner, ok := s.authorizeOwner(c, usageResources, resourceID, reportedTenantID, "Resource not found: "+resourceID)
+	if !ok {
+		return
+	}
+
+	if !s.runPreHooks(c, hooks.OperationDelete, hooks.ObjectTypeResource, resourceID, nil) {
+		return
 	}
 
 	if err := s.adapter.DeleteResource(c.Request.Context(), resourceID); err != nil {
@@ -1663,23 +2027,14 @@ func (s *Server) handleDeleteResource(c *gin.Context) {
 			)
 		}
 
-		if errors.Is(err, adapter.ErrResourceNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Resource not found: " + resourceID,
-				"code":    http.StatusNotFound,
-			})
-			return
-		}
-		s.logger.Error("failed to delete resource", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to delete resource",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to delete resource",
+			"Resource not found: "+resourceID, "Failed to delete resource")
 		return
 	}
 
+	s.unindexResource(c.Request.Context(), resourceID)
+	s.forgetOwner(c.Request.Context(), usageResources, resourceID, owner)
+
 	// Audit log the successful deletion
 	if s.auditLogger != nil {
 		user := auth.UserFromContext(c.Request.Context())
@@ -1694,6 +2049,8 @@ func (s *Server) handleDeleteResource(c *gin.Context) {
 		)
 	}
 
+	s.runPostHooks(c, hooks.OperationDelete, hooks.ObjectTypeResource, resourceID, nil)
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -1701,21 +2058,8 @@ func (s *Server) handleDeleteResource(c *gin.Context) {
 func (s *Server) getExistingResource(c *gin.Context, resourceID string) (*adapter.Resource, error) {
 	existing, err := s.adapter.GetResource(c.Request.Context(), resourceID)
 	if err != nil {
-		if errors.Is(err, adapter.ErrResourceNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "Resource not found: " + resourceID,
-				"code":    http.StatusNotFound,
-			})
-			return nil, fmt.Errorf("failed to get resource %s: %w", resourceID, err)
-		}
-
-		s.logger.Error("failed to get resource", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve resource",
-			"code":    http.StatusInternalServerError,
-		})
+		s.respondError(c, err, "failed to get resource",
+			"Resource not found: "+resourceID, "Failed to retrieve resource")
 		return nil, fmt.Errorf("failed to get resource %s: %w", resourceID, err)
 	}
 	return existing, nil
@@ -1725,21 +2069,13 @@ func (s *Server) getExistingResource(c *gin.Context, resourceID string) (*adapte
 func (s *Server) validateUpdateRequest(c *gin.Context, req, existing *adapter.Resource) error {
 	// Validate field constraints
 	if err := validateResourceFields(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return err
 	}
 
 	// Check immutable fields
 	if err := checkImmutableFields(req, existing); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
 		return err
 	}
 
@@ -1768,6 +2104,10 @@ func (s *Server) applyResourceUpdate(c *gin.Context, resourceID string, req, exi
 		req.ResourcePoolID = existing.ResourcePoolID
 	}
 
+	if !s.checkValidationRules(c, validation.KindResource, validation.OperationUpdate, resourceID, req) {
+		return
+	}
+
 	// Update via adapter
 	updated, err := s.adapter.UpdateResource(c.Request.Context(), resourceID, req)
 	if err != nil {
@@ -1787,19 +2127,17 @@ func (s *Server) applyResourceUpdate(c *gin.Context, resourceID string, req, exi
 			)
 		}
 
-		s.logger.Error("failed to update resource", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to update resource",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to update resource", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to update resource")
 		return
 	}
 
-	s.logger.Info("resource updated",
+	s.requestLogger(c).Info("resource updated",
 		zap.String("resource_id", updated.ResourceID),
 		zap.String("resource_type_id", SanitizeForLogging(updated.ResourceTypeID)))
 
+	s.indexResources(c.Request.Context(), updated)
+
 	// Audit log the successful update
 	if s.auditLogger != nil {
 		user := auth.UserFromContext(c.Request.Context())
@@ -1816,6 +2154,7 @@ func (s *Server) applyResourceUpdate(c *gin.Context, resourceID string, req, exi
 		)
 	}
 
+	s.setETag(c, updated)
 	c.JSON(http.StatusOK, updated)
 }
 
@@ -1824,35 +2163,39 @@ func (s *Server) applyResourceUpdate(c *gin.Context, resourceID string, req, exi
 // handleListResourceTypes lists all resource types.
 // GET /o2ims/v1/resourceTypes.
 func (s *Server) handleListResourceTypes(c *gin.Context) {
-	s.logger.Info("listing resource types")
+	s.requestLogger(c).Info("listing resource types")
 
 	// Parse filter from request (supports v1 basic and v2+ advanced filtering).
 	filter, err := s.parseFilterFromRequest(c)
 	if err != nil {
-		s.logger.Error("failed to parse filter", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "InvalidParameter",
-			"message": err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		s.requestLogger(c).Error("failed to parse filter", zap.Error(err))
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	// ?limit= and ?nextpage_opaque_marker= select the page.
+	page, err := parseListPage(c, "resourceTypes")
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", err.Error())
 		return
 	}
 
-	// List resource types via adapter.
+	// List one page of resource types via adapter.
+	page.apply(filter)
 	types, err := s.adapter.ListResourceTypes(c.Request.Context(), filter)
+	if err == nil {
+		types, err = cutListPage(c, page, types)
+	}
 	if err != nil {
-		s.logger.Error("failed to list resource types", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve resource types",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to list resource types", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve resource types")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"resourceTypes": types,
-		"total":         len(types),
+	c.JSON(http.StatusOK, o2imsmodels.ListEnvelope[*adapter.ResourceType]{
+		Kind:  "resourceTypes",
+		Items: types,
+		Total: len(types),
 	})
 }
 
@@ -1860,17 +2203,13 @@ func (s *Server) handleListResourceTypes(c *gin.Context) {
 // GET /o2ims/v1/resourceTypes/:resourceTypeId.
 func (s *Server) handleGetResourceType(c *gin.Context) {
 	resourceTypeID := c.Param("resourceTypeId")
-	s.logger.Info("getting resource type", zap.String("resource_type_id", resourceTypeID))
+	s.requestLogger(c).Info("getting resource type", zap.String("resource_type_id", resourceTypeID))
 
 	// Get resource type via adapter
 	resType, err := s.adapter.GetResourceType(c.Request.Context(), resourceTypeID)
 	if err != nil {
-		s.logger.Error("failed to get resource type", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "NotFound",
-			"message": "Resource type not found: " + resourceTypeID,
-			"code":    http.StatusNotFound,
-		})
+		s.requestLogger(c).Error("failed to get resource type", zap.Error(err))
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Resource type not found: "+resourceTypeID)
 		return
 	}
 
@@ -1882,24 +2221,23 @@ func (s *Server) handleGetResourceType(c *gin.Context) {
 // handleListDeploymentManagers lists all deployment managers.
 // GET /o2ims/v1/deploymentManagers.
 func (s *Server) handleListDeploymentManagers(c *gin.Context) {
-	s.logger.Info("listing deployment managers")
+	s.requestLogger(c).Info("listing deployment managers")
 
 	// For now, return a single deployment manager representing this gateway
 	// In multi-cluster setups, this could list multiple managers
 	dm, err := s.adapter.GetDeploymentManager(c.Request.Context(), "default")
 	if err != nil {
-		s.logger.Error("failed to get deployment manager", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve deployment managers",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to get deployment manager", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve deployment managers")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"deploymentManagers": []*adapter.DeploymentManager{dm},
-		"total":              1,
+	dm = s.withProfileSupport(dm)
+
+	c.JSON(http.StatusOK, o2imsmodels.ListEnvelope[*adapter.DeploymentManager]{
+		Kind:  "deploymentManagers",
+		Items: []*adapter.DeploymentManager{dm},
+		Total: 1,
 	})
 }
 
@@ -1907,21 +2245,17 @@ func (s *Server) handleListDeploymentManagers(c *gin.Context) {
 // GET /o2ims/v1/deploymentManagers/:deploymentManagerId.
 func (s *Server) handleGetDeploymentManager(c *gin.Context) {
 	deploymentManagerID := c.Param("deploymentManagerId")
-	s.logger.Info("getting deployment manager", zap.String("deployment_manager_id", deploymentManagerID))
+	s.requestLogger(c).Info("getting deployment manager", zap.String("deployment_manager_id", deploymentManagerID))
 
 	// Get deployment manager via adapter
 	dm, err := s.adapter.GetDeploymentManager(c.Request.Context(), deploymentManagerID)
 	if err != nil {
-		s.logger.Error("failed to get deployment manager", zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "NotFound",
-			"message": "Deployment manager not found: " + deploymentManagerID,
-			"code":    http.StatusNotFound,
-		})
+		s.requestLogger(c).Error("failed to get deployment manager", zap.Error(err))
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Deployment manager not found: "+deploymentManagerID)
 		return
 	}
 
-	c.JSON(http.StatusOK, dm)
+	c.JSON(http.StatusOK, s.withProfileSupport(dm))
 }
 
 // O-Cloud Infrastructure handlers
@@ -1929,56 +2263,54 @@ func (s *Server) handleGetDeploymentManager(c *gin.Context) {
 // handleGetOCloudInfrastructure retrieves O-Cloud infrastructure information.
 // GET /o2ims/v1/oCloudInfrastructure.
 func (s *Server) handleGetOCloudInfrastructure(c *gin.Context) {
-	s.logger.Info("getting O-Cloud infrastructure information")
+	s.requestLogger(c).Info("getting O-Cloud infrastructure information")
 
 	// Get deployment manager to retrieve O-Cloud information
 	dm, err := s.adapter.GetDeploymentManager(c.Request.Context(), "default")
 	if err != nil {
-		s.logger.Error("failed to get O-Cloud information", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "InternalError",
-			"message": "Failed to retrieve O-Cloud information",
-			"code":    http.StatusInternalServerError,
-		})
+		s.requestLogger(c).Error("failed to get O-Cloud information", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve O-Cloud information")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"oCloudId":    dm.OCloudID,
-		"name":        dm.Name,
-		"description": dm.Description,
-		"serviceUri":  dm.ServiceURI,
+	c.JSON(http.StatusOK, o2imsmodels.OCloudInfrastructure{
+		OCloudID:    dm.OCloudID,
+		Name:        dm.Name,
+		Description: dm.Description,
+		ServiceURI:  dm.ServiceURI,
 	})
 }
 
-// Tenant quota handlers (v3)
-// These remain as placeholders until quota management is fully implemented
+// Tenant quota handlers
 
-// handleGetTenantQuotas retrieves tenant quotas.
-// GET /o2ims/v3/tenants/:tenantId/quotas.
+// handleGetTenantQuotas retrieves the quota limits and usage of a tenant.
+// GET /o2ims/v1/tenants/:tenantId/quotas.
 func (s *Server) handleGetTenantQuotas(c *gin.Context) {
 	tenantID := c.Param("tenantId")
-	s.logger.Info("getting tenant quotas", zap.String("tenant_id", tenantID))
-
-	c.JSON(http.StatusOK, gin.H{
-		"tenantId": tenantID,
-		"quotas": gin.H{
-			"maxSubscriptions":  100,
-			"maxResourcePools":  50,
-			"maxResources":      1000,
-			"usedSubscriptions": 10,
-			"usedResourcePools": 5,
-			"usedResources":     100,
-		},
-	})
+	s.requestLogger(c).Info("getting tenant quotas", zap.String("tenant_id", tenantID))
+
+	tenants, ok := s.AuthStore.(tenantQuotaStore)
+	if !ok {
+		s.respondQuotasUnavailable(c)
+		return
+	}
+
+	tenant, err := tenants.GetTenant(c.Request.Context(), tenantID)
+	if err != nil {
+		s.respondTenantError(c, tenantID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tenantQuotas(tenant))
 }
 
-// handleUpdateTenantQuotas updates tenant quotas.
-// PUT /o2ims/v3/tenants/:tenantId/quotas.
+// handleUpdateTenantQuotas updates the quota limits of a tenant. Limits left
+// out of the request are kept; usage counters are never modified.
+// PUT /o2ims/v1/tenants/:tenantId/quotas.
 func (s *Server) handleUpdateTenantQuotas(c *gin.Context) {
 	ctx := c.Request.Context()
 	tenantID := c.Param("tenantId")
-	s.logger.Info("updating tenant quotas", zap.String("tenant_id", tenantID))
+	s.requestLogger(c).Info("updating tenant quotas", zap.String("tenant_id", tenantID))
 
 	var req struct {
 		MaxSubscriptions int `json:"maxSubscriptions,omitempty"`
@@ -1987,11 +2319,40 @@ func (s *Server) handleUpdateTenantQuotas(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "BadRequest",
-			"message": "Invalid request body: " + err.Error(),
-			"code":    http.StatusBadRequest,
-		})
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+	if req.MaxSubscriptions < 0 || req.MaxResourcePools < 0 || req.MaxResources < 0 {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Quota limits must not be negative")
+		return
+	}
+
+	tenants, ok := s.AuthStore.(tenantQuotaStore)
+	if !ok {
+		s.respondQuotasUnavailable(c)
+		return
+	}
+
+	existing, err := tenants.GetTenant(ctx, tenantID)
+	if err != nil {
+		s.respondTenantError(c, tenantID, err)
+		return
+	}
+
+	quota := existing.Quota
+	if req.MaxSubscriptions > 0 {
+		quota.MaxSubscriptions = req.MaxSubscriptions
+	}
+	if req.MaxResourcePools > 0 {
+		quota.MaxResourcePools = req.MaxResourcePools
+	}
+	if req.MaxResources > 0 {
+		quota.MaxResources = req.MaxResources
+	}
+
+	updated, err := tenants.UpdateTenantQuota(ctx, tenantID, quota)
+	if err != nil {
+		s.respondTenantError(c, tenantID, err)
 		return
 	}
 
@@ -1999,43 +2360,63 @@ func (s *Server) handleUpdateTenantQuotas(c *gin.Context) {
 	if s.auditLogger != nil {
 		user := auth.UserFromContext(ctx)
 		if req.MaxSubscriptions > 0 {
-			s.auditLogger.LogQuotaUpdate(ctx, tenantID, user, "maxSubscriptions", 0, req.MaxSubscriptions)
+			s.auditLogger.LogQuotaUpdate(ctx, tenantID, user, "maxSubscriptions",
+				existing.Quota.MaxSubscriptions, req.MaxSubscriptions)
 		}
 		if req.MaxResourcePools > 0 {
-			s.auditLogger.LogQuotaUpdate(ctx, tenantID, user, "maxResourcePools", 0, req.MaxResourcePools)
+			s.auditLogger.LogQuotaUpdate(ctx, tenantID, user, "maxResourcePools",
+				existing.Quota.MaxResourcePools, req.MaxResourcePools)
 		}
 		if req.MaxResources > 0 {
-			s.auditLogger.LogQuotaUpdate(ctx, tenantID, user, "maxResources", 0, req.MaxResources)
+			s.auditLogger.LogQuotaUpdate(ctx, tenantID, user, "maxResources",
+				existing.Quota.MaxResources, req.MaxResources)
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"tenantId": tenantID,
-		"quotas": gin.H{
-			"maxSubscriptions": req.MaxSubscriptions,
-			"maxResourcePools": req.MaxResourcePools,
-			"maxResources":     req.MaxResources,
-		},
-		"updatedAt": "2024-01-01T00:00:00Z",
-	})
+	c.JSON(http.StatusOK, tenantQuotas(updated))
+}
+
+// respondQuotasUnavailable answers quota requests when the auth store cannot
+// manage tenant quotas.
+func (s *Server) respondQuotasUnavailable(c *gin.Context) {
+	problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable", "Tenant quota management is not available")
+}
+
+// respondError answers a failed adapter or store call. Sentinel errors are
+// translated by the shared error translator, extended with the adapter's
+// rules; not-found errors are reported with notFoundMsg and errors the
+// translator doesn't know with failMsg. Server-side failures are logged
+// with logMsg.
+func (s *Server) respondError(c *gin.Context, err error, logMsg, notFoundMsg, failMsg string) {
+	p := httperror.Default.For(s.adapter).Describe(err, notFoundMsg, failMsg)
+	if p.Status >= http.StatusInternalServerError {
+		s.requestLogger(c).Error(logMsg, zap.Error(err))
+	}
+	if isStorageUnavailable(err) {
+		respondStorageUnavailable(c)
+		return
+	}
+	problem.Respond(c, p.Status, p.Code, p.Message)
+}
+
+// respondTenantError answers a failed tenant store operation.
+func (s *Server) respondTenantError(c *gin.Context, tenantID string, err error) {
+	s.respondError(c, err, "tenant store operation failed",
+		"Tenant not found: "+tenantID, "Failed to access tenant quotas")
 }
 
 // ValidateCallback validates a subscription callback URL.
 // It performs early validation to provide fast failure before calling the adapter.
-// Includes SSRF protection to prevent callbacks to localhost and private IP ranges.
+// Includes SSRF protection to prevent callbacks to localhost and private IP ranges,
+// and enforces the configured callback allowlist and denylist. Stored callbacks
+// are re-checked periodically by RevalidateCallbacks.
 //
-// SECURITY NOTE: DNS Rebinding Time-of-Check-Time-of-Use (TOCTOU) Vulnerability
-// This validation only checks the callback URL at registration time. An attacker could:
-// 1. Register a callback URL pointing to a legitimate public server
-// 2. Pass this validation
-// 3. Change DNS records to point the hostname to localhost/private IPs
-// 4. Receive webhooks at the new (malicious) destination
-//
-// Mitigation strategies for production deployments:
-// - Re-validate callback URLs before EACH webhook delivery attempt
-// - Cache DNS results with short TTL and re-validate on changes
-// - Implement webhook delivery through a dedicated egress proxy that enforces policies
-// - Consider additional authentication mechanisms for webhooks (HMAC signatures, mTLS).
+// SECURITY NOTE: DNS Rebinding Time-of-Check-Time-of-Use (TOCTOU)
+// This validation only checks the callback URL at registration time. An attacker could
+// register a callback host resolving to a public server, pass this validation, and then
+// rebind the host to localhost or a private IP to receive webhooks there. Deliveries are
+// therefore made through CallbackDialGuard, which checks the addresses each connection is
+// made to immediately before connecting and refuses private, loopback and metadata ranges.
 func (s *Server) ValidateCallback(ctx context.Context, sub *adapter.Subscription) error {
 	if sub == nil {
 		return fmt.Errorf("subscription cannot be nil")
@@ -2063,13 +2444,14 @@ func (s *Server) ValidateCallback(ctx context.Context, sub *adapter.Subscription
 
 	// SSRF Protection: Block localhost and private IP ranges
 	// Skip SSRF protection if disabled in config (for testing only)
-	if !s.config.Security.DisableSSRFProtection {
+	if !s.callbackSecuritySettings().disableSSRFProtection {
 		if err := ValidateCallbackHost(ctx, parsedURL.Hostname()); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	// Operator allowlist and denylist, enforced even without SSRF protection
+	return s.checkCallbackPolicy(ctx, parsedURL.Hostname())
 }
 
 // ValidateCallbackHost validates that the callback host is not localhost or a private IP address.
@@ -2083,8 +2465,7 @@ func ValidateCallbackHost(ctx context.Context, hostname string) error {
 	// Attempt to resolve hostname to IP
 	// If DNS lookup fails, we allow it - the actual webhook delivery will fail naturally
 	// This prevents blocking valid hostnames that are temporarily unresolvable
-	resolver := &net.Resolver{}
-	ips, _ := resolver.LookupIPAddr(ctx, hostname)
+	ips, _ := resolver.Default().LookupIPAddr(ctx, hostname)
 	if len(ips) == 0 {
 		// No IPs resolved (possibly due to DNS failure), allow it
 		return nil
@@ -2092,7 +2473,7 @@ func ValidateCallbackHost(ctx context.Context, hostname string) error {
 
 	// Check if any resolved IP is in a private range
 	for _, ipAddr := range ips {
-		if IsPrivateIP(ipAddr.IP) {
+		if resolver.BlockedReason(ipAddr.IP) != "" {
 			return fmt.Errorf("callback URL cannot be a private IP address")
 		}
 	}
@@ -2100,14 +2481,6 @@ func ValidateCallbackHost(ctx context.Context, hostname string) error {
 	return nil
 }
 
-// Pre-computed private IP ranges for SSRF protection.
-// These are initialized on first use via sync.Once to avoid runtime parsing overhead.
-var (
-	privateIPv4Nets []*net.IPNet
-	privateIPv6Nets []*net.IPNet
-	privateIPOnce   sync.Once
-)
-
 // logAuditEvent logs an audit event with tenant context if auth store is configured.
 func (s *Server) logAuditEvent(
 	ctx context.Context,
@@ -2124,13 +2497,8 @@ func (s *Server) logAuditEvent(
 	tenantID := auth.TenantIDFromContext(ctx)
 
 	// Extract user information from context if available
-	var userID, subject string
-	if user, exists := c.Get("user"); exists {
-		if authUser, ok := user.(*auth.AuthenticatedUser); ok {
-			userID = authUser.UserID
-			subject = authUser.Subject
-		}
-	}
+	caller := requestcontext.Identity(ctx)
+	userID, subject := caller.UserID, caller.Subject
 
 	event := &auth.AuditEvent{
 		ID:           uuid.New().String(),
@@ -2142,91 +2510,15 @@ func (s *Server) logAuditEvent(
 		ResourceID:   resourceID,
 		Action:       action,
 		Details:      details,
-		ClientIP:     c.ClientIP(),
+		ClientIP:     middleware.GetClientIP(c),
 		UserAgent:    c.Request.UserAgent(),
 	}
 
 	if err := s.AuthStore.LogEvent(ctx, event); err != nil {
-		s.logger.Warn("failed to log audit event",
+		s.requestLogger(c).Warn("failed to log audit event",
 			zap.String("event_type", string(eventType)),
 			zap.String("resource_type", resourceType),
 			zap.String("resource_id", resourceID),
 			zap.Error(err))
 	}
 }
-
-// initPrivateIPRanges initializes the private IP range networks.
-// This is called lazily on first use via sync.Once.
-func initPrivateIPRanges() {
-	// Parse private IPv4 ranges (RFC 1918 + link-local)
-	privateIPv4CIDRs := []string{
-		"10.0.0.0/8",     // Private class A
-		"172.16.0.0/12",  // Private class B
-		"192.168.0.0/16", // Private class C
-		"169.254.0.0/16", // Link-local
-	}
-
-	for _, cidr := range privateIPv4CIDRs {
-		_, network, err := net.ParseCIDR(cidr)
-		if err != nil {
-			// This should never happen with hardcoded CIDRs
-			panic(fmt.Sprintf("invalid IPv4 CIDR in privateIPv4CIDRs: %s: %v", cidr, err))
-		}
-		privateIPv4Nets = append(privateIPv4Nets, network)
-	}
-
-	// Parse private IPv6 ranges
-	privateIPv6CIDRs := []string{
-		"fc00::/7",  // IPv6 unique local addresses (ULA)
-		"fe80::/10", // IPv6 link-local
-	}
-
-	for _, cidr := range privateIPv6CIDRs {
-		_, network, err := net.ParseCIDR(cidr)
-		if err != nil {
-			// This should never happen with hardcoded CIDRs
-			panic(fmt.Sprintf("invalid IPv6 CIDR in privateIPv6CIDRs: %s: %v", cidr, err))
-		}
-		privateIPv6Nets = append(privateIPv6Nets, network)
-	}
-}
-
-// IsPrivateIP checks if an IP address is in a private or reserved range.
-func IsPrivateIP(ip net.IP) bool {
-	if ip.IsLoopback() {
-		return true
-	}
-
-	if isPrivateIPv4(ip) {
-		return true
-	}
-
-	return isPrivateIPv6(ip)
-}
-
-// isPrivateIPv4 checks if an IPv4 address is in a private range (RFC 1918).
-func isPrivateIPv4(ip net.IP) bool {
-	privateIPOnce.Do(initPrivateIPRanges)
-	for _, network := range privateIPv4Nets {
-		if network.Contains(ip) {
-			return true
-		}
-	}
-	return false
-}
-
-// isPrivateIPv6 checks if an IPv6 address is in a private range.
-func isPrivateIPv6(ip net.IP) bool {
-	privateIPOnce.Do(initPrivateIPRanges)
-	// Only check IPv6 addresses
-	if ip.To4() != nil {
-		return false
-	}
-
-	for _, network := range privateIPv6Nets {
-		if network.Contains(ip) {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/middleware"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/rollout"
+)
+
+// runtimeSettingsRollout names the runtime settings rollout in metrics and logs.
+const runtimeSettingsRollout = "runtime_settings"
+
+// RuntimeSettings are the settings that can be changed while the gateway is
+// running. New values are rolled out to a percentage of requests first and
+// promoted only if the error rate does not rise.
+type RuntimeSettings struct {
+	// RequestTimeout bounds the handling of each request. 0 disables it.
+	RequestTimeout time.Duration `json:"requestTimeout"`
+
+	// TenantRequestsPerSecond and TenantBurstSize are the per-tenant rate limits.
+	TenantRequestsPerSecond int `json:"tenantRequestsPerSecond"`
+	TenantBurstSize         int `json:"tenantBurstSize"`
+
+	// GlobalRequestsPerSecond is the global rate limit.
+	GlobalRequestsPerSecond int `json:"globalRequestsPerSecond"`
+}
+
+// runtimeSettingsJSON is the wire format of RuntimeSettings, with the request
+// timeout as a duration string (e.g. "30s").
+type runtimeSettingsJSON struct {
+	RequestTimeout          string `json:"requestTimeout"`
+	TenantRequestsPerSecond *int   `json:"tenantRequestsPerSecond,omitempty"`
+	TenantBurstSize         *int   `json:"tenantBurstSize,omitempty"`
+	GlobalRequestsPerSecond *int   `json:"globalRequestsPerSecond,omitempty"`
+}
+
+// MarshalJSON encodes the settings with the request timeout as a duration string.
+func (r RuntimeSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(runtimeSettingsJSON{
+		RequestTimeout:          r.RequestTimeout.String(),
+		TenantRequestsPerSecond: &r.TenantRequestsPerSecond,
+		TenantBurstSize:         &r.TenantBurstSize,
+		GlobalRequestsPerSecond: &r.GlobalRequestsPerSecond,
+	})
+}
+
+// runtimeSettingsKeys are the configuration keys of the runtime settings.
+var runtimeSettingsKeys = []string{
+	"server.request_timeout",
+	"security.rate_limit.tenant.requests_per_second",
+	"security.rate_limit.tenant.burst_size",
+	"security.rate_limit.global.requests_per_second",
+}
+
+// isRuntimeSettingsKey reports whether the dotted mapstructure key is one of
+// the runtime settings.
+func isRuntimeSettingsKey(key string) bool {
+	return slices.Contains(runtimeSettingsKeys, key)
+}
+
+// RuntimeSettingsFromConfig returns the runtime settings configured at startup.
+func RuntimeSettingsFromConfig(cfg *config.Config) RuntimeSettings {
+	return RuntimeSettings{
+		RequestTimeout:          cfg.Server.RequestTimeout,
+		TenantRequestsPerSecond: cfg.Security.RateLimit.PerTenant.RequestsPerSecond,
+		TenantBurstSize:         cfg.Security.RateLimit.PerTenant.BurstSize,
+		GlobalRequestsPerSecond: cfg.Security.RateLimit.Global.RequestsPerSecond,
+	}
+}
+
+// rolloutPolicyFromConfig returns the default rollout policy, falling back to
+// the built-in defaults for unset values.
+func rolloutPolicyFromConfig(cfg *config.RolloutConfig) rollout.Policy {
+	policy := rollout.DefaultPolicy()
+	if cfg.Percent > 0 {
+		policy.Percent = cfg.Percent
+	}
+	if cfg.BakeTime > 0 {
+		policy.BakeTime = cfg.BakeTime
+	}
+	if cfg.MinRequests > 0 {
+		policy.MinRequests = cfg.MinRequests
+	}
+	if cfg.MaxErrorRateIncrease > 0 {
+		policy.MaxErrorRateIncrease = cfg.MaxErrorRateIncrease
+	}
+	return policy
+}
+
+// runtimeSettingsMiddleware applies the runtime settings selected for each
+// request (stable or canary) and reports the outcome to the rollout (see
+// requestFailed).
+func (s *Server) runtimeSettingsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		settings, variant := s.runtimeSettings.Select()
+
+		if settings.RequestTimeout > 0 {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), settings.RequestTimeout)
+			defer cancel()
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		middleware.SetRateLimitOverride(c, middleware.RateLimitOverride{
+			PerTenant: middleware.TenantLimitConfig{
+				RequestsPerSecond: settings.TenantRequestsPerSecond,
+				BurstSize:         settings.TenantBurstSize,
+			},
+			Global: middleware.GlobalLimitConfig{
+				RequestsPerSecond:     settings.GlobalRequestsPerSecond,
+				MaxConcurrentRequests: s.config.Security.RateLimit.Global.MaxConcurrentRequests,
+			},
+		})
+
+		c.Next()
+
+		s.runtimeSettings.Observe(variant, requestFailed(c))
+	}
+}
+
+// requestFailed reports whether a request counts against the error rate of
+// its runtime settings: besides 5xx responses, this includes requests
+// rejected by the rate limits and requests that ran out of time, which are
+// the failures a bad timeout or rate limit causes.
+func requestFailed(c *gin.Context) bool {
+	switch status := c.Writer.Status(); {
+	case status >= http.StatusInternalServerError,
+		status == http.StatusTooManyRequests,
+		status == http.StatusRequestTimeout:
+		return true
+	}
+	return errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+}
+
+// startRolloutRequest is the body of POST /admin/config/rollout. Omitted
+// settings keep their current stable value; omitted policy fields use the
+// configured defaults.
+type startRolloutRequest struct {
+	Settings             runtimeSettingsJSON `json:"settings"`
+	Percent              int                 `json:"percent,omitempty"`
+	BakeTime             string              `json:"bakeTime,omitempty"`
+	MinRequests          int64               `json:"minRequests,omitempty"`
+	MaxErrorRateIncrease *float64            `json:"maxErrorRateIncrease,omitempty"`
+}
+
+// apply returns the canary settings and policy requested on top of the
+// current stable settings and default policy.
+func (r *startRolloutRequest) apply(
+	stable RuntimeSettings, policy rollout.Policy,
+) (RuntimeSettings, rollout.Policy, error) {
+	canary := stable
+	if r.Settings.RequestTimeout != "" {
+		timeout, err := time.ParseDuration(r.Settings.RequestTimeout)
+		if err != nil || timeout < 0 {
+			return canary, policy, fmt.Errorf("invalid requestTimeout %q", r.Settings.RequestTimeout)
+		}
+		canary.RequestTimeout = timeout
+	}
+	for _, limit := range []struct {
+		name  string
+		value *int
+		dst   *int
+	}{
+		{"tenantRequestsPerSecond", r.Settings.TenantRequestsPerSecond, &canary.TenantRequestsPerSecond},
+		{"tenantBurstSize", r.Settings.TenantBurstSize, &canary.TenantBurstSize},
+		{"globalRequestsPerSecond", r.Settings.GlobalRequestsPerSecond, &canary.GlobalRequestsPerSecond},
+	} {
+		if limit.value == nil {
+			continue
+		}
+		if *limit.value < 0 {
+			return canary, policy, fmt.Errorf("%s cannot be negative", limit.name)
+		}
+		*limit.dst = *limit.value
+	}
+	if canary == stable {
+		return canary, policy, errors.New("settings do not change any runtime setting")
+	}
+
+	if r.Percent != 0 {
+		policy.Percent = r.Percent
+	}
+	if r.BakeTime != "" {
+		bakeTime, err := time.ParseDuration(r.BakeTime)
+		if err != nil {
+			return canary, policy, fmt.Errorf("invalid bakeTime %q", r.BakeTime)
+		}
+		policy.BakeTime = bakeTime
+	}
+	if r.MinRequests != 0 {
+		policy.MinRequests = r.MinRequests
+	}
+	if r.MaxErrorRateIncrease != nil {
+		policy.MaxErrorRateIncrease = *r.MaxErrorRateIncrease
+	}
+	return canary, policy, policy.Validate()
+}
+
+// handleGetRollout returns the runtime settings and the state of the current
+// or last rollout.
+// GET /admin/config/rollout.
+func (s *Server) handleGetRollout(c *gin.Context) {
+	c.JSON(http.StatusOK, s.runtimeSettings.Status())
+}
+
+// handleStartRollout starts rolling out new runtime settings to a percentage
+// of requests.
+// POST /admin/config/rollout.
+func (s *Server) handleStartRollout(c *gin.Context) {
+	var req startRolloutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+
+	canary, policy, err := req.apply(s.runtimeSettings.Stable(), rolloutPolicyFromConfig(&s.config.Rollout))
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	if err := s.runtimeSettings.Start(canary, policy); err != nil {
+		if errors.Is(err, rollout.ErrInProgress) {
+			problem.Respond(c, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusAccepted, s.runtimeSettings.Status())
+}
+
+// handlePromoteRollout promotes the canary runtime settings immediately.
+// POST /admin/config/rollout/promote.
+func (s *Server) handlePromoteRollout(c *gin.Context) {
+	s.finishRollout(c, s.runtimeSettings.Promote)
+}
+
+// handleRollbackRollout discards the canary runtime settings.
+// POST /admin/config/rollout/rollback.
+func (s *Server) handleRollbackRollout(c *gin.Context) {
+	s.finishRollout(c, s.runtimeSettings.Rollback)
+}
+
+// finishRollout ends the current rollout with finish.
+func (s *Server) finishRollout(c *gin.Context, finish func(reason string) error) {
+	if err := finish("requested by administrator"); err != nil {
+		if errors.Is(err, rollout.ErrNotInProgress) {
+			problem.Respond(c, http.StatusConflict, "Conflict", err.Error())
+			return
+		}
+		s.logger.Error("failed to finish rollout", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to finish rollout")
+		return
+	}
+
+	c.JSON(http.StatusOK, s.runtimeSettings.Status())
+}
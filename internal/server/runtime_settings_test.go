@@ -0,0 +1,137 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rolloutStatusResponse struct {
+	State  string `json:"state"`
+	Stable struct {
+		RequestTimeout          string `json:"requestTimeout"`
+		TenantRequestsPerSecond int    `json:"tenantRequestsPerSecond"`
+	} `json:"stable"`
+	Canary *struct {
+		RequestTimeout          string `json:"requestTimeout"`
+		TenantRequestsPerSecond int    `json:"tenantRequestsPerSecond"`
+	} `json:"canary"`
+	Policy *struct {
+		Percent  int    `json:"percent"`
+		BakeTime string `json:"bakeTime"`
+	} `json:"policy"`
+}
+
+func TestRuntimeSettingsRollout(t *testing.T) {
+	const path = "/admin/config/rollout"
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	var status rolloutStatusResponse
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "idle", status.State)
+	assert.Equal(t, "0s", status.Stable.RequestTimeout)
+	assert.Nil(t, status.Canary)
+
+	// Finishing without a rollout in progress conflicts.
+	resp, _ = doResourceRequest(t, srv, http.MethodPost, path+"/promote", nil)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	start := map[string]interface{}{
+		"settings": map[string]interface{}{"requestTimeout": "30s", "tenantRequestsPerSecond": 50},
+		"percent":  20,
+		"bakeTime": "10m",
+	}
+	resp, body = doResourceRequest(t, srv, http.MethodPost, path, start)
+	require.Equal(t, http.StatusAccepted, resp.Code, string(body))
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "in_progress", status.State)
+	require.NotNil(t, status.Canary)
+	assert.Equal(t, "30s", status.Canary.RequestTimeout)
+	assert.Equal(t, 50, status.Canary.TenantRequestsPerSecond)
+	require.NotNil(t, status.Policy)
+	assert.Equal(t, 20, status.Policy.Percent)
+	assert.Equal(t, "10m0s", status.Policy.BakeTime)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodPost, path, start)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp, body = doResourceRequest(t, srv, http.MethodPost, path+"/rollback", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	status = rolloutStatusResponse{}
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "rolled_back", status.State)
+	assert.Equal(t, "0s", status.Stable.RequestTimeout)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodPost, path, start)
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	resp, body = doResourceRequest(t, srv, http.MethodPost, path+"/promote", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+	status = rolloutStatusResponse{}
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, "promoted", status.State)
+	assert.Equal(t, "30s", status.Stable.RequestTimeout)
+	assert.Equal(t, 50, status.Stable.TenantRequestsPerSecond)
+}
+
+func TestRuntimeSettingsRollout_InvalidRequests(t *testing.T) {
+	const path = "/admin/config/rollout"
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{name: "no changes", body: map[string]interface{}{"settings": map[string]interface{}{}}},
+		{name: "invalid timeout", body: map[string]interface{}{
+			"settings": map[string]interface{}{"requestTimeout": "soon"},
+		}},
+		{name: "negative rate limit", body: map[string]interface{}{
+			"settings": map[string]interface{}{"globalRequestsPerSecond": -1},
+		}},
+		{name: "invalid percent", body: map[string]interface{}{
+			"settings": map[string]interface{}{"requestTimeout": "5s"},
+			"percent":  100,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := doResourceRequest(t, srv, http.MethodPost, path, tt.body)
+			assert.Equal(t, http.StatusBadRequest, resp.Code, string(body))
+		})
+	}
+}
+
+func TestRuntimeSettingsRollout_RollsBackOnTimeouts(t *testing.T) {
+	const path = "/admin/config/rollout"
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+
+	// A timeout no request can meet fails every canary request, even though
+	// none of them is answered with a 5xx.
+	start := map[string]interface{}{
+		"settings":    map[string]interface{}{"requestTimeout": "1ns"},
+		"percent":     99,
+		"minRequests": 1,
+	}
+	resp, body := doResourceRequest(t, srv, http.MethodPost, path, start)
+	require.Equal(t, http.StatusAccepted, resp.Code, string(body))
+
+	var status rolloutStatusResponse
+	for range 50 {
+		doResourceRequest(t, srv, http.MethodGet,
+			"/o2ims-infrastructureInventory/v1/resources/550e8400-e29b-41d4-a716-446655440000", nil)
+
+		resp, body = doResourceRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		require.NoError(t, json.Unmarshal(body, &status))
+		if status.State != "in_progress" {
+			break
+		}
+	}
+	assert.Equal(t, "rolled_back", status.State)
+}
@@ -11,24 +11,35 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/piwi3910/netweave/internal/adapter"
 	"github.com/piwi3910/netweave/internal/auth"
 	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/cost"
+	dmsevents "github.com/piwi3910/netweave/internal/dms/events"
 	dmshandlers "github.com/piwi3910/netweave/internal/dms/handlers"
 	dmsregistry "github.com/piwi3910/netweave/internal/dms/registry"
 	dmsstorage "github.com/piwi3910/netweave/internal/dms/storage"
+	"github.com/piwi3910/netweave/internal/eventbus"
+	"github.com/piwi3910/netweave/internal/export"
 	"github.com/piwi3910/netweave/internal/handlers"
+	"github.com/piwi3910/netweave/internal/hooks"
 	"github.com/piwi3910/netweave/internal/middleware"
 	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/provisioning"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+	"github.com/piwi3910/netweave/internal/rollout"
 	"github.com/piwi3910/netweave/internal/smo"
 	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/validation"
+	"github.com/piwi3910/netweave/internal/webhookca"
 )
 
 // o2imsOpenAPISpec embeds the O2-IMS OpenAPI specification.
@@ -60,29 +71,70 @@ var o2imsOpenAPISpec []byte
 //	    log.Fatal(err)
 //	}
 type Server struct {
-	config           *config.Config
-	logger           *zap.Logger
-	router           *gin.Engine
-	httpServer       *http.Server
-	metrics          *Metrics
-	adapter          adapter.Adapter
-	store            storage.Store
-	healthCheck      *observability.HealthChecker
-	openAPIValidator *middleware.OpenAPIValidator
-	openAPISpec      []byte
+	config            *config.Config
+	logger            *zap.Logger
+	router            *gin.Engine
+	httpServer        *http.Server
+	httpMetrics       *observability.Metrics
+	adapter           adapter.Adapter
+	store             storage.Store
+	healthCheck       *observability.HealthChecker
+	openAPIValidator  *middleware.OpenAPIValidator
+	openAPISpec       []byte
+	versionConfig     *VersionConfig
+	versionAdoption   *VersionAdoptionTracker
+	configHistory     storage.ConfigHistoryStore
+	signingKeys       storage.SigningKeyStore
+	webhookCA         *webhookca.Authority
+	subscriptionStats storage.SubscriptionStatsStore
+	deliverability    storage.DeliverabilityStore
+	streamDrainer     *StreamDrainer
+	resourceIndex     storage.ResourceIndex
+	indexScans        indexScans
+	readCache         *storage.LocalCache
+	cacheBus          *storage.CacheInvalidationBus
+	stopCacheBus      context.CancelFunc
+	stopCertReload    context.CancelFunc
+	callbackSecurity  atomic.Pointer[callbackSecurity]
+	metricsDisabled   atomic.Bool
+	reloadMu          sync.Mutex
+	appliedConfig     *config.Config
+	hooks             *hooks.Runner
+	validationRules   *validation.Engine
+	listSnapshots     storage.ListSnapshotStore
+	runtimeSettings   *rollout.Controller[RuntimeSettings]
+	egressTargets     *EgressTargetTracker
+	revalidation      callbackRevalidation
+	listChanges       *listChangeNotifier
+	eventBus          *eventbus.Bus
+	inventoryChanges  *eventbus.Topic[InventoryChange]
+	reconciliations   storage.ReconciliationStore
+	exportStore       export.ObjectStore
+	exportClaims      storage.RunClaims
+	complianceScores  map[string]float64
+	pricing           *cost.Pricing
+	debugTaps         *debugTaps
 
 	// Handlers
 	batchHandler  *handlers.BatchHandler
 	tenantHandler *handlers.TenantHandler
 
 	// DMS subsystem.
-	dmsRegistry *dmsregistry.Registry
-	dmsStore    dmsstorage.Store
-	dmsHandler  *dmshandlers.Handler
+	dmsRegistry      *dmsregistry.Registry
+	dmsStore         dmsstorage.Store
+	dmsJobs          dmsstorage.JobStore
+	dmsRegistrations dmsstorage.RegistrationStore
+	dmsQuotaUsage    dmsstorage.QuotaUsageStore
+	dmsHandler       *dmshandlers.Handler
+	dmsEvents        *dmsevents.Engine
 
 	smoRegistry *smo.Registry
 	smoHandler  *SMOHandler
 
+	// Infrastructure provisioning subsystem.
+	provisioning         *provisioning.Manager
+	provisioningNotifier *provisioning.WebhookNotifier
+
 	// TMForum subsystem
 	tmfHandler *handlers.TMForumHandler
 
@@ -110,18 +162,14 @@ type AuthMiddleware interface {
 	RequirePlatformAdmin() gin.HandlerFunc
 }
 
-// Metrics holds Prometheus metrics for the server.
-type Metrics struct {
-	RequestsTotal   *prometheus.CounterVec
-	RequestDuration *prometheus.HistogramVec
-	ActiveRequests  prometheus.Gauge
-}
-
 // New creates a new Server instance with the given configuration, logger, adapter, and storage.
 // It initializes the Gin router, sets up middleware, and configures routes.
 //
 // The authStore parameter is optional. If provided, enables multi-tenancy and RBAC features.
 //
+// The adapter may be nil only in dms-only mode (server.mode), in which case the
+// O2-IMS, TMF639, and GraphQL routes are not registered.
+//
 // The function will panic if essential dependencies are missing or invalid.
 //
 // Example:
@@ -139,7 +187,7 @@ func New(cfg *config.Config, logger *zap.Logger, adp adapter.Adapter, store stor
 	if logger == nil {
 		panic("logger cannot be nil")
 	}
-	if adp == nil {
+	if adp == nil && cfg.Server.IMSEnabled() {
 		panic("adapter cannot be nil")
 	}
 	if store == nil {
@@ -152,14 +200,29 @@ func New(cfg *config.Config, logger *zap.Logger, adp adapter.Adapter, store stor
 	// Create Gin router
 	router := gin.New()
 
-	// Initialize metrics
-	metrics := initMetrics(cfg)
+	// Configure trusted proxies so the real client IP is derived correctly behind ingress
+	if err := middleware.ConfigureTrustedProxies(router, &middleware.TrustedProxyConfig{
+		TrustedProxies:  cfg.Server.TrustedProxies,
+		RemoteIPHeaders: cfg.Server.RemoteIPHeaders,
+	}); err != nil {
+		logger.Warn("failed to configure trusted proxies, forwarding headers will be ignored",
+			zap.Error(err),
+		)
+		_ = router.SetTrustedProxies(nil)
+	}
 
 	// Initialize global observability metrics
-	globalMetrics := observability.InitMetrics(cfg.Observability.Metrics.Namespace)
+	if cfg.Observability.Metrics.IsUsingDeprecatedSubsystem() {
+		logger.Warn("observability.metrics.subsystem is deprecated and ignored: HTTP metrics are named "+
+			"<namespace>_http_* instead of <namespace>_<subsystem>_http_*; update dashboards and alerts "+
+			"and remove the setting (see BREAKING_CHANGES.md)",
+			zap.String("subsystem", cfg.Observability.Metrics.Subsystem))
+	}
+	globalMetrics := observability.InitMetrics(cfg.Observability.Metrics.Namespace, metricsOptions(cfg)...)
 
 	// Initialize health checker with adapter and storage checks
 	healthCheck := initHealthChecker(cfg, adp, store, authStore)
+	healthCheck.SetMetrics(globalMetrics)
 
 	// Initialize OpenAPI validator
 	openAPIValidator, err := initOpenAPIValidator(cfg, logger)
@@ -169,8 +232,11 @@ func New(cfg *config.Config, logger *zap.Logger, adp adapter.Adapter, store stor
 		)
 	}
 
-	// Initialize batch handler
-	batchHandler := handlers.NewBatchHandler(adp, store, logger, globalMetrics)
+	// Initialize batch handler (batch routes are part of the O2-IMS API)
+	var batchHandler *handlers.BatchHandler
+	if adp != nil {
+		batchHandler = handlers.NewBatchHandler(adp, store, logger, globalMetrics)
+	}
 
 	// Initialize auth middleware and tenant handler if auth store is provided
 	var authMw AuthMiddleware
@@ -178,20 +244,23 @@ func New(cfg *config.Config, logger *zap.Logger, adp adapter.Adapter, store stor
 	var tenantHandler *handlers.TenantHandler
 	if authStore != nil {
 		authMwConfig := &auth.MiddlewareConfig{
-			Enabled:     true,
-			RequireMTLS: cfg.MultiTenancy.RequireMTLS,
-			SkipPaths:   []string{"/health", "/healthz", "/ready", "/readyz", "/metrics"},
+			Enabled:          true,
+			RequireMTLS:      cfg.MultiTenancy.RequireMTLS,
+			SkipPaths:        []string{"/health", "/healthz", "/ready", "/readyz", "/metrics"},
+			Policies:         AuthPoliciesFromConfig(&cfg.MultiTenancy),
+			IdentityMappings: IdentityMappingsFromConfig(&cfg.MultiTenancy),
 		}
 		// Type assert authStore to auth.Store for middleware initialization
 		authStoreTyped, ok := authStore.(auth.Store)
 		if !ok {
 			logger.Warn("auth store does not implement auth.Store interface, auth middleware disabled")
 		} else {
-			authMw = auth.NewMiddleware(authStoreTyped, authMwConfig, logger)
+			authLogger := observability.ModuleLogger(logger, observability.ModuleAuth)
+			authMw = auth.NewMiddleware(authStoreTyped, authMwConfig, authLogger)
 
 			// Initialize audit logger with the same auth store
 			var err error
-			auditLogger, err = auth.NewAuditLogger(authStoreTyped, logger)
+			auditLogger, err = auth.NewAuditLogger(authStoreTyped, authLogger)
 			if err != nil {
 				logger.Warn("failed to initialize audit logger", zap.Error(err))
 			}
@@ -206,7 +275,7 @@ func New(cfg *config.Config, logger *zap.Logger, adp adapter.Adapter, store stor
 		config:           cfg,
 		logger:           logger,
 		router:           router,
-		metrics:          metrics,
+		httpMetrics:      globalMetrics,
 		adapter:          adp,
 		store:            store,
 		healthCheck:      healthCheck,
@@ -217,6 +286,8 @@ func New(cfg *config.Config, logger *zap.Logger, adp adapter.Adapter, store stor
 		AuthStore:        authStore,
 		authMw:           authMw,
 		auditLogger:      auditLogger,
+		streamDrainer:    NewStreamDrainer(cfg.Server.StreamReconnectDelay, logger),
+		runtimeSettings:  rollout.NewController(runtimeSettingsRollout, RuntimeSettingsFromConfig(cfg), logger),
 	}
 
 	// Setup middleware
@@ -266,7 +337,8 @@ func initHealthChecker(
 		})
 	}
 
-	// Register readiness checks (same components for now)
+	// Register readiness checks. The inventory is served from the adapter, so
+	// without storage the gateway is degraded (no subscriptions) but ready.
 	if adp != nil {
 		checker.RegisterReadinessCheck("adapter", func(ctx context.Context) error {
 			return adp.Health(ctx)
@@ -274,59 +346,27 @@ func initHealthChecker(
 	}
 
 	if store != nil {
-		checker.RegisterReadinessCheck("storage", func(ctx context.Context) error {
-			return store.Ping(ctx)
-		})
+		checker.RegisterReadinessCheckWithCriticality("storage", observability.CriticalityInformational,
+			func(ctx context.Context) error {
+				return store.Ping(ctx)
+			})
 	}
 
 	return checker
 }
 
-// initMetrics initializes Prometheus metrics for the server.
-func initMetrics(cfg *config.Config) *Metrics {
-	if !cfg.Observability.Metrics.Enabled {
-		return nil
+// metricsOptions returns the optional features of the observability metrics:
+// native histograms when configured, and trace ID exemplars when tracing is
+// enabled.
+func metricsOptions(cfg *config.Config) []observability.MetricsOption {
+	var opts []observability.MetricsOption
+	if cfg.Observability.Metrics.NativeHistograms {
+		opts = append(opts, observability.WithNativeHistograms())
 	}
-
-	namespace := cfg.Observability.Metrics.Namespace
-	subsystem := cfg.Observability.Metrics.Subsystem
-
-	metrics := &Metrics{
-		RequestsTotal: prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Namespace: namespace,
-				Subsystem: subsystem,
-				Name:      "http_requests_total",
-				Help:      "Total number of HTTP requests",
-			},
-			[]string{"method", "path", "status"},
-		),
-		RequestDuration: prometheus.NewHistogramVec(
-			prometheus.HistogramOpts{
-				Namespace: namespace,
-				Subsystem: subsystem,
-				Name:      "http_request_duration_seconds",
-				Help:      "HTTP request duration in seconds",
-				Buckets:   prometheus.DefBuckets,
-			},
-			[]string{"method", "path", "status"},
-		),
-		ActiveRequests: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: namespace,
-				Subsystem: subsystem,
-				Name:      "http_requests_active",
-				Help:      "Number of active HTTP requests",
-			},
-		),
-	}
-
-	// Register metrics
-	prometheus.MustRegister(metrics.RequestsTotal)
-	prometheus.MustRegister(metrics.RequestDuration)
-	prometheus.MustRegister(metrics.ActiveRequests)
-
-	return metrics
+	if cfg.Observability.Tracing.Enabled {
+		opts = append(opts, observability.WithExemplars())
+	}
+	return opts
 }
 
 // initOpenAPIValidator initializes the OpenAPI validator with the embedded spec.
@@ -373,15 +413,50 @@ func (s *Server) setupMiddleware() {
 	// Recovery middleware - must be first to catch panics
 	s.router.Use(s.RecoveryMiddleware())
 
+	// Version negotiation middleware - route requests to the versionless
+	// O2-IMS base path to the version requested in the Accept header before
+	// the other middleware runs
+	s.router.Use(s.versionNegotiationMiddleware())
+
+	// Request ID middleware - assign request and correlation IDs before
+	// anything logs so every log line of a request carries them
+	s.router.Use(middleware.RequestID())
+
+	// Client IP middleware - derive the real client IP once for audit, rate limiting, and logs
+	s.router.Use(middleware.ClientIP())
+	s.router.Use(s.clientContextMiddleware())
+
+	// Stale read middleware - mark responses served from the read fallback
+	// cache during Redis outages
+	if s.config.Redis.ReadFallback.Enabled {
+		s.router.Use(s.staleReadMiddleware())
+	}
+
 	// Security headers middleware - add early to ensure headers are set
 	s.router.Use(s.securityHeadersMiddleware())
 
 	// Request logging middleware
 	s.router.Use(s.LoggingMiddleware())
 
-	// Metrics middleware (if enabled)
+	// Debug tap middleware - capture sanitized bodies of requests matching a
+	// debug tap started via the admin API
+	if s.config.Observability.DebugTap.Enabled {
+		s.debugTaps = newDebugTaps(s.config)
+		s.router.Use(s.debugTapMiddleware())
+	}
+
+	// Stream drain middleware - track SSE, log follow, WebSocket, and long-poll
+	// requests so they can be told to reconnect on shutdown
+	if s.streamDrainer != nil {
+		s.router.Use(s.streamDrainer.Middleware())
+	}
+
+	// Metrics middleware (if enabled); a configuration reload can pause it
 	if s.config.Observability.Metrics.Enabled {
-		s.router.Use(s.MetricsMiddleware())
+		if s.httpMetrics != nil {
+			s.router.Use(s.whenMetricsEnabled(observability.HTTPMetricsMiddleware(
+				s.httpMetrics, observability.WithAPIVersionLabel(apiVersionLabel))))
+		}
 	}
 
 	// CORS middleware (if enabled)
@@ -389,6 +464,10 @@ func (s *Server) setupMiddleware() {
 		s.router.Use(s.corsMiddleware())
 	}
 
+	// Runtime settings middleware - applies the request timeout and rate limits
+	// selected for the request, which differ while new settings are rolled out
+	s.router.Use(s.runtimeSettingsMiddleware())
+
 	// Rate limiting middleware (if enabled)
 	if s.config.Security.RateLimitEnabled {
 		s.router.Use(s.rateLimitMiddleware())
@@ -459,6 +538,19 @@ func (s *Server) Start() error {
 		MaxHeaderBytes: s.config.Server.MaxHeaderBytes,
 	}
 
+	// Serve the certificate through a reloader so that rotated files (e.g.
+	// a cert-manager renewal) are picked up without a restart.
+	if s.config.TLS.Enabled {
+		reloader, tlsConfig, err := s.newCertReloader()
+		if err != nil {
+			return err
+		}
+		s.httpServer.TLSConfig = tlsConfig
+		reloadCtx, stop := context.WithCancel(context.Background())
+		s.stopCertReload = stop
+		go reloader.Run(reloadCtx)
+	}
+
 	// Channel to listen for errors from the server
 	serverErrors := make(chan error, 1)
 
@@ -474,11 +566,10 @@ func (s *Server) Start() error {
 			s.logger.Info("TLS enabled",
 				zap.String("cert_file", s.config.TLS.CertFile),
 				zap.String("min_version", s.config.TLS.MinVersion),
+				zap.String("client_auth", s.config.TLS.ClientAuth),
+				zap.Bool("spiffe", s.config.TLS.SPIFFE.Enabled),
 			)
-			err = s.httpServer.ListenAndServeTLS(
-				s.config.TLS.CertFile,
-				s.config.TLS.KeyFile,
-			)
+			err = s.httpServer.ListenAndServeTLS("", "")
 		} else {
 			err = s.httpServer.ListenAndServe()
 		}
@@ -513,10 +604,11 @@ func (s *Server) Start() error {
 //
 // Returns an error if the shutdown fails.
 func (s *Server) Shutdown() error {
-	// Create shutdown context with timeout
+	// Create shutdown context with timeout. Streams get their own drain window
+	// on top of the regular shutdown timeout, which bounds regular requests.
 	ctx, cancel := context.WithTimeout(
 		context.Background(),
-		s.config.Server.ShutdownTimeout,
+		s.config.Server.ShutdownTimeout+s.config.Server.StreamDrainTimeout,
 	)
 	defer cancel()
 
@@ -541,12 +633,45 @@ func (s *Server) shutdownWithContext(ctx context.Context) error {
 			}
 		}
 
+		// Stop applying cache invalidations from other replicas
+		if s.stopCacheBus != nil {
+			s.stopCacheBus()
+		}
+
+		// Stop watching the certificate files
+		if s.stopCertReload != nil {
+			s.stopCertReload()
+		}
+
+		// Drain long-running streams alongside the HTTP shutdown. Shutdown waits
+		// for in-flight requests but not for hijacked WebSocket connections, so
+		// wait for the drain to finish as well. Regular requests keep their own
+		// window: ctx also covers the stream drain window, so the requests still
+		// running after ShutdownTimeout are cancelled.
+		streamsDrained := make(chan struct{})
+		go func() {
+			defer close(streamsDrained)
+			if s.streamDrainer != nil {
+				go s.streamDrainer.DrainRequests(ctx, s.config.Server.ShutdownTimeout)
+				s.streamDrainer.Drain(ctx, s.config.Server.StreamDrainTimeout)
+			}
+		}()
+
 		// Shutdown HTTP server
 		if err := s.httpServer.Shutdown(ctx); err != nil {
 			s.logger.Error("error during shutdown", zap.Error(err))
 			shutdownErr = fmt.Errorf("server shutdown failed: %w", err)
 			return
 		}
+		<-streamsDrained
+
+		// No more changes are published once requests have completed; let
+		// consumers finish the changes already queued.
+		if s.eventBus != nil {
+			if err := s.eventBus.Drain(ctx); err != nil {
+				s.logger.Warn("event bus did not drain", zap.Error(err))
+			}
+		}
 
 		s.logger.Info("server shutdown complete")
 	})
@@ -563,15 +688,83 @@ func (s *Server) Router() *gin.Engine {
 // SetHealthChecker sets the health checker for the server.
 // This allows the main application to configure health checks after server creation.
 func (s *Server) SetHealthChecker(hc *observability.HealthChecker) {
+	if hc != nil && s.httpMetrics != nil {
+		hc.SetMetrics(s.httpMetrics)
+	}
 	s.healthCheck = hc
 }
 
+// dmsRestoreTimeout bounds how long SetupDMS spends registering the persisted
+// DMS adapters.
+const dmsRestoreTimeout = 30 * time.Second
+
 // SetupDMS initializes the DMS subsystem with the provided registry.
 // This must be called after creating the server to enable O2-DMS API endpoints.
 func (s *Server) SetupDMS(reg *dmsregistry.Registry) {
 	s.dmsRegistry = reg
-	s.dmsStore = dmsstorage.NewMemoryStore()
-	s.dmsHandler = dmshandlers.NewHandler(reg, s.dmsStore, s.logger)
+	if s.dmsStore == nil {
+		s.dmsStore = dmsstorage.NewMemoryStore()
+	}
+	s.dmsHandler = dmshandlers.NewHandler(reg, s.dmsStore, observability.ModuleLogger(s.logger, observability.ModuleDMS))
+
+	// Enforce tenant DMS quotas when the auth store can resolve tenant quotas.
+	if provider, ok := s.AuthStore.(dmshandlers.QuotaProvider); ok {
+		if s.dmsQuotaUsage == nil {
+			s.dmsQuotaUsage = dmsstorage.NewMemoryQuotaUsageStore()
+		}
+		s.dmsHandler.SetQuotaEnforcer(dmshandlers.NewQuotaEnforcer(provider, s.dmsQuotaUsage))
+	}
+	if s.pricing != nil {
+		s.dmsHandler.SetPricing(s.pricing)
+	}
+	s.dmsHandler.SetValidationRules(s.validationRules)
+
+	// Register the adapters registered through the API before the restart.
+	if s.dmsRegistrations != nil {
+		s.dmsHandler.SetRegistrationStore(s.dmsRegistrations)
+		ctx, cancel := context.WithTimeout(context.Background(), dmsRestoreTimeout)
+		if err := s.dmsHandler.SyncDMSAdapters(ctx); err != nil {
+			s.logger.Error("failed to restore DMS adapters", zap.Error(err))
+		}
+		cancel()
+	}
+	if s.config != nil {
+		s.dmsHandler.SetMaxSubscriptionTTL(s.config.Subscriptions.MaxTTL)
+	}
+
+	// Answer waiting reconcile requests before the write timeout closes the connection.
+	if s.config != nil && s.config.Server.WriteTimeout > time.Second {
+		maxWait := min(dmshandlers.DefaultMaxReconcileWait, s.config.Server.WriteTimeout-time.Second)
+		s.dmsHandler.SetMaxReconcileWait(maxWait)
+	}
+
+	// Notify DMS subscribers of deployment state transitions.
+	if s.config != nil && s.config.DMS.Notifications.Enabled {
+		n := s.config.DMS.Notifications
+		deliveries := dmsstorage.NewMemoryDeliveryStore(n.History)
+		s.dmsHandler.SetDeliveryStore(deliveries)
+		s.dmsEvents = dmsevents.NewEngine(reg, s.dmsStore, deliveries, dmsevents.Config{
+			Interval:     n.Interval,
+			Timeout:      n.Timeout,
+			MaxRetries:   n.MaxRetries,
+			RetryBackoff: n.RetryBackoff,
+			HMACSecret:   s.config.Notifications.HMACSecret,
+			DialGuard:    s.CallbackDialGuard("dms"),
+		}, observability.ModuleLogger(s.logger, observability.ModuleDMS).Named("notifications"))
+	}
+
+	// Run mutating NF deployment operations as asynchronous jobs.
+	if s.config != nil && s.config.DMS.Jobs.Enabled {
+		j := s.config.DMS.Jobs
+		if s.dmsJobs == nil {
+			s.dmsJobs = dmsstorage.NewMemoryJobStore(j.Retention)
+		}
+		s.dmsHandler.EnableJobs(s.dmsJobs, dmshandlers.JobConfig{
+			Workers:      j.Workers,
+			QueueSize:    j.QueueSize,
+			LeaseTimeout: j.LeaseTimeout,
+		})
+	}
 
 	// Set up DMS routes.
 	s.setupDMSRoutes(s.dmsHandler)
@@ -594,6 +787,73 @@ func (s *Server) SetupDMS(reg *dmsregistry.Registry) {
 	s.logger.Info("TMForum API initialized", zap.Int("apis", 2))
 }
 
+// StartDMSNotifications runs the DMS notification engine until ctx is
+// canceled. It is a no-op when DMS notifications are disabled or SetupDMS was
+// not called.
+func (s *Server) StartDMSNotifications(ctx context.Context) {
+	if s.dmsEvents != nil {
+		s.dmsEvents.Start(ctx)
+	}
+}
+
+// StartDMSAdapterSync applies the DMS adapter registrations made and removed
+// on other replicas every dms.registrations.sync_interval until ctx is
+// canceled. It is a no-op without a registration store or when SetupDMS was
+// not called.
+func (s *Server) StartDMSAdapterSync(ctx context.Context) {
+	if s.dmsHandler != nil && s.config != nil {
+		s.dmsHandler.StartAdapterSync(ctx, s.config.DMS.Registrations.SyncInterval)
+	}
+}
+
+// StartDMSJobs runs the DMS job workers until ctx is canceled. It is a no-op
+// when DMS jobs are disabled or SetupDMS was not called.
+func (s *Server) StartDMSJobs(ctx context.Context) {
+	if s.dmsHandler != nil {
+		s.dmsHandler.StartJobs(ctx)
+	}
+}
+
+// DrainDMSJobs stops the DMS job workers taking new jobs and waits for the
+// running ones until ctx expires. It is a no-op when DMS jobs are disabled or
+// SetupDMS was not called.
+func (s *Server) DrainDMSJobs(ctx context.Context) error {
+	if s.dmsHandler == nil {
+		return nil
+	}
+	if err := s.dmsHandler.DrainJobs(ctx); err != nil {
+		return fmt.Errorf("failed to drain DMS jobs: %w", err)
+	}
+	return nil
+}
+
+// SetDMSJobStore sets the store persisting DMS jobs. It must be called before
+// SetupDMS; without it jobs are kept in memory and lost on restart.
+func (s *Server) SetDMSJobStore(store dmsstorage.JobStore) {
+	s.dmsJobs = store
+}
+
+// SetDMSRegistrationStore sets the store persisting the DMS adapters
+// registered through the API. It must be called before SetupDMS, which
+// registers the persisted adapters again; without it registrations are lost
+// on restart and not shared by replicas.
+func (s *Server) SetDMSRegistrationStore(store dmsstorage.RegistrationStore) {
+	s.dmsRegistrations = store
+}
+
+// SetDMSQuotaUsageStore sets the store recording the DMS quota usage of each
+// tenant. It must be called before SetupDMS; without it usage is kept in
+// memory, so it is lost on restart and not shared by replicas.
+func (s *Server) SetDMSQuotaUsageStore(store dmsstorage.QuotaUsageStore) {
+	s.dmsQuotaUsage = store
+}
+
+// SetDMSStore sets the DMS subscription store. It must be called before
+// SetupDMS; without it DMS subscriptions are kept in memory.
+func (s *Server) SetDMSStore(store dmsstorage.Store) {
+	s.dmsStore = store
+}
+
 // DMSRegistry returns the DMS adapter registry.
 func (s *Server) DMSRegistry() *dmsregistry.Registry {
 	return s.dmsRegistry
@@ -657,22 +917,37 @@ func (s *Server) RecoveryMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				s.logger.Error("panic recovered",
+				s.requestLogger(c).Error("panic recovered",
 					zap.Any("error", err),
 					zap.String("method", c.Request.Method),
 					zap.String("path", c.Request.URL.Path),
-					zap.String("client_ip", c.ClientIP()),
+					zap.String("client_ip", middleware.GetClientIP(c)),
 				)
 
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"error": "Internal server error",
-				})
+				problem.Abort(c, http.StatusInternalServerError, "InternalError", "Internal server error")
 			}
 		}()
 		c.Next()
 	}
 }
 
+// clientContextMiddleware propagates the derived client IP and user agent into the
+// request context so that audit events recorded outside of Gin handlers carry them.
+func (s *Server) clientContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := auth.WithClientIP(c.Request.Context(), middleware.GetClientIP(c))
+		ctx = auth.WithUserAgent(ctx, c.Request.UserAgent())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// requestLogger returns the server logger with the request and correlation IDs
+// of c, so the log lines of one API call can be stitched together.
+func (s *Server) requestLogger(c *gin.Context) *zap.Logger {
+	return s.logger.With(observability.ExtractContextFields(c.Request.Context())...)
+}
+
 // LoggingMiddleware logs HTTP requests and responses.
 func (s *Server) LoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -687,12 +962,13 @@ func (s *Server) LoggingMiddleware() gin.HandlerFunc {
 		latency := time.Since(start)
 
 		// Log request details
-		s.logger.Info("HTTP request",
+		logger := s.requestLogger(c)
+		logger.Info("HTTP request",
 			zap.Int("status", c.Writer.Status()),
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
 			zap.String("query", query),
-			zap.String("client_ip", c.ClientIP()),
+			zap.String("client_ip", middleware.GetClientIP(c)),
 			zap.Duration("latency", latency),
 			zap.Int("body_size", c.Writer.Size()),
 			zap.String("user_agent", c.Request.UserAgent()),
@@ -701,49 +977,35 @@ func (s *Server) LoggingMiddleware() gin.HandlerFunc {
 		// Log errors if any
 		if len(c.Errors) > 0 {
 			for _, e := range c.Errors {
-				s.logger.Error("request error", zap.Error(e.Err))
+				logger.Error("request error", zap.Error(e.Err))
 			}
 		}
 	}
 }
 
-// MetricsMiddleware collects Prometheus metrics for HTTP requests.
-func (s *Server) MetricsMiddleware() gin.HandlerFunc {
+// whenMetricsEnabled runs next unless metrics were disabled by a
+// configuration reload.
+func (s *Server) whenMetricsEnabled(next gin.HandlerFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if s.metrics == nil {
+		if s.metricsDisabled.Load() {
 			c.Next()
 			return
 		}
+		next(c)
+	}
+}
 
-		start := time.Now()
-		path := c.FullPath()
-		if path == "" {
-			path = c.Request.URL.Path
-		}
-
-		// Increment active requests
-		s.metrics.ActiveRequests.Inc()
-		defer s.metrics.ActiveRequests.Dec()
-
-		// Process request
-		c.Next()
-
-		// Record metrics
-		duration := time.Since(start).Seconds()
-		status := fmt.Sprintf("%d", c.Writer.Status())
-
-		s.metrics.RequestsTotal.WithLabelValues(
-			c.Request.Method,
-			path,
-			status,
-		).Inc()
-
-		s.metrics.RequestDuration.WithLabelValues(
-			c.Request.Method,
-			path,
-			status,
-		).Observe(duration)
+// apiVersionLabel returns the API version for metric labels. It prefers the version
+// resolved by VersioningMiddleware and falls back to the version in the route
+// template, which unlike the request path has a bounded set of values.
+func apiVersionLabel(c *gin.Context) string {
+	if version := requestcontext.APIVersion(c.Request.Context()); version != "" {
+		return version
+	}
+	if version := ExtractVersionFromPath(c.FullPath()); version != "" {
+		return version
 	}
+	return observability.NoAPIVersion
 }
 
 // corsMiddleware adds CORS headers to responses.
@@ -802,6 +1064,10 @@ func (s *Server) rateLimitMiddleware() gin.HandlerFunc {
 			RequestsPerSecond: s.config.Security.RateLimit.PerTenant.RequestsPerSecond,
 			BurstSize:         s.config.Security.RateLimit.PerTenant.BurstSize,
 		},
+		PerClient: middleware.ClientLimitConfig{
+			RequestsPerSecond: s.config.Security.RateLimit.PerClient.RequestsPerSecond,
+			BurstSize:         s.config.Security.RateLimit.PerClient.BurstSize,
+		},
 		Global: middleware.GlobalLimitConfig{
 			RequestsPerSecond:     s.config.Security.RateLimit.Global.RequestsPerSecond,
 			MaxConcurrentRequests: s.config.Security.RateLimit.Global.MaxConcurrentRequests,
@@ -818,6 +1084,16 @@ func (s *Server) rateLimitMiddleware() gin.HandlerFunc {
 		})
 	}
 
+	// Convert route group configs
+	for _, group := range s.config.Security.RateLimit.RouteGroups {
+		rateLimitConfig.RouteGroups = append(rateLimitConfig.RouteGroups, middleware.RouteGroupLimitConfig{
+			Name:              group.Name,
+			PathPrefix:        group.PathPrefix,
+			RequestsPerSecond: group.RequestsPerSecond,
+			BurstSize:         group.BurstSize,
+		})
+	}
+
 	// Create rate limiter
 	rateLimiter, err := middleware.NewRateLimiter(rateLimitConfig, s.logger)
 	if err != nil {
@@ -0,0 +1,324 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const (
+	// DefaultSigningKeyValidity is how long both the old and the new webhook
+	// signing secret sign notifications after a rotation when none is given.
+	DefaultSigningKeyValidity = 24 * time.Hour
+
+	// MaxSigningKeyValidity bounds the dual-secret window of a rotation.
+	MaxSigningKeyValidity = 30 * 24 * time.Hour
+
+	// MinSigningSecretLength is the minimum length of a supplied signing secret.
+	MinSigningSecretLength = 32
+
+	// SigningKeyRotationEventType is the notification sent to every
+	// subscription when a signing secret rotation is scheduled.
+	SigningKeyRotationEventType = "o2ims.Webhook.SigningKeyRotation"
+
+	// generatedSecretBytes is the entropy of generated signing secrets.
+	generatedSecretBytes = 32
+)
+
+// SigningKeyStatus describes the webhook signing secrets without revealing
+// them. GET /admin/webhooks/signing-keys.
+type SigningKeyStatus struct {
+	// Signed reports whether notifications are signed at all.
+	Signed bool `json:"signed"`
+
+	// Source is "config" until the first rotation, "rotation" afterwards.
+	Source string `json:"source"`
+
+	// CurrentFingerprint identifies the secret in X-O2IMS-Signature.
+	CurrentFingerprint string `json:"currentFingerprint,omitempty"`
+
+	// NextFingerprint identifies the secret in X-O2IMS-Signature-Next while a
+	// rotation is pending.
+	NextFingerprint string `json:"nextFingerprint,omitempty"`
+
+	// ActivatesAt is when the next secret replaces the current one.
+	ActivatesAt *time.Time `json:"activatesAt,omitempty"`
+
+	// RotatedAt is when the last rotation was requested.
+	RotatedAt *time.Time `json:"rotatedAt,omitempty"`
+}
+
+// RotateSigningKeyRequest is the body of POST /admin/webhooks/signing-keys/rotate.
+type RotateSigningKeyRequest struct {
+	// Secret is the new signing secret. A random secret is generated when empty.
+	Secret string `json:"secret,omitempty"`
+
+	// ValidFor is how long the old and the new secret both sign notifications
+	// (Go duration, default 24h). "0s" switches immediately, for example
+	// after a compromise.
+	ValidFor string `json:"validFor,omitempty"`
+}
+
+// RotateSigningKeyResponse is returned by a rotation. Secret is only ever
+// returned here, so it can be distributed to subscribers.
+type RotateSigningKeyResponse struct {
+	SigningKeyStatus
+
+	// Secret is the new signing secret.
+	Secret string `json:"secret"`
+
+	// NotifiedSubscriptions is the number of subscriptions sent a rotation notice.
+	NotifiedSubscriptions int `json:"notifiedSubscriptions"`
+}
+
+// SetSigningKeyStore sets the store holding rotated webhook signing secrets.
+// This enables the /admin/webhooks/signing-keys endpoints; the webhook
+// workers must read the same store.
+func (s *Server) SetSigningKeyStore(store storage.SigningKeyStore) {
+	s.signingKeys = store
+}
+
+// configuredSigningSecret returns notifications.hmac_secret.
+func (s *Server) configuredSigningSecret() string {
+	if s.config == nil {
+		return ""
+	}
+	return s.config.Notifications.HMACSecret
+}
+
+// signingKeyStatus reports the secrets in effect at now.
+func (s *Server) signingKeyStatus(ctx context.Context, now time.Time) (*SigningKeyStatus, error) {
+	keys, err := s.signingKeys.Get(ctx)
+	if errors.Is(err, storage.ErrSigningKeysNotSet) {
+		secret := s.configuredSigningSecret()
+		return &SigningKeyStatus{
+			Signed:             secret != "",
+			Source:             "config",
+			CurrentFingerprint: storage.SecretFingerprint(secret),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	current, next := keys.Effective(now)
+	status := &SigningKeyStatus{
+		Signed:             current != "",
+		Source:             "rotation",
+		CurrentFingerprint: storage.SecretFingerprint(current),
+		NextFingerprint:    storage.SecretFingerprint(next),
+		RotatedAt:          &keys.RotatedAt,
+	}
+	if next != "" {
+		status.ActivatesAt = &keys.ActivatesAt
+	}
+	return status, nil
+}
+
+// handleGetSigningKeys reports the webhook signing secrets in effect.
+// GET /admin/webhooks/signing-keys.
+func (s *Server) handleGetSigningKeys(c *gin.Context) {
+	if s.signingKeys == nil {
+		problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+			"webhook signing key rotation is not enabled")
+		return
+	}
+
+	status, err := s.signingKeyStatus(c.Request.Context(), time.Now())
+	if err != nil {
+		s.requestLogger(c).Error("failed to load webhook signing keys", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to load webhook signing keys")
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// handleRotateSigningKey schedules a new webhook signing secret. Until the
+// validity window ends, notifications carry signatures under both the old
+// secret (X-O2IMS-Signature) and the new one (X-O2IMS-Signature-Next), and
+// every subscription is sent a rotation notice.
+// POST /admin/webhooks/signing-keys/rotate.
+func (s *Server) handleRotateSigningKey(c *gin.Context) {
+	if s.signingKeys == nil {
+		problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+			"webhook signing key rotation is not enabled")
+		return
+	}
+
+	var req RotateSigningKeyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	validFor, err := parseSigningKeyValidity(req.ValidFor)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateSigningSecret(); err != nil {
+			s.requestLogger(c).Error("failed to generate webhook signing secret", zap.Error(err))
+			problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to generate signing secret")
+			return
+		}
+	} else if len(secret) < MinSigningSecretLength {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest",
+			fmt.Sprintf("secret must be at least %d characters", MinSigningSecretLength))
+		return
+	}
+
+	ctx := c.Request.Context()
+	keys, err := s.rotateSigningKeys(ctx, secret, validFor, time.Now().UTC())
+	if err != nil {
+		s.requestLogger(c).Error("failed to rotate webhook signing keys", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to rotate signing keys")
+		return
+	}
+
+	status, err := s.signingKeyStatus(ctx, keys.RotatedAt)
+	if err != nil {
+		s.requestLogger(c).Error("failed to load webhook signing keys", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to load webhook signing keys")
+		return
+	}
+
+	notified := 0
+	if keys.Next != "" {
+		notified, err = s.notifySigningKeyRotation(ctx, keys)
+		if err != nil {
+			// The rotation stands; subscribers still see both signatures.
+			s.requestLogger(c).Warn("failed to send signing key rotation notices", zap.Error(err))
+		}
+	}
+
+	s.requestLogger(c).Info("webhook signing secret rotated",
+		zap.String("next_fingerprint", storage.SecretFingerprint(secret)),
+		zap.Duration("valid_for", validFor),
+		zap.Int("notified_subscriptions", notified))
+
+	c.JSON(http.StatusOK, RotateSigningKeyResponse{
+		SigningKeyStatus:      *status,
+		Secret:                secret,
+		NotifiedSubscriptions: notified,
+	})
+}
+
+// parseSigningKeyValidity parses the validity window of a rotation.
+func parseSigningKeyValidity(raw string) (time.Duration, error) {
+	if raw == "" {
+		return DefaultSigningKeyValidity, nil
+	}
+	validFor, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid validFor: %w", err)
+	}
+	if validFor < 0 || validFor > MaxSigningKeyValidity {
+		return 0, fmt.Errorf("validFor must be between 0s and %s", MaxSigningKeyValidity)
+	}
+	return validFor, nil
+}
+
+// generateSigningSecret returns a random hex-encoded signing secret.
+func generateSigningSecret() (string, error) {
+	buf := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// rotateSigningKeys stores secret as the next signing secret, active after
+// validFor. The secret in effect now stays primary until then; a pending
+// rotation that has not activated yet is replaced. Without a secret in effect
+// or with a zero window the new secret applies immediately.
+func (s *Server) rotateSigningKeys(
+	ctx context.Context, secret string, validFor time.Duration, now time.Time,
+) (*storage.WebhookSigningKeys, error) {
+	primary := s.configuredSigningSecret()
+	keys, err := s.signingKeys.Get(ctx)
+	switch {
+	case err == nil:
+		primary, _ = keys.Effective(now)
+	case !errors.Is(err, storage.ErrSigningKeysNotSet):
+		return nil, err
+	}
+
+	rotated := &storage.WebhookSigningKeys{Current: secret, RotatedAt: now}
+	if primary != "" && validFor > 0 {
+		rotated = &storage.WebhookSigningKeys{
+			Current:     primary,
+			Next:        secret,
+			ActivatesAt: now.Add(validFor),
+			RotatedAt:   now,
+		}
+	}
+	if err := s.signingKeys.Set(ctx, rotated); err != nil {
+		return nil, err
+	}
+	return rotated, nil
+}
+
+// notifySigningKeyRotation queues a rotation notice for every subscription on
+// the notification stream. The notice names the new secret's fingerprint and
+// activation time, never the secret itself.
+func (s *Server) notifySigningKeyRotation(ctx context.Context, keys *storage.WebhookSigningKeys) (int, error) {
+	redisStore, ok := s.store.(*storage.RedisStore)
+	if !ok || s.config == nil || !s.config.Notifications.Enabled {
+		return 0, nil
+	}
+
+	subs, err := s.store.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	notified := 0
+	for _, sub := range subs {
+		event := &controllers.ResourceEvent{
+			SubscriptionID: sub.ID,
+			EventType:      SigningKeyRotationEventType,
+			Timestamp:      keys.RotatedAt,
+			NotificationID: uuid.New().String(),
+			CallbackURL:    sub.Callback,
+			Extensions: map[string]string{
+				"activatesAt":        keys.ActivatesAt.Format(time.RFC3339),
+				"currentFingerprint": storage.SecretFingerprint(keys.Current),
+				"nextFingerprint":    storage.SecretFingerprint(keys.Next),
+			},
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return notified, fmt.Errorf("failed to marshal rotation notice: %w", err)
+		}
+		err = redisStore.Client.XAdd(ctx, &redis.XAddArgs{
+			Stream: controllers.EventStreamKey,
+			MaxLen: controllers.MaxStreamLength,
+			Approx: true,
+			Values: map[string]interface{}{"event": string(data)},
+		}).Err()
+		if err != nil {
+			return notified, fmt.Errorf("failed to queue rotation notice: %w", err)
+		}
+		notified++
+	}
+	return notified, nil
+}
@@ -0,0 +1,166 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const configuredSigningSecret = "configured-signing-secret-0123456789"
+
+func doSigningKeyRequest(t *testing.T, srv *server.Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	srv.Router().ServeHTTP(w, req)
+	return w
+}
+
+func TestHandleSigningKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Notifications: config.NotificationsConfig{HMACSecret: configuredSigningSecret},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, &mockStore{})
+
+	// Without a store the endpoints are unavailable.
+	w := doSigningKeyRequest(t, srv, http.MethodGet, "/admin/webhooks/signing-keys", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	w = doSigningKeyRequest(t, srv, http.MethodPost, "/admin/webhooks/signing-keys/rotate", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	keys := storage.NewInMemorySigningKeyStore()
+	srv.SetSigningKeyStore(keys)
+
+	w = doSigningKeyRequest(t, srv, http.MethodGet, "/admin/webhooks/signing-keys", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var status server.SigningKeyStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.True(t, status.Signed)
+	assert.Equal(t, "config", status.Source)
+	assert.Equal(t, storage.SecretFingerprint(configuredSigningSecret), status.CurrentFingerprint)
+	assert.NotContains(t, w.Body.String(), configuredSigningSecret)
+
+	t.Run("rotation keeps the old secret during the window", func(t *testing.T) {
+		w := doSigningKeyRequest(t, srv, http.MethodPost, "/admin/webhooks/signing-keys/rotate", `{"validFor":"1h"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp server.RotateSigningKeyResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Secret, 64)
+		assert.Equal(t, storage.SecretFingerprint(configuredSigningSecret), resp.CurrentFingerprint)
+		assert.Equal(t, storage.SecretFingerprint(resp.Secret), resp.NextFingerprint)
+		require.NotNil(t, resp.ActivatesAt)
+
+		stored, err := keys.Get(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, configuredSigningSecret, stored.Current)
+		assert.Equal(t, resp.Secret, stored.Next)
+		assert.Equal(t, stored.RotatedAt.Add(time.Hour), stored.ActivatesAt)
+	})
+
+	t.Run("zero window switches immediately", func(t *testing.T) {
+		secret := strings.Repeat("s", server.MinSigningSecretLength)
+		w := doSigningKeyRequest(t, srv, http.MethodPost, "/admin/webhooks/signing-keys/rotate",
+			`{"secret":"`+secret+`","validFor":"0s"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		stored, err := keys.Get(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, secret, stored.Current)
+		assert.Empty(t, stored.Next)
+
+		w = doSigningKeyRequest(t, srv, http.MethodGet, "/admin/webhooks/signing-keys", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		assert.Equal(t, "rotation", status.Source)
+		assert.Equal(t, storage.SecretFingerprint(secret), status.CurrentFingerprint)
+		assert.Empty(t, status.NextFingerprint)
+	})
+
+	for name, body := range map[string]string{
+		"short secret":      `{"secret":"short"}`,
+		"invalid validFor":  `{"validFor":"soon"}`,
+		"validFor too long": `{"validFor":"1000h"}`,
+		"malformed body":    `{`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := doSigningKeyRequest(t, srv, http.MethodPost, "/admin/webhooks/signing-keys/rotate", body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestHandleRotateSigningKey_NotifiesSubscribers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{
+		Addr:                   mr.Addr(),
+		MaxRetries:             1,
+		DialTimeout:            time.Second,
+		ReadTimeout:            time.Second,
+		WriteTimeout:           time.Second,
+		PoolSize:               5,
+		AllowInsecureCallbacks: true,
+	})
+	defer func() { _ = store.Close() }()
+
+	ctx := context.Background()
+	for _, id := range []string{"sub-1", "sub-2"} {
+		require.NoError(t, store.Create(ctx, &storage.Subscription{
+			ID:       id,
+			Callback: "https://smo.example.com/" + id,
+		}))
+	}
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Notifications: config.NotificationsConfig{
+			Enabled:    true,
+			HMACSecret: configuredSigningSecret,
+		},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, store)
+	srv.SetSigningKeyStore(storage.NewRedisSigningKeyStore(store.Client))
+
+	w := doSigningKeyRequest(t, srv, http.MethodPost, "/admin/webhooks/signing-keys/rotate", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp server.RotateSigningKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.NotifiedSubscriptions)
+
+	messages, err := store.Client.XRange(ctx, controllers.EventStreamKey, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	for _, msg := range messages {
+		data, ok := msg.Values["event"].(string)
+		require.True(t, ok)
+		assert.NotContains(t, data, resp.Secret)
+
+		var event controllers.ResourceEvent
+		require.NoError(t, json.Unmarshal([]byte(data), &event))
+		assert.Equal(t, server.SigningKeyRotationEventType, event.EventType)
+		assert.Equal(t, "https://smo.example.com/"+event.SubscriptionID, event.CallbackURL)
+		assert.Equal(t, resp.NextFingerprint, event.Extensions["nextFingerprint"])
+		assert.Equal(t, resp.ActivatesAt.Format(time.RFC3339), event.Extensions["activatesAt"])
+	}
+}
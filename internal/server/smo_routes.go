@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/piwi3910/netweave/internal/smo"
+	"github.com/piwi3910/netweave/internal/timeutil"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
@@ -146,7 +147,9 @@ func (h *SMOHandler) setEventDefaults(eventID *string, timestamp *time.Time) {
 		*eventID = "event-" + uuid.New().String()
 	}
 	if timestamp.IsZero() {
-		*timestamp = time.Now()
+		*timestamp = timeutil.Now()
+	} else {
+		*timestamp = timestamp.UTC()
 	}
 }
 
@@ -263,7 +266,7 @@ func (s *Server) setupSMORoutes(smoHandler *SMOHandler) {
 	// O2-SMO API v3 routes (multi-tenancy)
 	v3 := s.router.Group("/o2smo/v3")
 	{
-		v3.Use(TenantMiddleware())
+		v3.Use(TenantMiddleware(s.tenantGetter()))
 		s.setupSMOV3Routes(v3, smoHandler)
 	}
 }
@@ -0,0 +1,326 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/middleware"
+)
+
+// DefaultStreamReconnectDelay is the reconnect delay suggested to stream clients
+// when no delay is configured.
+const DefaultStreamReconnectDelay = 2 * time.Second
+
+// streamDrainNoticeKey is the gin context key holding a stream's drain notice channel.
+const streamDrainNoticeKey = "stream_drain_notice"
+
+// StreamDrainer tracks long-running streaming requests (SSE, log follow, WebSocket,
+// long poll) so they can be drained separately from regular requests during
+// shutdown.
+//
+// When draining starts, new streaming requests are rejected with 503 and a
+// Retry-After hint, and active streams are notified through DrainNotice so their
+// handlers can tell the client to reconnect and return. Streams still open
+// when the drain window expires have their request context cancelled.
+//
+// Regular requests are tracked as well, so that DrainRequests can bound them
+// by their own window: the stream drain window does not extend it.
+type StreamDrainer struct {
+	logger         *zap.Logger
+	reconnectDelay time.Duration
+
+	mu       sync.Mutex
+	draining bool
+	nextID   uint64
+	streams  map[uint64]*trackedStream
+	wg       sync.WaitGroup
+
+	// requests are the regular requests in flight, cancelled once expired.
+	// requestDone is signalled when one of them completes.
+	requests    map[uint64]context.CancelFunc
+	requestDone chan struct{}
+	expired     bool
+}
+
+type trackedStream struct {
+	notice chan struct{}
+	cancel context.CancelFunc
+}
+
+// NewStreamDrainer creates a StreamDrainer that suggests reconnectDelay to
+// clients (DefaultStreamReconnectDelay if <= 0).
+func NewStreamDrainer(reconnectDelay time.Duration, logger *zap.Logger) *StreamDrainer {
+	if reconnectDelay <= 0 {
+		reconnectDelay = DefaultStreamReconnectDelay
+	}
+	return &StreamDrainer{
+		logger:         logger,
+		reconnectDelay: reconnectDelay,
+		streams:        make(map[uint64]*trackedStream),
+		requests:       make(map[uint64]context.CancelFunc),
+		requestDone:    make(chan struct{}, 1),
+	}
+}
+
+// ReconnectDelay returns the reconnect delay suggested to clients.
+func (d *StreamDrainer) ReconnectDelay() time.Duration {
+	return d.reconnectDelay
+}
+
+// Draining reports whether draining has started.
+func (d *StreamDrainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// ActiveStreams returns the number of streams currently open.
+func (d *StreamDrainer) ActiveStreams() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.streams)
+}
+
+// Middleware tracks streaming and regular requests; see IsStreamingRequest.
+func (d *StreamDrainer) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		if !IsStreamingRequest(c.Request) {
+			id := d.registerRequest(cancel)
+			defer d.unregisterRequest(id)
+
+			c.Request = c.Request.WithContext(ctx)
+			c.Next()
+			return
+		}
+
+		notice, id, ok := d.register(cancel)
+		if !ok {
+			c.Header("Connection", "close")
+			middleware.RespondBackpressure(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+				"Server is draining; reconnect to another instance",
+				middleware.Backpressure{RetryAfter: d.reconnectDelay})
+			return
+		}
+		defer d.unregister(id)
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Set(streamDrainNoticeKey, notice)
+		c.Next()
+	}
+}
+
+func (d *StreamDrainer) register(cancel context.CancelFunc) (<-chan struct{}, uint64, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return nil, 0, false
+	}
+
+	d.nextID++
+	stream := &trackedStream{notice: make(chan struct{}), cancel: cancel}
+	d.streams[d.nextID] = stream
+	d.wg.Add(1)
+	return stream.notice, d.nextID, true
+}
+
+func (d *StreamDrainer) unregister(id uint64) {
+	d.mu.Lock()
+	delete(d.streams, id)
+	d.mu.Unlock()
+	d.wg.Done()
+}
+
+func (d *StreamDrainer) registerRequest(cancel context.CancelFunc) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.expired {
+		cancel()
+	}
+
+	d.nextID++
+	d.requests[d.nextID] = cancel
+	return d.nextID
+}
+
+func (d *StreamDrainer) unregisterRequest(id uint64) {
+	d.mu.Lock()
+	delete(d.requests, id)
+	d.mu.Unlock()
+
+	select {
+	case d.requestDone <- struct{}{}:
+	default:
+	}
+}
+
+// waitRequests waits until no regular request is in flight, returning false
+// if stop is ready first.
+func (d *StreamDrainer) waitRequests(ctx context.Context, stop <-chan time.Time) bool {
+	for {
+		d.mu.Lock()
+		active := len(d.requests)
+		d.mu.Unlock()
+		if active == 0 {
+			return true
+		}
+
+		select {
+		case <-d.requestDone:
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// DrainRequests waits up to timeout for the regular requests in flight to
+// complete, then cancels the remaining ones and any request that still
+// arrives. It returns once every tracked request handler has returned or ctx
+// is done.
+func (d *StreamDrainer) DrainRequests(ctx context.Context, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	if d.waitRequests(ctx, timer.C) {
+		return
+	}
+
+	d.mu.Lock()
+	d.expired = true
+	remaining := len(d.requests)
+	for _, cancel := range d.requests {
+		cancel()
+	}
+	d.mu.Unlock()
+
+	d.logger.Warn("shutdown timeout expired, cancelling remaining requests",
+		zap.Int("requests", remaining))
+
+	d.waitRequests(ctx, nil)
+}
+
+// Drain stops accepting new streams, notifies active streams, and waits up to
+// timeout for them to close. Remaining streams are then cancelled. Drain returns
+// once every tracked stream handler has returned or ctx is done.
+func (d *StreamDrainer) Drain(ctx context.Context, timeout time.Duration) {
+	d.mu.Lock()
+	if !d.draining {
+		d.draining = true
+		for _, stream := range d.streams {
+			close(stream.notice)
+		}
+	}
+	active := len(d.streams)
+	d.mu.Unlock()
+
+	if active == 0 {
+		return
+	}
+
+	d.logger.Info("draining active streams",
+		zap.Int("streams", active),
+		zap.Duration("timeout", timeout))
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		d.logger.Info("all streams drained")
+		return
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	d.mu.Lock()
+	remaining := len(d.streams)
+	for _, stream := range d.streams {
+		stream.cancel()
+	}
+	d.mu.Unlock()
+
+	d.logger.Warn("stream drain window expired, closing remaining streams",
+		zap.Int("streams", remaining))
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// DrainNotice returns a channel that is closed when the server starts draining.
+// Streaming handlers should select on it, tell the client to reconnect (long
+// polls answer 304 Not Modified), and return. It returns nil (never ready) for
+// requests not tracked by StreamDrainer.
+func DrainNotice(c *gin.Context) <-chan struct{} {
+	if v, ok := c.Get(streamDrainNoticeKey); ok {
+		if notice, ok := v.(<-chan struct{}); ok {
+			return notice
+		}
+	}
+	return nil
+}
+
+// EndStreamOnDrain cancels the request context of a stream once the server
+// starts draining. It is for streaming handlers that cannot select on
+// DrainNotice themselves, such as GraphQL subscriptions, which end their
+// stream, and let the client reconnect, when their context is done.
+func EndStreamOnDrain() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notice := DrainNotice(c)
+		if notice == nil {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		go func() {
+			select {
+			case <-notice:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// IsStreamingRequest reports whether r opens a long-running stream: a WebSocket
+// upgrade, an SSE request (Accept: text/event-stream), a log follow request
+// (?follow=true), or a long-poll list request (?waitFor=).
+func IsStreamingRequest(r *http.Request) bool {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return true
+	}
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return true
+	}
+	query := r.URL.Query()
+	if query.Get("waitFor") != "" {
+		return true
+	}
+	follow, err := strconv.ParseBool(query.Get("follow"))
+	return err == nil && follow
+}
@@ -0,0 +1,226 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+// newDrainRouter returns a router with an SSE endpoint that sends a reconnect hint
+// on drain, and a stubborn endpoint that ignores drain notices.
+func newDrainRouter(drainer *server.StreamDrainer, started chan<- struct{}) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(drainer.Middleware())
+
+	router.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Writer.WriteHeader(http.StatusOK)
+		c.Writer.Flush()
+		started <- struct{}{}
+
+		select {
+		case <-server.DrainNotice(c):
+			_, _ = fmt.Fprintf(c.Writer, "retry: %d\nevent: reconnect\ndata: {}\n\n",
+				drainer.ReconnectDelay().Milliseconds())
+		case <-c.Request.Context().Done():
+		}
+	})
+	router.GET("/stubborn", func(c *gin.Context) {
+		c.Writer.WriteHeader(http.StatusOK)
+		c.Writer.Flush()
+		started <- struct{}{}
+		<-c.Request.Context().Done()
+	})
+	router.GET("/plain", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return router
+}
+
+func TestStreamDrainer_DrainSendsReconnectHint(t *testing.T) {
+	drainer := server.NewStreamDrainer(3*time.Second, zap.NewNop())
+	started := make(chan struct{}, 1)
+	router := newDrainRouter(drainer, started)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(w, req)
+		close(done)
+	}()
+	<-started
+	assert.Equal(t, 1, drainer.ActiveStreams())
+
+	drainer.Drain(context.Background(), 5*time.Second)
+	<-done
+
+	assert.True(t, drainer.Draining())
+	assert.Equal(t, 0, drainer.ActiveStreams())
+	assert.Contains(t, w.Body.String(), "retry: 3000\n")
+	assert.Contains(t, w.Body.String(), "event: reconnect\n")
+}
+
+func TestStreamDrainer_RejectsNewStreamsWhileDraining(t *testing.T) {
+	drainer := server.NewStreamDrainer(1500*time.Millisecond, zap.NewNop())
+	router := newDrainRouter(drainer, make(chan struct{}, 1))
+
+	drainer.Drain(context.Background(), time.Second)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, "close", w.Header().Get("Connection"))
+
+	// Regular requests are left to the HTTP server's own shutdown.
+	req = httptest.NewRequest(http.MethodGet, "/plain", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestStreamDrainer_CancelsStreamsAfterTimeout(t *testing.T) {
+	drainer := server.NewStreamDrainer(0, zap.NewNop())
+	assert.Equal(t, server.DefaultStreamReconnectDelay, drainer.ReconnectDelay())
+
+	started := make(chan struct{}, 1)
+	router := newDrainRouter(drainer, started)
+
+	req := httptest.NewRequest(http.MethodGet, "/stubborn?follow=true", nil)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(w, req)
+		close(done)
+	}()
+	<-started
+
+	start := time.Now()
+	drainer.Drain(context.Background(), 50*time.Millisecond)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "stream was not cancelled after drain timeout")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, 0, drainer.ActiveStreams())
+}
+
+func TestStreamDrainer_EndStreamOnDrain(t *testing.T) {
+	drainer := server.NewStreamDrainer(0, zap.NewNop())
+	started := make(chan struct{}, 1)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(drainer.Middleware())
+	router.GET("/subscriptions", server.EndStreamOnDrain(), func(c *gin.Context) {
+		c.Writer.WriteHeader(http.StatusOK)
+		c.Writer.Flush()
+		started <- struct{}{}
+		<-c.Request.Context().Done()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+	req.Header.Set("Upgrade", "websocket")
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(w, req)
+		close(done)
+	}()
+	<-started
+
+	// The stream ends on the drain notice, long before the drain window expires.
+	start := time.Now()
+	drainer.Drain(context.Background(), time.Minute)
+	<-done
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, 0, drainer.ActiveStreams())
+}
+
+func TestStreamDrainer_DrainRequests(t *testing.T) {
+	drainer := server.NewStreamDrainer(0, zap.NewNop())
+	started := make(chan struct{}, 1)
+	router := newDrainRouter(drainer, started)
+
+	// A regular request that only returns once cancelled.
+	req := httptest.NewRequest(http.MethodGet, "/stubborn", nil)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(w, req)
+		close(done)
+	}()
+	<-started
+
+	// Regular requests are bounded by their own window, not by the stream
+	// drain window.
+	streamsDrained := make(chan struct{})
+	go func() {
+		drainer.Drain(context.Background(), time.Minute)
+		close(streamsDrained)
+	}()
+
+	start := time.Now()
+	drainer.DrainRequests(context.Background(), 50*time.Millisecond)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "request was not cancelled after the shutdown timeout")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	<-streamsDrained
+
+	// Requests arriving once the window expired are cancelled at once.
+	req = httptest.NewRequest(http.MethodGet, "/stubborn", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestIsStreamingRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "plain request", target: "/o2ims/v1/resources", want: false},
+		{name: "sse", target: "/events", headers: map[string]string{"Accept": "text/event-stream"}, want: true},
+		{name: "websocket", target: "/ws", headers: map[string]string{"Upgrade": "WebSocket"}, want: true},
+		{name: "log follow", target: "/logs?follow=true", want: true},
+		{name: "log no follow", target: "/logs?follow=false", want: false},
+		{name: "long poll", target: "/o2ims/v1/resources?waitFor=abc", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, strings.NewReader(""))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			assert.Equal(t, tt.want, server.IsStreamingRequest(req))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// specObject describes the attributes the O2-IMS specification requires of
+// an object kind.
+type specObject struct {
+	// idField is the attribute identifying an object in compliance logs.
+	idField string
+
+	// required are the attributes every object of the kind must carry.
+	required []string
+
+	// requiredInRequest are the attributes a create request must carry.
+	requiredInRequest []string
+
+	// defaults are the mandated values of required attributes that may be
+	// left empty, e.g. an empty description or extensions object.
+	defaults map[string]func() interface{}
+}
+
+func emptyString() interface{} { return "" }
+func emptyList() interface{}   { return []interface{}{} }
+func emptyObject() interface{} { return map[string]interface{}{} }
+
+// specObjects are the O2-IMS objects checked in strict spec mode, by kind.
+var specObjects = map[string]specObject{
+	config.StrictSpecKindOCloud: {
+		idField:  "oCloudId",
+		required: []string{"oCloudId", "globalCloudId", "name", "description", "serviceUri", "extensions"},
+		defaults: map[string]func() interface{}{"description": emptyString, "extensions": emptyObject},
+	},
+	config.StrictSpecKindDeploymentManager: {
+		idField: "deploymentManagerId",
+		required: []string{
+			"deploymentManagerId", "name", "description", "oCloudId", "serviceUri",
+			"supportedLocations", "capabilities", "extensions",
+		},
+		defaults: map[string]func() interface{}{
+			"description": emptyString, "supportedLocations": emptyList,
+			"capabilities": emptyList, "extensions": emptyObject,
+		},
+	},
+	config.StrictSpecKindResourcePool: {
+		idField:           "resourcePoolId",
+		required:          []string{"resourcePoolId", "name", "description", "oCloudId", "extensions"},
+		requiredInRequest: []string{"name"},
+		defaults:          map[string]func() interface{}{"description": emptyString, "extensions": emptyObject},
+	},
+	config.StrictSpecKindResource: {
+		idField:           "resourceId",
+		required:          []string{"resourceId", "resourceTypeId", "resourcePoolId", "description", "extensions"},
+		requiredInRequest: []string{"resourceTypeId", "resourcePoolId"},
+		defaults:          map[string]func() interface{}{"description": emptyString, "extensions": emptyObject},
+	},
+	config.StrictSpecKindResourceType: {
+		idField: "resourceTypeId",
+		required: []string{
+			"resourceTypeId", "name", "vendor", "model", "version", "description", "extensions",
+		},
+		defaults: map[string]func() interface{}{"description": emptyString, "extensions": emptyObject},
+	},
+	config.StrictSpecKindSubscription: {
+		idField:           "subscriptionId",
+		required:          []string{"subscriptionId", "callback"},
+		requiredInRequest: []string{"callback"},
+	},
+}
+
+// specViolations collects the compliance-affecting omissions of a response.
+type specViolations struct {
+	objects   int
+	missing   map[string]bool
+	exampleID string
+}
+
+// add records the attributes missing from an object.
+func (v *specViolations) add(id string, missing []string) {
+	if len(missing) == 0 {
+		return
+	}
+	if v.missing == nil {
+		v.missing = make(map[string]bool)
+		v.exampleID = id
+	}
+	v.objects++
+	for _, attr := range missing {
+		v.missing[attr] = true
+	}
+}
+
+// attributes returns the missing attributes, sorted.
+func (v *specViolations) attributes() []string {
+	attrs := make([]string, 0, len(v.missing))
+	for attr := range v.missing {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+// isMissing reports whether a required attribute is absent or empty.
+func isMissing(obj map[string]interface{}, attr string) bool {
+	value, ok := obj[attr]
+	if !ok || value == nil {
+		return true
+	}
+	s, isString := value.(string)
+	return isString && s == ""
+}
+
+// strictSpec enforces the O2-IMS attribute set of one object kind.
+type strictSpec struct {
+	cfg    config.StrictSpecConfig
+	kind   string
+	object specObject
+}
+
+// fillObject fills the mandated defaults of obj and records the required
+// attributes and extension keys it still lacks.
+func (s *strictSpec) fillObject(obj map[string]interface{}, violations *specViolations) {
+	var missing []string
+	for _, attr := range s.object.required {
+		if !isMissing(obj, attr) {
+			continue
+		}
+		switch {
+		case attr == "oCloudId" && s.cfg.OCloudID != "":
+			obj[attr] = s.cfg.OCloudID
+		case attr == "globalCloudId" && s.cfg.GlobalCloudID != "":
+			obj[attr] = s.cfg.GlobalCloudID
+		case s.object.defaults[attr] != nil:
+			obj[attr] = s.object.defaults[attr]()
+		default:
+			missing = append(missing, attr)
+		}
+	}
+	extensions, _ := obj[extensionsField].(map[string]interface{})
+	for _, key := range s.cfg.RequiredExtensions[s.kind] {
+		if _, ok := extensions[key]; !ok {
+			missing = append(missing, extensionsField+"."+key)
+		}
+	}
+	id, _ := obj[s.object.idField].(string)
+	violations.add(id, missing)
+}
+
+// fillResponse fills the mandated defaults of every object of a JSON
+// response: the items under listKind of a list envelope, or the object
+// itself if listKind is empty. It returns the rewritten body.
+func (s *strictSpec) fillResponse(body []byte, listKind string, violations *specViolations) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if listKind == "" {
+		s.fillObject(obj, violations)
+	} else {
+		items, ok := obj[listKind].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("response has no %s list", listKind)
+		}
+		for _, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				s.fillObject(itemObj, violations)
+			}
+		}
+	}
+
+	filled, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	return filled, nil
+}
+
+// checkRequest returns the attributes the specification requires of a
+// create request that its JSON body lacks. Bodies that are not JSON objects
+// are left to the handler to reject.
+func (s *strictSpec) checkRequest(body []byte) []string {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil
+	}
+	var missing []string
+	for _, attr := range s.object.requiredInRequest {
+		if isMissing(obj, attr) {
+			missing = append(missing, attr)
+		}
+	}
+	return missing
+}
+
+// withStrictSpec enforces the O2-IMS attribute set of kind on the requests
+// and responses of handler when validation.strict_spec is enabled. Create
+// requests lacking required attributes are rejected with 400 Bad Request.
+// Successful responses, single objects or the items under listKind of a list,
+// get the mandated defaults of missing attributes; required attributes and
+// extension keys that cannot be defaulted are logged as compliance errors.
+func (s *Server) withStrictSpec(kind, listKind string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := s.config.Validation.StrictSpec
+		if !cfg.Enabled {
+			handler(c)
+			return
+		}
+		spec := &strictSpec{cfg: cfg, kind: kind, object: specObjects[kind]}
+
+		if c.Request.Method == http.MethodPost && c.Request.Body != nil && len(spec.object.requiredInRequest) > 0 {
+			body, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				problem.Respond(c, http.StatusBadRequest, "BadRequest", "Failed to read request body")
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			if missing := spec.checkRequest(body); len(missing) > 0 {
+				s.requestLogger(c).Warn("rejected request missing O2-IMS required attributes",
+					zap.String("kind", kind), zap.Strings("missing", missing))
+				problem.Respond(c, http.StatusBadRequest, "InvalidParameter",
+					fmt.Sprintf("missing required %s attributes: %s", kind, strings.Join(missing, ", ")))
+				return
+			}
+		}
+
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		handler(c)
+		c.Writer = writer.ResponseWriter
+
+		body := writer.body.Bytes()
+		if writer.status >= http.StatusOK && writer.status < http.StatusMultipleChoices && len(body) > 0 {
+			var violations specViolations
+			filled, err := spec.fillResponse(body, listKind, &violations)
+			if err != nil {
+				s.requestLogger(c).Error("failed to enforce O2-IMS attribute set", zap.Error(err))
+			} else {
+				body = filled
+			}
+			if violations.objects > 0 {
+				s.requestLogger(c).Error("response violates O2-IMS strict spec: required attributes missing",
+					zap.String("kind", kind),
+					zap.Int("objects", violations.objects),
+					zap.Strings("missing", violations.attributes()),
+					zap.String("example_id", violations.exampleID))
+			}
+		}
+
+		c.Writer.WriteHeader(writer.status)
+		if len(body) > 0 {
+			_, _ = c.Writer.Write(body)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestStrictSpec(t *testing.T) {
+	const resourcesPath = "/o2ims-infrastructureInventory/v1/resources"
+	gin.SetMode(gin.TestMode)
+	adp := &gettableAdapter{filteringAdapter{resources: []*adapter.Resource{
+		{ResourceID: "node-1", ResourceTypeID: "compute", ResourcePoolID: "pool-a", Description: "worker",
+			Extensions: map[string]interface{}{"zone": "zone-a"}},
+		{ResourceID: "node-2", ResourcePoolID: "pool-a"},
+	}}}
+
+	newServer := func(strict config.StrictSpecConfig) (*server.Server, *observer.ObservedLogs) {
+		core, logs := observer.New(zap.ErrorLevel)
+		cfg := &config.Config{
+			Server:     config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+			Validation: config.ValidationConfig{StrictSpec: strict},
+		}
+		srv, _ := server.NewTestServerWithMetrics(cfg, zap.New(core), adp, &mockStore{})
+		return srv, logs
+	}
+	listResources := func(t *testing.T, srv *server.Server) []map[string]interface{} {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, resourcesPath, nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		var list struct {
+			Resources []map[string]interface{} `json:"resources"`
+		}
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Len(t, list.Resources, 2)
+		return list.Resources
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, logs := newServer(config.StrictSpecConfig{})
+		items := listResources(t, srv)
+		assert.NotContains(t, items[1], "description")
+		assert.NotContains(t, items[1], "extensions")
+		assert.Zero(t, logs.Len())
+	})
+
+	t.Run("fills defaults and logs omissions", func(t *testing.T) {
+		srv, logs := newServer(config.StrictSpecConfig{
+			Enabled:            true,
+			GlobalCloudID:      "0f3c8a51-6a8f-4b4e-9a57-1d2b0c7e5f10",
+			RequiredExtensions: map[string][]string{config.StrictSpecKindResource: {"zone"}},
+		})
+		items := listResources(t, srv)
+		assert.Equal(t, "worker", items[0]["description"])
+		assert.Equal(t, "", items[1]["description"])
+		assert.Equal(t, map[string]interface{}{}, items[1]["extensions"])
+
+		entries := logs.FilterMessage("response violates O2-IMS strict spec: required attributes missing").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "resource", fields["kind"])
+		assert.Equal(t, int64(1), fields["objects"])
+		assert.Equal(t, []interface{}{"extensions.zone", "resourceTypeId"}, fields["missing"])
+		assert.Equal(t, "node-2", fields["example_id"])
+	})
+
+	t.Run("rejects incomplete create requests", func(t *testing.T) {
+		srv, _ := newServer(config.StrictSpecConfig{Enabled: true})
+		resp, body := doResourceRequest(t, srv, http.MethodPost, resourcesPath,
+			map[string]interface{}{"resourcePoolId": "pool-a"})
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, string(body), "missing required resource attributes: resourceTypeId")
+	})
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// DeliverableSubscription is the deliverability of a subscription's callback.
+type DeliverableSubscription struct {
+	SubscriptionID string `json:"subscriptionId"`
+	TenantID       string `json:"tenantId,omitempty"`
+	Callback       string `json:"callback"`
+	*storage.SubscriptionDeliverability
+}
+
+// SubscriptionDeliverabilityReport lists the deliverability of the
+// subscriptions with delivery attempts in the window, worst first.
+type SubscriptionDeliverabilityReport struct {
+	Window        string                    `json:"window"`
+	Subscriptions []DeliverableSubscription `json:"subscriptions"`
+	Total         int                       `json:"total"`
+}
+
+// SetDeliverabilityStore sets the store holding the delivery attempts the
+// webhook workers record. This enables GET /admin/subscriptions/deliverability.
+func (s *Server) SetDeliverabilityStore(store storage.DeliverabilityStore) {
+	s.deliverability = store
+}
+
+// handleSubscriptionDeliverability lists the deliverability score of every
+// subscription callback, lowest score first, so operators can contact the
+// consumers of failing endpoints. ?status= keeps only one status.
+// GET /admin/subscriptions/deliverability.
+func (s *Server) handleSubscriptionDeliverability(c *gin.Context) {
+	if s.deliverability == nil {
+		problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable", "Delivery statistics are not available")
+		return
+	}
+	status := c.Query("status")
+	switch status {
+	case "", storage.DeliverabilityHealthy, storage.DeliverabilityDegraded, storage.DeliverabilityFailing:
+	default:
+		problem.Respond(c, http.StatusBadRequest, "InvalidParameter", "status must be healthy, degraded or failing")
+		return
+	}
+
+	ctx := c.Request.Context()
+	snapshot, err := s.deliverability.Snapshot(ctx, time.Now())
+	if err != nil {
+		s.requestLogger(c).Error("failed to get delivery statistics", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to retrieve delivery statistics")
+		return
+	}
+	subs, err := s.store.List(ctx)
+	if err != nil {
+		s.requestLogger(c).Error("failed to list subscriptions for deliverability report", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to list subscriptions")
+		return
+	}
+
+	report := &SubscriptionDeliverabilityReport{
+		Window:        s.deliverability.Window().String(),
+		Subscriptions: []DeliverableSubscription{},
+	}
+	for _, sub := range subs {
+		d, ok := snapshot[sub.ID]
+		if !ok || (status != "" && d.Status != status) {
+			continue
+		}
+		report.Subscriptions = append(report.Subscriptions, DeliverableSubscription{
+			SubscriptionID:             sub.ID,
+			TenantID:                   sub.TenantID,
+			Callback:                   sub.Callback,
+			SubscriptionDeliverability: d,
+		})
+	}
+	sort.Slice(report.Subscriptions, func(i, j int) bool {
+		a, b := report.Subscriptions[i], report.Subscriptions[j]
+		if a.Score != b.Score {
+			return a.Score < b.Score
+		}
+		return a.SubscriptionID < b.SubscriptionID
+	})
+	report.Total = len(report.Subscriptions)
+
+	c.JSON(http.StatusOK, report)
+}
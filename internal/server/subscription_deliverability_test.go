@@ -0,0 +1,65 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestSubscriptionDeliverability(t *testing.T) {
+	srv, _, _ := setupRevalidationTestServer(t)
+
+	resp, _ := doResourceRequest(t, srv, http.MethodGet, "/admin/subscriptions/deliverability", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code, "no delivery statistics without a store")
+
+	ctx := context.Background()
+	now := time.Now()
+	store := storage.NewInMemoryDeliverabilityStore(time.Hour)
+	srv.SetDeliverabilityStore(store)
+	require.NoError(t, store.RecordAttempt(ctx, "sub-good", storage.DeliveryAttempt{
+		At: now, Success: true, Latency: 50 * time.Millisecond,
+	}))
+	require.NoError(t, store.RecordAttempt(ctx, "sub-bad", storage.DeliveryAttempt{
+		At: now, Latency: 10 * time.Second, TLSIssue: true,
+	}))
+
+	report := func(t *testing.T, query string) server.SubscriptionDeliverabilityReport {
+		t.Helper()
+		resp, body := doResourceRequest(t, srv, http.MethodGet, "/admin/subscriptions/deliverability"+query, nil)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		var r server.SubscriptionDeliverabilityReport
+		require.NoError(t, json.Unmarshal(body, &r))
+		return r
+	}
+
+	all := report(t, "")
+	assert.Equal(t, "1h0m0s", all.Window)
+	require.Equal(t, 2, all.Total)
+	assert.Equal(t, "sub-bad", all.Subscriptions[0].SubscriptionID, "worst callbacks first")
+	assert.Equal(t, "tenant-b", all.Subscriptions[0].TenantID)
+	assert.Equal(t, "https://hooks.example.net/notify", all.Subscriptions[0].Callback)
+	assert.Equal(t, storage.DeliverabilityFailing, all.Subscriptions[0].Status)
+	assert.Equal(t, int64(1), all.Subscriptions[0].TLSIssues)
+	assert.Equal(t, storage.DeliverabilityHealthy, all.Subscriptions[1].Status)
+
+	healthy := report(t, "?status=healthy")
+	require.Equal(t, 1, healthy.Total)
+	assert.Equal(t, "sub-good", healthy.Subscriptions[0].SubscriptionID)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodGet, "/admin/subscriptions/deliverability?status=bad", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, _ = doResourceRequest(t, srv, http.MethodDelete, subscriptionsPath+"/sub-bad", nil)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+	d, err := store.Get(ctx, "sub-bad", now)
+	require.NoError(t, err)
+	assert.Nil(t, d, "deleting a subscription drops its delivery statistics")
+}
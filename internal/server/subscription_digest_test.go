@@ -0,0 +1,91 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+func TestSubscriptionDigest(t *testing.T) {
+	hourly := map[string]interface{}{
+		"interval":            "1h",
+		"immediateEventTypes": []string{"o2ims.Resource.Deleted"},
+	}
+
+	t.Run("digest settings are stored", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback": "https://smo.example.com/notify",
+			"digest":   hourly,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		require.NotNil(t, created.Digest)
+		assert.Equal(t, "1h", created.Digest.Interval)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/"+created.SubscriptionID, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var got adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.Digest)
+		assert.Equal(t, []string{"o2ims.Resource.Deleted"}, got.Digest.ImmediateEventTypes)
+	})
+
+	t.Run("invalid digest settings are rejected", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		for _, settings := range []map[string]interface{}{
+			{"interval": "10s"},
+			{"interval": "daily"},
+			{"interval": "1h", "immediateEventTypes": []string{"o2ims.Resource.Exploded"}},
+		} {
+			resp, body := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath, map[string]interface{}{
+				"callback": "https://smo.example.com/notify",
+				"digest":   settings,
+			})
+			assert.Equal(t, http.StatusBadRequest, resp.Code, settings)
+			assert.Contains(t, string(body), "invalid digest")
+		}
+	})
+
+	t.Run("update replaces and removes the digest settings", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback": "https://smo.example.com/notify",
+			"digest":   hourly,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		path := subscriptionsPath + "/" + created.SubscriptionID
+
+		resp, _ := doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback": "https://smo.example.com/notify",
+			"digest":   map[string]interface{}{"interval": "15m"},
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var got adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.Digest)
+		assert.Equal(t, "15m", got.Digest.Interval)
+		assert.Empty(t, got.Digest.ImmediateEventTypes)
+
+		resp, _ = doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback": "https://smo.example.com/notify",
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		resp, body = doResourceRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		got = adapter.Subscription{}
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Nil(t, got.Digest)
+	})
+}
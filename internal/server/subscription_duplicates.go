@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// SubscriptionDuplicatesReport lists groups of subscriptions that deliver the
+// same notifications to the same callback for the same consumer.
+type SubscriptionDuplicatesReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Policy is the configured duplicate policy for new subscriptions.
+	Policy string `json:"policy"`
+
+	Groups []SubscriptionDuplicateGroup `json:"groups"`
+
+	// Redundant is the number of subscriptions that could be deleted while
+	// keeping one subscription per group.
+	Redundant int `json:"redundant"`
+}
+
+// SubscriptionDuplicateGroup is a set of subscriptions with the same
+// fingerprint (see storage.Subscription.Fingerprint).
+type SubscriptionDuplicateGroup struct {
+	Fingerprint     string                     `json:"fingerprint"`
+	TenantID        string                     `json:"tenantId,omitempty"`
+	Callback        string                     `json:"callback"`
+	Filter          storage.SubscriptionFilter `json:"filter"`
+	SubscriptionIDs []string                   `json:"subscriptionIds"`
+}
+
+// duplicatePolicy returns the configured duplicate subscription policy.
+func (s *Server) duplicatePolicy() string {
+	if s.config == nil || s.config.Subscriptions.DuplicatePolicy == "" {
+		return config.SubscriptionDuplicatesAllow
+	}
+	return s.config.Subscriptions.DuplicatePolicy
+}
+
+// findDuplicateSubscription returns the tenant's existing subscription with
+// the same callback and filter as sub, or nil if there is none.
+func (s *Server) findDuplicateSubscription(
+	ctx context.Context, tenantID string, sub *adapter.Subscription,
+) (*storage.Subscription, error) {
+	candidate := storage.Subscription{TenantID: tenantID, Callback: sub.Callback}
+	if sub.Filter != nil {
+		candidate.Filter = storage.SubscriptionFilter{
+			ResourcePoolID: sub.Filter.ResourcePoolID,
+			ResourceTypeID: sub.Filter.ResourceTypeID,
+			ResourceID:     sub.Filter.ResourceID,
+		}
+	}
+
+	var (
+		existing []*storage.Subscription
+		err      error
+	)
+	if tenantID != "" {
+		existing, err = s.store.ListByTenant(ctx, tenantID)
+	} else {
+		existing, err = s.store.List(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	fingerprint := candidate.Fingerprint()
+	var match *storage.Subscription
+	for _, other := range existing {
+		if other.TenantID != tenantID || other.Fingerprint() != fingerprint {
+			continue
+		}
+		// Prefer the oldest subscription so repeated requests resolve to the
+		// same one even when duplicates already exist.
+		if match == nil || other.CreatedAt.Before(match.CreatedAt) {
+			match = other
+		}
+	}
+	return match, nil
+}
+
+// checkDuplicateSubscription applies the duplicate policy to a subscription
+// about to be created. It returns false when the request has been answered:
+// with 409 Conflict under the reject policy, or with the existing
+// subscription under the merge policy.
+func (s *Server) checkDuplicateSubscription(c *gin.Context, tenantID string, req *adapter.Subscription) bool {
+	policy := s.duplicatePolicy()
+	if policy == config.SubscriptionDuplicatesAllow {
+		return true
+	}
+
+	existing, err := s.findDuplicateSubscription(c.Request.Context(), tenantID, req)
+	if err != nil {
+		s.requestLogger(c).Error("failed to check for duplicate subscriptions", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError",
+			"Failed to check for duplicate subscriptions")
+		return false
+	}
+	if existing == nil {
+		return true
+	}
+
+	s.requestLogger(c).Info("duplicate subscription requested",
+		zap.String("policy", policy),
+		zap.String("subscription_id", existing.ID),
+		zap.String("tenant_id", tenantID))
+
+	if policy == config.SubscriptionDuplicatesReject {
+		problem.Respond(c, http.StatusConflict, "Conflict",
+			"Subscription "+existing.ID+" already has the same callback and filter")
+		return false
+	}
+
+	c.Header("Location", "/o2ims-infrastructureInventory/v1/subscriptions/"+existing.ID)
+	c.JSON(http.StatusOK, &adapter.Subscription{
+		SubscriptionID:         existing.ID,
+		Callback:               existing.Callback,
+		ConsumerSubscriptionID: existing.ConsumerSubscriptionID,
+		Filter: &adapter.SubscriptionFilter{
+			ResourcePoolID: existing.Filter.ResourcePoolID,
+			ResourceTypeID: existing.Filter.ResourceTypeID,
+			ResourceID:     existing.Filter.ResourceID,
+		},
+	})
+	return false
+}
+
+// handleSubscriptionDuplicates reports groups of duplicate subscriptions.
+// GET /admin/subscriptions/duplicates.
+func (s *Server) handleSubscriptionDuplicates(c *gin.Context) {
+	subs, err := s.store.List(c.Request.Context())
+	if err != nil {
+		s.requestLogger(c).Error("failed to list subscriptions for duplicate report", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to list subscriptions")
+		return
+	}
+
+	c.JSON(http.StatusOK, buildDuplicatesReport(subs, s.duplicatePolicy()))
+}
+
+// buildDuplicatesReport groups subscriptions by fingerprint and returns the
+// groups with more than one member, ordered by tenant and callback.
+func buildDuplicatesReport(subs []*storage.Subscription, policy string) *SubscriptionDuplicatesReport {
+	byFingerprint := make(map[string][]*storage.Subscription)
+	for _, sub := range subs {
+		fingerprint := sub.Fingerprint()
+		byFingerprint[fingerprint] = append(byFingerprint[fingerprint], sub)
+	}
+
+	report := &SubscriptionDuplicatesReport{
+		GeneratedAt: time.Now().UTC(),
+		Policy:      policy,
+		Groups:      []SubscriptionDuplicateGroup{},
+	}
+	for fingerprint, members := range byFingerprint {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Slice(members, func(i, j int) bool {
+			if !members[i].CreatedAt.Equal(members[j].CreatedAt) {
+				return members[i].CreatedAt.Before(members[j].CreatedAt)
+			}
+			return members[i].ID < members[j].ID
+		})
+		ids := make([]string, 0, len(members))
+		for _, member := range members {
+			ids = append(ids, member.ID)
+		}
+		report.Groups = append(report.Groups, SubscriptionDuplicateGroup{
+			Fingerprint:     fingerprint,
+			TenantID:        members[0].TenantID,
+			Callback:        members[0].Callback,
+			Filter:          members[0].Filter,
+			SubscriptionIDs: ids,
+		})
+		report.Redundant += len(members) - 1
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.TenantID != b.TenantID {
+			return a.TenantID < b.TenantID
+		}
+		if a.Callback != b.Callback {
+			return a.Callback < b.Callback
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return report
+}
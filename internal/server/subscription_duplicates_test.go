@@ -0,0 +1,138 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const subscriptionsPath = "/o2ims-infrastructureInventory/v1/subscriptions"
+
+// setupDuplicatesTestServer creates a server with a Redis-backed
+// subscription store and the given duplicate policy.
+func setupDuplicatesTestServer(t *testing.T, policy string) *server.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{
+		Addr:                   mr.Addr(),
+		MaxRetries:             1,
+		DialTimeout:            time.Second,
+		ReadTimeout:            time.Second,
+		WriteTimeout:           time.Second,
+		PoolSize:               5,
+		AllowInsecureCallbacks: true,
+	})
+	t.Cleanup(func() { _ = store.Close() })
+
+	cfg := &config.Config{
+		Server:        config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Security:      config.SecurityConfig{DisableSSRFProtection: true},
+		Subscriptions: config.SubscriptionsConfig{DuplicatePolicy: policy},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), newMockResourceAdapter(), store)
+	return srv
+}
+
+func createSubscription(t *testing.T, srv *server.Server, body map[string]interface{}) (int, adapter.Subscription) {
+	t.Helper()
+	resp, raw := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath, body)
+	var sub adapter.Subscription
+	if resp.Code < http.StatusBadRequest {
+		require.NoError(t, json.Unmarshal(raw, &sub))
+	}
+	return resp.Code, sub
+}
+
+func TestCreateSubscription_DuplicatePolicy(t *testing.T) {
+	original := map[string]interface{}{
+		"callback": "https://smo.example.com/notify",
+		"filter":   map[string]string{"resourcePoolId": "pool-1"},
+	}
+	// Same callback (spelled differently) and filter, different consumer ID.
+	duplicate := map[string]interface{}{
+		"callback":               "https://SMO.example.com/notify/",
+		"consumerSubscriptionId": "smo-2",
+		"filter":                 map[string]string{"resourcePoolId": "pool-1"},
+	}
+	otherFilter := map[string]interface{}{
+		"callback": "https://smo.example.com/notify",
+		"filter":   map[string]string{"resourcePoolId": "pool-2"},
+	}
+
+	t.Run("allow creates duplicates", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, first := createSubscription(t, srv, original)
+		require.Equal(t, http.StatusCreated, code)
+		code, second := createSubscription(t, srv, duplicate)
+		require.Equal(t, http.StatusCreated, code)
+		assert.NotEqual(t, first.SubscriptionID, second.SubscriptionID)
+	})
+
+	t.Run("reject returns conflict", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, config.SubscriptionDuplicatesReject)
+
+		code, first := createSubscription(t, srv, original)
+		require.Equal(t, http.StatusCreated, code)
+
+		resp, body := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath, duplicate)
+		require.Equal(t, http.StatusConflict, resp.Code)
+		assert.Contains(t, string(body), first.SubscriptionID)
+
+		code, _ = createSubscription(t, srv, otherFilter)
+		assert.Equal(t, http.StatusCreated, code, "a different filter is not a duplicate")
+	})
+
+	t.Run("merge returns the existing subscription", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, config.SubscriptionDuplicatesMerge)
+
+		code, first := createSubscription(t, srv, original)
+		require.Equal(t, http.StatusCreated, code)
+
+		resp, body := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath, duplicate)
+		require.Equal(t, http.StatusOK, resp.Code, string(body))
+		var merged adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &merged))
+		assert.Equal(t, first.SubscriptionID, merged.SubscriptionID)
+		assert.Equal(t, subscriptionsPath+"/"+first.SubscriptionID, resp.Header().Get("Location"))
+	})
+}
+
+func TestSubscriptionDuplicatesReport(t *testing.T) {
+	srv := setupDuplicatesTestServer(t, "")
+
+	original := map[string]interface{}{"callback": "https://smo.example.com/notify"}
+	var ids []string
+	for range 3 {
+		code, sub := createSubscription(t, srv, original)
+		require.Equal(t, http.StatusCreated, code)
+		ids = append(ids, sub.SubscriptionID)
+	}
+	code, _ := createSubscription(t, srv, map[string]interface{}{"callback": "https://smo.example.com/other"})
+	require.Equal(t, http.StatusCreated, code)
+
+	resp, body := doResourceRequest(t, srv, http.MethodGet, "/admin/subscriptions/duplicates", nil)
+	require.Equal(t, http.StatusOK, resp.Code, string(body))
+
+	var report server.SubscriptionDuplicatesReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.Equal(t, config.SubscriptionDuplicatesAllow, report.Policy)
+	assert.Equal(t, 2, report.Redundant)
+	require.Len(t, report.Groups, 1)
+	assert.Equal(t, "https://smo.example.com/notify", report.Groups[0].Callback)
+	assert.ElementsMatch(t, ids, report.Groups[0].SubscriptionIDs)
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	dmshandlers "github.com/piwi3910/netweave/internal/dms/handlers"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// subscriptionExpiryActor is the audit log user of deletions made by the
+// expiry cleanup.
+const subscriptionExpiryActor = "system:subscription-expiry"
+
+// Subscription expiry metrics, labeled by API (o2ims or o2dms).
+var (
+	subscriptionsActive = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "active",
+			Help:      "Number of unexpired subscriptions after the last expiry cleanup by API",
+		},
+		[]string{"api"},
+	)
+
+	subscriptionsExpired = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "subscription",
+			Name:      "expired_total",
+			Help:      "Total number of expired subscriptions deleted by API",
+		},
+		[]string{"api"},
+	)
+)
+
+// validateSubscriptionExpiry checks the expiresAt of a subscription against
+// the configured maximum TTL.
+func (s *Server) validateSubscriptionExpiry(sub *adapter.Subscription) error {
+	if err := timeutil.ValidateExpiry(sub.ExpiresAt, timeutil.Now(), s.config.Subscriptions.MaxTTL); err != nil {
+		return fmt.Errorf("invalid expiresAt: %w", err)
+	}
+	return nil
+}
+
+// ReapExpiredSubscriptions deletes the O2-IMS and O2-DMS subscriptions that
+// expired at now, from storage and the adapter, and updates the subscription
+// metrics. It returns the number of deleted subscriptions.
+func (s *Server) ReapExpiredSubscriptions(ctx context.Context, now time.Time) (int, error) {
+	reaped := 0
+	if s.store != nil {
+		subs, err := s.store.List(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+		active := 0
+		for _, sub := range subs {
+			if !sub.Expired(now) {
+				active++
+				continue
+			}
+			deleted, err := s.deleteExpiredSubscription(ctx, sub)
+			if err != nil {
+				s.logger.Error("failed to delete expired subscription",
+					zap.String("subscription_id", sub.ID),
+					zap.Error(err))
+				active++
+				continue
+			}
+			if deleted {
+				reaped++
+				subscriptionsExpired.WithLabelValues("o2ims").Inc()
+			}
+		}
+		subscriptionsActive.WithLabelValues("o2ims").Set(float64(active))
+	}
+
+	if s.dmsHandler != nil {
+		active, expired, err := s.dmsHandler.ReapExpiredSubscriptions(ctx, now)
+		if err != nil {
+			return reaped, err
+		}
+		reaped += expired
+		subscriptionsExpired.WithLabelValues("o2dms").Add(float64(expired))
+		subscriptionsActive.WithLabelValues("o2dms").Set(float64(active))
+	}
+	return reaped, nil
+}
+
+// deleteExpiredSubscription deletes an expired subscription from the adapter
+// and storage and releases its statistics, certificates and quota. It
+// reports false when another replica deleted the subscription first.
+func (s *Server) deleteExpiredSubscription(ctx context.Context, sub *storage.Subscription) (bool, error) {
+	if err := s.adapter.DeleteSubscription(ctx, sub.ID); err != nil &&
+		!errors.Is(err, adapter.ErrSubscriptionNotFound) {
+		return false, fmt.Errorf("failed to delete subscription from adapter: %w", err)
+	}
+	if err := s.store.Delete(ctx, sub.ID); err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to delete subscription from storage: %w", err)
+	}
+	s.releaseSubscription(ctx, s.logger, sub.ID, sub.TenantID)
+
+	s.logger.Info("expired subscription deleted",
+		zap.String("subscription_id", sub.ID),
+		zap.String("tenant_id", sub.TenantID),
+		zap.String("callback", dmshandlers.RedactURL(sub.Callback)),
+		zap.Timep("expires_at", sub.ExpiresAt))
+
+	if s.auditLogger != nil {
+		s.auditLogger.LogSubscriptionOperation(ctx, auth.AuditEventSubscriptionDeleted, sub.ID, sub.Callback,
+			&auth.AuthenticatedUser{UserID: subscriptionExpiryActor, TenantID: sub.TenantID},
+			map[string]string{
+				"reason":    "expired",
+				"tenant_id": sub.TenantID,
+			})
+	}
+	return true, nil
+}
+
+// releaseSubscription removes the statistics and certificates of a deleted
+// subscription and returns its slot to the tenant quota. Failures are logged,
+// as the subscription is already gone.
+func (s *Server) releaseSubscription(ctx context.Context, logger *zap.Logger, subscriptionID, tenantID string) {
+	if s.subscriptionStats != nil {
+		if err := s.subscriptionStats.Delete(ctx, subscriptionID); err != nil {
+			logger.Warn("failed to delete subscription statistics", zap.Error(err))
+		}
+	}
+	if s.deliverability != nil {
+		if err := s.deliverability.Delete(ctx, subscriptionID); err != nil {
+			logger.Warn("failed to delete subscription delivery statistics", zap.Error(err))
+		}
+	}
+	if err := s.revokeSubscriptionCertificates(ctx, subscriptionID); err != nil {
+		logger.Warn("failed to revoke subscription certificates", zap.Error(err))
+	}
+
+	// Decrement tenant quota after successful deletion
+	if tenantID != "" && s.AuthStore != nil {
+		if err := s.AuthStore.DecrementUsage(ctx, tenantID, "subscriptions"); err != nil {
+			logger.Error("failed to decrement subscription quota",
+				zap.String("tenant_id", tenantID),
+				zap.Error(err))
+		}
+	}
+}
+
+// StartSubscriptionExpiry deletes expired subscriptions at the configured
+// interval until ctx is canceled.
+func (s *Server) StartSubscriptionExpiry(ctx context.Context) {
+	interval := s.config.Subscriptions.ExpiryCheckInterval
+	if interval <= 0 || (s.store == nil && s.dmsHandler == nil) {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			reaped, err := s.ReapExpiredSubscriptions(ctx, timeutil.Now())
+			switch {
+			case err != nil:
+				s.logger.Error("subscription expiry cleanup failed", zap.Error(err))
+			case reaped > 0:
+				s.logger.Info("expired subscriptions deleted", zap.Int("count", reaped))
+			}
+		}
+	}()
+}
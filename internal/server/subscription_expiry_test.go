@@ -0,0 +1,93 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+func TestSubscriptionExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("expiry is stored and refreshed", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback":  "https://smo.example.com/notify",
+			"expiresAt": now.Add(time.Hour),
+		})
+		require.Equal(t, http.StatusCreated, code)
+		require.NotNil(t, created.ExpiresAt)
+		assert.True(t, created.ExpiresAt.Equal(now.Add(time.Hour)))
+		path := subscriptionsPath + "/" + created.SubscriptionID
+
+		resp, _ := doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback":  "https://smo.example.com/notify",
+			"expiresAt": now.Add(24 * time.Hour),
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var got adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.ExpiresAt)
+		assert.True(t, got.ExpiresAt.Equal(now.Add(24*time.Hour)))
+
+		// The refreshed subscription outlives the original expiry.
+		reaped, err := srv.ReapExpiredSubscriptions(ctx, now.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, reaped)
+	})
+
+	t.Run("past expiry is rejected", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		resp, body := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath, map[string]interface{}{
+			"callback":  "https://smo.example.com/notify",
+			"expiresAt": now.Add(-time.Minute),
+		})
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, string(body), "invalid expiresAt")
+	})
+
+	t.Run("expired subscriptions are deleted", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, expiring := createSubscription(t, srv, map[string]interface{}{
+			"callback":  "https://smo.example.com/expiring",
+			"expiresAt": now.Add(time.Hour),
+		})
+		require.Equal(t, http.StatusCreated, code)
+		code, permanent := createSubscription(t, srv, map[string]interface{}{
+			"callback": "https://smo.example.com/permanent",
+		})
+		require.Equal(t, http.StatusCreated, code)
+
+		reaped, err := srv.ReapExpiredSubscriptions(ctx, now.Add(30*time.Minute))
+		require.NoError(t, err)
+		assert.Zero(t, reaped, "not expired yet")
+
+		reaped, err = srv.ReapExpiredSubscriptions(ctx, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, reaped)
+
+		resp, _ := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/"+expiring.SubscriptionID, nil)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		resp, _ = doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/"+permanent.SubscriptionID, nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		// A later run finds nothing left to delete.
+		reaped, err = srv.ReapExpiredSubscriptions(ctx, now.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, reaped)
+	})
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/problem"
+)
+
+// SubscriptionSecretGrace is how long the replaced signing secret of a
+// subscription keeps signing its notifications after a PUT rotates it.
+const SubscriptionSecretGrace = DefaultSigningKeyValidity
+
+// errSecretGeneration reports that a requested signing secret could not be
+// generated, as opposed to an invalid request.
+var errSecretGeneration = errors.New("failed to generate signing secret")
+
+// takeSigningSecret removes the signing secret of a subscription request,
+// which the adapters must not see, and returns it, generating it when asked
+// to. generated reports whether the gateway generated the secret, in which
+// case it is returned once in the response. An empty secret leaves the
+// signing of the subscription unchanged.
+func takeSigningSecret(req *adapter.Subscription) (secret string, generated bool, err error) {
+	secret, generate := req.SigningSecret, req.GenerateSigningSecret
+	req.SigningSecret, req.GenerateSigningSecret = "", false
+
+	switch {
+	case generate && secret != "":
+		return "", false, errors.New("signingSecret and generateSigningSecret are mutually exclusive")
+	case generate:
+		if secret, err = generateSigningSecret(); err != nil {
+			return "", false, fmt.Errorf("%w: %w", errSecretGeneration, err)
+		}
+		return secret, true, nil
+	case secret != "" && len(secret) < MinSigningSecretLength:
+		return "", false, fmt.Errorf("signingSecret must be at least %d characters", MinSigningSecretLength)
+	}
+	return secret, false, nil
+}
+
+// respondSigningSecretError responds to a request whose signing secret
+// takeSigningSecret rejected.
+func respondSigningSecretError(c *gin.Context, err error) {
+	if errors.Is(err, errSecretGeneration) {
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to generate signing secret")
+		return
+	}
+	problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+}
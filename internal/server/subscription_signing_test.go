@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// setupSigningTestServer creates a server with a Redis-backed subscription
+// store, which it also returns.
+func setupSigningTestServer(t *testing.T) (*server.Server, storage.Store) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mr := miniredis.RunT(t)
+	store := storage.NewRedisStore(&storage.RedisConfig{
+		Addr:                   mr.Addr(),
+		MaxRetries:             1,
+		DialTimeout:            time.Second,
+		ReadTimeout:            time.Second,
+		WriteTimeout:           time.Second,
+		PoolSize:               5,
+		AllowInsecureCallbacks: true,
+	})
+	t.Cleanup(func() { _ = store.Close() })
+
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Port: 8080, GinMode: gin.TestMode},
+		Security: config.SecurityConfig{DisableSSRFProtection: true},
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), newMockResourceAdapter(), store)
+	return srv, store
+}
+
+func TestSubscriptionSigningSecret(t *testing.T) {
+	ctx := context.Background()
+	secret := strings.Repeat("s", server.MinSigningSecretLength)
+
+	t.Run("supplied secret is stored but never returned", func(t *testing.T) {
+		srv, store := setupSigningTestServer(t)
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback":      "https://smo.example.com/notify",
+			"signingSecret": secret,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		assert.Empty(t, created.SigningSecret)
+
+		stored, err := store.Get(ctx, created.SubscriptionID)
+		require.NoError(t, err)
+		assert.Equal(t, secret, stored.SigningSecret)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/"+created.SubscriptionID, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.NotContains(t, string(body), secret)
+	})
+
+	t.Run("generated secret is returned once", func(t *testing.T) {
+		srv, store := setupSigningTestServer(t)
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback":              "https://smo.example.com/notify",
+			"generateSigningSecret": true,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		require.GreaterOrEqual(t, len(created.SigningSecret), server.MinSigningSecretLength)
+		assert.False(t, created.GenerateSigningSecret)
+
+		stored, err := store.Get(ctx, created.SubscriptionID)
+		require.NoError(t, err)
+		assert.Equal(t, created.SigningSecret, stored.SigningSecret)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/"+created.SubscriptionID, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var got adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Empty(t, got.SigningSecret)
+	})
+
+	t.Run("invalid secrets are rejected", func(t *testing.T) {
+		srv, _ := setupSigningTestServer(t)
+
+		for name, body := range map[string]map[string]interface{}{
+			"too short": {"callback": "https://smo.example.com/notify", "signingSecret": "short"},
+			"both":      {"callback": "https://smo.example.com/notify", "signingSecret": secret, "generateSigningSecret": true},
+		} {
+			code, _ := createSubscription(t, srv, body)
+			assert.Equal(t, http.StatusBadRequest, code, name)
+		}
+	})
+
+	t.Run("PUT rotates the secret with a grace period", func(t *testing.T) {
+		srv, store := setupSigningTestServer(t)
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback":      "https://smo.example.com/notify",
+			"signingSecret": secret,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		path := subscriptionsPath + "/" + created.SubscriptionID
+
+		// A PUT without a secret keeps it.
+		resp, _ := doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback": "https://smo.example.com/notify",
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+		stored, err := store.Get(ctx, created.SubscriptionID)
+		require.NoError(t, err)
+		assert.Equal(t, secret, stored.SigningSecret)
+
+		resp, body := doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback":              "https://smo.example.com/notify",
+			"generateSigningSecret": true,
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+		var updated adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &updated))
+		require.NotEmpty(t, updated.SigningSecret)
+
+		stored, err = store.Get(ctx, created.SubscriptionID)
+		require.NoError(t, err)
+		current, previous := stored.SigningSecrets(time.Now())
+		assert.Equal(t, updated.SigningSecret, current)
+		assert.Equal(t, secret, previous)
+
+		_, previous = stored.SigningSecrets(time.Now().Add(server.SubscriptionSecretGrace))
+		assert.Empty(t, previous)
+	})
+}
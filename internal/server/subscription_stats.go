@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+// SubscriptionWithStats is a GET subscriptions list item when ?stats=true.
+type SubscriptionWithStats struct {
+	*adapter.Subscription
+	Stats *storage.SubscriptionStats `json:"stats"`
+
+	// MatchesEverything is true when the subscription has an empty filter.
+	MatchesEverything bool `json:"matchesEverything"`
+}
+
+// SetSubscriptionStatsStore sets the store holding the match statistics the
+// notification controller records. This enables ?stats=true on
+// GET /subscriptions.
+func (s *Server) SetSubscriptionStatsStore(store storage.SubscriptionStatsStore) {
+	s.subscriptionStats = store
+}
+
+// writeSubscriptionsWithStats writes the subscription list with the match
+// statistics of every subscription. It is an admin view: tenant users get 403.
+func (s *Server) writeSubscriptionsWithStats(c *gin.Context, subs []*adapter.Subscription) {
+	ctx := c.Request.Context()
+	if auth.TenantIDFromContext(ctx) != "" && !auth.IsPlatformAdminFromContext(ctx) {
+		problem.Respond(c, http.StatusForbidden, "Forbidden",
+			"Subscription statistics require platform admin privileges")
+		return
+	}
+	if s.subscriptionStats == nil {
+		problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+			"Subscription statistics are not available")
+		return
+	}
+
+	snapshot, err := s.subscriptionStats.Snapshot(ctx)
+	if err != nil {
+		s.requestLogger(c).Error("failed to get subscription statistics", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError",
+			"Failed to retrieve subscription statistics")
+		return
+	}
+
+	items := make([]*SubscriptionWithStats, 0, len(subs))
+	for _, sub := range subs {
+		items = append(items, &SubscriptionWithStats{
+			Subscription:      sub,
+			Stats:             snapshot.For(sub.SubscriptionID),
+			MatchesEverything: sub.Filter == nil || *sub.Filter == adapter.SubscriptionFilter{},
+		})
+	}
+	c.JSON(http.StatusOK, o2imsmodels.ListEnvelope[*SubscriptionWithStats]{
+		Kind:  "subscriptions",
+		Items: items,
+		Total: len(items),
+	})
+}
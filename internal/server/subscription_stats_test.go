@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestHandleListSubscriptions_Stats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	store, mr := setupTestStore(t)
+	defer mr.Close()
+	require.NoError(t, store.Create(ctx, &storage.Subscription{
+		ID: "sub-all", Callback: "https://smo.example.com/all", TenantID: "tenant-1",
+	}))
+	require.NoError(t, store.Create(ctx, &storage.Subscription{
+		ID: "sub-pool", Callback: "https://smo.example.com/pool", TenantID: "tenant-1",
+		Filter: storage.SubscriptionFilter{ResourcePoolID: "pool-1"},
+	}))
+
+	srv := &Server{store: store, logger: zap.NewNop()}
+	var user *auth.AuthenticatedUser
+	router := gin.New()
+	router.GET("/subscriptions", func(c *gin.Context) {
+		if user != nil {
+			c.Request = c.Request.WithContext(auth.ContextWithUser(c.Request.Context(), user))
+		}
+		srv.handleListSubscriptions(c)
+	})
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/subscriptions?stats=true", nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, get().Code, "no statistics store configured")
+
+	stats := storage.NewInMemorySubscriptionStatsStore()
+	srv.SetSubscriptionStatsStore(stats)
+	now := time.Now()
+	require.NoError(t, stats.RecordEvent(ctx, []string{"sub-all", "sub-pool"}, now))
+	require.NoError(t, stats.RecordEvent(ctx, []string{"sub-all"}, now))
+
+	user = &auth.AuthenticatedUser{UserID: "user-1", TenantID: "tenant-1"}
+	assert.Equal(t, http.StatusForbidden, get().Code, "statistics are an admin view")
+
+	user = &auth.AuthenticatedUser{UserID: "admin", TenantID: "tenant-1", IsPlatformAdmin: true}
+	w := get()
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Subscriptions []SubscriptionWithStats `json:"subscriptions"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Subscriptions, 2)
+	byID := make(map[string]SubscriptionWithStats)
+	for _, sub := range resp.Subscriptions {
+		byID[sub.SubscriptionID] = sub
+	}
+
+	all := byID["sub-all"]
+	assert.True(t, all.MatchesEverything)
+	assert.Equal(t, int64(2), all.Stats.MatchedEvents)
+	assert.InDelta(t, 1.0, all.Stats.MatchRatio, 0.001)
+	assert.InDelta(t, 1.5, all.Stats.AverageFanOut, 0.001)
+
+	pool := byID["sub-pool"]
+	assert.False(t, pool.MatchesEverything)
+	assert.Equal(t, int64(1), pool.Stats.MatchedEvents)
+	assert.InDelta(t, 0.5, pool.Stats.MatchRatio, 0.001)
+}
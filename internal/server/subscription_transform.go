@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/transform"
+)
+
+// sampleNotification is a notification with every field set, against which
+// transformations are trial-run when a subscription registers them.
+var sampleNotification = controllers.ResourceEvent{
+	SubscriptionID:   "sub-00000000-0000-0000-0000-000000000000",
+	EventType:        "o2ims.Resource.Created",
+	ObjectRef:        "/o2ims/v1/resources/sample-node",
+	ResourceTypeID:   "k8s-node",
+	ResourcePoolID:   "default",
+	GlobalResourceID: "sample-node",
+	Timestamp:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	NotificationID:   "notif-sample",
+	CallbackURL:      "https://smo.example.com/notifications",
+	SequenceNumber:   1,
+	Extensions:       map[string]string{"sample": "true"},
+}
+
+// ValidateTransform checks the transformation of a subscription, if it has
+// one, by compiling it and running it against a sample notification.
+func ValidateTransform(ctx context.Context, sub *adapter.Subscription) error {
+	if sub.Transform == nil {
+		return nil
+	}
+	sample, err := json.Marshal(&sampleNotification)
+	if err != nil {
+		return fmt.Errorf("failed to encode sample notification: %w", err)
+	}
+	if err := transform.Validate(ctx, sub.Transform, sample); err != nil {
+		return fmt.Errorf("invalid transform: %w", err)
+	}
+	return nil
+}
+
+// ValidateDigest checks the digest settings of a subscription, if it has any.
+func ValidateDigest(sub *adapter.Subscription) error {
+	if sub.Digest == nil {
+		return nil
+	}
+	if err := sub.Digest.Validate(controllers.NotificationEventTypes); err != nil {
+		return fmt.Errorf("invalid digest: %w", err)
+	}
+	return nil
+}
+
+// storeSubscriptionDelivery records the transformation, digest settings and
+// expiry of an updated subscription, which the adapters do not persist. The
+// signing secrets of existing, the subscription before the update, are kept,
+// and rotated to secret unless it is empty.
+func (s *Server) storeSubscriptionDelivery(
+	c *gin.Context, subscriptionID string, req *adapter.Subscription,
+	existing *storage.Subscription, secret string,
+) {
+	if s.store == nil {
+		return
+	}
+	ctx := c.Request.Context()
+	sub, err := s.store.Get(ctx, subscriptionID)
+	if err != nil {
+		s.requestLogger(c).Error("failed to load subscription to store its delivery settings",
+			zap.String("subscription_id", subscriptionID),
+			zap.Error(err))
+		return
+	}
+	signed := secret != "" || (existing != nil && existing.SigningSecret != "")
+	if sub.Transform == nil && req.Transform == nil && sub.Digest == nil && req.Digest == nil &&
+		sub.ExpiresAt == nil && req.ExpiresAt == nil && !signed {
+		return
+	}
+
+	sub.Transform = req.Transform
+	sub.Digest = req.Digest
+	sub.ExpiresAt = req.ExpiresAt
+	if existing != nil {
+		sub.SigningSecret = existing.SigningSecret
+		sub.PreviousSigningSecret = existing.PreviousSigningSecret
+		sub.PreviousSigningSecretExpiresAt = existing.PreviousSigningSecretExpiresAt
+	}
+	if secret != "" {
+		sub.RotateSigningSecret(secret, time.Now(), SubscriptionSecretGrace)
+	}
+	if err := s.store.Update(ctx, sub); err != nil {
+		s.requestLogger(c).Error("failed to store subscription delivery settings",
+			zap.String("subscription_id", subscriptionID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,89 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+)
+
+func TestSubscriptionTransform(t *testing.T) {
+	jq := map[string]string{"language": "jq", "source": "{id: .globalResourceId, event: .notificationEventType}"}
+
+	t.Run("valid transform is stored", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback":  "https://smo.example.com/notify",
+			"transform": jq,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		require.NotNil(t, created.Transform)
+		assert.Equal(t, jq["source"], created.Transform.Source)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, subscriptionsPath+"/"+created.SubscriptionID, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var got adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.Transform)
+		assert.Equal(t, "jq", got.Transform.Language)
+	})
+
+	t.Run("invalid transforms are rejected at creation", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		for _, tmpl := range []map[string]string{
+			{"language": "jq", "source": ".a | length"},
+			{"language": "lua", "source": "return event"},
+			{"language": "gotemplate", "source": "{{.globalResourceId"},
+			{"language": "gotemplate", "source": "id={{.globalResourceId}}"},
+		} {
+			resp, body := doResourceRequest(t, srv, http.MethodPost, subscriptionsPath, map[string]interface{}{
+				"callback":  "https://smo.example.com/notify",
+				"transform": tmpl,
+			})
+			assert.Equal(t, http.StatusBadRequest, resp.Code, tmpl["source"])
+			assert.Contains(t, string(body), "invalid transform")
+		}
+	})
+
+	t.Run("update replaces and removes the transform", func(t *testing.T) {
+		srv := setupDuplicatesTestServer(t, "")
+
+		code, created := createSubscription(t, srv, map[string]interface{}{
+			"callback":  "https://smo.example.com/notify",
+			"transform": jq,
+		})
+		require.Equal(t, http.StatusCreated, code)
+		path := subscriptionsPath + "/" + created.SubscriptionID
+
+		goTemplate := map[string]string{"language": "gotemplate", "source": `{"id": {{json .globalResourceId}}}`}
+		resp, _ := doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback":  "https://smo.example.com/notify",
+			"transform": goTemplate,
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		resp, body := doResourceRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		var got adapter.Subscription
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.Transform)
+		assert.Equal(t, "gotemplate", got.Transform.Language)
+
+		resp, _ = doResourceRequest(t, srv, http.MethodPut, path, map[string]interface{}{
+			"callback": "https://smo.example.com/notify",
+		})
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		resp, body = doResourceRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		got = adapter.Subscription{}
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Nil(t, got.Transform)
+	})
+}
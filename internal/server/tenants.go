@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/auth"
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+// Tenant usage types of the inventory objects tenants create. They double as
+// the kinds of the ownership records of those objects.
+const (
+	usageResourcePools = "resourcePools"
+	usageResources     = "resources"
+)
+
+// TenantGetter looks up tenants by ID.
+type TenantGetter interface {
+	// GetTenant returns auth.ErrTenantNotFound if the tenant does not exist.
+	GetTenant(ctx context.Context, id string) (*auth.Tenant, error)
+}
+
+// tenantQuotaStore reads tenants and replaces their quotas.
+type tenantQuotaStore interface {
+	TenantGetter
+	UpdateTenantQuota(ctx context.Context, tenantID string, quota auth.TenantQuota) (*auth.Tenant, error)
+}
+
+// tenantGetter returns the auth store as a TenantGetter, or nil when
+// multi-tenancy is disabled.
+func (s *Server) tenantGetter() TenantGetter {
+	tenants, _ := s.AuthStore.(TenantGetter)
+	return tenants
+}
+
+// ownershipStore returns the store recording which tenant owns the inventory
+// objects created through the API, or nil when no ownership is recorded.
+func (s *Server) ownershipStore() auth.OwnershipStore {
+	owners, _ := s.AuthStore.(auth.OwnershipStore)
+	return owners
+}
+
+// ownerScope returns the tenant whose objects a request is restricted to, or ""
+// when it is unrestricted: without ownership records, for platform admins and
+// for requests without a tenant.
+func (s *Server) ownerScope(ctx context.Context) string {
+	if s.ownershipStore() == nil || auth.IsPlatformAdminFromContext(ctx) {
+		return ""
+	}
+	return auth.TenantIDFromContext(ctx)
+}
+
+// ownerVisible reports whether an object owned by owner is visible within
+// scope. Objects without an owner, such as discovered infrastructure, are shared.
+func ownerVisible(scope, owner string) bool {
+	return scope == "" || owner == "" || owner == scope
+}
+
+// authorizeOwner checks that the request may access an object and returns its
+// recorded owner. reported is the tenant the adapter reports for the object,
+// used when no owner is recorded. Objects of other tenants are answered with
+// 404, like missing ones; false is returned once a response was written.
+func (s *Server) authorizeOwner(c *gin.Context, kind, id, reported, notFound string) (string, bool) {
+	owners := s.ownershipStore()
+	if owners == nil {
+		return "", true
+	}
+
+	ctx := c.Request.Context()
+	owner, err := owners.GetOwner(ctx, kind, id)
+	if err != nil {
+		s.requestLogger(c).Error("failed to look up object owner",
+			zap.String("kind", kind),
+			zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to verify tenant ownership")
+		return "", false
+	}
+
+	effective := owner
+	if effective == "" {
+		effective = reported
+	}
+	if !ownerVisible(s.ownerScope(ctx), effective) {
+		s.requestLogger(c).Warn("cross-tenant access denied",
+			zap.String("kind", kind),
+			zap.String("tenant_id", auth.TenantIDFromContext(ctx)),
+			zap.String("owner_tenant_id", effective))
+		problem.Respond(c, http.StatusNotFound, "NotFound", notFound)
+		return "", false
+	}
+	return owner, true
+}
+
+// filterOwned drops the objects not visible within scope. ownerOf returns the
+// ID of an object and the tenant the adapter reports for it.
+func filterOwned[T any](
+	ctx context.Context,
+	owners auth.OwnershipStore,
+	kind, scope string,
+	items []T,
+	ownerOf func(T) (string, string),
+) ([]T, error) {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i], _ = ownerOf(item)
+	}
+	recorded, err := owners.GetOwners(ctx, kind, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]T, 0, len(items))
+	for i, item := range items {
+		owner := recorded[ids[i]]
+		if owner == "" {
+			_, owner = ownerOf(item)
+		}
+		if ownerVisible(scope, owner) {
+			visible = append(visible, item)
+		}
+	}
+	return visible, nil
+}
+
+// scopedList calls list with filter, dropping the objects of other tenants
+// when the request is restricted to one. Pages are then cut by the gateway,
+// after filtering, rather than by the adapter.
+func scopedList[T any](
+	ctx context.Context,
+	s *Server,
+	kind string,
+	filter *adapter.Filter,
+	list func(context.Context, *adapter.Filter) ([]T, error),
+	ownerOf func(T) (string, string),
+) ([]T, error) {
+	scope := s.ownerScope(ctx)
+	if scope == "" || filter == nil {
+		return list(ctx, filter)
+	}
+
+	unpaged := *filter
+	unpaged.Limit, unpaged.Offset = 0, 0
+	items, err := list(ctx, &unpaged)
+	if err != nil {
+		return nil, err
+	}
+	if items, err = filterOwned(ctx, s.ownershipStore(), kind, scope, items, ownerOf); err != nil {
+		return nil, err
+	}
+	return adapter.ApplyPagination(items, filter.Limit, filter.Offset), nil
+}
+
+// poolOwner returns the ID and reported tenant of a resource pool.
+func poolOwner(pool *adapter.ResourcePool) (string, string) {
+	return pool.ResourcePoolID, pool.TenantID
+}
+
+// resourceOwner returns the ID and reported tenant of a resource.
+func resourceOwner(resource *adapter.Resource) (string, string) {
+	return resource.ResourceID, resource.TenantID
+}
+
+// reserveUsage counts an object about to be created by a tenant against its
+// quota. noun names the object in the error response written when the quota
+// is exhausted or cannot be checked, in which case false is returned.
+func (s *Server) reserveUsage(c *gin.Context, tenantID, usageType, noun string) bool {
+	err := s.AuthStore.IncrementUsage(c.Request.Context(), tenantID, usageType)
+	if err == nil {
+		return true
+	}
+
+	if errors.Is(err, auth.ErrQuotaExceeded) {
+		s.requestLogger(c).Warn("tenant quota exceeded",
+			zap.String("tenant_id", tenantID),
+			zap.String("usage_type", usageType))
+		problem.Respond(c, http.StatusTooManyRequests, "QuotaExceeded", noun+" quota exceeded for tenant")
+		return false
+	}
+
+	s.requestLogger(c).Error("failed to check tenant quota",
+		zap.String("tenant_id", tenantID),
+		zap.String("usage_type", usageType),
+		zap.Error(err))
+	problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to check "+usageType+" quota")
+	return false
+}
+
+// releaseUsage gives back the quota of an object that was not created or was deleted.
+func (s *Server) releaseUsage(ctx context.Context, tenantID, usageType string) {
+	if err := s.AuthStore.DecrementUsage(ctx, tenantID, usageType); err != nil {
+		s.logger.Error("failed to release tenant quota",
+			zap.String("tenant_id", tenantID),
+			zap.String("usage_type", usageType),
+			zap.Error(err))
+	}
+}
+
+// recordOwner records a tenant as the owner of an object it created. Without
+// a record the object is shared, so its quota is released on failure.
+func (s *Server) recordOwner(ctx context.Context, kind, id, tenantID string) {
+	if err := s.ownershipStore().SetOwner(ctx, kind, id, tenantID); err != nil {
+		s.logger.Error("failed to record object owner",
+			zap.String("kind", kind),
+			zap.String("tenant_id", tenantID),
+			zap.Error(err))
+		s.releaseUsage(ctx, tenantID, kind)
+	}
+}
+
+// forgetOwner removes the ownership record of a deleted object and releases
+// the quota it held.
+func (s *Server) forgetOwner(ctx context.Context, kind, id, owner string) {
+	if owner == "" {
+		return
+	}
+	if err := s.ownershipStore().DeleteOwner(ctx, kind, id); err != nil {
+		s.logger.Warn("failed to delete object owner",
+			zap.String("kind", kind),
+			zap.Error(err))
+	}
+	s.releaseUsage(ctx, owner, kind)
+}
+
+// tenantQuotas returns the quota response of a tenant.
+func tenantQuotas(tenant *auth.Tenant) o2imsmodels.TenantQuotas {
+	usage := tenant.Usage
+	return o2imsmodels.TenantQuotas{
+		TenantID: tenant.ID,
+		Quotas: o2imsmodels.TenantQuotaDetails{
+			MaxSubscriptions:  tenant.Quota.MaxSubscriptions,
+			MaxResourcePools:  tenant.Quota.MaxResourcePools,
+			MaxResources:      tenant.Quota.MaxResources,
+			UsedSubscriptions: &usage.Subscriptions,
+			UsedResourcePools: &usage.ResourcePools,
+			UsedResources:     &usage.Resources,
+		},
+		UpdatedAt: timeutil.Format(tenant.UpdatedAt),
+	}
+}
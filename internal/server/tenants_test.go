@@ -0,0 +1,301 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapters/mock"
+	"github.com/piwi3910/netweave/internal/auth"
+	o2imsmodels "github.com/piwi3910/netweave/internal/o2ims/models"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+// testUserHeader carries the tenant of the test user; "admin" is a platform admin.
+const testUserHeader = "X-Test-Tenant"
+
+// setupTenantTestServer returns a router serving the inventory and quota
+// handlers of a server backed by a Redis auth store holding tenant-a and tenant-b.
+func setupTenantTestServer(t *testing.T) (*gin.Engine, *auth.RedisStore) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	mr := miniredis.RunT(t)
+	authStore := auth.NewRedisStoreWithClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	t.Cleanup(func() { _ = authStore.Close() })
+
+	ctx := context.Background()
+	for _, id := range []string{"tenant-a", "tenant-b"} {
+		require.NoError(t, authStore.CreateTenant(ctx, &auth.Tenant{
+			ID:     id,
+			Name:   id,
+			Status: auth.TenantStatusActive,
+			Quota:  auth.DefaultQuota(),
+		}))
+	}
+
+	srv := &Server{
+		logger:    zap.NewNop(),
+		adapter:   mock.NewAdapter(false),
+		AuthStore: authStore,
+	}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if tenantID := c.GetHeader(testUserHeader); tenantID != "" {
+			user := &auth.AuthenticatedUser{UserID: "user-" + tenantID, TenantID: tenantID}
+			if tenantID == "admin" {
+				user.TenantID, user.IsPlatformAdmin = "", true
+			}
+			c.Request = c.Request.WithContext(auth.ContextWithUser(c.Request.Context(), user))
+		}
+		c.Next()
+	})
+	router.GET("/resourcePools", srv.handleListResourcePools)
+	router.POST("/resourcePools", srv.handleCreateResourcePool)
+	router.GET("/resourcePools/:resourcePoolId", srv.handleGetResourcePool)
+	router.PUT("/resourcePools/:resourcePoolId", srv.handleUpdateResourcePool)
+	router.DELETE("/resourcePools/:resourcePoolId", srv.handleDeleteResourcePool)
+	router.GET("/resourcePools/:resourcePoolId/resources", srv.handleListResourcesInPool)
+	router.POST("/resources", srv.handleCreateResource)
+	router.GET("/tenants/:tenantId/quotas", srv.handleGetTenantQuotas)
+	router.PUT("/tenants/:tenantId/quotas", srv.handleUpdateTenantQuotas)
+	return router, authStore
+}
+
+func doTenantRequest(router *gin.Engine, tenantID, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if tenantID != "" {
+		req.Header.Set(testUserHeader, tenantID)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// listPoolIDs lists the resource pools visible to a tenant.
+func listPoolIDs(t *testing.T, router *gin.Engine, tenantID string) []string {
+	t.Helper()
+	w := doTenantRequest(router, tenantID, http.MethodGet, "/resourcePools", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var envelope struct {
+		ResourcePools []struct {
+			ResourcePoolID string `json:"resourcePoolId"`
+		} `json:"resourcePools"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	ids := make([]string, 0, len(envelope.ResourcePools))
+	for _, pool := range envelope.ResourcePools {
+		ids = append(ids, pool.ResourcePoolID)
+	}
+	return ids
+}
+
+func TestResourcePoolOwnership(t *testing.T) {
+	router, authStore := setupTenantTestServer(t)
+	ctx := context.Background()
+
+	pools := map[string]string{"tenant-a": "pool-a", "tenant-b": "pool-b", "admin": "pool-shared"}
+	for tenantID, poolID := range pools {
+		w := doTenantRequest(router, tenantID, http.MethodPost, "/resourcePools",
+			`{"resourcePoolId":"`+poolID+`","name":"`+poolID+`"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	owner, err := authStore.GetOwner(ctx, usageResourcePools, "pool-a")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", owner)
+	tenant, err := authStore.GetTenant(ctx, "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, tenant.Usage.ResourcePools)
+
+	// The mock adapter filters lists by the tenant of the request as well.
+	t.Run("lists hold own pools", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"pool-a"}, listPoolIDs(t, router, "tenant-a"))
+		assert.ElementsMatch(t, []string{"pool-b"}, listPoolIDs(t, router, "tenant-b"))
+		assert.ElementsMatch(t, []string{"pool-a", "pool-b", "pool-shared"}, listPoolIDs(t, router, "admin"))
+	})
+
+	t.Run("resources of other tenants are dropped from pool lists", func(t *testing.T) {
+		for tenantID, resourceID := range map[string]string{
+			"tenant-a": "0b5c3ac4-6a8e-4e43-9a3c-3c1d5e0f4a01",
+			"admin":    "0b5c3ac4-6a8e-4e43-9a3c-3c1d5e0f4a02",
+		} {
+			w := doTenantRequest(router, tenantID, http.MethodPost, "/resources",
+				`{"resourceId":"`+resourceID+`","resourceTypeId":"node","resourcePoolId":"pool-shared"}`)
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		}
+
+		w := doTenantRequest(router, "tenant-b", http.MethodGet, "/resourcePools/pool-shared/resources", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "4a01")
+		assert.Contains(t, w.Body.String(), "4a02")
+
+		w = doTenantRequest(router, "tenant-a", http.MethodGet, "/resourcePools/pool-shared/resources", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "4a01")
+	})
+
+	t.Run("pools of other tenants are not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK,
+			doTenantRequest(router, "tenant-a", http.MethodGet, "/resourcePools/pool-a", "").Code)
+		assert.Equal(t, http.StatusOK,
+			doTenantRequest(router, "tenant-b", http.MethodGet, "/resourcePools/pool-shared", "").Code)
+		assert.Equal(t, http.StatusOK,
+			doTenantRequest(router, "admin", http.MethodGet, "/resourcePools/pool-a", "").Code)
+		assert.Equal(t, http.StatusNotFound,
+			doTenantRequest(router, "tenant-b", http.MethodGet, "/resourcePools/pool-a", "").Code)
+		assert.Equal(t, http.StatusNotFound,
+			doTenantRequest(router, "tenant-b", http.MethodPut, "/resourcePools/pool-a", `{"name":"taken"}`).Code)
+		assert.Equal(t, http.StatusNotFound,
+			doTenantRequest(router, "tenant-b", http.MethodDelete, "/resourcePools/pool-a", "").Code)
+	})
+
+	t.Run("deletion releases quota and ownership", func(t *testing.T) {
+		w := doTenantRequest(router, "tenant-a", http.MethodDelete, "/resourcePools/pool-a", "")
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		tenant, err := authStore.GetTenant(ctx, "tenant-a")
+		require.NoError(t, err)
+		assert.Equal(t, 0, tenant.Usage.ResourcePools)
+		owner, err := authStore.GetOwner(ctx, usageResourcePools, "pool-a")
+		require.NoError(t, err)
+		assert.Empty(t, owner)
+	})
+
+	t.Run("creation beyond the quota is rejected", func(t *testing.T) {
+		w := doTenantRequest(router, "admin", http.MethodPut, "/tenants/tenant-b/quotas", `{"maxResourcePools":1}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doTenantRequest(router, "tenant-b", http.MethodPost, "/resourcePools", `{"name":"second"}`)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), "QuotaExceeded")
+		assert.ElementsMatch(t, []string{"pool-b"}, listPoolIDs(t, router, "tenant-b"))
+	})
+}
+
+func TestHandleTenantQuotas(t *testing.T) {
+	router, authStore := setupTenantTestServer(t)
+	ctx := context.Background()
+	require.NoError(t, authStore.IncrementUsage(ctx, "tenant-a", "subscriptions"))
+
+	w := doTenantRequest(router, "admin", http.MethodGet, "/tenants/tenant-a/quotas", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var quotas o2imsmodels.TenantQuotas
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &quotas))
+	assert.Equal(t, "tenant-a", quotas.TenantID)
+	assert.Equal(t, auth.DefaultQuota().MaxSubscriptions, quotas.Quotas.MaxSubscriptions)
+	require.NotNil(t, quotas.Quotas.UsedSubscriptions)
+	assert.Equal(t, 1, *quotas.Quotas.UsedSubscriptions)
+
+	w = doTenantRequest(router, "admin", http.MethodPut, "/tenants/tenant-a/quotas",
+		`{"maxSubscriptions":5,"maxResources":20}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &quotas))
+	assert.Equal(t, 5, quotas.Quotas.MaxSubscriptions)
+	assert.Equal(t, auth.DefaultQuota().MaxResourcePools, quotas.Quotas.MaxResourcePools, "omitted limits are kept")
+	assert.Equal(t, 20, quotas.Quotas.MaxResources)
+	assert.Equal(t, 1, *quotas.Quotas.UsedSubscriptions, "usage is kept")
+	assert.NotEmpty(t, quotas.UpdatedAt)
+
+	tenant, err := authStore.GetTenant(ctx, "tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, 5, tenant.Quota.MaxSubscriptions)
+	assert.Equal(t, 20, tenant.Quota.MaxResources)
+
+	assert.Equal(t, http.StatusNotFound,
+		doTenantRequest(router, "admin", http.MethodGet, "/tenants/missing/quotas", "").Code)
+	assert.Equal(t, http.StatusNotFound,
+		doTenantRequest(router, "admin", http.MethodPut, "/tenants/missing/quotas", `{"maxSubscriptions":5}`).Code)
+	assert.Equal(t, http.StatusBadRequest,
+		doTenantRequest(router, "admin", http.MethodPut, "/tenants/tenant-a/quotas", `{"maxResources":-1}`).Code)
+}
+
+func TestTenantMiddleware_Enforcement(t *testing.T) {
+	_, authStore := setupTenantTestServer(t)
+	ctx := context.Background()
+	suspended, err := authStore.GetTenant(ctx, "tenant-b")
+	require.NoError(t, err)
+	suspended.Status = auth.TenantStatusSuspended
+	require.NoError(t, authStore.UpdateTenant(ctx, suspended))
+
+	tests := []struct {
+		name         string
+		user         *auth.AuthenticatedUser
+		header       string
+		expectedCode int
+		expectedID   string
+	}{
+		{name: "anonymous default tenant", expectedCode: http.StatusOK, expectedID: "default"},
+		{name: "explicit active tenant", header: "tenant-a", expectedCode: http.StatusOK, expectedID: "tenant-a"},
+		{name: "unknown tenant", header: "missing", expectedCode: http.StatusNotFound},
+		{name: "suspended tenant", header: "tenant-b", expectedCode: http.StatusForbidden},
+		{
+			name:         "user pinned to own tenant",
+			user:         &auth.AuthenticatedUser{UserID: "u1", TenantID: "tenant-a"},
+			expectedCode: http.StatusOK,
+			expectedID:   "tenant-a",
+		},
+		{
+			name:         "user requesting another tenant",
+			user:         &auth.AuthenticatedUser{UserID: "u1", TenantID: "tenant-a"},
+			header:       "tenant-c",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "platform admin selects any tenant",
+			user:         &auth.AuthenticatedUser{UserID: "admin", IsPlatformAdmin: true},
+			header:       "tenant-a",
+			expectedCode: http.StatusOK,
+			expectedID:   "tenant-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedID string
+			var capturedTenant *auth.Tenant
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Request = c.Request.WithContext(requestcontext.WithAPIVersion(c.Request.Context(), "v3"))
+				if tt.user != nil {
+					c.Request = c.Request.WithContext(auth.ContextWithUser(c.Request.Context(), tt.user))
+				}
+				c.Next()
+			})
+			router.Use(TenantMiddleware(authStore))
+			router.GET("/test", func(c *gin.Context) {
+				capturedID = requestcontext.Tenant(c.Request.Context())
+				capturedTenant = auth.TenantFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedID, capturedID)
+			if tt.expectedCode == http.StatusOK && tt.expectedID != "default" {
+				require.NotNil(t, capturedTenant)
+				assert.Equal(t, tt.expectedID, capturedTenant.ID)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/piwi3910/netweave/internal/config"
 	"github.com/piwi3910/netweave/internal/handlers"
 	"github.com/piwi3910/netweave/internal/observability"
+	"github.com/piwi3910/netweave/internal/rollout"
 	"github.com/piwi3910/netweave/internal/storage"
 	"go.uber.org/zap"
 )
@@ -50,7 +51,10 @@ func NewTestServerWithMetrics(cfg *config.Config, logger *zap.Logger, adp adapte
 	router := gin.New()
 
 	// Initialize batch handler (needed for resource CRUD operations)
-	batchHandler := handlers.NewBatchHandler(adp, store, logger, globalMetrics)
+	var batchHandler *handlers.BatchHandler
+	if adp != nil {
+		batchHandler = handlers.NewBatchHandler(adp, store, logger, globalMetrics)
+	}
 
 	// Create minimal server for testing
 	srv := &Server{
@@ -59,10 +63,32 @@ func NewTestServerWithMetrics(cfg *config.Config, logger *zap.Logger, adp adapte
 		router:       router,
 		adapter:      adp,
 		store:        store,
-		metrics:      nil, // Server's own metrics - not needed for these tests
 		batchHandler: batchHandler,
+		runtimeSettings: rollout.NewController(
+			runtimeSettingsRollout, RuntimeSettingsFromConfig(cfg), logger),
+	}
+
+	// Route versionless O2-IMS requests by the Accept header, as setupMiddleware does
+	router.Use(srv.versionNegotiationMiddleware())
+
+	// Mark responses served from the read fallback cache, as setupMiddleware does
+	if cfg.Redis.ReadFallback.Enabled {
+		router.Use(srv.staleReadMiddleware())
 	}
 
+	// Capture bodies for debug taps, as setupMiddleware does
+	if cfg.Observability.DebugTap.Enabled {
+		srv.debugTaps = newDebugTaps(cfg)
+		router.Use(srv.debugTapMiddleware())
+	}
+
+	// Track long-running requests so shutdown drains them, as setupMiddleware does
+	srv.streamDrainer = NewStreamDrainer(cfg.Server.StreamReconnectDelay, logger)
+	router.Use(srv.streamDrainer.Middleware())
+
+	// Apply the runtime settings being rolled out, as setupMiddleware does
+	router.Use(srv.runtimeSettingsMiddleware())
+
 	// Setup routes (needed for resource CRUD tests)
 	srv.setupRoutes()
 
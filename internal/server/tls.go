@@ -0,0 +1,58 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/piwi3910/netweave/internal/certreload"
+)
+
+// newCertReloader builds the reloader serving the configured certificate,
+// key and client CA files, and the TLS configuration of the listener that
+// uses it.
+func (s *Server) newCertReloader() (*certreload.Reloader, *tls.Config, error) {
+	tlsCfg := &s.config.TLS
+	reloader, err := certreload.New(certreload.Config{
+		CertFile: tlsCfg.CertFile,
+		KeyFile:  tlsCfg.KeyFile,
+		CAFile:   tlsCfg.CAFile,
+		Interval: tlsCfg.ReloadInterval,
+		Logger:   s.logger,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	base := &tls.Config{
+		MinVersion: tlsCfg.MinTLSVersion(),
+		ClientAuth: tlsCfg.ClientAuthType(),
+	}
+	if base.CipherSuites, err = cipherSuiteIDs(tlsCfg.CipherSuites); err != nil {
+		return nil, nil, err
+	}
+	if tlsCfg.SPIFFE.Enabled {
+		base.VerifyPeerCertificate = certreload.SPIFFEVerifier(tlsCfg.SPIFFE.TrustDomain, tlsCfg.SPIFFE.AllowedIDs)
+	}
+	return reloader, reloader.TLSConfig(base), nil
+}
+
+// cipherSuiteIDs maps cipher suite names to their IDs. No names selects the
+// crypto/tls defaults; TLS 1.3 suites are not configurable.
+func cipherSuiteIDs(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -41,9 +41,11 @@ import (
 func (s *Server) setupTMForumRoutesEarly() {
 	s.logger.Info("Registering TMForum API route structure")
 
-	// TMF639 - Resource Inventory Management API v4
-	tmf639 := s.router.Group("/tmf-api/resourceInventoryManagement/v4")
-	{
+	// TMF639 - Resource Inventory Management API v4 (requires the IMS adapter)
+	if s.adapter != nil {
+		tmf639 := s.router.Group("/tmf-api/resourceInventoryManagement/v4")
+		tmf639.Use(s.InventoryChangeMiddleware())
+
 		// Resource CRUD operations
 		tmf639.GET("/resource", s.tmfHandlerOrUnavailable(func(h *handlers.TMForumHandler) gin.HandlerFunc {
 			return h.ListTMF639Resources
@@ -118,13 +120,15 @@ func (s *Server) setupTMForumRoutesEarly() {
 			return h.CreateTMF688Event
 		}))
 
-		// Hub (subscription) operations
-		tmf688.POST("/hub", s.tmfHandlerOrUnavailable(func(h *handlers.TMForumHandler) gin.HandlerFunc {
-			return h.RegisterTMF688Hub
-		}))
-		tmf688.DELETE("/hub/:id", s.tmfHandlerOrUnavailable(func(h *handlers.TMForumHandler) gin.HandlerFunc {
-			return h.UnregisterTMF688Hub
-		}))
+		// Hub (subscription) operations, backed by IMS adapter subscriptions
+		if s.adapter != nil {
+			tmf688.POST("/hub", s.tmfHandlerOrUnavailable(func(h *handlers.TMForumHandler) gin.HandlerFunc {
+				return h.RegisterTMF688Hub
+			}))
+			tmf688.DELETE("/hub/:id", s.tmfHandlerOrUnavailable(func(h *handlers.TMForumHandler) gin.HandlerFunc {
+				return h.UnregisterTMF688Hub
+			}))
+		}
 	}
 
 	// TMF642 - Alarm Management API v4
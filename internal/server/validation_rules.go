@@ -0,0 +1,99 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/validation"
+)
+
+// ValidationRulesFromConfig builds a validation engine from the configured
+// rules. Plugin rules must have been registered with validation.RegisterPlugin
+// beforehand.
+func ValidationRulesFromConfig(cfgs []config.ValidationRuleConfig, logger *zap.Logger) (*validation.Engine, error) {
+	engine := validation.NewEngine(logger)
+	for _, cfg := range cfgs {
+		var validator validation.Validator
+		switch cfg.Type {
+		case config.ValidationRuleTypeField:
+			rule, err := validation.NewFieldRule(validation.FieldRule{
+				Field:     cfg.Field,
+				Required:  cfg.Required,
+				Pattern:   cfg.Pattern,
+				Values:    cfg.Values,
+				MaxLength: cfg.MaxLength,
+				Message:   cfg.Message,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("validation rule %q: %w", cfg.Name, err)
+			}
+			validator = rule
+		case config.ValidationRuleTypePlugin:
+			plugin, ok := validation.LookupPlugin(cfg.Plugin)
+			if !ok {
+				return nil, fmt.Errorf("validation rule %q: plugin %q is not registered", cfg.Name, cfg.Plugin)
+			}
+			validator = plugin
+		default:
+			return nil, fmt.Errorf("validation rule %q: unsupported type %q", cfg.Name, cfg.Type)
+		}
+
+		reg := validation.Registration{
+			Name:          cfg.Name,
+			Validator:     validator,
+			Timeout:       cfg.Timeout,
+			FailurePolicy: validation.FailurePolicy(cfg.FailurePolicy),
+		}
+		for _, kind := range cfg.Kinds {
+			reg.Kinds = append(reg.Kinds, validation.Kind(kind))
+		}
+		for _, op := range cfg.Operations {
+			reg.Operations = append(reg.Operations, validation.Operation(op))
+		}
+		if err := engine.Register(reg); err != nil {
+			return nil, err
+		}
+	}
+	return engine, nil
+}
+
+// SetValidationRules sets the validation rules checked when resource pools,
+// resources and NF deployments are created or updated.
+func (s *Server) SetValidationRules(engine *validation.Engine) {
+	s.validationRules = engine
+}
+
+// checkValidationRules checks the request body against the validation rules.
+// When the body violates a rule it writes a 400 response listing the
+// violations and returns false; the caller must stop processing.
+func (s *Server) checkValidationRules(
+	c *gin.Context, kind validation.Kind, op validation.Operation, id string, body interface{},
+) bool {
+	if s.validationRules.Len() == 0 {
+		return true
+	}
+
+	obj, err := validation.NewObject(kind, op, id, body)
+	if err != nil {
+		s.requestLogger(c).Error("failed to prepare validation", zap.Error(err))
+		problem.Respond(c, http.StatusInternalServerError, "InternalError", "Failed to validate request")
+		return false
+	}
+
+	violations, err := s.validationRules.Validate(c.Request.Context(), obj)
+	if err != nil {
+		problem.Respond(c, http.StatusFailedDependency, "FailedDependency", err.Error())
+		return false
+	}
+	if len(violations) > 0 {
+		problem.Write(c, problem.New(http.StatusBadRequest, "BadRequest", violations[0].Message).
+			With("violations", violations))
+		return false
+	}
+	return true
+}
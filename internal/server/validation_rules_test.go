@@ -0,0 +1,90 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/adapter"
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/validation"
+)
+
+func TestValidationRules_Resources(t *testing.T) {
+	const (
+		resourceID = "660e8400-e29b-41d4-a716-446655440001"
+		path       = "/o2ims-infrastructureInventory/v1/resources"
+	)
+
+	rules, err := server.ValidationRulesFromConfig([]config.ValidationRuleConfig{
+		{
+			Name: "site-code", Type: config.ValidationRuleTypeField, Field: "extensions.siteCode",
+			Required: true, Pattern: `[A-Z]{3}[0-9]`, Kinds: []string{"resource"},
+		},
+		{
+			Name: "pool-only", Type: config.ValidationRuleTypeField, Field: "name",
+			Required: true, Kinds: []string{"resourcePool"},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	adp := newMockResourceAdapter()
+	srv := setupResourceTestServer(t, adp)
+	srv.SetValidationRules(rules)
+
+	resource := adapter.Resource{
+		ResourceID:     resourceID,
+		ResourceTypeID: "compute-node",
+		ResourcePoolID: "pool-1",
+		Extensions:     map[string]interface{}{"siteCode": "dc1"},
+	}
+	resp, body := doResourceRequest(t, srv, http.MethodPost, path, resource)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	var problem struct {
+		Detail               string `json:"detail"`
+		AdditionalAttributes struct {
+			Violations []validation.Violation `json:"violations"`
+		} `json:"additionalAttributes"`
+	}
+	require.NoError(t, json.Unmarshal(body, &problem))
+	assert.Equal(t, `extensions.siteCode must match "[A-Z]{3}[0-9]"`, problem.Detail)
+	assert.Equal(t, []validation.Violation{{
+		Rule: "site-code", Field: "extensions.siteCode", Message: `extensions.siteCode must match "[A-Z]{3}[0-9]"`,
+	}}, problem.AdditionalAttributes.Violations)
+
+	resource.Extensions["siteCode"] = "DCA1"
+	resp, _ = doResourceRequest(t, srv, http.MethodPost, path, resource)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	// Updates are checked as well.
+	resp, _ = doResourceRequest(t, srv, http.MethodPut, path+"/"+resourceID, adapter.Resource{Description: "rack 4"})
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "extensions.siteCode is required")
+}
+
+func TestValidationRulesFromConfig(t *testing.T) {
+	validation.RegisterPlugin("server-test-validator", validation.ValidatorFunc(
+		func(context.Context, *validation.Object) ([]validation.Violation, error) {
+			return nil, nil
+		}))
+
+	rules, err := server.ValidationRulesFromConfig([]config.ValidationRuleConfig{
+		{Name: "name", Type: config.ValidationRuleTypeField, Field: "name", MaxLength: 63},
+		{Name: "local", Type: config.ValidationRuleTypePlugin, Plugin: "server-test-validator"},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 2, rules.Len())
+
+	_, err = server.ValidationRulesFromConfig([]config.ValidationRuleConfig{
+		{Name: "missing", Type: config.ValidationRuleTypePlugin, Plugin: "not-registered"},
+	}, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
+)
+
+// Version adoption defaults.
+const (
+	// DefaultVersionAdoptionRetentionHours is how many hourly buckets are kept.
+	DefaultVersionAdoptionRetentionHours = 24
+
+	// DefaultDeprecatedVersionWarnThreshold is the hourly call count on a deprecated
+	// version above which a warning is logged.
+	DefaultDeprecatedVersionWarnThreshold = 1000
+)
+
+// versionRouteKey identifies a (version, route) pair within an hourly bucket.
+type versionRouteKey struct {
+	version string
+	route   string
+}
+
+// VersionAdoptionTracker records API calls per version and route in hourly
+// buckets so operators can see which clients still rely on older API versions.
+type VersionAdoptionTracker struct {
+	mu                      sync.Mutex
+	buckets                 map[int64]map[versionRouteKey]int64
+	statuses                map[string]string
+	warned                  map[string]int64
+	retentionHours          int
+	deprecatedWarnThreshold int64
+	logger                  *zap.Logger
+	now                     func() time.Time
+}
+
+// VersionAdoptionReport summarizes API calls per version and route over a time window.
+type VersionAdoptionReport struct {
+	WindowHours int            `json:"windowHours"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	TotalCalls  int64          `json:"totalCalls"`
+	Versions    []VersionUsage `json:"versions"`
+}
+
+// VersionUsage summarizes calls for a single API version.
+type VersionUsage struct {
+	Version    string       `json:"version"`
+	Status     string       `json:"status,omitempty"`
+	Calls      int64        `json:"calls"`
+	Percentage float64      `json:"percentage"`
+	Routes     []RouteUsage `json:"routes"`
+}
+
+// RouteUsage summarizes calls for a single route within an API version.
+type RouteUsage struct {
+	Route string `json:"route"`
+	Calls int64  `json:"calls"`
+}
+
+// NewVersionAdoptionTracker creates a tracker retaining retentionHours of hourly buckets.
+// A warning is logged once per hour per version when calls to a deprecated version
+// exceed deprecatedWarnThreshold. Non-positive values fall back to defaults.
+func NewVersionAdoptionTracker(retentionHours, deprecatedWarnThreshold int, logger *zap.Logger) *VersionAdoptionTracker {
+	if retentionHours <= 0 {
+		retentionHours = DefaultVersionAdoptionRetentionHours
+	}
+	if deprecatedWarnThreshold <= 0 {
+		deprecatedWarnThreshold = DefaultDeprecatedVersionWarnThreshold
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &VersionAdoptionTracker{
+		buckets:                 make(map[int64]map[versionRouteKey]int64),
+		statuses:                make(map[string]string),
+		warned:                  make(map[string]int64),
+		retentionHours:          retentionHours,
+		deprecatedWarnThreshold: int64(deprecatedWarnThreshold),
+		logger:                  logger,
+		now:                     time.Now,
+	}
+}
+
+// Record counts a call to route on the given API version.
+func (t *VersionAdoptionTracker) Record(version, status, route string) {
+	if version == "" {
+		return
+	}
+	if route == "" {
+		route = "unmatched"
+	}
+
+	hour := t.now().Unix() / 3600
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket, ok := t.buckets[hour]
+	if !ok {
+		bucket = make(map[versionRouteKey]int64)
+		t.buckets[hour] = bucket
+		t.pruneLocked(hour)
+	}
+	bucket[versionRouteKey{version: version, route: route}]++
+
+	if status != "" {
+		t.statuses[version] = status
+	}
+
+	if status == VersionStatusDeprecated {
+		t.checkDeprecatedLocked(version, hour, bucket)
+	}
+}
+
+// checkDeprecatedLocked logs a warning the first time a deprecated version
+// exceeds the threshold within an hour. The caller must hold t.mu.
+func (t *VersionAdoptionTracker) checkDeprecatedLocked(version string, hour int64, bucket map[versionRouteKey]int64) {
+	if t.warned[version] == hour {
+		return
+	}
+
+	var calls int64
+	for key, count := range bucket {
+		if key.version == version {
+			calls += count
+		}
+	}
+
+	if calls > t.deprecatedWarnThreshold {
+		t.warned[version] = hour
+		t.logger.Warn("deprecated API version usage exceeds threshold",
+			zap.String("api_version", version),
+			zap.Int64("calls_this_hour", calls),
+			zap.Int64("threshold", t.deprecatedWarnThreshold),
+		)
+	}
+}
+
+// pruneLocked removes buckets older than the retention window. The caller must hold t.mu.
+func (t *VersionAdoptionTracker) pruneLocked(currentHour int64) {
+	oldest := currentHour - int64(t.retentionHours) + 1
+	for hour := range t.buckets {
+		if hour < oldest {
+			delete(t.buckets, hour)
+		}
+	}
+}
+
+// Report summarizes calls over the last hours hours, capped at the retention window.
+func (t *VersionAdoptionTracker) Report(hours int) *VersionAdoptionReport {
+	if hours <= 0 || hours > t.retentionHours {
+		hours = t.retentionHours
+	}
+
+	now := t.now()
+	oldest := now.Unix()/3600 - int64(hours) + 1
+
+	t.mu.Lock()
+	perVersion := make(map[string]map[string]int64)
+	for hour, bucket := range t.buckets {
+		if hour < oldest {
+			continue
+		}
+		for key, count := range bucket {
+			routes, ok := perVersion[key.version]
+			if !ok {
+				routes = make(map[string]int64)
+				perVersion[key.version] = routes
+			}
+			routes[key.route] += count
+		}
+	}
+	statuses := make(map[string]string, len(t.statuses))
+	for v, s := range t.statuses {
+		statuses[v] = s
+	}
+	t.mu.Unlock()
+
+	report := &VersionAdoptionReport{
+		WindowHours: hours,
+		GeneratedAt: now.UTC(),
+		Versions:    make([]VersionUsage, 0, len(perVersion)),
+	}
+
+	for version, routes := range perVersion {
+		usage := VersionUsage{
+			Version: version,
+			Status:  statuses[version],
+			Routes:  make([]RouteUsage, 0, len(routes)),
+		}
+		for route, count := range routes {
+			usage.Calls += count
+			usage.Routes = append(usage.Routes, RouteUsage{Route: route, Calls: count})
+		}
+		sort.Slice(usage.Routes, func(i, j int) bool {
+			if usage.Routes[i].Calls != usage.Routes[j].Calls {
+				return usage.Routes[i].Calls > usage.Routes[j].Calls
+			}
+			return usage.Routes[i].Route < usage.Routes[j].Route
+		})
+		report.TotalCalls += usage.Calls
+		report.Versions = append(report.Versions, usage)
+	}
+
+	for i := range report.Versions {
+		if report.TotalCalls > 0 {
+			report.Versions[i].Percentage = float64(report.Versions[i].Calls) * 100 / float64(report.TotalCalls)
+		}
+	}
+	sort.Slice(report.Versions, func(i, j int) bool {
+		return ExtractVersionNumber(report.Versions[i].Version) < ExtractVersionNumber(report.Versions[j].Version)
+	})
+
+	return report
+}
+
+// VersionAdoptionMiddleware records each request against the API version resolved
+// by VersioningMiddleware. It must be registered after VersioningMiddleware.
+func VersionAdoptionMiddleware(tracker *VersionAdoptionTracker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if tracker == nil {
+			return
+		}
+
+		ctx := c.Request.Context()
+		status := ""
+		if info := apiVersionInfoKey.Value(ctx); info != nil {
+			status = info.Status
+		}
+
+		tracker.Record(requestcontext.APIVersion(ctx), status, c.FullPath())
+	}
+}
+
+// handleVersionAdoptionReport returns API version adoption over the last N hours.
+// GET /admin/versions/adoption?hours=N.
+func (s *Server) handleVersionAdoptionReport(c *gin.Context) {
+	hours := 0
+	if raw := c.Query("hours"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "hours must be a positive integer")
+			return
+		}
+		hours = parsed
+	}
+
+	c.JSON(http.StatusOK, s.versionAdoption.Report(hours))
+}
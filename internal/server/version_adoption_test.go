@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestVersionAdoptionTracker_Report(t *testing.T) {
+	tracker := server.NewVersionAdoptionTracker(0, 0, zap.NewNop())
+
+	tracker.Record("v1", server.VersionStatusStable, "/o2ims-infrastructureInventory/v1/resources")
+	tracker.Record("v1", server.VersionStatusStable, "/o2ims-infrastructureInventory/v1/resources")
+	tracker.Record("v1", server.VersionStatusStable, "/o2ims-infrastructureInventory/v1/resourcePools")
+	tracker.Record("v3", server.VersionStatusDeprecated, "")
+	tracker.Record("", server.VersionStatusStable, "/ignored")
+
+	report := tracker.Report(1)
+	require.NotNil(t, report)
+	assert.Equal(t, 1, report.WindowHours)
+	assert.Equal(t, int64(4), report.TotalCalls)
+	require.Len(t, report.Versions, 2)
+
+	v1 := report.Versions[0]
+	assert.Equal(t, "v1", v1.Version)
+	assert.Equal(t, server.VersionStatusStable, v1.Status)
+	assert.Equal(t, int64(3), v1.Calls)
+	assert.InDelta(t, 75.0, v1.Percentage, 0.001)
+	require.Len(t, v1.Routes, 2)
+	assert.Equal(t, "/o2ims-infrastructureInventory/v1/resources", v1.Routes[0].Route)
+	assert.Equal(t, int64(2), v1.Routes[0].Calls)
+
+	v3 := report.Versions[1]
+	assert.Equal(t, "v3", v3.Version)
+	assert.Equal(t, server.VersionStatusDeprecated, v3.Status)
+	assert.Equal(t, "unmatched", v3.Routes[0].Route)
+
+	// Windows beyond retention are capped.
+	assert.Equal(t, server.DefaultVersionAdoptionRetentionHours, tracker.Report(1000).WindowHours)
+}
+
+func TestVersionAdoptionMiddleware(t *testing.T) {
+	tracker := server.NewVersionAdoptionTracker(24, 10, zap.NewNop())
+
+	router := gin.New()
+	group := router.Group("/api/v1")
+	group.Use(server.VersioningMiddleware(server.NewVersionConfig()))
+	group.Use(server.VersionAdoptionMiddleware(tracker))
+	group.GET("/items/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for range 3 {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/items/abc", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	report := tracker.Report(24)
+	require.Len(t, report.Versions, 1)
+	assert.Equal(t, "v1", report.Versions[0].Version)
+	assert.Equal(t, int64(3), report.Versions[0].Calls)
+	assert.Equal(t, "/api/v1/items/:id", report.Versions[0].Routes[0].Route)
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"windowHours":24`)
+}
@@ -0,0 +1,98 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/server"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	supported := []string{"v1", "v3"}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+		wantOK bool
+	}{
+		{name: "no Accept header", accept: "", want: "v1", wantOK: true},
+		{name: "no version parameter", accept: "application/json, */*;q=0.8", want: "v1", wantOK: true},
+		{name: "numeric version", accept: "application/json;version=3", want: "v3", wantOK: true},
+		{name: "prefixed version", accept: "application/json; version=v3", want: "v3", wantOK: true},
+		{
+			name:   "highest quality wins",
+			accept: "application/json;version=1;q=0.5, application/json;version=3;q=0.9",
+			want:   "v3", wantOK: true,
+		},
+		{
+			name:   "earlier range wins on ties",
+			accept: "application/json;version=3, application/json;version=1",
+			want:   "v3", wantOK: true,
+		},
+		{
+			name:   "unsupported versions are skipped",
+			accept: "application/json;version=7, application/json;version=1;q=0.1",
+			want:   "v1", wantOK: true,
+		},
+		{name: "zero quality is not acceptable", accept: "application/json;version=3;q=0", wantOK: false},
+		{name: "only unsupported versions", accept: "application/json;version=2", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := server.NegotiateAPIVersion(tt.accept, supported, "v1")
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersionNegotiation(t *testing.T) {
+	srv := setupResourceTestServer(t, newMockResourceAdapter())
+
+	serve := func(method, path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		srv.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("defaults to v1", func(t *testing.T) {
+		w := serve(http.MethodGet, "/o2ims-infrastructureInventory/resources", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "v1", w.Header().Get("X-API-Version"))
+		assert.Equal(t, "/o2ims-infrastructureInventory/v1/resources", w.Header().Get("Content-Location"))
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	})
+
+	t.Run("serves the requested version", func(t *testing.T) {
+		w := serve(http.MethodGet, "/o2ims-infrastructureInventory/resources", "application/json;version=1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "v1", w.Header().Get("X-API-Version"))
+
+		w = serve(http.MethodGet, "/o2ims-infrastructureInventory/reconcile/rec-1/tasks",
+			"application/json;version=1;q=0.5, application/json;version=3")
+		assert.Equal(t, "v3", w.Header().Get("X-API-Version"))
+		assert.Equal(t, "/o2ims-infrastructureInventory/v3/reconcile/rec-1/tasks", w.Header().Get("Content-Location"))
+	})
+
+	t.Run("rejects unsupported versions", func(t *testing.T) {
+		w := serve(http.MethodGet, "/o2ims-infrastructureInventory/resources", "application/json;version=7")
+		assert.Equal(t, http.StatusNotAcceptable, w.Code)
+		assert.Contains(t, w.Body.String(), "supported versions: v1, v3")
+	})
+
+	t.Run("leaves versioned paths alone", func(t *testing.T) {
+		w := serve(http.MethodGet, "/o2ims-infrastructureInventory/v1/resources", "application/json;version=3")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "v1", w.Header().Get("X-API-Version"))
+		assert.Empty(t, w.Header().Get("Content-Location"))
+	})
+}
@@ -2,13 +2,25 @@
 package server
 
 import (
+	"errors"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 )
 
+// apiVersionInfoKey is the context key under which VersioningMiddleware
+// stores the configuration of the requested API version.
+var apiVersionInfoKey = requestcontext.NewKey[*APIVersion]("api_version_info")
+
 // APIVersion represents an API version configuration.
 type APIVersion struct {
 	// Version is the version string (e.g., "v1", "v2", "v3").
@@ -72,11 +84,8 @@ func VersioningMiddleware(config *VersionConfig) gin.HandlerFunc {
 
 		versionInfo, exists := config.Versions[version]
 		if !exists {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "NotFound",
-				"message": "API version not found: " + version,
-				"code":    http.StatusNotFound,
-			})
+			problem.Respond(c, http.StatusNotFound, "NotFound", "API version not found: "+version)
+
 			c.Abort()
 			return
 		}
@@ -98,23 +107,114 @@ func VersioningMiddleware(config *VersionConfig) gin.HandlerFunc {
 
 		// Handle sunset versions (completely removed)
 		if versionInfo.Status == VersionStatusSunset {
-			c.JSON(http.StatusGone, gin.H{
-				"error":   "Gone",
-				"message": "API version " + version + " has been removed. Please upgrade to a newer version.",
-				"code":    http.StatusGone,
-			})
+			problem.Respond(c, http.StatusGone, "Gone",
+				"API version "+version+" has been removed. Please upgrade to a newer version.")
+
 			c.Abort()
 			return
 		}
 
 		// Store version in context for handlers to use
-		c.Set("api_version", version)
-		c.Set("api_version_info", versionInfo)
+		ctx := requestcontext.WithAPIVersion(c.Request.Context(), version)
+		c.Request = c.Request.WithContext(apiVersionInfoKey.With(ctx, versionInfo))
 
 		c.Next()
 	}
 }
 
+// imsBasePath is the versionless base path of the O2-IMS API.
+const imsBasePath = "/o2ims-infrastructureInventory"
+
+// imsAPIVersions are the O2-IMS API versions served below imsBasePath.
+var imsAPIVersions = []string{"v1", "v3"}
+
+// versionNegotiationMiddleware serves requests to the versionless O2-IMS base
+// path, such as /o2ims-infrastructureInventory/resourcePools, with the API
+// version negotiated from the Accept header: the path is rewritten to the
+// versioned one and the request is routed again. Requests without a version
+// in the Accept header get the default version, and requests for versions
+// that are not served are rejected with 406 Not Acceptable. The middleware
+// must run before the other middleware, which then runs once, for the
+// versioned route.
+func (s *Server) versionNegotiationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rest, ok := strings.CutPrefix(c.Request.URL.Path, imsBasePath+"/")
+		if !ok {
+			c.Next()
+			return
+		}
+		segment, _, _ := strings.Cut(rest, "/")
+		if ExtractVersionFromPath("/"+segment) != "" {
+			c.Next()
+			return
+		}
+
+		supported := make([]string, 0, len(imsAPIVersions))
+		for _, version := range imsAPIVersions {
+			if _, ok := s.versionConfig.Versions[version]; ok {
+				supported = append(supported, version)
+			}
+		}
+
+		c.Header("Vary", "Accept")
+		version, ok := NegotiateAPIVersion(c.GetHeader("Accept"), supported, s.versionConfig.DefaultVersion)
+		if !ok {
+			problem.Abort(c, http.StatusNotAcceptable, "NotAcceptable",
+				"None of the requested API versions is supported; supported versions: "+strings.Join(supported, ", "))
+			return
+		}
+
+		path := imsBasePath + "/" + version + "/" + rest
+		c.Header("Content-Location", path)
+		c.Request.URL.Path = path
+		c.Request.URL.RawPath = ""
+		s.router.HandleContext(c)
+		c.Abort()
+	}
+}
+
+// NegotiateAPIVersion selects an API version from the version parameters of
+// the media ranges of an Accept header, e.g. "application/json;version=3" or
+// "application/json;version=v3". The supported version of the range with the
+// highest quality wins, the earlier range on ties. It returns defaultVersion
+// when no range carries a version, and false when none of the requested
+// versions is supported.
+func NegotiateAPIVersion(accept string, supported []string, defaultVersion string) (string, bool) {
+	selected, requested := "", false
+	bestQuality := 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		value, ok := params["version"]
+		if !ok {
+			continue
+		}
+		requested = true
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		version := "v" + strings.TrimPrefix(value, "v")
+		if quality > bestQuality && slices.Contains(supported, version) {
+			selected, bestQuality = version, quality
+		}
+	}
+
+	switch {
+	case selected != "":
+		return selected, true
+	case requested:
+		return "", false
+	default:
+		return defaultVersion, true
+	}
+}
+
 // ExtractVersionFromPath extracts the API version from the URL path.
 func ExtractVersionFromPath(path string) string {
 	parts := strings.Split(path, "/")
@@ -194,17 +294,15 @@ func GetV3Features() V3Features {
 // RequireVersion creates middleware that requires a minimum API version.
 func RequireVersion(minVersion string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		currentVersion := c.GetString("api_version")
+		currentVersion := requestcontext.APIVersion(c.Request.Context())
 		if currentVersion == "" {
 			currentVersion = "v1"
 		}
 
 		if !IsVersionAtLeast(currentVersion, minVersion) {
-			c.JSON(http.StatusNotImplemented, gin.H{
-				"error":   "NotImplemented",
-				"message": "This feature requires API version " + minVersion + " or higher",
-				"code":    http.StatusNotImplemented,
-			})
+			problem.Respond(c, http.StatusNotImplemented, "NotImplemented",
+				"This feature requires API version "+minVersion+" or higher")
+
 			c.Abort()
 			return
 		}
@@ -233,10 +331,15 @@ func ExtractVersionNumber(version string) int {
 }
 
 // TenantMiddleware extracts tenant information for v3 multi-tenancy support.
-func TenantMiddleware() gin.HandlerFunc {
+// The tenant comes from the X-Tenant-ID header or the tenantId query
+// parameter; authenticated users other than platform admins are pinned to
+// their own tenant. When tenants is not nil, the tenant must exist and be
+// active and is added to the request context. Without any tenant, requests
+// fall back to the "default" tenant for backward compatibility.
+func TenantMiddleware(tenants TenantGetter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Check if we're using v3 API
-		version := c.GetString("api_version")
+		version := requestcontext.APIVersion(c.Request.Context())
 		if version != "v3" {
 			c.Next()
 			return
@@ -248,13 +351,35 @@ func TenantMiddleware() gin.HandlerFunc {
 			tenantID = c.Query("tenantId")
 		}
 
+		// Tenant users may only act within their own tenant
+		ctx := c.Request.Context()
+		if user := auth.UserFromContext(ctx); user != nil && !user.IsPlatformAdmin && user.TenantID != "" {
+			if tenantID != "" && tenantID != user.TenantID {
+				problem.Abort(c, http.StatusForbidden, "Forbidden", "Access to tenant "+tenantID+" is not allowed")
+				return
+			}
+			tenantID = user.TenantID
+		}
+
 		if tenantID == "" {
 			// Default tenant for backward compatibility
 			tenantID = "default"
+		} else if tenants != nil {
+			tenant, err := tenants.GetTenant(ctx, tenantID)
+			if err != nil {
+				abortTenantLookup(c, tenantID, err)
+				return
+			}
+			if !tenant.IsActive() {
+				problem.Abort(c, http.StatusForbidden, "TenantSuspended",
+					"Tenant "+tenantID+" is "+string(tenant.Status))
+				return
+			}
+			ctx = auth.ContextWithTenant(ctx, tenant)
 		}
 
 		// Store tenant in context
-		c.Set("tenant_id", tenantID)
+		c.Request = c.Request.WithContext(requestcontext.WithTenant(ctx, tenantID))
 
 		// Add tenant to response headers
 		c.Header("X-Tenant-ID", tenantID)
@@ -262,3 +387,12 @@ func TenantMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// abortTenantLookup aborts a request whose tenant could not be loaded.
+func abortTenantLookup(c *gin.Context, tenantID string, err error) {
+	if errors.Is(err, auth.ErrTenantNotFound) {
+		problem.Abort(c, http.StatusNotFound, "NotFound", "Tenant not found: "+tenantID)
+		return
+	}
+	problem.Abort(c, http.StatusInternalServerError, "InternalError", "Failed to load tenant")
+}
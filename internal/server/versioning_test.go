@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/piwi3910/netweave/internal/requestcontext"
 	"github.com/piwi3910/netweave/internal/server"
 )
 
@@ -325,7 +326,7 @@ func TestRequireVersion(t *testing.T) {
 
 	// Simulate version being set in context
 	router.Use(func(c *gin.Context) {
-		c.Set("api_version", "v1")
+		c.Request = c.Request.WithContext(requestcontext.WithAPIVersion(c.Request.Context(), "v1"))
 		c.Next()
 	})
 	router.Use(server.RequireVersion("v2"))
@@ -348,7 +349,7 @@ func TestRequireVersion_Satisfied(t *testing.T) {
 
 	// Simulate version being set in context
 	router.Use(func(c *gin.Context) {
-		c.Set("api_version", "v3")
+		c.Request = c.Request.WithContext(requestcontext.WithAPIVersion(c.Request.Context(), "v3"))
 		c.Next()
 	})
 	router.Use(server.RequireVersion("v2"))
@@ -412,12 +413,12 @@ func TestTenantMiddleware(t *testing.T) {
 
 			router := gin.New()
 			router.Use(func(c *gin.Context) {
-				c.Set("api_version", tt.version)
+				c.Request = c.Request.WithContext(requestcontext.WithAPIVersion(c.Request.Context(), tt.version))
 				c.Next()
 			})
-			router.Use(server.TenantMiddleware())
+			router.Use(server.TenantMiddleware(nil))
 			router.GET("/test", func(c *gin.Context) {
-				capturedTenant = c.GetString("tenant_id")
+				capturedTenant = requestcontext.Tenant(c.Request.Context())
 				c.Status(http.StatusOK)
 			})
 
@@ -0,0 +1,391 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/auth"
+	"github.com/piwi3910/netweave/internal/problem"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/webhookca"
+)
+
+// pemContentType is the media type of the CA certificate and revocation list.
+const pemContentType = "application/x-pem-file"
+
+// EnrollCertificateRequest is the body of
+// POST /subscriptions/{subscriptionId}/certificates.
+type EnrollCertificateRequest struct {
+	// Usage is "server" for the certificate of the notification endpoint or
+	// "client" for a certificate authenticating the subscriber.
+	Usage string `json:"usage" binding:"required"`
+
+	// CSR is a PEM-encoded certificate signing request. When empty the
+	// gateway generates the key pair and returns the private key once.
+	CSR string `json:"csr,omitempty"`
+
+	// CommonName defaults to the CSR's, or to the subscription ID.
+	CommonName string `json:"commonName,omitempty"`
+
+	// DNSNames default to the CSR's, or for server certificates to the
+	// host of the subscription's callback.
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// ValidFor is the validity of the certificate (Go duration, default
+	// notifications.ca.cert_validity).
+	ValidFor string `json:"validFor,omitempty"`
+}
+
+// RotateCertificateRequest is the body of
+// POST /subscriptions/{subscriptionId}/certificates/{serialNumber}/rotate.
+type RotateCertificateRequest struct {
+	// CSR is a PEM-encoded certificate signing request for the new key. When
+	// empty the gateway generates a new key pair.
+	CSR string `json:"csr,omitempty"`
+
+	// ValidFor is the validity of the new certificate.
+	ValidFor string `json:"validFor,omitempty"`
+}
+
+// RevokeCertificateRequest is the body of
+// POST /subscriptions/{subscriptionId}/certificates/{serialNumber}/revoke.
+type RevokeCertificateRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// IssuedCertificateResponse is returned by an enrollment or rotation.
+// PrivateKey is only ever returned here.
+type IssuedCertificateResponse struct {
+	*storage.WebhookCertificate
+
+	// PrivateKey is the generated PEM-encoded private key; empty when a CSR
+	// was given.
+	PrivateKey string `json:"privateKey,omitempty"`
+
+	// CACertificate is the PEM-encoded CA certificate.
+	CACertificate string `json:"caCertificate"`
+}
+
+// SetWebhookCA sets the webhook CA. This enables certificate enrollment for
+// subscriptions and the /webhooks/ca.pem and /webhooks/crl.pem endpoints; the
+// webhook workers must use the same CA.
+func (s *Server) SetWebhookCA(ca *webhookca.Authority) {
+	s.webhookCA = ca
+}
+
+// requireWebhookCA answers 503 when the webhook CA is not enabled.
+func (s *Server) requireWebhookCA(c *gin.Context) bool {
+	if s.webhookCA != nil {
+		return true
+	}
+	problem.Respond(c, http.StatusServiceUnavailable, "ServiceUnavailable",
+		"webhook certificate issuance is not enabled")
+	return false
+}
+
+// tenantSubscription returns the subscription of the path, answering 404 when
+// it doesn't exist or belongs to another tenant.
+func (s *Server) tenantSubscription(c *gin.Context) (*storage.Subscription, bool) {
+	ctx := c.Request.Context()
+	subscriptionID := c.Param("subscriptionId")
+
+	sub, err := s.store.Get(ctx, subscriptionID)
+	if err != nil {
+		s.respondError(c, err, "failed to get subscription",
+			"Subscription not found: "+subscriptionID, "Failed to retrieve subscription")
+		return nil, false
+	}
+	tenantID := auth.TenantIDFromContext(ctx)
+	if tenantID != "" && !auth.IsPlatformAdminFromContext(ctx) && sub.TenantID != tenantID {
+		problem.Respond(c, http.StatusNotFound, "NotFound", "Subscription not found: "+subscriptionID)
+		return nil, false
+	}
+	return sub, true
+}
+
+// subscriptionCertificate returns the certificate of the path, answering 404
+// when it was not issued for the subscription.
+func (s *Server) subscriptionCertificate(
+	c *gin.Context, sub *storage.Subscription,
+) (*storage.WebhookCertificate, bool) {
+	serialNumber := c.Param("serialNumber")
+	cert, err := s.certificates().Get(c.Request.Context(), serialNumber)
+	if err == nil && cert.SubscriptionID != sub.ID {
+		err = storage.ErrCertificateNotFound
+	}
+	if err != nil {
+		s.respondError(c, err, "failed to get certificate",
+			"Certificate not found: "+serialNumber, "Failed to retrieve certificate")
+		return nil, false
+	}
+	return cert, true
+}
+
+// certificates returns the store of the webhook CA.
+func (s *Server) certificates() storage.WebhookCertificateStore {
+	return s.webhookCA.Store()
+}
+
+// handleEnrollCertificate issues a certificate to the owner of a
+// subscription: a server certificate for its notification endpoint, trusted
+// by the webhook workers, or a client certificate.
+// POST /o2ims-infrastructureInventory/v1/subscriptions/:subscriptionId/certificates.
+func (s *Server) handleEnrollCertificate(c *gin.Context) {
+	if !s.requireWebhookCA(c) {
+		return
+	}
+	sub, ok := s.tenantSubscription(c)
+	if !ok {
+		return
+	}
+
+	var req EnrollCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+		return
+	}
+	validFor, err := parseCertificateValidity(req.ValidFor)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	issueReq := webhookca.IssueRequest{
+		SubscriptionID:  sub.ID,
+		TenantID:        sub.TenantID,
+		Usage:           req.Usage,
+		CommonName:      req.CommonName,
+		DNSNames:        req.DNSNames,
+		AllowedDNSNames: []string{callbackHost(sub)},
+		CSRPEM:          req.CSR,
+		ValidFor:        validFor,
+	}
+	if req.CSR == "" {
+		issueReq.CommonName, issueReq.DNSNames = certificateSubject(sub, req)
+	}
+
+	issued, err := s.webhookCA.Issue(c.Request.Context(), issueReq)
+	if err != nil {
+		s.respondError(c, err, "failed to issue webhook certificate", "", "Failed to issue certificate")
+		return
+	}
+
+	s.requestLogger(c).Info("webhook certificate issued",
+		zap.String("subscription_id", sub.ID),
+		zap.String("serial_number", issued.Certificate.SerialNumber),
+		zap.String("usage", issued.Certificate.Usage),
+		zap.Time("not_after", issued.Certificate.NotAfter))
+
+	c.JSON(http.StatusCreated, issuedCertificateResponse(issued))
+}
+
+// certificateSubject returns the subject of a certificate whose key the
+// gateway generates: the requested names, defaulting to the subscription ID
+// and, for server certificates, to the callback host.
+func certificateSubject(sub *storage.Subscription, req EnrollCertificateRequest) (string, []string) {
+	commonName, dnsNames := req.CommonName, req.DNSNames
+	if commonName == "" {
+		commonName = sub.ID
+	}
+	if len(dnsNames) == 0 && req.Usage == storage.CertificateUsageServer {
+		if host := callbackHost(sub); host != "" {
+			dnsNames = []string{host}
+		}
+	}
+	return commonName, dnsNames
+}
+
+// callbackHost returns the lowercase host of the subscription's callback,
+// the only DNS name its certificates may name.
+func callbackHost(sub *storage.Subscription) string {
+	callback, err := url.Parse(sub.Callback)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(callback.Hostname())
+}
+
+// handleListCertificates lists the certificates issued for a subscription.
+// GET /o2ims-infrastructureInventory/v1/subscriptions/:subscriptionId/certificates.
+func (s *Server) handleListCertificates(c *gin.Context) {
+	if !s.requireWebhookCA(c) {
+		return
+	}
+	sub, ok := s.tenantSubscription(c)
+	if !ok {
+		return
+	}
+
+	certs, err := s.certificates().List(c.Request.Context())
+	if err != nil {
+		s.respondError(c, err, "failed to list webhook certificates", "", "Failed to list certificates")
+		return
+	}
+	result := make([]*storage.WebhookCertificate, 0)
+	for _, cert := range certs {
+		if cert.SubscriptionID == sub.ID {
+			result = append(result, cert)
+		}
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// handleRotateCertificate issues a certificate replacing one issued for a
+// subscription. The old certificate stays valid until it expires, so the
+// subscriber can revoke it once the new one is deployed.
+// POST /o2ims-infrastructureInventory/v1/subscriptions/:subscriptionId/certificates/:serialNumber/rotate.
+func (s *Server) handleRotateCertificate(c *gin.Context) {
+	if !s.requireWebhookCA(c) {
+		return
+	}
+	sub, ok := s.tenantSubscription(c)
+	if !ok {
+		return
+	}
+	cert, ok := s.subscriptionCertificate(c, sub)
+	if !ok {
+		return
+	}
+
+	var req RotateCertificateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+			return
+		}
+	}
+	validFor, err := parseCertificateValidity(req.ValidFor)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, "BadRequest", err.Error())
+		return
+	}
+
+	issued, err := s.webhookCA.Rotate(c.Request.Context(), cert, req.CSR, validFor)
+	if err != nil {
+		s.respondError(c, err, "failed to rotate webhook certificate", "", "Failed to rotate certificate")
+		return
+	}
+
+	s.requestLogger(c).Info("webhook certificate rotated",
+		zap.String("subscription_id", sub.ID),
+		zap.String("serial_number", cert.SerialNumber),
+		zap.String("replaced_by", issued.Certificate.SerialNumber))
+
+	c.JSON(http.StatusCreated, issuedCertificateResponse(issued))
+}
+
+// handleRevokeCertificate revokes a certificate issued for a subscription.
+// Webhook workers stop delivering to endpoints presenting it within seconds,
+// and it is listed in /webhooks/crl.pem.
+// POST /o2ims-infrastructureInventory/v1/subscriptions/:subscriptionId/certificates/:serialNumber/revoke.
+func (s *Server) handleRevokeCertificate(c *gin.Context) {
+	if !s.requireWebhookCA(c) {
+		return
+	}
+	sub, ok := s.tenantSubscription(c)
+	if !ok {
+		return
+	}
+	cert, ok := s.subscriptionCertificate(c, sub)
+	if !ok {
+		return
+	}
+
+	var req RevokeCertificateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, "BadRequest", "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	revoked, err := s.webhookCA.Revoke(c.Request.Context(), cert.SerialNumber, req.Reason)
+	if err != nil {
+		s.respondError(c, err, "failed to revoke webhook certificate", "", "Failed to revoke certificate")
+		return
+	}
+
+	s.requestLogger(c).Info("webhook certificate revoked",
+		zap.String("subscription_id", sub.ID),
+		zap.String("serial_number", cert.SerialNumber),
+		zap.String("reason", req.Reason))
+
+	c.JSON(http.StatusOK, revoked)
+}
+
+// handleWebhookCACertificate serves the CA certificate, which notification
+// endpoints use to verify the gateway's client certificate.
+// GET /webhooks/ca.pem.
+func (s *Server) handleWebhookCACertificate(c *gin.Context) {
+	if !s.requireWebhookCA(c) {
+		return
+	}
+	c.Data(http.StatusOK, pemContentType, []byte(s.webhookCA.CACertificatePEM()))
+}
+
+// handleWebhookCRL serves the revocation list of the webhook CA.
+// GET /webhooks/crl.pem.
+func (s *Server) handleWebhookCRL(c *gin.Context) {
+	if !s.requireWebhookCA(c) {
+		return
+	}
+	crl, err := s.webhookCA.CRL(c.Request.Context())
+	if err != nil {
+		s.respondError(c, err, "failed to create webhook revocation list", "", "Failed to create revocation list")
+		return
+	}
+	c.Data(http.StatusOK, pemContentType, []byte(crl))
+}
+
+// revokeSubscriptionCertificates revokes the certificates issued for a
+// deleted subscription.
+func (s *Server) revokeSubscriptionCertificates(ctx context.Context, subscriptionID string) error {
+	if s.webhookCA == nil {
+		return nil
+	}
+	certs, err := s.certificates().List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cert := range certs {
+		if cert.SubscriptionID != subscriptionID || cert.Revoked() {
+			continue
+		}
+		if _, err := s.webhookCA.Revoke(ctx, cert.SerialNumber, "subscription deleted"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseCertificateValidity parses the validity of a requested certificate;
+// zero selects the CA's default.
+func parseCertificateValidity(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	validFor, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid validFor: %w", err)
+	}
+	if validFor <= 0 || validFor > webhookca.MaxCertValidity {
+		return 0, fmt.Errorf("validFor must be between 0s and %s", webhookca.MaxCertValidity)
+	}
+	return validFor, nil
+}
+
+// issuedCertificateResponse converts an issued certificate to its response.
+func issuedCertificateResponse(issued *webhookca.Issued) *IssuedCertificateResponse {
+	return &IssuedCertificateResponse{
+		WebhookCertificate: issued.Certificate,
+		PrivateKey:         issued.PrivateKeyPEM,
+		CACertificate:      issued.CACertificatePEM,
+	}
+}
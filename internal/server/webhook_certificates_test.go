@@ -0,0 +1,120 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/config"
+	"github.com/piwi3910/netweave/internal/server"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/webhookca"
+)
+
+func TestHandleWebhookCertificates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{Server: config.ServerConfig{Port: 8080, GinMode: gin.TestMode}}
+	store := newMockSubscriptionStore()
+	store.subscriptions["other-sub"] = &storage.Subscription{
+		ID:       "other-sub",
+		Callback: "https://other.example.com/notify",
+	}
+	srv, _ := server.NewTestServerWithMetrics(cfg, zap.NewNop(), &mockAdapter{}, store)
+
+	const certsPath = "/o2ims-infrastructureInventory/v1/subscriptions/test-sub-123/certificates"
+
+	// Without a CA the endpoints are unavailable.
+	w := doSigningKeyRequest(t, srv, http.MethodPost, certsPath, `{"usage":"server"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	w = doSigningKeyRequest(t, srv, http.MethodGet, "/webhooks/ca.pem", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	ca, err := webhookca.New(context.Background(), webhookca.Config{}, storage.NewInMemoryWebhookCertificateStore())
+	require.NoError(t, err)
+	srv.SetWebhookCA(ca)
+
+	w = doSigningKeyRequest(t, srv, http.MethodPost, certsPath, `{"usage":"server","validFor":"24h"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var issued server.IssuedCertificateResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	assert.Equal(t, "test-sub-123", issued.SubscriptionID)
+	assert.Equal(t, "test-sub-123", issued.CommonName)
+	assert.Equal(t, []string{"smo.example.com"}, issued.DNSNames)
+	assert.Contains(t, issued.PrivateKey, "PRIVATE KEY")
+	assert.Equal(t, ca.CACertificatePEM(), issued.CACertificate)
+	certPath := certsPath + "/" + issued.SerialNumber
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, body := range []string{
+			`{}`,
+			`{"usage":"signing"}`,
+			`{"usage":"server","dnsNames":["other.example.com"]}`,
+			`{"usage":"client","validFor":"forever"}`,
+		} {
+			w := doSigningKeyRequest(t, srv, http.MethodPost, certsPath, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("unknown subscription", func(t *testing.T) {
+		w := doSigningKeyRequest(t, srv, http.MethodPost,
+			"/o2ims-infrastructureInventory/v1/subscriptions/missing/certificates", `{"usage":"client"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("certificates of other subscriptions are not found", func(t *testing.T) {
+		w := doSigningKeyRequest(t, srv, http.MethodPost,
+			"/o2ims-infrastructureInventory/v1/subscriptions/other-sub/certificates/"+issued.SerialNumber+"/revoke", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = doSigningKeyRequest(t, srv, http.MethodGet,
+			"/o2ims-infrastructureInventory/v1/subscriptions/other-sub/certificates", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, w.Body.String())
+	})
+
+	t.Run("rotate and revoke", func(t *testing.T) {
+		w := doSigningKeyRequest(t, srv, http.MethodPost, certPath+"/rotate", "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var rotated server.IssuedCertificateResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+		assert.NotEqual(t, issued.SerialNumber, rotated.SerialNumber)
+		assert.Equal(t, issued.DNSNames, rotated.DNSNames)
+
+		w = doSigningKeyRequest(t, srv, http.MethodPost, certPath+"/revoke", `{"reason":"rotated"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var revoked storage.WebhookCertificate
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &revoked))
+		assert.True(t, revoked.Revoked())
+		assert.Equal(t, rotated.SerialNumber, revoked.ReplacedBy)
+		assert.Equal(t, "rotated", revoked.RevocationReason)
+
+		w = doSigningKeyRequest(t, srv, http.MethodPost, certPath+"/rotate", "")
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = doSigningKeyRequest(t, srv, http.MethodGet, certsPath, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var certs []storage.WebhookCertificate
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &certs))
+		assert.Len(t, certs, 2)
+		assert.NotContains(t, w.Body.String(), "PRIVATE KEY")
+	})
+
+	t.Run("CA certificate and revocation list", func(t *testing.T) {
+		w := doSigningKeyRequest(t, srv, http.MethodGet, "/webhooks/ca.pem", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-pem-file", w.Header().Get("Content-Type"))
+		assert.Equal(t, ca.CACertificatePEM(), w.Body.String())
+
+		w = doSigningKeyRequest(t, srv, http.MethodGet, "/webhooks/crl.pem", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Body.String(), "-----BEGIN X509 CRL-----"))
+	})
+}
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // AAIClient provides a client for ONAP A&AI (Active & Available Inventory) REST API.
@@ -46,6 +48,7 @@ func NewAAIClient(config *Config, logger *zap.Logger) (*AAIClient, error) {
 	httpClient := &http.Client{
 		Timeout: config.RequestTimeout,
 		Transport: &http.Transport{
+			DialContext:         resolver.DialContext,
 			TLSClientConfig:     tlsConfig,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
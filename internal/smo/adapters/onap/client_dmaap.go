@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // DMaaPClient provides a client for ONAP DMaaP (Data Movement as a Platform) message bus.
@@ -44,6 +46,7 @@ func NewDMaaPClient(config *Config, logger *zap.Logger) (*DMaaPClient, error) {
 	httpClient := &http.Client{
 		Timeout: config.RequestTimeout,
 		Transport: &http.Transport{
+			DialContext:         resolver.DialContext,
 			TLSClientConfig:     tlsConfig,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
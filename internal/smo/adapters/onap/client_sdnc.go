@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // SDNCClient provides a client for ONAP SDNC (Software Defined Network Controller) REST API.
@@ -44,6 +46,7 @@ func NewSDNCClient(config *Config, logger *zap.Logger) (*SDNCClient, error) {
 	httpClient := &http.Client{
 		Timeout: config.RequestTimeout,
 		Transport: &http.Transport{
+			DialContext:         resolver.DialContext,
 			TLSClientConfig:     tlsConfig,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
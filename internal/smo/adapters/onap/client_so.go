@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // SOClient provides a client for ONAP SO (Service Orchestrator) REST API.
@@ -44,6 +46,7 @@ func NewSOClient(config *Config, logger *zap.Logger) (*SOClient, error) {
 	httpClient := &http.Client{
 		Timeout: config.RequestTimeout,
 		Transport: &http.Transport{
+			DialContext:         resolver.DialContext,
 			TLSClientConfig:     tlsConfig,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
@@ -12,6 +12,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // Client provides a REST API client for OSM NBI (Northbound Interface).
@@ -43,6 +45,7 @@ func NewClient(config *Config) (*Client, error) {
 	httpClient := &http.Client{
 		Timeout: config.RequestTimeout,
 		Transport: &http.Transport{
+			DialContext:         resolver.DialContext,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
 			IdleConnTimeout:     90 * time.Second,
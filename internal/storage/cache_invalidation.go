@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+// cacheInvalidationChannel is the Redis pub/sub channel carrying cache invalidations.
+const cacheInvalidationChannel = "cache:invalidations"
+
+var (
+	// CacheInvalidationsPublished tracks invalidations published to other replicas.
+	CacheInvalidationsPublished = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "cache",
+			Name:      "invalidations_published_total",
+			Help:      "Total number of cache invalidations published to other replicas",
+		},
+		[]string{"type", "status"},
+	)
+
+	// CacheInvalidationsReceived tracks invalidations received from other replicas.
+	CacheInvalidationsReceived = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "cache",
+			Name:      "invalidations_received_total",
+			Help:      "Total number of cache invalidations received from other replicas",
+		},
+		[]string{"type", "status"},
+	)
+
+	// CacheInvalidationLatency tracks the delay between a mutation being published
+	// on one replica and the local cache being invalidated on another.
+	CacheInvalidationLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "o2ims",
+			Subsystem: "cache",
+			Name:      "invalidation_latency_seconds",
+			Help:      "Delay between publishing a cache invalidation and applying it on a replica",
+			Buckets:   []float64{0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1.0},
+		},
+		[]string{"type"},
+	)
+)
+
+// CacheInvalidation is a message telling replicas to drop cached entries.
+type CacheInvalidation struct {
+	// Type is the cached object type (e.g. "resources").
+	Type string `json:"type"`
+
+	// Keys are the invalidated object IDs. Empty invalidates every entry of Type.
+	Keys []string `json:"keys,omitempty"`
+
+	// Origin identifies the publishing replica, which has already applied it.
+	Origin string `json:"origin"`
+
+	// PublishedAt is when the invalidation was published.
+	PublishedAt time.Time `json:"publishedAt"`
+}
+
+// LocalCache is a per-replica TTL cache of objects keyed by type and ID.
+// It is kept coherent across replicas by a CacheInvalidationBus; entries missed
+// while the bus is disconnected expire after the TTL.
+type LocalCache struct {
+	ttl time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]map[string]localCacheEntry
+}
+
+type localCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewLocalCache creates a local cache whose entries expire after ttl.
+func NewLocalCache(ttl time.Duration) *LocalCache {
+	return &LocalCache{
+		ttl:     ttl,
+		entries: make(map[string]map[string]localCacheEntry),
+	}
+}
+
+// Get returns the cached value for an object, if present and not expired.
+func (c *LocalCache) Get(cacheType, key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[cacheType][key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set caches the value for an object.
+func (c *LocalCache) Set(cacheType, key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[cacheType] == nil {
+		c.entries[cacheType] = make(map[string]localCacheEntry)
+	}
+	c.entries[cacheType][key] = localCacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// Invalidate drops the cached entries for keys, or every entry of cacheType
+// when no keys are given.
+func (c *LocalCache) Invalidate(cacheType string, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(keys) == 0 {
+		delete(c.entries, cacheType)
+		return
+	}
+	for _, key := range keys {
+		delete(c.entries[cacheType], key)
+	}
+}
+
+// CacheInvalidationBus propagates cache invalidations between replicas over
+// Redis pub/sub. Publish invalidates the local cache immediately and notifies
+// other replicas; Run applies invalidations published by other replicas.
+//
+// Pub/sub delivery is at-most-once, so invalidations sent while a replica is
+// disconnected are lost; the LocalCache TTL bounds how long entries stay stale.
+type CacheInvalidationBus struct {
+	client redis.UniversalClient
+	cache  *LocalCache
+	origin string
+	ready  chan struct{}
+	once   sync.Once
+}
+
+// NewCacheInvalidationBus creates a bus that keeps cache coherent with other replicas.
+func NewCacheInvalidationBus(client redis.UniversalClient, cache *LocalCache) *CacheInvalidationBus {
+	return &CacheInvalidationBus{
+		client: client,
+		cache:  cache,
+		origin: uuid.New().String(),
+		ready:  make(chan struct{}),
+	}
+}
+
+// Ready returns a channel that is closed once Run has subscribed to the
+// invalidation channel.
+func (b *CacheInvalidationBus) Ready() <-chan struct{} {
+	return b.ready
+}
+
+// Publish invalidates keys of cacheType locally and on every other replica.
+// No keys invalidates every entry of cacheType.
+func (b *CacheInvalidationBus) Publish(ctx context.Context, cacheType string, keys ...string) error {
+	b.cache.Invalidate(cacheType, keys...)
+
+	payload, err := json.Marshal(&CacheInvalidation{
+		Type:        cacheType,
+		Keys:        keys,
+		Origin:      b.origin,
+		PublishedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache invalidation: %w", err)
+	}
+
+	if err := b.client.Publish(ctx, cacheInvalidationChannel, payload).Err(); err != nil {
+		CacheInvalidationsPublished.WithLabelValues(cacheType, "error").Inc()
+		return fmt.Errorf("failed to publish cache invalidation: %w", err)
+	}
+	CacheInvalidationsPublished.WithLabelValues(cacheType, "success").Inc()
+	return nil
+}
+
+// Run subscribes to invalidations from other replicas and applies them to the
+// local cache until ctx is cancelled.
+func (b *CacheInvalidationBus) Run(ctx context.Context) error {
+	pubsub := b.client.Subscribe(ctx, cacheInvalidationChannel)
+	defer func() { _ = pubsub.Close() }()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to cache invalidations: %w", err)
+	}
+	b.once.Do(func() { close(b.ready) })
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			b.apply(msg.Payload)
+		}
+	}
+}
+
+func (b *CacheInvalidationBus) apply(payload string) {
+	var inv CacheInvalidation
+	if err := json.Unmarshal([]byte(payload), &inv); err != nil || inv.Type == "" {
+		CacheInvalidationsReceived.WithLabelValues("unknown", "malformed").Inc()
+		return
+	}
+	if inv.Origin == b.origin {
+		// Already applied by Publish.
+		return
+	}
+
+	b.cache.Invalidate(inv.Type, inv.Keys...)
+	CacheInvalidationsReceived.WithLabelValues(inv.Type, "applied").Inc()
+
+	// Clock skew between replicas can make the delay negative.
+	latency := max(time.Since(inv.PublishedAt), 0)
+	CacheInvalidationLatency.WithLabelValues(inv.Type).Observe(latency.Seconds())
+}
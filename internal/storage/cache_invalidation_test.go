@@ -0,0 +1,99 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestLocalCache(t *testing.T) {
+	cache := storage.NewLocalCache(time.Minute)
+
+	cache.Set("resources", "res-1", "one")
+	cache.Set("resources", "res-2", "two")
+	cache.Set("resourcePools", "pool-1", "pool")
+
+	value, ok := cache.Get("resources", "res-1")
+	require.True(t, ok)
+	assert.Equal(t, "one", value)
+
+	cache.Invalidate("resources", "res-1")
+	_, ok = cache.Get("resources", "res-1")
+	assert.False(t, ok)
+	_, ok = cache.Get("resources", "res-2")
+	assert.True(t, ok)
+
+	// No keys invalidates the whole type.
+	cache.Invalidate("resources")
+	_, ok = cache.Get("resources", "res-2")
+	assert.False(t, ok)
+	_, ok = cache.Get("resourcePools", "pool-1")
+	assert.True(t, ok)
+}
+
+func TestLocalCache_Expiry(t *testing.T) {
+	cache := storage.NewLocalCache(10 * time.Millisecond)
+	cache.Set("resources", "res-1", "one")
+
+	assert.Eventually(t, func() bool {
+		_, ok := cache.Get("resources", "res-1")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestCacheInvalidationBus_AcrossReplicas(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Two replicas sharing one Redis.
+	cacheA := storage.NewLocalCache(time.Minute)
+	busA := storage.NewCacheInvalidationBus(redisStore.Client, cacheA)
+	cacheB := storage.NewLocalCache(time.Minute)
+	busB := storage.NewCacheInvalidationBus(redisStore.Client, cacheB)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- busB.Run(ctx) }()
+	select {
+	case <-busB.Ready():
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "replica B did not subscribe")
+	}
+
+	cacheA.Set("resources", "res-1", "a")
+	cacheB.Set("resources", "res-1", "b")
+	cacheB.Set("resources", "res-2", "b")
+
+	applied := testutil.ToFloat64(storage.CacheInvalidationsReceived.WithLabelValues("resources", "applied"))
+	require.NoError(t, busA.Publish(ctx, "resources", "res-1"))
+
+	// The publishing replica is invalidated synchronously.
+	_, ok := cacheA.Get("resources", "res-1")
+	assert.False(t, ok)
+
+	assert.Eventually(t, func() bool {
+		_, ok := cacheB.Get("resources", "res-1")
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok = cacheB.Get("resources", "res-2")
+	assert.True(t, ok)
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(storage.CacheInvalidationsReceived.WithLabelValues("resources", "applied")) == applied+1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "subscriber did not stop")
+	}
+}
@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+const (
+	// configHistoryKey is the Redis list holding config snapshots, newest first.
+	configHistoryKey = "config:history"
+
+	// DefaultConfigHistoryMaxEntries is the default number of snapshots retained.
+	DefaultConfigHistoryMaxEntries = 100
+)
+
+// ErrConfigHistoryEmpty is returned when no configuration snapshot has been recorded.
+var ErrConfigHistoryEmpty = errors.New("config history is empty")
+
+// ConfigSnapshot is a redacted record of the gateway configuration at a point in time.
+type ConfigSnapshot struct {
+	ID        string         `json:"id"`
+	Hash      string         `json:"hash"`
+	Reason    string         `json:"reason"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Changes   []ConfigChange `json:"changes"`
+
+	// Values holds the full redacted configuration. It is persisted so the next
+	// snapshot can be diffed against it, but omitted from history listings.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// ConfigChange describes a single configuration key that changed between snapshots.
+// Old is empty for added keys and New is empty for removed keys.
+type ConfigChange struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// ConfigHistoryStore persists configuration snapshots.
+type ConfigHistoryStore interface {
+	// Append stores a snapshot as the newest history entry.
+	Append(ctx context.Context, snapshot *ConfigSnapshot) error
+
+	// List returns up to limit snapshots, newest first. limit <= 0 returns all.
+	List(ctx context.Context, limit int) ([]*ConfigSnapshot, error)
+
+	// Latest returns the newest snapshot.
+	// Returns ErrConfigHistoryEmpty if no snapshot has been recorded.
+	Latest(ctx context.Context) (*ConfigSnapshot, error)
+}
+
+// DiffConfigValues returns the keys whose values differ between two flattened
+// configurations, sorted by key.
+func DiffConfigValues(previous, current map[string]string) []ConfigChange {
+	changes := make([]ConfigChange, 0)
+	for key, newValue := range current {
+		if oldValue, ok := previous[key]; !ok || oldValue != newValue {
+			changes = append(changes, ConfigChange{Key: key, Old: previous[key], New: newValue})
+		}
+	}
+	for key, oldValue := range previous {
+		if _, ok := current[key]; !ok {
+			changes = append(changes, ConfigChange{Key: key, Old: oldValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// RecordConfigSnapshot appends a snapshot of values to the history if its hash
+// differs from the latest stored snapshot. The snapshot records the diff from the
+// previous entry. It returns the current snapshot and whether a new entry was
+// recorded; an unchanged configuration returns the latest stored snapshot.
+func RecordConfigSnapshot(
+	ctx context.Context,
+	store ConfigHistoryStore,
+	values map[string]string,
+	hash string,
+	reason string,
+) (*ConfigSnapshot, bool, error) {
+	if store == nil {
+		return nil, false, errors.New("config history store cannot be nil")
+	}
+
+	latest, err := store.Latest(ctx)
+	if err != nil && !errors.Is(err, ErrConfigHistoryEmpty) {
+		return nil, false, fmt.Errorf("failed to load latest config snapshot: %w", err)
+	}
+	if latest != nil && latest.Hash == hash {
+		return latest, false, nil
+	}
+
+	var previous map[string]string
+	if latest != nil {
+		previous = latest.Values
+	}
+
+	snapshot := &ConfigSnapshot{
+		ID:        uuid.New().String(),
+		Hash:      hash,
+		Reason:    reason,
+		CreatedAt: timeutil.Now(),
+		Changes:   DiffConfigValues(previous, values),
+		Values:    values,
+	}
+
+	if err := store.Append(ctx, snapshot); err != nil {
+		return nil, false, fmt.Errorf("failed to store config snapshot: %w", err)
+	}
+	return snapshot, true, nil
+}
+
+// InMemoryConfigHistoryStore implements ConfigHistoryStore in memory.
+type InMemoryConfigHistoryStore struct {
+	mu         sync.RWMutex
+	snapshots  []*ConfigSnapshot
+	maxEntries int
+}
+
+// NewInMemoryConfigHistoryStore creates an in-memory config history store retaining
+// at most maxEntries snapshots (DefaultConfigHistoryMaxEntries if <= 0).
+func NewInMemoryConfigHistoryStore(maxEntries int) *InMemoryConfigHistoryStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultConfigHistoryMaxEntries
+	}
+	return &InMemoryConfigHistoryStore{maxEntries: maxEntries}
+}
+
+// Append stores a snapshot as the newest history entry.
+func (s *InMemoryConfigHistoryStore) Append(_ context.Context, snapshot *ConfigSnapshot) error {
+	if snapshot == nil {
+		return errors.New("config snapshot cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots = append([]*ConfigSnapshot{snapshot}, s.snapshots...)
+	if len(s.snapshots) > s.maxEntries {
+		s.snapshots = s.snapshots[:s.maxEntries]
+	}
+	return nil
+}
+
+// List returns up to limit snapshots, newest first.
+func (s *InMemoryConfigHistoryStore) List(_ context.Context, limit int) ([]*ConfigSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n := len(s.snapshots)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	snapshots := make([]*ConfigSnapshot, n)
+	copy(snapshots, s.snapshots[:n])
+	return snapshots, nil
+}
+
+// Latest returns the newest snapshot.
+func (s *InMemoryConfigHistoryStore) Latest(_ context.Context) (*ConfigSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.snapshots) == 0 {
+		return nil, ErrConfigHistoryEmpty
+	}
+	return s.snapshots[0], nil
+}
+
+// RedisConfigHistoryStore implements ConfigHistoryStore using a capped Redis list
+// so history survives restarts and is shared across gateway replicas.
+type RedisConfigHistoryStore struct {
+	client     redis.UniversalClient
+	maxEntries int
+}
+
+// NewRedisConfigHistoryStore creates a Redis-backed config history store retaining
+// at most maxEntries snapshots (DefaultConfigHistoryMaxEntries if <= 0).
+func NewRedisConfigHistoryStore(client redis.UniversalClient, maxEntries int) *RedisConfigHistoryStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultConfigHistoryMaxEntries
+	}
+	return &RedisConfigHistoryStore{client: client, maxEntries: maxEntries}
+}
+
+// Append stores a snapshot as the newest history entry and trims old entries.
+func (s *RedisConfigHistoryStore) Append(ctx context.Context, snapshot *ConfigSnapshot) error {
+	if snapshot == nil {
+		return errors.New("config snapshot cannot be nil")
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config snapshot: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.LPush(ctx, configHistoryKey, data)
+	pipe.LTrim(ctx, configHistoryKey, 0, int64(s.maxEntries-1))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store config snapshot: %w", err)
+	}
+	return nil
+}
+
+// List returns up to limit snapshots, newest first.
+func (s *RedisConfigHistoryStore) List(ctx context.Context, limit int) ([]*ConfigSnapshot, error) {
+	stop := int64(-1)
+	if limit > 0 {
+		stop = int64(limit - 1)
+	}
+
+	entries, err := s.client.LRange(ctx, configHistoryKey, 0, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config snapshots: %w", err)
+	}
+
+	snapshots := make([]*ConfigSnapshot, 0, len(entries))
+	for _, entry := range entries {
+		var snapshot ConfigSnapshot
+		if err := json.Unmarshal([]byte(entry), &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config snapshot: %w", err)
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	return snapshots, nil
+}
+
+// Latest returns the newest snapshot.
+func (s *RedisConfigHistoryStore) Latest(ctx context.Context) (*ConfigSnapshot, error) {
+	snapshots, err := s.List(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, ErrConfigHistoryEmpty
+	}
+	return snapshots[0], nil
+}
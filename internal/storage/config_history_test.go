@@ -0,0 +1,72 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestDiffConfigValues(t *testing.T) {
+	previous := map[string]string{"a": "1", "b": "2", "c": "3"}
+	current := map[string]string{"a": "1", "b": "20", "d": "4"}
+
+	assert.Equal(t, []storage.ConfigChange{
+		{Key: "b", Old: "2", New: "20"},
+		{Key: "c", Old: "3"},
+		{Key: "d", New: "4"},
+	}, storage.DiffConfigValues(previous, current))
+}
+
+func TestConfigHistoryStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.ConfigHistoryStore{
+		"in-memory": storage.NewInMemoryConfigHistoryStore(2),
+		"redis":     storage.NewRedisConfigHistoryStore(redisStore.Client, 2),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := store.Latest(ctx)
+			require.ErrorIs(t, err, storage.ErrConfigHistoryEmpty)
+
+			first, recorded, err := storage.RecordConfigSnapshot(ctx, store,
+				map[string]string{"server.port": "8080"}, "h1", "startup")
+			require.NoError(t, err)
+			assert.True(t, recorded)
+			require.Len(t, first.Changes, 1)
+
+			_, recorded, err = storage.RecordConfigSnapshot(ctx, store,
+				map[string]string{"server.port": "8080"}, "h1", "startup")
+			require.NoError(t, err)
+			assert.False(t, recorded, "unchanged hash is not recorded")
+
+			second, recorded, err := storage.RecordConfigSnapshot(ctx, store,
+				map[string]string{"server.port": "9090"}, "h2", "reload")
+			require.NoError(t, err)
+			assert.True(t, recorded)
+			assert.Equal(t, []storage.ConfigChange{{Key: "server.port", Old: "8080", New: "9090"}}, second.Changes)
+
+			_, _, err = storage.RecordConfigSnapshot(ctx, store,
+				map[string]string{"server.port": "7070"}, "h3", "reload")
+			require.NoError(t, err)
+
+			snapshots, err := store.List(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 2, "history is capped at max entries")
+			assert.Equal(t, "h3", snapshots[0].Hash)
+			assert.Equal(t, "h2", snapshots[1].Hash)
+
+			snapshots, err = store.List(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 1)
+		})
+	}
+}
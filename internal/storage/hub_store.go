@@ -5,6 +5,8 @@ import (
 	"errors"
 	"sync"
 	"time"
+
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 var (
@@ -21,6 +23,7 @@ type HubRegistration struct {
 	Query          string                 `json:"query"`
 	SubscriptionID string                 `json:"subscriptionId"`
 	CreatedAt      time.Time              `json:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
 	Extensions     map[string]interface{} `json:"extensions,omitempty"`
 }
 
@@ -71,6 +74,7 @@ func (s *InMemoryHubStore) Create(_ context.Context, hub *HubRegistration) error
 		return ErrHubExists
 	}
 
+	timeutil.Stamp(&hub.CreatedAt, &hub.UpdatedAt)
 	s.hubs[hub.HubID] = hub
 	return nil
 }
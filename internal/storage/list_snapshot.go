@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// listSnapshotKeyPrefix prefixes the Redis keys holding list snapshots.
+const listSnapshotKeyPrefix = "list-snapshots:"
+
+// DefaultListSnapshotTTL is how long a list snapshot is kept when no TTL is configured.
+const DefaultListSnapshotTTL = 5 * time.Minute
+
+// ErrListSnapshotNotFound is returned when a list snapshot does not exist or has expired.
+var ErrListSnapshotNotFound = errors.New("list snapshot not found or expired")
+
+// ListSnapshot captures the full result set of a list request so that a
+// paginated session reads a consistent view regardless of concurrent changes.
+type ListSnapshot struct {
+	// Kind identifies the listed collection (e.g. "resourcePools").
+	Kind string `json:"kind"`
+
+	// TenantID is the tenant the snapshot was taken for. Snapshots are only
+	// served back to the same tenant.
+	TenantID string `json:"tenantId,omitempty"`
+
+	// Items is the JSON-encoded result set.
+	Items json.RawMessage `json:"items"`
+
+	// Total is the number of items in the snapshot.
+	Total int `json:"total"`
+
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ListSnapshotStore persists list snapshots for the duration of a pagination session.
+type ListSnapshotStore interface {
+	// Save stores a snapshot and returns its ID.
+	Save(ctx context.Context, snapshot *ListSnapshot) (string, error)
+
+	// Load returns a stored snapshot.
+	// Returns ErrListSnapshotNotFound if the snapshot does not exist or has expired.
+	Load(ctx context.Context, id string) (*ListSnapshot, error)
+}
+
+// InMemoryListSnapshotStore implements ListSnapshotStore in memory.
+// Snapshots are only visible to the replica that created them.
+type InMemoryListSnapshotStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	snapshots map[string]inMemoryListSnapshot
+}
+
+type inMemoryListSnapshot struct {
+	snapshot  ListSnapshot
+	expiresAt time.Time
+}
+
+// NewInMemoryListSnapshotStore creates an in-memory list snapshot store.
+// A non-positive ttl uses DefaultListSnapshotTTL.
+func NewInMemoryListSnapshotStore(ttl time.Duration) *InMemoryListSnapshotStore {
+	if ttl <= 0 {
+		ttl = DefaultListSnapshotTTL
+	}
+	return &InMemoryListSnapshotStore{
+		ttl:       ttl,
+		snapshots: make(map[string]inMemoryListSnapshot),
+	}
+}
+
+// Save stores a snapshot and returns its ID. Expired snapshots are pruned.
+func (s *InMemoryListSnapshotStore) Save(_ context.Context, snapshot *ListSnapshot) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, entry := range s.snapshots {
+		if now.After(entry.expiresAt) {
+			delete(s.snapshots, id)
+		}
+	}
+
+	id := uuid.New().String()
+	s.snapshots[id] = inMemoryListSnapshot{snapshot: *snapshot, expiresAt: now.Add(s.ttl)}
+	return id, nil
+}
+
+// Load returns a stored snapshot.
+func (s *InMemoryListSnapshotStore) Load(_ context.Context, id string) (*ListSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.snapshots[id]
+	if !ok {
+		return nil, ErrListSnapshotNotFound
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.snapshots, id)
+		return nil, ErrListSnapshotNotFound
+	}
+	snapshot := entry.snapshot
+	return &snapshot, nil
+}
+
+// RedisListSnapshotStore implements ListSnapshotStore with Redis keys that
+// expire after the TTL, so any gateway replica can serve the next page.
+type RedisListSnapshotStore struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisListSnapshotStore creates a Redis-backed list snapshot store.
+// A non-positive ttl uses DefaultListSnapshotTTL.
+func NewRedisListSnapshotStore(client redis.UniversalClient, ttl time.Duration) *RedisListSnapshotStore {
+	if ttl <= 0 {
+		ttl = DefaultListSnapshotTTL
+	}
+	return &RedisListSnapshotStore{client: client, ttl: ttl}
+}
+
+// Save stores a snapshot and returns its ID.
+func (s *RedisListSnapshotStore) Save(ctx context.Context, snapshot *ListSnapshot) (string, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal list snapshot: %w", err)
+	}
+
+	id := uuid.New().String()
+	if err := s.client.Set(ctx, listSnapshotKeyPrefix+id, data, s.ttl).Err(); err != nil {
+		return "", fmt.Errorf("failed to save list snapshot: %w", err)
+	}
+	return id, nil
+}
+
+// Load returns a stored snapshot.
+func (s *RedisListSnapshotStore) Load(ctx context.Context, id string) (*ListSnapshot, error) {
+	data, err := s.client.Get(ctx, listSnapshotKeyPrefix+id).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrListSnapshotNotFound
+		}
+		return nil, fmt.Errorf("failed to load list snapshot: %w", err)
+	}
+
+	var snapshot ListSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal list snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
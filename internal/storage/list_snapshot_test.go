@@ -0,0 +1,75 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestListSnapshotStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.ListSnapshotStore{
+		"in-memory": storage.NewInMemoryListSnapshotStore(time.Minute),
+		"redis":     storage.NewRedisListSnapshotStore(redisStore.Client, time.Minute),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := store.Load(ctx, "missing")
+			require.ErrorIs(t, err, storage.ErrListSnapshotNotFound)
+
+			id, err := store.Save(ctx, &storage.ListSnapshot{
+				Kind:     "resourcePools",
+				TenantID: "tenant-a",
+				Items:    json.RawMessage(`[{"resourcePoolId":"pool-1"}]`),
+				Total:    1,
+			})
+			require.NoError(t, err)
+			require.NotEmpty(t, id)
+
+			snapshot, err := store.Load(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "resourcePools", snapshot.Kind)
+			assert.Equal(t, "tenant-a", snapshot.TenantID)
+			assert.Equal(t, 1, snapshot.Total)
+			assert.JSONEq(t, `[{"resourcePoolId":"pool-1"}]`, string(snapshot.Items))
+		})
+	}
+}
+
+func TestListSnapshotStores_Expiry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("in-memory", func(t *testing.T) {
+		store := storage.NewInMemoryListSnapshotStore(10 * time.Millisecond)
+		id, err := store.Save(ctx, &storage.ListSnapshot{Kind: "resources", Items: json.RawMessage(`[]`)})
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+		_, err = store.Load(ctx, id)
+		require.ErrorIs(t, err, storage.ErrListSnapshotNotFound)
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		redisStore, mr := setupTestRedis(t)
+		defer func() { _ = redisStore.Close() }()
+
+		store := storage.NewRedisListSnapshotStore(redisStore.Client, time.Minute)
+		id, err := store.Save(ctx, &storage.ListSnapshot{Kind: "resources", Items: json.RawMessage(`[]`)})
+		require.NoError(t, err)
+
+		mr.FastForward(2 * time.Minute)
+		_, err = store.Load(ctx, id)
+		require.ErrorIs(t, err, storage.ErrListSnapshotNotFound)
+	})
+}
@@ -3,9 +3,16 @@
 package storage
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
+
+	"github.com/piwi3910/netweave/internal/digest"
+	"github.com/piwi3910/netweave/internal/transform"
 )
 
 // Subscription represents an O2-IMS subscription.
@@ -37,11 +44,75 @@ type Subscription struct {
 	// Filter defines which resource changes trigger notifications
 	Filter SubscriptionFilter `json:"filter,omitempty"`
 
+	// Transform reshapes notifications before delivery (optional)
+	Transform *transform.Template `json:"transform,omitempty"`
+
+	// Digest accumulates notifications into periodic summaries (optional)
+	Digest *digest.Settings `json:"digest,omitempty"`
+
 	// CreatedAt is the subscription creation timestamp
 	CreatedAt time.Time `json:"createdAt"`
 
 	// UpdatedAt is the last update timestamp
 	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+
+	// SuspendedAt is set when the callback was found to violate the callback
+	// policy. No notifications are sent to a suspended subscription until its
+	// callback is updated.
+	SuspendedAt *time.Time `json:"suspendedAt,omitempty"`
+
+	// SuspensionReason explains why the subscription was suspended.
+	SuspensionReason string `json:"suspensionReason,omitempty"`
+
+	// ExpiresAt is when the subscription expires (optional). Expired
+	// subscriptions receive no notifications and are deleted by the expiry
+	// cleanup.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// SigningSecret signs the notifications of the subscription with the
+	// X-Notification-Signature header (optional). It is never returned by
+	// the API.
+	SigningSecret string `json:"signingSecret,omitempty"`
+
+	// PreviousSigningSecret is the secret replaced by the last rotation.
+	// Until PreviousSigningSecretExpiresAt it also signs notifications, so
+	// the subscriber can switch to the new secret at its own pace.
+	PreviousSigningSecret          string     `json:"previousSigningSecret,omitempty"`
+	PreviousSigningSecretExpiresAt *time.Time `json:"previousSigningSecretExpiresAt,omitempty"`
+}
+
+// Suspended reports whether notifications to the subscription are suspended.
+func (s *Subscription) Suspended() bool {
+	return s.SuspendedAt != nil
+}
+
+// RotateSigningSecret replaces the signing secret with secret. The replaced
+// secret keeps signing notifications for grace after now.
+func (s *Subscription) RotateSigningSecret(secret string, now time.Time, grace time.Duration) {
+	if s.SigningSecret != "" && s.SigningSecret != secret && grace > 0 {
+		expiresAt := now.Add(grace)
+		s.PreviousSigningSecret = s.SigningSecret
+		s.PreviousSigningSecretExpiresAt = &expiresAt
+	} else {
+		s.PreviousSigningSecret = ""
+		s.PreviousSigningSecretExpiresAt = nil
+	}
+	s.SigningSecret = secret
+}
+
+// SigningSecrets returns the secrets signing the notifications of the
+// subscription at now: its signing secret and, during the grace period of a
+// rotation, the replaced one.
+func (s *Subscription) SigningSecrets(now time.Time) (current, previous string) {
+	if s.PreviousSigningSecretExpiresAt != nil && now.Before(*s.PreviousSigningSecretExpiresAt) {
+		return s.SigningSecret, s.PreviousSigningSecret
+	}
+	return s.SigningSecret, ""
+}
+
+// Expired reports whether the subscription expired at now.
+func (s *Subscription) Expired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
 }
 
 // SubscriptionFilter defines resource filtering criteria for subscriptions.
@@ -74,6 +145,12 @@ func (s *Subscription) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// MatchesEverything reports whether the filter is empty, so the subscription
+// receives every event.
+func (f *SubscriptionFilter) MatchesEverything() bool {
+	return *f == SubscriptionFilter{}
+}
+
 // MatchesFilter checks if a resource matches the subscription filter.
 // All non-empty filter fields must match (AND logic).
 func (f *SubscriptionFilter) MatchesFilter(resourcePoolID, resourceTypeID, resourceID string) bool {
@@ -88,3 +165,47 @@ func (f *SubscriptionFilter) MatchesFilter(resourcePoolID, resourceTypeID, resou
 	}
 	return true
 }
+
+// Fingerprint identifies what a subscription delivers and to whom: a hash of
+// the owning tenant, the normalized callback URL, and the filter. Two
+// subscriptions with the same fingerprint send the same notifications to the
+// same endpoint, so one of them is redundant.
+func (s *Subscription) Fingerprint() string {
+	h := sha256.New()
+	for _, field := range []string{
+		s.TenantID,
+		NormalizeCallback(s.Callback),
+		s.Filter.ResourcePoolID,
+		s.Filter.ResourceTypeID,
+		s.Filter.ResourceID,
+	} {
+		// Length-prefix each field so adjacent fields cannot run together.
+		_, _ = fmt.Fprintf(h, "%d:%s;", len(field), field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NormalizeCallback returns a canonical form of a callback URL for comparison:
+// the scheme and host are lowercased, a default port is removed, and a
+// trailing slash is dropped from the path. Unparseable URLs are returned
+// unchanged.
+func NormalizeCallback(callback string) string {
+	u, err := url.Parse(strings.TrimSpace(callback))
+	if err != nil || u.Host == "" {
+		return callback
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	port := u.Port()
+	if port != "" && !(u.Scheme == "https" && port == "443") && !(u.Scheme == "http" && port == "80") {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	u.Fragment = ""
+	return u.String()
+}
@@ -0,0 +1,57 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestNormalizeCallback(t *testing.T) {
+	tests := []struct {
+		callback string
+		want     string
+	}{
+		{"https://smo.example.com/notify", "https://smo.example.com/notify"},
+		{"HTTPS://SMO.Example.com:443/notify/", "https://smo.example.com/notify"},
+		{"http://smo.example.com:80/notify", "http://smo.example.com/notify"},
+		{"http://smo.example.com:8080/notify", "http://smo.example.com:8080/notify"},
+		{"https://[::1]:8443/notify#frag", "https://[::1]:8443/notify"},
+		{"https://smo.example.com/Notify?a=1", "https://smo.example.com/Notify?a=1"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.callback, func(t *testing.T) {
+			assert.Equal(t, tt.want, storage.NormalizeCallback(tt.callback))
+		})
+	}
+}
+
+func TestSubscription_Fingerprint(t *testing.T) {
+	base := storage.Subscription{
+		ID:       "sub-1",
+		TenantID: "tenant-a",
+		Callback: "https://smo.example.com/notify",
+		Filter:   storage.SubscriptionFilter{ResourcePoolID: "pool-1"},
+	}
+
+	same := base
+	same.ID = "sub-2"
+	same.ConsumerSubscriptionID = "smo-42"
+	same.Callback = "https://SMO.example.com/notify/"
+	assert.Equal(t, base.Fingerprint(), same.Fingerprint(), "IDs and callback spelling do not matter")
+
+	otherTenant := base
+	otherTenant.TenantID = "tenant-b"
+	assert.NotEqual(t, base.Fingerprint(), otherTenant.Fingerprint())
+
+	otherFilter := base
+	otherFilter.Filter = storage.SubscriptionFilter{ResourceTypeID: "pool-1"}
+	assert.NotEqual(t, base.Fingerprint(), otherFilter.Fingerprint())
+
+	otherCallback := base
+	otherCallback.Callback = "https://smo.example.com/other"
+	assert.NotEqual(t, base.Fingerprint(), otherCallback.Fingerprint())
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reconciliationKeyPrefix prefixes the Redis keys holding reconciliation tasks.
+const reconciliationKeyPrefix = "reconciliations:"
+
+// DefaultReconciliationTTL is how long reconciliation tasks are kept when no TTL is configured.
+const DefaultReconciliationTTL = 7 * 24 * time.Hour
+
+// ErrReconciliationNotFound is returned when a reconciliation does not exist or has expired.
+var ErrReconciliationNotFound = errors.New("reconciliation not found or expired")
+
+// Reconciliation task actions.
+const (
+	// ReconciliationActionCreate creates an object the SMO expects but the inventory lacks.
+	ReconciliationActionCreate = "create"
+	// ReconciliationActionUpdate updates an object whose attributes differ from the SMO view.
+	ReconciliationActionUpdate = "update"
+	// ReconciliationActionDelete removes an object the SMO does not expect.
+	ReconciliationActionDelete = "delete"
+)
+
+// ReconciliationTaskStatusPending is the status of a task that has not been acted on.
+const ReconciliationTaskStatusPending = "pending"
+
+// Reconciliation records the tasks raised by comparing an SMO inventory
+// manifest with the actual inventory.
+type Reconciliation struct {
+	ID string `json:"reconciliationId"`
+
+	// TenantID is the tenant that requested the reconciliation. Tasks are only
+	// served back to the same tenant.
+	TenantID string `json:"tenantId,omitempty"`
+
+	CreatedAt time.Time             `json:"createdAt"`
+	Tasks     []*ReconciliationTask `json:"tasks"`
+}
+
+// ReconciliationTask is a follow-up action needed to bring the inventory in
+// line with the SMO's expected view.
+type ReconciliationTask struct {
+	TaskID      string                `json:"taskId"`
+	Action      string                `json:"action"`
+	ObjectType  string                `json:"objectType"`
+	ObjectID    string                `json:"objectId"`
+	Differences []AttributeDifference `json:"differences,omitempty"`
+	Status      string                `json:"status"`
+}
+
+// AttributeDifference describes an attribute whose actual value differs from
+// the expected value.
+type AttributeDifference struct {
+	Attribute string      `json:"attribute"`
+	Expected  interface{} `json:"expected"`
+	Actual    interface{} `json:"actual"`
+}
+
+// ReconciliationStore persists reconciliation tasks.
+type ReconciliationStore interface {
+	// Save stores a reconciliation under its ID.
+	Save(ctx context.Context, reconciliation *Reconciliation) error
+
+	// Load returns a stored reconciliation.
+	// Returns ErrReconciliationNotFound if it does not exist or has expired.
+	Load(ctx context.Context, id string) (*Reconciliation, error)
+}
+
+// InMemoryReconciliationStore implements ReconciliationStore in memory.
+type InMemoryReconciliationStore struct {
+	mu              sync.Mutex
+	ttl             time.Duration
+	reconciliations map[string]inMemoryReconciliation
+}
+
+type inMemoryReconciliation struct {
+	reconciliation Reconciliation
+	expiresAt      time.Time
+}
+
+// NewInMemoryReconciliationStore creates an in-memory reconciliation store.
+// A non-positive ttl uses DefaultReconciliationTTL.
+func NewInMemoryReconciliationStore(ttl time.Duration) *InMemoryReconciliationStore {
+	if ttl <= 0 {
+		ttl = DefaultReconciliationTTL
+	}
+	return &InMemoryReconciliationStore{
+		ttl:             ttl,
+		reconciliations: make(map[string]inMemoryReconciliation),
+	}
+}
+
+// Save stores a reconciliation. Expired reconciliations are pruned.
+func (s *InMemoryReconciliationStore) Save(_ context.Context, reconciliation *Reconciliation) error {
+	if reconciliation.ID == "" {
+		return errors.New("reconciliation ID cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, entry := range s.reconciliations {
+		if now.After(entry.expiresAt) {
+			delete(s.reconciliations, id)
+		}
+	}
+
+	s.reconciliations[reconciliation.ID] = inMemoryReconciliation{
+		reconciliation: *reconciliation,
+		expiresAt:      now.Add(s.ttl),
+	}
+	return nil
+}
+
+// Load returns a stored reconciliation.
+func (s *InMemoryReconciliationStore) Load(_ context.Context, id string) (*Reconciliation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.reconciliations[id]
+	if !ok {
+		return nil, ErrReconciliationNotFound
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.reconciliations, id)
+		return nil, ErrReconciliationNotFound
+	}
+	reconciliation := entry.reconciliation
+	return &reconciliation, nil
+}
+
+// RedisReconciliationStore implements ReconciliationStore with Redis keys that
+// expire after the TTL.
+type RedisReconciliationStore struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisReconciliationStore creates a Redis-backed reconciliation store.
+// A non-positive ttl uses DefaultReconciliationTTL.
+func NewRedisReconciliationStore(client redis.UniversalClient, ttl time.Duration) *RedisReconciliationStore {
+	if ttl <= 0 {
+		ttl = DefaultReconciliationTTL
+	}
+	return &RedisReconciliationStore{client: client, ttl: ttl}
+}
+
+// Save stores a reconciliation.
+func (s *RedisReconciliationStore) Save(ctx context.Context, reconciliation *Reconciliation) error {
+	if reconciliation.ID == "" {
+		return errors.New("reconciliation ID cannot be empty")
+	}
+
+	data, err := json.Marshal(reconciliation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reconciliation: %w", err)
+	}
+	if err := s.client.Set(ctx, reconciliationKeyPrefix+reconciliation.ID, data, s.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save reconciliation: %w", err)
+	}
+	return nil
+}
+
+// Load returns a stored reconciliation.
+func (s *RedisReconciliationStore) Load(ctx context.Context, id string) (*Reconciliation, error) {
+	data, err := s.client.Get(ctx, reconciliationKeyPrefix+id).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrReconciliationNotFound
+		}
+		return nil, fmt.Errorf("failed to load reconciliation: %w", err)
+	}
+
+	var reconciliation Reconciliation
+	if err := json.Unmarshal(data, &reconciliation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reconciliation: %w", err)
+	}
+	return &reconciliation, nil
+}
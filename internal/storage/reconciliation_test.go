@@ -0,0 +1,82 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestReconciliationStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.ReconciliationStore{
+		"in-memory": storage.NewInMemoryReconciliationStore(time.Minute),
+		"redis":     storage.NewRedisReconciliationStore(redisStore.Client, time.Minute),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := store.Load(ctx, "missing")
+			require.ErrorIs(t, err, storage.ErrReconciliationNotFound)
+
+			require.Error(t, store.Save(ctx, &storage.Reconciliation{}))
+
+			err = store.Save(ctx, &storage.Reconciliation{
+				ID:       "rec-1",
+				TenantID: "tenant-a",
+				Tasks: []*storage.ReconciliationTask{{
+					TaskID:     "task-1",
+					Action:     storage.ReconciliationActionUpdate,
+					ObjectType: "resource",
+					ObjectID:   "res-1",
+					Differences: []storage.AttributeDifference{
+						{Attribute: "description", Expected: "edge", Actual: "core"},
+					},
+					Status: storage.ReconciliationTaskStatusPending,
+				}},
+			})
+			require.NoError(t, err)
+
+			rec, err := store.Load(ctx, "rec-1")
+			require.NoError(t, err)
+			assert.Equal(t, "tenant-a", rec.TenantID)
+			require.Len(t, rec.Tasks, 1)
+			assert.Equal(t, "res-1", rec.Tasks[0].ObjectID)
+			require.Len(t, rec.Tasks[0].Differences, 1)
+			assert.Equal(t, "edge", rec.Tasks[0].Differences[0].Expected)
+		})
+	}
+}
+
+func TestReconciliationStores_Expiry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("in-memory", func(t *testing.T) {
+		store := storage.NewInMemoryReconciliationStore(10 * time.Millisecond)
+		require.NoError(t, store.Save(ctx, &storage.Reconciliation{ID: "rec-1"}))
+
+		time.Sleep(20 * time.Millisecond)
+		_, err := store.Load(ctx, "rec-1")
+		require.ErrorIs(t, err, storage.ErrReconciliationNotFound)
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		redisStore, mr := setupTestRedis(t)
+		defer func() { _ = redisStore.Close() }()
+
+		store := storage.NewRedisReconciliationStore(redisStore.Client, time.Minute)
+		require.NoError(t, store.Save(ctx, &storage.Reconciliation{ID: "rec-1"}))
+
+		mr.FastForward(2 * time.Minute)
+		_, err := store.Load(ctx, "rec-1")
+		require.ErrorIs(t, err, storage.ErrReconciliationNotFound)
+	})
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/piwi3910/netweave/internal/readfallback"
+	"github.com/piwi3910/netweave/internal/timeutil"
 )
 
 const (
@@ -20,6 +23,9 @@ const (
 	subscriptionTenantIndexPrefix = "subscriptions:tenant:"
 	subscriptionEventChannel      = "subscriptions:events"
 
+	// subscriptionIndexPrefix is the common prefix of the index sets above.
+	subscriptionIndexPrefix = "subscriptions:"
+
 	// Default TTL for subscription keys (0 = no expiration).
 	subscriptionTTL = 0
 )
@@ -115,6 +121,9 @@ type RedisStore struct {
 	// Client is the underlying Redis client (public for middleware)
 	Client redis.UniversalClient
 	config *RedisConfig
+
+	// fallback serves reads while Redis is unavailable (nil: disabled).
+	fallback *readfallback.Cache
 }
 
 // NewRedisStore creates a new RedisStore instance.
@@ -160,6 +169,31 @@ func NewRedisStore(cfg *RedisConfig) *RedisStore {
 	}
 }
 
+// EnableReadFallback keeps the subscriptions read from Redis in cache, which
+// answers reads while Redis is unavailable. Writes fail with
+// ErrStorageUnavailable during an outage.
+func (r *RedisStore) EnableReadFallback(cache *readfallback.Cache) {
+	r.fallback = cache
+}
+
+// storageError wraps a failed Redis call, with ErrStorageUnavailable if Redis
+// could not be reached.
+func storageError(msg string, err error) error {
+	if readfallback.IsUnavailable(err) {
+		return fmt.Errorf("%w: %s: %w", ErrStorageUnavailable, msg, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// writeError wraps a failed Redis write like storageError and counts writes
+// rejected by an outage.
+func (r *RedisStore) writeError(msg string, err error) error {
+	if readfallback.IsUnavailable(err) {
+		r.fallback.RecordRejectedWrite()
+	}
+	return storageError(msg, err)
+}
+
 // Create creates a new subscription in Redis.
 // Returns ErrSubscriptionExists if a subscription with the same ID already exists.
 // Returns ErrInvalidCallback if the callback URL is invalid.
@@ -174,16 +208,15 @@ func (r *RedisStore) Create(ctx context.Context, sub *Subscription) error {
 	}
 
 	// Set timestamps
-	now := time.Now().UTC()
-	sub.CreatedAt = now
-	sub.UpdatedAt = now
+	sub.CreatedAt = time.Time{}
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
 
 	key := subscriptionKeyPrefix + sub.ID
 
 	// Check if subscription already exists
 	exists, err := r.Client.Exists(ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to check subscription existence: %w", err)
+		return r.writeError("failed to check subscription existence", err)
 	}
 	if exists > 0 {
 		return ErrSubscriptionExists
@@ -233,8 +266,10 @@ func (r *RedisStore) Create(ctx context.Context, sub *Subscription) error {
 	// Execute pipeline
 	_, err = pipe.Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create subscription: %w", err)
+		return r.writeError("failed to create subscription", err)
 	}
+	r.fallback.Put(key, json.RawMessage(data))
+	r.fallback.DeletePrefix(subscriptionIndexPrefix)
 
 	return nil
 }
@@ -253,13 +288,18 @@ func (r *RedisStore) Get(ctx context.Context, id string) (*Subscription, error)
 		if errors.Is(err, redis.Nil) {
 			return nil, ErrSubscriptionNotFound
 		}
-		return nil, fmt.Errorf("failed to get subscription: %w", err)
+		var sub Subscription
+		if readfallback.IsUnavailable(err) && r.fallback.Serve(ctx, key, &sub) {
+			return &sub, nil
+		}
+		return nil, storageError("failed to get subscription", err)
 	}
 
 	var sub Subscription
 	if err := json.Unmarshal(data, &sub); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
 	}
+	r.fallback.Put(key, json.RawMessage(data))
 
 	return &sub, nil
 }
@@ -277,8 +317,8 @@ func (r *RedisStore) Update(ctx context.Context, sub *Subscription) error {
 		return err
 	}
 
-	sub.UpdatedAt = time.Now().UTC()
 	sub.CreatedAt = existing.CreatedAt
+	timeutil.Stamp(&sub.CreatedAt, &sub.UpdatedAt)
 
 	data, err := json.Marshal(sub)
 	if err != nil {
@@ -293,8 +333,10 @@ func (r *RedisStore) Update(ctx context.Context, sub *Subscription) error {
 	r.publishUpdateEvent(ctx, pipe, sub.ID)
 
 	if _, err = pipe.Exec(ctx); err != nil {
-		return fmt.Errorf("failed to update subscription: %w", err)
+		return r.writeError("failed to update subscription", err)
 	}
+	r.fallback.Put(key, json.RawMessage(data))
+	r.fallback.DeletePrefix(subscriptionIndexPrefix)
 
 	return nil
 }
@@ -311,7 +353,7 @@ func (r *RedisStore) validateUpdate(ctx context.Context, sub *Subscription) erro
 	key := subscriptionKeyPrefix + sub.ID
 	exists, err := r.Client.Exists(ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to check subscription existence: %w", err)
+		return r.writeError("failed to check subscription existence", err)
 	}
 	if exists == 0 {
 		return ErrSubscriptionNotFound
@@ -433,8 +475,10 @@ func (r *RedisStore) Delete(ctx context.Context, id string) error {
 	// Execute pipeline
 	_, err = pipe.Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to delete subscription: %w", err)
+		return r.writeError("failed to delete subscription", err)
 	}
+	r.fallback.Delete(key)
+	r.fallback.DeletePrefix(subscriptionIndexPrefix)
 
 	return nil
 }
@@ -442,28 +486,7 @@ func (r *RedisStore) Delete(ctx context.Context, id string) error {
 // List retrieves all subscriptions.
 // Returns an empty slice if no subscriptions exist.
 func (r *RedisStore) List(ctx context.Context) ([]*Subscription, error) {
-	// Get all subscription IDs from the active set
-	ids, err := r.Client.SMembers(ctx, subscriptionSetKey).Result()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list subscription IDs: %w", err)
-	}
-
-	if len(ids) == 0 {
-		return []*Subscription{}, nil
-	}
-
-	// Retrieve all subscriptions
-	subs := make([]*Subscription, 0, len(ids))
-	for _, id := range ids {
-		sub, err := r.Get(ctx, id)
-		if err != nil {
-			// Skip subscriptions that failed to load (e.g., corrupted data)
-			continue
-		}
-		subs = append(subs, sub)
-	}
-
-	return subs, nil
+	return r.listIndex(ctx, subscriptionSetKey, "failed to list subscription IDs")
 }
 
 // ListByResourcePool retrieves subscriptions filtered by resource pool ID.
@@ -472,30 +495,7 @@ func (r *RedisStore) ListByResourcePool(ctx context.Context, resourcePoolID stri
 	if resourcePoolID == "" {
 		return []*Subscription{}, nil
 	}
-
-	poolKey := subscriptionPoolIndexPrefix + resourcePoolID
-
-	// Get subscription IDs from pool index
-	ids, err := r.Client.SMembers(ctx, poolKey).Result()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list subscriptions by pool: %w", err)
-	}
-
-	if len(ids) == 0 {
-		return []*Subscription{}, nil
-	}
-
-	// Retrieve subscriptions
-	subs := make([]*Subscription, 0, len(ids))
-	for _, id := range ids {
-		sub, err := r.Get(ctx, id)
-		if err != nil {
-			continue
-		}
-		subs = append(subs, sub)
-	}
-
-	return subs, nil
+	return r.listIndex(ctx, subscriptionPoolIndexPrefix+resourcePoolID, "failed to list subscriptions by pool")
 }
 
 // ListByResourceType retrieves subscriptions filtered by resource type ID.
@@ -504,30 +504,7 @@ func (r *RedisStore) ListByResourceType(ctx context.Context, resourceTypeID stri
 	if resourceTypeID == "" {
 		return []*Subscription{}, nil
 	}
-
-	typeKey := subscriptionTypeIndexPrefix + resourceTypeID
-
-	// Get subscription IDs from type index
-	ids, err := r.Client.SMembers(ctx, typeKey).Result()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list subscriptions by type: %w", err)
-	}
-
-	if len(ids) == 0 {
-		return []*Subscription{}, nil
-	}
-
-	// Retrieve subscriptions
-	subs := make([]*Subscription, 0, len(ids))
-	for _, id := range ids {
-		sub, err := r.Get(ctx, id)
-		if err != nil {
-			continue
-		}
-		subs = append(subs, sub)
-	}
-
-	return subs, nil
+	return r.listIndex(ctx, subscriptionTypeIndexPrefix+resourceTypeID, "failed to list subscriptions by type")
 }
 
 // ListByTenant retrieves subscriptions filtered by tenant ID.
@@ -536,24 +513,36 @@ func (r *RedisStore) ListByTenant(ctx context.Context, tenantID string) ([]*Subs
 	if tenantID == "" {
 		return []*Subscription{}, nil
 	}
+	return r.listIndex(ctx, subscriptionTenantIndexPrefix+tenantID, "failed to list subscriptions by tenant")
+}
 
-	tenantKey := subscriptionTenantIndexPrefix + tenantID
-
-	// Get subscription IDs from tenant index
-	ids, err := r.Client.SMembers(ctx, tenantKey).Result()
+// listIndex retrieves the subscriptions whose IDs are members of the set at
+// setKey. Subscriptions that fail to load (e.g., corrupted data) are skipped,
+// except when the set itself was served by the read fallback: a stale list
+// must be complete or fail.
+func (r *RedisStore) listIndex(ctx context.Context, setKey, errMsg string) ([]*Subscription, error) {
+	ids, err := r.Client.SMembers(ctx, setKey).Result()
+	stale := false
 	if err != nil {
-		return nil, fmt.Errorf("failed to list subscriptions by tenant: %w", err)
+		if !readfallback.IsUnavailable(err) || !r.fallback.Serve(ctx, setKey, &ids) {
+			return nil, storageError(errMsg, err)
+		}
+		stale = true
+	} else {
+		r.fallback.Put(setKey, ids)
 	}
 
 	if len(ids) == 0 {
 		return []*Subscription{}, nil
 	}
 
-	// Retrieve subscriptions
 	subs := make([]*Subscription, 0, len(ids))
 	for _, id := range ids {
 		sub, err := r.Get(ctx, id)
 		if err != nil {
+			if stale {
+				return nil, err
+			}
 			continue
 		}
 		subs = append(subs, sub)
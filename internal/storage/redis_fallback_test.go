@@ -0,0 +1,64 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/readfallback"
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestRedisStore_ReadFallback(t *testing.T) {
+	store, mr := setupTestRedis(t)
+	defer func() { _ = store.Close() }()
+	store.EnableReadFallback(readfallback.New("subscriptions", time.Minute, 100))
+
+	ctx := context.Background()
+	for _, sub := range []*storage.Subscription{
+		{ID: "sub-1", TenantID: "tenant-a", Callback: "https://smo.example.com/notify"},
+		{ID: "sub-2", TenantID: "tenant-b", Callback: "https://smo.example.com/notify"},
+		{ID: "sub-3", TenantID: "tenant-b", Callback: "https://smo.example.com/notify"},
+	} {
+		require.NoError(t, store.Create(ctx, sub))
+	}
+
+	// Reads while Redis is up fill the cache.
+	_, err := store.Get(ctx, "sub-1")
+	require.NoError(t, err)
+	all, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	mr.Close()
+
+	t.Run("cached reads are served stale", func(t *testing.T) {
+		readCtx := readfallback.WithTracker(ctx)
+		sub, err := store.Get(readCtx, "sub-1")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-a", sub.TenantID)
+		_, stale := readfallback.StaleAge(readCtx)
+		assert.True(t, stale)
+
+		subs, err := store.List(readCtx)
+		require.NoError(t, err)
+		assert.Len(t, subs, 3)
+	})
+
+	t.Run("uncached reads fail as unavailable", func(t *testing.T) {
+		_, err := store.ListByTenant(ctx, "tenant-b")
+		require.ErrorIs(t, err, storage.ErrStorageUnavailable)
+		_, err = store.Get(ctx, "sub-4")
+		require.ErrorIs(t, err, storage.ErrStorageUnavailable)
+	})
+
+	t.Run("writes are rejected", func(t *testing.T) {
+		err := store.Create(ctx, &storage.Subscription{ID: "sub-4", Callback: "https://smo.example.com/notify"})
+		require.ErrorIs(t, err, storage.ErrStorageUnavailable)
+		err = store.Delete(ctx, "sub-1")
+		require.ErrorIs(t, err, storage.ErrStorageUnavailable)
+	})
+}
@@ -0,0 +1,465 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// DefaultPoolStatsInterval is the default interval between two samples of
+// the stats of a Redis connection pool.
+const DefaultPoolStatsInterval = 10 * time.Second
+
+// Pool resize directions.
+const (
+	poolResizeGrow   = "grow"
+	poolResizeShrink = "shrink"
+)
+
+var (
+	// PoolConnections tracks the connections of a Redis pool, by state:
+	// "total" and "idle".
+	PoolConnections = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "connections",
+			Help:      "Connections of the Redis connection pool by state (total, idle)",
+		},
+		[]string{"pool", "state"},
+	)
+
+	// PoolSize tracks the effective size of a Redis pool, which changes with
+	// automatic resizing.
+	PoolSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "size",
+			Help:      "Effective maximum number of connections in use of the Redis connection pool",
+		},
+		[]string{"pool"},
+	)
+
+	// PoolHits tracks connection requests served by an idle connection.
+	PoolHits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "hits_total",
+			Help:      "Total number of times an idle connection was found in the Redis connection pool",
+		},
+		[]string{"pool"},
+	)
+
+	// PoolMisses tracks connection requests that needed a new connection.
+	PoolMisses = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "misses_total",
+			Help:      "Total number of times no idle connection was found in the Redis connection pool",
+		},
+		[]string{"pool"},
+	)
+
+	// PoolWaits tracks connection requests that had to wait for a connection.
+	PoolWaits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "waits_total",
+			Help:      "Total number of times a command waited for a Redis connection",
+		},
+		[]string{"pool"},
+	)
+
+	// PoolWaitDuration tracks the time spent waiting for connections.
+	PoolWaitDuration = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "wait_duration_seconds_total",
+			Help:      "Total time commands spent waiting for a Redis connection",
+		},
+		[]string{"pool"},
+	)
+
+	// PoolTimeouts tracks connection requests that gave up waiting.
+	PoolTimeouts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "timeouts_total",
+			Help:      "Total number of times waiting for a Redis connection timed out",
+		},
+		[]string{"pool"},
+	)
+
+	// PoolClosed tracks the connections removed from a pool, by reason:
+	// "stale" (idle for too long) and "unusable" (broken).
+	PoolClosed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "closed_connections_total",
+			Help:      "Total number of connections removed from the Redis connection pool by reason (stale, unusable)",
+		},
+		[]string{"pool", "reason"},
+	)
+
+	// PoolResizes tracks automatic pool resizes, by direction.
+	PoolResizes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "redis_pool",
+			Name:      "resizes_total",
+			Help:      "Total number of automatic Redis connection pool resizes by direction (grow, shrink)",
+		},
+		[]string{"pool", "direction"},
+	)
+)
+
+// PoolAutoResizeConfig configures the automatic resizing of a Redis pool.
+type PoolAutoResizeConfig struct {
+	// MinSize and MaxSize bound the pool size.
+	MinSize int
+	MaxSize int
+
+	// WaitThreshold is the average wait for a connection above which the
+	// pool is under pressure.
+	WaitThreshold time.Duration
+
+	// SustainedIntervals is the number of consecutive stats intervals the
+	// pool must be under pressure to grow, or idle to shrink.
+	SustainedIntervals int
+}
+
+// PoolMonitorConfig configures a PoolMonitor.
+type PoolMonitorConfig struct {
+	// Interval is the interval between two samples of the pool stats
+	// (default DefaultPoolStatsInterval).
+	Interval time.Duration
+
+	// InitialSize is the configured pool size, which resizing starts from.
+	InitialSize int
+
+	// PoolTimeout bounds the wait for a connection when resizing.
+	PoolTimeout time.Duration
+
+	// AutoResize enables automatic resizing when non-nil.
+	AutoResize *PoolAutoResizeConfig
+}
+
+// PoolMonitor exports the stats of a Redis connection pool as metrics, so
+// that pool exhaustion shows up as waits and timeouts rather than random
+// command timeouts, and optionally resizes the pool between bounds.
+//
+// go-redis pools have a fixed size, so the client of a resized pool must be
+// created with a pool size of AutoResize.MaxSize: the monitor then caps the
+// connections in use to the current size with a client hook, and changes
+// that size based on sustained wait times.
+type PoolMonitor struct {
+	name    string
+	client  redis.UniversalClient
+	cfg     PoolMonitorConfig
+	limiter *poolLimiter
+	logger  *zap.Logger
+
+	last     poolSample
+	pressure int
+	slack    int
+}
+
+// poolSample is a sample of the cumulative stats of a pool.
+type poolSample struct {
+	hits, misses, waits, timeouts, stale, unusable uint64
+	waitDuration                                   time.Duration
+	total, idle                                    int
+}
+
+// NewPoolMonitor returns a monitor of the connection pool of client, whose
+// metrics are labelled with name. With cfg.AutoResize set, it installs the
+// hook capping the connections in use on client.
+func NewPoolMonitor(name string, client redis.UniversalClient, cfg PoolMonitorConfig, logger *zap.Logger) *PoolMonitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultPoolStatsInterval
+	}
+	m := &PoolMonitor{name: name, client: client, cfg: cfg, logger: logger}
+	if resize := cfg.AutoResize; resize != nil {
+		size := min(max(cfg.InitialSize, resize.MinSize), resize.MaxSize)
+		m.limiter = newPoolLimiter(size, cfg.PoolTimeout)
+		client.AddHook(m.limiter)
+		PoolSize.WithLabelValues(name).Set(float64(size))
+	} else if cfg.InitialSize > 0 {
+		PoolSize.WithLabelValues(name).Set(float64(cfg.InitialSize))
+	}
+	return m
+}
+
+// Size returns the current size of the pool when it is resized, or 0.
+func (m *PoolMonitor) Size() int {
+	if m.limiter == nil {
+		return 0
+	}
+	return m.limiter.size()
+}
+
+// Start samples the pool stats every interval until ctx is canceled.
+func (m *PoolMonitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Sample()
+			}
+		}
+	}()
+}
+
+// Sample exports the pool stats accumulated since the previous sample and,
+// with automatic resizing, adjusts the pool size.
+func (m *PoolMonitor) Sample() {
+	stats := m.client.PoolStats()
+	sample := poolSample{
+		hits:         uint64(stats.Hits),
+		misses:       uint64(stats.Misses),
+		waits:        uint64(stats.WaitCount),
+		timeouts:     uint64(stats.Timeouts),
+		stale:        uint64(stats.StaleConns),
+		unusable:     uint64(stats.Unusable),
+		waitDuration: time.Duration(stats.WaitDurationNs),
+		total:        int(stats.TotalConns),
+		idle:         int(stats.IdleConns),
+	}
+	var peak int
+	if m.limiter != nil {
+		var waits, timeouts uint64
+		var waitDuration time.Duration
+		waits, timeouts, waitDuration, peak = m.limiter.stats()
+		sample.waits += waits
+		sample.timeouts += timeouts
+		sample.waitDuration += waitDuration
+	}
+
+	// go-redis counters are 32-bit and may wrap; skip negative deltas.
+	delta := func(current, last uint64) float64 {
+		if current < last {
+			return 0
+		}
+		return float64(current - last)
+	}
+	last := m.last
+	m.last = sample
+	PoolConnections.WithLabelValues(m.name, "total").Set(float64(sample.total))
+	PoolConnections.WithLabelValues(m.name, "idle").Set(float64(sample.idle))
+	PoolHits.WithLabelValues(m.name).Add(delta(sample.hits, last.hits))
+	PoolMisses.WithLabelValues(m.name).Add(delta(sample.misses, last.misses))
+	PoolWaits.WithLabelValues(m.name).Add(delta(sample.waits, last.waits))
+	PoolTimeouts.WithLabelValues(m.name).Add(delta(sample.timeouts, last.timeouts))
+	PoolClosed.WithLabelValues(m.name, "stale").Add(delta(sample.stale, last.stale))
+	PoolClosed.WithLabelValues(m.name, "unusable").Add(delta(sample.unusable, last.unusable))
+	waitDuration := max(sample.waitDuration-last.waitDuration, 0)
+	PoolWaitDuration.WithLabelValues(m.name).Add(waitDuration.Seconds())
+
+	if m.limiter != nil {
+		m.resize(delta(sample.waits, last.waits), delta(sample.timeouts, last.timeouts), waitDuration, peak)
+	}
+}
+
+// resize grows the pool after SustainedIntervals intervals under pressure
+// (an average wait above WaitThreshold, or timeouts) and shrinks it after
+// SustainedIntervals intervals without waits and with at most half of the
+// connections in use. Each step changes the size by a quarter, at least 1.
+func (m *PoolMonitor) resize(waits, timeouts float64, waitDuration time.Duration, peak int) {
+	resize := m.cfg.AutoResize
+	size := m.limiter.size()
+
+	switch {
+	case timeouts > 0 || (waits > 0 && waitDuration/time.Duration(waits) > resize.WaitThreshold):
+		m.pressure++
+		m.slack = 0
+	case waits == 0 && peak <= size/2:
+		m.slack++
+		m.pressure = 0
+	default:
+		m.pressure, m.slack = 0, 0
+	}
+
+	step := max(size/4, 1)
+	newSize, direction := size, ""
+	switch {
+	case m.pressure >= resize.SustainedIntervals && size < resize.MaxSize:
+		newSize, direction = min(size+step, resize.MaxSize), poolResizeGrow
+	case m.slack >= resize.SustainedIntervals && size > resize.MinSize:
+		newSize, direction = max(size-step, resize.MinSize), poolResizeShrink
+	default:
+		return
+	}
+	m.pressure, m.slack = 0, 0
+
+	m.limiter.setSize(newSize)
+	PoolSize.WithLabelValues(m.name).Set(float64(newSize))
+	PoolResizes.WithLabelValues(m.name, direction).Inc()
+	m.logger.Info("resized Redis connection pool",
+		zap.String("pool", m.name),
+		zap.String("direction", direction),
+		zap.Int("from", size),
+		zap.Int("to", newSize),
+	)
+}
+
+// poolLimiter is a go-redis hook capping the commands in flight, and thus
+// the connections in use, to a size that can change at runtime. Waiters are
+// served in FIFO order.
+type poolLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	inUse   int
+	peak    int
+	waiters []chan struct{}
+	timeout time.Duration
+
+	waits        uint64
+	timeouts     uint64
+	waitDuration time.Duration
+}
+
+func newPoolLimiter(size int, timeout time.Duration) *poolLimiter {
+	return &poolLimiter{limit: size, timeout: timeout}
+}
+
+// size returns the current limit.
+func (l *poolLimiter) size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// setSize changes the limit; waiters are admitted if it grew. Commands in
+// flight above a reduced limit complete normally.
+func (l *poolLimiter) setSize(size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = size
+	l.admitLocked()
+}
+
+// stats returns the cumulative waits, timeouts and wait duration, and the
+// peak of commands in flight since the previous call.
+func (l *poolLimiter) stats() (uint64, uint64, time.Duration, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	peak := l.peak
+	l.peak = l.inUse
+	return l.waits, l.timeouts, l.waitDuration, peak
+}
+
+// acquire waits for a slot, at most the pool timeout if set.
+func (l *poolLimiter) acquire(ctx context.Context) error {
+	l.mu.Lock()
+	if l.inUse < l.limit && len(l.waiters) == 0 {
+		l.inUse++
+		l.peak = max(l.peak, l.inUse)
+		l.mu.Unlock()
+		return nil
+	}
+	admitted := make(chan struct{})
+	l.waiters = append(l.waiters, admitted)
+	l.waits++
+	l.mu.Unlock()
+
+	start := time.Now()
+	var timeout <-chan time.Time
+	if l.timeout > 0 {
+		timer := time.NewTimer(l.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+	select {
+	case <-admitted:
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		err = redis.ErrPoolTimeout
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waitDuration += time.Since(start)
+	if err == nil {
+		return nil
+	}
+	for i, waiter := range l.waiters {
+		if waiter == admitted {
+			l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+			if errors.Is(err, redis.ErrPoolTimeout) {
+				l.timeouts++
+			}
+			return err
+		}
+	}
+	// Admitted concurrently with giving up: take the slot.
+	return nil
+}
+
+// release frees a slot.
+func (l *poolLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inUse--
+	l.admitLocked()
+}
+
+// admitLocked admits waiters while slots are free.
+func (l *poolLimiter) admitLocked() {
+	for l.inUse < l.limit && len(l.waiters) > 0 {
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+		l.inUse++
+		l.peak = max(l.peak, l.inUse)
+	}
+}
+
+// DialHook implements redis.Hook.
+func (l *poolLimiter) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implements redis.Hook.
+func (l *poolLimiter) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := l.acquire(ctx); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		defer l.release()
+		return next(ctx, cmd)
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook.
+func (l *poolLimiter) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := l.acquire(ctx); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		defer l.release()
+		return next(ctx, cmds)
+	}
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPoolLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := newPoolLimiter(1, 20*time.Millisecond)
+
+	require.NoError(t, l.acquire(ctx))
+	assert.ErrorIs(t, l.acquire(ctx), redis.ErrPoolTimeout)
+
+	waits, timeouts, _, peak := l.stats()
+	assert.Equal(t, uint64(1), waits)
+	assert.Equal(t, uint64(1), timeouts)
+	assert.Equal(t, 1, peak)
+
+	// Growing the limit admits waiters.
+	l.timeout = 0
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, l.acquire(ctx))
+	}()
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return len(l.waiters) == 1
+	}, time.Second, time.Millisecond)
+	l.setSize(2)
+	wg.Wait()
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, l.acquire(canceled), context.Canceled)
+
+	l.release()
+	l.release()
+	assert.Zero(t, l.inUse)
+}
+
+func TestPoolMonitor_Sample(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), PoolSize: 20})
+	defer func() { _ = client.Close() }()
+
+	misses := testutil.ToFloat64(PoolMisses.WithLabelValues("test-sample"))
+	m := NewPoolMonitor("test-sample", client, PoolMonitorConfig{InitialSize: 8}, zap.NewNop())
+	require.NoError(t, client.Ping(context.Background()).Err())
+	m.Sample()
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(PoolConnections.WithLabelValues("test-sample", "total")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(PoolMisses.WithLabelValues("test-sample")))
+	assert.Equal(t, float64(8), testutil.ToFloat64(PoolSize.WithLabelValues("test-sample")))
+	assert.Zero(t, m.Size(), "not resized")
+}
+
+func TestPoolMonitor_AutoResize(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), PoolSize: 20})
+	defer func() { _ = client.Close() }()
+	grows := testutil.ToFloat64(PoolResizes.WithLabelValues("test-resize", "grow"))
+	shrinks := testutil.ToFloat64(PoolResizes.WithLabelValues("test-resize", "shrink"))
+
+	m := NewPoolMonitor("test-resize", client, PoolMonitorConfig{
+		InitialSize: 8,
+		AutoResize: &PoolAutoResizeConfig{
+			MinSize: 4, MaxSize: 10, WaitThreshold: 5 * time.Millisecond, SustainedIntervals: 2,
+		},
+	}, zap.NewNop())
+	require.Equal(t, 8, m.Size())
+	require.NoError(t, client.Ping(context.Background()).Err(), "commands pass through the limiter")
+
+	// Pressure must be sustained.
+	m.resize(10, 0, 100*time.Millisecond, 8)
+	assert.Equal(t, 8, m.Size())
+	m.resize(1, 0, time.Millisecond, 8)
+	m.resize(10, 0, 100*time.Millisecond, 8)
+	assert.Equal(t, 8, m.Size(), "interrupted pressure")
+	m.resize(0, 1, 0, 8)
+	assert.Equal(t, 10, m.Size())
+
+	// Capped at the maximum.
+	m.resize(0, 1, 0, 10)
+	m.resize(0, 1, 0, 10)
+	assert.Equal(t, 10, m.Size())
+
+	// Shrinks when idle, down to the minimum.
+	for range 8 {
+		m.resize(0, 0, 0, 1)
+	}
+	assert.Equal(t, 4, m.Size())
+	assert.Equal(t, float64(4), testutil.ToFloat64(PoolSize.WithLabelValues("test-resize")))
+	assert.Equal(t, grows+1, testutil.ToFloat64(PoolResizes.WithLabelValues("test-resize", "grow")))
+	assert.Equal(t, shrinks+4, testutil.ToFloat64(PoolResizes.WithLabelValues("test-resize", "shrink")))
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Identifier keys maintained by a ResourceIndex.
+const (
+	// ResourceIndexGlobalAssetID indexes the resource globalAssetId.
+	ResourceIndexGlobalAssetID = "globalAssetId"
+
+	// ResourceIndexSerialNumber indexes the "serialNumber" resource extension.
+	ResourceIndexSerialNumber = "serialNumber"
+)
+
+const (
+	// resourceIndexKeyPrefix prefixes the Redis hashes mapping identifier value -> resource ID.
+	resourceIndexKeyPrefix = "resources:index:"
+
+	// resourceIndexReverseKeyPrefix prefixes the Redis hashes mapping identifier key -> value
+	// for a single resource, used to drop stale entries on update and delete.
+	resourceIndexReverseKeyPrefix = "resources:index-by-id:"
+)
+
+// IndexedResourceKeys lists the identifier keys maintained by a ResourceIndex.
+var IndexedResourceKeys = []string{ResourceIndexGlobalAssetID, ResourceIndexSerialNumber}
+
+// ErrResourceIndexMiss is returned when an identifier is not present in the index.
+var ErrResourceIndexMiss = errors.New("identifier not found in resource index")
+
+// ResourceIndexEntry holds the indexed identifiers of a single resource.
+type ResourceIndexEntry struct {
+	ResourceID  string
+	Identifiers map[string]string
+}
+
+// ResourceIndex maintains secondary indexes from external resource identifiers
+// (globalAssetId, serial number) to resource IDs, so lookups by those identifiers
+// do not require scanning every resource.
+//
+// The index is a cache of the backend: entries may be stale, so callers must
+// verify the resource returned for a looked-up ID.
+type ResourceIndex interface {
+	// Index records the identifiers of the given resources, replacing any
+	// identifiers previously recorded for them.
+	Index(ctx context.Context, entries ...ResourceIndexEntry) error
+
+	// Remove drops all identifiers recorded for a resource.
+	Remove(ctx context.Context, resourceID string) error
+
+	// Lookup returns the resource ID recorded for an identifier.
+	// Returns ErrResourceIndexMiss if the identifier is not indexed.
+	Lookup(ctx context.Context, key, value string) (string, error)
+}
+
+// ResourceIdentifiers extracts the indexed identifiers of a resource from its
+// globalAssetId and extensions. Empty identifiers are omitted.
+func ResourceIdentifiers(globalAssetID string, extensions map[string]interface{}) map[string]string {
+	identifiers := make(map[string]string, len(IndexedResourceKeys))
+	if globalAssetID != "" {
+		identifiers[ResourceIndexGlobalAssetID] = globalAssetID
+	}
+	if serial, ok := extensions[ResourceIndexSerialNumber].(string); ok && serial != "" {
+		identifiers[ResourceIndexSerialNumber] = serial
+	}
+	return identifiers
+}
+
+// InMemoryResourceIndex implements ResourceIndex in memory.
+type InMemoryResourceIndex struct {
+	mu sync.RWMutex
+	// byKey maps identifier key -> value -> resource ID.
+	byKey map[string]map[string]string
+	// byID maps resource ID -> identifier key -> value.
+	byID map[string]map[string]string
+}
+
+// NewInMemoryResourceIndex creates an empty in-memory resource index.
+func NewInMemoryResourceIndex() *InMemoryResourceIndex {
+	return &InMemoryResourceIndex{
+		byKey: make(map[string]map[string]string),
+		byID:  make(map[string]map[string]string),
+	}
+}
+
+// Index records the identifiers of the given resources.
+func (i *InMemoryResourceIndex) Index(_ context.Context, entries ...ResourceIndexEntry) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, entry := range entries {
+		if entry.ResourceID == "" {
+			return errors.New("resource ID cannot be empty")
+		}
+		i.removeLocked(entry.ResourceID)
+
+		identifiers := make(map[string]string, len(entry.Identifiers))
+		for key, value := range entry.Identifiers {
+			if value == "" {
+				continue
+			}
+			if i.byKey[key] == nil {
+				i.byKey[key] = make(map[string]string)
+			}
+			i.byKey[key][value] = entry.ResourceID
+			identifiers[key] = value
+		}
+		i.byID[entry.ResourceID] = identifiers
+	}
+	return nil
+}
+
+// Remove drops all identifiers recorded for a resource.
+func (i *InMemoryResourceIndex) Remove(_ context.Context, resourceID string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.removeLocked(resourceID)
+	return nil
+}
+
+func (i *InMemoryResourceIndex) removeLocked(resourceID string) {
+	for key, value := range i.byID[resourceID] {
+		if i.byKey[key][value] == resourceID {
+			delete(i.byKey[key], value)
+		}
+	}
+	delete(i.byID, resourceID)
+}
+
+// Lookup returns the resource ID recorded for an identifier.
+func (i *InMemoryResourceIndex) Lookup(_ context.Context, key, value string) (string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	id, ok := i.byKey[key][value]
+	if !ok {
+		return "", ErrResourceIndexMiss
+	}
+	return id, nil
+}
+
+// RedisResourceIndex implements ResourceIndex with Redis hashes so the index is
+// shared across gateway replicas. Each identifier key has a hash mapping values
+// to resource IDs, giving O(1) lookups with HGET.
+type RedisResourceIndex struct {
+	client redis.UniversalClient
+}
+
+// NewRedisResourceIndex creates a Redis-backed resource index.
+func NewRedisResourceIndex(client redis.UniversalClient) *RedisResourceIndex {
+	return &RedisResourceIndex{client: client}
+}
+
+// Index records the identifiers of the given resources.
+func (i *RedisResourceIndex) Index(ctx context.Context, entries ...ResourceIndexEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Load previously recorded identifiers so stale values can be dropped.
+	readPipe := i.client.Pipeline()
+	previous := make([]*redis.MapStringStringCmd, len(entries))
+	for n, entry := range entries {
+		if entry.ResourceID == "" {
+			return errors.New("resource ID cannot be empty")
+		}
+		previous[n] = readPipe.HGetAll(ctx, resourceIndexReverseKeyPrefix+entry.ResourceID)
+	}
+	if _, err := readPipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to read resource index: %w", err)
+	}
+
+	pipe := i.client.TxPipeline()
+	for n, entry := range entries {
+		reverseKey := resourceIndexReverseKeyPrefix + entry.ResourceID
+		for key, value := range previous[n].Val() {
+			if entry.Identifiers[key] != value {
+				pipe.HDel(ctx, resourceIndexKeyPrefix+key, value)
+				pipe.HDel(ctx, reverseKey, key)
+			}
+		}
+		for key, value := range entry.Identifiers {
+			if value == "" {
+				continue
+			}
+			pipe.HSet(ctx, resourceIndexKeyPrefix+key, value, entry.ResourceID)
+			pipe.HSet(ctx, reverseKey, key, value)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update resource index: %w", err)
+	}
+	return nil
+}
+
+// Remove drops all identifiers recorded for a resource.
+func (i *RedisResourceIndex) Remove(ctx context.Context, resourceID string) error {
+	reverseKey := resourceIndexReverseKeyPrefix + resourceID
+	identifiers, err := i.client.HGetAll(ctx, reverseKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read resource index: %w", err)
+	}
+
+	pipe := i.client.TxPipeline()
+	for key, value := range identifiers {
+		pipe.HDel(ctx, resourceIndexKeyPrefix+key, value)
+	}
+	pipe.Del(ctx, reverseKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove resource from index: %w", err)
+	}
+	return nil
+}
+
+// Lookup returns the resource ID recorded for an identifier.
+func (i *RedisResourceIndex) Lookup(ctx context.Context, key, value string) (string, error) {
+	id, err := i.client.HGet(ctx, resourceIndexKeyPrefix+key, value).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrResourceIndexMiss
+		}
+		return "", fmt.Errorf("failed to look up resource index: %w", err)
+	}
+	return id, nil
+}
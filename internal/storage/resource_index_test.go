@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestResourceIdentifiers(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		storage.ResourceIndexGlobalAssetID: "urn:o-ran:node:1",
+		storage.ResourceIndexSerialNumber:  "SN-1",
+	}, storage.ResourceIdentifiers("urn:o-ran:node:1", map[string]interface{}{"serialNumber": "SN-1"}))
+
+	assert.Empty(t, storage.ResourceIdentifiers("", map[string]interface{}{"serialNumber": 42}))
+}
+
+func TestResourceIndexes(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	indexes := map[string]storage.ResourceIndex{
+		"in-memory": storage.NewInMemoryResourceIndex(),
+		"redis":     storage.NewRedisResourceIndex(redisStore.Client),
+	}
+
+	for name, index := range indexes {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := index.Lookup(ctx, storage.ResourceIndexGlobalAssetID, "urn:o-ran:node:1")
+			require.ErrorIs(t, err, storage.ErrResourceIndexMiss)
+
+			require.NoError(t, index.Index(ctx,
+				storage.ResourceIndexEntry{ResourceID: "res-1", Identifiers: map[string]string{
+					storage.ResourceIndexGlobalAssetID: "urn:o-ran:node:1",
+					storage.ResourceIndexSerialNumber:  "SN-1",
+				}},
+				storage.ResourceIndexEntry{ResourceID: "res-2", Identifiers: map[string]string{
+					storage.ResourceIndexGlobalAssetID: "urn:o-ran:node:2",
+				}},
+			))
+
+			id, err := index.Lookup(ctx, storage.ResourceIndexGlobalAssetID, "urn:o-ran:node:1")
+			require.NoError(t, err)
+			assert.Equal(t, "res-1", id)
+			id, err = index.Lookup(ctx, storage.ResourceIndexSerialNumber, "SN-1")
+			require.NoError(t, err)
+			assert.Equal(t, "res-1", id)
+
+			// Re-indexing replaces previous identifiers.
+			require.NoError(t, index.Index(ctx, storage.ResourceIndexEntry{
+				ResourceID:  "res-1",
+				Identifiers: map[string]string{storage.ResourceIndexGlobalAssetID: "urn:o-ran:node:1b"},
+			}))
+			_, err = index.Lookup(ctx, storage.ResourceIndexGlobalAssetID, "urn:o-ran:node:1")
+			require.ErrorIs(t, err, storage.ErrResourceIndexMiss)
+			_, err = index.Lookup(ctx, storage.ResourceIndexSerialNumber, "SN-1")
+			require.ErrorIs(t, err, storage.ErrResourceIndexMiss)
+			id, err = index.Lookup(ctx, storage.ResourceIndexGlobalAssetID, "urn:o-ran:node:1b")
+			require.NoError(t, err)
+			assert.Equal(t, "res-1", id)
+
+			require.NoError(t, index.Remove(ctx, "res-2"))
+			_, err = index.Lookup(ctx, storage.ResourceIndexGlobalAssetID, "urn:o-ran:node:2")
+			require.ErrorIs(t, err, storage.ErrResourceIndexMiss)
+		})
+	}
+}
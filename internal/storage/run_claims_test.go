@@ -0,0 +1,44 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestRunClaims(t *testing.T) {
+	redisStore, mr := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	claims := map[string]storage.RunClaims{
+		"in-memory": storage.NewInMemoryRunClaims(),
+		"redis":     storage.NewRedisRunClaims(redisStore.Client),
+	}
+	for name, c := range claims {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			claimed, err := c.Claim(ctx, "export:1", 50*time.Millisecond)
+			require.NoError(t, err)
+			assert.True(t, claimed)
+
+			claimed, err = c.Claim(ctx, "export:1", 50*time.Millisecond)
+			require.NoError(t, err)
+			assert.False(t, claimed, "the run is already claimed")
+
+			claimed, err = c.Claim(ctx, "export:2", 50*time.Millisecond)
+			require.NoError(t, err)
+			assert.True(t, claimed, "other runs are independent")
+
+			mr.FastForward(time.Second)
+			time.Sleep(60 * time.Millisecond)
+			claimed, err = c.Claim(ctx, "export:1", 50*time.Millisecond)
+			require.NoError(t, err)
+			assert.True(t, claimed, "expired claims can be claimed again")
+		})
+	}
+}
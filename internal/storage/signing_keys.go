@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// signingKeysKey is the Redis key holding the rotated webhook signing keys.
+const signingKeysKey = "o2ims:webhook:signing-keys"
+
+// ErrSigningKeysNotSet is returned when the webhook signing secret was never
+// rotated, so the configured secret is in effect.
+var ErrSigningKeysNotSet = errors.New("webhook signing keys not set")
+
+// WebhookSigningKeys holds the webhook HMAC secrets after a rotation. Until
+// ActivatesAt, notifications are signed with both Current and Next so
+// subscribers can switch to Next at their own pace; from then on Next
+// replaces Current.
+type WebhookSigningKeys struct {
+	Current     string    `json:"current,omitempty"`
+	Next        string    `json:"next,omitempty"`
+	ActivatesAt time.Time `json:"activatesAt,omitempty"`
+	RotatedAt   time.Time `json:"rotatedAt"`
+}
+
+// Effective returns the secrets that sign notifications at now: the primary
+// secret and, while a rotation is pending, the next secret.
+func (k *WebhookSigningKeys) Effective(now time.Time) (primary, next string) {
+	if k.Next == "" {
+		return k.Current, ""
+	}
+	if !now.Before(k.ActivatesAt) {
+		return k.Next, ""
+	}
+	return k.Current, k.Next
+}
+
+// SecretFingerprint identifies a secret without revealing it: the first 16
+// hex characters of its SHA-256 hash. It is empty for an empty secret.
+func SecretFingerprint(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// SigningKeyStore persists the webhook signing keys shared by all replicas.
+type SigningKeyStore interface {
+	// Get returns the signing keys.
+	// Returns ErrSigningKeysNotSet if no rotation has been stored.
+	Get(ctx context.Context) (*WebhookSigningKeys, error)
+
+	// Set replaces the signing keys.
+	Set(ctx context.Context, keys *WebhookSigningKeys) error
+}
+
+// InMemorySigningKeyStore implements SigningKeyStore in memory.
+type InMemorySigningKeyStore struct {
+	mu   sync.RWMutex
+	keys *WebhookSigningKeys
+}
+
+// NewInMemorySigningKeyStore creates an empty in-memory signing key store.
+func NewInMemorySigningKeyStore() *InMemorySigningKeyStore {
+	return &InMemorySigningKeyStore{}
+}
+
+// Get returns a copy of the signing keys.
+func (s *InMemorySigningKeyStore) Get(_ context.Context) (*WebhookSigningKeys, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.keys == nil {
+		return nil, ErrSigningKeysNotSet
+	}
+	keys := *s.keys
+	return &keys, nil
+}
+
+// Set replaces the signing keys.
+func (s *InMemorySigningKeyStore) Set(_ context.Context, keys *WebhookSigningKeys) error {
+	if keys == nil {
+		return errors.New("signing keys cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *keys
+	s.keys = &stored
+	return nil
+}
+
+// RedisSigningKeyStore implements SigningKeyStore in Redis so a rotation on
+// one replica takes effect on the webhook workers of every replica.
+type RedisSigningKeyStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisSigningKeyStore creates a Redis-backed signing key store.
+func NewRedisSigningKeyStore(client redis.UniversalClient) *RedisSigningKeyStore {
+	return &RedisSigningKeyStore{client: client}
+}
+
+// Get returns the signing keys.
+func (s *RedisSigningKeyStore) Get(ctx context.Context) (*WebhookSigningKeys, error) {
+	data, err := s.client.Get(ctx, signingKeysKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSigningKeysNotSet
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook signing keys: %w", err)
+	}
+
+	var keys WebhookSigningKeys
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook signing keys: %w", err)
+	}
+	return &keys, nil
+}
+
+// Set replaces the signing keys.
+func (s *RedisSigningKeyStore) Set(ctx context.Context, keys *WebhookSigningKeys) error {
+	if keys == nil {
+		return errors.New("signing keys cannot be nil")
+	}
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook signing keys: %w", err)
+	}
+	if err := s.client.Set(ctx, signingKeysKey, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to store webhook signing keys: %w", err)
+	}
+	return nil
+}
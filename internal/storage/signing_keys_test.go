@@ -0,0 +1,79 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestWebhookSigningKeys_Effective(t *testing.T) {
+	activatesAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	keys := &storage.WebhookSigningKeys{Current: "old", Next: "new", ActivatesAt: activatesAt}
+
+	primary, next := keys.Effective(activatesAt.Add(-time.Second))
+	assert.Equal(t, "old", primary)
+	assert.Equal(t, "new", next)
+
+	primary, next = keys.Effective(activatesAt)
+	assert.Equal(t, "new", primary)
+	assert.Empty(t, next)
+
+	settled := &storage.WebhookSigningKeys{Current: "only"}
+	primary, next = settled.Effective(activatesAt)
+	assert.Equal(t, "only", primary)
+	assert.Empty(t, next)
+}
+
+func TestSecretFingerprint(t *testing.T) {
+	assert.Empty(t, storage.SecretFingerprint(""))
+	assert.Len(t, storage.SecretFingerprint("secret"), 16)
+	assert.Equal(t, storage.SecretFingerprint("secret"), storage.SecretFingerprint("secret"))
+	assert.NotEqual(t, storage.SecretFingerprint("secret"), storage.SecretFingerprint("other"))
+}
+
+func TestSigningKeyStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.SigningKeyStore{
+		"in-memory": storage.NewInMemorySigningKeyStore(),
+		"redis":     storage.NewRedisSigningKeyStore(redisStore.Client),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := store.Get(ctx)
+			require.ErrorIs(t, err, storage.ErrSigningKeysNotSet)
+			require.Error(t, store.Set(ctx, nil))
+
+			rotatedAt := time.Now().UTC().Truncate(time.Second)
+			keys := &storage.WebhookSigningKeys{
+				Current:     "old",
+				Next:        "new",
+				ActivatesAt: rotatedAt.Add(time.Hour),
+				RotatedAt:   rotatedAt,
+			}
+			require.NoError(t, store.Set(ctx, keys))
+
+			got, err := store.Get(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "old", got.Current)
+			assert.Equal(t, "new", got.Next)
+			assert.True(t, keys.ActivatesAt.Equal(got.ActivatesAt))
+			assert.True(t, keys.RotatedAt.Equal(got.RotatedAt))
+
+			// Returned keys are copies.
+			got.Current = "changed"
+			again, err := store.Get(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "old", again.Current)
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys of the delivery statistics. Each subscription has a hash with
+// the counters of every bucket of the window, in fields "<bucket>:<counter>".
+const (
+	deliverabilityKeyPrefix        = "o2ims:deliverability:"
+	deliverabilitySubscriptionsKey = "o2ims:deliverability:subscriptions"
+)
+
+// Counters of a delivery bucket.
+const (
+	deliverabilityAttempts  = "attempts"
+	deliverabilitySuccesses = "successes"
+	deliverabilityLatencyMs = "latency_ms"
+	deliverabilityTLSIssues = "tls_issues"
+)
+
+// deliverabilityBuckets is the number of buckets a window is split into; the
+// window slides by one bucket at a time.
+const deliverabilityBuckets = 60
+
+// DeliverabilitySlowLatency is the mean callback latency at which the
+// latency share of the deliverability score drops to zero.
+const DeliverabilitySlowLatency = 5 * time.Second
+
+// Deliverability statuses, derived from the score.
+const (
+	DeliverabilityHealthy  = "healthy"
+	DeliverabilityDegraded = "degraded"
+	DeliverabilityFailing  = "failing"
+)
+
+// DeliveryAttempt is the outcome of one POST of a notification to a callback.
+type DeliveryAttempt struct {
+	// At is when the attempt was made.
+	At time.Time
+
+	// Success is true when the callback answered with a 2xx status.
+	Success bool
+
+	// Latency is how long the callback took to answer or fail.
+	Latency time.Duration
+
+	// TLSIssue is true when the attempt failed in the TLS handshake, e.g.
+	// because of an expired or untrusted certificate.
+	TLSIssue bool
+}
+
+// SubscriptionDeliverability summarises the delivery attempts to a
+// subscription's callback over the deliverability window.
+type SubscriptionDeliverability struct {
+	// Attempts is the number of delivery attempts, retries included.
+	Attempts int64 `json:"attempts"`
+
+	// SuccessRate is the share of attempts that succeeded (0-1).
+	SuccessRate float64 `json:"successRate"`
+
+	// MeanLatencyMs is the mean latency of the attempts in milliseconds.
+	MeanLatencyMs float64 `json:"meanLatencyMs"`
+
+	// TLSIssues is the number of attempts that failed in the TLS handshake.
+	TLSIssues int64 `json:"tlsIssues"`
+
+	// Score rates the callback from 0 (unreachable) to 100: 70% success
+	// rate, 15% share of attempts without TLS issues and 15% latency, which
+	// scores zero at DeliverabilitySlowLatency.
+	Score float64 `json:"score"`
+
+	// Status is healthy (score >= 90), degraded (>= 60) or failing.
+	Status string `json:"status"`
+}
+
+// deliveryCounters are the raw counters of a subscription or bucket.
+type deliveryCounters struct {
+	attempts  int64
+	successes int64
+	latencyMs int64
+	tlsIssues int64
+}
+
+// add records an attempt.
+func (c *deliveryCounters) add(attempt DeliveryAttempt) {
+	c.attempts++
+	if attempt.Success {
+		c.successes++
+	}
+	c.latencyMs += attempt.Latency.Milliseconds()
+	if attempt.TLSIssue {
+		c.tlsIssues++
+	}
+}
+
+// merge adds the counters of other.
+func (c *deliveryCounters) merge(other *deliveryCounters) {
+	c.attempts += other.attempts
+	c.successes += other.successes
+	c.latencyMs += other.latencyMs
+	c.tlsIssues += other.tlsIssues
+}
+
+// deliverability derives the deliverability from the counters. It returns
+// nil when there were no attempts.
+func (c *deliveryCounters) deliverability() *SubscriptionDeliverability {
+	if c.attempts == 0 {
+		return nil
+	}
+	attempts := float64(c.attempts)
+	d := &SubscriptionDeliverability{
+		Attempts:      c.attempts,
+		SuccessRate:   float64(c.successes) / attempts,
+		MeanLatencyMs: float64(c.latencyMs) / attempts,
+		TLSIssues:     c.tlsIssues,
+	}
+
+	latencyPenalty := min(1, d.MeanLatencyMs/float64(DeliverabilitySlowLatency.Milliseconds()))
+	tlsIssueRate := float64(c.tlsIssues) / attempts
+	d.Score = 100 * (0.7*d.SuccessRate + 0.15*(1-tlsIssueRate) + 0.15*(1-latencyPenalty))
+
+	switch {
+	case d.Score >= 90:
+		d.Status = DeliverabilityHealthy
+	case d.Score >= 60:
+		d.Status = DeliverabilityDegraded
+	default:
+		d.Status = DeliverabilityFailing
+	}
+	return d
+}
+
+// DeliverabilityStore records the outcome of every webhook delivery attempt
+// and scores the deliverability of each subscription's callback over a
+// sliding window, so operators can contact consumers whose endpoints fail
+// before notifications end up in the dead-letter queue.
+type DeliverabilityStore interface {
+	// RecordAttempt records a delivery attempt to a subscription's callback.
+	RecordAttempt(ctx context.Context, subscriptionID string, attempt DeliveryAttempt) error
+
+	// Get returns the deliverability of a subscription over the window
+	// ending at now, or nil if there were no attempts in the window.
+	Get(ctx context.Context, subscriptionID string, now time.Time) (*SubscriptionDeliverability, error)
+
+	// Snapshot returns the deliverability of every subscription with
+	// attempts in the window ending at now, by subscription ID.
+	Snapshot(ctx context.Context, now time.Time) (map[string]*SubscriptionDeliverability, error)
+
+	// Delete forgets the delivery statistics of a deleted subscription.
+	Delete(ctx context.Context, subscriptionID string) error
+
+	// Window returns the length of the sliding window.
+	Window() time.Duration
+}
+
+// deliverabilityWindow splits a window into buckets.
+type deliverabilityWindow struct {
+	window time.Duration
+	bucket time.Duration
+}
+
+// newDeliverabilityWindow returns the buckets of window; windows shorter
+// than a minute are extended to a minute.
+func newDeliverabilityWindow(window time.Duration) deliverabilityWindow {
+	window = max(window, time.Minute)
+	return deliverabilityWindow{window: window, bucket: window / deliverabilityBuckets}
+}
+
+// bucketOf returns the bucket an instant falls in.
+func (w deliverabilityWindow) bucketOf(at time.Time) int64 {
+	return at.UnixNano() / int64(w.bucket)
+}
+
+// oldest returns the oldest bucket of the window ending at now.
+func (w deliverabilityWindow) oldest(now time.Time) int64 {
+	return w.bucketOf(now) - deliverabilityBuckets + 1
+}
+
+// InMemoryDeliverabilityStore implements DeliverabilityStore in memory.
+type InMemoryDeliverabilityStore struct {
+	window  deliverabilityWindow
+	mu      sync.Mutex
+	buckets map[string]map[int64]*deliveryCounters
+}
+
+// NewInMemoryDeliverabilityStore creates an empty in-memory deliverability
+// store with the given sliding window.
+func NewInMemoryDeliverabilityStore(window time.Duration) *InMemoryDeliverabilityStore {
+	return &InMemoryDeliverabilityStore{
+		window:  newDeliverabilityWindow(window),
+		buckets: make(map[string]map[int64]*deliveryCounters),
+	}
+}
+
+// RecordAttempt records a delivery attempt.
+func (s *InMemoryDeliverabilityStore) RecordAttempt(
+	_ context.Context,
+	subscriptionID string,
+	attempt DeliveryAttempt,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buckets, ok := s.buckets[subscriptionID]
+	if !ok {
+		buckets = make(map[int64]*deliveryCounters)
+		s.buckets[subscriptionID] = buckets
+	}
+	oldest := s.window.oldest(attempt.At)
+	for bucket := range buckets {
+		if bucket < oldest {
+			delete(buckets, bucket)
+		}
+	}
+	bucket := s.window.bucketOf(attempt.At)
+	if buckets[bucket] == nil {
+		buckets[bucket] = &deliveryCounters{}
+	}
+	buckets[bucket].add(attempt)
+	return nil
+}
+
+// Get returns the deliverability of a subscription.
+func (s *InMemoryDeliverabilityStore) Get(
+	_ context.Context,
+	subscriptionID string,
+	now time.Time,
+) (*SubscriptionDeliverability, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.deliverability(subscriptionID, now), nil
+}
+
+// Snapshot returns the deliverability of every subscription.
+func (s *InMemoryDeliverabilityStore) Snapshot(
+	_ context.Context,
+	now time.Time,
+) (map[string]*SubscriptionDeliverability, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(map[string]*SubscriptionDeliverability, len(s.buckets))
+	for id := range s.buckets {
+		if d := s.deliverability(id, now); d != nil {
+			snapshot[id] = d
+		}
+	}
+	return snapshot, nil
+}
+
+// deliverability sums the buckets of a subscription in the window ending at now.
+func (s *InMemoryDeliverabilityStore) deliverability(subscriptionID string, now time.Time) *SubscriptionDeliverability {
+	total := &deliveryCounters{}
+	oldest, newest := s.window.oldest(now), s.window.bucketOf(now)
+	for bucket, counters := range s.buckets[subscriptionID] {
+		if bucket >= oldest && bucket <= newest {
+			total.merge(counters)
+		}
+	}
+	return total.deliverability()
+}
+
+// Delete forgets the delivery statistics of a subscription.
+func (s *InMemoryDeliverabilityStore) Delete(_ context.Context, subscriptionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.buckets, subscriptionID)
+	return nil
+}
+
+// Window returns the length of the sliding window.
+func (s *InMemoryDeliverabilityStore) Window() time.Duration {
+	return s.window.window
+}
+
+// RedisDeliverabilityStore implements DeliverabilityStore in Redis so the
+// attempts of the webhook workers of every replica are scored together.
+type RedisDeliverabilityStore struct {
+	client redis.UniversalClient
+	window deliverabilityWindow
+}
+
+// NewRedisDeliverabilityStore creates a Redis-backed deliverability store
+// with the given sliding window.
+func NewRedisDeliverabilityStore(client redis.UniversalClient, window time.Duration) *RedisDeliverabilityStore {
+	return &RedisDeliverabilityStore{client: client, window: newDeliverabilityWindow(window)}
+}
+
+// RecordAttempt records a delivery attempt.
+func (s *RedisDeliverabilityStore) RecordAttempt(
+	ctx context.Context,
+	subscriptionID string,
+	attempt DeliveryAttempt,
+) error {
+	key := deliverabilityKeyPrefix + subscriptionID
+	field := strconv.FormatInt(s.window.bucketOf(attempt.At), 10) + ":"
+
+	pipe := s.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, field+deliverabilityAttempts, 1)
+	if attempt.Success {
+		pipe.HIncrBy(ctx, key, field+deliverabilitySuccesses, 1)
+	}
+	pipe.HIncrBy(ctx, key, field+deliverabilityLatencyMs, attempt.Latency.Milliseconds())
+	if attempt.TLSIssue {
+		pipe.HIncrBy(ctx, key, field+deliverabilityTLSIssues, 1)
+	}
+	// Subscriptions without attempts for a whole window are forgotten.
+	pipe.Expire(ctx, key, s.window.window)
+	pipe.SAdd(ctx, deliverabilitySubscriptionsKey, subscriptionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+	return nil
+}
+
+// Get returns the deliverability of a subscription.
+func (s *RedisDeliverabilityStore) Get(
+	ctx context.Context,
+	subscriptionID string,
+	now time.Time,
+) (*SubscriptionDeliverability, error) {
+	fields, err := s.client.HGetAll(ctx, deliverabilityKeyPrefix+subscriptionID).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get delivery statistics: %w", err)
+	}
+	return s.sum(ctx, subscriptionID, fields, now).deliverability(), nil
+}
+
+// Snapshot returns the deliverability of every subscription.
+func (s *RedisDeliverabilityStore) Snapshot(
+	ctx context.Context,
+	now time.Time,
+) (map[string]*SubscriptionDeliverability, error) {
+	ids, err := s.client.SMembers(ctx, deliverabilitySubscriptionsKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to list delivery statistics: %w", err)
+	}
+
+	pipe := s.client.Pipeline()
+	results := make(map[string]*redis.MapStringStringCmd, len(ids))
+	for _, id := range ids {
+		results[id] = pipe.HGetAll(ctx, deliverabilityKeyPrefix+id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get delivery statistics: %w", err)
+	}
+
+	snapshot := make(map[string]*SubscriptionDeliverability, len(ids))
+	var expired []interface{}
+	for id, result := range results {
+		fields := result.Val()
+		if len(fields) == 0 {
+			expired = append(expired, id)
+			continue
+		}
+		if d := s.sum(ctx, id, fields, now).deliverability(); d != nil {
+			snapshot[id] = d
+		}
+	}
+	if len(expired) > 0 {
+		// Best effort: forget subscriptions whose statistics expired.
+		_ = s.client.SRem(ctx, deliverabilitySubscriptionsKey, expired...).Err()
+	}
+	return snapshot, nil
+}
+
+// sum adds up the buckets of the window ending at now and drops the fields
+// of buckets that left the window.
+func (s *RedisDeliverabilityStore) sum(
+	ctx context.Context,
+	subscriptionID string,
+	fields map[string]string,
+	now time.Time,
+) *deliveryCounters {
+	total := &deliveryCounters{}
+	oldest, newest := s.window.oldest(now), s.window.bucketOf(now)
+	var stale []string
+	for field, raw := range fields {
+		bucketStr, counter, ok := strings.Cut(field, ":")
+		bucket, err := strconv.ParseInt(bucketStr, 10, 64)
+		if !ok || err != nil {
+			continue
+		}
+		if bucket < oldest {
+			stale = append(stale, field)
+			continue
+		}
+		if bucket > newest {
+			continue
+		}
+		value, _ := strconv.ParseInt(raw, 10, 64)
+		switch counter {
+		case deliverabilityAttempts:
+			total.attempts += value
+		case deliverabilitySuccesses:
+			total.successes += value
+		case deliverabilityLatencyMs:
+			total.latencyMs += value
+		case deliverabilityTLSIssues:
+			total.tlsIssues += value
+		}
+	}
+	if len(stale) > 0 {
+		// Best effort: the key expires anyway once the subscription is idle.
+		_ = s.client.HDel(ctx, deliverabilityKeyPrefix+subscriptionID, stale...).Err()
+	}
+	return total
+}
+
+// Delete forgets the delivery statistics of a subscription.
+func (s *RedisDeliverabilityStore) Delete(ctx context.Context, subscriptionID string) error {
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, deliverabilityKeyPrefix+subscriptionID)
+	pipe.SRem(ctx, deliverabilitySubscriptionsKey, subscriptionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete delivery statistics: %w", err)
+	}
+	return nil
+}
+
+// Window returns the length of the sliding window.
+func (s *RedisDeliverabilityStore) Window() time.Duration {
+	return s.window.window
+}
@@ -0,0 +1,89 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestDeliverabilityStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.DeliverabilityStore{
+		"in-memory": storage.NewInMemoryDeliverabilityStore(time.Hour),
+		"redis":     storage.NewRedisDeliverabilityStore(redisStore.Client, time.Hour),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now()
+			assert.Equal(t, time.Hour, store.Window())
+
+			d, err := store.Get(ctx, "healthy", now)
+			require.NoError(t, err)
+			assert.Nil(t, d, "no attempts yet")
+
+			// "healthy" answers fast; "failing" fails half its attempts,
+			// one of them with a TLS error, and answers slowly.
+			for i := 0; i < 4; i++ {
+				require.NoError(t, store.RecordAttempt(ctx, "healthy", storage.DeliveryAttempt{
+					At: now, Success: true, Latency: 100 * time.Millisecond,
+				}))
+			}
+			require.NoError(t, store.RecordAttempt(ctx, "failing", storage.DeliveryAttempt{
+				At: now, Success: true, Latency: 4 * time.Second,
+			}))
+			require.NoError(t, store.RecordAttempt(ctx, "failing", storage.DeliveryAttempt{
+				At: now, Latency: 6 * time.Second,
+			}))
+			require.NoError(t, store.RecordAttempt(ctx, "failing", storage.DeliveryAttempt{
+				At: now, Latency: 50 * time.Millisecond, TLSIssue: true,
+			}))
+			require.NoError(t, store.RecordAttempt(ctx, "failing", storage.DeliveryAttempt{
+				At: now, Success: true, Latency: 5950 * time.Millisecond,
+			}))
+			// Attempts that left the window do not count.
+			require.NoError(t, store.RecordAttempt(ctx, "failing", storage.DeliveryAttempt{
+				At: now.Add(-2 * time.Hour), Success: true,
+			}))
+
+			healthy, err := store.Get(ctx, "healthy", now)
+			require.NoError(t, err)
+			require.NotNil(t, healthy)
+			assert.Equal(t, int64(4), healthy.Attempts)
+			assert.InDelta(t, 1.0, healthy.SuccessRate, 1e-9)
+			assert.InDelta(t, 100.0, healthy.MeanLatencyMs, 1e-9)
+			assert.InDelta(t, 99.7, healthy.Score, 1e-9)
+			assert.Equal(t, storage.DeliverabilityHealthy, healthy.Status)
+
+			snapshot, err := store.Snapshot(ctx, now)
+			require.NoError(t, err)
+			require.Len(t, snapshot, 2)
+			failing := snapshot["failing"]
+			require.NotNil(t, failing)
+			assert.Equal(t, int64(4), failing.Attempts)
+			assert.InDelta(t, 0.5, failing.SuccessRate, 1e-9)
+			assert.InDelta(t, 4000.0, failing.MeanLatencyMs, 1e-9)
+			assert.Equal(t, int64(1), failing.TLSIssues)
+			// 70*0.5 + 15*0.75 + 15*0.2
+			assert.InDelta(t, 49.25, failing.Score, 1e-9)
+			assert.Equal(t, storage.DeliverabilityFailing, failing.Status)
+
+			later, err := store.Snapshot(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Empty(t, later, "the window slid past all attempts")
+
+			require.NoError(t, store.Delete(ctx, "healthy"))
+			d, err = store.Get(ctx, "healthy", now)
+			require.NoError(t, err)
+			assert.Nil(t, d)
+		})
+	}
+}
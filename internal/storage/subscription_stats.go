@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys of the subscription match statistics.
+const (
+	subscriptionStatsEventsKey  = "o2ims:subscription-stats:events"
+	subscriptionStatsMatchedKey = "o2ims:subscription-stats:matched"
+	subscriptionStatsFanOutKey  = "o2ims:subscription-stats:fanout"
+	subscriptionStatsLastKey    = "o2ims:subscription-stats:last"
+)
+
+// SubscriptionStats are the event matching statistics of a subscription.
+type SubscriptionStats struct {
+	// MatchedEvents is the number of events the subscription's filter matched.
+	MatchedEvents int64 `json:"matchedEvents"`
+
+	// MatchRatio is the share of all processed events the filter matched
+	// (0-1); a ratio of 1 means the filter selects everything.
+	MatchRatio float64 `json:"matchRatio"`
+
+	// AverageFanOut is the average number of subscriptions that matched the
+	// same events as this one.
+	AverageFanOut float64 `json:"averageFanOut"`
+
+	// LastMatchedAt is when the filter last matched an event.
+	LastMatchedAt *time.Time `json:"lastMatchedAt,omitempty"`
+}
+
+// SubscriptionStatsSnapshot holds the match statistics of all subscriptions.
+type SubscriptionStatsSnapshot struct {
+	// TotalEvents is the number of events processed.
+	TotalEvents int64
+
+	// Subscriptions holds the statistics of each subscription that matched
+	// at least one event, by subscription ID.
+	Subscriptions map[string]*SubscriptionStats
+}
+
+// For returns the statistics of a subscription; subscriptions that never
+// matched an event get zero statistics.
+func (s *SubscriptionStatsSnapshot) For(subscriptionID string) *SubscriptionStats {
+	if stats, ok := s.Subscriptions[subscriptionID]; ok {
+		return stats
+	}
+	return &SubscriptionStats{}
+}
+
+// SubscriptionStatsStore records which subscriptions match each processed
+// event, so operators can find subscriptions that match huge event volumes.
+type SubscriptionStatsStore interface {
+	// RecordEvent records an event processed at the given time and the IDs
+	// of the subscriptions it matched (possibly none).
+	RecordEvent(ctx context.Context, matched []string, at time.Time) error
+
+	// Snapshot returns the statistics of all subscriptions.
+	Snapshot(ctx context.Context) (*SubscriptionStatsSnapshot, error)
+
+	// Delete forgets the statistics of a deleted subscription.
+	Delete(ctx context.Context, subscriptionID string) error
+}
+
+// subscriptionCounters are the raw counters of a subscription.
+type subscriptionCounters struct {
+	matched int64
+	fanOut  int64
+	last    time.Time
+}
+
+// stats derives the statistics of a subscription from its counters.
+func (c *subscriptionCounters) stats(totalEvents int64) *SubscriptionStats {
+	stats := &SubscriptionStats{MatchedEvents: c.matched}
+	if totalEvents > 0 {
+		stats.MatchRatio = float64(c.matched) / float64(totalEvents)
+	}
+	if c.matched > 0 {
+		stats.AverageFanOut = float64(c.fanOut) / float64(c.matched)
+	}
+	if !c.last.IsZero() {
+		last := c.last
+		stats.LastMatchedAt = &last
+	}
+	return stats
+}
+
+// InMemorySubscriptionStatsStore implements SubscriptionStatsStore in memory.
+type InMemorySubscriptionStatsStore struct {
+	mu       sync.Mutex
+	events   int64
+	counters map[string]*subscriptionCounters
+}
+
+// NewInMemorySubscriptionStatsStore creates an empty in-memory statistics store.
+func NewInMemorySubscriptionStatsStore() *InMemorySubscriptionStatsStore {
+	return &InMemorySubscriptionStatsStore{counters: make(map[string]*subscriptionCounters)}
+}
+
+// RecordEvent records a processed event and the subscriptions it matched.
+func (s *InMemorySubscriptionStatsStore) RecordEvent(_ context.Context, matched []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events++
+	for _, id := range matched {
+		c, ok := s.counters[id]
+		if !ok {
+			c = &subscriptionCounters{}
+			s.counters[id] = c
+		}
+		c.matched++
+		c.fanOut += int64(len(matched))
+		c.last = at
+	}
+	return nil
+}
+
+// Snapshot returns the statistics of all subscriptions.
+func (s *InMemorySubscriptionStatsStore) Snapshot(_ context.Context) (*SubscriptionStatsSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := &SubscriptionStatsSnapshot{
+		TotalEvents:   s.events,
+		Subscriptions: make(map[string]*SubscriptionStats, len(s.counters)),
+	}
+	for id, c := range s.counters {
+		snapshot.Subscriptions[id] = c.stats(s.events)
+	}
+	return snapshot, nil
+}
+
+// Delete forgets the statistics of a subscription.
+func (s *InMemorySubscriptionStatsStore) Delete(_ context.Context, subscriptionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, subscriptionID)
+	return nil
+}
+
+// RedisSubscriptionStatsStore implements SubscriptionStatsStore in Redis so
+// the statistics recorded by the notification controller are visible to
+// every replica.
+type RedisSubscriptionStatsStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisSubscriptionStatsStore creates a Redis-backed statistics store.
+func NewRedisSubscriptionStatsStore(client redis.UniversalClient) *RedisSubscriptionStatsStore {
+	return &RedisSubscriptionStatsStore{client: client}
+}
+
+// RecordEvent records a processed event and the subscriptions it matched.
+func (s *RedisSubscriptionStatsStore) RecordEvent(ctx context.Context, matched []string, at time.Time) error {
+	pipe := s.client.TxPipeline()
+	pipe.Incr(ctx, subscriptionStatsEventsKey)
+	for _, id := range matched {
+		pipe.HIncrBy(ctx, subscriptionStatsMatchedKey, id, 1)
+		pipe.HIncrBy(ctx, subscriptionStatsFanOutKey, id, int64(len(matched)))
+		pipe.HSet(ctx, subscriptionStatsLastKey, id, at.UnixMilli())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record subscription matches: %w", err)
+	}
+	return nil
+}
+
+// Snapshot returns the statistics of all subscriptions.
+func (s *RedisSubscriptionStatsStore) Snapshot(ctx context.Context) (*SubscriptionStatsSnapshot, error) {
+	pipe := s.client.Pipeline()
+	events := pipe.Get(ctx, subscriptionStatsEventsKey)
+	matched := pipe.HGetAll(ctx, subscriptionStatsMatchedKey)
+	fanOut := pipe.HGetAll(ctx, subscriptionStatsFanOutKey)
+	last := pipe.HGetAll(ctx, subscriptionStatsLastKey)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to get subscription statistics: %w", err)
+	}
+
+	total, _ := events.Int64()
+	snapshot := &SubscriptionStatsSnapshot{
+		TotalEvents:   total,
+		Subscriptions: make(map[string]*SubscriptionStats, len(matched.Val())),
+	}
+	for id, raw := range matched.Val() {
+		c := &subscriptionCounters{}
+		c.matched, _ = strconv.ParseInt(raw, 10, 64)
+		c.fanOut, _ = strconv.ParseInt(fanOut.Val()[id], 10, 64)
+		if ms, err := strconv.ParseInt(last.Val()[id], 10, 64); err == nil {
+			c.last = time.UnixMilli(ms).UTC()
+		}
+		snapshot.Subscriptions[id] = c.stats(total)
+	}
+	return snapshot, nil
+}
+
+// Delete forgets the statistics of a subscription.
+func (s *RedisSubscriptionStatsStore) Delete(ctx context.Context, subscriptionID string) error {
+	pipe := s.client.TxPipeline()
+	pipe.HDel(ctx, subscriptionStatsMatchedKey, subscriptionID)
+	pipe.HDel(ctx, subscriptionStatsFanOutKey, subscriptionID)
+	pipe.HDel(ctx, subscriptionStatsLastKey, subscriptionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete subscription statistics: %w", err)
+	}
+	return nil
+}
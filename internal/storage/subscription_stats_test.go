@@ -0,0 +1,63 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestSubscriptionStatsStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.SubscriptionStatsStore{
+		"in-memory": storage.NewInMemorySubscriptionStatsStore(),
+		"redis":     storage.NewRedisSubscriptionStatsStore(redisStore.Client),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			at := time.Now().UTC().Truncate(time.Millisecond)
+
+			empty, err := store.Snapshot(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, empty.TotalEvents)
+			assert.Zero(t, empty.For("broad").MatchedEvents)
+
+			// "broad" matches all four events, "narrow" one of them; one
+			// event matches nobody.
+			require.NoError(t, store.RecordEvent(ctx, []string{"broad", "narrow"}, at))
+			require.NoError(t, store.RecordEvent(ctx, []string{"broad"}, at))
+			require.NoError(t, store.RecordEvent(ctx, []string{"broad"}, at))
+			require.NoError(t, store.RecordEvent(ctx, []string{"broad"}, at.Add(time.Second)))
+			require.NoError(t, store.RecordEvent(ctx, nil, at))
+
+			snapshot, err := store.Snapshot(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), snapshot.TotalEvents)
+
+			broad := snapshot.For("broad")
+			assert.Equal(t, int64(4), broad.MatchedEvents)
+			assert.InDelta(t, 0.8, broad.MatchRatio, 1e-9)
+			assert.InDelta(t, 1.25, broad.AverageFanOut, 1e-9)
+			require.NotNil(t, broad.LastMatchedAt)
+			assert.True(t, at.Add(time.Second).Equal(*broad.LastMatchedAt))
+
+			narrow := snapshot.For("narrow")
+			assert.Equal(t, int64(1), narrow.MatchedEvents)
+			assert.InDelta(t, 2.0, narrow.AverageFanOut, 1e-9)
+
+			require.NoError(t, store.Delete(ctx, "broad"))
+			snapshot, err = store.Snapshot(ctx)
+			require.NoError(t, err)
+			assert.NotContains(t, snapshot.Subscriptions, "broad")
+			assert.Contains(t, snapshot.Subscriptions, "narrow")
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// webhookCertificatesKey is the Redis hash holding the certificates
+	// issued by the webhook CA, keyed by serial number.
+	webhookCertificatesKey = "o2ims:webhook:certificates"
+
+	// webhookCAKey is the Redis key holding a generated webhook CA.
+	webhookCAKey = "o2ims:webhook:ca"
+)
+
+var (
+	// ErrCertificateNotFound is returned when no certificate with the given
+	// serial number was issued.
+	ErrCertificateNotFound = errors.New("certificate not found")
+
+	// ErrCertificateExists is returned when a certificate with the same
+	// serial number was already recorded.
+	ErrCertificateExists = errors.New("certificate already exists")
+)
+
+// Certificate usages.
+const (
+	// CertificateUsageClient certificates authenticate TLS clients.
+	CertificateUsageClient = "client"
+
+	// CertificateUsageServer certificates authenticate TLS servers, such as
+	// the notification endpoints of subscribers.
+	CertificateUsageServer = "server"
+)
+
+// WebhookCertificate records a certificate issued by the webhook CA. The
+// private key is never stored.
+type WebhookCertificate struct {
+	// SerialNumber is the hex-encoded serial number.
+	SerialNumber string `json:"serialNumber"`
+
+	// SubscriptionID is the subscription the certificate was issued for; it
+	// is empty for the gateway's own client certificate.
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+
+	// TenantID is the tenant owning the subscription.
+	TenantID string `json:"tenantId,omitempty"`
+
+	// Usage is CertificateUsageClient or CertificateUsageServer.
+	Usage string `json:"usage"`
+
+	CommonName string    `json:"commonName"`
+	DNSNames   []string  `json:"dnsNames,omitempty"`
+	NotBefore  time.Time `json:"notBefore"`
+	NotAfter   time.Time `json:"notAfter"`
+
+	// CertificatePEM is the PEM-encoded certificate.
+	CertificatePEM string `json:"certificate"`
+
+	// ReplacedBy is the serial number of the certificate a rotation issued
+	// in its place. A replaced certificate stays valid until it expires or
+	// is revoked.
+	ReplacedBy string `json:"replacedBy,omitempty"`
+
+	// RevokedAt is when the certificate was revoked; nil while valid.
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// RevocationReason is the reason given for the revocation.
+	RevocationReason string `json:"revocationReason,omitempty"`
+}
+
+// Revoked reports whether the certificate was revoked.
+func (c *WebhookCertificate) Revoked() bool {
+	return c.RevokedAt != nil
+}
+
+// WebhookCA is a certificate authority generated by the gateway, shared by
+// all replicas.
+type WebhookCA struct {
+	CertificatePEM string `json:"certificate"`
+	PrivateKeyPEM  string `json:"privateKey"`
+}
+
+// WebhookCertificateStore persists the certificates issued by the webhook CA
+// and the revocations consulted during delivery.
+type WebhookCertificateStore interface {
+	// Create records a newly issued certificate.
+	// Returns ErrCertificateExists if its serial number is already recorded.
+	Create(ctx context.Context, cert *WebhookCertificate) error
+
+	// Get returns the certificate with the given serial number.
+	// Returns ErrCertificateNotFound if it was not issued by the CA.
+	Get(ctx context.Context, serialNumber string) (*WebhookCertificate, error)
+
+	// List returns all issued certificates, oldest first.
+	List(ctx context.Context) ([]*WebhookCertificate, error)
+
+	// Update replaces a recorded certificate, e.g. to revoke it.
+	// Returns ErrCertificateNotFound if it is not recorded.
+	Update(ctx context.Context, cert *WebhookCertificate) error
+
+	// LoadOrStoreCA returns the stored CA, storing ca first if there is none,
+	// so that replicas starting at the same time agree on one CA.
+	LoadOrStoreCA(ctx context.Context, ca *WebhookCA) (*WebhookCA, error)
+}
+
+// sortCertificates orders certificates oldest first.
+func sortCertificates(certs []*WebhookCertificate) {
+	sort.Slice(certs, func(i, j int) bool {
+		if !certs[i].NotBefore.Equal(certs[j].NotBefore) {
+			return certs[i].NotBefore.Before(certs[j].NotBefore)
+		}
+		return certs[i].SerialNumber < certs[j].SerialNumber
+	})
+}
+
+// InMemoryWebhookCertificateStore implements WebhookCertificateStore in memory.
+type InMemoryWebhookCertificateStore struct {
+	mu    sync.RWMutex
+	certs map[string]*WebhookCertificate
+	ca    *WebhookCA
+}
+
+// NewInMemoryWebhookCertificateStore creates an empty in-memory certificate store.
+func NewInMemoryWebhookCertificateStore() *InMemoryWebhookCertificateStore {
+	return &InMemoryWebhookCertificateStore{certs: make(map[string]*WebhookCertificate)}
+}
+
+// Create records a newly issued certificate.
+func (s *InMemoryWebhookCertificateStore) Create(_ context.Context, cert *WebhookCertificate) error {
+	if cert == nil {
+		return errors.New("certificate cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.certs[cert.SerialNumber]; ok {
+		return ErrCertificateExists
+	}
+	stored := *cert
+	s.certs[cert.SerialNumber] = &stored
+	return nil
+}
+
+// Get returns a copy of the certificate with the given serial number.
+func (s *InMemoryWebhookCertificateStore) Get(_ context.Context, serialNumber string) (*WebhookCertificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cert, ok := s.certs[serialNumber]
+	if !ok {
+		return nil, ErrCertificateNotFound
+	}
+	copied := *cert
+	return &copied, nil
+}
+
+// List returns copies of all issued certificates, oldest first.
+func (s *InMemoryWebhookCertificateStore) List(_ context.Context) ([]*WebhookCertificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	certs := make([]*WebhookCertificate, 0, len(s.certs))
+	for _, cert := range s.certs {
+		copied := *cert
+		certs = append(certs, &copied)
+	}
+	sortCertificates(certs)
+	return certs, nil
+}
+
+// Update replaces a recorded certificate.
+func (s *InMemoryWebhookCertificateStore) Update(_ context.Context, cert *WebhookCertificate) error {
+	if cert == nil {
+		return errors.New("certificate cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.certs[cert.SerialNumber]; !ok {
+		return ErrCertificateNotFound
+	}
+	stored := *cert
+	s.certs[cert.SerialNumber] = &stored
+	return nil
+}
+
+// LoadOrStoreCA returns the stored CA, storing ca first if there is none.
+func (s *InMemoryWebhookCertificateStore) LoadOrStoreCA(_ context.Context, ca *WebhookCA) (*WebhookCA, error) {
+	if ca == nil {
+		return nil, errors.New("CA cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ca == nil {
+		stored := *ca
+		s.ca = &stored
+	}
+	loaded := *s.ca
+	return &loaded, nil
+}
+
+// RedisWebhookCertificateStore implements WebhookCertificateStore in Redis so
+// that certificates issued and revoked on one replica are known to the
+// webhook workers of every replica.
+type RedisWebhookCertificateStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisWebhookCertificateStore creates a Redis-backed certificate store.
+func NewRedisWebhookCertificateStore(client redis.UniversalClient) *RedisWebhookCertificateStore {
+	return &RedisWebhookCertificateStore{client: client}
+}
+
+// Create records a newly issued certificate.
+func (s *RedisWebhookCertificateStore) Create(ctx context.Context, cert *WebhookCertificate) error {
+	if cert == nil {
+		return errors.New("certificate cannot be nil")
+	}
+
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal certificate: %w", err)
+	}
+	created, err := s.client.HSetNX(ctx, webhookCertificatesKey, cert.SerialNumber, data).Result()
+	if err != nil {
+		return storageError("failed to store certificate", err)
+	}
+	if !created {
+		return ErrCertificateExists
+	}
+	return nil
+}
+
+// Get returns the certificate with the given serial number.
+func (s *RedisWebhookCertificateStore) Get(ctx context.Context, serialNumber string) (*WebhookCertificate, error) {
+	data, err := s.client.HGet(ctx, webhookCertificatesKey, serialNumber).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCertificateNotFound
+	}
+	if err != nil {
+		return nil, storageError("failed to get certificate", err)
+	}
+
+	var cert WebhookCertificate
+	if err := json.Unmarshal(data, &cert); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// List returns all issued certificates, oldest first.
+func (s *RedisWebhookCertificateStore) List(ctx context.Context) ([]*WebhookCertificate, error) {
+	values, err := s.client.HGetAll(ctx, webhookCertificatesKey).Result()
+	if err != nil {
+		return nil, storageError("failed to list certificates", err)
+	}
+
+	certs := make([]*WebhookCertificate, 0, len(values))
+	for _, data := range values {
+		var cert WebhookCertificate
+		if err := json.Unmarshal([]byte(data), &cert); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal certificate: %w", err)
+		}
+		certs = append(certs, &cert)
+	}
+	sortCertificates(certs)
+	return certs, nil
+}
+
+// Update replaces a recorded certificate.
+func (s *RedisWebhookCertificateStore) Update(ctx context.Context, cert *WebhookCertificate) error {
+	if cert == nil {
+		return errors.New("certificate cannot be nil")
+	}
+
+	exists, err := s.client.HExists(ctx, webhookCertificatesKey, cert.SerialNumber).Result()
+	if err != nil {
+		return storageError("failed to check certificate", err)
+	}
+	if !exists {
+		return ErrCertificateNotFound
+	}
+
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal certificate: %w", err)
+	}
+	if err := s.client.HSet(ctx, webhookCertificatesKey, cert.SerialNumber, data).Err(); err != nil {
+		return storageError("failed to update certificate", err)
+	}
+	return nil
+}
+
+// LoadOrStoreCA returns the stored CA, storing ca first if there is none.
+func (s *RedisWebhookCertificateStore) LoadOrStoreCA(ctx context.Context, ca *WebhookCA) (*WebhookCA, error) {
+	if ca == nil {
+		return nil, errors.New("CA cannot be nil")
+	}
+
+	data, err := json.Marshal(ca)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CA: %w", err)
+	}
+	if err := s.client.SetNX(ctx, webhookCAKey, data, 0).Err(); err != nil {
+		return nil, storageError("failed to store CA", err)
+	}
+
+	stored, err := s.client.Get(ctx, webhookCAKey).Bytes()
+	if err != nil {
+		return nil, storageError("failed to load CA", err)
+	}
+	var loaded WebhookCA
+	if err := json.Unmarshal(stored, &loaded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal CA: %w", err)
+	}
+	return &loaded, nil
+}
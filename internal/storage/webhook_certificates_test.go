@@ -0,0 +1,84 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+func TestWebhookCertificateStores(t *testing.T) {
+	redisStore, _ := setupTestRedis(t)
+	defer func() { _ = redisStore.Close() }()
+
+	stores := map[string]storage.WebhookCertificateStore{
+		"in-memory": storage.NewInMemoryWebhookCertificateStore(),
+		"redis":     storage.NewRedisWebhookCertificateStore(redisStore.Client),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			issuedAt := time.Now().UTC().Truncate(time.Second)
+
+			_, err := store.Get(ctx, "01")
+			require.ErrorIs(t, err, storage.ErrCertificateNotFound)
+			require.ErrorIs(t, store.Update(ctx, &storage.WebhookCertificate{SerialNumber: "01"}),
+				storage.ErrCertificateNotFound)
+			require.Error(t, store.Create(ctx, nil))
+
+			newer := &storage.WebhookCertificate{
+				SerialNumber:   "02",
+				SubscriptionID: "sub-1",
+				Usage:          storage.CertificateUsageServer,
+				CommonName:     "sub-1",
+				DNSNames:       []string{"smo.example.com"},
+				NotBefore:      issuedAt.Add(time.Minute),
+				NotAfter:       issuedAt.Add(time.Hour),
+			}
+			older := &storage.WebhookCertificate{
+				SerialNumber: "01",
+				Usage:        storage.CertificateUsageClient,
+				CommonName:   "netweave-gateway",
+				NotBefore:    issuedAt,
+				NotAfter:     issuedAt.Add(time.Hour),
+			}
+			require.NoError(t, store.Create(ctx, newer))
+			require.NoError(t, store.Create(ctx, older))
+			require.ErrorIs(t, store.Create(ctx, older), storage.ErrCertificateExists)
+
+			got, err := store.Get(ctx, "02")
+			require.NoError(t, err)
+			assert.Equal(t, newer, got)
+			assert.False(t, got.Revoked())
+
+			revokedAt := issuedAt.Add(2 * time.Minute)
+			got.RevokedAt = &revokedAt
+			got.RevocationReason = "key compromise"
+			require.NoError(t, store.Update(ctx, got))
+
+			certs, err := store.List(ctx)
+			require.NoError(t, err)
+			require.Len(t, certs, 2)
+			assert.Equal(t, "01", certs[0].SerialNumber)
+			assert.Equal(t, "02", certs[1].SerialNumber)
+			assert.True(t, certs[1].Revoked())
+			assert.Equal(t, "key compromise", certs[1].RevocationReason)
+
+			// The first CA stored wins.
+			_, err = store.LoadOrStoreCA(ctx, nil)
+			require.Error(t, err)
+			first := &storage.WebhookCA{CertificatePEM: "cert-1", PrivateKeyPEM: "key-1"}
+			loaded, err := store.LoadOrStoreCA(ctx, first)
+			require.NoError(t, err)
+			assert.Equal(t, first, loaded)
+			loaded, err = store.LoadOrStoreCA(ctx, &storage.WebhookCA{CertificatePEM: "cert-2", PrivateKeyPEM: "key-2"})
+			require.NoError(t, err)
+			assert.Equal(t, first, loaded)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package timeutil provides the timestamp conventions shared by the API and
+// storage layers: timestamps are stored and serialized in UTC and exchanged as
+// RFC 3339 strings.
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTimestamp is returned when a timestamp is not a valid RFC 3339 string.
+var ErrInvalidTimestamp = errors.New("invalid timestamp: expected RFC3339 format (e.g. 2026-01-02T15:04:05Z)")
+
+// Now returns the current time in UTC. Use it for every timestamp that is
+// persisted or returned by the API.
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Normalize converts t to UTC. The zero time is returned unchanged so that
+// unset timestamps stay recognizable with IsZero.
+func Normalize(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.UTC()
+}
+
+// Format formats t as an RFC 3339 string in UTC. The zero time formats as an
+// empty string.
+func Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Parse parses an RFC 3339 timestamp, with optional fractional seconds, and
+// returns it in UTC. Timestamps without a time zone are rejected.
+func Parse(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimestamp, value)
+	}
+	return t.UTC(), nil
+}
+
+// Stamp sets the creation and update timestamps of a persisted object.
+// createdAt is only set when it is zero, so the original creation time is
+// preserved on updates; updatedAt is always set to now. Existing values are
+// normalized to UTC.
+func Stamp(createdAt, updatedAt *time.Time) {
+	now := Now()
+	if createdAt.IsZero() {
+		*createdAt = now
+	} else {
+		*createdAt = createdAt.UTC()
+	}
+	*updatedAt = now
+}
+
+// ValidateExpiry checks an optional expiry time requested at now and
+// normalizes it to UTC. It must lie in the future and, when maxTTL is
+// positive, at most maxTTL after now.
+func ValidateExpiry(expiresAt *time.Time, now time.Time, maxTTL time.Duration) error {
+	if expiresAt == nil {
+		return nil
+	}
+	*expiresAt = expiresAt.UTC()
+	if !expiresAt.After(now) {
+		return fmt.Errorf("expiry %s is not in the future", Format(*expiresAt))
+	}
+	if maxTTL > 0 && expiresAt.Sub(now) > maxTTL {
+		return fmt.Errorf("expiry %s is more than %s ahead", Format(*expiresAt), maxTTL)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package timeutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/timeutil"
+)
+
+func TestNow(t *testing.T) {
+	assert.Equal(t, time.UTC, timeutil.Now().Location())
+}
+
+func TestNormalize(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	normalized := timeutil.Normalize(time.Date(2026, 1, 2, 16, 4, 5, 0, cet))
+	assert.Equal(t, time.UTC, normalized.Location())
+	assert.Equal(t, 15, normalized.Hour())
+
+	assert.True(t, timeutil.Normalize(time.Time{}).IsZero())
+}
+
+func TestFormat(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	assert.Equal(t, "2026-01-02T15:04:05Z", timeutil.Format(time.Date(2026, 1, 2, 16, 4, 5, 999, cet)))
+	assert.Empty(t, timeutil.Format(time.Time{}))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "utc", value: "2026-01-02T15:04:05Z", want: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{name: "offset", value: "2026-01-02T16:04:05+01:00", want: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{name: "fractional", value: "2026-01-02T15:04:05.5Z", want: time.Date(2026, 1, 2, 15, 4, 5, 5e8, time.UTC)},
+		{name: "no zone", value: "2026-01-02T15:04:05", wantErr: true},
+		{name: "date only", value: "2026-01-02", wantErr: true},
+		{name: "garbage", value: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timeutil.Parse(tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, timeutil.ErrInvalidTimestamp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestStamp(t *testing.T) {
+	var createdAt, updatedAt time.Time
+	timeutil.Stamp(&createdAt, &updatedAt)
+	assert.False(t, createdAt.IsZero())
+	assert.Equal(t, createdAt, updatedAt)
+
+	original := time.Date(2020, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+	createdAt = original
+	timeutil.Stamp(&createdAt, &updatedAt)
+	assert.True(t, createdAt.Equal(original))
+	assert.Equal(t, time.UTC, createdAt.Location())
+	assert.True(t, updatedAt.After(createdAt))
+}
+
+func TestValidateExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d).In(time.FixedZone("CET", 3600))
+		return &t
+	}
+
+	require.NoError(t, timeutil.ValidateExpiry(nil, now, time.Hour))
+
+	expiresAt := at(time.Hour)
+	require.NoError(t, timeutil.ValidateExpiry(expiresAt, now, 0))
+	assert.Equal(t, time.UTC, expiresAt.Location(), "normalized to UTC")
+
+	require.NoError(t, timeutil.ValidateExpiry(at(time.Hour), now, time.Hour))
+	assert.EqualError(t, timeutil.ValidateExpiry(at(0), now, 0), "expiry 2026-01-02T15:00:00Z is not in the future")
+	assert.EqualError(t, timeutil.ValidateExpiry(at(2*time.Hour), now, time.Hour),
+		"expiry 2026-01-02T17:00:00Z is more than 1h0m0s ahead")
+}
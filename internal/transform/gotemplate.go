@@ -0,0 +1,152 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// goTemplateFuncs are the only functions, besides the text/template builtins,
+// that Go templates may call.
+var goTemplateFuncs = template.FuncMap{
+	"json":  toJSON,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+
+	rangeGuard: rangeable,
+}
+
+// rangeGuard is the function appended to every range pipeline.
+const rangeGuard = "rangeable"
+
+// rangeable returns v if it is a collection, so that a range over a number
+// held in a variable or returned by a function fails instead of looping.
+func rangeable(v any) (any, error) {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Invalid, reflect.Slice, reflect.Array, reflect.Map:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("range over %T is not allowed", v)
+	}
+}
+
+// toJSON encodes v as JSON, so templates can emit correctly quoted values.
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode value: %w", err)
+	}
+	return string(data), nil
+}
+
+// compileGoTemplate parses a Go template. Template definitions and
+// invocations are rejected so that a template cannot recurse. Ranging over an
+// integer literal is rejected, and every other range pipeline is passed
+// through rangeable, so that only collections from the notification can be
+// ranged over and every loop is bounded by the size of the notification.
+// Each iteration also checks the deadline, whether or not it writes output.
+func compileGoTemplate(source string) (*Program, error) {
+	tmpl, err := template.New("transform").Funcs(goTemplateFuncs).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	if len(tmpl.Templates()) > 1 || tmpl.Tree == nil {
+		return nil, fmt.Errorf("%w: define and block are not allowed", ErrInvalidTemplate)
+	}
+	if err := checkNode(tmpl.Tree.Root); err != nil {
+		return nil, err
+	}
+
+	return &Program{run: func(ctx context.Context, doc any) ([]byte, error) {
+		w := &limitedWriter{ctx: ctx}
+		if err := tmpl.Execute(w, doc); err != nil {
+			return nil, fmt.Errorf("failed to execute template: %w", err)
+		}
+		return w.buf.Bytes(), nil
+	}}, nil
+}
+
+// checkNode rejects the template constructs that are not allowed and guards
+// every range action.
+func checkNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkNode(child); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("%w: template invocations are not allowed", ErrInvalidTemplate)
+	case *parse.RangeNode:
+		if rangesOverNumber(n.Pipe) {
+			return fmt.Errorf("%w: range over a number is not allowed", ErrInvalidTemplate)
+		}
+		guardRange(n)
+		return checkBranch(&n.BranchNode)
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode)
+	}
+	return nil
+}
+
+// checkBranch checks both lists of an if, range or with action.
+func checkBranch(n *parse.BranchNode) error {
+	if err := checkNode(n.List); err != nil {
+		return err
+	}
+	return checkNode(n.ElseList)
+}
+
+// rangesOverNumber reports whether a range pipeline is an integer literal.
+func rangesOverNumber(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	_, ok := pipe.Cmds[0].Args[0].(*parse.NumberNode)
+	return ok
+}
+
+// guardRange passes the range pipeline through rangeable and starts the loop
+// body with an empty text node. Executing the text node writes nothing, but
+// the write makes limitedWriter check the deadline on every iteration.
+func guardRange(n *parse.RangeNode) {
+	n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      n.Pipe.Pos,
+		Args:     []parse.Node{parse.NewIdentifier(rangeGuard).SetPos(n.Pipe.Pos)},
+	})
+	n.List.Nodes = append([]parse.Node{&parse.TextNode{NodeType: parse.NodeText, Pos: n.List.Pos}}, n.List.Nodes...)
+}
+
+// limitedWriter buffers template output, failing writes once the output
+// exceeds MaxOutputBytes or the execution is past its deadline.
+type limitedWriter struct {
+	ctx context.Context
+	buf bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if w.buf.Len()+len(p) > MaxOutputBytes {
+		return 0, fmt.Errorf("%w: limit is %d bytes", ErrOutputTooLarge, MaxOutputBytes)
+	}
+	n, err := w.buf.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("failed to buffer output: %w", err)
+	}
+	return n, nil
+}
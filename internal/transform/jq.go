@@ -0,0 +1,397 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The jq dialect is restricted to building a document from the fields of the
+// notification, which covers renaming and flattening:
+//
+//	.                          the whole notification
+//	.a.b  ."a-b"  .["a"]       a field path; missing fields are null
+//	{key: expr, "k": expr}     object construction; {key} is short for {key: .key}
+//	[expr, ...]                array construction
+//	"text"  42  true  false  null
+//
+// Pipes, operators, functions, variables and iteration are not supported, so
+// evaluation is linear in the size of the expression.
+
+// jqExpr is a node of a parsed jq expression.
+type jqExpr interface {
+	eval(doc any) (any, error)
+}
+
+// jqPath is a field path; an empty path is the identity.
+type jqPath []string
+
+func (p jqPath) eval(doc any) (any, error) {
+	value := doc
+	for _, key := range p {
+		switch v := value.(type) {
+		case nil:
+			return nil, nil
+		case map[string]any:
+			value = v[key]
+		default:
+			return nil, fmt.Errorf("cannot index %s with %q", jsonType(v), key)
+		}
+	}
+	return value, nil
+}
+
+// jqLiteral is a constant.
+type jqLiteral struct {
+	value any
+}
+
+func (l jqLiteral) eval(any) (any, error) {
+	return l.value, nil
+}
+
+// jqArray constructs an array.
+type jqArray []jqExpr
+
+func (a jqArray) eval(doc any) (any, error) {
+	values := make([]any, 0, len(a))
+	for _, elem := range a {
+		v, err := elem.eval(doc)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// jqObject constructs an object.
+type jqObject struct {
+	keys   []string
+	values []jqExpr
+}
+
+func (o *jqObject) eval(doc any) (any, error) {
+	obj := &orderedObject{keys: o.keys, values: make([]any, len(o.values))}
+	for i, expr := range o.values {
+		v, err := expr.eval(doc)
+		if err != nil {
+			return nil, err
+		}
+		obj.values[i] = v
+	}
+	return obj, nil
+}
+
+// orderedObject is a constructed object that, as in jq, keeps its keys in the
+// order they were written.
+type orderedObject struct {
+	keys   []string
+	values []any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key: %w", err)
+		}
+		v, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonType names the JSON type of a decoded value for error messages.
+func jsonType(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+// compileJQ parses a restricted jq expression.
+func compileJQ(source string) (*Program, error) {
+	p := &jqParser{src: source}
+	expr, err := p.parseExpr()
+	if err == nil {
+		p.skipSpace()
+		if p.pos < len(p.src) {
+			err = p.errorf("unexpected %q", p.src[p.pos])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	return &Program{run: func(_ context.Context, doc any) ([]byte, error) {
+		v, err := expr.eval(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate jq expression: %w", err)
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode jq result: %w", err)
+		}
+		return out, nil
+	}}, nil
+}
+
+// jqParser is a recursive descent parser for the restricted jq dialect.
+type jqParser struct {
+	src string
+	pos int
+}
+
+func (p *jqParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("jq: "+format+" at offset %d", append(args, p.pos)...)
+}
+
+func (p *jqParser) skipSpace() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *jqParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *jqParser) expect(c byte) error {
+	p.skipSpace()
+	if p.peek() != c {
+		if p.pos >= len(p.src) {
+			return p.errorf("expected %q, got end of input", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.src[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+func (p *jqParser) parseExpr() (jqExpr, error) {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '.':
+		return p.parsePath()
+	case c == '{':
+		return p.parseObject()
+	case c == '[':
+		return p.parseArray()
+	case c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return jqLiteral{value: s}, nil
+	case c == '-' || isDigit(c):
+		return p.parseNumber()
+	case isIdentStart(c):
+		switch word := p.parseIdent(); word {
+		case "true":
+			return jqLiteral{value: true}, nil
+		case "false":
+			return jqLiteral{value: false}, nil
+		case "null":
+			return jqLiteral{value: nil}, nil
+		default:
+			return nil, p.errorf("unsupported identifier %q", word)
+		}
+	case c == 0:
+		return nil, p.errorf("unexpected end of input")
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *jqParser) parsePath() (jqExpr, error) {
+	path := jqPath{}
+	for {
+		switch p.peek() {
+		case '.':
+			p.pos++
+			switch c := p.peek(); {
+			case isIdentStart(c):
+				path = append(path, p.parseIdent())
+			case c == '"':
+				key, err := p.parseString()
+				if err != nil {
+					return nil, err
+				}
+				path = append(path, key)
+			case c == '[' || (len(path) == 0 && c != '.'):
+				// Identity, or identity followed by a bracket index
+			default:
+				return nil, p.errorf("expected field name after '.'")
+			}
+		case '[':
+			p.pos++
+			p.skipSpace()
+			key, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(']'); err != nil {
+				return nil, err
+			}
+			path = append(path, key)
+		default:
+			return path, nil
+		}
+	}
+}
+
+func (p *jqParser) parseObject() (jqExpr, error) {
+	p.pos++ // '{'
+	obj := &jqObject{}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return obj, nil
+	}
+	for {
+		p.skipSpace()
+		var key string
+		switch c := p.peek(); {
+		case isIdentStart(c):
+			key = p.parseIdent()
+		case c == '"':
+			s, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = s
+		default:
+			return nil, p.errorf("expected object key")
+		}
+
+		p.skipSpace()
+		var value jqExpr = jqPath{key}
+		if p.peek() == ':' {
+			p.pos++
+			v, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			value = v
+		}
+		obj.keys = append(obj.keys, key)
+		obj.values = append(obj.values, value)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return obj, nil
+		default:
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (p *jqParser) parseArray() (jqExpr, error) {
+	p.pos++ // '['
+	arr := jqArray{}
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return arr, nil
+	}
+	for {
+		elem, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, elem)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return arr, nil
+		default:
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+// parseString parses a double-quoted string with JSON escapes.
+func (p *jqParser) parseString() (string, error) {
+	start := p.pos
+	if p.peek() != '"' {
+		return "", p.errorf("expected string")
+	}
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			var s string
+			if err := json.Unmarshal([]byte(p.src[start:p.pos]), &s); err != nil {
+				return "", p.errorf("invalid string %s", p.src[start:p.pos])
+			}
+			return s, nil
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jqParser) parseNumber() (jqExpr, error) {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte("+-.eE0123456789", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	num := p.src[start:p.pos]
+	if !json.Valid([]byte(num)) {
+		return nil, p.errorf("invalid number %q", num)
+	}
+	return jqLiteral{value: json.Number(num)}, nil
+}
+
+func (p *jqParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) && (isIdentStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
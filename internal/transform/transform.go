@@ -0,0 +1,149 @@
+// Package transform reshapes webhook notification bodies for consumers that
+// expect a different payload layout. A subscription may register a Template,
+// written as a Go text/template or in a restricted subset of jq, which is
+// applied to each notification just before it is signed and delivered.
+//
+// Templates are sandboxed: they only see the notification document, Go
+// templates get a fixed set of functions and cannot invoke other templates,
+// and every execution is bounded in time and output size.
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Template languages.
+const (
+	LanguageGoTemplate = "gotemplate"
+	LanguageJQ         = "jq"
+)
+
+// Limits applied to every template.
+const (
+	// MaxSourceBytes is the maximum size of a template source.
+	MaxSourceBytes = 4096
+
+	// MaxOutputBytes is the maximum size of a transformed notification.
+	MaxOutputBytes = 64 * 1024
+
+	// ExecutionTimeout bounds a single template execution.
+	ExecutionTimeout = 100 * time.Millisecond
+)
+
+var (
+	// ErrInvalidTemplate is returned for templates that do not compile or
+	// fail their trial run.
+	ErrInvalidTemplate = errors.New("invalid transformation template")
+
+	// ErrTimeout is returned when an execution exceeds ExecutionTimeout.
+	ErrTimeout = errors.New("transformation timed out")
+
+	// ErrOutputTooLarge is returned when the output exceeds MaxOutputBytes.
+	ErrOutputTooLarge = errors.New("transformation output too large")
+
+	// ErrInvalidOutput is returned when the output is not a JSON document.
+	ErrInvalidOutput = errors.New("transformation output is not valid JSON")
+)
+
+// Template is a transformation registered with a subscription.
+type Template struct {
+	// Language is the template language: "gotemplate" or "jq".
+	Language string `json:"language"`
+
+	// Source is the template text.
+	Source string `json:"source"`
+}
+
+// Program is a compiled Template, safe for concurrent use.
+type Program struct {
+	run func(ctx context.Context, doc any) ([]byte, error)
+}
+
+// Compile parses and checks a template.
+func Compile(t *Template) (*Program, error) {
+	if t == nil {
+		return nil, fmt.Errorf("%w: template is required", ErrInvalidTemplate)
+	}
+	if t.Source == "" {
+		return nil, fmt.Errorf("%w: source is required", ErrInvalidTemplate)
+	}
+	if len(t.Source) > MaxSourceBytes {
+		return nil, fmt.Errorf("%w: source exceeds %d bytes", ErrInvalidTemplate, MaxSourceBytes)
+	}
+
+	switch t.Language {
+	case LanguageGoTemplate:
+		return compileGoTemplate(t.Source)
+	case LanguageJQ:
+		return compileJQ(t.Source)
+	default:
+		return nil, fmt.Errorf("%w: unsupported language %q (supported: %s, %s)",
+			ErrInvalidTemplate, t.Language, LanguageGoTemplate, LanguageJQ)
+	}
+}
+
+// Validate compiles t and trial-runs it against sample, a notification of
+// the shape the subscription will receive, so that templates which fail or
+// produce invalid output are rejected when they are registered.
+func Validate(ctx context.Context, t *Template, sample []byte) error {
+	program, err := Compile(t)
+	if err != nil {
+		return err
+	}
+	if _, err := program.Apply(ctx, sample); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return nil
+}
+
+// Apply transforms a JSON notification. Executions are abandoned after
+// ExecutionTimeout, and the result must be a JSON document of at most
+// MaxOutputBytes.
+func (p *Program) Apply(ctx context.Context, payload []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ExecutionTimeout)
+	defer cancel()
+
+	type result struct {
+		out []byte
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		out, err := p.run(ctx, doc)
+		done <- result{out: out, err: err}
+	}()
+
+	var r result
+	select {
+	case r = <-done:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w after %s", ErrTimeout, ExecutionTimeout)
+	}
+	if r.err != nil {
+		if errors.Is(r.err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w after %s", ErrTimeout, ExecutionTimeout)
+		}
+		return nil, r.err
+	}
+
+	out := bytes.TrimSpace(r.out)
+	if len(out) > MaxOutputBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrOutputTooLarge, MaxOutputBytes)
+	}
+	if !json.Valid(out) {
+		return nil, ErrInvalidOutput
+	}
+	return out, nil
+}
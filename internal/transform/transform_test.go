@@ -0,0 +1,192 @@
+package transform_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/transform"
+)
+
+const notification = `{
+	"subscriptionId": "sub-1",
+	"notificationEventType": "o2ims.Resource.Created",
+	"objectRef": "/o2ims/v1/resources/node-1",
+	"globalResourceId": "node-1",
+	"sequenceNumber": 7,
+	"extensions": {"zone": "eu-west-1a"}
+}`
+
+func apply(t *testing.T, tmpl *transform.Template) (string, error) {
+	t.Helper()
+	program, err := transform.Compile(tmpl)
+	require.NoError(t, err)
+	out, err := program.Apply(context.Background(), []byte(notification))
+	return string(out), err
+}
+
+func TestGoTemplate(t *testing.T) {
+	out, err := apply(t, &transform.Template{
+		Language: transform.LanguageGoTemplate,
+		Source: `{"id": {{json .globalResourceId}}, "event": {{json (lower .notificationEventType)}},` +
+			` "seq": {{.sequenceNumber}}, "zone": {{json .extensions.zone}}, "missing": {{json .nope}}}`,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"id":"node-1","event":"o2ims.resource.created","seq":7,"zone":"eu-west-1a","missing":null}`, out)
+}
+
+func TestJQ(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{name: "identity", source: ".", want: notification},
+		{name: "path", source: ".extensions.zone", want: `"eu-west-1a"`},
+		{name: "bracket and quoted keys", source: `.["extensions"]."zone"`, want: `"eu-west-1a"`},
+		{name: "missing field", source: ".nope.deeper", want: `null`},
+		{
+			name:   "rename and flatten",
+			source: `{id: .globalResourceId, "event-type": .notificationEventType, zone: .extensions.zone, objectRef}`,
+			want: `{"id":"node-1","event-type":"o2ims.Resource.Created","zone":"eu-west-1a",` +
+				`"objectRef":"/o2ims/v1/resources/node-1"}`,
+		},
+		{
+			name:   "literals and arrays",
+			source: `{source: "netweave", version: 2, ids: [.subscriptionId, .globalResourceId], ok: true, x: null}`,
+			want:   `{"source":"netweave","version":2,"ids":["sub-1","node-1"],"ok":true,"x":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := apply(t, &transform.Template{Language: transform.LanguageJQ, Source: tt.source})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, out)
+		})
+	}
+}
+
+func TestJQ_KeepsKeyOrder(t *testing.T) {
+	out, err := apply(t, &transform.Template{Language: transform.LanguageJQ, Source: `{z: 1, a: 2, m: 3}`})
+	require.NoError(t, err)
+	assert.Equal(t, `{"z":1,"a":2,"m":3}`, out)
+}
+
+func TestJQ_IndexNonObject(t *testing.T) {
+	_, err := apply(t, &transform.Template{Language: transform.LanguageJQ, Source: `.globalResourceId.x`})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot index string")
+}
+
+func TestCompile_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl *transform.Template
+	}{
+		{name: "nil", tmpl: nil},
+		{name: "empty source", tmpl: &transform.Template{Language: transform.LanguageJQ}},
+		{name: "unknown language", tmpl: &transform.Template{Language: "lua", Source: "x"}},
+		{
+			name: "too large",
+			tmpl: &transform.Template{
+				Language: transform.LanguageJQ,
+				Source:   strings.Repeat(" ", transform.MaxSourceBytes) + ".",
+			},
+		},
+		{name: "go syntax", tmpl: &transform.Template{Language: transform.LanguageGoTemplate, Source: "{{.a"}},
+		{
+			name: "go unknown func",
+			tmpl: &transform.Template{Language: transform.LanguageGoTemplate, Source: "{{env 1}}"},
+		},
+		{
+			name: "go define",
+			tmpl: &transform.Template{Language: transform.LanguageGoTemplate, Source: `{{define "x"}}{}{{end}}{}`},
+		},
+		{
+			name: "go recursion",
+			tmpl: &transform.Template{Language: transform.LanguageGoTemplate, Source: `{{template "transform" .}}`},
+		},
+		{
+			name: "go range literal",
+			tmpl: &transform.Template{
+				Language: transform.LanguageGoTemplate,
+				Source:   `{{if .a}}{{else}}{{range 1000000000}}{{end}}{{end}}`,
+			},
+		},
+		{name: "jq pipe", tmpl: &transform.Template{Language: transform.LanguageJQ, Source: ".a | .b"}},
+		{name: "jq function", tmpl: &transform.Template{Language: transform.LanguageJQ, Source: "keys"}},
+		{name: "jq recurse", tmpl: &transform.Template{Language: transform.LanguageJQ, Source: ".."}},
+		{name: "jq unterminated", tmpl: &transform.Template{Language: transform.LanguageJQ, Source: `{a: .b`}},
+		{name: "jq bad string", tmpl: &transform.Template{Language: transform.LanguageJQ, Source: `"abc`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transform.Compile(tt.tmpl)
+			require.ErrorIs(t, err, transform.ErrInvalidTemplate)
+		})
+	}
+}
+
+func TestApply_Limits(t *testing.T) {
+	t.Run("invalid output", func(t *testing.T) {
+		_, err := apply(t, &transform.Template{
+			Language: transform.LanguageGoTemplate,
+			Source:   `id={{.globalResourceId}}`,
+		})
+		require.ErrorIs(t, err, transform.ErrInvalidOutput)
+	})
+
+	t.Run("output too large", func(t *testing.T) {
+		program, err := transform.Compile(&transform.Template{
+			Language: transform.LanguageGoTemplate,
+			Source:   `{{range .}}` + strings.Repeat("x", 4000) + `{{end}}`,
+		})
+		require.NoError(t, err)
+		large := `[` + strings.Repeat(`1,`, 100) + `1]`
+		_, err = program.Apply(context.Background(), []byte(large))
+		require.ErrorIs(t, err, transform.ErrOutputTooLarge)
+	})
+
+	t.Run("range over a number variable", func(t *testing.T) {
+		for _, source := range []string{
+			`{{$n := 300000000}}{{range $n}}{{end}}null`,
+			`{{range len .}}{{end}}null`,
+			`{{range $i, $v := .}}{{range $v}}{{end}}{{end}}null`,
+		} {
+			program, err := transform.Compile(&transform.Template{Language: transform.LanguageGoTemplate, Source: source})
+			require.NoError(t, err)
+			_, err = program.Apply(context.Background(), []byte(`[1]`))
+			require.ErrorContains(t, err, "range over", source)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		program, err := transform.Compile(&transform.Template{
+			Language: transform.LanguageGoTemplate,
+			Source:   `{{range .}}{{range $}}{{range $}}{{end}}{{end}}{{end}}null`,
+		})
+		require.NoError(t, err)
+		large := `[` + strings.Repeat(`1,`, 999) + `1]`
+		_, err = program.Apply(context.Background(), []byte(large))
+		require.ErrorIs(t, err, transform.ErrTimeout)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+	sample := []byte(notification)
+
+	require.NoError(t, transform.Validate(ctx,
+		&transform.Template{Language: transform.LanguageJQ, Source: `{id: .globalResourceId}`}, sample))
+
+	err := transform.Validate(ctx,
+		&transform.Template{Language: transform.LanguageGoTemplate, Source: `{{.globalResourceId}}`}, sample)
+	require.ErrorIs(t, err, transform.ErrInvalidTemplate)
+	require.ErrorIs(t, err, transform.ErrInvalidOutput)
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldRule is the built-in rule type. It checks a single field of the
+// request body and runs no operator code, so it is always safe to enable.
+type FieldRule struct {
+	// Field is the dot-separated path of the checked field, using the API
+	// field names (e.g. "extensions.siteCode").
+	Field string
+
+	// Required rejects objects without the field or with an empty value.
+	Required bool
+
+	// Pattern is a regular expression the whole value must match.
+	Pattern string
+
+	// Values lists the allowed values.
+	Values []string
+
+	// MaxLength limits the length of the value in characters.
+	MaxLength int
+
+	// Message replaces the generated violation message.
+	Message string
+
+	pattern *regexp.Regexp
+}
+
+// NewFieldRule checks the rule and compiles its pattern.
+func NewFieldRule(rule FieldRule) (*FieldRule, error) {
+	if rule.Field == "" {
+		return nil, fmt.Errorf("field is required")
+	}
+	if rule.MaxLength < 0 {
+		return nil, fmt.Errorf("max length cannot be negative")
+	}
+	if !rule.Required && rule.Pattern == "" && len(rule.Values) == 0 && rule.MaxLength == 0 {
+		return nil, fmt.Errorf("at least one of required, pattern, values or max length must be set")
+	}
+	if rule.Pattern != "" {
+		// Anchor the pattern so it describes the whole value.
+		pattern, err := regexp.Compile(`^(?:` + rule.Pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		rule.pattern = pattern
+	}
+	return &rule, nil
+}
+
+// Validate checks the field of obj. Absent or empty fields only violate
+// Required; the other checks apply to values that are present.
+func (r *FieldRule) Validate(_ context.Context, obj *Object) ([]Violation, error) {
+	raw, ok := obj.Lookup(r.Field)
+	if !ok || raw == nil || raw == "" {
+		if r.Required {
+			return r.violation("is required"), nil
+		}
+		return nil, nil
+	}
+
+	value, ok := scalar(raw)
+	if !ok {
+		if r.pattern == nil && len(r.Values) == 0 && r.MaxLength == 0 {
+			return nil, nil
+		}
+		return r.violation("must be a string, number or boolean"), nil
+	}
+
+	switch {
+	case r.pattern != nil && !r.pattern.MatchString(value):
+		return r.violation(fmt.Sprintf("must match %q", r.Pattern)), nil
+	case len(r.Values) > 0 && !slices.Contains(r.Values, value):
+		return r.violation("must be one of " + strings.Join(r.Values, ", ")), nil
+	case r.MaxLength > 0 && utf8.RuneCountInString(value) > r.MaxLength:
+		return r.violation(fmt.Sprintf("must be at most %d characters", r.MaxLength)), nil
+	}
+	return nil, nil
+}
+
+// violation reports the field as violating the rule.
+func (r *FieldRule) violation(reason string) []Violation {
+	message := r.Message
+	if message == "" {
+		message = r.Field + " " + reason
+	}
+	return []Violation{{Field: r.Field, Message: message}}
+}
+
+// scalar returns the string form of a JSON scalar value.
+func scalar(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64, bool:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Rule check results recorded in o2ims_validation_rule_checks_total.
+const (
+	resultPassed   = "passed"
+	resultViolated = "violated"
+	resultError    = "error"
+	resultTimeout  = "timeout"
+)
+
+var (
+	ruleChecks = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "o2ims",
+			Subsystem: "validation",
+			Name:      "rule_checks_total",
+			Help:      "Total number of validation rule checks by result",
+		},
+		[]string{"rule", "kind", "result"},
+	)
+
+	ruleDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "o2ims",
+			Subsystem: "validation",
+			Name:      "rule_duration_seconds",
+			Help:      "Validation rule check duration in seconds",
+			Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1.0, 2.0},
+		},
+		[]string{"rule"},
+	)
+)
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = make(map[string]Validator)
+)
+
+// RegisterPlugin makes an in-process validator available by name, so
+// configuration can attach it with type "plugin". Integrators typically call
+// it from an init function in a package linked into the gateway binary. It
+// panics if the name is empty, the validator is nil, or the name is already
+// registered.
+func RegisterPlugin(name string, validator Validator) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+
+	if name == "" || validator == nil {
+		panic("validation: RegisterPlugin requires a name and a validator")
+	}
+	if _, exists := plugins[name]; exists {
+		panic(fmt.Sprintf("validation: plugin %q registered twice", name))
+	}
+	plugins[name] = validator
+}
+
+// LookupPlugin returns the validator registered under name.
+func LookupPlugin(name string) (Validator, bool) {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+
+	validator, ok := plugins[name]
+	return validator, ok
+}
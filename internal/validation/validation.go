@@ -0,0 +1,299 @@
+// Package validation runs operator-defined validation rules on objects before
+// the gateway creates or updates them.
+//
+// Operators have their own conventions, such as naming schemes or site codes,
+// that the O2 specifications do not express. A rule is either a built-in field
+// rule configured at startup, which checks a field of the request against a
+// pattern, a list of allowed values or a length limit, or an in-process Go
+// plugin registered with RegisterPlugin for checks that need code. Rules are
+// attached to object kinds and operations; every matching rule runs and the
+// request is rejected with all of their violations.
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Kind is the kind of object a rule validates.
+type Kind string
+
+const (
+	// KindResourcePool is an O2-IMS resource pool.
+	KindResourcePool Kind = "resourcePool"
+
+	// KindResource is an O2-IMS resource.
+	KindResource Kind = "resource"
+
+	// KindNFDeployment is an O2-DMS NF deployment.
+	KindNFDeployment Kind = "nfDeployment"
+)
+
+// Operation is the operation an object is validated for.
+type Operation string
+
+const (
+	// OperationCreate is the creation of an object.
+	OperationCreate Operation = "create"
+
+	// OperationUpdate is the replacement of an object.
+	OperationUpdate Operation = "update"
+)
+
+// FailurePolicy controls what happens when a rule fails to run, for example
+// because a plugin returned an error or timed out.
+type FailurePolicy string
+
+const (
+	// FailurePolicyReject rejects the request.
+	FailurePolicyReject FailurePolicy = "reject"
+
+	// FailurePolicyIgnore logs the failure and skips the rule.
+	FailurePolicyIgnore FailurePolicy = "ignore"
+)
+
+// DefaultTimeout bounds a rule when its registration sets no timeout.
+const DefaultTimeout = 2 * time.Second
+
+// ErrRuleFailed is returned by Engine.Validate when a rule with the reject
+// policy could not run.
+var ErrRuleFailed = errors.New("validation rule failed")
+
+// Object is the object a rule validates.
+type Object struct {
+	// Kind is the kind of the object.
+	Kind Kind
+
+	// Operation is the operation being performed.
+	Operation Operation
+
+	// ID is the ID of the object, when known.
+	ID string
+
+	// Fields is the JSON form of the request body, so rules address fields by
+	// their API names (e.g. "name" or "extensions.siteCode").
+	Fields map[string]interface{}
+}
+
+// NewObject builds the Object for a request body by converting it to its
+// JSON form.
+func NewObject(kind Kind, op Operation, id string, body interface{}) (*Object, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", kind, err)
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", kind, err)
+	}
+	return &Object{Kind: kind, Operation: op, ID: id, Fields: fields}, nil
+}
+
+// Lookup returns the value of a dot-separated field path such as
+// "extensions.siteCode".
+func (o *Object) Lookup(path string) (interface{}, bool) {
+	var current interface{} = o.Fields
+	for _, part := range strings.Split(path, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = fields[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Violation is a rule an object does not satisfy.
+type Violation struct {
+	// Rule is the name of the violated rule.
+	Rule string `json:"rule"`
+
+	// Field is the offending field, when the rule checks one.
+	Field string `json:"field,omitempty"`
+
+	// Message explains the violation.
+	Message string `json:"message"`
+}
+
+// Validator checks objects. It returns the violations it finds; an error
+// means the check itself could not be performed.
+type Validator interface {
+	Validate(ctx context.Context, obj *Object) ([]Violation, error)
+}
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(ctx context.Context, obj *Object) ([]Violation, error)
+
+// Validate calls f(ctx, obj).
+func (f ValidatorFunc) Validate(ctx context.Context, obj *Object) ([]Violation, error) {
+	return f(ctx, obj)
+}
+
+// Registration attaches a validator to object kinds and operations.
+type Registration struct {
+	// Name identifies the rule in violations, logs and metrics.
+	Name string
+
+	// Validator checks matching objects.
+	Validator Validator
+
+	// Kinds restricts the rule to these object kinds. Empty matches all kinds.
+	Kinds []Kind
+
+	// Operations restricts the rule to these operations. Empty matches all
+	// operations.
+	Operations []Operation
+
+	// Timeout bounds each check. Zero uses DefaultTimeout.
+	Timeout time.Duration
+
+	// FailurePolicy controls failure handling. Empty means FailurePolicyReject.
+	FailurePolicy FailurePolicy
+}
+
+// Validate checks that the registration is well formed.
+func (r *Registration) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("validation rule name is required")
+	}
+	if r.Validator == nil {
+		return fmt.Errorf("validation rule %q has no implementation", r.Name)
+	}
+	if r.Timeout < 0 {
+		return fmt.Errorf("validation rule %q timeout cannot be negative", r.Name)
+	}
+	for _, kind := range r.Kinds {
+		switch kind {
+		case KindResourcePool, KindResource, KindNFDeployment:
+		default:
+			return fmt.Errorf("validation rule %q has invalid kind %q", r.Name, kind)
+		}
+	}
+	for _, op := range r.Operations {
+		if op != OperationCreate && op != OperationUpdate {
+			return fmt.Errorf("validation rule %q has invalid operation %q (must be create or update)", r.Name, op)
+		}
+	}
+	switch r.FailurePolicy {
+	case "", FailurePolicyReject, FailurePolicyIgnore:
+	default:
+		return fmt.Errorf("validation rule %q has invalid failure policy %q (must be reject or ignore)",
+			r.Name, r.FailurePolicy)
+	}
+	return nil
+}
+
+// matches reports whether the registration applies to the object.
+func (r *Registration) matches(obj *Object) bool {
+	return (len(r.Kinds) == 0 || slices.Contains(r.Kinds, obj.Kind)) &&
+		(len(r.Operations) == 0 || slices.Contains(r.Operations, obj.Operation))
+}
+
+// Engine runs the registered rules.
+// A nil *Engine has no rules, so callers need not check whether rules are configured.
+type Engine struct {
+	logger        *zap.Logger
+	registrations []Registration
+}
+
+// NewEngine creates an Engine without rules.
+func NewEngine(logger *zap.Logger) *Engine {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Engine{logger: logger}
+}
+
+// Register adds a rule. Registration is not safe for concurrent use with
+// Validate and is expected to happen during startup.
+func (e *Engine) Register(reg Registration) error {
+	if err := reg.Validate(); err != nil {
+		return err
+	}
+	if reg.Timeout == 0 {
+		reg.Timeout = DefaultTimeout
+	}
+	if reg.FailurePolicy == "" {
+		reg.FailurePolicy = FailurePolicyReject
+	}
+	e.registrations = append(e.registrations, reg)
+	return nil
+}
+
+// Len returns the number of registered rules.
+func (e *Engine) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.registrations)
+}
+
+// Validate runs every rule matching the object and returns their violations,
+// each attributed to its rule. It returns an error wrapping ErrRuleFailed
+// when a rule with the reject policy could not run.
+func (e *Engine) Validate(ctx context.Context, obj *Object) ([]Violation, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	var violations []Violation
+	for i := range e.registrations {
+		reg := &e.registrations[i]
+		if !reg.matches(obj) {
+			continue
+		}
+
+		found, err := e.check(ctx, reg, obj)
+		if err != nil {
+			if reg.FailurePolicy == FailurePolicyReject {
+				return nil, fmt.Errorf("%w: %s: %w", ErrRuleFailed, reg.Name, err)
+			}
+			e.logger.Warn("validation rule failed; skipping it",
+				zap.String("rule", reg.Name),
+				zap.String("kind", string(obj.Kind)),
+				zap.String("id", obj.ID),
+				zap.Error(err))
+			continue
+		}
+		for _, v := range found {
+			v.Rule = reg.Name
+			violations = append(violations, v)
+		}
+	}
+	return violations, nil
+}
+
+// check runs a single rule with its timeout and records metrics.
+func (e *Engine) check(ctx context.Context, reg *Registration, obj *Object) ([]Violation, error) {
+	ctx, cancel := context.WithTimeout(ctx, reg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	violations, err := reg.Validator.Validate(ctx, obj)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	result := resultPassed
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		result = resultTimeout
+	case err != nil:
+		result = resultError
+	case len(violations) > 0:
+		result = resultViolated
+	}
+	ruleChecks.WithLabelValues(reg.Name, string(obj.Kind), result).Inc()
+	ruleDuration.WithLabelValues(reg.Name).Observe(time.Since(start).Seconds())
+
+	return violations, err
+}
@@ -0,0 +1,262 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/internal/validation"
+)
+
+type pool struct {
+	Name       string                 `json:"name"`
+	Location   string                 `json:"location,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func newObject(t *testing.T, kind validation.Kind, op validation.Operation, body interface{}) *validation.Object {
+	t.Helper()
+	obj, err := validation.NewObject(kind, op, "obj-1", body)
+	require.NoError(t, err)
+	return obj
+}
+
+func newFieldRule(t *testing.T, rule validation.FieldRule) *validation.FieldRule {
+	t.Helper()
+	r, err := validation.NewFieldRule(rule)
+	require.NoError(t, err)
+	return r
+}
+
+func TestFieldRule(t *testing.T) {
+	body := pool{
+		Name:       "edge-dc1-pool",
+		Extensions: map[string]interface{}{"siteCode": "DC1", "replicas": 3},
+	}
+	obj := newObject(t, validation.KindResourcePool, validation.OperationCreate, body)
+
+	tests := []struct {
+		name    string
+		rule    validation.FieldRule
+		wantMsg string
+	}{
+		{name: "pattern matches", rule: validation.FieldRule{Field: "name", Pattern: `edge-[a-z0-9]+-pool`}},
+		{
+			name:    "pattern is anchored",
+			rule:    validation.FieldRule{Field: "name", Pattern: `edge`},
+			wantMsg: `name must match "edge"`,
+		},
+		{
+			name: "nested value allowed",
+			rule: validation.FieldRule{Field: "extensions.siteCode", Values: []string{"DC1"}},
+		},
+		{
+			name:    "nested value not allowed",
+			rule:    validation.FieldRule{Field: "extensions.siteCode", Values: []string{"DC2", "DC3"}},
+			wantMsg: "extensions.siteCode must be one of DC2, DC3",
+		},
+		{name: "number value", rule: validation.FieldRule{Field: "extensions.replicas", Pattern: `[1-5]`}},
+		{
+			name:    "max length",
+			rule:    validation.FieldRule{Field: "name", MaxLength: 4},
+			wantMsg: "name must be at most 4 characters",
+		},
+		{
+			name:    "required missing",
+			rule:    validation.FieldRule{Field: "location", Required: true},
+			wantMsg: "location is required",
+		},
+		{name: "optional missing", rule: validation.FieldRule{Field: "location", Pattern: `[a-z]+`}},
+		{
+			name:    "custom message",
+			rule:    validation.FieldRule{Field: "extensions.owner", Required: true, Message: "pools need an owner"},
+			wantMsg: "pools need an owner",
+		},
+		{
+			name:    "object value",
+			rule:    validation.FieldRule{Field: "extensions", MaxLength: 10},
+			wantMsg: "extensions must be a string, number or boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := newFieldRule(t, tt.rule).Validate(context.Background(), obj)
+			require.NoError(t, err)
+			if tt.wantMsg == "" {
+				assert.Empty(t, violations)
+				return
+			}
+			require.Len(t, violations, 1)
+			assert.Equal(t, tt.rule.Field, violations[0].Field)
+			assert.Equal(t, tt.wantMsg, violations[0].Message)
+		})
+	}
+}
+
+func TestNewFieldRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    validation.FieldRule
+		wantErr string
+	}{
+		{name: "missing field", rule: validation.FieldRule{Required: true}, wantErr: "field is required"},
+		{name: "no check", rule: validation.FieldRule{Field: "name"}, wantErr: "at least one of"},
+		{name: "bad pattern", rule: validation.FieldRule{Field: "name", Pattern: "("}, wantErr: "invalid pattern"},
+		{
+			name:    "negative max length",
+			rule:    validation.FieldRule{Field: "name", MaxLength: -1},
+			wantErr: "max length cannot be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validation.NewFieldRule(tt.rule)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestEngine_Validate(t *testing.T) {
+	ctx := context.Background()
+	body := pool{Name: "pool-1"}
+
+	t.Run("runs matching rules and attributes violations", func(t *testing.T) {
+		e := validation.NewEngine(zap.NewNop())
+		require.NoError(t, e.Register(validation.Registration{
+			Name:      "site-code",
+			Validator: newFieldRule(t, validation.FieldRule{Field: "extensions.siteCode", Required: true}),
+			Kinds:     []validation.Kind{validation.KindResourcePool},
+		}))
+		require.NoError(t, e.Register(validation.Registration{
+			Name:       "pool-name",
+			Validator:  newFieldRule(t, validation.FieldRule{Field: "name", Pattern: `edge-.*`}),
+			Operations: []validation.Operation{validation.OperationCreate},
+		}))
+		require.NoError(t, e.Register(validation.Registration{
+			Name:      "deployments-only",
+			Validator: newFieldRule(t, validation.FieldRule{Field: "name", MaxLength: 1}),
+			Kinds:     []validation.Kind{validation.KindNFDeployment},
+		}))
+
+		violations, err := e.Validate(ctx, newObject(t, validation.KindResourcePool, validation.OperationCreate, body))
+		require.NoError(t, err)
+		assert.Equal(t, []validation.Violation{
+			{Rule: "site-code", Field: "extensions.siteCode", Message: "extensions.siteCode is required"},
+			{Rule: "pool-name", Field: "name", Message: `name must match "edge-.*"`},
+		}, violations)
+
+		violations, err = e.Validate(ctx, newObject(t, validation.KindResourcePool, validation.OperationUpdate, body))
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "site-code", violations[0].Rule)
+	})
+
+	t.Run("failure policies", func(t *testing.T) {
+		errBackend := errors.New("backend unavailable")
+		failing := validation.ValidatorFunc(func(context.Context, *validation.Object) ([]validation.Violation, error) {
+			return nil, errBackend
+		})
+
+		e := validation.NewEngine(zap.NewNop())
+		require.NoError(t, e.Register(validation.Registration{
+			Name: "optional", Validator: failing, FailurePolicy: validation.FailurePolicyIgnore,
+		}))
+		violations, err := e.Validate(ctx, newObject(t, validation.KindResource, validation.OperationCreate, body))
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+
+		require.NoError(t, e.Register(validation.Registration{Name: "mandatory", Validator: failing}))
+		_, err = e.Validate(ctx, newObject(t, validation.KindResource, validation.OperationCreate, body))
+		require.ErrorIs(t, err, validation.ErrRuleFailed)
+		require.ErrorIs(t, err, errBackend)
+		assert.Contains(t, err.Error(), "mandatory")
+	})
+
+	t.Run("times out slow rules", func(t *testing.T) {
+		e := validation.NewEngine(zap.NewNop())
+		require.NoError(t, e.Register(validation.Registration{
+			Name: "slow",
+			Validator: validation.ValidatorFunc(
+				func(ctx context.Context, _ *validation.Object) ([]validation.Violation, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}),
+			Timeout: 10 * time.Millisecond,
+		}))
+		_, err := e.Validate(ctx, newObject(t, validation.KindResource, validation.OperationCreate, body))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("nil engine has no rules", func(t *testing.T) {
+		var e *validation.Engine
+		violations, err := e.Validate(ctx, newObject(t, validation.KindResource, validation.OperationCreate, body))
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+		assert.Zero(t, e.Len())
+	})
+}
+
+func TestRegistration_Validate(t *testing.T) {
+	rule := validation.ValidatorFunc(func(context.Context, *validation.Object) ([]validation.Violation, error) {
+		return nil, nil
+	})
+	tests := []struct {
+		name    string
+		reg     validation.Registration
+		wantErr string
+	}{
+		{name: "valid", reg: validation.Registration{Name: "r", Validator: rule}},
+		{name: "missing name", reg: validation.Registration{Validator: rule}, wantErr: "name is required"},
+		{name: "missing validator", reg: validation.Registration{Name: "r"}, wantErr: "no implementation"},
+		{
+			name:    "invalid kind",
+			reg:     validation.Registration{Name: "r", Validator: rule, Kinds: []validation.Kind{"pod"}},
+			wantErr: `invalid kind "pod"`,
+		},
+		{
+			name:    "invalid operation",
+			reg:     validation.Registration{Name: "r", Validator: rule, Operations: []validation.Operation{"delete"}},
+			wantErr: `invalid operation "delete"`,
+		},
+		{
+			name:    "invalid failure policy",
+			reg:     validation.Registration{Name: "r", Validator: rule, FailurePolicy: "retry"},
+			wantErr: `invalid failure policy "retry"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.reg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	rule := validation.ValidatorFunc(func(context.Context, *validation.Object) ([]validation.Violation, error) {
+		return nil, nil
+	})
+	validation.RegisterPlugin("test-registry-plugin", rule)
+
+	got, ok := validation.LookupPlugin("test-registry-plugin")
+	require.True(t, ok)
+	assert.NotNil(t, got)
+
+	_, ok = validation.LookupPlugin("unknown")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { validation.RegisterPlugin("test-registry-plugin", rule) })
+	assert.Panics(t, func() { validation.RegisterPlugin("", rule) })
+}
@@ -0,0 +1,595 @@
+// Package webhookca implements the optional internal certificate authority
+// for webhook mTLS. It issues client and server certificates to subscribers
+// through the enrollment API, issues and renews the client certificate the
+// gateway presents when delivering notifications, and publishes a revocation
+// list that the webhook workers consult before delivering to an endpoint.
+//
+// The CA key pair is either loaded from files, for example a CA Secret
+// maintained by cert-manager, or generated on first use and shared by all
+// replicas through the certificate store.
+package webhookca
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/piwi3910/netweave/internal/storage"
+)
+
+const (
+	// DefaultCertValidity is the validity of issued certificates when the
+	// request and the configuration give none.
+	DefaultCertValidity = 90 * 24 * time.Hour
+
+	// MaxCertValidity bounds the validity of issued certificates.
+	MaxCertValidity = 365 * 24 * time.Hour
+
+	// GatewayCommonName is the common name of the gateway's client certificate.
+	GatewayCommonName = "netweave-gateway"
+
+	// CRLValidity is how long a published revocation list is valid.
+	CRLValidity = 24 * time.Hour
+
+	// generatedCAValidity is the validity of a generated CA.
+	generatedCAValidity = 10 * 365 * 24 * time.Hour
+
+	// generatedCACommonName is the common name of a generated CA.
+	generatedCACommonName = "netweave webhook CA"
+
+	// revocationCacheTTL is how long the revoked serial numbers are cached
+	// before they are reloaded from the store.
+	revocationCacheTTL = 5 * time.Second
+
+	// serialNumberBits is the entropy of issued serial numbers.
+	serialNumberBits = 128
+
+	// backdate is subtracted from NotBefore to tolerate clock skew.
+	backdate = time.Minute
+)
+
+var (
+	// ErrInvalidRequest is returned for certificate requests the CA refuses
+	// to sign.
+	ErrInvalidRequest = errors.New("invalid certificate request")
+
+	// ErrCertificateRevoked is returned when a certificate issued by the CA
+	// was revoked, including by TLS handshakes with a revoked peer.
+	ErrCertificateRevoked = errors.New("certificate revoked")
+)
+
+// Config configures the CA.
+type Config struct {
+	// CertFile and KeyFile hold the PEM-encoded CA certificate and private
+	// key. When both are empty a CA is generated and stored in the store.
+	CertFile string
+	KeyFile  string
+
+	// CertValidity is the default validity of issued certificates
+	// (default DefaultCertValidity).
+	CertValidity time.Duration
+}
+
+// IssueRequest describes a certificate to issue.
+type IssueRequest struct {
+	// SubscriptionID and TenantID identify the subscriber.
+	SubscriptionID string
+	TenantID       string
+
+	// Usage is storage.CertificateUsageClient or storage.CertificateUsageServer.
+	Usage string
+
+	// CommonName and DNSNames name the subject. For a server certificate
+	// the DNS names must include the host of the notification endpoint.
+	CommonName string
+	DNSNames   []string
+
+	// AllowedDNSNames, if set, restricts the DNS names the certificate may
+	// name. Since deliveries trust every server certificate the CA issues,
+	// subscribers must only get certificates for their own endpoints.
+	AllowedDNSNames []string
+
+	// CSRPEM is a PEM-encoded certificate signing request whose public key
+	// is certified. When empty the CA generates the key pair and returns
+	// the private key once.
+	CSRPEM string
+
+	// ValidFor is the validity of the certificate (default: the CA's).
+	ValidFor time.Duration
+}
+
+// Issued is an issued certificate.
+type Issued struct {
+	// Certificate is the record of the certificate.
+	Certificate *storage.WebhookCertificate
+
+	// PrivateKeyPEM is the generated private key; empty when a CSR was given.
+	PrivateKeyPEM string
+
+	// CACertificatePEM is the CA certificate the certificate chains to.
+	CACertificatePEM string
+}
+
+// Authority is the webhook CA.
+type Authority struct {
+	cert     *x509.Certificate
+	certPEM  string
+	key      crypto.Signer
+	store    storage.WebhookCertificateStore
+	validity time.Duration
+	now      func() time.Time
+
+	// mu guards the gateway certificate and the revocation cache.
+	mu              sync.Mutex
+	gatewayCert     *tls.Certificate
+	gatewaySerial   string
+	revoked         map[string]bool
+	revokedLoadedAt time.Time
+}
+
+// New creates the CA. It loads the key pair from cfg's files, or generates
+// one and stores it unless the store already holds a CA.
+func New(ctx context.Context, cfg Config, store storage.WebhookCertificateStore) (*Authority, error) {
+	if store == nil {
+		return nil, errors.New("certificate store cannot be nil")
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("CA cert file and key file must be set together")
+	}
+
+	var certPEM, keyPEM []byte
+	if cfg.CertFile != "" {
+		var err error
+		if certPEM, err = os.ReadFile(cfg.CertFile); err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		if keyPEM, err = os.ReadFile(cfg.KeyFile); err != nil {
+			return nil, fmt.Errorf("failed to read CA key: %w", err)
+		}
+	} else {
+		generated, err := generateCA(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		stored, err := store.LoadOrStoreCA(ctx, generated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store CA: %w", err)
+		}
+		certPEM, keyPEM = []byte(stored.CertificatePEM), []byte(stored.PrivateKeyPEM)
+	}
+
+	return newAuthority(certPEM, keyPEM, store, cfg.CertValidity)
+}
+
+// newAuthority creates the CA from its PEM-encoded key pair.
+func newAuthority(
+	certPEM, keyPEM []byte, store storage.WebhookCertificateStore, validity time.Duration,
+) (*Authority, error) {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA key pair: %w", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, errors.New("CA certificate is not a CA")
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA key cannot sign")
+	}
+	if validity <= 0 {
+		validity = DefaultCertValidity
+	}
+
+	return &Authority{
+		cert:     cert,
+		certPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+		key:      key,
+		store:    store,
+		validity: validity,
+		now:      time.Now,
+	}, nil
+}
+
+// generateCA generates a self-signed ECDSA CA.
+func generateCA(now time.Time) (*storage.WebhookCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: generatedCACommonName},
+		NotBefore:             now.Add(-backdate),
+		NotAfter:              now.Add(generatedCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.WebhookCA{
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKeyPEM:  keyPEM,
+	}, nil
+}
+
+// Store returns the store recording the issued certificates.
+func (a *Authority) Store() storage.WebhookCertificateStore {
+	return a.store
+}
+
+// CACertificatePEM returns the PEM-encoded CA certificate.
+func (a *Authority) CACertificatePEM() string {
+	return a.certPEM
+}
+
+// Issue signs a certificate and records it.
+func (a *Authority) Issue(ctx context.Context, req IssueRequest) (*Issued, error) {
+	if req.Usage != storage.CertificateUsageClient && req.Usage != storage.CertificateUsageServer {
+		return nil, fmt.Errorf("%w: usage must be %q or %q", ErrInvalidRequest,
+			storage.CertificateUsageClient, storage.CertificateUsageServer)
+	}
+	validFor := req.ValidFor
+	if validFor == 0 {
+		validFor = a.validity
+	}
+	if validFor < 0 || validFor > MaxCertValidity {
+		return nil, fmt.Errorf("%w: validity must be between 0s and %s", ErrInvalidRequest, MaxCertValidity)
+	}
+
+	publicKey, privateKeyPEM, err := a.subjectKey(&req)
+	if err != nil {
+		return nil, err
+	}
+	if req.CommonName == "" {
+		return nil, fmt.Errorf("%w: common name is required", ErrInvalidRequest)
+	}
+	if req.SubscriptionID != "" && req.CommonName == GatewayCommonName {
+		return nil, fmt.Errorf("%w: common name %q is reserved for the gateway", ErrInvalidRequest, GatewayCommonName)
+	}
+	if req.Usage == storage.CertificateUsageServer && len(req.DNSNames) == 0 {
+		return nil, fmt.Errorf("%w: server certificates need at least one DNS name", ErrInvalidRequest)
+	}
+	if len(req.AllowedDNSNames) > 0 {
+		for _, name := range req.DNSNames {
+			if !slices.Contains(req.AllowedDNSNames, strings.ToLower(name)) {
+				return nil, fmt.Errorf("%w: DNS name %q is not allowed (allowed: %s)", ErrInvalidRequest,
+					name, strings.Join(req.AllowedDNSNames, ", "))
+			}
+		}
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	now := a.now()
+	notAfter := now.Add(validFor)
+	if notAfter.After(a.cert.NotAfter) {
+		notAfter = a.cert.NotAfter
+	}
+	extKeyUsage := x509.ExtKeyUsageClientAuth
+	if req.Usage == storage.CertificateUsageServer {
+		extKeyUsage = x509.ExtKeyUsageServerAuth
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: req.CommonName},
+		DNSNames:     req.DNSNames,
+		NotBefore:    now.Add(-backdate),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+	}
+	if req.SubscriptionID != "" {
+		template.URIs = []*url.URL{{Scheme: "urn", Opaque: "o2ims:subscription:" + req.SubscriptionID}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, publicKey, a.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	record := &storage.WebhookCertificate{
+		SerialNumber:   SerialNumber(serial),
+		SubscriptionID: req.SubscriptionID,
+		TenantID:       req.TenantID,
+		Usage:          req.Usage,
+		CommonName:     req.CommonName,
+		DNSNames:       req.DNSNames,
+		NotBefore:      template.NotBefore.UTC(),
+		NotAfter:       notAfter.UTC(),
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+	if err := a.store.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to record certificate: %w", err)
+	}
+	return &Issued{Certificate: record, PrivateKeyPEM: privateKeyPEM, CACertificatePEM: a.certPEM}, nil
+}
+
+// subjectKey returns the public key to certify: the CSR's, whose subject
+// fills in a missing common name and DNS names of req, or a generated one
+// whose private key is returned as well.
+func (a *Authority) subjectKey(req *IssueRequest) (any, string, error) {
+	if req.CSRPEM == "" {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to generate key: %w", err)
+		}
+		keyPEM, err := encodePrivateKey(key)
+		if err != nil {
+			return nil, "", err
+		}
+		return &key.PublicKey, keyPEM, nil
+	}
+
+	block, _ := pem.Decode([]byte(req.CSRPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, "", fmt.Errorf("%w: csr must be a PEM-encoded CERTIFICATE REQUEST", ErrInvalidRequest)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, "", fmt.Errorf("%w: csr signature: %w", ErrInvalidRequest, err)
+	}
+	if req.CommonName == "" {
+		req.CommonName = csr.Subject.CommonName
+	}
+	if len(req.DNSNames) == 0 {
+		req.DNSNames = csr.DNSNames
+	}
+	return csr.PublicKey, "", nil
+}
+
+// Rotate issues a certificate replacing old, for the same subscriber, usage
+// and names, and records the replacement on old. Old stays valid until it
+// expires or is revoked, so the subscriber can switch without downtime.
+func (a *Authority) Rotate(
+	ctx context.Context, old *storage.WebhookCertificate, csrPEM string, validFor time.Duration,
+) (*Issued, error) {
+	if old.Revoked() {
+		return nil, fmt.Errorf("%w: %s", ErrCertificateRevoked, old.SerialNumber)
+	}
+
+	issued, err := a.Issue(ctx, IssueRequest{
+		SubscriptionID: old.SubscriptionID,
+		TenantID:       old.TenantID,
+		Usage:          old.Usage,
+		CommonName:     old.CommonName,
+		DNSNames:       old.DNSNames,
+		CSRPEM:         csrPEM,
+		ValidFor:       validFor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	old.ReplacedBy = issued.Certificate.SerialNumber
+	if err := a.store.Update(ctx, old); err != nil {
+		return nil, fmt.Errorf("failed to record rotation: %w", err)
+	}
+	return issued, nil
+}
+
+// Revoke revokes the certificate with the given serial number. Revoking a
+// revoked certificate keeps the original revocation.
+func (a *Authority) Revoke(ctx context.Context, serialNumber, reason string) (*storage.WebhookCertificate, error) {
+	cert, err := a.store.Get(ctx, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if cert.Revoked() {
+		return cert, nil
+	}
+
+	revokedAt := a.now().UTC()
+	cert.RevokedAt = &revokedAt
+	cert.RevocationReason = reason
+	if err := a.store.Update(ctx, cert); err != nil {
+		return nil, fmt.Errorf("failed to record revocation: %w", err)
+	}
+
+	a.mu.Lock()
+	a.revoked = nil
+	a.mu.Unlock()
+	return cert, nil
+}
+
+// CRL returns the PEM-encoded revocation list of the certificates revoked
+// before they expired.
+func (a *Authority) CRL(ctx context.Context) (string, error) {
+	certs, err := a.store.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	now := a.now()
+	var entries []x509.RevocationListEntry
+	for _, cert := range certs {
+		if !cert.Revoked() || now.After(cert.NotAfter) {
+			continue
+		}
+		serial, ok := new(big.Int).SetString(cert.SerialNumber, 16)
+		if !ok {
+			continue
+		}
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: serial, RevocationTime: *cert.RevokedAt})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(now.UnixNano()),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(CRLValidity),
+		RevokedCertificateEntries: entries,
+	}, a.cert, a.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to create revocation list: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})), nil
+}
+
+// IsRevoked reports whether cert was issued by the CA and revoked.
+// Certificates of other issuers are never revoked by the CA.
+func (a *Authority) IsRevoked(ctx context.Context, cert *x509.Certificate) (bool, error) {
+	if cert.CheckSignatureFrom(a.cert) != nil {
+		return false, nil
+	}
+	revoked, err := a.revokedSerials(ctx)
+	if err != nil {
+		return false, err
+	}
+	return revoked[SerialNumber(cert.SerialNumber)], nil
+}
+
+// revokedSerials returns the serial numbers of revoked certificates, cached
+// for revocationCacheTTL so deliveries don't each read the store.
+func (a *Authority) revokedSerials(ctx context.Context) (map[string]bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.revoked != nil && a.now().Sub(a.revokedLoadedAt) < revocationCacheTTL {
+		return a.revoked, nil
+	}
+	certs, err := a.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revocations: %w", err)
+	}
+	revoked := make(map[string]bool)
+	for _, cert := range certs {
+		if cert.Revoked() {
+			revoked[cert.SerialNumber] = true
+		}
+	}
+	a.revoked = revoked
+	a.revokedLoadedAt = a.now()
+	return revoked, nil
+}
+
+// ClientTLSConfig returns the TLS configuration for delivering notifications.
+// It trusts the system roots and the CA, presents the gateway's client
+// certificate and refuses endpoints presenting a revoked certificate.
+func (a *Authority) ClientTLSConfig() *tls.Config {
+	roots, err := x509.SystemCertPool()
+	if err != nil || roots == nil {
+		roots = x509.NewCertPool()
+	}
+	roots.AddCert(a.cert)
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    roots,
+		GetClientCertificate: func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return a.GatewayCertificate(info.Context())
+		},
+		VerifyConnection: func(state tls.ConnectionState) error {
+			for _, cert := range state.PeerCertificates {
+				revoked, err := a.IsRevoked(context.Background(), cert)
+				if err != nil {
+					return err
+				}
+				if revoked {
+					return fmt.Errorf("%w: %s presented certificate %s", ErrCertificateRevoked,
+						state.ServerName, SerialNumber(cert.SerialNumber))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// GatewayCertificate returns the client certificate the gateway presents to
+// notification endpoints. It is issued on first use and renewed once two
+// thirds of its validity have passed or when it was revoked. Each replica
+// holds its own certificate.
+func (a *Authority) GatewayCertificate(ctx context.Context) (*tls.Certificate, error) {
+	a.mu.Lock()
+	current, serial := a.gatewayCert, a.gatewaySerial
+	a.mu.Unlock()
+
+	if current != nil && !a.needsRenewal(current.Leaf) {
+		// Keep presenting the certificate while revocations can't be loaded.
+		revoked, err := a.revokedSerials(ctx)
+		if err != nil || !revoked[serial] {
+			return current, nil
+		}
+	}
+
+	issued, err := a.Issue(ctx, IssueRequest{
+		Usage:      storage.CertificateUsageClient,
+		CommonName: GatewayCommonName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue gateway certificate: %w", err)
+	}
+	pair, err := tls.X509KeyPair([]byte(issued.Certificate.CertificatePEM), []byte(issued.PrivateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway certificate: %w", err)
+	}
+	pair.Certificate = append(pair.Certificate, a.cert.Raw)
+
+	a.mu.Lock()
+	a.gatewayCert = &pair
+	a.gatewaySerial = issued.Certificate.SerialNumber
+	a.mu.Unlock()
+	return &pair, nil
+}
+
+// needsRenewal reports whether two thirds of cert's validity have passed.
+func (a *Authority) needsRenewal(cert *x509.Certificate) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return !a.now().Before(cert.NotBefore.Add(lifetime * 2 / 3))
+}
+
+// SerialNumber formats a certificate serial number as lowercase hex.
+func SerialNumber(serial *big.Int) string {
+	return strings.ToLower(serial.Text(16))
+}
+
+// newSerialNumber returns a random positive serial number.
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial.Add(serial, big.NewInt(1)), nil
+}
+
+// encodePrivateKey PEM-encodes key in PKCS #8.
+func encodePrivateKey(key crypto.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
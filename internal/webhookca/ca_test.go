@@ -0,0 +1,307 @@
+package webhookca_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/webhookca"
+)
+
+func newTestCA(t *testing.T) (*webhookca.Authority, *storage.InMemoryWebhookCertificateStore) {
+	t.Helper()
+	store := storage.NewInMemoryWebhookCertificateStore()
+	ca, err := webhookca.New(context.Background(), webhookca.Config{}, store)
+	require.NoError(t, err)
+	return ca, store
+}
+
+func parseCertificate(t *testing.T, certPEM string) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode([]byte(certPEM))
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func newCSR(t *testing.T, commonName string, dnsNames ...string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: commonName},
+		DNSNames: dnsNames,
+	}, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("generated CA is stored once", func(t *testing.T) {
+		store := storage.NewInMemoryWebhookCertificateStore()
+		first, err := webhookca.New(ctx, webhookca.Config{}, store)
+		require.NoError(t, err)
+		second, err := webhookca.New(ctx, webhookca.Config{}, store)
+		require.NoError(t, err)
+		assert.Equal(t, first.CACertificatePEM(), second.CACertificatePEM())
+
+		caCert := parseCertificate(t, first.CACertificatePEM())
+		assert.True(t, caCert.IsCA)
+	})
+
+	t.Run("CA from files", func(t *testing.T) {
+		store := storage.NewInMemoryWebhookCertificateStore()
+		generated, err := webhookca.New(ctx, webhookca.Config{}, store)
+		require.NoError(t, err)
+		ca, err := store.LoadOrStoreCA(ctx, &storage.WebhookCA{})
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		require.NoError(t, os.WriteFile(certFile, []byte(ca.CertificatePEM), 0o600))
+		require.NoError(t, os.WriteFile(keyFile, []byte(ca.PrivateKeyPEM), 0o600))
+
+		loaded, err := webhookca.New(ctx, webhookca.Config{CertFile: certFile, KeyFile: keyFile},
+			storage.NewInMemoryWebhookCertificateStore())
+		require.NoError(t, err)
+		assert.Equal(t, generated.CACertificatePEM(), loaded.CACertificatePEM())
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := webhookca.New(ctx, webhookca.Config{}, nil)
+		require.Error(t, err)
+		_, err = webhookca.New(ctx, webhookca.Config{CertFile: "ca.crt"}, storage.NewInMemoryWebhookCertificateStore())
+		require.Error(t, err)
+		_, err = webhookca.New(ctx, webhookca.Config{CertFile: "/nonexistent/ca.crt", KeyFile: "/nonexistent/ca.key"},
+			storage.NewInMemoryWebhookCertificateStore())
+		require.Error(t, err)
+	})
+}
+
+func TestIssue(t *testing.T) {
+	ctx := context.Background()
+	ca, store := newTestCA(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(parseCertificate(t, ca.CACertificatePEM()))
+
+	t.Run("server certificate with generated key", func(t *testing.T) {
+		issued, err := ca.Issue(ctx, webhookca.IssueRequest{
+			SubscriptionID:  "sub-1",
+			TenantID:        "tenant-a",
+			Usage:           storage.CertificateUsageServer,
+			CommonName:      "sub-1",
+			DNSNames:        []string{"smo.example.com"},
+			AllowedDNSNames: []string{"smo.example.com"},
+			ValidFor:        24 * time.Hour,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, issued.PrivateKeyPEM)
+		assert.Equal(t, ca.CACertificatePEM(), issued.CACertificatePEM)
+
+		_, err = tls.X509KeyPair([]byte(issued.Certificate.CertificatePEM), []byte(issued.PrivateKeyPEM))
+		require.NoError(t, err)
+		cert := parseCertificate(t, issued.Certificate.CertificatePEM)
+		_, err = cert.Verify(x509.VerifyOptions{
+			DNSName:   "smo.example.com",
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		require.NoError(t, err)
+		require.Len(t, cert.URIs, 1)
+		assert.Equal(t, "urn:o2ims:subscription:sub-1", cert.URIs[0].String())
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+		stored, err := store.Get(ctx, issued.Certificate.SerialNumber)
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-a", stored.TenantID)
+		assert.Equal(t, webhookca.SerialNumber(cert.SerialNumber), stored.SerialNumber)
+	})
+
+	t.Run("client certificate from a CSR", func(t *testing.T) {
+		issued, err := ca.Issue(ctx, webhookca.IssueRequest{
+			SubscriptionID: "sub-1",
+			Usage:          storage.CertificateUsageClient,
+			CSRPEM:         newCSR(t, "smo-client"),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, issued.PrivateKeyPEM)
+		assert.Equal(t, "smo-client", issued.Certificate.CommonName)
+
+		cert := parseCertificate(t, issued.Certificate.CertificatePEM)
+		_, err = cert.Verify(x509.VerifyOptions{
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(webhookca.DefaultCertValidity), cert.NotAfter, time.Minute)
+	})
+
+	invalid := map[string]webhookca.IssueRequest{
+		"unknown usage":   {Usage: "signing", CommonName: "x"},
+		"no common name":  {Usage: storage.CertificateUsageClient},
+		"server no names": {Usage: storage.CertificateUsageServer, CommonName: "x"},
+		"validity too long": {
+			Usage: storage.CertificateUsageClient, CommonName: "x", ValidFor: 2 * webhookca.MaxCertValidity,
+		},
+		"gateway name": {
+			SubscriptionID: "sub-1", Usage: storage.CertificateUsageClient, CommonName: webhookca.GatewayCommonName,
+		},
+		"DNS name not allowed": {
+			Usage: storage.CertificateUsageServer, CommonName: "x", DNSNames: []string{"other.example.com"},
+			AllowedDNSNames: []string{"smo.example.com"},
+		},
+		"DNS name from CSR not allowed": {
+			Usage: storage.CertificateUsageServer, CSRPEM: newCSR(t, "x", "other.example.com"),
+			AllowedDNSNames: []string{"smo.example.com"},
+		},
+		"malformed CSR": {Usage: storage.CertificateUsageClient, CSRPEM: "not a csr"},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := ca.Issue(ctx, req)
+			require.ErrorIs(t, err, webhookca.ErrInvalidRequest)
+		})
+	}
+}
+
+func TestRotateAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	ca, store := newTestCA(t)
+
+	old, err := ca.Issue(ctx, webhookca.IssueRequest{
+		SubscriptionID: "sub-1",
+		Usage:          storage.CertificateUsageServer,
+		CommonName:     "sub-1",
+		DNSNames:       []string{"smo.example.com"},
+	})
+	require.NoError(t, err)
+
+	rotated, err := ca.Rotate(ctx, old.Certificate, "", time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, old.Certificate.SerialNumber, rotated.Certificate.SerialNumber)
+	assert.Equal(t, []string{"smo.example.com"}, rotated.Certificate.DNSNames)
+	assert.Equal(t, "sub-1", rotated.Certificate.SubscriptionID)
+
+	stored, err := store.Get(ctx, old.Certificate.SerialNumber)
+	require.NoError(t, err)
+	assert.Equal(t, rotated.Certificate.SerialNumber, stored.ReplacedBy)
+	assert.False(t, stored.Revoked())
+
+	oldCert := parseCertificate(t, old.Certificate.CertificatePEM)
+	revoked, err := ca.IsRevoked(ctx, oldCert)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	stored, err = ca.Revoke(ctx, old.Certificate.SerialNumber, "rotated")
+	require.NoError(t, err)
+	require.True(t, stored.Revoked())
+	assert.Equal(t, "rotated", stored.RevocationReason)
+
+	// Revoking again keeps the original revocation.
+	again, err := ca.Revoke(ctx, old.Certificate.SerialNumber, "other")
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", again.RevocationReason)
+
+	revoked, err = ca.IsRevoked(ctx, oldCert)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	_, err = ca.Rotate(ctx, stored, "", 0)
+	require.ErrorIs(t, err, webhookca.ErrCertificateRevoked)
+	_, err = ca.Revoke(ctx, "ff", "")
+	require.ErrorIs(t, err, storage.ErrCertificateNotFound)
+
+	crlPEM, err := ca.CRL(ctx)
+	require.NoError(t, err)
+	block, _ := pem.Decode([]byte(crlPEM))
+	require.NotNil(t, block)
+	assert.Equal(t, "X509 CRL", block.Type)
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	require.NoError(t, err)
+	require.NoError(t, crl.CheckSignatureFrom(parseCertificate(t, ca.CACertificatePEM())))
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, 0, crl.RevokedCertificateEntries[0].SerialNumber.Cmp(oldCert.SerialNumber))
+
+	// Certificates of other issuers are never revoked.
+	other, _ := newTestCA(t)
+	otherCert := parseCertificate(t, other.CACertificatePEM())
+	revoked, err = ca.IsRevoked(ctx, otherCert)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	ctx := context.Background()
+	ca, _ := newTestCA(t)
+
+	serverCert, err := ca.Issue(ctx, webhookca.IssueRequest{
+		SubscriptionID: "sub-1",
+		Usage:          storage.CertificateUsageServer,
+		CommonName:     "sub-1",
+		DNSNames:       []string{"localhost"},
+	})
+	require.NoError(t, err)
+	pair, err := tls.X509KeyPair([]byte(serverCert.Certificate.CertificatePEM), []byte(serverCert.PrivateKeyPEM))
+	require.NoError(t, err)
+
+	// The endpoint requires a client certificate issued by the CA.
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(parseCertificate(t, ca.CACertificatePEM()))
+	var clientName string
+	endpoint := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientName = r.TLS.PeerCertificates[0].Subject.CommonName
+		w.WriteHeader(http.StatusOK)
+	}))
+	endpoint.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+	endpoint.StartTLS()
+	defer endpoint.Close()
+
+	_, port, err := net.SplitHostPort(endpoint.Listener.Addr().String())
+	require.NoError(t, err)
+	endpointURL := "https://localhost:" + port
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: ca.ClientTLSConfig()}}
+	resp, err := client.Get(endpointURL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, webhookca.GatewayCommonName, clientName)
+
+	// The gateway certificate is reused until it needs renewal.
+	first, err := ca.GatewayCertificate(ctx)
+	require.NoError(t, err)
+	second, err := ca.GatewayCertificate(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// Once revoked, the endpoint's certificate is refused.
+	_, err = ca.Revoke(ctx, serverCert.Certificate.SerialNumber, "key compromise")
+	require.NoError(t, err)
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: ca.ClientTLSConfig()}}
+	_, err = client.Get(endpointURL)
+	require.ErrorIs(t, err, webhookca.ErrCertificateRevoked)
+}
@@ -34,6 +34,16 @@ var (
 		[]string{"subscription_id", "attempt"},
 	)
 
+	// WebhookDeliverabilityScore tracks the deliverability score (0-100) of
+	// each subscription's callback over the deliverability window.
+	WebhookDeliverabilityScore = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "o2ims_webhook_deliverability_score",
+			Help: "Deliverability score (0-100) of the subscription callback over the sliding window",
+		},
+		[]string{"subscription_id"},
+	)
+
 	// DeadLetterQueueTotal tracks the total number of events moved to DLQ.
 	DeadLetterQueueTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -43,6 +53,46 @@ var (
 		[]string{"subscription_id"},
 	)
 
+	// OrderingWaitsTotal counts events held back until the previous event of
+	// the same resource completed, i.e. reorderings that were prevented.
+	OrderingWaitsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_webhook_ordering_waits_total",
+			Help: "Total number of events held back to preserve per-resource delivery order",
+		},
+		[]string{"subscription_id"},
+	)
+
+	// OrderingStaleTotal counts events skipped because a later event of the
+	// same resource had already completed.
+	OrderingStaleTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_webhook_ordering_stale_total",
+			Help: "Total number of events skipped because a later event of the same resource was delivered",
+		},
+		[]string{"subscription_id"},
+	)
+
+	// OrderingTimeoutsTotal counts events delivered without their predecessor
+	// after the ordering timeout.
+	OrderingTimeoutsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_webhook_ordering_timeouts_total",
+			Help: "Total number of events delivered out of order after the ordering timeout",
+		},
+		[]string{"subscription_id"},
+	)
+
+	// WebhookRequeuedTotal tracks events put back on the stream because
+	// shutdown interrupted their delivery.
+	WebhookRequeuedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_webhook_requeued_total",
+			Help: "Total number of events requeued because shutdown interrupted their delivery",
+		},
+		[]string{"subscription_id"},
+	)
+
 	// EventStreamLengthGauge tracks the current length of the event stream.
 	EventStreamLengthGauge = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -58,4 +108,40 @@ var (
 			Help: "Current number of active webhook worker goroutines",
 		},
 	)
+
+	// WebhookBacklogGauge tracks the number of events not yet acknowledged by webhook workers.
+	WebhookBacklogGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "o2ims_webhook_backlog",
+			Help: "Number of events pending or not yet delivered to webhook workers",
+		},
+	)
+
+	// WebhookAutoscaleDecisionsTotal counts the scaling actions of the webhook
+	// worker autoscaler by direction (up or down).
+	WebhookAutoscaleDecisionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "o2ims_webhook_autoscale_decisions_total",
+			Help: "Total number of webhook worker scaling actions by direction",
+		},
+		[]string{"direction"},
+	)
+
+	// WebhookAutoscaleLatencyGauge tracks the mean delivery latency seen by
+	// the webhook worker autoscaler at its last check.
+	WebhookAutoscaleLatencyGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "o2ims_webhook_autoscale_latency_seconds",
+			Help: "Mean webhook delivery latency between the last two autoscaler checks",
+		},
+	)
+
+	// WebhookClaimedTotal counts the events delivered after they were left
+	// pending by a removed or vanished consumer.
+	WebhookClaimedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "o2ims_webhook_claimed_total",
+			Help: "Total number of pending webhook events claimed from removed or idle consumers",
+		},
+	)
 )
@@ -0,0 +1,349 @@
+package workers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// QueueSnapshotVersion is the format version of queue snapshot files.
+	QueueSnapshotVersion = 1
+
+	// ImportedEventsKey is the Redis set of the original stream IDs of the
+	// events replayed from queue snapshots, so that an event is replayed once
+	// even when several replicas import a snapshot of the same queue.
+	ImportedEventsKey = "o2ims:events:imported"
+
+	// importedEventsTTL bounds the lifetime of ImportedEventsKey.
+	importedEventsTTL = 7 * 24 * time.Hour
+
+	// pendingPageSize is the number of pending entries read per XPENDING call.
+	pendingPageSize = 1000
+)
+
+// ErrCorruptSnapshot is returned for a queue snapshot that fails its
+// integrity checks. The file is left in place.
+var ErrCorruptSnapshot = errors.New("corrupt queue snapshot")
+
+// QueueSnapshot is the file format of an exported webhook delivery queue.
+type QueueSnapshot struct {
+	// Version is QueueSnapshotVersion.
+	Version int `json:"version"`
+
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Stream is the stream the entries were read from.
+	Stream string `json:"stream"`
+
+	// Count is the number of entries.
+	Count int `json:"count"`
+
+	// Checksum is the hex-encoded SHA-256 of the JSON encoding of Entries.
+	Checksum string `json:"checksum"`
+
+	// Entries are the undelivered events, oldest first.
+	Entries []QueueEntry `json:"entries"`
+}
+
+// QueueEntry is one undelivered event of a queue snapshot.
+type QueueEntry struct {
+	// ID is the stream ID of the event when it was exported.
+	ID string `json:"id"`
+
+	// Values are the fields of the stream message.
+	Values map[string]string `json:"values"`
+}
+
+// QueueImport reports the outcome of a snapshot import.
+type QueueImport struct {
+	// Imported is the number of events added to the stream.
+	Imported int
+
+	// Skipped is the number of events still on the stream or already
+	// replayed from another snapshot.
+	Skipped int
+}
+
+// ExportQueue writes the events of the webhook delivery queue that were not
+// delivered yet to a snapshot file at path: the events no worker has read
+// and the events read but not acknowledged. The file is replaced
+// atomically. It returns the number of exported events.
+func ExportQueue(ctx context.Context, client redis.UniversalClient, path string) (int, error) {
+	entries, err := undeliveredEntries(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	checksum, err := entriesChecksum(entries)
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(&QueueSnapshot{
+		Version:   QueueSnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		Stream:    EventStreamKey,
+		Count:     len(entries),
+		Checksum:  checksum,
+		Entries:   entries,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode queue snapshot: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return 0, fmt.Errorf("failed to write queue snapshot: %w", err)
+	}
+	return len(entries), nil
+}
+
+// ImportQueue replays the events of the snapshot file at path onto the
+// webhook delivery queue. Events still on the stream, because the queue
+// survived, and events already replayed are skipped. After a successful
+// import the file is renamed with the suffix ".imported" so that it is not
+// replayed again. A file failing the integrity checks is not imported and
+// ErrCorruptSnapshot is returned.
+func ImportQueue(ctx context.Context, client redis.UniversalClient, path string) (*QueueImport, error) {
+	snapshot, err := ReadQueueSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueueImport{}
+	for _, entry := range snapshot.Entries {
+		kept, err := client.XRange(ctx, EventStreamKey, entry.ID, entry.ID).Result()
+		if err != nil {
+			return result, fmt.Errorf("failed to check queued event %s: %w", entry.ID, err)
+		}
+		if len(kept) > 0 {
+			result.Skipped++
+			continue
+		}
+		added, err := client.SAdd(ctx, ImportedEventsKey, entry.ID).Result()
+		if err != nil {
+			return result, fmt.Errorf("failed to record replayed event %s: %w", entry.ID, err)
+		}
+		if added == 0 {
+			result.Skipped++
+			continue
+		}
+
+		values := make(map[string]interface{}, len(entry.Values))
+		for k, v := range entry.Values {
+			values[k] = v
+		}
+		if err := client.XAdd(ctx, &redis.XAddArgs{Stream: EventStreamKey, Values: values}).Err(); err != nil {
+			// Let a later import retry the event
+			client.SRem(context.WithoutCancel(ctx), ImportedEventsKey, entry.ID)
+			return result, fmt.Errorf("failed to replay event %s: %w", entry.ID, err)
+		}
+		result.Imported++
+	}
+	client.Expire(ctx, ImportedEventsKey, importedEventsTTL)
+
+	if err := os.Rename(path, path+".imported"); err != nil {
+		return result, fmt.Errorf("failed to mark queue snapshot as imported: %w", err)
+	}
+	return result, nil
+}
+
+// ReadQueueSnapshot reads the snapshot file at path and verifies its
+// version, entry count and checksum.
+func ReadQueueSnapshot(path string) (*QueueSnapshot, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- operator-configured path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue snapshot: %w", err)
+	}
+	var snapshot QueueSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptSnapshot, err)
+	}
+	if snapshot.Version != QueueSnapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrCorruptSnapshot, snapshot.Version)
+	}
+	if snapshot.Stream != EventStreamKey {
+		return nil, fmt.Errorf("%w: snapshot of stream %q", ErrCorruptSnapshot, snapshot.Stream)
+	}
+	if snapshot.Count != len(snapshot.Entries) {
+		return nil, fmt.Errorf("%w: %d entries, expected %d",
+			ErrCorruptSnapshot, len(snapshot.Entries), snapshot.Count)
+	}
+	checksum, err := entriesChecksum(snapshot.Entries)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != snapshot.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCorruptSnapshot)
+	}
+	for _, entry := range snapshot.Entries {
+		if _, _, err := parseStreamID(entry.ID); err != nil || entry.Values["event"] == "" {
+			return nil, fmt.Errorf("%w: invalid entry %q", ErrCorruptSnapshot, entry.ID)
+		}
+	}
+	return &snapshot, nil
+}
+
+// undeliveredEntries returns the entries of the event stream after the last
+// entry delivered to the consumer group, and the pending entries delivered
+// but not acknowledged, oldest first.
+func undeliveredEntries(ctx context.Context, client redis.UniversalClient) ([]QueueEntry, error) {
+	length, err := client.XLen(ctx, EventStreamKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event stream length: %w", err)
+	}
+	if length == 0 {
+		return []QueueEntry{}, nil
+	}
+
+	groups, err := client.XInfoGroups(ctx, EventStreamKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read consumer groups: %w", err)
+	}
+	lastDelivered := ""
+	for _, group := range groups {
+		if group.Name == ConsumerGroup {
+			lastDelivered = group.LastDeliveredID
+		}
+	}
+
+	byID := make(map[string]QueueEntry)
+	start := "-"
+	if lastDelivered != "" {
+		start = lastDelivered
+	}
+	messages, err := client.XRange(ctx, EventStreamKey, start, "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event stream: %w", err)
+	}
+	for _, msg := range messages {
+		if msg.ID != lastDelivered {
+			byID[msg.ID] = queueEntry(msg)
+		}
+	}
+
+	if lastDelivered != "" {
+		if err := addPendingEntries(ctx, client, byID); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := make([]QueueEntry, 0, len(byID))
+	for _, entry := range byID {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return streamIDLess(entries[i].ID, entries[j].ID) })
+	return entries, nil
+}
+
+// addPendingEntries adds the entries delivered to the consumer group but not
+// acknowledged to byID.
+func addPendingEntries(ctx context.Context, client redis.UniversalClient, byID map[string]QueueEntry) error {
+	start := "-"
+	for {
+		pending, err := client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: EventStreamKey,
+			Group:  ConsumerGroup,
+			Start:  start,
+			End:    "+",
+			Count:  pendingPageSize,
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read pending events: %w", err)
+		}
+		for _, p := range pending {
+			messages, err := client.XRange(ctx, EventStreamKey, p.ID, p.ID).Result()
+			if err != nil {
+				return fmt.Errorf("failed to read pending event %s: %w", p.ID, err)
+			}
+			// Trimmed entries have no values left to export
+			for _, msg := range messages {
+				byID[msg.ID] = queueEntry(msg)
+			}
+		}
+		if len(pending) < pendingPageSize {
+			return nil
+		}
+		start = "(" + pending[len(pending)-1].ID
+	}
+}
+
+// queueEntry converts a stream message to a snapshot entry.
+func queueEntry(msg redis.XMessage) QueueEntry {
+	values := make(map[string]string, len(msg.Values))
+	for k, v := range msg.Values {
+		values[k] = fmt.Sprint(v)
+	}
+	return QueueEntry{ID: msg.ID, Values: values}
+}
+
+// entriesChecksum returns the hex-encoded SHA-256 of the JSON encoding of
+// entries. Map keys are encoded in sorted order, so the encoding is stable.
+func entriesChecksum(entries []QueueEntry) (string, error) {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode queue entries: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// parseStreamID splits a stream ID "ms-seq" into its parts.
+func parseStreamID(id string) (uint64, uint64, error) {
+	msPart, seqPart, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid stream ID %q", id)
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stream ID %q: %w", id, err)
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stream ID %q: %w", id, err)
+	}
+	return ms, seq, nil
+}
+
+// streamIDLess orders stream IDs as Redis does.
+func streamIDLess(a, b string) bool {
+	aMs, aSeq, _ := parseStreamID(a)
+	bMs, bSeq, _ := parseStreamID(b)
+	if aMs != bMs {
+		return aMs < bMs
+	}
+	return aSeq < bSeq
+}
+
+// writeFileAtomic writes data to a temporary file next to path, readable by
+// the owner only, and renames it to path once it is synced.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,116 @@
+package workers_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/internal/workers"
+)
+
+func addEvent(t *testing.T, rdb *redis.Client, event string) string {
+	t.Helper()
+	id, err := rdb.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: workers.EventStreamKey,
+		Values: map[string]interface{}{"event": event},
+	}).Result()
+	require.NoError(t, err)
+	return id
+}
+
+func streamEvents(t *testing.T, rdb *redis.Client) []string {
+	t.Helper()
+	messages, err := rdb.XRange(context.Background(), workers.EventStreamKey, "-", "+").Result()
+	require.NoError(t, err)
+	events := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		events = append(events, msg.Values["event"].(string))
+	}
+	return events
+}
+
+func TestQueueSnapshot(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	// e1 is delivered and acknowledged, e2 is delivered but not acknowledged,
+	// e3 has not been read yet.
+	require.NoError(t, rdb.XGroupCreateMkStream(ctx, workers.EventStreamKey, workers.ConsumerGroup, "0").Err())
+	id1 := addEvent(t, rdb, `{"n":1}`)
+	addEvent(t, rdb, `{"n":2}`)
+	_, err := rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group: workers.ConsumerGroup, Consumer: "c", Streams: []string{workers.EventStreamKey, ">"}, Count: 2,
+	}).Result()
+	require.NoError(t, err)
+	require.NoError(t, rdb.XAck(ctx, workers.EventStreamKey, workers.ConsumerGroup, id1).Err())
+	addEvent(t, rdb, `{"n":3}`)
+
+	path := filepath.Join(t.TempDir(), "queue.json")
+	count, err := workers.ExportQueue(ctx, rdb, path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	t.Run("replays onto a new Redis once", func(t *testing.T) {
+		snapshot := filepath.Join(t.TempDir(), "queue.json")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(snapshot, data, 0o600))
+
+		fresh := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+		t.Cleanup(func() { _ = fresh.Close() })
+
+		result, err := workers.ImportQueue(ctx, fresh, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, []string{`{"n":2}`, `{"n":3}`}, streamEvents(t, fresh))
+
+		// The snapshot is renamed, and a copy of it is not replayed twice.
+		_, err = os.Stat(snapshot)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.NoError(t, os.WriteFile(snapshot, data, 0o600))
+		result, err = workers.ImportQueue(ctx, fresh, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Imported)
+		assert.Equal(t, 2, result.Skipped)
+	})
+
+	t.Run("skips events still queued", func(t *testing.T) {
+		snapshot := filepath.Join(t.TempDir(), "queue.json")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(snapshot, data, 0o600))
+
+		result, err := workers.ImportQueue(ctx, rdb, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Imported)
+		assert.Equal(t, 2, result.Skipped)
+	})
+
+	t.Run("rejects a tampered snapshot", func(t *testing.T) {
+		var snapshot workers.QueueSnapshot
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &snapshot))
+		snapshot.Entries[0].Values["event"] = `{"n":99}`
+		data, err = json.Marshal(&snapshot)
+		require.NoError(t, err)
+
+		tampered := filepath.Join(t.TempDir(), "queue.json")
+		require.NoError(t, os.WriteFile(tampered, data, 0o600))
+		_, err = workers.ImportQueue(ctx, rdb, tampered)
+		require.ErrorIs(t, err, workers.ErrCorruptSnapshot)
+
+		// The file is kept for inspection.
+		_, err = os.Stat(tampered)
+		require.NoError(t, err)
+	})
+}
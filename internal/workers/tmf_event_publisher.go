@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/piwi3910/netweave/internal/models"
+	"github.com/piwi3910/netweave/internal/resolver"
 )
 
 // TMFEventPublisher publishes TMF688 events to registered webhooks.
@@ -51,7 +52,8 @@ func NewTMFEventPublisher(logger *zap.Logger, config *TMFEventPublisherConfig) *
 
 	return &TMFEventPublisher{
 		client: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: resolver.NewTransport(),
 		},
 		logger:  logger,
 		timeout: config.Timeout,
@@ -109,6 +111,9 @@ func (p *TMFEventPublisher) PublishEventWithRetry(
 	maxRetries int,
 	retryDelay time.Duration,
 ) error {
+	// All attempts connect to the callback addresses of the first resolution.
+	ctx = resolver.Pin(ctx)
+
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
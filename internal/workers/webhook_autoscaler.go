@@ -0,0 +1,386 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultAutoscaleMinWorkers is the default lower bound of the worker pool.
+	DefaultAutoscaleMinWorkers = 1
+
+	// DefaultAutoscaleMaxWorkers is the default upper bound of the worker pool.
+	DefaultAutoscaleMaxWorkers = 50
+
+	// DefaultAutoscaleTargetBacklogPerWorker is the default number of undelivered
+	// events each worker is expected to handle.
+	DefaultAutoscaleTargetBacklogPerWorker = 100
+
+	// DefaultAutoscaleInterval is the default interval between backlog checks.
+	DefaultAutoscaleInterval = 15 * time.Second
+
+	// DefaultAutoscaleScaleDownCooldown is the default minimum time between
+	// a scaling action and a subsequent scale-down.
+	DefaultAutoscaleScaleDownCooldown = 2 * time.Minute
+
+	// DefaultAutoscaleTargetLatency is the default mean delivery latency
+	// above which a backlog gets more workers than its size alone calls for.
+	DefaultAutoscaleTargetLatency = 2 * time.Second
+
+	// DefaultAutoscaleClaimIdle is the default time after which an event read
+	// but not acknowledged by a consumer is claimed and delivered again.
+	DefaultAutoscaleClaimIdle = 10 * time.Minute
+
+	// claimBatchSize is the number of pending events claimed at a time.
+	claimBatchSize = 10
+)
+
+// Autoscaling directions, the direction label of
+// o2ims_webhook_autoscale_decisions_total.
+const (
+	scaleUp   = "up"
+	scaleDown = "down"
+)
+
+// AutoscaleConfig configures autoscaling of the webhook worker pool by backlog
+// and delivery latency.
+//
+// The desired pool size is ceil(backlog / TargetBacklogPerWorker), or one more
+// worker than the pool has while there is a backlog and the mean delivery
+// latency exceeds TargetLatency, clamped to [MinWorkers, MaxWorkers]. Scale-up
+// happens immediately; scale-down removes one worker at a time and only after
+// ScaleDownCooldown has elapsed since the last scaling action, so short bursts
+// do not cause the pool to flap.
+type AutoscaleConfig struct {
+	// Enabled turns autoscaling on. When false the pool stays at WorkerCount.
+	Enabled bool
+
+	// MinWorkers is the minimum pool size (default: 1).
+	MinWorkers int
+
+	// MaxWorkers is the maximum pool size (default: 50).
+	MaxWorkers int
+
+	// TargetBacklogPerWorker is the backlog each worker should handle (default: 100).
+	TargetBacklogPerWorker int64
+
+	// Interval is how often the backlog is checked (default: 15s).
+	Interval time.Duration
+
+	// ScaleDownCooldown is the minimum time between scaling actions before
+	// scaling down (default: 2m).
+	ScaleDownCooldown time.Duration
+
+	// TargetLatency is the mean delivery latency the pool should keep while
+	// there is a backlog (default: 2s).
+	TargetLatency time.Duration
+
+	// ClaimIdle is how long an event may stay read but unacknowledged, for
+	// example by a consumer of a replica that crashed, before the autoscaler
+	// claims and delivers it (default: 10m). It must exceed the time a
+	// delivery with all its retries can take.
+	ClaimIdle time.Duration
+}
+
+// withDefaults returns a copy of c with zero values replaced by defaults.
+func (c *AutoscaleConfig) withDefaults() *AutoscaleConfig {
+	out := *c
+	if out.MinWorkers == 0 {
+		out.MinWorkers = DefaultAutoscaleMinWorkers
+	}
+	if out.MaxWorkers == 0 {
+		out.MaxWorkers = DefaultAutoscaleMaxWorkers
+	}
+	if out.TargetBacklogPerWorker == 0 {
+		out.TargetBacklogPerWorker = DefaultAutoscaleTargetBacklogPerWorker
+	}
+	if out.Interval == 0 {
+		out.Interval = DefaultAutoscaleInterval
+	}
+	if out.ScaleDownCooldown == 0 {
+		out.ScaleDownCooldown = DefaultAutoscaleScaleDownCooldown
+	}
+	if out.TargetLatency == 0 {
+		out.TargetLatency = DefaultAutoscaleTargetLatency
+	}
+	if out.ClaimIdle == 0 {
+		out.ClaimIdle = DefaultAutoscaleClaimIdle
+	}
+	return &out
+}
+
+// Validate checks the autoscale configuration for consistency.
+func (c *AutoscaleConfig) Validate() error {
+	if c.MinWorkers < 1 {
+		return errors.New("min workers must be at least 1")
+	}
+	if c.MaxWorkers < c.MinWorkers {
+		return fmt.Errorf("max workers (%d) must be >= min workers (%d)", c.MaxWorkers, c.MinWorkers)
+	}
+	if c.TargetBacklogPerWorker < 1 {
+		return errors.New("target backlog per worker must be at least 1")
+	}
+	if c.Interval < 0 || c.ScaleDownCooldown < 0 || c.TargetLatency < 0 || c.ClaimIdle < 0 {
+		return errors.New("interval, scale-down cooldown, target latency and claim idle must not be negative")
+	}
+	return nil
+}
+
+// clamp bounds n to [MinWorkers, MaxWorkers].
+func (c *AutoscaleConfig) clamp(n int) int {
+	return min(max(n, c.MinWorkers), c.MaxWorkers)
+}
+
+// DesiredWorkers returns the pool size the autoscaler should move to given the
+// current size, the observed backlog, the mean delivery latency since the last
+// check (0 if nothing was delivered), and the time since the last scaling action.
+func (c *AutoscaleConfig) DesiredWorkers(current int, backlog int64, latency, sinceLastScale time.Duration) int {
+	target := int((backlog + c.TargetBacklogPerWorker - 1) / c.TargetBacklogPerWorker)
+	// Slow callbacks hold workers longer, so a backlog delivered slower than
+	// the target needs more workers than its size alone calls for.
+	if backlog > 0 && c.TargetLatency > 0 && latency > c.TargetLatency {
+		target = max(target, current+1)
+	}
+	target = c.clamp(target)
+
+	switch {
+	case target > current:
+		return target
+	case target < current && sinceLastScale >= c.ScaleDownCooldown:
+		return c.clamp(current - 1)
+	default:
+		return c.clamp(current)
+	}
+}
+
+// Backlog returns the number of events not yet acknowledged by the consumer group:
+// entries not yet delivered to any consumer (lag) plus delivered but unacknowledged
+// entries (pending).
+func (w *WebhookWorker) Backlog(ctx context.Context) (int64, error) {
+	groups, err := w.redisClient.XInfoGroups(ctx, EventStreamKey).Result()
+	if err != nil {
+		// The stream does not exist until the first event is published.
+		if errors.Is(err, redis.Nil) || strings.Contains(err.Error(), "no such key") {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get consumer group info: %w", err)
+	}
+
+	for _, group := range groups {
+		if group.Name != ConsumerGroup {
+			continue
+		}
+		return max(group.Lag, 0) + group.Pending, nil
+	}
+	return 0, nil
+}
+
+// ActiveWorkers returns the number of worker goroutines currently running.
+func (w *WebhookWorker) ActiveWorkers() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.consumers)
+}
+
+// scaleTo starts or stops worker goroutines until the pool has n workers.
+// Stopped workers finish the event they are processing and deliver the events
+// still pending for their consumer before exiting (see releaseConsumer).
+func (w *WebhookWorker) scaleTo(ctx context.Context, n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for len(w.consumers) < n {
+		quit := make(chan struct{})
+		name := fmt.Sprintf("%s-worker-%d", w.consumerPrefix, w.consumerSeq)
+		w.consumerSeq++
+		w.consumers = append(w.consumers, quit)
+
+		w.wg.Add(1)
+		go w.processEvents(ctx, name, quit)
+	}
+
+	for len(w.consumers) > n {
+		last := len(w.consumers) - 1
+		close(w.consumers[last])
+		w.consumers = w.consumers[:last]
+	}
+
+	ActiveWorkersGauge.Set(float64(len(w.consumers)))
+}
+
+// runAutoscaler periodically adjusts the worker pool to the stream backlog and
+// the delivery latency, and claims events left pending by consumers that are
+// gone.
+func (w *WebhookWorker) runAutoscaler(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.autoscale.Interval)
+	defer ticker.Stop()
+
+	lastScale := time.Now()
+
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Claimed events are delivered beside the pool, one batch at a
+			// time, so that slow deliveries do not hold up scaling.
+			if w.claiming.CompareAndSwap(false, true) {
+				w.wg.Add(1)
+				go func() {
+					defer w.wg.Done()
+					defer w.claiming.Store(false)
+					w.claimIdle(ctx)
+				}()
+			}
+
+			backlog, err := w.Backlog(ctx)
+			if err != nil {
+				w.logger.Warn("failed to read webhook backlog", zap.Error(err))
+				continue
+			}
+			WebhookBacklogGauge.Set(float64(backlog))
+
+			latency := w.takeMeanLatency()
+			WebhookAutoscaleLatencyGauge.Set(latency.Seconds())
+
+			current := w.ActiveWorkers()
+			desired := w.autoscale.DesiredWorkers(current, backlog, latency, time.Since(lastScale))
+			if desired == current {
+				continue
+			}
+
+			direction := scaleUp
+			if desired < current {
+				direction = scaleDown
+			}
+			WebhookAutoscaleDecisionsTotal.WithLabelValues(direction).Inc()
+			w.logger.Info("scaling webhook workers",
+				zap.Int("from", current),
+				zap.Int("to", desired),
+				zap.Int64("backlog", backlog),
+				zap.Duration("mean_latency", latency))
+
+			w.scaleTo(ctx, desired)
+			lastScale = time.Now()
+		}
+	}
+}
+
+// observeLatency adds a delivery to the mean latency read by the autoscaler.
+func (w *WebhookWorker) observeLatency(d time.Duration) {
+	w.latencySum.Add(int64(d))
+	w.latencyCount.Add(1)
+}
+
+// takeMeanLatency returns the mean latency of the deliveries since the last
+// call, or 0 if there were none.
+func (w *WebhookWorker) takeMeanLatency() time.Duration {
+	count := w.latencyCount.Swap(0)
+	sum := w.latencySum.Swap(0)
+	if count == 0 {
+		return 0
+	}
+	return time.Duration(sum / count)
+}
+
+// releaseConsumer delivers the events still pending for a consumer removed by
+// a scale-down, such as events whose acknowledgement failed, and then deletes
+// the consumer from the group. Consumers are named after the replica, so no
+// other worker reads these events and they would otherwise be stranded. The
+// consumer is kept if its events cannot all be delivered; claimIdle picks
+// them up later.
+func (w *WebhookWorker) releaseConsumer(ctx context.Context, name string) {
+	logger := w.logger.With(zap.String("consumer", name))
+
+	// Reading from an ID other than ">" returns the consumer's own pending
+	// events after that ID.
+	lastID := "0"
+	for ctx.Err() == nil {
+		streams, err := w.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    ConsumerGroup,
+			Consumer: name,
+			Streams:  []string{EventStreamKey, lastID},
+			Count:    claimBatchSize,
+		}).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			logger.Warn("failed to read pending events of removed consumer", zap.Error(err))
+			return
+		}
+		if len(streams) == 0 || len(streams[0].Messages) == 0 {
+			break
+		}
+		for _, msg := range streams[0].Messages {
+			w.handleClaimed(ctx, name, msg)
+			lastID = msg.ID
+		}
+	}
+
+	pending, err := w.redisClient.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   EventStreamKey,
+		Group:    ConsumerGroup,
+		Consumer: name,
+		Start:    "-",
+		End:      "+",
+		Count:    1,
+	}).Result()
+	if err != nil || len(pending) > 0 {
+		logger.Warn("keeping removed consumer with pending events", zap.Error(err))
+		return
+	}
+	if err := w.redisClient.XGroupDelConsumer(ctx, EventStreamKey, ConsumerGroup, name).Err(); err != nil {
+		logger.Warn("failed to delete removed consumer", zap.Error(err))
+	}
+}
+
+// claimIdle claims the events that have been read but not acknowledged for
+// longer than ClaimIdle, for example by a consumer of a replica that crashed
+// or by a removed consumer that could not deliver them, and delivers them.
+func (w *WebhookWorker) claimIdle(ctx context.Context) {
+	name := w.consumerPrefix + "-claimer"
+	start := "0-0"
+	for ctx.Err() == nil {
+		msgs, next, err := w.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   EventStreamKey,
+			Group:    ConsumerGroup,
+			Consumer: name,
+			MinIdle:  w.autoscale.ClaimIdle,
+			Start:    start,
+			Count:    claimBatchSize,
+		}).Result()
+		if err != nil {
+			// The stream does not exist until the first event is published.
+			if !errors.Is(err, redis.Nil) && !strings.Contains(err.Error(), "no such key") {
+				w.logger.Warn("failed to claim idle webhook events", zap.Error(err))
+			}
+			return
+		}
+		for _, msg := range msgs {
+			w.handleClaimed(ctx, name, msg)
+		}
+		if next == "0-0" || next == "" {
+			return
+		}
+		start = next
+	}
+}
+
+// handleClaimed delivers an event claimed from another consumer or left
+// pending by a removed one.
+func (w *WebhookWorker) handleClaimed(ctx context.Context, consumer string, msg redis.XMessage) {
+	WebhookClaimedTotal.Inc()
+	if err := w.HandleMessage(w.deliveryCtx, consumer, msg); err != nil {
+		w.logger.Error("failed to handle claimed message",
+			zap.String("message_id", msg.ID),
+			zap.Error(err))
+	}
+}
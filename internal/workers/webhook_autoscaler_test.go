@@ -0,0 +1,318 @@
+package workers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/workers"
+)
+
+func TestAutoscaleConfig_DesiredWorkers(t *testing.T) {
+	cfg := &workers.AutoscaleConfig{
+		MinWorkers:             2,
+		MaxWorkers:             10,
+		TargetBacklogPerWorker: 100,
+		ScaleDownCooldown:      time.Minute,
+		TargetLatency:          2 * time.Second,
+	}
+
+	tests := []struct {
+		name      string
+		current   int
+		backlog   int64
+		latency   time.Duration
+		sinceLast time.Duration
+		want      int
+	}{
+		{name: "empty backlog keeps minimum", current: 2, backlog: 0, sinceLast: time.Hour, want: 2},
+		{name: "scale up immediately", current: 2, backlog: 550, sinceLast: 0, want: 6},
+		{name: "scale up capped at max", current: 2, backlog: 5000, sinceLast: 0, want: 10},
+		{name: "no scale down during cooldown", current: 6, backlog: 0, sinceLast: 30 * time.Second, want: 6},
+		{name: "scale down one step after cooldown", current: 6, backlog: 0, sinceLast: 2 * time.Minute, want: 5},
+		{name: "steady state", current: 3, backlog: 300, sinceLast: time.Hour, want: 3},
+		{name: "current below minimum is raised", current: 0, backlog: 0, sinceLast: 0, want: 2},
+		{name: "slow deliveries add a worker", current: 3, backlog: 300, latency: 5 * time.Second, want: 4},
+		{name: "slow deliveries block scale down", current: 6, backlog: 100, latency: 5 * time.Second,
+			sinceLast: time.Hour, want: 7},
+		{name: "slow deliveries without backlog", current: 3, backlog: 0, latency: 5 * time.Second,
+			sinceLast: time.Hour, want: 2},
+		{name: "fast deliveries", current: 3, backlog: 300, latency: time.Second, sinceLast: time.Hour, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.DesiredWorkers(tt.current, tt.backlog, tt.latency, tt.sinceLast))
+		})
+	}
+}
+
+func TestAutoscaleConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&workers.AutoscaleConfig{MinWorkers: 1, MaxWorkers: 1, TargetBacklogPerWorker: 1}).Validate())
+	assert.Error(t, (&workers.AutoscaleConfig{MinWorkers: 0, MaxWorkers: 1, TargetBacklogPerWorker: 1}).Validate())
+	assert.Error(t, (&workers.AutoscaleConfig{MinWorkers: 5, MaxWorkers: 2, TargetBacklogPerWorker: 1}).Validate())
+	assert.Error(t, (&workers.AutoscaleConfig{MinWorkers: 1, MaxWorkers: 2, TargetBacklogPerWorker: 0}).Validate())
+	assert.Error(t, (&workers.AutoscaleConfig{
+		MinWorkers: 1, MaxWorkers: 2, TargetBacklogPerWorker: 1, ClaimIdle: -time.Second,
+	}).Validate())
+}
+
+func TestNewWebhookWorker_Autoscale(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		WorkerCount: 100,
+		Autoscale:   &workers.AutoscaleConfig{Enabled: true, MaxWorkers: 8},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 8, worker.WorkerCount, "initial pool is clamped to max workers")
+
+	_, err = workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		Autoscale:   &workers.AutoscaleConfig{Enabled: true, MinWorkers: 5, MaxWorkers: 2},
+	})
+	require.Error(t, err)
+}
+
+func TestWebhookWorker_Backlog(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	backlog, err := worker.Backlog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), backlog, "missing stream has no backlog")
+
+	require.NoError(t, worker.CreateConsumerGroup(ctx))
+	for range 5 {
+		require.NoError(t, rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: workers.EventStreamKey,
+			Values: map[string]interface{}{"event": "{}"},
+		}).Err())
+	}
+
+	// Deliver two entries without acknowledging them: they remain pending.
+	_, err = rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    workers.ConsumerGroup,
+		Consumer: "test-consumer",
+		Streams:  []string{workers.EventStreamKey, ">"},
+		Count:    2,
+	}).Result()
+	require.NoError(t, err)
+
+	// Redis reports lag 3 + pending 2; miniredis approximates lag as the stream
+	// length, so only a lower bound is asserted here.
+	backlog, err = worker.Backlog(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, backlog, int64(5))
+}
+
+func TestWebhookWorker_AutoscaleStartStop(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		WorkerCount: 1,
+		Autoscale: &workers.AutoscaleConfig{
+			Enabled:                true,
+			MaxWorkers:             4,
+			TargetBacklogPerWorker: 1,
+			Interval:               10 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Hold three entries pending under an idle consumer so the backlog stays put.
+	require.NoError(t, worker.CreateConsumerGroup(ctx))
+	for range 3 {
+		require.NoError(t, rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: workers.EventStreamKey,
+			Values: map[string]interface{}{"event": "{}"},
+		}).Err())
+	}
+	_, err = rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    workers.ConsumerGroup,
+		Consumer: "idle-consumer",
+		Streams:  []string{workers.EventStreamKey, ">"},
+		Count:    3,
+	}).Result()
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- worker.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return worker.ActiveWorkers() >= 3 }, 2*time.Second, 10*time.Millisecond)
+	assert.LessOrEqual(t, worker.ActiveWorkers(), 4, "pool never exceeds max workers")
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("worker did not stop")
+	}
+	assert.Equal(t, 0, worker.ActiveWorkers())
+}
+
+// addPendingEvents adds n events for callback to the stream and reads them as
+// consumer without acknowledging them.
+func addPendingEvents(t *testing.T, rdb *redis.Client, consumer, callback string, n int) {
+	t.Helper()
+	ctx := context.Background()
+	for i := range n {
+		event, err := json.Marshal(&controllers.ResourceEvent{
+			SubscriptionID: "sub-1",
+			EventType:      "o2ims.Resource.Updated",
+			NotificationID: fmt.Sprintf("n-%d", i),
+			CallbackURL:    callback,
+		})
+		require.NoError(t, err)
+		require.NoError(t, rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: workers.EventStreamKey,
+			Values: map[string]interface{}{"event": string(event)},
+		}).Err())
+	}
+	_, err := rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    workers.ConsumerGroup,
+		Consumer: consumer,
+		Streams:  []string{workers.EventStreamKey, ">"},
+		Count:    int64(n),
+	}).Result()
+	require.NoError(t, err)
+}
+
+// pendingCount returns the number of events pending for consumer.
+func pendingCount(t *testing.T, rdb *redis.Client, consumer string) int {
+	t.Helper()
+	pending, err := rdb.XPendingExt(context.Background(), &redis.XPendingExtArgs{
+		Stream:   workers.EventStreamKey,
+		Group:    workers.ConsumerGroup,
+		Consumer: consumer,
+		Start:    "-",
+		End:      "+",
+		Count:    100,
+	}).Result()
+	require.NoError(t, err)
+	return len(pending)
+}
+
+func TestWebhookWorker_AutoscaleReleasesRemovedConsumers(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delivered.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		WorkerCount: 2,
+		Autoscale: &workers.AutoscaleConfig{
+			Enabled:           true,
+			MaxWorkers:        2,
+			Interval:          10 * time.Millisecond,
+			ScaleDownCooldown: time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Leave two events pending for the consumer that the scale-down removes.
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	removed := host + "-worker-1"
+	require.NoError(t, worker.CreateConsumerGroup(ctx))
+	addPendingEvents(t, rdb, removed, server.URL, 2)
+
+	done := make(chan error, 1)
+	go func() { done <- worker.Start(ctx) }()
+
+	// The removed worker notices the scale-down once its blocking read ends.
+	require.Eventually(t, func() bool {
+		return worker.ActiveWorkers() == 1 && delivered.Load() == 2 && pendingCount(t, rdb, removed) == 0
+	}, 15*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestWebhookWorker_AutoscaleClaimsIdleEvents(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		delivered.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		WorkerCount: 1,
+		Autoscale: &workers.AutoscaleConfig{
+			Enabled:   true,
+			Interval:  10 * time.Millisecond,
+			ClaimIdle: 50 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Events read by a consumer of a replica that is gone.
+	require.NoError(t, worker.CreateConsumerGroup(ctx))
+	addPendingEvents(t, rdb, "gone-worker-0", server.URL, 3)
+
+	done := make(chan error, 1)
+	go func() { done <- worker.Start(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return delivered.Load() == 3 && pendingCount(t, rdb, "gone-worker-0") == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -7,19 +7,29 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	redis "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/resolver"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/timeutil"
+	"github.com/piwi3910/netweave/internal/transform"
+	"github.com/piwi3910/netweave/internal/webhookca"
 )
 
 const (
@@ -49,12 +59,43 @@ const (
 
 	// DeliverySuccessStatus is the HTTP status indicating successful delivery.
 	DeliverySuccessStatus = 200
+
+	// DeliveredKeyPrefix prefixes the Redis keys holding the sequence number
+	// of the last event completed (delivered or moved to the DLQ) per
+	// ordering key.
+	DeliveredKeyPrefix = "o2ims:events:delivered:"
+
+	// DefaultOrderingTimeout is how long an event waits for its predecessor
+	// before it is delivered anyway.
+	DefaultOrderingTimeout = 2 * time.Minute
+
+	// signingKeysCacheTTL is how long rotated signing keys are cached before
+	// the worker reloads them from the store.
+	signingKeysCacheTTL = 5 * time.Second
+
+	// orderingPollInterval is how often a waiting event checks whether its
+	// predecessor has completed.
+	orderingPollInterval = 50 * time.Millisecond
+
+	// requeueTimeout bounds putting an interrupted event back on the stream
+	// during shutdown.
+	requeueTimeout = 5 * time.Second
 )
 
+// markDeliveredScript records a completed sequence number unless a later one
+// was already recorded.
+var markDeliveredScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) > current then
+	redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[2])
+end
+return 0
+`)
+
 // WebhookWorker processes webhook notifications from Redis Stream.
 type WebhookWorker struct {
 	// redisClient is used for stream operations.
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 
 	// httpClient is used for webhook delivery.
 	HTTPClient *http.Client
@@ -77,17 +118,69 @@ type WebhookWorker struct {
 	// hmacSecret is the secret key for HMAC signature generation.
 	HMACSecret string
 
+	// orderingTimeout bounds how long an event waits for its predecessor.
+	orderingTimeout time.Duration
+
+	// signingKeys holds rotated signing secrets; nil signs with HMACSecret only.
+	signingKeys storage.SigningKeyStore
+
+	// deliverability records the outcome of every delivery attempt (optional).
+	deliverability storage.DeliverabilityStore
+
+	// subscriptions looks up the signing secrets of subscriptions (optional).
+	subscriptions SubscriptionSource
+
+	// keysMu guards the cached signing keys.
+	keysMu       sync.Mutex
+	keys         *storage.WebhookSigningKeys
+	keysLoadedAt time.Time
+
 	// stopCh is used to signal worker shutdown.
-	stopCh chan struct{}
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
+	// stopReading interrupts the stream reads of the running workers.
+	stopReading context.CancelFunc
+
+	// deliveryCtx is the context of webhook deliveries. It outlives the
+	// context passed to Start so that Drain can let deliveries finish, and is
+	// canceled by cancelDeliveries.
+	deliveryCtx      context.Context
+	cancelDeliveries context.CancelFunc
 
 	// wg tracks running goroutines.
 	wg sync.WaitGroup
+
+	// autoscale configures backlog-based worker autoscaling (nil = fixed pool).
+	autoscale *AutoscaleConfig
+
+	// mu protects consumers and consumerSeq.
+	mu sync.Mutex
+
+	// consumers holds one quit channel per running worker goroutine.
+	consumers []chan struct{}
+
+	// consumerSeq numbers consumer names so they stay unique across scaling.
+	consumerSeq int
+
+	// consumerPrefix starts the consumer names of this replica, so that they
+	// are unique across replicas.
+	consumerPrefix string
+
+	// latencySum and latencyCount accumulate the latency of successful
+	// deliveries for the autoscaler.
+	latencySum   atomic.Int64
+	latencyCount atomic.Int64
+
+	// claiming is set while idle events claimed by the autoscaler are
+	// delivered.
+	claiming atomic.Bool
 }
 
 // Config holds configuration for creating a WebhookWorker.
 type Config struct {
 	// RedisClient is used for stream operations.
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
 
 	// Logger is the logger to use.
 	Logger *zap.Logger
@@ -109,6 +202,40 @@ type Config struct {
 
 	// HMACSecret is the secret key for HMAC signature generation.
 	HMACSecret string
+
+	// OrderingTimeout is how long an event waits for the previous event of
+	// the same resource to complete before it is delivered anyway (default: 2m).
+	OrderingTimeout time.Duration
+
+	// SigningKeys holds secrets rotated through the admin API. Once a rotation
+	// is stored it takes precedence over HMACSecret.
+	SigningKeys storage.SigningKeyStore
+
+	// Autoscale enables backlog-based worker autoscaling (optional).
+	Autoscale *AutoscaleConfig
+
+	// Deliverability records the outcome of every delivery attempt to score
+	// the callbacks of subscriptions (optional).
+	Deliverability storage.DeliverabilityStore
+
+	// CA is the webhook CA (optional). When set, deliveries present the
+	// gateway's client certificate, trust endpoints with certificates issued
+	// by the CA and refuse endpoints whose certificate the CA revoked.
+	CA *webhookca.Authority
+
+	// DialGuard, when set, refuses deliveries to callbacks resolving to
+	// private, loopback or metadata addresses at connection time (optional).
+	DialGuard *resolver.Guard
+
+	// Subscriptions looks up the subscription of each delivery to sign it
+	// with the subscription's own secret (optional).
+	Subscriptions SubscriptionSource
+}
+
+// SubscriptionSource looks up stored subscriptions. storage.Store implements it.
+type SubscriptionSource interface {
+	// Get returns the subscription with the given ID.
+	Get(ctx context.Context, id string) (*storage.Subscription, error)
 }
 
 // NewWebhookWorker creates a new WebhookWorker.
@@ -149,19 +276,56 @@ func NewWebhookWorker(cfg *Config) (*WebhookWorker, error) {
 		maxBackoff = DefaultMaxBackoff
 	}
 
+	orderingTimeout := cfg.OrderingTimeout
+	if orderingTimeout == 0 {
+		orderingTimeout = DefaultOrderingTimeout
+	}
+
+	var autoscale *AutoscaleConfig
+	if cfg.Autoscale != nil && cfg.Autoscale.Enabled {
+		autoscale = cfg.Autoscale.withDefaults()
+		if err := autoscale.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid autoscale config: %w", err)
+		}
+		workerCount = autoscale.clamp(workerCount)
+	}
+
+	transport := resolver.NewTransport()
+	if cfg.DialGuard != nil {
+		transport = cfg.DialGuard.Transport()
+	}
+	if cfg.CA != nil {
+		transport.TLSClientConfig = cfg.CA.ClientTLSConfig()
+	}
+
 	return &WebhookWorker{
-		redisClient:  cfg.RedisClient,
-		HTTPClient:   &http.Client{Timeout: timeout},
-		logger:       cfg.Logger,
-		WorkerCount:  workerCount,
-		MaxRetries:   maxRetries,
-		retryBackoff: retryBackoff,
-		maxBackoff:   maxBackoff,
-		HMACSecret:   cfg.HMACSecret,
-		stopCh:       make(chan struct{}),
+		redisClient:     cfg.RedisClient,
+		HTTPClient:      &http.Client{Timeout: timeout, Transport: transport},
+		logger:          cfg.Logger,
+		WorkerCount:     workerCount,
+		MaxRetries:      maxRetries,
+		retryBackoff:    retryBackoff,
+		maxBackoff:      maxBackoff,
+		HMACSecret:      cfg.HMACSecret,
+		orderingTimeout: orderingTimeout,
+		signingKeys:     cfg.SigningKeys,
+		deliverability:  cfg.Deliverability,
+		subscriptions:   cfg.Subscriptions,
+		stopCh:          make(chan struct{}),
+		autoscale:       autoscale,
+		consumerPrefix:  consumerPrefix(),
 	}, nil
 }
 
+// consumerPrefix returns the prefix of the consumer names of this replica: its
+// host name, which is the pod name in Kubernetes.
+func consumerPrefix() string {
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return host
+	}
+	return "webhooks"
+}
+
 // Start starts the webhook worker and begins processing events.
 func (w *WebhookWorker) Start(ctx context.Context) error {
 	w.logger.Info("starting webhook worker",
@@ -172,40 +336,97 @@ func (w *WebhookWorker) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to create consumer group: %w", err)
 	}
 
+	w.mu.Lock()
+	readCtx, stopReading := context.WithCancel(ctx)
+	w.stopReading = stopReading
+	w.deliveryCtx, w.cancelDeliveries = context.WithCancel(context.WithoutCancel(ctx))
+	w.mu.Unlock()
+
 	// Start worker goroutines
-	for i := 0; i < w.WorkerCount; i++ {
+	w.scaleTo(readCtx, w.WorkerCount)
+
+	// Start backlog-based autoscaler if enabled
+	if w.autoscale != nil {
 		w.wg.Add(1)
-		consumerName := fmt.Sprintf("worker-%d", i)
-		go w.processEvents(ctx, consumerName)
+		go w.runAutoscaler(readCtx)
 	}
 
-	// Update active workers gauge
-	ActiveWorkersGauge.Set(float64(w.WorkerCount))
-
 	w.logger.Info("webhook worker started successfully")
 
-	// Wait for context cancellation
-	<-ctx.Done()
-
-	// Stop worker
-	return w.Stop()
+	// Wait for context cancellation or a drain
+	select {
+	case <-ctx.Done():
+		return w.Stop()
+	case <-w.stopCh:
+		return nil
+	}
 }
 
-// Stop stops the webhook worker and waits for all goroutines to finish.
+// Stop stops the webhook worker, canceling the deliveries in progress, and
+// waits for all goroutines to finish. Interrupted deliveries are put back on
+// the event stream.
 func (w *WebhookWorker) Stop() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.stop(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Drain stops reading new events and waits for the deliveries in progress to
+// finish. If ctx expires first, the deliveries are canceled, their events are
+// put back on the event stream for the next worker, and ctx's error is
+// returned.
+func (w *WebhookWorker) Drain(ctx context.Context) error {
+	return w.stop(ctx)
+}
+
+// stop signals shutdown and waits for the goroutines, canceling the
+// deliveries once ctx is done.
+func (w *WebhookWorker) stop(ctx context.Context) error {
 	w.logger.Info("stopping webhook worker")
 
 	// Signal shutdown
-	close(w.stopCh)
+	w.stopOnce.Do(func() { close(w.stopCh) })
+
+	w.mu.Lock()
+	stopReading, cancelDeliveries := w.stopReading, w.cancelDeliveries
+	w.mu.Unlock()
+	if stopReading != nil {
+		stopReading()
+	}
 
 	// Wait for all goroutines to finish
-	w.wg.Wait()
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		if cancelDeliveries != nil {
+			cancelDeliveries()
+		}
+		<-done
+		err = fmt.Errorf("webhook deliveries interrupted: %w", ctx.Err())
+	}
+	if cancelDeliveries != nil {
+		cancelDeliveries()
+	}
+
+	w.mu.Lock()
+	w.consumers = nil
+	w.mu.Unlock()
 
 	// Reset active workers gauge
 	ActiveWorkersGauge.Set(0)
 
 	w.logger.Info("webhook worker stopped")
-	return nil
+	return err
 }
 
 // CreateConsumerGroup creates the Redis Stream consumer group.
@@ -226,8 +447,9 @@ func (w *WebhookWorker) CreateConsumerGroup(ctx context.Context) error {
 	return nil
 }
 
-// processEvents processes events from the Redis Stream.
-func (w *WebhookWorker) processEvents(ctx context.Context, name string) {
+// processEvents processes events from the Redis Stream until the worker is stopped
+// or its quit channel is closed by a scale-down.
+func (w *WebhookWorker) processEvents(ctx context.Context, name string, quit <-chan struct{}) {
 	defer w.wg.Done()
 
 	w.logger.Info("worker started",
@@ -239,12 +461,17 @@ func (w *WebhookWorker) processEvents(ctx context.Context, name string) {
 			w.logger.Info("worker stopping",
 				zap.String("consumer", name))
 			return
+		case <-quit:
+			w.logger.Info("worker scaled down",
+				zap.String("consumer", name))
+			w.releaseConsumer(ctx, name)
+			return
 		case <-ctx.Done():
 			w.logger.Info("worker context canceled",
 				zap.String("consumer", name))
 			return
 		default:
-			if err := w.ProcessNextEvent(ctx, name); err != nil {
+			if err := w.processNextEvent(ctx, w.deliveryCtx, name); err != nil {
 				w.logger.Error("failed to process event",
 					zap.String("consumer", name),
 					zap.Error(err))
@@ -257,8 +484,14 @@ func (w *WebhookWorker) processEvents(ctx context.Context, name string) {
 
 // ProcessNextEvent reads and processes the next event from the stream.
 func (w *WebhookWorker) ProcessNextEvent(ctx context.Context, consumerName string) error {
+	return w.processNextEvent(ctx, ctx, consumerName)
+}
+
+// processNextEvent reads the next event with readCtx and delivers it with
+// deliveryCtx.
+func (w *WebhookWorker) processNextEvent(readCtx, deliveryCtx context.Context, consumerName string) error {
 	// Read from stream (blocking with timeout)
-	streams, err := w.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+	streams, err := w.redisClient.XReadGroup(readCtx, &redis.XReadGroupArgs{
 		Group:    ConsumerGroup,
 		Consumer: consumerName,
 		Streams:  []string{EventStreamKey, ">"},
@@ -267,8 +500,9 @@ func (w *WebhookWorker) ProcessNextEvent(ctx context.Context, consumerName strin
 	}).Result()
 
 	if err != nil {
-		// Timeout is expected when no events are available
-		if errors.Is(err, redis.Nil) {
+		// Timeout is expected when no events are available, and the read
+		// is canceled when the worker stops
+		if errors.Is(err, redis.Nil) || readCtx.Err() != nil {
 			return nil
 		}
 		return fmt.Errorf("failed to read from stream: %w", err)
@@ -277,7 +511,7 @@ func (w *WebhookWorker) ProcessNextEvent(ctx context.Context, consumerName strin
 	// Process each message
 	for _, stream := range streams {
 		for _, message := range stream.Messages {
-			if err := w.HandleMessage(ctx, consumerName, message); err != nil {
+			if err := w.HandleMessage(deliveryCtx, consumerName, message); err != nil {
 				w.logger.Error("failed to handle message",
 					zap.String("message_id", message.ID),
 					zap.Error(err))
@@ -307,9 +541,20 @@ func (w *WebhookWorker) HandleMessage(ctx context.Context, _ string, msg redis.X
 		return w.AcknowledgeMessage(ctx, msg.ID)
 	}
 
+	// Keep the events of a resource in order: wait for the previous one and
+	// skip events that a later one has overtaken.
+	if !w.awaitTurn(ctx, &event) {
+		return w.AcknowledgeMessage(ctx, msg.ID)
+	}
+
 	// Deliver webhook with retries
 	startTime := time.Now()
 	if err := w.DeliverWithRetries(ctx, &event); err != nil {
+		if ctx.Err() != nil {
+			// Interrupted by shutdown; leave the event for the next worker
+			return w.requeue(context.WithoutCancel(ctx), &event, msg)
+		}
+
 		w.logger.Error("failed to deliver webhook after retries",
 			zap.String("subscription", event.SubscriptionID),
 			zap.Error(err))
@@ -327,14 +572,22 @@ func (w *WebhookWorker) HandleMessage(ctx context.Context, _ string, msg redis.X
 		duration := time.Since(startTime).Seconds()
 		WebhookDeliveriesTotal.WithLabelValues(event.SubscriptionID, "success").Inc()
 		WebhookLatency.WithLabelValues(event.SubscriptionID).Observe(duration)
+		w.observeLatency(time.Since(startTime))
 	}
 
+	// The event is complete either way; let its successor go
+	w.markDelivered(ctx, &event)
+
 	// Acknowledge message
 	return w.AcknowledgeMessage(ctx, msg.ID)
 }
 
 // DeliverWithRetries attempts webhook delivery with exponential backoff.
 func (w *WebhookWorker) DeliverWithRetries(ctx context.Context, event *controllers.ResourceEvent) error {
+	// Every attempt connects to the addresses of the first resolution, so the
+	// callback host cannot be rebound between retries.
+	ctx = resolver.Pin(ctx)
+
 	var lastErr error
 
 	for attempt := 0; attempt <= w.MaxRetries; attempt++ {
@@ -361,12 +614,20 @@ func (w *WebhookWorker) DeliverWithRetries(ctx context.Context, event *controlle
 			}
 		}
 
-		if err := w.DeliverWebhook(ctx, event); err != nil {
+		start := time.Now()
+		err := w.DeliverWebhook(ctx, event)
+		w.recordAttempt(ctx, event.SubscriptionID, start, err)
+		if err != nil {
 			lastErr = err
 			w.logger.Warn("webhook delivery failed",
 				zap.String("subscription", event.SubscriptionID),
 				zap.Int("attempt", attempt),
 				zap.Error(err))
+			// The pinned addresses are checked again on every attempt, so
+			// retrying a blocked callback cannot succeed.
+			if errors.Is(err, resolver.ErrBlocked) {
+				return fmt.Errorf("webhook delivery refused: %w", err)
+			}
 			continue
 		}
 
@@ -382,10 +643,9 @@ func (w *WebhookWorker) DeliverWithRetries(ctx context.Context, event *controlle
 
 // DeliverWebhook delivers a webhook notification via HTTP POST.
 func (w *WebhookWorker) DeliverWebhook(ctx context.Context, event *controllers.ResourceEvent) error {
-	// Marshal event to JSON
-	payload, err := json.Marshal(event)
+	payload, err := NotificationPayload(ctx, event)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		return err
 	}
 
 	// Create HTTP request
@@ -400,10 +660,21 @@ func (w *WebhookWorker) DeliverWebhook(ctx context.Context, event *controllers.R
 	req.Header.Set("X-O2IMS-Notification-ID", event.NotificationID)
 	req.Header.Set("X-O2IMS-Subscription-ID", event.SubscriptionID)
 
-	// Add HMAC signature if secret is configured
-	if w.HMACSecret != "" {
-		signature := w.GenerateHMAC(payload)
-		req.Header.Set("X-O2IMS-Signature", signature)
+	// Add HMAC signature if secret is configured. The timestamp is signed
+	// with the body so receivers can reject replayed notifications. Signing
+	// at send time means queued and retried notifications always carry the
+	// secrets valid now; during a rotation both secrets sign.
+	if primary, next := w.signingSecrets(ctx); primary != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("X-O2IMS-Timestamp", timestamp)
+		req.Header.Set("X-O2IMS-Signature", SignWithSecret(primary, timestamp, payload))
+		if next != "" {
+			req.Header.Set("X-O2IMS-Signature-Next", SignWithSecret(next, timestamp, payload))
+		}
+	}
+
+	if err := w.signForSubscription(ctx, req, event.SubscriptionID, payload); err != nil {
+		return err
 	}
 
 	// Send request
@@ -433,6 +704,157 @@ func (w *WebhookWorker) DeliverWebhook(ctx context.Context, event *controllers.R
 	return nil
 }
 
+// NotificationPayload returns the body delivered for an event: the event as
+// JSON, reshaped by the transformation of its subscription if it has one.
+func NotificationPayload(ctx context.Context, event *controllers.ResourceEvent) ([]byte, error) {
+	notification := *event
+	notification.Transform = nil
+	payload, err := json.Marshal(&notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	if event.Transform == nil {
+		return payload, nil
+	}
+
+	program, err := transform.Compile(event.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile notification transform: %w", err)
+	}
+	payload, err = program.Apply(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform notification: %w", err)
+	}
+	return payload, nil
+}
+
+// recordAttempt records the outcome of a delivery attempt that started at
+// start and updates the deliverability score of the subscription.
+func (w *WebhookWorker) recordAttempt(ctx context.Context, subscriptionID string, start time.Time, err error) {
+	if w.deliverability == nil {
+		return
+	}
+	// Attempts cut short by shutdown say nothing about the callback.
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := time.Now()
+	attempt := storage.DeliveryAttempt{
+		At:       now,
+		Success:  err == nil,
+		Latency:  now.Sub(start),
+		TLSIssue: IsTLSError(err),
+	}
+	if err := w.deliverability.RecordAttempt(ctx, subscriptionID, attempt); err != nil {
+		w.logger.Warn("failed to record delivery attempt",
+			zap.String("subscription", subscriptionID),
+			zap.Error(err))
+		return
+	}
+	d, err := w.deliverability.Get(ctx, subscriptionID, now)
+	if err != nil {
+		w.logger.Debug("failed to get deliverability",
+			zap.String("subscription", subscriptionID),
+			zap.Error(err))
+		return
+	}
+	if d != nil {
+		WebhookDeliverabilityScore.WithLabelValues(subscriptionID).Set(d.Score)
+	}
+}
+
+// IsTLSError reports whether a delivery failed in the TLS handshake, e.g.
+// because the callback's certificate expired, is not trusted, does not
+// match its host or was revoked by the webhook CA.
+func IsTLSError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	return errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) ||
+		errors.Is(err, webhookca.ErrCertificateRevoked)
+}
+
+// awaitTurn blocks until the previous event with the same ordering key has
+// been delivered or moved to the DLQ. It returns false when the event is
+// stale because a later event of the same key has already completed; such an
+// event must not be delivered. Unordered events (sequence 0) never wait, and
+// an event whose predecessor does not complete within the ordering timeout
+// (for example because it was lost) is delivered anyway.
+func (w *WebhookWorker) awaitTurn(ctx context.Context, event *controllers.ResourceEvent) bool {
+	if event.SequenceNumber <= 0 {
+		return true
+	}
+
+	key := DeliveredKeyPrefix + event.OrderingKey()
+	deadline := time.Now().Add(w.orderingTimeout)
+	waited := false
+	for {
+		completed, err := w.redisClient.Get(ctx, key).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			w.logger.Warn("failed to read delivery sequence; delivering without ordering",
+				zap.String("subscription", event.SubscriptionID),
+				zap.Error(err))
+			return true
+		}
+
+		switch {
+		case completed >= event.SequenceNumber:
+			OrderingStaleTotal.WithLabelValues(event.SubscriptionID).Inc()
+			w.logger.Info("skipping event overtaken by a later event of the same resource",
+				zap.String("subscription", event.SubscriptionID),
+				zap.String("resource", event.GlobalResourceID),
+				zap.Int64("sequence", event.SequenceNumber),
+				zap.Int64("completed", completed))
+			return false
+		case completed >= event.SequenceNumber-1:
+			return true
+		case time.Now().After(deadline):
+			OrderingTimeoutsTotal.WithLabelValues(event.SubscriptionID).Inc()
+			w.logger.Warn("previous event of the resource did not complete in time; delivering out of order",
+				zap.String("subscription", event.SubscriptionID),
+				zap.String("resource", event.GlobalResourceID),
+				zap.Int64("sequence", event.SequenceNumber),
+				zap.Int64("completed", completed))
+			return true
+		}
+
+		if !waited {
+			waited = true
+			OrderingWaitsTotal.WithLabelValues(event.SubscriptionID).Inc()
+		}
+		select {
+		case <-time.After(orderingPollInterval):
+		case <-ctx.Done():
+			return true
+		}
+	}
+}
+
+// markDelivered records that an event completed so its successor may be delivered.
+func (w *WebhookWorker) markDelivered(ctx context.Context, event *controllers.ResourceEvent) {
+	if event.SequenceNumber <= 0 {
+		return
+	}
+	key := DeliveredKeyPrefix + event.OrderingKey()
+	ttl := int64(controllers.SequenceTTL / time.Second)
+	if err := markDeliveredScript.Run(ctx, w.redisClient, []string{key}, event.SequenceNumber, ttl).Err(); err != nil &&
+		!errors.Is(err, redis.Nil) {
+		w.logger.Warn("failed to record delivery sequence",
+			zap.String("subscription", event.SubscriptionID),
+			zap.Error(err))
+	}
+}
+
 // GenerateHMAC generates an HMAC-SHA256 signature for the payload.
 func (w *WebhookWorker) GenerateHMAC(payload []byte) string {
 	mac := hmac.New(sha256.New, []byte(w.HMACSecret))
@@ -440,6 +862,92 @@ func (w *WebhookWorker) GenerateHMAC(payload []byte) string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
+// SignPayload returns the X-O2IMS-Signature value for a notification body sent
+// at timestamp (Unix seconds) with the configured secret.
+func (w *WebhookWorker) SignPayload(timestamp string, payload []byte) string {
+	return SignWithSecret(w.HMACSecret, timestamp, payload)
+}
+
+// SignWithSecret returns the hex-encoded HMAC-SHA256 of "{timestamp}.{body}"
+// under secret.
+func SignWithSecret(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signForSubscription adds the X-Notification-Signature header to a
+// notification of a subscription with its own signing secret: the
+// hex-encoded HMAC-SHA256 of "{timestamp}.{body}", prefixed with "sha256=",
+// where timestamp is the X-Notification-Timestamp header. During the grace
+// period of a rotation the replaced secret signs X-Notification-Signature-Previous.
+// The secret is looked up for every delivery, so a rotation applies to
+// queued and retried notifications too.
+func (w *WebhookWorker) signForSubscription(
+	ctx context.Context, req *http.Request, subscriptionID string, payload []byte,
+) error {
+	if w.subscriptions == nil {
+		return nil
+	}
+	sub, err := w.subscriptions.Get(ctx, subscriptionID)
+	if errors.Is(err, storage.ErrSubscriptionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load subscription signing secret: %w", err)
+	}
+
+	now := time.Now()
+	current, previous := sub.SigningSecrets(now)
+	if current == "" {
+		return nil
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("X-Notification-Timestamp", timestamp)
+	req.Header.Set("X-Notification-Signature", SubscriptionSignature(current, timestamp, payload))
+	if previous != "" {
+		req.Header.Set("X-Notification-Signature-Previous", SubscriptionSignature(previous, timestamp, payload))
+	}
+	return nil
+}
+
+// SubscriptionSignature returns the X-Notification-Signature value of a
+// notification body sent at timestamp (Unix seconds) under secret.
+func SubscriptionSignature(secret, timestamp string, payload []byte) string {
+	return "sha256=" + SignWithSecret(secret, timestamp, payload)
+}
+
+// signingSecrets returns the secrets to sign with now: the primary secret and,
+// while a rotation is pending, the next one. Rotated keys are cached briefly;
+// if they cannot be loaded the last known keys (or HMACSecret) are used.
+func (w *WebhookWorker) signingSecrets(ctx context.Context) (primary, next string) {
+	if w.signingKeys == nil {
+		return w.HMACSecret, ""
+	}
+
+	w.keysMu.Lock()
+	defer w.keysMu.Unlock()
+
+	if time.Since(w.keysLoadedAt) >= signingKeysCacheTTL {
+		keys, err := w.signingKeys.Get(ctx)
+		switch {
+		case err == nil:
+			w.keys, w.keysLoadedAt = keys, time.Now()
+		case errors.Is(err, storage.ErrSigningKeysNotSet):
+			w.keys, w.keysLoadedAt = nil, time.Now()
+		default:
+			w.logger.Warn("failed to load webhook signing keys; using last known keys", zap.Error(err))
+		}
+	}
+
+	if w.keys == nil {
+		return w.HMACSecret, ""
+	}
+	return w.keys.Effective(time.Now())
+}
+
 // AcknowledgeMessage acknowledges a message to remove it from pending.
 func (w *WebhookWorker) AcknowledgeMessage(ctx context.Context, messageID string) error {
 	if err := w.redisClient.XAck(ctx, EventStreamKey, ConsumerGroup, messageID).Err(); err != nil {
@@ -448,6 +956,26 @@ func (w *WebhookWorker) AcknowledgeMessage(ctx context.Context, messageID string
 	return nil
 }
 
+// requeue puts an event whose delivery was interrupted back on the event
+// stream and acknowledges its message, so that another worker delivers it.
+func (w *WebhookWorker) requeue(ctx context.Context, event *controllers.ResourceEvent, msg redis.XMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, requeueTimeout)
+	defer cancel()
+
+	pipe := w.redisClient.TxPipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{Stream: EventStreamKey, Values: msg.Values})
+	pipe.XAck(ctx, EventStreamKey, ConsumerGroup, msg.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to requeue interrupted event: %w", err)
+	}
+
+	WebhookRequeuedTotal.WithLabelValues(event.SubscriptionID).Inc()
+	w.logger.Info("requeued event interrupted by shutdown",
+		zap.String("subscription", event.SubscriptionID),
+		zap.String("message_id", msg.ID))
+	return nil
+}
+
 // MoveToDLQ moves a failed event to the dead letter queue.
 func (w *WebhookWorker) MoveToDLQ(ctx context.Context, event *controllers.ResourceEvent, messageID string) error {
 	// Marshal event
@@ -464,7 +992,7 @@ func (w *WebhookWorker) MoveToDLQ(ctx context.Context, event *controllers.Resour
 		Values: map[string]interface{}{
 			"event":           string(data),
 			"original_id":     messageID,
-			"failed_at":       time.Now().Format(time.RFC3339),
+			"failed_at":       timeutil.Format(timeutil.Now()),
 			"subscription_id": event.SubscriptionID,
 		},
 	}
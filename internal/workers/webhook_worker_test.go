@@ -9,18 +9,22 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/piwi3910/netweave/internal/workers"
 
 	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	redis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/piwi3910/netweave/internal/controllers"
+	"github.com/piwi3910/netweave/internal/storage"
+	"github.com/piwi3910/netweave/internal/transform"
 )
 
 // addAndReadMessage is a helper that adds a message to Redis stream and reads it back.
@@ -239,8 +243,11 @@ func TestWebhookWorker_DeliverWebhook_WithHMAC(t *testing.T) {
 		signature := r.Header.Get("X-O2IMS-Signature")
 		assert.NotEmpty(t, signature)
 
-		// Calculate expected signature
+		// Calculate expected signature over "{timestamp}.{body}"
+		timestamp := r.Header.Get("X-O2IMS-Timestamp")
+		require.NotEmpty(t, timestamp)
 		mac := hmac.New(sha256.New, []byte(hmacSecret))
+		mac.Write([]byte(timestamp + "."))
 		mac.Write(body)
 		expectedSignature := hex.EncodeToString(mac.Sum(nil))
 
@@ -273,6 +280,201 @@ func TestWebhookWorker_DeliverWebhook_WithHMAC(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestWebhookWorker_DeliverWebhook_Transform(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	hmacSecret := "test-secret-key"
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		// The signature covers the transformed body
+		timestamp := r.Header.Get("X-O2IMS-Timestamp")
+		assert.Equal(t, workers.SignWithSecret(hmacSecret, timestamp, body), r.Header.Get("X-O2IMS-Signature"))
+
+		received <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		WorkerCount: 1,
+		HMACSecret:  hmacSecret,
+	})
+	require.NoError(t, err)
+
+	event := &controllers.ResourceEvent{
+		SubscriptionID:   "sub-123",
+		EventType:        "o2ims.Resource.Created",
+		GlobalResourceID: "test-node",
+		CallbackURL:      server.URL,
+		Transform: &transform.Template{
+			Language: transform.LanguageJQ,
+			Source:   "{node: .globalResourceId, type: .notificationEventType}",
+		},
+	}
+	require.NoError(t, worker.DeliverWebhook(context.Background(), event))
+
+	select {
+	case body := <-received:
+		assert.JSONEq(t, `{"node":"test-node","type":"o2ims.Resource.Created"}`, string(body))
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for webhook")
+	}
+}
+
+func TestNotificationPayload(t *testing.T) {
+	ctx := context.Background()
+	event := &controllers.ResourceEvent{SubscriptionID: "sub-123", GlobalResourceID: "test-node"}
+
+	payload, err := workers.NotificationPayload(ctx, event)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "transform")
+
+	// The transform itself is never delivered, even when it echoes the event
+	event.Transform = &transform.Template{Language: transform.LanguageJQ, Source: "."}
+	payload, err = workers.NotificationPayload(ctx, event)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "transform")
+	assert.Contains(t, string(payload), "test-node")
+
+	event.Transform = &transform.Template{Language: transform.LanguageJQ, Source: ".globalResourceId.x"}
+	_, err = workers.NotificationPayload(ctx, event)
+	require.Error(t, err)
+}
+
+func TestWebhookWorker_DeliverWebhook_SigningKeyRotation(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	var mu sync.Mutex
+	var headers []http.Header
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	keys := storage.NewInMemorySigningKeyStore()
+	require.NoError(t, keys.Set(ctx, &storage.WebhookSigningKeys{
+		Current:     "old-secret",
+		Next:        "new-secret",
+		ActivatesAt: time.Now().Add(time.Hour),
+	}))
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient: rdb,
+		Logger:      zaptest.NewLogger(t),
+		WorkerCount: 1,
+		HMACSecret:  "configured-secret",
+		SigningKeys: keys,
+	})
+	require.NoError(t, err)
+
+	event := &controllers.ResourceEvent{
+		SubscriptionID: "sub-123",
+		EventType:      "o2ims.Resource.Created",
+		CallbackURL:    server.URL,
+	}
+	require.NoError(t, worker.DeliverWebhook(ctx, event))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, headers, 1)
+	timestamp := headers[0].Get("X-O2IMS-Timestamp")
+	assert.Equal(t, workers.SignWithSecret("old-secret", timestamp, bodies[0]), headers[0].Get("X-O2IMS-Signature"))
+	assert.Equal(t, workers.SignWithSecret("new-secret", timestamp, bodies[0]), headers[0].Get("X-O2IMS-Signature-Next"))
+}
+
+func TestWebhookWorker_DeliverWebhook_SubscriptionSignature(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	var mu sync.Mutex
+	var headers []http.Header
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		headers = append(headers, r.Header.Clone())
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store := storage.NewRedisStore(&storage.RedisConfig{Addr: mr.Addr(), AllowInsecureCallbacks: true})
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	sub := &storage.Subscription{ID: "sub-signed", Callback: server.URL, SigningSecret: "old-subscription-secret"}
+	sub.RotateSigningSecret("new-subscription-secret", time.Now(), time.Hour)
+	require.NoError(t, store.Create(ctx, sub))
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient:   rdb,
+		Logger:        zaptest.NewLogger(t),
+		WorkerCount:   1,
+		Subscriptions: store,
+	})
+	require.NoError(t, err)
+
+	for _, id := range []string{"sub-signed", "sub-unknown"} {
+		require.NoError(t, worker.DeliverWebhook(ctx, &controllers.ResourceEvent{
+			SubscriptionID: id,
+			EventType:      "o2ims.Resource.Created",
+			CallbackURL:    server.URL,
+		}))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, headers, 2)
+	timestamp := headers[0].Get("X-Notification-Timestamp")
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, "sha256="+workers.SignWithSecret("new-subscription-secret", timestamp, bodies[0]),
+		headers[0].Get("X-Notification-Signature"))
+	assert.Equal(t, "sha256="+workers.SignWithSecret("old-subscription-secret", timestamp, bodies[0]),
+		headers[0].Get("X-Notification-Signature-Previous"))
+
+	// Notifications of subscriptions without a secret are not signed.
+	assert.Empty(t, headers[1].Get("X-Notification-Signature"))
+}
+
 func TestWebhookWorker_DeliverWebhook_Failure(t *testing.T) {
 	// Setup miniredis
 	mr := miniredis.RunT(t)
@@ -409,6 +611,66 @@ func TestWebhookWorker_DeliverWithRetries_MaxRetriesExceeded(t *testing.T) {
 	assert.Equal(t, 3, attempts) // Initial attempt + 2 retries
 }
 
+func TestWebhookWorker_DeliverWithRetries_RecordsDeliverability(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	// A TLS callback whose certificate the worker does not trust.
+	untrusted := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer untrusted.Close()
+
+	deliverability := storage.NewInMemoryDeliverabilityStore(time.Hour)
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient:    rdb,
+		Logger:         zaptest.NewLogger(t),
+		WorkerCount:    1,
+		MaxRetries:     1,
+		RetryBackoff:   10 * time.Millisecond,
+		Deliverability: deliverability,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, worker.DeliverWithRetries(ctx, &controllers.ResourceEvent{
+		SubscriptionID: "sub-flaky",
+		CallbackURL:    server.URL,
+	}))
+	require.Error(t, worker.DeliverWithRetries(ctx, &controllers.ResourceEvent{
+		SubscriptionID: "sub-tls",
+		CallbackURL:    untrusted.URL,
+	}))
+
+	flaky, err := deliverability.Get(ctx, "sub-flaky", time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, flaky)
+	assert.Equal(t, int64(2), flaky.Attempts, "retries are recorded as attempts")
+	assert.InDelta(t, 0.5, flaky.SuccessRate, 1e-9)
+	assert.Zero(t, flaky.TLSIssues)
+	assert.InDelta(t, flaky.Score,
+		testutil.ToFloat64(workers.WebhookDeliverabilityScore.WithLabelValues("sub-flaky")), 1e-9)
+
+	tlsIssues, err := deliverability.Get(ctx, "sub-tls", time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, tlsIssues)
+	assert.Equal(t, int64(2), tlsIssues.TLSIssues)
+	assert.Equal(t, storage.DeliverabilityFailing, tlsIssues.Status)
+}
+
 func TestWebhookWorker_GenerateHMAC(t *testing.T) {
 	worker := &workers.WebhookWorker{
 		HMACSecret: "test-secret",
@@ -685,6 +947,84 @@ func TestWebhookWorker_HandleMessage(t *testing.T) {
 	})
 }
 
+// TestWebhookWorker_HandleMessage_Ordering tests per-resource ordering of sequenced events.
+func TestWebhookWorker_HandleMessage_Ordering(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() {
+		require.NoError(t, rdb.Close())
+	}()
+
+	var delivered []int64
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event controllers.ResourceEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		mu.Lock()
+		delivered = append(delivered, event.SequenceNumber)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	worker, err := workers.NewWebhookWorker(&workers.Config{
+		RedisClient:     rdb,
+		Logger:          zaptest.NewLogger(t),
+		WorkerCount:     1,
+		OrderingTimeout: 300 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, worker.CreateConsumerGroup(ctx))
+
+	newMessage := func(consumer string, seq int64) redis.XMessage {
+		data, err := json.Marshal(&controllers.ResourceEvent{
+			SubscriptionID:   "sub-1",
+			ResourceTypeID:   "k8s-node",
+			GlobalResourceID: "node-1",
+			CallbackURL:      server.URL,
+			SequenceNumber:   seq,
+		})
+		require.NoError(t, err)
+		return addAndReadMessage(ctx, t, rdb, consumer, string(data))
+	}
+	deliveredKey := workers.DeliveredKeyPrefix + "sub-1/k8s-node/node-1"
+
+	// Event 2 waits until event 1 has completed.
+	first := newMessage("c1", 1)
+	second := newMessage("c2", 2)
+	done := make(chan error, 1)
+	go func() { done <- worker.HandleMessage(ctx, "c2", second) }()
+
+	select {
+	case <-done:
+		t.Fatal("event 2 was delivered before event 1")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, worker.HandleMessage(ctx, "c1", first))
+	require.NoError(t, <-done)
+
+	got, err := rdb.Get(ctx, deliveredKey).Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+
+	// A stale event is acknowledged without being delivered.
+	stale := newMessage("c3", 1)
+	require.NoError(t, worker.HandleMessage(ctx, "c3", stale))
+
+	// A gap does not block delivery forever.
+	require.NoError(t, worker.HandleMessage(ctx, "c4", newMessage("c4", 5)))
+
+	mu.Lock()
+	assert.Equal(t, []int64{1, 2, 5}, delivered)
+	mu.Unlock()
+
+	pending, err := rdb.XPending(ctx, workers.EventStreamKey, workers.ConsumerGroup).Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count)
+}
+
 // TestWebhookWorker_ProcessNextEvent tests the processNextEvent function.
 func TestWebhookWorker_ProcessNextEvent(t *testing.T) {
 	mr := miniredis.RunT(t)
@@ -804,3 +1144,104 @@ func TestWebhookWorker_Stop(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+// TestWebhookWorker_Drain tests that Drain lets deliveries in progress finish
+// and requeues the ones still running when its context expires.
+func TestWebhookWorker_Drain(t *testing.T) {
+	setup := func(t *testing.T) (*redis.Client, *workers.WebhookWorker, chan struct{}) {
+		t.Helper()
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = rdb.Close() })
+
+		// The callback holds every delivery until gate is closed.
+		received := make(chan struct{}, 1)
+		gate := make(chan struct{})
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- struct{}{}
+			select {
+			case <-gate:
+				w.WriteHeader(http.StatusOK)
+			case <-r.Context().Done():
+			case <-done:
+			}
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(done) })
+
+		worker, err := workers.NewWebhookWorker(&workers.Config{
+			RedisClient: rdb,
+			Logger:      zaptest.NewLogger(t),
+			WorkerCount: 1,
+			MaxRetries:  1,
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		started := make(chan error, 1)
+		go func() { started <- worker.Start(ctx) }()
+		t.Cleanup(func() { require.NoError(t, <-started) })
+
+		data, err := json.Marshal(&controllers.ResourceEvent{
+			SubscriptionID: "sub-drain",
+			EventType:      "o2ims.Resource.Created",
+			NotificationID: "notif-drain",
+			CallbackURL:    server.URL,
+			Timestamp:      time.Now(),
+		})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return rdb.XAdd(context.Background(), &redis.XAddArgs{
+				Stream: workers.EventStreamKey,
+				Values: map[string]interface{}{"event": string(data)},
+			}).Err() == nil
+		}, time.Second, 10*time.Millisecond)
+
+		select {
+		case <-received:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for webhook")
+		}
+		return rdb, worker, gate
+	}
+
+	t.Run("waits for deliveries in progress", func(t *testing.T) {
+		rdb, worker, gate := setup(t)
+
+		drained := make(chan error, 1)
+		go func() { drained <- worker.Drain(context.Background()) }()
+		select {
+		case err := <-drained:
+			t.Fatalf("drain returned before the delivery finished: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(gate)
+		require.NoError(t, <-drained)
+
+		pending, err := rdb.XPending(context.Background(), workers.EventStreamKey, workers.ConsumerGroup).Result()
+		require.NoError(t, err)
+		assert.Zero(t, pending.Count)
+		length, err := rdb.XLen(context.Background(), workers.EventStreamKey).Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), length)
+	})
+
+	t.Run("requeues interrupted deliveries", func(t *testing.T) {
+		rdb, worker, _ := setup(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, worker.Drain(ctx), context.DeadlineExceeded)
+
+		pending, err := rdb.XPending(context.Background(), workers.EventStreamKey, workers.ConsumerGroup).Result()
+		require.NoError(t, err)
+		assert.Zero(t, pending.Count)
+		length, err := rdb.XLen(context.Background(), workers.EventStreamKey).Result()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), length, "the event is added to the stream again")
+		assert.InDelta(t, 1, testutil.ToFloat64(workers.WebhookRequeuedTotal.WithLabelValues("sub-drain")), 0)
+	})
+}
@@ -10,8 +10,10 @@ package compliance
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -52,26 +54,84 @@ type Result struct {
 	TestedAt        time.Time `json:"testedAt"`
 }
 
+// EndpointResult is the outcome of a single endpoint check. Results are
+// reported as checks complete when a reporter is set.
+type EndpointResult struct {
+	SpecName   string `json:"specName"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	StatusCode int    `json:"statusCode,omitempty"` // 0 when no response was received
+	Passed     bool   `json:"passed"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Checker defaults.
+const (
+	// DefaultConcurrency is the default number of endpoint checks run at once.
+	DefaultConcurrency = 4
+	// DefaultCheckTimeout is the default timeout of a single endpoint check.
+	DefaultCheckTimeout = 10 * time.Second
+)
+
 // Checker performs O-RAN API compliance validation.
 type Checker struct {
-	baseURL    string        // Gateway base URL (e.g., http://localhost:8080)
-	httpClient *http.Client  // HTTP client for API calls
-	logger     *zap.Logger   // Logger for test output
-	specs      []SpecVersion // O-RAN specifications to validate against
+	baseURL      string        // Gateway base URL (e.g., http://localhost:8080)
+	httpClient   *http.Client  // HTTP client for API calls
+	logger       *zap.Logger   // Logger for test output
+	specs        []SpecVersion // O-RAN specifications to validate against
+	workers      chan struct{} // Bounds the endpoint checks running at once
+	checkTimeout time.Duration // Timeout of a single endpoint check
+
+	reportMu sync.Mutex           // Serializes calls to reporter
+	reporter func(EndpointResult) // Optional; called as each check completes
 }
 
 // NewChecker creates a new compliance checker.
 func NewChecker(baseURL string, logger *zap.Logger) *Checker {
 	return &Checker{
 		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		logger: logger,
-		specs:  getORANSpecifications(),
+		// Each check is bounded by its own context (see SetCheckTimeout).
+		httpClient:   &http.Client{},
+		logger:       logger,
+		specs:        getORANSpecifications(),
+		workers:      make(chan struct{}, DefaultConcurrency),
+		checkTimeout: DefaultCheckTimeout,
 	}
 }
 
+// SetConcurrency sets the number of endpoint checks run at once, across all
+// specifications. Values below 1 are treated as 1. It must be called before
+// the checks start.
+func (c *Checker) SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	c.workers = make(chan struct{}, n)
+}
+
+// SetCheckTimeout sets the timeout of a single endpoint check. A check that
+// times out fails. Values <= 0 restore DefaultCheckTimeout.
+func (c *Checker) SetCheckTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	c.checkTimeout = timeout
+}
+
+// SetHTTPClient sets the client the checks are sent with, e.g. to configure
+// TLS or add authentication. Each check remains bounded by the check timeout.
+func (c *Checker) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// SetReporter sets a function called with each endpoint result as soon as
+// the check completes, so results can be streamed instead of waiting for the
+// whole run. Calls are serialized; completion order is not endpoint order.
+func (c *Checker) SetReporter(reporter func(EndpointResult)) {
+	c.reporter = reporter
+}
+
 // getORANSpecifications returns the list of O-RAN specifications.
 func getORANSpecifications() []SpecVersion {
 	return []SpecVersion{
@@ -96,34 +156,45 @@ func getORANSpecifications() []SpecVersion {
 	}
 }
 
-// CheckAll validates compliance with all O-RAN specifications.
+// CheckAll validates compliance with all O-RAN specifications. The
+// specifications are checked concurrently, sharing the concurrency limit.
+// When ctx expires before every check completed, CheckAll returns an error
+// rather than a partial report.
 func (c *Checker) CheckAll(ctx context.Context) ([]Result, error) {
-	results := make([]Result, 0, len(c.specs))
-
-	for _, spec := range c.specs {
-		c.logger.Info("checking compliance",
-			zap.String("spec", spec.Name),
-			zap.String("version", spec.Version))
-
-		var result Result
-		var err error
-
+	checks := make([]func(context.Context, SpecVersion) (Result, error), len(c.specs))
+	for i, spec := range c.specs {
 		switch spec.Name {
 		case "O2-IMS":
-			result, err = c.CheckO2IMS(ctx, spec)
+			checks[i] = c.CheckO2IMS
 		case "O2-DMS":
-			result, err = c.CheckO2DMS(ctx, spec)
+			checks[i] = c.CheckO2DMS
 		case "O2-SMO":
-			result, err = c.CheckO2SMO(ctx, spec)
+			checks[i] = c.CheckO2SMO
 		default:
 			return nil, fmt.Errorf("unknown specification: %s", spec.Name)
 		}
+	}
+
+	results := make([]Result, len(c.specs))
+	errs := make([]error, len(c.specs))
+	var wg sync.WaitGroup
+	for i, spec := range c.specs {
+		c.logger.Info("checking compliance",
+			zap.String("spec", spec.Name),
+			zap.String("version", spec.Version))
+
+		wg.Add(1)
+		go func(i int, spec SpecVersion) {
+			defer wg.Done()
+			results[i], errs[i] = checks[i](ctx, spec)
+		}(i, spec)
+	}
+	wg.Wait()
 
+	for i, err := range errs {
 		if err != nil {
-			return nil, fmt.Errorf("failed to check %s compliance: %w", spec.Name, err)
+			return nil, fmt.Errorf("failed to check %s compliance: %w", c.specs[i].Name, err)
 		}
-
-		results = append(results, result)
 	}
 
 	return results, nil
@@ -263,27 +334,49 @@ type EndpointTest struct {
 	Body           string // Optional request body for POST/PUT
 }
 
-// validateEndpoints tests a list of API endpoints.
+// validateEndpoints tests a list of API endpoints, at most the configured
+// number at a time across the Checker. Only the pass/fail outcome of each
+// endpoint is kept; full results go to the reporter.
 func (c *Checker) validateEndpoints(
 	ctx context.Context,
 	spec SpecVersion,
 	endpoints []EndpointTest,
 ) (Result, error) {
 	totalEndpoints := len(endpoints)
-	passedEndpoints := 0
-	failedEndpoints := 0
-	missingFeatures := []string{}
+	passed := make([]bool, totalEndpoints)
 
-	for _, test := range endpoints {
-		passed, err := c.testEndpoint(ctx, test)
-		if err != nil {
-			c.logger.Error("endpoint test failed",
-				zap.String("method", test.Method),
-				zap.String("path", test.Path),
-				zap.Error(err))
+	var wg sync.WaitGroup
+	for i, test := range endpoints {
+		select {
+		case c.workers <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
 		}
 
-		if passed {
+		wg.Add(1)
+		go func(i int, test EndpointTest) {
+			defer wg.Done()
+			defer func() { <-c.workers }()
+
+			result := c.testEndpoint(ctx, spec, test)
+			passed[i] = result.Passed
+			c.report(result)
+		}(i, test)
+	}
+	wg.Wait()
+
+	// Checks cut short by the overall deadline say nothing about compliance.
+	if err := ctx.Err(); err != nil {
+		return Result{}, fmt.Errorf("checks did not complete: %w", err)
+	}
+
+	passedEndpoints := 0
+	failedEndpoints := 0
+	missingFeatures := []string{}
+	for i, test := range endpoints {
+		if passed[i] {
 			passedEndpoints++
 		} else {
 			failedEndpoints++
@@ -319,24 +412,49 @@ func (c *Checker) validateEndpoints(
 	}, nil
 }
 
-// testEndpoint tests a single API endpoint.
-func (c *Checker) testEndpoint(ctx context.Context, test EndpointTest) (bool, error) {
+// report passes a completed endpoint result to the reporter, if any.
+func (c *Checker) report(result EndpointResult) {
+	if c.reporter == nil {
+		return
+	}
+	c.reportMu.Lock()
+	defer c.reportMu.Unlock()
+	c.reporter(result)
+}
+
+// testEndpoint tests a single API endpoint within the check timeout.
+func (c *Checker) testEndpoint(ctx context.Context, spec SpecVersion, test EndpointTest) EndpointResult {
 	// For parameterized paths, replace with test values
 	path := ReplacePlaceholders(test.Path)
+	result := EndpointResult{SpecName: spec.Name, Method: test.Method, Path: path}
+
+	start := time.Now()
+	defer func() { result.DurationMs = time.Since(start).Milliseconds() }()
 
-	url := c.baseURL + path
+	checkCtx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+	defer cancel()
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, test.Method, url, http.NoBody)
+	req, err := http.NewRequestWithContext(checkCtx, test.Method, c.baseURL+path, http.NoBody)
 	if err != nil {
-		return false, fmt.Errorf("failed to create request: %w", err)
+		result.Error = fmt.Sprintf("failed to create request: %v", err)
+		c.logger.Error("endpoint test failed",
+			zap.String("method", test.Method),
+			zap.String("path", test.Path),
+			zap.Error(err))
+		return result
 	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		// Endpoint not reachable = not implemented
-		return false, nil
+		if errors.Is(checkCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			result.Error = fmt.Sprintf("timed out after %s", c.checkTimeout)
+		} else {
+			result.Error = err.Error()
+		}
+		return result
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -347,16 +465,17 @@ func (c *Checker) testEndpoint(ctx context.Context, test EndpointTest) (bool, er
 	// Check status code
 	// Accept both the required status and 404 (endpoint exists but resource not found)
 	// This distinguishes between "endpoint implemented" vs "endpoint missing"
-	passed := resp.StatusCode == test.RequiredStatus ||
+	result.StatusCode = resp.StatusCode
+	result.Passed = resp.StatusCode == test.RequiredStatus ||
 		(test.Method == http.MethodGet && resp.StatusCode == http.StatusNotFound)
 
 	c.logger.Debug("endpoint tested",
 		zap.String("method", test.Method),
 		zap.String("path", path),
 		zap.Int("status", resp.StatusCode),
-		zap.Bool("passed", passed))
+		zap.Bool("passed", result.Passed))
 
-	return passed, nil
+	return result
 }
 
 // ReplacePlaceholders replaces {param} with test values.
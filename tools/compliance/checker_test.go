@@ -4,7 +4,10 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/piwi3910/netweave/tools/compliance"
 
@@ -134,6 +137,96 @@ func TestChecker_CheckAll(t *testing.T) {
 	assert.True(t, specNames["O2-SMO"])
 }
 
+func TestChecker_Concurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	checker := compliance.NewChecker(server.URL, zap.NewNop())
+	checker.SetConcurrency(3)
+
+	var mu sync.Mutex
+	var reported []compliance.EndpointResult
+	checker.SetReporter(func(r compliance.EndpointResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, r)
+	})
+
+	results, err := checker.CheckAll(context.Background())
+	require.NoError(t, err)
+
+	total := 0
+	for _, r := range results {
+		total += r.TotalEndpoints
+	}
+	assert.Len(t, reported, total, "every endpoint result should be streamed")
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	assert.Greater(t, maxInFlight.Load(), int32(1), "checks should run concurrently")
+}
+
+func TestChecker_CheckTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	checker := compliance.NewChecker(server.URL, zap.NewNop())
+	checker.SetCheckTimeout(50 * time.Millisecond)
+
+	var timedOut []compliance.EndpointResult
+	checker.SetReporter(func(r compliance.EndpointResult) {
+		if r.Error != "" {
+			timedOut = append(timedOut, r)
+		}
+	})
+
+	result, err := checker.CheckO2SMO(context.Background(), compliance.SpecVersion{Name: "O2-SMO"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.FailedEndpoints)
+	assert.Equal(t, []string{"GET /"}, result.MissingFeatures)
+	require.Len(t, timedOut, 1)
+	assert.Contains(t, timedOut[0].Error, "timed out")
+}
+
+func TestChecker_Deadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	checker := compliance.NewChecker(server.URL, zap.NewNop())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	results, err := checker.CheckAll(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, results)
+}
+
 func TestReplacePlaceholders(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,390 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// totalRoute names the aggregate of all routes in a report.
+const totalRoute = "total"
+
+// LatencyStats summarizes the latencies of a route.
+type LatencyStats struct {
+	Min  Duration `json:"min"`
+	Mean Duration `json:"mean"`
+	P50  Duration `json:"p50"`
+	P95  Duration `json:"p95"`
+	P99  Duration `json:"p99"`
+	Max  Duration `json:"max"`
+}
+
+// RouteStats are the results of the requests of one route.
+type RouteStats struct {
+	Route    string `json:"route"`
+	Category string `json:"category,omitempty"`
+
+	Requests  int     `json:"requests"`
+	Failures  int     `json:"failures"`
+	ErrorRate float64 `json:"errorRate"`
+
+	// Throughput is the number of requests per second.
+	Throughput float64 `json:"throughput"`
+
+	Latency LatencyStats `json:"latency"`
+
+	// StatusCodes counts the responses per HTTP status code.
+	StatusCodes map[int]int `json:"statusCodes,omitempty"`
+
+	// TransportErrors counts requests that got no response.
+	TransportErrors int `json:"transportErrors,omitempty"`
+
+	SLO           *SLO     `json:"slo,omitempty"`
+	SLOViolations []string `json:"sloViolations,omitempty"`
+}
+
+// Regression is a latency increase of a route over a baseline report.
+type Regression struct {
+	Route    string   `json:"route"`
+	Metric   string   `json:"metric"`
+	Baseline Duration `json:"baseline"`
+	Current  Duration `json:"current"`
+
+	// Change is the relative increase, e.g. 0.25 for 25% slower.
+	Change float64 `json:"change"`
+}
+
+// Report is the result of a load test run.
+type Report struct {
+	BaseURL     string    `json:"baseUrl"`
+	StartedAt   time.Time `json:"startedAt"`
+	Duration    Duration  `json:"duration"`
+	Concurrency int       `json:"concurrency"`
+	Rate        float64   `json:"rate,omitempty"`
+
+	Total  RouteStats   `json:"total"`
+	Routes []RouteStats `json:"routes"`
+
+	Regressions []Regression `json:"regressions,omitempty"`
+
+	// Passed is set when every route met its SLO and nothing regressed.
+	Passed bool `json:"passed"`
+}
+
+// recorder collects request outcomes from concurrent workers.
+type recorder struct {
+	mu     sync.Mutex
+	routes map[string]*routeSamples
+}
+
+// routeSamples are the outcomes of the requests of one route.
+type routeSamples struct {
+	category        string
+	latencies       []time.Duration
+	failures        int
+	statusCodes     map[int]int
+	transportErrors int
+}
+
+func newRecorder() *recorder {
+	return &recorder{routes: make(map[string]*routeSamples)}
+}
+
+// record adds the outcome of a request.
+func (r *recorder) record(route, category string, res result, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	samples, exists := r.routes[route]
+	if !exists {
+		samples = &routeSamples{category: category, statusCodes: make(map[int]int)}
+		r.routes[route] = samples
+	}
+	samples.latencies = append(samples.latencies, res.latency)
+	if !ok {
+		samples.failures++
+	}
+	if res.status != 0 {
+		samples.statusCodes[res.status]++
+	} else {
+		samples.transportErrors++
+	}
+}
+
+// report builds the report of a run and evaluates the SLOs.
+func (r *recorder) report(scenario *Scenario, startedAt time.Time, elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		BaseURL:     scenario.BaseURL,
+		StartedAt:   startedAt.UTC(),
+		Duration:    Duration(elapsed),
+		Concurrency: scenario.Concurrency,
+		Rate:        scenario.Rate,
+		Passed:      true,
+	}
+
+	slos := make(map[string]SLO, len(scenario.Requests))
+	for i := range scenario.Requests {
+		slo := scenario.sloFor(&scenario.Requests[i])
+		slos[scenario.Requests[i].Name] = slo
+		slos[scenario.Requests[i].Name+cleanupSuffix] = slo
+	}
+
+	total := &routeSamples{statusCodes: make(map[int]int)}
+	for name, samples := range r.routes {
+		stats := samples.stats(name, elapsed)
+		if slo, ok := slos[name]; ok && slo != (SLO{}) {
+			stats.SLO = &slo
+			stats.SLOViolations = slo.violations(stats)
+		}
+		if len(stats.SLOViolations) > 0 {
+			report.Passed = false
+		}
+		report.Routes = append(report.Routes, stats)
+
+		total.latencies = append(total.latencies, samples.latencies...)
+		total.failures += samples.failures
+		total.transportErrors += samples.transportErrors
+		for status, count := range samples.statusCodes {
+			total.statusCodes[status] += count
+		}
+	}
+	sort.Slice(report.Routes, func(i, j int) bool { return report.Routes[i].Route < report.Routes[j].Route })
+	report.Total = total.stats(totalRoute, elapsed)
+	return report
+}
+
+// stats summarizes the samples of a route.
+func (s *routeSamples) stats(route string, elapsed time.Duration) RouteStats {
+	stats := RouteStats{
+		Route:           route,
+		Category:        s.category,
+		Requests:        len(s.latencies),
+		Failures:        s.failures,
+		StatusCodes:     s.statusCodes,
+		TransportErrors: s.transportErrors,
+	}
+	if stats.Requests == 0 {
+		return stats
+	}
+
+	stats.ErrorRate = float64(s.failures) / float64(stats.Requests)
+	if elapsed > 0 {
+		stats.Throughput = float64(stats.Requests) / elapsed.Seconds()
+	}
+
+	sorted := slices.Clone(s.latencies)
+	slices.Sort(sorted)
+	var sum time.Duration
+	for _, latency := range sorted {
+		sum += latency
+	}
+	stats.Latency = LatencyStats{
+		Min:  Duration(sorted[0]),
+		Mean: Duration(sum / time.Duration(len(sorted))),
+		P50:  Duration(percentile(sorted, 50)),
+		P95:  Duration(percentile(sorted, 95)),
+		P99:  Duration(percentile(sorted, 99)),
+		Max:  Duration(sorted[len(sorted)-1]),
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// violations returns the objectives a route missed.
+func (s SLO) violations(stats RouteStats) []string {
+	var missed []string
+	check := func(metric string, objective, actual Duration) {
+		if objective > 0 && actual > objective {
+			missed = append(missed, fmt.Sprintf("%s %s exceeds %s",
+				metric, time.Duration(actual), time.Duration(objective)))
+		}
+	}
+	check("p50", s.P50, stats.Latency.P50)
+	check("p95", s.P95, stats.Latency.P95)
+	check("p99", s.P99, stats.Latency.P99)
+	if s.MaxErrorRate > 0 && stats.ErrorRate > s.MaxErrorRate {
+		missed = append(missed, fmt.Sprintf("error rate %.2f%% exceeds %.2f%%",
+			stats.ErrorRate*100, s.MaxErrorRate*100))
+	}
+	return missed
+}
+
+// Compare records the p95 and p99 latencies that grew by more than threshold
+// (e.g. 0.2 for 20%) over the same routes of a baseline report. Any regression
+// fails the report.
+func (r *Report) Compare(baseline *Report, threshold float64) {
+	previous := make(map[string]RouteStats, len(baseline.Routes))
+	for _, stats := range baseline.Routes {
+		previous[stats.Route] = stats
+	}
+
+	for _, stats := range r.Routes {
+		before, ok := previous[stats.Route]
+		if !ok {
+			continue
+		}
+		for _, metric := range []struct {
+			name             string
+			baseline, actual Duration
+		}{
+			{"p95", before.Latency.P95, stats.Latency.P95},
+			{"p99", before.Latency.P99, stats.Latency.P99},
+		} {
+			if metric.baseline <= 0 {
+				continue
+			}
+			change := float64(metric.actual-metric.baseline) / float64(metric.baseline)
+			if change > threshold {
+				r.Regressions = append(r.Regressions, Regression{
+					Route:    stats.Route,
+					Metric:   metric.name,
+					Baseline: metric.baseline,
+					Current:  metric.actual,
+					Change:   change,
+				})
+				r.Passed = false
+			}
+		}
+	}
+}
+
+// LoadReport reads a JSON report written by WriteJSON.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// WriteText writes the report as a plain text table.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Load test against %s: %s, %d workers\n\n",
+		r.BaseURL, time.Duration(r.Duration).Round(time.Millisecond), r.Concurrency)
+	_, _ = fmt.Fprintln(tw, "ROUTE\tREQUESTS\tERRORS\tRPS\tP50\tP95\tP99\tMAX\tSLO")
+	for _, stats := range append(slices.Clone(r.Routes), r.Total) {
+		slo := "-"
+		if stats.SLO != nil {
+			slo = "ok"
+			if len(stats.SLOViolations) > 0 {
+				slo = "FAILED"
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%.1f\t%s\t%s\t%s\t%s\t%s\n",
+			stats.Route, stats.Requests, stats.ErrorRate*100, stats.Throughput,
+			formatLatency(stats.Latency.P50), formatLatency(stats.Latency.P95),
+			formatLatency(stats.Latency.P99), formatLatency(stats.Latency.Max), slo)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	for _, stats := range r.Routes {
+		for _, violation := range stats.SLOViolations {
+			_, _ = fmt.Fprintf(w, "SLO violation: %s: %s\n", stats.Route, violation)
+		}
+	}
+	for _, regression := range r.Regressions {
+		_, _ = fmt.Fprintf(w, "Regression: %s %s %s -> %s (+%.0f%%)\n", regression.Route, regression.Metric,
+			formatLatency(regression.Baseline), formatLatency(regression.Current), regression.Change*100)
+	}
+	if r.Passed {
+		_, _ = fmt.Fprintln(w, "\nResult: PASSED")
+	} else {
+		_, _ = fmt.Fprintln(w, "\nResult: FAILED")
+	}
+	return nil
+}
+
+// formatLatency rounds a latency for display.
+func formatLatency(d Duration) string {
+	return time.Duration(d).Round(10 * time.Microsecond).String()
+}
+
+// htmlReport renders a report as a standalone HTML page.
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"latency": formatLatency,
+	"percent": func(rate float64) string { return fmt.Sprintf("%.2f%%", rate*100) },
+	"rps":     func(rps float64) string { return fmt.Sprintf("%.1f", rps) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>netweave load test report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.failed { background: #fdd; }
+.passed { color: #080; }
+.total { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Load test report</h1>
+<p>Target {{.BaseURL}}, started {{.StartedAt.Format "2006-01-02 15:04:05 MST"}},
+ran {{latency .Duration}} with {{.Concurrency}} workers{{if .Rate}} at up to {{.Rate}} req/s{{end}}.</p>
+<p>Result: {{if .Passed}}<span class="passed">PASSED</span>{{else}}<span class="failed">FAILED</span>{{end}}</p>
+<table>
+<tr><th>Route</th><th>Category</th><th>Requests</th><th>Error rate</th><th>Req/s</th>
+<th>p50</th><th>p95</th><th>p99</th><th>Max</th><th>SLO</th></tr>
+{{range .Routes}}<tr{{if .SLOViolations}} class="failed"{{end}}>
+<td>{{.Route}}</td><td>{{.Category}}</td><td>{{.Requests}}</td><td>{{percent .ErrorRate}}</td>
+<td>{{rps .Throughput}}</td><td>{{latency .Latency.P50}}</td><td>{{latency .Latency.P95}}</td>
+<td>{{latency .Latency.P99}}</td><td>{{latency .Latency.Max}}</td>
+<td>{{if .SLOViolations}}{{range .SLOViolations}}{{.}}<br>{{end}}{{else if .SLO}}ok{{else}}-{{end}}</td></tr>
+{{end}}{{with .Total}}<tr class="total">
+<td>{{.Route}}</td><td></td><td>{{.Requests}}</td><td>{{percent .ErrorRate}}</td>
+<td>{{rps .Throughput}}</td><td>{{latency .Latency.P50}}</td><td>{{latency .Latency.P95}}</td>
+<td>{{latency .Latency.P99}}</td><td>{{latency .Latency.Max}}</td><td></td></tr>{{end}}
+</table>
+{{if .Regressions}}<h2>Regressions</h2>
+<table>
+<tr><th>Route</th><th>Metric</th><th>Baseline</th><th>Current</th><th>Change</th></tr>
+{{range .Regressions}}<tr class="failed"><td>{{.Route}}</td><td>{{.Metric}}</td>
+<td>{{latency .Baseline}}</td><td>{{latency .Current}}</td><td>+{{percent .Change}}</td></tr>
+{{end}}</table>{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a standalone HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	if err := htmlReport.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
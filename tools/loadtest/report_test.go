@@ -0,0 +1,117 @@
+package loadtest
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordLatencies records one successful request per latency in milliseconds.
+func recordLatencies(rec *recorder, route string, millis ...int) {
+	for _, ms := range millis {
+		rec.record(route, CategoryRead, result{status: 200, latency: time.Duration(ms) * time.Millisecond}, true)
+	}
+}
+
+func testScenario(slo SLO) *Scenario {
+	return &Scenario{
+		BaseURL:     "http://gateway",
+		Concurrency: 2,
+		SLO:         slo,
+		Requests: []RequestSpec{
+			{Name: "fast", Category: CategoryRead},
+			{Name: "slow", Category: CategoryRead, SLO: &SLO{P99: Duration(time.Second)}},
+		},
+	}
+}
+
+func TestRecorder_Report(t *testing.T) {
+	rec := newRecorder()
+	millis := make([]int, 0, 100)
+	for i := 1; i <= 100; i++ {
+		millis = append(millis, i)
+	}
+	recordLatencies(rec, "fast", millis...)
+	recordLatencies(rec, "slow", 900, 950)
+	rec.record("slow", CategoryRead, result{err: errors.New("connection refused"), latency: time.Millisecond}, false)
+
+	report := rec.report(testScenario(SLO{P95: Duration(90 * time.Millisecond), MaxErrorRate: 0.1}),
+		time.Now(), 10*time.Second)
+
+	require.Len(t, report.Routes, 2)
+	fast, slow := report.Routes[0], report.Routes[1]
+	assert.Equal(t, "fast", fast.Route)
+	assert.Equal(t, 100, fast.Requests)
+	assert.Equal(t, 10.0, fast.Throughput)
+	assert.Equal(t, Duration(1*time.Millisecond), fast.Latency.Min)
+	assert.Equal(t, Duration(50*time.Millisecond), fast.Latency.P50)
+	assert.Equal(t, Duration(95*time.Millisecond), fast.Latency.P95)
+	assert.Equal(t, Duration(99*time.Millisecond), fast.Latency.P99)
+	assert.Equal(t, Duration(100*time.Millisecond), fast.Latency.Max)
+	assert.Equal(t, []string{"p95 95ms exceeds 90ms"}, fast.SLOViolations)
+
+	assert.Equal(t, 3, slow.Requests)
+	assert.Equal(t, 1, slow.Failures)
+	assert.Equal(t, 1, slow.TransportErrors)
+	assert.Empty(t, slow.SLOViolations, "the route SLO replaces the default")
+
+	assert.Equal(t, 103, report.Total.Requests)
+	assert.Equal(t, 102, report.Total.StatusCodes[200])
+	assert.False(t, report.Passed)
+}
+
+func TestReport_Compare(t *testing.T) {
+	baselineRec := newRecorder()
+	recordLatencies(baselineRec, "fast", 10, 10, 10)
+	recordLatencies(baselineRec, "slow", 100)
+	baseline := baselineRec.report(testScenario(SLO{}), time.Now(), time.Second)
+
+	currentRec := newRecorder()
+	recordLatencies(currentRec, "fast", 11, 11, 11)
+	recordLatencies(currentRec, "slow", 200)
+	recordLatencies(currentRec, "new", 500)
+	current := currentRec.report(testScenario(SLO{}), time.Now(), time.Second)
+	require.True(t, current.Passed)
+
+	current.Compare(baseline, 0.2)
+	require.Len(t, current.Regressions, 2, "p95 and p99 of the slow route regressed")
+	for _, regression := range current.Regressions {
+		assert.Equal(t, "slow", regression.Route)
+		assert.InDelta(t, 1.0, regression.Change, 0.001)
+	}
+	assert.False(t, current.Passed)
+}
+
+func TestReport_Writers(t *testing.T) {
+	rec := newRecorder()
+	recordLatencies(rec, "fast", 10, 20)
+	recordLatencies(rec, "slow", 2000)
+	report := rec.report(testScenario(SLO{}), time.Now(), time.Second)
+	report.Compare(report, 0.2)
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	assert.Contains(t, text.String(), "fast")
+	assert.Contains(t, text.String(), "SLO violation: slow: p99 2s exceeds 1s")
+	assert.Contains(t, text.String(), "Result: FAILED")
+
+	var html bytes.Buffer
+	require.NoError(t, report.WriteHTML(&html))
+	assert.Contains(t, html.String(), "<td>slow</td>")
+	assert.Contains(t, html.String(), `class="failed"`)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	var data bytes.Buffer
+	require.NoError(t, report.WriteJSON(&data))
+	require.NoError(t, os.WriteFile(path, data.Bytes(), 0o600))
+	loaded, err := LoadReport(path)
+	require.NoError(t, err)
+	assert.Equal(t, report.Routes, loaded.Routes)
+	assert.Equal(t, report.Total.Latency, loaded.Total.Latency)
+}
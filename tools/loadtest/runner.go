@@ -0,0 +1,245 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// cleanupSuffix is appended to the route name of cleanup requests.
+const cleanupSuffix = "/cleanup"
+
+// maxResponseBytes caps the response body read for each request.
+const maxResponseBytes = 10 << 20
+
+// Runner drives the request mix of a scenario against a gateway.
+type Runner struct {
+	scenario *Scenario
+	client   *http.Client
+	logger   *zap.Logger
+
+	// cumulative holds the running sum of the request weights, for picking
+	// requests in proportion to their weight.
+	cumulative []int
+}
+
+// NewRunner creates a runner for a validated scenario.
+func NewRunner(scenario *Scenario, logger *zap.Logger) (*Runner, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+
+	cumulative := make([]int, len(scenario.Requests))
+	total := 0
+	for i, req := range scenario.Requests {
+		total += req.Weight
+		cumulative[i] = total
+	}
+
+	return &Runner{
+		scenario: scenario,
+		client: &http.Client{
+			Timeout: time.Duration(scenario.Timeout),
+			Transport: &http.Transport{
+				MaxIdleConns:        scenario.Concurrency,
+				MaxIdleConnsPerHost: scenario.Concurrency,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+		logger:     logger,
+		cumulative: cumulative,
+	}, nil
+}
+
+// Run sends requests for the duration of the scenario and returns the report.
+// Requests in flight when the run ends are completed, so that the objects
+// they create are cleaned up.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	runCtx, cancel := context.WithTimeout(ctx, time.Duration(r.scenario.Duration))
+	defer cancel()
+
+	var tokens <-chan time.Time
+	if r.scenario.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / r.scenario.Rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	r.logger.Info("starting load test",
+		zap.String("url", r.scenario.BaseURL),
+		zap.Duration("duration", time.Duration(r.scenario.Duration)),
+		zap.Int("concurrency", r.scenario.Concurrency),
+		zap.Float64("rate", r.scenario.Rate))
+
+	rec := newRecorder()
+	startedAt := time.Now()
+	var wg sync.WaitGroup
+	for range r.scenario.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(runCtx, tokens, rec)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("load test interrupted: %w", err)
+	}
+	return rec.report(r.scenario, startedAt, time.Since(startedAt)), nil
+}
+
+// work sends requests until the run ends, waiting for a token before each
+// request when the rate is capped.
+func (r *Runner) work(ctx context.Context, tokens <-chan time.Time, rec *recorder) {
+	for ctx.Err() == nil {
+		if tokens != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tokens:
+			}
+		}
+		r.execute(ctx, r.pick(), rec)
+	}
+}
+
+// pick returns a request of the mix in proportion to its weight.
+func (r *Runner) pick() *RequestSpec {
+	n := rand.IntN(r.cumulative[len(r.cumulative)-1])
+	for i, limit := range r.cumulative {
+		if n < limit {
+			return &r.scenario.Requests[i]
+		}
+	}
+	return &r.scenario.Requests[len(r.scenario.Requests)-1]
+}
+
+// execute sends one request of the mix and its cleanup requests. Both outlive
+// the run and are bounded by the request timeout only.
+func (r *Runner) execute(ctx context.Context, spec *RequestSpec, rec *recorder) {
+	ctx = context.WithoutCancel(ctx)
+	res := r.send(ctx, spec.Method, expandUUIDs(spec.Path), expandUUIDs(string(spec.Body)))
+	rec.record(spec.Name, spec.Category, res, spec.succeeded(res))
+
+	if spec.Cleanup == nil || res.err != nil || res.status >= http.StatusMultipleChoices {
+		return
+	}
+
+	ids, err := extractIDs(res.body, spec.Cleanup.IDField)
+	if err != nil {
+		r.logger.Warn("failed to extract created IDs", zap.String("route", spec.Name), zap.Error(err))
+		return
+	}
+	for _, id := range ids {
+		path := strings.ReplaceAll(spec.Cleanup.Path, idPlaceholder, id)
+		cleanup := r.send(ctx, spec.Cleanup.Method, path, "")
+		rec.record(spec.Name+cleanupSuffix, spec.Category, cleanup,
+			cleanup.err == nil && cleanup.status < http.StatusBadRequest)
+	}
+}
+
+// result is the outcome of one request.
+type result struct {
+	status  int
+	body    []byte
+	latency time.Duration
+	err     error
+}
+
+// send sends a request and reads the whole response.
+func (r *Runner) send(ctx context.Context, method, path, body string) result {
+	var reader io.Reader
+	if body != "" {
+		reader = bytes.NewReader([]byte(body))
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(r.scenario.BaseURL, "/")+path, reader)
+	if err != nil {
+		return result{err: err}
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range r.scenario.Headers {
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return result{latency: time.Since(start), err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	return result{status: resp.StatusCode, body: data, latency: time.Since(start), err: err}
+}
+
+// succeeded reports whether a request counts as successful.
+func (r *RequestSpec) succeeded(res result) bool {
+	if res.err != nil {
+		return false
+	}
+	if len(r.ExpectStatus) == 0 {
+		return res.status < http.StatusBadRequest
+	}
+	for _, status := range r.ExpectStatus {
+		if res.status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// expandUUIDs replaces every {{uuid}} with a fresh UUID.
+func expandUUIDs(s string) string {
+	for strings.Contains(s, uuidPlaceholder) {
+		s = strings.Replace(s, uuidPlaceholder, uuid.New().String(), 1)
+	}
+	return s
+}
+
+// extractIDs returns the string values at a dotted path of a JSON document.
+// A "*" segment iterates the elements of an array.
+func extractIDs(body []byte, field string) ([]string, error) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	values := []interface{}{doc}
+	for _, segment := range strings.Split(field, ".") {
+		var next []interface{}
+		for _, value := range values {
+			switch v := value.(type) {
+			case []interface{}:
+				if segment == "*" {
+					next = append(next, v...)
+				}
+			case map[string]interface{}:
+				if child, ok := v[segment]; ok {
+					next = append(next, child)
+				}
+			}
+		}
+		values = next
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
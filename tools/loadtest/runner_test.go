@@ -0,0 +1,154 @@
+package loadtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/piwi3910/netweave/tools/loadtest"
+)
+
+// fakeGateway serves reads, creates with and without batching, deletes and a
+// failing route, and tracks the objects that were created but not deleted.
+type fakeGateway struct {
+	mu      sync.Mutex
+	live    map[string]bool
+	nextID  int
+	headers []string
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.headers = append(g.headers, r.Header.Get("Authorization"))
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/items":
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/items":
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":%q}`, g.create())
+	case r.Method == http.MethodPost && r.URL.Path == "/batch/items":
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"results":[{"data":{"id":%q}},{"data":{"id":%q}}]}`, g.create(), g.create())
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/items/"):
+		delete(g.live, strings.TrimPrefix(r.URL.Path, "/items/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (g *fakeGateway) create() string {
+	g.nextID++
+	id := fmt.Sprintf("item-%d", g.nextID)
+	g.live[id] = true
+	return id
+}
+
+func TestRunner_Run(t *testing.T) {
+	gateway := &fakeGateway{live: map[string]bool{}}
+	srv := httptest.NewServer(gateway)
+	defer srv.Close()
+
+	cleanup := &loadtest.Cleanup{Method: http.MethodDelete, Path: "/items/{id}", IDField: "id"}
+	scenario := &loadtest.Scenario{
+		BaseURL:     srv.URL,
+		Duration:    loadtest.Duration(300 * time.Millisecond),
+		Concurrency: 4,
+		Timeout:     loadtest.Duration(time.Second),
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		SLO:         loadtest.SLO{MaxErrorRate: 0.5},
+		Requests: []loadtest.RequestSpec{
+			{Name: "list", Category: loadtest.CategoryRead, Method: http.MethodGet, Path: "/items", Weight: 4},
+			{
+				Name: "create", Category: loadtest.CategoryWrite, Method: http.MethodPost, Path: "/items",
+				Body: json.RawMessage(`{"name":"{{uuid}}"}`), Weight: 2, Cleanup: cleanup,
+			},
+			{
+				Name: "batch", Category: loadtest.CategoryBatch, Method: http.MethodPost, Path: "/batch/items",
+				Weight: 1, Cleanup: &loadtest.Cleanup{Method: http.MethodDelete, Path: "/items/{id}",
+					IDField: "results.*.data.id"},
+			},
+			{Name: "broken", Category: loadtest.CategoryRead, Method: http.MethodGet, Path: "/broken", Weight: 1},
+		},
+	}
+
+	runner, err := loadtest.NewRunner(scenario, zap.NewNop())
+	require.NoError(t, err)
+	report, err := runner.Run(context.Background())
+	require.NoError(t, err)
+
+	routes := map[string]loadtest.RouteStats{}
+	for _, stats := range report.Routes {
+		routes[stats.Route] = stats
+	}
+	for _, name := range []string{"list", "create", "create/cleanup", "batch", "batch/cleanup", "broken"} {
+		require.Contains(t, routes, name)
+		assert.Positive(t, routes[name].Requests, name)
+	}
+
+	assert.Zero(t, routes["list"].Failures)
+	assert.Equal(t, 2*routes["batch"].Requests, routes["batch/cleanup"].Requests)
+	assert.Equal(t, routes["broken"].Requests, routes["broken"].Failures)
+	assert.Equal(t, 1.0, routes["broken"].ErrorRate)
+	assert.Equal(t, routes["broken"].Requests, routes["broken"].StatusCodes[http.StatusInternalServerError])
+	assert.NotEmpty(t, routes["broken"].SLOViolations)
+	assert.Empty(t, routes["list"].SLOViolations)
+	assert.False(t, report.Passed)
+
+	assert.Positive(t, routes["list"].Latency.P50)
+	assert.LessOrEqual(t, routes["list"].Latency.P50, routes["list"].Latency.P99)
+	assert.Positive(t, report.Total.Throughput)
+
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	assert.Empty(t, gateway.live, "created objects are cleaned up")
+	for _, header := range gateway.headers {
+		assert.Equal(t, "Bearer token", header)
+	}
+}
+
+func TestRunner_Rate(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	runner, err := loadtest.NewRunner(&loadtest.Scenario{
+		BaseURL:     srv.URL,
+		Duration:    loadtest.Duration(500 * time.Millisecond),
+		Concurrency: 4,
+		Rate:        20,
+		Timeout:     loadtest.Duration(time.Second),
+		Requests: []loadtest.RequestSpec{
+			{Name: "get", Category: loadtest.CategoryRead, Method: http.MethodGet, Path: "/", Weight: 1},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	report, err := runner.Run(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Passed)
+	assert.LessOrEqual(t, report.Total.Requests, 12, "requests are capped at the rate")
+	assert.Positive(t, report.Total.Requests)
+}
+
+func TestNewRunner_InvalidScenario(t *testing.T) {
+	_, err := loadtest.NewRunner(&loadtest.Scenario{}, zap.NewNop())
+	assert.Error(t, err)
+}
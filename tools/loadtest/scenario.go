@@ -0,0 +1,286 @@
+// Package loadtest drives synthetic request mixes against a netweave gateway
+// and reports per-route latency percentiles, error rates and SLO results.
+//
+// A scenario describes the requests to send and their relative weights, how
+// long and how hard to drive them, and the latency and error rate objectives
+// each route must meet. Reports can be compared against the report of an
+// earlier run to track performance regressions between releases.
+package loadtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Request categories of a scenario mix.
+const (
+	// CategoryRead marks requests that only read inventory.
+	CategoryRead = "read"
+	// CategoryWrite marks requests that create, update or delete objects.
+	CategoryWrite = "write"
+	// CategoryBatch marks batch operation requests.
+	CategoryBatch = "batch"
+)
+
+// idPlaceholder is replaced by each extracted ID in a cleanup path.
+const idPlaceholder = "{id}"
+
+// uuidPlaceholder is replaced by a fresh UUID wherever it occurs in a request
+// path or body, so creates do not collide.
+const uuidPlaceholder = "{{uuid}}"
+
+// Duration is a time.Duration that reads and writes JSON strings such as "30s".
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// SLO is a service level objective for the requests of a route. Zero fields
+// are not checked.
+type SLO struct {
+	P50 Duration `json:"p50,omitempty"`
+	P95 Duration `json:"p95,omitempty"`
+	P99 Duration `json:"p99,omitempty"`
+
+	// MaxErrorRate is the highest tolerated share of failed requests (0-1).
+	MaxErrorRate float64 `json:"maxErrorRate,omitempty"`
+}
+
+// Cleanup deletes the objects created by a request, so write mixes can run
+// for long periods without growing the inventory. Cleanup requests are
+// reported as a route of their own.
+type Cleanup struct {
+	// Method is the HTTP method of the cleanup request, DELETE by default.
+	Method string `json:"method,omitempty"`
+
+	// Path is the path of the cleanup request; "{id}" is replaced by each ID.
+	Path string `json:"path"`
+
+	// IDField is the dotted path of the created IDs in the response body.
+	// A "*" segment iterates an array, e.g. "results.*.data.subscriptionId".
+	IDField string `json:"idField"`
+}
+
+// RequestSpec is one kind of request of a scenario mix.
+type RequestSpec struct {
+	// Name identifies the route in the report.
+	Name string `json:"name"`
+
+	// Category is read, write or batch.
+	Category string `json:"category"`
+
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+
+	// Weight is the relative frequency of the request in the mix.
+	Weight int `json:"weight"`
+
+	// ExpectStatus lists the status codes counted as success. By default,
+	// every status below 400 is.
+	ExpectStatus []int `json:"expectStatus,omitempty"`
+
+	// Cleanup optionally deletes what the request created.
+	Cleanup *Cleanup `json:"cleanup,omitempty"`
+
+	// SLO overrides the default SLO of the scenario for this route.
+	SLO *SLO `json:"slo,omitempty"`
+}
+
+// Scenario describes a load test run.
+type Scenario struct {
+	// BaseURL is the gateway URL, e.g. http://localhost:8080.
+	BaseURL string `json:"baseUrl"`
+
+	// Duration is how long requests are sent.
+	Duration Duration `json:"duration"`
+
+	// Concurrency is the number of parallel workers.
+	Concurrency int `json:"concurrency"`
+
+	// Rate caps the total requests per second; 0 sends as fast as the
+	// workers can.
+	Rate float64 `json:"rate,omitempty"`
+
+	// Timeout bounds each request.
+	Timeout Duration `json:"timeout,omitempty"`
+
+	// Headers are added to every request, e.g. an Authorization header.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// SLO is the default objective of every route.
+	SLO SLO `json:"slo"`
+
+	// Requests is the request mix.
+	Requests []RequestSpec `json:"requests"`
+}
+
+// Scenario defaults.
+const (
+	DefaultDuration    = 30 * time.Second
+	DefaultConcurrency = 10
+	DefaultTimeout     = 10 * time.Second
+)
+
+// DefaultScenario returns a mix of O2-IMS reads, subscription writes and
+// batch subscription creates, each cleaned up after itself.
+func DefaultScenario(baseURL string) *Scenario {
+	const base = "/o2ims-infrastructureInventory/v1"
+	subscription := `{"callback":"https://smo.example.com/loadtest/{{uuid}}","consumerSubscriptionId":"loadtest"}`
+	return &Scenario{
+		BaseURL:     baseURL,
+		Duration:    Duration(DefaultDuration),
+		Concurrency: DefaultConcurrency,
+		Timeout:     Duration(DefaultTimeout),
+		SLO: SLO{
+			P95:          Duration(100 * time.Millisecond),
+			P99:          Duration(500 * time.Millisecond),
+			MaxErrorRate: 0.01,
+		},
+		Requests: []RequestSpec{
+			{Name: "list-resource-pools", Category: CategoryRead, Method: http.MethodGet,
+				Path: base + "/resourcePools", Weight: 30},
+			{Name: "list-resources", Category: CategoryRead, Method: http.MethodGet,
+				Path: base + "/resources", Weight: 30},
+			{Name: "list-resource-types", Category: CategoryRead, Method: http.MethodGet,
+				Path: base + "/resourceTypes", Weight: 15},
+			{Name: "list-subscriptions", Category: CategoryRead, Method: http.MethodGet,
+				Path: base + "/subscriptions", Weight: 15},
+			{
+				Name: "create-subscription", Category: CategoryWrite, Method: http.MethodPost,
+				Path: base + "/subscriptions", Body: json.RawMessage(subscription), Weight: 7,
+				Cleanup: &Cleanup{Path: base + "/subscriptions/{id}", IDField: "subscriptionId"},
+			},
+			{
+				Name: "batch-create-subscriptions", Category: CategoryBatch, Method: http.MethodPost,
+				Path:   base + "/batch/subscriptions",
+				Body:   json.RawMessage(`{"subscriptions":[` + subscription + `,` + subscription + `]}`),
+				Weight: 3,
+				Cleanup: &Cleanup{
+					Path:    base + "/subscriptions/{id}",
+					IDField: "results.*.data.subscriptionId",
+				},
+			},
+		},
+	}
+}
+
+// LoadScenario reads a scenario from a JSON file. Unset settings take their
+// defaults.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var scenario Scenario
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+	scenario.applyDefaults()
+	return &scenario, nil
+}
+
+// applyDefaults fills in unset settings.
+func (s *Scenario) applyDefaults() {
+	if s.Duration == 0 {
+		s.Duration = Duration(DefaultDuration)
+	}
+	if s.Concurrency == 0 {
+		s.Concurrency = DefaultConcurrency
+	}
+	if s.Timeout == 0 {
+		s.Timeout = Duration(DefaultTimeout)
+	}
+	for i := range s.Requests {
+		if s.Requests[i].Cleanup != nil && s.Requests[i].Cleanup.Method == "" {
+			s.Requests[i].Cleanup.Method = http.MethodDelete
+		}
+	}
+}
+
+// Validate checks that the scenario can be run.
+func (s *Scenario) Validate() error {
+	if s.BaseURL == "" {
+		return errors.New("baseUrl is required")
+	}
+	if s.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if s.Concurrency <= 0 {
+		return errors.New("concurrency must be positive")
+	}
+	if s.Rate < 0 {
+		return errors.New("rate must not be negative")
+	}
+	if len(s.Requests) == 0 {
+		return errors.New("at least one request is required")
+	}
+
+	names := make(map[string]bool, len(s.Requests))
+	for _, req := range s.Requests {
+		if err := req.validate(); err != nil {
+			return err
+		}
+		if names[req.Name] {
+			return fmt.Errorf("duplicate request name %q", req.Name)
+		}
+		names[req.Name] = true
+	}
+	return nil
+}
+
+// validate checks a request of the mix.
+func (r *RequestSpec) validate() error {
+	if r.Name == "" {
+		return errors.New("request name is required")
+	}
+	switch r.Category {
+	case CategoryRead, CategoryWrite, CategoryBatch:
+	default:
+		return fmt.Errorf("request %q: category must be %s, %s or %s",
+			r.Name, CategoryRead, CategoryWrite, CategoryBatch)
+	}
+	if r.Method == "" || !strings.HasPrefix(r.Path, "/") {
+		return fmt.Errorf("request %q: method and a path starting with / are required", r.Name)
+	}
+	if r.Weight <= 0 {
+		return fmt.Errorf("request %q: weight must be positive", r.Name)
+	}
+	if r.Cleanup != nil && (r.Cleanup.IDField == "" || !strings.Contains(r.Cleanup.Path, idPlaceholder)) {
+		return fmt.Errorf("request %q: cleanup needs an idField and a path containing %s", r.Name, idPlaceholder)
+	}
+	return nil
+}
+
+// sloFor returns the objective of a request.
+func (s *Scenario) sloFor(req *RequestSpec) SLO {
+	if req.SLO != nil {
+		return *req.SLO
+	}
+	return s.SLO
+}
@@ -0,0 +1,87 @@
+package loadtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piwi3910/netweave/tools/loadtest"
+)
+
+func TestDefaultScenario_IsValid(t *testing.T) {
+	scenario := loadtest.DefaultScenario("http://localhost:8080")
+	require.NoError(t, scenario.Validate())
+
+	categories := map[string]bool{}
+	for _, req := range scenario.Requests {
+		categories[req.Category] = true
+	}
+	assert.True(t, categories[loadtest.CategoryRead])
+	assert.True(t, categories[loadtest.CategoryWrite])
+	assert.True(t, categories[loadtest.CategoryBatch])
+}
+
+func TestLoadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"baseUrl": "http://gateway:8080",
+		"duration": "2m",
+		"slo": {"p95": "50ms", "maxErrorRate": 0.01},
+		"requests": [
+			{"name": "pools", "category": "read", "method": "GET", "path": "/pools", "weight": 1},
+			{"name": "create", "category": "write", "method": "POST", "path": "/subs", "weight": 1,
+			 "body": {"callback": "https://smo.example.com/{{uuid}}"},
+			 "cleanup": {"path": "/subs/{id}", "idField": "subscriptionId"}}
+		]
+	}`), 0o600))
+
+	scenario, err := loadtest.LoadScenario(path)
+	require.NoError(t, err)
+	require.NoError(t, scenario.Validate())
+	assert.Equal(t, loadtest.Duration(2*time.Minute), scenario.Duration)
+	assert.Equal(t, loadtest.DefaultConcurrency, scenario.Concurrency, "unset settings take defaults")
+	assert.Equal(t, loadtest.Duration(50*time.Millisecond), scenario.SLO.P95)
+	assert.Equal(t, "DELETE", scenario.Requests[1].Cleanup.Method)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"requests": [], "unknown": true}`), 0o600))
+	_, err = loadtest.LoadScenario(path)
+	assert.Error(t, err, "unknown fields are rejected")
+}
+
+func TestScenario_Validate(t *testing.T) {
+	valid := func() *loadtest.Scenario {
+		return &loadtest.Scenario{
+			BaseURL:     "http://localhost:8080",
+			Duration:    loadtest.Duration(time.Second),
+			Concurrency: 1,
+			Requests: []loadtest.RequestSpec{
+				{Name: "a", Category: loadtest.CategoryRead, Method: "GET", Path: "/a", Weight: 1},
+			},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	for name, mutate := range map[string]func(*loadtest.Scenario){
+		"no base URL":      func(s *loadtest.Scenario) { s.BaseURL = "" },
+		"no requests":      func(s *loadtest.Scenario) { s.Requests = nil },
+		"zero concurrency": func(s *loadtest.Scenario) { s.Concurrency = 0 },
+		"negative rate":    func(s *loadtest.Scenario) { s.Rate = -1 },
+		"zero weight":      func(s *loadtest.Scenario) { s.Requests[0].Weight = 0 },
+		"bad category":     func(s *loadtest.Scenario) { s.Requests[0].Category = "other" },
+		"relative path":    func(s *loadtest.Scenario) { s.Requests[0].Path = "a" },
+		"duplicate name":   func(s *loadtest.Scenario) { s.Requests = append(s.Requests, s.Requests[0]) },
+		"cleanup without id placeholder": func(s *loadtest.Scenario) {
+			s.Requests[0].Cleanup = &loadtest.Cleanup{Path: "/a", IDField: "id"}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scenario := valid()
+			mutate(scenario)
+			assert.Error(t, scenario.Validate())
+		})
+	}
+}